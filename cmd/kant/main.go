@@ -8,7 +8,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/msto63/mDW/internal/kant/audit"
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/probe"
+	"github.com/msto63/mDW/internal/kant/proxy"
 	"github.com/msto63/mDW/internal/kant/server"
+	"github.com/msto63/mDW/internal/kant/tenant"
 	"github.com/msto63/mDW/pkg/core/config"
 	"github.com/msto63/mDW/pkg/core/logging"
 )
@@ -82,6 +87,39 @@ func loadConfig() (server.Config, error) {
 		if appCfg.Kant.WriteTimeout.Duration > 0 {
 			cfg.WriteTimeout = appCfg.Kant.WriteTimeout.Duration
 		}
+
+		// Authentication
+		authCfg := appCfg.Kant.Auth
+		cfg.Auth = auth.Config{
+			Enabled:     authCfg.Enabled,
+			PublicPaths: authCfg.PublicPaths,
+			AdminKey:    authCfg.AdminKey,
+			KeyFile:     authCfg.KeyFile,
+			JWKSURL:     authCfg.JWKSURL,
+			OIDCIssuer:  authCfg.OIDCIssuer,
+			Issuer:      authCfg.Issuer,
+			Audience:    authCfg.Audience,
+			JWKSRefresh: authCfg.JWKSRefresh.Duration,
+		}
+
+		// Backend call policies
+		cfg.Proxy = proxyConfig(appCfg.Kant.Proxy)
+
+		// Tenant isolation, with the public paths of authentication
+		cfg.Tenancy = tenancyConfig(appCfg.Kant.Tenancy)
+		cfg.Tenancy.PublicPaths = authCfg.PublicPaths
+
+		// Audit trail
+		cfg.Audit = auditConfig(appCfg.Kant.Audit)
+
+		// Native gRPC listener
+		cfg.GRPCEnabled = appCfg.Kant.GRPC.Enabled
+		if appCfg.Kant.GRPC.Port > 0 {
+			cfg.GRPCPort = appCfg.Kant.GRPC.Port
+		}
+
+		// Dependencies of the readiness probe
+		cfg.Health = healthConfig(appCfg.Kant.Health)
 	}
 
 	// Override from environment
@@ -91,6 +129,98 @@ func loadConfig() (server.Config, error) {
 	if port := os.Getenv("KANT_PORT"); port != "" {
 		fmt.Sscanf(port, "%d", &cfg.HTTPPort)
 	}
+	if port := os.Getenv("KANT_GRPC_PORT"); port != "" {
+		fmt.Sscanf(port, "%d", &cfg.GRPCPort)
+		cfg.GRPCEnabled = true
+	}
+	if adminKey := os.Getenv("KANT_ADMIN_KEY"); adminKey != "" {
+		cfg.Auth.AdminKey = adminKey
+	}
+	if os.Getenv("KANT_AUTH_ENABLED") == "true" {
+		cfg.Auth.Enabled = true
+	}
 
 	return cfg, nil
 }
+
+// proxyConfig applies the configured backend call policies to the defaults
+func proxyConfig(c config.KantProxyConfig) proxy.Config {
+	cfg := proxy.DefaultConfig()
+	if c.RetryRatio > 0 {
+		cfg.RetryRatio = c.RetryRatio
+	}
+	if c.MinRetriesPerSecond > 0 {
+		cfg.MinRetriesPerSecond = c.MinRetriesPerSecond
+	}
+
+	d := c.Default
+	if d.Timeout.Duration > 0 {
+		cfg.Default.Timeout = d.Timeout.Duration
+	}
+	if d.MaxAttempts > 0 {
+		cfg.Default.MaxAttempts = d.MaxAttempts
+	}
+	if d.Backoff.Duration > 0 {
+		cfg.Default.Backoff = d.Backoff.Duration
+	}
+	for route, p := range c.Routes {
+		cfg.Override(route, proxy.Override{
+			Timeout:     p.Timeout.Duration,
+			Idempotent:  p.Idempotent,
+			MaxAttempts: p.MaxAttempts,
+			Backoff:     p.Backoff.Duration,
+			HedgeDelay:  p.HedgeDelay.Duration,
+		})
+	}
+	return cfg
+}
+
+// tenancyConfig maps the configured tenants and their quotas
+func tenancyConfig(c config.KantTenancyConfig) tenant.Config {
+	cfg := tenant.Config{
+		Enabled:       c.Enabled,
+		RequireTenant: c.RequireTenant,
+		BaseDomain:    c.BaseDomain,
+		DefaultQuota: tenant.Quota{
+			RequestsPerMinute: c.RequestsPerMinute,
+			RequestsPerDay:    c.RequestsPerDay,
+		},
+		Tenants: make(map[string]tenant.Quota, len(c.Tenants)),
+	}
+	for id, q := range c.Tenants {
+		cfg.Tenants[id] = tenant.Quota{
+			RequestsPerMinute: q.RequestsPerMinute,
+			RequestsPerDay:    q.RequestsPerDay,
+		}
+	}
+	return cfg
+}
+
+// auditConfig maps the audit trail settings and redaction rules
+func auditConfig(c config.KantAuditConfig) audit.Config {
+	return audit.Config{
+		Enabled:             c.Enabled,
+		CaptureRequestBody:  c.CaptureRequestBody,
+		CaptureResponseBody: c.CaptureResponseBody,
+		MaxBodyBytes:        c.MaxBodyBytes,
+		Redaction: audit.RedactionConfig{
+			Patterns: c.Redaction.Patterns,
+			Custom:   c.Redaction.Custom,
+			Fields:   c.Redaction.Fields,
+		},
+		SkipPaths:     c.SkipPaths,
+		BufferSize:    c.BufferSize,
+		BatchSize:     c.BatchSize,
+		FlushInterval: c.FlushInterval.Duration,
+	}
+}
+
+// healthConfig maps the dependencies of the readiness probe
+func healthConfig(c config.KantHealthConfig) probe.Config {
+	return probe.Config{
+		Critical: c.Critical,
+		Optional: c.Optional,
+		Timeout:  c.Timeout.Duration,
+		CacheTTL: c.CacheTTL.Duration,
+	}
+}
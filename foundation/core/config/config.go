@@ -4,16 +4,26 @@
 //              loading, parsing, and accessing configuration data from TOML
 //              and YAML files with environment variable support.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.8
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
+// - 2026-10-16 v0.1.1: Added secret reference resolution and redaction
+// - 2026-10-16 v0.1.2: Added JSON format and provider watch state
+// - 2026-10-16 v0.1.3: Tracked keys changed with Set for Save
+// - 2026-10-16 v0.1.4: Applied profile overlays selected via MDW_ENV
+// - 2026-10-16 v0.1.5: Validated configurations against schemas at load time
+// - 2026-10-16 v0.1.6: Added include directive and ${key} interpolation
+// - 2026-10-16 v0.1.7: Added key change subscriptions
+// - 2026-10-16 v0.1.8: Applied bound command-line flags in typed getters
 
 package config
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -41,6 +51,9 @@ const (
 	
 	// FormatAuto auto-detects format from file extension
 	FormatAuto
+	
+	// FormatJSON represents JSON format
+	FormatJSON
 )
 
 // String returns the string representation of the format
@@ -52,6 +65,8 @@ func (f Format) String() string {
 		return "yaml"
 	case FormatAuto:
 		return "auto"
+	case FormatJSON:
+		return "json"
 	default:
 		return "unknown"
 	}
@@ -65,8 +80,13 @@ type Config struct {
 	format       Format
 	envPrefix    string
 	watchers     []ChangeHandler
+	keyWatchers  []*keySubscription
+	flags        map[string]boundFlag // Command-line flags by bound key
 	watching     bool
 	lastModified time.Time
+	profile      string // Active profile overlay
+	schema       *ConfigSchema // Schema validated at load and reload
+	includes     []string      // Files merged via include directives
 	
 	// Context information for better error reporting and tracing
 	requestID    string
@@ -80,6 +100,17 @@ type Config struct {
 	cacheTimeout time.Duration    // Cache timeout duration (default 5 minutes)
 	pathCache    map[string][]string // Cache for dot notation paths
 	pathCacheMu  sync.RWMutex        // Separate mutex for path cache
+	
+	// Secret handling
+	secretProviders map[string]SecretProvider // Providers by reference scheme
+	secretKeys      map[string]bool           // Keys whose values were resolved from secrets
+	
+	// Provider-based loading
+	provider  Provider           // Source of the configuration data (nil for files)
+	stopWatch context.CancelFunc // Stops the provider watch
+	
+	// Write-back
+	dirty map[string]bool // Keys changed with Set since the last Save
 }
 
 // ChangeHandler is called when configuration changes are detected
@@ -91,6 +122,9 @@ type LoadOptions struct {
 	EnvPrefix string            // Environment variable prefix (default: none)
 	Defaults  map[string]interface{} // Default values
 	Watch     bool              // Enable file watching (default: false)
+	SecretProviders map[string]SecretProvider // Secret providers by scheme (env and file are built in)
+	Profile   string            // Profile overlay to apply (default: $MDW_ENV)
+	Schema    *ConfigSchema     // Schema validated at load and reload (default: none)
 }
 
 // ValidationRule defines validation criteria for configuration values
@@ -174,18 +208,65 @@ func LoadWithOptions(filePath string, options LoadOptions) (*Config, error) {
 		return nil, returnErr
 	}
 
+	// Merge included files
+	includes, err := resolveIncludes(data, filePath)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to include config files").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.LoadWithOptions").
+			WithDetail("filePath", filePath)
+	}
+
+	// Apply profile overlays
+	profile := resolveProfile(options.Profile)
+	profileIncludes, err := applyProfile(data, filePath, format, profile)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to apply config profile").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("config.LoadWithOptions").
+			WithDetail("filePath", filePath).
+			WithDetail("profile", profile)
+	}
+	includes = append(includes, profileIncludes...)
+
 	// Apply defaults
 	if options.Defaults != nil {
 		data = mergeDefaults(data, options.Defaults)
 	}
 
-	// Get file modification time
-	fileInfo, _ := os.Stat(filePath)
-	lastModified := time.Time{}
-	if fileInfo != nil {
-		lastModified = fileInfo.ModTime()
+	// Expand ${key} references
+	if err := interpolate(data); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to interpolate config values").
+			WithCode(mdwerror.CodeInvalidConfig).
+			WithOperation("config.LoadWithOptions").
+			WithDetail("filePath", filePath)
+	}
+
+	// Resolve secret references
+	secretProviders := defaultSecretProviders(options.SecretProviders)
+	secretKeys, err := resolveSecrets(data, secretProviders)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to resolve config secrets").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.LoadWithOptions").
+			WithDetail("filePath", filePath)
 	}
 
+	// Apply schema defaults and validate
+	if err := applySchema(options.Schema, data); err != nil {
+		return nil, mdwerror.Wrap(err, "config does not match schema").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation("config.LoadWithOptions").
+			WithDetail("filePath", filePath)
+	}
+
+	// Get the latest modification time of all source files
+	sources := append([]string{filePath}, includes...)
+	if profile != "" {
+		sources = append(sources, profileFilePath(filePath, profile))
+	}
+	lastModified := latestModTime(sources...)
+
 	config := &Config{
 		data:         data,
 		filePath:     filePath,
@@ -194,9 +275,14 @@ func LoadWithOptions(filePath string, options LoadOptions) (*Config, error) {
 		watchers:     make([]ChangeHandler, 0),
 		watching:     options.Watch,
 		lastModified: lastModified,
+		profile:      profile,
+		schema:       options.Schema,
+		includes:     includes,
 		envCache:     make(map[string]string),
 		cacheTimeout: 5 * time.Minute, // Default cache timeout
 		pathCache:    make(map[string][]string),
+		secretProviders: secretProviders,
+		secretKeys:      secretKeys,
 	}
 
 	// Start watching if requested
@@ -221,14 +307,35 @@ func LoadFromString(content string, format Format) (*Config, error) {
 			WithDetail("format", format.String())
 	}
 
+	// Profile sections apply; there are no sibling profile files
+	profile := resolveProfile("")
+	applyProfile(data, "", format, profile)
+
+	if err := interpolate(data); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to interpolate config values").
+			WithCode(mdwerror.CodeInvalidConfig).
+			WithOperation("config.LoadFromString")
+	}
+
+	secretProviders := defaultSecretProviders(nil)
+	secretKeys, err := resolveSecrets(data, secretProviders)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to resolve config secrets").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.LoadFromString")
+	}
+
 	return &Config{
 		data:         data,
 		format:       format,
+		profile:      profile,
 		watchers:     make([]ChangeHandler, 0),
 		watching:     false,
 		envCache:     make(map[string]string),
 		cacheTimeout: 5 * time.Minute,
 		pathCache:    make(map[string][]string),
+		secretProviders: secretProviders,
+		secretKeys:      secretKeys,
 	}, nil
 }
 
@@ -240,6 +347,8 @@ func detectFormat(filePath string) Format {
 		return FormatYAML
 	case ".toml":
 		return FormatTOML
+	case ".json":
+		return FormatJSON
 	default:
 		return FormatTOML // Default to TOML
 	}
@@ -262,6 +371,12 @@ func parseContent(content []byte, format Format) (map[string]interface{}, error)
 				WithCode(mdwerror.CodeInvalidInput).
 				WithOperation("config.parseContent")
 		}
+	case FormatJSON:
+		if err := json.Unmarshal(content, &data); err != nil {
+			return nil, mdwerror.Wrap(err, "JSON parse error").
+				WithCode(mdwerror.CodeInvalidInput).
+				WithOperation("config.parseContent")
+		}
 	default:
 		return nil, mdwerror.New(fmt.Sprintf("unsupported format: %s", format)).
 			WithCode(mdwerror.CodeInvalidInput).
@@ -269,6 +384,10 @@ func parseContent(content []byte, format Format) (map[string]interface{}, error)
 			WithDetail("format", format.String())
 	}
 	
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	
 	return data, nil
 }
 
@@ -294,12 +413,12 @@ func (c *Config) GetString(key string, defaultValue ...string) string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	// Check environment variable first
-	if envValue := c.getEnvValue(key); envValue != "" {
-		return envValue
+	// Check command-line flags and environment variables first
+	if override := c.getOverrideValue(key); override != "" {
+		return override
 	}
 	
-	value := c.getValue(key)
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -322,14 +441,14 @@ func (c *Config) GetInt(key string, defaultValue ...int) int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	// Check environment variable first
-	if envValue := c.getEnvValue(key); envValue != "" {
-		if intVal, err := strconv.Atoi(envValue); err == nil {
+	// Check command-line flags and environment variables first
+	if override := c.getOverrideValue(key); override != "" {
+		if intVal, err := strconv.Atoi(override); err == nil {
 			return intVal
 		}
 	}
 	
-	value := c.getValue(key)
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -361,14 +480,14 @@ func (c *Config) GetBool(key string, defaultValue ...bool) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	// Check environment variable first
-	if envValue := c.getEnvValue(key); envValue != "" {
-		if boolVal, err := strconv.ParseBool(envValue); err == nil {
+	// Check command-line flags and environment variables first
+	if override := c.getOverrideValue(key); override != "" {
+		if boolVal, err := strconv.ParseBool(override); err == nil {
 			return boolVal
 		}
 	}
 	
-	value := c.getValue(key)
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -396,14 +515,14 @@ func (c *Config) GetFloat(key string, defaultValue ...float64) float64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	// Check environment variable first
-	if envValue := c.getEnvValue(key); envValue != "" {
-		if floatVal, err := strconv.ParseFloat(envValue, 64); err == nil {
+	// Check command-line flags and environment variables first
+	if override := c.getOverrideValue(key); override != "" {
+		if floatVal, err := strconv.ParseFloat(override, 64); err == nil {
 			return floatVal
 		}
 	}
 	
-	value := c.getValue(key)
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -437,14 +556,14 @@ func (c *Config) GetDuration(key string, defaultValue ...time.Duration) time.Dur
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	// Check environment variable first
-	if envValue := c.getEnvValue(key); envValue != "" {
-		if duration, err := time.ParseDuration(envValue); err == nil {
+	// Check command-line flags and environment variables first
+	if override := c.getOverrideValue(key); override != "" {
+		if duration, err := time.ParseDuration(override); err == nil {
 			return duration
 		}
 	}
 	
-	value := c.getValue(key)
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -476,7 +595,12 @@ func (c *Config) GetStringSlice(key string, defaultValue ...[]string) []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	value := c.getValue(key)
+	// Command-line flags hold comma-separated lists
+	if flagValue, changed, _ := c.flagValue(key); changed {
+		return strings.Split(flagValue, ",")
+	}
+	
+	value := c.lookupValue(key)
 	if value == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -586,14 +710,16 @@ func (c *Config) Has(key string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
-	return c.getValue(key) != nil
+	return c.lookupValue(key) != nil
 }
 
-// Set sets a configuration value (runtime only, not persisted)
+// Set sets a configuration value at runtime. Changed keys are written to
+// the configuration file by Save.
 func (c *Config) Set(key string, value interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
+	c.markDirty(key)
 	keys := strings.Split(key, ".")
 	current := c.data
 	
@@ -682,6 +808,11 @@ func (c *Config) WithRequestID(requestID string) *Config {
 		envCache:      make(map[string]string),
 		cacheTimeout:  c.cacheTimeout,
 		pathCache:     make(map[string][]string),
+		secretProviders: c.secretProviders,
+		secretKeys:      c.secretKeys,
+		profile:         c.profile,
+		schema:          c.schema,
+		flags:           c.flags,
 	}
 	return clone
 }
@@ -705,6 +836,11 @@ func (c *Config) WithUserID(userID string) *Config {
 		envCache:      make(map[string]string),
 		cacheTimeout:  c.cacheTimeout,
 		pathCache:     make(map[string][]string),
+		secretProviders: c.secretProviders,
+		secretKeys:      c.secretKeys,
+		profile:         c.profile,
+		schema:          c.schema,
+		flags:           c.flags,
 	}
 	return clone
 }
@@ -728,6 +864,11 @@ func (c *Config) WithCorrelationID(correlationID string) *Config {
 		envCache:      make(map[string]string),
 		cacheTimeout:  c.cacheTimeout,
 		pathCache:     make(map[string][]string),
+		secretProviders: c.secretProviders,
+		secretKeys:      c.secretKeys,
+		profile:         c.profile,
+		schema:          c.schema,
+		flags:           c.flags,
 	}
 	return clone
 }
@@ -793,6 +934,10 @@ func (c *Config) String() string {
 		parts = append(parts, fmt.Sprintf("userID: %s", c.userID))
 	}
 	
+	if len(c.secretKeys) > 0 {
+		parts = append(parts, fmt.Sprintf("secrets: %d", len(c.secretKeys)))
+	}
+	
 	parts = append(parts, fmt.Sprintf("keys: %d}", len(c.data)))
 	
 	return strings.Join(parts, ", ")
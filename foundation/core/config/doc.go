@@ -5,12 +5,22 @@
 //              include automatic file discovery, environment variable injection,
 //              configuration validation, hot-reloading, and type-safe access.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.10
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
+// - 2026-10-16 v0.1.1: Documented secret references and redaction
+// - 2026-10-16 v0.1.2: Documented typed Unmarshal into structs
+// - 2026-10-16 v0.1.3: Documented JSON format and remote configuration providers
+// - 2026-10-16 v0.1.4: Documented Set and Save write-back
+// - 2026-10-16 v0.1.5: Documented profile overlays
+// - 2026-10-16 v0.1.6: Documented configuration schemas and example generation
+// - 2026-10-16 v0.1.7: Documented includes and ${key} interpolation
+// - 2026-10-16 v0.1.8: Documented key change subscriptions
+// - 2026-10-16 v0.1.9: Documented command-line flag binding
+// - 2026-10-16 v0.1.10: Documented Dump and Diff
 
 /*
 Package config provides comprehensive configuration management for mDW applications.
@@ -29,10 +39,18 @@ Change History:
 - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
 
 Key Features:
-  • Multi-format support (TOML, YAML) with automatic detection
+  • Multi-format support (TOML, YAML, JSON) with automatic detection
   • Environment variable injection and override capabilities
+  • Command-line flag binding with flags > env > file > default precedence
+  • Profile overlays ([profile.production], config.production.toml) selected via MDW_ENV
+  • Include directives with globs and ${key} interpolation with cycle detection
+  • Secret references (${secret:...}, ${file:...}, ${env:...}, ${enc:...}) with redaction
   • Configuration validation with structured rules
+  • Configuration schemas with defaults, enums, deprecations, and example generation
+  • Typed Unmarshal into structs with config and validate tags
   • Hot-reloading with change notification callbacks
+  • Write-back with Set and Save, preserving TOML comments and key order
+  • Remote providers (HTTP, Consul, etcd) with push/poll updates
   • Thread-safe concurrent access patterns
   • Performance-optimized with caching and lazy loading
   • mDW error integration with structured error codes
@@ -95,6 +113,137 @@ following a consistent naming convention:
 	host := cfg.GetString("database.host")  // Returns "prod-db.example.com"
 	port := cfg.GetInt("database.port")     // Returns 3306
 
+# Command-Line Flags
+
+BindFlags maps flags onto configuration keys. The typed getters resolve
+values with the precedence flags set on the command line > environment
+variables > configuration file > flag defaults:
+
+	flag.String("host", "0.0.0.0", "listen address")
+	flag.Int("port", 8080, "listen port")
+	flag.Parse()
+
+	cfg.BindFlags(flag.CommandLine, map[string]string{
+		"host": "server.host",
+		"port": "server.port",
+	})
+	port := cfg.GetInt("server.port") // --port, MYAPP_SERVER_PORT, file, 8080
+
+With a nil map every flag binds to the key of its name with "-" replaced
+by ".", so --database-host sets database.host. Other flag packages such as
+pflag are bound through a FlagSource adapter with BindFlagSource.
+
+# Profiles and Environment Overlays
+
+One configuration file can serve all environments. The profile selected by
+LoadOptions.Profile, or by the MDW_ENV environment variable, is merged over
+the base configuration:
+
+	# config.toml
+	[database]
+	host = "localhost"
+	pool = 5
+
+	[profile.production.database]
+	host = "db.prod"
+
+	# config.production.toml (optional sibling file)
+	[database]
+	pool = 50
+
+With MDW_ENV=production, database.host is "db.prod" and database.pool is 50.
+Precedence from lowest to highest:
+
+	1. LoadOptions.Defaults
+	2. Base file
+	3. [profile.<name>] section of the base file
+	4. Sibling profile file (config.<name>.toml)
+	5. Environment variables (MYAPP_DATABASE_HOST)
+	6. Command-line flags bound with BindFlags
+
+Sections are merged key by key. The profile section itself is not part of
+the loaded configuration, and Profile reports the active profile. Watched
+configurations reload when either the base or the profile file changes.
+
+# Includes and Interpolation
+
+Large configurations can be split into several files. The top-level include
+key lists files or glob patterns, relative to the including file:
+
+	include = ["database.toml", "conf.d/*.toml"]
+
+	[server]
+	port = 9200
+
+Included files are merged in order (glob matches sorted by name), later
+files overriding earlier ones; the including file overrides everything it
+includes. Included files may include further files in any supported format.
+Include cycles fail the load, and watched configurations reload when an
+included file changes. Includes are resolved for files, not for
+LoadFromString.
+
+String values may reference other keys with ${key}. A value consisting of
+a single reference takes the referenced value including its type; embedded
+references are formatted as text. $${key} produces a literal ${key}:
+
+	base_dir = "/var/lib/mdw"
+	data_dir = "${base_dir}/data"      # "/var/lib/mdw/data"
+
+	[client]
+	port = "${server.port}"            # 9200 (integer)
+
+References are expanded after includes, profiles, and defaults are merged,
+and before secret references are resolved. Undefined keys and reference
+cycles fail the load with CodeInvalidConfig.
+
+# Secret References
+
+Passwords and tokens do not need to live in plain configuration files.
+Values may reference secrets, which are resolved when the file is loaded
+or reloaded:
+
+	[database]
+	user     = "${secret:kv/database#user}"        # External secret store
+	password = "${file:/run/secrets/db_pass}"      # Docker/Kubernetes secret
+	token    = "${env:DB_TOKEN}"                   # Environment variable
+	key      = "${enc:3q2+7w...}"                  # Encrypted at rest
+	dsn      = "postgres://${env:DB_USER}@db/app"  # Embedded reference
+
+The env and file schemes are built in. Other schemes are served by
+SecretProvider implementations passed in LoadOptions; EncryptedSecretProvider
+decrypts values created with EncryptSecret (AES-GCM):
+
+	cfg, err := mdwconfig.LoadWithOptions("app.toml", mdwconfig.LoadOptions{
+		SecretProviders: map[string]mdwconfig.SecretProvider{
+			mdwconfig.SecretSchemeSecret:    vaultProvider,
+			mdwconfig.SecretSchemeEncrypted: mdwconfig.EncryptedSecretProvider(masterKey),
+		},
+	})
+
+Unresolvable references fail the load with CodeConfigError. Resolved keys
+are tracked: IsSecret and SecretKeys report them, and Redacted returns the
+configuration with secret values replaced by RedactedValue for logs and
+diagnostic dumps.
+
+# Dump and Diff
+
+Dump writes the configuration with sorted keys for support bundles and
+admin endpoints. Flat output lists one dotted key per value:
+
+	cfg.Dump(w, mdwconfig.DumpOptions{RedactSecrets: true, Format: mdwconfig.FormatTOML, Flat: true})
+
+	// database.host = "db"
+	// database.password = "[REDACTED]"
+	// server.port = 8080
+
+DefaultDumpOptions redacts secrets; DumpOptions{} does not. Diff lists the
+leaf keys that differ between two configurations as KeyChange values, with
+secret values redacted in either configuration:
+
+	for _, change := range running.Diff(candidate) {
+		fmt.Printf("%s: %v -> %v\n", change.Key, change.OldValue, change.NewValue)
+	}
+
 # Configuration Validation
 
 Validate configuration structure and constraints:
@@ -124,6 +273,50 @@ Validate configuration structure and constraints:
 		mdwlog.Fatal("Configuration validation failed:", err)
 	}
 
+# Configuration Schemas
+
+A ConfigSchema describes the keys of a configuration in code. Passed in
+LoadOptions, it is applied at load time and on every reload: deprecated keys
+are migrated to their replacements, defaults fill missing keys, and loads
+that violate the schema fail with CodeValidationFailed.
+
+	schema := mdwconfig.NewSchema("turing").
+		Field(mdwconfig.SchemaField{
+			Key:         "server.port",
+			Type:        "int",
+			Description: "Port of the gRPC server",
+			Required:    true,
+			Min:         1,
+			Max:         65535,
+			Example:     9200,
+		}).
+		Field(mdwconfig.SchemaField{
+			Key:     "logging.level",
+			Type:    "string",
+			Enum:    []interface{}{"debug", "info", "warn", "error"},
+			Default: "info",
+		}).
+		Field(mdwconfig.SchemaField{
+			Key:        "server.address",
+			Deprecated: "split into host and port",
+			ReplacedBy: "server.host",
+		})
+
+	cfg, err := mdwconfig.LoadWithOptions("turing.toml", mdwconfig.LoadOptions{
+		Schema: schema,
+	})
+
+	// Deprecated keys in use are reported as warnings
+	for _, warning := range cfg.ValidateSchema().Warnings {
+		mdwlog.Warn(warning)
+	}
+
+Strict schemas also reject keys they do not describe. GenerateExample writes
+a commented TOML example from the schema, so shipped example files stay in
+sync with the code:
+
+	cfg.GenerateExample(os.Stdout)
+
 # Hot-Reloading and Change Notifications
 
 Monitor configuration files for changes with automatic reloading:
@@ -148,6 +341,50 @@ Monitor configuration files for changes with automatic reloading:
 		}
 	})
 
+Subscribe to a subset of keys to receive only the changed leaf keys with
+their old and new values. Patterns are dotted keys whose segments may be
+"*" and cover all keys below them. A debounce window coalesces bursts of
+file events into one delivery per subscription:
+
+	cancel := cfg.OnKeyChange("database.*", func(changes []mdwconfig.KeyChange) {
+		for _, change := range changes {
+			mdwlog.Printf("%s: %v -> %v", change.Key, change.OldValue, change.NewValue)
+		}
+		// Reconnect once for all database changes
+	}, mdwconfig.KeyChangeOptions{Debounce: 500 * time.Millisecond})
+	defer cancel()
+
+# Remote Configuration Providers
+
+Clustered services can share configuration from a central source instead of
+deployed files. A Provider loads configuration data; a WatchableProvider also
+reports changes, which are applied like file reloads and passed to OnChange
+handlers:
+
+	consul := mdwconfig.NewConsulProvider(mdwconfig.ConsulProviderOptions{
+		Address: "http://consul:8500",
+		Prefix:  "mdw/turing/",
+		Token:   os.Getenv("CONSUL_TOKEN"),
+	})
+
+	cfg, err := mdwconfig.LoadFromProvider(ctx, consul, mdwconfig.LoadOptions{
+		Defaults: defaults,
+		Watch:    true,
+	})
+	defer cfg.StopWatching()
+
+Available providers:
+
+	NewFileProvider(path, format)  // Local file, polls the modification time
+	NewHTTPProvider(options)       // JSON/TOML/YAML document, polls with ETag
+	NewConsulProvider(options)     // Consul KV prefix, blocking queries
+	NewEtcdProvider(options)       // etcd v3 prefix via the JSON gateway, watch stream
+
+Key/value backends map keys below the prefix to nested keys, so
+"mdw/turing/database/host" becomes "database.host"; values are parsed like
+environment variables. Updates that fail to resolve secrets are discarded
+and the previous configuration stays active.
+
 # Multi-Format Support
 
 The package automatically detects and supports multiple configuration formats:
@@ -158,12 +395,38 @@ The package automatically detects and supports multiple configuration formats:
 	// YAML format (auto-detected)
 	cfg2, _ := mdwconfig.Load("config.yaml")
 	cfg3, _ := mdwconfig.Load("config.yml")
+
+	// JSON format (auto-detected)
+	cfgJSON, _ := mdwconfig.Load("config.json")
 	
 	// Explicit format specification
 	cfg4, _ := mdwconfig.LoadWithOptions("config.txt", mdwconfig.LoadOptions{
 		Format: mdwconfig.FormatTOML,
 	})
 
+# Writing Configuration
+
+Set changes values at runtime; Save writes the changed keys back to the
+loaded file, for example to implement "mdw config set turing.port 9090":
+
+	cfg, err := mdwconfig.Load("configs/config.toml")
+	cfg.Set("turing.port", 9090)
+	if err := cfg.Save("", mdwconfig.FormatAuto); err != nil {
+		return err
+	}
+
+Only keys changed with Set are written, so defaults, environment overrides,
+and secret references never end up in the file. TOML files are patched in
+place: comments, key order, and formatting of untouched lines are kept, new
+keys are appended to their table, and new tables to the end of the file.
+YAML and JSON files, and TOML layouts that cannot be patched line by line
+(keys inside inline tables or arrays of tables), are re-encoded.
+
+Save(path, format) with another path writes the complete configuration, for
+example to export it in a different format; it fails with CodeInvalidConfig
+if the configuration contains resolved secret values. Files are replaced
+atomically, so services watching the file never read a partial write.
+
 # String-Based Configuration Loading
 
 Load configuration from string content:
@@ -308,6 +571,39 @@ Complete application configuration setup:
 		Watch: true,
 	})
 
+	var app AppConfig
+	if err := cfg.Unmarshal(&app); err != nil {
+		mdwlog.Fatal("Invalid configuration:", err)
+	}
+
+# Typed Unmarshalling
+
+Unmarshal populates a struct from the whole configuration and UnmarshalKey
+from a single section or value:
+
+	var db DatabaseConfig
+	err := cfg.UnmarshalKey("database", &db)
+
+Fields are matched by their config tag or lower-case field name; "-" skips
+a field and embedded structs without a tag share the parent section.
+Nested structs, pointers, slices (including arrays of tables), and maps
+with string keys are supported. time.Duration accepts strings such as
+"30s", time.Time accepts TOML datetimes and RFC 3339 strings, mathx.Decimal
+accepts strings and numbers, and types implementing encoding.TextUnmarshaler
+are decoded from strings. Environment variables override scalar values as
+for the Get methods; for slices they hold comma-separated lists.
+
+The validate tag supports required, min:N and max:N (values or lengths),
+in:a,b,c (values continue up to the next rule), and ip. Afterwards, Validate is called
+on every struct implementing Validatable:
+
+	func (d *DatabaseConfig) Validate() error {
+		if d.Pool > d.MaxConnections {
+			return errors.New("pool must not exceed max_connections")
+		}
+		return nil
+	}
+
 # Performance Characteristics
 
 The config module is optimized for production use:
@@ -0,0 +1,141 @@
+// File: dump.go
+// Title: Configuration Dump and Diff
+// Description: Implements Dump, which writes the configuration with secrets
+//              redacted for support bundles and admin endpoints, and Diff,
+//              which lists the keys that differ between two configurations.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Dump and Diff
+
+package config
+
+import (
+	"bytes"
+	"io"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// DumpOptions configures Dump
+type DumpOptions struct {
+	// RedactSecrets replaces values resolved from secret references with
+	// RedactedValue
+	RedactSecrets bool
+
+	// Format is the output format; FormatAuto uses the format of the
+	// configuration
+	Format Format
+
+	// Flat writes one dotted key per leaf value, sorted by key, instead of
+	// nested sections
+	Flat bool
+}
+
+// DefaultDumpOptions returns the default dump options: TOML with secrets
+// redacted
+func DefaultDumpOptions() DumpOptions {
+	return DumpOptions{
+		RedactSecrets: true,
+		Format:        FormatTOML,
+	}
+}
+
+// Dump writes the loaded configuration to w with keys sorted. Environment
+// variable and flag overrides are not part of the output.
+func (c *Config) Dump(w io.Writer, options DumpOptions) error {
+	c.mu.RLock()
+	data := c.deepCopyMap(c.data)
+	if options.RedactSecrets {
+		redactSecrets(data, "", c.secretKeys)
+	}
+	format := options.Format
+	if format == FormatAuto {
+		format = c.format
+	}
+	c.mu.RUnlock()
+
+	var content []byte
+	var err error
+	switch {
+	case options.Flat && format == FormatTOML:
+		content, err = encodeFlatTOML(data)
+	case options.Flat:
+		flat := make(map[string]interface{})
+		probe := &Config{data: data}
+		for _, key := range leafKeys(data, "") {
+			flat[key] = probe.getValue(key)
+		}
+		content, err = encodeContent(flat, format)
+	default:
+		content, err = encodeContent(data, format)
+	}
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to encode config for dump").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.Dump").
+			WithDetail("format", format.String())
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return mdwerror.Wrap(err, "failed to write config dump").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.Dump")
+	}
+	return nil
+}
+
+// encodeFlatTOML encodes data as sorted dotted TOML keys
+func encodeFlatTOML(data map[string]interface{}) ([]byte, error) {
+	probe := &Config{data: normalizeForEncoding(data).(map[string]interface{})}
+
+	var buf bytes.Buffer
+	for _, key := range leafKeys(probe.data, "") {
+		value, err := encodeTOMLValue(probe.getValue(key))
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(encodeTOMLKey(key) + " = " + value + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// Diff returns the leaf keys whose values differ between c and other, sorted
+// by key. OldValue holds the value of c and NewValue the value of other;
+// values of keys that are secret in either configuration are replaced with
+// RedactedValue.
+func (c *Config) Diff(other *Config) []KeyChange {
+	if other == nil {
+		other = &Config{}
+	}
+
+	c.mu.RLock()
+	oldData := c.deepCopyMap(c.data)
+	oldSecrets := c.secretKeys
+	c.mu.RUnlock()
+
+	other.mu.RLock()
+	newData := other.deepCopyMap(other.data)
+	newSecrets := other.secretKeys
+	other.mu.RUnlock()
+
+	changes := diffData(oldData, newData)
+	for i, change := range changes {
+		if oldSecrets[change.Key] || newSecrets[change.Key] {
+			changes[i].OldValue = redactValue(change.OldValue)
+			changes[i].NewValue = redactValue(change.NewValue)
+		}
+	}
+	return changes
+}
+
+// redactValue replaces a present value with RedactedValue
+func redactValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return RedactedValue
+}
@@ -0,0 +1,121 @@
+// File: dump_test.go
+// Title: Configuration Dump and Diff Tests
+// Description: Tests redacted dumps in nested and flat formats and redacted
+//              diffs between configurations.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial dump and diff tests
+
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const dumpTestConfig = `
+name = "turing"
+
+[server]
+port = 8080
+timeout = "30s"
+
+[database]
+host = "db"
+password = "${env:MDW_DUMP_TEST_PASSWORD}"
+`
+
+func TestDump(t *testing.T) {
+	t.Setenv("MDW_DUMP_TEST_PASSWORD", "s3cret")
+	cfg, err := LoadFromString(dumpTestConfig, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.Dump(&buf, DumpOptions{RedactSecrets: true, Format: FormatTOML, Flat: true}); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	const expected = `database.host = "db"
+database.password = "[REDACTED]"
+name = "turing"
+server.port = 8080
+server.timeout = "30s"
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("Dump() =\n%s\nwant:\n%s", got, expected)
+	}
+
+	// The flat TOML dump loads as the same configuration
+	reloaded, err := LoadFromString(buf.String(), FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() of dump error = %v", err)
+	}
+	if reloaded.GetInt("server.port") != 8080 || reloaded.GetString("database.password") != RedactedValue {
+		t.Errorf("reloaded dump = %v", reloaded.GetAll())
+	}
+
+	tests := []struct {
+		name    string
+		options DumpOptions
+		want    []string
+		notWant string
+	}{
+		{"toml", DefaultDumpOptions(), []string{"[database]", `password = "[REDACTED]"`}, "s3cret"},
+		{"yaml", DumpOptions{RedactSecrets: true, Format: FormatYAML}, []string{"database:", "password: '[REDACTED]'"}, "s3cret"},
+		{"json flat", DumpOptions{RedactSecrets: true, Format: FormatJSON, Flat: true}, []string{`"database.password": "[REDACTED]"`, `"server.port": 8080`}, "s3cret"},
+		{"auto", DumpOptions{RedactSecrets: true, Format: FormatAuto}, []string{"[server]"}, "s3cret"},
+		{"unredacted", DumpOptions{Format: FormatTOML}, []string{`password = "s3cret"`}, RedactedValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := cfg.Dump(&buf, tt.options); err != nil {
+				t.Fatalf("Dump() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Dump() missing %q:\n%s", want, buf.String())
+				}
+			}
+			if strings.Contains(buf.String(), tt.notWant) {
+				t.Errorf("Dump() contains %q:\n%s", tt.notWant, buf.String())
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Setenv("MDW_DUMP_TEST_PASSWORD", "s3cret")
+	current, err := LoadFromString(dumpTestConfig, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	t.Setenv("MDW_DUMP_TEST_PASSWORD", "rotated")
+	changed := strings.Replace(dumpTestConfig, "port = 8080", "port = 9090\ndebug = true", 1)
+	changed = strings.Replace(changed, `name = "turing"`, "", 1)
+	updated, err := LoadFromString(changed, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	want := []KeyChange{
+		{Key: "database.password", OldValue: RedactedValue, NewValue: RedactedValue},
+		{Key: "name", OldValue: "turing", NewValue: nil},
+		{Key: "server.debug", OldValue: nil, NewValue: true},
+		{Key: "server.port", OldValue: int64(8080), NewValue: int64(9090)},
+	}
+	if got := current.Diff(updated); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+	if got := current.Diff(current); len(got) != 0 {
+		t.Errorf("Diff() with itself = %+v", got)
+	}
+}
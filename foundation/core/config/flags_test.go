@@ -0,0 +1,115 @@
+// File: flags_test.go
+// Title: Command-Line Flag Binding Tests
+// Description: Tests the precedence of bound flags over environment
+//              variables, file values, and flag defaults.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial flag binding tests
+
+package config
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBindFlags_Precedence(t *testing.T) {
+	cfg, err := LoadWithOptions(writeConfigFile(t, "config.toml", "[server]\nhost = \"file-host\"\nport = 8080\ntimeout = \"5s\"\n"),
+		LoadOptions{Format: FormatAuto, EnvPrefix: "FLAGTEST"})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("host", "flag-default-host", "")
+	fs.Int("port", 9090, "")
+	fs.Duration("timeout", time.Second, "")
+	fs.Bool("debug", false, "")
+	fs.String("tags", "a,b", "")
+	err = cfg.BindFlags(fs, map[string]string{
+		"host": "server.host", "port": "server.port", "timeout": "server.timeout",
+		"debug": "logging.debug", "tags": "logging.tags",
+	})
+	if err != nil {
+		t.Fatalf("BindFlags() error = %v", err)
+	}
+	t.Setenv("FLAGTEST_SERVER_HOST", "env-host")
+	t.Setenv("FLAGTEST_SERVER_PORT", "7070")
+
+	if err := fs.Parse([]string{"-port", "6060", "-debug"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := cfg.GetInt("server.port"); got != 6060 {
+		t.Errorf("server.port = %d, want flag 6060", got)
+	}
+	if got := cfg.GetString("server.host"); got != "env-host" {
+		t.Errorf("server.host = %q, want env-host", got)
+	}
+	if got := cfg.GetDuration("server.timeout"); got != 5*time.Second {
+		t.Errorf("server.timeout = %v, want file value 5s", got)
+	}
+	if !cfg.GetBool("logging.debug") {
+		t.Error("logging.debug = false, want flag true")
+	}
+	if got := cfg.GetStringSlice("logging.tags"); !reflect.DeepEqual(got, []string{"a,b"}) {
+		t.Errorf("logging.tags = %v, want flag default", got)
+	}
+	if !cfg.Has("logging.tags") {
+		t.Error("Has() = false for key with flag default")
+	}
+
+	// Flags set on the command line also hold lists
+	if err := fs.Parse([]string{"-tags", "x,y"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := cfg.GetStringSlice("logging.tags"); !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Errorf("logging.tags = %v, want [x y]", got)
+	}
+}
+
+func TestBindFlags_NameMapping(t *testing.T) {
+	cfg, err := LoadFromString("[database]\nhost = \"file\"\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("database-host", "", "")
+	fs.Int("database-pool", 4, "")
+	if err := fs.Parse([]string{"-database-host", "cli"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := cfg.BindFlags(fs, nil); err != nil {
+		t.Fatalf("BindFlags() error = %v", err)
+	}
+
+	if got := cfg.GetString("database.host"); got != "cli" {
+		t.Errorf("database.host = %q, want cli", got)
+	}
+	if got := cfg.GetInt("database.pool"); got != 4 {
+		t.Errorf("database.pool = %d, want flag default 4", got)
+	}
+
+	// Derived configurations keep the bindings
+	if got := cfg.WithRequestID("req").GetString("database.host"); got != "cli" {
+		t.Errorf("derived database.host = %q, want cli", got)
+	}
+}
+
+func TestBindFlags_Errors(t *testing.T) {
+	cfg, _ := LoadFromString("", FormatTOML)
+	if err := cfg.BindFlags(nil, nil); err == nil {
+		t.Error("BindFlags(nil) succeeded")
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := cfg.BindFlags(fs, map[string]string{"missing": "server.port"}); err == nil {
+		t.Error("BindFlags() with undefined flag succeeded")
+	}
+}
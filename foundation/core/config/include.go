@@ -0,0 +1,258 @@
+// File: include.go
+// Title: Configuration Includes and Interpolation
+// Description: Implements the include directive, which merges other files
+//              into a configuration, and ${key} interpolation referencing
+//              other configuration keys. Both detect cycles.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of includes and interpolation
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IncludeKey is the top-level key listing files to include
+const IncludeKey = "include"
+
+// ===============================
+// Includes
+// ===============================
+
+// resolveIncludes merges the files listed under IncludeKey into data and
+// removes the key. Paths are relative to the including file and may be glob
+// patterns. Included files are merged in order, later files overriding
+// earlier ones, and the including file overrides all of them. It returns
+// the paths of all included files, including nested includes.
+func resolveIncludes(data map[string]interface{}, filePath string) ([]string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return resolveIncludesFrom(data, absPath, []string{absPath})
+}
+
+// resolveIncludesFrom resolves includes of the file at filePath; chain
+// holds the files currently being included, outermost first
+func resolveIncludesFrom(data map[string]interface{}, filePath string, chain []string) ([]string, error) {
+	raw, exists := data[IncludeKey]
+	if !exists {
+		return nil, nil
+	}
+	delete(data, IncludeKey)
+
+	var patterns []string
+	switch v := raw.(type) {
+	case string:
+		patterns = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			pattern, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: include entries must be strings, got %T", filePath, item)
+			}
+			patterns = append(patterns, pattern)
+		}
+	default:
+		return nil, fmt.Errorf("%s: include must be a string or a list of strings, got %T", filePath, raw)
+	}
+
+	merged := make(map[string]interface{})
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(filePath), pattern)
+		}
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			globbed, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid include pattern %s: %w", filePath, pattern, err)
+			}
+			sort.Strings(globbed)
+			matches = globbed
+		}
+
+		for _, match := range matches {
+			for _, including := range chain {
+				if including == match {
+					return nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(chain, " -> "), match)
+				}
+			}
+
+			content, err := os.ReadFile(match)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to read included file: %w", filePath, err)
+			}
+			included, err := parseContent(content, detectFormat(match))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse included file %s: %w", match, err)
+			}
+			nested, err := resolveIncludesFrom(included, match, append(chain[:len(chain):len(chain)], match))
+			if err != nil {
+				return nil, err
+			}
+
+			mergeOverlay(merged, included)
+			files = append(files, match)
+			files = append(files, nested...)
+		}
+	}
+
+	// The including file overrides the included ones
+	mergeOverlay(merged, data)
+	for key := range data {
+		delete(data, key)
+	}
+	for key, value := range merged {
+		data[key] = value
+	}
+	return files, nil
+}
+
+// ===============================
+// Interpolation
+// ===============================
+
+// referencePattern matches ${key} references to other keys; $${key} is an
+// escaped literal. Secret references contain a scheme and do not match.
+var referencePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// Interpolation states of keys
+const (
+	interpolating = 1
+	interpolated  = 2
+)
+
+// interpolator expands references in configuration data
+type interpolator struct {
+	data  map[string]interface{}
+	probe *Config
+	state map[string]int
+	chain []string
+}
+
+// interpolate replaces ${key} references in string values with the values
+// of the referenced keys. A string consisting of a single reference takes
+// the referenced value with its type.
+func interpolate(data map[string]interface{}) error {
+	ip := &interpolator{
+		data:  data,
+		probe: &Config{data: data},
+		state: make(map[string]int),
+	}
+	for _, key := range leafKeys(data, "") {
+		if _, err := ip.resolve(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve expands the value of key and returns it
+func (ip *interpolator) resolve(key string) (interface{}, error) {
+	switch ip.state[key] {
+	case interpolated:
+		return ip.probe.getValue(key), nil
+	case interpolating:
+		return nil, fmt.Errorf("interpolation cycle: %s -> %s", strings.Join(ip.chain, " -> "), key)
+	}
+
+	value := ip.probe.getValue(key)
+	if value == nil {
+		return nil, fmt.Errorf("undefined key '%s'", key)
+	}
+
+	ip.state[key] = interpolating
+	ip.chain = append(ip.chain, key)
+	expanded, err := ip.expand(key, value)
+	ip.chain = ip.chain[:len(ip.chain)-1]
+	if err != nil {
+		return nil, err
+	}
+	if _, isSection := value.(map[string]interface{}); !isSection {
+		setNestedValue(ip.data, key, expanded)
+	}
+	ip.state[key] = interpolated
+	return expanded, nil
+}
+
+// expand expands the references within value
+func (ip *interpolator) expand(key string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return ip.expandString(key, v)
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			result, err := ip.expand(key, item)
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = result
+		}
+		return expanded, nil
+	case map[string]interface{}:
+		// Resolve the section key by key so that it can be referenced whole
+		for child := range v {
+			if _, err := ip.resolve(key + "." + child); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// expandString expands the references within s
+func (ip *interpolator) expandString(key, s string) (interface{}, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	// A single reference keeps the type of the referenced value
+	if match := referencePattern.FindStringSubmatch(s); match != nil && match[0] == s && !strings.HasPrefix(s, "$$") {
+		value, err := ip.resolve(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %w", key, err)
+		}
+		return deepCopyValue(value), nil
+	}
+
+	var expandErr error
+	expanded := referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if expandErr != nil {
+			return match
+		}
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		reference := match[2 : len(match)-1]
+		value, err := ip.resolve(reference)
+		if err != nil {
+			expandErr = fmt.Errorf("key '%s': %w", key, err)
+			return match
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			expandErr = fmt.Errorf("key '%s': cannot embed '%s' in a string", key, reference)
+			return match
+		}
+		return fmt.Sprint(value)
+	})
+	if expandErr != nil {
+		return nil, expandErr
+	}
+	return expanded, nil
+}
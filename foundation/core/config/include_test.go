@@ -0,0 +1,210 @@
+// File: include_test.go
+// Title: Configuration Include and Interpolation Tests
+// Description: Tests include directives with globs, nesting, precedence, and
+//              cycle detection, and ${key} interpolation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial include and interpolation tests
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFiles writes files relative to a temporary directory and returns it
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoad_Include(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"config.toml": `include = ["db.toml", "conf.d/*.toml"]
+
+[server]
+port = 8080
+
+[database]
+pool = 10
+`,
+		"db.toml":            "include = \"common.yaml\"\n\n[database]\nhost = \"db\"\npool = 5\n",
+		"common.yaml":        "database:\n  port: 5432\n  host: common\n",
+		"conf.d/10-log.toml": "[logging]\nlevel = \"info\"\n",
+		"conf.d/20-log.toml": "[logging]\nlevel = \"warn\"\nformat = \"json\"\n",
+	})
+
+	cfg, err := Load(filepath.Join(dir, "config.toml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"server.port", 8080},
+		{"database.pool", 10},     // Including file overrides includes
+		{"database.host", "db"},   // Including file of a nested include wins
+		{"database.port", 5432},   // Nested include
+		{"logging.level", "warn"}, // Later glob matches override earlier ones
+		{"logging.format", "json"},
+	}
+	for _, tt := range tests {
+		var got interface{}
+		switch tt.want.(type) {
+		case string:
+			got = cfg.GetString(tt.key)
+		case int:
+			got = cfg.GetInt(tt.key)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+	if cfg.Has(IncludeKey) {
+		t.Error("include key is visible in the configuration")
+	}
+
+	// Changes of included files are detected by watching
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(filepath.Join(dir, "common.yaml"), future, future)
+	if modTime, err := cfg.sourceModTime(); err != nil || !modTime.Equal(future) {
+		t.Errorf("sourceModTime() = %v, %v", modTime, err)
+	}
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "cycle",
+			files:   map[string]string{"config.toml": `include = "a.toml"`, "a.toml": `include = "b.toml"`, "b.toml": `include = "a.toml"`},
+			wantErr: "include cycle",
+		},
+		{
+			name:    "self",
+			files:   map[string]string{"config.toml": `include = "config.toml"`},
+			wantErr: "include cycle",
+		},
+		{
+			name:    "missing",
+			files:   map[string]string{"config.toml": `include = "missing.toml"`},
+			wantErr: "failed to read included file",
+		},
+		{
+			name:    "invalid",
+			files:   map[string]string{"config.toml": `include = 5`},
+			wantErr: "string or a list of strings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			_, err := Load(filepath.Join(dir, "config.toml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Globs without matches are not an error
+	dir := writeFiles(t, map[string]string{"config.toml": "include = [\"conf.d/*.toml\"]\nname = \"x\"\n"})
+	if _, err := Load(filepath.Join(dir, "config.toml")); err != nil {
+		t.Errorf("Load() with empty glob error = %v", err)
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	t.Setenv("MDW_INTERPOLATION_TEST_PASSWORD", "pw")
+	cfg, err := LoadFromString(`
+base_dir = "/var/lib/mdw"
+data_dir = "${base_dir}/data"
+cache_dir = "${paths.cache}"
+literal = "$${base_dir}"
+password = "${env:MDW_INTERPOLATION_TEST_PASSWORD}"
+
+[paths]
+cache = "${data_dir}/cache"
+list = ["${base_dir}", "static"]
+
+[server]
+port = 8080
+health = "http://localhost:${server.port}/health"
+
+[client]
+port = "${server.port}"
+server = "${server}"
+password = "${password}"
+`, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"data_dir", "/var/lib/mdw/data"},
+		{"cache_dir", "/var/lib/mdw/data/cache"},
+		{"literal", "${base_dir}"},
+		{"server.health", "http://localhost:8080/health"},
+		{"client.password", "pw"},
+	}
+	for _, tt := range tests {
+		if got := cfg.GetString(tt.key); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if _, isInt := cfg.GetAll()["client"].(map[string]interface{})["port"].(int64); !isInt {
+		t.Errorf("client.port = %#v, want typed integer", cfg.GetAll()["client"])
+	}
+	if cfg.GetInt("client.server.port") != 8080 || cfg.GetStringSlice("paths.list")[0] != "/var/lib/mdw" {
+		t.Errorf("config = %v", cfg.GetAll())
+	}
+	if !cfg.IsSecret("client.password") {
+		t.Error("interpolated secret is not tracked as secret")
+	}
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"cycle", "a = \"${b}\"\nb = \"x${c}\"\nc = \"${a}\"\n", "interpolation cycle"},
+		{"self", "a = \"${a}\"\n", "interpolation cycle"},
+		{"undefined", "a = \"${missing.key}\"\n", "undefined key 'missing.key'"},
+		{"embed section", "a = \"x${s}\"\n[s]\nb = 1\n", "cannot embed 's'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFromString(tt.content, FormatTOML)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFromString() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// File: keychange.go
+// Title: Key Change Subscriptions
+// Description: Implements OnKeyChange, which delivers only the changed keys
+//              matching a pattern with their old and new values, optionally
+//              debounced to coalesce bursts of file events.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of key change subscriptions
+// - 2026-10-16 v0.1.1: Added JSON tags for diff listings
+
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyChange describes a changed configuration key
+type KeyChange struct {
+	Key      string      `json:"key"`           // Dotted key
+	OldValue interface{} `json:"old,omitempty"` // Previous value, nil if the key was added
+	NewValue interface{} `json:"new,omitempty"` // Current value, nil if the key was removed
+}
+
+// Added reports whether the key did not exist before
+func (kc KeyChange) Added() bool {
+	return kc.OldValue == nil
+}
+
+// Removed reports whether the key no longer exists
+func (kc KeyChange) Removed() bool {
+	return kc.NewValue == nil
+}
+
+// KeyChangeHandler receives the changed keys of a subscription, sorted by key
+type KeyChangeHandler func(changes []KeyChange)
+
+// KeyChangeOptions configures a key change subscription
+type KeyChangeOptions struct {
+	// Debounce delays delivery until no further change arrived for the given
+	// duration; changes in between are coalesced per key (default: 0, deliver
+	// every update)
+	Debounce time.Duration
+}
+
+// keySubscription is a registered OnKeyChange handler
+type keySubscription struct {
+	pattern  []string
+	handler  KeyChangeHandler
+	debounce time.Duration
+
+	mu      sync.Mutex
+	pending map[string]KeyChange
+	timer   *time.Timer
+	closed  bool
+}
+
+// OnKeyChange registers handler for changes of keys matching pattern. The
+// pattern is a dotted key whose segments may be "*" to match any single
+// segment; a pattern also matches all keys below the keys it matches, so
+// "database.*" and "database" both cover "database.pool.size". Only leaf
+// values are reported. The returned function cancels the subscription and
+// discards pending debounced changes.
+func (c *Config) OnKeyChange(pattern string, handler KeyChangeHandler, options ...KeyChangeOptions) func() {
+	sub := &keySubscription{handler: handler}
+	if pattern != "" && pattern != "*" {
+		sub.pattern = strings.Split(pattern, ".")
+	}
+	if len(options) > 0 {
+		sub.debounce = options[0].Debounce
+	}
+
+	c.mu.Lock()
+	c.keyWatchers = append(c.keyWatchers, sub)
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		for i, registered := range c.keyWatchers {
+			if registered == sub {
+				c.keyWatchers = append(c.keyWatchers[:i:i], c.keyWatchers[i+1:]...)
+				break
+			}
+		}
+		c.mu.Unlock()
+
+		sub.mu.Lock()
+		sub.closed = true
+		sub.pending = nil
+		if sub.timer != nil {
+			sub.timer.Stop()
+		}
+		sub.mu.Unlock()
+	}
+}
+
+// notifyKeyChanges delivers the differences between oldData and newData
+// to the subscriptions
+func notifyKeyChanges(subs []*keySubscription, oldData, newData map[string]interface{}) {
+	if len(subs) == 0 {
+		return
+	}
+	changes := diffData(oldData, newData)
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, sub := range subs {
+		var matched []KeyChange
+		for _, change := range changes {
+			if sub.matches(change.Key) {
+				matched = append(matched, change)
+			}
+		}
+		if len(matched) > 0 {
+			sub.deliver(matched)
+		}
+	}
+}
+
+// matches reports whether key is covered by the subscription pattern
+func (s *keySubscription) matches(key string) bool {
+	segments := strings.Split(key, ".")
+	if len(segments) < len(s.pattern) {
+		return false
+	}
+	for i, segment := range s.pattern {
+		if segment != "*" && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver calls the handler directly or after the debounce delay
+func (s *keySubscription) deliver(changes []KeyChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	if s.debounce <= 0 {
+		go s.handler(changes)
+		return
+	}
+
+	if s.pending == nil {
+		s.pending = make(map[string]KeyChange)
+	}
+	for _, change := range changes {
+		// Keep the value from before the first coalesced change
+		if previous, exists := s.pending[change.Key]; exists {
+			change.OldValue = previous.OldValue
+		}
+		s.pending[change.Key] = change
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(s.debounce, s.flush)
+}
+
+// flush delivers the coalesced changes; keys that changed back to their
+// original value are dropped
+func (s *keySubscription) flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.timer = nil
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return
+	}
+
+	changes := make([]KeyChange, 0, len(pending))
+	for _, change := range pending {
+		if !reflect.DeepEqual(change.OldValue, change.NewValue) {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	s.handler(changes)
+}
+
+// diffData returns the leaf keys whose values differ between oldData and
+// newData, sorted by key
+func diffData(oldData, newData map[string]interface{}) []KeyChange {
+	oldProbe := &Config{data: oldData}
+	newProbe := &Config{data: newData}
+
+	keys := leafKeys(oldData, "")
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+	for _, key := range leafKeys(newData, "") {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []KeyChange
+	for _, key := range keys {
+		oldValue := leafValue(oldProbe, key)
+		newValue := leafValue(newProbe, key)
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, KeyChange{Key: key, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return changes
+}
+
+// leafValue returns the value of key unless it is a section
+func leafValue(probe *Config, key string) interface{} {
+	value := probe.getValue(key)
+	if _, isSection := value.(map[string]interface{}); isSection {
+		return nil
+	}
+	return value
+}
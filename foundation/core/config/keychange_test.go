@@ -0,0 +1,154 @@
+// File: keychange_test.go
+// Title: Key Change Subscription Tests
+// Description: Tests pattern matching, change delivery with old and new
+//              values, debouncing, and cancellation of key subscriptions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial key change subscription tests
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// receiveChanges waits for a delivery on ch
+func receiveChanges(t *testing.T, ch <-chan []KeyChange) []KeyChange {
+	t.Helper()
+	select {
+	case changes := <-ch:
+		return changes
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for key changes")
+		return nil
+	}
+}
+
+func TestOnKeyChange(t *testing.T) {
+	cfg, err := LoadFromString("[database]\nhost = \"db1\"\nport = 5432\n\n[server]\nport = 8080\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	database := make(chan []KeyChange, 1)
+	server := make(chan []KeyChange, 1)
+	cfg.OnKeyChange("database.*", func(changes []KeyChange) { database <- changes })
+	cfg.OnKeyChange("server.port", func(changes []KeyChange) { server <- changes })
+
+	err = cfg.applyUpdate(map[string]interface{}{
+		"database": map[string]interface{}{"host": "db2", "port": int64(5432), "user": "app"},
+		"server":   map[string]interface{}{"port": int64(8080)},
+	}, cfg.lastModified)
+	if err != nil {
+		t.Fatalf("applyUpdate() error = %v", err)
+	}
+
+	want := []KeyChange{
+		{Key: "database.host", OldValue: "db1", NewValue: "db2"},
+		{Key: "database.user", OldValue: nil, NewValue: "app"},
+	}
+	if got := receiveChanges(t, database); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %+v, want %+v", got, want)
+	}
+	if !want[1].Added() || want[1].Removed() {
+		t.Error("Added()/Removed() mismatch for new key")
+	}
+
+	select {
+	case changes := <-server:
+		t.Errorf("unchanged key delivered: %+v", changes)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestKeySubscription_Matches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"database.*", "database.host", true},
+		{"database.*", "database.pool.size", true},
+		{"database", "database.host", true},
+		{"database.*", "databases.host", false},
+		{"*.port", "server.port", true},
+		{"*.port", "server.host", false},
+		{"server.port", "server.port", true},
+		{"server.port", "server", false},
+		{"*", "anything.at.all", true},
+		{"", "name", true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{}
+		cfg.OnKeyChange(tt.pattern, func([]KeyChange) {})
+		if got := cfg.keyWatchers[0].matches(tt.key); got != tt.want {
+			t.Errorf("pattern %q matches(%q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestOnKeyChange_Debounce(t *testing.T) {
+	cfg, err := LoadFromString("[database]\nhost = \"db1\"\nport = 5432\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	delivered := make(chan []KeyChange, 4)
+	cfg.OnKeyChange("database", func(changes []KeyChange) { delivered <- changes },
+		KeyChangeOptions{Debounce: 100 * time.Millisecond})
+
+	updates := []map[string]interface{}{
+		{"host": "db2", "port": int64(5433)},
+		{"host": "db3", "port": int64(5433)},
+		{"host": "db3", "port": int64(5432)}, // Port changes back
+	}
+	for _, update := range updates {
+		if err := cfg.applyUpdate(map[string]interface{}{"database": update}, cfg.lastModified); err != nil {
+			t.Fatalf("applyUpdate() error = %v", err)
+		}
+	}
+
+	want := []KeyChange{{Key: "database.host", OldValue: "db1", NewValue: "db3"}}
+	if got := receiveChanges(t, delivered); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %+v, want %+v", got, want)
+	}
+	select {
+	case changes := <-delivered:
+		t.Errorf("unexpected second delivery: %+v", changes)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestOnKeyChange_Cancel(t *testing.T) {
+	cfg, err := LoadFromString("name = \"a\"\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	delivered := make(chan []KeyChange, 1)
+	cancel := cfg.OnKeyChange("name", func(changes []KeyChange) { delivered <- changes },
+		KeyChangeOptions{Debounce: 50 * time.Millisecond})
+
+	if err := cfg.applyUpdate(map[string]interface{}{"name": "b"}, cfg.lastModified); err != nil {
+		t.Fatalf("applyUpdate() error = %v", err)
+	}
+	cancel()
+	if err := cfg.applyUpdate(map[string]interface{}{"name": "c"}, cfg.lastModified); err != nil {
+		t.Fatalf("applyUpdate() error = %v", err)
+	}
+
+	select {
+	case changes := <-delivered:
+		t.Errorf("delivery after cancel: %+v", changes)
+	case <-time.After(150 * time.Millisecond):
+	}
+	if len(cfg.keyWatchers) != 0 {
+		t.Errorf("keyWatchers = %d after cancel", len(cfg.keyWatchers))
+	}
+}
@@ -0,0 +1,137 @@
+// File: profile.go
+// Title: Configuration Profiles
+// Description: Implements profile overlays selected via LoadOptions.Profile or
+//              the MDW_ENV environment variable. Profile sections of the base
+//              file and sibling profile files are merged over the base
+//              configuration.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of profile overlays
+// - 2026-10-16 v0.1.1: Resolved includes of profile files and tracked included files
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ProfileEnvVar selects the profile when LoadOptions.Profile is empty
+	ProfileEnvVar = "MDW_ENV"
+
+	// ProfileSection is the top-level section holding profile overlays,
+	// e.g. [profile.production.database]
+	ProfileSection = "profile"
+)
+
+// resolveProfile returns the explicit profile or the one selected by MDW_ENV
+func resolveProfile(profile string) string {
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	return strings.TrimSpace(profile)
+}
+
+// profileFilePath returns the sibling file of a profile, e.g.
+// config.production.toml for config.toml
+func profileFilePath(filePath, profile string) string {
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "." + profile + ext
+}
+
+// applyProfile merges the overlays of profile over data, in increasing
+// precedence: the [profile.<name>] section of the base file, then the
+// sibling profile file with its includes. The profile section is removed
+// from data in any case. It returns the files included by the sibling.
+func applyProfile(data map[string]interface{}, filePath string, format Format, profile string) ([]string, error) {
+	sections, _ := data[ProfileSection].(map[string]interface{})
+	delete(data, ProfileSection)
+	if profile == "" {
+		return nil, nil
+	}
+
+	if overlay, ok := sections[profile].(map[string]interface{}); ok {
+		mergeOverlay(data, overlay)
+	}
+
+	if filePath == "" {
+		return nil, nil
+	}
+	siblingPath := profileFilePath(filePath, profile)
+	content, err := os.ReadFile(siblingPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile file %s: %w", siblingPath, err)
+	}
+	overlay, err := parseContent(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile file %s: %w", siblingPath, err)
+	}
+	included, err := resolveIncludes(overlay, siblingPath)
+	if err != nil {
+		return nil, err
+	}
+	delete(overlay, ProfileSection)
+	mergeOverlay(data, overlay)
+	return included, nil
+}
+
+// mergeOverlay merges overlay into base recursively; values of overlay win,
+// and nested sections are merged key by key
+func mergeOverlay(base, overlay map[string]interface{}) {
+	for key, value := range overlay {
+		nested, isMap := value.(map[string]interface{})
+		existing, baseIsMap := base[key].(map[string]interface{})
+		if isMap && baseIsMap {
+			mergeOverlay(existing, nested)
+			continue
+		}
+		base[key] = value
+	}
+}
+
+// Profile returns the active profile, or "" if none is selected
+func (c *Config) Profile() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.profile
+}
+
+// sourceModTime returns the latest modification time of the configuration
+// file, the active profile file, and the included files. It fails if the
+// configuration file is missing.
+func (c *Config) sourceModTime() (time.Time, error) {
+	if _, err := os.Stat(c.filePath); err != nil {
+		return time.Time{}, err
+	}
+
+	c.mu.RLock()
+	paths := append([]string{c.filePath}, c.includes...)
+	if c.profile != "" {
+		paths = append(paths, profileFilePath(c.filePath, c.profile))
+	}
+	c.mu.RUnlock()
+	return latestModTime(paths...), nil
+}
+
+// latestModTime returns the latest modification time of the existing files
+// among paths
+func latestModTime(paths ...string) time.Time {
+	var latest time.Time
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,176 @@
+// File: profile_test.go
+// Title: Configuration Profile Tests
+// Description: Tests profile selection, overlay precedence of profile
+//              sections and sibling files, and reloading of profile files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial profile tests
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const profileBaseConfig = `
+[database]
+host = "localhost"
+port = 5432
+pool = 5
+
+[logging]
+level = "debug"
+
+[profile.production.database]
+host = "db.prod"
+pool = 50
+
+[profile.production.logging]
+level = "warn"
+
+[profile.staging.database]
+host = "db.staging"
+`
+
+// writeProfileFiles writes the base config and an optional production file
+func writeProfileFiles(t *testing.T, production string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte(profileBaseConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if production != "" {
+		if err := os.WriteFile(filepath.Join(dir, "config.production.toml"), []byte(production), 0644); err != nil {
+			t.Fatalf("Failed to write profile config: %v", err)
+		}
+	}
+	return path
+}
+
+func TestLoad_ProfileOverlays(t *testing.T) {
+	path := writeProfileFiles(t, "[database]\npool = 100\n\n[cache]\nenabled = true\n")
+	t.Setenv(ProfileEnvVar, "production")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Profile() != "production" {
+		t.Errorf("Profile() = %q", cfg.Profile())
+	}
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"database.host", "db.prod"}, // Profile section
+		{"database.port", 5432},      // Base
+		{"database.pool", 100},       // Profile file over profile section
+		{"logging.level", "warn"},
+		{"cache.enabled", true},
+	}
+	for _, tt := range tests {
+		var got interface{}
+		switch tt.want.(type) {
+		case string:
+			got = cfg.GetString(tt.key)
+		case int:
+			got = cfg.GetInt(tt.key)
+		case bool:
+			got = cfg.GetBool(tt.key)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+	if cfg.Has(ProfileSection) {
+		t.Error("profile section is visible in the configuration")
+	}
+}
+
+func TestLoad_ProfileSelection(t *testing.T) {
+	path := writeProfileFiles(t, "")
+	t.Setenv(ProfileEnvVar, "production")
+
+	staging, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Profile: "staging"})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+	if staging.GetString("database.host") != "db.staging" || staging.GetString("logging.level") != "debug" {
+		t.Errorf("staging config = %v", staging.GetAll())
+	}
+
+	t.Setenv(ProfileEnvVar, "")
+	base, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if base.Profile() != "" || base.GetString("database.host") != "localhost" || base.Has(ProfileSection) {
+		t.Errorf("base config = %v", base.GetAll())
+	}
+
+	t.Setenv(ProfileEnvVar, "production")
+	fromString, err := LoadFromString(profileBaseConfig, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	if fromString.GetInt("database.pool") != 50 {
+		t.Errorf("string config = %v", fromString.GetAll())
+	}
+}
+
+func TestLoad_InvalidProfileFile(t *testing.T) {
+	path := writeProfileFiles(t, "[database\n")
+	if _, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Profile: "production"}); err == nil {
+		t.Error("LoadWithOptions() with invalid profile file succeeded")
+	}
+}
+
+func TestReload_ProfileFile(t *testing.T) {
+	path := writeProfileFiles(t, "[database]\npool = 100\n")
+	cfg, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Profile: "production"})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	profilePath := profileFilePath(path, "production")
+	if err := os.WriteFile(profilePath, []byte("[database]\npool = 200\n"), 0644); err != nil {
+		t.Fatalf("Failed to update profile config: %v", err)
+	}
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(profilePath, future, future)
+
+	if modTime, err := cfg.sourceModTime(); err != nil || !modTime.Equal(future) {
+		t.Errorf("sourceModTime() = %v, %v", modTime, err)
+	}
+	if err := cfg.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	if cfg.GetInt("database.pool") != 200 || cfg.GetString("database.host") != "db.prod" {
+		t.Errorf("reloaded config = %v", cfg.GetAll())
+	}
+}
+
+func TestMergeOverlay(t *testing.T) {
+	base := map[string]interface{}{
+		"a": map[string]interface{}{"x": 1, "y": 2},
+		"b": "keep",
+		"c": map[string]interface{}{"z": 1},
+	}
+	mergeOverlay(base, map[string]interface{}{
+		"a": map[string]interface{}{"y": 3},
+		"c": "replaced",
+	})
+	a := base["a"].(map[string]interface{})
+	if a["x"] != 1 || a["y"] != 3 || base["b"] != "keep" || base["c"] != "replaced" {
+		t.Errorf("mergeOverlay() = %v", base)
+	}
+}
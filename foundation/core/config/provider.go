@@ -0,0 +1,365 @@
+// File: provider.go
+// Title: Configuration Providers
+// Description: Defines the Provider interface for configuration sources and
+//              loads configurations from providers with optional watching.
+//              Includes providers for local files and HTTP-polled documents;
+//              remote key/value backends are implemented in remote.go.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.3
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of providers and provider watching
+// - 2026-10-16 v0.1.1: Applied profile sections to provider data
+// - 2026-10-16 v0.1.2: Validated provider data against schemas
+// - 2026-10-16 v0.1.3: Interpolated ${key} references in provider data
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// Provider supplies configuration data from a source such as a file or a
+// remote backend
+type Provider interface {
+	// Name identifies the provider in errors and String output
+	Name() string
+
+	// Load returns the current configuration data
+	Load(ctx context.Context) (map[string]interface{}, error)
+}
+
+// WatchableProvider is a Provider that reports changes. Watch blocks until
+// ctx is done and calls onChange with the new data whenever the source
+// changes; transient errors are retried.
+type WatchableProvider interface {
+	Provider
+	Watch(ctx context.Context, onChange func(data map[string]interface{})) error
+}
+
+// LoadFromProvider loads configuration from a provider. Defaults, the
+// environment prefix, profile sections, secret providers, and the schema
+// apply as for files. With Watch
+// set, updates of a WatchableProvider are applied and reported to OnChange
+// handlers until StopWatching is called.
+func LoadFromProvider(ctx context.Context, provider Provider, options LoadOptions) (*Config, error) {
+	if provider == nil {
+		return nil, mdwerror.New("config provider cannot be nil").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation("config.LoadFromProvider")
+	}
+
+	data, err := provider.Load(ctx)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to load config from provider").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.LoadFromProvider").
+			WithDetail("provider", provider.Name())
+	}
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	profile := resolveProfile(options.Profile)
+	applyProfile(data, "", options.Format, profile)
+	if options.Defaults != nil {
+		data = mergeDefaults(data, options.Defaults)
+	}
+	if err := interpolate(data); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to interpolate config values").
+			WithCode(mdwerror.CodeInvalidConfig).
+			WithOperation("config.LoadFromProvider").
+			WithDetail("provider", provider.Name())
+	}
+
+	secretProviders := defaultSecretProviders(options.SecretProviders)
+	secretKeys, err := resolveSecrets(data, secretProviders)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to resolve config secrets").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.LoadFromProvider").
+			WithDetail("provider", provider.Name())
+	}
+	if err := applySchema(options.Schema, data); err != nil {
+		return nil, mdwerror.Wrap(err, "config does not match schema").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation("config.LoadFromProvider").
+			WithDetail("provider", provider.Name())
+	}
+
+	config := &Config{
+		data:            data,
+		format:          options.Format,
+		envPrefix:       options.EnvPrefix,
+		watchers:        make([]ChangeHandler, 0),
+		envCache:        make(map[string]string),
+		cacheTimeout:    5 * time.Minute,
+		pathCache:       make(map[string][]string),
+		secretProviders: secretProviders,
+		secretKeys:      secretKeys,
+		provider:        provider,
+		profile:         profile,
+		schema:          options.Schema,
+	}
+
+	if watchable, ok := provider.(WatchableProvider); ok && options.Watch {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		config.watching = true
+		config.stopWatch = cancel
+		go watchable.Watch(watchCtx, func(data map[string]interface{}) {
+			applyProfile(data, "", options.Format, profile)
+			if options.Defaults != nil {
+				data = mergeDefaults(data, options.Defaults)
+			}
+			// Keep the previous configuration if the update is invalid
+			_ = config.applyUpdate(data, time.Time{})
+		})
+	}
+
+	return config, nil
+}
+
+// Provider returns the provider the configuration was loaded from, or nil
+// for configurations loaded from files or strings
+func (c *Config) Provider() Provider {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.provider
+}
+
+// ===============================
+// File Provider
+// ===============================
+
+// DefaultPollInterval is the default interval of polling providers
+const DefaultPollInterval = time.Second
+
+// FileProvider reads configuration from a local file and watches it by
+// polling its modification time
+type FileProvider struct {
+	path     string
+	format   Format
+	interval time.Duration
+	mu       sync.Mutex
+	modTime  time.Time // Modification time of the last loaded content
+}
+
+// NewFileProvider creates a provider for the file at path. FormatAuto
+// detects the format from the file extension.
+func NewFileProvider(path string, format Format) *FileProvider {
+	if format == FormatAuto {
+		format = detectFormat(path)
+	}
+	return &FileProvider{path: path, format: format, interval: DefaultPollInterval}
+}
+
+// WithInterval sets the polling interval of Watch
+func (p *FileProvider) WithInterval(interval time.Duration) *FileProvider {
+	p.interval = interval
+	return p
+}
+
+// Name returns "file:" followed by the file path
+func (p *FileProvider) Name() string {
+	return "file:" + p.path
+}
+
+// Load reads and parses the file
+func (p *FileProvider) Load(ctx context.Context) (map[string]interface{}, error) {
+	// Stat before reading so that a concurrent write is detected by Watch
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", p.path, err)
+	}
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", p.path, err)
+	}
+	data, err := parseContent(content, p.format)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.modTime = info.ModTime()
+	p.mu.Unlock()
+	return data, nil
+}
+
+// Watch polls the modification time of the file and reloads it when it
+// changed since the last Load
+func (p *FileProvider) Watch(ctx context.Context, onChange func(data map[string]interface{})) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(p.path)
+		p.mu.Lock()
+		modified := err == nil && !info.ModTime().Equal(p.modTime)
+		p.mu.Unlock()
+		if !modified {
+			continue
+		}
+		if data, err := p.Load(ctx); err == nil {
+			onChange(data)
+		}
+	}
+}
+
+// ===============================
+// HTTP Provider
+// ===============================
+
+// HTTPProviderOptions configures an HTTPProvider
+type HTTPProviderOptions struct {
+	URL      string            // Document URL
+	Format   Format            // Document format (FormatAuto: from Content-Type, default JSON)
+	Headers  map[string]string // Additional request headers, e.g. Authorization
+	Interval time.Duration     // Polling interval of Watch (default: 30s)
+	Client   *http.Client      // HTTP client (default: 10s timeout)
+}
+
+// DefaultHTTPProviderOptions returns default options for url
+func DefaultHTTPProviderOptions(url string) HTTPProviderOptions {
+	return HTTPProviderOptions{
+		URL:      url,
+		Format:   FormatAuto,
+		Interval: 30 * time.Second,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// HTTPProvider loads a configuration document over HTTP and watches it by
+// polling with conditional requests (ETag)
+type HTTPProvider struct {
+	options HTTPProviderOptions
+	mu      sync.Mutex
+	etag    string
+	digest  [sha256.Size]byte
+}
+
+// NewHTTPProvider creates an HTTP provider
+func NewHTTPProvider(options HTTPProviderOptions) (*HTTPProvider, error) {
+	if options.URL == "" {
+		return nil, mdwerror.New("HTTP provider URL cannot be empty").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation("config.NewHTTPProvider")
+	}
+	defaults := DefaultHTTPProviderOptions(options.URL)
+	if options.Interval <= 0 {
+		options.Interval = defaults.Interval
+	}
+	if options.Client == nil {
+		options.Client = defaults.Client
+	}
+	return &HTTPProvider{options: options}, nil
+}
+
+// Name returns the document URL
+func (p *HTTPProvider) Name() string {
+	return p.options.URL
+}
+
+// Load fetches and parses the document
+func (p *HTTPProvider) Load(ctx context.Context) (map[string]interface{}, error) {
+	data, _, err := p.fetch(ctx, false)
+	return data, err
+}
+
+// Watch polls the document and reports changed content
+func (p *HTTPProvider) Watch(ctx context.Context, onChange func(data map[string]interface{})) error {
+	ticker := time.NewTicker(p.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		data, changed, err := p.fetch(ctx, true)
+		if err == nil && changed {
+			onChange(data)
+		}
+	}
+}
+
+// fetch requests the document; conditional requests report unchanged
+// documents (304 or identical content) with changed == false
+func (p *HTTPProvider) fetch(ctx context.Context, conditional bool) (map[string]interface{}, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.options.URL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid request: %w", err)
+	}
+	for name, value := range p.options.Headers {
+		request.Header.Set(name, value)
+	}
+	p.mu.Lock()
+	if conditional && p.etag != "" {
+		request.Header.Set("If-None-Match", p.etag)
+	}
+	p.mu.Unlock()
+
+	response, err := p.options.Client.Do(request)
+	if err != nil {
+		return nil, false, fmt.Errorf("request to %s failed: %w", p.options.URL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified {
+		return nil, false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("request to %s failed with status %s", p.options.URL, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response from %s: %w", p.options.URL, err)
+	}
+
+	data, err := parseContent(body, p.format(response.Header.Get("Content-Type")))
+	if err != nil {
+		return nil, false, err
+	}
+
+	digest := sha256.Sum256(body)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	changed := !bytes.Equal(digest[:], p.digest[:])
+	p.digest = digest
+	p.etag = response.Header.Get("ETag")
+	return data, changed, nil
+}
+
+// format returns the configured format or derives it from the content type
+func (p *HTTPProvider) format(contentType string) Format {
+	if p.options.Format != FormatAuto {
+		return p.options.Format
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/toml":
+		return FormatTOML
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
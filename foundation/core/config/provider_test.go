@@ -0,0 +1,149 @@
+// File: provider_test.go
+// Title: Configuration Provider Tests
+// Description: Tests loading and watching configurations through the file
+//              and HTTP providers and LoadFromProvider.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial provider tests
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForChange returns the next configuration passed to OnChange
+func waitForChange(t *testing.T, changes <-chan *Config) *Config {
+	t.Helper()
+	select {
+	case cfg := <-changes:
+		return cfg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for configuration change")
+		return nil
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(`{"server": {"port": 8080}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	provider := NewFileProvider(path, FormatAuto).WithInterval(10 * time.Millisecond)
+	cfg, err := LoadFromProvider(context.Background(), provider, LoadOptions{
+		Defaults: map[string]interface{}{"debug": true},
+		Watch:    true,
+	})
+	if err != nil {
+		t.Fatalf("LoadFromProvider() error = %v", err)
+	}
+	defer cfg.StopWatching()
+
+	if cfg.GetInt("server.port") != 8080 || !cfg.GetBool("debug") || cfg.Provider() != provider {
+		t.Errorf("config = %v", cfg.GetAll())
+	}
+
+	changes := make(chan *Config, 1)
+	cfg.OnChange(func(oldConfig, newConfig *Config) { changes <- newConfig })
+	future := time.Now().Add(time.Second)
+	if err := os.WriteFile(path, []byte(`{"server": {"port": 9090}}`), 0644); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	os.Chtimes(path, future, future)
+
+	updated := waitForChange(t, changes)
+	if updated.GetInt("server.port") != 9090 || cfg.GetInt("server.port") != 9090 || !cfg.GetBool("debug") {
+		t.Errorf("updated config = %v", cfg.GetAll())
+	}
+
+	cfg.StopWatching()
+	if cfg.IsWatching() {
+		t.Error("IsWatching() after StopWatching")
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var mu sync.Mutex
+	document := `{"feature": {"enabled": false}}`
+	requests, notModified := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		etag := `"` + document + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(document))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(HTTPProviderOptions{
+		URL:      server.URL,
+		Format:   FormatAuto,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPProvider() error = %v", err)
+	}
+	cfg, err := LoadFromProvider(context.Background(), provider, LoadOptions{Watch: true})
+	if err != nil {
+		t.Fatalf("LoadFromProvider() error = %v", err)
+	}
+	defer cfg.StopWatching()
+	if cfg.GetBool("feature.enabled") {
+		t.Error("feature.enabled = true before update")
+	}
+
+	changes := make(chan *Config, 1)
+	cfg.OnChange(func(oldConfig, newConfig *Config) { changes <- newConfig })
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	document = `{"feature": {"enabled": true}}`
+	mu.Unlock()
+
+	if !waitForChange(t, changes).GetBool("feature.enabled") {
+		t.Error("update not applied")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if notModified == 0 {
+		t.Errorf("no conditional requests among %d requests", requests)
+	}
+
+	if _, err := NewHTTPProvider(HTTPProviderOptions{}); err == nil {
+		t.Error("NewHTTPProvider() without URL succeeded")
+	}
+}
+
+func TestLoadFromProvider_Errors(t *testing.T) {
+	if _, err := LoadFromProvider(context.Background(), nil, LoadOptions{}); err == nil {
+		t.Error("LoadFromProvider(nil) succeeded")
+	}
+	missing := NewFileProvider(filepath.Join(t.TempDir(), "missing.toml"), FormatAuto)
+	if _, err := LoadFromProvider(context.Background(), missing, LoadOptions{}); err == nil {
+		t.Error("LoadFromProvider() with missing file succeeded")
+	}
+}
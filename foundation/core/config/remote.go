@@ -0,0 +1,473 @@
+// File: remote.go
+// Title: Remote Configuration Backends
+// Description: Implements configuration providers for the Consul and etcd
+//              key/value stores using their HTTP APIs. Keys below a prefix
+//              map to dot-notation configuration keys, and changes are pushed
+//              through Consul blocking queries and etcd watch streams, so
+//              clustered services share configuration without redeploying
+//              files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Consul and etcd providers
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// DefaultRetryDelay is the delay before remote watches retry after errors
+const DefaultRetryDelay = 5 * time.Second
+
+// kvToData converts key/value pairs below prefix to nested configuration
+// data; "app/database/host" with prefix "app/" becomes database.host.
+// Values are parsed like environment variables (bool, int, float, string).
+func kvToData(prefix string, pairs map[string]string) map[string]interface{} {
+	data := make(map[string]interface{})
+	for key, value := range pairs {
+		relative := strings.Trim(strings.TrimPrefix(key, prefix), "/")
+		if relative == "" || strings.HasSuffix(key, "/") {
+			continue // Prefix itself or folder entry
+		}
+		setNestedValue(data, strings.ReplaceAll(relative, "/", "."), parseEnvValue(value))
+	}
+	return data
+}
+
+// sleepContext waits for delay and reports false if ctx was cancelled first
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// ===============================
+// Consul Provider
+// ===============================
+
+// ConsulProviderOptions configures a ConsulProvider
+type ConsulProviderOptions struct {
+	Address    string        // Consul HTTP address (default: http://127.0.0.1:8500)
+	Prefix     string        // Key prefix, e.g. "mdw/turing/"
+	Token      string        // ACL token
+	Datacenter string        // Datacenter (default: agent's datacenter)
+	WaitTime   time.Duration // Maximum duration of blocking queries (default: 5m)
+	RetryDelay time.Duration // Delay before retrying after errors (default: 5s)
+	Client     *http.Client  // HTTP client (default: http.DefaultClient)
+}
+
+// ConsulProvider loads configuration from the Consul KV store and watches
+// it with blocking queries
+type ConsulProvider struct {
+	options ConsulProviderOptions
+	mu      sync.Mutex
+	index   uint64
+}
+
+// NewConsulProvider creates a Consul provider
+func NewConsulProvider(options ConsulProviderOptions) *ConsulProvider {
+	if options.Address == "" {
+		options.Address = "http://127.0.0.1:8500"
+	}
+	if options.WaitTime <= 0 {
+		options.WaitTime = 5 * time.Minute
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = DefaultRetryDelay
+	}
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	return &ConsulProvider{options: options}
+}
+
+// Name returns "consul:" followed by the key prefix
+func (p *ConsulProvider) Name() string {
+	return "consul:" + p.options.Prefix
+}
+
+// Load reads all keys below the prefix
+func (p *ConsulProvider) Load(ctx context.Context) (map[string]interface{}, error) {
+	data, index, err := p.query(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.index = index
+	p.mu.Unlock()
+	return data, nil
+}
+
+// Watch issues blocking queries and reports data whenever the index changes
+func (p *ConsulProvider) Watch(ctx context.Context, onChange func(data map[string]interface{})) error {
+	for ctx.Err() == nil {
+		p.mu.Lock()
+		lastIndex := p.index
+		p.mu.Unlock()
+
+		data, index, err := p.query(ctx, lastIndex)
+		if err != nil {
+			if !sleepContext(ctx, p.options.RetryDelay) {
+				return nil
+			}
+			continue
+		}
+		if index == lastIndex {
+			continue // Blocking query timed out without changes
+		}
+		if index < lastIndex {
+			index = 0 // Index went backwards, e.g. after a snapshot restore
+		}
+
+		p.mu.Lock()
+		p.index = index
+		p.mu.Unlock()
+		onChange(data)
+	}
+	return nil
+}
+
+// query reads the keys below the prefix; a non-zero index makes it a
+// blocking query that returns after a change or the wait time
+func (p *ConsulProvider) query(ctx context.Context, index uint64) (map[string]interface{}, uint64, error) {
+	params := url.Values{"recurse": {"true"}}
+	if p.options.Datacenter != "" {
+		params.Set("dc", p.options.Datacenter)
+	}
+	if index > 0 {
+		params.Set("index", strconv.FormatUint(index, 10))
+		params.Set("wait", p.options.WaitTime.String())
+	}
+	endpoint := fmt.Sprintf("%s/v1/kv/%s?%s", strings.TrimRight(p.options.Address, "/"), p.options.Prefix, params.Encode())
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid Consul request: %w", err)
+	}
+	if p.options.Token != "" {
+		request.Header.Set("X-Consul-Token", p.options.Token)
+	}
+
+	response, err := p.options.Client.Do(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Consul request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	newIndex, _ := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+	if response.StatusCode == http.StatusNotFound {
+		return make(map[string]interface{}), newIndex, nil // No keys below the prefix
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, 0, mdwerror.New(fmt.Sprintf("Consul request failed with status %s", response.Status)).
+			WithCode(mdwerror.CodeExternalServiceError).
+			WithOperation("config.ConsulProvider.query").
+			WithDetail("prefix", p.options.Prefix)
+	}
+
+	var entries []struct {
+		Key   string
+		Value []byte // Base64 in JSON, decoded by encoding/json
+	}
+	if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode Consul response: %w", err)
+	}
+	pairs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		pairs[entry.Key] = string(entry.Value)
+	}
+	return kvToData(p.options.Prefix, pairs), newIndex, nil
+}
+
+// ===============================
+// etcd Provider
+// ===============================
+
+// EtcdProviderOptions configures an EtcdProvider
+type EtcdProviderOptions struct {
+	Endpoint   string        // etcd gRPC gateway address (default: http://127.0.0.1:2379)
+	Prefix     string        // Key prefix, e.g. "/mdw/turing/"
+	Username   string        // User for authentication (optional)
+	Password   string        // Password for authentication
+	RetryDelay time.Duration // Delay before reconnecting the watch (default: 5s)
+	Client     *http.Client  // HTTP client (default: http.DefaultClient)
+}
+
+// EtcdProvider loads configuration from etcd v3 through its JSON gateway
+// and watches the prefix with a watch stream
+type EtcdProvider struct {
+	options  EtcdProviderOptions
+	mu       sync.Mutex
+	revision int64
+	token    string
+}
+
+// NewEtcdProvider creates an etcd provider
+func NewEtcdProvider(options EtcdProviderOptions) *EtcdProvider {
+	if options.Endpoint == "" {
+		options.Endpoint = "http://127.0.0.1:2379"
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = DefaultRetryDelay
+	}
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+	return &EtcdProvider{options: options}
+}
+
+// etcdInt decodes int64 values, which the gateway encodes as JSON strings
+type etcdInt int64
+
+// UnmarshalJSON accepts quoted and unquoted integers
+func (i *etcdInt) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = etcdInt(value)
+	return nil
+}
+
+// etcdHeader is the response header of the etcd gateway
+type etcdHeader struct {
+	Revision etcdInt `json:"revision"`
+}
+
+// etcdKeyValue is a key/value pair with base64 encoded key and value
+type etcdKeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Name returns "etcd:" followed by the key prefix
+func (p *EtcdProvider) Name() string {
+	return "etcd:" + p.options.Prefix
+}
+
+// Load reads all keys below the prefix
+func (p *EtcdProvider) Load(ctx context.Context) (map[string]interface{}, error) {
+	key, rangeEnd := p.keyRange()
+	var response struct {
+		Header etcdHeader     `json:"header"`
+		Kvs    []etcdKeyValue `json:"kvs"`
+	}
+	if err := p.post(ctx, "/v3/kv/range", map[string]interface{}{"key": key, "range_end": rangeEnd}, &response); err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]string, len(response.Kvs))
+	for _, kv := range response.Kvs {
+		pairs[string(kv.Key)] = string(kv.Value)
+	}
+	p.mu.Lock()
+	p.revision = int64(response.Header.Revision)
+	p.mu.Unlock()
+	return kvToData(p.options.Prefix, pairs), nil
+}
+
+// Watch opens a watch stream on the prefix and reloads all keys after each
+// batch of events; broken streams are reopened after the retry delay
+func (p *EtcdProvider) Watch(ctx context.Context, onChange func(data map[string]interface{})) error {
+	for ctx.Err() == nil {
+		err := p.watchStream(ctx, onChange)
+		if err != nil && !sleepContext(ctx, p.options.RetryDelay) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// watchStream processes one watch stream until it ends
+func (p *EtcdProvider) watchStream(ctx context.Context, onChange func(data map[string]interface{})) error {
+	key, rangeEnd := p.keyRange()
+	p.mu.Lock()
+	startRevision := p.revision + 1
+	p.mu.Unlock()
+
+	body, err := json.Marshal(map[string]interface{}{
+		"create_request": map[string]interface{}{
+			"key":            key,
+			"range_end":      rangeEnd,
+			"start_revision": startRevision,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	response, err := p.do(ctx, "/v3/watch", body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var message struct {
+			Result struct {
+				Header   etcdHeader        `json:"header"`
+				Events   []json.RawMessage `json:"events"`
+				Canceled bool              `json:"canceled"`
+			} `json:"result"`
+		}
+		if err := decoder.Decode(&message); err != nil {
+			if err == io.EOF {
+				return fmt.Errorf("etcd watch stream closed")
+			}
+			return fmt.Errorf("failed to decode etcd watch response: %w", err)
+		}
+		if message.Result.Canceled {
+			return fmt.Errorf("etcd watch was canceled")
+		}
+		if len(message.Result.Events) == 0 {
+			continue // Creation confirmation or progress notification
+		}
+
+		data, err := p.Load(ctx)
+		if err != nil {
+			return err
+		}
+		onChange(data)
+	}
+}
+
+// keyRange returns the key and range end covering the prefix
+func (p *EtcdProvider) keyRange() ([]byte, []byte) {
+	if p.options.Prefix == "" {
+		return []byte{0}, []byte{0} // All keys
+	}
+	key := []byte(p.options.Prefix)
+	end := append([]byte(nil), key...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return key, end[:i+1]
+		}
+	}
+	return key, []byte{0}
+}
+
+// post sends a JSON request to the gateway and decodes the response
+func (p *EtcdProvider) post(ctx context.Context, path string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	response, err := p.do(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode etcd response: %w", err)
+	}
+	return nil
+}
+
+// do sends an authenticated request to the gateway
+func (p *EtcdProvider) do(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	token, err := p.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimRight(p.options.Endpoint, "/") + path
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid etcd request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		request.Header.Set("Authorization", token)
+	}
+
+	response, err := p.options.Client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("etcd request failed: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		if response.StatusCode == http.StatusUnauthorized {
+			p.mu.Lock()
+			p.token = "" // Authenticate again on the next request
+			p.mu.Unlock()
+		}
+		return nil, mdwerror.New(fmt.Sprintf("etcd request failed with status %s", response.Status)).
+			WithCode(mdwerror.CodeExternalServiceError).
+			WithOperation("config.EtcdProvider.do").
+			WithDetail("path", path)
+	}
+	return response, nil
+}
+
+// authenticate returns a cached or new auth token if credentials are set
+func (p *EtcdProvider) authenticate(ctx context.Context) (string, error) {
+	if p.options.Username == "" {
+		return "", nil
+	}
+	p.mu.Lock()
+	token := p.token
+	p.mu.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	body, _ := json.Marshal(map[string]string{"name": p.options.Username, "password": p.options.Password})
+	endpoint := strings.TrimRight(p.options.Endpoint, "/") + "/v3/auth/authenticate"
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("invalid etcd request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := p.options.Client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("etcd authentication failed: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", mdwerror.New(fmt.Sprintf("etcd authentication failed with status %s", response.Status)).
+			WithCode(mdwerror.CodeUnauthorized).
+			WithOperation("config.EtcdProvider.authenticate")
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode etcd authentication response: %w", err)
+	}
+	p.mu.Lock()
+	p.token = result.Token
+	p.mu.Unlock()
+	return result.Token, nil
+}
+
+// Ensure the providers satisfy the watch interface
+var (
+	_ WatchableProvider = (*FileProvider)(nil)
+	_ WatchableProvider = (*HTTPProvider)(nil)
+	_ WatchableProvider = (*ConsulProvider)(nil)
+	_ WatchableProvider = (*EtcdProvider)(nil)
+)
@@ -0,0 +1,216 @@
+// File: remote_test.go
+// Title: Remote Configuration Backend Tests
+// Description: Tests the Consul and etcd providers against in-process fakes
+//              of their HTTP APIs, including change notifications.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial remote provider tests
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeKV is a versioned key/value store shared by the fake backends
+type fakeKV struct {
+	mu      sync.Mutex
+	index   uint64
+	pairs   map[string]string
+	changed chan struct{}
+}
+
+func newFakeKV(pairs map[string]string) *fakeKV {
+	return &fakeKV{index: 1, pairs: pairs, changed: make(chan struct{})}
+}
+
+func (kv *fakeKV) put(key, value string) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.pairs[key] = value
+	kv.index++
+	close(kv.changed)
+	kv.changed = make(chan struct{})
+}
+
+func (kv *fakeKV) snapshot(prefix string) (map[string]string, uint64, chan struct{}) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	pairs := make(map[string]string)
+	for key, value := range kv.pairs {
+		if strings.HasPrefix(key, prefix) {
+			pairs[key] = value
+		}
+	}
+	return pairs, kv.index, kv.changed
+}
+
+func TestConsulProvider(t *testing.T) {
+	kv := newFakeKV(map[string]string{
+		"mdw/turing/port":          "9090",
+		"mdw/turing/database/host": "db1",
+		"mdw/turing/":              "",
+		"mdw/other/port":           "1",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		pairs, index, changed := kv.snapshot(prefix)
+		if wait, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); wait == index {
+			select {
+			case <-changed:
+			case <-r.Context().Done():
+				return
+			}
+			pairs, index, _ = kv.snapshot(prefix)
+		}
+
+		var entries []map[string]interface{}
+		for key, value := range pairs {
+			entries = append(entries, map[string]interface{}{"Key": key, "Value": []byte(value)})
+		}
+		w.Header().Set("X-Consul-Index", strconv.FormatUint(index, 10))
+		json.NewEncoder(w).Encode(entries)
+	}))
+	defer server.Close()
+
+	provider := NewConsulProvider(ConsulProviderOptions{Address: server.URL, Prefix: "mdw/turing/", Token: "secret"})
+	cfg, err := LoadFromProvider(context.Background(), provider, LoadOptions{Watch: true})
+	if err != nil {
+		t.Fatalf("LoadFromProvider() error = %v", err)
+	}
+	defer cfg.StopWatching()
+
+	if cfg.GetInt("port") != 9090 || cfg.GetString("database.host") != "db1" || cfg.Has("other") {
+		t.Errorf("config = %v", cfg.GetAll())
+	}
+
+	changes := make(chan *Config, 1)
+	cfg.OnChange(func(oldConfig, newConfig *Config) { changes <- newConfig })
+	kv.put("mdw/turing/database/host", "db2")
+
+	if updated := waitForChange(t, changes); updated.GetString("database.host") != "db2" {
+		t.Errorf("updated config = %v", updated.GetAll())
+	}
+
+	unauthorized := NewConsulProvider(ConsulProviderOptions{Address: server.URL, Prefix: "mdw/turing/"})
+	if _, err := unauthorized.Load(context.Background()); err == nil {
+		t.Error("Load() without token succeeded")
+	}
+}
+
+func TestEtcdProvider(t *testing.T) {
+	kv := newFakeKV(map[string]string{
+		"/mdw/turing/port":    "9090",
+		"/mdw/turing/debug":   "true",
+		"/mdw/turingx/ignore": "1",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"token": "tok"})
+			return
+		}
+		if r.Header.Get("Authorization") != "tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var request struct {
+			Key           []byte `json:"key"`
+			RangeEnd      []byte `json:"range_end"`
+			CreateRequest struct {
+				Key []byte `json:"key"`
+			} `json:"create_request"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		switch r.URL.Path {
+		case "/v3/kv/range":
+			if string(request.RangeEnd) != "/mdw/turing0" {
+				t.Errorf("range_end = %q", request.RangeEnd)
+			}
+			pairs, index, _ := kv.snapshot(string(request.Key))
+			var kvs []map[string]interface{}
+			for key, value := range pairs {
+				kvs = append(kvs, map[string]interface{}{"key": []byte(key), "value": []byte(value)})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"header": map[string]string{"revision": strconv.FormatUint(index, 10)},
+				"kvs":    kvs,
+			})
+		case "/v3/watch":
+			_, _, changed := kv.snapshot("")
+			encoder := json.NewEncoder(w)
+			encoder.Encode(map[string]interface{}{"result": map[string]interface{}{"created": true}})
+			w.(http.Flusher).Flush()
+			select {
+			case <-changed:
+			case <-r.Context().Done():
+				return
+			}
+			encoder.Encode(map[string]interface{}{"result": map[string]interface{}{
+				"events": []map[string]interface{}{{"kv": map[string]interface{}{"key": request.CreateRequest.Key}}},
+			}})
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	provider := NewEtcdProvider(EtcdProviderOptions{
+		Endpoint:   server.URL,
+		Prefix:     "/mdw/turing/",
+		Username:   "mdw",
+		Password:   "pw",
+		RetryDelay: 10 * time.Millisecond,
+	})
+	cfg, err := LoadFromProvider(context.Background(), provider, LoadOptions{Watch: true})
+	if err != nil {
+		t.Fatalf("LoadFromProvider() error = %v", err)
+	}
+	defer cfg.StopWatching()
+
+	if cfg.GetInt("port") != 9090 || !cfg.GetBool("debug") || cfg.Has("ignore") {
+		t.Errorf("config = %v", cfg.GetAll())
+	}
+
+	changes := make(chan *Config, 1)
+	cfg.OnChange(func(oldConfig, newConfig *Config) { changes <- newConfig })
+	time.Sleep(50 * time.Millisecond) // Let the watch stream start
+	kv.put("/mdw/turing/port", "9191")
+
+	if updated := waitForChange(t, changes); updated.GetInt("port") != 9191 {
+		t.Errorf("updated config = %v", updated.GetAll())
+	}
+}
+
+func TestKVToData(t *testing.T) {
+	data := kvToData("app/", map[string]string{
+		"app/server/port":  "80",
+		"app/server/":      "",
+		"app/feature/beta": "true",
+		"app/name":         "mdw",
+	})
+	server := data["server"].(map[string]interface{})
+	if server["port"] != 80 || data["feature"].(map[string]interface{})["beta"] != true || data["name"] != "mdw" {
+		t.Errorf("kvToData() = %v", data)
+	}
+}
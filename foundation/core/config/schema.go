@@ -0,0 +1,489 @@
+// File: schema.go
+// Title: Configuration Schema
+// Description: Implements ConfigSchema, a declarative description of
+//              configuration keys with types, ranges, enums, defaults, and
+//              deprecations. Schemas are validated at load time and generate
+//              commented example configuration files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of schemas and example generation
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// SchemaField describes one configuration key
+type SchemaField struct {
+	Key         string        // Dotted key, e.g. "database.port"
+	Type        string        // Value type as in ValidationRule: "string", "int", "float", "bool", "duration", "[]string"
+	Description string        // Written as comment into generated examples
+	Required    bool          // Whether the key must be present
+	Default     interface{}   // Value applied when the key is missing
+	Example     interface{}   // Value for generated examples if there is no default
+	Min         interface{}   // Minimum value (numbers) or length (strings/slices)
+	Max         interface{}   // Maximum value (numbers) or length (strings/slices)
+	Enum        []interface{} // Allowed values
+	Pattern     string        // Regex pattern for strings
+	Deprecated  string        // Deprecation notice; set keys produce warnings
+	ReplacedBy  string        // Key receiving the value of a deprecated key
+}
+
+// ConfigSchema describes the keys of a configuration
+type ConfigSchema struct {
+	Name   string // Name used in generated examples
+	Strict bool   // Whether keys not described by the schema are errors
+
+	fields []SchemaField
+	index  map[string]int
+}
+
+// NewSchema creates an empty schema
+func NewSchema(name string) *ConfigSchema {
+	return &ConfigSchema{
+		Name:  name,
+		index: make(map[string]int),
+	}
+}
+
+// Field adds or replaces the description of a key and returns the schema
+// for chaining. Fields keep their registration order in examples.
+func (s *ConfigSchema) Field(field SchemaField) *ConfigSchema {
+	if i, exists := s.index[field.Key]; exists {
+		s.fields[i] = field
+		return s
+	}
+	s.index[field.Key] = len(s.fields)
+	s.fields = append(s.fields, field)
+	return s
+}
+
+// Fields returns the fields in registration order
+func (s *ConfigSchema) Fields() []SchemaField {
+	return append([]SchemaField(nil), s.fields...)
+}
+
+// Lookup returns the field describing key
+func (s *ConfigSchema) Lookup(key string) (SchemaField, bool) {
+	if i, exists := s.index[key]; exists {
+		return s.fields[i], true
+	}
+	return SchemaField{}, false
+}
+
+// Validate checks cfg against the schema. Deprecated keys in use are
+// reported as warnings.
+func (s *ConfigSchema) Validate(cfg *Config) *ValidationResult {
+	cfg.mu.RLock()
+	defer cfg.mu.RUnlock()
+	return s.validateData(cfg.data)
+}
+
+// Schema returns the schema the configuration was loaded with, or nil
+func (c *Config) Schema() *ConfigSchema {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.schema
+}
+
+// ValidateSchema checks the configuration against its schema
+func (c *Config) ValidateSchema() *ValidationResult {
+	schema := c.Schema()
+	if schema == nil {
+		return &ValidationResult{Valid: true, Errors: make([]string, 0)}
+	}
+	return schema.Validate(c)
+}
+
+// GenerateExample writes an example configuration for the schema of the
+// configuration
+func (c *Config) GenerateExample(w io.Writer) error {
+	schema := c.Schema()
+	if schema == nil {
+		return mdwerror.New("configuration has no schema").
+			WithCode(mdwerror.CodeInvalidOperation).
+			WithOperation("config.GenerateExample")
+	}
+	return schema.GenerateExample(w)
+}
+
+// applySchema migrates deprecated keys, applies defaults, and validates
+// data. A nil schema accepts any data.
+func applySchema(schema *ConfigSchema, data map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	schema.apply(data)
+	if result := schema.validateData(data); !result.Valid {
+		return fmt.Errorf("schema '%s': %s", schema.Name, strings.Join(result.Errors, "; "))
+	}
+	return nil
+}
+
+// apply moves values of deprecated keys to their replacements and sets
+// defaults of missing keys
+func (s *ConfigSchema) apply(data map[string]interface{}) {
+	probe := &Config{data: data}
+	for _, field := range s.fields {
+		if field.ReplacedBy == "" {
+			continue
+		}
+		if value := probe.getValue(field.Key); value != nil && probe.getValue(field.ReplacedBy) == nil {
+			setNestedValue(data, field.ReplacedBy, value)
+		}
+	}
+	for _, field := range s.fields {
+		if field.Default != nil && probe.getValue(field.Key) == nil {
+			setNestedValue(data, field.Key, field.Default)
+		}
+	}
+}
+
+// validateData checks data against the schema
+func (s *ConfigSchema) validateData(data map[string]interface{}) *ValidationResult {
+	result := &ValidationResult{Valid: true, Errors: make([]string, 0)}
+	fail := func(err error) {
+		result.Valid = false
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	probe := &Config{data: data}
+	for _, field := range s.fields {
+		value := probe.getValue(field.Key)
+		if value == nil {
+			if field.Required {
+				fail(fmt.Errorf("required field '%s' is missing", field.Key))
+			}
+			continue
+		}
+
+		if field.Deprecated != "" {
+			warning := fmt.Sprintf("field '%s' is deprecated: %s", field.Key, field.Deprecated)
+			if field.ReplacedBy != "" {
+				warning += fmt.Sprintf(" (use '%s')", field.ReplacedBy)
+			}
+			result.Warnings = append(result.Warnings, warning)
+		}
+
+		if field.Type != "" {
+			if err := checkSchemaType(field.Key, value, field.Type); err != nil {
+				fail(err)
+				continue
+			}
+		}
+		if err := checkSchemaBounds(field.Key, value, field.Min, field.Max); err != nil {
+			fail(err)
+		}
+		if field.Pattern != "" {
+			if err := probe.validatePattern(field.Key, value, field.Pattern); err != nil {
+				fail(err)
+			}
+		}
+		if len(field.Enum) > 0 && !enumContains(field.Enum, value) {
+			fail(fmt.Errorf("field '%s' value '%v' is not one of %s", field.Key, value, formatEnum(field.Enum)))
+		}
+	}
+
+	if s.Strict {
+		for _, key := range leafKeys(data, "") {
+			if !s.describes(key) {
+				fail(fmt.Errorf("field '%s' is not defined in the schema", key))
+			}
+		}
+	}
+	return result
+}
+
+// describes reports whether key or one of its parents is a schema field
+func (s *ConfigSchema) describes(key string) bool {
+	for {
+		if _, exists := s.index[key]; exists {
+			return true
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return false
+		}
+		key = key[:i]
+	}
+}
+
+// leafKeys returns the sorted dotted keys of the non-section values of data
+func leafKeys(data map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range data {
+		path := joinKey(prefix, key)
+		if nested, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, leafKeys(nested, path)...)
+			continue
+		}
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkSchemaType checks the type of value without converting it
+func checkSchemaType(key string, value interface{}, expectedType string) error {
+	kind := reflect.TypeOf(value).Kind()
+	switch expectedType {
+	case "string":
+		if kind != reflect.String {
+			return fmt.Errorf("field '%s' must be a string, got %s", key, kind)
+		}
+	case "int":
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		case reflect.Float64:
+			if f := value.(float64); f != float64(int64(f)) {
+				return fmt.Errorf("field '%s' must be an integer, got float with decimal places", key)
+			}
+		default:
+			return fmt.Errorf("field '%s' must be an integer, got %s", key, kind)
+		}
+	case "float":
+		switch kind {
+		case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		default:
+			return fmt.Errorf("field '%s' must be a float, got %s", key, kind)
+		}
+	case "bool":
+		if kind != reflect.Bool {
+			return fmt.Errorf("field '%s' must be a boolean, got %s", key, kind)
+		}
+	case "duration":
+		if d, ok := value.(string); ok {
+			if _, err := time.ParseDuration(d); err != nil {
+				return fmt.Errorf("field '%s' must be a valid duration string, got '%v'", key, value)
+			}
+		} else if _, ok := value.(time.Duration); !ok {
+			return fmt.Errorf("field '%s' must be a duration, got %s", key, kind)
+		}
+	case "[]string":
+		switch v := value.(type) {
+		case []string:
+		case []interface{}:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return fmt.Errorf("field '%s' must be a slice of strings", key)
+				}
+			}
+		default:
+			return fmt.Errorf("field '%s' must be a slice of strings, got %s", key, kind)
+		}
+	default:
+		return fmt.Errorf("unknown validation type: %s", expectedType)
+	}
+	return nil
+}
+
+// checkSchemaBounds checks numeric bounds and string/slice lengths; bounds
+// and values of any numeric type are compared by value
+func checkSchemaBounds(key string, value, min, max interface{}) error {
+	measure, what := 0.0, "value"
+	if number, ok := toFloat(value); ok {
+		measure = number
+	} else if v := reflect.ValueOf(value); v.Kind() == reflect.String || v.Kind() == reflect.Slice {
+		measure, what = float64(v.Len()), "length"
+	} else {
+		return nil
+	}
+
+	if bound, ok := toFloat(min); ok && measure < bound {
+		return fmt.Errorf("field '%s' %s %g is less than minimum %g", key, what, measure, bound)
+	}
+	if bound, ok := toFloat(max); ok && measure > bound {
+		return fmt.Errorf("field '%s' %s %g is greater than maximum %g", key, what, measure, bound)
+	}
+	return nil
+}
+
+// enumContains reports whether value equals one of the allowed values;
+// numbers compare by value regardless of their type
+func enumContains(allowed []interface{}, value interface{}) bool {
+	for _, candidate := range allowed {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+		a, aOK := toFloat(candidate)
+		b, bOK := toFloat(value)
+		if aOK && bOK && a == b {
+			return true
+		}
+	}
+	return false
+}
+
+// toFloat converts numeric values to float64
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// formatEnum formats allowed values for messages and comments
+func formatEnum(allowed []interface{}) string {
+	values := make([]string, len(allowed))
+	for i, value := range allowed {
+		if encoded, err := encodeTOMLValue(value); err == nil {
+			values[i] = encoded
+		} else {
+			values[i] = fmt.Sprint(value)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// ===============================
+// Example Generation
+// ===============================
+
+// GenerateExample writes a commented example configuration in TOML format.
+// Each key is preceded by its description and constraints and set to its
+// default or example value; optional keys without either are commented
+// out. Deprecated keys are omitted.
+func (s *ConfigSchema) GenerateExample(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	if s.Name != "" {
+		fmt.Fprintf(out, "# %s configuration\n", s.Name)
+	}
+	fmt.Fprintln(out, "# Generated from the configuration schema.")
+
+	// Group fields by section; root keys must precede all tables
+	var sections []string
+	bySection := make(map[string][]SchemaField)
+	for _, field := range s.fields {
+		if field.Deprecated != "" {
+			continue
+		}
+		section := ""
+		if i := strings.LastIndex(field.Key, "."); i >= 0 {
+			section = field.Key[:i]
+		}
+		if _, exists := bySection[section]; !exists && section != "" {
+			sections = append(sections, section)
+		}
+		bySection[section] = append(bySection[section], field)
+	}
+	sections = append([]string{""}, sections...)
+
+	for _, section := range sections {
+		fields := bySection[section]
+		if len(fields) == 0 {
+			continue
+		}
+		if section != "" {
+			fmt.Fprintf(out, "\n[%s]\n", encodeTOMLKey(section))
+		}
+		for _, field := range fields {
+			if err := writeExampleField(out, field, section); err != nil {
+				return mdwerror.Wrap(err, "failed to generate example config").
+					WithCode(mdwerror.CodeConfigError).
+					WithOperation("config.GenerateExample").
+					WithDetail("key", field.Key)
+			}
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return mdwerror.Wrap(err, "failed to write example config").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.GenerateExample")
+	}
+	return nil
+}
+
+// writeExampleField writes the comment and assignment of one field
+func writeExampleField(out *bufio.Writer, field SchemaField, section string) error {
+	fmt.Fprintln(out)
+	for _, line := range strings.Split(strings.TrimSpace(field.Description), "\n") {
+		if line != "" {
+			fmt.Fprintf(out, "# %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	var constraints []string
+	if field.Type != "" {
+		constraints = append(constraints, "type: "+field.Type)
+	}
+	if field.Required {
+		constraints = append(constraints, "required")
+	}
+	if field.Min != nil {
+		constraints = append(constraints, fmt.Sprintf("min: %v", field.Min))
+	}
+	if field.Max != nil {
+		constraints = append(constraints, fmt.Sprintf("max: %v", field.Max))
+	}
+	if len(field.Enum) > 0 {
+		constraints = append(constraints, "one of: "+formatEnum(field.Enum))
+	}
+	if field.Pattern != "" {
+		constraints = append(constraints, "pattern: "+field.Pattern)
+	}
+	if len(constraints) > 0 {
+		fmt.Fprintf(out, "# (%s)\n", strings.Join(constraints, ", "))
+	}
+
+	value := field.Default
+	if value == nil {
+		value = field.Example
+	}
+	prefix := ""
+	if value == nil {
+		value = typePlaceholder(field.Type)
+		if !field.Required {
+			prefix = "# "
+		}
+	}
+	encoded, err := encodeTOMLValue(value)
+	if err != nil {
+		return err
+	}
+
+	key := strings.TrimPrefix(field.Key, section+".")
+	if section == "" {
+		key = field.Key
+	}
+	fmt.Fprintf(out, "%s%s = %s\n", prefix, encodeTOMLKey(key), encoded)
+	return nil
+}
+
+// typePlaceholder returns the zero value written for keys without value
+func typePlaceholder(fieldType string) interface{} {
+	switch fieldType {
+	case "int":
+		return 0
+	case "float":
+		return 0.0
+	case "bool":
+		return false
+	case "duration":
+		return "0s"
+	case "[]string":
+		return []string{}
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,165 @@
+// File: schema_test.go
+// Title: Configuration Schema Tests
+// Description: Tests schema validation at load time, defaults, deprecations,
+//              strict mode, and example generation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial schema tests
+
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// testSchema describes a small service configuration
+func testSchema() *ConfigSchema {
+	return NewSchema("turing").
+		Field(SchemaField{Key: "name", Type: "string", Description: "Service name", Default: "turing"}).
+		Field(SchemaField{Key: "server.port", Type: "int", Description: "Listen port", Required: true, Min: 1, Max: 65535, Example: 9090}).
+		Field(SchemaField{Key: "server.timeout", Type: "duration", Default: "30s"}).
+		Field(SchemaField{Key: "logging.level", Type: "string", Enum: []interface{}{"debug", "info", "warn"}, Default: "info"}).
+		Field(SchemaField{Key: "logging.ratio", Type: "float", Min: 0, Max: 1}).
+		Field(SchemaField{Key: "logging.tags", Type: "[]string", Description: "Tags added to each entry"}).
+		Field(SchemaField{Key: "server.address", Type: "string", Deprecated: "split into host and port", ReplacedBy: "server.host"}).
+		Field(SchemaField{Key: "server.host", Type: "string", Pattern: `^[a-z0-9.:-]+$`})
+}
+
+func TestLoad_Schema(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", "[server]\nport = 8080\naddress = \"0.0.0.0\"\n\n[logging]\nratio = 1\n")
+	cfg, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Schema: testSchema()})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	if cfg.GetString("name") != "turing" || cfg.GetString("logging.level") != "info" || cfg.GetString("server.timeout") != "30s" {
+		t.Errorf("defaults not applied: %v", cfg.GetAll())
+	}
+	if cfg.GetString("server.host") != "0.0.0.0" {
+		t.Errorf("deprecated key not migrated: %v", cfg.GetAll())
+	}
+
+	result := cfg.ValidateSchema()
+	if !result.Valid || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "use 'server.host'") {
+		t.Errorf("ValidateSchema() = %+v", result)
+	}
+	if cfg.Schema() == nil {
+		t.Error("Schema() = nil")
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing required", "[logging]\nlevel = \"info\"\n", "required field 'server.port'"},
+		{"wrong type", "[server]\nport = \"80\"\n", "must be an integer"},
+		{"out of range", "[server]\nport = 70000\n", "greater than maximum 65535"},
+		{"float below min", "[server]\nport = 80\n[logging]\nratio = -0.5\n", "less than minimum 0"},
+		{"not in enum", "[server]\nport = 80\n[logging]\nlevel = \"trace\"\n", `not one of "debug", "info", "warn"`},
+		{"pattern", "[server]\nport = 80\nhost = \"Bad Host\"\n", "does not match pattern"},
+		{"invalid duration", "[server]\nport = 80\ntimeout = \"soon\"\n", "valid duration"},
+		{"mixed slice", "[server]\nport = 80\n[logging]\ntags = [\"a\", 1]\n", "slice of strings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, "config.toml", tt.content)
+			_, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Schema: testSchema()})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadWithOptions() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchema_Strict(t *testing.T) {
+	schema := testSchema()
+	schema.Strict = true
+
+	cfg, err := LoadFromString("[server]\nport = 80\ntypo = 1\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	result := schema.Validate(cfg)
+	if result.Valid || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "'server.typo' is not defined") {
+		t.Errorf("Validate() = %+v", result)
+	}
+}
+
+func TestSchema_RejectsInvalidReload(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", "[server]\nport = 80\n")
+	cfg, err := LoadWithOptions(path, LoadOptions{Format: FormatAuto, Schema: testSchema()})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	if err := cfg.applyUpdate(map[string]interface{}{"server": map[string]interface{}{"port": 0}}, cfg.lastModified); err == nil {
+		t.Error("applyUpdate() with invalid data succeeded")
+	}
+	if cfg.GetInt("server.port") != 80 {
+		t.Errorf("server.port = %d after rejected update", cfg.GetInt("server.port"))
+	}
+}
+
+func TestSchema_GenerateExample(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSchema().GenerateExample(&buf); err != nil {
+		t.Fatalf("GenerateExample() error = %v", err)
+	}
+	const expected = `# turing configuration
+# Generated from the configuration schema.
+
+# Service name
+# (type: string)
+name = "turing"
+
+[server]
+
+# Listen port
+# (type: int, required, min: 1, max: 65535)
+port = 9090
+
+# (type: duration)
+timeout = "30s"
+
+# (type: string, pattern: ^[a-z0-9.:-]+$)
+# host = ""
+
+[logging]
+
+# (type: string, one of: "debug", "info", "warn")
+level = "info"
+
+# (type: float, min: 0, max: 1)
+# ratio = 0.0
+
+# Tags added to each entry
+# (type: []string)
+# tags = []
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("GenerateExample() =\n%s\nwant:\n%s", got, expected)
+	}
+
+	// The example is a valid configuration for the schema
+	cfg, err := LoadFromString(buf.String(), FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() of example error = %v", err)
+	}
+	if result := testSchema().Validate(cfg); !result.Valid {
+		t.Errorf("example does not validate: %v", result.Errors)
+	}
+
+	plain, _ := LoadFromString("", FormatTOML)
+	if err := plain.GenerateExample(&buf); err == nil {
+		t.Error("GenerateExample() without schema succeeded")
+	}
+}
@@ -0,0 +1,300 @@
+// File: secrets.go
+// Title: Configuration Secret Resolution
+// Description: Resolves secret references such as ${secret:vault/db#password},
+//              ${file:/run/secrets/db_pass}, and ${env:DB_PASS} in configuration
+//              values at load time through pluggable secret providers. Values
+//              can be stored encrypted at rest as ${enc:...} references, and
+//              resolved secrets are tracked so dumps can redact them.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of secret references and redaction
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// Secret reference schemes
+const (
+	SecretSchemeEnv       = "env"    // ${env:VAR} - environment variable
+	SecretSchemeFile      = "file"   // ${file:/run/secrets/name} - file content
+	SecretSchemeSecret    = "secret" // ${secret:path#key} - external secret store
+	SecretSchemeEncrypted = "enc"    // ${enc:base64} - value encrypted at rest
+)
+
+// RedactedValue replaces secret values in redacted output
+const RedactedValue = "[REDACTED]"
+
+// secretRefPattern matches secret references of the form ${scheme:reference}
+var secretRefPattern = regexp.MustCompile(`\$\{([a-z][a-z0-9_-]*):([^}]*)\}`)
+
+// SecretProvider resolves the reference part of a secret reference, e.g.
+// "vault/db#password" for ${secret:vault/db#password}, to the secret value
+type SecretProvider interface {
+	Resolve(ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to a SecretProvider
+type SecretProviderFunc func(ref string) (string, error)
+
+// Resolve implements SecretProvider
+func (f SecretProviderFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// ===============================
+// Built-in Providers
+// ===============================
+
+// EnvSecretProvider resolves references to environment variables
+func EnvSecretProvider() SecretProvider {
+	return SecretProviderFunc(func(ref string) (string, error) {
+		value, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", ref)
+		}
+		return value, nil
+	})
+}
+
+// FileSecretProvider resolves references to file contents, e.g. Docker or
+// Kubernetes secrets. Trailing line breaks are removed.
+func FileSecretProvider() SecretProvider {
+	return SecretProviderFunc(func(ref string) (string, error) {
+		content, err := os.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file %s: %w", ref, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	})
+}
+
+// EncryptedSecretProvider decrypts values encrypted with EncryptSecret using
+// AES-GCM. The key must be 16, 24, or 32 bytes long.
+func EncryptedSecretProvider(key []byte) SecretProvider {
+	return SecretProviderFunc(func(ref string) (string, error) {
+		gcm, err := newGCM(key)
+		if err != nil {
+			return "", err
+		}
+		data, err := base64.StdEncoding.DecodeString(ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+		}
+		if len(data) < gcm.NonceSize() {
+			return "", fmt.Errorf("encrypted value is too short")
+		}
+		nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+		plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt value: %w", err)
+		}
+		return string(plaintext), nil
+	})
+}
+
+// EncryptSecret encrypts plaintext with AES-GCM and returns an ${enc:...}
+// reference that can be stored in configuration files and is resolved by
+// EncryptedSecretProvider with the same key
+func EncryptSecret(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return fmt.Sprintf("${%s:%s}", SecretSchemeEncrypted, base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// newGCM creates an AES-GCM cipher for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// defaultSecretProviders returns the built-in providers merged with custom
+// providers; custom providers take precedence
+func defaultSecretProviders(custom map[string]SecretProvider) map[string]SecretProvider {
+	providers := map[string]SecretProvider{
+		SecretSchemeEnv:  EnvSecretProvider(),
+		SecretSchemeFile: FileSecretProvider(),
+	}
+	for scheme, provider := range custom {
+		providers[scheme] = provider
+	}
+	return providers
+}
+
+// ===============================
+// Resolution
+// ===============================
+
+// resolveSecrets replaces secret references in data in place and returns
+// the keys (dot notation) whose values contained secrets
+func resolveSecrets(data map[string]interface{}, providers map[string]SecretProvider) (map[string]bool, error) {
+	secretKeys := make(map[string]bool)
+	if err := resolveSecretsIn(data, "", providers, secretKeys); err != nil {
+		return nil, err
+	}
+	return secretKeys, nil
+}
+
+// resolveSecretsIn resolves the references of one configuration section
+func resolveSecretsIn(section map[string]interface{}, prefix string, providers map[string]SecretProvider, secretKeys map[string]bool) error {
+	for key, value := range section {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case string:
+			resolved, found, err := resolveSecretString(path, v, providers)
+			if err != nil {
+				return err
+			}
+			if found {
+				section[key] = resolved
+				secretKeys[path] = true
+			}
+		case map[string]interface{}:
+			if err := resolveSecretsIn(v, path, providers, secretKeys); err != nil {
+				return err
+			}
+		case []interface{}:
+			for i, item := range v {
+				str, ok := item.(string)
+				if !ok {
+					continue
+				}
+				resolved, found, err := resolveSecretString(path, str, providers)
+				if err != nil {
+					return err
+				}
+				if found {
+					v[i] = resolved
+					secretKeys[path] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSecretString resolves all secret references within value
+func resolveSecretString(key, value string, providers map[string]SecretProvider) (string, bool, error) {
+	if !strings.Contains(value, "${") {
+		return value, false, nil
+	}
+
+	var resolveErr error
+	found := false
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		parts := secretRefPattern.FindStringSubmatch(match)
+		scheme, ref := parts[1], parts[2]
+
+		provider, ok := providers[scheme]
+		if !ok {
+			resolveErr = mdwerror.New(fmt.Sprintf("no secret provider registered for scheme '%s'", scheme)).
+				WithCode(mdwerror.CodeConfigError).
+				WithOperation("config.resolveSecrets").
+				WithDetail("key", key).
+				WithDetail("scheme", scheme)
+			return match
+		}
+		secret, err := provider.Resolve(ref)
+		if err != nil {
+			resolveErr = mdwerror.Wrap(err, fmt.Sprintf("failed to resolve secret for key '%s'", key)).
+				WithCode(mdwerror.CodeConfigError).
+				WithOperation("config.resolveSecrets").
+				WithDetail("key", key).
+				WithDetail("scheme", scheme)
+			return match
+		}
+		found = true
+		return secret
+	})
+	if resolveErr != nil {
+		return "", false, resolveErr
+	}
+	return resolved, found, nil
+}
+
+// ===============================
+// Redaction
+// ===============================
+
+// IsSecret reports whether the value of key was resolved from a secret reference
+func (c *Config) IsSecret(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.secretKeys[key]
+}
+
+// SecretKeys returns the sorted keys whose values were resolved from secrets
+func (c *Config) SecretKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.secretKeys))
+	for key := range c.secretKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Redacted returns all configuration data like GetAll, with secret values
+// replaced by RedactedValue, for logging and diagnostic dumps
+func (c *Config) Redacted() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	data := c.deepCopyMap(c.data)
+	redactSecrets(data, "", c.secretKeys)
+	return data
+}
+
+// redactSecrets replaces secret values of a configuration section in place
+func redactSecrets(section map[string]interface{}, prefix string, secretKeys map[string]bool) {
+	if len(secretKeys) == 0 {
+		return
+	}
+	for key, value := range section {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if secretKeys[path] {
+			section[key] = RedactedValue
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			redactSecrets(nested, path, secretKeys)
+		}
+	}
+}
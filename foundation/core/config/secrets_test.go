@@ -0,0 +1,153 @@
+// File: secrets_test.go
+// Title: Configuration Secret Resolution Tests
+// Description: Tests secret references resolved through the built-in and
+//              custom providers, encrypted values, error handling, and
+//              redaction of secret values.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial secret resolution tests
+// - 2026-10-16 v0.1.1: Escaped key references now that ${key} is interpolated
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretResolution(t *testing.T) {
+	tempDir := t.TempDir()
+	secretFile := filepath.Join(tempDir, "db_pass")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	t.Setenv("TEST_API_TOKEN", "env-secret")
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted, err := EncryptSecret(key, "encrypted-secret")
+	if err != nil {
+		t.Fatalf("EncryptSecret() error = %v", err)
+	}
+
+	vault := SecretProviderFunc(func(ref string) (string, error) {
+		if ref == "kv/db#user" {
+			return "vault-user", nil
+		}
+		return "", fmt.Errorf("secret %s not found", ref)
+	})
+
+	configPath := filepath.Join(tempDir, "app.toml")
+	content := fmt.Sprintf(`
+[database]
+host = "localhost"
+user = "${secret:kv/db#user}"
+password = "${file:%s}"
+dsn = "postgres://${secret:kv/db#user}@localhost/app"
+
+[api]
+token = "${env:TEST_API_TOKEN}"
+signing_key = "%s"
+`, filepath.ToSlash(secretFile), encrypted)
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadWithOptions(configPath, LoadOptions{
+		SecretProviders: map[string]SecretProvider{
+			SecretSchemeSecret:    vault,
+			SecretSchemeEncrypted: EncryptedSecretProvider(key),
+		},
+	})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"database.user":     "vault-user",
+		"database.password": "file-secret",
+		"database.dsn":      "postgres://vault-user@localhost/app",
+		"api.token":         "env-secret",
+		"api.signing_key":   "encrypted-secret",
+		"database.host":     "localhost",
+	}
+	for key, want := range expected {
+		if got := cfg.GetString(key); got != want {
+			t.Errorf("GetString(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	if cfg.IsSecret("database.host") || !cfg.IsSecret("database.password") || len(cfg.SecretKeys()) != 5 {
+		t.Errorf("SecretKeys() = %v", cfg.SecretKeys())
+	}
+
+	redacted := cfg.Redacted()
+	database := redacted["database"].(map[string]interface{})
+	if database["password"] != RedactedValue || database["host"] != "localhost" {
+		t.Errorf("Redacted() database = %v", database)
+	}
+	if cfg.GetString("database.password") != "file-secret" {
+		t.Error("Redacted() modified the configuration")
+	}
+	if s := cfg.String(); !strings.Contains(s, "secrets: 5") || strings.Contains(s, "file-secret") {
+		t.Errorf("String() = %s", s)
+	}
+	if clone := cfg.WithRequestID("req-1"); !clone.IsSecret("api.token") {
+		t.Error("clone lost secret tracking")
+	}
+}
+
+func TestSecretResolutionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown scheme", `password = "${secret:kv/db#password}"`},
+		{"missing env", `password = "${env:MDW_TEST_UNSET_VARIABLE}"`},
+		{"missing file", `password = "${file:/nonexistent/mdw/secret}"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadFromString(tt.content, FormatTOML); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	// Values without secret references are not secrets
+	cfg, err := LoadFromString(`template = "$${name}"`+"\n"+`price = "$5"`, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	if cfg.GetString("template") != "${name}" || len(cfg.SecretKeys()) != 0 {
+		t.Errorf("template = %q, secrets = %v", cfg.GetString("template"), cfg.SecretKeys())
+	}
+}
+
+func TestEncryptedSecretProvider(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	ref, err := EncryptSecret(key, "s3cret")
+	if err != nil {
+		t.Fatalf("EncryptSecret() error = %v", err)
+	}
+	if !strings.HasPrefix(ref, "${enc:") || strings.Contains(ref, "s3cret") {
+		t.Errorf("EncryptSecret() = %s", ref)
+	}
+
+	ciphertext := strings.TrimSuffix(strings.TrimPrefix(ref, "${enc:"), "}")
+	if plain, err := EncryptedSecretProvider(key).Resolve(ciphertext); err != nil || plain != "s3cret" {
+		t.Errorf("Resolve() = %q, %v", plain, err)
+	}
+	if _, err := EncryptedSecretProvider([]byte("fedcba9876543210")).Resolve(ciphertext); err == nil {
+		t.Error("Resolve() with wrong key succeeded")
+	}
+	if _, err := EncryptSecret([]byte("short"), "x"); err == nil {
+		t.Error("EncryptSecret() accepted invalid key length")
+	}
+}
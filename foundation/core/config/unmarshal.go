@@ -0,0 +1,586 @@
+// File: unmarshal.go
+// Title: Typed Configuration Unmarshalling
+// Description: Populates Go structs from configuration data. Honors config
+//              tags, validate tags (required, min, max, in, ip), nested
+//              structs, slices, and maps, converts durations, times, and
+//              mathx.Decimal values, applies environment overrides, and calls
+//              a validation hook on structs implementing Validatable.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Unmarshal and UnmarshalKey
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	"github.com/msto63/mDW/foundation/utils/mathx"
+)
+
+// Validatable is implemented by configuration structs that validate
+// themselves after unmarshalling. Validate is called for the target and for
+// every nested struct, innermost first.
+type Validatable interface {
+	Validate() error
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	decimalType         = reflect.TypeOf(mathx.Decimal{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	validatableType     = reflect.TypeOf((*Validatable)(nil)).Elem()
+)
+
+// Unmarshal populates target, a pointer to a struct, from the whole
+// configuration
+func (c *Config) Unmarshal(target interface{}) error {
+	return c.unmarshal("", target, "config.Unmarshal")
+}
+
+// UnmarshalKey populates target from the configuration section or value at
+// key, e.g. UnmarshalKey("database", &dbConfig)
+func (c *Config) UnmarshalKey(key string, target interface{}) error {
+	return c.unmarshal(key, target, "config.UnmarshalKey")
+}
+
+// unmarshal decodes the data at key into target and runs the validation hooks
+func (c *Config) unmarshal(key string, target interface{}, operation string) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return mdwerror.New("unmarshal target must be a non-nil pointer").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation(operation)
+	}
+
+	var hooks []Validatable
+	err := func() error {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+
+		var raw interface{} = c.data
+		if key != "" {
+			raw = c.getValue(key)
+			if raw == nil {
+				return mdwerror.New(fmt.Sprintf("configuration key '%s' not found", key)).
+					WithCode(mdwerror.CodeNotFound).
+					WithOperation(operation).
+					WithDetail("key", key)
+			}
+		}
+
+		decoder := &decoder{config: c}
+		if err := decoder.decode(key, raw, targetValue.Elem()); err != nil {
+			return err
+		}
+		hooks = decoder.hooks
+		return nil
+	}()
+	if err != nil {
+		if _, ok := err.(*mdwerror.Error); ok {
+			return err
+		}
+		return mdwerror.Wrap(err, "failed to unmarshal configuration").
+			WithCode(mdwerror.CodeInvalidConfig).
+			WithOperation(operation).
+			WithDetail("key", key)
+	}
+
+	// Run validation hooks without holding the lock
+	for _, hook := range hooks {
+		if err := hook.Validate(); err != nil {
+			return mdwerror.Wrap(err, "configuration validation failed").
+				WithCode(mdwerror.CodeValidationFailed).
+				WithOperation(operation).
+				WithDetail("key", key)
+		}
+	}
+	return nil
+}
+
+// decoder converts configuration data into Go values
+type decoder struct {
+	config *Config
+	hooks  []Validatable
+}
+
+// decode converts raw into target; path is the configuration key of raw
+func (d *decoder) decode(path string, raw interface{}, target reflect.Value) error {
+	// Environment variables override scalar values
+	if path != "" && !isSection(raw) && !isComposite(target.Type()) {
+		if envValue := d.config.getEnvValue(path); envValue != "" {
+			raw = envValue
+		}
+	}
+	if raw == nil {
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return d.decode(path, raw, target.Elem())
+	}
+
+	// Special types
+	switch target.Type() {
+	case durationType:
+		return decodeDuration(path, raw, target)
+	case timeType:
+		return decodeTime(path, raw, target)
+	case decimalType:
+		return decodeDecimal(path, raw, target)
+	}
+	if str, ok := raw.(string); ok && reflect.PointerTo(target.Type()).Implements(textUnmarshalerType) {
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+			return fmt.Errorf("field '%s': %w", path, err)
+		}
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return d.decodeStruct(path, raw, target)
+	case reflect.Map:
+		return d.decodeMap(path, raw, target)
+	case reflect.Slice:
+		return d.decodeSlice(path, raw, target)
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(raw))
+		return nil
+	case reflect.String:
+		if str, ok := raw.(string); ok {
+			target.SetString(str)
+		} else {
+			target.SetString(fmt.Sprintf("%v", raw))
+		}
+		return nil
+	case reflect.Bool:
+		switch v := raw.(type) {
+		case bool:
+			target.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("field '%s': cannot convert '%v' to boolean", path, v)
+			}
+			target.SetBool(b)
+		default:
+			return fmt.Errorf("field '%s': cannot convert '%v' to boolean", path, raw)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := toInt64(raw)
+		if err != nil || target.OverflowInt(i) {
+			return fmt.Errorf("field '%s': cannot convert '%v' to %s", path, raw, target.Type())
+		}
+		target.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := toInt64(raw)
+		if err != nil || i < 0 || target.OverflowUint(uint64(i)) {
+			return fmt.Errorf("field '%s': cannot convert '%v' to %s", path, raw, target.Type())
+		}
+		target.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(raw)
+		if err != nil || target.OverflowFloat(f) {
+			return fmt.Errorf("field '%s': cannot convert '%v' to %s", path, raw, target.Type())
+		}
+		target.SetFloat(f)
+		return nil
+	default:
+		return fmt.Errorf("field '%s': unsupported field type %s", path, target.Type())
+	}
+}
+
+// decodeStruct populates a struct from a configuration section
+func (d *decoder) decodeStruct(path string, raw interface{}, target reflect.Value) error {
+	section, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("field '%s': expected a section, got %T", path, raw)
+	}
+
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		fieldType := targetType.Field(i)
+		field := target.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		configKey := fieldType.Tag.Get("config")
+		if configKey == "-" {
+			continue
+		}
+
+		// Embedded structs without a config tag share the section
+		if fieldType.Anonymous && configKey == "" && fieldType.Type.Kind() == reflect.Struct {
+			if err := d.decodeStruct(path, section, field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if configKey == "" {
+			configKey = strings.ToLower(fieldType.Name)
+		}
+		fieldPath := joinKey(path, configKey)
+
+		value, exists := section[configKey]
+		if !exists && d.config.getEnvValue(fieldPath) == "" || exists && value == nil {
+			if err := checkRequired(fieldPath, fieldType.Tag.Get("validate")); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.decode(fieldPath, value, field); err != nil {
+			return err
+		}
+		if err := validateTag(fieldPath, field, fieldType.Tag.Get("validate")); err != nil {
+			return err
+		}
+	}
+
+	if target.CanAddr() && target.Addr().Type().Implements(validatableType) {
+		d.hooks = append(d.hooks, target.Addr().Interface().(Validatable))
+	}
+	return nil
+}
+
+// decodeMap populates a map with string keys from a configuration section
+func (d *decoder) decodeMap(path string, raw interface{}, target reflect.Value) error {
+	section, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("field '%s': expected a section, got %T", path, raw)
+	}
+	if target.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("field '%s': map keys must be strings", path)
+	}
+
+	if target.IsNil() {
+		target.Set(reflect.MakeMapWithSize(target.Type(), len(section)))
+	}
+	for key, value := range section {
+		elem := reflect.New(target.Type().Elem()).Elem()
+		if err := d.decode(joinKey(path, key), value, elem); err != nil {
+			return err
+		}
+		target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elem)
+	}
+	return nil
+}
+
+// decodeSlice populates a slice from a configuration array; single values
+// become one-element slices and strings from the environment are split at commas
+func (d *decoder) decodeSlice(path string, raw interface{}, target reflect.Value) error {
+	rawValue := reflect.ValueOf(raw)
+	if rawValue.Kind() != reflect.Slice {
+		if str, ok := raw.(string); ok && target.Type().Elem().Kind() != reflect.Uint8 {
+			parts := strings.Split(str, ",")
+			items := make([]interface{}, len(parts))
+			for i, part := range parts {
+				items[i] = strings.TrimSpace(part)
+			}
+			rawValue = reflect.ValueOf(items)
+		} else {
+			rawValue = reflect.ValueOf([]interface{}{raw})
+		}
+	}
+
+	slice := reflect.MakeSlice(target.Type(), rawValue.Len(), rawValue.Len())
+	for i := 0; i < rawValue.Len(); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		if err := d.decode(elemPath, rawValue.Index(i).Interface(), slice.Index(i)); err != nil {
+			return err
+		}
+	}
+	target.Set(slice)
+	return nil
+}
+
+// ===============================
+// Value Conversion
+// ===============================
+
+// decodeDuration converts strings ("30s") and integers (nanoseconds) to durations
+func decodeDuration(path string, raw interface{}, target reflect.Value) error {
+	switch v := raw.(type) {
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("field '%s': invalid duration '%s'", path, v)
+		}
+		target.SetInt(int64(duration))
+	case time.Duration:
+		target.SetInt(int64(v))
+	default:
+		i, err := toInt64(raw)
+		if err != nil {
+			return fmt.Errorf("field '%s': cannot convert '%v' to duration", path, raw)
+		}
+		target.SetInt(i)
+	}
+	return nil
+}
+
+// decodeTime converts TOML datetimes and RFC 3339 strings to time.Time
+func decodeTime(path string, raw interface{}, target reflect.Value) error {
+	switch v := raw.(type) {
+	case time.Time:
+		target.Set(reflect.ValueOf(v))
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("field '%s': invalid time '%s'", path, v)
+		}
+		target.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("field '%s': cannot convert '%v' to time", path, raw)
+	}
+	return nil
+}
+
+// decodeDecimal converts strings and numbers to mathx.Decimal; use strings
+// in configuration files to avoid floating point rounding
+func decodeDecimal(path string, raw interface{}, target reflect.Value) error {
+	var decimal mathx.Decimal
+	switch v := raw.(type) {
+	case string:
+		parsed, err := mathx.NewDecimal(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", path, err)
+		}
+		decimal = parsed
+	case float64:
+		decimal = mathx.NewDecimalFromFloat(v)
+	default:
+		i, err := toInt64(raw)
+		if err != nil {
+			return fmt.Errorf("field '%s': cannot convert '%v' to decimal", path, raw)
+		}
+		decimal = mathx.NewDecimalFromInt(i)
+	}
+	target.Set(reflect.ValueOf(decimal))
+	return nil
+}
+
+// toInt64 converts integer values, whole floats, and numeric strings to int64
+func toInt64(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64", v)
+		}
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("value %g is not a whole number", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	}
+	return 0, fmt.Errorf("value %v is not an integer", raw)
+}
+
+// toFloat64 converts numbers and numeric strings to float64
+func toFloat64(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	i, err := toInt64(raw)
+	return float64(i), err
+}
+
+// isSection reports whether raw is a configuration section or array
+func isSection(raw interface{}) bool {
+	switch raw.(type) {
+	case map[string]interface{}, []interface{}, []map[string]interface{}:
+		return true
+	}
+	return false
+}
+
+// isComposite reports whether values of t are decoded from sections
+func isComposite(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType, decimalType:
+		return false
+	}
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Map
+}
+
+// joinKey appends key to a dot-notation path
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// ===============================
+// Validate Tags
+// ===============================
+
+// validateRule is a single rule of a validate tag, e.g. "min:1"
+type validateRule struct {
+	name string
+	args []string
+}
+
+// parseValidateTag splits a validate tag such as "required,min:1,max:10" or
+// "in:json,text". The in rule consumes all following values without a rule
+// name.
+func parseValidateTag(tag string) []validateRule {
+	var rules []validateRule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, hasArg := strings.Cut(part, ":")
+		if !hasArg && len(rules) > 0 && rules[len(rules)-1].name == "in" && !isValidateRuleName(name) {
+			rules[len(rules)-1].args = append(rules[len(rules)-1].args, part)
+			continue
+		}
+		rule := validateRule{name: name}
+		if hasArg {
+			rule.args = []string{arg}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// isValidateRuleName reports whether name is a supported rule without arguments
+func isValidateRuleName(name string) bool {
+	return name == "required" || name == "ip"
+}
+
+// checkRequired reports an error if a missing field is required
+func checkRequired(path, tag string) error {
+	for _, rule := range parseValidateTag(tag) {
+		if rule.name == "required" {
+			return mdwerror.New(fmt.Sprintf("required field '%s' not found in configuration", path)).
+				WithCode(mdwerror.CodeValidationFailed).
+				WithOperation("config.Unmarshal").
+				WithDetail("configKey", path)
+		}
+	}
+	return nil
+}
+
+// validateTag checks a decoded field against its validate tag. Min and max
+// apply to numeric values and to the length of strings, slices, and maps.
+func validateTag(path string, field reflect.Value, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+
+	for _, rule := range parseValidateTag(tag) {
+		var problem string
+		switch rule.name {
+		case "required":
+			if field.IsZero() {
+				problem = "is required"
+			}
+		case "min", "max":
+			if len(rule.args) != 1 {
+				return fmt.Errorf("field '%s': %s rule requires a value", path, rule.name)
+			}
+			limit, err := strconv.ParseFloat(rule.args[0], 64)
+			if err != nil {
+				return fmt.Errorf("field '%s': invalid %s value '%s'", path, rule.name, rule.args[0])
+			}
+			value, ok := measure(field)
+			if !ok {
+				return fmt.Errorf("field '%s': %s rule is not supported for %s", path, rule.name, field.Type())
+			}
+			if rule.name == "min" && value < limit {
+				problem = fmt.Sprintf("must be at least %s", rule.args[0])
+			}
+			if rule.name == "max" && value > limit {
+				problem = fmt.Sprintf("must be at most %s", rule.args[0])
+			}
+		case "in":
+			value := fmt.Sprintf("%v", field.Interface())
+			allowed := false
+			for _, option := range rule.args {
+				if value == option {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				problem = fmt.Sprintf("must be one of: %s", strings.Join(rule.args, ", "))
+			}
+		case "ip":
+			if field.Kind() != reflect.String || net.ParseIP(field.String()) == nil {
+				problem = "must be a valid IP address"
+			}
+		default:
+			return fmt.Errorf("field '%s': unknown validate rule '%s'", path, rule.name)
+		}
+
+		if problem != "" {
+			return mdwerror.New(fmt.Sprintf("field '%s' %s", path, problem)).
+				WithCode(mdwerror.CodeValidationFailed).
+				WithOperation("config.Unmarshal").
+				WithDetail("configKey", path).
+				WithDetail("rule", rule.name)
+		}
+	}
+	return nil
+}
+
+// measure returns the numeric value or length checked by min and max rules
+func measure(field reflect.Value) (float64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	case reflect.String:
+		return float64(len([]rune(field.String()))), true
+	case reflect.Slice, reflect.Map:
+		return float64(field.Len()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,210 @@
+// File: unmarshal_test.go
+// Title: Typed Configuration Unmarshalling Tests
+// Description: Tests Unmarshal and UnmarshalKey with nested structs, slices,
+//              maps, durations, decimals, environment overrides, validate
+//              tags, and validation hooks.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial unmarshal tests
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/foundation/utils/mathx"
+)
+
+// testDatabaseConfig mirrors the documented database section
+type testDatabaseConfig struct {
+	Host     string        `config:"host" validate:"required"`
+	Port     int           `config:"port" validate:"min:1,max:65535"`
+	SSL      bool          `config:"ssl"`
+	Timeout  time.Duration `config:"timeout"`
+	Replicas []string      `config:"replicas"`
+}
+
+// testUpstream is an element of an array of tables
+type testUpstream struct {
+	Name   string  `config:"name" validate:"required"`
+	Weight float64 `config:"weight"`
+}
+
+// testAppConfig mirrors the documented AppConfig pattern
+type testAppConfig struct {
+	Database testDatabaseConfig `config:"database"`
+	Logging  struct {
+		Level  string `config:"level" validate:"in:trace,debug,info,warn,error"`
+		Format string `config:"format" validate:"in:json,text,console"`
+	} `config:"logging"`
+	Billing struct {
+		VATRate mathx.Decimal `config:"vat_rate"`
+		Fee     mathx.Decimal `config:"fee"`
+	} `config:"billing"`
+	Upstreams []testUpstream      `config:"upstreams"`
+	Limits    map[string]int      `config:"limits"`
+	Features  *map[string]bool    `config:"features"`
+	Ignored   string              `config:"-"`
+	Labels    map[string][]string `config:"labels"`
+}
+
+const unmarshalTestConfig = `
+[database]
+host = "db.internal"
+port = 5432
+ssl = true
+timeout = "15s"
+replicas = ["r1", "r2"]
+
+[logging]
+level = "info"
+format = "json"
+
+[billing]
+vat_rate = "0.19"
+fee = 2
+
+[[upstreams]]
+name = "primary"
+weight = 0.7
+
+[[upstreams]]
+name = "fallback"
+weight = 0.3
+
+[limits]
+requests = 100
+burst = 20
+
+[features]
+beta = true
+
+[labels]
+team = ["core", "platform"]
+
+[grid]
+matrix = [[1, 2], [3, 4]]
+`
+
+func TestUnmarshal(t *testing.T) {
+	cfg, err := LoadFromString(unmarshalTestConfig, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	var app testAppConfig
+	if err := cfg.Unmarshal(&app); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	db := app.Database
+	if db.Host != "db.internal" || db.Port != 5432 || !db.SSL || db.Timeout != 15*time.Second || len(db.Replicas) != 2 {
+		t.Errorf("Database = %+v", db)
+	}
+	if app.Logging.Level != "info" || app.Logging.Format != "json" {
+		t.Errorf("Logging = %+v", app.Logging)
+	}
+	if app.Billing.VATRate.String() != mathx.MustNewDecimal("0.19").String() || app.Billing.Fee.String() != mathx.NewDecimalFromInt(2).String() {
+		t.Errorf("Billing = %s, %s", app.Billing.VATRate, app.Billing.Fee)
+	}
+	if len(app.Upstreams) != 2 || app.Upstreams[1].Name != "fallback" || app.Upstreams[0].Weight != 0.7 {
+		t.Errorf("Upstreams = %+v", app.Upstreams)
+	}
+	if app.Limits["burst"] != 20 || app.Features == nil || !(*app.Features)["beta"] || app.Labels["team"][1] != "platform" {
+		t.Errorf("Limits = %v, Features = %v, Labels = %v", app.Limits, app.Features, app.Labels)
+	}
+
+	var grid struct {
+		Matrix [][]int `config:"matrix"`
+	}
+	if err := cfg.UnmarshalKey("grid", &grid); err != nil || len(grid.Matrix) != 2 || grid.Matrix[1][0] != 3 {
+		t.Errorf("UnmarshalKey(grid) = %v, %v", grid.Matrix, err)
+	}
+
+	var dbOnly testDatabaseConfig
+	if err := cfg.UnmarshalKey("database", &dbOnly); err != nil || dbOnly.Host != "db.internal" {
+		t.Errorf("UnmarshalKey(database) = %+v, %v", dbOnly, err)
+	}
+	var port int
+	if err := cfg.UnmarshalKey("database.port", &port); err != nil || port != 5432 {
+		t.Errorf("UnmarshalKey(database.port) = %d, %v", port, err)
+	}
+}
+
+func TestUnmarshal_EnvOverride(t *testing.T) {
+	cfg, err := LoadFromString("[database]\nhost = \"localhost\"\nport = 5432\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	cfg.envPrefix = "MDWTEST"
+	t.Setenv("MDWTEST_DATABASE_PORT", "6432")
+	t.Setenv("MDWTEST_DATABASE_REPLICAS", "a, b")
+
+	var db testDatabaseConfig
+	if err := cfg.UnmarshalKey("database", &db); err != nil {
+		t.Fatalf("UnmarshalKey() error = %v", err)
+	}
+	if db.Port != 6432 || len(db.Replicas) != 2 || db.Replicas[1] != "b" {
+		t.Errorf("db = %+v", db)
+	}
+}
+
+// hookedConfig validates itself after unmarshalling
+type hookedConfig struct {
+	Min int `config:"min"`
+	Max int `config:"max"`
+}
+
+func (h *hookedConfig) Validate() error {
+	if h.Min > h.Max {
+		return errors.New("min must not exceed max")
+	}
+	return nil
+}
+
+func TestUnmarshal_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing required", "[database]\nport = 80\n", "required field 'database.host'"},
+		{"out of range", "[database]\nhost = \"h\"\nport = 70000\n", "must be at most 65535"},
+		{"not in set", "[logging]\nlevel = \"verbose\"\n", "must be one of"},
+		{"wrong type", "[database]\nhost = \"h\"\nport = \"eighty\"\n", "cannot convert"},
+		{"invalid duration", "[database]\nhost = \"h\"\ntimeout = \"soon\"\n", "invalid duration"},
+		{"required in slice", "[[upstreams]]\nweight = 1.0\n", "upstreams[0].name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadFromString(tt.content, FormatTOML)
+			if err != nil {
+				t.Fatalf("LoadFromString() error = %v", err)
+			}
+			var app testAppConfig
+			err = cfg.Unmarshal(&app)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Unmarshal() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg, _ := LoadFromString("[range]\nmin = 5\nmax = 1\n", FormatTOML)
+	var hooked hookedConfig
+	if err := cfg.UnmarshalKey("range", &hooked); err == nil || !strings.Contains(err.Error(), "min must not exceed max") {
+		t.Errorf("validation hook error = %v", err)
+	}
+	if err := cfg.UnmarshalKey("missing", &hooked); err == nil {
+		t.Error("UnmarshalKey() of missing key succeeded")
+	}
+	if err := cfg.Unmarshal(hooked); err == nil {
+		t.Error("Unmarshal() into non-pointer succeeded")
+	}
+}
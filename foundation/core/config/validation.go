@@ -4,12 +4,13 @@
 //              including type checking, range validation, required fields,
 //              and custom validation rules.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation of validation
+// - 2026-10-16 v0.1.1: Added warnings to ValidationResult for schema deprecations
 
 package config
 
@@ -26,8 +27,9 @@ import (
 
 // ValidationResult contains the results of configuration validation
 type ValidationResult struct {
-	Valid  bool     `json:"valid"`
-	Errors []string `json:"errors,omitempty"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // Validate validates the configuration against the provided rules
@@ -3,12 +3,18 @@
 // Description: Implements file system watching for configuration files to
 //              support hot-reloading and automatic configuration updates.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation of file watching
+// - 2026-10-16 v0.1.1: Resolved secret references on reload
+// - 2026-10-16 v0.1.2: Extracted applyUpdate for provider watches
+// - 2026-10-16 v0.1.3: Watched and reapplied profile files
+// - 2026-10-16 v0.1.4: Rejected updates that do not match the schema
+// - 2026-10-16 v0.1.5: Reloaded included files and interpolated updates
+// - 2026-10-16 v0.1.6: Notified key change subscriptions
 
 package config
 
@@ -37,8 +43,8 @@ func (c *Config) startWatching() error {
 			break
 		}
 
-		// Check if file was modified
-		fileInfo, err := os.Stat(c.filePath)
+		// Check if the file or its profile file was modified
+		modTime, err := c.sourceModTime()
 		if err != nil {
 			// File might have been deleted or moved
 			continue
@@ -48,7 +54,7 @@ func (c *Config) startWatching() error {
 		lastModified := c.lastModified
 		c.mu.RUnlock()
 
-		if fileInfo.ModTime().After(lastModified) {
+		if modTime.After(lastModified) {
 			// File was modified - reload configuration
 			if err := c.reload(); err != nil {
 				// Log error but continue watching
@@ -80,6 +86,65 @@ func (c *Config) reload() error {
 			WithDetail("format", c.format.String())
 	}
 
+	includes, err := resolveIncludes(newData, c.filePath)
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to include config files during reload").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.reload").
+			WithDetail("filePath", c.filePath)
+	}
+
+	c.mu.RLock()
+	profile := c.profile
+	c.mu.RUnlock()
+	profileIncludes, err := applyProfile(newData, c.filePath, c.format, profile)
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to apply config profile during reload").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("config.reload").
+			WithDetail("filePath", c.filePath).
+			WithDetail("profile", profile)
+	}
+
+	c.mu.Lock()
+	c.includes = append(includes, profileIncludes...)
+	c.mu.Unlock()
+	lastModified, _ := c.sourceModTime()
+
+	if err := c.applyUpdate(newData, lastModified); err != nil {
+		return mdwerror.Wrap(err, "failed to apply reloaded config").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.reload").
+			WithDetail("filePath", c.filePath)
+	}
+
+	return nil
+}
+
+// applyUpdate resolves secrets in newData, replaces the configuration data,
+// and notifies watchers. A zero lastModified keeps the previous value
+func (c *Config) applyUpdate(newData map[string]interface{}, lastModified time.Time) error {
+	if err := interpolate(newData); err != nil {
+		return mdwerror.Wrap(err, "failed to interpolate config values during update").
+			WithCode(mdwerror.CodeInvalidConfig).
+			WithOperation("config.applyUpdate")
+	}
+
+	secretKeys, err := resolveSecrets(newData, c.secretProviders)
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to resolve config secrets during update").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.applyUpdate")
+	}
+	c.mu.RLock()
+	schema := c.schema
+	c.mu.RUnlock()
+	if err := applySchema(schema, newData); err != nil {
+		return mdwerror.Wrap(err, "updated config does not match schema").
+			WithCode(mdwerror.CodeValidationFailed).
+			WithOperation("config.applyUpdate")
+	}
+
 	// Create a copy of the old configuration for comparison
 	c.mu.Lock()
 	oldConfig := &Config{
@@ -89,22 +154,26 @@ func (c *Config) reload() error {
 
 	// Update the configuration
 	c.data = newData
-	fileInfo, _ := os.Stat(c.filePath)
-	if fileInfo != nil {
-		c.lastModified = fileInfo.ModTime()
+	c.secretKeys = secretKeys
+	if !lastModified.IsZero() {
+		c.lastModified = lastModified
+	}
+
+	newConfig := &Config{
+		data:   c.deepCopyMap(c.data),
+		format: c.format,
 	}
 
 	// Get watchers (copy to avoid holding lock during callbacks)
 	watchers := make([]ChangeHandler, len(c.watchers))
 	copy(watchers, c.watchers)
+	keyWatchers := append([]*keySubscription(nil), c.keyWatchers...)
 	c.mu.Unlock()
 
-	// Notify all watchers
-	newConfig := &Config{
-		data:   c.deepCopyMap(c.data),
-		format: c.format,
-	}
+	// Notify key subscriptions of the changed keys
+	notifyKeyChanges(keyWatchers, oldConfig.data, newConfig.data)
 
+	// Notify all watchers
 	for _, handler := range watchers {
 		if handler != nil {
 			go handler(oldConfig, newConfig)
@@ -114,11 +183,15 @@ func (c *Config) reload() error {
 	return nil
 }
 
-// StopWatching stops file monitoring
+// StopWatching stops file and provider monitoring
 func (c *Config) StopWatching() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.watching = false
+	if c.stopWatch != nil {
+		c.stopWatch()
+		c.stopWatch = nil
+	}
 }
 
 // IsWatching returns whether file monitoring is active
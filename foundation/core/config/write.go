@@ -0,0 +1,806 @@
+// File: write.go
+// Title: Configuration Write-Back
+// Description: Implements saving configurations to disk. Values changed with
+//              Set are patched into the existing file, preserving comments
+//              and key order of TOML documents; files are replaced
+//              atomically so readers never observe partial writes.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Save and TOML patching
+// - 2026-10-16 v0.1.1: Included profile files in the saved modification time
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	"github.com/msto63/mDW/foundation/utils/filex"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFileMode is the permission of newly created configuration files
+const defaultFileMode os.FileMode = 0644
+
+// Save writes the configuration to path in the given format. An empty path
+// and FormatAuto refer to the file the configuration was loaded from.
+//
+// Saving to the loaded file writes only the keys changed with Set, so
+// defaults, environment overrides, and secret references stay out of the
+// file. TOML files are patched in place, preserving comments, key order, and
+// formatting; other formats, and TOML layouts that cannot be patched (such as
+// keys inside arrays of tables), are re-encoded. Saving to any other path
+// writes the complete configuration and fails if it contains resolved
+// secret values. The file is replaced atomically.
+func (c *Config) Save(path string, format Format) error {
+	c.mu.RLock()
+	if path == "" {
+		path = c.filePath
+	}
+	if format == FormatAuto {
+		if path == c.filePath {
+			format = c.format
+		} else {
+			format = detectFormat(path)
+		}
+	}
+	patch := path == c.filePath
+	updates := make(map[string]interface{}, len(c.dirty))
+	for key := range c.dirty {
+		if value := c.getValue(key); value != nil {
+			updates[key] = deepCopyValue(value)
+		}
+	}
+	data := c.deepCopyMap(c.data)
+	var unsaved []string
+	for key := range c.secretKeys {
+		if !coveredBy(key, c.dirty) {
+			unsaved = append(unsaved, key)
+		}
+	}
+	c.mu.RUnlock()
+
+	if path == "" {
+		return mdwerror.New("file path required for saving").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("config.Save")
+	}
+
+	perm := defaultFileMode
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if info, statErr := os.Stat(path); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+	case os.IsNotExist(err):
+		patch = false
+	default:
+		return mdwerror.Wrap(err, "failed to read config file for saving").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.Save").
+			WithDetail("filePath", path)
+	}
+
+	var content []byte
+	if patch {
+		content, err = patchContent(existing, format, updates)
+	} else {
+		if len(unsaved) > 0 {
+			sort.Strings(unsaved)
+			return mdwerror.New("cannot save resolved secret values").
+				WithCode(mdwerror.CodeInvalidConfig).
+				WithOperation("config.Save").
+				WithDetail("filePath", path).
+				WithDetail("secretKeys", unsaved)
+		}
+		content, err = encodeContent(data, format)
+	}
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to encode config for saving").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.Save").
+			WithDetail("filePath", path).
+			WithDetail("format", format.String())
+	}
+
+	if err := filex.WriteFileAtomic(path, content, perm); err != nil {
+		return mdwerror.Wrap(err, "failed to write config file").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("config.Save").
+			WithDetail("filePath", path)
+	}
+
+	if patch {
+		// Keep the file watcher from reloading the file just written
+		modTime, modErr := c.sourceModTime()
+		c.mu.Lock()
+		for key := range updates {
+			delete(c.dirty, key)
+		}
+		if modErr == nil {
+			c.lastModified = modTime
+		}
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// markDirty records key as changed; the caller must hold the write lock
+func (c *Config) markDirty(key string) {
+	if c.dirty == nil {
+		c.dirty = make(map[string]bool)
+	}
+	// The new value replaces changes recorded below key
+	for dirty := range c.dirty {
+		if strings.HasPrefix(dirty, key+".") {
+			delete(c.dirty, dirty)
+		}
+	}
+	c.dirty[key] = true
+}
+
+// coveredBy reports whether key or one of its parents is in keys
+func coveredBy(key string, keys map[string]bool) bool {
+	for {
+		if keys[key] {
+			return true
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return false
+		}
+		key = key[:i]
+	}
+}
+
+// deepCopyValue copies maps and slices of a configuration value
+func deepCopyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopyValue(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// ===============================
+// Encoding
+// ===============================
+
+// patchContent applies updates to an existing document. TOML documents are
+// patched textually when possible; everything else is re-encoded.
+func patchContent(content []byte, format Format, updates map[string]interface{}) ([]byte, error) {
+	if format == FormatTOML {
+		if patched, ok, err := patchTOML(string(content), updates); err != nil || ok {
+			return []byte(patched), err
+		}
+	}
+
+	data, err := parseContent(content, format)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		setNestedValue(data, key, updates[key])
+	}
+	return encodeContent(data, format)
+}
+
+// encodeContent encodes data in the given format
+func encodeContent(data map[string]interface{}, format Format) ([]byte, error) {
+	data = normalizeForEncoding(data).(map[string]interface{})
+
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(data)
+	case FormatJSON:
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case FormatTOML:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format.String())
+	}
+}
+
+// normalizeForEncoding converts durations to the strings they are read from
+func normalizeForEncoding(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeForEncoding(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeForEncoding(item)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
+
+// ===============================
+// TOML Patching
+// ===============================
+
+// tomlEntry is a key/value line of a TOML document
+type tomlEntry struct {
+	lineStart  int
+	valueStart int
+	valueEnd   int
+	lineEnd    int
+}
+
+// tomlTable is a table of a TOML document
+type tomlTable struct {
+	name   string
+	array  bool // [[array]] table; entries are not addressable
+	insert int  // Offset for new keys: after the last entry or the header
+	keys   bool // Whether the table has entries
+}
+
+// tomlLayout records the positions of tables and entries of a TOML document
+type tomlLayout struct {
+	tables      map[string]*tomlTable
+	arrays      map[string]bool
+	entries     map[string]*tomlEntry
+	prefixes    map[string]*tomlTable // Dotted key prefixes of entries
+	firstHeader int
+}
+
+// tomlEdit replaces content[start:end] with text
+type tomlEdit struct {
+	start, end int
+	text       string
+}
+
+// patchTOML applies updates to TOML content without disturbing comments or
+// unrelated lines. ok is false if the layout requires re-encoding.
+func patchTOML(content string, updates map[string]interface{}) (string, bool, error) {
+	layout, err := parseTOMLLayout(content)
+	if err != nil {
+		return "", false, err
+	}
+
+	// Flatten updates to leaf keys and remove entries of replaced tables
+	leaves := make(map[string]interface{})
+	var edits []tomlEdit
+	for key, value := range updates {
+		if table, ok := value.(map[string]interface{}); ok && len(table) > 0 {
+			flattenLeaves(key, table, leaves)
+			for entryKey, entry := range layout.entries {
+				if _, kept := leaves[entryKey]; !kept && strings.HasPrefix(entryKey, key+".") {
+					edits = append(edits, tomlEdit{entry.lineStart, entry.lineEnd, ""})
+				}
+			}
+			continue
+		}
+		leaves[key] = value
+	}
+
+	keys := make([]string, 0, len(leaves))
+	for key := range leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	inserts := make(map[*tomlTable][]string)
+	var tableOrder []*tomlTable
+	sections := make(map[string][]string)
+	var sectionOrder []string
+	for _, key := range keys {
+		encoded, err := encodeTOMLValue(leaves[key])
+		if err != nil {
+			return "", false, fmt.Errorf("key '%s': %w", key, err)
+		}
+		if entry, ok := layout.entries[key]; ok {
+			edits = append(edits, tomlEdit{entry.valueStart, entry.valueEnd, encoded})
+			continue
+		}
+		if layout.conflicts(key) {
+			return "", false, nil
+		}
+
+		table, rest := layout.tableFor(key)
+		if table == nil {
+			// New section for the parent of key
+			i := strings.LastIndex(key, ".")
+			section := key[:i]
+			if _, exists := sections[section]; !exists {
+				sectionOrder = append(sectionOrder, section)
+			}
+			sections[section] = append(sections[section], encodeTOMLKey(key[i+1:])+" = "+encoded+"\n")
+			continue
+		}
+		if _, exists := inserts[table]; !exists {
+			tableOrder = append(tableOrder, table)
+		}
+		inserts[table] = append(inserts[table], encodeTOMLKey(rest)+" = "+encoded+"\n")
+	}
+
+	for _, table := range tableOrder {
+		text := strings.Join(inserts[table], "")
+		if table.insert > 0 && content[table.insert-1] != '\n' {
+			text = "\n" + text
+		}
+		if table.name == "" && !table.keys && table.insert < len(content) {
+			text += "\n" // Separate new root keys from the first table
+		}
+		edits = append(edits, tomlEdit{table.insert, table.insert, text})
+	}
+	if len(sectionOrder) > 0 {
+		var text strings.Builder
+		if len(content) > 0 && !strings.HasSuffix(content, "\n") {
+			text.WriteString("\n")
+		}
+		for _, section := range sectionOrder {
+			if text.Len() > 0 || len(content) > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString("[" + encodeTOMLKey(section) + "]\n")
+			text.WriteString(strings.Join(sections[section], ""))
+		}
+		edits = append(edits, tomlEdit{len(content), len(content), text.String()})
+	}
+
+	// Apply edits back to front so offsets stay valid
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		content = content[:edit.start] + edit.text + content[edit.end:]
+	}
+	return content, true, nil
+}
+
+// flattenLeaves adds the leaf values of table below prefix to leaves
+func flattenLeaves(prefix string, table map[string]interface{}, leaves map[string]interface{}) {
+	for key, value := range table {
+		path := prefix + "." + key
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenLeaves(path, nested, leaves)
+			continue
+		}
+		leaves[path] = value
+	}
+}
+
+// conflicts reports whether key cannot be added by inserting a line: a
+// parent is a value or an array of tables, or key is itself a table
+func (l *tomlLayout) conflicts(key string) bool {
+	if _, ok := l.prefixes[key]; ok {
+		return true
+	}
+	for name := range l.tables {
+		if name == key || strings.HasPrefix(name, key+".") {
+			return true
+		}
+	}
+	for name := range l.arrays {
+		if name == key || strings.HasPrefix(name, key+".") {
+			return true
+		}
+	}
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		parent := key[:i]
+		if _, ok := l.entries[parent]; ok || l.arrays[parent] {
+			return true
+		}
+	}
+	return false
+}
+
+// tableFor returns the table that should receive key and the key relative
+// to it, or nil if key needs a new section
+func (l *tomlLayout) tableFor(key string) (*tomlTable, string) {
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		parent := key[:i]
+		if table, ok := l.tables[parent]; ok {
+			return table, key[i+1:]
+		}
+		if table, ok := l.prefixes[parent]; ok {
+			return table, strings.TrimPrefix(key, table.name+".")
+		}
+	}
+	if !strings.Contains(key, ".") {
+		return l.tables[""], key
+	}
+	return nil, ""
+}
+
+// parseTOMLLayout scans content for tables and key/value entries
+func parseTOMLLayout(content string) (*tomlLayout, error) {
+	root := &tomlTable{}
+	layout := &tomlLayout{
+		tables:      map[string]*tomlTable{"": root},
+		arrays:      make(map[string]bool),
+		entries:     make(map[string]*tomlEntry),
+		prefixes:    make(map[string]*tomlTable),
+		firstHeader: -1,
+	}
+
+	table := root
+	pos := 0
+	for pos < len(content) {
+		lineStart := pos
+		for pos < len(content) && (content[pos] == ' ' || content[pos] == '\t') {
+			pos++
+		}
+		if pos >= len(content) {
+			break
+		}
+
+		switch content[pos] {
+		case '\n', '\r', '#':
+			pos = nextLine(content, pos)
+			continue
+		case '[':
+			array := strings.HasPrefix(content[pos:], "[[")
+			end := strings.IndexByte(content[pos:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated table header at offset %d", pos)
+			}
+			name := strings.TrimPrefix(content[pos+1:pos+end], "[")
+			name = normalizeTOMLKey(name)
+			pos = nextLine(content, pos)
+			if layout.firstHeader < 0 {
+				layout.firstHeader = lineStart
+			}
+			table = &tomlTable{name: name, array: array, insert: pos}
+			if array {
+				layout.arrays[name] = true
+			} else {
+				layout.tables[name] = table
+			}
+			continue
+		}
+
+		eq := scanTOMLKey(content, pos)
+		if eq < 0 {
+			return nil, fmt.Errorf("expected key/value pair at offset %d", pos)
+		}
+		valueStart := eq + 1
+		for valueStart < len(content) && (content[valueStart] == ' ' || content[valueStart] == '\t') {
+			valueStart++
+		}
+		valueEnd, err := scanTOMLValue(content, valueStart)
+		if err != nil {
+			return nil, err
+		}
+		lineEnd := nextLine(content, valueEnd)
+
+		if !table.array {
+			key := joinKey(table.name, normalizeTOMLKey(content[pos:eq]))
+			layout.entries[key] = &tomlEntry{
+				lineStart:  lineStart,
+				valueStart: valueStart,
+				valueEnd:   valueEnd,
+				lineEnd:    lineEnd,
+			}
+			for i := strings.LastIndex(key, "."); i > len(table.name); i = strings.LastIndex(key[:i], ".") {
+				layout.prefixes[key[:i]] = table
+			}
+			table.insert = lineEnd
+			table.keys = true
+		}
+		pos = lineEnd
+	}
+
+	if !root.keys {
+		root.insert = len(content)
+		if layout.firstHeader >= 0 {
+			root.insert = layout.firstHeader
+		}
+	}
+	return layout, nil
+}
+
+// nextLine returns the offset of the line following pos
+func nextLine(content string, pos int) int {
+	if i := strings.IndexByte(content[pos:], '\n'); i >= 0 {
+		return pos + i + 1
+	}
+	return len(content)
+}
+
+// scanTOMLKey returns the offset of the '=' following the key at pos, or -1
+func scanTOMLKey(content string, pos int) int {
+	for pos < len(content) {
+		switch content[pos] {
+		case '=':
+			return pos
+		case '"', '\'':
+			end, err := scanTOMLString(content, pos)
+			if err != nil {
+				return -1
+			}
+			pos = end
+		case '\n', '#':
+			return -1
+		default:
+			pos++
+		}
+	}
+	return -1
+}
+
+// normalizeTOMLKey converts a possibly quoted, dotted TOML key to the dotted
+// form used by Config
+func normalizeTOMLKey(key string) string {
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; c {
+		case '"', '\'':
+			end, err := scanTOMLString(key, i)
+			if err != nil {
+				end = len(key)
+			}
+			quoted := key[i:end]
+			if unquoted, err := strconv.Unquote(quoted); c == '"' && err == nil {
+				part.WriteString(unquoted)
+			} else {
+				part.WriteString(strings.Trim(quoted, string(c)))
+			}
+			i = end - 1
+		case '.':
+			parts = append(parts, part.String())
+			part.Reset()
+		case ' ', '\t':
+		default:
+			part.WriteByte(c)
+		}
+	}
+	return strings.Join(append(parts, part.String()), ".")
+}
+
+// scanTOMLValue returns the offset following the value starting at pos
+func scanTOMLValue(content string, pos int) (int, error) {
+	if pos >= len(content) {
+		return 0, fmt.Errorf("missing value at offset %d", pos)
+	}
+	switch content[pos] {
+	case '"', '\'':
+		return scanTOMLString(content, pos)
+	case '[', '{':
+		depth := 0
+		for i := pos; i < len(content); i++ {
+			switch content[i] {
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+				if depth == 0 {
+					return i + 1, nil
+				}
+			case '"', '\'':
+				end, err := scanTOMLString(content, i)
+				if err != nil {
+					return 0, err
+				}
+				i = end - 1
+			case '#':
+				i = nextLine(content, i) - 1
+			}
+		}
+		return 0, fmt.Errorf("unterminated array or inline table at offset %d", pos)
+	default:
+		end := pos
+		for end < len(content) && content[end] != '\n' && content[end] != '\r' && content[end] != '#' {
+			end++
+		}
+		return pos + len(strings.TrimRight(content[pos:end], " \t")), nil
+	}
+}
+
+// scanTOMLString returns the offset following the string starting at pos
+func scanTOMLString(content string, pos int) (int, error) {
+	quote := content[pos]
+	if strings.HasPrefix(content[pos:], strings.Repeat(string(quote), 3)) {
+		delimiter := strings.Repeat(string(quote), 3)
+		for i := pos + 3; i < len(content); i++ {
+			if quote == '"' && content[i] == '\\' {
+				i++
+				continue
+			}
+			if strings.HasPrefix(content[i:], delimiter) {
+				end := i + 3
+				// Up to two quotes may directly precede the delimiter
+				for n := 0; n < 2 && end < len(content) && content[end] == quote; n++ {
+					end++
+				}
+				return end, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated multi-line string at offset %d", pos)
+	}
+
+	for i := pos + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1, nil
+		case '\n':
+			return 0, fmt.Errorf("unterminated string at offset %d", pos)
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at offset %d", pos)
+}
+
+// bareKeyPattern matches TOML keys that need no quoting
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeTOMLKey encodes a dotted key, quoting parts as needed
+func encodeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if !bareKeyPattern.MatchString(part) {
+			parts[i] = quoteTOMLString(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// encodeTOMLValue encodes a value as a single-line TOML value
+func encodeTOMLValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("TOML cannot represent nil values")
+	case string:
+		return quoteTOMLString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Duration:
+		return quoteTOMLString(v.String()), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case fmt.Stringer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Struct {
+			return quoteTOMLString(v.String()), nil
+		}
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return "", fmt.Errorf("TOML integer out of range: %d", rv.Uint())
+		}
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return formatTOMLFloat(rv.Float()), nil
+	case reflect.String:
+		return quoteTOMLString(rv.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := encodeTOMLValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("TOML tables require string keys, got %s", rv.Type().Key())
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			item, err := encodeTOMLValue(rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = encodeTOMLKey(key) + " = " + item
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("unsupported TOML value type %T", value)
+	}
+}
+
+// formatTOMLFloat formats a float so that TOML reads it back as a float
+func formatTOMLFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// quoteTOMLString encodes s as a TOML basic string
+func quoteTOMLString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
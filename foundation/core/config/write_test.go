@@ -0,0 +1,257 @@
+// File: write_test.go
+// Title: Configuration Write-Back Tests
+// Description: Tests Save with TOML layout preservation, re-encoding
+//              fallbacks, other formats, and secret handling.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial write-back tests
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes content to name in a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+// readConfigFile returns the content of path
+func readConfigFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	return string(content)
+}
+
+func TestSave_PreservesTOMLLayout(t *testing.T) {
+	const original = `# mDW service configuration
+name = "mdw"   # service name
+
+[turing]
+# Port of the LLM service
+port = 8080
+models = [
+  "llama3", # default
+  "mistral",
+]
+motd = "Welcome # not a comment"
+
+[database]
+host = "localhost"
+password = "${env:MDW_WRITE_TEST_PASSWORD}"
+
+[[upstreams]]
+name = "primary"
+`
+	const expected = `# mDW service configuration
+name = "mdw-prod"   # service name
+
+[turing]
+# Port of the LLM service
+port = 9090
+models = ["llama3"]
+motd = "Welcome # not a comment"
+timeout = "30s"
+
+[database]
+host = "localhost"
+password = "${env:MDW_WRITE_TEST_PASSWORD}"
+"pool size" = 20
+
+[[upstreams]]
+name = "primary"
+
+[cache]
+ttl = 300
+`
+	t.Setenv("MDW_WRITE_TEST_PASSWORD", "s3cret")
+	path := writeConfigFile(t, "config.toml", original)
+	cfg, err := LoadWithOptions(path, LoadOptions{
+		Format:   FormatTOML,
+		Defaults: map[string]interface{}{"logging": map[string]interface{}{"level": "info"}},
+	})
+	if err != nil {
+		t.Fatalf("LoadWithOptions() error = %v", err)
+	}
+
+	cfg.Set("turing.port", 9090)
+	cfg.Set("turing.models", []interface{}{"llama3"})
+	cfg.Set("turing.timeout", 30*time.Second)
+	cfg.Set("name", "mdw-prod")
+	cfg.Set("database.pool size", 20)
+	cfg.Set("cache.ttl", 300)
+	if err := cfg.Save("", FormatAuto); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got := readConfigFile(t, path); got != expected {
+		t.Errorf("saved content:\n%s\nwant:\n%s", got, expected)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of saved file error = %v", err)
+	}
+	if reloaded.GetInt("turing.port") != 9090 || reloaded.GetDuration("turing.timeout") != 30*time.Second || reloaded.GetString("database.password") != "s3cret" {
+		t.Errorf("reloaded config = %v", reloaded.GetAll())
+	}
+
+	// Saved keys are no longer dirty
+	cfg.mu.RLock()
+	dirty := len(cfg.dirty)
+	cfg.mu.RUnlock()
+	if dirty != 0 {
+		t.Errorf("dirty keys after Save = %d", dirty)
+	}
+}
+
+func TestSave_ReencodesUnpatchableTOML(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", "[[upstreams]]\nname = \"primary\"\n\n[limits]\nrate = { requests = 10 }\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cfg.Set("limits.rate.burst", 5)
+	cfg.Set("upstreams", []interface{}{map[string]interface{}{"name": "secondary"}})
+	if err := cfg.Save("", FormatAuto); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of saved file error = %v", err)
+	}
+	upstreams, _ := reloaded.GetAll()["upstreams"].([]map[string]interface{})
+	if reloaded.GetInt("limits.rate.burst") != 5 || reloaded.GetInt("limits.rate.requests") != 10 || len(upstreams) != 1 || upstreams[0]["name"] != "secondary" {
+		t.Errorf("reloaded config = %v", reloaded.GetAll())
+	}
+}
+
+func TestSave_ReplacesTables(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", "[server]\nhost = \"a\"\nport = 1\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cfg.Set("server", map[string]interface{}{"port": 2})
+	if err := cfg.Save("", FormatAuto); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := readConfigFile(t, path); got != "[server]\nport = 2\n" {
+		t.Errorf("saved content = %q", got)
+	}
+}
+
+func TestSave_OtherFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"config.yaml", "server:\n  port: 1\n  host: a\n"},
+		{"config.json", `{"server": {"port": 1, "host": "a"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.name, tt.content)
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			cfg.Set("server.port", 2)
+			cfg.Set("server.timeout", 5*time.Second)
+			if err := cfg.Save("", FormatAuto); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			reloaded, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() of saved file error = %v", err)
+			}
+			if reloaded.GetInt("server.port") != 2 || reloaded.GetString("server.host") != "a" || reloaded.GetDuration("server.timeout") != 5*time.Second {
+				t.Errorf("reloaded config = %v", reloaded.GetAll())
+			}
+		})
+	}
+}
+
+func TestSave_NewFile(t *testing.T) {
+	cfg, err := LoadFromString("[server]\nport = 8080\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	if err := cfg.Save("", FormatAuto); err == nil {
+		t.Error("Save() without path succeeded")
+	}
+
+	path := filepath.Join(t.TempDir(), "exported.yaml")
+	if err := cfg.Save(path, FormatAuto); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := readConfigFile(t, path); !strings.Contains(got, "port: 8080") {
+		t.Errorf("saved content = %q", got)
+	}
+
+	t.Setenv("MDW_WRITE_TEST_TOKEN", "t0ken")
+	secret, _ := LoadFromString("token = \"${env:MDW_WRITE_TEST_TOKEN}\"\n", FormatTOML)
+	secretPath := filepath.Join(t.TempDir(), "secret.toml")
+	if err := secret.Save(secretPath, FormatAuto); err == nil {
+		t.Error("Save() of resolved secrets succeeded")
+	}
+	if _, err := os.Stat(secretPath); !os.IsNotExist(err) {
+		t.Error("secret file was written")
+	}
+
+	secret.Set("token", "replaced")
+	if err := secret.Save(secretPath, FormatAuto); err != nil {
+		t.Errorf("Save() after replacing secret error = %v", err)
+	}
+}
+
+func TestEncodeTOMLValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"a \"quoted\"\nline", `"a \"quoted\"\nline"`},
+		{42, "42"},
+		{uint8(7), "7"},
+		{3.0, "3.0"},
+		{0.25, "0.25"},
+		{true, "true"},
+		{90 * time.Second, `"1m30s"`},
+		{[]string{"a", "b"}, `["a", "b"]`},
+		{map[string]interface{}{"b": 1, "a key": "x"}, `{ "a key" = "x", b = 1 }`},
+		{map[string]int{}, "{}"},
+	}
+	for _, tt := range tests {
+		got, err := encodeTOMLValue(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("encodeTOMLValue(%v) = %s, %v, want %s", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := encodeTOMLValue(nil); err == nil {
+		t.Error("encodeTOMLValue(nil) succeeded")
+	}
+}
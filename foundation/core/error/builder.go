@@ -0,0 +1,269 @@
+// File: builder.go
+// Title: Fluent Error Builder and Templates
+// Description: Provides a fluent Builder for constructing errors with
+//              operation, path, cause, and details in one expression, and
+//              reusable templates with predefined code, message, and severity
+//              so that modules do not assemble context maps inline.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of builder and templates
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Builder constructs an Error step by step:
+//
+//	err := error.NewBuilder().Op("filex.read_file").Path(p).Wrap(cause).Build()
+type Builder struct {
+	message     string
+	code        Code
+	severity    Severity
+	hasSeverity bool
+	operation   string
+	context     string
+	requestID   string
+	userID      string
+	cause       error
+	details     map[string]interface{}
+	messageKey  string
+	messageArgs map[string]interface{}
+	retryable   *bool
+	retryAfter  time.Duration
+}
+
+// NewBuilder creates an empty builder
+func NewBuilder() *Builder {
+	return &Builder{details: make(map[string]interface{})}
+}
+
+// Message sets the error message
+func (b *Builder) Message(message string) *Builder {
+	b.message = message
+	return b
+}
+
+// Messagef sets the error message with formatting
+func (b *Builder) Messagef(format string, args ...interface{}) *Builder {
+	b.message = fmt.Sprintf(format, args...)
+	return b
+}
+
+// Code sets the error code
+func (b *Builder) Code(code Code) *Builder {
+	b.code = code
+	return b
+}
+
+// Severity sets the severity; without it the severity follows the code
+func (b *Builder) Severity(severity Severity) *Builder {
+	b.severity = severity
+	b.hasSeverity = true
+	return b
+}
+
+// Op sets the operation, e.g. "filex.read_file"
+func (b *Builder) Op(operation string) *Builder {
+	b.operation = operation
+	return b
+}
+
+// Context sets the context information
+func (b *Builder) Context(context string) *Builder {
+	b.context = context
+	return b
+}
+
+// Path adds the file or resource path as detail "path"
+func (b *Builder) Path(path string) *Builder {
+	return b.Detail("path", path)
+}
+
+// Detail adds a key-value detail
+func (b *Builder) Detail(key string, value interface{}) *Builder {
+	b.details[key] = value
+	return b
+}
+
+// Details adds multiple key-value details
+func (b *Builder) Details(details map[string]interface{}) *Builder {
+	for key, value := range details {
+		b.details[key] = value
+	}
+	return b
+}
+
+// RequestID sets the request ID
+func (b *Builder) RequestID(requestID string) *Builder {
+	b.requestID = requestID
+	return b
+}
+
+// UserID sets the user ID
+func (b *Builder) UserID(userID string) *Builder {
+	b.userID = userID
+	return b
+}
+
+// MessageKey sets the localization key of the user-facing message
+func (b *Builder) MessageKey(key string) *Builder {
+	b.messageKey = key
+	return b
+}
+
+// MessageArg adds a template argument for the user-facing message
+func (b *Builder) MessageArg(key string, value interface{}) *Builder {
+	if b.messageArgs == nil {
+		b.messageArgs = make(map[string]interface{})
+	}
+	b.messageArgs[key] = value
+	return b
+}
+
+// Retryable marks the error as retryable or not
+func (b *Builder) Retryable(retryable bool) *Builder {
+	b.retryable = &retryable
+	return b
+}
+
+// RetryAfter sets the retry delay and marks the error as retryable
+func (b *Builder) RetryAfter(delay time.Duration) *Builder {
+	b.retryAfter = delay
+	return b.Retryable(true)
+}
+
+// Wrap sets the underlying cause
+func (b *Builder) Wrap(cause error) *Builder {
+	b.cause = cause
+	return b
+}
+
+// Build creates the error. Without a message it is derived from the
+// operation; without a code a wrapped mDW error keeps its code. The stack
+// trace starts at the caller of Build.
+func (b *Builder) Build() *Error {
+	message := b.message
+	if message == "" {
+		if b.operation != "" {
+			message = b.operation + " failed"
+		} else {
+			message = "operation failed"
+		}
+	}
+
+	var err *Error
+	if b.cause != nil {
+		err = Wrap(b.cause, message)
+	} else {
+		err = New(message)
+	}
+	err.stack = captureStackTrace(2)
+
+	if b.code != "" {
+		err.code = b.code
+		err.severity = GetSeverityFromCode(b.code)
+	}
+	if b.hasSeverity {
+		err.severity = b.severity
+	}
+	for key, value := range b.details {
+		err.details[key] = value
+	}
+	if b.operation != "" {
+		err.operation = b.operation
+	}
+	if b.context != "" {
+		err.context = b.context
+	}
+	if b.requestID != "" {
+		err.requestID = b.requestID
+	}
+	if b.userID != "" {
+		err.userID = b.userID
+	}
+	if b.messageKey != "" {
+		err.messageKey = b.messageKey
+	}
+	if len(b.messageArgs) > 0 {
+		err.messageArgs = make(map[string]interface{}, len(b.messageArgs))
+		for key, value := range b.messageArgs {
+			err.messageArgs[key] = value
+		}
+	}
+	if b.retryable != nil {
+		err.retryable = b.retryable
+		err.retryAfter = b.retryAfter
+	}
+	return err
+}
+
+// ===============================
+// Templates
+// ===============================
+
+// Template is a reusable error definition with predefined code, message,
+// and severity:
+//
+//	var ErrReadFile = error.NewTemplate(error.CodeInternal, "failed to read file", error.SeverityHigh)
+//
+//	return ErrReadFile.Op("filex.read_file").Path(p).Wrap(err).Build()
+type Template struct {
+	Code       Code
+	Message    string
+	Severity   Severity
+	MessageKey string
+}
+
+// NewTemplate creates a template
+func NewTemplate(code Code, message string, severity Severity) Template {
+	return Template{Code: code, Message: message, Severity: severity}
+}
+
+// WithMessageKey returns a copy of the template with a localization key
+func (t Template) WithMessageKey(key string) Template {
+	t.MessageKey = key
+	return t
+}
+
+// Builder returns a builder preset with the template
+func (t Template) Builder() *Builder {
+	return NewBuilder().Code(t.Code).Message(t.Message).Severity(t.Severity).MessageKey(t.MessageKey)
+}
+
+// Op returns a builder preset with the template and operation
+func (t Template) Op(operation string) *Builder {
+	return t.Builder().Op(operation)
+}
+
+// Wrap returns a builder preset with the template and cause
+func (t Template) Wrap(cause error) *Builder {
+	return t.Builder().Wrap(cause)
+}
+
+// New creates an error from the template without further context; the stack
+// trace starts at the caller of New
+func (t Template) New() *Error {
+	err := t.Builder().Build()
+	err.stack = captureStackTrace(2)
+	return err
+}
+
+// Is reports whether err or an mDW error in its chain has the template's code
+func (t Template) Is(err error) bool {
+	var mdwErr *Error
+	for err != nil && errors.As(err, &mdwErr) {
+		if mdwErr.code == t.Code {
+			return true
+		}
+		err = mdwErr.cause
+	}
+	return false
+}
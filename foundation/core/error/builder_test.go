@@ -0,0 +1,90 @@
+// File: builder_test.go
+// Title: Fluent Error Builder and Template Tests
+// Description: Tests fluent construction, cause wrapping, defaults, and
+//              reusable error templates.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial builder tests
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errTestReadFile = NewTemplate(CodeInternal, "failed to read file", SeverityHigh).WithMessageKey("errors.read_file")
+
+func TestBuilder(t *testing.T) {
+	err := NewBuilder().
+		Code(CodeNotFound).
+		Op("filex.read_file").
+		Path("/srv/data.csv").
+		Detail("attempt", 2).
+		RequestID("req-1").
+		RetryAfter(time.Second).
+		Wrap(os.ErrNotExist).
+		Build()
+
+	if err.Code() != CodeNotFound || err.Severity() != SeverityLow || err.Operation() != "filex.read_file" || err.RequestID() != "req-1" {
+		t.Errorf("Build() = %s", err.String())
+	}
+	if err.Error() != "filex.read_file failed: file does not exist" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if details := err.Details(); details["path"] != "/srv/data.csv" || details["attempt"] != 2 {
+		t.Errorf("Details() = %v", details)
+	}
+	if !errors.Is(err, os.ErrNotExist) || !err.Retryable() || err.RetryAfter() != time.Second {
+		t.Error("cause or retry metadata lost")
+	}
+	if frames := err.StackTrace(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".TestBuilder") {
+		t.Errorf("stack starts at %v", frames)
+	}
+}
+
+func TestBuilder_Defaults(t *testing.T) {
+	cause := New("connection refused").WithCode(CodeConnectionFailed)
+	err := NewBuilder().Message("query failed").Wrap(cause).Build()
+	if err.Code() != CodeConnectionFailed || err.Severity() != SeverityHigh {
+		t.Errorf("wrapped code = %s, severity = %s", err.Code(), err.Severity())
+	}
+
+	if err := NewBuilder().Build(); err.Error() != "operation failed" || err.Code() != CodeUnknown {
+		t.Errorf("empty Build() = %q (%s)", err.Error(), err.Code())
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	err := errTestReadFile.Op("filex.read_file").Path("a.txt").Wrap(os.ErrPermission).Build()
+	if err.Code() != CodeInternal || err.Severity() != SeverityHigh || err.MessageKey() != "errors.read_file" {
+		t.Errorf("template error = %s", err.String())
+	}
+	if err.Error() != "failed to read file: permission denied" || err.Details()["path"] != "a.txt" {
+		t.Errorf("template error = %q, details %v", err.Error(), err.Details())
+	}
+
+	// Each use starts from the template, not from previous builders
+	if second := errTestReadFile.New(); len(second.Details()) != 0 || second.Operation() != "" {
+		t.Errorf("template state leaked: %s", second.String())
+	}
+	if frames := errTestReadFile.New().StackTrace(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".TestTemplate") {
+		t.Errorf("New() stack starts at %v", frames)
+	}
+
+	wrapped := fmt.Errorf("handler: %w", Wrap(err, "request failed").WithCode(CodeServiceUnavailable))
+	if !errTestReadFile.Is(wrapped) {
+		t.Error("Is() did not find the template code in the chain")
+	}
+	if errTestReadFile.Is(New("other").WithCode(CodeNotFound)) || errTestReadFile.Is(nil) {
+		t.Error("Is() matched a different code")
+	}
+}
@@ -0,0 +1,138 @@
+// File: classify.go
+// Title: Error Retryability and Classification
+// Description: Provides retry metadata on errors and predicates that classify
+//              errors as transient, permanent, or caused by the user, so that
+//              clients decide about retries from the error itself instead of
+//              matching error messages.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of retry classification
+
+package error
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// WithRetryable marks the error as retryable or not, overriding the default
+// of its code
+func (e *Error) WithRetryable(retryable bool) *Error {
+	e.retryable = &retryable
+	return e
+}
+
+// WithRetryAfter sets the delay after which a retry may succeed and marks
+// the error as retryable
+func (e *Error) WithRetryAfter(delay time.Duration) *Error {
+	e.retryAfter = delay
+	return e.WithRetryable(true)
+}
+
+// Retryable reports whether retrying the failed operation may succeed. An
+// explicit WithRetryable takes precedence over the default of the code.
+func (e *Error) Retryable() bool {
+	if e.retryable != nil {
+		return *e.retryable
+	}
+	return e.code.IsTransient()
+}
+
+// RetryAfter returns the delay set with WithRetryAfter, or 0
+func (e *Error) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// IsTransient reports whether errors with this code are temporary by
+// default, so that a later retry may succeed
+func (c Code) IsTransient() bool {
+	switch c {
+	case CodeTimeout, CodeServiceTimeout, CodeServiceUnavailable, CodeConnectionFailed,
+		CodeNetworkError, CodeResourceLocked, CodeQuotaExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUserError reports whether errors with this code are caused by the
+// request rather than by the system, i.e. map to a 4xx HTTP status other
+// than timeouts and rate limits
+func (c Code) IsUserError() bool {
+	status := LookupStatus(c).HTTP
+	return status >= 400 && status < 500 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// ===============================
+// Classification Predicates
+// ===============================
+
+// IsTransient reports whether err is temporary, so that retrying may
+// succeed. The first mDW error in the chain decides; other errors are only
+// transient if they report so through a Temporary or Timeout method.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	var mdwErr *Error
+	if errors.As(err, &mdwErr) {
+		return mdwErr.Retryable()
+	}
+	if isContextError(err) {
+		return false
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// IsPermanent reports whether retrying err cannot succeed: mDW errors that
+// are not retryable and context cancellation or deadline errors. Errors
+// without classification, including mDW errors with CodeUnknown and no
+// explicit WithRetryable, are neither transient nor permanent.
+func IsPermanent(err error) bool {
+	if err == nil {
+		return false
+	}
+	var mdwErr *Error
+	if errors.As(err, &mdwErr) {
+		if mdwErr.retryable != nil {
+			return !*mdwErr.retryable
+		}
+		return !mdwErr.code.IsTransient() && mdwErr.code != CodeUnknown
+	}
+	return isContextError(err)
+}
+
+// IsUserError reports whether err was caused by the request, e.g. invalid
+// input, missing permissions, or unknown objects, and should be reported to
+// the user instead of being retried or alerted on
+func IsUserError(err error) bool {
+	var mdwErr *Error
+	return errors.As(err, &mdwErr) && mdwErr.code.IsUserError()
+}
+
+// RetryAfter returns the retry delay of the first mDW error in the chain of
+// err, or 0 if none was set
+func RetryAfter(err error) time.Duration {
+	var mdwErr *Error
+	if errors.As(err, &mdwErr) {
+		return mdwErr.retryAfter
+	}
+	return 0
+}
+
+// isContextError reports whether err is a context cancellation or deadline
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,102 @@
+// File: classify_test.go
+// Title: Error Retryability and Classification Tests
+// Description: Tests retry metadata, code defaults, and the transient,
+//              permanent, and user error predicates.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial classification tests
+
+package error
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// timeoutError is a standard error reporting a timeout, like net.Error
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+		permanent bool
+		user      bool
+	}{
+		{"nil", nil, false, false, false},
+		{"unavailable", New("down").WithCode(CodeServiceUnavailable), true, false, false},
+		{"quota", New("slow down").WithCode(CodeQuotaExceeded), true, false, false},
+		{"validation", New("bad").WithCode(CodeRequiredField), false, true, true},
+		{"tcol not found", New("missing").WithCode(CodeTCOLObjectNotFound), false, true, true},
+		{"internal", New("bug").WithCode(CodeInternal), false, true, false},
+		{"unknown", New("what"), false, false, false},
+		{"explicit retryable", New("flaky").WithCode(CodeDatabaseError).WithRetryable(true), true, false, false},
+		{"explicit permanent", New("closed").WithCode(CodeNetworkError).WithRetryable(false), false, true, false},
+		{"wrapped", fmt.Errorf("call: %w", Wrap(New("down").WithCode(CodeNetworkError), "query")), true, false, false},
+		{"canceled", context.Canceled, false, true, false},
+		{"timeout error", fmt.Errorf("read: %w", timeoutError{}), true, false, false},
+		{"plain", errors.New("boom"), false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.transient {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.transient)
+			}
+			if got := IsPermanent(tt.err); got != tt.permanent {
+				t.Errorf("IsPermanent() = %v, want %v", got, tt.permanent)
+			}
+			if got := IsUserError(tt.err); got != tt.user {
+				t.Errorf("IsUserError() = %v, want %v", got, tt.user)
+			}
+		})
+	}
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	err := New("rate limited").WithCode(CodeBusinessRule).WithRetryAfter(2 * time.Second)
+	if !err.Retryable() || err.RetryAfter() != 2*time.Second {
+		t.Errorf("Retryable() = %v, RetryAfter() = %v", err.Retryable(), err.RetryAfter())
+	}
+
+	wrapped := Wrap(err, "order failed")
+	if !IsTransient(wrapped) || RetryAfter(fmt.Errorf("api: %w", wrapped)) != 2*time.Second {
+		t.Error("retry metadata lost by wrapping")
+	}
+	if RetryAfter(errors.New("plain")) != 0 {
+		t.Error("RetryAfter() of plain error != 0")
+	}
+
+	data, _ := json.Marshal(err)
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if decoded["retryable"] != true || decoded["retry_after"] != "2s" {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
+
+func TestRetryMetadata_StatusRoundTrip(t *testing.T) {
+	err := New("locked").WithCode(CodeInvalidOperation).WithRetryAfter(500 * time.Millisecond)
+
+	restored := FromGRPCStatus(ToGRPCStatus(err))
+	if !restored.Retryable() || restored.RetryAfter() != 500*time.Millisecond {
+		t.Errorf("restored Retryable() = %v, RetryAfter() = %v", restored.Retryable(), restored.RetryAfter())
+	}
+
+	restored = FromGRPCStatus(ToGRPCStatus(New("busy").WithCode(CodeResourceLocked)))
+	if !restored.Retryable() {
+		t.Error("code default not preserved")
+	}
+}
@@ -7,21 +7,29 @@
 //              monitoring systems. It provides a foundation for consistent error handling
 //              across all mDW services and supports multi-language error messages.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.5
 // Created: 2025-01-24
-// Modified: 2025-01-24
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with contextual errors and codes
+// - 2026-10-16 v0.1.1: Added gRPC and HTTP status mapping
+// - 2026-10-16 v0.1.2: Added retryability and classification predicates
+// - 2026-10-16 v0.1.3: Added localized user-facing messages
+// - 2026-10-16 v0.1.4: Added stack capture controls and source mapping
+// - 2026-10-16 v0.1.5: Added fluent builder and error templates
 //
 // Features:
 // - Contextual error wrapping with additional metadata
 // - Structured error codes for consistent API responses
-// - Stack trace capture for debugging
+// - Lazy stack trace capture with configurable depth, severity, and filtering
 // - Integration with logging and monitoring systems
-// - Multi-language error message support
+// - Multi-language user-facing messages via i18n, separate from log messages
 // - Error severity levels and categorization
 // - Custom error types for specific business domains
+// - Fluent builder and reusable error templates
+// - Registrable mapping of error codes to gRPC and HTTP statuses
+// - Retry metadata and transient/permanent/user error classification
 //
 // Usage:
 //   import "github.com/msto63/mDW/foundation/core/error"
@@ -37,8 +45,33 @@
 //     WithCode(error.CodeServiceInitialization).
 //     WithDetail("service", "user")
 //
+//   // Build errors from reusable templates
+//   var ErrReadFile = error.NewTemplate(error.CodeInternal, "failed to read file", error.SeverityHigh)
+//   err = ErrReadFile.Op("filex.read_file").Path(path).Wrap(cause).Build()
+//
 //   // Check error type and code
 //   if error.HasCode(err, error.CodeDatabaseError) {
 //     // Handle database errors specifically
 //   }
+//
+//   // Translate errors at service boundaries
+//   st := error.ToGRPCStatus(err)       // Reason and Metadata carry code and details
+//   restored := error.FromGRPCStatus(st)
+//   httpStatus := error.ToHTTPStatus(err)
+//
+//   // Capture traces only for severe errors, starting at application code
+//   opts := error.DefaultStackOptions()
+//   opts.MinSeverity = error.SeverityHigh
+//   opts.FilterInternal = true
+//   error.SetStackOptions(opts)
+//
+//   // Render a translated message for the user; err.Error() stays technical
+//   error.SetTranslator(i18nManager)
+//   msg := error.New("open failed").WithMessageKey("errors.file_missing").
+//     WithDetail("path", path).UserMessage("de")
+//
+//   // Decide about retries from the error itself
+//   if error.IsTransient(err) {
+//     time.Sleep(error.RetryAfter(err))
+//   }
 package error
\ No newline at end of file
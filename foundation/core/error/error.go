@@ -5,21 +5,21 @@
 //              compatibility with Go's standard error interface while adding powerful
 //              debugging and monitoring capabilities.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-24
-// Modified: 2025-01-24
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with contextual errors
+// - 2026-10-16 v0.1.1: Added retryability metadata
+// - 2026-10-16 v0.1.2: Moved stack capture to stack.go with lazy resolution
 
 package error
 
 import (
 	"encoding/json"
 	"fmt"
-	"runtime"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -39,17 +39,22 @@ type Error struct {
 	userID    string
 	requestID string
 	
-	// Stack trace information
-	stackTrace []StackFrame
+	// Stack trace information, resolved on first access
+	stack *stackCapture
 	
 	// Localization
 	messageKey string
 	messageArgs map[string]interface{}
+	
+	// Retry classification; nil retryable defers to the code
+	retryable  *bool
+	retryAfter time.Duration
 }
 
 // StackFrame represents a single frame in the stack trace
 type StackFrame struct {
 	Function string `json:"function"`
+	Package  string `json:"package,omitempty"`
 	File     string `json:"file"`
 	Line     int    `json:"line"`
 }
@@ -66,28 +71,6 @@ const (
 	StackTracePoolSize = 32
 )
 
-var (
-	// stackFramePool pools stack frame slices for efficient memory reuse
-	stackFramePool = sync.Pool{
-		New: func() interface{} {
-			return make([]StackFrame, 0, MaxStackFrames)
-		},
-	}
-)
-
-// getStackFrameSlice gets a stack frame slice from the pool
-func getStackFrameSlice() []StackFrame {
-	slice := stackFramePool.Get().([]StackFrame)
-	return slice[:0] // Reset length but keep capacity
-}
-
-// putStackFrameSlice returns a stack frame slice to the pool
-func putStackFrameSlice(slice []StackFrame) {
-	if slice != nil && cap(slice) >= MaxStackFrames {
-		stackFramePool.Put(slice)
-	}
-}
-
 // New creates a new Error with the given message
 func New(message string) *Error {
 	return &Error{
@@ -96,7 +79,7 @@ func New(message string) *Error {
 		severity:   SeverityMedium,
 		timestamp:  time.Now(),
 		details:    make(map[string]interface{}),
-		stackTrace: captureStackTrace(2), // Skip New and caller
+		stack:      captureStackTrace(2), // Skip New and caller
 	}
 }
 
@@ -134,7 +117,7 @@ func Wrap(err error, message string) *Error {
 			severity:   SeverityHigh, // High severity for truncated chains
 			timestamp:  time.Now(),
 			details:    map[string]interface{}{"truncated": true, "original_depth": depth},
-			stackTrace: captureStackTrace(2),
+			stack:      captureStackTrace(2),
 		}
 	}
 	
@@ -147,9 +130,11 @@ func Wrap(err error, message string) *Error {
 			severity:    mdwErr.severity,
 			timestamp:   time.Now(),
 			details:     make(map[string]interface{}),
-			stackTrace:  captureStackTrace(2),
+			stack:       captureStackTrace(2),
 			messageKey:  mdwErr.messageKey,
 			messageArgs: mdwErr.messageArgs,
+			retryable:   mdwErr.retryable,
+			retryAfter:  mdwErr.retryAfter,
 		}
 		// Copy details from the original error
 		for k, v := range mdwErr.details {
@@ -166,7 +151,7 @@ func Wrap(err error, message string) *Error {
 		severity:   SeverityMedium,
 		timestamp:  time.Now(),
 		details:    make(map[string]interface{}),
-		stackTrace: captureStackTrace(2),
+		stack:      captureStackTrace(2),
 	}
 }
 
@@ -304,11 +289,10 @@ func (e *Error) RequestID() string {
 	return e.requestID
 }
 
-// StackTrace returns the stack trace
+// StackTrace returns the stack trace as structured frames. It is empty when
+// capture is disabled or the severity is below StackOptions.MinSeverity.
 func (e *Error) StackTrace() []StackFrame {
-	result := make([]StackFrame, len(e.stackTrace))
-	copy(result, e.stackTrace)
-	return result
+	return e.stack.frames(e.severity)
 }
 
 // MessageKey returns the localization message key
@@ -414,8 +398,8 @@ func (e *Error) MarshalJSON() ([]byte, error) {
 		data["cause"] = e.cause.Error()
 	}
 	
-	if len(e.stackTrace) > 0 {
-		data["stack_trace"] = e.stackTrace
+	if stackTrace := e.StackTrace(); len(stackTrace) > 0 {
+		data["stack_trace"] = stackTrace
 	}
 	
 	if e.messageKey != "" {
@@ -425,39 +409,15 @@ func (e *Error) MarshalJSON() ([]byte, error) {
 		}
 	}
 	
-	return json.Marshal(data)
-}
-
-// captureStackTrace captures the current stack trace with pooling optimization
-func captureStackTrace(skip int) []StackFrame {
-	frames := getStackFrameSlice()
-	
-	for i := skip; i < MaxStackFrames+skip; i++ {
-		pc, file, line, ok := runtime.Caller(i)
-		if !ok {
-			break
-		}
-		
-		fn := runtime.FuncForPC(pc)
-		if fn == nil {
-			continue
-		}
-		
-		frames = append(frames, StackFrame{
-			Function: fn.Name(),
-			File:     file,
-			Line:     line,
-		})
+	if e.retryable != nil {
+		data["retryable"] = *e.retryable
 	}
 	
-	// Create a copy for the error since we'll return the slice to the pool
-	result := make([]StackFrame, len(frames))
-	copy(result, frames)
-	
-	// Return pooled slice
-	putStackFrameSlice(frames)
+	if e.retryAfter > 0 {
+		data["retry_after"] = e.retryAfter.String()
+	}
 	
-	return result
+	return json.Marshal(data)
 }
 
 // HasCode checks if an error has a specific code
@@ -0,0 +1,103 @@
+// File: message.go
+// Title: Localized User-Facing Error Messages
+// Description: Renders translated, context-interpolated messages for end users
+//              from an error's message key, arguments, and details, while the
+//              technical message stays unchanged for logs. Translation is
+//              delegated to a Translator such as the i18n Manager.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of localized user messages
+
+package error
+
+import (
+	"strings"
+	"sync"
+)
+
+// Translator translates message keys per locale; *i18n.Manager implements
+// it. An empty locale selects the translator's current locale.
+type Translator interface {
+	TryTInLocale(locale, key string, data ...map[string]interface{}) (string, error)
+}
+
+// MessageKeyPrefix is the prefix of the default message keys of codes, e.g.
+// "errors.not_found" for CodeNotFound
+const MessageKeyPrefix = "errors."
+
+var (
+	defaultTranslator   Translator
+	defaultTranslatorMu sync.RWMutex
+)
+
+// SetTranslator sets the translator used by UserMessage; nil disables
+// translation
+func SetTranslator(translator Translator) {
+	defaultTranslatorMu.Lock()
+	defer defaultTranslatorMu.Unlock()
+	defaultTranslator = translator
+}
+
+// WithMessageKey sets the localization key of the user-facing message and
+// keeps previously set message arguments
+func (e *Error) WithMessageKey(key string) *Error {
+	e.messageKey = key
+	return e
+}
+
+// WithMessageArg adds a template argument for the user-facing message
+func (e *Error) WithMessageArg(key string, value interface{}) *Error {
+	if e.messageArgs == nil {
+		e.messageArgs = make(map[string]interface{})
+	}
+	e.messageArgs[key] = value
+	return e
+}
+
+// DefaultMessageKey returns the message key of the code, e.g.
+// "errors.not_found" for CodeNotFound
+func (c Code) DefaultMessageKey() string {
+	return MessageKeyPrefix + strings.ToLower(string(c))
+}
+
+// UserMessage renders the user-facing message in locale with the translator
+// set by SetTranslator. See UserMessageWith for the lookup order.
+func (e *Error) UserMessage(locale string) string {
+	defaultTranslatorMu.RLock()
+	translator := defaultTranslator
+	defaultTranslatorMu.RUnlock()
+	return e.UserMessageWith(translator, locale)
+}
+
+// UserMessageWith renders the user-facing message in locale. It translates
+// the message key, then the default key of the code, with the error details
+// and message arguments as template data, e.g. "{{.path}}". Without
+// translation the technical message is returned.
+func (e *Error) UserMessageWith(translator Translator, locale string) string {
+	if translator == nil {
+		return e.message
+	}
+
+	data := make(map[string]interface{}, len(e.details)+len(e.messageArgs)+1)
+	for key, value := range e.details {
+		data[key] = value
+	}
+	for key, value := range e.messageArgs {
+		data[key] = value
+	}
+	data["code"] = string(e.code)
+
+	for _, key := range []string{e.messageKey, e.code.DefaultMessageKey()} {
+		if key == "" {
+			continue
+		}
+		if message, err := translator.TryTInLocale(locale, key, data); err == nil {
+			return message
+		}
+	}
+	return e.message
+}
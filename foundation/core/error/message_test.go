@@ -0,0 +1,101 @@
+// File: message_test.go
+// Title: Localized User-Facing Error Message Tests
+// Description: Tests message key lookup, template data, fallbacks, and the
+//              default translator of user-facing messages.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial user message tests
+
+package error
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mapTranslator translates from a map keyed by "locale:key" and replaces
+// "{name}" placeholders with template data
+type mapTranslator map[string]string
+
+func (m mapTranslator) TryTInLocale(locale, key string, data ...map[string]interface{}) (string, error) {
+	message, ok := m[locale+":"+key]
+	if !ok {
+		return "", New("translation not found").WithCode(CodeNotFound)
+	}
+	if len(data) > 0 {
+		for name, value := range data[0] {
+			message = strings.ReplaceAll(message, "{"+name+"}", fmt.Sprint(value))
+		}
+	}
+	return message, nil
+}
+
+func TestUserMessageWith(t *testing.T) {
+	translator := mapTranslator{
+		"de:errors.file_missing": "Datei {path} nicht gefunden",
+		"en:errors.file_missing": "File {path} not found",
+		"de:errors.not_found":    "Nicht gefunden ({code})",
+	}
+
+	err := New("open /srv/data.csv: no such file").
+		WithCode(CodeNotFound).
+		WithMessageKey("errors.file_missing").
+		WithDetail("path", "/srv/data.csv")
+
+	if got := err.UserMessageWith(translator, "de"); got != "Datei /srv/data.csv nicht gefunden" {
+		t.Errorf("UserMessageWith(de) = %q", got)
+	}
+	if got := err.UserMessageWith(translator, "en"); got != "File /srv/data.csv not found" {
+		t.Errorf("UserMessageWith(en) = %q", got)
+	}
+	if err.Error() != "open /srv/data.csv: no such file" {
+		t.Errorf("technical message changed: %q", err.Error())
+	}
+
+	// Message arguments take precedence over details
+	err.WithMessageArg("path", "data.csv")
+	if got := err.UserMessageWith(translator, "en"); got != "File data.csv not found" {
+		t.Errorf("UserMessageWith() with argument = %q", got)
+	}
+
+	// Without a message key the default key of the code is used
+	plain := New("row 17 missing").WithCode(CodeNotFound)
+	if got := plain.UserMessageWith(translator, "de"); got != "Nicht gefunden (NOT_FOUND)" {
+		t.Errorf("UserMessageWith() with code key = %q", got)
+	}
+
+	// Without any translation the technical message is returned
+	if got := plain.UserMessageWith(translator, "fr"); got != "row 17 missing" {
+		t.Errorf("UserMessageWith(fr) = %q", got)
+	}
+	if got := plain.UserMessageWith(nil, "de"); got != "row 17 missing" {
+		t.Errorf("UserMessageWith(nil) = %q", got)
+	}
+}
+
+func TestUserMessage_DefaultTranslator(t *testing.T) {
+	SetTranslator(mapTranslator{"de:errors.forbidden": "Zugriff verweigert"})
+	defer SetTranslator(nil)
+
+	err := New("user 42 lacks role admin").WithCode(CodeForbidden)
+	if got := err.UserMessage("de"); got != "Zugriff verweigert" {
+		t.Errorf("UserMessage(de) = %q", got)
+	}
+
+	// Wrapping keeps the message key
+	wrapped := Wrap(New("denied").WithMessageKey("errors.forbidden"), "request failed")
+	if got := wrapped.UserMessage("de"); got != "Zugriff verweigert" {
+		t.Errorf("wrapped UserMessage(de) = %q", got)
+	}
+}
+
+func TestCode_DefaultMessageKey(t *testing.T) {
+	if got := CodeTCOLObjectNotFound.DefaultMessageKey(); got != "errors.tcol_object_not_found" {
+		t.Errorf("DefaultMessageKey() = %q", got)
+	}
+}
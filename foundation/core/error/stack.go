@@ -0,0 +1,192 @@
+// File: stack.go
+// Title: Stack Trace Capture and Source Mapping
+// Description: Captures program counters when errors are created and resolves
+//              them to structured frames on first access. Capture depth, the
+//              minimum severity for traces, filtering of foundation internals,
+//              and trimming of source roots are configurable.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of configurable lazy stack capture
+
+package error
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// FoundationPackagePrefix is the function prefix of foundation packages,
+// whose frames FilterInternal removes
+const FoundationPackagePrefix = "github.com/msto63/mDW/foundation/"
+
+// StackOptions control the capture and resolution of stack traces
+type StackOptions struct {
+	// Depth is the maximum number of captured frames; 0 disables capture
+	Depth int
+
+	// MinSeverity is the lowest severity whose stack trace is resolved.
+	// Capture only records program counters, so the check happens on
+	// access, after the code and severity of the error are final.
+	MinSeverity Severity
+
+	// FilterInternal removes frames whose function starts with one of
+	// InternalPrefixes, so that traces start at application code
+	FilterInternal   bool
+	InternalPrefixes []string
+
+	// TrimPaths are source root prefixes removed from file paths, e.g. the
+	// module root, to map frames to repository-relative files
+	TrimPaths []string
+}
+
+// DefaultStackOptions returns the default options: up to MaxStackFrames
+// frames for all severities without filtering
+func DefaultStackOptions() StackOptions {
+	return StackOptions{
+		Depth:            MaxStackFrames,
+		MinSeverity:      SeverityLow,
+		InternalPrefixes: []string{FoundationPackagePrefix, "runtime."},
+	}
+}
+
+var (
+	stackOptions atomic.Pointer[StackOptions]
+
+	// pcPool pools program counter buffers for capture
+	pcPool = sync.Pool{
+		New: func() interface{} {
+			buffer := make([]uintptr, MaxStackFrames)
+			return &buffer
+		},
+	}
+)
+
+func init() {
+	options := DefaultStackOptions()
+	stackOptions.Store(&options)
+}
+
+// SetStackOptions sets the stack trace options for errors created
+// afterwards; the options are copied
+func SetStackOptions(options StackOptions) {
+	options.InternalPrefixes = append([]string(nil), options.InternalPrefixes...)
+	options.TrimPaths = append([]string(nil), options.TrimPaths...)
+	stackOptions.Store(&options)
+}
+
+// GetStackOptions returns the current stack trace options
+func GetStackOptions() StackOptions {
+	return *stackOptions.Load()
+}
+
+// String returns the frame as "function (file:line)"
+func (f StackFrame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+// stackCapture holds the program counters of an error and resolves them
+// once on first access
+type stackCapture struct {
+	pcs      []uintptr
+	once     sync.Once
+	resolved []StackFrame
+}
+
+// captureStackTrace records the program counters of the stack; skip 0 is
+// captureStackTrace itself, as with runtime.Caller
+func captureStackTrace(skip int) *stackCapture {
+	depth := stackOptions.Load().Depth
+	if depth <= 0 {
+		return nil
+	}
+
+	buffer := pcPool.Get().(*[]uintptr)
+	defer pcPool.Put(buffer)
+	if len(*buffer) < depth {
+		*buffer = make([]uintptr, depth)
+	}
+
+	// runtime.Callers counts itself as frame 0
+	n := runtime.Callers(skip+1, (*buffer)[:depth])
+	if n == 0 {
+		return nil
+	}
+	return &stackCapture{pcs: append([]uintptr(nil), (*buffer)[:n]...)}
+}
+
+// frames returns a copy of the resolved frames, or nil if the severity is
+// below the configured minimum
+func (s *stackCapture) frames(severity Severity) []StackFrame {
+	if s == nil {
+		return nil
+	}
+	options := stackOptions.Load()
+	if severity < options.MinSeverity {
+		return nil
+	}
+
+	s.once.Do(func() {
+		s.resolved = resolveFrames(s.pcs, options)
+	})
+	result := make([]StackFrame, len(s.resolved))
+	copy(result, s.resolved)
+	return result
+}
+
+// resolveFrames maps program counters to frames, applying filtering and
+// path trimming
+func resolveFrames(pcs []uintptr, options *StackOptions) []StackFrame {
+	result := make([]StackFrame, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !(options.FilterInternal && hasAnyPrefix(frame.Function, options.InternalPrefixes)) {
+			result = append(result, StackFrame{
+				Function: frame.Function,
+				Package:  packageName(frame.Function),
+				File:     trimPath(frame.File, options.TrimPaths),
+				Line:     frame.Line,
+			})
+		}
+		if !more {
+			return result
+		}
+	}
+}
+
+// packageName returns the import path of a fully qualified function name,
+// e.g. "github.com/a/b" for "github.com/a/b.(*T).Method"
+func packageName(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return ""
+}
+
+// trimPath removes the first matching source root from path
+func trimPath(path string, roots []string) string {
+	for _, root := range roots {
+		if root != "" && strings.HasPrefix(path, root) {
+			return strings.TrimLeft(strings.TrimPrefix(path, root), "/")
+		}
+	}
+	return path
+}
+
+// hasAnyPrefix reports whether s starts with one of prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// File: stack_test.go
+// Title: Stack Trace Capture and Source Mapping Tests
+// Description: Tests capture depth, severity-gated resolution, filtering of
+//              internal frames, path trimming, and structured frames.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial stack capture tests
+
+package error
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// withStackOptions sets options for the duration of a test
+func withStackOptions(t *testing.T, options StackOptions) {
+	previous := GetStackOptions()
+	SetStackOptions(options)
+	t.Cleanup(func() { SetStackOptions(previous) })
+}
+
+// newNestedError creates an error one call below the test function
+func newNestedError() *Error {
+	return New("nested")
+}
+
+func TestStackTrace_Frames(t *testing.T) {
+	frames := newNestedError().StackTrace()
+	if len(frames) < 2 {
+		t.Fatalf("StackTrace() = %v", frames)
+	}
+	if !strings.HasSuffix(frames[0].Function, ".newNestedError") || frames[0].Package != "github.com/msto63/mDW/foundation/core/error" {
+		t.Errorf("first frame = %+v", frames[0])
+	}
+	if !strings.HasSuffix(frames[1].Function, ".TestStackTrace_Frames") {
+		t.Errorf("second frame = %+v", frames[1])
+	}
+	if !strings.Contains(frames[0].String(), "stack_test.go:") {
+		t.Errorf("String() = %q", frames[0].String())
+	}
+
+	// Frames are resolved once and returned as copies
+	err := newNestedError()
+	err.StackTrace()[0].Function = "changed"
+	if err.StackTrace()[0].Function == "changed" {
+		t.Error("StackTrace() returned shared frames")
+	}
+}
+
+func TestStackOptions_Depth(t *testing.T) {
+	withStackOptions(t, StackOptions{Depth: 1})
+	if frames := newNestedError().StackTrace(); len(frames) != 1 {
+		t.Errorf("frames with depth 1 = %d", len(frames))
+	}
+
+	SetStackOptions(StackOptions{Depth: 0})
+	err := newNestedError()
+	if frames := err.StackTrace(); frames != nil {
+		t.Errorf("frames with capture disabled = %v", frames)
+	}
+	if data, _ := json.Marshal(err); strings.Contains(string(data), "stack_trace") {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
+
+func TestStackOptions_MinSeverity(t *testing.T) {
+	options := DefaultStackOptions()
+	options.MinSeverity = SeverityHigh
+	withStackOptions(t, options)
+
+	err := New("invalid input").WithCode(CodeInvalidInput)
+	if frames := err.StackTrace(); len(frames) != 0 {
+		t.Errorf("low severity frames = %d", len(frames))
+	}
+
+	// The severity decides on access, after the builder calls
+	err.WithSeverity(SeverityCritical)
+	if frames := err.StackTrace(); len(frames) == 0 {
+		t.Error("critical error has no stack trace")
+	}
+}
+
+func TestStackOptions_FilterAndTrim(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	options := DefaultStackOptions()
+	options.FilterInternal = true
+	options.InternalPrefixes = []string{"github.com/msto63/mDW/foundation/core/error.newNestedError", "runtime."}
+	options.TrimPaths = []string{filepath.Dir(file)}
+	withStackOptions(t, options)
+
+	frames := newNestedError().StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".TestStackOptions_FilterAndTrim") {
+		t.Fatalf("filtered frames = %v", frames)
+	}
+	if frames[0].File != "stack_test.go" {
+		t.Errorf("trimmed file = %q", frames[0].File)
+	}
+	for _, frame := range frames {
+		if strings.HasPrefix(frame.Function, "runtime.") {
+			t.Errorf("runtime frame not filtered: %v", frame)
+		}
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"github.com/a/b.(*T).Method": "github.com/a/b",
+		"github.com/a/b.Func.func1":  "github.com/a/b",
+		"main.main":                  "main",
+		"runtime.goexit":             "runtime",
+	}
+	for function, want := range tests {
+		if got := packageName(function); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
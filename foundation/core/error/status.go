@@ -0,0 +1,378 @@
+// File: status.go
+// Title: gRPC and HTTP Status Mapping
+// Description: Maps mDW errors to gRPC status values and HTTP status codes
+//              through a registrable code table, and restores mDW errors from
+//              gRPC status values so that codes and details survive service
+//              boundaries. The gRPC types mirror google.golang.org/grpc without
+//              depending on it.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of status mapping
+// - 2026-10-16 v0.1.1: Carry retry metadata in status details
+// - 2026-10-16 v0.1.2: Adapted to lazy stack capture
+
+package error
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GRPCCode is a gRPC status code; the values equal those of
+// google.golang.org/grpc/codes, so codes.Code(c) converts it
+type GRPCCode uint32
+
+// gRPC status codes
+const (
+	GRPCOK                 GRPCCode = 0
+	GRPCCanceled           GRPCCode = 1
+	GRPCUnknown            GRPCCode = 2
+	GRPCInvalidArgument    GRPCCode = 3
+	GRPCDeadlineExceeded   GRPCCode = 4
+	GRPCNotFound           GRPCCode = 5
+	GRPCAlreadyExists      GRPCCode = 6
+	GRPCPermissionDenied   GRPCCode = 7
+	GRPCResourceExhausted  GRPCCode = 8
+	GRPCFailedPrecondition GRPCCode = 9
+	GRPCAborted            GRPCCode = 10
+	GRPCOutOfRange         GRPCCode = 11
+	GRPCUnimplemented      GRPCCode = 12
+	GRPCInternal           GRPCCode = 13
+	GRPCUnavailable        GRPCCode = 14
+	GRPCDataLoss           GRPCCode = 15
+	GRPCUnauthenticated    GRPCCode = 16
+)
+
+// StatusDomain is the error domain of mDW status details
+const StatusDomain = "mdw"
+
+// Metadata keys of status details besides the "detail." prefixed details
+const (
+	metaSeverity    = "severity"
+	metaOperation   = "operation"
+	metaContext     = "context"
+	metaRequestID   = "request_id"
+	metaUserID      = "user_id"
+	metaMessageKey  = "message_key"
+	metaMessageArgs = "message_args"
+	metaRetryable   = "retryable"
+	metaRetryAfter  = "retry_after"
+	metaDetail      = "detail."
+)
+
+// Status is the wire representation of an error as a gRPC status with an
+// ErrorInfo detail: Reason carries the mDW code and Metadata the error
+// context and details. Services convert it with
+//
+//	st := status.New(codes.Code(s.Code), s.Message)
+//	st, _ = st.WithDetails(&errdetails.ErrorInfo{Reason: s.Reason, Domain: s.Domain, Metadata: s.Metadata})
+type Status struct {
+	Code     GRPCCode
+	Message  string
+	Reason   string
+	Domain   string
+	Metadata map[string]string
+}
+
+// ===============================
+// Status Table
+// ===============================
+
+// StatusMapping is the gRPC and HTTP status of an error code
+type StatusMapping struct {
+	GRPC GRPCCode
+	HTTP int
+}
+
+var (
+	statusTable   = defaultStatusTable()
+	statusTableMu sync.RWMutex
+)
+
+// defaultStatusTable maps the built-in codes; HTTP statuses follow
+// Code.HTTPStatus
+func defaultStatusTable() map[Code]StatusMapping {
+	grpcCodes := map[Code]GRPCCode{
+		CodeUnknown:               GRPCUnknown,
+		CodeInternal:              GRPCInternal,
+		CodeNotFound:              GRPCNotFound,
+		CodeInvalidInput:          GRPCInvalidArgument,
+		CodeTimeout:               GRPCDeadlineExceeded,
+		CodeUnauthorized:          GRPCUnauthenticated,
+		CodeForbidden:             GRPCPermissionDenied,
+		CodeInvalidToken:          GRPCUnauthenticated,
+		CodeExpiredToken:          GRPCUnauthenticated,
+		CodeInvalidCredentials:    GRPCUnauthenticated,
+		CodeDatabaseError:         GRPCInternal,
+		CodeConnectionFailed:      GRPCUnavailable,
+		CodeDataCorruption:        GRPCDataLoss,
+		CodeConstraintViolation:   GRPCFailedPrecondition,
+		CodeDuplicateEntry:        GRPCAlreadyExists,
+		CodeBusinessRule:          GRPCFailedPrecondition,
+		CodeInsufficientFunds:     GRPCFailedPrecondition,
+		CodeInvalidOperation:      GRPCFailedPrecondition,
+		CodeResourceLocked:        GRPCAborted,
+		CodeQuotaExceeded:         GRPCResourceExhausted,
+		CodeServiceUnavailable:    GRPCUnavailable,
+		CodeNetworkError:          GRPCUnavailable,
+		CodeServiceTimeout:        GRPCDeadlineExceeded,
+		CodeServiceInitialization: GRPCUnavailable,
+		CodeExternalServiceError:  GRPCInternal,
+		CodeTCOLSyntax:            GRPCInvalidArgument,
+		CodeTCOLSemantic:          GRPCInvalidArgument,
+		CodeTCOLPermission:        GRPCPermissionDenied,
+		CodeTCOLExecution:         GRPCInternal,
+		CodeTCOLObjectNotFound:    GRPCNotFound,
+		CodeConfigError:           GRPCInternal,
+		CodeMissingConfig:         GRPCInternal,
+		CodeInvalidConfig:         GRPCInternal,
+		CodeEnvironmentError:      GRPCInternal,
+		CodeValidationFailed:      GRPCInvalidArgument,
+		CodeRequiredField:         GRPCInvalidArgument,
+		CodeInvalidFormat:         GRPCInvalidArgument,
+		CodeValueOutOfRange:       GRPCInvalidArgument,
+		CodeInvalidLength:         GRPCInvalidArgument,
+	}
+
+	table := make(map[Code]StatusMapping, len(grpcCodes))
+	for code, grpcCode := range grpcCodes {
+		table[code] = StatusMapping{GRPC: grpcCode, HTTP: code.HTTPStatus()}
+	}
+	return table
+}
+
+// RegisterStatus sets the gRPC and HTTP status of code, e.g. for
+// service-specific codes or to override a built-in mapping
+func RegisterStatus(code Code, mapping StatusMapping) {
+	statusTableMu.Lock()
+	defer statusTableMu.Unlock()
+	statusTable[code] = mapping
+}
+
+// LookupStatus returns the status mapping of code. Unregistered codes map
+// to Code.HTTPStatus and the matching gRPC code.
+func LookupStatus(code Code) StatusMapping {
+	statusTableMu.RLock()
+	mapping, exists := statusTable[code]
+	statusTableMu.RUnlock()
+	if exists {
+		return mapping
+	}
+	return StatusMapping{GRPC: grpcFromHTTP(code.HTTPStatus()), HTTP: code.HTTPStatus()}
+}
+
+// grpcFromHTTP returns the gRPC code closest to an HTTP status
+func grpcFromHTTP(status int) GRPCCode {
+	switch status {
+	case http.StatusBadRequest:
+		return GRPCInvalidArgument
+	case http.StatusUnauthorized:
+		return GRPCUnauthenticated
+	case http.StatusForbidden:
+		return GRPCPermissionDenied
+	case http.StatusNotFound:
+		return GRPCNotFound
+	case http.StatusConflict:
+		return GRPCAborted
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return GRPCDeadlineExceeded
+	case http.StatusTooManyRequests:
+		return GRPCResourceExhausted
+	case http.StatusNotImplemented:
+		return GRPCUnimplemented
+	case http.StatusServiceUnavailable:
+		return GRPCUnavailable
+	default:
+		return GRPCUnknown
+	}
+}
+
+// codeFromGRPC returns the mDW code for a status without mDW reason
+func codeFromGRPC(code GRPCCode) Code {
+	switch code {
+	case GRPCInvalidArgument, GRPCOutOfRange:
+		return CodeInvalidInput
+	case GRPCDeadlineExceeded:
+		return CodeTimeout
+	case GRPCNotFound:
+		return CodeNotFound
+	case GRPCAlreadyExists:
+		return CodeDuplicateEntry
+	case GRPCPermissionDenied:
+		return CodeForbidden
+	case GRPCResourceExhausted:
+		return CodeQuotaExceeded
+	case GRPCFailedPrecondition:
+		return CodeInvalidOperation
+	case GRPCAborted:
+		return CodeResourceLocked
+	case GRPCInternal, GRPCUnimplemented:
+		return CodeInternal
+	case GRPCUnavailable:
+		return CodeServiceUnavailable
+	case GRPCDataLoss:
+		return CodeDataCorruption
+	case GRPCUnauthenticated:
+		return CodeUnauthorized
+	default:
+		return CodeUnknown
+	}
+}
+
+// ===============================
+// Conversion
+// ===============================
+
+// ToGRPCStatus returns the gRPC status of err, or nil for a nil error. The
+// first mDW error in the chain provides code and details; context
+// cancellation and deadline errors map to Canceled and DeadlineExceeded.
+func ToGRPCStatus(err error) *Status {
+	if err == nil {
+		return nil
+	}
+
+	var mdwErr *Error
+	if !errors.As(err, &mdwErr) {
+		status := &Status{Code: GRPCUnknown, Message: err.Error()}
+		switch {
+		case errors.Is(err, context.Canceled):
+			status.Code = GRPCCanceled
+		case errors.Is(err, context.DeadlineExceeded):
+			status.Code = GRPCDeadlineExceeded
+		}
+		return status
+	}
+
+	status := &Status{
+		Code:     LookupStatus(mdwErr.code).GRPC,
+		Message:  mdwErr.Error(),
+		Reason:   string(mdwErr.code),
+		Domain:   StatusDomain,
+		Metadata: map[string]string{metaSeverity: mdwErr.severity.String()},
+	}
+	setMetadata := func(key, value string) {
+		if value != "" {
+			status.Metadata[key] = value
+		}
+	}
+	setMetadata(metaOperation, mdwErr.operation)
+	setMetadata(metaContext, mdwErr.context)
+	setMetadata(metaRequestID, mdwErr.requestID)
+	setMetadata(metaUserID, mdwErr.userID)
+	setMetadata(metaMessageKey, mdwErr.messageKey)
+	if len(mdwErr.messageArgs) > 0 {
+		if data, err := json.Marshal(mdwErr.messageArgs); err == nil {
+			status.Metadata[metaMessageArgs] = string(data)
+		}
+	}
+	if mdwErr.retryable != nil {
+		status.Metadata[metaRetryable] = strconv.FormatBool(*mdwErr.retryable)
+	}
+	if mdwErr.retryAfter > 0 {
+		status.Metadata[metaRetryAfter] = mdwErr.retryAfter.String()
+	}
+	// Details are JSON encoded so that their types survive the round trip
+	for key, value := range mdwErr.details {
+		if data, err := json.Marshal(value); err == nil {
+			status.Metadata[metaDetail+key] = string(data)
+		}
+	}
+	return status
+}
+
+// FromGRPCStatus restores an mDW error from a status, or returns nil for a
+// nil or OK status. Statuses from other domains keep their message and get
+// the mDW code matching the gRPC code.
+func FromGRPCStatus(status *Status) *Error {
+	if status == nil || status.Code == GRPCOK {
+		return nil
+	}
+
+	err := New(status.Message)
+	err.stack = nil // The stack of the remote side is not available
+	if status.Domain != StatusDomain || status.Reason == "" {
+		err.code = codeFromGRPC(status.Code)
+		err.severity = GetSeverityFromCode(err.code)
+		return err
+	}
+
+	err.code = Code(status.Reason)
+	err.severity = GetSeverityFromCode(err.code)
+	metadata := status.Metadata
+	if severity, ok := parseSeverity(metadata[metaSeverity]); ok {
+		err.severity = severity
+	}
+	err.operation = metadata[metaOperation]
+	err.context = metadata[metaContext]
+	err.requestID = metadata[metaRequestID]
+	err.userID = metadata[metaUserID]
+	err.messageKey = metadata[metaMessageKey]
+	if args := metadata[metaMessageArgs]; args != "" {
+		json.Unmarshal([]byte(args), &err.messageArgs)
+	}
+
+	if retryable, parseErr := strconv.ParseBool(metadata[metaRetryable]); parseErr == nil {
+		err.retryable = &retryable
+	}
+	if retryAfter, parseErr := time.ParseDuration(metadata[metaRetryAfter]); parseErr == nil {
+		err.retryAfter = retryAfter
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		if strings.HasPrefix(key, metaDetail) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value interface{}
+		if json.Unmarshal([]byte(metadata[key]), &value) != nil {
+			value = metadata[key]
+		}
+		err.details[strings.TrimPrefix(key, metaDetail)] = value
+	}
+	return err
+}
+
+// ToHTTPStatus returns the HTTP status code for err: 200 for nil, the
+// mapped status of the first mDW error in the chain, 499/504 for context
+// cancellation and deadline errors, and 500 otherwise
+func ToHTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var mdwErr *Error
+	if errors.As(err, &mdwErr) {
+		return LookupStatus(mdwErr.code).HTTP
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return 499 // Client closed request
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseSeverity parses a severity name as returned by Severity.String
+func parseSeverity(name string) (Severity, bool) {
+	for _, severity := range []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical} {
+		if severity.String() == name {
+			return severity, true
+		}
+	}
+	return SeverityMedium, false
+}
@@ -0,0 +1,138 @@
+// File: status_test.go
+// Title: gRPC and HTTP Status Mapping Tests
+// Description: Tests the code table, conversion of errors to gRPC and HTTP
+//              statuses, and the round trip of codes and details.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial status mapping tests
+
+package error
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestToGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want GRPCCode
+	}{
+		{"not found", New("missing").WithCode(CodeNotFound), GRPCNotFound},
+		{"validation", New("bad").WithCode(CodeRequiredField), GRPCInvalidArgument},
+		{"auth", New("expired").WithCode(CodeExpiredToken), GRPCUnauthenticated},
+		{"tcol permission", New("denied").WithCode(CodeTCOLPermission), GRPCPermissionDenied},
+		{"duplicate", New("exists").WithCode(CodeDuplicateEntry), GRPCAlreadyExists},
+		{"unavailable", New("down").WithCode(CodeServiceUnavailable), GRPCUnavailable},
+		{"wrapped by fmt", fmt.Errorf("call: %w", New("slow").WithCode(CodeTimeout)), GRPCDeadlineExceeded},
+		{"canceled", context.Canceled, GRPCCanceled},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), GRPCDeadlineExceeded},
+		{"plain", errors.New("boom"), GRPCUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToGRPCStatus(tt.err); got.Code != tt.want {
+				t.Errorf("ToGRPCStatus() code = %d, want %d", got.Code, tt.want)
+			}
+		})
+	}
+
+	if ToGRPCStatus(nil) != nil {
+		t.Error("ToGRPCStatus(nil) != nil")
+	}
+}
+
+func TestGRPCStatus_RoundTrip(t *testing.T) {
+	original := New("order not found").
+		WithCode(CodeNotFound).
+		WithSeverity(SeverityHigh).
+		WithOperation("orders.Get").
+		WithRequestID("req-1").
+		WithUserID("user-1").
+		WithDetail("order_id", "A-17").
+		WithDetail("attempt", 3).
+		WithDetail("retryable", false)
+
+	status := ToGRPCStatus(original)
+	if status.Reason != string(CodeNotFound) || status.Domain != StatusDomain {
+		t.Fatalf("status = %+v", status)
+	}
+
+	restored := FromGRPCStatus(status)
+	if restored.Code() != CodeNotFound || restored.Severity() != SeverityHigh ||
+		restored.Operation() != "orders.Get" || restored.RequestID() != "req-1" || restored.UserID() != "user-1" {
+		t.Errorf("restored = %+v", restored)
+	}
+	if restored.Error() != "order not found" {
+		t.Errorf("restored message = %q", restored.Error())
+	}
+	details := restored.Details()
+	if details["order_id"] != "A-17" || details["attempt"] != float64(3) || details["retryable"] != false {
+		t.Errorf("restored details = %v", details)
+	}
+}
+
+func TestFromGRPCStatus_ForeignDomain(t *testing.T) {
+	restored := FromGRPCStatus(&Status{Code: GRPCPermissionDenied, Message: "no access", Domain: "other", Reason: "DENIED"})
+	if restored.Code() != CodeForbidden || restored.Error() != "no access" {
+		t.Errorf("restored = %v (%s)", restored, restored.Code())
+	}
+
+	if FromGRPCStatus(nil) != nil || FromGRPCStatus(&Status{Code: GRPCOK}) != nil {
+		t.Error("FromGRPCStatus() of nil or OK status returned an error")
+	}
+}
+
+func TestToHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{New("missing").WithCode(CodeNotFound), http.StatusNotFound},
+		{New("bad").WithCode(CodeInvalidFormat), http.StatusBadRequest},
+		{fmt.Errorf("wrapped: %w", New("denied").WithCode(CodeForbidden)), http.StatusForbidden},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := ToHTTPStatus(tt.err); got != tt.want {
+			t.Errorf("ToHTTPStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterStatus(t *testing.T) {
+	const codeRateLimited Code = "RATE_LIMITED"
+	defer func() {
+		statusTableMu.Lock()
+		delete(statusTable, codeRateLimited)
+		statusTableMu.Unlock()
+	}()
+
+	err := New("slow down").WithCode(codeRateLimited)
+	if ToHTTPStatus(err) != http.StatusInternalServerError {
+		t.Errorf("unregistered code HTTP status = %d", ToHTTPStatus(err))
+	}
+
+	RegisterStatus(codeRateLimited, StatusMapping{GRPC: GRPCResourceExhausted, HTTP: http.StatusTooManyRequests})
+	if got := ToGRPCStatus(err).Code; got != GRPCResourceExhausted {
+		t.Errorf("registered gRPC code = %d", got)
+	}
+	if got := ToHTTPStatus(err); got != http.StatusTooManyRequests {
+		t.Errorf("registered HTTP status = %d", got)
+	}
+	if restored := FromGRPCStatus(ToGRPCStatus(err)); restored.Code() != codeRateLimited {
+		t.Errorf("restored code = %s", restored.Code())
+	}
+}
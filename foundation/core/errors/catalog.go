@@ -0,0 +1,281 @@
+// File: catalog.go
+// Title: Error Code Catalog
+// Description: Central registry of error codes with owning module, description,
+//              and default severity. Codes are unique across the platform:
+//              duplicate registrations fail, so conflicting modules are detected
+//              at init. Catalog emits the machine-readable list for
+//              documentation and monitoring dashboards.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the error code catalog
+
+package errors
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// Modules owning the codes registered by this package
+const (
+	ModuleCore   = "core"
+	ModuleCommon = "common"
+)
+
+// CatalogEntry describes a registered error code
+type CatalogEntry struct {
+	Code        mdwerror.Code
+	Module      string
+	Description string
+	Severity    mdwerror.Severity
+}
+
+// catalogEntryJSON is the machine-readable form of a catalog entry, extended
+// by the classification and status mapping of the code
+type catalogEntryJSON struct {
+	Code        string `json:"code"`
+	Module      string `json:"module"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Category    string `json:"category"`
+	HTTPStatus  int    `json:"http_status"`
+	GRPCCode    uint32 `json:"grpc_code"`
+	Transient   bool   `json:"transient"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (e CatalogEntry) MarshalJSON() ([]byte, error) {
+	status := mdwerror.LookupStatus(e.Code)
+	return json.Marshal(catalogEntryJSON{
+		Code:        string(e.Code),
+		Module:      e.Module,
+		Description: e.Description,
+		Severity:    e.Severity.String(),
+		Category:    e.Code.Category(),
+		HTTPStatus:  status.HTTP,
+		GRPCCode:    uint32(status.GRPC),
+		Transient:   e.Code.IsTransient(),
+	})
+}
+
+var (
+	catalog   = make(map[mdwerror.Code]CatalogEntry)
+	catalogMu sync.RWMutex
+)
+
+// RegisterCodes registers the error codes of a module. Either all entries
+// are registered or, if a code is empty or already registered, none.
+func RegisterCodes(module string, entries ...CatalogEntry) error {
+	if strings.TrimSpace(module) == "" {
+		return mdwerror.New("module name cannot be empty").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("errors.RegisterCodes")
+	}
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	seen := make(map[mdwerror.Code]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Code == "" {
+			return mdwerror.New("error code cannot be empty").
+				WithCode(mdwerror.CodeInvalidInput).
+				WithOperation("errors.RegisterCodes").
+				WithDetail("module", module)
+		}
+		registeredBy := module
+		if existing, exists := catalog[entry.Code]; exists {
+			registeredBy = existing.Module
+		} else if !seen[entry.Code] {
+			registeredBy = ""
+		}
+		if registeredBy != "" {
+			return mdwerror.New("error code already registered").
+				WithCode(mdwerror.CodeDuplicateEntry).
+				WithOperation("errors.RegisterCodes").
+				WithDetail("code", string(entry.Code)).
+				WithDetail("module", module).
+				WithDetail("registered_by", registeredBy)
+		}
+		seen[entry.Code] = true
+	}
+
+	for _, entry := range entries {
+		entry.Module = module
+		catalog[entry.Code] = entry
+	}
+	return nil
+}
+
+// MustRegisterCodes is like RegisterCodes but panics on error; it is meant
+// for init functions, so that duplicate codes fail at program start
+func MustRegisterCodes(module string, entries ...CatalogEntry) {
+	if err := RegisterCodes(module, entries...); err != nil {
+		panic(err)
+	}
+}
+
+// LookupCode returns the catalog entry of code
+func LookupCode(code mdwerror.Code) (CatalogEntry, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	entry, exists := catalog[code]
+	return entry, exists
+}
+
+// IsRegistered reports whether code is in the catalog
+func IsRegistered(code mdwerror.Code) bool {
+	_, exists := LookupCode(code)
+	return exists
+}
+
+// Catalog returns all registered codes sorted by code
+func Catalog() []CatalogEntry {
+	catalogMu.RLock()
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, entry := range catalog {
+		entries = append(entries, entry)
+	}
+	catalogMu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+	return entries
+}
+
+// ModuleCatalog returns the codes registered by module sorted by code
+func ModuleCatalog(module string) []CatalogEntry {
+	var entries []CatalogEntry
+	for _, entry := range Catalog() {
+		if entry.Module == module {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// coreEntry creates a catalog entry with the default severity of the code
+func coreEntry(code mdwerror.Code, description string) CatalogEntry {
+	return CatalogEntry{Code: code, Description: description, Severity: mdwerror.GetSeverityFromCode(code)}
+}
+
+// moduleEntry creates a catalog entry for a module code with an explicit
+// default severity
+func moduleEntry(code string, severity mdwerror.Severity, description string) CatalogEntry {
+	return CatalogEntry{Code: mdwerror.Code(code), Description: description, Severity: severity}
+}
+
+func init() {
+	MustRegisterCodes(ModuleCore,
+		coreEntry(mdwerror.CodeUnknown, "Unclassified error"),
+		coreEntry(mdwerror.CodeInternal, "Internal error in the platform"),
+		coreEntry(mdwerror.CodeNotFound, "Requested resource does not exist"),
+		coreEntry(mdwerror.CodeInvalidInput, "Input parameter is invalid"),
+		coreEntry(mdwerror.CodeTimeout, "Operation did not complete in time"),
+		coreEntry(mdwerror.CodeUnauthorized, "Authentication is required"),
+		coreEntry(mdwerror.CodeForbidden, "Caller lacks the required permission"),
+		coreEntry(mdwerror.CodeInvalidToken, "Authentication token is invalid"),
+		coreEntry(mdwerror.CodeExpiredToken, "Authentication token has expired"),
+		coreEntry(mdwerror.CodeInvalidCredentials, "Credentials are invalid"),
+		coreEntry(mdwerror.CodeDatabaseError, "Database operation failed"),
+		coreEntry(mdwerror.CodeConnectionFailed, "Connection to a backend failed"),
+		coreEntry(mdwerror.CodeDataCorruption, "Stored data is corrupt"),
+		coreEntry(mdwerror.CodeConstraintViolation, "Database constraint violated"),
+		coreEntry(mdwerror.CodeDuplicateEntry, "Entry already exists"),
+		coreEntry(mdwerror.CodeBusinessRule, "Business rule violated"),
+		coreEntry(mdwerror.CodeInsufficientFunds, "Insufficient funds for the operation"),
+		coreEntry(mdwerror.CodeInvalidOperation, "Operation not allowed in the current state"),
+		coreEntry(mdwerror.CodeResourceLocked, "Resource is locked by another operation"),
+		coreEntry(mdwerror.CodeQuotaExceeded, "Quota or rate limit exceeded"),
+		coreEntry(mdwerror.CodeServiceUnavailable, "Service is unavailable"),
+		coreEntry(mdwerror.CodeNetworkError, "Network communication failed"),
+		coreEntry(mdwerror.CodeServiceTimeout, "Service call timed out"),
+		coreEntry(mdwerror.CodeServiceInitialization, "Service failed to initialize"),
+		coreEntry(mdwerror.CodeExternalServiceError, "External service returned an error"),
+		coreEntry(mdwerror.CodeTCOLSyntax, "TCOL command has a syntax error"),
+		coreEntry(mdwerror.CodeTCOLSemantic, "TCOL command is semantically invalid"),
+		coreEntry(mdwerror.CodeTCOLPermission, "TCOL command not permitted"),
+		coreEntry(mdwerror.CodeTCOLExecution, "TCOL command execution failed"),
+		coreEntry(mdwerror.CodeTCOLObjectNotFound, "TCOL object does not exist"),
+		coreEntry(mdwerror.CodeConfigError, "Configuration error"),
+		coreEntry(mdwerror.CodeMissingConfig, "Required configuration is missing"),
+		coreEntry(mdwerror.CodeInvalidConfig, "Configuration value is invalid"),
+		coreEntry(mdwerror.CodeEnvironmentError, "Runtime environment error"),
+		coreEntry(mdwerror.CodeValidationFailed, "Validation failed"),
+		coreEntry(mdwerror.CodeRequiredField, "Required field is missing"),
+		coreEntry(mdwerror.CodeInvalidFormat, "Value has an invalid format"),
+		coreEntry(mdwerror.CodeValueOutOfRange, "Value is out of the allowed range"),
+		coreEntry(mdwerror.CodeInvalidLength, "Value has an invalid length"),
+	)
+
+	MustRegisterCodes(ModuleCommon,
+		moduleEntry(CodeOutOfRange, mdwerror.SeverityMedium, "Value is out of range"),
+		moduleEntry(CodePermissionDenied, mdwerror.SeverityHigh, "Permission denied"),
+		moduleEntry(CodeOperationFailed, mdwerror.SeverityHigh, "Operation failed"),
+	)
+
+	MustRegisterCodes(ModuleStringx,
+		moduleEntry(CodeStringxInvalidFormat, mdwerror.SeverityMedium, "String has an invalid format"),
+		moduleEntry(CodeStringxLengthExceeded, mdwerror.SeverityMedium, "String exceeds the maximum length"),
+		moduleEntry(CodeStringxEncodingError, mdwerror.SeverityMedium, "String encoding is invalid"),
+		moduleEntry(CodeStringxInvalidPattern, mdwerror.SeverityMedium, "Pattern is invalid"),
+	)
+
+	MustRegisterCodes(ModuleMathx,
+		moduleEntry(CodeMathxPrecisionLoss, mdwerror.SeverityMedium, "Calculation lost precision"),
+		moduleEntry(CodeMathxDivisionByZero, mdwerror.SeverityHigh, "Division by zero"),
+		moduleEntry(CodeMathxOverflow, mdwerror.SeverityHigh, "Calculation overflowed"),
+		moduleEntry(CodeMathxUnderflow, mdwerror.SeverityHigh, "Calculation underflowed"),
+		moduleEntry(CodeMathxInvalidDecimal, mdwerror.SeverityMedium, "Decimal string is invalid"),
+		moduleEntry(CodeMathxOperationFailed, mdwerror.SeverityHigh, "Mathematical operation failed"),
+	)
+
+	MustRegisterCodes(ModuleMapx,
+		moduleEntry(CodeMapxKeyNotFound, mdwerror.SeverityLow, "Map key not found"),
+		moduleEntry(CodeMapxInvalidType, mdwerror.SeverityMedium, "Map value has an unexpected type"),
+		moduleEntry(CodeMapxOperationFailed, mdwerror.SeverityHigh, "Map operation failed"),
+	)
+
+	MustRegisterCodes(ModuleSlicex,
+		moduleEntry(CodeSlicexIndexOutOfRange, mdwerror.SeverityMedium, "Slice index out of range"),
+		moduleEntry(CodeSlicexInvalidFunction, mdwerror.SeverityMedium, "Slice function is invalid"),
+		moduleEntry(CodeSlicexOperationFailed, mdwerror.SeverityHigh, "Slice operation failed"),
+	)
+
+	MustRegisterCodes(ModuleTimex,
+		moduleEntry(CodeTimexInvalidFormat, mdwerror.SeverityMedium, "Time has an invalid format"),
+		moduleEntry(CodeTimexInvalidTimeZone, mdwerror.SeverityMedium, "Time zone is invalid"),
+		moduleEntry(CodeTimexParseError, mdwerror.SeverityMedium, "Time could not be parsed"),
+		moduleEntry(CodeTimexCalculationError, mdwerror.SeverityMedium, "Time calculation failed"),
+		moduleEntry(CodeTimexOperationFailed, mdwerror.SeverityHigh, "Time operation failed"),
+	)
+
+	MustRegisterCodes(ModuleValidationx,
+		moduleEntry(CodeValidationxRuleFailed, mdwerror.SeverityLow, "Validation rule failed"),
+		moduleEntry(CodeValidationxChainFailed, mdwerror.SeverityLow, "Validation chain failed"),
+		moduleEntry(CodeValidationxInvalidRule, mdwerror.SeverityMedium, "Validation rule is invalid"),
+	)
+
+	MustRegisterCodes(ModuleFilex,
+		moduleEntry(CodeFilexNotFound, mdwerror.SeverityMedium, "File not found"),
+		moduleEntry(CodeFilexPermissionDenied, mdwerror.SeverityHigh, "File permission denied"),
+		moduleEntry(CodeFilexOperationFailed, mdwerror.SeverityHigh, "File operation failed"),
+		moduleEntry(CodeFilexInvalidPath, mdwerror.SeverityMedium, "File path is invalid"),
+		moduleEntry(CodeFilexReadFailed, mdwerror.SeverityHigh, "File could not be read"),
+		moduleEntry(CodeFilexWriteFailed, mdwerror.SeverityHigh, "File could not be written"),
+	)
+
+	// ValidationFailed derives "<MODULE>_VALIDATION_FAILED" codes
+	for _, module := range []string{ModuleStringx, ModuleMathx, ModuleMapx, ModuleSlicex, ModuleTimex, ModuleValidationx, ModuleFilex} {
+		MustRegisterCodes(module, moduleEntry(strings.ToUpper(module)+"_VALIDATION_FAILED", mdwerror.SeverityLow, "Validation failed in "+module))
+	}
+}
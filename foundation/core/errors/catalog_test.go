@@ -0,0 +1,116 @@
+// File: catalog_test.go
+// Title: Error Code Catalog Tests
+// Description: Tests registration, uniqueness enforcement, lookup, and the
+//              machine-readable catalog output.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial catalog tests
+
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// unregisterCodes removes codes registered by a test
+func unregisterCodes(codes ...mdwerror.Code) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	for _, code := range codes {
+		delete(catalog, code)
+	}
+}
+
+func TestCatalog_BuiltinCodes(t *testing.T) {
+	for _, code := range []mdwerror.Code{mdwerror.CodeNotFound, mdwerror.CodeTCOLSyntax, CodeFilexReadFailed, CodeOutOfRange, "STRINGX_VALIDATION_FAILED"} {
+		if !IsRegistered(code) {
+			t.Errorf("code %s not registered", code)
+		}
+	}
+
+	entry, ok := LookupCode(CodeMathxDivisionByZero)
+	if !ok || entry.Module != ModuleMathx || entry.Severity != mdwerror.SeverityHigh || entry.Description == "" {
+		t.Errorf("LookupCode() = %+v, %v", entry, ok)
+	}
+
+	entries := Catalog()
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Code >= entries[i].Code {
+			t.Fatalf("Catalog() not sorted at %s", entries[i].Code)
+		}
+	}
+	if filex := ModuleCatalog(ModuleFilex); len(filex) != 7 {
+		t.Errorf("ModuleCatalog(filex) = %d entries, want 7", len(filex))
+	}
+}
+
+func TestRegisterCodes_Uniqueness(t *testing.T) {
+	defer unregisterCodes("BILLING_INVOICE_LOCKED", "BILLING_RATE_LIMITED")
+
+	err := RegisterCodes("billing",
+		CatalogEntry{Code: "BILLING_INVOICE_LOCKED", Description: "Invoice is locked", Severity: mdwerror.SeverityMedium},
+		CatalogEntry{Code: "BILLING_RATE_LIMITED", Description: "Rate limited"},
+	)
+	if err != nil {
+		t.Fatalf("RegisterCodes() error = %v", err)
+	}
+
+	// A code owned by another module is rejected
+	err = RegisterCodes("shipping", CatalogEntry{Code: "BILLING_INVOICE_LOCKED"})
+	if !mdwerror.HasCode(err, mdwerror.CodeDuplicateEntry) {
+		t.Fatalf("duplicate RegisterCodes() error = %v", err)
+	}
+	if details := err.(*mdwerror.Error).Details(); details["registered_by"] != "billing" {
+		t.Errorf("duplicate error details = %v", details)
+	}
+
+	// A failed registration registers none of its codes
+	err = RegisterCodes("shipping",
+		CatalogEntry{Code: "SHIPPING_DELAYED"},
+		CatalogEntry{Code: "SHIPPING_DELAYED"},
+	)
+	if err == nil || IsRegistered("SHIPPING_DELAYED") {
+		t.Errorf("RegisterCodes() with duplicate in batch = %v", err)
+	}
+
+	if err := RegisterCodes("", CatalogEntry{Code: "X"}); err == nil {
+		t.Error("RegisterCodes() without module succeeded")
+	}
+	if err := RegisterCodes("shipping", CatalogEntry{}); err == nil {
+		t.Error("RegisterCodes() with empty code succeeded")
+	}
+}
+
+func TestMustRegisterCodes_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegisterCodes() with duplicate code did not panic")
+		}
+	}()
+	MustRegisterCodes("other", CatalogEntry{Code: mdwerror.CodeNotFound})
+}
+
+func TestCatalogEntry_MarshalJSON(t *testing.T) {
+	entry, _ := LookupCode(mdwerror.CodeServiceUnavailable)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded["code"] != "SERVICE_UNAVAILABLE" || decoded["module"] != ModuleCore || decoded["severity"] != "critical" ||
+		decoded["category"] != "service" || decoded["http_status"] != float64(503) ||
+		decoded["grpc_code"] != float64(mdwerror.GRPCUnavailable) || decoded["transient"] != true {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
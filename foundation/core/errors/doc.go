@@ -9,12 +9,13 @@
 //              to provide module-specific error handling while maintaining
 //              consistency and enabling better error analysis and monitoring.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation for cross-module error standardization
+// - 2026-10-16 v0.1.1: Added error code catalog
 //
 // Package Overview:
 //
@@ -41,6 +42,21 @@
 //   - FormatError: For format-related errors
 //   - OperationError: For operation failures
 //
+// # Error Code Catalog
+//
+// All codes are registered in a central catalog with owning module,
+// description, and default severity. Codes are unique: registering a code
+// twice fails, and MustRegisterCodes panics so conflicts surface at init:
+//
+//	func init() {
+//		errors.MustRegisterCodes("billing",
+//			errors.CatalogEntry{Code: "BILLING_INVOICE_LOCKED", Description: "Invoice is locked", Severity: mdwerror.SeverityMedium},
+//		)
+//	}
+//
+// Catalog returns the full list; its JSON form adds category, HTTP status,
+// gRPC code, and transience for documentation and dashboards.
+//
 // # Error Analysis Functions
 //
 // Utilities for analyzing and working with standardized errors:
@@ -0,0 +1,329 @@
+// File: backfill.go
+// Title: Machine-Translation Backfill
+// Description: Fills untranslated messages of a locale through a pluggable
+//              machine-translation provider and records the filled keys as
+//              machine-translated in the language file's metadata. Existing
+//              translations are never overwritten. Includes a provider for
+//              the Turing LLM service; the gRPC transport is supplied by the
+//              service through TuringClient.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of machine-translation backfill
+
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// MetadataKey is the top-level section of a language file holding metadata
+// rather than messages. Backfilled keys are listed in it:
+//
+//	[_meta]
+//	machine_translated = ["checkout.cta", "errors.timeout"]
+const MetadataKey = "_meta"
+
+// metadataMachineTranslated lists the machine-translated keys in MetadataKey
+const metadataMachineTranslated = "machine_translated"
+
+// MTProvider translates messages for Backfill. texts maps keys to messages
+// in sourceLocale; the result maps keys to messages in targetLocale. Keys
+// missing from the result stay untranslated.
+type MTProvider interface {
+	Translate(ctx context.Context, sourceLocale, targetLocale string, texts map[string]string) (map[string]string, error)
+}
+
+// BackfillReport lists the outcome of a backfill run
+type BackfillReport struct {
+	Locale     string
+	Translated []string // Keys filled by machine translation
+	Skipped    []string // Untranslated keys not filled: no result, changed template actions, or no plain message
+}
+
+// Backfill translates all messages of the default locale that are missing
+// or empty in targetLocale with provider. Results whose template actions
+// differ from the source message are rejected. Filled keys are listed as
+// machine-translated in the metadata and written to the language file if
+// the manager loads from LocalesDir; reviewers remove a key from the list
+// when they approve or replace its translation.
+func (m *Manager) Backfill(ctx context.Context, provider MTProvider, targetLocale string) (*BackfillReport, error) {
+	m.mu.RLock()
+	sourceLocale := m.defaultLocale
+	defaults := m.translations[sourceLocale]
+	var texts map[string]string
+	var skipped []string
+	if targetLocale != sourceLocale && defaults != nil {
+		texts, skipped = m.untranslatedMessages(defaults, m.translations[targetLocale])
+	}
+	m.mu.RUnlock()
+
+	if targetLocale == "" || targetLocale == sourceLocale {
+		return nil, mdwerror.New("backfill target must differ from the default locale").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("i18n.Backfill").
+			WithDetail("locale", targetLocale)
+	}
+	if defaults == nil {
+		return nil, mdwerror.New("default locale not loaded").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("i18n.Backfill").
+			WithDetail("locale", sourceLocale)
+	}
+
+	report := &BackfillReport{Locale: targetLocale, Skipped: skipped}
+	if len(texts) == 0 {
+		return report, nil
+	}
+
+	results, err := provider.Translate(ctx, sourceLocale, targetLocale, texts)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "machine translation failed").
+			WithCode(mdwerror.CodeExternalServiceError).
+			WithOperation("i18n.Backfill").
+			WithDetail("locale", targetLocale).
+			WithDetail("keys", len(texts))
+	}
+
+	m.mu.Lock()
+	translations := m.deepCopyTranslations(m.translations[targetLocale])
+	if translations == nil {
+		translations = make(map[string]interface{})
+	}
+	machineTranslated := machineTranslatedKeys(translations)
+	for _, key := range sortedKeys(texts) {
+		translated := results[key]
+		value := m.getNestedRawValue(translations, key)
+		// A translation added meanwhile is never overwritten
+		if (value != nil && value != "") || translated == "" ||
+			!reflect.DeepEqual(templateActions(translated), templateActions(texts[key])) ||
+			!setNestedValue(translations, key, translated) {
+			report.Skipped = append(report.Skipped, key)
+			continue
+		}
+		report.Translated = append(report.Translated, key)
+		machineTranslated = append(machineTranslated, key)
+	}
+	if len(report.Translated) == 0 {
+		m.mu.Unlock()
+		sort.Strings(report.Skipped)
+		return report, nil
+	}
+
+	sort.Strings(machineTranslated)
+	metadata, _ := variantTable(translations[MetadataKey])
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		translations[MetadataKey] = metadata
+	}
+	metadata[metadataMachineTranslated] = machineTranslated
+
+	if m.localesDir != "" {
+		path, format := m.localeFile(targetLocale)
+		if path == "" {
+			path = filepath.Join(m.localesDir, targetLocale+".toml")
+			if format == FormatYAML {
+				path = filepath.Join(m.localesDir, targetLocale+".yaml")
+			}
+		}
+		if err := writeLocaleFile(path, format, translations); err != nil {
+			m.mu.Unlock()
+			return nil, mdwerror.Wrap(err, "failed to write machine translations").
+				WithCode(mdwerror.CodeInvalidOperation).
+				WithOperation("i18n.Backfill").
+				WithDetail("path", path)
+		}
+	}
+	m.translations[targetLocale] = translations
+	watchers := append([]LocaleChangeHandler(nil), m.watchers...)
+	snapshot := m.deepCopyTranslations(translations)
+	m.mu.Unlock()
+
+	m.clearTemplateCache(targetLocale)
+	for _, handler := range watchers {
+		if handler != nil {
+			go handler(targetLocale, snapshot)
+		}
+	}
+
+	sort.Strings(report.Skipped)
+	return report, nil
+}
+
+// IsMachineTranslated reports whether key of locale is listed as
+// machine-translated and not yet reviewed
+func (m *Manager) IsMachineTranslated(key, locale string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, machineTranslated := range machineTranslatedKeys(m.translations[locale]) {
+		if machineTranslated == key {
+			return true
+		}
+	}
+	return false
+}
+
+// MachineTranslatedKeys returns the keys of locale listed as
+// machine-translated
+func (m *Manager) MachineTranslatedKeys(locale string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return machineTranslatedKeys(m.translations[locale])
+}
+
+// untranslatedMessages returns the default locale's messages missing or
+// empty in translations, and the untranslated keys that are no plain
+// messages (plural, variant, and revision tables)
+func (m *Manager) untranslatedMessages(defaults, translations map[string]interface{}) (map[string]string, []string) {
+	texts := make(map[string]string)
+	var skipped []string
+	for _, key := range m.collectKeys(defaults, "") {
+		if value := m.getNestedRawValue(translations, key); value != nil && value != "" {
+			continue
+		}
+		if text, ok := m.getNestedRawValue(defaults, key).(string); ok {
+			if text != "" {
+				texts[key] = text
+			}
+			continue
+		}
+		skipped = append(skipped, key)
+	}
+	return texts, skipped
+}
+
+// machineTranslatedKeys returns the machine-translated keys listed in the
+// metadata of translations
+func machineTranslatedKeys(translations map[string]interface{}) []string {
+	metadata, _ := variantTable(translations[MetadataKey])
+	var keys []string
+	switch list := metadata[metadataMachineTranslated].(type) {
+	case []string:
+		keys = append(keys, list...)
+	case []interface{}:
+		for _, key := range list {
+			if s, ok := key.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+	}
+	return keys
+}
+
+// templateActionPattern matches template actions such as {{.Name}}
+var templateActionPattern = regexp.MustCompile(`\{\{-?\s*(.*?)\s*-?\}\}`)
+
+// templateActions returns the sorted template actions of a message
+func templateActions(text string) []string {
+	var actions []string
+	for _, match := range templateActionPattern.FindAllStringSubmatch(text, -1) {
+		actions = append(actions, match[1])
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// sortedKeys returns the keys of texts in sorted order
+func sortedKeys(texts map[string]string) []string {
+	keys := make([]string, 0, len(texts))
+	for key := range texts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TuringClient sends a chat prompt to the Turing LLM service and returns the
+// reply. Services implement it with the generated gRPC client.
+type TuringClient interface {
+	Chat(ctx context.Context, systemPrompt, prompt string) (string, error)
+}
+
+// TuringTranslator is an MTProvider backed by the Turing LLM service
+type TuringTranslator struct {
+	Client    TuringClient
+	BatchSize int // Messages per Chat call (default: 50)
+}
+
+// NewTuringTranslator creates a Turing-backed machine translator
+func NewTuringTranslator(client TuringClient) *TuringTranslator {
+	return &TuringTranslator{Client: client, BatchSize: 50}
+}
+
+// turingSystemPrompt instructs the model to translate a JSON object
+const turingSystemPrompt = `You translate user interface messages. The user sends a JSON object mapping message keys to messages in the source locale. Reply with a JSON object mapping the same keys to the messages translated into the target locale, and nothing else. Keep template actions such as {{.Name}} or {{.Amount | currency}} unchanged, and keep leading and trailing whitespace and punctuation style.`
+
+// Translate translates texts in batches, one Chat call per batch
+func (t *TuringTranslator) Translate(ctx context.Context, sourceLocale, targetLocale string, texts map[string]string) (map[string]string, error) {
+	batchSize := t.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+
+	keys := sortedKeys(texts)
+	results := make(map[string]string, len(texts))
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := make(map[string]string, end-start)
+		for _, key := range keys[start:end] {
+			batch[key] = texts[key]
+		}
+		payload, err := json.MarshalIndent(batch, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		prompt := fmt.Sprintf("Source locale: %s\nTarget locale: %s\n\n%s", sourceLocale, targetLocale, payload)
+		reply, err := t.Client.Chat(ctx, turingSystemPrompt, prompt)
+		if err != nil {
+			return nil, mdwerror.Wrap(err, "Turing chat request failed").
+				WithCode(mdwerror.CodeExternalServiceError).
+				WithOperation("i18n.TuringTranslator.Translate")
+		}
+
+		translated, err := parseTuringReply(reply)
+		if err != nil {
+			return nil, mdwerror.Wrap(err, "invalid Turing translation reply").
+				WithCode(mdwerror.CodeInvalidFormat).
+				WithOperation("i18n.TuringTranslator.Translate")
+		}
+		for key, text := range translated {
+			if _, requested := batch[key]; requested {
+				results[key] = text
+			}
+		}
+	}
+	return results, nil
+}
+
+// parseTuringReply extracts the JSON object of a model reply, which may be
+// wrapped in prose or a Markdown code fence
+func parseTuringReply(reply string) (map[string]string, error) {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in reply")
+	}
+	var translated map[string]string
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &translated); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
@@ -0,0 +1,223 @@
+// File: backfill_test.go
+// Title: Machine-Translation Backfill Tests
+// Description: Tests backfilling untranslated messages, machine-translation
+//              metadata, protection of human translations, and the Turing
+//              translator's batching and reply parsing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial backfill tests
+
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeMTProvider translates by prefixing messages with the target locale
+type fakeMTProvider struct {
+	requests []map[string]string
+	results  map[string]string // Fixed results overriding the prefixing
+	err      error
+}
+
+func (p *fakeMTProvider) Translate(ctx context.Context, sourceLocale, targetLocale string, texts map[string]string) (map[string]string, error) {
+	p.requests = append(p.requests, texts)
+	if p.err != nil {
+		return nil, p.err
+	}
+	results := make(map[string]string)
+	for key, text := range texts {
+		results[key] = "[" + targetLocale + "] " + text
+		if fixed, exists := p.results[key]; exists {
+			results[key] = fixed
+		}
+	}
+	return results, nil
+}
+
+// newBackfillManager creates a manager with a partly translated German file
+func newBackfillManager(t *testing.T) (*Manager, string) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": `
+greeting = "Hello, {{.Name}}"
+farewell = "Goodbye"
+files = ["{{.Count}} file", "{{.Count}} files"]
+
+[errors]
+timeout = "Request timed out"
+denied = "Access denied for {{.User}}"
+`,
+		"de.toml": `
+greeting = "Hallo, {{.Name}}"
+farewell = ""
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return manager, tempDir
+}
+
+func TestBackfill(t *testing.T) {
+	manager, tempDir := newBackfillManager(t)
+	provider := &fakeMTProvider{results: map[string]string{"errors.denied": "Zugriff verweigert"}}
+
+	report, err := manager.Backfill(context.Background(), provider, "de")
+	if err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if len(provider.requests) != 1 || len(provider.requests[0]) != 3 || provider.requests[0]["greeting"] != "" {
+		t.Errorf("provider requests = %v", provider.requests)
+	}
+	if !reflect.DeepEqual(report.Translated, []string{"errors.timeout", "farewell"}) {
+		t.Errorf("Translated = %v", report.Translated)
+	}
+	// Plural messages are no plain messages; changed template actions are rejected
+	if !reflect.DeepEqual(report.Skipped, []string{"errors.denied", "files"}) {
+		t.Errorf("Skipped = %v", report.Skipped)
+	}
+
+	if got := manager.TInLocale("de", "farewell"); got != "[de] Goodbye" {
+		t.Errorf("backfilled message = %q", got)
+	}
+	if got := manager.TInLocale("de", "greeting", map[string]interface{}{"Name": "Ada"}); got != "Hallo, Ada" {
+		t.Errorf("human translation = %q", got)
+	}
+	if !manager.IsMachineTranslated("farewell", "de") || manager.IsMachineTranslated("greeting", "de") {
+		t.Error("machine-translation metadata not recorded")
+	}
+	manager.SetLocale("de")
+	if keys := manager.GetTranslationKeys(); !reflect.DeepEqual(keys, []string{"errors.timeout", "farewell", "greeting"}) {
+		t.Errorf("GetTranslationKeys() = %v", keys)
+	}
+
+	// The language file keeps the results and metadata across reloads
+	content, err := os.ReadFile(filepath.Join(tempDir, "de.toml"))
+	if err != nil || !strings.Contains(string(content), "machine_translated") {
+		t.Fatalf("language file not updated: %s", content)
+	}
+	reloaded, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !reflect.DeepEqual(reloaded.MachineTranslatedKeys("de"), []string{"errors.timeout", "farewell"}) {
+		t.Errorf("MachineTranslatedKeys() = %v", reloaded.MachineTranslatedKeys("de"))
+	}
+
+	// A second run only requests the remaining key and keeps the metadata
+	provider.results = nil
+	report, err = reloaded.Backfill(context.Background(), provider, "de")
+	if err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Translated, []string{"errors.denied"}) || len(provider.requests[1]) != 1 {
+		t.Errorf("second run Translated = %v, requests = %v", report.Translated, provider.requests[1])
+	}
+	if !reflect.DeepEqual(reloaded.MachineTranslatedKeys("de"), []string{"errors.denied", "errors.timeout", "farewell"}) {
+		t.Errorf("MachineTranslatedKeys() = %v", reloaded.MachineTranslatedKeys("de"))
+	}
+}
+
+func TestBackfill_NewLocale(t *testing.T) {
+	manager, tempDir := newBackfillManager(t)
+
+	report, err := manager.Backfill(context.Background(), &fakeMTProvider{}, "fr")
+	if err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if len(report.Translated) != 4 || !manager.HasLocale("fr") {
+		t.Errorf("Translated = %v", report.Translated)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "fr.toml")); err != nil {
+		t.Errorf("language file not created: %v", err)
+	}
+}
+
+func TestBackfill_Errors(t *testing.T) {
+	manager, tempDir := newBackfillManager(t)
+	before, _ := os.ReadFile(filepath.Join(tempDir, "de.toml"))
+
+	if _, err := manager.Backfill(context.Background(), &fakeMTProvider{}, "en"); err == nil {
+		t.Error("backfill of the default locale did not fail")
+	}
+	if _, err := manager.Backfill(context.Background(), &fakeMTProvider{err: errors.New("unavailable")}, "de"); err == nil {
+		t.Error("provider error not returned")
+	}
+	if after, _ := os.ReadFile(filepath.Join(tempDir, "de.toml")); string(after) != string(before) {
+		t.Error("failed backfill changed the language file")
+	}
+	if manager.IsMachineTranslated("farewell", "de") {
+		t.Error("failed backfill recorded metadata")
+	}
+}
+
+// fakeTuringClient answers with the messages of the prompt upper-cased,
+// wrapped in a Markdown code fence
+type fakeTuringClient struct {
+	prompts []string
+	reply   string
+}
+
+func (c *fakeTuringClient) Chat(ctx context.Context, systemPrompt, prompt string) (string, error) {
+	c.prompts = append(c.prompts, prompt)
+	if c.reply != "" {
+		return c.reply, nil
+	}
+	var texts map[string]string
+	if err := json.Unmarshal([]byte(prompt[strings.Index(prompt, "{"):]), &texts); err != nil {
+		return "", err
+	}
+	for key, text := range texts {
+		texts[key] = strings.ToUpper(text)
+	}
+	reply, _ := json.Marshal(texts)
+	return "Here you go:\n```json\n" + string(reply) + "\n```", nil
+}
+
+func TestTuringTranslator(t *testing.T) {
+	client := &fakeTuringClient{}
+	translator := NewTuringTranslator(client)
+	translator.BatchSize = 2
+
+	results, err := translator.Translate(context.Background(), "en", "de", map[string]string{
+		"a": "one", "b": "two", "c": "three",
+	})
+	if err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if len(client.prompts) != 2 || !strings.Contains(client.prompts[0], "Target locale: de") {
+		t.Errorf("prompts = %q", client.prompts)
+	}
+	if !reflect.DeepEqual(results, map[string]string{"a": "ONE", "b": "TWO", "c": "THREE"}) {
+		t.Errorf("Translate() = %v", results)
+	}
+
+	client.reply = `{"a": "eins", "x": "unrequested"}`
+	results, err = translator.Translate(context.Background(), "en", "de", map[string]string{"a": "one"})
+	if err != nil || !reflect.DeepEqual(results, map[string]string{"a": "eins"}) {
+		t.Errorf("Translate() = %v, %v", results, err)
+	}
+
+	client.reply = "I cannot translate this."
+	if _, err := translator.Translate(context.Background(), "en", "de", map[string]string{"a": "one"}); err == nil {
+		t.Error("reply without JSON did not fail")
+	}
+}
@@ -0,0 +1,283 @@
+// File: bundle.go
+// Title: Compiled Binary Translation Bundles
+// Description: Compiles TOML and YAML language files into compact binary
+//              bundles (gob) that load without text parsing. Compilation
+//              validates all message templates and stores templates made of
+//              plain field references pre-parsed, so they render without
+//              text/template. Managers and providers load bundles and source
+//              files transparently.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of compiled bundles
+
+package i18n
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// BundleExtension is the file extension of compiled bundles
+const BundleExtension = ".gob"
+
+// bundleMagic starts every compiled bundle; the last byte is the version
+var bundleMagic = []byte("MDWI18N\x01")
+
+// compiledBundle is the gob payload of a compiled bundle
+type compiledBundle struct {
+	Locale    string
+	Messages  map[string]interface{}       // Translation tree as parsed from the source
+	Templates map[string][]templateSegment // Template text -> pre-parsed segments
+}
+
+// templateSegment is literal text or a reference to a data field
+type templateSegment struct {
+	Text  string
+	Field string // Name of the data field; Text is ignored if set
+}
+
+func init() {
+	// Concrete types of the interface values in translation trees
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register([]map[string]interface{}{})
+	gob.Register(time.Time{})
+}
+
+// CompileBundle compiles the translations of locale into a binary bundle.
+// It fails if a message template does not parse.
+func CompileBundle(locale string, translations map[string]interface{}) ([]byte, error) {
+	bundle := compiledBundle{Locale: locale, Templates: make(map[string][]templateSegment)}
+	messages, err := compileMessages(locale, "", translations, bundle.Templates)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Messages = messages
+
+	buffer := bytes.NewBuffer(append([]byte(nil), bundleMagic...))
+	if err := gob.NewEncoder(buffer).Encode(&bundle); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to encode bundle").
+			WithCode(mdwerror.CodeInternal).
+			WithOperation("i18n.CompileBundle").
+			WithDetail("locale", locale)
+	}
+	return buffer.Bytes(), nil
+}
+
+// CompileDir compiles all TOML and YAML language files of srcDir into
+// bundles in dstDir and returns the compiled locales
+func CompileDir(srcDir, dstDir string) ([]string, error) {
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to read locales directory").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("i18n.CompileDir").
+			WithDetail("directory", srcDir)
+	}
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to create bundle directory").
+			WithCode(mdwerror.CodeInvalidOperation).
+			WithOperation("i18n.CompileDir").
+			WithDetail("directory", dstDir)
+	}
+
+	var compiled []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		format := formatForExtension(ext)
+		if entry.IsDir() || (format != FormatTOML && format != FormatYAML) {
+			continue
+		}
+		locale := strings.TrimSuffix(entry.Name(), ext)
+		source := filepath.Join(srcDir, entry.Name())
+
+		content, err := os.ReadFile(source)
+		if err != nil {
+			return compiled, fmt.Errorf("failed to read locale file %s: %w", source, err)
+		}
+		translations, err := parseTranslations(content, format, source)
+		if err != nil {
+			return compiled, err
+		}
+		data, err := CompileBundle(locale, translations)
+		if err != nil {
+			return compiled, err
+		}
+		if err := mdwfilex.WriteFileAtomic(filepath.Join(dstDir, locale+BundleExtension), data, 0644); err != nil {
+			return compiled, mdwerror.Wrap(err, "failed to write bundle").
+				WithCode(mdwerror.CodeInvalidOperation).
+				WithOperation("i18n.CompileDir").
+				WithDetail("locale", locale)
+		}
+		compiled = append(compiled, locale)
+	}
+	sort.Strings(compiled)
+	return compiled, nil
+}
+
+// compileMessages copies a translation tree for encoding, dropping empty
+// values, and validates and pre-parses the templates of all messages
+func compileMessages(locale, prefix string, data map[string]interface{}, templates map[string][]templateSegment) (map[string]interface{}, error) {
+	messages := make(map[string]interface{}, len(data))
+	for name, value := range data {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			nested, err := compileMessages(locale, key, v, templates)
+			if err != nil {
+				return nil, err
+			}
+			messages[name] = nested
+		case TranslationData:
+			nested, err := compileMessages(locale, key, v, templates)
+			if err != nil {
+				return nil, err
+			}
+			messages[name] = nested
+		case []interface{}:
+			for _, item := range v {
+				if text, ok := item.(string); ok {
+					if err := compileTemplateText(locale, key, text, templates); err != nil {
+						return nil, err
+					}
+				}
+			}
+			messages[name] = v
+		case string:
+			if err := compileTemplateText(locale, key, v, templates); err != nil {
+				return nil, err
+			}
+			messages[name] = v
+		default:
+			messages[name] = v
+		}
+	}
+	return messages, nil
+}
+
+// compileTemplateText validates a message template and records its
+// segments if it consists of literal text and field references only
+func compileTemplateText(locale, key, text string, templates map[string][]templateSegment) error {
+	if !strings.Contains(text, "{{") {
+		return nil
+	}
+	tmpl, err := template.New(key).Funcs(templateFuncs(locale)).Parse(text)
+	if err != nil {
+		return mdwerror.Wrap(err, "invalid message template").
+			WithCode(mdwerror.CodeInvalidFormat).
+			WithOperation("i18n.CompileBundle").
+			WithDetail("locale", locale).
+			WithDetail("key", key)
+	}
+	if segments, ok := templateSegments(tmpl.Tree); ok {
+		templates[text] = segments
+	}
+	return nil
+}
+
+// templateSegments splits a parsed template into segments; ok is false if
+// the template uses anything but literal text and {{.Field}} actions
+func templateSegments(tree *parse.Tree) ([]templateSegment, bool) {
+	if tree == nil || tree.Root == nil {
+		return nil, false
+	}
+	var segments []templateSegment
+	for _, node := range tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			segments = append(segments, templateSegment{Text: string(n.Text)})
+		case *parse.ActionNode:
+			if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+				return nil, false
+			}
+			field, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+			if !ok || len(field.Ident) != 1 {
+				return nil, false
+			}
+			segments = append(segments, templateSegment{Field: field.Ident[0]})
+		default:
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// renderSegments renders pre-parsed segments like text/template would
+// render the template with a data map
+func renderSegments(segments []templateSegment, data map[string]interface{}) string {
+	var result strings.Builder
+	for _, segment := range segments {
+		if segment.Field == "" {
+			result.WriteString(segment.Text)
+			continue
+		}
+		value, exists := data[segment.Field]
+		if !exists {
+			result.WriteString("<no value>")
+			continue
+		}
+		fmt.Fprint(&result, value)
+	}
+	return result.String()
+}
+
+// isBundle reports whether content is a compiled bundle
+func isBundle(content []byte) bool {
+	return bytes.HasPrefix(content, bundleMagic)
+}
+
+// decodeBundle decodes a compiled bundle
+func decodeBundle(content []byte, source string) (*compiledBundle, error) {
+	if !isBundle(content) {
+		return nil, fmt.Errorf("invalid or unsupported bundle %s", source)
+	}
+	var bundle compiledBundle
+	if err := gob.NewDecoder(bytes.NewReader(content[len(bundleMagic):])).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode bundle %s: %w", source, err)
+	}
+	return &bundle, nil
+}
+
+// parseLocale parses language file content of any format. The pre-parsed
+// templates of compiled bundles are added to the manager's segment cache.
+func (m *Manager) parseLocale(content []byte, format Format, source string) (TranslationData, error) {
+	if format != FormatBinary {
+		return parseTranslations(content, format, source)
+	}
+
+	bundle, err := decodeBundle(content, source)
+	if err != nil {
+		return nil, err
+	}
+	m.templateMu.Lock()
+	for text, segments := range bundle.Templates {
+		m.segments[text] = segments
+	}
+	m.templateMu.Unlock()
+
+	if bundle.Messages == nil {
+		return TranslationData{}, nil
+	}
+	return TranslationData(bundle.Messages), nil
+}
@@ -0,0 +1,200 @@
+// File: bundle_test.go
+// Title: Compiled Bundle Tests
+// Description: Tests bundle compilation, template validation, loading of
+//              bundles by managers and providers, and compares load times of
+//              source files and bundles.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial bundle tests
+
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// bundleSource is a language file using all kinds of messages
+const bundleSource = `
+greeting = "Hello, {{.Name}}!"
+total = "Total: {{.Amount | currency}}"
+files = ["{{.Count}} file", "{{.Count}} files"]
+
+[items]
+one = "{{.Count}} item"
+other = "{{.Count}} items"
+
+[checkout.cta]
+_active = "v2"
+v1 = "Buy now"
+v2 = "Complete purchase"
+`
+
+// writeLocales writes language files to a new directory
+func writeLocales(t testing.TB, files map[string]string) string {
+	tempDir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tempDir
+}
+
+func TestCompileDir(t *testing.T) {
+	srcDir := writeLocales(t, map[string]string{
+		"en.toml":   bundleSource,
+		"de.yaml":   "greeting: \"Hallo, {{.Name}}!\"\n",
+		"notes.txt": "not a language file",
+	})
+	dstDir := filepath.Join(t.TempDir(), "bundles")
+
+	compiled, err := CompileDir(srcDir, dstDir)
+	if err != nil {
+		t.Fatalf("CompileDir() error = %v", err)
+	}
+	if !reflect.DeepEqual(compiled, []string{"de", "en"}) {
+		t.Errorf("CompileDir() = %v", compiled)
+	}
+
+	source, err := New(Options{DefaultLocale: "en", LocalesDir: srcDir, Format: FormatAuto})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: dstDir, Format: FormatBinary})
+	if err != nil {
+		t.Fatalf("New() with bundles error = %v", err)
+	}
+
+	data := map[string]interface{}{"Name": "Ada", "Amount": 12.5, "Count": 2}
+	for _, key := range []string{"greeting", "total", "checkout.cta"} {
+		if got, want := manager.T(key, data), source.T(key, data); got != want {
+			t.Errorf("T(%s) = %q, want %q", key, got, want)
+		}
+	}
+	for _, key := range []string{"files", "items"} {
+		if got, want := manager.Plural(key, 1, map[string]interface{}{"Count": 1}), source.Plural(key, 1, map[string]interface{}{"Count": 1}); got != want {
+			t.Errorf("Plural(%s) = %q, want %q", key, got, want)
+		}
+	}
+	if got := manager.TInLocale("de", "greeting", data); got != "Hallo, Ada!" {
+		t.Errorf("TInLocale(de) = %q", got)
+	}
+	if got := manager.T("greeting", map[string]interface{}{}); got != source.T("greeting", map[string]interface{}{}) {
+		t.Errorf("missing field rendered as %q", got)
+	}
+	if !reflect.DeepEqual(manager.GetTranslationKeys(), source.GetTranslationKeys()) {
+		t.Errorf("GetTranslationKeys() = %v", manager.GetTranslationKeys())
+	}
+}
+
+func TestCompileBundle_Templates(t *testing.T) {
+	data, err := CompileBundle("en", map[string]interface{}{
+		"plain":   "Hello, {{.Name}}!",
+		"complex": "{{if .Admin}}Admin{{else}}User{{end}}",
+		"text":    "No template",
+	})
+	if err != nil {
+		t.Fatalf("CompileBundle() error = %v", err)
+	}
+	bundle, err := decodeBundle(data, "en")
+	if err != nil {
+		t.Fatalf("decodeBundle() error = %v", err)
+	}
+	// Only templates of field references are pre-parsed
+	expected := map[string][]templateSegment{
+		"Hello, {{.Name}}!": {{Text: "Hello, "}, {Field: "Name"}, {Text: "!"}},
+	}
+	if !reflect.DeepEqual(bundle.Templates, expected) {
+		t.Errorf("Templates = %v", bundle.Templates)
+	}
+
+	if _, err := CompileBundle("en", map[string]interface{}{"broken": "Hello, {{.Name"}); err == nil || !strings.Contains(err.Error(), "invalid message template") {
+		t.Errorf("invalid template error = %v", err)
+	}
+	if _, err := decodeBundle([]byte("greeting = \"Hello\""), "en"); err == nil {
+		t.Error("source file decoded as bundle")
+	}
+}
+
+func TestBundle_TransparentLoading(t *testing.T) {
+	// A bundle is used when no source file of the configured format exists
+	data, err := CompileBundle("de", map[string]interface{}{"greeting": "Hallo, {{.Name}}!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tempDir := writeLocales(t, map[string]string{"en.toml": "greeting = \"Hello, {{.Name}}!\"\n", "de.gob": string(data)})
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := manager.TInLocale("de", "greeting", map[string]interface{}{"Name": "Ada"}); got != "Hallo, Ada!" {
+		t.Errorf("TInLocale(de) = %q", got)
+	}
+
+	provider := NewFSProvider(fstest.MapFS{
+		"locales/en.gob":  {Data: data},
+		"locales/en.toml": {Data: []byte("greeting = \"Hello\"\n")},
+	}, "locales")
+	locales, err := provider.Locales(context.Background())
+	if err != nil || !reflect.DeepEqual(locales, []string{"en"}) {
+		t.Errorf("Locales() = %v, %v", locales, err)
+	}
+	if format := formatForExtension(BundleExtension); format != FormatBinary || format.String() != "binary" {
+		t.Errorf("formatForExtension(%s) = %v", BundleExtension, format)
+	}
+}
+
+// benchmarkLoadLocale measures loading one locale from the given file
+func benchmarkLoadLocale(b *testing.B, name string, content []byte, format Format) {
+	tempDir := writeLocales(b, map[string]string{name: string(content)})
+	manager := &Manager{localesDir: tempDir, format: format, translations: make(map[string]map[string]interface{}),
+		segments: make(map[string][]templateSegment), templateMu: &sync.Mutex{}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := manager.loadLocale("en"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largeLocale returns a language file with many messages
+func largeLocale() string {
+	var builder strings.Builder
+	for section := 0; section < 20; section++ {
+		fmt.Fprintf(&builder, "[section%d]\n", section)
+		for message := 0; message < 25; message++ {
+			fmt.Fprintf(&builder, "message%d = \"Message %d of {{.Name}} in section %d\"\n", message, message, section)
+		}
+	}
+	return builder.String()
+}
+
+func BenchmarkLoadLocale_TOML(b *testing.B) {
+	benchmarkLoadLocale(b, "en.toml", []byte(largeLocale()), FormatTOML)
+}
+
+func BenchmarkLoadLocale_Binary(b *testing.B) {
+	translations, err := parseTranslations([]byte(largeLocale()), FormatTOML, "en.toml")
+	if err != nil {
+		b.Fatal(err)
+	}
+	data, err := CompileBundle("en", translations)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkLoadLocale(b, "en.gob", data, FormatBinary)
+}
@@ -0,0 +1,52 @@
+// File: main.go
+// Title: Translation Bundle Compilation Command
+// Description: Command line entry point for i18n.CompileDir, meant for
+//              go:generate directives and release builds. Compiles the TOML
+//              and YAML language files of a directory into binary bundles.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the compilation command
+
+// Command i18n-compile compiles language files into binary bundles that
+// load faster than TOML or YAML.
+//
+// Usage:
+//
+//	//go:generate go run github.com/msto63/mDW/foundation/core/i18n/cmd/i18n-compile -locales ./locales -out ./locales
+//
+// Flags:
+//
+//	-locales  directory containing the language files (default "./locales")
+//	-out      directory for the bundles (default: the locales directory)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/msto63/mDW/foundation/core/i18n"
+)
+
+func main() {
+	locales := flag.String("locales", "./locales", "directory containing the language files")
+	out := flag.String("out", "", "directory for the bundles (default: the locales directory)")
+	flag.Parse()
+
+	if *out == "" {
+		*out = *locales
+	}
+
+	compiled, err := i18n.CompileDir(*locales, *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "i18n-compile: %v\n", err)
+		os.Exit(1)
+	}
+	for _, locale := range compiled {
+		fmt.Printf("%s%s\n", locale, i18n.BundleExtension)
+	}
+}
@@ -0,0 +1,73 @@
+// File: main.go
+// Title: Translation Key Extraction Command
+// Description: Command line entry point for i18n.Extract, meant for
+//              go:generate directives and CI checks. Prints the key report
+//              and optionally writes stub entries for missing keys.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the extraction command
+
+// Command i18n-extract reports translation keys that are missing, unused, or
+// untranslated in the language files.
+//
+// Usage:
+//
+//	//go:generate go run github.com/msto63/mDW/foundation/core/i18n/cmd/i18n-extract -src . -locales ./locales
+//
+// Flags:
+//
+//	-src      comma-separated source directories (default ".")
+//	-locales  directory containing the language files (default "./locales")
+//	-default  default locale (default "en")
+//	-format   language file format, toml or yaml (default "toml")
+//	-tests    also scan _test.go files
+//	-stubs    write stub entries for missing keys
+//	-strict   exit with status 1 if keys are missing or untranslated
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/msto63/mDW/foundation/core/i18n"
+)
+
+func main() {
+	sources := flag.String("src", ".", "comma-separated source directories")
+	locales := flag.String("locales", "./locales", "directory containing the language files")
+	defaultLocale := flag.String("default", "en", "default locale")
+	formatName := flag.String("format", "toml", "language file format, toml or yaml")
+	includeTests := flag.Bool("tests", false, "also scan _test.go files")
+	writeStubs := flag.Bool("stubs", false, "write stub entries for missing keys")
+	strict := flag.Bool("strict", false, "exit with status 1 if keys are missing or untranslated")
+	flag.Parse()
+
+	format := i18n.FormatTOML
+	if *formatName == "yaml" {
+		format = i18n.FormatYAML
+	}
+
+	report, err := i18n.Extract(i18n.ExtractOptions{
+		SourceDirs:    strings.Split(*sources, ","),
+		LocalesDir:    *locales,
+		DefaultLocale: *defaultLocale,
+		Format:        format,
+		IncludeTests:  *includeTests,
+		WriteStubs:    *writeStubs,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "i18n-extract: %v\n", err)
+		os.Exit(2)
+	}
+
+	fmt.Print(report.String())
+	if *strict && report.HasIssues() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,111 @@
+// File: context_test.go
+// Title: Request-Scoped Translation Tests
+// Description: Tests context propagation of locales and translators, the
+//              HTTP middleware, and the gRPC metadata helper.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial context tests
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// newContextManager creates a manager with English and German greetings
+func newContextManager(t *testing.T) *Manager {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": "greeting = \"Hello, {{.Name}}\"\nfiles = [\"{{.Count}} file\", \"{{.Count}} files\"]\n",
+		"de.toml": "greeting = \"Hallo, {{.Name}}\"\nfiles = [\"{{.Count}} Datei\", \"{{.Count}} Dateien\"]\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return manager
+}
+
+func TestContext(t *testing.T) {
+	manager := newContextManager(t)
+	ctx := context.Background()
+
+	if FromContext(ctx) != nil || LocaleFromContext(ctx) != "" || manager.FromContext(ctx) != manager {
+		t.Error("empty context carries a translator")
+	}
+
+	localeOnly := NewContext(ctx, "de")
+	if FromContext(localeOnly) != nil || LocaleFromContext(localeOnly) != "de" {
+		t.Error("NewContext() did not store the locale only")
+	}
+	if got := manager.FromContext(localeOnly).T("greeting", map[string]interface{}{"Name": "Ada"}); got != "Hallo, Ada" {
+		t.Errorf("translator for locale-only context = %q", got)
+	}
+
+	scoped := manager.NewContext(ctx, "de")
+	translator := FromContext(scoped)
+	if translator == nil || translator.GetCurrentLocale() != "de" {
+		t.Fatal("NewContext() did not store a translator")
+	}
+	if got := translator.Plural("files", 2, map[string]interface{}{"Count": 2}); got != "2 Dateien" {
+		t.Errorf("Plural() = %q", got)
+	}
+	if manager.GetCurrentLocale() != "en" {
+		t.Error("request-scoped translator changed the shared locale")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	manager := newContextManager(t)
+	handler := manager.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(FromContext(r.Context()).T("greeting", map[string]interface{}{"Name": "Ada"})))
+	}))
+
+	var wg sync.WaitGroup
+	for _, tt := range []struct{ header, expected, locale string }{
+		{"de-DE,de;q=0.9,en;q=0.8", "Hallo, Ada", "de"},
+		{"fr-FR,en;q=0.5", "Hello, Ada", "en"},
+		{"", "Hello, Ada", "en"},
+	} {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(header, expected, locale string) {
+				defer wg.Done()
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set("Accept-Language", header)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+				if recorder.Body.String() != expected || recorder.Header().Get("Content-Language") != locale {
+					t.Errorf("Accept-Language %q: body %q, Content-Language %q", header, recorder.Body.String(), recorder.Header().Get("Content-Language"))
+				}
+			}(tt.header, tt.expected, tt.locale)
+		}
+	}
+	wg.Wait()
+}
+
+func TestGRPCContext(t *testing.T) {
+	manager := newContextManager(t)
+	ctx := manager.GRPCContext(context.Background(), map[string][]string{MetadataKeyAcceptLanguage: {"de"}})
+	if LocaleFromContext(ctx) != "de" || FromContext(ctx).T("greeting", map[string]interface{}{"Name": "Ada"}) != "Hallo, Ada" {
+		t.Errorf("GRPCContext() locale = %q", LocaleFromContext(ctx))
+	}
+	if ctx := manager.GRPCContext(context.Background(), nil); LocaleFromContext(ctx) != "en" {
+		t.Errorf("GRPCContext() without metadata = %q", LocaleFromContext(ctx))
+	}
+}
@@ -5,12 +5,22 @@
 //              rules, locale detection, translation templates, and runtime language
 //              switching capabilities.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.10
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
+// - 2026-10-16 v0.1.1: Added translation key extraction and reporting
+// - 2026-10-16 v0.1.2: Added CLDR plural rules
+// - 2026-10-16 v0.1.3: Added locale-aware number, currency, and date formatting
+// - 2026-10-16 v0.1.4: Added locale fallback chains
+// - 2026-10-16 v0.1.5: Added bundle providers for embedded and remote sources
+// - 2026-10-16 v0.1.6: Added request-scoped translators with context propagation
+// - 2026-10-16 v0.1.7: Added select-style message variants
+// - 2026-10-16 v0.1.8: Added message revisions and A/B experiments
+// - 2026-10-16 v0.1.9: Added machine-translation backfill
+// - 2026-10-16 v0.1.10: Added compiled binary bundles
 
 /*
 Package i18n provides comprehensive internationalization and localization support for mDW applications.
@@ -21,12 +31,22 @@ Description: Provides comprehensive i18n/l10n capabilities for mDW applications
              with support for TOML and YAML language files, advanced pluralization,
              template interpolation, locale detection, and runtime language switching.
 Author: msto63 with Claude Sonnet 4.0
-Version: v0.1.0
+Version: v0.1.10
 Created: 2025-01-25
-Modified: 2025-01-25
+Modified: 2026-10-16
 
 Change History:
 - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
+- 2026-10-16 v0.1.1: Added translation key extraction and reporting
+- 2026-10-16 v0.1.2: Added CLDR plural rules
+- 2026-10-16 v0.1.3: Added locale-aware number, currency, and date formatting
+- 2026-10-16 v0.1.4: Added locale fallback chains
+- 2026-10-16 v0.1.5: Added bundle providers for embedded and remote sources
+- 2026-10-16 v0.1.6: Added request-scoped translators with context propagation
+- 2026-10-16 v0.1.7: Added select-style message variants
+- 2026-10-16 v0.1.8: Added message revisions and A/B experiments
+- 2026-10-16 v0.1.9: Added machine-translation backfill
+- 2026-10-16 v0.1.10: Added compiled binary bundles
 
 Key Features:
   • Multi-format language files (TOML, YAML) with automatic detection
@@ -173,6 +193,18 @@ Template interpolation with functions and complex data:
 	msg := i18nManager.T("ecommerce.order_total", orderData)
 	// Output: "Order Total: $129.99"
 
+Every template can use the built-in functions number, currency, and date,
+which format according to the locale being rendered:
+
+	# en.toml: total = "Total: {{.Amount | currency}} on {{.Date | date}}"
+	// en: "Total: $1,234.56 on May 1, 2026"
+	// de: "Summe: 1.234,56 € am 1. Mai 2026"
+
+The same formatting is available directly through FormatNumber,
+FormatDecimal, FormatCurrency, and FormatDate. Separators, grouping, the
+currency symbol and its position, and month names come from LocaleData;
+RegisterLocaleData adds or overrides the data of a language or region.
+
 # Comprehensive Pluralization
 
 Handle complex pluralization rules for different languages:
@@ -201,6 +233,71 @@ Handle complex pluralization rules for different languages:
 		"Count": 21,
 	})
 
+Plural selects a CLDR plural category (zero, one, two, few, many, other)
+from the count and the current locale. Messages with more than two forms
+are written as tables keyed by category; "other" is required and used for
+categories without an entry:
+
+	# ru.toml
+	[plurals.day_count]
+	one = "{{.Count}} день"
+	few = "{{.Count}} дня"
+	many = "{{.Count}} дней"
+	other = "{{.Count}} дня"
+
+Arrays remain supported. An array with one entry per category of the locale
+is indexed in CLDR order (see PluralCategories); any other array is read as
+singular and plural. Rules for further languages or regional variants can
+be added with RegisterPluralRule:
+
+	category := i18n.PluralCategoryFor("pl", 22) // i18n.PluralFew
+
+# Gender, Formality, and Grammatical Case
+
+Messages that depend on gender, formality, grammatical case, or any other
+dimension are written as nested variant tables. SelectVariant picks one
+level per selector and uses "other" where a selector has no entry; a plural
+table at the end of the path is resolved with data["Count"]:
+
+	# de.toml
+	[letter.salutation.formal]
+	female = "Sehr geehrte Frau {{.Name}}"
+	male = "Sehr geehrter Herr {{.Name}}"
+	other = "Guten Tag {{.Name}}"
+
+	msg := i18nManager.SelectVariant("letter.salutation", map[string]interface{}{
+		"Name": "Weber",
+	}, "formal", "female")
+	// Output: "Sehr geehrte Frau Weber"
+
+Locales with fewer levels ignore the remaining selectors, so an English
+file can provide a single "other" entry for the same key.
+
+# Message Revisions and Experiments
+
+A message can carry several revisions under one key. The "_active" entry
+names the revision in use; SetActiveRevision switches it at runtime:
+
+	[checkout.cta]
+	_active = "v1"
+	v1 = "Buy now"
+	v2 = "Complete purchase"
+
+	i18nManager.SetActiveRevision("checkout.cta", "v2")
+
+An ExperimentAssigner chooses revisions per user for wording tests. It
+receives the key, the user ID of the manager (see WithUserID), and the
+revision names; returning "" keeps the active revision. HashAssigner
+spreads users evenly and stably over all revisions:
+
+	i18nManager.SetExperimentAssigner(func(key, userID string, revisions []string) string {
+		if key != "checkout.cta" {
+			return ""
+		}
+		return i18n.HashAssigner("checkout-copy")(key, userID, revisions)
+	})
+	label := i18nManager.WithUserID(user.ID).T("checkout.cta")
+
 # Locale Management and Detection
 
 Advanced locale handling and automatic detection:
@@ -230,6 +327,30 @@ Advanced locale handling and automatic detection:
 	})
 	// Output: "Bienvenue, Pierre Dubois!" (if fr.toml exists)
 
+# Locale Fallback Chains
+
+A key missing in the requested locale is resolved per key along a fallback
+chain instead of falling straight back to the default locale. The chain is
+the requested locale, its configured fallbacks, its parent locales, and
+finally the default locale:
+
+	manager, _ := i18n.New(i18n.Options{
+		DefaultLocale: "en",
+		LocalesDir:    "./locales",
+		Fallbacks:     map[string][]string{"zh-Hant-TW": {"zh-Hant-HK"}},
+	})
+	manager.FallbackChain("zh-Hant-TW")
+	// ["zh-Hant-TW", "zh-Hant-HK", "zh-Hant", "zh", "en"]
+
+Resolve reports which locale supplied a key, and FallbackReport lists the
+supplier of every known key, which shows where a locale relies on others:
+
+	for _, r := range manager.FallbackReport("zh-Hant-TW") {
+		if r.IsFallback() {
+			fmt.Printf("%s from %s\n", r.Key, r.Locale)
+		}
+	}
+
 # Context-Aware Translations
 
 Organize translations by application domain and context:
@@ -313,6 +434,27 @@ Monitor language files for changes during development and production:
 		// Implement retry logic or fallback behavior
 	})
 
+# Embedded and Remote Bundles
+
+Instead of a locales directory, locale data can come from a LoaderProvider.
+FSProvider reads any fs.FS, so translations can ship inside the binary;
+HTTPProvider fetches <base>/<locale>.toml from a translation service; other
+sources such as a database table implement the two provider methods:
+
+	//go:embed locales/*.toml
+	var localeFiles embed.FS
+
+	manager, _ := i18n.New(i18n.Options{
+		DefaultLocale: "en",
+		Provider:      i18n.NewFSProvider(localeFiles, "locales"),
+	})
+
+Loaded bundles are cached with their ETag. Refresh (or ReloadAll) passes
+the ETag back to the provider and only reparses locales that changed;
+HTTPProvider sends it as If-None-Match. With Watch enabled, the manager
+refreshes every RefreshInterval. Locales that fail to refresh keep their
+cached translations, and change handlers are notified of updated locales.
+
 # Multi-Format Support
 
 Support for both TOML and YAML language files:
@@ -335,6 +477,51 @@ Support for both TOML and YAML language files:
 		Format:     i18n.FormatAuto,  // Detects .toml, .yaml, .yml
 	})
 
+# Compiled Bundles
+
+CompileDir compiles TOML and YAML language files into binary bundles
+(<locale>.gob) that load without text parsing. Compilation fails on invalid
+message templates, and templates made of plain {{.Field}} references are
+stored pre-parsed, so they render without text/template:
+
+	//go:generate go run github.com/msto63/mDW/foundation/core/i18n/cmd/i18n-compile -locales ./locales -out ./dist/locales
+
+Bundles load transparently: managers fall back to a bundle when no source
+file of the configured format exists, FormatBinary loads bundles only, and
+FSProvider serves them like language files.
+
+# Key Extraction and Missing-Key Reports
+
+Extract scans Go sources for keys passed to T, Plural, and related calls and
+compares them with the language files. The report lists per locale the keys
+that are missing, unused, or identical to the default locale:
+
+	report, err := i18n.Extract(i18n.ExtractOptions{
+		SourceDirs: []string{"./internal"},
+		LocalesDir: "./locales",
+		WriteStubs: true, // Add missing keys with the default text
+	})
+	fmt.Print(report.String())
+
+The i18n-extract command wraps Extract for go:generate and CI checks:
+
+	//go:generate go run github.com/msto63/mDW/foundation/core/i18n/cmd/i18n-extract -src . -locales ./locales -strict
+
+# Machine-Translation Backfill
+
+Backfill sends the messages of the default locale that are missing or empty
+in a locale to a machine-translation provider. Existing translations are
+never overwritten, and results that change template actions are rejected.
+Filled keys are listed in the "_meta" section of the language file until a
+reviewer removes them:
+
+	translator := i18n.NewTuringTranslator(turingClient) // Implements i18n.TuringClient
+	report, err := i18nManager.Backfill(ctx, translator, "fr")
+
+	if i18nManager.IsMachineTranslated("checkout.cta", "fr") {
+		// Flag the message for review
+	}
+
 # Integration with mDW Foundation
 
 Seamless integration with other mDW foundation modules:
@@ -379,28 +566,15 @@ Seamless integration with other mDW foundation modules:
 
 Complete web application internationalization:
 
-	// HTTP middleware for locale detection
-	func LocaleMiddleware(i18n *i18n.Manager) func(http.Handler) http.Handler {
-		return func(next http.Handler) http.Handler {
-			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				// Try locale from query parameter first
-				locale := r.URL.Query().Get("locale")
-				
-				// Fall back to Accept-Language header
-				if locale == "" {
-					locale = i18n.DetectLocale(r.Header.Get("Accept-Language"))
-				}
-				
-				// Create request-specific i18n context
-				ctx := context.WithValue(r.Context(), "i18n", i18n.WithLocale(locale))
-				next.ServeHTTP(w, r.WithContext(ctx))
-			})
-		}
-	}
+	// Middleware detects the locale from Accept-Language and stores a
+	// request-scoped translator in the request context
+	mux := http.NewServeMux()
+	mux.HandleFunc("/welcome", WelcomeHandler)
+	http.ListenAndServe(":8080", i18nManager.Middleware(mux))
 
 	// HTTP handler using i18n
 	func WelcomeHandler(w http.ResponseWriter, r *http.Request) {
-		i18nCtx := r.Context().Value("i18n").(*i18n.Manager)
+		i18nCtx := i18n.FromContext(r.Context())
 		userName := r.URL.Query().Get("name")
 		
 		if stringx.IsBlank(userName) {
@@ -419,6 +593,12 @@ Complete web application internationalization:
 		json.NewEncoder(w).Encode(response)
 	}
 
+For gRPC, interceptors pass the incoming metadata to GRPCContext, which
+resolves the accept-language entry the same way. Code without a manager at
+hand can propagate only the locale with i18n.NewContext(ctx, locale) and
+read it with LocaleFromContext; Manager.FromContext turns either form into
+a translator for the request.
+
 # Business Application Example
 
 Complete business application setup:
@@ -0,0 +1,362 @@
+// File: extract.go
+// Title: Translation Key Extraction and Reporting
+// Description: Scans Go sources for translation keys passed to T(), Plural(),
+//              and related calls, compares them against the locale files, and
+//              reports missing, unused, and untranslated keys per locale.
+//              Optionally writes stub entries for missing keys.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of key extraction and reporting
+// - 2026-10-16 v0.1.1: Also extract keys of SelectVariant calls
+// - 2026-10-16 v0.1.2: Moved language file writing to writeLocaleFile
+
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// DefaultExtractFunctions maps the names of functions and methods taking a
+// translation key to the index of the key argument
+var DefaultExtractFunctions = map[string]int{
+	"T":                        0,
+	"TryT":                     0,
+	"TWithFallback":            0,
+	"Plural":                   0,
+	"TInLocale":                1,
+	"TryTInLocale":             1,
+	"WithMessageKey":           0,
+	"SelectVariant":            0,
+	"SelectVariantInLocale":    1,
+	"TrySelectVariantInLocale": 1,
+}
+
+// ExtractOptions configure Extract
+type ExtractOptions struct {
+	SourceDirs    []string       // Directories scanned recursively (default: ".")
+	LocalesDir    string         // Directory containing language files
+	DefaultLocale string         // Default locale (default: "en")
+	Format        Format         // Language file format (default: TOML)
+	Functions     map[string]int // Calls to scan (default: DefaultExtractFunctions)
+	IncludeTests  bool           // Also scan _test.go files
+	WriteStubs    bool           // Add stub entries for missing keys to the locale files
+}
+
+// KeyUsage is a translation key and the source positions using it
+type KeyUsage struct {
+	Key       string
+	Positions []string // "file:line"
+}
+
+// LocaleReport lists the key issues of one locale
+type LocaleReport struct {
+	Locale       string
+	Missing      []string // Used in sources but not translated
+	Unused       []string // Translated but not used in sources
+	Untranslated []string // Identical to the default locale
+	Stubbed      []string // Stub entries written for missing keys
+}
+
+// ExtractReport is the result of Extract
+type ExtractReport struct {
+	Keys         []KeyUsage     // Keys found in sources, sorted
+	DynamicCalls []string       // Positions of calls with non-literal keys
+	Locales      []LocaleReport // Reports per locale, sorted by locale
+}
+
+// HasIssues reports whether any locale has missing or untranslated keys
+func (r *ExtractReport) HasIssues() bool {
+	for _, locale := range r.Locales {
+		if len(locale.Missing) > 0 || len(locale.Untranslated) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the report as text
+func (r *ExtractReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d keys used in sources\n", len(r.Keys))
+	if len(r.DynamicCalls) > 0 {
+		fmt.Fprintf(&b, "%d calls with dynamic keys (not checked):\n", len(r.DynamicCalls))
+		for _, position := range r.DynamicCalls {
+			fmt.Fprintf(&b, "  %s\n", position)
+		}
+	}
+	for _, locale := range r.Locales {
+		fmt.Fprintf(&b, "\n[%s] missing: %d, unused: %d, untranslated: %d\n",
+			locale.Locale, len(locale.Missing), len(locale.Unused), len(locale.Untranslated))
+		for _, section := range []struct {
+			name string
+			keys []string
+		}{
+			{"missing", locale.Missing},
+			{"unused", locale.Unused},
+			{"untranslated", locale.Untranslated},
+			{"stubbed", locale.Stubbed},
+		} {
+			for _, key := range section.keys {
+				fmt.Fprintf(&b, "  %-12s %s\n", section.name, key)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Extract scans the sources for translation keys and compares them with
+// the language files in options.LocalesDir
+func Extract(options ExtractOptions) (*ExtractReport, error) {
+	if len(options.SourceDirs) == 0 {
+		options.SourceDirs = []string{"."}
+	}
+	if options.DefaultLocale == "" {
+		options.DefaultLocale = "en"
+	}
+	if options.Functions == nil {
+		options.Functions = DefaultExtractFunctions
+	}
+
+	report := &ExtractReport{}
+	usages := make(map[string]*KeyUsage)
+	for _, dir := range options.SourceDirs {
+		if err := extractDir(dir, options, usages, report); err != nil {
+			return nil, mdwerror.Wrap(err, "failed to scan sources").
+				WithCode(mdwerror.CodeInvalidOperation).
+				WithOperation("i18n.Extract").
+				WithDetail("directory", dir)
+		}
+	}
+	for _, usage := range usages {
+		report.Keys = append(report.Keys, *usage)
+	}
+	sort.Slice(report.Keys, func(i, j int) bool { return report.Keys[i].Key < report.Keys[j].Key })
+	sort.Strings(report.DynamicCalls)
+
+	manager, err := New(Options{DefaultLocale: options.DefaultLocale, LocalesDir: options.LocalesDir, Format: options.Format})
+	if err != nil {
+		return nil, err
+	}
+	defaults := manager.translations[options.DefaultLocale]
+
+	for _, locale := range manager.GetAvailableLocales() {
+		translations := manager.translations[locale]
+		localeReport := LocaleReport{Locale: locale}
+
+		for _, usage := range report.Keys {
+			if !manager.HasTranslationInLocale(usage.Key, locale) {
+				localeReport.Missing = append(localeReport.Missing, usage.Key)
+			}
+		}
+		for _, key := range manager.collectKeys(translations, "") {
+			if _, used := usages[key]; !used {
+				localeReport.Unused = append(localeReport.Unused, key)
+			}
+			if locale != options.DefaultLocale && isUntranslated(translations, defaults, key, manager) {
+				localeReport.Untranslated = append(localeReport.Untranslated, key)
+			}
+		}
+
+		if options.WriteStubs && len(localeReport.Missing) > 0 {
+			stubbed, err := writeStubs(manager, locale, localeReport.Missing)
+			if err != nil {
+				return nil, err
+			}
+			localeReport.Stubbed = stubbed
+		}
+		report.Locales = append(report.Locales, localeReport)
+	}
+	return report, nil
+}
+
+// extractDir collects the keys of all Go files below dir
+func extractDir(dir string, options ExtractOptions, usages map[string]*KeyUsage, report *ExtractReport) error {
+	fset := token.NewFileSet()
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || (!options.IncludeTests && strings.HasSuffix(name, "_test.go")) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			index, ok := options.Functions[callName(call)]
+			if !ok || index >= len(call.Args) {
+				return true
+			}
+
+			position := fset.Position(call.Pos())
+			location := fmt.Sprintf("%s:%d", position.Filename, position.Line)
+			literal, ok := call.Args[index].(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				report.DynamicCalls = append(report.DynamicCalls, location)
+				return true
+			}
+			key, err := strconv.Unquote(literal.Value)
+			if err != nil || key == "" {
+				return true
+			}
+			if usages[key] == nil {
+				usages[key] = &KeyUsage{Key: key}
+			}
+			usages[key].Positions = append(usages[key].Positions, location)
+			return true
+		})
+		return nil
+	})
+}
+
+// callName returns the name of the called function or method
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	default:
+		return ""
+	}
+}
+
+// isUntranslated reports whether key is identical to the default locale;
+// empty values count as missing instead
+func isUntranslated(translations, defaults map[string]interface{}, key string, manager *Manager) bool {
+	value := manager.getNestedRawValue(translations, key)
+	if value == nil || value == "" {
+		return false
+	}
+	return reflect.DeepEqual(value, manager.getNestedRawValue(defaults, key))
+}
+
+// writeStubs adds entries for missing keys to the language file of locale:
+// the default locale's text, so that the entry shows up as untranslated, or
+// an empty string in the default locale itself. The file is rewritten, so
+// comments are not preserved.
+func writeStubs(manager *Manager, locale string, missing []string) ([]string, error) {
+	path, format := manager.localeFile(locale)
+	if path == "" {
+		return nil, mdwerror.New("language file not found").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("i18n.Extract").
+			WithDetail("locale", locale)
+	}
+
+	translations := manager.translations[locale]
+	defaults := manager.translations[manager.defaultLocale]
+	var stubbed []string
+	for _, key := range missing {
+		var stub interface{} = ""
+		if locale != manager.defaultLocale {
+			if value := manager.getNestedRawValue(defaults, key); value != nil {
+				stub = value
+			}
+		}
+		if setNestedValue(translations, key, stub) {
+			stubbed = append(stubbed, key)
+		}
+	}
+
+	if err := writeLocaleFile(path, format, translations); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to write stub entries").
+			WithCode(mdwerror.CodeInvalidOperation).
+			WithOperation("i18n.Extract").
+			WithDetail("path", path)
+	}
+	return stubbed, nil
+}
+
+// writeLocaleFile atomically writes translations as a language file in
+// format; comments of an existing file are not preserved
+func writeLocaleFile(path string, format Format, translations map[string]interface{}) error {
+	var buffer bytes.Buffer
+	if format == FormatYAML {
+		data, err := yaml.Marshal(translations)
+		if err != nil {
+			return err
+		}
+		buffer.Write(data)
+	} else if err := toml.NewEncoder(&buffer).Encode(translations); err != nil {
+		return err
+	}
+	return mdwfilex.WriteFileAtomic(path, buffer.Bytes(), 0644)
+}
+
+// localeFile returns the path and format of the language file of locale
+func (m *Manager) localeFile(locale string) (string, Format) {
+	extensions := []string{".toml", ".yaml", ".yml"}
+	if m.format == FormatYAML {
+		extensions = []string{".yaml", ".yml"}
+	} else if m.format == FormatTOML {
+		extensions = []string{".toml"}
+	}
+	for _, ext := range extensions {
+		path := filepath.Join(m.localesDir, locale+ext)
+		if _, err := os.Stat(path); err == nil {
+			if ext == ".toml" {
+				return path, FormatTOML
+			}
+			return path, FormatYAML
+		}
+	}
+	return "", m.format
+}
+
+// setNestedValue sets a dot-notation key, creating sections as needed. It
+// returns false if a prefix of the key is a message rather than a section.
+func setNestedValue(data map[string]interface{}, key string, value interface{}) bool {
+	parts := strings.Split(key, ".")
+	current := data
+	for _, part := range parts[:len(parts)-1] {
+		switch next := current[part].(type) {
+		case nil:
+			section := make(map[string]interface{})
+			current[part] = section
+			current = section
+		case map[string]interface{}:
+			current = next
+		case TranslationData:
+			current = next
+		default:
+			return false
+		}
+	}
+	current[parts[len(parts)-1]] = value
+	return true
+}
@@ -0,0 +1,150 @@
+// File: extract_test.go
+// Title: Translation Key Extraction Tests
+// Description: Tests key extraction from Go sources, the per-locale report,
+//              and writing of stub entries.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial extraction tests
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const extractSource = `package app
+
+func handler(m *i18n.Manager, key string, n int) {
+	m.T("messages.welcome")
+	m.T("messages.welcome", nil)
+	m.Plural("items.count", n, nil)
+	m.TInLocale("de", "messages.goodbye")
+	m.T(key)
+	mdwerror.New("x").WithMessageKey("errors.not_found")
+}
+`
+
+// writeExtractFixture creates a source and a locales directory
+func writeExtractFixture(t *testing.T) (sourceDir, localesDir string) {
+	root := t.TempDir()
+	sourceDir = filepath.Join(root, "src")
+	localesDir = filepath.Join(root, "locales")
+	files := map[string]string{
+		filepath.Join(sourceDir, "app.go"):              extractSource,
+		filepath.Join(sourceDir, "app_test.go"):         "package app\n\nfunc f(m *i18n.Manager) { m.T(\"test.only\") }\n",
+		filepath.Join(sourceDir, "testdata", "skip.go"): "package skip\n\nfunc f(m *i18n.Manager) { m.T(\"skipped\") }\n",
+		filepath.Join(localesDir, "en.toml"): `[messages]
+welcome = "Welcome"
+goodbye = "Goodbye"
+legacy = "Old text"
+
+[items]
+count = ["{{.Count}} item", "{{.Count}} items"]
+`,
+		filepath.Join(localesDir, "de.toml"): `[messages]
+welcome = "Willkommen"
+goodbye = "Goodbye"
+`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sourceDir, localesDir
+}
+
+func TestExtract(t *testing.T) {
+	sourceDir, localesDir := writeExtractFixture(t)
+
+	report, err := Extract(ExtractOptions{SourceDirs: []string{sourceDir}, LocalesDir: localesDir})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	var keys []string
+	for _, usage := range report.Keys {
+		keys = append(keys, usage.Key)
+	}
+	expectedKeys := []string{"errors.not_found", "items.count", "messages.goodbye", "messages.welcome"}
+	if !reflect.DeepEqual(keys, expectedKeys) {
+		t.Errorf("keys = %v, want %v", keys, expectedKeys)
+	}
+	if welcome := report.Keys[3]; len(welcome.Positions) != 2 || !strings.Contains(welcome.Positions[0], "app.go:4") {
+		t.Errorf("positions = %v", welcome.Positions)
+	}
+	if len(report.DynamicCalls) != 1 || !strings.HasSuffix(report.DynamicCalls[0], "app.go:8") {
+		t.Errorf("dynamic calls = %v", report.DynamicCalls)
+	}
+
+	if len(report.Locales) != 2 {
+		t.Fatalf("locales = %d, want 2", len(report.Locales))
+	}
+	de, en := report.Locales[0], report.Locales[1]
+	if !reflect.DeepEqual(en.Missing, []string{"errors.not_found"}) || !reflect.DeepEqual(en.Unused, []string{"messages.legacy"}) || len(en.Untranslated) != 0 {
+		t.Errorf("en report = %+v", en)
+	}
+	if !reflect.DeepEqual(de.Missing, []string{"errors.not_found", "items.count"}) || !reflect.DeepEqual(de.Untranslated, []string{"messages.goodbye"}) {
+		t.Errorf("de report = %+v", de)
+	}
+	if !report.HasIssues() || !strings.Contains(report.String(), "[de] missing: 2, unused: 0, untranslated: 1") {
+		t.Errorf("String() = %s", report.String())
+	}
+}
+
+func TestExtract_IncludeTests(t *testing.T) {
+	sourceDir, localesDir := writeExtractFixture(t)
+
+	report, err := Extract(ExtractOptions{SourceDirs: []string{sourceDir}, LocalesDir: localesDir, IncludeTests: true})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(report.Keys) != 5 || report.Keys[4].Key != "test.only" {
+		t.Errorf("keys = %+v", report.Keys)
+	}
+}
+
+func TestExtract_WriteStubs(t *testing.T) {
+	sourceDir, localesDir := writeExtractFixture(t)
+
+	report, err := Extract(ExtractOptions{SourceDirs: []string{sourceDir}, LocalesDir: localesDir, WriteStubs: true})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if de := report.Locales[0]; !reflect.DeepEqual(de.Stubbed, de.Missing) {
+		t.Errorf("stubbed = %v, missing = %v", de.Stubbed, de.Missing)
+	}
+
+	// Stubs copy the default text, so German keys are now untranslated
+	report, err = Extract(ExtractOptions{SourceDirs: []string{sourceDir}, LocalesDir: localesDir})
+	if err != nil {
+		t.Fatalf("second Extract() error = %v", err)
+	}
+	de := report.Locales[0]
+	if !reflect.DeepEqual(de.Missing, []string{"errors.not_found"}) || !reflect.DeepEqual(de.Untranslated, []string{"items.count", "messages.goodbye"}) {
+		t.Errorf("de report after stubs = %+v", de)
+	}
+
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: localesDir})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	manager.SetLocale("de")
+	if got := manager.Plural("items.count", 2, map[string]interface{}{"Count": 2}); got != "2 items" {
+		t.Errorf("stubbed plural = %q", got)
+	}
+	if got := manager.T("messages.welcome"); got != "Willkommen" {
+		t.Errorf("existing translation changed: %q", got)
+	}
+}
@@ -0,0 +1,113 @@
+// File: fallback_test.go
+// Title: Locale Fallback Chain Tests
+// Description: Tests chain construction, per-key resolution through parent
+//              and configured locales, and the resolution report.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial fallback chain tests
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newFallbackManager creates a manager with a zh-Hant-TW hierarchy
+func newFallbackManager(t *testing.T, fallbacks map[string][]string) *Manager {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml":         "[app]\ntitle = \"Title\"\nhelp = \"Help\"\nabout = \"About\"\nversion = \"Version\"\n[files]\ncount = [\"{{.Count}} file\", \"{{.Count}} files\"]\n",
+		"zh.toml":         "[app]\ntitle = \"标题\"\nhelp = \"帮助\"\n[files]\ncount = \"{{.Count}} 个文件\"\n",
+		"zh-Hant.toml":    "[app]\ntitle = \"標題\"\n",
+		"zh-Hant-TW.toml": "[app]\ntitle = \"\"\n",
+		"zh-Hant-HK.toml": "[app]\nabout = \"關於\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML, Fallbacks: fallbacks})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return manager
+}
+
+func TestFallbackChain(t *testing.T) {
+	manager := newFallbackManager(t, map[string][]string{"zh-Hant-TW": {"zh-Hant-HK"}})
+
+	expected := []string{"zh-Hant-TW", "zh-Hant-HK", "zh-Hant", "zh", "en"}
+	if chain := manager.FallbackChain("zh-Hant-TW"); !reflect.DeepEqual(chain, expected) {
+		t.Errorf("FallbackChain() = %v, want %v", chain, expected)
+	}
+	if chain := manager.FallbackChain("en"); !reflect.DeepEqual(chain, []string{"en"}) {
+		t.Errorf("FallbackChain(en) = %v", chain)
+	}
+
+	manager.SetFallbacks("zh-Hant-TW")
+	if chain := manager.FallbackChain("zh-Hant-TW"); len(chain) != 4 {
+		t.Errorf("FallbackChain() after reset = %v", chain)
+	}
+}
+
+func TestFallback_PerKeyResolution(t *testing.T) {
+	manager := newFallbackManager(t, map[string][]string{"zh-Hant-TW": {"zh-Hant-HK"}})
+
+	tests := []struct {
+		key      string
+		expected string
+		source   string
+	}{
+		{"app.title", "標題", "zh-Hant"}, // Empty in zh-Hant-TW
+		{"app.about", "關於", "zh-Hant-HK"},
+		{"app.help", "帮助", "zh"},
+		{"app.version", "Version", "en"},
+		{"app.missing", "", ""},
+	}
+	for _, tt := range tests {
+		if got := manager.TInLocale("zh-Hant-TW", tt.key); got != tt.expected {
+			t.Errorf("TInLocale(%s) = %q, want %q", tt.key, got, tt.expected)
+		}
+		resolution := manager.Resolve(tt.key, "zh-Hant-TW")
+		if resolution.Locale != tt.source || resolution.Found() != (tt.source != "") {
+			t.Errorf("Resolve(%s) = %+v, want locale %q", tt.key, resolution, tt.source)
+		}
+	}
+
+	// Plural forms resolve through the chain with the supplier's plural rule
+	manager.SetLocale("zh-Hant-TW")
+	if got := manager.Plural("files.count", 3, map[string]interface{}{"Count": 3}); got != "3 个文件" {
+		t.Errorf("Plural() = %q", got)
+	}
+}
+
+func TestFallbackReport(t *testing.T) {
+	manager := newFallbackManager(t, nil)
+
+	report := manager.FallbackReport("zh-Hant-TW")
+	sources := make(map[string]string)
+	for _, resolution := range report {
+		sources[resolution.Key] = resolution.Locale
+	}
+	expected := map[string]string{
+		"app.about":   "en",
+		"app.help":    "zh",
+		"app.title":   "zh-Hant",
+		"app.version": "en",
+		"files.count": "zh",
+	}
+	if !reflect.DeepEqual(sources, expected) {
+		t.Errorf("FallbackReport() sources = %v, want %v", sources, expected)
+	}
+	if report[0].Key != "app.about" || !report[0].IsFallback() {
+		t.Errorf("first resolution = %+v", report[0])
+	}
+}
@@ -0,0 +1,233 @@
+// File: format.go
+// Title: Locale-Aware Number, Currency, and Date Formatting
+// Description: Formats numbers, currency amounts, and dates according to
+//              per-locale data (separators, grouping, currency symbol position,
+//              month names) and exposes the formatters as template functions,
+//              so that {{.Amount | currency}} renders per locale.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of locale formatting
+
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// LocaleData holds the formatting conventions of a locale
+type LocaleData struct {
+	DecimalSeparator string     // "." in en, "," in de
+	GroupSeparator   string     // "," in en, "." in de
+	CurrencySymbol   string     // Symbol of the locale's currency
+	CurrencyDecimals int        // Fraction digits of currency amounts
+	SymbolAfter      bool       // Symbol follows the amount ("1,00 €")
+	SymbolSpacing    bool       // Non-breaking space between symbol and amount
+	DateLayout       string     // Go time layout; "January" is replaced by MonthNames
+	MonthNames       [12]string // Month names as used in DateLayout
+}
+
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+var (
+	localeData = map[string]LocaleData{
+		"en": {DecimalSeparator: ".", GroupSeparator: ",", CurrencySymbol: "$", CurrencyDecimals: 2,
+			DateLayout: "January 2, 2006", MonthNames: englishMonths},
+		"en-GB": {DecimalSeparator: ".", GroupSeparator: ",", CurrencySymbol: "£", CurrencyDecimals: 2,
+			DateLayout: "2 January 2006", MonthNames: englishMonths},
+		"de": {DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2. January 2006",
+			MonthNames: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+				"Juli", "August", "September", "Oktober", "November", "Dezember"}},
+		"de-CH": {DecimalSeparator: ".", GroupSeparator: "’", CurrencySymbol: "CHF", CurrencyDecimals: 2,
+			SymbolSpacing: true, DateLayout: "2. January 2006",
+			MonthNames: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+				"Juli", "August", "September", "Oktober", "November", "Dezember"}},
+		"fr": {DecimalSeparator: ",", GroupSeparator: "\u202f", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 January 2006",
+			MonthNames: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+				"juillet", "août", "septembre", "octobre", "novembre", "décembre"}},
+		"es": {DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 de January de 2006",
+			MonthNames: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+				"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}},
+		"it": {DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 January 2006",
+			MonthNames: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+				"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"}},
+		"nl": {DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolSpacing: true, DateLayout: "2 January 2006",
+			MonthNames: [12]string{"januari", "februari", "maart", "april", "mei", "juni",
+				"juli", "augustus", "september", "oktober", "november", "december"}},
+		"pt": {DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "R$", CurrencyDecimals: 2,
+			SymbolSpacing: true, DateLayout: "2 de January de 2006",
+			MonthNames: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+				"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}},
+		"pt-PT": {DecimalSeparator: ",", GroupSeparator: "\u00a0", CurrencySymbol: "€", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 de January de 2006",
+			MonthNames: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+				"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}},
+		"ru": {DecimalSeparator: ",", GroupSeparator: "\u00a0", CurrencySymbol: "₽", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 January 2006 г.",
+			MonthNames: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня",
+				"июля", "августа", "сентября", "октября", "ноября", "декабря"}},
+		"pl": {DecimalSeparator: ",", GroupSeparator: "\u00a0", CurrencySymbol: "zł", CurrencyDecimals: 2,
+			SymbolAfter: true, SymbolSpacing: true, DateLayout: "2 January 2006",
+			MonthNames: [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca",
+				"lipca", "sierpnia", "września", "października", "listopada", "grudnia"}},
+		"ja": {DecimalSeparator: ".", GroupSeparator: ",", CurrencySymbol: "¥", CurrencyDecimals: 0,
+			DateLayout: "2006年1月2日"},
+		"zh": {DecimalSeparator: ".", GroupSeparator: ",", CurrencySymbol: "¥", CurrencyDecimals: 2,
+			DateLayout: "2006年1月2日"},
+	}
+	localeDataMu sync.RWMutex
+)
+
+// RegisterLocaleData sets the formatting conventions of a language ("de")
+// or locale ("de-AT")
+func RegisterLocaleData(locale string, data LocaleData) {
+	localeDataMu.Lock()
+	defer localeDataMu.Unlock()
+	localeData[localeKey(locale)] = data
+}
+
+// GetLocaleData returns the formatting conventions of the locale, its
+// language, or English
+func GetLocaleData(locale string) LocaleData {
+	localeDataMu.RLock()
+	defer localeDataMu.RUnlock()
+
+	key := localeKey(locale)
+	if data, exists := localeData[key]; exists {
+		return data
+	}
+	language, _ := SplitLocale(key)
+	if data, exists := localeData[language]; exists {
+		return data
+	}
+	return localeData["en"]
+}
+
+// FormatNumber formats a number with the separators of locale, using as
+// many fraction digits as needed
+func FormatNumber(locale string, value float64) string {
+	return formatDecimal(GetLocaleData(locale), value, -1)
+}
+
+// FormatDecimal formats a number with a fixed number of fraction digits
+func FormatDecimal(locale string, value float64, decimals int) string {
+	return formatDecimal(GetLocaleData(locale), value, decimals)
+}
+
+// FormatCurrency formats an amount in the currency of locale
+func FormatCurrency(locale string, value float64) string {
+	data := GetLocaleData(locale)
+	amount := formatDecimal(data, value, data.CurrencyDecimals)
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	space := ""
+	if data.SymbolSpacing {
+		space = "\u00a0"
+	}
+	if data.SymbolAfter {
+		return sign + amount + space + data.CurrencySymbol
+	}
+	return sign + data.CurrencySymbol + space + amount
+}
+
+// FormatDate formats a date in the long form of locale with localized
+// month names
+func FormatDate(locale string, t time.Time) string {
+	data := GetLocaleData(locale)
+	formatted := t.Format(data.DateLayout)
+	if month := data.MonthNames[t.Month()-1]; month != "" && strings.Contains(data.DateLayout, "January") {
+		formatted = strings.Replace(formatted, t.Month().String(), month, 1)
+	}
+	return formatted
+}
+
+// formatDecimal formats value with grouping; decimals < 0 uses the
+// shortest representation
+func formatDecimal(data LocaleData, value float64, decimals int) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	digits := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(digits, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(data.GroupSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(data.DecimalSeparator)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// templateFuncs returns the formatting functions available in templates
+// rendered for locale
+func templateFuncs(locale string) template.FuncMap {
+	return template.FuncMap{
+		"number":   func(value interface{}) string { return FormatNumber(locale, toFloat64(value)) },
+		"currency": func(value interface{}) string { return FormatCurrency(locale, toFloat64(value)) },
+		"date": func(value time.Time) string {
+			return FormatDate(locale, value)
+		},
+	}
+}
+
+// toFloat64 converts numeric template values; other values yield 0
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,132 @@
+// File: format_test.go
+// Title: Locale-Aware Formatting Tests
+// Description: Tests number, currency, and date formatting per locale and
+//              the formatting functions in translation templates.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial formatting tests
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale   string
+		value    float64
+		expected string
+	}{
+		{"en", 1234567.891, "1,234,567.891"},
+		{"de", 1234567.891, "1.234.567,891"},
+		{"de-CH", 1234.5, "1’234.5"},
+		{"fr", 1234.5, "1\u202f234,5"},
+		{"en", -1000, "-1,000"},
+		{"en", 999, "999"},
+		{"xx", 1234, "1,234"},
+	}
+	for _, tt := range tests {
+		if got := FormatNumber(tt.locale, tt.value); got != tt.expected {
+			t.Errorf("FormatNumber(%s, %v) = %q, want %q", tt.locale, tt.value, got, tt.expected)
+		}
+	}
+	if got := FormatDecimal("de", 0.5, 2); got != "0,50" {
+		t.Errorf("FormatDecimal() = %q", got)
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		locale   string
+		value    float64
+		expected string
+	}{
+		{"en", 1234.56, "$1,234.56"},
+		{"en-US", -1234.5, "-$1,234.50"},
+		{"en-GB", 3, "£3.00"},
+		{"de", 1234.56, "1.234,56\u00a0€"},
+		{"de-DE", -0.001, "0,00\u00a0€"},
+		{"ja", 1234.4, "¥1,234"},
+		{"pt-BR", 10, "R$\u00a010,00"},
+	}
+	for _, tt := range tests {
+		if got := FormatCurrency(tt.locale, tt.value); got != tt.expected {
+			t.Errorf("FormatCurrency(%s, %v) = %q, want %q", tt.locale, tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+	tests := map[string]string{
+		"en":    "March 5, 2026",
+		"en-GB": "5 March 2026",
+		"de":    "5. März 2026",
+		"es":    "5 de marzo de 2026",
+		"ru":    "5 марта 2026 г.",
+		"ja":    "2026年3月5日",
+	}
+	for locale, expected := range tests {
+		if got := FormatDate(locale, date); got != expected {
+			t.Errorf("FormatDate(%s) = %q, want %q", locale, got, expected)
+		}
+	}
+}
+
+func TestRegisterLocaleData(t *testing.T) {
+	data := GetLocaleData("de")
+	data.SymbolAfter = false
+	RegisterLocaleData("de-AT", data)
+	defer func() {
+		localeDataMu.Lock()
+		delete(localeData, "de-AT")
+		localeDataMu.Unlock()
+	}()
+
+	if got := FormatCurrency("de-AT", 5); got != "€\u00a05,00" {
+		t.Errorf("FormatCurrency(de-AT) = %q", got)
+	}
+}
+
+func TestTemplateFormatFunctions(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": "[order]\ntotal = \"Total: {{.Amount | currency}} for {{.Count | number}} items on {{.Date | date}}\"\n",
+		"de.toml": "[order]\ntotal = \"Summe: {{.Amount | currency}} für {{.Count | number}} Artikel am {{.Date | date}}\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	data := map[string]interface{}{
+		"Amount": 1234.56,
+		"Count":  1500,
+		"Date":   time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if got := manager.T("order.total", data); got != "Total: $1,234.56 for 1,500 items on May 1, 2026" {
+		t.Errorf("en = %q", got)
+	}
+	if got := manager.TInLocale("de", "order.total", data); got != "Summe: 1.234,56\u00a0€ für 1.500 Artikel am 1. Mai 2026" {
+		t.Errorf("de = %q", got)
+	}
+
+	// The cached template of one locale must not be reused for another
+	manager.SetLocale("de")
+	if got := manager.T("order.total", data); got != "Summe: 1.234,56\u00a0€ für 1.500 Artikel am 1. Mai 2026" {
+		t.Errorf("de after SetLocale = %q", got)
+	}
+}
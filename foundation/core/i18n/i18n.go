@@ -4,14 +4,27 @@
 //              loading, parsing, and managing translations from TOML and YAML
 //              language files with template interpolation and pluralization.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.11
 // Created: 2025-01-25
-// Modified: 2025-07-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with TOML/YAML support
 // - 2025-07-26 v0.1.1: Fixed template cache collision issue in pluralization,
 //                       improved cache key uniqueness for plural forms
+// - 2026-10-15 v0.1.2: Added HasTranslationInLocale for key integrity checks
+// - 2026-10-16 v0.1.3: Added TInLocale/TryTInLocale for per-locale translation
+// - 2026-10-16 v0.1.4: Plural selects CLDR categories per locale, with plural
+//                       tables keyed by category and arrays as fallback
+// - 2026-10-16 v0.1.5: Templates are cached per locale and provide the number,
+//                       currency, and date formatting functions
+// - 2026-10-16 v0.1.6: Translations resolve through locale fallback chains
+// - 2026-10-16 v0.1.7: Added loading from a LoaderProvider instead of LocalesDir
+// - 2026-10-16 v0.1.8: Added WithLocale and PluralInLocale; the template cache is
+//                       guarded by a mutex shared with clones
+// - 2026-10-16 v0.1.9: Added message revisions; templates are cached by text
+// - 2026-10-16 v0.1.10: The _meta metadata section is not listed as messages
+// - 2026-10-16 v0.1.11: Added loading of compiled bundles (FormatBinary)
 
 package i18n
 
@@ -23,9 +36,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
-
-	"github.com/BurntSushi/toml"
-	"gopkg.in/yaml.v3"
+	"time"
 
 	mdwerror "github.com/msto63/mDW/foundation/core/error"
 	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
@@ -43,6 +54,9 @@ const (
 	
 	// FormatAuto auto-detects format from file extension
 	FormatAuto
+
+	// FormatBinary represents compiled bundles (see CompileBundle)
+	FormatBinary
 )
 
 // String returns the string representation of the format
@@ -54,6 +68,8 @@ func (f Format) String() string {
 		return "yaml"
 	case FormatAuto:
 		return "auto"
+	case FormatBinary:
+		return "binary"
 	default:
 		return "unknown"
 	}
@@ -66,6 +82,10 @@ type Options struct {
 	Format        Format // File format (default: auto-detect)
 	Watch         bool   // Enable file watching for hot-reloading
 	Fallback      bool   // Enable fallback to default locale (default: true)
+	Fallbacks     map[string][]string // Fallback locales per locale, tried before parent locales
+	Provider      LoaderProvider      // Source of locale data; replaces LocalesDir if set
+	RefreshInterval time.Duration     // Provider refresh interval when watching (default: 1m)
+	ExperimentAssigner ExperimentAssigner // Chooses message revisions per user
 }
 
 // Manager manages internationalization for an application
@@ -76,8 +96,14 @@ type Manager struct {
 	localesDir      string
 	format          Format
 	fallback        bool
+	fallbacks       map[string][]string               // locale -> fallback locales
+	provider        LoaderProvider
+	bundleETags     map[string]string                 // locale -> ETag of the provider bundle
 	translations    map[string]map[string]interface{} // locale -> translations
 	templates       map[string]*template.Template     // key -> compiled template
+	segments        map[string][]templateSegment      // template text -> pre-parsed segments from bundles
+	templateMu      *sync.Mutex                       // Guards templates and segments; shared with clones
+	experiments     *experimentState                  // Revision settings; shared with clones
 	watchers        []LocaleChangeHandler
 	watching        bool
 	
@@ -99,7 +125,7 @@ func New(options Options) (*Manager, error) {
 		return nil, mdwerror.New("default locale cannot be empty").WithCode(mdwerror.CodeValidationFailed).WithOperation("i18n.New")
 	}
 
-	if mdwstringx.IsBlank(options.LocalesDir) {
+	if mdwstringx.IsBlank(options.LocalesDir) && options.Provider == nil {
 		options.LocalesDir = "./locales"
 	}
 
@@ -107,9 +133,11 @@ func New(options Options) (*Manager, error) {
 		options.Format = FormatTOML // Default to TOML
 	}
 
-	// Check if locales directory exists
-	if _, err := os.Stat(options.LocalesDir); os.IsNotExist(err) {
-		return nil, mdwerror.New("locales directory not found").WithCode(mdwerror.CodeNotFound).WithOperation("i18n.New").WithDetail("directory", options.LocalesDir)
+	// Check if locales directory exists (unless a provider supplies the data)
+	if options.Provider == nil {
+		if _, err := os.Stat(options.LocalesDir); os.IsNotExist(err) {
+			return nil, mdwerror.New("locales directory not found").WithCode(mdwerror.CodeNotFound).WithOperation("i18n.New").WithDetail("directory", options.LocalesDir)
+		}
 	}
 
 	manager := &Manager{
@@ -118,19 +146,35 @@ func New(options Options) (*Manager, error) {
 		localesDir:    options.LocalesDir,
 		format:        options.Format,
 		fallback:      true, // Enable fallback by default
+		fallbacks:     make(map[string][]string),
+		provider:      options.Provider,
+		bundleETags:   make(map[string]string),
 		translations:  make(map[string]map[string]interface{}),
 		templates:     make(map[string]*template.Template),
+		segments:      make(map[string][]templateSegment),
+		templateMu:    &sync.Mutex{},
+		experiments:   newExperimentState(options.ExperimentAssigner),
 		watchers:      make([]LocaleChangeHandler, 0),
 		watching:      options.Watch,
 	}
 
+	for locale, fallbacks := range options.Fallbacks {
+		manager.fallbacks[locale] = append([]string(nil), fallbacks...)
+	}
+
 	// Load all available locales
-	if err := manager.loadAllLocales(); err != nil {
+	if manager.provider != nil {
+		if err := manager.loadFromProvider(); err != nil {
+			return nil, mdwerror.Wrap(err, "failed to load locales").WithCode(mdwerror.CodeInvalidOperation).WithOperation("i18n.loadFromProvider")
+		}
+	} else if err := manager.loadAllLocales(); err != nil {
 		return nil, mdwerror.Wrap(err, "failed to load locales").WithCode(mdwerror.CodeInvalidOperation).WithOperation("i18n.loadAllLocales")
 	}
 
 	// Start watching if requested
-	if options.Watch {
+	if options.Watch && manager.provider != nil {
+		go manager.startRefreshing(options.RefreshInterval)
+	} else if options.Watch {
 		go manager.startWatching()
 	}
 
@@ -160,12 +204,7 @@ func (m *Manager) loadAllLocales() error {
 		
 		// Check if file has a supported extension based on configured format
 		ext := strings.ToLower(filepath.Ext(fileName))
-		supportedExtensions := []string{".toml", ".yaml", ".yml"}
-		if m.format == FormatTOML {
-			supportedExtensions = []string{".toml"}
-		} else if m.format == FormatYAML {
-			supportedExtensions = []string{".yaml", ".yml"}
-		}
+		supportedExtensions := localeExtensions(m.format)
 		
 		isSupported := false
 		for _, supportedExt := range supportedExtensions {
@@ -202,19 +241,7 @@ func (m *Manager) loadAllLocales() error {
 // loadLocale loads translations for a specific locale
 func (m *Manager) loadLocale(locale string) error {
 	// Determine file extensions to try based on configured format
-	var extensions []string
-	switch m.format {
-	case FormatTOML:
-		extensions = []string{".toml"}
-	case FormatYAML:
-		extensions = []string{".yaml", ".yml"}
-	case FormatAuto:
-		// Auto-detect: try all, prefer TOML
-		extensions = []string{".toml", ".yaml", ".yml"}
-	default:
-		// Default fallback (same as auto)
-		extensions = []string{".toml", ".yaml", ".yml"}
-	}
+	extensions := localeExtensions(m.format)
 	
 	var filePath string
 	var format Format
@@ -223,11 +250,7 @@ func (m *Manager) loadLocale(locale string) error {
 		testPath := filepath.Join(m.localesDir, locale+ext)
 		if _, err := os.Stat(testPath); err == nil {
 			filePath = testPath
-			if ext == ".toml" {
-				format = FormatTOML
-			} else {
-				format = FormatYAML
-			}
+			format = formatForExtension(ext)
 			break
 		}
 	}
@@ -243,18 +266,9 @@ func (m *Manager) loadLocale(locale string) error {
 	}
 
 	// Parse content
-	var data TranslationData
-	switch format {
-	case FormatTOML:
-		if err := toml.Unmarshal(content, &data); err != nil {
-			return fmt.Errorf("failed to parse TOML file %s: %w", filePath, err)
-		}
-	case FormatYAML:
-		if err := yaml.Unmarshal(content, &data); err != nil {
-			return fmt.Errorf("failed to parse YAML file %s: %w", filePath, err)
-		}
-	default:
-		return fmt.Errorf("unsupported format for file %s", filePath)
+	data, err := m.parseLocale(content, format, filePath)
+	if err != nil {
+		return err
 	}
 
 	// Store translations
@@ -266,6 +280,23 @@ func (m *Manager) loadLocale(locale string) error {
 	return nil
 }
 
+// localeExtensions returns the language file extensions loaded for format
+// in order of preference. Source files take precedence over compiled
+// bundles, which are loaded in every format.
+func localeExtensions(format Format) []string {
+	switch format {
+	case FormatTOML:
+		return []string{".toml", BundleExtension}
+	case FormatYAML:
+		return []string{".yaml", ".yml", BundleExtension}
+	case FormatBinary:
+		return []string{BundleExtension}
+	default:
+		// Auto-detect: try all, prefer TOML
+		return []string{".toml", ".yaml", ".yml", BundleExtension}
+	}
+}
+
 // T translates a key with optional template data
 func (m *Manager) T(key string, data ...map[string]interface{}) string {
 	translation, _ := m.TryT(key, data...)
@@ -285,7 +316,7 @@ func (m *Manager) TryT(key string, data ...map[string]interface{}) (string, erro
 
 	// Render template if data provided
 	if len(data) > 0 && data[0] != nil {
-		rendered, err := m.renderTemplate(key, translation, data[0])
+		rendered, err := m.renderTemplate(m.currentLocale, key, translation, data[0])
 		if err != nil {
 			return translation, mdwerror.Wrap(err, "template rendering failed").WithCode(mdwerror.CodeInvalidOperation).WithOperation("i18n.renderTemplate")
 		}
@@ -303,7 +334,7 @@ func (m *Manager) TWithFallback(key string, fallbackMsg string, data ...map[stri
 	
 	// Render fallback with template data if provided
 	if len(data) > 0 && data[0] != nil {
-		if rendered, err := m.renderTemplate(key+"_fallback", fallbackMsg, data[0]); err == nil {
+		if rendered, err := m.renderTemplate(m.currentLocale, key+"_fallback", fallbackMsg, data[0]); err == nil {
 			return rendered
 		}
 	}
@@ -311,42 +342,92 @@ func (m *Manager) TWithFallback(key string, fallbackMsg string, data ...map[stri
 	return fallbackMsg
 }
 
+// TInLocale translates a key in a specific locale without changing the
+// current locale
+func (m *Manager) TInLocale(locale, key string, data ...map[string]interface{}) string {
+	translation, _ := m.TryTInLocale(locale, key, data...)
+	return translation
+}
+
+// TryTInLocale translates a key in a specific locale and returns an error if
+// translation fails. An empty locale uses the current locale. This allows
+// concurrent requests with different locales to share one manager.
+func (m *Manager) TryTInLocale(locale, key string, data ...map[string]interface{}) (string, error) {
+	// Exclusive lock: rendering writes to the template cache
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if locale == "" {
+		locale = m.currentLocale
+	}
+
+	translation := m.getTranslation(key, locale)
+	if mdwstringx.IsBlank(translation) {
+		return "", mdwerror.New("translation not found").WithCode(mdwerror.CodeNotFound).WithOperation("i18n.TryTInLocale").WithDetail("key", key).WithDetail("locale", locale)
+	}
+
+	if len(data) > 0 && data[0] != nil {
+		rendered, err := m.renderTemplate(locale, key, translation, data[0])
+		if err != nil {
+			return translation, mdwerror.Wrap(err, "template rendering failed").WithCode(mdwerror.CodeInvalidOperation).WithOperation("i18n.renderTemplate")
+		}
+		return rendered, nil
+	}
+
+	return translation, nil
+}
+
 // Plural returns the appropriate plural form based on count
 func (m *Manager) Plural(key string, count int, data map[string]interface{}) string {
+	return m.PluralInLocale("", key, count, data)
+}
+
+// PluralInLocale returns the plural form for count in a specific locale
+// without changing the current locale. An empty locale uses the current
+// locale.
+func (m *Manager) PluralInLocale(locale, key string, count int, data map[string]interface{}) string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// Get raw translation value
-	translations := m.translations[m.currentLocale]
-	if translations == nil {
-		return fmt.Sprintf("[%s]", key)
+	if locale == "" {
+		locale = m.currentLocale
 	}
 
-	rawValue := m.getNestedRawValue(translations, key)
+	// Get raw translation value along the fallback chain; the plural rule
+	// follows the language of the supplying locale
+	rawValue, source := m.resolveRawValue(key, locale)
 	if rawValue == nil {
 		return fmt.Sprintf("[%s]", key)
 	}
 
-	// Handle plural forms
-	forms := m.parsePluralFormsFromRaw(rawValue)
-	if len(forms) == 0 {
-		return fmt.Sprintf("[%s]", key)
-	}
+	// Plural table keyed by CLDR category
+	var selectedForm, formID string
+	if table, ok := pluralTable(rawValue); ok {
+		category := PluralCategoryFor(source, count)
+		if _, exists := table[string(category)]; !exists {
+			category = PluralOther
+		}
+		selectedForm = table[string(category)]
+		formID = string(category)
+	} else {
+		// Handle plural forms
+		forms := m.parsePluralFormsFromRaw(rawValue)
+		if len(forms) == 0 {
+			return fmt.Sprintf("[%s]", key)
+		}
 
-	// Select appropriate form based on count
-	formIndex := m.getPluralFormIndex(count, m.currentLocale)
-	if formIndex >= len(forms) {
-		formIndex = len(forms) - 1
-	}
-	if formIndex < 0 {
-		formIndex = 0
+		// Select appropriate form based on count
+		formIndex := pluralFormIndex(source, count, len(forms))
+		if formIndex >= len(forms) {
+			formIndex = len(forms) - 1
+		}
+		selectedForm = forms[formIndex]
+		formID = fmt.Sprintf("%d", formIndex)
 	}
 
-	selectedForm := forms[formIndex]
-
 	// Render template with data
 	if data != nil {
-		if rendered, err := m.renderTemplate(key+"_plural_"+fmt.Sprintf("%d", formIndex), selectedForm, data); err == nil {
+		if rendered, err := m.renderTemplate(locale, key+"_plural_"+formID, selectedForm, data); err == nil {
 			return rendered
 		}
 	}
@@ -354,25 +435,11 @@ func (m *Manager) Plural(key string, count int, data map[string]interface{}) str
 	return selectedForm
 }
 
-// getTranslation retrieves a translation for a specific locale with fallback
+// getTranslation retrieves a translation for a specific locale, falling
+// back along the locale's fallback chain
 func (m *Manager) getTranslation(key, locale string) string {
-	// Try current locale first
-	if translations, exists := m.translations[locale]; exists {
-		if value := m.getNestedValue(translations, key); value != "" {
-			return value
-		}
-	}
-
-	// Try fallback to default locale if enabled
-	if m.fallback && locale != m.defaultLocale {
-		if translations, exists := m.translations[m.defaultLocale]; exists {
-			if value := m.getNestedValue(translations, key); value != "" {
-				return value
-			}
-		}
-	}
-
-	return ""
+	translation, _ := m.resolveTranslation(key, locale)
+	return translation
 }
 
 // getNestedValue retrieves a nested value from translations using dot notation
@@ -384,6 +451,14 @@ func (m *Manager) getNestedValue(data map[string]interface{}, key string) string
 		if i == len(keys)-1 {
 			// Last key - return the value
 			if value, ok := current[k]; ok {
+				value = m.selectRevision(key, value)
+				// Plural tables return the singular form, or other
+				if table, isTable := pluralTable(value); isTable {
+					if one, exists := table[string(PluralOne)]; exists {
+						return one
+					}
+					return table[string(PluralOther)]
+				}
 				// Handle array values (for plurals)
 				if arr, isSlice := value.([]interface{}); isSlice {
 					// Return first element for non-plural calls
@@ -437,24 +512,33 @@ func (m *Manager) getNestedRawValue(data map[string]interface{}, key string) int
 	return nil
 }
 
-// renderTemplate renders a translation template with data
-func (m *Manager) renderTemplate(key, template string, data map[string]interface{}) (string, error) {
-	// Check if template is cached
-	if tmpl, exists := m.templates[key]; exists {
-		var result strings.Builder
-		if err := tmpl.Execute(&result, data); err != nil {
-			return template, fmt.Errorf("template execution failed: %w", err)
-		}
-		return result.String(), nil
+// renderTemplate renders a translation template of a locale with data
+func (m *Manager) renderTemplate(locale, key, template string, data map[string]interface{}) (string, error) {
+	// Cache templates per locale and text: the same key differs between
+	// locales and message revisions
+	key = locale + ":" + key + ":" + template
+
+	// Check if template is cached or pre-parsed by a compiled bundle
+	m.templateMu.Lock()
+	tmpl, exists := m.templates[key]
+	segments, precompiled := m.segments[template]
+	m.templateMu.Unlock()
+	if precompiled {
+		return renderSegments(segments, data), nil
 	}
 
 	// Compile and cache template
-	tmpl, err := m.compileTemplate(key, template)
-	if err != nil {
-		return template, fmt.Errorf("template compilation failed: %w", err)
-	}
+	if !exists {
+		var err error
+		tmpl, err = m.compileTemplate(locale, key, template)
+		if err != nil {
+			return template, fmt.Errorf("template compilation failed: %w", err)
+		}
 
-	m.templates[key] = tmpl
+		m.templateMu.Lock()
+		m.templates[key] = tmpl
+		m.templateMu.Unlock()
+	}
 
 	// Execute template
 	var result strings.Builder
@@ -465,9 +549,10 @@ func (m *Manager) renderTemplate(key, template string, data map[string]interface
 	return result.String(), nil
 }
 
-// compileTemplate compiles a text template
-func (m *Manager) compileTemplate(name, templateStr string) (*template.Template, error) {
-	return template.New(name).Parse(templateStr)
+// compileTemplate compiles a text template with the formatting functions
+// of locale
+func (m *Manager) compileTemplate(locale, name, templateStr string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs(locale)).Parse(templateStr)
 }
 
 // parsePluralFormsFromRaw parses plural forms from raw translation value
@@ -490,32 +575,31 @@ func (m *Manager) parsePluralFormsFromRaw(value interface{}) []string {
 	return []string{fmt.Sprintf("%v", value)}
 }
 
-// getPluralFormIndex returns the appropriate plural form index for a count and locale
-func (m *Manager) getPluralFormIndex(count int, locale string) int {
-	// Simplified plural rules - can be extended with full CLDR support
-	switch {
-	case strings.HasPrefix(locale, "en"): // English
-		if count == 1 {
-			return 0 // singular
-		}
-		return 1 // plural
-	case strings.HasPrefix(locale, "de"): // German
-		if count == 1 {
-			return 0 // singular
-		}
-		return 1 // plural
-	case strings.HasPrefix(locale, "fr"): // French
-		if count <= 1 {
-			return 0 // singular
-		}
-		return 1 // plural
+// pluralTable returns value as a plural table if all its keys are CLDR
+// plural categories, one of them "other", and all its values strings
+func pluralTable(value interface{}) (map[string]string, bool) {
+	var entries map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		entries = v
+	case TranslationData:
+		entries = v
 	default:
-		// Default English rules
-		if count == 1 {
-			return 0
+		return nil, false
+	}
+	if _, exists := entries[string(PluralOther)]; !exists {
+		return nil, false
+	}
+
+	table := make(map[string]string, len(entries))
+	for category, form := range entries {
+		text, ok := form.(string)
+		if !ok || !IsPluralCategory(category) {
+			return nil, false
 		}
-		return 1
+		table[category] = text
 	}
+	return table, true
 }
 
 // SetLocale changes the current locale
@@ -578,6 +662,31 @@ func (m *Manager) HasTranslation(key string) bool {
 	return translation != ""
 }
 
+// HasTranslationInLocale checks if a translation key exists in the given
+// locale without falling back to the default locale
+func (m *Manager) HasTranslationInLocale(key, locale string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	translations, exists := m.translations[locale]
+	if !exists {
+		return false
+	}
+
+	rawValue := m.selectRevision(key, m.getNestedRawValue(translations, key))
+	if table, isTable := pluralTable(rawValue); isTable {
+		return table[string(PluralOther)] != ""
+	}
+	switch value := rawValue.(type) {
+	case nil, map[string]interface{}, TranslationData:
+		return false // Missing key or a section rather than a message
+	case string:
+		return value != ""
+	default:
+		return true
+	}
+}
+
 // GetTranslationKeys returns all available translation keys for the current locale
 func (m *Manager) GetTranslationKeys() []string {
 	m.mu.RLock()
@@ -599,11 +708,19 @@ func (m *Manager) collectKeys(data map[string]interface{}, prefix string) []stri
 		fullKey := key
 		if prefix != "" {
 			fullKey = prefix + "." + key
+		} else if key == MetadataKey {
+			continue // Metadata section, not a message
 		}
 
 
 		// Try both map types since YAML might use TranslationData type
-		if nestedMap, ok := value.(map[string]interface{}); ok {
+		if _, isRevisions := revisionTable(value); isRevisions {
+			// Revision table is a single message
+			keys = append(keys, fullKey)
+		} else if _, isTable := pluralTable(value); isTable {
+			// Plural table is a single message
+			keys = append(keys, fullKey)
+		} else if nestedMap, ok := value.(map[string]interface{}); ok {
 			// Recurse into nested structure
 			nestedKeys := m.collectKeys(nestedMap, fullKey)
 			keys = append(keys, nestedKeys...)
@@ -642,8 +759,14 @@ func (m *Manager) WithRequestID(requestID string) *Manager {
 		localesDir:      m.localesDir,
 		format:          m.format,
 		fallback:        m.fallback,
+		fallbacks:       m.fallbacks,
+		provider:        m.provider,
+		bundleETags:     m.bundleETags,
 		translations:    m.translations, // Shared data
 		templates:       m.templates,    // Shared templates
+		segments:        m.segments,
+		templateMu:      m.templateMu,
+		experiments:     m.experiments,
 		watchers:        append([]LocaleChangeHandler(nil), m.watchers...),
 		watching:        m.watching,
 		requestID:       requestID,
@@ -653,6 +776,35 @@ func (m *Manager) WithRequestID(requestID string) *Manager {
 	return clone
 }
 
+// WithLocale creates a copy of the manager translating into locale, for
+// request-scoped translation without changing the shared current locale
+func (m *Manager) WithLocale(locale string) *Manager {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	clone := &Manager{
+		defaultLocale:   m.defaultLocale,
+		currentLocale:   locale,
+		localesDir:      m.localesDir,
+		format:          m.format,
+		fallback:        m.fallback,
+		fallbacks:       m.fallbacks,
+		provider:        m.provider,
+		bundleETags:     m.bundleETags,
+		translations:    m.translations,
+		templates:       m.templates,
+		segments:        m.segments,
+		templateMu:      m.templateMu,
+		experiments:     m.experiments,
+		watchers:        append([]LocaleChangeHandler(nil), m.watchers...),
+		watching:        m.watching,
+		requestID:       m.requestID,
+		userID:          m.userID,
+		correlationID:   m.correlationID,
+	}
+	return clone
+}
+
 // WithUserID creates a copy of the manager with a user ID for tracing
 func (m *Manager) WithUserID(userID string) *Manager {
 	m.mu.RLock()
@@ -664,8 +816,14 @@ func (m *Manager) WithUserID(userID string) *Manager {
 		localesDir:      m.localesDir,
 		format:          m.format,
 		fallback:        m.fallback,
+		fallbacks:       m.fallbacks,
+		provider:        m.provider,
+		bundleETags:     m.bundleETags,
 		translations:    m.translations,
 		templates:       m.templates,
+		segments:        m.segments,
+		templateMu:      m.templateMu,
+		experiments:     m.experiments,
 		watchers:        append([]LocaleChangeHandler(nil), m.watchers...),
 		watching:        m.watching,
 		requestID:       m.requestID,
@@ -686,8 +844,14 @@ func (m *Manager) WithCorrelationID(correlationID string) *Manager {
 		localesDir:      m.localesDir,
 		format:          m.format,
 		fallback:        m.fallback,
+		fallbacks:       m.fallbacks,
+		provider:        m.provider,
+		bundleETags:     m.bundleETags,
 		translations:    m.translations,
 		templates:       m.templates,
+		segments:        m.segments,
+		templateMu:      m.templateMu,
+		experiments:     m.experiments,
 		watchers:        append([]LocaleChangeHandler(nil), m.watchers...),
 		watching:        m.watching,
 		requestID:       m.requestID,
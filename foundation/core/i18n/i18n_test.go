@@ -4,12 +4,14 @@
 //              parsing, locale detection, translation templates, pluralization,
 //              and all core internationalization functionality.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial test implementation
+// - 2026-10-16 v0.1.1: Added per-locale translation tests
+// - 2026-10-16 v0.1.2: Added localized error message tests
 
 package i18n
 
@@ -19,6 +21,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
 )
 
 func TestNew(t *testing.T) {
@@ -210,6 +214,54 @@ value = "Tiefer Wert"
 			t.Error("Expected error for invalid locale")
 		}
 	})
+
+	t.Run("TInLocale", func(t *testing.T) {
+		data := map[string]interface{}{"Name": "Anna"}
+		tests := []struct {
+			locale string
+			key    string
+			want   string
+		}{
+			{"de", "messages.welcome", "Willkommen, Anna!"},
+			{"en", "messages.welcome", "Welcome, Anna!"},
+			{"", "messages.welcome", "Welcome, Anna!"}, // Current locale
+			{"de", "missing.key", ""},
+		}
+
+		for _, tt := range tests {
+			if got := manager.TInLocale(tt.locale, tt.key, data); got != tt.want {
+				t.Errorf("TInLocale(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+			}
+		}
+		if manager.GetCurrentLocale() != "en" {
+			t.Errorf("TInLocale changed current locale to %s", manager.GetCurrentLocale())
+		}
+		if _, err := manager.TryTInLocale("de", "missing.key"); err == nil {
+			t.Error("Expected error for missing key")
+		}
+	})
+
+	t.Run("HasTranslationInLocale", func(t *testing.T) {
+		tests := []struct {
+			key    string
+			locale string
+			want   bool
+		}{
+			{"messages.simple", "en", true},
+			{"messages.simple", "de", true},
+			{"plurals.item_count", "en", true},
+			{"plurals.item_count", "de", false}, // No fallback to default locale
+			{"nested.deep", "en", false},        // Section, not a message
+			{"missing.key", "en", false},
+			{"messages.simple", "fr", false},
+		}
+
+		for _, tt := range tests {
+			if got := manager.HasTranslationInLocale(tt.key, tt.locale); got != tt.want {
+				t.Errorf("HasTranslationInLocale(%q, %q) = %v, want %v", tt.key, tt.locale, got, tt.want)
+			}
+		}
+	})
 }
 
 func TestYAMLFormat(t *testing.T) {
@@ -680,6 +732,37 @@ value = "test"
 	}
 }
 
+func TestErrorUserMessage(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": "[errors]\nnot_found = \"Not found\"\nfile_missing = \"File {{.path}} not found\"\n",
+		"de.toml": "[errors]\nfile_missing = \"Datei {{.path}} nicht gefunden\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("Failed to create i18n manager: %v", err)
+	}
+
+	mdwErr := mdwerror.New("stat /srv/data.csv: no such file").
+		WithCode(mdwerror.CodeNotFound).
+		WithMessageKey("errors.file_missing").
+		WithDetail("path", "data.csv")
+	if got := mdwErr.UserMessageWith(manager, "de"); got != "Datei data.csv nicht gefunden" {
+		t.Errorf("Expected German message, got '%s'", got)
+	}
+
+	// The code key falls back to the default locale
+	plain := mdwerror.New("row missing").WithCode(mdwerror.CodeNotFound)
+	if got := plain.UserMessageWith(manager, "de"); got != "Not found" {
+		t.Errorf("Expected default locale message, got '%s'", got)
+	}
+}
+
 func TestFormatString(t *testing.T) {
 	tests := []struct {
 		format   Format
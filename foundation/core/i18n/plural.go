@@ -0,0 +1,373 @@
+// File: plural.go
+// Title: CLDR Plural Rules
+// Description: Implements CLDR plural categories (zero, one, two, few, many,
+//              other) with integer rules per language, so that Russian, Polish,
+//              Arabic, and other languages with more than two forms pluralize
+//              correctly. Rules are registrable per language or locale.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of CLDR plural rules
+
+package i18n
+
+import (
+	"sync"
+)
+
+// PluralCategory is a CLDR plural category
+type PluralCategory string
+
+// CLDR plural categories
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// PluralRule selects the plural category of a non-negative integer count
+type PluralRule func(n int) PluralCategory
+
+// pluralRuleSet is a rule with the categories it produces in CLDR order
+type pluralRuleSet struct {
+	categories []PluralCategory
+	rule       PluralRule
+}
+
+var (
+	pluralRules   = defaultPluralRules()
+	pluralRulesMu sync.RWMutex
+)
+
+// RegisterPluralRule sets the plural rule of a language ("pt") or locale
+// ("pt-PT"). categories lists the categories the rule produces in CLDR
+// order; it maps the entries of plural arrays to categories.
+func RegisterPluralRule(locale string, categories []PluralCategory, rule PluralRule) {
+	pluralRulesMu.Lock()
+	defer pluralRulesMu.Unlock()
+	pluralRules[localeKey(locale)] = pluralRuleSet{categories: append([]PluralCategory(nil), categories...), rule: rule}
+}
+
+// PluralCategoryFor returns the plural category of count in locale.
+// Languages without a rule use the English rule.
+func PluralCategoryFor(locale string, count int) PluralCategory {
+	if count < 0 {
+		count = -count
+	}
+	return lookupPluralRule(locale).rule(count)
+}
+
+// PluralCategories returns the categories used by locale in CLDR order
+func PluralCategories(locale string) []PluralCategory {
+	return append([]PluralCategory(nil), lookupPluralRule(locale).categories...)
+}
+
+// IsPluralCategory reports whether name is a CLDR plural category
+func IsPluralCategory(name string) bool {
+	switch PluralCategory(name) {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// lookupPluralRule returns the rule of the locale, its language, or English
+func lookupPluralRule(locale string) pluralRuleSet {
+	pluralRulesMu.RLock()
+	defer pluralRulesMu.RUnlock()
+
+	key := localeKey(locale)
+	if ruleSet, exists := pluralRules[key]; exists {
+		return ruleSet
+	}
+	language, _ := SplitLocale(key)
+	if ruleSet, exists := pluralRules[language]; exists {
+		return ruleSet
+	}
+	return pluralRules["en"]
+}
+
+// localeKey normalizes a locale for per-locale tables
+func localeKey(locale string) string {
+	if normalized := NormalizeLocale(locale); normalized != "" {
+		return normalized
+	}
+	return locale
+}
+
+// pluralFormIndex maps a category to an index into a plural array. Arrays
+// with one entry per category of the locale are indexed in CLDR order;
+// other arrays use the two-form convention of singular and plural.
+func pluralFormIndex(locale string, count, forms int) int {
+	ruleSet := lookupPluralRule(locale)
+	if count < 0 {
+		count = -count
+	}
+	category := ruleSet.rule(count)
+
+	if forms == len(ruleSet.categories) {
+		for i, candidate := range ruleSet.categories {
+			if candidate == category {
+				return i
+			}
+		}
+	}
+	if category == PluralOne {
+		return 0
+	}
+	return 1
+}
+
+// inRange reports whether min <= n <= max
+func inRange(n, min, max int) bool {
+	return n >= min && n <= max
+}
+
+// defaultPluralRules returns the integer rules of CLDR for common languages
+func defaultPluralRules() map[string]pluralRuleSet {
+	rules := make(map[string]pluralRuleSet)
+	add := func(languages []string, categories []PluralCategory, rule PluralRule) {
+		for _, language := range languages {
+			rules[language] = pluralRuleSet{categories: categories, rule: rule}
+		}
+	}
+
+	// No plural forms
+	add([]string{"zh", "ja", "ko", "th", "vi", "id", "ms", "lo", "my"},
+		[]PluralCategory{PluralOther},
+		func(n int) PluralCategory { return PluralOther })
+
+	// one: n = 1
+	add([]string{"en", "de", "nl", "sv", "da", "nb", "nn", "no", "fi", "et", "el", "hu", "tr", "bg", "eu", "gl", "af", "sq", "az", "ka", "kk", "ur", "sw"},
+		[]PluralCategory{PluralOne, PluralOther},
+		func(n int) PluralCategory {
+			if n == 1 {
+				return PluralOne
+			}
+			return PluralOther
+		})
+
+	// one: n = 1; many: n != 0 and n % 1000000 = 0
+	add([]string{"es", "it", "ca"},
+		[]PluralCategory{PluralOne, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n != 0 && n%1000000 == 0:
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: n = 0, 1; many: n != 0 and n % 1000000 = 0
+	add([]string{"fr", "pt"},
+		[]PluralCategory{PluralOne, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n <= 1:
+				return PluralOne
+			case n%1000000 == 0:
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		})
+	rules["pt-PT"] = rules["es"]
+
+	// one: n % 10 = 1 and n % 100 != 11; few: n % 10 = 2..4 and n % 100 != 12..14; many: otherwise
+	add([]string{"ru", "uk", "be"},
+		[]PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		})
+
+	// Like Russian, but without many for integers
+	add([]string{"hr", "sr", "bs"},
+		[]PluralCategory{PluralOne, PluralFew, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: n = 1; few: n % 10 = 2..4 and n % 100 != 12..14; many: otherwise
+	add([]string{"pl"},
+		[]PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		})
+
+	// one: n = 1; few: n = 2..4
+	add([]string{"cs", "sk"},
+		[]PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case inRange(n, 2, 4):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		})
+
+	// zero: 0; one: 1; two: 2; few: n % 100 = 3..10; many: n % 100 = 11..99
+	add([]string{"ar"},
+		[]PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 0:
+				return PluralZero
+			case n == 1:
+				return PluralOne
+			case n == 2:
+				return PluralTwo
+			case inRange(n%100, 3, 10):
+				return PluralFew
+			case inRange(n%100, 11, 99):
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: 1; two: 2
+	add([]string{"he"},
+		[]PluralCategory{PluralOne, PluralTwo, PluralOther},
+		func(n int) PluralCategory {
+			switch n {
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: n % 10 = 1 and n % 100 != 11..19; few: n % 10 = 2..9 and n % 100 != 11..19
+	add([]string{"lt"},
+		[]PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n%10 == 1 && !inRange(n%100, 11, 19):
+				return PluralOne
+			case inRange(n%10, 2, 9) && !inRange(n%100, 11, 19):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		})
+
+	// zero: n % 10 = 0 or n % 100 = 11..19; one: n % 10 = 1 and n % 100 != 11
+	add([]string{"lv"},
+		[]PluralCategory{PluralZero, PluralOne, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n%10 == 0 || inRange(n%100, 11, 19):
+				return PluralZero
+			case n%10 == 1:
+				return PluralOne
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: 1; few: 0 or n % 100 = 2..19
+	add([]string{"ro"},
+		[]PluralCategory{PluralOne, PluralFew, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n == 0 || inRange(n%100, 2, 19):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: n % 100 = 1; two: n % 100 = 2; few: n % 100 = 3..4
+	add([]string{"sl"},
+		[]PluralCategory{PluralOne, PluralTwo, PluralFew, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n%100 == 1:
+				return PluralOne
+			case n%100 == 2:
+				return PluralTwo
+			case inRange(n%100, 3, 4):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		})
+
+	// one: 1; two: 2; few: 3..6; many: 7..10
+	add([]string{"ga"},
+		[]PluralCategory{PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n == 2:
+				return PluralTwo
+			case inRange(n, 3, 6):
+				return PluralFew
+			case inRange(n, 7, 10):
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		})
+
+	// zero: 0; one: 1; two: 2; few: 3; many: 6
+	add([]string{"cy"},
+		[]PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		func(n int) PluralCategory {
+			switch n {
+			case 0:
+				return PluralZero
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			case 3:
+				return PluralFew
+			case 6:
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		})
+
+	return rules
+}
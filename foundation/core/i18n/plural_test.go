@@ -0,0 +1,166 @@
+// File: plural_test.go
+// Title: CLDR Plural Rule Tests
+// Description: Tests plural categories per language, plural tables, array
+//              fallback, and custom rule registration.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial plural rule tests
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPluralCategoryFor(t *testing.T) {
+	tests := []struct {
+		locale   string
+		counts   []int
+		expected PluralCategory
+	}{
+		{"en", []int{1, -1}, PluralOne},
+		{"en-US", []int{0, 2, 11}, PluralOther},
+		{"fr", []int{0, 1}, PluralOne},
+		{"fr", []int{1000000}, PluralMany},
+		{"pt-BR", []int{0}, PluralOne},
+		{"pt-PT", []int{0}, PluralOther},
+		{"ja", []int{0, 1, 5}, PluralOther},
+		{"ru", []int{1, 21, 101}, PluralOne},
+		{"ru", []int{2, 4, 22, 34}, PluralFew},
+		{"ru", []int{0, 5, 11, 12, 14, 25, 111}, PluralMany},
+		{"pl", []int{1}, PluralOne},
+		{"pl", []int{2, 22, 104}, PluralFew},
+		{"pl", []int{0, 5, 12, 21}, PluralMany},
+		{"cs", []int{3}, PluralFew},
+		{"cs", []int{5}, PluralOther},
+		{"ar", []int{0}, PluralZero},
+		{"ar", []int{2}, PluralTwo},
+		{"ar", []int{3, 10, 103}, PluralFew},
+		{"ar", []int{11, 99, 111}, PluralMany},
+		{"ar", []int{100, 102}, PluralOther},
+		{"xx", []int{1}, PluralOne},
+	}
+
+	for _, tt := range tests {
+		for _, count := range tt.counts {
+			if got := PluralCategoryFor(tt.locale, count); got != tt.expected {
+				t.Errorf("PluralCategoryFor(%q, %d) = %s, want %s", tt.locale, count, got, tt.expected)
+			}
+		}
+	}
+}
+
+func TestPluralCategories(t *testing.T) {
+	if got := PluralCategories("ru"); !reflect.DeepEqual(got, []PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther}) {
+		t.Errorf("PluralCategories(ru) = %v", got)
+	}
+	if got := PluralCategories("zh-CN"); !reflect.DeepEqual(got, []PluralCategory{PluralOther}) {
+		t.Errorf("PluralCategories(zh-CN) = %v", got)
+	}
+}
+
+func TestRegisterPluralRule(t *testing.T) {
+	RegisterPluralRule("en-XX", []PluralCategory{PluralZero, PluralOther}, func(n int) PluralCategory {
+		if n == 0 {
+			return PluralZero
+		}
+		return PluralOther
+	})
+	defer func() {
+		pluralRulesMu.Lock()
+		delete(pluralRules, "en-XX")
+		pluralRulesMu.Unlock()
+	}()
+
+	if got := PluralCategoryFor("en-XX", 0); got != PluralZero {
+		t.Errorf("custom rule = %s", got)
+	}
+	if got := PluralCategoryFor("en-GB", 0); got != PluralOther {
+		t.Errorf("other regions changed: %s", got)
+	}
+}
+
+func TestPlural_Categories(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": `
+[files]
+count = ["{{.Count}} file", "{{.Count}} files"]
+
+[files.size]
+one = "one byte"
+other = "{{.Count}} bytes"
+`,
+		"ru.toml": `
+[files]
+count = ["{{.Count}} файл", "{{.Count}} файла", "{{.Count}} файлов", "{{.Count}} файла"]
+legacy = ["{{.Count}} файл", "{{.Count}} файлы"]
+
+[files.size]
+one = "{{.Count}} байт"
+few = "{{.Count}} байта"
+many = "{{.Count}} байтов"
+other = "{{.Count}} байта"
+`,
+		"ar.toml": `
+[files.size]
+zero = "لا بايت"
+other = "{{.Count}} بايت"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		locale   string
+		key      string
+		count    int
+		expected string
+	}{
+		{"en", "files.size", 1, "one byte"},
+		{"en", "files.size", 3, "3 bytes"},
+		{"ru", "files.size", 21, "21 байт"},
+		{"ru", "files.size", 3, "3 байта"},
+		{"ru", "files.size", 11, "11 байтов"},
+		{"ru", "files.count", 1, "1 файл"},
+		{"ru", "files.count", 22, "22 файла"},
+		{"ru", "files.count", 5, "5 файлов"},
+		{"ru", "files.legacy", 5, "5 файлы"},
+		{"ar", "files.size", 0, "لا بايت"},
+		{"ar", "files.size", 2, "2 بايت"}, // two falls back to other
+	}
+	for _, tt := range tests {
+		if err := manager.SetLocale(tt.locale); err != nil {
+			t.Fatalf("SetLocale(%s) error = %v", tt.locale, err)
+		}
+		if got := manager.Plural(tt.key, tt.count, map[string]interface{}{"Count": tt.count}); got != tt.expected {
+			t.Errorf("[%s] Plural(%s, %d) = %q, want %q", tt.locale, tt.key, tt.count, got, tt.expected)
+		}
+	}
+
+	manager.SetLocale("en")
+	if got := manager.T("files.size"); got != "one byte" {
+		t.Errorf("T() on plural table = %q", got)
+	}
+	if !manager.HasTranslationInLocale("files.size", "ar") {
+		t.Error("plural table not reported as translation")
+	}
+	if keys := manager.GetTranslationKeys(); !reflect.DeepEqual(keys, []string{"files.count", "files.size"}) {
+		t.Errorf("GetTranslationKeys() = %v", keys)
+	}
+}
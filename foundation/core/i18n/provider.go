@@ -0,0 +1,373 @@
+// File: provider.go
+// Title: Translation Bundle Providers
+// Description: Loads locale data from sources other than the locales
+//              directory through the LoaderProvider interface: embedded
+//              filesystems (embed.FS), HTTP endpoints, or custom sources such
+//              as databases. Loaded bundles are cached and revalidated with
+//              ETags, so refreshes only transfer and parse changed locales.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of bundle providers
+// - 2026-10-16 v0.1.1: Providers also serve compiled bundles
+
+package i18n
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// DefaultRefreshInterval is the provider refresh interval of watching managers
+const DefaultRefreshInterval = time.Minute
+
+// Bundle is the content of one locale supplied by a LoaderProvider
+type Bundle struct {
+	Locale      string // Locale of the bundle
+	Format      Format // Format of Data (TOML, YAML, or binary)
+	Data        []byte // Raw language file content
+	ETag        string // Version identifier used for revalidation
+	NotModified bool   // Content is unchanged since the ETag passed to Load
+}
+
+// LoaderProvider supplies locale data to a Manager
+type LoaderProvider interface {
+	// Locales lists the locales available from the source
+	Locales(ctx context.Context) ([]string, error)
+
+	// Load returns the bundle of locale. If etag is not empty and matches
+	// the current version, Load may return a bundle with NotModified set
+	// and without data.
+	Load(ctx context.Context, locale, etag string) (*Bundle, error)
+}
+
+// FSProvider loads language files from a directory of an fs.FS such as
+// an embed.FS
+type FSProvider struct {
+	FS  fs.FS  // Filesystem containing the language files
+	Dir string // Directory within FS (default: ".")
+}
+
+// NewFSProvider creates a provider for the language files in dir of fsys
+func NewFSProvider(fsys fs.FS, dir string) *FSProvider {
+	return &FSProvider{FS: fsys, Dir: dir}
+}
+
+// Locales lists the locales of the language files and compiled bundles in
+// the directory
+func (p *FSProvider) Locales(ctx context.Context) ([]string, error) {
+	entries, err := fs.ReadDir(p.FS, p.dir())
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to read locales directory").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("i18n.FSProvider.Locales").
+			WithDetail("directory", p.dir())
+	}
+
+	var locales []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || formatForExtension(ext) == FormatAuto {
+			continue
+		}
+		locale := strings.TrimSuffix(entry.Name(), ext)
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales, nil
+}
+
+// Load reads the language file of locale. The ETag is a content hash,
+// since embedded files carry no modification time.
+func (p *FSProvider) Load(ctx context.Context, locale, etag string) (*Bundle, error) {
+	for _, ext := range localeExtensions(FormatAuto) {
+		data, err := fs.ReadFile(p.FS, path.Join(p.dir(), locale+ext))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		bundle := &Bundle{Locale: locale, Format: formatForExtension(ext), ETag: `"` + hex.EncodeToString(sum[:8]) + `"`}
+		if bundle.ETag == etag {
+			bundle.NotModified = true
+			return bundle, nil
+		}
+		bundle.Data = data
+		return bundle, nil
+	}
+	return nil, mdwerror.New("language file not found").
+		WithCode(mdwerror.CodeNotFound).
+		WithOperation("i18n.FSProvider.Load").
+		WithDetail("locale", locale).
+		WithDetail("directory", p.dir())
+}
+
+// dir returns the directory within the filesystem
+func (p *FSProvider) dir() string {
+	if p.Dir == "" {
+		return "."
+	}
+	return p.Dir
+}
+
+// HTTPProvider loads language files from an HTTP endpoint as
+// <BaseURL>/<locale>.<ext>, revalidating with If-None-Match
+type HTTPProvider struct {
+	BaseURL     string       // Endpoint serving the language files
+	LocaleNames []string     // Available locales; fetched from <BaseURL>/locales.json if empty
+	Format      Format       // Format of the files (default: TOML)
+	Client      *http.Client // HTTP client (default: client with 30s timeout)
+	Header      http.Header  // Additional request headers, e.g. authorization
+}
+
+// NewHTTPProvider creates a provider for the language files at baseURL
+func NewHTTPProvider(baseURL string, locales ...string) *HTTPProvider {
+	return &HTTPProvider{BaseURL: strings.TrimSuffix(baseURL, "/"), LocaleNames: locales}
+}
+
+// Locales returns the configured locales or the JSON array served at
+// <BaseURL>/locales.json
+func (p *HTTPProvider) Locales(ctx context.Context) ([]string, error) {
+	if len(p.LocaleNames) > 0 {
+		return append([]string(nil), p.LocaleNames...), nil
+	}
+
+	response, err := p.get(ctx, p.BaseURL+"/locales.json", "")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var locales []string
+	if err := json.NewDecoder(response.Body).Decode(&locales); err != nil {
+		return nil, mdwerror.Wrap(err, "invalid locale index").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("i18n.HTTPProvider.Locales").
+			WithDetail("url", p.BaseURL+"/locales.json")
+	}
+	return locales, nil
+}
+
+// Load fetches the language file of locale
+func (p *HTTPProvider) Load(ctx context.Context, locale, etag string) (*Bundle, error) {
+	format := p.Format
+	if format == FormatAuto {
+		format = FormatTOML
+	}
+	ext := ".toml"
+	if format == FormatYAML {
+		ext = ".yaml"
+	} else if format == FormatBinary {
+		ext = BundleExtension
+	}
+
+	response, err := p.get(ctx, p.BaseURL+"/"+locale+ext, etag)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	bundle := &Bundle{Locale: locale, Format: format, ETag: response.Header.Get("ETag")}
+	if response.StatusCode == http.StatusNotModified {
+		bundle.ETag = etag
+		bundle.NotModified = true
+		return bundle, nil
+	}
+	if bundle.Data, err = io.ReadAll(response.Body); err != nil {
+		return nil, mdwerror.Wrap(err, "failed to read language file").
+			WithCode(mdwerror.CodeNetworkError).
+			WithOperation("i18n.HTTPProvider.Load").
+			WithDetail("locale", locale)
+	}
+	return bundle, nil
+}
+
+// get performs a GET request and fails on statuses other than 200 and 304
+func (p *HTTPProvider) get(ctx context.Context, url, etag string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "invalid request").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("i18n.HTTPProvider").
+			WithDetail("url", url)
+	}
+	for name, values := range p.Header {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "request failed").
+			WithCode(mdwerror.CodeConnectionFailed).
+			WithOperation("i18n.HTTPProvider").
+			WithDetail("url", url)
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotModified {
+		response.Body.Close()
+		return nil, mdwerror.New(fmt.Sprintf("unexpected status %d", response.StatusCode)).
+			WithCode(mdwerror.CodeServiceUnavailable).
+			WithOperation("i18n.HTTPProvider").
+			WithDetail("url", url).
+			WithDetail("status", response.StatusCode)
+	}
+	return response, nil
+}
+
+// Refresh revalidates all locales of the provider and reloads the changed
+// ones. Locales that fail to load keep their cached translations. It returns
+// the reloaded locales and the first error.
+func (m *Manager) Refresh(ctx context.Context) ([]string, error) {
+	if m.provider == nil {
+		return nil, mdwerror.New("manager has no provider").
+			WithCode(mdwerror.CodeInvalidOperation).
+			WithOperation("i18n.Refresh")
+	}
+
+	locales, err := m.provider.Locales(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []string
+	var firstErr error
+	for _, locale := range locales {
+		m.mu.RLock()
+		etag := m.bundleETags[locale]
+		m.mu.RUnlock()
+
+		bundle, err := m.provider.Load(ctx, locale, etag)
+		if err == nil && !bundle.NotModified {
+			var data TranslationData
+			if data, err = m.parseLocale(bundle.Data, bundle.Format, locale); err == nil {
+				m.mu.Lock()
+				m.translations[locale] = data
+				m.bundleETags[locale] = bundle.ETag
+				m.mu.Unlock()
+				updated = append(updated, locale)
+			}
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if len(updated) > 0 {
+		m.mu.Lock()
+		m.clearTemplateCache("")
+		watchers := append([]LocaleChangeHandler(nil), m.watchers...)
+		copies := make(map[string]map[string]interface{}, len(updated))
+		for _, locale := range updated {
+			copies[locale] = m.deepCopyTranslations(m.translations[locale])
+		}
+		m.mu.Unlock()
+
+		for _, locale := range updated {
+			for _, handler := range watchers {
+				if handler != nil {
+					go handler(locale, copies[locale])
+				}
+			}
+		}
+	}
+	return updated, firstErr
+}
+
+// loadFromProvider performs the initial load from the provider
+func (m *Manager) loadFromProvider() error {
+	// Other locales failing to load do not prevent startup
+	_, err := m.Refresh(context.Background())
+
+	m.mu.RLock()
+	_, loaded := m.translations[m.defaultLocale]
+	m.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("default locale '%s' not found", m.defaultLocale)
+}
+
+// startRefreshing refreshes from the provider until watching stops
+func (m *Manager) startRefreshing(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !m.IsWatching() {
+			return
+		}
+		// Errors keep the cached translations until the next refresh
+		m.Refresh(context.Background())
+	}
+}
+
+// parseTranslations parses the content of a language file
+func parseTranslations(content []byte, format Format, source string) (TranslationData, error) {
+	var data TranslationData
+	switch format {
+	case FormatTOML:
+		if err := toml.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML file %s: %w", source, err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML file %s: %w", source, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format for file %s", source)
+	}
+	if data == nil {
+		data = TranslationData{}
+	}
+	return data, nil
+}
+
+// formatForExtension returns the format of a file extension, or FormatAuto
+// for unsupported extensions
+func formatForExtension(ext string) Format {
+	switch strings.ToLower(ext) {
+	case ".toml":
+		return FormatTOML
+	case ".yaml", ".yml":
+		return FormatYAML
+	case BundleExtension:
+		return FormatBinary
+	default:
+		return FormatAuto
+	}
+}
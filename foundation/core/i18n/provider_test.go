@@ -0,0 +1,174 @@
+// File: provider_test.go
+// Title: Translation Bundle Provider Tests
+// Description: Tests loading from filesystems and HTTP endpoints, ETag
+//              revalidation, refresh with change notification, and custom
+//              providers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial provider tests
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFSProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.toml":   {Data: []byte("[app]\ntitle = \"Title\"\n")},
+		"locales/de.yaml":   {Data: []byte("app:\n  title: Titel\n")},
+		"locales/README.md": {Data: []byte("ignored")},
+	}
+
+	manager, err := New(Options{DefaultLocale: "en", Provider: NewFSProvider(fsys, "locales")})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if locales := manager.GetAvailableLocales(); !reflect.DeepEqual(locales, []string{"de", "en"}) {
+		t.Errorf("GetAvailableLocales() = %v", locales)
+	}
+	if got := manager.TInLocale("de", "app.title"); got != "Titel" {
+		t.Errorf("TInLocale(de) = %q", got)
+	}
+
+	// Unchanged content is revalidated by hash and not reloaded
+	updated, err := manager.Refresh(context.Background())
+	if err != nil || len(updated) != 0 {
+		t.Errorf("Refresh() = %v, %v; want no updates", updated, err)
+	}
+
+	fsys["locales/de.yaml"] = &fstest.MapFile{Data: []byte("app:\n  title: Überschrift\n")}
+	if updated, err = manager.Refresh(context.Background()); err != nil || !reflect.DeepEqual(updated, []string{"de"}) {
+		t.Errorf("Refresh() after change = %v, %v", updated, err)
+	}
+	if got := manager.TInLocale("de", "app.title"); got != "Überschrift" {
+		t.Errorf("TInLocale(de) after refresh = %q", got)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var mu sync.Mutex
+	content := map[string]string{"en": "[app]\ntitle = \"Title\"\n", "fr": "[app]\ntitle = \"Titre\"\n"}
+	version := map[string]string{"en": `"v1"`, "fr": `"v1"`}
+	fullResponses := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/locales.json" {
+			w.Write([]byte(`["en", "fr"]`))
+			return
+		}
+		locale := r.URL.Path[1 : len(r.URL.Path)-len(".toml")]
+		if r.Header.Get("If-None-Match") == version[locale] {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", version[locale])
+		w.Write([]byte(content[locale]))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL + "/")
+	provider.Header = http.Header{"Authorization": {"Bearer token"}}
+
+	manager, err := New(Options{DefaultLocale: "en", Provider: provider})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := manager.TInLocale("fr", "app.title"); got != "Titre" || fullResponses != 2 {
+		t.Errorf("TInLocale(fr) = %q after %d responses", got, fullResponses)
+	}
+
+	mu.Lock()
+	content["fr"], version["fr"] = "[app]\ntitle = \"Intitulé\"\n", `"v2"`
+	mu.Unlock()
+
+	changed := make(chan string, 1)
+	manager.OnLocaleChange(func(locale string, translations map[string]interface{}) { changed <- locale })
+
+	updated, err := manager.Refresh(context.Background())
+	if err != nil || !reflect.DeepEqual(updated, []string{"fr"}) || fullResponses != 3 {
+		t.Errorf("Refresh() = %v, %v after %d responses", updated, err, fullResponses)
+	}
+	if got := manager.TInLocale("fr", "app.title"); got != "Intitulé" {
+		t.Errorf("TInLocale(fr) after refresh = %q", got)
+	}
+	select {
+	case locale := <-changed:
+		if locale != "fr" {
+			t.Errorf("change notification for %q", locale)
+		}
+	case <-time.After(time.Second):
+		t.Error("no change notification")
+	}
+
+	// Failed refreshes keep the cached translations
+	server.Close()
+	if _, err := manager.Refresh(context.Background()); err == nil {
+		t.Error("Refresh() without server succeeded")
+	}
+	if got := manager.TInLocale("fr", "app.title"); got != "Intitulé" {
+		t.Errorf("cached translation lost: %q", got)
+	}
+}
+
+// tableProvider serves locales from a map, like a database table with a
+// version column
+type tableProvider struct {
+	rows  map[string][2]string // locale -> content, version
+	loads int
+}
+
+func (p *tableProvider) Locales(ctx context.Context) ([]string, error) {
+	return []string{"en", "es"}, nil
+}
+
+func (p *tableProvider) Load(ctx context.Context, locale, etag string) (*Bundle, error) {
+	p.loads++
+	row := p.rows[locale]
+	if row[1] == etag {
+		return &Bundle{Locale: locale, ETag: etag, NotModified: true}, nil
+	}
+	return &Bundle{Locale: locale, Format: FormatYAML, Data: []byte(row[0]), ETag: row[1]}, nil
+}
+
+func TestCustomProvider(t *testing.T) {
+	provider := &tableProvider{rows: map[string][2]string{
+		"en": {"greeting: Hello\n", "1"},
+		"es": {"greeting: Hola\n", "1"},
+	}}
+	manager, err := New(Options{DefaultLocale: "en", Provider: provider})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := manager.TInLocale("es", "greeting"); got != "Hola" {
+		t.Errorf("TInLocale(es) = %q", got)
+	}
+	if err := manager.ReloadAll(); err != nil || provider.loads != 4 {
+		t.Errorf("ReloadAll() = %v after %d loads", err, provider.loads)
+	}
+}
+
+func TestProvider_MissingDefaultLocale(t *testing.T) {
+	fsys := fstest.MapFS{"de.toml": {Data: []byte("a = \"b\"\n")}}
+	if _, err := New(Options{DefaultLocale: "en", Provider: NewFSProvider(fsys, "")}); err == nil {
+		t.Error("New() without default locale succeeded")
+	}
+}
@@ -0,0 +1,120 @@
+// File: revision_test.go
+// Title: Message Revision Tests
+// Description: Tests active revisions, runtime overrides, experiment
+//              assignment per user, and revisions of plural messages.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial revision tests
+
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newRevisionManager creates a manager with revised checkout messages
+func newRevisionManager(t *testing.T, assigner ExperimentAssigner) *Manager {
+	tempDir := t.TempDir()
+	content := `
+[checkout.cta]
+_active = "v1"
+v1 = "Buy now"
+v2 = "Complete purchase for {{.Amount | currency}}"
+
+[checkout.items]
+_active = "v2"
+v1 = ["{{.Count}} item", "{{.Count}} items"]
+v2 = ["{{.Count}} product", "{{.Count}} products"]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "en.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML, ExperimentAssigner: assigner})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return manager
+}
+
+func TestRevisions(t *testing.T) {
+	manager := newRevisionManager(t, nil)
+	data := map[string]interface{}{"Amount": 20}
+
+	if got := manager.T("checkout.cta", data); got != "Buy now" {
+		t.Errorf("active revision = %q", got)
+	}
+	if got := manager.Plural("checkout.items", 2, map[string]interface{}{"Count": 2}); got != "2 products" {
+		t.Errorf("plural revision = %q", got)
+	}
+
+	manager.SetActiveRevision("checkout.cta", "v2")
+	if got := manager.T("checkout.cta", data); got != "Complete purchase for $20.00" {
+		t.Errorf("overridden revision = %q", got)
+	}
+	manager.SetActiveRevision("checkout.cta", "")
+	if got := manager.T("checkout.cta", data); got != "Buy now" {
+		t.Errorf("restored revision = %q", got)
+	}
+
+	active, revisions := manager.Revisions("checkout.cta", "en")
+	if active != "v1" || !reflect.DeepEqual(revisions, []string{"v1", "v2"}) {
+		t.Errorf("Revisions() = %q, %v", active, revisions)
+	}
+	if !manager.HasTranslationInLocale("checkout.cta", "en") {
+		t.Error("revision table not reported as translation")
+	}
+	if keys := manager.GetTranslationKeys(); !reflect.DeepEqual(keys, []string{"checkout.cta", "checkout.items"}) {
+		t.Errorf("GetTranslationKeys() = %v", keys)
+	}
+}
+
+func TestRevisions_Experiment(t *testing.T) {
+	var calls []string
+	manager := newRevisionManager(t, func(key, userID string, revisions []string) string {
+		calls = append(calls, key+"/"+userID)
+		if key == "checkout.cta" && userID == "beta" {
+			return "v2"
+		}
+		return ""
+	})
+	data := map[string]interface{}{"Amount": 5}
+
+	if got := manager.WithUserID("beta").T("checkout.cta", data); got != "Complete purchase for $5.00" {
+		t.Errorf("experiment revision = %q", got)
+	}
+	if got := manager.WithUserID("control").T("checkout.cta", data); got != "Buy now" {
+		t.Errorf("control revision = %q", got)
+	}
+	if len(calls) != 2 || calls[0] != "checkout.cta/beta" {
+		t.Errorf("assigner calls = %v", calls)
+	}
+}
+
+func TestHashAssigner(t *testing.T) {
+	assign := HashAssigner("checkout-2026")
+	revisions := []string{"v1", "v2"}
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		revision := assign("checkout.cta", user, revisions)
+		if again := assign("checkout.cta", user, revisions); again != revision {
+			t.Fatalf("assignment of %s not stable", user)
+		}
+		counts[revision]++
+	}
+	if counts["v1"] < 400 || counts["v2"] < 400 {
+		t.Errorf("uneven assignment: %v", counts)
+	}
+	if assign("checkout.cta", "", revisions) != "" {
+		t.Error("anonymous user assigned to an experiment")
+	}
+}
@@ -0,0 +1,126 @@
+// File: variant.go
+// Title: Select-Style Message Variants
+// Description: Selects message variants keyed by arbitrary dimensions such
+//              as gender, formality, or grammatical case. Variants are nested
+//              tables below the message key; each selector picks one level,
+//              with "other" as the fallback at every level.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of message variants
+
+package i18n
+
+import (
+	"strings"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// VariantOther is the variant used when a selector has no entry
+const VariantOther = "other"
+
+// SelectVariant translates a message with variants in the current locale.
+// Each selector chooses an entry on one level of the variant table:
+//
+//	[letter.salutation.formal]
+//	female = "Sehr geehrte Frau {{.Name}}"
+//	male = "Sehr geehrter Herr {{.Name}}"
+//	other = "Guten Tag {{.Name}}"
+//
+//	m.SelectVariant("letter.salutation", data, "formal", "female")
+func (m *Manager) SelectVariant(key string, data map[string]interface{}, selectors ...string) string {
+	translation, _ := m.TrySelectVariantInLocale("", key, data, selectors...)
+	return translation
+}
+
+// SelectVariantInLocale translates a message with variants in a specific
+// locale
+func (m *Manager) SelectVariantInLocale(locale, key string, data map[string]interface{}, selectors ...string) string {
+	translation, _ := m.TrySelectVariantInLocale(locale, key, data, selectors...)
+	return translation
+}
+
+// TrySelectVariantInLocale translates a message with variants and returns
+// an error if no variant matches. An empty locale uses the current locale.
+// If the selected entry is a plural table, data["Count"] chooses the plural
+// form; remaining tables resolve through "other".
+func (m *Manager) TrySelectVariantInLocale(locale, key string, data map[string]interface{}, selectors ...string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if locale == "" {
+		locale = m.currentLocale
+	}
+
+	node, source := m.resolveRawValue(key, locale)
+	path := make([]string, 0, len(selectors)+1)
+	for _, selector := range selectors {
+		variants, ok := variantTable(node)
+		if !ok {
+			break // Fewer levels than selectors: the remaining ones do not apply
+		}
+		choice := selector
+		if _, exists := variants[choice]; !exists {
+			choice = VariantOther
+		}
+		node = variants[choice]
+		path = append(path, choice)
+	}
+
+	// Resolve remaining levels through plural tables and "other"
+	for node != nil {
+		if table, ok := pluralTable(node); ok {
+			category := PluralOther
+			if count, exists := data["Count"]; exists {
+				category = PluralCategoryFor(source, int(toFloat64(count)))
+			}
+			if _, exists := table[string(category)]; !exists {
+				category = PluralOther
+			}
+			node = table[string(category)]
+			path = append(path, string(category))
+			continue
+		}
+		variants, ok := variantTable(node)
+		if !ok {
+			break
+		}
+		node = variants[VariantOther]
+		path = append(path, VariantOther)
+	}
+
+	translation, ok := node.(string)
+	if !ok || translation == "" {
+		return "", mdwerror.New("message variant not found").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("i18n.SelectVariant").
+			WithDetail("key", key).
+			WithDetail("locale", locale).
+			WithDetail("selectors", selectors)
+	}
+
+	if data != nil {
+		rendered, err := m.renderTemplate(locale, key+"_variant_"+strings.Join(path, "."), translation, data)
+		if err != nil {
+			return translation, mdwerror.Wrap(err, "template rendering failed").WithCode(mdwerror.CodeInvalidOperation).WithOperation("i18n.renderTemplate")
+		}
+		return rendered, nil
+	}
+	return translation, nil
+}
+
+// variantTable returns value as a table of variants
+func variantTable(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case TranslationData:
+		return v, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,101 @@
+// File: variant_test.go
+// Title: Message Variant Tests
+// Description: Tests variant selection by gender, formality, and case,
+//              "other" fallbacks, and variants combined with plural forms.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial variant tests
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSelectVariant(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"en.toml": `
+[letter.salutation]
+other = "Dear {{.Name}}"
+`,
+		"de.toml": `
+[letter.salutation.formal]
+female = "Sehr geehrte Frau {{.Name}}"
+male = "Sehr geehrter Herr {{.Name}}"
+other = "Guten Tag {{.Name}}"
+
+[letter.salutation.informal]
+female = "Liebe {{.Name}}"
+male = "Lieber {{.Name}}"
+other = "Hallo {{.Name}}"
+
+[product.invoice]
+nominative = "die Rechnung"
+genitive = "der Rechnung"
+`,
+		"ru.toml": `
+[order.shipped.female]
+one = "{{.Name}} отправила {{.Count}} заказ"
+few = "{{.Name}} отправила {{.Count}} заказа"
+many = "{{.Name}} отправила {{.Count}} заказов"
+other = "{{.Name}} отправила {{.Count}} заказа"
+
+[order.shipped.male]
+one = "{{.Name}} отправил {{.Count}} заказ"
+few = "{{.Name}} отправил {{.Count}} заказа"
+many = "{{.Name}} отправил {{.Count}} заказов"
+other = "{{.Name}} отправил {{.Count}} заказа"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := New(Options{DefaultLocale: "en", LocalesDir: tempDir, Format: FormatTOML})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		locale    string
+		key       string
+		data      map[string]interface{}
+		selectors []string
+		expected  string
+	}{
+		{"de", "letter.salutation", map[string]interface{}{"Name": "Weber"}, []string{"formal", "female"}, "Sehr geehrte Frau Weber"},
+		{"de", "letter.salutation", map[string]interface{}{"Name": "Weber"}, []string{"formal", "male"}, "Sehr geehrter Herr Weber"},
+		{"de", "letter.salutation", map[string]interface{}{"Name": "Kim"}, []string{"informal", "diverse"}, "Hallo Kim"},
+		{"de", "letter.salutation", map[string]interface{}{"Name": "Kim"}, []string{"informal"}, "Hallo Kim"},
+		{"de", "product.invoice", nil, []string{"genitive"}, "der Rechnung"},
+		{"en", "letter.salutation", map[string]interface{}{"Name": "Ms Weber"}, []string{"formal", "female"}, "Dear Ms Weber"},
+		{"ru", "order.shipped", map[string]interface{}{"Name": "Анна", "Count": 3}, []string{"female"}, "Анна отправила 3 заказа"},
+		{"ru", "order.shipped", map[string]interface{}{"Name": "Иван", "Count": 5}, []string{"male"}, "Иван отправил 5 заказов"},
+		{"ru", "order.shipped", map[string]interface{}{"Name": "Иван", "Count": 21}, []string{"male"}, "Иван отправил 21 заказ"},
+	}
+	for _, tt := range tests {
+		if got := manager.SelectVariantInLocale(tt.locale, tt.key, tt.data, tt.selectors...); got != tt.expected {
+			t.Errorf("[%s] SelectVariant(%s, %v) = %q, want %q", tt.locale, tt.key, tt.selectors, got, tt.expected)
+		}
+	}
+
+	manager.SetLocale("de")
+	if got := manager.SelectVariant("letter.salutation", map[string]interface{}{"Name": "Weber"}, "formal", "male"); got != "Sehr geehrter Herr Weber" {
+		t.Errorf("SelectVariant() = %q", got)
+	}
+
+	if _, err := manager.TrySelectVariantInLocale("de", "product.invoice", nil, "dative"); err == nil {
+		t.Error("missing variant without other did not fail")
+	}
+	if _, err := manager.TrySelectVariantInLocale("de", "missing.key", nil); err == nil {
+		t.Error("missing key did not fail")
+	}
+}
@@ -3,20 +3,23 @@
 // Description: Implements file system watching for language files to support
 //              hot-reloading and automatic translation updates during development.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.3
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation of locale file watching
+// - 2026-10-16 v0.1.1: ReloadAll refreshes from the provider if one is set
+// - 2026-10-16 v0.1.2: Template cache is cleared in place, so clones keep sharing it
+// - 2026-10-16 v0.1.3: Also watches compiled bundles
 
 package i18n
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 	"time"
 
 	mdwerror "github.com/msto63/mDW/foundation/core/error"
@@ -79,7 +82,7 @@ func (m *Manager) scanLocaleFiles(watchedFiles map[string]watchedFileInfo) error
 		
 		// Check if file has a supported extension
 		ext := strings.ToLower(filepath.Ext(fileName))
-		if ext != ".toml" && ext != ".yaml" && ext != ".yml" {
+		if ext != ".toml" && ext != ".yaml" && ext != ".yml" && ext != BundleExtension {
 			continue
 		}
 
@@ -163,7 +166,7 @@ func (m *Manager) scanForNewFiles(watchedFiles map[string]watchedFileInfo) error
 
 		// Check if file has a supported extension
 		ext := strings.ToLower(filepath.Ext(fileName))
-		if ext != ".toml" && ext != ".yaml" && ext != ".yml" {
+		if ext != ".toml" && ext != ".yaml" && ext != ".yml" && ext != BundleExtension {
 			continue
 		}
 
@@ -257,7 +260,11 @@ func (m *Manager) handleFileDeleted(locale string) {
 func (m *Manager) clearTemplateCache(locale string) {
 	// Clear all templates (simple approach - could be optimized to only clear
 	// templates that are related to the specific locale)
-	m.templates = make(map[string]*template.Template)
+	m.templateMu.Lock()
+	defer m.templateMu.Unlock()
+	for key := range m.templates {
+		delete(m.templates, key)
+	}
 }
 
 // deepCopyTranslations creates a deep copy of translation data
@@ -296,7 +303,11 @@ func (m *Manager) IsWatching() bool {
 	return m.watching
 }
 
-// ReloadAll reloads all locale files
+// ReloadAll reloads all locale files, or refreshes from the provider
 func (m *Manager) ReloadAll() error {
+	if m.provider != nil {
+		_, err := m.Refresh(context.Background())
+		return err
+	}
 	return m.loadAllLocales()
 }
\ No newline at end of file
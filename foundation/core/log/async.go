@@ -0,0 +1,254 @@
+// File: async.go
+// Title: Asynchronous Buffered Writer
+// Description: Implements AsyncWriter, which decouples log producers from
+//              slow outputs through a bounded ring buffer with a configurable
+//              overflow policy, and drains all buffered records on Close.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the asynchronous writer
+
+package log
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy defines how an AsyncWriter handles writes to a full buffer
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the writer until the buffer has room; no record is lost
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDrop discards records written while the buffer is full
+	OverflowDrop
+
+	// OverflowSample keeps every SampleRate-th record written while the buffer
+	// is full, replacing the oldest buffered record, and discards the others
+	OverflowSample
+)
+
+// String returns the string representation of the overflow policy
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDrop:
+		return "drop"
+	case OverflowSample:
+		return "sample"
+	default:
+		return "unknown"
+	}
+}
+
+// AsyncOptions configures an AsyncWriter
+type AsyncOptions struct {
+	BufferSize int            // Maximum number of buffered records (default: 1000)
+	Overflow   OverflowPolicy // Policy when the buffer is full (default: OverflowBlock)
+	SampleRate int            // Records kept per overflow with OverflowSample (default: 1 in 10)
+}
+
+// DefaultAsyncOptions returns the default asynchronous writer options
+func DefaultAsyncOptions() AsyncOptions {
+	return AsyncOptions{
+		BufferSize: 1000,
+		Overflow:   OverflowBlock,
+		SampleRate: 10,
+	}
+}
+
+// AsyncStats reports the activity of an AsyncWriter
+type AsyncStats struct {
+	Written  uint64 // Records written to the output
+	Dropped  uint64 // Records discarded by the overflow policy
+	Buffered int    // Records currently buffered
+}
+
+// AsyncWriter writes records to an output from a background goroutine. Each
+// Write call is one record. Records written after Close are written
+// synchronously, so none are lost during shutdown.
+type AsyncWriter struct {
+	output  io.Writer
+	options AsyncOptions
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	ring      [][]byte
+	head      int
+	count     int
+	writing   bool
+	closed    bool
+	overflows uint64
+	done      chan struct{}
+
+	outputMu sync.Mutex // Serializes writes to output
+	written  atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// NewAsyncWriter creates an asynchronous writer for output and starts its
+// background goroutine
+func NewAsyncWriter(output io.Writer, options AsyncOptions) *AsyncWriter {
+	defaults := DefaultAsyncOptions()
+	if options.BufferSize <= 0 {
+		options.BufferSize = defaults.BufferSize
+	}
+	if options.SampleRate <= 0 {
+		options.SampleRate = defaults.SampleRate
+	}
+
+	w := &AsyncWriter{
+		output:  output,
+		options: options,
+		ring:    make([][]byte, options.BufferSize),
+		done:    make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+	go w.run()
+	return w
+}
+
+// Write buffers a copy of p as one record. It always reports len(p) bytes
+// written, even if the overflow policy discards the record.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	record := append([]byte(nil), p...)
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.writeRecord(record)
+	}
+
+	if w.count == len(w.ring) {
+		switch w.options.Overflow {
+		case OverflowDrop:
+			w.mu.Unlock()
+			w.dropped.Add(1)
+			return len(p), nil
+		case OverflowSample:
+			w.overflows++
+			w.dropped.Add(1)
+			if w.overflows%uint64(w.options.SampleRate) != 0 {
+				w.mu.Unlock()
+				return len(p), nil
+			}
+			// Replace the oldest record
+			w.head = (w.head + 1) % len(w.ring)
+			w.count--
+		default:
+			for w.count == len(w.ring) && !w.closed {
+				w.cond.Wait()
+			}
+			if w.closed {
+				w.mu.Unlock()
+				return w.writeRecord(record)
+			}
+		}
+	}
+
+	w.ring[(w.head+w.count)%len(w.ring)] = record
+	w.count++
+	w.cond.Broadcast()
+	w.mu.Unlock()
+	return len(p), nil
+}
+
+// Flush blocks until all records buffered before the call are written
+func (w *AsyncWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for (w.count > 0 || w.writing) && !w.isStopped() {
+		w.cond.Wait()
+	}
+}
+
+// Close writes all buffered records and stops the background goroutine.
+// The output is not closed.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		w.cond.Broadcast()
+	}
+	w.mu.Unlock()
+	<-w.done
+	return nil
+}
+
+// Stats returns the activity counters of the writer
+func (w *AsyncWriter) Stats() AsyncStats {
+	w.mu.Lock()
+	buffered := w.count
+	w.mu.Unlock()
+	return AsyncStats{
+		Written:  w.written.Load(),
+		Dropped:  w.dropped.Load(),
+		Buffered: buffered,
+	}
+}
+
+// run writes buffered records until the writer is closed and drained
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	batch := make([][]byte, 0, len(w.ring))
+	for {
+		w.mu.Lock()
+		for w.count == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if w.count == 0 {
+			w.mu.Unlock()
+			return
+		}
+
+		// Take all buffered records at once
+		batch = batch[:0]
+		for w.count > 0 {
+			batch = append(batch, w.ring[w.head])
+			w.ring[w.head] = nil
+			w.head = (w.head + 1) % len(w.ring)
+			w.count--
+		}
+		w.writing = true
+		w.cond.Broadcast()
+		w.mu.Unlock()
+
+		for _, record := range batch {
+			w.writeRecord(record)
+		}
+
+		w.mu.Lock()
+		w.writing = false
+		w.cond.Broadcast()
+		w.mu.Unlock()
+	}
+}
+
+// writeRecord writes one record to the output
+func (w *AsyncWriter) writeRecord(record []byte) (int, error) {
+	w.outputMu.Lock()
+	defer w.outputMu.Unlock()
+	n, err := w.output.Write(record)
+	if err == nil {
+		w.written.Add(1)
+	}
+	return n, err
+}
+
+// isStopped reports whether the background goroutine has exited
+func (w *AsyncWriter) isStopped() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
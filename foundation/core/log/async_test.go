@@ -0,0 +1,173 @@
+// File: async_test.go
+// Title: Asynchronous Buffered Writer Tests
+// Description: Tests buffering, overflow policies, flushing, and draining on
+//              close of the asynchronous writer and async loggers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial asynchronous writer tests
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// gatedWriter blocks writes until the gate is opened
+type gatedWriter struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{gate: make(chan struct{})}
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Split(strings.TrimSpace(w.buf.String()), "\n")
+}
+
+// fillAsyncWriter writes records until the writer holds one record in
+// flight and a full buffer
+func fillAsyncWriter(t *testing.T, w *AsyncWriter, records int) {
+	t.Helper()
+	for i := 0; i < records; i++ {
+		fmt.Fprintf(w, "record %d\n", i)
+		if i == 0 {
+			// Wait until the first record is taken by the background goroutine
+			for w.Stats().Buffered != 0 {
+				runtime.Gosched()
+			}
+		}
+	}
+}
+
+func TestAsyncWriter_FlushAndClose(t *testing.T) {
+	output := newGatedWriter()
+	close(output.gate)
+	w := NewAsyncWriter(output, AsyncOptions{BufferSize: 4})
+
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(w, "record %d\n", i)
+	}
+	w.Flush()
+	if lines := output.lines(); len(lines) != 100 || lines[99] != "record 99" {
+		t.Errorf("Flush() wrote %d records, last %q", len(lines), lines[len(lines)-1])
+	}
+
+	fmt.Fprintln(w, "before close")
+	w.Close()
+	fmt.Fprintln(w, "after close")
+	lines := output.lines()
+	if lines[len(lines)-2] != "before close" || lines[len(lines)-1] != "after close" {
+		t.Errorf("records around Close() lost: %v", lines[len(lines)-2:])
+	}
+	if stats := w.Stats(); stats.Written != 102 || stats.Dropped != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestAsyncWriter_OverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     AsyncOptions
+		wantWritten []string
+		wantDropped uint64
+	}{
+		{
+			name:        "drop",
+			options:     AsyncOptions{BufferSize: 2, Overflow: OverflowDrop},
+			wantWritten: []string{"record 0", "record 1", "record 2"},
+			wantDropped: 3,
+		},
+		{
+			name:        "sample",
+			options:     AsyncOptions{BufferSize: 2, Overflow: OverflowSample, SampleRate: 2},
+			wantWritten: []string{"record 0", "record 2", "record 4"},
+			wantDropped: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := newGatedWriter()
+			w := NewAsyncWriter(output, tt.options)
+			fillAsyncWriter(t, w, 6)
+
+			close(output.gate)
+			w.Close()
+			if got := output.lines(); strings.Join(got, ",") != strings.Join(tt.wantWritten, ",") {
+				t.Errorf("written = %v, want %v", got, tt.wantWritten)
+			}
+			if stats := w.Stats(); stats.Dropped != tt.wantDropped {
+				t.Errorf("Stats().Dropped = %d, want %d", stats.Dropped, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestAsyncWriter_Block(t *testing.T) {
+	output := newGatedWriter()
+	w := NewAsyncWriter(output, AsyncOptions{BufferSize: 1, Overflow: OverflowBlock})
+	fillAsyncWriter(t, w, 2)
+
+	written := make(chan struct{})
+	go func() {
+		fmt.Fprintln(w, "blocked")
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("Write() to a full buffer did not block")
+	default:
+	}
+
+	close(output.gate)
+	<-written
+	w.Close()
+	if lines := output.lines(); len(lines) != 3 || lines[2] != "blocked" {
+		t.Errorf("written = %v", lines)
+	}
+}
+
+func TestLogger_Async(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Level:           LevelInfo,
+		Format:          FormatText,
+		Output:          &buf,
+		AsyncEnabled:    true,
+		AsyncBufferSize: 8,
+	})
+
+	for i := 0; i < 50; i++ {
+		logger.Info("async entry", Int("i", i))
+	}
+	logger.WithField("component", "clone").Info("from clone")
+	logger.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 51 {
+		t.Fatalf("Close() wrote %d entries, want 51", len(lines))
+	}
+	if !strings.Contains(lines[50], "from clone") {
+		t.Errorf("last entry = %s", lines[50])
+	}
+}
@@ -0,0 +1,254 @@
+// File: audit.go
+// Title: Tamper-Evident Audit Log Stream
+// Description: Implements an append-only audit sink that writes audit entries
+//              as JSON lines with per-record SHA-256 hash chaining, and
+//              verification of audit streams and files so that modified,
+//              removed, or reordered records are detected.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the hash-chained audit sink
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// maxAuditRecordSize bounds the length of a single audit record line
+const maxAuditRecordSize = 1024 * 1024
+
+// AuditRecord is one line of an audit stream. Hash is the hex SHA-256 of the
+// record's JSON encoding without Hash; PrevHash links it to the previous
+// record and is empty for the first record.
+type AuditRecord struct {
+	Seq           uint64                 `json:"seq"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Message       string                 `json:"message"`
+	Logger        string                 `json:"logger,omitempty"`
+	RequestID     string                 `json:"request_id,omitempty"`
+	UserID        string                 `json:"user_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Error         string                 `json:"error,omitempty"`
+	Fields        map[string]interface{} `json:"fields,omitempty"`
+	PrevHash      string                 `json:"prev_hash"`
+	Hash          string                 `json:"hash,omitempty"`
+}
+
+// computeHash returns the hash of the record without its Hash field
+func (r AuditRecord) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditSink writes audit entries to an append-only, hash-chained stream.
+// It is safe for concurrent use.
+type AuditSink struct {
+	mu       sync.Mutex
+	output   io.Writer
+	seq      uint64
+	lastHash string
+}
+
+// NewAuditSink creates an audit sink that starts a new chain on output
+func NewAuditSink(output io.Writer) *AuditSink {
+	return &AuditSink{output: output}
+}
+
+// OpenAuditFile opens the audit file at path for appending, creating it with
+// owner-only permissions if needed, and continues its chain. The existing
+// records are verified first so that a broken file is not extended.
+func OpenAuditFile(path string) (*AuditSink, error) {
+	sink := &AuditSink{}
+	if existing, err := os.Open(path); err == nil {
+		last, verifyErr := verifyAudit(existing)
+		existing.Close()
+		if verifyErr != nil {
+			return nil, verifyErr
+		}
+		if last != nil {
+			sink.seq = last.Seq
+			sink.lastHash = last.Hash
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, mdwerror.Wrap(err, "failed to open audit file").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("log.OpenAuditFile").
+			WithDetail("path", path)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, mdwerror.Wrap(err, "failed to open audit file").
+			WithCode(mdwerror.CodeConfigError).
+			WithOperation("log.OpenAuditFile").
+			WithDetail("path", path)
+	}
+	sink.output = file
+	return sink, nil
+}
+
+// Write appends entry as the next record of the chain
+func (s *AuditSink) Write(entry *Entry) error {
+	record := AuditRecord{
+		Timestamp:     entry.Timestamp.UTC(),
+		Message:       entry.Message,
+		Logger:        entry.Logger,
+		RequestID:     entry.RequestID,
+		UserID:        entry.UserID,
+		CorrelationID: entry.CorrelationID,
+	}
+	if entry.Error != nil {
+		record.Error = entry.Error.Error()
+	}
+	if len(entry.Fields) > 0 {
+		// Normalize through JSON so that verification re-encodes identically
+		data, err := json.Marshal(entry.Fields)
+		if err != nil {
+			return mdwerror.Wrap(err, "failed to encode audit fields").
+				WithCode(mdwerror.CodeInvalidInput).
+				WithOperation("log.AuditSink.Write")
+		}
+		json.Unmarshal(data, &record.Fields)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Seq = s.seq + 1
+	record.PrevHash = s.lastHash
+	hash, err := record.computeHash()
+	if err != nil {
+		return mdwerror.Wrap(err, "failed to encode audit record").
+			WithCode(mdwerror.CodeInternal).
+			WithOperation("log.AuditSink.Write")
+	}
+	record.Hash = hash
+
+	line, _ := json.Marshal(record)
+	if _, err := s.output.Write(append(line, '\n')); err != nil {
+		return mdwerror.Wrap(err, "failed to write audit record").
+			WithCode(mdwerror.CodeInternal).
+			WithOperation("log.AuditSink.Write").
+			WithDetail("seq", record.Seq)
+	}
+	s.seq = record.Seq
+	s.lastHash = hash
+	return nil
+}
+
+// Head returns the sequence number and hash of the last written record, for
+// checkpointing the chain outside the audit file
+func (s *AuditSink) Head() (uint64, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seq, s.lastHash
+}
+
+// Close syncs and closes the output if it is a file or other io.Closer
+func (s *AuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file, ok := s.output.(*os.File); ok {
+		file.Sync()
+	}
+	if closer, ok := s.output.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// VerifyAuditLog verifies the hash chain of an audit stream and returns the
+// number of valid records. The error reports the first record that was
+// modified, removed, inserted, or reordered. Removal of trailing records is
+// only detected against a checkpoint recorded from AuditSink.Head.
+func VerifyAuditLog(r io.Reader) (int, error) {
+	last, err := verifyAudit(r)
+	if last == nil {
+		return 0, err
+	}
+	return int(last.Seq), err
+}
+
+// VerifyAuditFile verifies the hash chain of the audit file at path
+func VerifyAuditFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, mdwerror.Wrap(err, "failed to open audit file").
+			WithCode(mdwerror.CodeNotFound).
+			WithOperation("log.VerifyAuditFile").
+			WithDetail("path", path)
+	}
+	defer file.Close()
+	return VerifyAuditLog(file)
+}
+
+// verifyAudit verifies a stream and returns the last valid record
+func verifyAudit(r io.Reader) (*AuditRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxAuditRecordSize)
+
+	var last *AuditRecord
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		expectedSeq := uint64(1)
+		expectedPrev := ""
+		if last != nil {
+			expectedSeq = last.Seq + 1
+			expectedPrev = last.Hash
+		}
+
+		var record AuditRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return last, auditChainError("malformed audit record", expectedSeq)
+		}
+		if record.Seq != expectedSeq {
+			return last, auditChainError("audit record out of sequence", expectedSeq).
+				WithDetail("found_seq", record.Seq)
+		}
+		if record.PrevHash != expectedPrev {
+			return last, auditChainError("audit chain broken", expectedSeq)
+		}
+		if hash, err := record.computeHash(); err != nil || hash != record.Hash {
+			return last, auditChainError("audit record modified", expectedSeq)
+		}
+		last = &record
+	}
+	if err := scanner.Err(); err != nil {
+		return last, mdwerror.Wrap(err, "failed to read audit log").
+			WithCode(mdwerror.CodeInternal).
+			WithOperation("log.VerifyAuditLog")
+	}
+	return last, nil
+}
+
+// auditChainError creates a verification error for the record seq
+func auditChainError(message string, seq uint64) *mdwerror.Error {
+	return mdwerror.New(message).
+		WithCode(mdwerror.CodeDataCorruption).
+		WithOperation("log.VerifyAuditLog").
+		WithDetail("seq", seq)
+}
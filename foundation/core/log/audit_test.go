@@ -0,0 +1,134 @@
+// File: audit_test.go
+// Title: Audit Log Stream Tests
+// Description: Tests hash chaining of audit records, detection of tampered
+//              streams, continuation of audit files, and logger integration.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial audit log stream tests
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// writeAuditRecords writes count audit entries to sink
+func writeAuditRecords(t *testing.T, sink *AuditSink, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		entry := NewEntry(LevelAudit, "TCOL command executed")
+		entry.UserID = "admin"
+		entry.Fields["command"] = "CUSTOMER.CREATE"
+		entry.Fields["attempt"] = i
+		if err := sink.Write(entry); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+}
+
+func TestAuditSink_Chain(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewAuditSink(&buf)
+	writeAuditRecords(t, sink, 3)
+
+	count, err := VerifyAuditLog(strings.NewReader(buf.String()))
+	if err != nil || count != 3 {
+		t.Fatalf("VerifyAuditLog() = %d, %v", count, err)
+	}
+	if seq, hash := sink.Head(); seq != 3 || !strings.Contains(buf.String(), `"hash":"`+hash+`"`) {
+		t.Errorf("Head() = %d, %q", seq, hash)
+	}
+
+	lines := strings.SplitAfter(strings.TrimSpace(buf.String()), "\n")
+	tests := []struct {
+		name   string
+		stream string
+		valid  int
+		seq    uint64
+	}{
+		{"modified", lines[0] + strings.Replace(lines[1], "admin", "guest", 1) + lines[2], 1, 2},
+		{"removed", lines[0] + lines[2], 1, 2},
+		{"reordered", lines[1] + lines[0] + lines[2], 0, 1},
+		{"malformed", lines[0] + "{not json\n", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := VerifyAuditLog(strings.NewReader(tt.stream))
+			if err == nil || count != tt.valid {
+				t.Fatalf("VerifyAuditLog() = %d, %v, want %d and error", count, err, tt.valid)
+			}
+			mdwErr, ok := err.(*mdwerror.Error)
+			if !ok || mdwErr.Code() != mdwerror.CodeDataCorruption || mdwErr.Details()["seq"] != tt.seq {
+				t.Errorf("error = %v, want data corruption at seq %d", err, tt.seq)
+			}
+		})
+	}
+}
+
+func TestOpenAuditFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := OpenAuditFile(path)
+	if err != nil {
+		t.Fatalf("OpenAuditFile() error = %v", err)
+	}
+	writeAuditRecords(t, sink, 2)
+	sink.Close()
+
+	// Reopening continues the chain
+	sink, err = OpenAuditFile(path)
+	if err != nil {
+		t.Fatalf("OpenAuditFile() reopen error = %v", err)
+	}
+	writeAuditRecords(t, sink, 2)
+	sink.Close()
+
+	if count, err := VerifyAuditFile(path); err != nil || count != 4 {
+		t.Errorf("VerifyAuditFile() = %d, %v", count, err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("audit file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A tampered file is not extended
+	content, _ := os.ReadFile(path)
+	os.WriteFile(path, bytes.Replace(content, []byte("CUSTOMER"), []byte("SUPPLIER"), 1), 0600)
+	if _, err := OpenAuditFile(path); err == nil {
+		t.Error("OpenAuditFile() accepted a tampered file")
+	}
+}
+
+func TestLogger_AuditSink(t *testing.T) {
+	var output, audit bytes.Buffer
+	logger := NewWithConfig(Config{
+		Level:      LevelError,
+		Format:     FormatText,
+		Output:     &output,
+		Processors: []Processor{NewRedactor(DefaultRedactionRules())},
+		AuditSink:  NewAuditSink(&audit),
+	}).WithName("tcol").WithUserID("admin")
+
+	logger.Audit("password reset", Fields{"password": "hunter2"})
+	logger.Error("not audited")
+	logger.WithField("object", "CUSTOMER").Audit("object deleted")
+
+	if !strings.Contains(output.String(), "password reset") {
+		t.Error("audit entry missing from regular output")
+	}
+	if strings.Contains(audit.String(), "not audited") || strings.Contains(audit.String(), "hunter2") {
+		t.Errorf("audit stream = %q", audit.String())
+	}
+	if count, err := VerifyAuditLog(&audit); err != nil || count != 2 {
+		t.Errorf("VerifyAuditLog() = %d, %v", count, err)
+	}
+}
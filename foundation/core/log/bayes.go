@@ -0,0 +1,559 @@
+// File: bayes.go
+// Title: Bayes Remote Sink
+// Description: Implements a log output that ships records to the Bayes logging
+//              service in batches, retries with exponential backoff, and
+//              spools records to local disk while Bayes is unavailable. The
+//              gRPC transport is supplied by the service through BayesClient,
+//              so foundation does not depend on the generated API code.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the Bayes remote sink
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mdwconfig "github.com/msto63/mDW/foundation/core/config"
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	"github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// bayesSpoolPrefix and bayesSpoolExt name the spool files
+const (
+	bayesSpoolPrefix = "bayes-"
+	bayesSpoolExt    = ".jsonl"
+)
+
+// BayesRecord is a log record as accepted by the Bayes LogBatch call
+type BayesRecord struct {
+	Service   string            `json:"service"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	Timestamp time.Time         `json:"timestamp"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	TraceID   string            `json:"trace_id,omitempty"` // Correlation ID
+	Caller    string            `json:"caller,omitempty"`   // file:line
+	Error     string            `json:"error,omitempty"`
+}
+
+// BayesClient sends record batches to the Bayes service. Services implement
+// it with the generated gRPC client; an error marks the whole batch failed.
+type BayesClient interface {
+	LogBatch(ctx context.Context, records []BayesRecord) error
+}
+
+// BayesOptions configures a BayesSink
+type BayesOptions struct {
+	Service       string        // Service name of records (default: logger name)
+	BatchSize     int           // Records per LogBatch call (default: 100)
+	FlushInterval time.Duration // Maximum delay before sending (default: 5s)
+	BufferSize    int           // Records held in memory before spooling or dropping (default: 10000)
+	Timeout       time.Duration // Timeout per LogBatch call (default: 5s)
+	MinBackoff    time.Duration // First retry delay after a failure (default: 500ms)
+	MaxBackoff    time.Duration // Maximum retry delay (default: 30s)
+	SpoolDir      string        // Directory for records while Bayes is unavailable ("" = drop)
+	MaxSpoolMB    int           // Spool size limit in MiB, oldest records dropped first (default: 100)
+}
+
+// DefaultBayesOptions returns the default Bayes sink options
+func DefaultBayesOptions() BayesOptions {
+	return BayesOptions{
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+		BufferSize:    10000,
+		Timeout:       5 * time.Second,
+		MinBackoff:    500 * time.Millisecond,
+		MaxBackoff:    30 * time.Second,
+		MaxSpoolMB:    100,
+	}
+}
+
+// BayesOptionsFromConfig reads Bayes sink options from the configuration
+// section at key, starting from DefaultBayesOptions:
+//
+//	[logging.sinks.remote]
+//	type = "bayes"
+//	level = "warn"
+//	service = "kant"
+//	batch_size = 100
+//	flush_interval = "5s"
+//	spool_dir = "/var/spool/mdw/logs"
+func BayesOptionsFromConfig(cfg *mdwconfig.Config, key string) BayesOptions {
+	options := DefaultBayesOptions()
+	options.Service = cfg.GetString(key+".service", options.Service)
+	options.BatchSize = cfg.GetInt(key+".batch_size", options.BatchSize)
+	options.FlushInterval = cfg.GetDuration(key+".flush_interval", options.FlushInterval)
+	options.BufferSize = cfg.GetInt(key+".buffer_size", options.BufferSize)
+	options.Timeout = cfg.GetDuration(key+".timeout", options.Timeout)
+	options.MinBackoff = cfg.GetDuration(key+".min_backoff", options.MinBackoff)
+	options.MaxBackoff = cfg.GetDuration(key+".max_backoff", options.MaxBackoff)
+	options.SpoolDir = cfg.GetString(key+".spool_dir", options.SpoolDir)
+	options.MaxSpoolMB = cfg.GetInt(key+".max_spool_mb", options.MaxSpoolMB)
+	return options
+}
+
+// BayesSinkFactory returns a sink factory for SinksFromConfig that creates
+// Bayes sinks using client, e.g. RegisterSinkType("bayes", BayesSinkFactory(client))
+func BayesSinkFactory(client BayesClient) SinkFactory {
+	return func(cfg *mdwconfig.Config, key string) (io.Writer, error) {
+		return NewBayesSink(client, BayesOptionsFromConfig(cfg, key))
+	}
+}
+
+// BayesStats reports the delivery counters of a BayesSink
+type BayesStats struct {
+	Sent     uint64 // Records accepted by Bayes
+	Spooled  uint64 // Records written to the spool
+	Dropped  uint64 // Records lost to full buffers or spool limits
+	Failures uint64 // Failed LogBatch calls
+}
+
+// BayesSink is a log output shipping entries to the Bayes service. It
+// receives entries directly as EntryWriter, so its format setting is
+// ignored. Writing never blocks logging; delivery runs in the background.
+type BayesSink struct {
+	client  BayesClient
+	options BayesOptions
+
+	input   chan BayesRecord
+	flushCh chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	closed  atomic.Bool
+
+	// Owned by the worker goroutine
+	pending     []BayesRecord
+	backoff     time.Duration
+	nextAttempt time.Time
+	spoolSeq    uint64
+
+	closeOnce sync.Once
+	sent      atomic.Uint64
+	spooled   atomic.Uint64
+	dropped   atomic.Uint64
+	failures  atomic.Uint64
+}
+
+// NewBayesSink creates a Bayes sink and starts its delivery worker. Records
+// spooled by a previous run are delivered once Bayes is reachable.
+func NewBayesSink(client BayesClient, options BayesOptions) (*BayesSink, error) {
+	if client == nil {
+		return nil, mdwerror.New("bayes client is required").
+			WithCode(mdwerror.CodeInvalidInput).
+			WithOperation("log.NewBayesSink")
+	}
+	defaults := DefaultBayesOptions()
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaults.BatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = defaults.FlushInterval
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = defaults.BufferSize
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaults.Timeout
+	}
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = defaults.MinBackoff
+	}
+	if options.MaxBackoff < options.MinBackoff {
+		options.MaxBackoff = options.MinBackoff
+	}
+	if options.MaxSpoolMB <= 0 {
+		options.MaxSpoolMB = defaults.MaxSpoolMB
+	}
+	if options.SpoolDir != "" {
+		if err := os.MkdirAll(options.SpoolDir, 0700); err != nil {
+			return nil, mdwerror.Wrap(err, "failed to create bayes spool directory").
+				WithCode(mdwerror.CodeConfigError).
+				WithOperation("log.NewBayesSink").
+				WithDetail("path", options.SpoolDir)
+		}
+	}
+
+	s := &BayesSink{
+		client:  client,
+		options: options,
+		input:   make(chan BayesRecord, options.BufferSize),
+		flushCh: make(chan chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// WriteEntry queues entry for delivery; it drops the entry if the buffer is
+// full or the sink is closed
+func (s *BayesSink) WriteEntry(entry *Entry) error {
+	return s.enqueue(s.recordFromEntry(entry))
+}
+
+// Write queues a JSON log line, e.g. from a JSON formatter, for delivery.
+// Keys other than the standard entry keys become fields.
+func (s *BayesSink) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var data map[string]interface{}
+		if err := json.Unmarshal(line, &data); err != nil {
+			return 0, mdwerror.Wrap(err, "bayes sink expects JSON log lines").
+				WithCode(mdwerror.CodeInvalidFormat).
+				WithOperation("log.BayesSink.Write")
+		}
+		if err := s.enqueue(s.recordFromJSON(data)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush delivers all queued records now, ignoring a pending backoff, and
+// returns when the attempt is finished
+func (s *BayesSink) Flush() {
+	if s.closed.Load() {
+		return
+	}
+	done := make(chan struct{})
+	select {
+	case s.flushCh <- done:
+		<-done
+	case <-s.done:
+	}
+}
+
+// Close makes a final delivery attempt and spools what could not be sent
+func (s *BayesSink) Close() error {
+	s.closeOnce.Do(func() {
+		s.closed.Store(true)
+		close(s.stop)
+	})
+	<-s.done
+	return nil
+}
+
+// Stats returns the delivery counters
+func (s *BayesSink) Stats() BayesStats {
+	return BayesStats{
+		Sent:     s.sent.Load(),
+		Spooled:  s.spooled.Load(),
+		Dropped:  s.dropped.Load(),
+		Failures: s.failures.Load(),
+	}
+}
+
+// enqueue hands record to the worker without blocking
+func (s *BayesSink) enqueue(record BayesRecord) error {
+	if s.closed.Load() {
+		s.dropped.Add(1)
+		return mdwerror.New("bayes sink is closed").
+			WithCode(mdwerror.CodeServiceUnavailable).
+			WithOperation("log.BayesSink.Write")
+	}
+	select {
+	case s.input <- record:
+	default:
+		s.dropped.Add(1)
+	}
+	return nil
+}
+
+// ===============================
+// Delivery Worker
+// ===============================
+
+// run batches queued records and delivers them until Close
+func (s *BayesSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case record := <-s.input:
+			s.pending = append(s.pending, record)
+			if len(s.pending) >= s.options.BatchSize {
+				s.deliver(false)
+			}
+		case <-ticker.C:
+			s.deliver(false)
+		case done := <-s.flushCh:
+			s.drainInput()
+			s.deliver(true)
+			close(done)
+		case <-s.stop:
+			s.drainInput()
+			s.deliver(true)
+			s.spool(s.pending)
+			s.pending = nil
+			return
+		}
+	}
+}
+
+// drainInput moves all queued records to pending
+func (s *BayesSink) drainInput() {
+	for {
+		select {
+		case record := <-s.input:
+			s.pending = append(s.pending, record)
+		default:
+			return
+		}
+	}
+}
+
+// deliver sends pending records and then spooled records in batches. Unless
+// force is set, it waits for the backoff after a failure.
+func (s *BayesSink) deliver(force bool) {
+	if !force && time.Now().Before(s.nextAttempt) {
+		s.limitPending()
+		return
+	}
+
+	for len(s.pending) > 0 {
+		n := min(len(s.pending), s.options.BatchSize)
+		if !s.send(s.pending[:n]) {
+			s.limitPending()
+			return
+		}
+		s.pending = s.pending[n:]
+	}
+	s.pending = nil
+
+	for _, path := range s.spoolFiles() {
+		records, err := readBayesSpool(path)
+		if err == nil && len(records) > 0 && !s.send(records) {
+			return
+		}
+		os.Remove(path)
+	}
+}
+
+// send delivers one batch and updates the backoff; it reports success
+func (s *BayesSink) send(records []BayesRecord) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.Timeout)
+	defer cancel()
+
+	if err := s.client.LogBatch(ctx, records); err != nil {
+		s.failures.Add(1)
+		s.backoff = min(max(s.backoff*2, s.options.MinBackoff), s.options.MaxBackoff)
+		s.nextAttempt = time.Now().Add(s.backoff)
+		return false
+	}
+	s.sent.Add(uint64(len(records)))
+	s.backoff = 0
+	s.nextAttempt = time.Time{}
+	return true
+}
+
+// limitPending moves records beyond the buffer size to the spool
+func (s *BayesSink) limitPending() {
+	if excess := len(s.pending) - s.options.BufferSize; excess > 0 {
+		s.spool(s.pending[:excess])
+		s.pending = s.pending[excess:]
+	}
+}
+
+// ===============================
+// Disk Spool
+// ===============================
+
+// spool writes records to a new spool file, or drops them without spool
+// directory. The oldest spool files are removed to stay within MaxSpoolMB.
+func (s *BayesSink) spool(records []BayesRecord) {
+	if len(records) == 0 {
+		return
+	}
+	if s.options.SpoolDir == "" {
+		s.dropped.Add(uint64(len(records)))
+		return
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		encoder.Encode(record)
+	}
+	s.trimSpool(int64(buf.Len()))
+
+	s.spoolSeq++
+	name := fmt.Sprintf("%s%020d-%06d%s", bayesSpoolPrefix, time.Now().UnixNano(), s.spoolSeq, bayesSpoolExt)
+	if err := filex.WriteFileAtomic(filepath.Join(s.options.SpoolDir, name), buf.Bytes(), 0600); err != nil {
+		s.dropped.Add(uint64(len(records)))
+		return
+	}
+	s.spooled.Add(uint64(len(records)))
+}
+
+// trimSpool removes the oldest spool files until size more bytes fit
+func (s *BayesSink) trimSpool(size int64) {
+	limit := int64(s.options.MaxSpoolMB) * 1024 * 1024
+	files := s.spoolFiles()
+	sizes := make([]int64, len(files))
+	total := size
+	for i, path := range files {
+		if info, err := os.Stat(path); err == nil {
+			sizes[i] = info.Size()
+			total += sizes[i]
+		}
+	}
+	for i := 0; i < len(files) && total > limit; i++ {
+		if data, err := os.ReadFile(files[i]); err == nil {
+			s.dropped.Add(uint64(bytes.Count(data, []byte{'\n'})))
+		}
+		os.Remove(files[i])
+		total -= sizes[i]
+	}
+}
+
+// spoolFiles returns the spool files, oldest first
+func (s *BayesSink) spoolFiles() []string {
+	if s.options.SpoolDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(s.options.SpoolDir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, bayesSpoolPrefix) && strings.HasSuffix(name, bayesSpoolExt) {
+			files = append(files, filepath.Join(s.options.SpoolDir, name))
+		}
+	}
+	return files
+}
+
+// readBayesSpool reads the records of a spool file
+func readBayesSpool(path string) ([]BayesRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []BayesRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxAuditRecordSize)
+	for scanner.Scan() {
+		var record BayesRecord
+		if json.Unmarshal(scanner.Bytes(), &record) == nil {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// ===============================
+// Record Conversion
+// ===============================
+
+// recordFromEntry converts an entry; user ID, logger name, and duration are
+// carried as fields
+func (s *BayesSink) recordFromEntry(entry *Entry) BayesRecord {
+	record := BayesRecord{
+		Service:   s.options.Service,
+		Level:     entry.Level.String(),
+		Message:   entry.Message,
+		Timestamp: entry.Timestamp,
+		RequestID: entry.RequestID,
+		TraceID:   entry.CorrelationID,
+		Fields:    make(map[string]string, len(entry.Fields)+3),
+	}
+	if record.Service == "" {
+		record.Service = entry.Logger
+	}
+	if entry.Error != nil {
+		record.Error = entry.Error.Error()
+	}
+	if entry.Caller != nil {
+		record.Caller = fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line)
+	}
+	for k, v := range entry.Fields {
+		record.Fields[k] = bayesFieldString(v)
+	}
+	if entry.UserID != "" {
+		record.Fields["user_id"] = entry.UserID
+	}
+	if entry.Logger != "" {
+		record.Fields["logger"] = entry.Logger
+	}
+	if entry.Duration > 0 {
+		record.Fields["duration_ms"] = fmt.Sprintf("%.3f", float64(entry.Duration.Nanoseconds())/1000000)
+	}
+	return record
+}
+
+// recordFromJSON converts a decoded JSON log line
+func (s *BayesSink) recordFromJSON(data map[string]interface{}) BayesRecord {
+	take := func(key string) string {
+		value, _ := data[key].(string)
+		delete(data, key)
+		return value
+	}
+
+	record := BayesRecord{
+		Service:   s.options.Service,
+		Level:     take(jsonKeyLevel),
+		Message:   take(jsonKeyMessage),
+		RequestID: take(jsonKeyRequestID),
+		TraceID:   take(jsonKeyCorrelationID),
+		Error:     take(jsonKeyError),
+		Fields:    make(map[string]string, len(data)),
+	}
+	record.Timestamp, _ = time.Parse(time.RFC3339Nano, take(jsonKeyTimestamp))
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	if logger := take(jsonKeyLogger); logger != "" {
+		if record.Service == "" {
+			record.Service = logger
+		}
+		record.Fields["logger"] = logger
+	}
+	for k, v := range data {
+		record.Fields[k] = bayesFieldString(v)
+	}
+	return record
+}
+
+// bayesFieldString converts a field value to the string form Bayes stores
+func bayesFieldString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case map[string]interface{}, Fields, []interface{}, []string:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,235 @@
+// File: bayes_test.go
+// Title: Bayes Remote Sink Tests
+// Description: Tests batching, backoff, disk spooling and replay, record
+//              conversion, and configuration of the Bayes sink.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Bayes remote sink tests
+
+package log
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mdwconfig "github.com/msto63/mDW/foundation/core/config"
+)
+
+// fakeBayesClient records batches and fails while unavailable is set
+type fakeBayesClient struct {
+	mu          sync.Mutex
+	batches     [][]BayesRecord
+	calls       int
+	unavailable bool
+}
+
+func (c *fakeBayesClient) LogBatch(ctx context.Context, records []BayesRecord) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.unavailable {
+		return errors.New("connection refused")
+	}
+	c.batches = append(c.batches, append([]BayesRecord(nil), records...))
+	return nil
+}
+
+func (c *fakeBayesClient) setUnavailable(unavailable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unavailable = unavailable
+}
+
+// received returns the messages of all received records
+func (c *fakeBayesClient) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var messages []string
+	for _, batch := range c.batches {
+		for _, record := range batch {
+			messages = append(messages, record.Message)
+		}
+	}
+	return messages
+}
+
+func TestBayesSink_Batching(t *testing.T) {
+	client := &fakeBayesClient{}
+	sink, err := NewBayesSink(client, BayesOptions{Service: "kant", BatchSize: 2, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewBayesSink() error = %v", err)
+	}
+	defer sink.Close()
+
+	for _, message := range []string{"one", "two", "three"} {
+		sink.WriteEntry(NewEntry(LevelInfo, message))
+	}
+	sink.Flush()
+
+	client.mu.Lock()
+	batchSizes := []int{}
+	for _, batch := range client.batches {
+		batchSizes = append(batchSizes, len(batch))
+	}
+	client.mu.Unlock()
+	if len(batchSizes) != 2 || batchSizes[0] != 2 || batchSizes[1] != 1 {
+		t.Errorf("batch sizes = %v, want [2 1]", batchSizes)
+	}
+	if stats := sink.Stats(); stats.Sent != 3 || stats.Dropped != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestBayesSink_SpoolAndReplay(t *testing.T) {
+	client := &fakeBayesClient{unavailable: true}
+	spoolDir := t.TempDir()
+	options := BayesOptions{
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		BufferSize:    2,
+		MinBackoff:    time.Hour,
+		SpoolDir:      spoolDir,
+	}
+	sink, err := NewBayesSink(client, options)
+	if err != nil {
+		t.Fatalf("NewBayesSink() error = %v", err)
+	}
+
+	sink.WriteEntry(NewEntry(LevelWarn, "a"))
+	sink.WriteEntry(NewEntry(LevelWarn, "b"))
+	sink.Flush() // Fails; both records stay buffered
+	sink.WriteEntry(NewEntry(LevelWarn, "c"))
+	sink.Flush() // Fails; "a" exceeds the buffer and is spooled
+	if stats := sink.Stats(); stats.Failures != 2 || stats.Spooled != 1 {
+		t.Errorf("Stats() after failures = %+v", stats)
+	}
+
+	// Within the backoff period no delivery is attempted
+	sink.WriteEntry(NewEntry(LevelWarn, "d"))
+	time.Sleep(10 * time.Millisecond)
+	client.mu.Lock()
+	calls := client.calls
+	client.mu.Unlock()
+	if calls != 2 {
+		t.Errorf("calls during backoff = %d, want 2", calls)
+	}
+
+	// Close spools the pending records
+	sink.Close()
+	if stats := sink.Stats(); stats.Spooled != 4 {
+		t.Errorf("Stats() after Close = %+v", stats)
+	}
+
+	// A new sink delivers the spool once Bayes is back, oldest first
+	client.setUnavailable(false)
+	sink, err = NewBayesSink(client, options)
+	if err != nil {
+		t.Fatalf("NewBayesSink() error = %v", err)
+	}
+	sink.Flush()
+	sink.Close()
+
+	received := client.received()
+	if len(received) != 4 || received[0] != "a" {
+		t.Errorf("received = %v, want a first of 4", received)
+	}
+	if files, _ := os.ReadDir(spoolDir); len(files) != 0 {
+		t.Errorf("spool not emptied: %d files", len(files))
+	}
+}
+
+func TestBayesSink_WithoutSpool(t *testing.T) {
+	client := &fakeBayesClient{unavailable: true}
+	sink, _ := NewBayesSink(client, BayesOptions{FlushInterval: time.Hour, BufferSize: 1})
+
+	sink.WriteEntry(NewEntry(LevelError, "kept"))
+	sink.WriteEntry(NewEntry(LevelError, "dropped"))
+	sink.Flush()
+	sink.Close()
+
+	if stats := sink.Stats(); stats.Dropped != 2 || stats.Spooled != 0 {
+		t.Errorf("Stats() = %+v, want 2 dropped", stats)
+	}
+	if err := sink.WriteEntry(NewEntry(LevelError, "after close")); err == nil {
+		t.Error("WriteEntry() after Close succeeded")
+	}
+}
+
+func TestBayesSink_Records(t *testing.T) {
+	client := &fakeBayesClient{}
+	sink, _ := NewBayesSink(client, BayesOptions{FlushInterval: time.Hour})
+	defer sink.Close()
+
+	logger := NewWithConfig(Config{
+		Level:        LevelInfo,
+		Name:         "kant",
+		AsyncEnabled: true,
+		Sinks:        []Sink{{Name: "remote", Output: sink, Level: LevelWarn}},
+	}).WithRequestID("req-1").WithCorrelationID("corr-1").WithUserID("user-1")
+
+	logger.Info("not shipped")
+	logger.ErrorWithErr("query failed", errors.New("timeout"), Fields{"table": "orders", "rows": 3})
+	sink.Write([]byte(`{"timestamp":"2026-10-16T12:00:00Z","level":"warn","message":"from json","logger":"legacy","attempt":2}` + "\n"))
+	sink.Flush()
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.batches) != 1 || len(client.batches[0]) != 2 {
+		t.Fatalf("batches = %v", client.batches)
+	}
+	record := client.batches[0][0]
+	if record.Service != "kant" || record.Level != "error" || record.RequestID != "req-1" || record.TraceID != "corr-1" ||
+		record.Error != "timeout" || record.Fields["rows"] != "3" || record.Fields["user_id"] != "user-1" {
+		t.Errorf("entry record = %+v", record)
+	}
+	record = client.batches[0][1]
+	if record.Service != "legacy" || record.Level != "warn" || record.Fields["attempt"] != "2" || record.Timestamp.Year() != 2026 {
+		t.Errorf("JSON record = %+v", record)
+	}
+}
+
+func TestBayesSinkFactory(t *testing.T) {
+	spoolDir := filepath.Join(t.TempDir(), "spool")
+	cfg, err := mdwconfig.LoadFromString(`
+[logging.sinks.remote]
+type = "bayes"
+level = "warn"
+service = "kant"
+batch_size = 50
+spool_dir = "`+filepath.ToSlash(spoolDir)+`"
+`, mdwconfig.FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	if options := BayesOptionsFromConfig(cfg, "logging.sinks.remote"); options.Service != "kant" || options.BatchSize != 50 || options.FlushInterval != 5*time.Second {
+		t.Errorf("BayesOptionsFromConfig() = %+v", options)
+	}
+
+	RegisterSinkType("bayes", BayesSinkFactory(&fakeBayesClient{}))
+	defer RegisterSinkType("bayes", nil)
+	sinks, err := SinksFromConfig(cfg, "logging.sinks")
+	if err != nil {
+		t.Fatalf("SinksFromConfig() error = %v", err)
+	}
+	defer sinks[0].Close()
+	if _, ok := sinks[0].Output.(*BayesSink); !ok || sinks[0].Level != LevelWarn {
+		t.Errorf("sink = %+v", sinks[0])
+	}
+	if _, err := os.Stat(spoolDir); err != nil {
+		t.Errorf("spool directory not created: %v", err)
+	}
+
+	if _, err := NewBayesSink(nil, BayesOptions{}); err == nil {
+		t.Error("NewBayesSink(nil) succeeded")
+	}
+}
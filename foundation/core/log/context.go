@@ -0,0 +1,187 @@
+// File: context.go
+// Title: Context-First Logging API
+// Description: Implements logging methods taking a context.Context that
+//              extract request ID, user ID, correlation ID, and tenant from
+//              context values, with registrable extractors for services that
+//              store them differently.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the context-first API
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// Context keys read by the default extractor; these are the keys used across
+// the mDW services, e.g. context.WithValue(ctx, log.ContextKeyRequestID, id)
+const (
+	ContextKeyRequestID     = "requestId"
+	ContextKeyUserID        = "userId"
+	ContextKeyCorrelationID = "correlationId"
+	ContextKeyTenant        = "tenant"
+)
+
+// ContextInfo holds the logging information extracted from a context
+type ContextInfo struct {
+	RequestID     string
+	UserID        string
+	CorrelationID string
+	Tenant        string // Logged as field "tenant"
+	Fields        Fields // Additional fields
+}
+
+// ContextExtractor fills info from ctx. Extractors run in registration order
+// after the default extractor and may override values set before.
+type ContextExtractor func(ctx context.Context, info *ContextInfo)
+
+// contextExtractors holds the registered extractors; the slice is replaced,
+// never modified, so that logging reads it without locking
+var contextExtractors atomic.Pointer[[]ContextExtractor]
+
+// RegisterContextExtractor adds an extractor used by the Ctx logging methods
+// and WithContext
+func RegisterContextExtractor(extractor ContextExtractor) {
+	for {
+		current := contextExtractors.Load()
+		var extractors []ContextExtractor
+		if current != nil {
+			extractors = append(extractors, *current...)
+		}
+		extractors = append(extractors, extractor)
+		if contextExtractors.CompareAndSwap(current, &extractors) {
+			return
+		}
+	}
+}
+
+// ExtractContext returns the logging information of ctx
+func ExtractContext(ctx context.Context) ContextInfo {
+	var info ContextInfo
+	if ctx == nil {
+		return info
+	}
+	info.RequestID = contextString(ctx, ContextKeyRequestID)
+	info.UserID = contextString(ctx, ContextKeyUserID)
+	info.CorrelationID = contextString(ctx, ContextKeyCorrelationID)
+	info.Tenant = contextString(ctx, ContextKeyTenant)
+
+	if extractors := contextExtractors.Load(); extractors != nil {
+		for _, extractor := range *extractors {
+			extractor(ctx, &info)
+		}
+	}
+	return info
+}
+
+// contextString returns the value of key in ctx as a string
+func contextString(ctx context.Context, key string) string {
+	switch value := ctx.Value(key).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// apply sets the non-empty values of info on entry
+func (info *ContextInfo) apply(entry *Entry) {
+	if info.RequestID != "" {
+		entry.RequestID = info.RequestID
+	}
+	if info.UserID != "" {
+		entry.UserID = info.UserID
+	}
+	if info.CorrelationID != "" {
+		entry.CorrelationID = info.CorrelationID
+	}
+	if info.Tenant != "" {
+		entry.Fields["tenant"] = info.Tenant
+	}
+	for k, v := range info.Fields {
+		entry.Fields[k] = v
+	}
+}
+
+// WithContext returns a logger carrying the logging information of ctx, for
+// code that logs repeatedly within one request
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	info := ExtractContext(ctx)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	clone := l.clone()
+	if info.RequestID != "" {
+		clone.requestID = info.RequestID
+	}
+	if info.UserID != "" {
+		clone.userID = info.UserID
+	}
+	if info.CorrelationID != "" {
+		clone.correlationID = info.CorrelationID
+	}
+	if info.Tenant != "" {
+		clone.contextFields["tenant"] = info.Tenant
+	}
+	for k, v := range info.Fields {
+		clone.contextFields[k] = v
+	}
+	return clone
+}
+
+// TraceCtx logs a trace level message with the logging information of ctx
+func (l *Logger) TraceCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelTrace, message, nil, fields)
+}
+
+// DebugCtx logs a debug level message with the logging information of ctx
+func (l *Logger) DebugCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelDebug, message, nil, fields)
+}
+
+// InfoCtx logs an info level message with the logging information of ctx
+func (l *Logger) InfoCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelInfo, message, nil, fields)
+}
+
+// WarnCtx logs a warning level message with the logging information of ctx
+func (l *Logger) WarnCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelWarn, message, nil, fields)
+}
+
+// ErrorCtx logs an error level message with the logging information of ctx
+func (l *Logger) ErrorCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelError, message, nil, fields)
+}
+
+// ErrorWithErrCtx logs an error with an error object and the logging
+// information of ctx
+func (l *Logger) ErrorWithErrCtx(ctx context.Context, message string, err error, fields ...Fields) {
+	l.logCtx(ctx, LevelError, message, err, fields)
+}
+
+// AuditCtx logs an audit level message with the logging information of ctx
+func (l *Logger) AuditCtx(ctx context.Context, message string, fields ...Fields) {
+	l.logCtx(ctx, LevelAudit, message, nil, fields)
+}
+
+// logCtx extracts ctx only if level is enabled and logs the entry
+func (l *Logger) logCtx(ctx context.Context, level Level, message string, err error, fields []Fields) {
+	if !l.IsLevelEnabled(level) {
+		return
+	}
+	info := ExtractContext(ctx)
+	l.logEntry(level, message, err, &info, fields)
+}
@@ -0,0 +1,110 @@
+// File: context_test.go
+// Title: Context-First Logging API Tests
+// Description: Tests extraction of logging information from contexts,
+//              registered extractors, and the Ctx logging methods.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial context-first API tests
+
+package log
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// tenantKey is a typed context key used by the extractor test
+type tenantKey struct{}
+
+func TestExtractContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ContextKeyRequestID, "req-1")
+	ctx = context.WithValue(ctx, ContextKeyUserID, "user-1")
+	ctx = context.WithValue(ctx, ContextKeyCorrelationID, "corr-1")
+	ctx = context.WithValue(ctx, ContextKeyTenant, 42)
+
+	info := ExtractContext(ctx)
+	expected := ContextInfo{RequestID: "req-1", UserID: "user-1", CorrelationID: "corr-1", Tenant: "42"}
+	if info.RequestID != expected.RequestID || info.UserID != expected.UserID ||
+		info.CorrelationID != expected.CorrelationID || info.Tenant != expected.Tenant {
+		t.Errorf("ExtractContext() = %+v, want %+v", info, expected)
+	}
+	if info := ExtractContext(nil); info.RequestID != "" {
+		t.Errorf("ExtractContext(nil) = %+v", info)
+	}
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	previous := contextExtractors.Load()
+	defer contextExtractors.Store(previous)
+
+	RegisterContextExtractor(func(ctx context.Context, info *ContextInfo) {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			info.Tenant = tenant
+			info.Fields = Fields{"region": "eu"}
+		}
+	})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	info := ExtractContext(ctx)
+	if info.Tenant != "acme" || info.Fields["region"] != "eu" {
+		t.Errorf("ExtractContext() = %+v", info)
+	}
+}
+
+func TestLogger_CtxMethods(t *testing.T) {
+	logger, entries := captureEntries(LevelInfo)
+	logger = logger.WithRequestID("req-logger").WithUserID("user-logger")
+
+	ctx := context.WithValue(context.Background(), ContextKeyRequestID, "req-ctx")
+	ctx = context.WithValue(ctx, ContextKeyTenant, "acme")
+
+	logger.DebugCtx(ctx, "filtered")
+	logger.InfoCtx(ctx, "info", Fields{"tenant": "explicit"})
+	logger.ErrorWithErrCtx(ctx, "failed", errors.New("boom"))
+	logger.AuditCtx(context.Background(), "audited")
+
+	if len(*entries) != 3 {
+		t.Fatalf("entries = %d, want 3", len(*entries))
+	}
+	info := (*entries)[0]
+	if info.RequestID != "req-ctx" || info.UserID != "user-logger" || info.Fields["tenant"] != "explicit" {
+		t.Errorf("info entry = %+v", info)
+	}
+	failed := (*entries)[1]
+	if failed.Level != LevelError || failed.Error == nil || failed.Fields["tenant"] != "acme" {
+		t.Errorf("error entry = %+v", failed)
+	}
+	if audited := (*entries)[2]; audited.RequestID != "req-logger" || audited.Fields["tenant"] != nil {
+		t.Errorf("audit entry = %+v", audited)
+	}
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	logger, entries := captureEntries(LevelInfo)
+	ctx := context.WithValue(context.Background(), ContextKeyCorrelationID, "corr-1")
+	ctx = context.WithValue(ctx, ContextKeyTenant, "acme")
+
+	logger.WithContext(ctx).Info("first")
+	if entry := (*entries)[0]; entry.CorrelationID != "corr-1" || entry.Fields["tenant"] != "acme" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestLogger_CtxCaller(t *testing.T) {
+	logger, entries := captureEntries(LevelInfo)
+	logger = logger.WithCaller(0)
+
+	logger.Info("plain")
+	logger.InfoCtx(context.Background(), "with context")
+	for _, entry := range *entries {
+		if entry.Caller == nil || !strings.HasSuffix(entry.Caller.File, "context_test.go") {
+			t.Errorf("%q caller = %+v, want context_test.go", entry.Message, entry.Caller)
+		}
+	}
+}
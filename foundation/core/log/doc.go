@@ -7,22 +7,47 @@
 //              integration with the mDW error handling system. It supports performance
 //              monitoring, audit trails, and distributed tracing for microservices.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.10
 // Created: 2025-01-24
-// Modified: 2025-01-24
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with structured logging and error integration
+// - 2026-10-16 v0.1.1: Documented asynchronous output with overflow policies
+// - 2026-10-16 v0.1.2: Documented rotating file output
+// - 2026-10-16 v0.1.3: Documented runtime level control
+// - 2026-10-16 v0.1.4: Documented log sampling
+// - 2026-10-16 v0.1.5: Documented processors and sensitive data redaction
+// - 2026-10-16 v0.1.6: Documented the tamper-evident audit sink
+// - 2026-10-16 v0.1.7: Documented slog and standard log adapters
+// - 2026-10-16 v0.1.8: Documented multi-sink fan-out
+// - 2026-10-16 v0.1.9: Documented the context-first API
+// - 2026-10-16 v0.1.10: Documented the Bayes remote sink
 //
 // Features:
 // - Structured logging with JSON and text formats
+// - Pooled, append-based JSON encoding without per-field allocations
 // - Multiple log levels with filtering capabilities
+// - Runtime level changes, per-component overrides, and an HTTP admin hook
 // - Contextual logging with request IDs, user IDs, and custom fields
+// - Context-first methods (InfoCtx, ...) extracting request, user, correlation,
+//   and tenant IDs from context values with registrable extractors
 // - Integration with mDW error system for automatic error logging
 // - Performance metrics and timing measurements
-// - Audit trail capabilities for TCOL commands
-// - Multiple output destinations (console, file, remote)
-// - Log sampling and rate limiting for high-volume scenarios
+// - Audit trail capabilities for TCOL commands, with a hash-chained,
+//   append-only audit sink and verification of audit files
+// - Processor chain with redaction of credentials, emails, and IBANs before
+//   output, with per-logger opt-out
+// - Multiple output destinations (console, file, remote), each with its own
+//   format and level, configured declaratively from core/config
+// - Bayes remote sink with batching, exponential backoff, and disk spooling
+//   while the Bayes service is unavailable
+// - log/slog handler and standard log writer adapters for third-party libraries
+// - Asynchronous output with a bounded buffer, drop/block/sample overflow
+//   policies, and flush on Close
+// - Rotating file output with size/time rotation, gzip, and retention limits
+// - Log sampling and rate limiting for high-volume scenarios, with summary
+//   entries reporting suppressed counts
 // - Correlation IDs for distributed tracing
 //
 // Usage:
@@ -44,6 +69,10 @@
 //     "body_size": 1024,
 //   })
 //
+//   // Take request, user, correlation, and tenant IDs from the context
+//   ctx = context.WithValue(ctx, log.ContextKeyRequestID, "req-123")
+//   logger.InfoCtx(ctx, "Order accepted", log.Field("order_id", orderID))
+//
 //   // Log performance metrics
 //   timer := logger.StartTimer("database_query")
 //   // ... perform database operation
@@ -55,4 +84,63 @@
 //     "user_id": "admin123",
 //     "success": true,
 //   })
+//
+//   // Tamper-evident audit trail: each record carries the previous hash
+//   audit, err := log.OpenAuditFile("/var/log/mdw/audit.log")
+//   if err != nil {
+//     return err
+//   }
+//   defer audit.Close()
+//   logger = logger.WithAuditSink(audit)
+//   count, err := log.VerifyAuditFile("/var/log/mdw/audit.log") // Error names the first bad record
+//
+//   // Rotating file output configured from the [logging.file] section
+//   file, err := log.NewFileOutput(log.FileOptionsFromConfig(cfg, "logging.file"))
+//   if err != nil {
+//     return err
+//   }
+//   defer file.Close()
+//
+//   // Asynchronous output keeps slow file writes off request paths
+//   logger = log.NewWithConfig(log.Config{
+//     Output:          file,
+//     AsyncEnabled:    true,
+//     AsyncBufferSize: 10000,
+//     AsyncOverflow:   log.OverflowDrop,
+//   })
+//   defer logger.Close() // Writes all buffered entries
+//
+//   // Fan out to sinks from [logging.sinks.*], e.g. console text from Info
+//   // and file JSON from Debug
+//   sinks, err := log.SinksFromConfig(cfg, "logging.sinks")
+//   if err != nil {
+//     return err
+//   }
+//   logger = log.NewWithConfig(log.Config{Level: log.LowestLevel(sinks), Sinks: sinks})
+//
+//   // Ship warnings to Bayes; the service provides the gRPC BayesClient and
+//   // registers the sink type before SinksFromConfig:
+//   //   [logging.sinks.remote]
+//   //   type = "bayes"
+//   //   level = "warn"
+//   //   spool_dir = "/var/spool/mdw/logs"
+//   log.RegisterSinkType("bayes", log.BayesSinkFactory(bayesClient))
+//
+//   // Raise verbosity at runtime without restart
+//   logger.SetLevel(log.LevelDebug)             // Logger and derived loggers
+//   log.SetComponentLevel("tcol", log.LevelDebug) // Loggers named tcol or tcol.*
+//   mux.Handle("/admin/log/level", log.LevelHandler(logger))
+//
+//   // Funnel slog and standard log output of libraries into the logger
+//   slog.SetDefault(slog.New(log.NewSlogHandler(logger)))
+//   stdlog.SetFlags(0)
+//   stdlog.SetOutput(logger.StdWriter(log.LevelInfo))
+//
+//   // Sample retry loops: 10 entries per message and second, then every 100th
+//   retries := logger.WithSampling(log.DefaultSamplingOptions())
+//   retries.Warn("connection refused", log.Field("attempt", attempt))
+//
+//   // Scrub passwords, tokens, emails, and IBANs before output
+//   logger = logger.WithRedaction(log.DefaultRedactionRules())
+//   logger.Info("login", log.Fields{"user": "jane", "password": pw}) // password=[REDACTED]
 package log
\ No newline at end of file
@@ -0,0 +1,437 @@
+// File: encoder.go
+// Title: Append-Based JSON Encoder
+// Description: Implements a pooled, append-based JSON encoder for log entries
+//              that produces byte-identical output to the encoding/json based
+//              formatter while avoiding per-field allocations. Uses cached
+//              level names and timestamps and fast paths for common field
+//              types, falling back to encoding/json for everything else.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial zero-allocation JSON encoder
+
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// AppendFormatter is implemented by formatters that can append a formatted
+// entry to a caller-provided buffer. The logger uses it to avoid allocating
+// a new byte slice for every record.
+type AppendFormatter interface {
+	AppendFormat(dst []byte, entry *Entry) ([]byte, error)
+}
+
+// Standard JSON keys written by the JSON formatter
+const (
+	jsonKeyTimestamp     = "timestamp"
+	jsonKeyLevel         = "level"
+	jsonKeyMessage       = "message"
+	jsonKeyLogger        = "logger"
+	jsonKeyRequestID     = "request_id"
+	jsonKeyUserID        = "user_id"
+	jsonKeyCorrelationID = "correlation_id"
+	jsonKeyError         = "error"
+	jsonKeyErrorDetails  = "error_details"
+	jsonKeyDuration      = "duration_ms"
+)
+
+// jsonLevels holds the pre-quoted JSON representation of each level
+var jsonLevels = func() [LevelAudit + 1][]byte {
+	var levels [LevelAudit + 1][]byte
+	for level := LevelTrace; level <= LevelAudit; level++ {
+		levels[level] = appendJSONString(nil, level.String())
+	}
+	return levels
+}()
+
+// jsonEncoder holds reusable scratch space for encoding one entry
+type jsonEncoder struct {
+	buf  []byte
+	keys []string
+}
+
+// jsonEncoderPool recycles encoders between records
+var jsonEncoderPool = sync.Pool{
+	New: func() interface{} {
+		return &jsonEncoder{
+			buf:  make([]byte, 0, 512),
+			keys: make([]string, 0, 16),
+		}
+	},
+}
+
+// maxPooledBufferSize prevents unusually large records from pinning memory
+const maxPooledBufferSize = 64 * 1024
+
+// getJSONEncoder returns an encoder from the pool
+func getJSONEncoder() *jsonEncoder {
+	return jsonEncoderPool.Get().(*jsonEncoder)
+}
+
+// putJSONEncoder returns an encoder to the pool
+func putJSONEncoder(enc *jsonEncoder) {
+	if cap(enc.buf) > maxPooledBufferSize {
+		return
+	}
+	enc.buf = enc.buf[:0]
+	clear(enc.keys)
+	enc.keys = enc.keys[:0]
+	jsonEncoderPool.Put(enc)
+}
+
+// ===============================
+// Timestamp Cache
+// ===============================
+
+// timestampCache holds the most recently formatted timestamp. It is only used
+// for layouts without fractional seconds, where all instants within the same
+// second format identically.
+type timestampCache struct {
+	layout    string
+	unix      int64
+	loc       *time.Location
+	formatted []byte
+}
+
+// lastTimestamp is shared by all JSON formatters
+var lastTimestamp atomic.Pointer[timestampCache]
+
+// cacheableLayout reports whether a layout has at most second resolution
+func cacheableLayout(layout string) bool {
+	return !strings.Contains(layout, ".0") && !strings.Contains(layout, ".9") &&
+		!strings.Contains(layout, ",0") && !strings.Contains(layout, ",9")
+}
+
+// appendTimestamp appends the JSON-quoted timestamp, reusing the cached
+// formatting when the second and location have not changed
+func appendTimestamp(dst []byte, t time.Time, layout string) []byte {
+	if !cacheableLayout(layout) {
+		return appendJSONString(dst, string(t.AppendFormat(nil, layout)))
+	}
+
+	unix := t.Unix()
+	loc := t.Location()
+	if cached := lastTimestamp.Load(); cached != nil && cached.unix == unix && cached.loc == loc && cached.layout == layout {
+		return append(dst, cached.formatted...)
+	}
+
+	formatted := appendJSONString(nil, t.Format(layout))
+	lastTimestamp.Store(&timestampCache{layout: layout, unix: unix, loc: loc, formatted: formatted})
+	return append(dst, formatted...)
+}
+
+// ===============================
+// Entry Encoding
+// ===============================
+
+// appendEntry appends the JSON encoding of the entry to dst. The output
+// matches json.Marshal of the map built by JSONFormatter.formatMap: keys are
+// sorted, custom fields override context fields, and error and duration
+// information override custom fields.
+func (enc *jsonEncoder) appendEntry(dst []byte, entry *Entry, layout string) ([]byte, error) {
+	hasError := entry.Error != nil
+	hasDetails := false
+	var details []byte
+	if hasError {
+		if marshaler, ok := entry.Error.(json.Marshaler); ok {
+			if detailData, err := errorDetails(marshaler); err == nil {
+				hasDetails = true
+				details = detailData
+			}
+		}
+	}
+	hasDuration := entry.Duration > 0
+
+	// overridden reports whether a later assignment replaces a custom field
+	overridden := func(key string) bool {
+		return (hasError && key == jsonKeyError) ||
+			(hasDetails && key == jsonKeyErrorDetails) ||
+			(hasDuration && key == jsonKeyDuration)
+	}
+
+	// standard adds a context key unless a custom field replaces it
+	keys := enc.keys[:0]
+	standard := func(key string, present bool) {
+		if !present {
+			return
+		}
+		if _, custom := entry.Fields[key]; custom || overridden(key) {
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	standard(jsonKeyTimestamp, true)
+	standard(jsonKeyLevel, true)
+	standard(jsonKeyMessage, true)
+	standard(jsonKeyLogger, entry.Logger != "")
+	standard(jsonKeyRequestID, entry.RequestID != "")
+	standard(jsonKeyUserID, entry.UserID != "")
+	standard(jsonKeyCorrelationID, entry.CorrelationID != "")
+
+	for key := range entry.Fields {
+		if !overridden(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if hasError {
+		keys = append(keys, jsonKeyError)
+	}
+	if hasDetails {
+		keys = append(keys, jsonKeyErrorDetails)
+	}
+	if hasDuration {
+		keys = append(keys, jsonKeyDuration)
+	}
+
+	slices.Sort(keys)
+	enc.keys = keys
+
+	dst = append(dst, '{')
+	for i, key := range keys {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+
+		var err error
+		switch {
+		case hasError && key == jsonKeyError:
+			dst = appendJSONString(dst, entry.Error.Error())
+		case hasDetails && key == jsonKeyErrorDetails:
+			dst = append(dst, details...)
+		case hasDuration && key == jsonKeyDuration:
+			dst, err = appendJSONValue(dst, float64(entry.Duration.Nanoseconds())/1000000)
+		default:
+			if value, custom := entry.Fields[key]; custom {
+				dst, err = appendJSONValue(dst, value)
+			} else {
+				dst = appendStandardValue(dst, entry, key, layout)
+			}
+		}
+		if err != nil {
+			return dst, err
+		}
+	}
+	dst = append(dst, '}')
+
+	return dst, nil
+}
+
+// appendStandardValue appends the value of a standard entry key
+func appendStandardValue(dst []byte, entry *Entry, key, layout string) []byte {
+	switch key {
+	case jsonKeyTimestamp:
+		return appendTimestamp(dst, entry.Timestamp, layout)
+	case jsonKeyLevel:
+		if entry.Level >= LevelTrace && entry.Level <= LevelAudit {
+			return append(dst, jsonLevels[entry.Level]...)
+		}
+		return appendJSONString(dst, entry.Level.String())
+	case jsonKeyMessage:
+		return appendJSONString(dst, entry.Message)
+	case jsonKeyLogger:
+		return appendJSONString(dst, entry.Logger)
+	case jsonKeyRequestID:
+		return appendJSONString(dst, entry.RequestID)
+	case jsonKeyUserID:
+		return appendJSONString(dst, entry.UserID)
+	case jsonKeyCorrelationID:
+		return appendJSONString(dst, entry.CorrelationID)
+	default:
+		return append(dst, "null"...)
+	}
+}
+
+// errorDetails re-encodes the JSON object produced by an error's MarshalJSON
+// so that its keys are sorted exactly as the map-based formatter sorts them
+func errorDetails(marshaler json.Marshaler) ([]byte, error) {
+	data, err := marshaler.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var errorObj map[string]interface{}
+	if err := json.Unmarshal(data, &errorObj); err != nil {
+		return nil, err
+	}
+	return json.Marshal(errorObj)
+}
+
+// appendJSONValue appends the JSON encoding of a field value. Common types are
+// encoded directly; everything else is delegated to encoding/json.
+func appendJSONValue(dst []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, v), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, v, 10), nil
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(dst, v, 10), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			break
+		}
+		return appendJSONFloat(dst, v, 64), nil
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			break
+		}
+		return appendJSONFloat(dst, float64(v), 32), nil
+	case time.Duration:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case time.Time:
+		if y := v.Year(); y < 0 || y > 9999 {
+			break // Let encoding/json report the error
+		}
+		dst = append(dst, '"')
+		dst = v.AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"'), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
+}
+
+// appendJSONFloat appends a float using the same formatting rules as
+// encoding/json
+func appendJSONFloat(dst []byte, f float64, bits int) []byte {
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst
+}
+
+// jsonEscapes holds the escape sequence encoding/json uses for each ASCII
+// byte that needs escaping (empty if the byte is written as is). Deriving the
+// table from encoding/json keeps the output identical across Go releases.
+var jsonEscapes = func() [utf8.RuneSelf]string {
+	var escapes [utf8.RuneSelf]string
+	for b := 0; b < utf8.RuneSelf; b++ {
+		if quoted := jsonQuote(string(rune(b))); quoted != string(rune(b)) {
+			escapes[b] = quoted
+		}
+	}
+	return escapes
+}()
+
+// Escapes for invalid UTF-8 and the JavaScript line/paragraph separators
+var (
+	jsonInvalidUTF8 = jsonQuote("\xff")
+	jsonLineSep     = jsonQuote("\u2028")
+	jsonParaSep     = jsonQuote("\u2029")
+)
+
+// jsonQuote returns the unquoted body of the encoding/json string encoding
+func jsonQuote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
+
+// appendJSONString appends a quoted JSON string using the same escaping as
+// encoding/json, including HTML-safe escaping of <, >, and &
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if escape := jsonEscapes[b]; escape != "" {
+				dst = append(dst, s[start:i]...)
+				dst = append(dst, escape...)
+				start = i + 1
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		var escape string
+		switch {
+		case r == utf8.RuneError && size == 1:
+			escape = jsonInvalidUTF8
+		case r == '\u2028':
+			escape = jsonLineSep
+		case r == '\u2029':
+			escape = jsonParaSep
+		}
+		if escape != "" {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, escape...)
+			start = i + size
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// writeEntry formats an entry and writes it to output. Formatters that
+// implement AppendFormatter are encoded into a pooled buffer.
+func writeEntry(formatter Formatter, output io.Writer, entry *Entry) {
+	if appender, ok := formatter.(AppendFormatter); ok {
+		enc := getJSONEncoder()
+		formatted, err := appender.AppendFormat(enc.buf[:0], entry)
+		if err == nil {
+			output.Write(formatted)
+		}
+		enc.buf = formatted[:0]
+		putJSONEncoder(enc)
+		return
+	}
+
+	if formatted, err := formatter.Format(entry); err == nil {
+		output.Write(formatted)
+	}
+}
@@ -0,0 +1,275 @@
+// File: encoder_test.go
+// Title: Append-Based JSON Encoder Tests
+// Description: Verifies that the append-based JSON encoder produces output
+//              byte-identical to the encoding/json reference for a wide range
+//              of entries and field types, stays below one allocation per
+//              record, and benchmarks it against the map-based encoding.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial encoder parity tests and benchmarks
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// detailedError implements json.Marshaler like mDW errors do
+type detailedError struct {
+	code string
+}
+
+func (e detailedError) Error() string { return "detailed: " + e.code }
+
+func (e detailedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"code": e.code, "retryable": false, "attempts": 3})
+}
+
+// referenceJSON encodes an entry with the map-based reference implementation
+func referenceJSON(f *JSONFormatter, entry *Entry) ([]byte, error) {
+	return json.Marshal(f.formatMap(entry))
+}
+
+func TestJSONFormatter_AppendFormatParity(t *testing.T) {
+	timestamp := time.Date(2026, 10, 15, 9, 30, 15, 123456789, time.UTC)
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	tests := []struct {
+		name   string
+		layout string
+		entry  *Entry
+	}{
+		{
+			name:  "minimal",
+			entry: &Entry{Timestamp: timestamp, Level: LevelInfo, Message: "started"},
+		},
+		{
+			name: "context fields",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelAudit, Message: "login",
+				Logger: "auth", RequestID: "req-1", UserID: "u-42", CorrelationID: "corr-7",
+			},
+		},
+		{
+			name: "common field types",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelDebug, Message: "types",
+				Fields: Fields{
+					"string": "value", "int": 42, "int8": int8(-8), "int16": int16(16), "int32": int32(-32),
+					"int64": int64(1 << 60), "uint": uint(7), "uint8": uint8(255), "uint16": uint16(65535),
+					"uint32": uint32(1 << 31), "uint64": uint64(math.MaxUint64), "bool": true, "nil": nil,
+					"float": 3.14159, "float_int": 2.0, "float_small": 1e-7, "float_large": 1e21,
+					"float_neg_small": -0.000001, "float32": float32(0.1), "float32_small": float32(1e-8),
+					"duration": 1500 * time.Millisecond, "time": timestamp, "time_zone": timestamp.In(berlin),
+				},
+			},
+		},
+		{
+			name: "fallback field types",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelWarn, Message: "complex",
+				Fields: Fields{
+					"slice": []string{"a", "b"}, "map": map[string]int{"z": 1, "a": 2},
+					"bytes": []byte("raw"), "struct": struct{ A int }{A: 1}, "level": LevelError,
+					"plain_error": errors.New("not marshaled"),
+				},
+			},
+		},
+		{
+			name: "string escaping",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelError,
+				Message: "quote \" backslash \\ tab \t newline \n cr \r bell \a bs \b ff \f <html> & more",
+				Fields: Fields{
+					"unicode": "größe 漢字 😀", "separators": "line\u2028para\u2029", "invalid": "bad\xffutf8",
+					"control": "\x00\x01\x1f\x7f", "key<&>": "escaped key",
+				},
+			},
+		},
+		{
+			name: "overrides",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelInfo, Message: "original", Logger: "svc",
+				Fields:   Fields{"message": "custom", "level": 5, "logger": nil, "error": "field", "duration_ms": "field"},
+				Error:    errors.New("boom"),
+				Duration: 2500 * time.Microsecond,
+			},
+		},
+		{
+			name: "error details",
+			entry: &Entry{
+				Timestamp: timestamp, Level: LevelError, Message: "failed",
+				Fields: Fields{"error_details": "replaced"},
+				Error:  detailedError{code: "E42"},
+			},
+		},
+		{
+			name:   "fractional layout",
+			layout: time.RFC3339Nano,
+			entry:  &Entry{Timestamp: timestamp, Level: LevelTrace, Message: "nano"},
+		},
+		{
+			name:   "custom layout",
+			layout: "2006-01-02 15:04:05 MST",
+			entry:  &Entry{Timestamp: timestamp.In(berlin), Level: Level(99), Message: "unknown level"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewJSONFormatter()
+			if tt.layout != "" {
+				formatter.TimestampFormat = tt.layout
+			}
+
+			want, err := referenceJSON(formatter, tt.entry)
+			if err != nil {
+				t.Fatalf("reference encoding error = %v", err)
+			}
+
+			// Run twice to exercise the timestamp cache
+			for i := 0; i < 2; i++ {
+				got, err := formatter.Format(tt.entry)
+				if err != nil {
+					t.Fatalf("Format() error = %v", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Fatalf("Format() mismatch\n got: %s\nwant: %s", got, want)
+				}
+
+				prefix := []byte("prefix:")
+				appended, err := formatter.AppendFormat(prefix, tt.entry)
+				if err != nil {
+					t.Fatalf("AppendFormat() error = %v", err)
+				}
+				if !bytes.Equal(appended, append([]byte("prefix:"), want...)) {
+					t.Fatalf("AppendFormat() mismatch\n got: %s\nwant: prefix:%s", appended, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFormatter_AppendFormatErrors(t *testing.T) {
+	formatter := NewJSONFormatter()
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"NaN", math.NaN()},
+		{"infinity", math.Inf(1)},
+		{"float32 infinity", float32(math.Inf(-1))},
+		{"year out of range", time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"channel", make(chan int)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := NewEntry(LevelInfo, "bad value").WithField("value", tt.value)
+
+			_, wantErr := referenceJSON(formatter, entry)
+			_, gotErr := formatter.Format(entry)
+			if wantErr == nil || gotErr == nil {
+				t.Fatalf("Format() error = %v, reference error = %v; want both non-nil", gotErr, wantErr)
+			}
+			if gotErr.Error() != wantErr.Error() {
+				t.Errorf("Format() error = %q, want %q", gotErr, wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONFormatter_AppendFormatAllocations(t *testing.T) {
+	formatter := NewJSONFormatter()
+	entry := NewEntry(LevelInfo, "request handled")
+	entry.RequestID = "req-123"
+	entry.Fields = Fields{
+		"component": "tcol-engine",
+		"status":    200,
+		"bytes":     int64(5120),
+		"cached":    true,
+		"ratio":     0.75,
+		"elapsed":   15 * time.Millisecond,
+	}
+
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(1000, func() {
+		var err error
+		buf, err = formatter.AppendFormat(buf[:0], entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs >= 1 {
+		t.Errorf("AppendFormat() allocations per record = %.2f, want < 1", allocs)
+	}
+}
+
+func TestLogger_JSONOutputParity(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Level: LevelInfo, Format: FormatJSON, Output: &buf, Name: "parity"})
+	logger.Info("hello <world>", Fields{"count": 3, "ok": true})
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("logger output is not valid JSON: %v\n%s", err, buf.Bytes())
+	}
+	if record["message"] != "hello <world>" || record["logger"] != "parity" || record["count"] != float64(3) {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+// newBenchmarkEntry creates an entry with typical production fields
+func newBenchmarkEntry() *Entry {
+	entry := NewEntry(LevelInfo, "benchmark message")
+	entry.Logger = "benchmark"
+	entry.RequestID = "req-123"
+	entry.Fields = Fields{"key": "value", "count": 42, "enabled": true, "ratio": 0.5}
+	return entry
+}
+
+func BenchmarkJSONFormatter_MapReference(b *testing.B) {
+	formatter := NewJSONFormatter()
+	entry := newBenchmarkEntry()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = referenceJSON(formatter, entry)
+	}
+}
+
+func BenchmarkJSONFormatter_AppendFormat(b *testing.B) {
+	formatter := NewJSONFormatter()
+	entry := newBenchmarkEntry()
+	buf := make([]byte, 0, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = formatter.AppendFormat(buf[:0], entry)
+	}
+}
+
+func BenchmarkJSONFormatter_FormatParallel(b *testing.B) {
+	formatter := NewJSONFormatter()
+	entry := newBenchmarkEntry()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = formatter.Format(entry)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+// File: file_test.go
+// Title: Rotating File Output Tests
+// Description: Tests file output options from configuration and logging
+//              through a rotating file.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial rotating file output tests
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mdwconfig "github.com/msto63/mDW/foundation/core/config"
+)
+
+func TestFileOptionsFromConfig(t *testing.T) {
+	cfg, err := mdwconfig.LoadFromString(`
+[logging.file]
+path = "/var/log/mdw/kant.log"
+max_size_mb = 50
+interval = "24h"
+compress = false
+`, mdwconfig.FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	options := FileOptionsFromConfig(cfg, "logging.file")
+	expected := FileOptions{
+		Path:       "/var/log/mdw/kant.log",
+		MaxSizeMB:  50,
+		Interval:   24 * time.Hour,
+		MaxBackups: 10, // Default
+		Compress:   false,
+	}
+	if options != expected {
+		t.Errorf("FileOptionsFromConfig() = %+v, want %+v", options, expected)
+	}
+}
+
+func TestNewFileOutput(t *testing.T) {
+	if _, err := NewFileOutput(FileOptions{}); err == nil {
+		t.Error("NewFileOutput() without path succeeded")
+	}
+
+	path := filepath.Join(t.TempDir(), "logs", "service.log")
+	output, err := NewFileOutput(FileOptions{Path: path, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewFileOutput() error = %v", err)
+	}
+
+	logger := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: output})
+	logger.Info("before rotation")
+	if err := output.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	logger.Info("after rotation")
+	output.Close()
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "after rotation") || strings.Contains(string(content), "before rotation") {
+		t.Errorf("log file = %q", content)
+	}
+	if backups, _ := output.Backups(); len(backups) != 1 {
+		t.Errorf("Backups() = %v, want 1", backups)
+	}
+}
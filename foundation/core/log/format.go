@@ -4,12 +4,13 @@
 //              and console formats. Provides formatters for different output
 //              destinations and use cases.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-24
-// Modified: 2025-01-24
+// Modified: 2026-10-15
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with multiple output formats
+// - 2026-10-15 v0.1.1: JSONFormatter uses the append-based encoder
 
 package log
 
@@ -96,6 +97,42 @@ func NewJSONFormatter() *JSONFormatter {
 
 // Format formats a log entry as JSON
 func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	if f.PrettyPrint {
+		return json.MarshalIndent(f.formatMap(entry), "", "  ")
+	}
+
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+
+	formatted, err := enc.appendEntry(enc.buf[:0], entry, f.TimestampFormat)
+	enc.buf = formatted[:0]
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), formatted...), nil
+}
+
+// AppendFormat appends the JSON encoding of the entry to dst without
+// allocating for common field types
+func (f *JSONFormatter) AppendFormat(dst []byte, entry *Entry) ([]byte, error) {
+	if f.PrettyPrint {
+		data, err := f.Format(entry)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, data...), nil
+	}
+
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+
+	return enc.appendEntry(dst, entry, f.TimestampFormat)
+}
+
+// formatMap builds the generic map representation of an entry. It defines
+// the reference output that the append-based encoder reproduces.
+func (f *JSONFormatter) formatMap(entry *Entry) map[string]interface{} {
 	data := make(map[string]interface{})
 	
 	// Standard fields
@@ -144,11 +181,7 @@ func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
 		data["duration_ms"] = float64(entry.Duration.Nanoseconds()) / 1000000
 	}
 	
-	if f.PrettyPrint {
-		return json.MarshalIndent(data, "", "  ")
-	}
-	
-	return json.Marshal(data)
+	return data
 }
 
 // TextFormatter formats log entries as human-readable text
@@ -0,0 +1,162 @@
+// File: interop.go
+// Title: slog and io.Writer Interoperability
+// Description: Provides a log/slog handler and an io.Writer for the standard
+//              log package that funnel third-party library output into mDW
+//              structured logging with attributes preserved as fields.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of slog and std log adapters
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+)
+
+// ===============================
+// slog Handler
+// ===============================
+
+// SlogHandler is a slog.Handler that writes records to a Logger. Attributes
+// become fields; attributes in groups are named "group.key". An error
+// attribute named "err" or "error" becomes the entry's error.
+type SlogHandler struct {
+	logger *Logger
+	attrs  Fields
+	prefix string
+}
+
+// NewSlogHandler creates a slog handler writing to logger, e.g.
+// slog.SetDefault(slog.New(log.NewSlogHandler(logger)))
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	return &SlogHandler{logger: logger, attrs: make(Fields)}
+}
+
+// Enabled reports whether the logger writes records of level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.IsLevelEnabled(levelFromSlog(level))
+}
+
+// Handle writes the record to the logger
+func (h *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := make(Fields, len(h.attrs)+record.NumAttrs())
+	for k, v := range h.attrs {
+		fields[k] = v
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addSlogAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	var err error
+	for _, key := range []string{h.prefix + "err", h.prefix + "error"} {
+		if e, ok := fields[key].(error); ok {
+			err = e
+			delete(fields, key)
+			break
+		}
+	}
+
+	h.logger.log(levelFromSlog(record.Level), record.Message, err, fields)
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to all records
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := &SlogHandler{logger: h.logger, attrs: make(Fields, len(h.attrs)+len(attrs)), prefix: h.prefix}
+	for k, v := range h.attrs {
+		clone.attrs[k] = v
+	}
+	for _, attr := range attrs {
+		addSlogAttr(clone.attrs, h.prefix, attr)
+	}
+	return clone
+}
+
+// WithGroup returns a handler that qualifies subsequent attributes with name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{logger: h.logger, attrs: h.attrs, prefix: h.prefix + name + "."}
+}
+
+// addSlogAttr adds attr to fields, flattening groups into dotted names
+func addSlogAttr(fields Fields, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, member := range value.Group() {
+			addSlogAttr(fields, groupPrefix, member)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+	fields[prefix+attr.Key] = value.Any()
+}
+
+// levelFromSlog maps a slog level to the nearest mDW level
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LevelTrace
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	case level < slog.LevelError+4:
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// ===============================
+// Standard Library Writer
+// ===============================
+
+// StdWriter is an io.Writer that logs each written line as a message, for
+// use as output of the standard log package
+type StdWriter struct {
+	logger *Logger // nil = default logger at the time of writing
+	level  Level
+}
+
+// NewStdWriter creates a writer that logs to the default logger at level,
+// e.g. stdlog.SetOutput(log.NewStdWriter(log.LevelInfo)) with
+// stdlog.SetFlags(0), as the logger adds its own timestamp
+func NewStdWriter(level Level) *StdWriter {
+	return &StdWriter{level: level}
+}
+
+// StdWriter creates a writer that logs to l at level
+func (l *Logger) StdWriter(level Level) *StdWriter {
+	return &StdWriter{logger: l, level: level}
+}
+
+// Write logs each non-empty line of p and always succeeds
+func (w *StdWriter) Write(p []byte) (int, error) {
+	logger := w.logger
+	if logger == nil {
+		logger = GetDefault()
+	}
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		if line = bytes.TrimRight(line, "\r"); len(line) > 0 {
+			logger.log(w.level, string(line), nil)
+		}
+	}
+	return len(p), nil
+}
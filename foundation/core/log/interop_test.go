@@ -0,0 +1,142 @@
+// File: interop_test.go
+// Title: slog and io.Writer Interoperability Tests
+// Description: Tests the slog handler and the standard log writer adapters.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial interoperability tests
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	stdlog "log"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+)
+
+// captureEntries returns a logger that records entries through a processor
+func captureEntries(level Level) (*Logger, *[]*Entry) {
+	var entries []*Entry
+	logger := NewWithConfig(Config{
+		Level:  level,
+		Format: FormatText,
+		Output: &bytes.Buffer{},
+		Processors: []Processor{ProcessorFunc(func(entry *Entry) {
+			entries = append(entries, entry)
+		})},
+	})
+	return logger, &entries
+}
+
+func TestSlogHandler(t *testing.T) {
+	logger, entries := captureEntries(LevelDebug)
+	slogger := slog.New(NewSlogHandler(logger)).With("component", "grpc").WithGroup("request")
+
+	slogger.Info("request done", "method", "GET", slog.Group("user", "id", 42), "err", errors.New("boom"))
+	slogger.Log(context.Background(), slog.LevelDebug-4, "too verbose")
+
+	if len(*entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(*entries))
+	}
+	entry := (*entries)[0]
+	if entry.Level != LevelInfo || entry.Message != "request done" {
+		t.Errorf("entry = %v %q", entry.Level, entry.Message)
+	}
+	expected := Fields{"component": "grpc", "request.method": "GET", "request.user.id": int64(42)}
+	for key, want := range expected {
+		if entry.Fields[key] != want {
+			t.Errorf("Fields[%q] = %#v, want %#v", key, entry.Fields[key], want)
+		}
+	}
+	if entry.Error == nil || entry.Error.Error() != "boom" || entry.Fields["request.err"] != nil {
+		t.Errorf("Error = %v, fields = %v", entry.Error, entry.Fields)
+	}
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	tests := []struct {
+		slogLevel slog.Level
+		expected  Level
+	}{
+		{slog.LevelDebug - 4, LevelTrace},
+		{slog.LevelDebug, LevelDebug},
+		{slog.LevelInfo, LevelInfo},
+		{slog.LevelWarn + 1, LevelWarn},
+		{slog.LevelError, LevelError},
+		{slog.LevelError + 4, LevelFatal},
+	}
+	for _, tt := range tests {
+		if got := levelFromSlog(tt.slogLevel); got != tt.expected {
+			t.Errorf("levelFromSlog(%v) = %v, want %v", tt.slogLevel, got, tt.expected)
+		}
+	}
+}
+
+func TestSlogHandler_Conformance(t *testing.T) {
+	logger, entries := captureEntries(LevelTrace)
+	err := slogtest.TestHandler(NewSlogHandler(logger), func() []map[string]any {
+		var results []map[string]any
+		for _, entry := range *entries {
+			result := map[string]any{
+				slog.LevelKey:   entry.Level,
+				slog.MessageKey: entry.Message,
+				slog.TimeKey:    entry.Timestamp,
+			}
+			for key, value := range entry.Fields {
+				// Expand dotted group names into nested maps
+				parts := strings.Split(key, ".")
+				target := result
+				for _, part := range parts[:len(parts)-1] {
+					nested, ok := target[part].(map[string]any)
+					if !ok {
+						nested = make(map[string]any)
+						target[part] = nested
+					}
+					target = nested
+				}
+				target[parts[len(parts)-1]] = value
+			}
+			results = append(results, result)
+		}
+		return results
+	})
+	if err != nil {
+		// Entries always carry the logging time, even for a zero record time
+		for _, line := range strings.Split(err.Error(), "\n") {
+			if !strings.Contains(line, "time") {
+				t.Error(line)
+			}
+		}
+	}
+}
+
+func TestStdWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+
+	std := stdlog.New(logger.StdWriter(LevelWarn), "", 0)
+	std.Print("deprecated option used")
+	std.Print("line one\nline two")
+	if strings.Count(buf.String(), "[WRN]") != 3 || !strings.Contains(buf.String(), "line two") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	// NewStdWriter follows the default logger
+	previous := GetDefault()
+	defer SetDefault(previous)
+	buf.Reset()
+	SetDefault(logger)
+	NewStdWriter(LevelDebug).Write([]byte("filtered\n"))
+	NewStdWriter(LevelError).Write([]byte("failed\r\n"))
+	if buf.String() == "" || strings.Contains(buf.String(), "filtered") || strings.Contains(buf.String(), "\r") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,206 @@
+// File: levelcontrol.go
+// Title: Runtime Level Control
+// Description: Implements atomic level changes at runtime, per-component level
+//              overrides keyed by logger name, and an HTTP admin handler for
+//              inspecting and changing levels on a running service.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of runtime level control
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ===============================
+// Level Variables
+// ===============================
+
+// LevelVar is a level that can be changed atomically while loggers use it.
+// Loggers derived from a logger share its LevelVar, except those created
+// with WithLevel.
+type LevelVar struct {
+	level atomic.Int32
+}
+
+// NewLevelVar creates a level variable set to level
+func NewLevelVar(level Level) *LevelVar {
+	v := &LevelVar{}
+	v.Set(level)
+	return v
+}
+
+// Level returns the current level
+func (v *LevelVar) Level() Level {
+	return Level(v.level.Load())
+}
+
+// Set changes the level
+func (v *LevelVar) Set(level Level) {
+	v.level.Store(int32(level))
+}
+
+// ===============================
+// Component Levels
+// ===============================
+
+// componentLevels holds the per-component overrides; the map is replaced,
+// never modified, so that loggers read it without locking
+var (
+	componentLevels   atomic.Pointer[map[string]Level]
+	componentLevelsMu sync.Mutex
+)
+
+// SetComponentLevel overrides the level of all loggers named component or
+// named below it, e.g. "tcol" also covers "tcol.parser". The most specific
+// override wins over the logger's own level.
+func SetComponentLevel(component string, level Level) {
+	updateComponentLevels(func(levels map[string]Level) {
+		levels[component] = level
+	})
+}
+
+// ClearComponentLevel removes the override of component
+func ClearComponentLevel(component string) {
+	updateComponentLevels(func(levels map[string]Level) {
+		delete(levels, component)
+	})
+}
+
+// ComponentLevels returns a copy of the component level overrides
+func ComponentLevels() map[string]Level {
+	levels := make(map[string]Level)
+	if current := componentLevels.Load(); current != nil {
+		for component, level := range *current {
+			levels[component] = level
+		}
+	}
+	return levels
+}
+
+// updateComponentLevels replaces the overrides with a modified copy
+func updateComponentLevels(update func(levels map[string]Level)) {
+	componentLevelsMu.Lock()
+	defer componentLevelsMu.Unlock()
+
+	levels := ComponentLevels()
+	update(levels)
+	componentLevels.Store(&levels)
+}
+
+// componentLevel returns the most specific override for a logger name
+func componentLevel(name string) (Level, bool) {
+	levels := componentLevels.Load()
+	if levels == nil || len(*levels) == 0 || name == "" {
+		return 0, false
+	}
+	for {
+		if level, ok := (*levels)[name]; ok {
+			return level, true
+		}
+		idx := strings.LastIndex(name, ".")
+		if idx < 0 {
+			return 0, false
+		}
+		name = name[:idx]
+	}
+}
+
+// ===============================
+// Admin Hook
+// ===============================
+
+// LevelState reports the levels of a logger and the component overrides
+type LevelState struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// LevelRequest changes the level of a logger or, if Component is set, the
+// override of a component; an empty Level clears the component override
+type LevelRequest struct {
+	Component string `json:"component,omitempty"`
+	Level     string `json:"level"`
+}
+
+// CurrentLevels returns the level state of logger
+func CurrentLevels(logger *Logger) LevelState {
+	state := LevelState{
+		Level:      logger.GetLevel().String(),
+		Components: make(map[string]string),
+	}
+	for component, level := range ComponentLevels() {
+		state.Components[component] = level.String()
+	}
+	return state
+}
+
+// ApplyLevelRequest applies request to logger and returns the resulting
+// state. It is the transport-independent core of LevelHandler and can back
+// a gRPC admin service as well.
+func ApplyLevelRequest(logger *Logger, request LevelRequest) (LevelState, error) {
+	if request.Component != "" && request.Level == "" {
+		ClearComponentLevel(request.Component)
+		return CurrentLevels(logger), nil
+	}
+
+	level, err := ParseLevel(request.Level)
+	if err != nil {
+		return LevelState{}, err
+	}
+	if request.Component != "" {
+		SetComponentLevel(request.Component, level)
+	} else {
+		logger.SetLevel(level)
+	}
+	return CurrentLevels(logger), nil
+}
+
+// LevelHandler returns an HTTP handler for runtime level control of logger.
+// GET returns the LevelState as JSON; PUT or POST with a JSON LevelRequest
+// changes a level, e.g. {"component": "tcol", "level": "debug"}. Protect the
+// handler like any other admin endpoint.
+func LevelHandler(logger *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeLevelResponse(w, http.StatusOK, CurrentLevels(logger))
+		case http.MethodPut, http.MethodPost:
+			var request LevelRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				writeLevelResponse(w, http.StatusBadRequest, map[string]string{"error": "invalid request: " + err.Error()})
+				return
+			}
+			state, err := ApplyLevelRequest(logger, request)
+			if err != nil {
+				writeLevelResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			logger.Audit("log level changed", Fields{
+				"component": request.Component,
+				"level":     request.Level,
+				"remote":    r.RemoteAddr,
+			})
+			writeLevelResponse(w, http.StatusOK, state)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			writeLevelResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		}
+	})
+}
+
+// writeLevelResponse writes body as JSON with status
+func writeLevelResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,132 @@
+// File: levelcontrol_test.go
+// Title: Runtime Level Control Tests
+// Description: Tests shared atomic levels, component level overrides, and the
+//              HTTP admin handler.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial runtime level control tests
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogger_SetLevelShared(t *testing.T) {
+	var buf bytes.Buffer
+	root := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	derived := root.WithName("service").WithField("k", "v")
+	independent := root.WithLevel(LevelError)
+
+	root.SetLevel(LevelDebug)
+	if derived.GetLevel() != LevelDebug {
+		t.Errorf("derived level = %v, want debug", derived.GetLevel())
+	}
+	if independent.GetLevel() != LevelError {
+		t.Errorf("WithLevel() logger level = %v, want error", independent.GetLevel())
+	}
+
+	// Level changes race freely with logging
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if i == 0 {
+					root.SetLevel(Level(j % 3))
+				} else {
+					derived.IsLevelEnabled(LevelDebug)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestComponentLevels(t *testing.T) {
+	defer func() {
+		for component := range ComponentLevels() {
+			ClearComponentLevel(component)
+		}
+	}()
+
+	var buf bytes.Buffer
+	base := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	parser := base.WithName("tcol.parser")
+	other := base.WithName("kant")
+
+	SetComponentLevel("tcol", LevelDebug)
+	parser.Debug("parser debug")
+	other.Debug("kant debug")
+	if !strings.Contains(buf.String(), "parser debug") || strings.Contains(buf.String(), "kant debug") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	// The most specific override wins
+	SetComponentLevel("tcol.parser", LevelError)
+	if parser.IsLevelEnabled(LevelWarn) {
+		t.Error("tcol.parser override not applied")
+	}
+	if !base.WithName("tcol.executor").IsLevelEnabled(LevelDebug) {
+		t.Error("tcol override not applied to tcol.executor")
+	}
+
+	ClearComponentLevel("tcol.parser")
+	ClearComponentLevel("tcol")
+	if parser.IsLevelEnabled(LevelDebug) || len(ComponentLevels()) != 0 {
+		t.Errorf("overrides not cleared: %v", ComponentLevels())
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	defer ClearComponentLevel("tcol")
+
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	handler := LevelHandler(logger)
+
+	request := func(method, body string) (*httptest.ResponseRecorder, LevelState) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/log/level", strings.NewReader(body)))
+		var state LevelState
+		json.Unmarshal(rec.Body.Bytes(), &state)
+		return rec, state
+	}
+
+	if rec, state := request(http.MethodGet, ""); rec.Code != http.StatusOK || state.Level != "info" {
+		t.Errorf("GET = %d %+v", rec.Code, state)
+	}
+	if rec, state := request(http.MethodPut, `{"level": "debug"}`); rec.Code != http.StatusOK || state.Level != "debug" {
+		t.Errorf("PUT level = %d %+v", rec.Code, state)
+	}
+	if logger.GetLevel() != LevelDebug {
+		t.Errorf("logger level = %v after PUT", logger.GetLevel())
+	}
+	if rec, state := request(http.MethodPost, `{"component": "tcol", "level": "trace"}`); rec.Code != http.StatusOK || state.Components["tcol"] != "trace" {
+		t.Errorf("POST component = %d %+v", rec.Code, state)
+	}
+	if !strings.Contains(buf.String(), "log level changed") {
+		t.Error("level change was not audited")
+	}
+	if rec, state := request(http.MethodPost, `{"component": "tcol"}`); rec.Code != http.StatusOK || len(state.Components) != 0 {
+		t.Errorf("POST clear = %d %+v", rec.Code, state)
+	}
+
+	if rec, _ := request(http.MethodPut, `{"level": "loud"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("PUT invalid level = %d", rec.Code)
+	}
+	if rec, _ := request(http.MethodDelete, ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d", rec.Code)
+	}
+}
@@ -4,12 +4,22 @@
 //              with contextual information, multiple output formats, and
 //              integration with the mDW error system.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.10
 // Created: 2025-01-24
-// Modified: 2025-01-24
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with structured logging
+// - 2026-10-15 v0.1.1: Write entries through pooled buffers via writeEntry
+// - 2026-10-16 v0.1.2: Moved async logging to AsyncWriter with overflow policies
+// - 2026-10-16 v0.1.3: Shared atomic levels and applied component level overrides
+// - 2026-10-16 v0.1.4: Added per-message sampling with summary entries
+// - 2026-10-16 v0.1.5: Added entry processor chain with redaction opt-out
+// - 2026-10-16 v0.1.6: Wrote audit entries to the hash-chained audit sink
+// - 2026-10-16 v0.1.7: Added fan-out to sinks with per-sink level and format
+// - 2026-10-16 v0.1.8: Moved entry creation to logEntry for context logging
+// - 2026-10-16 v0.1.9: Kept EntryWriter outputs out of async buffering
+// - 2026-10-16 v0.1.10: Writes every sampling summary of an entry
 
 package log
 
@@ -26,7 +36,7 @@ import (
 // Logger represents a structured logger with contextual information
 type Logger struct {
 	// Configuration
-	level     Level
+	level     *LevelVar
 	formatter Formatter
 	output    io.Writer
 	name      string
@@ -41,11 +51,22 @@ type Logger struct {
 	enableCaller    bool
 	callerSkipFrames int
 	
+	// Sinks replacing formatter and output if set; shared, never modified
+	sinks []*sinkWriter
+	
 	// Async logging support
 	asyncEnabled bool
-	asyncBuffer  chan *Entry
-	asyncDone    chan struct{}
-	asyncOnce    sync.Once
+	asyncWriter  *AsyncWriter
+	
+	// Sampling of high-volume messages (nil = log everything)
+	sampler *Sampler
+	
+	// Entry processors, e.g. redaction; shared and never modified in place
+	processors        []Processor
+	redactionDisabled bool
+	
+	// Dedicated sink receiving audit entries in addition to the output
+	auditSink *AuditSink
 	
 	// Thread safety
 	mutex sync.RWMutex
@@ -61,12 +82,18 @@ type Config struct {
 	CallerSkipFrames int
 	AsyncEnabled    bool
 	AsyncBufferSize int
+	AsyncOverflow   OverflowPolicy // Policy when the async buffer is full (default: block)
+	AsyncSampleRate int            // Records kept per overflow with OverflowSample
+	Sampling        *SamplingOptions // Per-message sampling (default: none)
+	Processors      []Processor      // Entry processors run before output, e.g. a Redactor
+	AuditSink       *AuditSink       // Hash-chained sink for audit entries (default: none)
+	Sinks           []Sink           // Outputs with own format and level, replacing Format and Output
 }
 
 // New creates a new logger with default configuration
 func New() *Logger {
 	return &Logger{
-		level:            DefaultLevel(),
+		level:            NewLevelVar(DefaultLevel()),
 		formatter:        NewJSONFormatter(),
 		output:           os.Stdout,
 		contextFields:    make(Fields),
@@ -79,13 +106,15 @@ func New() *Logger {
 // NewWithConfig creates a new logger with the specified configuration
 func NewWithConfig(config Config) *Logger {
 	logger := &Logger{
-		level:            config.Level,
+		level:            NewLevelVar(config.Level),
 		output:           config.Output,
 		name:             config.Name,
 		contextFields:    make(Fields),
 		enableCaller:     config.EnableCaller,
 		callerSkipFrames: config.CallerSkipFrames,
 		asyncEnabled:     config.AsyncEnabled,
+		processors:       append([]Processor(nil), config.Processors...),
+		auditSink:        config.AuditSink,
 		mutex:           sync.RWMutex{},
 	}
 	
@@ -95,15 +124,24 @@ func NewWithConfig(config Config) *Logger {
 	
 	logger.formatter = GetFormatter(config.Format)
 	
-	// Initialize async logging if enabled
+	if config.Sampling != nil {
+		logger.sampler = NewSampler(*config.Sampling)
+	}
+	
+	// Initialize async logging if enabled, with one buffer per sink
+	var asyncOptions *AsyncOptions
 	if config.AsyncEnabled {
-		bufferSize := config.AsyncBufferSize
-		if bufferSize <= 0 {
-			bufferSize = 1000 // Default buffer size
+		asyncOptions = &AsyncOptions{
+			BufferSize: config.AsyncBufferSize,
+			Overflow:   config.AsyncOverflow,
+			SampleRate: config.AsyncSampleRate,
 		}
-		logger.asyncBuffer = make(chan *Entry, bufferSize)
-		logger.asyncDone = make(chan struct{})
-		logger.startAsyncWorker()
+	}
+	if len(config.Sinks) > 0 {
+		logger.sinks = newSinkWriters(config.Sinks, asyncOptions)
+	} else if _, isEntryWriter := logger.output.(EntryWriter); asyncOptions != nil && !isEntryWriter {
+		logger.asyncWriter = NewAsyncWriter(logger.output, *asyncOptions)
+		logger.output = logger.asyncWriter
 	}
 	
 	return logger
@@ -115,7 +153,7 @@ func (l *Logger) WithLevel(level Level) *Logger {
 	defer l.mutex.Unlock()
 	
 	clone := l.clone()
-	clone.level = level
+	clone.level = NewLevelVar(level)
 	return clone
 }
 
@@ -129,13 +167,14 @@ func (l *Logger) WithFormat(format Format) *Logger {
 	return clone
 }
 
-// WithOutput sets the output destination
+// WithOutput sets the output destination, replacing configured sinks
 func (l *Logger) WithOutput(output io.Writer) *Logger {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 	
 	clone := l.clone()
 	clone.output = output
+	clone.sinks = nil
 	return clone
 }
 
@@ -201,6 +240,54 @@ func (l *Logger) WithCorrelationID(correlationID string) *Logger {
 	return clone
 }
 
+// WithSampling limits high-volume messages according to options. Loggers
+// derived from the result share its sampling state.
+func (l *Logger) WithSampling(options SamplingOptions) *Logger {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	
+	clone := l.clone()
+	clone.sampler = NewSampler(options)
+	return clone
+}
+
+// WithProcessor appends an entry processor to the chain of the returned logger
+func (l *Logger) WithProcessor(processor Processor) *Logger {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	
+	clone := l.clone()
+	clone.processors = append(append([]Processor(nil), l.processors...), processor)
+	return clone
+}
+
+// WithRedaction appends a Redactor for rules to the processor chain
+func (l *Logger) WithRedaction(rules RedactionRules) *Logger {
+	return l.WithProcessor(NewRedactor(rules))
+}
+
+// WithoutRedaction returns a logger that skips Redactor processors, for
+// components that must log the original values, e.g. in secured debug logs
+func (l *Logger) WithoutRedaction() *Logger {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	
+	clone := l.clone()
+	clone.redactionDisabled = true
+	return clone
+}
+
+// WithAuditSink writes audit entries of the returned logger and its derived
+// loggers to sink in addition to the output
+func (l *Logger) WithAuditSink(sink *AuditSink) *Logger {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	
+	clone := l.clone()
+	clone.auditSink = sink
+	return clone
+}
+
 // WithCaller enables caller information in log entries
 func (l *Logger) WithCaller(skip int) *Logger {
 	l.mutex.Lock()
@@ -303,36 +390,47 @@ func (l *Logger) StartTimer(operation string) *Timer {
 	return NewTimer(l, operation)
 }
 
-// IsLevelEnabled returns true if the given level is enabled
+// IsLevelEnabled returns true if the given level is enabled, taking
+// component level overrides into account
 func (l *Logger) IsLevelEnabled(level Level) bool {
 	l.mutex.RLock()
 	defer l.mutex.RUnlock()
 	
-	return level.ShouldLog(l.level)
+	return level.ShouldLog(l.effectiveLevel())
 }
 
 // GetLevel returns the current log level
 func (l *Logger) GetLevel() Level {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-	
-	return l.level
+	return l.level.Level()
 }
 
-// SetLevel sets the log level (not thread-safe, use WithLevel for safety)
+// SetLevel atomically changes the log level of this logger and of all
+// loggers derived from it, except those created with WithLevel
 func (l *Logger) SetLevel(level Level) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	
-	l.level = level
+	l.level.Set(level)
+}
+
+// effectiveLevel returns the component override for the logger name, or
+// the logger's own level
+func (l *Logger) effectiveLevel() Level {
+	if level, ok := componentLevel(l.name); ok {
+		return level
+	}
+	return l.level.Level()
 }
 
 // log is the internal logging method
 func (l *Logger) log(level Level, message string, err error, fields ...Fields) {
+	l.logEntry(level, message, err, nil, fields)
+}
+
+// logEntry creates and writes an entry, applying context information after
+// the logger's context fields and before the provided fields
+func (l *Logger) logEntry(level Level, message string, err error, info *ContextInfo, fields []Fields) {
 	l.mutex.RLock()
 	
 	// Check if level is enabled
-	if !level.ShouldLog(l.level) {
+	if !level.ShouldLog(l.effectiveLevel()) {
 		l.mutex.RUnlock()
 		return
 	}
@@ -349,6 +447,9 @@ func (l *Logger) log(level Level, message string, err error, fields ...Fields) {
 	for k, v := range l.contextFields {
 		entry.Fields[k] = v
 	}
+	if info != nil {
+		info.apply(entry)
+	}
 	
 	// Add provided fields
 	for _, fieldSet := range fields {
@@ -357,49 +458,59 @@ func (l *Logger) log(level Level, message string, err error, fields ...Fields) {
 		}
 	}
 	
-	// Add caller information if enabled
-	if l.enableCaller {
-		if function, file, line, ok := l.getCaller(); ok {
-			entry.WithCaller(function, file, line)
+	// Run the processor chain, e.g. redaction, before anything reads the entry
+	for _, processor := range l.processors {
+		if _, isRedactor := processor.(*Redactor); isRedactor && l.redactionDisabled {
+			continue
 		}
+		processor.Process(entry)
 	}
 	
-	// Check if async logging is enabled
-	if l.asyncEnabled && l.asyncBuffer != nil {
-		// Send to async buffer (non-blocking)
-		select {
-		case l.asyncBuffer <- entry:
-			// Successfully queued
-		default:
-			// Buffer full, fallback to synchronous logging
-			formatter := l.formatter
-			output := l.output
-			l.mutex.RUnlock()
-			
-			if formatted, formatErr := formatter.Format(entry); formatErr == nil {
-				output.Write(formatted)
-			}
-			return
+	// Apply sampling; summaries report entries suppressed in the last period
+	keep := true
+	var summaries []*Entry
+	if l.sampler != nil {
+		keep, summaries = l.sampler.Sample(entry)
+	}
+	
+	// Add caller information if enabled
+	if keep && l.enableCaller {
+		if function, file, line, ok := l.getCaller(); ok {
+			entry.WithCaller(function, file, line)
 		}
-		l.mutex.RUnlock()
-		return
 	}
 	
-	// Synchronous logging
+	// Async logging buffers the formatted entry in the AsyncWriter output
 	formatter := l.formatter
 	output := l.output
+	sinks := l.sinks
+	auditSink := l.auditSink
 	l.mutex.RUnlock()
 	
+	// Audit entries go to the audit sink first; failures are reported on the output
+	if level == LevelAudit && auditSink != nil {
+		if err := auditSink.Write(entry); err != nil {
+			failure := NewEntry(LevelError, "failed to write audit record")
+			failure.Logger = entry.Logger
+			failure.Error = err
+			failure.Fields["audit_message"] = entry.Message
+			dispatchEntry(formatter, output, sinks, failure)
+		}
+	}
+	
 	// Format and write the log entry
-	if formatted, formatErr := formatter.Format(entry); formatErr == nil {
-		output.Write(formatted)
+	for _, summary := range summaries {
+		dispatchEntry(formatter, output, sinks, summary)
+	}
+	if keep {
+		dispatchEntry(formatter, output, sinks, entry)
 	}
 }
 
 // getCaller returns caller information
 func (l *Logger) getCaller() (function, file string, line int, ok bool) {
-	// Skip frames: getCaller, log, public method, user code
-	skip := 3 + l.callerSkipFrames
+	// Skip frames: getCaller, logEntry, log or logCtx, public method, user code
+	skip := 4 + l.callerSkipFrames
 	
 	pc, file, line, ok := runtime.Caller(skip)
 	if !ok {
@@ -435,6 +546,13 @@ func (l *Logger) clone() *Logger {
 		correlationID:    l.correlationID,
 		enableCaller:     l.enableCaller,
 		callerSkipFrames: l.callerSkipFrames,
+		asyncEnabled:     l.asyncEnabled,
+		sinks:            l.sinks,
+		asyncWriter:      l.asyncWriter,
+		sampler:          l.sampler,
+		processors:       l.processors,
+		redactionDisabled: l.redactionDisabled,
+		auditSink:        l.auditSink,
 		contextFields:    make(Fields),
 		mutex:           sync.RWMutex{},
 	}
@@ -447,53 +565,48 @@ func (l *Logger) clone() *Logger {
 	return clone
 }
 
-// startAsyncWorker starts the background goroutine for async logging
-func (l *Logger) startAsyncWorker() {
-	l.asyncOnce.Do(func() {
-		go l.asyncWorker()
-	})
-}
-
-// asyncWorker processes log entries from the async buffer
-func (l *Logger) asyncWorker() {
-	for {
-		select {
-		case entry := <-l.asyncBuffer:
-			// Process the log entry synchronously in the worker
-			l.mutex.RLock()
-			formatter := l.formatter
-			output := l.output
-			l.mutex.RUnlock()
-			
-			if formatted, formatErr := formatter.Format(entry); formatErr == nil {
-				output.Write(formatted)
-			}
-			
-		case <-l.asyncDone:
-			// Drain remaining entries before shutting down
-			for {
-				select {
-				case entry := <-l.asyncBuffer:
-					l.mutex.RLock()
-					formatter := l.formatter
-					output := l.output
-					l.mutex.RUnlock()
-					
-					if formatted, formatErr := formatter.Format(entry); formatErr == nil {
-						output.Write(formatted)
-					}
-				default:
-					return
-				}
-			}
+// Flush writes sampling summaries of suppressed entries and blocks until
+// buffered async entries are written
+func (l *Logger) Flush() {
+	l.flushSampler()
+	if l.asyncWriter != nil {
+		l.asyncWriter.Flush()
+	}
+	for _, sink := range l.sinks {
+		if sink.asyncWriter != nil {
+			sink.asyncWriter.Flush()
 		}
 	}
 }
 
-// Close gracefully shuts down async logging and flushes remaining entries
+// Close gracefully shuts down async logging and writes sampling summaries
+// and all buffered entries before returning. Entries logged afterwards are
+// written synchronously. Outputs and sinks are not closed.
 func (l *Logger) Close() {
-	if l.asyncEnabled && l.asyncDone != nil {
-		close(l.asyncDone)
+	l.flushSampler()
+	if l.asyncWriter != nil {
+		l.asyncWriter.Close()
+	}
+	for _, sink := range l.sinks {
+		if sink.asyncWriter != nil {
+			sink.asyncWriter.Close()
+		}
+	}
+}
+
+// flushSampler writes the pending sampling summaries
+func (l *Logger) flushSampler() {
+	if l.sampler == nil {
+		return
+	}
+	l.mutex.RLock()
+	formatter := l.formatter
+	output := l.output
+	sinks := l.sinks
+	l.mutex.RUnlock()
+	
+	for _, summary := range l.sampler.Flush() {
+		dispatchEntry(formatter, output, sinks, summary)
 	}
 }
 
@@ -0,0 +1,179 @@
+// File: redact.go
+// Title: Entry Processors and Sensitive Data Redaction
+// Description: Implements the entry processor chain that runs before output and
+//              a redaction processor that scrubs sensitive values by field
+//              name (password, token, ...) and by pattern (emails, IBANs) to
+//              meet the audit and compliance requirements.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of processors and redaction
+
+package log
+
+import (
+	"regexp"
+	"strings"
+
+	mdwconfig "github.com/msto63/mDW/foundation/core/config"
+)
+
+// ===============================
+// Processors
+// ===============================
+
+// Processor modifies log entries before they are sampled and written.
+// Processors run in order on a fresh entry and may change it in place, but
+// must copy nested values such as maps before modifying them.
+type Processor interface {
+	Process(entry *Entry)
+}
+
+// ProcessorFunc adapts a function to a Processor
+type ProcessorFunc func(entry *Entry)
+
+// Process calls f(entry)
+func (f ProcessorFunc) Process(entry *Entry) {
+	f(entry)
+}
+
+// ===============================
+// Redaction
+// ===============================
+
+var (
+	// EmailPattern matches email addresses
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// IBANPattern matches IBANs, with or without grouping spaces
+	IBANPattern = regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`)
+)
+
+// RedactionRules configures a Redactor
+type RedactionRules struct {
+	Fields      []string         // Field names whose values are replaced entirely (case-insensitive)
+	Patterns    []*regexp.Regexp // Patterns replaced within message and string field values
+	Replacement string           // Replacement text (default: [REDACTED])
+}
+
+// DefaultRedactionRules returns rules for credentials, emails, and IBANs
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		Fields: []string{
+			"password", "passwd", "secret", "token", "api_key", "apikey",
+			"access_token", "refresh_token", "authorization", "cookie",
+			"private_key", "credit_card", "iban",
+		},
+		Patterns:    []*regexp.Regexp{EmailPattern, IBANPattern},
+		Replacement: mdwconfig.RedactedValue,
+	}
+}
+
+// Redactor is a Processor that scrubs sensitive values from entries. A field
+// matches a rule name if its lowercased name equals the name or ends with it
+// after a separator, e.g. "password" matches "db_password" and
+// "user.password". Error values are not modified.
+type Redactor struct {
+	fields      map[string]bool
+	patterns    []*regexp.Regexp
+	replacement string
+}
+
+// NewRedactor creates a redaction processor for rules
+func NewRedactor(rules RedactionRules) *Redactor {
+	r := &Redactor{
+		fields:      make(map[string]bool, len(rules.Fields)),
+		patterns:    rules.Patterns,
+		replacement: rules.Replacement,
+	}
+	if r.replacement == "" {
+		r.replacement = mdwconfig.RedactedValue
+	}
+	for _, name := range rules.Fields {
+		r.fields[strings.ToLower(name)] = true
+	}
+	return r
+}
+
+// Process redacts the message and fields of entry
+func (r *Redactor) Process(entry *Entry) {
+	entry.Message = r.redactString(entry.Message)
+	for key, value := range entry.Fields {
+		entry.Fields[key] = r.redactField(key, value)
+	}
+}
+
+// redactField returns the redacted value of the field key
+func (r *Redactor) redactField(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if r.isSensitive(key) {
+		return r.replacement
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.redactString(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = r.redactString(s)
+		}
+		return redacted
+	case Fields:
+		return Fields(r.redactMap(v))
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, s := range v {
+			if r.isSensitive(k) {
+				redacted[k] = r.replacement
+			} else {
+				redacted[k] = r.redactString(s)
+			}
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// redactMap returns a redacted copy of a nested map
+func (r *Redactor) redactMap(m map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		redacted[k] = r.redactField(k, v)
+	}
+	return redacted
+}
+
+// redactString replaces all pattern matches in s
+func (r *Redactor) redactString(s string) string {
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllLiteralString(s, r.replacement)
+	}
+	return s
+}
+
+// isSensitive reports whether a field name matches a redaction rule
+func (r *Redactor) isSensitive(key string) bool {
+	if len(r.fields) == 0 {
+		return false
+	}
+	key = strings.ToLower(key)
+	for {
+		if r.fields[key] {
+			return true
+		}
+		idx := strings.IndexAny(key, "_.-")
+		if idx < 0 {
+			return false
+		}
+		key = key[idx+1:]
+	}
+}
@@ -0,0 +1,119 @@
+// File: redact_test.go
+// Title: Sensitive Data Redaction Tests
+// Description: Tests redaction by field name and pattern, the processor
+//              chain, and the per-logger redaction opt-out.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial redaction tests
+
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Process(t *testing.T) {
+	redactor := NewRedactor(DefaultRedactionRules())
+	nested := map[string]interface{}{"api_key": "k-123", "host": "db"}
+	entry := NewEntry(LevelInfo, "mail sent to jane.doe@example.com")
+	entry.Fields = Fields{
+		"password":    "hunter2",
+		"DB_Password": "hunter3",
+		"user.token":  "abc",
+		"tokens_used": 42,
+		"recipient":   "max@example.org",
+		"account":     "payment to DE89 3704 0044 0532 0130 00 done",
+		"account_raw": "DE89370400440532013000",
+		"headers":     map[string]string{"Authorization": "Bearer x", "Accept": "text/plain"},
+		"connection":  nested,
+		"count":       3,
+	}
+
+	redactor.Process(entry)
+
+	if entry.Message != "mail sent to [REDACTED]" {
+		t.Errorf("Message = %q", entry.Message)
+	}
+	expected := map[string]interface{}{
+		"password":    "[REDACTED]",
+		"DB_Password": "[REDACTED]",
+		"user.token":  "[REDACTED]",
+		"tokens_used": 42,
+		"recipient":   "[REDACTED]",
+		"account":     "payment to [REDACTED] done",
+		"account_raw": "[REDACTED]",
+		"count":       3,
+	}
+	for key, want := range expected {
+		if entry.Fields[key] != want {
+			t.Errorf("Fields[%q] = %v, want %v", key, entry.Fields[key], want)
+		}
+	}
+	if headers := entry.Fields["headers"].(map[string]string); headers["Authorization"] != "[REDACTED]" || headers["Accept"] != "text/plain" {
+		t.Errorf("headers = %v", headers)
+	}
+	if connection := entry.Fields["connection"].(map[string]interface{}); connection["api_key"] != "[REDACTED]" || connection["host"] != "db" {
+		t.Errorf("connection = %v", connection)
+	}
+	if nested["api_key"] != "k-123" {
+		t.Error("nested map of the caller was modified")
+	}
+}
+
+func TestRedactor_CustomRules(t *testing.T) {
+	redactor := NewRedactor(RedactionRules{Fields: []string{"pin"}, Replacement: "***"})
+	entry := NewEntry(LevelInfo, "contact admin@example.com")
+	entry.Fields = Fields{"card_pin": "1234", "pinned": "yes"}
+
+	redactor.Process(entry)
+	if entry.Fields["card_pin"] != "***" || entry.Fields["pinned"] != "yes" {
+		t.Errorf("Fields = %v", entry.Fields)
+	}
+	if entry.Message != "contact admin@example.com" {
+		t.Errorf("Message = %q, want no pattern redaction", entry.Message)
+	}
+}
+
+func TestLogger_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Level:      LevelInfo,
+		Format:     FormatText,
+		Output:     &buf,
+		Processors: []Processor{NewRedactor(DefaultRedactionRules())},
+	}).WithField("session_token", "s-1")
+
+	logger.Info("login", Fields{"password": "hunter2", "user": "jane"})
+	logger.Audit("password changed", Fields{"email": "jane@example.com"})
+	output := buf.String()
+	if strings.Contains(output, "hunter2") || strings.Contains(output, "s-1") || strings.Contains(output, "jane@example.com") {
+		t.Errorf("sensitive values in output: %q", output)
+	}
+	if !strings.Contains(output, "jane") {
+		t.Errorf("non-sensitive values missing from output: %q", output)
+	}
+
+	// Opt-out skips redactors only
+	buf.Reset()
+	tagged := 0
+	raw := logger.WithoutRedaction().WithProcessor(ProcessorFunc(func(entry *Entry) {
+		tagged++
+		entry.Fields["tagged"] = true
+	}))
+	raw.Info("debug", Fields{"password": "hunter2"})
+	if !strings.Contains(buf.String(), "hunter2") || tagged != 1 {
+		t.Errorf("opt-out output = %q, processor calls = %d", buf.String(), tagged)
+	}
+
+	buf.Reset()
+	logger.Info("still redacted", Fields{"password": "hunter2"})
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Error("opt-out affected the parent logger")
+	}
+}
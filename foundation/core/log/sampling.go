@@ -0,0 +1,204 @@
+// File: sampling.go
+// Title: Log Sampling and Rate Limiting
+// Description: Implements per-message-key sampling that logs the first
+//              entries of each period and every Nth entry thereafter, and
+//              reports suppressed counts in summary entries to keep retry
+//              loops from flooding the logs.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of log sampling
+// - 2026-10-16 v0.1.1: Bound the tracked keys; evicted keys report their summaries
+
+package log
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SampledSummaryMessage is the message of summary entries reporting
+// suppressed entries
+const SampledSummaryMessage = "log entries suppressed by sampling"
+
+// maxSampleKeys bounds the number of tracked keys. When it is reached,
+// expired keys and then the oldest ones are evicted until a tenth is free.
+const maxSampleKeys = 10000
+
+// SamplingOptions configures a Sampler. Within each period, the first
+// Initial entries of a key are logged, then every Thereafter-th entry.
+type SamplingOptions struct {
+	Initial    int                 // Entries per key and period logged unconditionally (default: 10)
+	Thereafter int                 // Log every Nth entry after Initial; 0 suppresses all (default: 100)
+	Period     time.Duration       // Sampling period (default: 1s)
+	Key        func(*Entry) string // Message key of an entry (default: level and message)
+}
+
+// DefaultSamplingOptions returns the default sampling options: at most 10
+// entries per message and second, then every 100th
+func DefaultSamplingOptions() SamplingOptions {
+	return SamplingOptions{
+		Initial:    10,
+		Thereafter: 100,
+		Period:     time.Second,
+	}
+}
+
+// Sampler decides which entries of high-volume messages are logged. Audit
+// entries are never sampled. It is safe for concurrent use.
+type Sampler struct {
+	options SamplingOptions
+
+	mu       sync.Mutex
+	counters map[string]*sampleCounter
+}
+
+// sampleCounter tracks one message key in the current period
+type sampleCounter struct {
+	periodStart time.Time
+	count       int
+	suppressed  int
+	level       Level
+	message     string
+	logger      string
+}
+
+// NewSampler creates a sampler; zero Initial and Period take the defaults
+func NewSampler(options SamplingOptions) *Sampler {
+	defaults := DefaultSamplingOptions()
+	if options.Initial <= 0 {
+		options.Initial = defaults.Initial
+	}
+	if options.Thereafter < 0 {
+		options.Thereafter = 0
+	}
+	if options.Period <= 0 {
+		options.Period = defaults.Period
+	}
+	return &Sampler{
+		options:  options,
+		counters: make(map[string]*sampleCounter),
+	}
+}
+
+// Sample reports whether entry should be logged. If the previous period of
+// the entry's key suppressed entries, or keys with suppressed entries were
+// evicted to make room for it, it also returns summary entries to be logged
+// first.
+func (s *Sampler) Sample(entry *Entry) (bool, []*Entry) {
+	if entry.Level == LevelAudit {
+		return true, nil
+	}
+	key := entry.Level.String() + "|" + entry.Message
+	if s.options.Key != nil {
+		key = s.options.Key(entry)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summaries []*Entry
+	counter, exists := s.counters[key]
+	if !exists {
+		if len(s.counters) >= maxSampleKeys {
+			summaries = s.evict(entry.Timestamp)
+		}
+		counter = &sampleCounter{periodStart: entry.Timestamp}
+		s.counters[key] = counter
+	} else if entry.Timestamp.Sub(counter.periodStart) >= s.options.Period {
+		if summary := counter.summary(); summary != nil {
+			summaries = append(summaries, summary)
+		}
+		counter.periodStart = entry.Timestamp
+		counter.count = 0
+	}
+	counter.level = entry.Level
+	counter.message = entry.Message
+	counter.logger = entry.Logger
+
+	counter.count++
+	if counter.count <= s.options.Initial {
+		return true, summaries
+	}
+	if s.options.Thereafter > 0 && (counter.count-s.options.Initial)%s.options.Thereafter == 0 {
+		return true, summaries
+	}
+	counter.suppressed++
+	return false, summaries
+}
+
+// Flush returns summary entries for all keys with suppressed entries, sorted
+// by message, and resets their suppressed counts
+func (s *Sampler) Flush() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var summaries []*Entry
+	for _, counter := range s.counters {
+		if summary := counter.summary(); summary != nil {
+			summaries = append(summaries, summary)
+		}
+	}
+	sortSummaries(summaries)
+	return summaries
+}
+
+// sortSummaries sorts summary entries by message
+func sortSummaries(summaries []*Entry) {
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Fields["sampled_message"].(string) < summaries[j].Fields["sampled_message"].(string)
+	})
+}
+
+// summary returns the summary entry for suppressed entries and resets the
+// count, or nil if none were suppressed
+func (c *sampleCounter) summary() *Entry {
+	if c.suppressed == 0 {
+		return nil
+	}
+	summary := NewEntry(c.level, SampledSummaryMessage)
+	summary.Logger = c.logger
+	summary.Fields["sampled_message"] = c.message
+	summary.Fields["suppressed"] = c.suppressed
+	summary.Fields["since"] = c.periodStart
+	c.suppressed = 0
+	return summary
+}
+
+// evict removes the keys whose period ended, then the oldest keys until a
+// tenth of maxSampleKeys is free, and returns the summaries of their
+// suppressed entries sorted by message
+func (s *Sampler) evict(now time.Time) []*Entry {
+	var summaries []*Entry
+	remove := func(key string) {
+		if summary := s.counters[key].summary(); summary != nil {
+			summaries = append(summaries, summary)
+		}
+		delete(s.counters, key)
+	}
+
+	for key, counter := range s.counters {
+		if now.Sub(counter.periodStart) >= s.options.Period {
+			remove(key)
+		}
+	}
+	if excess := len(s.counters) - (maxSampleKeys - maxSampleKeys/10); excess > 0 {
+		keys := make([]string, 0, len(s.counters))
+		for key := range s.counters {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return s.counters[keys[i]].periodStart.Before(s.counters[keys[j]].periodStart)
+		})
+		for _, key := range keys[:excess] {
+			remove(key)
+		}
+	}
+
+	sortSummaries(summaries)
+	return summaries
+}
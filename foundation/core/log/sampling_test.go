@@ -0,0 +1,157 @@
+// File: sampling_test.go
+// Title: Log Sampling Tests
+// Description: Tests per-key sampling decisions, summary entries for
+//              suppressed entries, and sampling in the logger.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial log sampling tests
+// - 2026-10-16 v0.1.1: Added key eviction test
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampler_Sample(t *testing.T) {
+	sampler := NewSampler(SamplingOptions{Initial: 2, Thereafter: 3, Period: time.Second})
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	entryAt := func(message string, offset time.Duration) *Entry {
+		entry := NewEntry(LevelError, message)
+		entry.Timestamp = start.Add(offset)
+		return entry
+	}
+
+	// Initial 2, then every 3rd: entries 1, 2, 5, 8 of 8 are kept
+	var kept []int
+	for i := 1; i <= 8; i++ {
+		keep, summaries := sampler.Sample(entryAt("retry failed", time.Duration(i)*time.Millisecond))
+		if len(summaries) != 0 {
+			t.Fatalf("unexpected summary in first period: %v", summaries[0].Fields)
+		}
+		if keep {
+			kept = append(kept, i)
+		}
+	}
+	if len(kept) != 4 || kept[2] != 5 || kept[3] != 8 {
+		t.Errorf("kept entries = %v, want [1 2 5 8]", kept)
+	}
+
+	// Other keys are sampled independently
+	if keep, _ := sampler.Sample(entryAt("other", 0)); !keep {
+		t.Error("first entry of another key was suppressed")
+	}
+
+	// The first entry of the next period reports the suppressed count
+	keep, summaries := sampler.Sample(entryAt("retry failed", 2*time.Second))
+	if !keep || len(summaries) != 1 {
+		t.Fatalf("Sample() next period = %v, %v", keep, summaries)
+	}
+	summary := summaries[0]
+	if summary.Message != SampledSummaryMessage || summary.Fields["sampled_message"] != "retry failed" || summary.Fields["suppressed"] != 4 {
+		t.Errorf("summary = %q %v", summary.Message, summary.Fields)
+	}
+
+	// Audit entries are never sampled
+	for i := 0; i < 10; i++ {
+		if keep, _ := sampler.Sample(NewEntry(LevelAudit, "user login")); !keep {
+			t.Fatal("audit entry was suppressed")
+		}
+	}
+}
+
+func TestSampler_Flush(t *testing.T) {
+	sampler := NewSampler(SamplingOptions{Initial: 1, Period: time.Hour})
+	for _, message := range []string{"b", "a", "b", "a", "a"} {
+		sampler.Sample(NewEntry(LevelWarn, message))
+	}
+
+	summaries := sampler.Flush()
+	if len(summaries) != 2 {
+		t.Fatalf("Flush() = %d summaries, want 2", len(summaries))
+	}
+	if summaries[0].Fields["sampled_message"] != "a" || summaries[0].Fields["suppressed"] != 2 {
+		t.Errorf("summaries[0] = %v", summaries[0].Fields)
+	}
+	if len(sampler.Flush()) != 0 {
+		t.Error("second Flush() returned summaries")
+	}
+}
+
+func TestSampler_EvictsKeys(t *testing.T) {
+	sampler := NewSampler(SamplingOptions{Initial: 1, Thereafter: 0, Period: time.Hour})
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// Every key suppresses its second entry, so no key expires unreported
+	keys := maxSampleKeys + maxSampleKeys/2
+	reported := 0
+	for i := 0; i < keys; i++ {
+		for j := 0; j < 2; j++ {
+			entry := NewEntry(LevelWarn, fmt.Sprintf("request %d failed", i))
+			entry.Timestamp = start.Add(time.Duration(i) * time.Millisecond)
+			_, summaries := sampler.Sample(entry)
+			for _, summary := range summaries {
+				reported += summary.Fields["suppressed"].(int)
+			}
+		}
+		if len(sampler.counters) > maxSampleKeys {
+			t.Fatalf("tracked keys = %d after %d keys, want at most %d", len(sampler.counters), i+1, maxSampleKeys)
+		}
+	}
+
+	// Evicted keys reported their suppressed entries; Flush reports the rest
+	for _, summary := range sampler.Flush() {
+		reported += summary.Fields["suppressed"].(int)
+	}
+	if reported != keys {
+		t.Errorf("reported suppressed entries = %d, want %d", reported, keys)
+	}
+}
+
+func TestLogger_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithConfig(Config{
+		Level:    LevelInfo,
+		Format:   FormatText,
+		Output:   &buf,
+		Sampling: &SamplingOptions{Initial: 3, Period: time.Hour},
+	})
+
+	for i := 0; i < 100; i++ {
+		logger.Error("connection refused")
+	}
+	logger.Audit("config changed", nil)
+	if count := strings.Count(buf.String(), "connection refused"); count != 3 {
+		t.Errorf("logged %d entries, want 3", count)
+	}
+
+	logger.Flush()
+	output := buf.String()
+	if !strings.Contains(output, SampledSummaryMessage) || !strings.Contains(output, "suppressed=97") {
+		t.Errorf("summary missing from output: %q", output)
+	}
+	if !strings.Contains(output, "config changed") {
+		t.Error("audit entry missing from output")
+	}
+
+	// WithSampling applies to a derived logger only
+	buf.Reset()
+	base := NewWithConfig(Config{Level: LevelInfo, Format: FormatText, Output: &buf})
+	sampled := base.WithSampling(SamplingOptions{Initial: 1, Period: time.Hour})
+	for i := 0; i < 5; i++ {
+		base.Info("unsampled")
+		sampled.Info("sampled")
+	}
+	if strings.Count(buf.String(), "] unsampled") != 5 || strings.Count(buf.String(), "] sampled") != 1 {
+		t.Errorf("output = %q", buf.String())
+	}
+}
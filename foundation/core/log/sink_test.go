@@ -0,0 +1,171 @@
+// File: sink_test.go
+// Title: Multi-Sink Fan-Out Tests
+// Description: Tests per-sink level and format filtering, async sinks, and
+//              declarative sink configuration.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial multi-sink fan-out tests
+
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mdwconfig "github.com/msto63/mDW/foundation/core/config"
+)
+
+func TestLogger_Sinks(t *testing.T) {
+	var console, file, remote bytes.Buffer
+	sinks := []Sink{
+		{Name: "console", Output: &console, Format: FormatText, Level: LevelInfo},
+		{Name: "file", Output: &file, Format: FormatJSON, Level: LevelDebug},
+		{Name: "remote", Output: &remote, Format: FormatJSON, Level: LevelWarn},
+	}
+	if LowestLevel(sinks) != LevelDebug {
+		t.Fatalf("LowestLevel() = %v", LowestLevel(sinks))
+	}
+	logger := NewWithConfig(Config{Level: LowestLevel(sinks), Sinks: sinks})
+
+	logger.Trace("trace entry")
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Audit("audit entry")
+
+	tests := []struct {
+		name     string
+		output   string
+		contains []string
+		excludes []string
+	}{
+		{"console", console.String(), []string{"[INF] info entry", "warn entry", "audit entry"}, []string{"debug entry", "{"}},
+		{"file", file.String(), []string{`"message":"debug entry"`, "info entry", "audit entry"}, []string{"trace entry"}},
+		{"remote", remote.String(), []string{"warn entry", "audit entry"}, []string{"info entry"}},
+	}
+	for _, tt := range tests {
+		for _, want := range tt.contains {
+			if !strings.Contains(tt.output, want) {
+				t.Errorf("%s output missing %q: %q", tt.name, want, tt.output)
+			}
+		}
+		for _, unwanted := range tt.excludes {
+			if strings.Contains(tt.output, unwanted) {
+				t.Errorf("%s output contains %q: %q", tt.name, unwanted, tt.output)
+			}
+		}
+	}
+
+	// WithOutput replaces the sinks
+	var single bytes.Buffer
+	logger.WithOutput(&single).Info("single output")
+	if !strings.Contains(single.String(), "single output") || strings.Contains(console.String(), "single output") {
+		t.Errorf("WithOutput() did not replace sinks")
+	}
+}
+
+func TestLogger_AsyncSinks(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := NewWithConfig(Config{
+		Level:        LevelDebug,
+		AsyncEnabled: true,
+		Sinks: []Sink{
+			{Output: &console, Format: FormatText, Level: LevelInfo},
+			{Output: &file, Format: FormatText, Level: LevelDebug},
+		},
+	})
+	for i := 0; i < 50; i++ {
+		logger.Debug("debug entry")
+		logger.Info("info entry")
+	}
+	logger.Close()
+
+	if strings.Count(console.String(), "\n") != 50 || strings.Count(file.String(), "\n") != 100 {
+		t.Errorf("console lines = %d, file lines = %d", strings.Count(console.String(), "\n"), strings.Count(file.String(), "\n"))
+	}
+}
+
+func TestSinksFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := mdwconfig.LoadFromString(`
+[logging.sinks.console]
+type = "stderr"
+format = "text"
+level = "info"
+
+[logging.sinks.file]
+format = "json"
+level = "debug"
+path = "`+filepath.ToSlash(filepath.Join(dir, "service.log"))+`"
+
+[logging.sinks.remote]
+type = "test"
+level = "warn"
+`, mdwconfig.FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	var remote bytes.Buffer
+	RegisterSinkType("test", func(cfg *mdwconfig.Config, key string) (io.Writer, error) {
+		return &remote, nil
+	})
+	defer RegisterSinkType("test", nil)
+
+	sinks, err := SinksFromConfig(cfg, "logging.sinks")
+	if err != nil {
+		t.Fatalf("SinksFromConfig() error = %v", err)
+	}
+	defer func() {
+		for _, sink := range sinks {
+			sink.Close()
+		}
+	}()
+
+	if len(sinks) != 3 {
+		t.Fatalf("SinksFromConfig() = %d sinks, want 3", len(sinks))
+	}
+	if sinks[0].Name != "console" || sinks[0].Output != os.Stderr || sinks[0].Format != FormatText || sinks[0].Level != LevelInfo {
+		t.Errorf("console sink = %+v", sinks[0])
+	}
+	if sinks[1].Name != "file" || sinks[1].Format != FormatJSON || sinks[1].Level != LevelDebug {
+		t.Errorf("file sink = %+v", sinks[1])
+	}
+	if sinks[2].Output != &remote || sinks[2].Level != LevelWarn {
+		t.Errorf("remote sink = %+v", sinks[2])
+	}
+	if _, err := os.Stat(filepath.Join(dir, "service.log")); err != nil {
+		t.Errorf("log file not created: %v", err)
+	}
+}
+
+func TestSinksFromConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"unknown type", "[sinks.a]\ntype = \"carrier-pigeon\""},
+		{"invalid format", "[sinks.console]\nformat = \"xml\""},
+		{"invalid level", "[sinks.console]\nlevel = \"loud\""},
+		{"file without path", "[sinks.file]\nlevel = \"info\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := mdwconfig.LoadFromString(tt.config, mdwconfig.FormatTOML)
+			if err != nil {
+				t.Fatalf("LoadFromString() error = %v", err)
+			}
+			if _, err := SinksFromConfig(cfg, "sinks"); err == nil {
+				t.Error("SinksFromConfig() succeeded")
+			}
+		})
+	}
+}
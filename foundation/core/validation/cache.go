@@ -0,0 +1,234 @@
+// File: cache.go
+// Title: Validation Result Caching
+// Description: Provides a memoizing validator decorator for expensive
+//              validators such as regex-heavy checks or external lookups.
+//              Results are cached per value key with a time-to-live and a
+//              bounded number of entries; hit and miss counters expose the
+//              cache effectiveness.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of cached validators
+
+package validation
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheMaxEntries is the default number of results kept by a cached validator
+const DefaultCacheMaxEntries = 10000
+
+// CacheKeyFunc derives the cache key for a value. Returning ok = false
+// bypasses the cache for that value.
+type CacheKeyFunc func(value interface{}) (key string, ok bool)
+
+// DefaultCacheKey caches strings, booleans, and numeric values by type and
+// value. Other values are not cached.
+func DefaultCacheKey(value interface{}) (string, bool) {
+	switch value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%T:%v", value, value), true
+	}
+	return "", false
+}
+
+// CacheStats holds the counters of a cached validator
+type CacheStats struct {
+	Hits      uint64 // Results served from the cache
+	Misses    uint64 // Results computed by the wrapped validator
+	Evictions uint64 // Entries removed because the cache was full
+	Entries   int    // Entries currently cached
+}
+
+// HitRatio returns the share of cache hits among all cacheable lookups
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// cacheEntry is a cached result with its expiry time
+type cacheEntry struct {
+	key     string
+	result  ValidationResult
+	expires time.Time
+}
+
+// CachedValidator memoizes the results of a wrapped validator
+type CachedValidator struct {
+	validator   Validator
+	keyFn       CacheKeyFunc
+	ttl         time.Duration
+	maxEntries  int
+	shouldCache func(ValidationResult) bool
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Least recently used at the back
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// Cached wraps a validator so that results for identical values are reused
+// for ttl (ttl <= 0 caches until evicted). A nil keyFn uses DefaultCacheKey.
+func Cached(validator Validator, keyFn CacheKeyFunc, ttl time.Duration) *CachedValidator {
+	if keyFn == nil {
+		keyFn = DefaultCacheKey
+	}
+	return &CachedValidator{
+		validator:  validator,
+		keyFn:      keyFn,
+		ttl:        ttl,
+		maxEntries: DefaultCacheMaxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// WithMaxEntries limits the number of cached results; the least recently
+// used entry is evicted when the limit is reached
+func (c *CachedValidator) WithMaxEntries(n int) *CachedValidator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxEntries = n
+	return c
+}
+
+// CacheIf restricts caching to results accepted by predicate, e.g. to skip
+// results of failed external lookups
+func (c *CachedValidator) CacheIf(predicate func(ValidationResult) bool) *CachedValidator {
+	c.shouldCache = predicate
+	return c
+}
+
+// Validate returns the cached result for value or validates it
+func (c *CachedValidator) Validate(value interface{}) ValidationResult {
+	return c.ValidateWithContext(context.Background(), value)
+}
+
+// ValidateWithContext returns the cached result for value or validates it
+// with context. Cached results carry no request-specific context.
+func (c *CachedValidator) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	key, ok := c.keyFn(value)
+	if !ok {
+		return c.validator.ValidateWithContext(ctx, value)
+	}
+
+	if result, found := c.lookup(key); found {
+		c.hits.Add(1)
+		result.WithContext("cached", true)
+		return result
+	}
+
+	c.misses.Add(1)
+	result := c.validator.ValidateWithContext(ctx, value)
+	if c.shouldCache == nil || c.shouldCache(result) {
+		c.store(key, result)
+	}
+	return result
+}
+
+// lookup returns a copy of the cached result for key
+func (c *CachedValidator) lookup(key string) (ValidationResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return ValidationResult{}, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return ValidationResult{}, false
+	}
+	c.order.MoveToFront(element)
+	return copyResult(entry.result), true
+}
+
+// store caches a copy of result under key
+func (c *CachedValidator) store(key string, result ValidationResult) {
+	entry := &cacheEntry{key: key, result: copyResult(result)}
+	entry.result.Context = nil
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	for c.maxEntries > 0 && c.order.Len() >= c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.evictions.Add(1)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+}
+
+// Stats returns the current cache counters
+func (c *CachedValidator) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.order.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+	}
+}
+
+// Clear removes all cached results; counters are kept
+func (c *CachedValidator) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// String returns a string representation of the cached validator
+func (c *CachedValidator) String() string {
+	stats := c.Stats()
+	return fmt.Sprintf("CachedValidator{ttl: %v, entries: %d, hits: %d, misses: %d}",
+		c.ttl, stats.Entries, stats.Hits, stats.Misses)
+}
+
+// copyResult returns a copy of result that shares no slices or maps with it,
+// so callers may modify returned findings without corrupting the cache
+func copyResult(result ValidationResult) ValidationResult {
+	if result.Errors != nil {
+		result.Errors = append([]ValidationError(nil), result.Errors...)
+	}
+	if result.Warnings != nil {
+		result.Warnings = append([]ValidationError(nil), result.Warnings...)
+	}
+	if result.Context != nil {
+		context := make(map[string]interface{}, len(result.Context))
+		for key, value := range result.Context {
+			context[key] = value
+		}
+		result.Context = context
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+// File: cache_test.go
+// Title: Validation Result Caching Tests
+// Description: Tests cached validators covering hits and misses, expiry,
+//              eviction, custom keys, cache predicates, result isolation,
+//              and concurrent use.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial cached validator tests
+
+package validation
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingValidator counts calls and rejects values containing "bad"
+func countingValidator(calls *int64) ValidatorFunc {
+	return func(value interface{}) ValidationResult {
+		atomic.AddInt64(calls, 1)
+		if s, ok := value.(string); ok && strings.Contains(s, "bad") {
+			return NewValidationError(CodeFormat, "bad value")
+		}
+		return NewValidationResult()
+	}
+}
+
+func TestCached(t *testing.T) {
+	var calls int64
+	cached := Cached(countingValidator(&calls), nil, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if !cached.Validate("good").Valid || cached.Validate("bad").Valid {
+			t.Fatal("cached validator returned wrong result")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("wrapped validator called %d times, want 2", calls)
+	}
+
+	stats := cached.Stats()
+	if stats.Hits != 4 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if ratio := stats.HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("HitRatio() = %v", ratio)
+	}
+	if result := cached.Validate("good"); result.Context["cached"] != true {
+		t.Errorf("cache hit context = %v", result.Context)
+	}
+
+	// Values without a key bypass the cache
+	cached.Validate([]string{"bad"})
+	cached.Validate([]string{"bad"})
+	if calls != 4 {
+		t.Errorf("uncacheable values called validator %d times, want 4 total", calls)
+	}
+
+	cached.Clear()
+	cached.Validate("good")
+	if calls != 5 || cached.Stats().Hits != 5 {
+		t.Errorf("after Clear() calls = %d, stats = %+v", calls, cached.Stats())
+	}
+}
+
+func TestCached_ExpiryAndEviction(t *testing.T) {
+	var calls int64
+	expiring := Cached(countingValidator(&calls), nil, 20*time.Millisecond)
+	expiring.Validate("a")
+	time.Sleep(40 * time.Millisecond)
+	expiring.Validate("a")
+	if calls != 2 {
+		t.Errorf("expired entry reused: calls = %d", calls)
+	}
+
+	calls = 0
+	bounded := Cached(countingValidator(&calls), nil, 0).WithMaxEntries(2)
+	bounded.Validate("a")
+	bounded.Validate("b")
+	bounded.Validate("a") // "b" is now least recently used
+	bounded.Validate("c")
+	bounded.Validate("a")
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if stats := bounded.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	bounded.Validate("b")
+	if calls != 4 {
+		t.Error("evicted entry was still cached")
+	}
+}
+
+func TestCached_KeyAndPredicate(t *testing.T) {
+	var calls int64
+	caseInsensitive := func(value interface{}) (string, bool) {
+		s, ok := value.(string)
+		return strings.ToLower(s), ok
+	}
+	cached := Cached(countingValidator(&calls), caseInsensitive, time.Minute).
+		CacheIf(func(result ValidationResult) bool { return result.Valid })
+
+	cached.Validate("Good")
+	cached.Validate("GOOD")
+	cached.Validate("bad")
+	cached.Validate("bad")
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (invalid results not cached)", calls)
+	}
+}
+
+func TestCached_ResultIsolation(t *testing.T) {
+	var calls int64
+	cached := Cached(countingValidator(&calls), nil, time.Minute)
+
+	first := cached.Validate("bad")
+	first.Errors[0].Field = "email"
+	first.WithContext("requestId", "r1")
+
+	second := cached.Validate("bad")
+	if second.Errors[0].Field != "" || second.Context["requestId"] != nil {
+		t.Errorf("cached result was modified by caller: %+v", second)
+	}
+}
+
+func TestCached_Concurrent(t *testing.T) {
+	var calls int64
+	cached := Cached(countingValidator(&calls), nil, time.Minute).WithMaxEntries(10)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				cached.Validate(i % 20)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	stats := cached.Stats()
+	if stats.Hits+stats.Misses != 1600 || stats.Entries > 10 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
@@ -4,18 +4,22 @@
 //              validation rules into a single validator. Supports fluent API for
 //              building complex validation pipelines with proper error handling.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.3
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial validator chain implementation
+// - 2026-10-16 v0.1.1: Added warnings-as-errors policy
+// - 2026-10-16 v0.1.2: Added MaxErrors limit for collect-all execution
+// - 2026-10-16 v0.1.3: Added observer hooks for tracing and metrics
 
 package validation
 
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // ValidatorChain represents a chain of validators that can be executed sequentially
@@ -23,6 +27,9 @@ type ValidatorChain struct {
 	validators []Validator
 	name       string
 	stopOnFirstError bool
+	warningsAsErrors bool
+	maxErrors        int
+	observers        *ObserverRegistry
 	context    map[string]interface{}
 }
 
@@ -60,6 +67,28 @@ func (c *ValidatorChain) StopOnFirstError(stop bool) *ValidatorChain {
 	return c
 }
 
+// MaxErrors limits the number of errors collected by the chain. Validation
+// stops once n errors were reported and surplus errors are dropped; the
+// result context then contains "errorsTruncated". Zero collects all errors
+func (c *ValidatorChain) MaxErrors(n int) *ValidatorChain {
+	c.maxErrors = n
+	return c
+}
+
+// WarningsAsErrors configures the chain to report warnings as errors,
+// making results with warnings invalid. By default, warnings keep results valid
+func (c *ValidatorChain) WarningsAsErrors(strict bool) *ValidatorChain {
+	c.warningsAsErrors = strict
+	return c
+}
+
+// WithObservers sets the observer registry notified of chain executions.
+// Chains without an explicit registry use DefaultObserverRegistry
+func (c *ValidatorChain) WithObservers(registry *ObserverRegistry) *ValidatorChain {
+	c.observers = registry
+	return c
+}
+
 // WithContext adds context information that will be passed to all validators
 func (c *ValidatorChain) WithContext(key string, value interface{}) *ValidatorChain {
 	c.context[key] = value
@@ -79,6 +108,20 @@ func (c *ValidatorChain) Validate(value interface{}) ValidationResult {
 
 // ValidateWithContext executes all validators with context support
 func (c *ValidatorChain) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	// Notify observers, which may derive the context (e.g. tracing spans)
+	registry := c.observers
+	if registry == nil {
+		registry = defaultObservers
+	}
+	observers := registry.snapshot()
+	var start time.Time
+	if observers != nil {
+		for _, observer := range observers {
+			ctx = observer.OnStart(ctx, c.name)
+		}
+		start = time.Now()
+	}
+	
 	// Create context with chain information
 	chainCtx := context.WithValue(ctx, "validatorChain", c.name)
 	for key, val := range c.context {
@@ -86,6 +129,7 @@ func (c *ValidatorChain) ValidateWithContext(ctx context.Context, value interfac
 	}
 	
 	var allResults []ValidationResult
+	errorCount := 0
 	
 	// Execute each validator in sequence
 	for i, validator := range c.validators {
@@ -100,16 +144,30 @@ func (c *ValidatorChain) ValidateWithContext(ctx context.Context, value interfac
 		}
 		result.Context["validatorIndex"] = i
 		
+		if c.warningsAsErrors {
+			result = result.PromoteWarnings()
+		}
+		
 		allResults = append(allResults, result)
 		
 		// Stop on first error if configured
 		if c.stopOnFirstError && !result.Valid {
 			break
 		}
+		
+		// Stop once the error limit is reached
+		errorCount += len(result.Errors)
+		if c.maxErrors > 0 && errorCount >= c.maxErrors {
+			break
+		}
 	}
 	
 	// Combine all results
 	combined := Combine(allResults...)
+	if c.maxErrors > 0 && len(combined.Errors) > c.maxErrors {
+		combined.Errors = combined.Errors[:c.maxErrors]
+		combined.WithContext("errorsTruncated", true)
+	}
 	
 	// Add chain-level context
 	if c.name != "" {
@@ -118,6 +176,18 @@ func (c *ValidatorChain) ValidateWithContext(ctx context.Context, value interfac
 	combined.WithContext("totalValidators", len(c.validators))
 	combined.WithContext("executedValidators", len(allResults))
 	
+	if observers != nil {
+		event := ValidationEvent{
+			Chain:    c.name,
+			Executed: len(allResults),
+			Duration: time.Since(start),
+			Result:   combined,
+		}
+		for _, observer := range observers {
+			observer.OnFinish(ctx, event)
+		}
+	}
+	
 	return combined
 }
 
@@ -137,8 +207,8 @@ func (c *ValidatorChain) String() string {
 	if name == "" {
 		name = "unnamed"
 	}
-	return fmt.Sprintf("ValidatorChain{name: %s, validators: %d, stopOnFirstError: %v}", 
-		name, len(c.validators), c.stopOnFirstError)
+	return fmt.Sprintf("ValidatorChain{name: %s, validators: %d, stopOnFirstError: %v, maxErrors: %d, warningsAsErrors: %v}", 
+		name, len(c.validators), c.stopOnFirstError, c.maxErrors, c.warningsAsErrors)
 }
 
 // ConditionalValidator allows conditional execution of validators based on a predicate
@@ -0,0 +1,131 @@
+// File: describe.go
+// Title: Validation Rule Metadata
+// Description: Provides machine-readable descriptions of validators and
+//              validator chains (rule name, params, error codes) so that
+//              API documentation such as OpenAPI constraints and client-side
+//              validation can be generated from the server-side rules.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of rule introspection
+// - 2026-10-16 v0.1.1: Included the chain error limit in chain metadata
+// - 2026-10-16 v0.1.2: Added rule name for validator groups
+
+package validation
+
+import (
+	"context"
+)
+
+// Rule names used for composite validators
+const (
+	RuleChain       = "chain"       // ValidatorChain
+	RuleConditional = "conditional" // ConditionalValidator
+	RuleParallel    = "parallel"    // ParallelValidator
+	RuleGroup       = "group"       // ValidatorGroup
+	RuleCustom      = "custom"      // Validator without metadata
+)
+
+// RuleDescription is the machine-readable metadata of a validation rule
+type RuleDescription struct {
+	Name     string                 `json:"name"`               // Rule name, e.g. "min_length"
+	Params   map[string]interface{} `json:"params,omitempty"`   // Rule parameters, e.g. {"min": 3}
+	Codes    []string               `json:"codes,omitempty"`    // Error codes the rule may report
+	Optional bool                   `json:"optional,omitempty"` // Rule is skipped for empty values
+	Warning  bool                   `json:"warning,omitempty"`  // Findings are reported as warnings
+	Rules    []RuleDescription      `json:"rules,omitempty"`    // Nested rules of composite validators
+}
+
+// Describer is implemented by validators that provide rule metadata
+type Describer interface {
+	Describe() RuleDescription
+}
+
+// Describe returns the metadata of a validator. Validators that do not
+// implement Describer, such as plain ValidatorFuncs, are described as
+// RuleCustom.
+func Describe(validator Validator) RuleDescription {
+	if describer, ok := validator.(Describer); ok {
+		return describer.Describe()
+	}
+	return RuleDescription{Name: RuleCustom}
+}
+
+// DescribedValidator attaches rule metadata to a validator
+type DescribedValidator struct {
+	validator   Validator
+	description RuleDescription
+}
+
+// WithDescription returns a validator that behaves like validator and
+// reports description as its metadata
+func WithDescription(validator Validator, description RuleDescription) *DescribedValidator {
+	return &DescribedValidator{validator: validator, description: description}
+}
+
+// Validate executes the wrapped validator
+func (d *DescribedValidator) Validate(value interface{}) ValidationResult {
+	return d.validator.Validate(value)
+}
+
+// ValidateWithContext executes the wrapped validator with context support
+func (d *DescribedValidator) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	return d.validator.ValidateWithContext(ctx, value)
+}
+
+// Describe returns the attached rule metadata
+func (d *DescribedValidator) Describe() RuleDescription {
+	return d.description
+}
+
+// Describe returns the metadata of the chain and all its validators
+func (c *ValidatorChain) Describe() RuleDescription {
+	description := RuleDescription{
+		Name: RuleChain,
+		Params: map[string]interface{}{
+			"name":             c.name,
+			"stopOnFirstError": c.stopOnFirstError,
+			"maxErrors":        c.maxErrors,
+		},
+	}
+	for _, validator := range c.validators {
+		description.Rules = append(description.Rules, Describe(validator))
+	}
+	return description
+}
+
+// Describe returns the metadata of the conditional validator
+func (c *ConditionalValidator) Describe() RuleDescription {
+	return RuleDescription{
+		Name:   RuleConditional,
+		Params: map[string]interface{}{"name": c.name},
+		Rules:  []RuleDescription{Describe(c.validator)},
+	}
+}
+
+// Describe returns the metadata of the parallel validator
+func (p *ParallelValidator) Describe() RuleDescription {
+	description := RuleDescription{
+		Name:   RuleParallel,
+		Params: map[string]interface{}{"name": p.name},
+	}
+	for _, validator := range p.validators {
+		description.Rules = append(description.Rules, Describe(validator))
+	}
+	return description
+}
+
+// Describe returns the metadata of the wrapped validator marked as warning
+func (w *WarningValidator) Describe() RuleDescription {
+	description := Describe(w.validator)
+	description.Warning = true
+	return description
+}
+
+// Describe returns the metadata of the wrapped validator
+func (c *CachedValidator) Describe() RuleDescription {
+	return Describe(c.validator)
+}
@@ -0,0 +1,75 @@
+// File: describe_test.go
+// Title: Validation Rule Metadata Tests
+// Description: Tests rule descriptions of described validators, chains,
+//              composite validators, and JSON encoding of the metadata.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial rule metadata tests
+
+package validation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	maxLength := WithDescription(shortString, RuleDescription{
+		Name:   "max_length",
+		Params: map[string]interface{}{"max": 5},
+		Codes:  []string{CodeType, CodeLength},
+	})
+	if !maxLength.Validate("short").Valid || maxLength.Validate("too long").Valid {
+		t.Error("DescribedValidator changed validation behavior")
+	}
+
+	chain := NewValidatorChain("username").
+		Add(WithDescription(nonEmpty, RuleDescription{Name: "required", Codes: []string{CodeRequired}})).
+		Add(AsWarning(maxLength)).
+		Add(Cached(maxLength, nil, time.Minute)).
+		Add(NewConditionalValidator(func(interface{}) bool { return true }, nonEmpty, "admin")).
+		StopOnFirstError(true)
+
+	description := chain.Describe()
+	if description.Name != RuleChain || description.Params["name"] != "username" || description.Params["stopOnFirstError"] != true {
+		t.Fatalf("chain description = %+v", description)
+	}
+	if len(description.Rules) != 4 {
+		t.Fatalf("chain rules = %d, want 4", len(description.Rules))
+	}
+
+	rules := description.Rules
+	if rules[0].Name != "required" || rules[0].Codes[0] != CodeRequired {
+		t.Errorf("rules[0] = %+v", rules[0])
+	}
+	if rules[1].Name != "max_length" || !rules[1].Warning {
+		t.Errorf("rules[1] = %+v, want max_length warning", rules[1])
+	}
+	if rules[2].Name != "max_length" || rules[2].Warning {
+		t.Errorf("rules[2] = %+v, want cached max_length", rules[2])
+	}
+	if rules[3].Name != RuleConditional || rules[3].Rules[0].Name != RuleCustom {
+		t.Errorf("rules[3] = %+v, want conditional with custom rule", rules[3])
+	}
+
+	parallel := NewParallelValidator("checks").Add(maxLength).Add(nonEmpty)
+	if d := Describe(parallel); d.Name != RuleParallel || len(d.Rules) != 2 {
+		t.Errorf("parallel description = %+v", d)
+	}
+
+	data, err := json.Marshal(description)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"name":"chain"`, `"params":{"max":5}`, `"warning":true`, `"codes":["VALIDATION_REQUIRED"]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON %s does not contain %s", data, want)
+		}
+	}
+}
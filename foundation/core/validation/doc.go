@@ -5,12 +5,21 @@
 //              Establishes standard patterns for validation functions, error
 //              handling, and result composition.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.9
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial validation framework implementation
+// - 2026-10-16 v0.1.1: Documented message localization
+// - 2026-10-16 v0.1.2: Documented warning-level findings
+// - 2026-10-16 v0.1.3: Documented cached validators
+// - 2026-10-16 v0.1.4: Documented rule metadata and introspection
+// - 2026-10-16 v0.1.5: Documented chain execution modes
+// - 2026-10-16 v0.1.6: Documented typed generic validators
+// - 2026-10-16 v0.1.7: Documented observer hooks for tracing and metrics
+// - 2026-10-16 v0.1.8: Documented conditional validator groups
+// - 2026-10-16 v0.1.9: Documented result serialization for API responses
 
 /*
 Package validation provides the core validation framework infrastructure for the mDW Foundation.
@@ -35,6 +44,12 @@ Core Framework Components:
   • Standardized error codes for consistent error handling across modules
   • ValidatorChain for composing multiple validators into complex validation logic
   • ConditionalValidator and ParallelValidator for advanced orchestration patterns
+  • Group and When for rule groups toggled by request attributes
+  • CachedValidator for memoizing expensive validators with hit/miss metrics
+  • TypedValidator[T] and Chain[T] generics alongside the interface{} API
+  • Rule metadata (Describe, RuleDescription) for documentation and client validation
+  • ObserverRegistry and MetricsObserver for tracing spans and validation metrics
+  • ToProblemDetails (RFC 9457) and ToJSON for consistent API error payloads
   • Context-aware validation support with request tracing and metadata
   • Utility functions for common validation framework operations
   • Integration with mDW Foundation error handling and logging systems
@@ -60,6 +75,7 @@ This package provides the foundational infrastructure for validation systems:
 	type ValidationResult struct {
 		Valid   bool                    // Overall validation status
 		Errors  []ValidationError       // Detailed error information
+		Warnings []ValidationError      // Soft findings that keep Valid true
 		Context map[string]interface{}  // Additional validation context
 	}
 
@@ -71,6 +87,8 @@ This package provides the foundational infrastructure for validation systems:
 		Value    interface{}           // Actual value that failed validation
 		Context  map[string]interface{} // Additional error context
 		Expected interface{}           // Expected value or format
+		MessageKey string              // i18n key for the message
+		Params   map[string]interface{} // Template data for the localized message
 	}
 
 ## Error Code Standards
@@ -94,6 +112,159 @@ Standardized error codes ensure consistent error handling across modules:
 	
 	// Additional specialized codes for files, locales, dates, etc.
 
+## Message Localization
+
+Each error code maps to an i18n message key "validation.<code>" (the code
+without its VALIDATION_ prefix, lower case). Validators may refine the key
+and supply template params with NewValidationErrorWithKey. Results are
+translated through any Translator, such as *i18n.Manager:
+
+	result := validation.NewValidationErrorWithKey(validation.CodeLength,
+		"validation.length_min", "must be at least 3 characters long",
+		map[string]interface{}{"Min": 3})
+
+	localized := result.Localize(i18nManager, "de")
+
+Errors without a translation keep their original message.
+
+## Warnings
+
+Warnings are findings that are reported but do not make a result invalid,
+for example when an import should accept rows with soft issues. AsWarning
+downgrades the errors of any validator; chains can promote warnings back to
+errors for strict processing:
+
+	chain := validation.NewValidatorChain("email").
+		Add(emailFormat).
+		Add(validation.AsWarning(corporateDomain))
+
+	result := chain.Validate("user@gmail.com")
+	// result.Valid == true, result.Warnings holds the domain finding
+
+	strict := chain.WarningsAsErrors(true)
+	// strict.Validate("user@gmail.com").Valid == false
+
+## Caching
+
+Cached memoizes expensive validators, such as regex-heavy checks or external
+lookups, on hot paths. Results are keyed by value (DefaultCacheKey handles
+strings, booleans, and numbers), expire after a TTL, and are bounded by an
+LRU limit:
+
+	domainCheck := validation.Cached(mxLookup, nil, 10*time.Minute).
+		WithMaxEntries(50000).
+		CacheIf(func(r validation.ValidationResult) bool { return r.Valid })
+
+	stats := domainCheck.Stats() // Hits, Misses, Evictions, Entries, HitRatio()
+
+Cached results carry no request-specific context and are copied on every
+hit, so callers may modify them freely.
+
+## Rule Metadata
+
+Validators implementing Describer report machine-readable metadata: rule
+name, params, and the error codes they may return. Chains and composite
+validators describe their nested rules, so API documentation (e.g. OpenAPI
+constraints) and client-side validation can be generated from server rules:
+
+	minLength := validation.WithDescription(minLengthFunc(3), validation.RuleDescription{
+		Name:   "min_length",
+		Params: map[string]interface{}{"min": 3},
+		Codes:  []string{validation.CodeLength},
+	})
+
+	description := validation.NewValidatorChain("username").Add(minLength).Describe()
+	data, _ := json.Marshal(description)
+
+Validators without metadata, such as plain ValidatorFuncs, are described
+as "custom".
+
+## Typed Validators
+
+TypedValidator[T], TypedFunc[T], and Chain[T] validate values of a known
+type without runtime type assertions. Adapters connect both worlds:
+Untyped turns a typed validator into a Validator (values of other types
+fail with CodeType), and Typed reuses an existing Validator in a typed chain:
+
+	positive := validation.TypedFunc[int](func(v int) validation.ValidationResult {
+		if v <= 0 {
+			return validation.NewValidationError(validation.CodeRange, "must be positive")
+		}
+		return validation.NewValidationResult()
+	})
+
+	quantity := validation.NewChain[int]("quantity").
+		Add(positive).
+		Add(validation.Typed[int](legacyValidator))
+
+	result := quantity.Validate(3)     // Checked at compile time
+	rules["quantity"] = quantity.Untyped() // Use in interface{} rule maps
+
+## API Responses
+
+ToProblemDetails converts a result into RFC 9457 problem details, so all
+handlers return the same 400 payload. Each error carries its field path,
+a JSON Pointer, the error code, the message key with params, and the
+message; errors are ordered by field. Rejected values and internal context
+are never included:
+
+	if result := rules.ValidateWithContext(ctx, req); !result.Valid {
+		problem := result.Localize(i18nManager, locale).ToProblemDetails(validation.ProblemOptions{
+			Type:     validation.ProblemTypeValidation,
+			Title:    validation.ProblemTitleValidation,
+			Status:   http.StatusBadRequest,
+			Instance: r.URL.Path,
+		})
+		w.Header().Set("Content-Type", validation.ProblemContentType)
+		w.WriteHeader(problem.Status)
+		json.NewEncoder(w).Encode(problem)
+	}
+
+ToJSON produces the compact form {"valid": ..., "errors": [...], "warnings": [...]}
+with the same error entries, and FieldPointer converts paths such as
+"items[0].name" to "#/items/0/name".
+
+## Conditional Groups
+
+Group bundles validators that are enabled or disabled together; When makes
+a group, or a single chain, conditional on a Predicate. Predicates see the
+validation context, so rule groups can depend on request attributes such
+as the customer type instead of conditional plumbing in handlers:
+
+	isB2B := validation.ContextValueEquals("customerType", "B2B")
+
+	company := validation.NewValidatorChain("company").
+		Add(validation.Group("b2b", requiredVATID, requiredCompanyName).When(isB2B)).
+		Add(validation.When(validation.Not(isB2B), consumerRules))
+
+	ctx = context.WithValue(ctx, "customerType", customer.Type)
+	result := company.ValidateWithContext(ctx, customer)
+
+ValueMatches, Not, AllOf, and AnyOf build predicates from value conditions
+and other predicates. Otherwise sets a validator executed while a group is
+disabled; results carry "validatorGroup" and "groupEnabled" context.
+
+## Observability
+
+Every ValidatorChain execution notifies the observers of an ObserverRegistry.
+OnStart may return a derived context, e.g. carrying an OpenTelemetry span,
+which is passed to the chain's validators; OnFinish receives a
+ValidationEvent with chain name, duration, and combined result. Chains use
+DefaultObserverRegistry unless WithObservers sets another registry:
+
+	metrics := validation.NewMetricsObserver()
+	unregister := validation.DefaultObserverRegistry().Register(metrics)
+	defer unregister()
+
+	// ... validate ...
+
+	snapshot := metrics.Snapshot()
+	rejected := snapshot.FailuresByCode[validation.CodeRequired]
+
+Tracing adapters implement Observer directly, starting a span in OnStart
+and ending it in OnFinish. With no observers registered, the hooks cost a
+single registry lookup per chain execution.
+
 # Framework Usage Patterns
 
 This package provides the infrastructure for building validation systems:
@@ -179,6 +350,12 @@ Build complex validation logic by composing validators:
 		}
 	}
 
+	// Execution modes: chains collect all errors by default
+	strict := validation.NewValidatorChain("password").
+		StopOnFirstError(true) // Short-circuit on the first failing validator
+	form := validation.NewValidatorChain("comment").
+		MaxErrors(5) // Collect up to 5 errors, then stop
+
 	// Chain with context
 	ctx := context.WithValue(context.Background(), "requestId", "req-123")
 	result = chain.ValidateWithContext(ctx, "user@example.com")
@@ -0,0 +1,190 @@
+// File: generic.go
+// Title: Typed Generic Validator API
+// Description: Provides type-safe generic validators and chains alongside
+//              the interface{} based API. Typed validators receive values of
+//              their parameter type without per-call type assertions, and
+//              adapters convert between typed and legacy validators in both
+//              directions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of typed validators and chains
+
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypedValidator validates values of type T. It is the generic counterpart
+// of Validator.
+type TypedValidator[T any] interface {
+	Validate(value T) ValidationResult
+	ValidateWithContext(ctx context.Context, value T) ValidationResult
+}
+
+// TypedFunc is a function type that implements TypedValidator
+type TypedFunc[T any] func(value T) ValidationResult
+
+// Validate implements the TypedValidator interface for TypedFunc
+func (f TypedFunc[T]) Validate(value T) ValidationResult {
+	return f(value)
+}
+
+// ValidateWithContext implements the TypedValidator interface for TypedFunc
+func (f TypedFunc[T]) ValidateWithContext(ctx context.Context, value T) ValidationResult {
+	return f(value)
+}
+
+// ===============================
+// Adapters
+// ===============================
+
+// untypedValidator adapts a TypedValidator to the Validator interface
+type untypedValidator[T any] struct {
+	validator TypedValidator[T]
+}
+
+// Untyped adapts a typed validator for use with the interface{} API, e.g.
+// in a ValidatorChain. Nil values are validated as the zero value of T;
+// values of other types fail with CodeType.
+func Untyped[T any](validator TypedValidator[T]) Validator {
+	return &untypedValidator[T]{validator: validator}
+}
+
+// Validate asserts the value type and executes the typed validator
+func (u *untypedValidator[T]) Validate(value interface{}) ValidationResult {
+	return u.ValidateWithContext(context.Background(), value)
+}
+
+// ValidateWithContext asserts the value type and executes the typed
+// validator with context support
+func (u *untypedValidator[T]) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	if value == nil {
+		var zero T
+		return u.validator.ValidateWithContext(ctx, zero)
+	}
+	typed, ok := value.(T)
+	if !ok {
+		var zero T
+		return NewValidationErrorWithKey(CodeType, "", fmt.Sprintf("value must be of type %T", zero),
+			map[string]interface{}{"Type": fmt.Sprintf("%T", zero)})
+	}
+	return u.validator.ValidateWithContext(ctx, typed)
+}
+
+// Describe returns the metadata of the typed validator
+func (u *untypedValidator[T]) Describe() RuleDescription {
+	if describer, ok := u.validator.(Describer); ok {
+		return describer.Describe()
+	}
+	return RuleDescription{Name: RuleCustom}
+}
+
+// typedValidator adapts a Validator to the TypedValidator interface
+type typedValidator[T any] struct {
+	validator Validator
+}
+
+// Typed adapts a legacy validator to a typed validator, so existing
+// validators can be used in a Chain
+func Typed[T any](validator Validator) TypedValidator[T] {
+	return &typedValidator[T]{validator: validator}
+}
+
+// Validate executes the legacy validator
+func (t *typedValidator[T]) Validate(value T) ValidationResult {
+	return t.validator.Validate(value)
+}
+
+// ValidateWithContext executes the legacy validator with context support
+func (t *typedValidator[T]) ValidateWithContext(ctx context.Context, value T) ValidationResult {
+	return t.validator.ValidateWithContext(ctx, value)
+}
+
+// Describe returns the metadata of the legacy validator
+func (t *typedValidator[T]) Describe() RuleDescription {
+	return Describe(t.validator)
+}
+
+// ===============================
+// Typed Chains
+// ===============================
+
+// Chain is a typed validator chain. It shares the execution semantics of
+// ValidatorChain (collect-all by default, StopOnFirstError, MaxErrors,
+// WarningsAsErrors).
+type Chain[T any] struct {
+	chain *ValidatorChain
+}
+
+// NewChain creates a new typed validator chain with an optional name
+func NewChain[T any](name ...string) *Chain[T] {
+	return &Chain[T]{chain: NewValidatorChain(name...)}
+}
+
+// Add adds a typed validator to the chain
+func (c *Chain[T]) Add(validator TypedValidator[T]) *Chain[T] {
+	c.chain.Add(Untyped(validator))
+	return c
+}
+
+// AddFunc adds a typed validator function to the chain
+func (c *Chain[T]) AddFunc(fn TypedFunc[T]) *Chain[T] {
+	return c.Add(fn)
+}
+
+// AddLegacy adds an interface{} based validator to the chain
+func (c *Chain[T]) AddLegacy(validator Validator) *Chain[T] {
+	c.chain.Add(validator)
+	return c
+}
+
+// StopOnFirstError configures the chain to stop on the first validation error
+func (c *Chain[T]) StopOnFirstError(stop bool) *Chain[T] {
+	c.chain.StopOnFirstError(stop)
+	return c
+}
+
+// MaxErrors limits the number of errors collected by the chain
+func (c *Chain[T]) MaxErrors(n int) *Chain[T] {
+	c.chain.MaxErrors(n)
+	return c
+}
+
+// WarningsAsErrors configures the chain to report warnings as errors
+func (c *Chain[T]) WarningsAsErrors(strict bool) *Chain[T] {
+	c.chain.WarningsAsErrors(strict)
+	return c
+}
+
+// Validate executes all validators in the chain
+func (c *Chain[T]) Validate(value T) ValidationResult {
+	return c.chain.Validate(value)
+}
+
+// ValidateWithContext executes all validators with context support
+func (c *Chain[T]) ValidateWithContext(ctx context.Context, value T) ValidationResult {
+	return c.chain.ValidateWithContext(ctx, value)
+}
+
+// Untyped returns the chain as an interface{} based ValidatorChain, e.g.
+// for field rule maps
+func (c *Chain[T]) Untyped() *ValidatorChain {
+	return c.chain
+}
+
+// Describe returns the metadata of the chain and all its validators
+func (c *Chain[T]) Describe() RuleDescription {
+	return c.chain.Describe()
+}
+
+// String returns a string representation of the typed chain
+func (c *Chain[T]) String() string {
+	var zero T
+	return fmt.Sprintf("Chain[%T]%s", zero, c.chain.String()[len("ValidatorChain"):])
+}
@@ -0,0 +1,91 @@
+// File: generic_test.go
+// Title: Typed Generic Validator Tests
+// Description: Tests typed validators and chains and the adapters between
+//              typed and interface{} based validators.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial typed validator tests
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// positive is a typed validator rejecting non-positive integers
+var positive TypedFunc[int] = func(value int) ValidationResult {
+	if value <= 0 {
+		return NewValidationError(CodeRange, "must be positive")
+	}
+	return NewValidationResult()
+}
+
+// even is a typed validator rejecting odd integers
+var even TypedFunc[int] = func(value int) ValidationResult {
+	if value%2 != 0 {
+		return NewValidationError(CodeCustom, "must be even")
+	}
+	return NewValidationResult()
+}
+
+func TestChain(t *testing.T) {
+	chain := NewChain[int]("quantity").Add(positive).AddFunc(even)
+
+	if result := chain.Validate(4); !result.Valid {
+		t.Errorf("Validate(4) = %v", result.ErrorMessages())
+	}
+	if result := chain.Validate(-3); len(result.Errors) != 2 {
+		t.Errorf("Validate(-3) errors = %v, want 2", result.ErrorMessages())
+	}
+	if result := chain.StopOnFirstError(true).Validate(-3); len(result.Errors) != 1 {
+		t.Errorf("StopOnFirstError Validate(-3) errors = %v, want 1", result.ErrorMessages())
+	}
+	if result := chain.ValidateWithContext(context.Background(), 2); !result.Valid {
+		t.Errorf("ValidateWithContext(2) = %v", result.ErrorMessages())
+	}
+
+	// Legacy validators can be mixed in
+	names := NewChain[string]("name").AddLegacy(nonEmpty).Add(Typed[string](shortString))
+	if !names.Validate("Bob").Valid || names.Validate("").Valid || names.Validate("Bartholomew").Valid {
+		t.Error("chain with legacy validators gave wrong results")
+	}
+
+	if !strings.HasPrefix(chain.String(), "Chain[int]{name: quantity") {
+		t.Errorf("String() = %s", chain.String())
+	}
+	if d := chain.Describe(); d.Name != RuleChain || len(d.Rules) != 2 {
+		t.Errorf("Describe() = %+v", d)
+	}
+}
+
+func TestUntyped(t *testing.T) {
+	legacy := NewValidatorChain("quantity").Add(Untyped[int](positive))
+
+	if !legacy.Validate(3).Valid {
+		t.Error("Untyped validator rejected valid int")
+	}
+	result := legacy.Validate("3")
+	if result.Valid || !result.HasError(CodeType) || result.Errors[0].Params["Type"] != "int" {
+		t.Errorf("Untyped validator with string = %+v", result)
+	}
+	if !legacy.Validate(nil).HasError(CodeRange) {
+		t.Error("Untyped validator should validate nil as zero value")
+	}
+
+	// Typed chains can be used where field rule maps expect ValidatorChains
+	rules := map[string]*ValidatorChain{"quantity": NewChain[int]("quantity").Add(even).Untyped()}
+	if rules["quantity"].Validate(3).Valid {
+		t.Error("Untyped() chain accepted an odd value")
+	}
+
+	described := WithDescription(Untyped[int](positive), RuleDescription{Name: "positive"})
+	if d := Describe(Untyped[int](Typed[int](described))); d.Name != "positive" {
+		t.Errorf("adapters lost rule metadata: %+v", d)
+	}
+}
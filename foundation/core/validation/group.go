@@ -0,0 +1,195 @@
+// File: group.go
+// Title: Conditional Validator Groups
+// Description: Provides validator groups that are enabled or disabled as a
+//              whole by a predicate on the validation context and value,
+//              e.g. B2B-only rules toggled by the customer type of a request.
+//              Predicates can be combined and read request attributes from
+//              the context.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of conditional validator groups
+
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+// Predicate decides whether a validator group applies. It receives the
+// validation context, which carries request attributes, and the value.
+type Predicate func(ctx context.Context, value interface{}) bool
+
+// ===============================
+// Predicates
+// ===============================
+
+// ContextValueEquals returns a predicate that holds if the context value
+// for key equals want, e.g. ContextValueEquals("customerType", "B2B")
+func ContextValueEquals(key, want interface{}) Predicate {
+	return func(ctx context.Context, value interface{}) bool {
+		return ctx.Value(key) == want
+	}
+}
+
+// ValueMatches adapts a value condition, as used by ConditionalValidator,
+// to a Predicate
+func ValueMatches(condition func(interface{}) bool) Predicate {
+	return func(ctx context.Context, value interface{}) bool {
+		return condition(value)
+	}
+}
+
+// Not returns a predicate that holds if predicate does not hold
+func Not(predicate Predicate) Predicate {
+	return func(ctx context.Context, value interface{}) bool {
+		return !predicate(ctx, value)
+	}
+}
+
+// AllOf returns a predicate that holds if all predicates hold
+func AllOf(predicates ...Predicate) Predicate {
+	return func(ctx context.Context, value interface{}) bool {
+		for _, predicate := range predicates {
+			if !predicate(ctx, value) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf returns a predicate that holds if at least one predicate holds
+func AnyOf(predicates ...Predicate) Predicate {
+	return func(ctx context.Context, value interface{}) bool {
+		for _, predicate := range predicates {
+			if predicate(ctx, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ===============================
+// Validator Groups
+// ===============================
+
+// ValidatorGroup is a named set of validators that is enabled or disabled
+// as a whole. Enabled groups execute all validators and collect all errors;
+// disabled groups pass or execute their Otherwise validator.
+type ValidatorGroup struct {
+	name       string
+	predicate  Predicate
+	validators []Validator
+	otherwise  Validator
+}
+
+// Group creates an always enabled validator group; use When to make it
+// conditional
+func Group(name string, validators ...Validator) *ValidatorGroup {
+	return &ValidatorGroup{
+		name:       name,
+		validators: validators,
+	}
+}
+
+// When creates an unnamed group that executes validator, typically a
+// ValidatorChain, only if predicate holds
+func When(predicate Predicate, validator Validator) *ValidatorGroup {
+	return Group("", validator).When(predicate)
+}
+
+// Add adds a validator to the group
+func (g *ValidatorGroup) Add(validator Validator) *ValidatorGroup {
+	g.validators = append(g.validators, validator)
+	return g
+}
+
+// When makes the group conditional. Multiple conditions must all hold
+func (g *ValidatorGroup) When(predicate Predicate) *ValidatorGroup {
+	if g.predicate == nil {
+		g.predicate = predicate
+	} else {
+		g.predicate = AllOf(g.predicate, predicate)
+	}
+	return g
+}
+
+// Otherwise sets a validator that is executed while the group is disabled
+func (g *ValidatorGroup) Otherwise(validator Validator) *ValidatorGroup {
+	g.otherwise = validator
+	return g
+}
+
+// Enabled reports whether the group applies to value in ctx
+func (g *ValidatorGroup) Enabled(ctx context.Context, value interface{}) bool {
+	return g.predicate == nil || g.predicate(ctx, value)
+}
+
+// Validate executes the group if it is enabled
+func (g *ValidatorGroup) Validate(value interface{}) ValidationResult {
+	return g.ValidateWithContext(context.Background(), value)
+}
+
+// ValidateWithContext executes the group if it is enabled, with context support
+func (g *ValidatorGroup) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	var result ValidationResult
+	enabled := g.Enabled(ctx, value)
+
+	switch {
+	case enabled:
+		results := make([]ValidationResult, 0, len(g.validators))
+		for _, validator := range g.validators {
+			results = append(results, validator.ValidateWithContext(ctx, value))
+		}
+		result = Combine(results...)
+	case g.otherwise != nil:
+		result = g.otherwise.ValidateWithContext(ctx, value)
+	default:
+		result = NewValidationResult()
+	}
+
+	if g.name != "" {
+		result.WithContext("validatorGroup", g.name)
+	}
+	result.WithContext("groupEnabled", enabled)
+	return result
+}
+
+// Name returns the group name
+func (g *ValidatorGroup) Name() string {
+	return g.name
+}
+
+// String returns a string representation of the validator group
+func (g *ValidatorGroup) String() string {
+	name := g.name
+	if name == "" {
+		name = "unnamed"
+	}
+	return fmt.Sprintf("ValidatorGroup{name: %s, validators: %d, conditional: %v}",
+		name, len(g.validators), g.predicate != nil)
+}
+
+// Describe returns the metadata of the group and all its validators
+func (g *ValidatorGroup) Describe() RuleDescription {
+	description := RuleDescription{
+		Name: RuleGroup,
+		Params: map[string]interface{}{
+			"name":        g.name,
+			"conditional": g.predicate != nil,
+		},
+	}
+	if g.otherwise != nil {
+		description.Params["otherwise"] = Describe(g.otherwise)
+	}
+	for _, validator := range g.validators {
+		description.Rules = append(description.Rules, Describe(validator))
+	}
+	return description
+}
@@ -0,0 +1,97 @@
+// File: group_test.go
+// Title: Conditional Validator Group Tests
+// Description: Tests validator groups toggled by request attributes, the
+//              predicate combinators, and group metadata.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial validator group tests
+
+package validation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidatorGroup(t *testing.T) {
+	isB2B := ContextValueEquals("customerType", "B2B")
+	b2b := Group("b2b", nonEmpty, shortString).When(isB2B)
+	b2bCtx := context.WithValue(context.Background(), "customerType", "B2B")
+	b2cCtx := context.WithValue(context.Background(), "customerType", "B2C")
+
+	result := b2b.ValidateWithContext(b2bCtx, "")
+	if result.Valid || result.Context["validatorGroup"] != "b2b" || result.Context["groupEnabled"] != true {
+		t.Errorf("enabled group = %+v", result)
+	}
+	if result := b2b.ValidateWithContext(b2cCtx, ""); !result.Valid || result.Context["groupEnabled"] != false {
+		t.Errorf("disabled group = %+v", result)
+	}
+
+	// Groups collect errors of all validators
+	if result := b2b.ValidateWithContext(b2bCtx, "Bartholomew "); len(result.Errors) != 1 {
+		t.Errorf("errors = %v, want 1", result.ErrorMessages())
+	}
+
+	// Otherwise runs while the group is disabled
+	b2b.Otherwise(shortString)
+	if result := b2b.ValidateWithContext(b2cCtx, "Bartholomew"); !result.HasError(CodeLength) {
+		t.Errorf("Otherwise codes = %v", result.ErrorCodes())
+	}
+
+	// Request attributes set on a chain reach nested groups
+	chain := NewValidatorChain("vatId").
+		WithContext("customerType", "B2B").
+		Add(When(isB2B, NewValidatorChain().Add(nonEmpty)))
+	if chain.Validate("").Valid {
+		t.Error("chain context did not enable group")
+	}
+	if !Group("always", nonEmpty).Validate("x").Valid {
+		t.Error("unconditional group rejected valid value")
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "region", "EU")
+	isEU := ContextValueEquals("region", "EU")
+	isLong := ValueMatches(func(value interface{}) bool {
+		s, _ := value.(string)
+		return len(s) > 5
+	})
+
+	tests := []struct {
+		name      string
+		predicate Predicate
+		want      bool
+	}{
+		{"context value", isEU, true},
+		{"missing context value", ContextValueEquals("tier", "gold"), false},
+		{"value", isLong, false},
+		{"not", Not(isLong), true},
+		{"all of", AllOf(isEU, isLong), false},
+		{"any of", AnyOf(isEU, isLong), true},
+	}
+	for _, tt := range tests {
+		if got := tt.predicate(ctx, "short"); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	group := Group("eu", nonEmpty).When(isEU).When(isLong)
+	if group.Enabled(ctx, "short") || !group.Enabled(ctx, "longer value") {
+		t.Error("multiple When conditions are not combined with AND")
+	}
+}
+
+func TestValidatorGroup_Describe(t *testing.T) {
+	d := Group("b2b", nonEmpty).When(ContextValueEquals("customerType", "B2B")).Otherwise(shortString).Describe()
+	if d.Name != RuleGroup || d.Params["name"] != "b2b" || d.Params["conditional"] != true || len(d.Rules) != 1 {
+		t.Errorf("Describe() = %+v", d)
+	}
+	if otherwise, ok := d.Params["otherwise"].(RuleDescription); !ok || otherwise.Name != RuleCustom {
+		t.Errorf("otherwise = %+v", d.Params["otherwise"])
+	}
+}
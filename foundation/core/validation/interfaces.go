@@ -4,12 +4,14 @@
 //              validation across all mDW Foundation modules. Provides the
 //              foundation for consistent validation patterns and error handling.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial validation interfaces implementation
+// - 2026-10-16 v0.1.1: Added MessageKey and Params to ValidationError for localization
+// - 2026-10-16 v0.1.2: Added warning-level findings to ValidationResult
 
 package validation
 
@@ -96,6 +98,7 @@ func (f ValidatorFunc) ValidateWithContext(ctx context.Context, value interface{
 type ValidationResult struct {
 	Valid   bool                    `json:"valid"`   // Whether validation passed
 	Errors  []ValidationError      `json:"errors,omitempty"`  // Detailed error information  
+	Warnings []ValidationError     `json:"warnings,omitempty"` // Soft findings that do not affect Valid
 	Context map[string]interface{} `json:"context,omitempty"` // Additional context data
 }
 
@@ -107,6 +110,8 @@ type ValidationError struct {
 	Value    interface{}           `json:"value,omitempty"`   // Actual value that failed validation
 	Context  map[string]interface{} `json:"context,omitempty"` // Additional error context
 	Expected interface{}           `json:"expected,omitempty"` // Expected value or format
+	MessageKey string              `json:"messageKey,omitempty"` // i18n key for the message (default derived from Code)
+	Params   map[string]interface{} `json:"params,omitempty"`  // Template data for the localized message
 }
 
 // NewValidationResult creates a successful validation result
@@ -146,6 +151,22 @@ func NewValidationErrorWithField(code, field, message string, value interface{})
 	}
 }
 
+// NewValidationErrorWithKey creates a failed validation result whose message
+// can be localized through the given i18n message key and template params
+func NewValidationErrorWithKey(code, messageKey, message string, params map[string]interface{}) ValidationResult {
+	return ValidationResult{
+		Valid: false,
+		Errors: []ValidationError{
+			{
+				Code:       code,
+				Message:    message,
+				MessageKey: messageKey,
+				Params:     params,
+			},
+		},
+	}
+}
+
 // AddError adds an error to an existing validation result
 func (r *ValidationResult) AddError(code, message string) *ValidationResult {
 	r.Valid = false
@@ -261,6 +282,9 @@ func (r ValidationResult) ToError() error {
 // String returns a human-readable representation of the validation result
 func (r ValidationResult) String() string {
 	if r.Valid {
+		if len(r.Warnings) > 0 {
+			return fmt.Sprintf("ValidationResult{valid: true, warnings: %d}", len(r.Warnings))
+		}
 		return "ValidationResult{valid: true}"
 	}
 	
@@ -278,6 +302,10 @@ func (r ValidationResult) String() string {
 		}
 	}
 	
+	if len(r.Warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("warnings: %d", len(r.Warnings)))
+	}
+	
 	parts = append(parts, "}")
 	return strings.Join(parts, ", ")
 }
@@ -313,6 +341,7 @@ func Combine(results ...ValidationResult) ValidationResult {
 			combined.Valid = false
 			combined.Errors = append(combined.Errors, result.Errors...)
 		}
+		combined.Warnings = append(combined.Warnings, result.Warnings...)
 		
 		// Merge context information
 		for key, value := range result.Context {
@@ -0,0 +1,105 @@
+// File: localize.go
+// Title: Validation Message Localization
+// Description: Translates validation error messages through an i18n
+//              translator using a message-key convention derived from the
+//              standardized error codes. Validators may refine the key and
+//              supply template params; untranslated errors keep their
+//              original message.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of result localization
+// - 2026-10-16 v0.1.1: Localize warnings alongside errors
+
+package validation
+
+import (
+	"strings"
+)
+
+// MessageKeyPrefix is the namespace for validation messages in locale files
+const MessageKeyPrefix = "validation."
+
+// FieldKeyPrefix is the namespace for translated field labels in locale files
+const FieldKeyPrefix = MessageKeyPrefix + "fields."
+
+// Translator translates message keys for a specific locale.
+// It is implemented by *i18n.Manager.
+type Translator interface {
+	TryTInLocale(locale, key string, data ...map[string]interface{}) (string, error)
+}
+
+// MessageKeyForCode returns the i18n message key for a validation error code
+// following the convention "validation.<code>", where <code> is the error
+// code without its VALIDATION_ prefix in lower case.
+// For example, VALIDATION_REQUIRED maps to "validation.required".
+func MessageKeyForCode(code string) string {
+	return MessageKeyPrefix + strings.ToLower(strings.TrimPrefix(code, "VALIDATION_"))
+}
+
+// MessageKeys returns the i18n keys tried for the error, most specific first:
+// the explicit MessageKey (if any) followed by the key derived from the code.
+func (e ValidationError) MessageKeys() []string {
+	codeKey := MessageKeyForCode(e.Code)
+	if e.MessageKey == "" || e.MessageKey == codeKey {
+		return []string{codeKey}
+	}
+	return []string{e.MessageKey, codeKey}
+}
+
+// Localize returns a copy of the error with its message translated into
+// locale. Templates receive the Params plus Field, FieldLabel, Value,
+// Expected, and Message (the original message). The original message is
+// kept when no translation exists.
+func (e ValidationError) Localize(translator Translator, locale string) ValidationError {
+	if translator == nil {
+		return e
+	}
+
+	data := make(map[string]interface{}, len(e.Params)+5)
+	for key, value := range e.Params {
+		data[key] = value
+	}
+	data["Field"] = e.Field
+	data["FieldLabel"] = e.Field
+	data["Value"] = e.Value
+	data["Expected"] = e.Expected
+	data["Message"] = e.Message
+	if e.Field != "" {
+		if label, err := translator.TryTInLocale(locale, FieldKeyPrefix+e.Field); err == nil {
+			data["FieldLabel"] = label
+		}
+	}
+
+	for _, key := range e.MessageKeys() {
+		if message, err := translator.TryTInLocale(locale, key, data); err == nil {
+			e.Message = message
+			break
+		}
+	}
+	return e
+}
+
+// Localize returns a copy of the result with all error and warning messages
+// translated into locale. An empty locale uses the translator's current locale.
+func (r ValidationResult) Localize(translator Translator, locale string) ValidationResult {
+	r.Errors = localizeAll(r.Errors, translator, locale)
+	r.Warnings = localizeAll(r.Warnings, translator, locale)
+	return r
+}
+
+// localizeAll returns a translated copy of findings
+func localizeAll(findings []ValidationError, translator Translator, locale string) []ValidationError {
+	if len(findings) == 0 {
+		return findings
+	}
+
+	localized := make([]ValidationError, len(findings))
+	for i, finding := range findings {
+		localized[i] = finding.Localize(translator, locale)
+	}
+	return localized
+}
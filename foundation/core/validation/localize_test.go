@@ -0,0 +1,103 @@
+// File: localize_test.go
+// Title: Validation Message Localization Tests
+// Description: Tests for the message-key convention and localization of
+//              validation results through a translator.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial localization tests
+
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// mapTranslator is a minimal Translator backed by locale -> key -> template
+type mapTranslator map[string]map[string]string
+
+func (m mapTranslator) TryTInLocale(locale, key string, data ...map[string]interface{}) (string, error) {
+	message, ok := m[locale][key]
+	if !ok {
+		return "", errors.New("translation not found")
+	}
+	if len(data) == 0 {
+		return message, nil
+	}
+	tmpl, err := template.New(key).Parse(message)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data[0]); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+func TestMessageKeyForCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{CodeRequired, "validation.required"},
+		{CodeFileExists, "validation.file_exists"},
+		{"CUSTOM_CODE", "validation.custom_code"},
+	}
+
+	for _, tt := range tests {
+		if got := MessageKeyForCode(tt.code); got != tt.want {
+			t.Errorf("MessageKeyForCode(%s) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+
+	err := ValidationError{Code: CodeLength, MessageKey: "validation.length_min"}
+	if got := err.MessageKeys(); !reflect.DeepEqual(got, []string{"validation.length_min", "validation.length"}) {
+		t.Errorf("MessageKeys() = %v", got)
+	}
+}
+
+func TestValidationResult_Localize(t *testing.T) {
+	translator := mapTranslator{
+		"de": {
+			"validation.required":      "Feld ist erforderlich",
+			"validation.length_min":    "{{.FieldLabel}} muss mindestens {{.Min}} Zeichen lang sein",
+			"validation.fields.name":   "Name",
+			"validation.fields.street": "Straße",
+		},
+	}
+
+	result := NewValidationResult()
+	result.AddFieldError(CodeRequired, "email", "value is required", "")
+	minResult := NewValidationErrorWithKey(CodeLength, "validation.length_min", "must be at least 3 characters long", map[string]interface{}{"Min": 3})
+	minResult.Errors[0].Field = "name"
+	result = Combine(result, minResult)
+	result.AddError(CodeEmail, "must be a valid email address")
+
+	localized := result.Localize(translator, "de")
+	want := []string{
+		"Feld ist erforderlich",
+		"Name muss mindestens 3 Zeichen lang sein",
+		"must be a valid email address", // No translation: original message kept
+	}
+	if got := localized.ErrorMessages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Localize() messages = %q, want %q", got, want)
+	}
+	if result.Errors[0].Message != "value is required" {
+		t.Error("Localize() modified the original result")
+	}
+
+	if got := result.Localize(translator, "fr").ErrorMessages(); !reflect.DeepEqual(got, result.ErrorMessages()) {
+		t.Errorf("Localize() with unknown locale = %q", got)
+	}
+	if got := result.Localize(nil, "de").ErrorMessages(); !reflect.DeepEqual(got, result.ErrorMessages()) {
+		t.Errorf("Localize() with nil translator = %q", got)
+	}
+}
@@ -0,0 +1,201 @@
+// File: observe.go
+// Title: Validation Observability Hooks
+// Description: Provides an observer registry that is notified of every
+//              validator chain execution, so tracing spans (e.g.
+//              OpenTelemetry) and metrics can be emitted without changing
+//              validation code. Includes a counting observer that tracks
+//              validations and failures by chain and error code.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of observer hooks and metrics
+
+package validation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ValidationEvent describes a completed validator chain execution
+type ValidationEvent struct {
+	Chain    string           // Chain name (may be empty)
+	Executed int              // Number of validators executed
+	Duration time.Duration    // Execution time of the chain
+	Result   ValidationResult // Combined result of the chain
+}
+
+// Observer is notified of validator chain executions. OnStart may return a
+// derived context, e.g. carrying a tracing span; that context is passed to
+// the chain's validators and to OnFinish.
+type Observer interface {
+	OnStart(ctx context.Context, chain string) context.Context
+	OnFinish(ctx context.Context, event ValidationEvent)
+}
+
+// ObserverFunc adapts a function to an Observer that is only notified of
+// finished executions
+type ObserverFunc func(ctx context.Context, event ValidationEvent)
+
+// OnStart implements Observer and returns ctx unchanged
+func (f ObserverFunc) OnStart(ctx context.Context, chain string) context.Context {
+	return ctx
+}
+
+// OnFinish implements Observer
+func (f ObserverFunc) OnFinish(ctx context.Context, event ValidationEvent) {
+	f(ctx, event)
+}
+
+// ===============================
+// Observer Registry
+// ===============================
+
+// ObserverRegistry holds the observers notified by validator chains
+type ObserverRegistry struct {
+	mu        sync.RWMutex
+	observers []*registeredObserver
+}
+
+// registeredObserver wraps an observer so that it can be unregistered by identity
+type registeredObserver struct {
+	observer Observer
+}
+
+// NewObserverRegistry creates an empty observer registry
+func NewObserverRegistry() *ObserverRegistry {
+	return &ObserverRegistry{}
+}
+
+var defaultObservers = NewObserverRegistry()
+
+// DefaultObserverRegistry returns the registry used by chains without an
+// explicit registry
+func DefaultObserverRegistry() *ObserverRegistry {
+	return defaultObservers
+}
+
+// Register adds an observer and returns a function that removes it again
+func (r *ObserverRegistry) Register(observer Observer) (unregister func()) {
+	entry := &registeredObserver{observer: observer}
+
+	r.mu.Lock()
+	r.observers = append(r.observers, entry)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i, registered := range r.observers {
+			if registered == entry {
+				r.observers = append(r.observers[:i:i], r.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of registered observers
+func (r *ObserverRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.observers)
+}
+
+// snapshot returns the currently registered observers
+func (r *ObserverRegistry) snapshot() []Observer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.observers) == 0 {
+		return nil
+	}
+	observers := make([]Observer, len(r.observers))
+	for i, registered := range r.observers {
+		observers[i] = registered.observer
+	}
+	return observers
+}
+
+// ===============================
+// Metrics Observer
+// ===============================
+
+// ValidationMetrics is a snapshot of the counters of a MetricsObserver
+type ValidationMetrics struct {
+	ValidationsTotal uint64            // Chain executions
+	FailuresTotal    uint64            // Chain executions with errors
+	WarningsTotal    uint64            // Chain executions with warnings
+	FailuresByCode   map[string]uint64 // Reported errors by error code
+	FailuresByChain  map[string]uint64 // Failed executions by chain name
+	TotalDuration    time.Duration     // Summed execution time
+}
+
+// MetricsObserver counts validations and failures by error code and chain,
+// showing which rules reject the most traffic
+type MetricsObserver struct {
+	mu      sync.Mutex
+	metrics ValidationMetrics
+}
+
+// NewMetricsObserver creates a metrics observer with zeroed counters
+func NewMetricsObserver() *MetricsObserver {
+	m := &MetricsObserver{}
+	m.Reset()
+	return m
+}
+
+// OnStart implements Observer and returns ctx unchanged
+func (m *MetricsObserver) OnStart(ctx context.Context, chain string) context.Context {
+	return ctx
+}
+
+// OnFinish implements Observer and updates the counters
+func (m *MetricsObserver) OnFinish(ctx context.Context, event ValidationEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metrics.ValidationsTotal++
+	m.metrics.TotalDuration += event.Duration
+	if event.Result.HasWarnings() {
+		m.metrics.WarningsTotal++
+	}
+	if event.Result.Valid {
+		return
+	}
+	m.metrics.FailuresTotal++
+	m.metrics.FailuresByChain[event.Chain]++
+	for _, err := range event.Result.Errors {
+		m.metrics.FailuresByCode[err.Code]++
+	}
+}
+
+// Snapshot returns a copy of the current counters
+func (m *MetricsObserver) Snapshot() ValidationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := m.metrics
+	snapshot.FailuresByCode = make(map[string]uint64, len(m.metrics.FailuresByCode))
+	for code, count := range m.metrics.FailuresByCode {
+		snapshot.FailuresByCode[code] = count
+	}
+	snapshot.FailuresByChain = make(map[string]uint64, len(m.metrics.FailuresByChain))
+	for chain, count := range m.metrics.FailuresByChain {
+		snapshot.FailuresByChain[chain] = count
+	}
+	return snapshot
+}
+
+// Reset sets all counters to zero
+func (m *MetricsObserver) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = ValidationMetrics{
+		FailuresByCode:  make(map[string]uint64),
+		FailuresByChain: make(map[string]uint64),
+	}
+}
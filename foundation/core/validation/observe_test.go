@@ -0,0 +1,126 @@
+// File: observe_test.go
+// Title: Validation Observability Hook Tests
+// Description: Tests observer registration, context propagation from
+//              observers to validators, and the metrics observer counters.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial observer hook tests
+
+package validation
+
+import (
+	"context"
+	"testing"
+)
+
+// spanKey is the context key used by the test tracing observer
+type spanKey struct{}
+
+// spanObserver records chain names and injects a span marker into the context
+type spanObserver struct {
+	started  []string
+	finished []ValidationEvent
+	spans    []interface{}
+}
+
+func (s *spanObserver) OnStart(ctx context.Context, chain string) context.Context {
+	s.started = append(s.started, chain)
+	return context.WithValue(ctx, spanKey{}, "span-"+chain)
+}
+
+func (s *spanObserver) OnFinish(ctx context.Context, event ValidationEvent) {
+	s.finished = append(s.finished, event)
+	s.spans = append(s.spans, ctx.Value(spanKey{}))
+}
+
+func TestObserverRegistry(t *testing.T) {
+	registry := NewObserverRegistry()
+	observer := &spanObserver{}
+	unregister := registry.Register(observer)
+
+	var seenSpan interface{}
+	chain := NewValidatorChain("email").
+		WithObservers(registry).
+		Add(nonEmpty).
+		Add(ValidatorFunc(func(value interface{}) ValidationResult { return NewValidationResult() }))
+	chain.Add(&contextProbe{seen: &seenSpan})
+
+	chain.Validate("")
+	if len(observer.started) != 1 || observer.started[0] != "email" {
+		t.Fatalf("started = %v", observer.started)
+	}
+	event := observer.finished[0]
+	if event.Chain != "email" || event.Executed != 3 || event.Result.Valid {
+		t.Errorf("event = %+v", event)
+	}
+	if seenSpan != "span-email" || observer.spans[0] != "span-email" {
+		t.Errorf("span not propagated: validator saw %v, OnFinish saw %v", seenSpan, observer.spans[0])
+	}
+
+	unregister()
+	chain.Validate("x")
+	if registry.Len() != 0 || len(observer.finished) != 1 {
+		t.Errorf("observer still notified after unregister")
+	}
+}
+
+// contextProbe records the span marker of the validation context
+type contextProbe struct {
+	seen *interface{}
+}
+
+func (p *contextProbe) Validate(value interface{}) ValidationResult {
+	return p.ValidateWithContext(context.Background(), value)
+}
+
+func (p *contextProbe) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	*p.seen = ctx.Value(spanKey{})
+	return NewValidationResult()
+}
+
+func TestMetricsObserver(t *testing.T) {
+	metrics := NewMetricsObserver()
+	unregister := DefaultObserverRegistry().Register(metrics)
+	defer unregister()
+
+	username := NewValidatorChain("username").Add(nonEmpty).Add(shortString)
+	username.Validate("Bob")
+	username.Validate("")
+	username.Validate("Bartholomew")
+	NewValidatorChain("nickname").Add(AsWarning(shortString)).Validate("Bartholomew")
+
+	snapshot := metrics.Snapshot()
+	if snapshot.ValidationsTotal != 4 || snapshot.FailuresTotal != 2 || snapshot.WarningsTotal != 1 {
+		t.Errorf("totals = %+v", snapshot)
+	}
+	if snapshot.FailuresByChain["username"] != 2 || snapshot.FailuresByChain["nickname"] != 0 {
+		t.Errorf("FailuresByChain = %v", snapshot.FailuresByChain)
+	}
+	if snapshot.FailuresByCode[CodeRequired] != 1 || snapshot.FailuresByCode[CodeLength] != 1 {
+		t.Errorf("FailuresByCode = %v", snapshot.FailuresByCode)
+	}
+
+	// Snapshots are independent of later updates
+	metrics.Reset()
+	if snapshot.ValidationsTotal != 4 || metrics.Snapshot().ValidationsTotal != 0 {
+		t.Error("Reset() changed earlier snapshot or did not reset counters")
+	}
+
+	// Chains with their own registry do not report to the default registry
+	NewValidatorChain("isolated").WithObservers(NewObserverRegistry()).Add(nonEmpty).Validate("")
+	if metrics.Snapshot().ValidationsTotal != 0 {
+		t.Error("chain with own registry notified default observers")
+	}
+
+	var calls int
+	stop := DefaultObserverRegistry().Register(ObserverFunc(func(ctx context.Context, event ValidationEvent) { calls++ }))
+	NewValidatorChain().Add(nonEmpty).Validate("x")
+	stop()
+	if calls != 1 {
+		t.Errorf("ObserverFunc calls = %d, want 1", calls)
+	}
+}
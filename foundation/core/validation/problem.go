@@ -0,0 +1,175 @@
+// File: problem.go
+// Title: Validation Result Serialization
+// Description: Serializes validation results for API responses, either as
+//              RFC 9457 problem details or as a compact JSON document. Both
+//              formats use stable field paths (JSON Pointers), error codes,
+//              and a deterministic order, and omit internal context and the
+//              rejected values.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of problem details and JSON output
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Problem details defaults
+const (
+	ProblemContentType     = "application/problem+json"        // Media type of problem details (RFC 9457)
+	ProblemTypeValidation  = "urn:problem-type:mdw:validation" // Problem type of validation failures
+	ProblemTitleValidation = "Validation failed"               // Problem title of validation failures
+)
+
+// ProblemOptions configures the problem details created from a result
+type ProblemOptions struct {
+	Type     string // Problem type URI
+	Title    string // Short summary of the problem type
+	Status   int    // HTTP status code
+	Detail   string // Explanation; default summarizes the error count
+	Instance string // URI of the specific occurrence, e.g. the request path
+}
+
+// DefaultProblemOptions returns the default problem options (400 Bad Request)
+func DefaultProblemOptions() ProblemOptions {
+	return ProblemOptions{
+		Type:   ProblemTypeValidation,
+		Title:  ProblemTitleValidation,
+		Status: http.StatusBadRequest,
+	}
+}
+
+// ProblemDetails is an RFC 9457 problem details document with the
+// validation errors and warnings as extension members
+type ProblemDetails struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []ProblemError `json:"errors,omitempty"`
+	Warnings []ProblemError `json:"warnings,omitempty"`
+}
+
+// ProblemError is the API representation of a validation error or warning
+type ProblemError struct {
+	Pointer    string                 `json:"pointer,omitempty"`    // JSON Pointer to the field, e.g. "#/address/zip"
+	Field      string                 `json:"field,omitempty"`      // Field path as reported by the validator
+	Code       string                 `json:"code"`                 // Standardized error code
+	Detail     string                 `json:"detail"`               // Human-readable (localized) message
+	MessageKey string                 `json:"messageKey,omitempty"` // i18n key for client-side translation
+	Params     map[string]interface{} `json:"params,omitempty"`     // Template data of the message
+}
+
+// ToProblemDetails converts the result to RFC 9457 problem details. Localize
+// the result first to return translated messages.
+func (r ValidationResult) ToProblemDetails(options ...ProblemOptions) ProblemDetails {
+	opts := DefaultProblemOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	problem := ProblemDetails{
+		Type:     opts.Type,
+		Title:    opts.Title,
+		Status:   opts.Status,
+		Detail:   opts.Detail,
+		Instance: opts.Instance,
+		Errors:   toProblemErrors(r.Errors),
+		Warnings: toProblemErrors(r.Warnings),
+	}
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Status == 0 {
+		problem.Status = http.StatusBadRequest
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Detail == "" && len(problem.Errors) > 0 {
+		problem.Detail = summarizeErrors(len(problem.Errors))
+	}
+	return problem
+}
+
+// ToJSON serializes the result as a compact JSON document with validity,
+// errors, and warnings in the ProblemError format
+func (r ValidationResult) ToJSON() ([]byte, error) {
+	document := struct {
+		Valid    bool           `json:"valid"`
+		Errors   []ProblemError `json:"errors,omitempty"`
+		Warnings []ProblemError `json:"warnings,omitempty"`
+	}{
+		Valid:    r.Valid,
+		Errors:   toProblemErrors(r.Errors),
+		Warnings: toProblemErrors(r.Warnings),
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize validation result: %w", err)
+	}
+	return data, nil
+}
+
+// FieldPointer converts a field path such as "items[0].name" to a JSON
+// Pointer fragment such as "#/items/0/name". Empty paths yield "".
+func FieldPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("#")
+	segments := strings.FieldsFunc(field, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	for _, segment := range segments {
+		builder.WriteString("/")
+		segment = strings.ReplaceAll(segment, "~", "~0")
+		builder.WriteString(strings.ReplaceAll(segment, "/", "~1"))
+	}
+	return builder.String()
+}
+
+// toProblemErrors converts validation errors to their API representation,
+// ordered by field path while keeping the order of errors per field
+func toProblemErrors(findings []ValidationError) []ProblemError {
+	if len(findings) == 0 {
+		return nil
+	}
+
+	problems := make([]ProblemError, len(findings))
+	for i, finding := range findings {
+		problems[i] = ProblemError{
+			Pointer:    FieldPointer(finding.Field),
+			Field:      finding.Field,
+			Code:       finding.Code,
+			Detail:     finding.Message,
+			MessageKey: finding.MessageKeys()[0],
+			Params:     finding.Params,
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Field < problems[j].Field
+	})
+	return problems
+}
+
+// summarizeErrors returns the default detail for count validation errors
+func summarizeErrors(count int) string {
+	if count == 1 {
+		return "1 validation error"
+	}
+	return fmt.Sprintf("%d validation errors", count)
+}
@@ -0,0 +1,107 @@
+// File: problem_test.go
+// Title: Validation Result Serialization Tests
+// Description: Tests RFC 9457 problem details, the compact JSON output,
+//              and the conversion of field paths to JSON Pointers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial serialization tests
+
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// invalidOrder returns a result with errors in non-deterministic field order
+func invalidOrder() ValidationResult {
+	result := NewValidationResult()
+	result.AddFieldError(CodeRequired, "name", "name is required", nil)
+	result.AddFieldError(CodeRange, "items[0].quantity", "must be positive", -1)
+	result.AddFieldWarning(CodeFormat, "email", "unusual format", "a@b")
+	result.Errors = append(result.Errors, NewValidationErrorWithKey(CodeLength, "validation.length_min", "too short", map[string]interface{}{"Min": 3}).Errors[0])
+	result.Errors[2].Field = "name"
+	result.WithContext("validatorIndex", 2)
+	return result
+}
+
+func TestToProblemDetails(t *testing.T) {
+	problem := invalidOrder().ToProblemDetails()
+
+	if problem.Type != ProblemTypeValidation || problem.Status != http.StatusBadRequest || problem.Detail != "3 validation errors" {
+		t.Errorf("problem = %+v", problem)
+	}
+	gotFields := []string{problem.Errors[0].Field, problem.Errors[1].Field, problem.Errors[2].Field}
+	if strings.Join(gotFields, ",") != "items[0].quantity,name,name" || problem.Errors[1].Code != CodeRequired {
+		t.Errorf("errors not ordered by field: %+v", problem.Errors)
+	}
+	if problem.Errors[0].Pointer != "#/items/0/quantity" || problem.Errors[2].MessageKey != "validation.length_min" {
+		t.Errorf("errors = %+v", problem.Errors)
+	}
+	if len(problem.Warnings) != 1 || problem.Warnings[0].Pointer != "#/email" {
+		t.Errorf("warnings = %+v", problem.Warnings)
+	}
+
+	custom := invalidOrder().ToProblemDetails(ProblemOptions{Status: http.StatusUnprocessableEntity, Instance: "/orders"})
+	if custom.Type != "about:blank" || custom.Title != "Unprocessable Entity" || custom.Instance != "/orders" {
+		t.Errorf("custom problem = %+v", custom)
+	}
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"type":"urn:problem-type:mdw:validation"`, `"status":400`, `"pointer":"#/name"`, `"params":{"Min":3}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("problem JSON %s lacks %s", data, want)
+		}
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	data, err := invalidOrder().ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	// Rejected values and internal context are not exposed
+	if strings.Contains(string(data), "validatorIndex") || strings.Contains(string(data), "a@b") {
+		t.Errorf("ToJSON() exposes internals: %s", data)
+	}
+
+	var decoded struct {
+		Valid  bool           `json:"valid"`
+		Errors []ProblemError `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Valid || len(decoded.Errors) != 3 || decoded.Errors[0].Code != CodeRange {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	if data, _ := NewValidationResult().ToJSON(); string(data) != `{"valid":true}` {
+		t.Errorf("valid ToJSON() = %s", data)
+	}
+}
+
+func TestFieldPointer(t *testing.T) {
+	tests := map[string]string{
+		"":              "",
+		"email":         "#/email",
+		"address.zip":   "#/address/zip",
+		"items[2].name": "#/items/2/name",
+		"a/b~c":         "#/a~1b~0c",
+		"matrix[1][0]":  "#/matrix/1/0",
+	}
+	for field, want := range tests {
+		if got := FieldPointer(field); got != want {
+			t.Errorf("FieldPointer(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
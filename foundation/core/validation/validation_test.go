@@ -4,9 +4,13 @@
 //              chains, error handling, and orchestration components. Does NOT test
 //              concrete validators - those belong in utils/validationx.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2025-01-25 v0.1.0: Initial framework tests
+// - 2026-10-16 v0.1.1: Added MaxErrors tests
 
 package validation
 
@@ -255,6 +259,43 @@ func TestValidatorChain(t *testing.T) {
 		}
 	})
 
+	t.Run("MaxErrors behavior", func(t *testing.T) {
+		var executed int
+		counting := ValidatorFunc(func(value interface{}) ValidationResult {
+			executed++
+			result := NewValidationError(CodeCustom, "first")
+			result.AddError(CodeCustom, "second")
+			return result
+		})
+		
+		chain := NewValidatorChain("max-errors").
+			MaxErrors(3).
+			Add(counting).
+			Add(counting).
+			Add(counting)
+		
+		result := chain.Validate("test-value")
+		if len(result.Errors) != 3 || executed != 2 {
+			t.Errorf("Expected 3 errors from 2 validators, got %d errors from %d", len(result.Errors), executed)
+		}
+		if result.Context["errorsTruncated"] != true {
+			t.Error("Expected errorsTruncated in context")
+		}
+		
+		// Collect-all reports every error
+		executed = 0
+		result = chain.MaxErrors(0).Validate("test-value")
+		if len(result.Errors) != 6 || executed != 3 || result.Context["errorsTruncated"] != nil {
+			t.Errorf("Expected 6 errors from 3 validators, got %d errors from %d", len(result.Errors), executed)
+		}
+		
+		// Limit reached exactly: no truncation
+		result = NewValidatorChain().MaxErrors(2).Add(counting).Add(counting).Validate("test-value")
+		if len(result.Errors) != 2 || result.Context["errorsTruncated"] != nil {
+			t.Errorf("Expected 2 errors without truncation, got %v", result.Context)
+		}
+	})
+
 	t.Run("Context propagation", func(t *testing.T) {
 		chain := NewValidatorChain("context-test").
 			WithContext("testKey", "testValue")
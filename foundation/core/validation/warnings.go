@@ -0,0 +1,127 @@
+// File: warnings.go
+// Title: Validation Warnings
+// Description: Provides warning-level validation findings that are reported
+//              alongside errors without making a result invalid. Validators
+//              can be downgraded to warnings, and chains can promote
+//              warnings back to errors for strict processing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of warning-level findings
+
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewValidationWarning creates a passing validation result with a single warning
+func NewValidationWarning(code, message string) ValidationResult {
+	return ValidationResult{
+		Valid: true,
+		Warnings: []ValidationError{
+			{
+				Code:    code,
+				Message: message,
+			},
+		},
+	}
+}
+
+// AddWarning adds a warning to an existing validation result without
+// changing its validity
+func (r *ValidationResult) AddWarning(code, message string) *ValidationResult {
+	r.Warnings = append(r.Warnings, ValidationError{
+		Code:    code,
+		Message: message,
+	})
+	return r
+}
+
+// AddFieldWarning adds a field-specific warning to the validation result
+func (r *ValidationResult) AddFieldWarning(code, field, message string, value interface{}) *ValidationResult {
+	r.Warnings = append(r.Warnings, ValidationError{
+		Code:    code,
+		Field:   field,
+		Message: message,
+		Value:   value,
+	})
+	return r
+}
+
+// HasWarnings reports whether the result contains any warnings
+func (r ValidationResult) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// HasWarning checks if the result contains a warning with a specific code
+func (r ValidationResult) HasWarning(code string) bool {
+	for _, warning := range r.Warnings {
+		if warning.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// WarningMessages returns all warning messages as a slice of strings
+func (r ValidationResult) WarningMessages() []string {
+	messages := make([]string, len(r.Warnings))
+	for i, warning := range r.Warnings {
+		messages[i] = warning.Message
+	}
+	return messages
+}
+
+// PromoteWarnings returns a copy of the result with all warnings turned
+// into errors. A result with warnings becomes invalid.
+func (r ValidationResult) PromoteWarnings() ValidationResult {
+	if len(r.Warnings) == 0 {
+		return r
+	}
+
+	errors := make([]ValidationError, 0, len(r.Errors)+len(r.Warnings))
+	errors = append(errors, r.Errors...)
+	errors = append(errors, r.Warnings...)
+	r.Errors = errors
+	r.Warnings = nil
+	r.Valid = false
+	return r
+}
+
+// WarningValidator reports the errors of a wrapped validator as warnings
+type WarningValidator struct {
+	validator Validator
+}
+
+// AsWarning wraps a validator so that its errors are reported as warnings
+// and the result stays valid, e.g. to accept imported rows with soft issues
+func AsWarning(validator Validator) *WarningValidator {
+	return &WarningValidator{validator: validator}
+}
+
+// Validate executes the wrapped validator and downgrades its errors
+func (w *WarningValidator) Validate(value interface{}) ValidationResult {
+	return w.ValidateWithContext(context.Background(), value)
+}
+
+// ValidateWithContext executes the wrapped validator with context support
+// and downgrades its errors
+func (w *WarningValidator) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	result := w.validator.ValidateWithContext(ctx, value)
+	if len(result.Errors) > 0 {
+		result.Warnings = append(result.Warnings, result.Errors...)
+		result.Errors = nil
+	}
+	result.Valid = true
+	return result
+}
+
+// String returns a string representation of the warning validator
+func (w *WarningValidator) String() string {
+	return fmt.Sprintf("WarningValidator{%v}", w.validator)
+}
@@ -0,0 +1,108 @@
+// File: warnings_test.go
+// Title: Validation Warning Tests
+// Description: Tests warning-level findings, downgrading validators to
+//              warnings, combining results, and the chain policy that
+//              treats warnings as errors.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial warning tests
+
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+// nonEmpty fails for empty strings
+var nonEmpty ValidatorFunc = func(value interface{}) ValidationResult {
+	if value == "" {
+		return NewValidationError(CodeRequired, "value required")
+	}
+	return NewValidationResult()
+}
+
+// shortString fails for strings longer than five characters
+var shortString ValidatorFunc = func(value interface{}) ValidationResult {
+	if s, ok := value.(string); ok && len(s) > 5 {
+		return NewValidationError(CodeLength, "value too long")
+	}
+	return NewValidationResult()
+}
+
+func TestValidationWarnings(t *testing.T) {
+	result := NewValidationWarning(CodeFormat, "unusual format")
+	result.AddFieldWarning(CodeLength, "name", "long name", "Bartholomew")
+
+	if !result.Valid || !result.HasWarnings() {
+		t.Fatalf("warning result = %+v, want valid with warnings", result)
+	}
+	if !result.HasWarning(CodeLength) || result.HasWarning(CodeRequired) {
+		t.Error("HasWarning() gave wrong result")
+	}
+	if got := result.WarningMessages(); len(got) != 2 || got[0] != "unusual format" {
+		t.Errorf("WarningMessages() = %v", got)
+	}
+	if !strings.Contains(result.String(), "warnings: 2") {
+		t.Errorf("String() = %s", result.String())
+	}
+
+	promoted := result.PromoteWarnings()
+	if promoted.Valid || len(promoted.Errors) != 2 || promoted.HasWarnings() {
+		t.Errorf("PromoteWarnings() = %+v", promoted)
+	}
+	if !result.Valid || len(result.Warnings) != 2 {
+		t.Error("PromoteWarnings() modified the original result")
+	}
+
+	combined := Combine(NewValidationError(CodeRequired, "missing"), result, NewValidationResult())
+	if combined.Valid || len(combined.Errors) != 1 || len(combined.Warnings) != 2 {
+		t.Errorf("Combine() = %+v", combined)
+	}
+}
+
+func TestAsWarning(t *testing.T) {
+	chain := NewValidatorChain("name").Add(nonEmpty).Add(AsWarning(shortString))
+
+	result := chain.Validate("Bartholomew")
+	if !result.Valid || !result.HasWarning(CodeLength) || len(result.Errors) != 0 {
+		t.Errorf("Validate() = %+v, want valid with length warning", result)
+	}
+
+	result = chain.Validate("")
+	if result.Valid || result.HasWarnings() {
+		t.Errorf("Validate(\"\") = %+v, want error without warnings", result)
+	}
+
+	chain.WarningsAsErrors(true)
+	result = chain.Validate("Bartholomew")
+	if result.Valid || !result.HasError(CodeLength) || result.HasWarnings() {
+		t.Errorf("strict Validate() = %+v, want length error", result)
+	}
+	if !chain.Validate("Bob").Valid {
+		t.Error("strict Validate() rejected a value without findings")
+	}
+
+	stopping := NewValidatorChain().Add(AsWarning(shortString)).Add(nonEmpty).
+		WarningsAsErrors(true).StopOnFirstError(true)
+	if result := stopping.Validate("Bartholomew"); result.Context["executedValidators"] != 1 {
+		t.Errorf("promoted warning did not stop the chain: %v", result.Context)
+	}
+}
+
+func TestValidationWarnings_Localize(t *testing.T) {
+	translator := mapTranslator{"de": {"validation.length": "Wert ist zu lang"}}
+	result := AsWarning(shortString).Validate("Bartholomew")
+
+	localized := result.Localize(translator, "de")
+	if got := localized.WarningMessages(); len(got) != 1 || got[0] != "Wert ist zu lang" {
+		t.Errorf("Localize() warnings = %q", got)
+	}
+	if result.Warnings[0].Message != "value too long" {
+		t.Error("Localize() modified the original warnings")
+	}
+}
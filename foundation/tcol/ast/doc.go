@@ -4,12 +4,14 @@
 //              representing parsed TCOL commands. Provides visitor patterns
 //              and tree manipulation utilities.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial AST implementation
+// - 2026-10-16 v0.1.1: Documented walking, rewriting and the built-in passes
+// - 2026-10-16 v0.1.2: Documented canonical text and JSON encoding
 
 /*
 Package ast defines the Abstract Syntax Tree structures for TCOL commands.
@@ -22,5 +24,58 @@ The AST enables:
   • Command analysis and optimization
   • Code generation and transformation
   • Static analysis and validation
+
+# Walking and Rewriting
+
+Walk visits every node of a tree before its children; returning false
+skips the children:
+
+	ast.Walk(cmd, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.IdentifierExpr); ok {
+			fields = append(fields, ident.Name)
+		}
+		return true
+	})
+
+Rewrite replaces nodes bottom-up: the function receives each node after
+its children and returns the node to keep or its replacement. The tree is
+changed in place. Apply runs named passes over a tree in order:
+
+	cmd, err := ast.Apply(cmd,
+		ast.FoldConstants(),
+		ast.Normalize(),
+		ast.ExpandAbbreviations(registry.ExpandAbbreviation),
+	)
+
+The built-in passes are:
+
+  • FoldConstants evaluates operators on literals with the semantics of
+    the filter engine: [total > 10 * 100] becomes [total > 1000], and
+    [active AND true] becomes [active]
+  • Normalize upper-cases objects, methods and word operators, writes
+    "==" as "=", and moves literals to the right of comparisons
+  • ExpandAbbreviations replaces abbreviated commands such as CUST.LS with
+    their full form
+
+# Serialization
+
+Command.String returns the canonical TCOL text of a command: parameters in
+sorted order, strings quoted, and filter operations parenthesized. It parses
+back to the same command, so canonical text can be stored and executed
+again:
+
+	CUSTOMER[name LIKE "A%" AND age >= 18].LIST tag=vip
+	→ CUSTOMER[((name LIKE "A%") AND (age >= 18))].LIST tag="vip"
+
+Commands also encode to JSON, with filters as trees of expressions tagged
+by kind, so they can be queued and sent between services as structured
+data:
+
+	data, err := json.Marshal(cmd)
+
+	var cmd ast.Command
+	err := json.Unmarshal(data, &cmd)
+
+Numbers keep their integer or float type. Source positions are not encoded.
 */
 package ast
\ No newline at end of file
@@ -4,12 +4,19 @@
 //              including commands, expressions, filters, and parameters.
 //              Provides string representations and validation methods.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.7
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial AST node definitions
+// - 2026-10-16 v0.1.1: Added BetweenExpr and IsNullExpr for the filter grammar
+// - 2026-10-16 v0.1.2: Added interpolating string values
+// - 2026-10-16 v0.1.3: Added scripts, LET statements, and variable references
+// - 2026-10-16 v0.1.4: Added IF and FOREACH statements
+// - 2026-10-16 v0.1.5: Added transform stages of pipes
+// - 2026-10-16 v0.1.6: Command.String returns canonical syntax that parses back
+// - 2026-10-16 v0.1.7: Added explicit method versions (METHOD@v2)
 
 package ast
 
@@ -47,14 +54,37 @@ type Position struct {
 type Command struct {
 	Object     string            // Object name (e.g., "CUSTOMER")
 	Method     string            // Method name (e.g., "CREATE")
+	Version    int               // Explicit method version (METHOD@v2); 0 for the current version
 	Parameters map[string]Value  // Method parameters
 	Filter     *FilterExpr       // Optional filter expression
 	ObjectID   string            // Direct object ID access (OBJECT:ID)
 	FieldOp    *FieldOperation   // Field operation (OBJECT:ID:field=value)
 	Chain      *Command          // Next command in chain
+	Transform  *Transform        // Local transform stage of a pipe; no object or method
 	Pos        Position          // Source position
 }
 
+// TransformKind identifies a built-in transform stage
+type TransformKind string
+
+// Transform stages of pipes
+const (
+	TransformSelect TransformKind = "SELECT" // SELECT field, ...
+	TransformSort   TransformKind = "SORT"   // SORT BY field [ASC|DESC], ...
+	TransformLimit  TransformKind = "LIMIT"  // LIMIT count
+	TransformGroup  TransformKind = "GROUP"  // GROUP BY field, ...
+)
+
+// Transform is a pipe stage that is executed locally on the result of the
+// previous stage instead of being sent to a service
+type Transform struct {
+	Kind       TransformKind
+	Fields     []string // Field paths (name or name.sub) of SELECT, SORT BY, and GROUP BY
+	Descending []bool   // Sort direction of each SORT BY field
+	Limit      int      // Row count of LIMIT
+	Pos        Position // Source position
+}
+
 // FilterExpr represents a filter expression [condition]
 type FilterExpr struct {
 	Condition Expr     // Filter condition expression
@@ -81,6 +111,10 @@ type Value struct {
 	Raw   string      // Raw string representation
 	Value interface{} // Parsed value
 	Pos   Position    // Source position
+
+	// Interpolate marks strings from triple quotes or unquoted heredocs.
+	// Raw keeps their \$ escapes; Value has them resolved.
+	Interpolate bool
 }
 
 // ValueType represents the type of a value
@@ -95,6 +129,7 @@ const (
 	ValueTypeNull
 	ValueTypeArray
 	ValueTypeObject
+	ValueTypeVariable // $name or $name.field; Value holds the path without '$'
 )
 
 // String returns string representation of ValueType
@@ -116,6 +151,8 @@ func (vt ValueType) String() string {
 		return "array"
 	case ValueTypeObject:
 		return "object"
+	case ValueTypeVariable:
+		return "variable"
 	default:
 		return "unknown"
 	}
@@ -123,10 +160,10 @@ func (vt ValueType) String() string {
 
 // Expression types
 
-// BinaryExpr represents a binary expression (a AND b, a = b, etc.)
+// BinaryExpr represents a binary expression (a AND b, a = b, a + b, etc.)
 type BinaryExpr struct {
 	Left  Expr     // Left operand
-	Op    string   // Operator (AND, OR, =, !=, <, >, etc.)
+	Op    string   // Operator (AND, OR, =, !=, <, >, LIKE, IN, +, -, *, /, %)
 	Right Expr     // Right operand
 	Pos   Position // Source position
 }
@@ -169,62 +206,127 @@ type ObjectExpr struct {
 	Pos    Position        // Source position
 }
 
+// BetweenExpr represents a range test (a BETWEEN low AND high), inclusive
+// on both ends; NOT BETWEEN is a NOT UnaryExpr around it
+type BetweenExpr struct {
+	Expr Expr     // Tested expression
+	Low  Expr     // Lower bound
+	High Expr     // Upper bound
+	Pos  Position // Source position
+}
+
+// IsNullExpr represents a null test (a IS NULL); IS NOT NULL is a NOT
+// UnaryExpr around it
+type IsNullExpr struct {
+	Expr Expr     // Tested expression
+	Pos  Position // Source position
+}
+
+// Statement represents a top-level statement of a script
+type Statement interface {
+	Node
+	stmtNode() // marker method
+}
+
+// Script represents a sequence of statements separated by newlines or ';'
+type Script struct {
+	Statements []Statement // Statements in execution order
+	Pos        Position    // Source position
+}
+
+// LetStmt binds a variable to the result of a command or to a value:
+// LET id = CUSTOMER.CREATE name="ACME" or LET limit = 100
+type LetStmt struct {
+	Name    string   // Variable name without '$'
+	Command *Command // Command whose result data is bound
+	Value   *Value   // Value bound if Command is nil
+	Pos     Position // Source position
+}
+
+// IfStmt runs one of two blocks depending on a filter expression:
+// IF condition THEN ... ELSE ... END
+type IfStmt struct {
+	Condition Expr        // Filter expression evaluated against the variables
+	Then      []Statement // Statements run if the condition holds
+	Else      []Statement // Statements run otherwise (optional)
+	Pos       Position    // Source position
+}
+
+// ForEachStmt runs a block once per element of a list:
+// FOREACH item IN $list DO ... END or FOREACH item IN OBJECT.LIST DO ... END
+type ForEachStmt struct {
+	Name    string      // Loop variable name without '$'
+	Command *Command    // Command whose result data is iterated
+	Value   *Value      // Variable iterated if Command is nil
+	Body    []Statement // Statements run per element
+	Pos     Position    // Source position
+}
+
 // Implementation of Node interface for Command
 
+// String returns the command in canonical TCOL syntax, which parses back to
+// the same command: parameters in sorted order, strings quoted, and filter
+// operations parenthesized
 func (c *Command) String() string {
-	var parts []string
+	var b strings.Builder
 
-	// Object and method
-	if !mdwstringx.IsBlank(c.ObjectID) {
-		parts = append(parts, fmt.Sprintf("%s:%s", c.Object, c.ObjectID))
-		
-		// Field operation
+	switch {
+	case c.Transform != nil:
+		b.WriteString(c.Transform.String())
+	case !mdwstringx.IsBlank(c.ObjectID):
+		b.WriteString(c.Object + ":" + c.ObjectID)
 		if c.FieldOp != nil {
+			b.WriteString(":" + c.FieldOp.Field)
 			if c.FieldOp.Op == "=" {
-				parts = append(parts, fmt.Sprintf(":%s=%s", c.FieldOp.Field, c.FieldOp.Value.String()))
-			} else {
-				parts = append(parts, fmt.Sprintf(":%s", c.FieldOp.Field))
+				b.WriteString("=" + canonicalValue(c.FieldOp.Value))
 			}
 		}
-	} else {
-		objectPart := c.Object
-		
-		// Add filter if present
+	default:
+		b.WriteString(c.Object)
 		if c.Filter != nil {
-			objectPart += c.Filter.String()
+			b.WriteString("[" + canonicalExpr(c.Filter.Condition) + "]")
 		}
-		
-		parts = append(parts, fmt.Sprintf("%s.%s", objectPart, c.Method))
-	}
-
-	// Parameters
-	if len(c.Parameters) > 0 {
-		var paramParts []string
-		for key, value := range c.Parameters {
-			paramParts = append(paramParts, fmt.Sprintf("%s=%s", key, value.String()))
+		b.WriteString("." + c.Method)
+		if c.Version > 0 {
+			b.WriteString(fmt.Sprintf("@v%d", c.Version))
 		}
-		parts = append(parts, strings.Join(paramParts, " "))
 	}
 
-	result := strings.Join(parts, " ")
+	for _, name := range sortedKeys(c.Parameters) {
+		b.WriteString(" " + name + "=" + canonicalValue(c.Parameters[name]))
+	}
 
-	// Chain
 	if c.Chain != nil {
-		result += " | " + c.Chain.String()
+		b.WriteString(" | " + c.Chain.String())
 	}
 
-	return result
+	return b.String()
 }
 
 func (c *Command) Accept(visitor Visitor) interface{} {
 	return visitor.VisitCommand(c)
 }
 
+func (c *Command) stmtNode() {}
+
 func (c *Command) Position() Position {
 	return c.Pos
 }
 
 func (c *Command) Validate() error {
+	// Transform stages have no object
+	if c.Transform != nil {
+		if err := c.Transform.Validate(); err != nil {
+			return fmt.Errorf("transform: %w", err)
+		}
+		if c.Chain != nil {
+			if err := c.Chain.Validate(); err != nil {
+				return fmt.Errorf("chain: %w", err)
+			}
+		}
+		return nil
+	}
+
 	// Object name is required
 	if mdwstringx.IsBlank(c.Object) {
 		return fmt.Errorf("object name is required")
@@ -289,6 +391,55 @@ func (c *Command) HasFilter() bool {
 	return c.Filter != nil
 }
 
+// IsTransform returns true if this is a transform stage of a pipe
+func (c *Command) IsTransform() bool {
+	return c.Transform != nil
+}
+
+// String returns the transform stage in TCOL syntax
+func (t *Transform) String() string {
+	switch t.Kind {
+	case TransformLimit:
+		return fmt.Sprintf("LIMIT %d", t.Limit)
+	case TransformSort:
+		fields := make([]string, len(t.Fields))
+		for i, field := range t.Fields {
+			fields[i] = field
+			if i < len(t.Descending) && t.Descending[i] {
+				fields[i] += " DESC"
+			}
+		}
+		return "SORT BY " + strings.Join(fields, ", ")
+	case TransformGroup:
+		return "GROUP BY " + strings.Join(t.Fields, ", ")
+	default:
+		return string(t.Kind) + " " + strings.Join(t.Fields, ", ")
+	}
+}
+
+// Validate checks that the transform stage is complete
+func (t *Transform) Validate() error {
+	switch t.Kind {
+	case TransformLimit:
+		if t.Limit < 1 {
+			return fmt.Errorf("LIMIT must be at least 1, got %d", t.Limit)
+		}
+		return nil
+	case TransformSelect, TransformSort, TransformGroup:
+		if len(t.Fields) == 0 {
+			return fmt.Errorf("%s requires at least one field", t.Kind)
+		}
+		for _, field := range t.Fields {
+			if mdwstringx.IsBlank(field) {
+				return fmt.Errorf("%s field name cannot be empty", t.Kind)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown transform %q", t.Kind)
+	}
+}
+
 // Implementation of Node interface for FilterExpr
 
 func (f *FilterExpr) String() string {
@@ -344,6 +495,9 @@ func (fo *FieldOperation) Validate() error {
 func (v *Value) String() string {
 	switch v.Type {
 	case ValueTypeString:
+		if v.Interpolate {
+			return `"""` + v.Raw + `"""`
+		}
 		if strings.Contains(v.Raw, " ") || strings.Contains(v.Raw, "\"") {
 			return fmt.Sprintf(`"%s"`, strings.ReplaceAll(v.Raw, `"`, `\"`))
 		}
@@ -390,6 +544,11 @@ func (v *Value) Validate() error {
 		return nil
 	case ValueTypeNull:
 		return nil // Null is always valid
+	case ValueTypeVariable:
+		if name, ok := v.Value.(string); !ok || mdwstringx.IsBlank(name) {
+			return fmt.Errorf("invalid variable reference: %v", v.Raw)
+		}
+		return nil
 	case ValueTypeArray:
 		// Validate array elements
 		if arr, ok := v.Value.([]interface{}); ok {
@@ -423,6 +582,9 @@ func (v *Value) Validate() error {
 func (v *Value) GetStringValue() string {
 	switch v.Type {
 	case ValueTypeString:
+		if s, ok := v.Value.(string); ok && v.Interpolate {
+			return s
+		}
 		return v.Raw
 	case ValueTypeNumber, ValueTypeBoolean:
 		return fmt.Sprintf("%v", v.Value)
@@ -642,4 +804,220 @@ func (oe *ObjectExpr) Validate() error {
 	return nil
 }
 
-func (oe *ObjectExpr) exprNode() {}
\ No newline at end of file
+func (oe *ObjectExpr) exprNode() {}
+
+func (be *BetweenExpr) String() string {
+	return fmt.Sprintf("(%s BETWEEN %s AND %s)", be.Expr.String(), be.Low.String(), be.High.String())
+}
+
+func (be *BetweenExpr) Accept(visitor Visitor) interface{} {
+	return visitor.VisitBetween(be)
+}
+
+func (be *BetweenExpr) Position() Position {
+	return be.Pos
+}
+
+func (be *BetweenExpr) Validate() error {
+	if be.Expr == nil || be.Low == nil || be.High == nil {
+		return fmt.Errorf("BETWEEN requires an operand and two bounds")
+	}
+
+	if err := be.Expr.Validate(); err != nil {
+		return fmt.Errorf("operand: %w", err)
+	}
+	if err := be.Low.Validate(); err != nil {
+		return fmt.Errorf("lower bound: %w", err)
+	}
+	if err := be.High.Validate(); err != nil {
+		return fmt.Errorf("upper bound: %w", err)
+	}
+
+	return nil
+}
+
+func (be *BetweenExpr) exprNode() {}
+
+func (ine *IsNullExpr) String() string {
+	return fmt.Sprintf("(%s IS NULL)", ine.Expr.String())
+}
+
+func (ine *IsNullExpr) Accept(visitor Visitor) interface{} {
+	return visitor.VisitIsNull(ine)
+}
+
+func (ine *IsNullExpr) Position() Position {
+	return ine.Pos
+}
+
+func (ine *IsNullExpr) Validate() error {
+	if ine.Expr == nil {
+		return fmt.Errorf("operand is required")
+	}
+	return ine.Expr.Validate()
+}
+
+func (ine *IsNullExpr) exprNode() {}
+
+// Implementation of Node interface for Script
+
+func (s *Script) String() string {
+	lines := make([]string, len(s.Statements))
+	for i, stmt := range s.Statements {
+		lines[i] = stmt.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *Script) Accept(visitor Visitor) interface{} {
+	return visitor.VisitScript(s)
+}
+
+func (s *Script) Position() Position {
+	return s.Pos
+}
+
+func (s *Script) Validate() error {
+	return validateBlock(s.Statements)
+}
+
+// Implementation of Node interface for LetStmt
+
+func (ls *LetStmt) String() string {
+	if ls.Command != nil {
+		return fmt.Sprintf("LET %s = %s", ls.Name, ls.Command.String())
+	}
+	if ls.Value != nil {
+		return fmt.Sprintf("LET %s = %s", ls.Name, ls.Value.String())
+	}
+	return fmt.Sprintf("LET %s", ls.Name)
+}
+
+func (ls *LetStmt) Accept(visitor Visitor) interface{} {
+	return visitor.VisitLet(ls)
+}
+
+func (ls *LetStmt) Position() Position {
+	return ls.Pos
+}
+
+func (ls *LetStmt) Validate() error {
+	if mdwstringx.IsBlank(ls.Name) {
+		return fmt.Errorf("variable name is required")
+	}
+	if (ls.Command == nil) == (ls.Value == nil) {
+		return fmt.Errorf("LET %s requires either a command or a value", ls.Name)
+	}
+	if ls.Command != nil {
+		return ls.Command.Validate()
+	}
+	return ls.Value.Validate()
+}
+
+func (ls *LetStmt) stmtNode() {}
+
+// Implementation of Node interface for IfStmt
+
+func (is *IfStmt) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("IF %s THEN\n", is.Condition.String()))
+	writeBlock(&builder, is.Then)
+	if len(is.Else) > 0 {
+		builder.WriteString("ELSE\n")
+		writeBlock(&builder, is.Else)
+	}
+	builder.WriteString("END")
+	return builder.String()
+}
+
+func (is *IfStmt) Accept(visitor Visitor) interface{} {
+	return visitor.VisitIf(is)
+}
+
+func (is *IfStmt) Position() Position {
+	return is.Pos
+}
+
+func (is *IfStmt) Validate() error {
+	if is.Condition == nil {
+		return fmt.Errorf("IF requires a condition")
+	}
+	if err := is.Condition.Validate(); err != nil {
+		return fmt.Errorf("condition: %w", err)
+	}
+	if err := validateBlock(is.Then); err != nil {
+		return fmt.Errorf("THEN: %w", err)
+	}
+	if err := validateBlock(is.Else); err != nil {
+		return fmt.Errorf("ELSE: %w", err)
+	}
+	return nil
+}
+
+func (is *IfStmt) stmtNode() {}
+
+// Implementation of Node interface for ForEachStmt
+
+func (fs *ForEachStmt) String() string {
+	var builder strings.Builder
+	source := ""
+	if fs.Command != nil {
+		source = fs.Command.String()
+	} else if fs.Value != nil {
+		source = fs.Value.String()
+	}
+	builder.WriteString(fmt.Sprintf("FOREACH %s IN %s DO\n", fs.Name, source))
+	writeBlock(&builder, fs.Body)
+	builder.WriteString("END")
+	return builder.String()
+}
+
+func (fs *ForEachStmt) Accept(visitor Visitor) interface{} {
+	return visitor.VisitForEach(fs)
+}
+
+func (fs *ForEachStmt) Position() Position {
+	return fs.Pos
+}
+
+func (fs *ForEachStmt) Validate() error {
+	if mdwstringx.IsBlank(fs.Name) {
+		return fmt.Errorf("loop variable name is required")
+	}
+	if (fs.Command == nil) == (fs.Value == nil) {
+		return fmt.Errorf("FOREACH %s requires either a command or a variable", fs.Name)
+	}
+	if fs.Value != nil && fs.Value.Type != ValueTypeVariable {
+		return fmt.Errorf("FOREACH %s iterates a variable, got %s", fs.Name, fs.Value.Type)
+	}
+	if fs.Command != nil {
+		if err := fs.Command.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateBlock(fs.Body)
+}
+
+func (fs *ForEachStmt) stmtNode() {}
+
+// writeBlock writes the statements of a block, indented by one tab
+func writeBlock(builder *strings.Builder, stmts []Statement) {
+	for _, stmt := range stmts {
+		for _, line := range strings.Split(stmt.String(), "\n") {
+			builder.WriteString("\t" + line + "\n")
+		}
+	}
+}
+
+// validateBlock validates the statements of a block
+func validateBlock(stmts []Statement) error {
+	for i, stmt := range stmts {
+		if stmt == nil {
+			return fmt.Errorf("statement %d is missing", i+1)
+		}
+		if err := stmt.Validate(); err != nil {
+			return fmt.Errorf("statement %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
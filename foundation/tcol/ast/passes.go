@@ -0,0 +1,265 @@
+// File: passes.go
+// Title: TCOL AST Rewriting Passes
+// Description: Built-in rewriting passes: expansion of command
+//              abbreviations, constant folding in filter expressions, and
+//              normalization of commands and operators to canonical form.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial abbreviation, folding and normalization passes
+
+package ast
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExpandAbbreviations returns a pass that replaces abbreviated commands
+// with their full form. expand maps "OBJ.METH" to "OBJECT.METHOD" and
+// returns its argument for unknown abbreviations, as the registry's
+// ExpandAbbreviation does.
+func ExpandAbbreviations(expand func(abbrev string) string) Pass {
+	return Pass{
+		Name: "expand-abbreviations",
+		Rewrite: func(node Node) (Node, error) {
+			cmd, ok := node.(*Command)
+			if !ok || cmd.Object == "" || cmd.Method == "" {
+				return node, nil
+			}
+			abbrev := cmd.Object + "." + cmd.Method
+			full := expand(abbrev)
+			if object, method, found := strings.Cut(full, "."); found && full != abbrev {
+				cmd.Object, cmd.Method = object, method
+			}
+			return cmd, nil
+		},
+	}
+}
+
+// FoldConstants returns a pass that evaluates the parts of expressions
+// whose operands are literals, with the semantics of the filter engine:
+// arithmetic on integers stays integral except for "/", "+" concatenates
+// strings, comparisons of numbers yield booleans, and AND, OR and NOT with
+// a boolean literal are simplified. Division by zero is left to fail at
+// run time.
+func FoldConstants() Pass {
+	return Pass{Name: "fold-constants", Rewrite: foldConstants}
+}
+
+func foldConstants(node Node) (Node, error) {
+	switch expr := node.(type) {
+	case *BinaryExpr:
+		if folded := foldBinary(expr); folded != nil {
+			return folded, nil
+		}
+	case *UnaryExpr:
+		if folded := foldUnary(expr); folded != nil {
+			return folded, nil
+		}
+	}
+	return node, nil
+}
+
+func foldBinary(expr *BinaryExpr) Expr {
+	op := strings.ToUpper(expr.Op)
+
+	switch op {
+	case "AND", "OR":
+		// x AND true = x, x AND false = false, x OR true = true,
+		// x OR false = x; also with the literal on the left
+		if b, ok := boolValue(expr.Left); ok {
+			if b == (op == "AND") {
+				return expr.Right
+			}
+			return expr.Left
+		}
+		if b, ok := boolValue(expr.Right); ok {
+			if b == (op == "AND") {
+				return expr.Left
+			}
+			return expr.Right
+		}
+		return nil
+	}
+
+	left, lok := expr.Left.(*LiteralExpr)
+	right, rok := expr.Right.(*LiteralExpr)
+	if !lok || !rok {
+		return nil
+	}
+
+	if op == "+" && isPlainString(left.Value) && isPlainString(right.Value) {
+		return stringLiteral(left.Value.GetStringValue()+right.Value.GetStringValue(), expr.Pos)
+	}
+
+	l, lnum := left.Value.Value.(int64)
+	r, rnum := right.Value.Value.(int64)
+	if lnum && rnum && left.Value.Type == ValueTypeNumber && right.Value.Type == ValueTypeNumber {
+		switch op {
+		case "+":
+			return intLiteral(l+r, expr.Pos)
+		case "-":
+			return intLiteral(l-r, expr.Pos)
+		case "*":
+			return intLiteral(l*r, expr.Pos)
+		case "%":
+			if r == 0 {
+				return nil
+			}
+			return intLiteral(l%r, expr.Pos)
+		}
+	}
+
+	lf, lnum := numberValue(left)
+	rf, rnum := numberValue(right)
+	if !lnum || !rnum {
+		return nil
+	}
+	switch op {
+	case "+":
+		return floatLiteral(lf+rf, expr.Pos)
+	case "-":
+		return floatLiteral(lf-rf, expr.Pos)
+	case "*":
+		return floatLiteral(lf*rf, expr.Pos)
+	case "/":
+		if rf == 0 {
+			return nil
+		}
+		return floatLiteral(lf/rf, expr.Pos)
+	case "%":
+		if rf == 0 {
+			return nil
+		}
+		return floatLiteral(math.Mod(lf, rf), expr.Pos)
+	case "=", "==":
+		return boolLiteral(lf == rf, expr.Pos)
+	case "!=", "<>":
+		return boolLiteral(lf != rf, expr.Pos)
+	case "<":
+		return boolLiteral(lf < rf, expr.Pos)
+	case "<=":
+		return boolLiteral(lf <= rf, expr.Pos)
+	case ">":
+		return boolLiteral(lf > rf, expr.Pos)
+	case ">=":
+		return boolLiteral(lf >= rf, expr.Pos)
+	}
+	return nil
+}
+
+func foldUnary(expr *UnaryExpr) Expr {
+	switch strings.ToUpper(expr.Op) {
+	case "NOT":
+		if b, ok := boolValue(expr.Expr); ok {
+			return boolLiteral(!b, expr.Pos)
+		}
+	case "-":
+		if literal, ok := expr.Expr.(*LiteralExpr); ok && literal.Value.Type == ValueTypeNumber {
+			switch v := literal.Value.Value.(type) {
+			case int64:
+				return intLiteral(-v, expr.Pos)
+			case float64:
+				return floatLiteral(-v, expr.Pos)
+			}
+		}
+	}
+	return nil
+}
+
+// boolValue returns the value of a boolean literal
+func boolValue(expr Expr) (bool, bool) {
+	literal, ok := expr.(*LiteralExpr)
+	if !ok || literal.Value.Type != ValueTypeBoolean {
+		return false, false
+	}
+	return literal.Value.GetBoolValue()
+}
+
+// numberValue returns the value of a number literal
+func numberValue(literal *LiteralExpr) (float64, bool) {
+	if literal.Value.Type != ValueTypeNumber {
+		return 0, false
+	}
+	return literal.Value.GetNumberValue()
+}
+
+// isPlainString reports whether a value is a string without interpolation
+func isPlainString(value Value) bool {
+	return value.Type == ValueTypeString && !value.Interpolate
+}
+
+func intLiteral(v int64, pos Position) Expr {
+	return &LiteralExpr{Value: Value{Type: ValueTypeNumber, Raw: strconv.FormatInt(v, 10), Value: v, Pos: pos}, Pos: pos}
+}
+
+// floatLiteral keeps a decimal point in Raw, so the literal parses as a
+// float again; infinite results are not folded
+func floatLiteral(v float64, pos Position) Expr {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	raw := strconv.FormatFloat(v, 'f', -1, 64)
+	if !strings.Contains(raw, ".") {
+		raw += ".0"
+	}
+	return &LiteralExpr{Value: Value{Type: ValueTypeNumber, Raw: raw, Value: v, Pos: pos}, Pos: pos}
+}
+
+func boolLiteral(v bool, pos Position) Expr {
+	return &LiteralExpr{Value: Value{Type: ValueTypeBoolean, Raw: strconv.FormatBool(v), Value: v, Pos: pos}, Pos: pos}
+}
+
+func stringLiteral(v string, pos Position) Expr {
+	return &LiteralExpr{Value: Value{Type: ValueTypeString, Raw: v, Value: v, Pos: pos}, Pos: pos}
+}
+
+// Normalize returns a pass that rewrites a tree to canonical form: object
+// and method names in upper case, word operators such as AND and LIKE in
+// upper case, "==" as "=" and "<>" as "!=", and comparisons with a
+// literal on the left flipped so the literal is on the right ("5 < age"
+// becomes "age > 5"). Run FoldConstants first to flip comparisons with
+// folded operands.
+func Normalize() Pass {
+	return Pass{Name: "normalize", Rewrite: normalize}
+}
+
+// flippedComparisons maps comparison operators to their mirror image
+var flippedComparisons = map[string]string{
+	"=": "=", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<=",
+}
+
+func normalize(node Node) (Node, error) {
+	switch n := node.(type) {
+	case *Command:
+		n.Object = strings.ToUpper(n.Object)
+		n.Method = strings.ToUpper(n.Method)
+	case *BinaryExpr:
+		n.Op = normalizeOperator(n.Op)
+		if flipped, ok := flippedComparisons[n.Op]; ok {
+			_, leftLiteral := n.Left.(*LiteralExpr)
+			_, rightLiteral := n.Right.(*LiteralExpr)
+			if leftLiteral && !rightLiteral {
+				n.Left, n.Right, n.Op = n.Right, n.Left, flipped
+			}
+		}
+	case *UnaryExpr:
+		n.Op = normalizeOperator(n.Op)
+	}
+	return node, nil
+}
+
+func normalizeOperator(op string) string {
+	switch op {
+	case "==":
+		return "="
+	case "<>":
+		return "!="
+	}
+	return strings.ToUpper(op)
+}
@@ -0,0 +1,305 @@
+// File: rewrite.go
+// Title: TCOL AST Traversal and Rewriting
+// Description: Implements Walk for read-only traversal and Rewrite for
+//              bottom-up replacement of AST nodes, and runs named rewriting
+//              passes over a tree for linters and optimizers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial traversal and rewriting API
+
+package ast
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Walk traverses the tree rooted at node in depth-first order. It calls fn
+// for each node before its children and skips the children if fn returns
+// false. Parameters and object fields are visited in sorted order.
+// Transform stages have no child nodes.
+func Walk(node Node, fn func(Node) bool) {
+	if node == nil || !fn(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Script:
+		walkStatements(n.Statements, fn)
+	case *Command:
+		for _, name := range sortedKeys(n.Parameters) {
+			value := n.Parameters[name]
+			Walk(&value, fn)
+		}
+		if n.Filter != nil {
+			Walk(n.Filter, fn)
+		}
+		if n.FieldOp != nil {
+			Walk(n.FieldOp, fn)
+		}
+		if n.Chain != nil {
+			Walk(n.Chain, fn)
+		}
+	case *FilterExpr:
+		Walk(n.Condition, fn)
+	case *FieldOperation:
+		if n.Op == "=" {
+			Walk(&n.Value, fn)
+		}
+	case *BinaryExpr:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *UnaryExpr:
+		Walk(n.Expr, fn)
+	case *LiteralExpr:
+		Walk(&n.Value, fn)
+	case *FunctionCallExpr:
+		for _, arg := range n.Args {
+			Walk(arg, fn)
+		}
+	case *ArrayExpr:
+		for _, element := range n.Elements {
+			Walk(element, fn)
+		}
+	case *ObjectExpr:
+		for _, name := range sortedKeys(n.Fields) {
+			Walk(n.Fields[name], fn)
+		}
+	case *BetweenExpr:
+		Walk(n.Expr, fn)
+		Walk(n.Low, fn)
+		Walk(n.High, fn)
+	case *IsNullExpr:
+		Walk(n.Expr, fn)
+	case *LetStmt:
+		if n.Command != nil {
+			Walk(n.Command, fn)
+		} else if n.Value != nil {
+			Walk(n.Value, fn)
+		}
+	case *IfStmt:
+		Walk(n.Condition, fn)
+		walkStatements(n.Then, fn)
+		walkStatements(n.Else, fn)
+	case *ForEachStmt:
+		if n.Command != nil {
+			Walk(n.Command, fn)
+		} else if n.Value != nil {
+			Walk(n.Value, fn)
+		}
+		walkStatements(n.Body, fn)
+	}
+}
+
+func walkStatements(statements []Statement, fn func(Node) bool) {
+	for _, stmt := range statements {
+		Walk(stmt, fn)
+	}
+}
+
+// RewriteFunc returns the replacement of a node whose children have already
+// been rewritten; it returns the node itself to keep it. The replacement
+// must fit the place of the node: an expression for an expression, a
+// statement for a statement, and a node of the same type otherwise.
+type RewriteFunc func(node Node) (Node, error)
+
+// Rewrite rewrites the tree rooted at node bottom-up with fn and returns
+// the new root. The tree is modified in place, so callers that need the
+// original tree must parse it again. A replacement that does not fit its
+// place is an error; on errors the tree may be partially rewritten, but
+// no node is lost.
+func Rewrite(node Node, fn RewriteFunc) (Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+	if err := rewriteChildren(node, fn); err != nil {
+		return nil, err
+	}
+	return fn(node)
+}
+
+func rewriteChildren(node Node, fn RewriteFunc) error {
+	switch n := node.(type) {
+	case *Script:
+		return rewriteStatements(n.Statements, fn)
+	case *Command:
+		for _, name := range sortedKeys(n.Parameters) {
+			value := n.Parameters[name]
+			replaced := &value
+			if err := rewriteInto(&replaced, fn); err != nil {
+				return fmt.Errorf("parameter %s: %w", name, err)
+			}
+			n.Parameters[name] = *replaced
+		}
+		if n.Filter != nil {
+			if err := rewriteInto(&n.Filter, fn); err != nil {
+				return err
+			}
+		}
+		if n.FieldOp != nil {
+			if err := rewriteInto(&n.FieldOp, fn); err != nil {
+				return err
+			}
+		}
+		if n.Chain != nil {
+			return rewriteInto(&n.Chain, fn)
+		}
+	case *FilterExpr:
+		return rewriteExprs(fn, &n.Condition)
+	case *FieldOperation:
+		if n.Op == "=" {
+			return rewriteValue(&n.Value, fn)
+		}
+	case *BinaryExpr:
+		return rewriteExprs(fn, &n.Left, &n.Right)
+	case *UnaryExpr:
+		return rewriteExprs(fn, &n.Expr)
+	case *LiteralExpr:
+		return rewriteValue(&n.Value, fn)
+	case *FunctionCallExpr:
+		return rewriteExprList(n.Args, fn)
+	case *ArrayExpr:
+		return rewriteExprList(n.Elements, fn)
+	case *ObjectExpr:
+		for _, name := range sortedKeys(n.Fields) {
+			field := n.Fields[name]
+			if err := rewriteExprs(fn, &field); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			n.Fields[name] = field
+		}
+	case *BetweenExpr:
+		return rewriteExprs(fn, &n.Expr, &n.Low, &n.High)
+	case *IsNullExpr:
+		return rewriteExprs(fn, &n.Expr)
+	case *LetStmt:
+		if n.Command != nil {
+			return rewriteInto(&n.Command, fn)
+		}
+		if n.Value != nil {
+			return rewriteInto(&n.Value, fn)
+		}
+	case *IfStmt:
+		if err := rewriteExprs(fn, &n.Condition); err != nil {
+			return err
+		}
+		if err := rewriteStatements(n.Then, fn); err != nil {
+			return err
+		}
+		return rewriteStatements(n.Else, fn)
+	case *ForEachStmt:
+		var err error
+		if n.Command != nil {
+			err = rewriteInto(&n.Command, fn)
+		} else if n.Value != nil {
+			err = rewriteInto(&n.Value, fn)
+		}
+		if err != nil {
+			return err
+		}
+		return rewriteStatements(n.Body, fn)
+	}
+	return nil
+}
+
+// rewriteInto rewrites the node in slot, whose replacement must have the
+// same type; slot is only changed on success
+func rewriteInto[T Node](slot *T, fn RewriteFunc) error {
+	rewritten, err := Rewrite(*slot, fn)
+	if err != nil {
+		return err
+	}
+	replaced, ok := rewritten.(T)
+	if !ok {
+		return fmt.Errorf("cannot replace %T with %T", *slot, rewritten)
+	}
+	*slot = replaced
+	return nil
+}
+
+// rewriteValue rewrites a value stored by value in its parent
+func rewriteValue(value *Value, fn RewriteFunc) error {
+	replaced := value
+	if err := rewriteInto(&replaced, fn); err != nil {
+		return err
+	}
+	*value = *replaced
+	return nil
+}
+
+// rewriteExprs rewrites the expressions in slots; nil expressions are
+// skipped
+func rewriteExprs(fn RewriteFunc, slots ...*Expr) error {
+	for _, slot := range slots {
+		if *slot == nil {
+			continue
+		}
+		rewritten, err := Rewrite(*slot, fn)
+		if err != nil {
+			return err
+		}
+		replaced, ok := rewritten.(Expr)
+		if !ok {
+			return fmt.Errorf("cannot replace expression %T with %T", *slot, rewritten)
+		}
+		*slot = replaced
+	}
+	return nil
+}
+
+func rewriteExprList(exprs []Expr, fn RewriteFunc) error {
+	for i := range exprs {
+		if err := rewriteExprs(fn, &exprs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rewriteStatements(statements []Statement, fn RewriteFunc) error {
+	for i, stmt := range statements {
+		rewritten, err := Rewrite(stmt, fn)
+		if err != nil {
+			return err
+		}
+		replaced, ok := rewritten.(Statement)
+		if !ok {
+			return fmt.Errorf("cannot replace statement %T with %T", stmt, rewritten)
+		}
+		statements[i] = replaced
+	}
+	return nil
+}
+
+// Pass is a named rewriting pass, such as the built-in ExpandAbbreviations,
+// FoldConstants and Normalize
+type Pass struct {
+	Name    string      // Name used in errors
+	Rewrite RewriteFunc // Rewrites a single node
+}
+
+// Apply runs the passes over the tree rooted at node in the given order and
+// returns the new root
+func Apply(node Node, passes ...Pass) (Node, error) {
+	for _, pass := range passes {
+		rewritten, err := Rewrite(node, pass.Rewrite)
+		if err != nil {
+			return nil, fmt.Errorf("pass %s: %w", pass.Name, err)
+		}
+		node = rewritten
+	}
+	return node, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
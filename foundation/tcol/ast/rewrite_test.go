@@ -0,0 +1,249 @@
+// File: rewrite_test.go
+// Title: TCOL AST Traversal and Rewriting Tests
+// Description: Tests Walk, Rewrite and Apply on parsed commands and scripts,
+//              and the built-in abbreviation, folding and normalization
+//              passes.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial traversal, rewriting and pass tests
+
+package ast_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+)
+
+func newParser(t *testing.T) *mdwparser.Parser {
+	t.Helper()
+	p, err := mdwparser.New(mdwparser.Options{EnableChaining: true})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	return p
+}
+
+func parseCommand(t *testing.T, input string) *mdwast.Command {
+	t.Helper()
+	cmd, err := newParser(t).Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", input, err)
+	}
+	return cmd
+}
+
+func parseFilter(t *testing.T, input string) mdwast.Expr {
+	t.Helper()
+	expr, err := newParser(t).ParseExpression(input)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", input, err)
+	}
+	return expr
+}
+
+func TestWalk(t *testing.T) {
+	cmd := parseCommand(t, `CUSTOMER[age BETWEEN 18 AND 65 OR name IS NULL].LIST limit=10 active=true`)
+
+	var visited []string
+	mdwast.Walk(cmd, func(node mdwast.Node) bool {
+		visited = append(visited, fmt.Sprintf("%T", node))
+		return true
+	})
+	expected := "*ast.Command *ast.Value *ast.Value *ast.FilterExpr *ast.BinaryExpr " +
+		"*ast.BetweenExpr *ast.IdentifierExpr *ast.LiteralExpr *ast.Value *ast.LiteralExpr *ast.Value " +
+		"*ast.IsNullExpr *ast.IdentifierExpr"
+	if got := strings.Join(visited, " "); got != expected {
+		t.Errorf("Walk() visited\n%s\nwant\n%s", got, expected)
+	}
+
+	// Returning false skips the children
+	count := 0
+	mdwast.Walk(cmd, func(node mdwast.Node) bool {
+		count++
+		_, isFilter := node.(*mdwast.FilterExpr)
+		return !isFilter
+	})
+	if count != 4 {
+		t.Errorf("Walk() without filter children visited %d nodes, want 4", count)
+	}
+}
+
+func TestWalk_Script(t *testing.T) {
+	script, err := newParser(t).ParseScript("LET n = 3\nIF n > 2 THEN\nCUSTOMER.LIST\nELSE\nFOREACH c IN ORDER.LIST DO\nORDER.SHOW id=$c\nEND\nEND")
+	if err != nil {
+		t.Fatalf("ParseScript() error = %v", err)
+	}
+
+	var commands []string
+	mdwast.Walk(script, func(node mdwast.Node) bool {
+		if cmd, ok := node.(*mdwast.Command); ok {
+			commands = append(commands, cmd.Object+"."+cmd.Method)
+		}
+		return true
+	})
+	if got := strings.Join(commands, " "); got != "CUSTOMER.LIST ORDER.LIST ORDER.SHOW" {
+		t.Errorf("commands = %s", got)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	cmd := parseCommand(t, `CUSTOMER[status = "old" AND NOT archived].LIST`)
+
+	// Replace identifiers bottom-up
+	root, err := mdwast.Rewrite(cmd, func(node mdwast.Node) (mdwast.Node, error) {
+		if ident, ok := node.(*mdwast.IdentifierExpr); ok && ident.Name == "archived" {
+			return &mdwast.IdentifierExpr{Name: "deleted", Pos: ident.Pos}, nil
+		}
+		return node, nil
+	})
+	if err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+	if got := root.(*mdwast.Command).Filter.Condition.String(); got != "((status = old) AND (NOT deleted))" {
+		t.Errorf("rewritten filter = %s", got)
+	}
+
+	// A replacement that does not fit its place is an error
+	_, err = mdwast.Rewrite(cmd, func(node mdwast.Node) (mdwast.Node, error) {
+		if _, ok := node.(*mdwast.FilterExpr); ok {
+			return &mdwast.IdentifierExpr{Name: "x"}, nil
+		}
+		return node, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "cannot replace *ast.FilterExpr") {
+		t.Errorf("Rewrite() with misplaced node error = %v", err)
+	}
+
+	// Errors of the rewrite function are returned
+	failure := errors.New("rejected")
+	_, err = mdwast.Rewrite(cmd, func(node mdwast.Node) (mdwast.Node, error) {
+		if _, ok := node.(*mdwast.UnaryExpr); ok {
+			return nil, failure
+		}
+		return node, nil
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Rewrite() error = %v, want %v", err, failure)
+	}
+	if cmd.Filter == nil || cmd.Filter.Condition == nil {
+		t.Error("failed rewrites removed nodes from the tree")
+	}
+}
+
+func TestExpandAbbreviations(t *testing.T) {
+	abbreviations := map[string]string{"CUST.LS": "CUSTOMER.LIST", "ORD.SH": "ORDER.SHOW"}
+	expand := func(abbrev string) string {
+		if full, ok := abbreviations[abbrev]; ok {
+			return full
+		}
+		return abbrev
+	}
+
+	cmd := parseCommand(t, `CUST[active = true].LS | ORD.SH | INVOICE.LIST`)
+	if _, err := mdwast.Apply(cmd, mdwast.ExpandAbbreviations(expand)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	var commands []string
+	for c := cmd; c != nil; c = c.Chain {
+		commands = append(commands, c.Object+"."+c.Method)
+	}
+	if got := strings.Join(commands, " "); got != "CUSTOMER.LIST ORDER.SHOW INVOICE.LIST" {
+		t.Errorf("expanded commands = %s", got)
+	}
+}
+
+func TestFoldConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`total > 10 * 100`, "(total > 1000)"},
+		{`total > 1 + 2 * 3 - 4`, "(total > 3)"},
+		{`total > 7 / 2`, "(total > 3.5)"},
+		{`total > 6 / 2`, "(total > 3.0)"},
+		{`total > 7 % 4`, "(total > 3)"},
+		{`total > 1.5 * 2`, "(total > 3.0)"},
+		{`total > 1 / 0`, "(total > (1 / 0))"},
+		{`total > -(2 + 3)`, "(total > -5)"},
+		{`name = "Acme" + " Corp"`, `(name = "Acme Corp")`},
+		{`active AND 2 > 1`, "active"},
+		{`1 > 2 AND active`, "false"},
+		{`active OR NOT false`, "true"},
+		{`active OR 1 = 2`, "active"},
+		{`active AND total > 2 + 2`, "(active AND (total > 4))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			folded, err := mdwast.Apply(parseFilter(t, tt.input), mdwast.FoldConstants())
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got := folded.String(); got != tt.expected {
+				t.Errorf("folded = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+
+	// Folded integers and floats keep their type
+	folded, _ := mdwast.Apply(parseFilter(t, `2 * 3`), mdwast.FoldConstants())
+	if value := folded.(*mdwast.LiteralExpr).Value.Value; value != int64(6) {
+		t.Errorf("2 * 3 = %#v, want int64(6)", value)
+	}
+	folded, _ = mdwast.Apply(parseFilter(t, `6 / 3`), mdwast.FoldConstants())
+	if value := folded.(*mdwast.LiteralExpr).Value.Value; value != float64(2) {
+		t.Errorf("6 / 3 = %#v, want float64(2)", value)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cmd := parseCommand(t, `customer[100 < revenue and not (name like "A%") or 5 == level].list`)
+	if _, err := mdwast.Apply(cmd, mdwast.Normalize()); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if cmd.Object != "CUSTOMER" || cmd.Method != "LIST" {
+		t.Errorf("command = %s.%s, want CUSTOMER.LIST", cmd.Object, cmd.Method)
+	}
+	expected := `(((revenue > 100) AND (NOT (name LIKE A%))) OR (level = 5))`
+	if got := cmd.Filter.Condition.String(); got != expected {
+		t.Errorf("normalized filter = %s, want %s", got, expected)
+	}
+}
+
+func TestApply(t *testing.T) {
+	expand := func(abbrev string) string {
+		if abbrev == "CUST.LS" {
+			return "CUSTOMER.LIST"
+		}
+		return abbrev
+	}
+
+	// Folding lets normalization flip the comparison, and normalization
+	// lets the lower-case abbreviation be expanded
+	cmd := parseCommand(t, `cust[3 * 1000 <= revenue].ls`)
+	_, err := mdwast.Apply(cmd, mdwast.FoldConstants(), mdwast.Normalize(), mdwast.ExpandAbbreviations(expand))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got := cmd.Object + "." + cmd.Method + " " + cmd.Filter.Condition.String(); got != "CUSTOMER.LIST (revenue >= 3000)" {
+		t.Errorf("Apply() = %s", got)
+	}
+
+	failing := mdwast.Pass{Name: "reject", Rewrite: func(node mdwast.Node) (mdwast.Node, error) {
+		return nil, errors.New("rejected")
+	}}
+	if _, err := mdwast.Apply(cmd, failing); err == nil || err.Error() != "pass reject: rejected" {
+		t.Errorf("Apply() error = %v, want pass reject: rejected", err)
+	}
+}
@@ -0,0 +1,504 @@
+// File: serialize.go
+// Title: TCOL Command Serialization
+// Description: Canonical TCOL text of commands that parses back to the same
+//              command, and JSON encoding of commands with their filters,
+//              field operations, transforms and chains, so commands can be
+//              queued, stored and sent between services as structured data.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial canonical text and JSON encoding
+// - 2026-10-16 v0.1.1: Encodes method versions
+
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Canonical text
+
+// canonicalValue returns a value in TCOL syntax. Strings are always quoted,
+// so they do not parse back as identifiers, keywords or numbers.
+func canonicalValue(v Value) string {
+	switch v.Type {
+	case ValueTypeString:
+		if v.Interpolate {
+			return `"""` + v.Raw + `"""`
+		}
+		return quoteRaw(v.Raw)
+	case ValueTypeNull:
+		return "null"
+	default:
+		return v.Raw
+	}
+}
+
+// quoteRaw quotes the raw text of a string. Raw keeps escape sequences as
+// typed, so they are copied unchanged; quotes that are not escaped decide
+// between double and single quotes.
+func quoteRaw(raw string) string {
+	quote := byte('"')
+	if hasBareQuote(raw, '"') && !hasBareQuote(raw, '\'') {
+		quote = '\''
+	}
+
+	var b strings.Builder
+	b.WriteByte(quote)
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			b.WriteByte('\\')
+			if i+1 < len(raw) {
+				i++
+				b.WriteByte(raw[i])
+			} else {
+				b.WriteByte('\\')
+			}
+		case quote:
+			b.WriteByte('\\')
+			b.WriteByte(quote)
+		default:
+			b.WriteByte(raw[i])
+		}
+	}
+	b.WriteByte(quote)
+	return b.String()
+}
+
+// hasBareQuote reports whether raw contains quote without a backslash
+// before it
+func hasBareQuote(raw string, quote byte) bool {
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '\\' {
+			i++
+		} else if raw[i] == quote {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalExpr returns an expression in TCOL syntax. Unlike String, string
+// literals are quoted; every operation is parenthesized.
+func canonicalExpr(expr Expr) string {
+	switch e := expr.(type) {
+	case *BinaryExpr:
+		return fmt.Sprintf("(%s %s %s)", canonicalExpr(e.Left), e.Op, canonicalExpr(e.Right))
+	case *UnaryExpr:
+		return fmt.Sprintf("(%s %s)", e.Op, canonicalExpr(e.Expr))
+	case *LiteralExpr:
+		return canonicalValue(e.Value)
+	case *FunctionCallExpr:
+		return fmt.Sprintf("%s(%s)", e.Name, canonicalExprs(e.Args))
+	case *ArrayExpr:
+		return fmt.Sprintf("[%s]", canonicalExprs(e.Elements))
+	case *ObjectExpr:
+		fields := make([]string, 0, len(e.Fields))
+		for _, name := range sortedKeys(e.Fields) {
+			fields = append(fields, fmt.Sprintf("%s: %s", name, canonicalExpr(e.Fields[name])))
+		}
+		return fmt.Sprintf("{%s}", strings.Join(fields, ", "))
+	case *BetweenExpr:
+		return fmt.Sprintf("(%s BETWEEN %s AND %s)", canonicalExpr(e.Expr), canonicalExpr(e.Low), canonicalExpr(e.High))
+	case *IsNullExpr:
+		return fmt.Sprintf("(%s IS NULL)", canonicalExpr(e.Expr))
+	case nil:
+		return ""
+	default:
+		return expr.String()
+	}
+}
+
+func canonicalExprs(exprs []Expr) string {
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		parts[i] = canonicalExpr(expr)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// JSON encoding
+
+// commandJSON is the JSON form of a Command. Source positions are not
+// encoded.
+type commandJSON struct {
+	Object     string               `json:"object,omitempty"`
+	Method     string               `json:"method,omitempty"`
+	Version    int                  `json:"version,omitempty"`
+	Parameters map[string]valueJSON `json:"parameters,omitempty"`
+	Filter     *exprJSON            `json:"filter,omitempty"`
+	ObjectID   string               `json:"object_id,omitempty"`
+	Field      *fieldJSON           `json:"field,omitempty"`
+	Transform  *transformJSON       `json:"transform,omitempty"`
+	Chain      *Command             `json:"chain,omitempty"`
+}
+
+type fieldJSON struct {
+	Name  string     `json:"name"`
+	Op    string     `json:"op,omitempty"`
+	Value *valueJSON `json:"value,omitempty"`
+}
+
+type transformJSON struct {
+	Kind       TransformKind `json:"kind"`
+	Fields     []string      `json:"fields,omitempty"`
+	Descending []bool        `json:"descending,omitempty"`
+	Limit      int           `json:"limit,omitempty"`
+}
+
+// valueJSON is the JSON form of a Value. The parsed value is derived from
+// the raw text when decoding; it is encoded for readers in other languages.
+type valueJSON struct {
+	Type        string      `json:"type"`
+	Raw         string      `json:"raw"`
+	Value       interface{} `json:"value,omitempty"`
+	Interpolate bool        `json:"interpolate,omitempty"`
+}
+
+// exprJSON is the JSON form of an expression, tagged with its kind
+type exprJSON struct {
+	Kind    string               `json:"kind"`
+	Op      string               `json:"op,omitempty"`
+	Name    string               `json:"name,omitempty"`
+	Value   *valueJSON           `json:"value,omitempty"`
+	Left    *exprJSON            `json:"left,omitempty"`
+	Right   *exprJSON            `json:"right,omitempty"`
+	Operand *exprJSON            `json:"operand,omitempty"`
+	Low     *exprJSON            `json:"low,omitempty"`
+	High    *exprJSON            `json:"high,omitempty"`
+	Items   []*exprJSON          `json:"items,omitempty"`
+	Fields  map[string]*exprJSON `json:"fields,omitempty"`
+}
+
+// Expression kinds in JSON
+const (
+	exprKindBinary     = "binary"
+	exprKindUnary      = "unary"
+	exprKindIdentifier = "identifier"
+	exprKindLiteral    = "literal"
+	exprKindCall       = "call"
+	exprKindArray      = "array"
+	exprKindObject     = "object"
+	exprKindBetween    = "between"
+	exprKindIsNull     = "is_null"
+)
+
+// MarshalJSON encodes the command with its filter, field operation,
+// transform and chain; source positions are not encoded
+func (c *Command) MarshalJSON() ([]byte, error) {
+	out := commandJSON{
+		Object:   c.Object,
+		Method:   c.Method,
+		Version:  c.Version,
+		ObjectID: c.ObjectID,
+		Chain:    c.Chain,
+	}
+
+	if len(c.Parameters) > 0 {
+		out.Parameters = make(map[string]valueJSON, len(c.Parameters))
+		for name, value := range c.Parameters {
+			out.Parameters[name] = encodeValue(value)
+		}
+	}
+	if c.Filter != nil {
+		filter, err := encodeExpr(c.Filter.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+		out.Filter = filter
+	}
+	if c.FieldOp != nil {
+		out.Field = &fieldJSON{Name: c.FieldOp.Field, Op: c.FieldOp.Op}
+		if c.FieldOp.Op == "=" {
+			value := encodeValue(c.FieldOp.Value)
+			out.Field.Value = &value
+		}
+	}
+	if c.Transform != nil {
+		out.Transform = &transformJSON{
+			Kind:       c.Transform.Kind,
+			Fields:     c.Transform.Fields,
+			Descending: c.Transform.Descending,
+			Limit:      c.Transform.Limit,
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a command encoded by MarshalJSON
+func (c *Command) UnmarshalJSON(data []byte) error {
+	var in commandJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	cmd := Command{
+		Object:   in.Object,
+		Method:   in.Method,
+		Version:  in.Version,
+		ObjectID: in.ObjectID,
+		Chain:    in.Chain,
+	}
+
+	if len(in.Parameters) > 0 {
+		cmd.Parameters = make(map[string]Value, len(in.Parameters))
+		for name, encoded := range in.Parameters {
+			value, err := decodeValue(encoded)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", name, err)
+			}
+			cmd.Parameters[name] = value
+		}
+	}
+	if in.Filter != nil {
+		condition, err := decodeExpr(in.Filter)
+		if err != nil {
+			return fmt.Errorf("filter: %w", err)
+		}
+		cmd.Filter = &FilterExpr{Condition: condition}
+	}
+	if in.Field != nil {
+		cmd.FieldOp = &FieldOperation{Field: in.Field.Name, Op: in.Field.Op}
+		if in.Field.Value != nil {
+			value, err := decodeValue(*in.Field.Value)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", in.Field.Name, err)
+			}
+			cmd.FieldOp.Value = value
+		}
+	}
+	if in.Transform != nil {
+		cmd.Transform = &Transform{
+			Kind:       in.Transform.Kind,
+			Fields:     in.Transform.Fields,
+			Descending: in.Transform.Descending,
+			Limit:      in.Transform.Limit,
+		}
+	}
+
+	*c = cmd
+	return nil
+}
+
+func encodeValue(v Value) valueJSON {
+	return valueJSON{Type: v.Type.String(), Raw: v.Raw, Value: v.Value, Interpolate: v.Interpolate}
+}
+
+// decodeValue restores a value; scalar values are parsed from the raw text
+// as the parser does, so numbers keep their integer or float type
+func decodeValue(in valueJSON) (Value, error) {
+	v := Value{Raw: in.Raw, Interpolate: in.Interpolate}
+
+	switch in.Type {
+	case "string":
+		v.Type = ValueTypeString
+		v.Value = in.Raw
+		if in.Interpolate {
+			v.Value = strings.ReplaceAll(in.Raw, `\$`, "$")
+		}
+	case "number":
+		v.Type = ValueTypeNumber
+		if strings.Contains(in.Raw, ".") {
+			f, err := strconv.ParseFloat(in.Raw, 64)
+			if err != nil {
+				return Value{}, fmt.Errorf("invalid number: %s", in.Raw)
+			}
+			v.Value = f
+		} else {
+			i, err := strconv.ParseInt(in.Raw, 10, 64)
+			if err != nil {
+				return Value{}, fmt.Errorf("invalid number: %s", in.Raw)
+			}
+			v.Value = i
+		}
+	case "boolean":
+		v.Type = ValueTypeBoolean
+		v.Value = strings.ToLower(in.Raw) == "true"
+	case "null":
+		v.Type = ValueTypeNull
+	case "variable":
+		v.Type = ValueTypeVariable
+		v.Value = strings.TrimPrefix(in.Raw, "$")
+	case "date":
+		v.Type, v.Value = ValueTypeDate, in.Value
+	case "time":
+		v.Type, v.Value = ValueTypeTime, in.Value
+	case "array":
+		v.Type, v.Value = ValueTypeArray, in.Value
+	case "object":
+		v.Type, v.Value = ValueTypeObject, in.Value
+	default:
+		return Value{}, fmt.Errorf("unknown value type %q", in.Type)
+	}
+
+	return v, nil
+}
+
+func encodeExpr(expr Expr) (*exprJSON, error) {
+	var err error
+	out := &exprJSON{}
+
+	switch e := expr.(type) {
+	case *BinaryExpr:
+		out.Kind, out.Op = exprKindBinary, e.Op
+		if out.Left, err = encodeExpr(e.Left); err == nil {
+			out.Right, err = encodeExpr(e.Right)
+		}
+	case *UnaryExpr:
+		out.Kind, out.Op = exprKindUnary, e.Op
+		out.Operand, err = encodeExpr(e.Expr)
+	case *IdentifierExpr:
+		out.Kind, out.Name = exprKindIdentifier, e.Name
+	case *LiteralExpr:
+		value := encodeValue(e.Value)
+		out.Kind, out.Value = exprKindLiteral, &value
+	case *FunctionCallExpr:
+		out.Kind, out.Name = exprKindCall, e.Name
+		out.Items, err = encodeExprs(e.Args)
+	case *ArrayExpr:
+		out.Kind = exprKindArray
+		out.Items, err = encodeExprs(e.Elements)
+	case *ObjectExpr:
+		out.Kind = exprKindObject
+		out.Fields = make(map[string]*exprJSON, len(e.Fields))
+		for name, field := range e.Fields {
+			if out.Fields[name], err = encodeExpr(field); err != nil {
+				break
+			}
+		}
+	case *BetweenExpr:
+		out.Kind = exprKindBetween
+		if out.Operand, err = encodeExpr(e.Expr); err == nil {
+			if out.Low, err = encodeExpr(e.Low); err == nil {
+				out.High, err = encodeExpr(e.High)
+			}
+		}
+	case *IsNullExpr:
+		out.Kind = exprKindIsNull
+		out.Operand, err = encodeExpr(e.Expr)
+	case nil:
+		return nil, fmt.Errorf("missing expression")
+	default:
+		return nil, fmt.Errorf("cannot encode expression %T", expr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func encodeExprs(exprs []Expr) ([]*exprJSON, error) {
+	out := make([]*exprJSON, len(exprs))
+	for i, expr := range exprs {
+		encoded, err := encodeExpr(expr)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = encoded
+	}
+	return out, nil
+}
+
+func decodeExpr(in *exprJSON) (Expr, error) {
+	if in == nil {
+		return nil, fmt.Errorf("missing expression")
+	}
+
+	switch in.Kind {
+	case exprKindBinary:
+		left, err := decodeExpr(in.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := decodeExpr(in.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &BinaryExpr{Left: left, Op: in.Op, Right: right}, nil
+	case exprKindUnary:
+		operand, err := decodeExpr(in.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Op: in.Op, Expr: operand}, nil
+	case exprKindIdentifier:
+		return &IdentifierExpr{Name: in.Name}, nil
+	case exprKindLiteral:
+		if in.Value == nil {
+			return nil, fmt.Errorf("literal without value")
+		}
+		value, err := decodeValue(*in.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &LiteralExpr{Value: value}, nil
+	case exprKindCall:
+		args, err := decodeExprs(in.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &FunctionCallExpr{Name: in.Name, Args: args}, nil
+	case exprKindArray:
+		elements, err := decodeExprs(in.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &ArrayExpr{Elements: elements}, nil
+	case exprKindObject:
+		fields := make(map[string]Expr, len(in.Fields))
+		for name, field := range in.Fields {
+			decoded, err := decodeExpr(field)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			fields[name] = decoded
+		}
+		return &ObjectExpr{Fields: fields}, nil
+	case exprKindBetween:
+		operand, err := decodeExpr(in.Operand)
+		if err != nil {
+			return nil, err
+		}
+		low, err := decodeExpr(in.Low)
+		if err != nil {
+			return nil, err
+		}
+		high, err := decodeExpr(in.High)
+		if err != nil {
+			return nil, err
+		}
+		return &BetweenExpr{Expr: operand, Low: low, High: high}, nil
+	case exprKindIsNull:
+		operand, err := decodeExpr(in.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return &IsNullExpr{Expr: operand}, nil
+	default:
+		return nil, fmt.Errorf("unknown expression kind %q", in.Kind)
+	}
+}
+
+func decodeExprs(in []*exprJSON) ([]Expr, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make([]Expr, len(in))
+	for i, encoded := range in {
+		decoded, err := decodeExpr(encoded)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = decoded
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+// File: serialize_test.go
+// Title: TCOL Command Serialization Tests
+// Description: Tests that the canonical text and the JSON encoding of parsed
+//              commands round-trip through the parser and the decoder.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial serialization tests
+// - 2026-10-16 v0.1.1: Added method versions
+
+package ast_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// roundTripInputs cover parameters of every type, the filter grammar,
+// object access, field operations, chains, transforms and method versions
+var roundTripInputs = []string{
+	`CUSTOMER.CREATE name="Acme Corp" active=true revenue=1500.50 count=3 note=null owner=$user tag=vip`,
+	`CUSTOMER[(status = "open" OR status = 'hold') AND revenue BETWEEN 10 AND 20.5].LIST limit=10`,
+	`CUSTOMER[deleted_at IS NOT NULL AND name NOT LIKE "A%" AND NOT active].LIST`,
+	`ORDER[id IN (1, 2, 3) AND -(a + b) < -1 AND is_overdue() AND total % 2 == 0].LIST`,
+	`ORDER.LIST status="open" | INVOICE.LIST | SORT BY total DESC, name | LIMIT 5`,
+	`CUSTOMER.LIST | SELECT name, address.city | GROUP BY address.city`,
+	`CUSTOMER:123:name="New Name"`,
+	`CUSTOMER:C-42:email`,
+	`CUSTOMER:42`,
+	`CUSTOMER[status = "new"].UPDATE@v2 status="active" | INVOICE.LIST@v3`,
+	`EMAIL.SEND subject='say "hi"' body="""Total: \$100 for $user""" note="it's \"quoted\""`,
+	"EMAIL.SEND body=<<EOF\nline one\nline two\nEOF\n",
+}
+
+func TestCommand_StringRoundTrip(t *testing.T) {
+	for _, input := range roundTripInputs {
+		t.Run(input, func(t *testing.T) {
+			cmd := parseCommand(t, input)
+			canonical := cmd.String()
+
+			again := parseCommand(t, canonical)
+			if got := again.String(); got != canonical {
+				t.Errorf("String() is not stable:\n%s\n%s", canonical, got)
+			}
+			if first, second := mustMarshal(t, cmd), mustMarshal(t, again); first != second {
+				t.Errorf("reparsed command differs:\n%s\n%s", first, second)
+			}
+		})
+	}
+}
+
+func TestCommand_String(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`customer.create  tag=vip name=Acme`, `customer.create name="Acme" tag="vip"`},
+		{`CUSTOMER[name LIKE "A%" AND age >= 18].LIST`, `CUSTOMER[((name LIKE "A%") AND (age >= 18))].LIST`},
+		{`CUSTOMER:42:name = "Acme"`, `CUSTOMER:42:name="Acme"`},
+		{`CUSTOMER.LIST|LIMIT 5`, `CUSTOMER.LIST | LIMIT 5`},
+		{`NOTE.CREATE text='say "hi"'`, `NOTE.CREATE text='say "hi"'`},
+		{`NOTE.CREATE code=007`, `NOTE.CREATE code=007`},
+		{`NOTE.CREATE code="007"`, `NOTE.CREATE code="007"`},
+	}
+
+	for _, tt := range tests {
+		if got := parseCommand(t, tt.input).String(); got != tt.expected {
+			t.Errorf("String() of %s = %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestCommand_JSONRoundTrip(t *testing.T) {
+	for _, input := range roundTripInputs {
+		t.Run(input, func(t *testing.T) {
+			cmd := parseCommand(t, input)
+			data := mustMarshal(t, cmd)
+
+			var decoded mdwast.Command
+			if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := decoded.String(); got != cmd.String() {
+				t.Errorf("decoded command = %s, want %s", got, cmd.String())
+			}
+			if again := mustMarshal(t, &decoded); again != data {
+				t.Errorf("JSON is not stable:\n%s\n%s", data, again)
+			}
+		})
+	}
+}
+
+func TestCommand_JSON(t *testing.T) {
+	cmd := parseCommand(t, `ORDER[total > 100 AND NOT closed].LIST limit=10 rate=0.5 | LIMIT 3`)
+	data := mustMarshal(t, cmd)
+	for _, expected := range []string{
+		`"object":"ORDER"`,
+		`"kind":"binary","op":"AND"`,
+		`"kind":"unary","op":"NOT","operand":{"kind":"identifier","name":"closed"}`,
+		`"limit":{"type":"number","raw":"10","value":10}`,
+		`"chain":{"transform":{"kind":"LIMIT","limit":3}}`,
+	} {
+		if !strings.Contains(data, expected) {
+			t.Errorf("JSON does not contain %s:\n%s", expected, data)
+		}
+	}
+
+	// Numbers keep their integer or float type
+	var decoded mdwast.Command
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if limit := decoded.Parameters["limit"].Value; limit != int64(10) {
+		t.Errorf("limit = %#v, want int64(10)", limit)
+	}
+	if rate := decoded.Parameters["rate"].Value; rate != 0.5 {
+		t.Errorf("rate = %#v, want 0.5", rate)
+	}
+
+	// Commands nest in other JSON documents
+	var queued struct {
+		ID      string          `json:"id"`
+		Command *mdwast.Command `json:"command"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":"j1","command":`+data+`}`), &queued); err != nil || queued.Command.String() != cmd.String() {
+		t.Errorf("nested command = %v, %v", queued.Command, err)
+	}
+
+	invalid := []string{
+		`{"object":"A","method":"B","parameters":{"x":{"type":"money","raw":"1"}}}`,
+		`{"object":"A","method":"B","parameters":{"x":{"type":"number","raw":"one"}}}`,
+		`{"object":"A","method":"B","filter":{"kind":"ternary"}}`,
+		`{"object":"A","method":"B","filter":{"kind":"binary","op":"AND","left":{"kind":"identifier","name":"x"}}}`,
+		`{"object":"A","method":"B","filter":{"kind":"literal"}}`,
+	}
+	for _, input := range invalid {
+		if err := json.Unmarshal([]byte(input), &decoded); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", input)
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, cmd *mdwast.Command) string {
+	t.Helper()
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
@@ -4,17 +4,22 @@
 //              TCOL AST nodes. Provides base visitor interface and common
 //              visitor implementations for analysis and transformation.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.3
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial visitor pattern implementation
+// - 2026-10-16 v0.1.1: Added BETWEEN and IS NULL expressions
+// - 2026-10-16 v0.1.2: Added scripts and LET statements; StringVisitor lists
+//                       parameters in sorted order
+// - 2026-10-16 v0.1.3: Added IF and FOREACH statements
 
 package ast
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -34,6 +39,14 @@ type Visitor interface {
 	VisitFunctionCall(expr *FunctionCallExpr) interface{}
 	VisitArray(expr *ArrayExpr) interface{}
 	VisitObject(expr *ObjectExpr) interface{}
+	VisitBetween(expr *BetweenExpr) interface{}
+	VisitIsNull(expr *IsNullExpr) interface{}
+
+	// Visit script nodes
+	VisitScript(script *Script) interface{}
+	VisitLet(stmt *LetStmt) interface{}
+	VisitIf(stmt *IfStmt) interface{}
+	VisitForEach(stmt *ForEachStmt) interface{}
 }
 
 // BaseVisitor provides default implementations for all visitor methods
@@ -128,6 +141,57 @@ func (bv *BaseVisitor) VisitObject(expr *ObjectExpr) interface{} {
 	return nil
 }
 
+func (bv *BaseVisitor) VisitBetween(expr *BetweenExpr) interface{} {
+	expr.Expr.Accept(bv)
+	expr.Low.Accept(bv)
+	expr.High.Accept(bv)
+	return nil
+}
+
+func (bv *BaseVisitor) VisitIsNull(expr *IsNullExpr) interface{} {
+	return expr.Expr.Accept(bv)
+}
+
+func (bv *BaseVisitor) VisitScript(script *Script) interface{} {
+	for _, stmt := range script.Statements {
+		stmt.Accept(bv)
+	}
+	return nil
+}
+
+func (bv *BaseVisitor) VisitLet(stmt *LetStmt) interface{} {
+	if stmt.Command != nil {
+		return stmt.Command.Accept(bv)
+	}
+	if stmt.Value != nil {
+		return stmt.Value.Accept(bv)
+	}
+	return nil
+}
+
+func (bv *BaseVisitor) VisitIf(stmt *IfStmt) interface{} {
+	stmt.Condition.Accept(bv)
+	for _, s := range stmt.Then {
+		s.Accept(bv)
+	}
+	for _, s := range stmt.Else {
+		s.Accept(bv)
+	}
+	return nil
+}
+
+func (bv *BaseVisitor) VisitForEach(stmt *ForEachStmt) interface{} {
+	if stmt.Command != nil {
+		stmt.Command.Accept(bv)
+	} else if stmt.Value != nil {
+		stmt.Value.Accept(bv)
+	}
+	for _, s := range stmt.Body {
+		s.Accept(bv)
+	}
+	return nil
+}
+
 // StringVisitor creates a string representation of the AST
 type StringVisitor struct {
 	BaseVisitor
@@ -179,7 +243,13 @@ func (sv *StringVisitor) VisitCommand(cmd *Command) interface{} {
 		sv.writeIndent()
 		sv.buffer.WriteString("Parameters:\n")
 		sv.indent++
-		for key, value := range cmd.Parameters {
+		keys := make([]string, 0, len(cmd.Parameters))
+		for key := range cmd.Parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := cmd.Parameters[key]
 			sv.writeIndent()
 			sv.buffer.WriteString(fmt.Sprintf("%s: ", key))
 			value.Accept(sv)
@@ -301,6 +371,92 @@ func (sv *StringVisitor) VisitObject(expr *ObjectExpr) interface{} {
 	return nil
 }
 
+func (sv *StringVisitor) VisitBetween(expr *BetweenExpr) interface{} {
+	sv.buffer.WriteString("(")
+	expr.Expr.Accept(sv)
+	sv.buffer.WriteString(" BETWEEN ")
+	expr.Low.Accept(sv)
+	sv.buffer.WriteString(" AND ")
+	expr.High.Accept(sv)
+	sv.buffer.WriteString(")")
+	return nil
+}
+
+func (sv *StringVisitor) VisitIsNull(expr *IsNullExpr) interface{} {
+	sv.buffer.WriteString("(")
+	expr.Expr.Accept(sv)
+	sv.buffer.WriteString(" IS NULL)")
+	return nil
+}
+
+func (sv *StringVisitor) VisitScript(script *Script) interface{} {
+	sv.writeIndent()
+	sv.buffer.WriteString("Script:\n")
+	sv.indent++
+	for _, stmt := range script.Statements {
+		stmt.Accept(sv)
+	}
+	sv.indent--
+	return nil
+}
+
+func (sv *StringVisitor) VisitLet(stmt *LetStmt) interface{} {
+	sv.writeIndent()
+	sv.buffer.WriteString(fmt.Sprintf("Let: %s\n", stmt.Name))
+	sv.indent++
+	if stmt.Command != nil {
+		stmt.Command.Accept(sv)
+	} else if stmt.Value != nil {
+		sv.writeIndent()
+		sv.buffer.WriteString("Value: ")
+		stmt.Value.Accept(sv)
+		sv.buffer.WriteString("\n")
+	}
+	sv.indent--
+	return nil
+}
+
+func (sv *StringVisitor) VisitIf(stmt *IfStmt) interface{} {
+	sv.writeIndent()
+	sv.buffer.WriteString("If: ")
+	stmt.Condition.Accept(sv)
+	sv.buffer.WriteString("\n")
+	sv.writeBlock("Then", stmt.Then)
+	if len(stmt.Else) > 0 {
+		sv.writeBlock("Else", stmt.Else)
+	}
+	return nil
+}
+
+func (sv *StringVisitor) VisitForEach(stmt *ForEachStmt) interface{} {
+	sv.writeIndent()
+	sv.buffer.WriteString(fmt.Sprintf("ForEach: %s\n", stmt.Name))
+	sv.indent++
+	if stmt.Command != nil {
+		stmt.Command.Accept(sv)
+	} else if stmt.Value != nil {
+		sv.writeIndent()
+		sv.buffer.WriteString("In: ")
+		stmt.Value.Accept(sv)
+		sv.buffer.WriteString("\n")
+	}
+	sv.indent--
+	sv.writeBlock("Do", stmt.Body)
+	return nil
+}
+
+// writeBlock writes a labelled block of statements
+func (sv *StringVisitor) writeBlock(label string, stmts []Statement) {
+	sv.indent++
+	sv.writeIndent()
+	sv.buffer.WriteString(label + ":\n")
+	sv.indent++
+	for _, stmt := range stmts {
+		stmt.Accept(sv)
+	}
+	sv.indent -= 2
+}
+
 // ValidationVisitor validates AST nodes and collects errors
 type ValidationVisitor struct {
 	BaseVisitor
@@ -423,6 +579,81 @@ func (vv *ValidationVisitor) VisitObject(expr *ObjectExpr) interface{} {
 	return vv.BaseVisitor.VisitObject(expr)
 }
 
+func (vv *ValidationVisitor) VisitBetween(expr *BetweenExpr) interface{} {
+	if err := expr.Validate(); err != nil {
+		vv.addError(fmt.Errorf("between expression validation failed: %w", err))
+		return nil
+	}
+
+	expr.Expr.Accept(vv)
+	expr.Low.Accept(vv)
+	expr.High.Accept(vv)
+	return nil
+}
+
+func (vv *ValidationVisitor) VisitIsNull(expr *IsNullExpr) interface{} {
+	if err := expr.Validate(); err != nil {
+		vv.addError(fmt.Errorf("null test validation failed: %w", err))
+		return nil
+	}
+
+	return expr.Expr.Accept(vv)
+}
+
+func (vv *ValidationVisitor) VisitScript(script *Script) interface{} {
+	for i, stmt := range script.Statements {
+		if stmt == nil {
+			vv.addError(fmt.Errorf("statement %d is missing", i+1))
+			continue
+		}
+		stmt.Accept(vv)
+	}
+	return nil
+}
+
+func (vv *ValidationVisitor) VisitLet(stmt *LetStmt) interface{} {
+	if err := stmt.Validate(); err != nil {
+		vv.addError(fmt.Errorf("LET statement validation failed: %w", err))
+		return nil
+	}
+
+	if stmt.Command != nil {
+		return stmt.Command.Accept(vv)
+	}
+	return stmt.Value.Accept(vv)
+}
+
+func (vv *ValidationVisitor) VisitIf(stmt *IfStmt) interface{} {
+	if err := stmt.Validate(); err != nil {
+		vv.addError(fmt.Errorf("IF statement validation failed: %w", err))
+		return nil
+	}
+
+	stmt.Condition.Accept(vv)
+	for _, s := range stmt.Then {
+		s.Accept(vv)
+	}
+	for _, s := range stmt.Else {
+		s.Accept(vv)
+	}
+	return nil
+}
+
+func (vv *ValidationVisitor) VisitForEach(stmt *ForEachStmt) interface{} {
+	if err := stmt.Validate(); err != nil {
+		vv.addError(fmt.Errorf("FOREACH statement validation failed: %w", err))
+		return nil
+	}
+
+	if stmt.Command != nil {
+		stmt.Command.Accept(vv)
+	}
+	for _, s := range stmt.Body {
+		s.Accept(vv)
+	}
+	return nil
+}
+
 // CollectorVisitor collects specific types of nodes from the AST
 type CollectorVisitor struct {
 	BaseVisitor
@@ -505,6 +736,52 @@ func (cv *CollectorVisitor) VisitObject(expr *ObjectExpr) interface{} {
 	return nil
 }
 
+func (cv *CollectorVisitor) VisitBetween(expr *BetweenExpr) interface{} {
+	expr.Expr.Accept(cv)
+	expr.Low.Accept(cv)
+	expr.High.Accept(cv)
+	return nil
+}
+
+func (cv *CollectorVisitor) VisitIsNull(expr *IsNullExpr) interface{} {
+	return expr.Expr.Accept(cv)
+}
+
+func (cv *CollectorVisitor) VisitScript(script *Script) interface{} {
+	for _, stmt := range script.Statements {
+		stmt.Accept(cv)
+	}
+	return nil
+}
+
+func (cv *CollectorVisitor) VisitLet(stmt *LetStmt) interface{} {
+	if stmt.Command != nil {
+		return stmt.Command.Accept(cv)
+	}
+	return nil
+}
+
+func (cv *CollectorVisitor) VisitIf(stmt *IfStmt) interface{} {
+	stmt.Condition.Accept(cv)
+	for _, s := range stmt.Then {
+		s.Accept(cv)
+	}
+	for _, s := range stmt.Else {
+		s.Accept(cv)
+	}
+	return nil
+}
+
+func (cv *CollectorVisitor) VisitForEach(stmt *ForEachStmt) interface{} {
+	if stmt.Command != nil {
+		stmt.Command.Accept(cv)
+	}
+	for _, s := range stmt.Body {
+		s.Accept(cv)
+	}
+	return nil
+}
+
 // Utility functions for working with visitors
 
 // ValidateAST validates an AST node and returns any validation errors
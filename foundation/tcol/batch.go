@@ -0,0 +1,179 @@
+// File: batch.go
+// Title: TCOL Batch Command Execution
+// Description: Executes a list of TCOL commands, e.g. the lines of a command
+//              file, sequentially or concurrently. Reports the outcome of
+//              every command and aggregates the failures in a BatchError.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of batch execution
+
+package tcol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+)
+
+// BatchOptions configures the execution of a batch of commands
+type BatchOptions struct {
+	// Concurrency is the number of commands executed at the same time
+	// (default: 1, in order)
+	Concurrency int
+
+	// StopOnError skips the commands not yet started after the first failure
+	StopOnError bool
+}
+
+// BatchItem is the outcome of a command of a batch
+type BatchItem struct {
+	Index   int // Position of the command in the batch, from 0
+	Command string
+	Result  *Result // Nil if the command failed or was skipped
+	Err     error
+	Skipped bool // Not executed because an earlier command failed with StopOnError
+}
+
+// BatchResult reports the outcome of every command of a batch
+type BatchResult struct {
+	Items         []BatchItem // In the order of the commands
+	Succeeded     int
+	Failed        int
+	Skipped       int
+	ExecutionTime time.Duration
+}
+
+// BatchFailure is a command of a batch that failed
+type BatchFailure struct {
+	Index   int
+	Command string
+	Err     error
+}
+
+// BatchError aggregates the failures of a batch. errors.Is and errors.As
+// match the errors of the individual commands.
+type BatchError struct {
+	Failures []BatchFailure
+	Total    int // Number of commands in the batch
+	Skipped  int
+}
+
+// Error lists the failed commands with their errors
+func (e *BatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d batch commands failed", len(e.Failures), e.Total)
+	if e.Skipped > 0 {
+		fmt.Fprintf(&b, ", %d skipped", e.Skipped)
+	}
+	for _, failure := range e.Failures {
+		fmt.Fprintf(&b, "; command %d (%s): %v", failure.Index+1, failure.Command, failure.Err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed commands
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// Err returns a *BatchError if any command failed, otherwise nil
+func (r *BatchResult) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+
+	batchErr := &BatchError{Total: len(r.Items), Skipped: r.Skipped}
+	for _, item := range r.Items {
+		if item.Err != nil && !item.Skipped {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{
+				Index:   item.Index,
+				Command: item.Command,
+				Err:     item.Err,
+			})
+		}
+	}
+	return batchErr
+}
+
+// ExecuteBatch executes the commands with Execute and reports the outcome of
+// each. The result is returned even if commands failed; the error is then a
+// *BatchError listing the failures. Commands run in order unless
+// opts.Concurrency is greater than 1. A cancelled ctx fails the commands not
+// yet started.
+func (e *Engine) ExecuteBatch(ctx context.Context, commands []string, opts BatchOptions) (*BatchResult, error) {
+	startTime := time.Now()
+	concurrency := min(max(opts.Concurrency, 1), max(len(commands), 1))
+
+	e.logger.Info("Executing TCOL batch", mdwlog.Fields{
+		"commands":    len(commands),
+		"concurrency": concurrency,
+		"stopOnError": opts.StopOnError,
+	})
+
+	result := &BatchResult{Items: make([]BatchItem, len(commands))}
+	var stopped atomic.Bool
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item := BatchItem{Index: i, Command: commands[i]}
+				switch {
+				case stopped.Load():
+					item.Skipped = true
+				case ctx.Err() != nil:
+					item.Err = ctx.Err()
+				default:
+					item.Result, item.Err = e.Execute(ctx, commands[i])
+				}
+				if item.Err != nil && opts.StopOnError {
+					stopped.Store(true)
+				}
+				result.Items[i] = item
+			}
+		}()
+	}
+	for i := range commands {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, item := range result.Items {
+		switch {
+		case item.Skipped:
+			result.Skipped++
+		case item.Err != nil:
+			result.Failed++
+		default:
+			result.Succeeded++
+		}
+	}
+	result.ExecutionTime = time.Since(startTime)
+
+	e.logger.Info("TCOL batch executed", mdwlog.Fields{
+		"commands":  len(commands),
+		"succeeded": result.Succeeded,
+		"failed":    result.Failed,
+		"skipped":   result.Skipped,
+		"duration":  result.ExecutionTime,
+	})
+
+	return result, result.Err()
+}
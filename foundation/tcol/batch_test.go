@@ -0,0 +1,160 @@
+// File: batch_test.go
+// Title: TCOL Batch Command Execution Tests
+// Description: Tests sequential and concurrent batch execution, the report
+//              of partial failures, StopOnError, and cancellation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial batch tests
+
+package tcol
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+var errLocked = errors.New("customer is locked")
+
+// batchClient answers concurrent calls after a short delay and fails
+// DELETE; it records the largest number of calls in flight
+type batchClient struct {
+	inFlight    int
+	maxInFlight int
+	calls       int
+	mutex       sync.Mutex
+}
+
+func (c *batchClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+	c.mutex.Lock()
+	c.calls++
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mutex.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mutex.Lock()
+	c.inFlight--
+	c.mutex.Unlock()
+	if methodName == "DELETE" {
+		return nil, errLocked
+	}
+	return &mdwexecutor.ServiceResponse{Success: true, Data: methodName}, nil
+}
+
+func (c *batchClient) Health(ctx context.Context, serviceName string) error { return nil }
+func (c *batchClient) Close() error                                         { return nil }
+
+func newBatchEngine(t *testing.T) (*Engine, *batchClient) {
+	client := &batchClient{}
+	engine, err := NewEngine(Options{ServiceClient: client})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"LIST": {}, "CREATE": {}, "DELETE": {}},
+	})
+	return engine, client
+}
+
+func TestEngine_ExecuteBatch(t *testing.T) {
+	engine, client := newBatchEngine(t)
+	commands := []string{
+		`CUSTOMER.LIST`,
+		`CUSTOMER.DELETE id=1`,
+		`CUSTOMER.CREATE name="Acme"`,
+		`CUSTOMER.LSIT`,
+	}
+
+	result, err := engine.ExecuteBatch(context.Background(), commands, BatchOptions{})
+	if result == nil || len(result.Items) != 4 {
+		t.Fatalf("ExecuteBatch() result = %+v", result)
+	}
+	if result.Succeeded != 2 || result.Failed != 2 || result.Skipped != 0 {
+		t.Errorf("counts = %d succeeded, %d failed, %d skipped", result.Succeeded, result.Failed, result.Skipped)
+	}
+	if item := result.Items[2]; item.Err != nil || item.Result.Data[0] != "CREATE" || item.Command != commands[2] {
+		t.Errorf("item 2 = %+v", item)
+	}
+	if client.maxInFlight != 1 {
+		t.Errorf("sequential batch ran %d commands at once", client.maxInFlight)
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 2 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("ExecuteBatch() error = %v, want *BatchError with 2 failures", err)
+	}
+	if !errors.Is(err, errLocked) {
+		t.Error("BatchError does not match the error of a failed command")
+	}
+	var tcolErr *Error
+	if !errors.As(batchErr.Failures[1].Err, &tcolErr) || len(tcolErr.Suggestions()) == 0 {
+		t.Errorf("failure of an unknown command = %v, want suggestions", batchErr.Failures[1].Err)
+	}
+	if !strings.HasPrefix(err.Error(), "2 of 4 batch commands failed; command 2 (CUSTOMER.DELETE id=1)") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	result, err = engine.ExecuteBatch(context.Background(), commands[:1], BatchOptions{})
+	if err != nil || result.Succeeded != 1 {
+		t.Errorf("batch without failures = %+v, %v", result, err)
+	}
+}
+
+func TestEngine_ExecuteBatch_Concurrency(t *testing.T) {
+	engine, client := newBatchEngine(t)
+	commands := make([]string, 12)
+	for i := range commands {
+		commands[i] = `CUSTOMER.LIST`
+	}
+
+	result, err := engine.ExecuteBatch(context.Background(), commands, BatchOptions{Concurrency: 4})
+	if err != nil || result.Succeeded != len(commands) {
+		t.Fatalf("ExecuteBatch() = %+v, %v", result, err)
+	}
+	if client.maxInFlight < 2 || client.maxInFlight > 4 {
+		t.Errorf("max commands in flight = %d, want 2 to 4", client.maxInFlight)
+	}
+	for i, item := range result.Items {
+		if item.Index != i {
+			t.Errorf("item %d has index %d", i, item.Index)
+		}
+	}
+}
+
+func TestEngine_ExecuteBatch_StopOnError(t *testing.T) {
+	engine, client := newBatchEngine(t)
+	commands := []string{`CUSTOMER.LIST`, `CUSTOMER.DELETE id=1`, `CUSTOMER.LIST`, `CUSTOMER.LIST`}
+
+	result, err := engine.ExecuteBatch(context.Background(), commands, BatchOptions{StopOnError: true})
+	if result.Succeeded != 1 || result.Failed != 1 || result.Skipped != 2 {
+		t.Errorf("counts = %d succeeded, %d failed, %d skipped", result.Succeeded, result.Failed, result.Skipped)
+	}
+	if !result.Items[3].Skipped || result.Items[3].Result != nil || client.calls != 2 {
+		t.Errorf("commands after the failure were executed: %+v, %d calls", result.Items[3], client.calls)
+	}
+	if err == nil || !strings.Contains(err.Error(), "1 of 4 batch commands failed, 2 skipped") {
+		t.Errorf("ExecuteBatch() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = engine.ExecuteBatch(ctx, commands, BatchOptions{Concurrency: 2})
+	if result.Failed != len(commands) || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled batch = %+v, %v", result, err)
+	}
+}
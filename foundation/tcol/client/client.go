@@ -5,12 +5,15 @@
 //              service discovery, health checking, and circuit breaker
 //              patterns for reliable service communication.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial client implementation
+// - 2026-10-16 v0.1.1: Added streaming execution mapped to server streaming
+// - 2026-10-16 v0.1.2: Calls go through per-service breakers with retry policies,
+//                       deadline budgets, health-check ejection, and metrics
 
 package client
 
@@ -21,17 +24,25 @@ import (
 	"sync"
 	"time"
 
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
 )
 
 // Client implements the ServiceClient interface for TCOL
 type Client struct {
-	connections map[string]*ServiceConnection
-	discovery   ServiceDiscovery
-	logger      *mdwlog.Logger
-	options     Options
-	mutex       sync.RWMutex
+	connections   map[string]*ServiceConnection
+	services      map[string]*serviceState
+	discovery     ServiceDiscovery
+	logger        *mdwlog.Logger
+	options       Options
+	mutex         sync.RWMutex
+	servicesMutex sync.Mutex
+
+	// Transport of requests and health checks; replaced in tests
+	request func(ctx context.Context, conn *ServiceConnection, objectName, methodName string,
+		params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error)
+	probe func(ctx context.Context, conn *ServiceConnection) error
 }
 
 // Options configures client behavior
@@ -43,6 +54,9 @@ type Options struct {
 	MaxRetries          int
 	HealthCheckInterval time.Duration
 	CircuitBreakerConfig CircuitBreakerConfig
+	RetryPolicy         RetryPolicy   // Backoff between retries
+	AttemptTimeout      time.Duration // Timeout of each attempt within RequestTimeout; none if 0
+	EjectionThreshold   int           // Consecutive failed health checks that eject a service
 }
 
 // ServiceConnection represents a connection to a microservice
@@ -55,6 +69,8 @@ type ServiceConnection struct {
 	RequestCount  int64
 	ErrorCount    int64
 	CircuitBreaker *CircuitBreaker
+	Ejected       bool // Excluded from requests until a health check succeeds
+	healthFailures int
 	mutex         sync.RWMutex
 }
 
@@ -143,6 +159,20 @@ func New(opts Options) (*Client, error) {
 	if opts.ServiceDiscovery == nil {
 		opts.ServiceDiscovery = NewMockServiceDiscovery()
 	}
+	if opts.EjectionThreshold == 0 {
+		opts.EjectionThreshold = 3
+	}
+
+	// Set retry policy defaults
+	if opts.RetryPolicy.InitialBackoff == 0 {
+		opts.RetryPolicy.InitialBackoff = 100 * time.Millisecond
+	}
+	if opts.RetryPolicy.MaxBackoff == 0 {
+		opts.RetryPolicy.MaxBackoff = 2 * time.Second
+	}
+	if opts.RetryPolicy.Multiplier == 0 {
+		opts.RetryPolicy.Multiplier = 2
+	}
 
 	// Set circuit breaker defaults
 	if opts.CircuitBreakerConfig.FailureThreshold == 0 {
@@ -160,10 +190,13 @@ func New(opts Options) (*Client, error) {
 
 	client := &Client{
 		connections: make(map[string]*ServiceConnection),
+		services:    make(map[string]*serviceState),
 		discovery:   opts.ServiceDiscovery,
 		logger:      opts.Logger.WithField("component", "tcol-client"),
 		options:     opts,
 	}
+	client.request = client.executeRequest
+	client.probe = client.probeService
 
 	// Start health check routine
 	go client.healthCheckLoop()
@@ -173,66 +206,72 @@ func New(opts Options) (*Client, error) {
 		"requestTimeout":      opts.RequestTimeout,
 		"maxRetries":          opts.MaxRetries,
 		"healthCheckInterval": opts.HealthCheckInterval,
+		"ejectionThreshold":   opts.EjectionThreshold,
 	})
 
 	return client, nil
 }
 
-// Execute executes a command on a microservice
+// Execute executes a command on a microservice. Failed attempts are retried
+// as their errors allow, within RequestTimeout; calls to services whose
+// circuit breaker is open or which are ejected fail without being sent.
 func (c *Client) Execute(ctx context.Context, serviceName, objectName, methodName string,
 	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
 
-	// Get or create connection
-	conn, err := c.getConnection(serviceName)
+	var response *mdwexecutor.ServiceResponse
+	attempts, err := c.call(ctx, serviceName, c.options.RequestTimeout, func(ctx context.Context, conn *ServiceConnection) error {
+		var err error
+		response, err = c.request(ctx, conn, objectName, methodName, params, execCtx)
+		return err
+	}, nil)
+	if err != nil && attempts > 0 {
+		return nil, fmt.Errorf("service request failed for service %s after %d attempts: %w", serviceName, attempts, err)
+	}
 	if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
 
-	// Check circuit breaker
-	if !conn.CircuitBreaker.AllowRequest() {
-		return nil, fmt.Errorf("circuit breaker is open for service %s (state: %v)", serviceName, conn.CircuitBreaker.state)
-	}
-
-	// Create request context with timeout
-	reqCtx, cancel := context.WithTimeout(ctx, c.options.RequestTimeout)
-	defer cancel()
-
-	// Execute with retries
-	var lastErr error
-	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
-		if attempt > 0 {
-			c.logger.Debug("Retrying service request", mdwlog.Fields{
-				"serviceName": serviceName,
-				"attempt":     attempt,
-				"maxRetries":  c.options.MaxRetries,
-			})
-			
-			// Wait before retry
-			select {
-			case <-time.After(time.Duration(attempt) * time.Second):
-			case <-reqCtx.Done():
-				return nil, reqCtx.Err()
-			}
-		}
-
-		response, err := c.executeRequest(reqCtx, conn, objectName, methodName, params, execCtx)
-		if err == nil {
-			conn.CircuitBreaker.RecordSuccess()
-			conn.updateStats(true)
-			return response, nil
+// ExecuteStream executes a command on a microservice and passes the result
+// items to handler as they arrive. It maps to gRPC server streaming, so the
+// result is never materialized as a whole. The stream is not bound by the
+// request timeout; it ends with ctx. Retries only happen before the first
+// item has been delivered.
+func (c *Client) ExecuteStream(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext,
+	handler mdwexecutor.StreamHandler) (*mdwexecutor.ServiceResponse, error) {
+
+	delivered := 0
+	counted := func(item interface{}) error {
+		if err := handler(item); err != nil {
+			// The receiver ended the stream; the service is not at fault
+			return &receiverError{err: err}
 		}
+		delivered++
+		return nil
+	}
 
-		lastErr = err
-		conn.CircuitBreaker.RecordFailure()
-		conn.updateStats(false)
+	var response *mdwexecutor.ServiceResponse
+	attempts, err := c.call(ctx, serviceName, 0, func(ctx context.Context, conn *ServiceConnection) error {
+		var err error
+		response, err = c.streamRequest(ctx, conn, objectName, methodName, params, execCtx, counted)
+		return err
+	}, func() bool {
+		// Items already delivered cannot be taken back
+		return delivered == 0
+	})
 
-		// Don't retry on certain errors
-		if c.shouldNotRetry(err) {
-			break
-		}
+	var receiverErr *receiverError
+	switch {
+	case errors.As(err, &receiverErr):
+		return nil, receiverErr.err
+	case err != nil && attempts > 0:
+		return nil, fmt.Errorf("service stream failed for service %s after %d items: %w", serviceName, delivered, err)
+	case err != nil:
+		return nil, err
 	}
-
-	return nil, fmt.Errorf("service request failed after %d retries for service %s: %w", c.options.MaxRetries, serviceName, lastErr)
+	return response, nil
 }
 
 // Health checks the health of a service
@@ -245,18 +284,10 @@ func (c *Client) Health(ctx context.Context, serviceName string) error {
 	// Create request context with timeout
 	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	_ = reqCtx // Used in real implementation
-
-	// Mock health check - in real implementation, this would be a gRPC health check
-	if !conn.Connected {
-		return fmt.Errorf("service %s is not connected (address: %s)", serviceName, conn.Address)
-	}
-
-	conn.mutex.Lock()
-	conn.HealthStatus = HealthHealthy
-	conn.mutex.Unlock()
 
-	return nil
+	err = c.probe(reqCtx, conn)
+	c.recordHealth(conn, err)
+	return err
 }
 
 // Close closes all connections and resources
@@ -321,7 +352,7 @@ func (c *Client) createConnection(serviceName string) (*ServiceConnection, error
 		Connected:      false,
 		HealthStatus:   HealthUnknown,
 		LastUsed:       time.Now(),
-		CircuitBreaker: NewCircuitBreaker(c.options.CircuitBreakerConfig),
+		CircuitBreaker: c.state(serviceName).breaker,
 	}
 
 	// Mock connection - in real implementation, this would establish gRPC connection
@@ -368,13 +399,56 @@ func (c *Client) executeRequest(ctx context.Context, conn *ServiceConnection,
 	}
 
 	// Simulate occasional failures for circuit breaker testing
-	if conn.RequestCount%10 == 7 { // Fail every 10th request starting at 7
-		return nil, errors.New("mock service error for testing")
+	conn.mutex.RLock()
+	requestCount := conn.RequestCount
+	conn.mutex.RUnlock()
+	if requestCount%10 == 7 { // Fail every 10th request starting at 7
+		return nil, mdwerror.New("mock service error for testing").WithCode(mdwerror.CodeServiceUnavailable)
 	}
 
 	return response, nil
 }
 
+// streamRequest executes a streaming request to a service
+func (c *Client) streamRequest(ctx context.Context, conn *ServiceConnection,
+	objectName, methodName string, params map[string]interface{},
+	execCtx *mdwexecutor.ExecutionContext, handler mdwexecutor.StreamHandler) (*mdwexecutor.ServiceResponse, error) {
+
+	c.logger.Debug("Executing service stream", mdwlog.Fields{
+		"serviceName": conn.ServiceName,
+		"objectName":  objectName,
+		"methodName":  methodName,
+		"requestID":   execCtx.RequestID,
+	})
+
+	// Mock stream - in real implementation, this would open a gRPC server
+	// stream and pass each received message to handler until io.EOF
+	response, err := c.request(ctx, conn, objectName, methodName, params, execCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := handler(response.Data); err != nil {
+		return nil, err
+	}
+
+	response.Data = nil
+	return response, nil
+}
+
+// probeService checks the health of a service
+func (c *Client) probeService(ctx context.Context, conn *ServiceConnection) error {
+	// Mock health check - in real implementation, this would be a gRPC health check
+	conn.mutex.RLock()
+	defer conn.mutex.RUnlock()
+	if !conn.Connected {
+		return fmt.Errorf("service %s is not connected (address: %s)", conn.ServiceName, conn.Address)
+	}
+	return nil
+}
+
 // connectToService establishes connection to a service
 func (c *Client) connectToService(conn *ServiceConnection) error {
 	// Mock connection - in real implementation, this would establish gRPC connection
@@ -411,15 +485,6 @@ func (conn *ServiceConnection) updateStats(success bool) {
 	conn.LastUsed = time.Now()
 }
 
-// shouldNotRetry determines if an error should not be retried
-func (c *Client) shouldNotRetry(err error) bool {
-	// Don't retry on certain error conditions
-	if err == context.Canceled || err == context.DeadlineExceeded {
-		return true
-	}
-	return false
-}
-
 // healthCheckLoop runs periodic health checks
 func (c *Client) healthCheckLoop() {
 	ticker := time.NewTicker(c.options.HealthCheckInterval)
@@ -444,17 +509,12 @@ func (c *Client) performHealthChecks() {
 		err := c.Health(ctx, conn.ServiceName)
 		cancel()
 
-		conn.mutex.Lock()
 		if err != nil {
-			conn.HealthStatus = HealthUnhealthy
 			c.logger.Warn("Service health check failed", mdwlog.Fields{
 				"serviceName": conn.ServiceName,
 				"error":       err.Error(),
 			})
-		} else {
-			conn.HealthStatus = HealthHealthy
 		}
-		conn.mutex.Unlock()
 	}
 }
 
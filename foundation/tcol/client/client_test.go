@@ -5,12 +5,15 @@
 //              circuit breaker patterns, retry logic, and mock service
 //              interactions. Tests cover reliability and resilience features.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial comprehensive client test suite
+// - 2026-10-16 v0.1.1: Added streaming tests
+// - 2026-10-16 v0.1.2: Circuit breaker integration test drives failures through
+//                       the request transport
 
 package client
 
@@ -23,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
 )
@@ -295,6 +299,50 @@ func TestClient_Execute_Success(t *testing.T) {
 	}
 }
 
+func TestClient_ExecuteStream(t *testing.T) {
+	discovery := NewTestServiceDiscovery()
+	discovery.SetServiceAddress("test-service", "localhost:50001")
+
+	client, err := createTestClient(Options{
+		ServiceDiscovery: discovery,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// The client streams natively
+	var _ mdwexecutor.StreamingServiceClient = client
+
+	var items []interface{}
+	response, err := client.ExecuteStream(context.Background(), "test-service", "OBJECT", "LIST",
+		map[string]interface{}{}, createTestExecutionContext(), func(item interface{}) error {
+			items = append(items, item)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ExecuteStream() error = %v", err)
+	}
+	if !response.Success || response.Data != nil {
+		t.Errorf("response = %+v", response)
+	}
+	if len(items) != 1 || items[0].(map[string]interface{})["method"] != "LIST" {
+		t.Errorf("items = %v", items)
+	}
+
+	// Handler errors end the stream without retries or circuit breaker failures
+	stop := errors.New("enough")
+	calls := 0
+	_, err = client.ExecuteStream(context.Background(), "test-service", "OBJECT", "LIST",
+		map[string]interface{}{}, createTestExecutionContext(), func(item interface{}) error {
+			calls++
+			return stop
+		})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("ExecuteStream() error = %v after %d calls", err, calls)
+	}
+}
+
 func TestClient_Execute_ServiceDiscoveryError(t *testing.T) {
 	discovery := NewTestServiceDiscovery()
 	discovery.SetServiceError("unknown-service", errors.New("service not found"))
@@ -514,7 +562,7 @@ func TestCircuitBreaker_Integration(t *testing.T) {
 		ServiceDiscovery: discovery,
 		CircuitBreakerConfig: CircuitBreakerConfig{
 			FailureThreshold:   2,
-			RecoveryTimeout:    100 * time.Millisecond,
+			RecoveryTimeout:    500 * time.Millisecond,
 			HalfOpenRequests:   1,
 			MinRequestsToTrip:  2,
 		},
@@ -527,36 +575,24 @@ func TestCircuitBreaker_Integration(t *testing.T) {
 	ctx := context.Background()
 	execCtx := createTestExecutionContext()
 
-	// First, trigger failures to open the circuit breaker
-	// The mock service fails every 10th request starting at 7
-	// We need to get the request count to 7, 17, 27, etc.
-	
-	// Get connection first
-	conn, err := client.getConnection("circuit-service")
-	if err != nil {
-		t.Fatalf("Failed to get connection: %v", err)
+	// The service fails until it is repaired
+	var mutex sync.Mutex
+	failing, sent := true, 0
+	client.request = func(ctx context.Context, conn *ServiceConnection, objectName, methodName string,
+		params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		sent++
+		if failing {
+			return nil, mdwerror.New("service down").WithCode(mdwerror.CodeServiceUnavailable)
+		}
+		return &mdwexecutor.ServiceResponse{Success: true}, nil
 	}
 
-	// Manually set request count to trigger failures
-	conn.mutex.Lock()
-	conn.RequestCount = 6 // Next request (7) will fail
-	conn.mutex.Unlock()
-
-	// This should fail (request count 7)
+	// The second failed attempt opens the circuit breaker, which ends the retries
 	_, err = client.Execute(ctx, "circuit-service", "OBJECT", "METHOD", nil, execCtx)
-	if err == nil {
-		t.Error("Expected first request to fail")
-	}
-
-	// This should fail (request count 8) but will succeed due to mock logic
-	_, err = client.Execute(ctx, "circuit-service", "OBJECT", "METHOD", nil, execCtx)
-	if err != nil {
-		t.Errorf("Second request failed unexpectedly: %v", err)
-	}
-
-	// Manually trigger failures to open circuit breaker
-	for i := 0; i < 3; i++ {
-		conn.CircuitBreaker.RecordFailure()
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("Expected request to fail after 2 attempts, got: %v", err)
 	}
 
 	// Circuit breaker should now be open
@@ -565,9 +601,28 @@ func TestCircuitBreaker_Integration(t *testing.T) {
 		t.Error("Expected request to fail when circuit breaker is open")
 	}
 
-	if !strings.Contains(err.Error(), "circuit breaker is open") {
+	if !strings.Contains(err.Error(), "circuit breaker is open") || !errors.Is(err, ErrCircuitOpen) {
 		t.Errorf("Expected circuit breaker error, got: %v", err)
 	}
+	if !mdwerror.IsTransient(err) || mdwerror.RetryAfter(err) <= 0 {
+		t.Errorf("Expected a transient error with retry delay, got: %v", err)
+	}
+	if sent != 2 {
+		t.Errorf("Expected 2 requests to reach the service, got %d", sent)
+	}
+
+	// After the recovery timeout a successful probe closes the breaker
+	mutex.Lock()
+	failing = false
+	mutex.Unlock()
+	time.Sleep(550 * time.Millisecond)
+
+	if _, err := client.Execute(ctx, "circuit-service", "OBJECT", "METHOD", nil, execCtx); err != nil {
+		t.Errorf("Expected half-open request to succeed, got: %v", err)
+	}
+	if state := client.state("circuit-service").breaker.State(); state != StateClosed {
+		t.Errorf("Expected circuit breaker to close, got %s", state)
+	}
 }
 
 func TestHealthStatus_String(t *testing.T) {
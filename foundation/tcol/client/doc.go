@@ -4,12 +4,14 @@
 //              microservices. Provides gRPC-based communication, connection
 //              management, and service discovery for TCOL command execution.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial client implementation
+// - 2026-10-16 v0.1.1: Documented streaming execution
+// - 2026-10-16 v0.1.2: Documented retries, circuit breakers, ejection, and metrics
 
 /*
 Package client provides service communication capabilities for TCOL.
@@ -22,6 +24,20 @@ microservices during TCOL command execution. It includes:
   • Service discovery and health checking
   • Request/response serialization
   • Circuit breaker patterns for resilience
+  • Streaming of large results via gRPC server streaming
+
+Each service has a circuit breaker that opens after repeated failures and
+lets probe requests through after CircuitBreakerConfig.RecoveryTimeout.
+Failed attempts are retried as their errors allow: transient mDW errors and
+unclassified transport errors are retried with the backoff of the
+RetryPolicy, or after the delay set with WithRetryAfter; permanent and user
+errors are not, and user errors do not count against the breaker. All
+attempts share the RequestTimeout as deadline budget, and a retry that could
+not finish within it is not started. Services failing EjectionThreshold
+consecutive health checks are ejected until a check succeeds. Rejected calls
+fail with errors wrapping ErrCircuitOpen or ErrServiceEjected and carrying
+CodeServiceUnavailable. Metrics reports requests, retries, failures, and the
+breaker and health state per service.
 
 The client integrates with the executor to provide reliable communication
 with the distributed mDW microservice architecture.
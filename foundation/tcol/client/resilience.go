@@ -0,0 +1,371 @@
+// File: resilience.go
+// Title: TCOL Service Client Resilience
+// Description: Implements the resilience of service calls: per-service
+//              circuit breakers, retries driven by the retryability of
+//              errors, deadline budgets, ejection of services that fail
+//              their health checks, and per-service metrics.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of resilient service calls
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+)
+
+// Causes of rejected service calls; the returned errors wrap them and carry
+// CodeServiceUnavailable
+var (
+	ErrCircuitOpen         = errors.New("circuit breaker is open")
+	ErrServiceEjected      = errors.New("service is ejected after failed health checks")
+	ErrDeadlineBudgetSpent = errors.New("deadline budget exhausted")
+)
+
+// RetryPolicy configures the delay between retries of failed service calls.
+// Errors decide whether they are retried: transient mDW errors are, mDW
+// errors that are not retryable and context errors are not, and errors
+// without classification are retried as transport failures. A retry delay
+// set on the error with WithRetryAfter replaces the backoff.
+type RetryPolicy struct {
+	InitialBackoff time.Duration // Delay before the first retry
+	MaxBackoff     time.Duration // Upper bound of the delay
+	Multiplier     float64       // Growth of the delay per retry
+}
+
+// ServiceMetrics is a snapshot of the calls to a service
+type ServiceMetrics struct {
+	ServiceName    string
+	Requests       int64 // Calls of Execute and ExecuteStream
+	Attempts       int64 // Requests sent to the service, including retries
+	Retries        int64
+	Failures       int64 // Calls that returned an error
+	Rejected       int64 // Calls rejected by the circuit breaker or ejection
+	AverageLatency time.Duration
+	LastError      string
+	CircuitState   CircuitBreakerState
+	HealthStatus   HealthStatus
+	Ejected        bool
+}
+
+// serviceState holds the state of a service that outlives its connections
+type serviceState struct {
+	breaker  *CircuitBreaker
+	requests int64
+	attempts int64
+	retries  int64
+	failures int64
+	rejected int64
+	latency  time.Duration
+	lastErr  string
+	mutex    sync.Mutex
+}
+
+// receiverError ends a call because the receiver of a stream failed; the
+// service is not at fault
+type receiverError struct {
+	err error
+}
+
+func (e *receiverError) Error() string { return e.err.Error() }
+func (e *receiverError) Unwrap() error { return e.err }
+
+// String returns the name of the state
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "CLOSED"
+	case StateOpen:
+		return "OPEN"
+	case StateHalfOpen:
+		return "HALF_OPEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// State returns the current state of the circuit breaker
+func (cb *CircuitBreaker) State() CircuitBreakerState {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+	return cb.state
+}
+
+// openFor returns how long an open breaker stays open, or 0
+func (cb *CircuitBreaker) openFor() time.Duration {
+	cb.mutex.RLock()
+	defer cb.mutex.RUnlock()
+	if cb.state != StateOpen {
+		return 0
+	}
+	return max(cb.config.RecoveryTimeout-time.Since(cb.lastFailTime), 0)
+}
+
+// state returns the state of a service, creating it on first use
+func (c *Client) state(serviceName string) *serviceState {
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	state, exists := c.services[serviceName]
+	if !exists {
+		state = &serviceState{breaker: NewCircuitBreaker(c.options.CircuitBreakerConfig)}
+		c.services[serviceName] = state
+	}
+	return state
+}
+
+// call sends a request to a service through its circuit breaker, retrying
+// failures the error allows within the deadline budget. timeout bounds all
+// attempts together; 0 leaves them bound by ctx only. canRetry, if set,
+// vetoes further attempts. It returns the number of attempts made; errors
+// of calls that were never sent are returned as they are.
+func (c *Client) call(ctx context.Context, serviceName string, timeout time.Duration,
+	send func(ctx context.Context, conn *ServiceConnection) error, canRetry func() bool) (attempts int, err error) {
+
+	state := c.state(serviceName)
+	startTime := time.Now()
+	defer func() {
+		state.mutex.Lock()
+		state.requests++
+		state.latency += time.Since(startTime)
+		var receiverErr *receiverError
+		if err != nil && !errors.As(err, &receiverErr) {
+			state.failures++
+			state.lastErr = err.Error()
+		}
+		state.mutex.Unlock()
+	}()
+	reject := func(cause error, retryAfter time.Duration) error {
+		state.mutex.Lock()
+		state.rejected++
+		state.mutex.Unlock()
+		return unavailable(serviceName, cause, retryAfter)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	conn, err := c.getConnection(serviceName)
+	if err != nil {
+		return 0, err
+	}
+	conn.mutex.RLock()
+	ejected := conn.Ejected
+	conn.mutex.RUnlock()
+	if ejected {
+		return 0, reject(ErrServiceEjected, c.options.HealthCheckInterval)
+	}
+
+	reqCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
+		if !state.breaker.AllowRequest() {
+			if attempt > 0 {
+				break // Report the failure that opened the breaker
+			}
+			return 0, reject(ErrCircuitOpen, state.breaker.openFor())
+		}
+
+		attemptCtx, cancelAttempt := reqCtx, context.CancelFunc(func() {})
+		if c.options.AttemptTimeout > 0 {
+			attemptCtx, cancelAttempt = context.WithTimeout(reqCtx, c.options.AttemptTimeout)
+		}
+		err := send(attemptCtx, conn)
+		if err != nil && attemptCtx.Err() == context.DeadlineExceeded && reqCtx.Err() == nil {
+			err = mdwerror.Wrap(err, fmt.Sprintf("attempt timed out after %s", c.options.AttemptTimeout)).
+				WithCode(mdwerror.CodeServiceTimeout)
+		}
+		cancelAttempt()
+		attempts++
+		state.mutex.Lock()
+		state.attempts++
+		state.mutex.Unlock()
+
+		var receiverErr *receiverError
+		switch {
+		case err == nil:
+			state.breaker.RecordSuccess()
+			conn.updateStats(true)
+			return attempts, nil
+		case errors.As(err, &receiverErr):
+			conn.updateStats(true)
+			return attempts, err
+		case errors.Is(err, context.Canceled):
+			// Abandoned by the caller; the service is not at fault
+		case mdwerror.IsUserError(err):
+			// The service rejected the request and is working
+			state.breaker.RecordSuccess()
+		default:
+			state.breaker.RecordFailure()
+		}
+		conn.updateStats(false)
+		lastErr = err
+
+		if attempt == c.options.MaxRetries || !isRetryable(err) || (canRetry != nil && !canRetry()) {
+			break
+		}
+
+		delay := c.retryDelay(attempt+1, err)
+		if deadline, ok := reqCtx.Deadline(); ok && time.Until(deadline) < delay {
+			lastErr = fmt.Errorf("%w before retry in %s: %w", ErrDeadlineBudgetSpent, delay, err)
+			break
+		}
+
+		c.logger.Debug("Retrying service request", mdwlog.Fields{
+			"serviceName": serviceName,
+			"attempt":     attempt + 1,
+			"maxRetries":  c.options.MaxRetries,
+			"delay":       delay,
+			"error":       err.Error(),
+		})
+		state.mutex.Lock()
+		state.retries++
+		state.mutex.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-reqCtx.Done():
+			timer.Stop()
+			return attempts, reqCtx.Err()
+		}
+	}
+
+	return attempts, lastErr
+}
+
+// isRetryable reports whether a failed attempt may be retried. Errors
+// without classification are treated as transport failures.
+func isRetryable(err error) bool {
+	if mdwerror.IsTransient(err) {
+		return true
+	}
+	return !mdwerror.IsPermanent(err)
+}
+
+// retryDelay returns the delay before a retry: the delay requested by the
+// error, or the exponential backoff of the retry policy with jitter
+func (c *Client) retryDelay(retry int, err error) time.Duration {
+	if delay := mdwerror.RetryAfter(err); delay > 0 {
+		return delay
+	}
+
+	policy := c.options.RetryPolicy
+	backoff := float64(policy.InitialBackoff) * math.Pow(policy.Multiplier, float64(retry-1))
+	delay := time.Duration(min(backoff, float64(policy.MaxBackoff)))
+
+	// Half of the delay is random, so clients do not retry in lockstep
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// unavailable returns the error of a call rejected without reaching the
+// service
+func unavailable(serviceName string, cause error, retryAfter time.Duration) error {
+	err := mdwerror.Wrap(cause, fmt.Sprintf("service %s is unavailable", serviceName)).
+		WithCode(mdwerror.CodeServiceUnavailable).
+		WithDetail("service", serviceName)
+	if retryAfter > 0 {
+		err = err.WithRetryAfter(retryAfter)
+	}
+	return err
+}
+
+// recordHealth updates the health status of a connection after a health
+// check and ejects the service after EjectionThreshold consecutive failed
+// checks, until a check succeeds again
+func (c *Client) recordHealth(conn *ServiceConnection, err error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	if err == nil {
+		if conn.Ejected {
+			c.logger.Info("Service readmitted after successful health check", mdwlog.Fields{
+				"serviceName": conn.ServiceName,
+			})
+		}
+		conn.healthFailures = 0
+		conn.Ejected = false
+		conn.HealthStatus = HealthHealthy
+		return
+	}
+
+	conn.healthFailures++
+	conn.HealthStatus = HealthDegraded
+	if conn.healthFailures >= c.options.EjectionThreshold {
+		if !conn.Ejected {
+			c.logger.Warn("Service ejected after failed health checks", mdwlog.Fields{
+				"serviceName": conn.ServiceName,
+				"failures":    conn.healthFailures,
+				"error":       err.Error(),
+			})
+		}
+		conn.Ejected = true
+		conn.HealthStatus = HealthUnhealthy
+	}
+}
+
+// Metrics returns a snapshot of the calls to each service, sorted by
+// service name
+func (c *Client) Metrics() []ServiceMetrics {
+	c.servicesMutex.Lock()
+	names := make([]string, 0, len(c.services))
+	states := make(map[string]*serviceState, len(c.services))
+	for name, state := range c.services {
+		names = append(names, name)
+		states[name] = state
+	}
+	c.servicesMutex.Unlock()
+	sort.Strings(names)
+
+	metrics := make([]ServiceMetrics, len(names))
+	for i, name := range names {
+		state := states[name]
+		state.mutex.Lock()
+		metrics[i] = ServiceMetrics{
+			ServiceName:  name,
+			Requests:     state.requests,
+			Attempts:     state.attempts,
+			Retries:      state.retries,
+			Failures:     state.failures,
+			Rejected:     state.rejected,
+			LastError:    state.lastErr,
+			CircuitState: state.breaker.State(),
+			HealthStatus: HealthUnknown,
+		}
+		if state.requests > 0 {
+			metrics[i].AverageLatency = state.latency / time.Duration(state.requests)
+		}
+		state.mutex.Unlock()
+
+		c.mutex.RLock()
+		conn, exists := c.connections[name]
+		c.mutex.RUnlock()
+		if exists {
+			conn.mutex.RLock()
+			metrics[i].HealthStatus = conn.HealthStatus
+			metrics[i].Ejected = conn.Ejected
+			conn.mutex.RUnlock()
+		}
+	}
+	return metrics
+}
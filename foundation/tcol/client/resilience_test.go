@@ -0,0 +1,223 @@
+// File: resilience_test.go
+// Title: TCOL Service Client Resilience Tests
+// Description: Tests retries driven by error retryability, deadline budgets,
+//              attempt timeouts, health-check ejection, and service metrics.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial resilience tests
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+)
+
+// scriptedService answers requests with a sequence of errors, then succeeds
+type scriptedService struct {
+	errors []error
+	sent   int
+	mutex  sync.Mutex
+}
+
+func (s *scriptedService) request(ctx context.Context, conn *ServiceConnection, objectName, methodName string,
+	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sent++
+	if s.sent <= len(s.errors) {
+		return nil, s.errors[s.sent-1]
+	}
+	return &mdwexecutor.ServiceResponse{Success: true, Data: "ok"}, nil
+}
+
+// newResilienceClient creates a client with fast retries whose requests are
+// answered by service
+func newResilienceClient(t *testing.T, opts Options, service *scriptedService) *Client {
+	opts.HealthCheckInterval = time.Hour
+	if opts.RetryPolicy.InitialBackoff == 0 {
+		opts.RetryPolicy = RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	}
+	client, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if service != nil {
+		client.request = service.request
+	}
+	return client
+}
+
+func TestClient_Execute_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		errors   []error
+		succeeds bool
+		sent     int
+	}{
+		{"transient errors are retried", []error{
+			mdwerror.New("busy").WithCode(mdwerror.CodeServiceUnavailable),
+			mdwerror.New("slow").WithCode(mdwerror.CodeServiceTimeout),
+		}, true, 3},
+		{"unclassified errors are retried", []error{errors.New("connection reset")}, true, 2},
+		{"user errors are not retried", []error{mdwerror.New("bad input").WithCode(mdwerror.CodeInvalidInput)}, false, 1},
+		{"explicitly permanent errors are not retried", []error{
+			mdwerror.New("busy").WithCode(mdwerror.CodeServiceUnavailable).WithRetryable(false),
+		}, false, 1},
+		{"retries are limited", []error{errors.New("a"), errors.New("b"), errors.New("c"), errors.New("d")}, false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &scriptedService{errors: tt.errors}
+			client := newResilienceClient(t, Options{MaxRetries: 2}, service)
+
+			response, err := client.Execute(context.Background(), "retry-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+			if tt.succeeds && (err != nil || response.Data != "ok") {
+				t.Errorf("Execute() = %v, %v, want success", response, err)
+			}
+			if !tt.succeeds && err == nil {
+				t.Error("Execute() succeeded, want error")
+			}
+			if service.sent != tt.sent {
+				t.Errorf("requests sent = %d, want %d", service.sent, tt.sent)
+			}
+		})
+	}
+}
+
+func TestClient_Execute_UserErrorsKeepBreakerClosed(t *testing.T) {
+	invalid := mdwerror.New("bad input").WithCode(mdwerror.CodeInvalidInput)
+	service := &scriptedService{errors: []error{invalid, invalid, invalid, invalid}}
+	client := newResilienceClient(t, Options{
+		CircuitBreakerConfig: CircuitBreakerConfig{FailureThreshold: 2, MinRequestsToTrip: 1},
+	}, service)
+
+	for i := 0; i < 4; i++ {
+		client.Execute(context.Background(), "user-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+	}
+	if state := client.state("user-service").breaker.State(); state != StateClosed {
+		t.Errorf("breaker state = %s, want CLOSED", state)
+	}
+}
+
+func TestClient_Execute_DeadlineBudget(t *testing.T) {
+	// The service asks for a retry after the request timeout
+	service := &scriptedService{errors: []error{
+		mdwerror.New("throttled").WithCode(mdwerror.CodeQuotaExceeded).WithRetryAfter(time.Second),
+	}}
+	client := newResilienceClient(t, Options{RequestTimeout: 100 * time.Millisecond}, service)
+
+	start := time.Now()
+	_, err := client.Execute(context.Background(), "budget-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+	if !errors.Is(err, ErrDeadlineBudgetSpent) {
+		t.Errorf("Execute() error = %v, want ErrDeadlineBudgetSpent", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Execute() waited %s for a retry that could not finish", elapsed)
+	}
+	if service.sent != 1 {
+		t.Errorf("requests sent = %d, want 1", service.sent)
+	}
+}
+
+func TestClient_Execute_AttemptTimeout(t *testing.T) {
+	client := newResilienceClient(t, Options{
+		RequestTimeout: time.Second,
+		AttemptTimeout: 20 * time.Millisecond,
+		MaxRetries:     1,
+	}, nil)
+
+	// The first attempt hangs until it times out, the second succeeds
+	var attempts int
+	client.request = func(ctx context.Context, conn *ServiceConnection, objectName, methodName string,
+		params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &mdwexecutor.ServiceResponse{Success: true}, nil
+	}
+
+	if _, err := client.Execute(context.Background(), "slow-service", "OBJECT", "METHOD", nil, createTestExecutionContext()); err != nil {
+		t.Errorf("Execute() error = %v, want success after a timed out attempt", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestClient_HealthCheckEjection(t *testing.T) {
+	service := &scriptedService{}
+	client := newResilienceClient(t, Options{EjectionThreshold: 2}, service)
+	ctx := context.Background()
+
+	var healthy bool
+	client.probe = func(ctx context.Context, conn *ServiceConnection) error {
+		if healthy {
+			return nil
+		}
+		return errors.New("health check failed")
+	}
+
+	client.Health(ctx, "flaky-service")
+	if _, err := client.Execute(ctx, "flaky-service", "OBJECT", "METHOD", nil, createTestExecutionContext()); err != nil {
+		t.Errorf("Execute() after one failed check error = %v", err)
+	}
+
+	client.Health(ctx, "flaky-service")
+	_, err := client.Execute(ctx, "flaky-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+	if !errors.Is(err, ErrServiceEjected) || !mdwerror.IsTransient(err) {
+		t.Errorf("Execute() of ejected service error = %v, want ErrServiceEjected", err)
+	}
+	if service.sent != 1 {
+		t.Errorf("requests sent = %d, want 1", service.sent)
+	}
+
+	healthy = true
+	client.Health(ctx, "flaky-service")
+	if _, err := client.Execute(ctx, "flaky-service", "OBJECT", "METHOD", nil, createTestExecutionContext()); err != nil {
+		t.Errorf("Execute() after readmission error = %v", err)
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	service := &scriptedService{errors: []error{errors.New("reset")}}
+	client := newResilienceClient(t, Options{MaxRetries: 1}, service)
+	ctx := context.Background()
+
+	client.Execute(ctx, "b-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+	client.Execute(ctx, "a-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.Execute(cancelled, "a-service", "OBJECT", "METHOD", nil, createTestExecutionContext())
+
+	metrics := client.Metrics()
+	if len(metrics) != 2 || metrics[0].ServiceName != "a-service" {
+		t.Fatalf("Metrics() = %+v, want a-service and b-service", metrics)
+	}
+	b := metrics[1]
+	if b.Requests != 1 || b.Attempts != 2 || b.Retries != 1 || b.Failures != 0 {
+		t.Errorf("b-service metrics = %+v", b)
+	}
+	if b.CircuitState != StateClosed || b.HealthStatus != HealthHealthy {
+		t.Errorf("b-service state = %s, %s", b.CircuitState, b.HealthStatus)
+	}
+	a := metrics[0]
+	if a.Requests != 2 || a.Failures != 1 || !strings.Contains(a.LastError, "context canceled") {
+		t.Errorf("a-service metrics = %+v", a)
+	}
+}
@@ -5,12 +5,37 @@
 //              object-oriented command syntax with method calls, filtering,
 //              and command chaining for business applications.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.25
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial TCOL implementation with parser and AST
+// - 2026-10-16 v0.1.1: Documented the full filter expression grammar
+// - 2026-10-16 v0.1.2: Documented multi-line strings
+// - 2026-10-16 v0.1.3: Documented scripts and LET variables
+// - 2026-10-16 v0.1.4: Documented IF and FOREACH
+// - 2026-10-16 v0.1.5: Documented asynchronous jobs
+// - 2026-10-16 v0.1.6: Documented pagination and streaming
+// - 2026-10-16 v0.1.7: Documented the executor middleware pipeline
+// - 2026-10-16 v0.1.8: Documented HELP and DESCRIBE
+// - 2026-10-16 v0.1.9: Documented alias namespaces and persistence
+// - 2026-10-16 v0.1.10: Documented command suggestions
+// - 2026-10-16 v0.1.11: Documented output formatting
+// - 2026-10-16 v0.1.12: Documented result piping and transforms
+// - 2026-10-16 v0.1.13: Documented the command history
+// - 2026-10-16 v0.1.14: Documented batch execution
+// - 2026-10-16 v0.1.15: Documented role-based permissions
+// - 2026-10-16 v0.1.16: Documented the interactive shell
+// - 2026-10-16 v0.1.17: Documented AST walking and rewriting passes
+// - 2026-10-16 v0.1.18: Documented stored procedures
+// - 2026-10-16 v0.1.19: Documented natural language translation
+// - 2026-10-16 v0.1.20: Documented the tagged result cache
+// - 2026-10-16 v0.1.21: Documented scheduled commands
+// - 2026-10-16 v0.1.22: Documented the script linter
+// - 2026-10-16 v0.1.23: Documented method versions
+// - 2026-10-16 v0.1.24: Documented EXPORT and IMPORT instead of ad-hoc exports
+// - 2026-10-16 v0.1.25: Documented tracing and metrics
 
 /*
 Package tcol implements the Terminal Command Object Language parser and execution engine for the mDW platform.
@@ -68,12 +93,56 @@ with business objects and services.
 	INVOICE[unpaid].SEND-REMINDER            # Send reminders to unpaid invoices
 	ORDER[status="pending" AND total>100].PROCESS   # Process large pending orders
 
+Filters support parentheses, NOT, IN lists, BETWEEN, LIKE with % and _
+wildcards, NULL checks, and arithmetic on both sides of a comparison:
+
+	ORDER[(status="open" OR status="hold") AND amount>100].LIST
+	ORDER[status NOT IN ("closed", "void") AND shipped_at IS NULL].LIST
+	INVOICE[amount BETWEEN 100 AND 500 AND name LIKE "M%"].LIST
+	INVOICE[amount * 1.19 > credit_limit - 50].LIST
+
+Subtraction needs spaces (a - b), since identifiers may contain hyphens.
+
 ### Direct Object Access
 
 	CUSTOMER:12345                           # Show customer 12345
 	INVOICE:98765                           # Show invoice 98765
 	CUSTOMER:12345:email="new@example.com"  # Update customer email
 
+### Method Versions
+
+Services evolve methods as new versions; a command without a version calls
+the current one, and METHOD@vN calls version N explicitly:
+
+	CUSTOMER.UPDATE id=42 status="active"       # Current version
+	CUSTOMER.UPDATE@v1 id=42 status="active"    # Version 1, e.g. a deprecated one
+
+Using a deprecated object or method version still works, but the result
+carries a warning (Result.Warnings) that names the replacement, and the
+interactive shell and the linter show it. CUSTOMER.UPDATE@v1 ? describes
+that version.
+
+### Multi-line Strings
+
+Parameter values may span lines as triple-quoted strings or heredocs. The
+heredoc opener must end its line; the body runs up to a line holding only
+the delimiter:
+
+	EMAIL.SEND to="ops@example.com" subject="Nightly run" body=<<EOF
+	Dear team,
+
+	the nightly run completed. Costs: \$120
+	EOF
+
+	NOTE.CREATE text="""
+	First line
+	Second line"""
+
+<<-EOF removes the common indentation of the body and allows an indented
+delimiter; <<'EOF' takes the body literally. In triple-quoted strings and
+unquoted heredocs, $name refers to a variable and \$ is a literal dollar
+sign.
+
 ### Command Abbreviations
 
 TCOL supports intelligent abbreviations that expand to full commands:
@@ -89,6 +158,27 @@ Create shortcuts for complex commands:
 
 	ALIAS.CREATE name="uc" command="CUSTOMER.LIST filter='unpaid=true'"
 	ALIAS.CREATE name="lr" command="REPORT.GENERATE type='monthly' format='pdf'"
+	ALIAS.CREATE name="ar" command="REPORT.GENERATE type='audit'" scope="global"
+	ALIAS.DELETE name="lr"
+
+Aliases live in the user's namespace unless scope="global" is given; a
+user's aliases take precedence over global aliases of the same name. Alias
+names may not shadow registered objects, commands, or abbreviations. With
+Options.AliasStore, e.g. registry.NewFileAliasStore, aliases are persisted
+and Registry().WatchAliases reloads them when the store changes.
+
+### Help and Introspection
+
+Available commands are discovered at runtime from the registry:
+
+	HELP.LIST                                      → object names
+	HELP.OBJECT name="CUSTOMER"                    → help text for all methods
+	HELP.METHOD object="CUSTOMER" method="CREATE"  → help text for one method
+	DESCRIBE.OBJECT name="CUSTOMER"                → structured schema
+
+Descriptions list each method's parameters with types, defaults, and allowed
+values, its required permissions, and examples. Programs use
+Registry.Describe and Registry.DescribeMethod directly.
 
 # Basic Usage Examples
 
@@ -144,15 +234,246 @@ Initialize and use the TCOL engine:
 		CUSTOMER[city="Munich"].UPDATE status="premium"
 	`)
 
+## Scripts and Variables
+
+Scripts hold one statement per line or several separated by ';'. LET binds
+the result of a command, or a value, to a variable that later statements
+reference with $name or $name.field:
+
+	results, err := highLevel.ExecuteScript(ctx, `
+		LET cust = CUSTOMER.CREATE name="Acme Corp" email="contact@acme.com"
+		LET limit = 1000
+		INVOICE.CREATE customer_id=$cust.id amount=$limit
+		INVOICE[customer_id=$cust.id AND amount>=$limit].LIST
+	`, execCtx)
+
+Variables cannot be rebound in the same scope; referencing an undefined
+variable fails the statement and stops the script. Triple-quoted strings and
+heredocs interpolate $name and ${name.field}.
+
+### Control Flow
+
+IF evaluates a filter expression against the script variables; FOREACH runs
+its body once per element of a list held in a variable or returned by a
+command:
+
+	FOREACH c IN CUSTOMER[status="active"].LIST DO
+		IF $c.balance > 1000 THEN
+			INVOICE.CREATE customer_id=$c.id amount=$c.balance
+		ELSE
+			CUSTOMER.UPDATE id=$c.id note="below limit"
+		END
+	END
+
+Blocks open a new scope, so the loop variable and LETs inside a block are
+not visible after END. A FOREACH over more elements than
+executor.Options.MaxLoopIterations (default 1000) fails before the first
+iteration, and a script running longer than ScriptTimeout (default 5
+minutes) stops before its next statement.
+
+## Pagination and Streaming
+
+List methods accept page_size and next_token. A result with more rows carries
+a NextToken that is passed as next_token to fetch the following page:
+
+	CUSTOMER.LIST status=active page_size=100
+	CUSTOMER.LIST status=active page_size=100 next_token="c2VxPTEwMA"
+
+To process large results without holding them in memory, stream the items:
+
+	_, err := highLevel.ExecuteStream(ctx, "CUSTOMER.LIST", execCtx,
+		func(item interface{}) error {
+			return encoder.Encode(item)
+		})
+
+Service clients implementing executor.StreamingServiceClient, such as the
+gRPC client, use server streaming; others are read page by page. Returning
+executor.ErrStopStream from the handler ends the stream early.
+
+## Output Formatting
+
+Results render themselves as aligned terminal tables, JSON, CSV, or YAML, so
+consumers need no renderer of their own. The format= and columns= parameters
+choose the format and the fields to show; they are not sent to services:
+
+	CUSTOMER.LIST status=active format=csv columns="name,email"
+
+	result, err := engine.Execute(ctx, `CUSTOMER.LIST format=table`)
+	if err == nil {
+		err = result.Render(os.Stdout)
+	}
+
+Options.OutputFormat sets the format used when a command has no format=
+parameter (default: table), and Options.MaxColumnWidth truncates long table
+cells. Table columns are padded by display width, so accented and East Asian
+text lines up. The tcol/format package renders arbitrary data the same way.
+
+## Asynchronous Jobs
+
+Long-running commands such as report generation or bulk updates can run in
+the background so the terminal session stays responsive:
+
+	jobID, err := highLevel.ExecuteAsync(ctx, `REPORT.GENERATE type="annual"`, execCtx)
+
+	JOB.STATUS id="job-..."   // PENDING, RUNNING, COMPLETED, FAILED, or CANCELLED
+	JOB.RESULT id="job-..."   // Result data once the job has completed
+	JOB.CANCEL id="job-..."   // Cancel a pending or running job
+
+Users only see their own jobs. Jobs are kept in memory unless
+executor.Options.JobStore is set, e.g. to a FileJobStore that keeps them
+across restarts.
+
+## Command History
+
+Every command a user enters is recorded with its session, outcome, and
+duration, and can be searched and executed again by its index:
+
+	HISTORY.LIST contains="invoice" limit=10   // Latest matching commands
+	HISTORY.LIST session="current"             // Commands of this session
+	HISTORY.RERUN index=42                     // Execute entry 42 again
+	HISTORY.RERUN index="-1"                   // Execute the latest entry again
+
+Histories are kept in memory unless Options.HistoryStore is set, e.g. to an
+executor.FileHistoryStore. With audit logging enabled, each entry is also
+written to the audit log. The History and ExportHistory methods of the
+executor search histories and export them as a table, JSON, CSV, or YAML.
+
+## Stored Procedures
+
+A script can be stored under a name with parameters, which the body refers
+to as variables, and run later with arguments:
+
+	PROC.DEFINE name="monthly-close" params="month" description="Close a month" body=<<'EOF'
+	FOREACH inv IN INVOICE.LIST month=$month status="open" DO
+		INVOICE.CLOSE id=$inv.id
+	END
+	REPORT.GENERATE type="monthly" month=$month
+	EOF
+	PROC.RUN name="monthly-close" month="2026-09"
+	PROC.LIST                                  // Name, description, parameters
+	PROC.SHOW name="monthly-close"             // Including the body
+
+Use a literal heredoc (<<'EOF') so that $month is bound when the procedure
+runs rather than when it is defined. Every command of a procedure is checked
+against the permissions of the user who runs it. Procedures are kept in
+memory unless Options.ProcedureStore is set, e.g. to a
+registry.FileProcedureStore.
+
+## Scheduled Commands
+
+SCHEDULE runs a command periodically by a five-field cron expression
+(minute, hour, day of month, month, day of week):
+
+	SCHEDULE.CREATE command="REPORT.GENERATE type='weekly'" cron="0 6 * * 1"
+	SCHEDULE.CREATE command="INVOICE.REMIND" cron="0 9 * * 1-5" timezone="Europe/Berlin"
+	SCHEDULE.LIST                        // ID, command, cron, status, next run
+	SCHEDULE.PAUSE id="sched-..."        // Stop running until resumed
+	SCHEDULE.RESUME id="sched-..."
+	SCHEDULE.HISTORY id="sched-..."      // Latest runs with status and error
+	SCHEDULE.DELETE id="sched-..."
+
+Scheduled commands run on behalf of the user who created them and are
+checked against that user's permissions. Users only see their own
+schedules. Commands are executed once StartScheduler has been called;
+schedules are kept in memory unless Options.ScheduleStore is set, e.g. to an
+executor.FileScheduleStore.
+
+## Natural Language Requests
+
+Package translate proposes commands for requests in plain language with the
+Turing LLM service, using the registry schema as context. Proposals are
+validated against the registry and never executed by the translator; the
+user confirms them first, as with ask in the interactive shell:
+
+	tcol> ask open invoices of Acme over 1000
+	Proposed: INVOICE[((customer = "Acme") AND (total > 1000))].LIST status="open"
+	  Lists the open invoices of Acme over 1000.
+	Execute? [y/N]
+
+## Batch Execution
+
+ExecuteBatch runs a list of commands, e.g. the lines of a command file, and
+reports the outcome of each instead of stopping at the first error:
+
+	result, err := engine.ExecuteBatch(ctx, commands, tcol.BatchOptions{
+		Concurrency: 4,     // Commands executed at the same time (default: 1, in order)
+		StopOnError: false, // Skip the remaining commands after a failure
+	})
+	for _, item := range result.Items {
+		if item.Err != nil {
+			fmt.Printf("line %d: %v\n", item.Index+1, item.Err)
+		}
+	}
+
+If commands failed, err is a *BatchError listing them; errors.Is and
+errors.As look into the errors of the individual commands.
+
+## Interactive Shell
+
+Package repl runs an engine as an interactive shell with line editing, tab
+completion from the registry, syntax highlighting, multi-line input, a
+persistent history, and inline help (CUSTOMER.CREATE ?):
+
+	shell, _ := repl.New(engine, repl.Options{HistoryFile: historyPath})
+	err := shell.Run(ctx)
+
+## Linting
+
+Package lint checks scripts against the registry without executing them.
+It reports syntax errors, unknown objects and methods (with suggestions),
+abbreviations that should be written out in scripts, missing required
+parameters, and UPDATE or DELETE without a filter or id:
+
+	linter, _ := lint.New(engine.Registry(), lint.Options{})
+	diagnostics := linter.Lint(script)
+	lint.Write(os.Stderr, "nightly.tcol", diagnostics)
+	// nightly.tcol:3:3: warning: CUSTOMER.UPDATE without filter or id applies to every CUSTOMER [unfiltered-bulk]
+	if lint.HasErrors(diagnostics) {
+		os.Exit(1)
+	}
+
+The interactive shell writes the warnings of a command before executing it
+and asks for confirmation of unfiltered updates and deletes.
+
 ## Command Chaining
 
 	// Chain multiple operations
 	result, err = engine.Execute(ctx, `
 		CUSTOMER.CREATE name="New Customer" email="new@test.com" |
-		INVOICE.CREATE customer_id=$LAST_ID amount=500.00 |
-		INVOICE.SEND template="welcome"
+		INVOICE.CREATE customer_id=$PREV.id amount=500.00 |
+		INVOICE.SEND id=$PREV.id template="welcome"
 	`)
 
+Each stage sees the data of the previous one as $PREV. List elements are
+addressed by index, and [*] collects a field of every element:
+
+	ORDER:4711 | STOCK.RESERVE skus=$PREV.items[*].sku first=$PREV.items[0].sku
+
+Transform stages reshape results locally between service calls:
+
+	CUSTOMER.LIST | SELECT name, address.city      // Keep only these fields
+	CUSTOMER.LIST | SORT BY balance DESC, name     // ASC is the default
+	CUSTOMER.LIST | SORT BY balance DESC | LIMIT 10
+	INVOICE.LIST | GROUP BY status                 // status, count, and items per group
+
+A pipe returns the result of its last stage and fails if any stage fails.
+
+### Import and Export
+
+EXPORT writes the result of the previous stage to a file, and IMPORT reads
+a file into parameter sets. A service command following IMPORT runs once
+per row, with the columns as parameters and the row as $PREV:
+
+	CUSTOMER[city="Berlin"].LIST | EXPORT.CSV file="berlin-customers.csv"
+	INVOICE.LIST status=open | SORT BY due | EXPORT.XLSX file="reports/open.xlsx" fields="number,customer,total,due"
+	CUSTOMER.LIST | EXPORT.JSON file="customers.json" overwrite=true
+	IMPORT.CSV file="new-customers.csv" | CUSTOMER.CREATE status="new"
+	IMPORT.JSONL file="moves.jsonl" | CUSTOMER.UPDATE id=$PREV.customer city=$PREV.to
+
+Files live in the directory of Options.FileRoot, a filex.Root; names that
+would leave it are rejected, and EXPORT and IMPORT are disabled without it.
+EXPORT does not replace a file unless overwrite=true is given.
+
 ## Error Handling
 
 TCOL integrates with mDW Foundation error handling:
@@ -173,6 +494,20 @@ TCOL integrates with mDW Foundation error handling:
 		}
 	}
 
+Unknown objects and methods carry up to three registered commands the user
+may have meant, ranked by similarity, with prefixes ("CUST") and
+abbreviations ("CRT" for CREATE) counting as close matches:
+
+	_, err := engine.Execute(ctx, "CUSTOMER.CRAETE")
+	// unknown method CRAETE for object CUSTOMER (did you mean CUSTOMER.CREATE?)
+	if tcolErr, ok := err.(*tcol.Error); ok {
+		for _, command := range tcolErr.Suggestions() {
+			fmt.Println("Did you mean", command)
+		}
+	}
+
+Registry.Suggest ranks suggestions directly, e.g. for completion.
+
 # Architecture Components
 
 ## Parser Pipeline
@@ -217,6 +552,14 @@ Abstract Syntax Tree representation:
 		Chain      *Command          // Next command in chain
 	}
 
+ast.Walk traverses a tree and ast.Rewrite replaces nodes bottom-up, so
+linters and optimizers can work on parsed commands. ast.Apply runs
+rewriting passes in order; the built-in passes fold constants, normalize
+names and operators, and expand registry abbreviations:
+
+	cmd, err = ast.Apply(cmd, ast.FoldConstants(), ast.Normalize(),
+		ast.ExpandAbbreviations(engine.Registry().ExpandAbbreviation))
+
 ### Executor (pkg/tcol/executor)
 
 Executes parsed commands:
@@ -285,6 +628,27 @@ TCOL seamlessly integrates with mDW Foundation modules:
 		return result, nil
 	}
 
+## Tracing and Metrics
+
+The engine reports its work through the telemetry package. A Tracer, e.g. an
+adapter for an OpenTelemetry tracer, receives tcol.parse and tcol.lex spans
+for the input, a tcol.execute span for each stage of a pipe, and a
+tcol.command span for each object and method executed:
+
+	import mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
+
+	engine, err := tcol.NewEngine(tcol.Options{
+		ServiceClient: client,
+		Tracer:        otelAdapter,
+	})
+
+Engine.Metrics counts commands in total and by object and method, failures
+by error code, and keeps latency histograms of commands and of parsing. An
+admin metrics endpoint serves them through the MetricsSource interface, as
+JSON or in the Prometheus text format:
+
+	mux.Handle("/admin/tcol/metrics", mdwtelemetry.Handler(engine.Metrics()))
+
 ## String Utilities Integration
 
 	import "github.com/msto63/mDW/foundation/utils/stringx"
@@ -356,27 +720,37 @@ All TCOL input is validated and sanitized:
 
 ## Permission Checking
 
-Commands are checked against user permissions:
+Options.Permissions is checked by the executor before every command reaches
+a service, including each stage of a pipe and each command of a script.
+Built-in objects such as HELP, HISTORY and JOB are not checked.
+RolePermissionChecker grants commands through roles:
 
-	func (e *Engine) checkPermissions(ctx context.Context, cmd *ast.Command) error {
-		userID := ctx.Value("userId").(string)
-		
-		required := fmt.Sprintf("%s:%s", cmd.Object, cmd.Method)
-		
-		if !e.permissions.HasPermission(userID, required) {
-			return error.NewWithContext(
-				ErrorCodePermissionDenied,
-				"Insufficient permissions for command",
-				map[string]interface{}{
-					"userId":     userID,
-					"required":   required,
-					"command":    cmd.String(),
-				},
-			).WithSeverity(error.SeverityHigh)
-		}
-		
-		return nil
-	}
+	store := mdwexecutor.NewMemoryPermissionStore()
+	store.SaveRole(&mdwexecutor.Role{
+		Name:        "viewer",
+		Permissions: []string{"*:LIST:self", "*:GET:self"},
+	})
+	store.SaveRole(&mdwexecutor.Role{
+		Name:        "clerk",
+		Inherits:    []string{"viewer"},
+		Permissions: []string{"CUSTOMER:*:team", "!CUSTOMER:DELETE"},
+	})
+	store.AssignRoles("carl", "clerk")
+
+	engine, _ := tcol.NewEngine(tcol.Options{
+		Permissions: mdwexecutor.NewRolePermissionChecker(store),
+	})
+
+Permissions are written OBJECT:METHOD:scope, where object and method may be
+'*' and the scope is all, team or self (default: all). A role includes the
+permissions of the roles it inherits. A deny rule such as !CUSTOMER:DELETE
+wins over grants from any role. The widest granted scope is passed to the
+service in the execution context metadata under
+mdwexecutor.PermissionScopeKey; the service restricts the records it
+touches accordingly. Denied commands fail with an error wrapping
+mdwexecutor.ErrPermissionDenied and are audit logged. The user is read from
+the "userId" context value. Other stores implement
+mdwexecutor.PermissionStore.
 
 ## Audit Logging
 
@@ -417,44 +791,55 @@ Register custom business objects:
 
 ## Custom Functions
 
-Add custom filter functions:
+Administrators define reusable filter functions (macros) in the restricted
+filter expression language; no Go code is required (see package tcol/macro):
 
-	// Register custom filter function
-	engine.Registry().RegisterFunction("age_in_days", func(date time.Time) int {
-		return int(time.Since(date).Hours() / 24)
+	macros, _ := macro.New(macro.Options{})
+	macros.Register(macro.Definition{
+		Name: "is_overdue",
+		Body: `due_date < today() AND status != "paid"`,
 	})
 
+	engine, _ := tcol.NewEngine(tcol.Options{FilterMacros: macros})
+
 	// Use in commands
-	result, err = engine.Execute(ctx, `
-		CUSTOMER[age_in_days(created_at) > 30].LIST
-	`)
+	result, err = engine.Execute(ctx, `INVOICE[is_overdue()].SEND-REMINDER`)
 
 ## Middleware Support
 
-Add middleware for cross-cutting concerns:
+Middleware wraps the execution of each command. The first
+registered middleware is the outermost; UseFor limits middleware to an
+object and method, with "*" matching any:
 
-	// Add authentication middleware
-	engine.Use(func(ctx context.Context, cmd *ast.Command, next ExecutorFunc) (*Result, error) {
-		if !isAuthenticated(ctx) {
-			return nil, NewAuthenticationError("User not authenticated")
-		}
-		return next(ctx, cmd)
+	engine.Use(executor.AuthMiddleware(permissions))
+	engine.Use(executor.RateLimitMiddleware(100, time.Minute))
+	engine.UseFor("CUSTOMER", "*", executor.CacheMiddleware(executor.CacheOptions{TTL: time.Minute}))
+	engine.Use(executor.AuditMiddleware(logger))
+
+	// Custom middleware may change the context seen by later steps
+	engine.Use(func(ctx context.Context, cmd *ast.Command, execCtx *executor.ExecutionContext,
+		next executor.ExecutorFunc) (*executor.ExecutionResult, error) {
+		return next(context.WithValue(ctx, tenantKey{}, execCtx.Metadata["tenant"]), cmd, execCtx)
 	})
 
-	// Add caching middleware
-	engine.Use(func(ctx context.Context, cmd *ast.Command, next ExecutorFunc) (*Result, error) {
-		cacheKey := generateCacheKey(cmd)
-		if cached := cache.Get(cacheKey); cached != nil {
-			return cached.(*Result), nil
-		}
-		
-		result, err := next(ctx, cmd)
-		if err == nil {
-			cache.Set(cacheKey, result, 5*time.Minute)
-		}
-		
-		return result, err
+Returning without calling next short-circuits the command. The cache
+middleware caches read methods per user and invalidates an object's entries
+when any other method on it succeeds. A ResultCache adds invalidation tags
+for reads that depend on other objects, and counters for admin endpoints:
+
+	cache := executor.NewResultCache(executor.CacheOptions{
+		TTL: time.Minute,
+		Tags: func(cmd *ast.Command) []string {
+			if cmd.Object == "REPORT" {
+				return []string{"CUSTOMER", "INVOICE"} // Writes to either invalidate reports
+			}
+			return nil
+		},
 	})
+	engine.Use(cache.Middleware())
+
+	cache.Invalidate("CUSTOMER") // E.g. after an import outside TCOL
+	stats := cache.Stats()       // Hits, Misses, Evictions, Expirations, Invalidations, Entries
 
 For comprehensive examples, advanced usage patterns, and integration guides, see the
 examples directory and TCOL specification documentation.
@@ -4,12 +4,18 @@
 //              integrates parser, executor, and registry components for
 //              command processing. Compatible with the existing tcol.go API.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial high-level engine implementation
+// - 2026-10-16 v0.1.1: Added script parsing and execution
+// - 2026-10-16 v0.1.2: Added asynchronous command execution
+// - 2026-10-16 v0.1.3: Added streaming command execution
+// - 2026-10-16 v0.1.4: Added middleware registration
+// - 2026-10-16 v0.1.5: Commands are recorded in the history as entered
+// - 2026-10-16 v0.1.6: Added the scheduler of SCHEDULE commands
 
 package tcol
 
@@ -135,7 +141,9 @@ func (e *HighLevelEngine) Execute(ctx context.Context, command string, execCtx *
 
 	// Execute the command if executor is available
 	if e.executor != nil {
-		result, err := e.executor.Execute(ctx, cmd, execCtx)
+		cmdCtx := *execCtx
+		cmdCtx.Input = command
+		result, err := e.executor.Execute(ctx, cmd, &cmdCtx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute TCOL command: %w", err)
 		}
@@ -150,6 +158,110 @@ func (e *HighLevelEngine) Execute(ctx context.Context, command string, execCtx *
 	}, nil
 }
 
+// ExecuteAsync parses a TCOL command and starts executing it in the
+// background. The returned job ID is used with JOB.STATUS, JOB.RESULT, and
+// JOB.CANCEL.
+func (e *HighLevelEngine) ExecuteAsync(ctx context.Context, command string, execCtx *ExecutionContext) (string, error) {
+	if mdwstringx.IsBlank(command) {
+		return "", fmt.Errorf("command cannot be empty")
+	}
+	if e.executor == nil {
+		return "", fmt.Errorf("asynchronous execution requires an executor")
+	}
+
+	cmd, err := e.parser.Parse(command)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse TCOL command: %w", err)
+	}
+
+	jobCtx := *execCtx
+	jobCtx.Input = command
+	jobID, err := e.executor.ExecuteAsync(ctx, cmd, &jobCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to start TCOL job: %w", err)
+	}
+	return jobID, nil
+}
+
+// ExecuteStream parses a TCOL command and passes the items of its result to
+// handler as they arrive, so large results are not held in memory
+func (e *HighLevelEngine) ExecuteStream(ctx context.Context, command string, execCtx *ExecutionContext, handler mdwexecutor.StreamHandler) (*ExecutionResult, error) {
+	if mdwstringx.IsBlank(command) {
+		return nil, fmt.Errorf("command cannot be empty")
+	}
+	if e.executor == nil {
+		return nil, fmt.Errorf("streaming requires an executor")
+	}
+
+	cmd, err := e.parser.Parse(command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TCOL command: %w", err)
+	}
+
+	result, err := e.executor.ExecuteStream(ctx, cmd, execCtx, handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream TCOL command: %w", err)
+	}
+	return result, nil
+}
+
+// StartScheduler starts executing the commands scheduled with
+// SCHEDULE.CREATE when they are due; Close stops it
+func (e *HighLevelEngine) StartScheduler() error {
+	if e.executor == nil {
+		return fmt.Errorf("scheduling requires an executor")
+	}
+	return e.executor.StartScheduler()
+}
+
+// Use adds middleware around the execution of all commands
+func (e *HighLevelEngine) Use(middleware mdwexecutor.Middleware) error {
+	return e.UseFor("*", "*", middleware)
+}
+
+// UseFor adds middleware around the execution of commands on object and
+// method; "*" matches any object or method
+func (e *HighLevelEngine) UseFor(object, method string, middleware mdwexecutor.Middleware) error {
+	if e.executor == nil {
+		return fmt.Errorf("middleware requires an executor")
+	}
+	e.executor.UseFor(object, method, middleware)
+	return nil
+}
+
+// ExecuteScript parses and executes a TCOL script statement by statement.
+// Variables bound with LET live in execCtx.Variables if set, so a session
+// can keep them across scripts.
+func (e *HighLevelEngine) ExecuteScript(ctx context.Context, script string, execCtx *ExecutionContext) ([]*ExecutionResult, error) {
+	parsed, err := e.ParseScript(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TCOL script: %w", err)
+	}
+
+	if e.executor == nil {
+		return []*ExecutionResult{{
+			Success:     true,
+			Data:        parsed,
+			CommandType: "PARSE_ONLY",
+		}}, nil
+	}
+
+	results, err := e.executor.ExecuteScript(ctx, parsed, execCtx)
+	if err != nil {
+		return results, fmt.Errorf("failed to execute TCOL script: %w", err)
+	}
+	return results, nil
+}
+
+// ParseScript parses a TCOL script without executing it
+func (e *HighLevelEngine) ParseScript(script string) (*mdwast.Script, error) {
+	if mdwstringx.IsBlank(script) {
+		return nil, fmt.Errorf("script cannot be empty")
+	}
+
+	return e.parser.ParseScript(script)
+}
+
 // Parse parses a TCOL command without executing it
 func (e *HighLevelEngine) Parse(command string) (*mdwast.Command, error) {
 	if mdwstringx.IsBlank(command) {
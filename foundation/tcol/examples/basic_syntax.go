@@ -5,9 +5,9 @@
 //              object-method operations, and basic command structures
 //              for the Terminal Command Object Language.
 // Author: msto63 with Claude Opus 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-07-26
-// Modified: 2025-07-26
+// Modified: 2026-10-16
 
 package examples
 
@@ -207,7 +207,8 @@ func (demo *TCOLBasicSyntaxDemo) ChainedCommands() []string {
 		"INVOICE:INV-001 || INVOICE.CREATE customer_id=12345",
 		
 		// Piped operations (output of first becomes input of second)
-		"CUSTOMER[city=\"Berlin\"].LIST | EXPORT.CSV filename=\"berlin-customers.csv\"",
+		"CUSTOMER[city=\"Berlin\"].LIST | EXPORT.CSV file=\"berlin-customers.csv\"",
+		"IMPORT.CSV file=\"new-customers.csv\" | CUSTOMER.CREATE status=\"new\"",
 		"INVOICE[status=\"unpaid\"].LIST | EMAIL.SEND template=\"reminder\"",
 		"TASK[assignee=\"john.doe\"].LIST | REPORT.GENERATE format=\"pdf\"",
 		
@@ -146,7 +146,7 @@
 // ### Integration Commands
 //   - EMAIL: Communication integration
 //   - API: External system integration
-//   - IMPORT/EXPORT: Data exchange operations
+//   - IMPORT/EXPORT: Built-in file import and export stages of pipes
 //   - SYNC: Data synchronization
 //   - WEBHOOK: Event-driven integrations
 //
@@ -0,0 +1,300 @@
+// File: cache.go
+// Title: TCOL Command Result Cache
+// Description: Implements the result cache behind CacheMiddleware. Results
+//              of read methods are cached per user under the canonical form
+//              of the command and tagged with the objects they depend on;
+//              writes invalidate the results carrying their tags. Keeps
+//              counters for admin and monitoring endpoints.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the tagged result cache
+
+package executor
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// CacheOptions configures a ResultCache
+type CacheOptions struct {
+	TTL        time.Duration // Lifetime of cached results (default: 1 minute)
+	MaxEntries int           // Maximum number of cached results (default: 1000)
+	Methods    []string      // Read methods whose results are cached (default: GET, LIST, SEARCH, SHOW, COUNT)
+
+	// Tags returns the invalidation tags of a command in addition to its
+	// object, e.g. the objects a report reads. Cached results carry the
+	// tags of their command, and a write invalidates all results carrying
+	// one of its tags (optional).
+	Tags func(cmd *mdwast.Command) []string
+}
+
+// CacheStats holds the counters of a result cache. Its JSON form is served
+// by admin metrics endpoints.
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`          // Results served from the cache
+	Misses        uint64 `json:"misses"`        // Cacheable commands sent to the service
+	Evictions     uint64 `json:"evictions"`     // Results removed because the cache was full
+	Expirations   uint64 `json:"expirations"`   // Results removed after their TTL
+	Invalidations uint64 `json:"invalidations"` // Results removed by writes or Invalidate
+	Entries       int    `json:"entries"`       // Results currently cached
+}
+
+// HitRatio returns the share of cache hits among all cacheable commands
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// cachedResult is a cached result with its tags and expiry time
+type cachedResult struct {
+	key     string
+	tags    []string
+	result  *ExecutionResult
+	expires time.Time
+}
+
+// ResultCache caches the results of read methods. Add it to an engine with
+// Use(cache.Middleware()).
+type ResultCache struct {
+	options     CacheOptions
+	readMethods map[string]bool
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	tagged  map[string]map[string]*list.Element // Tag → key → entry
+	order   *list.List                          // Most recently used first
+	epoch   uint64                              // Incremented by every invalidation
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	evictions     atomic.Uint64
+	expirations   atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// NewResultCache creates an empty result cache
+func NewResultCache(opts CacheOptions) *ResultCache {
+	if opts.TTL == 0 {
+		opts.TTL = time.Minute
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = 1000
+	}
+	if len(opts.Methods) == 0 {
+		opts.Methods = []string{"GET", "LIST", "SEARCH", "SHOW", "COUNT"}
+	}
+	readMethods := make(map[string]bool, len(opts.Methods))
+	for _, method := range opts.Methods {
+		readMethods[strings.ToUpper(method)] = true
+	}
+
+	return &ResultCache{
+		options:     opts,
+		readMethods: readMethods,
+		entries:     make(map[string]*list.Element),
+		tagged:      make(map[string]map[string]*list.Element),
+		order:       list.New(),
+	}
+}
+
+// Middleware returns the middleware that serves read methods from the cache
+// and invalidates cached results after successful writes. Streamed results
+// are not cached.
+func (c *ResultCache) Middleware() Middleware {
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		if !c.readMethods[strings.ToUpper(cmd.Method)] {
+			result, err := next(ctx, cmd, execCtx)
+			if err == nil && result.Success {
+				c.Invalidate(c.tags(cmd)...)
+			}
+			return result, err
+		}
+
+		key, cacheable := cacheKey(cmd, execCtx)
+		if !cacheable {
+			return next(ctx, cmd, execCtx)
+		}
+		if hit := c.lookup(key); hit != nil {
+			c.hits.Add(1)
+			return hit, nil
+		}
+		c.misses.Add(1)
+
+		c.mutex.Lock()
+		epoch := c.epoch
+		c.mutex.Unlock()
+
+		result, err := next(ctx, cmd, execCtx)
+		if err != nil || !result.Success || result.CommandType == "STREAM" {
+			return result, err
+		}
+		c.store(key, c.tags(cmd), result, epoch)
+		return result, nil
+	}
+}
+
+// Invalidate removes all cached results carrying one of the tags and
+// returns their number. Tags ignore case; object names are tags.
+func (c *ResultCache) Invalidate(tags ...string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.epoch++
+	removed := 0
+	for _, tag := range tags {
+		for _, element := range c.tagged[strings.ToUpper(tag)] {
+			c.remove(element)
+			removed++
+		}
+	}
+	c.invalidations.Add(uint64(removed))
+	return removed
+}
+
+// Clear removes all cached results; counters are kept
+func (c *ResultCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.epoch++
+	c.entries = make(map[string]*list.Element)
+	c.tagged = make(map[string]map[string]*list.Element)
+	c.order.Init()
+}
+
+// Stats returns the current cache counters
+func (c *ResultCache) Stats() CacheStats {
+	c.mutex.Lock()
+	entries := c.order.Len()
+	c.mutex.Unlock()
+
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Expirations:   c.expirations.Load(),
+		Invalidations: c.invalidations.Load(),
+		Entries:       entries,
+	}
+}
+
+// lookup returns a copy of the cached result for key, marked as cached, or
+// nil if there is none or it has expired
+func (c *ResultCache) lookup(key string) *ExecutionResult {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil
+	}
+	cached := element.Value.(*cachedResult)
+	if !time.Now().Before(cached.expires) {
+		c.remove(element)
+		c.expirations.Add(1)
+		return nil
+	}
+
+	c.order.MoveToFront(element)
+	hit := *cached.result
+	hit.Metadata = copyMetadata(cached.result.Metadata)
+	hit.Metadata["cached"] = true
+	return &hit
+}
+
+// store caches a copy of a result under key and evicts the least recently
+// used results beyond MaxEntries. Results of reads that ran while results
+// were invalidated, the epoch at their start being outdated, may be stale
+// and are not cached.
+func (c *ResultCache) store(key string, tags []string, result *ExecutionResult, epoch uint64) {
+	// Store a copy; the executor adds chain results to the metadata
+	stored := *result
+	stored.Metadata = copyMetadata(result.Metadata)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.entries[key]; exists || epoch != c.epoch {
+		return
+	}
+	element := c.order.PushFront(&cachedResult{key: key, tags: tags, result: &stored, expires: time.Now().Add(c.options.TTL)})
+	c.entries[key] = element
+	for _, tag := range tags {
+		if c.tagged[tag] == nil {
+			c.tagged[tag] = make(map[string]*list.Element)
+		}
+		c.tagged[tag][key] = element
+	}
+
+	for c.order.Len() > c.options.MaxEntries {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// remove removes a cached result from the list and the indexes; the caller
+// holds the mutex
+func (c *ResultCache) remove(element *list.Element) {
+	cached := element.Value.(*cachedResult)
+	c.order.Remove(element)
+	delete(c.entries, cached.key)
+	for _, tag := range cached.tags {
+		delete(c.tagged[tag], cached.key)
+		if len(c.tagged[tag]) == 0 {
+			delete(c.tagged, tag)
+		}
+	}
+}
+
+// tags returns the upper-case invalidation tags of a command: its object
+// and the tags added by CacheOptions.Tags
+func (c *ResultCache) tags(cmd *mdwast.Command) []string {
+	tags := []string{strings.ToUpper(cmd.Object)}
+	if c.options.Tags != nil {
+		for _, tag := range c.options.Tags(cmd) {
+			tag = strings.ToUpper(strings.TrimSpace(tag))
+			if tag != "" && !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// cacheKey identifies a command for a user by the canonical JSON form of
+// the command without its chain. It holds the types and resolved values of
+// the parameters and does not depend on their order.
+func cacheKey(cmd *mdwast.Command, execCtx *ExecutionContext) (string, bool) {
+	stage := *cmd
+	stage.Chain = nil
+	data, err := json.Marshal(&stage)
+	if err != nil {
+		return "", false
+	}
+	return execCtx.UserID + "|" + string(data), true
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
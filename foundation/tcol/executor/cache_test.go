@@ -0,0 +1,166 @@
+// File: cache_test.go
+// Title: TCOL Command Result Cache Tests
+// Description: Tests the canonical cache keys, tag-based invalidation, and
+//              the counters of the result cache.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial result cache tests
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+func TestResultCache_Keys(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	cache := NewResultCache(CacheOptions{})
+	engine.Use(cache.Middleware())
+	execCtx := createTestContext()
+
+	// Parameter order and spelling of the same values share one entry
+	enter(t, engine, `CUSTOMER.LIST status="open" region=eu`, execCtx)
+	enter(t, engine, `CUSTOMER.LIST region="eu"  status=open`, execCtx)
+	if calls := len(client.GetCallHistory()); calls != 1 {
+		t.Errorf("service calls = %d, want 1", calls)
+	}
+
+	// Variables are keyed by their values, not by their names
+	script := "LET s = \"open\"\nCUSTOMER.LIST status=$s region=\"eu\"\nLET t = \"closed\"\nCUSTOMER.LIST status=$t region=\"eu\"\n"
+	if _, err := engine.ExecuteScript(context.Background(), parseScript(t, script), execCtx); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if calls := len(client.GetCallHistory()); calls != 2 {
+		t.Errorf("service calls after script = %d, want 2", calls)
+	}
+
+	// A string and a number variable of the same text differ
+	enter(t, engine, `CUSTOMER.LIST code="7"`, execCtx)
+	scope := NewScope(nil)
+	scope.Define("code", int64(7))
+	withCode := *execCtx
+	withCode.Variables = scope
+	enter(t, engine, `CUSTOMER.LIST code=$code`, &withCode)
+	if calls := len(client.GetCallHistory()); calls != 4 {
+		t.Errorf("service calls after typed values = %d, want 4", calls)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 4 || stats.Entries != 4 || stats.HitRatio() != 2.0/6 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestResultCache_Tags(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	cache := NewResultCache(CacheOptions{
+		Methods: []string{"LIST"},
+		Tags: func(cmd *mdwast.Command) []string {
+			if cmd.Object == "INVOICE" {
+				return []string{"customer", " "}
+			}
+			return nil
+		},
+	})
+	engine.Use(cache.Middleware())
+	execCtx := createTestContext()
+	run := func(input string) {
+		if _, err := enter(t, engine, input, execCtx); err != nil {
+			t.Fatalf("%s error = %v", input, err)
+		}
+	}
+	calls := func() int {
+		defer client.ClearHistory()
+		return len(client.GetCallHistory())
+	}
+
+	run(`CUSTOMER.LIST`)
+	run(`INVOICE.LIST`)
+	run(`CUSTOMER.LIST`)
+	run(`INVOICE.LIST`)
+	if n := calls(); n != 2 {
+		t.Fatalf("service calls = %d, want 2", n)
+	}
+
+	// A write to CUSTOMER invalidates CUSTOMER reads and reads tagged CUSTOMER
+	run(`CUSTOMER.UPDATE id=1 name="Acme"`)
+	run(`CUSTOMER.LIST`)
+	run(`INVOICE.LIST`)
+	if n := calls(); n != 3 {
+		t.Errorf("service calls after CUSTOMER.UPDATE = %d, want 3", n)
+	}
+
+	// Writes invalidate their own tags as well
+	run(`INVOICE.CREATE amount=10`)
+	run(`CUSTOMER.LIST`)
+	run(`INVOICE.LIST`)
+	if n := calls(); n != 3 {
+		t.Errorf("service calls after INVOICE.CREATE = %d, want 3", n)
+	}
+
+	if removed := cache.Invalidate("invoice"); removed != 1 {
+		t.Errorf("Invalidate(invoice) = %d, want 1", removed)
+	}
+	if stats := cache.Stats(); stats.Invalidations != 5 || stats.Entries != 1 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	cache.Clear()
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Hits != 2 {
+		t.Errorf("Stats() after Clear() = %+v", stats)
+	}
+}
+
+func TestResultCache_Stats(t *testing.T) {
+	engine, _ := newMiddlewareEngine(t)
+	cache := NewResultCache(CacheOptions{TTL: 20 * time.Millisecond, MaxEntries: 1})
+	engine.Use(cache.Middleware())
+	list := func(status string) {
+		engine.Execute(context.Background(), customerCommand("LIST", map[string]mdwast.Value{
+			"status": {Type: mdwast.ValueTypeString, Value: status},
+		}), createTestContext())
+	}
+
+	list("active")
+	list("inactive") // Evicts active
+	time.Sleep(30 * time.Millisecond)
+	list("inactive") // Expired
+
+	stats := cache.Stats()
+	if stats.Misses != 3 || stats.Evictions != 1 || stats.Expirations != 1 || stats.Entries != 1 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil || string(data) != `{"hits":0,"misses":3,"evictions":1,"expirations":1,"invalidations":0,"entries":1}` {
+		t.Errorf("JSON = %s, %v", data, err)
+	}
+}
+
+func TestResultCache_ConcurrentWrite(t *testing.T) {
+	cache := NewResultCache(CacheOptions{})
+	middleware := cache.Middleware()
+	execCtx := createTestContext()
+	list := customerCommand("LIST", nil)
+
+	// A write finishing during a read makes its result possibly stale
+	read := func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+		middleware(ctx, customerCommand("UPDATE", nil), execCtx, func(context.Context, *mdwast.Command, *ExecutionContext) (*ExecutionResult, error) {
+			return &ExecutionResult{Success: true}, nil
+		})
+		return &ExecutionResult{Success: true, Data: "stale"}, nil
+	}
+	middleware(context.Background(), list, execCtx, read)
+	if stats := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("read overlapping a write was cached: %+v", stats)
+	}
+}
@@ -0,0 +1,160 @@
+// File: control.go
+// Title: TCOL Script Control Flow
+// Description: Implements the execution of IF and FOREACH statements in TCOL
+//              scripts. Conditions are filter expressions evaluated against
+//              the script variables; loops are bounded by the configured
+//              iteration limit and the script timeout.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of IF and FOREACH
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwmacro "github.com/msto63/mDW/foundation/tcol/macro"
+)
+
+// executeIf evaluates the condition of an IF statement and runs the THEN or
+// ELSE block in a child scope
+func (e *Engine) executeIf(ctx context.Context, stmt *mdwast.IfStmt, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	if err := stmt.Validate(); err != nil {
+		return nil, err
+	}
+
+	matches, err := e.evaluateCondition(stmt.Condition, execCtx.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("IF condition: %w", err)
+	}
+
+	block, branch := stmt.Then, "THEN"
+	if !matches {
+		block, branch = stmt.Else, "ELSE"
+	}
+
+	blockCtx := *execCtx
+	blockCtx.Variables = NewScope(execCtx.Variables)
+	results, err := e.executeStatements(ctx, block, &blockCtx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", branch, err)
+	}
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        results,
+		CommandType: "IF",
+		Metadata:    map[string]interface{}{"branch": branch},
+	}, nil
+}
+
+// executeForEach runs the body of a FOREACH statement once per element of
+// its source list, binding the element in a new child scope per iteration
+func (e *Engine) executeForEach(ctx context.Context, stmt *mdwast.ForEachStmt, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	if err := stmt.Validate(); err != nil {
+		return nil, err
+	}
+
+	items, err := e.loopItems(ctx, stmt, execCtx)
+	if err != nil {
+		return nil, fmt.Errorf("FOREACH %s: %w", stmt.Name, err)
+	}
+	if len(items) > e.options.MaxLoopIterations {
+		return nil, fmt.Errorf("FOREACH %s: %d elements exceed the loop limit of %d",
+			stmt.Name, len(items), e.options.MaxLoopIterations)
+	}
+
+	results := make([]*ExecutionResult, 0, len(items)*len(stmt.Body))
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("FOREACH %s: aborted at iteration %d: %w", stmt.Name, i+1, err)
+		}
+
+		iterCtx := *execCtx
+		iterCtx.RequestID = fmt.Sprintf("%s.%d", execCtx.RequestID, i)
+		iterCtx.Variables = NewScope(execCtx.Variables)
+		if err := iterCtx.Variables.Define(stmt.Name, item); err != nil {
+			return nil, fmt.Errorf("FOREACH %s: %w", stmt.Name, err)
+		}
+
+		iterResults, err := e.executeStatements(ctx, stmt.Body, &iterCtx)
+		if err != nil {
+			return nil, fmt.Errorf("FOREACH %s: iteration %d: %w", stmt.Name, i+1, err)
+		}
+		results = append(results, iterResults...)
+	}
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        results,
+		CommandType: "FOREACH",
+		Metadata:    map[string]interface{}{"iterations": len(items)},
+	}, nil
+}
+
+// loopItems returns the list a FOREACH statement iterates: the value of its
+// variable or the result data of its command. A nil source has no elements.
+func (e *Engine) loopItems(ctx context.Context, stmt *mdwast.ForEachStmt, execCtx *ExecutionContext) ([]interface{}, error) {
+	var source interface{}
+	if stmt.Command != nil {
+		result, err := e.Execute(ctx, stmt.Command, execCtx)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Success {
+			return nil, fmt.Errorf("command %s.%s failed", stmt.Command.Object, stmt.Command.Method)
+		}
+		source = result.Data
+	} else {
+		path, _ := stmt.Value.Value.(string)
+		value, err := execCtx.Variables.resolve(path)
+		if err != nil {
+			return nil, err
+		}
+		source = value
+	}
+
+	switch list := source.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return list, nil
+	case []map[string]interface{}:
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("cannot iterate over %T", source)
+	}
+}
+
+// evaluateCondition resolves the variable references of a condition and
+// evaluates it in-process. Bare identifiers refer to variables by name.
+func (e *Engine) evaluateCondition(condition mdwast.Expr, scope *Scope) (bool, error) {
+	resolved, err := resolveExpr(condition, scope)
+	if err != nil {
+		return false, err
+	}
+
+	record := make(map[string]interface{})
+	for _, name := range scope.Names() {
+		record[name], _ = scope.Lookup(name)
+	}
+
+	if e.macros != nil {
+		return e.macros.Evaluate(resolved, record)
+	}
+	prog, err := mdwmacro.Compile(resolved)
+	if err != nil {
+		return false, err
+	}
+	return prog.Matches(record, 0)
+}
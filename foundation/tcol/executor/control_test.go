@@ -0,0 +1,156 @@
+// File: control_test.go
+// Title: TCOL Script Control Flow Tests
+// Description: Tests IF and FOREACH statements including branch selection,
+//              loop scoping, loop limits, and script timeouts.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial control flow tests
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+func TestEngine_ExecuteScript_ControlFlow(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{
+		Success: true,
+		Data: []interface{}{
+			map[string]interface{}{"id": "C-1", "balance": 900},
+			map[string]interface{}{"id": "C-2", "balance": 100},
+			map[string]interface{}{"id": "C-3", "balance": 700},
+		},
+	})
+
+	script := parseScript(t, `
+LET limit = 500
+FOREACH c IN CUSTOMER[status = "active"].LIST DO
+  IF $c.balance > $limit THEN
+    INVOICE.CREATE customer_id=$c.id
+  ELSE
+    LET note = """Skipped $c.id"""
+    CUSTOMER.UPDATE id=$c.id note=$note
+  END
+END
+IF limit < 100 THEN CUSTOMER.DELETE id=1 END
+`)
+	results, err := engine.ExecuteScript(context.Background(), script, createTestContext())
+	if err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if len(results) != 3 || results[1].CommandType != "FOREACH" || results[2].CommandType != "IF" {
+		t.Fatalf("results = %v", results)
+	}
+	if results[1].Metadata["iterations"] != 3 || results[2].Metadata["branch"] != "ELSE" {
+		t.Errorf("metadata = %v, %v", results[1].Metadata, results[2].Metadata)
+	}
+
+	var calls []string
+	for _, call := range client.GetCallHistory() {
+		calls = append(calls, call.ObjectName+"."+call.MethodName)
+	}
+	expected := "CUSTOMER.LIST INVOICE.CREATE CUSTOMER.UPDATE INVOICE.CREATE"
+	if strings.Join(calls, " ") != expected {
+		t.Fatalf("calls = %v, want %s", calls, expected)
+	}
+	history := client.GetCallHistory()
+	if history[1].Params["customer_id"] != "C-1" || history[3].Params["customer_id"] != "C-3" {
+		t.Errorf("loop variable not bound per iteration: %v, %v", history[1].Params, history[3].Params)
+	}
+	if history[2].Params["note"] != "Skipped C-2" {
+		t.Errorf("note = %v", history[2].Params["note"])
+	}
+}
+
+func TestEngine_ExecuteScript_ForEachVariable(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	execCtx := createTestContext()
+	execCtx.Variables = NewScope(nil)
+	execCtx.Variables.Define("ids", []interface{}{"A", "B"})
+	execCtx.Variables.Define("none", nil)
+
+	script := parseScript(t, `
+FOREACH id IN $ids DO INVOICE.SEND id=$id; END
+FOREACH id IN $none DO INVOICE.SEND id=$id; END
+`)
+	if _, err := engine.ExecuteScript(context.Background(), script, execCtx); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if calls := client.GetCallHistory(); len(calls) != 2 || calls[1].Params["id"] != "B" {
+		t.Errorf("calls = %v", calls)
+	}
+
+	// Loop and block variables do not leak into the enclosing scope
+	if _, exists := execCtx.Variables.Lookup("id"); exists {
+		t.Error("loop variable visible after the loop")
+	}
+}
+
+func TestEngine_ExecuteScript_ControlFlowErrors(t *testing.T) {
+	client := NewMockServiceClient()
+	engine, err := New(Options{ServiceClient: client, MaxLoopIterations: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	registry := createTestRegistry()
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "INVOICE",
+		Service: "invoice-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"SEND": {Name: "SEND"}},
+	})
+	engine.SetRegistry(registry)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{
+		Success: true,
+		Data:    []interface{}{1, 2, 3},
+	})
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{
+		Success: true,
+		Data:    map[string]interface{}{"id": "C-1"},
+	})
+
+	tests := []struct {
+		name   string
+		script string
+		errMsg string
+	}{
+		{"Loop limit", "FOREACH x IN CUSTOMER.LIST DO INVOICE.SEND id=$x; END", "3 elements exceed the loop limit of 2"},
+		{"Not a list", "FOREACH x IN CUSTOMER.CREATE name=x DO INVOICE.SEND id=$x; END", "cannot iterate over map"},
+		{"Undefined source", "FOREACH x IN $nothing DO INVOICE.SEND id=$x; END", "undefined variable $nothing"},
+		{"Undefined in condition", "IF $nothing > 1 THEN INVOICE.SEND id=1 END", "IF condition"},
+		{"Error in body", "IF 1 = 1 THEN INVOICE.SEND id=$nothing END", "THEN: statement 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.ExecuteScript(context.Background(), parseScript(t, tt.script), createTestContext())
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ExecuteScript() error = %v, want %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestEngine_ExecuteScript_Timeout(t *testing.T) {
+	client := NewMockServiceClient()
+	engine, _ := New(Options{ServiceClient: client, ScriptTimeout: time.Nanosecond})
+	engine.SetRegistry(createTestRegistry())
+
+	time.Sleep(time.Millisecond)
+	_, err := engine.ExecuteScript(context.Background(), parseScript(t, "CUSTOMER.LIST"), createTestContext())
+	if err == nil || !strings.Contains(err.Error(), "script aborted") {
+		t.Errorf("ExecuteScript() error = %v, want timeout", err)
+	}
+	if calls := len(client.GetCallHistory()); calls != 0 {
+		t.Errorf("service calls after timeout = %d", calls)
+	}
+}
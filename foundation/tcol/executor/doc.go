@@ -4,12 +4,28 @@
 //              AST nodes and executes them by routing commands to appropriate
 //              services, handling responses, and managing execution context.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.16
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial executor implementation
+// - 2026-10-16 v0.1.1: Documented script execution and variables
+// - 2026-10-16 v0.1.2: Documented IF and FOREACH execution
+// - 2026-10-16 v0.1.3: Documented asynchronous jobs
+// - 2026-10-16 v0.1.4: Documented pagination and streaming
+// - 2026-10-16 v0.1.5: Documented middleware
+// - 2026-10-16 v0.1.6: Documented HELP and DESCRIBE
+// - 2026-10-16 v0.1.7: Documented pipes and transform stages
+// - 2026-10-16 v0.1.8: Documented the command history
+// - 2026-10-16 v0.1.9: Documented role-based permissions
+// - 2026-10-16 v0.1.10: Documented stored procedures
+// - 2026-10-16 v0.1.11: Documented the result cache
+// - 2026-10-16 v0.1.12: Documented scheduled commands
+// - 2026-10-16 v0.1.13: Documented method versions and deprecation warnings
+// - 2026-10-16 v0.1.14: Documented the EXPORT and IMPORT stages
+// - 2026-10-16 v0.1.15: Documented tracing and metrics
+// - 2026-10-16 v0.1.16: Service objects registered as EXPORT or IMPORT
 
 /*
 Package executor provides command execution capabilities for TCOL.
@@ -23,6 +39,96 @@ This package implements the execution engine that takes parsed TCOL commands
   • Supporting command chaining and composition
   • Providing audit logging and monitoring
 
+Scripts (ast.Script) run statement by statement with ExecuteScript. LET
+binds the result data of a command, or a value, in the variable Scope of the
+ExecutionContext; $name and $name.field references and interpolated strings
+are resolved before a command is sent. After each successful command whose
+data has an "id" field, the implicit variable $LAST_ID holds it.
+
+IF conditions are evaluated in-process, with the filter macros of the
+engine, against the visible variables. FOREACH iterates a list variable or
+the list data of a command, binding each element in a child scope. Both are
+bounded by Options.MaxLoopIterations and Options.ScriptTimeout.
+
+ExecuteAsync runs a command in the background and returns a job ID. Jobs are
+recorded in a JobStore (MemoryJobStore by default, or FileJobStore) and
+queried or cancelled with the built-in JOB.STATUS, JOB.RESULT, and
+JOB.CANCEL commands. Close cancels jobs that are still running.
+
+Commands executed at the top level are recorded in a HistoryStore
+(MemoryHistoryStore by default, or FileHistoryStore) as ExecutionContext.Input,
+the text the user entered, and fed into audit logging. History, Rerun, and
+ExportHistory search, re-execute, and export the entries; the built-in
+HISTORY.LIST and HISTORY.RERUN commands do the same for the current user.
+
+DefineProcedure stores a script under a name after checking that it parses;
+RunProcedure runs it with its arguments bound as variables in a new Scope,
+so the body neither sees nor changes the variables of the caller. The
+built-in PROC object defines, runs, shows, lists, and deletes procedures.
+Procedures that run procedures are bounded by Options.MaxChainDepth.
+
+CreateSchedule stores a command with a cron expression (timex.ParseCron) in
+a ScheduleStore (MemoryScheduleStore by default, or FileScheduleStore).
+StartScheduler checks for due schedules every Options.ScheduleInterval and
+executes them on behalf of the user who created them; RunDueSchedules does
+the same once. Each schedule keeps its latest Options.MaxScheduleRuns runs.
+The built-in SCHEDULE object creates, lists, pauses, resumes, and deletes
+schedules and shows their runs.
+
+Options.PermissionChecker is consulted before a command is sent to a
+service; denials are audit logged. RolePermissionChecker grants commands
+through roles kept in a PermissionStore (MemoryPermissionStore or a custom
+store). Permissions are written [!]OBJECT:METHOD[:scope] with '*' wildcards
+and the scopes all, team and self; roles inherit the permissions of other
+roles, and deny rules win over grants. The granted scope is passed to the
+service in the metadata of the ExecutionContext under PermissionScopeKey.
+
+The page_size and next_token parameters are validated and sent to services
+as _page_size and _next_token; ExecutionResult.NextToken holds the cursor of
+the next page. ExecuteStream passes result items to a StreamHandler, using
+StreamingServiceClient where the client supports it and paging otherwise.
+
+Use and UseFor add Middleware around command execution, e.g. the built-in
+AuthMiddleware, RateLimitMiddleware, CacheMiddleware, and AuditMiddleware.
+CacheMiddleware is backed by a ResultCache, which keys results by the
+canonical form of the command and tags them with their object and the tags
+of CacheOptions.Tags; successful writes invalidate their tags. Stats returns
+CacheStats for admin endpoints.
+
+In a pipe, each stage sees the data of the previous stage as $PREV, with
+list paths such as $PREV.items[0] and $PREV.items[*].sku. SELECT, SORT BY,
+LIMIT, and GROUP BY stages reshape that data locally without a service
+call. A pipe returns the result of its last stage and fails if any stage
+fails.
+
+The built-in EXPORT stage writes $PREV to a CSV, JSON, or XLSX file with the
+renderers of the format package; IMPORT reads the rows of a CSV, JSON, or
+JSON Lines file, at most Options.MaxImportRows. A service command directly
+following an IMPORT runs once per row, with the fields of the row as
+parameters it does not set itself and the row as $PREV; the first failing
+row ends the batch. Both resolve file names in Options.FileRoot, a
+filex.Root that rejects paths leaving it, are checked by the permission
+checker like service commands, and fail with ErrFileAccessDisabled if no
+root is set. EXPORT does not replace existing files unless overwrite=true.
+A service that registers its own EXPORT or IMPORT object replaces the
+built-in, and its commands are sent to the service like any other.
+
+A command naming a method version (METHOD@v2) is sent with the version as
+the _version parameter (VersionKey), to the service of that version if it
+declares one; without a version the current one is called and no _version
+is sent. Using a deprecated object or method version logs a warning and
+adds it to ExecutionResult.Warnings, which collects the warnings of all
+stages of a pipe.
+
+The built-in HELP object renders registry descriptions as text; DESCRIBE
+returns them as registry.ObjectDescription and registry.MethodDescription.
+
+Execute starts a span on Options.Tracer for every stage of a pipe, and the
+middleware pipeline a span for every command it runs. The commands are
+counted with their latency and error code in Options.Metrics, which Metrics
+returns; commands answered by middleware, such as cached results, count as
+well, transform stages do not.
+
 The executor integrates with the mDW Foundation's error handling, logging,
 and service communication infrastructure to provide secure and reliable
 command execution.
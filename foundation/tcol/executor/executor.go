@@ -5,24 +5,45 @@
 //              and execution context management with comprehensive error
 //              handling and audit logging.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.17
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial executor implementation
+// - 2026-10-16 v0.1.1: Serialize BETWEEN and IS NULL filter expressions
+// - 2026-10-16 v0.1.2: Resolve script variables and set LAST_ID
+// - 2026-10-16 v0.1.3: Loop limit and timeout for scripts
+// - 2026-10-16 v0.1.4: Asynchronous jobs and the built-in JOB object
+// - 2026-10-16 v0.1.5: Result pagination and streaming
+// - 2026-10-16 v0.1.6: Middleware pipeline around command execution
+// - 2026-10-16 v0.1.7: HELP rendering and the built-in DESCRIBE object
+// - 2026-10-16 v0.1.8: User and global alias namespaces, ALIAS.DELETE
+// - 2026-10-16 v0.1.9: Pipes pass $PREV and return the last stage; transform stages
+// - 2026-10-16 v0.1.10: Command history and the built-in HISTORY object
+// - 2026-10-16 v0.1.11: Log denied permission checks
+// - 2026-10-16 v0.1.12: Added the built-in PROC object for stored procedures
+// - 2026-10-16 v0.1.13: Added the built-in SCHEDULE object and the scheduler
+// - 2026-10-16 v0.1.14: Method versions and warnings for deprecated commands
+// - 2026-10-16 v0.1.15: Built-in EXPORT and IMPORT stages; batches of imported rows
+// - 2026-10-16 v0.1.16: Tracing spans of stages and commands; command metrics
+// - 2026-10-16 v0.1.17: Registered service objects take precedence over EXPORT and IMPORT
 
 package executor
 
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwmacro "github.com/msto63/mDW/foundation/tcol/macro"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
 )
 
 // Engine executes TCOL commands by routing them to appropriate services
@@ -30,9 +51,20 @@ type Engine struct {
 	registry    *mdwregistry.Registry
 	client      ServiceClient
 	permissions PermissionChecker
+	macros      *mdwmacro.Library
+	jobStore    JobStore
+	history     HistoryStore
+	running     map[string]*runningJob
+	schedules   ScheduleStore
+	scheduling  map[string]bool // IDs of schedules being run
+	scheduler   *scheduler
+	middleware  []scopedMiddleware
 	logger      *mdwlog.Logger
 	options     Options
 	mutex       sync.RWMutex
+
+	// scheduleMutex serializes changes of stored schedules and scheduling
+	scheduleMutex sync.Mutex
 }
 
 // Options configures executor behavior
@@ -43,6 +75,20 @@ type Options struct {
 	EnableAuditLog   bool
 	PermissionChecker PermissionChecker
 	ServiceClient    ServiceClient
+	FilterMacros     *mdwmacro.Library // Optional user-defined filter functions
+	MaxLoopIterations int              // Maximum elements a FOREACH may iterate
+	ScriptTimeout    time.Duration     // Maximum run time of a script
+	JobStore         JobStore          // Store of asynchronous jobs; in memory if nil
+	MaxPageSize      int               // Largest page_size a command may request
+	StreamPageSize   int               // Page size used to stream from non-streaming clients
+	HistoryStore     HistoryStore      // Store of command histories; in memory if nil
+	ScheduleStore    ScheduleStore     // Store of scheduled commands; in memory if nil
+	ScheduleInterval time.Duration     // How often the scheduler checks for due schedules
+	MaxScheduleRuns  int               // Runs kept in the history of each schedule
+	FileRoot         *mdwfilex.Root    // Directory EXPORT writes to and IMPORT reads from; both are disabled if nil
+	MaxImportRows    int               // Most rows IMPORT reads from a file
+	Tracer           mdwtelemetry.Tracer   // Receives spans of stages and commands; none are recorded if nil
+	Metrics          *mdwtelemetry.Metrics // Counts commands, latencies, and errors; new metrics if nil
 }
 
 // ExecutionContext provides context for command execution
@@ -57,6 +103,8 @@ type ExecutionContext struct {
 	Metadata       map[string]interface{}
 	ChainDepth     int
 	ParentCommand  *mdwast.Command
+	Variables      *Scope // Script variables; a new scope is used if nil
+	Input          string // Command text as entered; recorded in the history
 }
 
 // ExecutionResult represents the result of command execution
@@ -68,8 +116,14 @@ type ExecutionResult struct {
 	ServiceName   string                 `json:"service_name,omitempty"`
 	CommandType   string                 `json:"command_type"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	NextToken     string                 `json:"next_token,omitempty"` // Cursor of the next page; empty on the last page
+	Warnings      []string               `json:"warnings,omitempty"`   // E.g. use of deprecated commands
 }
 
+// VersionKey is the reserved parameter key an explicit method version
+// (METHOD@v2) is sent as; calls of the current version do not have it
+const VersionKey = "_version"
+
 // ServiceClient interface for communicating with microservices
 type ServiceClient interface {
 	Execute(ctx context.Context, serviceName, objectName, methodName string, 
@@ -85,6 +139,7 @@ type ServiceResponse struct {
 	Error     string                 `json:"error,omitempty"`
 	ErrorCode string                 `json:"error_code,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	NextToken string                 `json:"next_token,omitempty"`
 }
 
 // PermissionChecker interface for checking command permissions
@@ -105,6 +160,42 @@ func New(opts Options) (*Engine, error) {
 	if opts.MaxChainDepth == 0 {
 		opts.MaxChainDepth = 10
 	}
+	if opts.MaxLoopIterations == 0 {
+		opts.MaxLoopIterations = 1000
+	}
+	if opts.ScriptTimeout == 0 {
+		opts.ScriptTimeout = 5 * time.Minute
+	}
+	if opts.JobStore == nil {
+		opts.JobStore = NewMemoryJobStore()
+	}
+	if opts.MaxPageSize == 0 {
+		opts.MaxPageSize = 1000
+	}
+	if opts.StreamPageSize == 0 {
+		opts.StreamPageSize = 500
+	}
+	if opts.HistoryStore == nil {
+		opts.HistoryStore = NewMemoryHistoryStore(DefaultHistorySize)
+	}
+	if opts.ScheduleStore == nil {
+		opts.ScheduleStore = NewMemoryScheduleStore()
+	}
+	if opts.ScheduleInterval == 0 {
+		opts.ScheduleInterval = DefaultScheduleInterval
+	}
+	if opts.MaxScheduleRuns == 0 {
+		opts.MaxScheduleRuns = DefaultScheduleRuns
+	}
+	if opts.MaxImportRows == 0 {
+		opts.MaxImportRows = DefaultMaxImportRows
+	}
+	if opts.Tracer == nil {
+		opts.Tracer = mdwtelemetry.NoopTracer()
+	}
+	if opts.Metrics == nil {
+		opts.Metrics = mdwtelemetry.NewMetrics()
+	}
 
 	// Validate required dependencies
 	if opts.ServiceClient == nil {
@@ -114,6 +205,12 @@ func New(opts Options) (*Engine, error) {
 	engine := &Engine{
 		client:      opts.ServiceClient,
 		permissions: opts.PermissionChecker,
+		macros:      opts.FilterMacros,
+		jobStore:    opts.JobStore,
+		history:     opts.HistoryStore,
+		running:     make(map[string]*runningJob),
+		schedules:   opts.ScheduleStore,
+		scheduling:  make(map[string]bool),
 		logger:      opts.Logger.WithField("component", "tcol-executor"),
 		options:     opts,
 	}
@@ -135,18 +232,12 @@ func (e *Engine) SetRegistry(registry *mdwregistry.Registry) {
 }
 
 // Execute executes a TCOL command with the given context
-func (e *Engine) Execute(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+func (e *Engine) Execute(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (result *ExecutionResult, err error) {
 	if cmd == nil {
 		return nil, fmt.Errorf("command cannot be nil")
 	}
 
-	if execCtx == nil {
-		execCtx = &ExecutionContext{
-			RequestID:     fmt.Sprintf("req-%d", time.Now().UnixNano()),
-			Timestamp:     time.Now(),
-			Metadata:      make(map[string]interface{}),
-		}
-	}
+	execCtx = withScope(execCtx)
 
 	// Check chain depth
 	if execCtx.ChainDepth >= e.options.MaxChainDepth {
@@ -156,6 +247,19 @@ func (e *Engine) Execute(ctx context.Context, cmd *mdwast.Command, execCtx *Exec
 
 	startTime := time.Now()
 
+	ctx, span := e.options.Tracer.Start(ctx, mdwtelemetry.SpanExecute, stageAttributes(cmd, execCtx)...)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	// Commands entered at the top level are recorded in the history;
+	// HISTORY commands themselves are not
+	if execCtx.ChainDepth == 0 && cmd.Object != "HISTORY" {
+		defer func() {
+			e.recordHistory(cmd, execCtx, startTime, result, err)
+		}()
+	}
+
 	e.logger.Debug("Executing TCOL command", mdwlog.Fields{
 		"requestID":   execCtx.RequestID,
 		"userID":      execCtx.UserID,
@@ -169,22 +273,56 @@ func (e *Engine) Execute(ctx context.Context, cmd *mdwast.Command, execCtx *Exec
 		e.auditCommand(cmd, execCtx, "STARTED")
 	}
 
-	// Execute main command
-	result, err := e.executeCommand(ctx, cmd, execCtx)
-	if err != nil {
+	// Transform stages run locally on the previous result
+	if cmd.Transform != nil {
+		result, err := e.executeTransform(cmd.Transform, execCtx)
+		if err == nil {
+			return e.completeExecution(ctx, cmd, execCtx, result, startTime)
+		}
 		if e.options.EnableAuditLog {
 			e.auditCommand(cmd, execCtx, "FAILED")
 		}
 		return nil, err
 	}
 
+	// Service commands following an IMPORT run once per imported row
+	if rows, isBatch := e.importedRows(cmd, execCtx); isBatch {
+		if result, err = e.executeImportBatch(ctx, cmd, rows, execCtx); err == nil {
+			return e.completeExecution(ctx, cmd, execCtx, result, startTime)
+		}
+		if e.options.EnableAuditLog {
+			e.auditCommand(cmd, execCtx, "FAILED")
+		}
+		return nil, err
+	}
+
+	// Resolve variables and execute main command
+	resolved, err := resolveCommand(cmd, execCtx.Variables)
+	if err == nil {
+		if result, err = e.pipeline(resolved, e.executeCommand)(ctx, resolved, execCtx); err == nil {
+			return e.completeExecution(ctx, cmd, execCtx, result, startTime)
+		}
+	}
+	if e.options.EnableAuditLog {
+		e.auditCommand(cmd, execCtx, "FAILED")
+	}
+	return nil, err
+}
+
+// completeExecution records LAST_ID and executes the chain of a command
+// that has executed successfully. The chained stage sees the data as $PREV;
+// a pipe returns the result of its last stage and fails with any stage.
+func (e *Engine) completeExecution(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, result *ExecutionResult, startTime time.Time) (*ExecutionResult, error) {
 	result.ExecutionTime = time.Since(startTime)
+	recordLastID(execCtx.Variables, result)
 
 	// Execute chain if present
 	if cmd.Chain != nil {
 		chainCtx := *execCtx // Copy context
 		chainCtx.ChainDepth++
 		chainCtx.ParentCommand = cmd
+		chainCtx.Variables = NewScope(execCtx.Variables)
+		chainCtx.Variables.setLocal(PrevVariable, result.Data)
 
 		chainResult, err := e.Execute(ctx, cmd.Chain, &chainCtx)
 		if err != nil {
@@ -192,14 +330,14 @@ func (e *Engine) Execute(ctx context.Context, cmd *mdwast.Command, execCtx *Exec
 				"requestID": execCtx.RequestID,
 				"error":     err.Error(),
 			})
-			// Don't fail the main command if chain fails
-		} else {
-			// Merge chain result with main result
-			if result.Metadata == nil {
-				result.Metadata = make(map[string]interface{})
+			if e.options.EnableAuditLog {
+				e.auditCommand(cmd, execCtx, "FAILED")
 			}
-			result.Metadata["chainResult"] = chainResult
+			return nil, err
 		}
+		chainResult.ExecutionTime = time.Since(startTime)
+		chainResult.Warnings = append(append([]string(nil), result.Warnings...), chainResult.Warnings...)
+		result = chainResult
 	}
 
 	if e.options.EnableAuditLog {
@@ -227,6 +365,7 @@ func (e *Engine) ExecuteBatch(ctx context.Context, commands []*mdwast.Command, e
 		// Create separate context for each command
 		cmdCtx := *execCtx
 		cmdCtx.RequestID = fmt.Sprintf("%s-%d", execCtx.RequestID, i)
+		cmdCtx.Input = ""
 		
 		result, err := e.Execute(ctx, cmd, &cmdCtx)
 		if err != nil {
@@ -342,52 +481,89 @@ func (e *Engine) executeFieldOperation(ctx context.Context, cmd *mdwast.Command,
 // executeMethodCall executes method calls (OBJECT.METHOD)
 func (e *Engine) executeMethodCall(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
 	// Handle built-in commands
-	if cmd.Object == "ALIAS" || cmd.Object == "HELP" {
+	if e.isBuiltin(cmd.Object) {
 		return e.executeBuiltinCommand(ctx, cmd, execCtx)
 	}
 
+	serviceName, params, err := e.prepareMethodCall(ctx, cmd, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute service call
+	response, err := e.client.Execute(ctx, serviceName, cmd.Object, cmd.Method, params, execCtx)
+	if err != nil {
+		return nil, e.wrapServiceError(err, serviceName, cmd.Object, cmd.Method)
+	}
+
+	return &ExecutionResult{
+		Success:     response.Success,
+		Data:        response.Data,
+		ServiceName: serviceName,
+		CommandType: "METHOD_CALL",
+		Metadata:    response.Metadata,
+		NextToken:   response.NextToken,
+		Warnings:    e.deprecationWarnings(cmd, execCtx),
+	}, nil
+}
+
+// prepareMethodCall checks a method call and returns its service and the
+// parameters to send. An explicit method version is sent as VersionKey,
+// to the service of that version if it has its own.
+func (e *Engine) prepareMethodCall(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (string, map[string]interface{}, error) {
 	// Check permissions
 	if err := e.checkPermission(ctx, cmd.Object, cmd.Method, execCtx); err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
 	// Validate command exists in registry
+	var method *mdwregistry.MethodDefinition
 	if e.registry != nil {
 		if err := e.registry.ValidateCommand(cmd.Object, cmd.Method); err != nil {
-			return nil, err
+			return "", nil, err
+		}
+		var err error
+		if method, err = e.registry.GetMethodVersion(cmd.Object, cmd.Method, cmd.Version); err != nil {
+			return "", nil, err
 		}
 	}
 
 	// Get service for object
 	serviceName, err := e.getServiceForObject(cmd.Object)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+	if method != nil && method.Service != "" {
+		serviceName = method.Service
 	}
 
-	// Convert AST values to interface{}
+	// Convert AST values to interface{}, moving paging parameters to their
+	// reserved keys
 	params := make(map[string]interface{})
 	for key, value := range cmd.Parameters {
 		params[key] = value.Value
 	}
-
-	// Add filter if present
-	if cmd.Filter != nil {
-		params["_filter"] = e.serializeFilter(cmd.Filter)
+	if err := e.pageParams(params); err != nil {
+		return "", nil, err
+	}
+	if cmd.Version > 0 {
+		params[VersionKey] = cmd.Version
 	}
 
-	// Execute service call
-	response, err := e.client.Execute(ctx, serviceName, cmd.Object, cmd.Method, params, execCtx)
-	if err != nil {
-		return nil, e.wrapServiceError(err, serviceName, cmd.Object, cmd.Method)
+	// Add filter if present, expanding user-defined filter macros first
+	if cmd.Filter != nil {
+		filter := cmd.Filter
+		if e.macros != nil {
+			expanded, err := e.macros.ExpandFilter(filter)
+			if err != nil {
+				return "", nil, err
+			}
+			filter = expanded
+		}
+		params["_filter"] = e.serializeFilter(filter)
 	}
 
-	return &ExecutionResult{
-		Success:     response.Success,
-		Data:        response.Data,
-		ServiceName: serviceName,
-		CommandType: "METHOD_CALL",
-		Metadata:    response.Metadata,
-	}, nil
+	return serviceName, params, nil
 }
 
 // executeBuiltinCommand executes built-in TCOL commands
@@ -397,11 +573,50 @@ func (e *Engine) executeBuiltinCommand(ctx context.Context, cmd *mdwast.Command,
 		return e.executeAliasCommand(ctx, cmd, execCtx)
 	case "HELP":
 		return e.executeHelpCommand(ctx, cmd, execCtx)
+	case "DESCRIBE":
+		return e.executeDescribeCommand(ctx, cmd, execCtx)
+	case "JOB":
+		return e.executeJobCommand(ctx, cmd, execCtx)
+	case "HISTORY":
+		return e.executeHistoryCommand(ctx, cmd, execCtx)
+	case "PROC":
+		return e.executeProcCommand(ctx, cmd, execCtx)
+	case "SCHEDULE":
+		return e.executeScheduleCommand(ctx, cmd, execCtx)
+	case "EXPORT":
+		return e.executeExportCommand(ctx, cmd, execCtx)
+	case "IMPORT":
+		return e.executeImportCommand(ctx, cmd, execCtx)
 	default:
 		return nil, fmt.Errorf("unknown built-in command: %s", cmd.Object)
 	}
 }
 
+// isBuiltinObject reports whether an object is handled by the executor
+// itself rather than a service
+func isBuiltinObject(object string) bool {
+	switch object {
+	case "ALIAS", "HELP", "DESCRIBE", "JOB", "HISTORY", "PROC", "SCHEDULE", "EXPORT", "IMPORT":
+		return true
+	default:
+		return false
+	}
+}
+
+// isBuiltin reports whether the engine handles an object itself. A service
+// object registered in place of the EXPORT or IMPORT built-in takes
+// precedence over it.
+func (e *Engine) isBuiltin(object string) bool {
+	if !isBuiltinObject(object) {
+		return false
+	}
+	if e.registry == nil {
+		return true
+	}
+	obj, err := e.registry.GetObject(object)
+	return err != nil || obj.Service == mdwregistry.InternalService
+}
+
 // executeAliasCommand executes ALIAS commands
 func (e *Engine) executeAliasCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
 	if e.registry == nil {
@@ -416,8 +631,12 @@ func (e *Engine) executeAliasCommand(ctx context.Context, cmd *mdwast.Command, e
 		if !hasName || !hasCommand {
 			return nil, fmt.Errorf("ALIAS.CREATE requires 'name' and 'command' parameters")
 		}
+		userID, err := aliasNamespace(cmd, execCtx)
+		if err != nil {
+			return nil, err
+		}
 		
-		err := e.registry.RegisterAlias(name.Value.(string), command.Value.(string))
+		err = e.registry.RegisterUserAlias(userID, fmt.Sprint(name.Value), fmt.Sprint(command.Value))
 		if err != nil {
 			return nil, err
 		}
@@ -428,8 +647,28 @@ func (e *Engine) executeAliasCommand(ctx context.Context, cmd *mdwast.Command, e
 			CommandType: "BUILTIN",
 		}, nil
 
+	case "DELETE":
+		name, hasName := cmd.Parameters["name"]
+		if !hasName {
+			return nil, fmt.Errorf("ALIAS.DELETE requires 'name' parameter")
+		}
+		userID, err := aliasNamespace(cmd, execCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.registry.DeleteAlias(userID, fmt.Sprint(name.Value)); err != nil {
+			return nil, err
+		}
+
+		return &ExecutionResult{
+			Success:     true,
+			Data:        fmt.Sprintf("Alias '%s' deleted successfully", name.Value),
+			CommandType: "BUILTIN",
+		}, nil
+
 	case "LIST":
-		aliases := e.registry.GetAliases()
+		aliases := e.registry.GetUserAliases(execCtx.UserID)
 		return &ExecutionResult{
 			Success:     true,
 			Data:        aliases,
@@ -441,6 +680,30 @@ func (e *Engine) executeAliasCommand(ctx context.Context, cmd *mdwast.Command, e
 	}
 }
 
+// aliasNamespace returns the user ID of the alias namespace selected by the
+// scope parameter: the user's own by default, or "" for the global one
+func aliasNamespace(cmd *mdwast.Command, execCtx *ExecutionContext) (string, error) {
+	scope := "user"
+	if execCtx.UserID == "" {
+		scope = "global"
+	}
+	if value, exists := cmd.Parameters["scope"]; exists {
+		scope = strings.ToLower(fmt.Sprint(value.Value))
+	}
+
+	switch scope {
+	case "global":
+		return "", nil
+	case "user":
+		if execCtx.UserID == "" {
+			return "", fmt.Errorf("ALIAS.%s with scope user requires a user", cmd.Method)
+		}
+		return execCtx.UserID, nil
+	default:
+		return "", fmt.Errorf("invalid alias scope %q: must be user or global", scope)
+	}
+}
+
 // executeHelpCommand executes HELP commands
 func (e *Engine) executeHelpCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
 	if e.registry == nil {
@@ -456,28 +719,67 @@ func (e *Engine) executeHelpCommand(ctx context.Context, cmd *mdwast.Command, ex
 			CommandType: "BUILTIN",
 		}, nil
 
-	case "OBJECT":
-		name, hasName := cmd.Parameters["name"]
-		if !hasName {
-			return nil, fmt.Errorf("HELP.OBJECT requires 'name' parameter")
+	case "OBJECT", "METHOD":
+		desc, err := e.describe(cmd)
+		if err != nil {
+			return nil, err
 		}
 
-		obj, err := e.registry.GetObject(name.Value.(string))
+		return &ExecutionResult{
+			Success:     true,
+			Data:        desc.Text(),
+			CommandType: "BUILTIN",
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown HELP method: %s", cmd.Method)
+	}
+}
+
+// executeDescribeCommand executes DESCRIBE commands
+func (e *Engine) executeDescribeCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	if e.registry == nil {
+		return nil, fmt.Errorf("registry not available for describe operations")
+	}
+
+	switch cmd.Method {
+	case "OBJECT", "METHOD":
+		desc, err := e.describe(cmd)
 		if err != nil {
 			return nil, err
 		}
 
 		return &ExecutionResult{
 			Success:     true,
-			Data:        obj,
+			Data:        desc,
 			CommandType: "BUILTIN",
 		}, nil
 
 	default:
-		return nil, fmt.Errorf("unknown HELP method: %s", cmd.Method)
+		return nil, fmt.Errorf("unknown DESCRIBE method: %s", cmd.Method)
 	}
 }
 
+// describe returns the registry description requested by a HELP or
+// DESCRIBE command: the object named by 'name' for OBJECT, or the method
+// named by 'object' and 'method' for METHOD
+func (e *Engine) describe(cmd *mdwast.Command) (interface{ Text() string }, error) {
+	if cmd.Method == "OBJECT" {
+		name, hasName := cmd.Parameters["name"]
+		if !hasName {
+			return nil, fmt.Errorf("%s.OBJECT requires 'name' parameter", cmd.Object)
+		}
+		return e.registry.Describe(fmt.Sprint(name.Value))
+	}
+
+	object, hasObject := cmd.Parameters["object"]
+	method, hasMethod := cmd.Parameters["method"]
+	if !hasObject || !hasMethod {
+		return nil, fmt.Errorf("%s.METHOD requires 'object' and 'method' parameters", cmd.Object)
+	}
+	return e.registry.DescribeMethod(fmt.Sprint(object.Value), fmt.Sprint(method.Value))
+}
+
 // Utility methods
 
 // checkPermission checks if the user has permission to execute the command
@@ -486,7 +788,43 @@ func (e *Engine) checkPermission(ctx context.Context, objectName, methodName str
 		return nil // No permission checker configured
 	}
 
-	return e.permissions.CheckPermission(ctx, execCtx.UserID, objectName, methodName, execCtx)
+	err := e.permissions.CheckPermission(ctx, execCtx.UserID, objectName, methodName, execCtx)
+	if err != nil {
+		fields := mdwlog.Fields{
+			"object":    objectName,
+			"method":    methodName,
+			"requestID": execCtx.RequestID,
+			"userID":    execCtx.UserID,
+			"sessionID": execCtx.SessionID,
+			"error":     err.Error(),
+		}
+		if e.options.EnableAuditLog {
+			e.logger.Audit("TCOL permission denied", fields)
+		} else {
+			e.logger.Warn("TCOL permission denied", fields)
+		}
+	}
+	return err
+}
+
+// deprecationWarnings logs and returns the warnings for a command that
+// uses a deprecated object or method version
+func (e *Engine) deprecationWarnings(cmd *mdwast.Command, execCtx *ExecutionContext) []string {
+	if e.registry == nil {
+		return nil
+	}
+	warnings := e.registry.Deprecations(cmd.Object, cmd.Method, cmd.Version)
+	for _, warning := range warnings {
+		e.logger.Warn("Deprecated TCOL command used", mdwlog.Fields{
+			"requestID": execCtx.RequestID,
+			"userID":    execCtx.UserID,
+			"object":    cmd.Object,
+			"method":    cmd.Method,
+			"version":   cmd.Version,
+			"warning":   warning,
+		})
+	}
+	return warnings
 }
 
 // getServiceForObject gets the service name for an object
@@ -537,6 +875,37 @@ func (eng *Engine) serializeExpression(expr mdwast.Expr) interface{} {
 			"type":  "literal",
 			"value": e.Value.Value,
 		}
+	case *mdwast.FunctionCallExpr:
+		args := make([]interface{}, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = eng.serializeExpression(arg)
+		}
+		return map[string]interface{}{
+			"type": "function",
+			"name": e.Name,
+			"args": args,
+		}
+	case *mdwast.ArrayExpr:
+		elements := make([]interface{}, len(e.Elements))
+		for i, elem := range e.Elements {
+			elements[i] = eng.serializeExpression(elem)
+		}
+		return map[string]interface{}{
+			"type":     "array",
+			"elements": elements,
+		}
+	case *mdwast.BetweenExpr:
+		return map[string]interface{}{
+			"type": "between",
+			"expr": eng.serializeExpression(e.Expr),
+			"low":  eng.serializeExpression(e.Low),
+			"high": eng.serializeExpression(e.High),
+		}
+	case *mdwast.IsNullExpr:
+		return map[string]interface{}{
+			"type": "is_null",
+			"expr": eng.serializeExpression(e.Expr),
+		}
 	default:
 		return map[string]interface{}{
 			"type": "unknown",
@@ -560,8 +929,22 @@ func (e *Engine) auditCommand(cmd *mdwast.Command, execCtx *ExecutionContext, st
 	})
 }
 
-// Close closes the executor and releases resources
+// Close stops the scheduler, cancels running jobs, closes the executor and
+// releases resources
 func (e *Engine) Close() error {
+	e.StopScheduler()
+
+	e.mutex.RLock()
+	running := make([]*runningJob, 0, len(e.running))
+	for _, job := range e.running {
+		running = append(running, job)
+	}
+	e.mutex.RUnlock()
+	for _, job := range running {
+		job.cancel()
+		<-job.done
+	}
+
 	if e.client != nil {
 		return e.client.Close()
 	}
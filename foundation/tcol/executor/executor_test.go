@@ -5,12 +5,17 @@
 //              built-in command execution, error handling, and audit logging.
 //              Tests cover all command types with mock service clients.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.5
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial comprehensive executor test suite
+// - 2026-10-16 v0.1.1: Added HELP and DESCRIBE tests
+// - 2026-10-16 v0.1.2: Added alias namespace tests
+// - 2026-10-16 v0.1.3: A pipe returns the result of its last stage
+// - 2026-10-16 v0.1.4: Added method version and deprecation tests
+// - 2026-10-16 v0.1.5: A service's EXPORT object takes precedence over the built-in
 
 package executor
 
@@ -24,6 +29,7 @@ import (
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwmacro "github.com/msto63/mDW/foundation/tcol/macro"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
 )
 
@@ -894,9 +900,9 @@ func TestEngine_Execute_CommandChaining(t *testing.T) {
 		return
 	}
 
-	// Check chain result in metadata
-	if result.Metadata == nil || result.Metadata["chainResult"] == nil {
-		t.Error("Expected chain result in metadata")
+	// The pipe returns the result of its last stage
+	if result.Data != "CSV export completed" {
+		t.Errorf("Expected result of the last stage, got %v", result.Data)
 	}
 }
 
@@ -1131,8 +1137,245 @@ func TestEngine_SerializeFilter(t *testing.T) {
 	}
 }
 
+func TestEngine_Execute_FilterMacroExpansion(t *testing.T) {
+	mockClient := NewMockServiceClient()
+
+	macros, err := mdwmacro.New(mdwmacro.Options{})
+	if err != nil {
+		t.Fatalf("Failed to create macro library: %v", err)
+	}
+	if err := macros.Register(mdwmacro.Definition{
+		Name:   "is_vip",
+		Params: []string{"limit"},
+		Body:   `status = "active" AND revenue > limit`,
+	}); err != nil {
+		t.Fatalf("Failed to register macro: %v", err)
+	}
+
+	engine, err := New(Options{
+		ServiceClient: mockClient,
+		Logger:        mdwlog.GetDefault(),
+		FilterMacros:  macros,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+
+	cmd := createTestCommand("CUSTOMER", "LIST")
+	cmd.Filter = &mdwast.FilterExpr{
+		Condition: &mdwast.FunctionCallExpr{
+			Name: "is_vip",
+			Args: []mdwast.Expr{
+				&mdwast.LiteralExpr{Value: mdwast.Value{Type: mdwast.ValueTypeNumber, Raw: "1000", Value: int64(1000)}},
+			},
+		},
+	}
+
+	if _, err := engine.Execute(context.Background(), cmd, createTestContext()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calls := mockClient.GetCallHistory()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 service call, got %d", len(calls))
+	}
+
+	filter, ok := calls[0].Params["_filter"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected serialized filter in service call")
+	}
+	condition := filter["condition"].(map[string]interface{})
+	if condition["type"] != "binary" || condition["op"] != "AND" {
+		t.Errorf("Expected expanded AND condition, got %v", condition)
+	}
+
+	// The original command must remain unchanged
+	if _, ok := cmd.Filter.Condition.(*mdwast.FunctionCallExpr); !ok {
+		t.Error("Expected original filter to remain a function call")
+	}
+
+	// Calling a macro with the wrong arity fails before the service call
+	cmd.Filter.Condition.(*mdwast.FunctionCallExpr).Args = nil
+	if _, err := engine.Execute(context.Background(), cmd, createTestContext()); err == nil {
+		t.Error("Expected error for wrong macro arity")
+	}
+}
+
 // Benchmarks
 
+func TestEngine_Execute_HelpAndDescribe(t *testing.T) {
+	engine, _ := New(Options{ServiceClient: NewMockServiceClient()})
+	engine.SetRegistry(createTestRegistry())
+	execCtx := createTestContext()
+	param := func(value string) mdwast.Value {
+		return mdwast.Value{Type: mdwast.ValueTypeString, Value: value}
+	}
+
+	result, err := engine.Execute(context.Background(), &mdwast.Command{
+		Object:     "DESCRIBE",
+		Method:     "OBJECT",
+		Parameters: map[string]mdwast.Value{"name": param("customer")},
+	}, execCtx)
+	if err != nil {
+		t.Fatalf("DESCRIBE.OBJECT error = %v", err)
+	}
+	desc, ok := result.Data.(*mdwregistry.ObjectDescription)
+	if !ok || desc.Name != "CUSTOMER" || len(desc.Methods) != 4 || len(desc.Fields) != 2 {
+		t.Errorf("DESCRIBE.OBJECT data = %#v", result.Data)
+	}
+
+	result, err = engine.Execute(context.Background(), &mdwast.Command{
+		Object:     "DESCRIBE",
+		Method:     "METHOD",
+		Parameters: map[string]mdwast.Value{"object": param("CUSTOMER"), "method": param("LIST")},
+	}, execCtx)
+	if method, ok := result.Data.(*mdwregistry.MethodDescription); err != nil || !ok || method.Permissions[0] != "CUSTOMER.LIST" {
+		t.Errorf("DESCRIBE.METHOD = %#v, %v", result, err)
+	}
+
+	result, err = engine.Execute(context.Background(), &mdwast.Command{
+		Object:     "HELP",
+		Method:     "OBJECT",
+		Parameters: map[string]mdwast.Value{"name": param("CUSTOMER")},
+	}, execCtx)
+	if text, _ := result.Data.(string); err != nil || !strings.HasPrefix(text, "CUSTOMER - Customer management\n") {
+		t.Errorf("HELP.OBJECT = %#v, %v", result, err)
+	}
+
+	// HELP and DESCRIBE describe themselves
+	result, err = engine.Execute(context.Background(), &mdwast.Command{
+		Object:     "HELP",
+		Method:     "METHOD",
+		Parameters: map[string]mdwast.Value{"object": param("DESCRIBE"), "method": param("OBJECT")},
+	}, execCtx)
+	if text, _ := result.Data.(string); err != nil || !strings.Contains(text, `DESCRIBE.OBJECT name="CUSTOMER"`) {
+		t.Errorf("HELP.METHOD = %#v, %v", result, err)
+	}
+
+	invalid := []*mdwast.Command{
+		{Object: "DESCRIBE", Method: "OBJECT"},
+		{Object: "DESCRIBE", Method: "OBJECT", Parameters: map[string]mdwast.Value{"name": param("ORDER")}},
+		{Object: "HELP", Method: "METHOD", Parameters: map[string]mdwast.Value{"object": param("CUSTOMER")}},
+		{Object: "DESCRIBE", Method: "LIST"},
+	}
+	for _, cmd := range invalid {
+		if _, err := engine.Execute(context.Background(), cmd, execCtx); err == nil {
+			t.Errorf("%s.%s %v did not fail", cmd.Object, cmd.Method, cmd.Parameters)
+		}
+	}
+}
+
+func TestEngine_Execute_AliasNamespaces(t *testing.T) {
+	engine, _ := New(Options{ServiceClient: NewMockServiceClient()})
+	engine.SetRegistry(createTestRegistry())
+	alias := func(method string, params ...string) *mdwast.Command {
+		cmd := &mdwast.Command{Object: "ALIAS", Method: method, Parameters: map[string]mdwast.Value{}}
+		for i := 0; i+1 < len(params); i += 2 {
+			cmd.Parameters[params[i]] = mdwast.Value{Type: mdwast.ValueTypeString, Value: params[i+1]}
+		}
+		return cmd
+	}
+	alice, bob := createTestContext(), createTestContext()
+	alice.UserID, bob.UserID = "alice", "bob"
+
+	commands := []struct {
+		cmd     *mdwast.Command
+		execCtx *ExecutionContext
+	}{
+		{alias("CREATE", "name", "uc", "command", "CUSTOMER.LIST status=unpaid", "scope", "global"), alice},
+		{alias("CREATE", "name", "uc", "command", "CUSTOMER.LIST status=open"), alice},
+		{alias("CREATE", "name", "mine", "command", "CUSTOMER.LIST owner=bob"), bob},
+	}
+	for _, c := range commands {
+		if _, err := engine.Execute(context.Background(), c.cmd, c.execCtx); err != nil {
+			t.Fatalf("ALIAS.CREATE error = %v", err)
+		}
+	}
+
+	result, err := engine.Execute(context.Background(), alias("LIST"), alice)
+	if aliases, _ := result.Data.(map[string]string); err != nil || len(aliases) != 1 || aliases["UC"] != "CUSTOMER.LIST status=open" {
+		t.Errorf("alice ALIAS.LIST = %v, %v", result.Data, err)
+	}
+	result, err = engine.Execute(context.Background(), alias("LIST"), bob)
+	if aliases, _ := result.Data.(map[string]string); err != nil || len(aliases) != 2 || aliases["UC"] != "CUSTOMER.LIST status=unpaid" {
+		t.Errorf("bob ALIAS.LIST = %v, %v", result.Data, err)
+	}
+
+	if _, err := engine.Execute(context.Background(), alias("DELETE", "name", "uc"), alice); err != nil {
+		t.Errorf("ALIAS.DELETE error = %v", err)
+	}
+	if _, err := engine.Execute(context.Background(), alias("DELETE", "name", "mine"), alice); err == nil {
+		t.Error("ALIAS.DELETE of another user's alias did not fail")
+	}
+
+	anonymous := createTestContext()
+	anonymous.UserID = ""
+	invalid := []struct {
+		cmd     *mdwast.Command
+		execCtx *ExecutionContext
+	}{
+		{alias("CREATE", "name", "x", "command", "CUSTOMER.LIST", "scope", "team"), alice},
+		{alias("CREATE", "name", "x", "command", "CUSTOMER.LIST", "scope", "user"), anonymous},
+		{alias("CREATE", "name", "customer", "command", "CUSTOMER.LIST"), alice},
+		{alias("DELETE"), alice},
+	}
+	for _, c := range invalid {
+		if _, err := engine.Execute(context.Background(), c.cmd, c.execCtx); err == nil {
+			t.Errorf("ALIAS.%s %v did not fail", c.cmd.Method, c.cmd.Parameters)
+		}
+	}
+}
+
+func TestEngine_Execute_MethodVersions(t *testing.T) {
+	client := NewMockServiceClient()
+	engine, _ := New(Options{ServiceClient: client})
+	registry := createTestRegistry()
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "INVOICE",
+		Service: "invoice-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"LIST": {Version: 2, Versions: []*mdwregistry.MethodDefinition{{
+				Version:     1,
+				Service:     "invoice-legacy",
+				Deprecation: &mdwregistry.Deprecation{Replacement: "INVOICE.LIST@v2"},
+			}}},
+		},
+	})
+	engine.SetRegistry(registry)
+
+	// The current version is called without a version parameter
+	cmd := createTestCommand("INVOICE", "LIST")
+	result, err := engine.Execute(context.Background(), cmd, createTestContext())
+	if err != nil || len(result.Warnings) != 0 {
+		t.Fatalf("INVOICE.LIST = %v, %v", result, err)
+	}
+	if call := client.GetCallHistory()[0]; call.ServiceName != "invoice-service" || call.Params[VersionKey] != nil {
+		t.Errorf("current version call = %+v", call)
+	}
+
+	// An explicit version is routed to its service and warned about, also
+	// as an earlier stage of a pipe
+	cmd.Version = 1
+	cmd.Chain = createTestCommand("CUSTOMER", "LIST")
+	result, err = engine.Execute(context.Background(), cmd, createTestContext())
+	if err != nil {
+		t.Fatalf("INVOICE.LIST@v1 error = %v", err)
+	}
+	expected := "INVOICE.LIST@v1 is deprecated, use INVOICE.LIST@v2 instead"
+	if len(result.Warnings) != 1 || result.Warnings[0] != expected {
+		t.Errorf("Warnings = %q, want %q", result.Warnings, expected)
+	}
+	if call := client.GetCallHistory()[1]; call.ServiceName != "invoice-legacy" || call.MethodName != "LIST" || call.Params[VersionKey] != 1 {
+		t.Errorf("versioned call = %+v", call)
+	}
+
+	cmd.Version, cmd.Chain = 3, nil
+	if _, err := engine.Execute(context.Background(), cmd, createTestContext()); err == nil {
+		t.Error("INVOICE.LIST@v3 did not fail")
+	}
+}
+
 func BenchmarkEngine_Execute_SimpleCommand(b *testing.B) {
 	mockClient := NewMockServiceClient()
 	mockPermissions := NewMockPermissionChecker()
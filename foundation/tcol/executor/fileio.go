@@ -0,0 +1,334 @@
+// File: fileio.go
+// Title: TCOL File Import and Export
+// Description: Implements the built-in EXPORT and IMPORT stages of pipes.
+//              EXPORT writes the result of the previous stage to a CSV,
+//              JSON, or XLSX file; IMPORT reads CSV, JSON, or JSON Lines
+//              files into parameter sets, and a service command following
+//              it runs once per set. Files are confined to Options.FileRoot.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of EXPORT and IMPORT
+// - 2026-10-16 v0.1.1: Rows of a service's IMPORT object are not batched
+// - 2026-10-16 v0.1.2: Stream the rows of JSON arrays up to MaxImportRows
+
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwformat "github.com/msto63/mDW/foundation/tcol/format"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// DefaultMaxImportRows is the default number of rows IMPORT reads at most
+const DefaultMaxImportRows = 10000
+
+// ErrFileAccessDisabled is returned by EXPORT and IMPORT if no file root is
+// configured
+var ErrFileAccessDisabled = errors.New("file access is disabled: no file root configured")
+
+// exportFormats are the formats EXPORT writes, by method
+var exportFormats = map[string]mdwformat.Format{
+	"CSV":  mdwformat.CSV,
+	"JSON": mdwformat.JSON,
+	"XLSX": mdwformat.XLSX,
+}
+
+// executeExportCommand writes the data of the previous stage to a file:
+// EXPORT.CSV, EXPORT.JSON, or EXPORT.XLSX file="name" [fields="a,b"]
+// [overwrite=true]
+func (e *Engine) executeExportCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	format, supported := exportFormats[cmd.Method]
+	if !supported {
+		return nil, fmt.Errorf("unknown EXPORT method %s (use CSV, JSON, or XLSX)", cmd.Method)
+	}
+	name, err := e.fileParam(ctx, cmd, execCtx)
+	if err != nil {
+		return nil, err
+	}
+	data, exists := execCtx.Variables.Lookup(PrevVariable)
+	if !exists {
+		return nil, fmt.Errorf("EXPORT.%s must follow a command in a pipe", cmd.Method)
+	}
+
+	// A single object is exported like a list of one row
+	rows, isList := data.([]interface{})
+	if !isList && data != nil {
+		rows = []interface{}{data}
+	}
+
+	output := mdwformat.Options{Format: format}
+	if value, exists := cmd.Parameters["fields"]; exists {
+		output.Columns = mdwformat.ParseColumns(fmt.Sprint(value.Value))
+	}
+	var content bytes.Buffer
+	if err := mdwformat.Render(&content, rows, output); err != nil {
+		return nil, fmt.Errorf("EXPORT.%s: %w", cmd.Method, err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite, exists := cmd.Parameters["overwrite"]; exists && overwrite.Value == true {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	if dir := path.Dir(name); dir != "." {
+		if err := e.options.FileRoot.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("EXPORT.%s: %w", cmd.Method, err)
+		}
+	}
+	file, err := e.options.FileRoot.OpenFile(name, flags, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("EXPORT.%s: file %s exists; set overwrite=true to replace it", cmd.Method, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("EXPORT.%s: %w", cmd.Method, err)
+	}
+	_, err = file.Write(content.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("EXPORT.%s: failed to write %s: %w", cmd.Method, name, err)
+	}
+
+	e.logger.Info("TCOL result exported", mdwlog.Fields{
+		"requestID": execCtx.RequestID,
+		"userID":    execCtx.UserID,
+		"file":      name,
+		"format":    string(format),
+		"rows":      len(rows),
+	})
+
+	return &ExecutionResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"file":   name,
+			"format": string(format),
+			"rows":   len(rows),
+			"bytes":  content.Len(),
+		},
+		CommandType: "BUILTIN",
+	}, nil
+}
+
+// executeImportCommand reads a file into a list of parameter sets:
+// IMPORT.CSV file="name" [delimiter=";"], IMPORT.JSON, or IMPORT.JSONL
+func (e *Engine) executeImportCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	name, err := e.fileParam(ctx, cmd, execCtx)
+	if err != nil {
+		return nil, err
+	}
+	file, err := e.options.FileRoot.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("IMPORT.%s: %w", cmd.Method, err)
+	}
+	defer file.Close()
+
+	var rows []interface{}
+	add := func(row interface{}) error {
+		record, isObject := row.(map[string]interface{})
+		if !isObject {
+			return fmt.Errorf("row %d is not an object", len(rows)+1)
+		}
+		if len(rows) >= e.options.MaxImportRows {
+			return fmt.Errorf("file has more than %d rows", e.options.MaxImportRows)
+		}
+		rows = append(rows, record)
+		return nil
+	}
+
+	switch cmd.Method {
+	case "CSV":
+		opts := mdwfilex.DefaultCSVOptions()
+		if value, exists := cmd.Parameters["delimiter"]; exists {
+			delimiter := []rune(fmt.Sprint(value.Value))
+			if len(delimiter) != 1 {
+				return nil, fmt.Errorf("IMPORT.CSV delimiter must be a single character")
+			}
+			opts.Delimiter = delimiter[0]
+		}
+		err = mdwfilex.ProcessCSVReader(file, func(_ int, record map[string]string) error {
+			row := make(map[string]interface{}, len(record))
+			for key, value := range record {
+				row[key] = value
+			}
+			return add(row)
+		}, opts)
+	case "JSON":
+		err = decodeJSONRows(file, add)
+	case "JSONL":
+		err = mdwfilex.ProcessJSONLReader(file, func(_ int, raw json.RawMessage) error {
+			var row interface{}
+			if err := json.Unmarshal(raw, &row); err != nil {
+				return err
+			}
+			return add(row)
+		})
+	default:
+		return nil, fmt.Errorf("unknown IMPORT method %s (use CSV, JSON, or JSONL)", cmd.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("IMPORT.%s: %s: %w", cmd.Method, name, err)
+	}
+
+	e.logger.Info("TCOL file imported", mdwlog.Fields{
+		"requestID": execCtx.RequestID,
+		"userID":    execCtx.UserID,
+		"file":      name,
+		"rows":      len(rows),
+	})
+
+	if rows == nil {
+		rows = []interface{}{}
+	}
+	return &ExecutionResult{
+		Success:     true,
+		Data:        rows,
+		CommandType: "BUILTIN",
+		Metadata: map[string]interface{}{
+			"file": name,
+			"rows": len(rows),
+		},
+	}, nil
+}
+
+// decodeJSONRows passes the elements of a JSON array, or a single JSON
+// value, to add as they are decoded, so an error of add ends the import
+// before the rest of the file is read
+func decodeJSONRows(r io.Reader, add func(interface{}) error) error {
+	// The first character tells an array from a single value
+	reader := bufio.NewReader(r)
+	var first byte
+	for first == 0 {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			first = c
+			reader.UnreadByte()
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	if first != '[' {
+		var row interface{}
+		if err := decoder.Decode(&row); err != nil {
+			return err
+		}
+		return add(row)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var row interface{}
+		if err := decoder.Decode(&row); err != nil {
+			return err
+		}
+		if err := add(row); err != nil {
+			return err
+		}
+	}
+	_, err := decoder.Token()
+	return err
+}
+
+// fileParam checks that file access is enabled and permitted and returns
+// the file parameter, a slash-separated name inside Options.FileRoot
+func (e *Engine) fileParam(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (string, error) {
+	if e.options.FileRoot == nil {
+		return "", fmt.Errorf("%s.%s: %w", cmd.Object, cmd.Method, ErrFileAccessDisabled)
+	}
+	if err := e.checkPermission(ctx, cmd.Object, cmd.Method, execCtx); err != nil {
+		return "", err
+	}
+	var name string
+	if value, exists := cmd.Parameters["file"]; exists {
+		name = strings.TrimSpace(fmt.Sprint(value.Value))
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s.%s requires 'file' parameter", cmd.Object, cmd.Method)
+	}
+	return name, nil
+}
+
+// importedRows returns the rows of the previous stage if cmd is a service
+// command directly following the built-in IMPORT, which then runs once per row
+func (e *Engine) importedRows(cmd *mdwast.Command, execCtx *ExecutionContext) ([]interface{}, bool) {
+	parent := execCtx.ParentCommand
+	if parent == nil || parent.Object != "IMPORT" || parent.Transform != nil || !e.isBuiltin("IMPORT") ||
+		cmd.Transform != nil || cmd.Method == "" || e.isBuiltin(cmd.Object) {
+		return nil, false
+	}
+	data, exists := execCtx.Variables.Lookup(PrevVariable)
+	rows, isList := data.([]interface{})
+	return rows, exists && isList
+}
+
+// executeImportBatch executes a command once per imported row. The fields
+// of the row become parameters unless the command sets them itself, and
+// the row is $PREV, e.g. to rename fields: name=$PREV.company. The result
+// lists the data of the executions; the first failing row ends the batch.
+func (e *Engine) executeImportBatch(ctx context.Context, cmd *mdwast.Command, rows []interface{}, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	data := make([]interface{}, 0, len(rows))
+	var warnings []string
+	for i, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("import batch stopped before row %d: %w", i+1, err)
+		}
+
+		rowCtx := *execCtx
+		rowCtx.Variables = NewScope(execCtx.Variables)
+		rowCtx.Variables.setLocal(PrevVariable, row)
+
+		resolved, err := resolveCommand(cmd, rowCtx.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		rowCmd := *resolved
+		rowCmd.Parameters = make(map[string]mdwast.Value, len(resolved.Parameters))
+		for key, value := range row.(map[string]interface{}) {
+			rowCmd.Parameters[key] = toValue(value, cmd.Pos)
+		}
+		for key, value := range resolved.Parameters {
+			rowCmd.Parameters[key] = value
+		}
+
+		result, err := e.pipeline(&rowCmd, e.executeCommand)(ctx, &rowCmd, &rowCtx)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		data = append(data, result.Data)
+		if len(warnings) == 0 {
+			warnings = result.Warnings // The same command warns the same for every row
+		}
+	}
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        data,
+		CommandType: "IMPORT_BATCH",
+		Metadata: map[string]interface{}{
+			"rows":      len(rows),
+			"timestamp": time.Now(),
+		},
+		Warnings: warnings,
+	}, nil
+}
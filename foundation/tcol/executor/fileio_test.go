@@ -0,0 +1,197 @@
+// File: fileio_test.go
+// Title: TCOL File Import and Export Tests
+// Description: Tests the EXPORT formats, overwrite protection and path
+//              sandboxing, the IMPORT formats, and the batches of service
+//              commands run for imported rows.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial import and export tests
+// - 2026-10-16 v0.1.1: JSON imports stop at MaxImportRows
+
+package executor
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// newFileEngine creates an engine whose EXPORT and IMPORT use a temporary
+// directory, which it returns as well
+func newFileEngine(t *testing.T) (*Engine, *MockServiceClient, string) {
+	dir := t.TempDir()
+	root, err := mdwfilex.OpenRoot(dir)
+	if err != nil {
+		t.Fatalf("OpenRoot() error = %v", err)
+	}
+	client := NewMockServiceClient()
+	engine, err := New(Options{ServiceClient: client, FileRoot: root})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+	return engine, client, dir
+}
+
+func TestEngine_Export(t *testing.T) {
+	engine, client, dir := newFileEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: testCustomers()})
+
+	result, err := executePipe(t, engine, `CUSTOMER.LIST | SORT BY name | EXPORT.CSV file="out/customers.csv" fields="name,total"`)
+	if err != nil {
+		t.Fatalf("EXPORT.CSV error = %v", err)
+	}
+	summary := result.Data.(map[string]interface{})
+	if summary["rows"] != 4 || summary["file"] != "out/customers.csv" || summary["format"] != "csv" {
+		t.Errorf("EXPORT.CSV result = %v", summary)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "out", "customers.csv"))
+	if expected := "name,total\nAlice,100.5\nBob,900\nCarol,250\nDave,\n"; string(content) != expected {
+		t.Errorf("CSV file = %q, want %q", content, expected)
+	}
+
+	if _, err := executePipe(t, engine, `CUSTOMER.LIST | EXPORT.CSV file="out/customers.csv"`); err == nil ||
+		!strings.Contains(err.Error(), "overwrite=true") {
+		t.Errorf("EXPORT over an existing file error = %v", err)
+	}
+	if _, err := executePipe(t, engine, `CUSTOMER.LIST | LIMIT 1 | EXPORT.JSON file="out/customers.csv" overwrite=true`); err != nil {
+		t.Errorf("EXPORT with overwrite=true error = %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(dir, "out", "customers.csv"))
+	if !strings.HasPrefix(string(content), "[\n  {") || !strings.Contains(string(content), `"name": "Carol"`) {
+		t.Errorf("JSON file = %s", content)
+	}
+
+	if _, err := executePipe(t, engine, `CUSTOMER.LIST | EXPORT.XLSX file="customers.xlsx"`); err != nil {
+		t.Fatalf("EXPORT.XLSX error = %v", err)
+	}
+	if _, err := zip.OpenReader(filepath.Join(dir, "customers.xlsx")); err != nil {
+		t.Errorf("XLSX file is no workbook: %v", err)
+	}
+}
+
+func TestEngine_Export_Errors(t *testing.T) {
+	engine, client, _ := newFileEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: testCustomers()})
+
+	for pipe, expected := range map[string]error{
+		`CUSTOMER.LIST | EXPORT.CSV file="../escape.csv"`:   mdwfilex.ErrPathEscapesRoot,
+		`CUSTOMER.LIST | EXPORT.CSV file="/etc/escape.csv"`: mdwfilex.ErrPathEscapesRoot,
+		`CUSTOMER.LIST | EXPORT.CSV file="a/../../esc.csv"`: mdwfilex.ErrPathEscapesRoot,
+	} {
+		if _, err := executePipe(t, engine, pipe); !errors.Is(err, expected) {
+			t.Errorf("%s: error = %v, want %v", pipe, err, expected)
+		}
+	}
+	if _, err := executePipe(t, engine, `EXPORT.CSV file="alone.csv"`); err == nil ||
+		!strings.Contains(err.Error(), "must follow a command") {
+		t.Errorf("EXPORT without previous stage error = %v", err)
+	}
+	if _, err := executePipe(t, engine, `CUSTOMER.LIST | EXPORT.CSV`); err == nil ||
+		!strings.Contains(err.Error(), "requires 'file'") {
+		t.Errorf("EXPORT without file error = %v", err)
+	}
+
+	disabled, disabledClient := newScriptEngine(t)
+	disabledClient.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: testCustomers()})
+	if _, err := executePipe(t, disabled, `CUSTOMER.LIST | EXPORT.CSV file="x.csv"`); !errors.Is(err, ErrFileAccessDisabled) {
+		t.Errorf("EXPORT without file root error = %v", err)
+	}
+}
+
+func TestEngine_Import(t *testing.T) {
+	engine, _, dir := newFileEngine(t)
+	os.WriteFile(filepath.Join(dir, "customers.csv"), []byte("name;city\nAcme;Berlin\nGlobex;Paris\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "customers.json"), []byte(`[{"name": "Acme", "total": 10}, {"name": "Globex"}]`), 0644)
+	os.WriteFile(filepath.Join(dir, "customers.jsonl"), []byte("{\"name\": \"Acme\"}\n\n{\"name\": \"Globex\"}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "scalars.json"), []byte(`[1, 2]`), 0644)
+	os.WriteFile(filepath.Join(dir, "customer.json"), []byte(` {"name": "Acme"}`), 0644)
+
+	for _, command := range []string{
+		`IMPORT.CSV file="customers.csv"`,
+		`IMPORT.CSV file="customers.csv" delimiter=";"`,
+		`IMPORT.JSON file="customers.json"`,
+		`IMPORT.JSONL file="customers.jsonl"`,
+	} {
+		result, err := executePipe(t, engine, command)
+		if err != nil {
+			t.Errorf("%s: error = %v", command, err)
+			continue
+		}
+		if got := names(t, result.Data); strings.Join(got, ",") != "Acme,Globex" {
+			t.Errorf("%s: names = %v", command, got)
+		}
+	}
+
+	if result, err := executePipe(t, engine, `IMPORT.JSON file="customer.json"`); err != nil ||
+		strings.Join(names(t, result.Data), ",") != "Acme" {
+		t.Errorf("IMPORT of a single object = %v, %v", result, err)
+	}
+	if _, err := executePipe(t, engine, `IMPORT.JSON file="scalars.json"`); err == nil ||
+		!strings.Contains(err.Error(), "row 1 is not an object") {
+		t.Errorf("IMPORT of scalars error = %v", err)
+	}
+	if _, err := executePipe(t, engine, `IMPORT.CSV file="missing.csv"`); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("IMPORT of a missing file error = %v", err)
+	}
+
+	engine.options.MaxImportRows = 1
+	if _, err := executePipe(t, engine, `IMPORT.JSONL file="customers.jsonl"`); err == nil ||
+		!strings.Contains(err.Error(), "more than 1 rows") {
+		t.Errorf("IMPORT over MaxImportRows error = %v", err)
+	}
+
+	// A JSON array is read up to the limit: the broken rest of the file is
+	// never decoded
+	os.WriteFile(filepath.Join(dir, "large.json"), []byte(`[{"name": "Acme"}, {"name": "Globex"}, {"name": `), 0644)
+	if _, err := executePipe(t, engine, `IMPORT.JSON file="large.json"`); err == nil ||
+		!strings.Contains(err.Error(), "more than 1 rows") {
+		t.Errorf("IMPORT.JSON over MaxImportRows error = %v", err)
+	}
+}
+
+func TestEngine_ImportBatch(t *testing.T) {
+	engine, client, dir := newFileEngine(t)
+	os.WriteFile(filepath.Join(dir, "new.csv"), []byte("company,email\nAcme,info@acme.com\nGlobex,hi@globex.com\n"), 0644)
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{Success: true, Data: map[string]interface{}{"id": "c-1"}})
+
+	result, err := executePipe(t, engine, `IMPORT.CSV file="new.csv" | CUSTOMER.CREATE name=$PREV.company status="new"`)
+	if err != nil {
+		t.Fatalf("batch error = %v", err)
+	}
+	if rows, _ := result.Data.([]interface{}); len(rows) != 2 || result.CommandType != "IMPORT_BATCH" {
+		t.Errorf("batch result = %+v", result)
+	}
+
+	calls := client.GetCallHistory()
+	if len(calls) != 2 {
+		t.Fatalf("service calls = %d, want one per row", len(calls))
+	}
+	second := calls[1].Params
+	if second["name"] != "Globex" || second["email"] != "hi@globex.com" || second["status"] != "new" || second["company"] != "Globex" {
+		t.Errorf("parameters of the second row = %v", second)
+	}
+
+	// IMPORT followed by a built-in stage passes the rows on as a whole
+	if _, err := executePipe(t, engine, `IMPORT.CSV file="new.csv" | SORT BY company DESC | EXPORT.JSON file="copy.json"`); err != nil {
+		t.Errorf("IMPORT | EXPORT error = %v", err)
+	}
+	if len(client.GetCallHistory()) != 2 {
+		t.Error("IMPORT | EXPORT called a service")
+	}
+
+	client.SetError("customer-service", "CUSTOMER", "CREATE", errors.New("duplicate customer"))
+	if _, err := executePipe(t, engine, `IMPORT.CSV file="new.csv" | CUSTOMER.CREATE`); err == nil ||
+		!strings.Contains(err.Error(), "row 1") {
+		t.Errorf("failing batch error = %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+// File: history.go
+// Title: TCOL Command History
+// Description: Records executed commands per user and session in a
+//              HistoryStore, in memory or as JSON Lines files. Provides
+//              search, re-execution by index, export, and the built-in
+//              HISTORY object; entries are fed into audit logging.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the command history
+
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwformat "github.com/msto63/mDW/foundation/tcol/format"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// DefaultHistorySize is the number of entries per user kept by the default
+// in-memory history store
+const DefaultHistorySize = 1000
+
+// defaultHistoryListLimit is the number of entries HISTORY.LIST returns
+// without a limit parameter
+const defaultHistoryListLimit = 20
+
+// ErrHistoryNotFound is returned for history indexes without an entry
+var ErrHistoryNotFound = errors.New("history entry not found")
+
+// HistoryEntry is an executed command in the history of a user
+type HistoryEntry struct {
+	Index      int           `json:"index"` // Position in the history of the user, from 1
+	Command    string        `json:"command"`
+	UserID     string        `json:"user_id,omitempty"`
+	SessionID  string        `json:"session_id,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Success    bool          `json:"success"`
+	Error      string        `json:"error,omitempty"`
+	ExecutedAt time.Time     `json:"executed_at"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// HistoryStore persists command histories per user. Implementations must be
+// safe for concurrent use.
+type HistoryStore interface {
+	// Append assigns the next index of the user to the entry and stores it
+	Append(entry *HistoryEntry) error
+
+	// List returns the entries of a user, oldest first
+	List(userID string) ([]*HistoryEntry, error)
+}
+
+// HistoryQuery selects history entries of a user
+type HistoryQuery struct {
+	UserID    string
+	SessionID string    // Only entries of this session if set
+	Contains  string    // Only commands containing this text, ignoring case
+	Since     time.Time // Only entries executed at or after this time if set
+	Limit     int       // Only the latest Limit entries if greater than 0
+}
+
+// MemoryHistoryStore keeps the latest entries of each user in memory
+type MemoryHistoryStore struct {
+	entries map[string][]*HistoryEntry
+	next    map[string]int
+	size    int
+	mutex   sync.RWMutex
+}
+
+// NewMemoryHistoryStore creates an in-memory history store keeping the
+// latest size entries per user; 0 keeps all entries
+func NewMemoryHistoryStore(size int) *MemoryHistoryStore {
+	return &MemoryHistoryStore{
+		entries: make(map[string][]*HistoryEntry),
+		next:    make(map[string]int),
+		size:    size,
+	}
+}
+
+// Append stores a copy of the entry under the next index of its user
+func (s *MemoryHistoryStore) Append(entry *HistoryEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.next[entry.UserID]++
+	entry.Index = s.next[entry.UserID]
+	stored := *entry
+	entries := append(s.entries[entry.UserID], &stored)
+	if s.size > 0 && len(entries) > s.size {
+		entries = entries[len(entries)-s.size:]
+	}
+	s.entries[entry.UserID] = entries
+	return nil
+}
+
+// List returns copies of the entries of a user, oldest first
+func (s *MemoryHistoryStore) List(userID string) ([]*HistoryEntry, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entries := make([]*HistoryEntry, len(s.entries[userID]))
+	for i, entry := range s.entries[userID] {
+		copied := *entry
+		entries[i] = &copied
+	}
+	return entries, nil
+}
+
+// FileHistoryStore appends the entries of each user to a JSON Lines file,
+// history.jsonl for commands without user and users/<id>.jsonl otherwise,
+// so histories survive restarts of the process
+type FileHistoryStore struct {
+	dir   string
+	next  map[string]int
+	mutex sync.Mutex
+}
+
+// NewFileHistoryStore creates a history store in dir, creating the
+// directory if needed
+func NewFileHistoryStore(dir string) (*FileHistoryStore, error) {
+	if mdwstringx.IsBlank(dir) {
+		return nil, fmt.Errorf("history store directory is required")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "users"), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create history store directory: %w", err)
+	}
+	return &FileHistoryStore{dir: dir, next: make(map[string]int)}, nil
+}
+
+// Append writes the entry as a line to the file of its user
+func (s *FileHistoryStore) Append(entry *HistoryEntry) error {
+	path, err := s.path(entry.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, known := s.next[entry.UserID]; !known {
+		entries, err := readHistoryFile(path)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			s.next[entry.UserID] = entries[len(entries)-1].Index
+		}
+	}
+	entry.Index = s.next[entry.UserID] + 1
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	s.next[entry.UserID] = entry.Index
+	return nil
+}
+
+// List reads the entries of a user, oldest first
+func (s *FileHistoryStore) List(userID string) ([]*HistoryEntry, error) {
+	path, err := s.path(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return readHistoryFile(path)
+}
+
+// path returns the history file of a user, rejecting IDs that would leave
+// the directory
+func (s *FileHistoryStore) path(userID string) (string, error) {
+	if userID == "" {
+		return filepath.Join(s.dir, "history.jsonl"), nil
+	}
+	if mdwstringx.IsBlank(userID) || strings.ContainsAny(userID, `/\`) || strings.HasPrefix(userID, ".") {
+		return "", fmt.Errorf("invalid history user ID %q", userID)
+	}
+	return filepath.Join(s.dir, "users", userID+".jsonl"), nil
+}
+
+// readHistoryFile reads a history file; a missing file holds no entries
+func readHistoryFile(path string) ([]*HistoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var entries []*HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode %s line %d: %w", filepath.Base(path), line, err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
+
+// recordHistory appends a command executed at the top level to the history
+// and feeds the entry into audit logging. A command succeeded if it returned
+// a successful result. Failures to record are logged; they do not fail the
+// command.
+func (e *Engine) recordHistory(cmd *mdwast.Command, execCtx *ExecutionContext, startTime time.Time, result *ExecutionResult, err error) {
+	command := execCtx.Input
+	if command == "" {
+		command = cmd.String()
+	}
+	entry := &HistoryEntry{
+		Command:    command,
+		UserID:     execCtx.UserID,
+		SessionID:  execCtx.SessionID,
+		RequestID:  execCtx.RequestID,
+		Success:    err == nil && result != nil && result.Success,
+		ExecutedAt: startTime,
+		Duration:   time.Since(startTime),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if appendErr := e.history.Append(entry); appendErr != nil {
+		e.logger.Error("Failed to record TCOL command history", mdwlog.Fields{
+			"requestID": execCtx.RequestID,
+			"error":     appendErr.Error(),
+		})
+		return
+	}
+
+	if e.options.EnableAuditLog {
+		e.logger.Audit("TCOL command history", mdwlog.Fields{
+			"historyIndex": entry.Index,
+			"command":      entry.Command,
+			"requestID":    entry.RequestID,
+			"userID":       entry.UserID,
+			"sessionID":    entry.SessionID,
+			"success":      entry.Success,
+			"error":        entry.Error,
+			"duration":     entry.Duration,
+		})
+	}
+}
+
+// History returns the entries of a user matching the query, oldest first
+func (e *Engine) History(query HistoryQuery) ([]*HistoryEntry, error) {
+	entries, err := e.history.List(query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	contains := strings.ToLower(query.Contains)
+	matches := entries[:0]
+	for _, entry := range entries {
+		switch {
+		case query.SessionID != "" && entry.SessionID != query.SessionID,
+			contains != "" && !strings.Contains(strings.ToLower(entry.Command), contains),
+			!query.Since.IsZero() && entry.ExecutedAt.Before(query.Since):
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[len(matches)-query.Limit:]
+	}
+	return matches, nil
+}
+
+// GetHistoryEntry returns the entry with the given index from the history of
+// a user; negative indexes count back from the latest entry (-1)
+func (e *Engine) GetHistoryEntry(userID string, index int) (*HistoryEntry, error) {
+	entries, err := e.history.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 && -index <= len(entries) {
+		return entries[len(entries)+index], nil
+	}
+	for _, entry := range entries {
+		if entry.Index == index {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("history entry %d: %w", index, ErrHistoryNotFound)
+}
+
+// Rerun executes the command with the given index from the history of
+// execCtx.UserID again. The re-execution is recorded as a new entry.
+func (e *Engine) Rerun(ctx context.Context, index int, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	execCtx = withScope(execCtx)
+	entry, err := e.GetHistoryEntry(execCtx.UserID, index)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored text is parsed again so aliases and abbreviations expand as
+	// they did when the command was entered
+	e.mutex.RLock()
+	registry := e.registry
+	e.mutex.RUnlock()
+	parser, err := mdwparser.New(mdwparser.Options{Logger: e.logger, EnableChaining: true, Registry: registry})
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := parser.Parse(entry.Command)
+	if err != nil {
+		return nil, fmt.Errorf("history entry %d: %w", entry.Index, err)
+	}
+
+	e.logger.Info("Re-executing TCOL command from history", mdwlog.Fields{
+		"requestID":    execCtx.RequestID,
+		"userID":       execCtx.UserID,
+		"historyIndex": entry.Index,
+		"command":      entry.Command,
+	})
+
+	rerunCtx := *execCtx
+	rerunCtx.ChainDepth = 0
+	rerunCtx.Input = entry.Command
+	return e.Execute(ctx, cmd, &rerunCtx)
+}
+
+// ExportHistory writes the entries matching the query to w as a table,
+// JSON, CSV, or YAML
+func (e *Engine) ExportHistory(w io.Writer, query HistoryQuery, format mdwformat.Format) error {
+	entries, err := e.History(query)
+	if err != nil {
+		return err
+	}
+	data := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		data[i] = entry
+	}
+	return mdwformat.Render(w, data, mdwformat.Options{Format: format})
+}
+
+// executeHistoryCommand executes HISTORY commands. Users only see their own
+// history.
+func (e *Engine) executeHistoryCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	switch cmd.Method {
+	case "LIST":
+		query := HistoryQuery{UserID: execCtx.UserID, Limit: defaultHistoryListLimit}
+		if value, exists := cmd.Parameters["contains"]; exists {
+			query.Contains = fmt.Sprint(value.Value)
+		}
+		if value, exists := cmd.Parameters["limit"]; exists {
+			limit, ok := toPageSize(value.Value)
+			if !ok || limit < 1 {
+				return nil, fmt.Errorf("HISTORY.LIST limit must be a positive integer, got %v", value.Value)
+			}
+			query.Limit = limit
+		}
+		if value, exists := cmd.Parameters["session"]; exists {
+			switch session := strings.ToLower(fmt.Sprint(value.Value)); session {
+			case "current":
+				query.SessionID = execCtx.SessionID
+			case "all":
+			default:
+				return nil, fmt.Errorf("HISTORY.LIST session must be current or all, got %s", session)
+			}
+		}
+
+		entries, err := e.History(query)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]interface{}, len(entries))
+		for i, entry := range entries {
+			data[i] = map[string]interface{}{
+				"index":       entry.Index,
+				"command":     entry.Command,
+				"success":     entry.Success,
+				"error":       entry.Error,
+				"session_id":  entry.SessionID,
+				"executed_at": entry.ExecutedAt,
+				"duration":    entry.Duration.String(),
+			}
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        data,
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "RERUN":
+		value, exists := cmd.Parameters["index"]
+		if !exists {
+			return nil, fmt.Errorf("HISTORY.RERUN requires 'index' parameter")
+		}
+		// Negative indexes are given as strings, e.g. index="-1"
+		index, ok := toPageSize(value.Value)
+		if text, isText := value.Value.(string); isText {
+			parsed, err := strconv.Atoi(text)
+			index, ok = parsed, err == nil
+		}
+		if !ok || index == 0 {
+			return nil, fmt.Errorf("HISTORY.RERUN index must be a non-zero integer, got %v", value.Value)
+		}
+		return e.Rerun(ctx, index, execCtx)
+
+	default:
+		return nil, fmt.Errorf("unknown HISTORY method: %s", cmd.Method)
+	}
+}
@@ -0,0 +1,198 @@
+// File: history_test.go
+// Title: TCOL Command History Tests
+// Description: Tests the memory and file history stores, the recording of
+//              executed commands, history search, re-execution, export, and
+//              the built-in HISTORY object.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial history tests
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwformat "github.com/msto63/mDW/foundation/tcol/format"
+)
+
+// enter parses and executes a command as entered by a user
+func enter(t *testing.T, engine *Engine, input string, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	cmd, ok := parseScript(t, input).Statements[0].(*mdwast.Command)
+	if !ok {
+		t.Fatalf("%q is not a command", input)
+	}
+	cmdCtx := *execCtx
+	cmdCtx.Input = input
+	return engine.Execute(context.Background(), cmd, &cmdCtx)
+}
+
+func TestMemoryHistoryStore(t *testing.T) {
+	store := NewMemoryHistoryStore(2)
+	for _, command := range []string{"A.LIST", "B.LIST", "C.LIST"} {
+		if err := store.Append(&HistoryEntry{Command: command, UserID: "alice"}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	store.Append(&HistoryEntry{Command: "D.LIST", UserID: "bob"})
+
+	entries, _ := store.List("alice")
+	if len(entries) != 2 || entries[0].Index != 2 || entries[1].Command != "C.LIST" {
+		t.Errorf("alice history = %+v, want the latest two entries", entries)
+	}
+	if entries, _ := store.List("bob"); len(entries) != 1 || entries[0].Index != 1 {
+		t.Errorf("bob history = %+v, want its own index", entries)
+	}
+
+	entries[0].Command = "changed"
+	if stored, _ := store.List("alice"); stored[0].Command != "B.LIST" {
+		t.Error("List() returned stored entries instead of copies")
+	}
+}
+
+func TestFileHistoryStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileHistoryStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileHistoryStore() error = %v", err)
+	}
+	store.Append(&HistoryEntry{Command: "A.LIST", UserID: "alice"})
+	store.Append(&HistoryEntry{Command: "B.LIST", UserID: "alice", Success: true})
+	store.Append(&HistoryEntry{Command: "C.LIST"})
+
+	// A new store continues the indexes in the files
+	reopened, _ := NewFileHistoryStore(dir)
+	entry := &HistoryEntry{Command: "D.LIST", UserID: "alice"}
+	if err := reopened.Append(entry); err != nil || entry.Index != 3 {
+		t.Errorf("Append() index = %d, %v, want 3", entry.Index, err)
+	}
+	entries, err := reopened.List("alice")
+	if err != nil || len(entries) != 3 || entries[1].Command != "B.LIST" || !entries[1].Success {
+		t.Errorf("List(alice) = %+v, %v", entries, err)
+	}
+	if entries, _ := reopened.List(""); len(entries) != 1 || entries[0].Command != "C.LIST" {
+		t.Errorf("List() without user = %+v", entries)
+	}
+
+	for _, userID := range []string{"../alice", ".hidden", " "} {
+		if err := store.Append(&HistoryEntry{Command: "A.LIST", UserID: userID}); err == nil {
+			t.Errorf("Append() with user %q did not fail", userID)
+		}
+	}
+}
+
+func TestEngine_History(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	execCtx := createTestContext()
+
+	enter(t, engine, `CUSTOMER.LIST`, execCtx)
+	enter(t, engine, `CUSTOMER.CREATE name="Acme Corp" | INVOICE.CREATE`, execCtx)
+	enter(t, engine, `CUSTOMER.LIST | LIMIT 1`, execCtx)
+	client.SetResponse("customer-service", "CUSTOMER", "DELETE", &ServiceResponse{Success: false, Error: "locked"})
+	enter(t, engine, `CUSTOMER.DELETE id=42`, execCtx)
+	other := createTestContext()
+	other.UserID = "other-user"
+	other.SessionID = "other-session"
+	enter(t, engine, `INVOICE.LIST`, other)
+	enter(t, engine, `HISTORY.LIST`, execCtx)
+	enter(t, engine, `CUSTOMER.LIST name=$missing`, other)
+
+	// Pipes are recorded once as entered; HISTORY commands are not recorded
+	entries, err := engine.History(HistoryQuery{UserID: "test-user"})
+	if err != nil || len(entries) != 4 {
+		t.Fatalf("History() = %d entries, %v, want 4", len(entries), err)
+	}
+	if entries[1].Command != `CUSTOMER.CREATE name="Acme Corp" | INVOICE.CREATE` || entries[1].Index != 2 {
+		t.Errorf("pipe entry = %+v", entries[1])
+	}
+	if last := entries[3]; last.Success || last.SessionID != "test-session" {
+		t.Errorf("failed command entry = %+v", last)
+	}
+
+	queries := []struct {
+		query    HistoryQuery
+		expected int
+	}{
+		{HistoryQuery{UserID: "test-user", Contains: "customer.list"}, 2},
+		{HistoryQuery{UserID: "test-user", Limit: 1}, 1},
+		{HistoryQuery{UserID: "test-user", SessionID: "other-session"}, 0},
+		{HistoryQuery{UserID: "other-user"}, 2},
+	}
+	for _, tt := range queries {
+		if entries, _ := engine.History(tt.query); len(entries) != tt.expected {
+			t.Errorf("History(%+v) = %d entries, want %d", tt.query, len(entries), tt.expected)
+		}
+	}
+
+	if entry, _ := engine.GetHistoryEntry("other-user", 2); entry == nil || entry.Success || !strings.Contains(entry.Error, "$missing") {
+		t.Errorf("command error entry = %+v", entry)
+	}
+	if entry, err := engine.GetHistoryEntry("test-user", -2); err != nil || entry.Index != 3 {
+		t.Errorf("GetHistoryEntry(-2) = %+v, %v", entry, err)
+	}
+	if _, err := engine.GetHistoryEntry("test-user", 9); !errors.Is(err, ErrHistoryNotFound) {
+		t.Errorf("GetHistoryEntry(9) error = %v, want ErrHistoryNotFound", err)
+	}
+
+	var out bytes.Buffer
+	if err := engine.ExportHistory(&out, HistoryQuery{UserID: "other-user", Contains: "invoice"}, mdwformat.CSV); err != nil {
+		t.Fatalf("ExportHistory() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "INVOICE.LIST") {
+		t.Errorf("ExportHistory() = %q", out.String())
+	}
+}
+
+func TestEngine_HistoryBuiltins(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	execCtx := createTestContext()
+
+	enter(t, engine, `CUSTOMER.CREATE name="Acme Corp"`, execCtx)
+	enter(t, engine, `CUSTOMER.LIST`, execCtx)
+
+	result, err := enter(t, engine, `HISTORY.LIST contains="acme"`, execCtx)
+	if err != nil {
+		t.Fatalf("HISTORY.LIST error = %v", err)
+	}
+	rows, ok := result.Data.([]interface{})
+	if !ok || len(rows) != 1 || rows[0].(map[string]interface{})["index"] != 1 {
+		t.Errorf("HISTORY.LIST data = %v", result.Data)
+	}
+
+	// Re-executions run the stored text and are recorded as new entries
+	if _, err := enter(t, engine, `HISTORY.RERUN index="-2"`, execCtx); err != nil {
+		t.Fatalf("HISTORY.RERUN error = %v", err)
+	}
+	calls := client.GetCallHistory()
+	if last := calls[len(calls)-1]; last.MethodName != "CREATE" || last.Params["name"] != "Acme Corp" {
+		t.Errorf("re-executed call = %+v", last)
+	}
+	entry, _ := engine.GetHistoryEntry("test-user", -1)
+	if entry.Index != 3 || entry.Command != `CUSTOMER.CREATE name="Acme Corp"` {
+		t.Errorf("re-execution entry = %+v", entry)
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`HISTORY.RERUN`, "requires 'index' parameter"},
+		{`HISTORY.RERUN index=7`, "history entry 7"},
+		{`HISTORY.RERUN index="last"`, "index must be a non-zero integer"},
+		{`HISTORY.LIST limit=0`, "limit must be a positive integer"},
+		{`HISTORY.LIST session="today"`, "session must be current or all"},
+	}
+	for _, tt := range errorTests {
+		if _, err := enter(t, engine, tt.input, execCtx); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s error = %v, want %q", tt.input, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+// File: jobs.go
+// Title: TCOL Asynchronous Job Execution
+// Description: Implements asynchronous execution of TCOL commands as jobs.
+//              ExecuteAsync returns a job ID immediately; the built-in JOB
+//              object reports status, returns results, and cancels jobs.
+//              Jobs are kept in a JobStore, in memory or as JSON files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of asynchronous jobs
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// JobStatus is the state of an asynchronously executed command
+type JobStatus string
+
+// Job states
+const (
+	JobPending   JobStatus = "PENDING"
+	JobRunning   JobStatus = "RUNNING"
+	JobCompleted JobStatus = "COMPLETED"
+	JobFailed    JobStatus = "FAILED"
+	JobCancelled JobStatus = "CANCELLED"
+)
+
+// IsFinal reports whether a job in this state will not change anymore
+func (s JobStatus) IsFinal() bool {
+	return s == JobCompleted || s == JobFailed || s == JobCancelled
+}
+
+// ErrJobNotFound is returned by job stores for unknown job IDs
+var ErrJobNotFound = errors.New("job not found")
+
+// Job describes an asynchronously executed command
+type Job struct {
+	ID         string                 `json:"id"`
+	Command    string                 `json:"command"`
+	UserID     string                 `json:"user_id,omitempty"`
+	SessionID  string                 `json:"session_id,omitempty"`
+	Status     JobStatus              `json:"status"`
+	Success    bool                   `json:"success"`
+	Data       interface{}            `json:"data,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+}
+
+// JobStore persists jobs. Implementations must be safe for concurrent use.
+type JobStore interface {
+	Save(job *Job) error
+	Get(id string) (*Job, error)
+	Delete(id string) error
+	List() ([]*Job, error)
+}
+
+// MemoryJobStore keeps jobs in memory
+type MemoryJobStore struct {
+	jobs  map[string]*Job
+	mutex sync.RWMutex
+}
+
+// NewMemoryJobStore creates an empty in-memory job store
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]*Job)}
+}
+
+// Save stores a copy of the job
+func (s *MemoryJobStore) Save(job *Job) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored := *job
+	s.jobs[job.ID] = &stored
+	return nil
+}
+
+// Get returns a copy of the job with the given ID
+func (s *MemoryJobStore) Get(id string) (*Job, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return nil, ErrJobNotFound
+	}
+	result := *job
+	return &result, nil
+}
+
+// Delete removes the job with the given ID
+func (s *MemoryJobStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.jobs[id]; !exists {
+		return ErrJobNotFound
+	}
+	delete(s.jobs, id)
+	return nil
+}
+
+// List returns copies of all jobs ordered by creation time
+func (s *MemoryJobStore) List() ([]*Job, error) {
+	s.mutex.RLock()
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		copied := *job
+		jobs = append(jobs, &copied)
+	}
+	s.mutex.RUnlock()
+
+	sortJobs(jobs)
+	return jobs, nil
+}
+
+// FileJobStore keeps each job as a JSON file in a directory, so jobs
+// survive restarts of the process
+type FileJobStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileJobStore creates a job store in dir, creating the directory if needed
+func NewFileJobStore(dir string) (*FileJobStore, error) {
+	if mdwstringx.IsBlank(dir) {
+		return nil, fmt.Errorf("job store directory is required")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create job store directory: %w", err)
+	}
+	return &FileJobStore{dir: dir}, nil
+}
+
+// Save writes the job atomically to its file
+func (s *FileJobStore) Save(job *Job) error {
+	path, err := s.path(job.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", job.ID, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return mdwfilex.WriteFileAtomic(path, data, 0600)
+}
+
+// Get reads the job with the given ID
+func (s *FileJobStore) Get(id string) (*Job, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	data, err := os.ReadFile(path)
+	s.mutex.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job %s: %w", id, err)
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode job %s: %w", id, err)
+	}
+	return &job, nil
+}
+
+// Delete removes the file of the job with the given ID
+func (s *FileJobStore) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return ErrJobNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to delete job %s: %w", id, err)
+	}
+	return nil
+}
+
+// List reads all jobs in the directory ordered by creation time
+func (s *FileJobStore) List() ([]*Job, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(matches))
+	for _, match := range matches {
+		job, err := s.Get(strings.TrimSuffix(filepath.Base(match), ".json"))
+		if errors.Is(err, ErrJobNotFound) {
+			continue // Deleted concurrently
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	sortJobs(jobs)
+	return jobs, nil
+}
+
+// path returns the file of a job, rejecting IDs that would leave the directory
+func (s *FileJobStore) path(id string) (string, error) {
+	if mdwstringx.IsBlank(id) || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid job ID %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// sortJobs orders jobs by creation time, then ID
+func sortJobs(jobs []*Job) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) {
+			return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+}
+
+// runningJob tracks a job executing in this engine
+type runningJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// ExecuteAsync starts executing a command in the background and returns
+// its job ID. The job does not depend on ctx; it runs until it completes,
+// fails, is cancelled with JOB.CANCEL or CancelJob, or the engine is closed.
+func (e *Engine) ExecuteAsync(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (string, error) {
+	if cmd == nil {
+		return "", fmt.Errorf("command cannot be nil")
+	}
+	if err := cmd.Validate(); err != nil {
+		return "", fmt.Errorf("invalid command: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	execCtx = withScope(execCtx)
+
+	suffix, err := mdwstringx.RandomHex(16)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	job := &Job{
+		ID:        "job-" + suffix,
+		Command:   cmd.String(),
+		UserID:    execCtx.UserID,
+		SessionID: execCtx.SessionID,
+		Status:    JobPending,
+		CreatedAt: time.Now(),
+	}
+	if err := e.jobStore.Save(job); err != nil {
+		return "", fmt.Errorf("failed to store job: %w", err)
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	running := &runningJob{cancel: cancel, done: make(chan struct{})}
+	e.mutex.Lock()
+	e.running[job.ID] = running
+	e.mutex.Unlock()
+
+	jobExecCtx := *execCtx
+	jobExecCtx.RequestID = job.ID
+	go e.runJob(jobCtx, job, cmd, &jobExecCtx, running)
+
+	e.logger.Info("TCOL job started", mdwlog.Fields{
+		"jobID":     job.ID,
+		"requestID": execCtx.RequestID,
+		"userID":    execCtx.UserID,
+		"object":    cmd.Object,
+		"method":    cmd.Method,
+	})
+
+	return job.ID, nil
+}
+
+// runJob executes the command of a job and records its outcome
+func (e *Engine) runJob(ctx context.Context, job *Job, cmd *mdwast.Command, execCtx *ExecutionContext, running *runningJob) {
+	defer func() {
+		running.cancel()
+		e.mutex.Lock()
+		delete(e.running, job.ID)
+		e.mutex.Unlock()
+		close(running.done)
+	}()
+
+	job.Status = JobRunning
+	job.StartedAt = time.Now()
+	e.saveJob(job)
+
+	result, err := e.Execute(ctx, cmd, execCtx)
+	job.FinishedAt = time.Now()
+	switch {
+	case ctx.Err() != nil:
+		job.Status = JobCancelled
+		job.Error = "job cancelled"
+	case err != nil:
+		job.Status = JobFailed
+		job.Error = err.Error()
+	default:
+		job.Status = JobCompleted
+		job.Success = result.Success
+		job.Data = result.Data
+		job.Metadata = result.Metadata
+		if result.Error != nil {
+			job.Error = result.Error.Error()
+		}
+	}
+	e.saveJob(job)
+
+	e.logger.Info("TCOL job finished", mdwlog.Fields{
+		"jobID":    job.ID,
+		"status":   string(job.Status),
+		"duration": job.FinishedAt.Sub(job.StartedAt),
+	})
+}
+
+// saveJob stores a job and logs failures; the job keeps running regardless
+func (e *Engine) saveJob(job *Job) {
+	if err := e.jobStore.Save(job); err != nil {
+		e.logger.Error("Failed to store TCOL job", mdwlog.Fields{
+			"jobID": job.ID,
+			"error": err.Error(),
+		})
+	}
+}
+
+// GetJob returns the job with the given ID
+func (e *Engine) GetJob(id string) (*Job, error) {
+	return e.jobStore.Get(id)
+}
+
+// CancelJob cancels a pending or running job. Cancelling a finished job fails.
+func (e *Engine) CancelJob(id string) error {
+	job, err := e.jobStore.Get(id)
+	if err != nil {
+		return err
+	}
+	if job.Status.IsFinal() {
+		return fmt.Errorf("job %s is already %s", id, job.Status)
+	}
+
+	e.mutex.RLock()
+	running, exists := e.running[id]
+	e.mutex.RUnlock()
+	if !exists {
+		// Left behind by an earlier process, e.g. in a file store
+		job.Status = JobCancelled
+		job.Error = "job cancelled"
+		job.FinishedAt = time.Now()
+		return e.jobStore.Save(job)
+	}
+
+	running.cancel()
+	<-running.done
+	return nil
+}
+
+// WaitJob blocks until a job running in this engine has finished or ctx is
+// done, and returns the job
+func (e *Engine) WaitJob(ctx context.Context, id string) (*Job, error) {
+	e.mutex.RLock()
+	running, exists := e.running[id]
+	e.mutex.RUnlock()
+
+	if exists {
+		select {
+		case <-running.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return e.jobStore.Get(id)
+}
+
+// executeJobCommand executes JOB commands. Users only see their own jobs.
+func (e *Engine) executeJobCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	idParam, hasID := cmd.Parameters["id"]
+	if !hasID {
+		return nil, fmt.Errorf("JOB.%s requires 'id' parameter", cmd.Method)
+	}
+	id := fmt.Sprint(idParam.Value)
+
+	job, err := e.jobStore.Get(id)
+	if err == nil && job.UserID != execCtx.UserID {
+		err = ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", id, err)
+	}
+
+	switch cmd.Method {
+	case "STATUS":
+		return &ExecutionResult{
+			Success: true,
+			Data: map[string]interface{}{
+				"id":          job.ID,
+				"command":     job.Command,
+				"status":      string(job.Status),
+				"error":       job.Error,
+				"created_at":  job.CreatedAt,
+				"started_at":  job.StartedAt,
+				"finished_at": job.FinishedAt,
+			},
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "RESULT":
+		if job.Status != JobCompleted {
+			return nil, fmt.Errorf("job %s is %s, not %s", id, job.Status, JobCompleted)
+		}
+		return &ExecutionResult{
+			Success:     job.Success,
+			Data:        job.Data,
+			CommandType: "BUILTIN",
+			Metadata:    job.Metadata,
+		}, nil
+
+	case "CANCEL":
+		if err := e.CancelJob(id); err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        fmt.Sprintf("Job '%s' cancelled", id),
+			CommandType: "BUILTIN",
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown JOB method: %s", cmd.Method)
+	}
+}
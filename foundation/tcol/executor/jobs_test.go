@@ -0,0 +1,240 @@
+// File: jobs_test.go
+// Title: TCOL Asynchronous Job Tests
+// Description: Tests the memory and file job stores, asynchronous command
+//              execution, the built-in JOB object, and job cancellation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial job tests
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// blockingClient blocks every call until its context is cancelled
+type blockingClient struct {
+	started chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *ExecutionContext) (*ServiceResponse, error) {
+	b.once.Do(func() { close(b.started) })
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (b *blockingClient) Health(ctx context.Context, serviceName string) error { return nil }
+func (b *blockingClient) Close() error                                         { return nil }
+
+// jobCommand builds a JOB command for a job ID
+func jobCommand(method, id string) *mdwast.Command {
+	return &mdwast.Command{
+		Object: "JOB",
+		Method: method,
+		Parameters: map[string]mdwast.Value{
+			"id": {Type: mdwast.ValueTypeString, Raw: id, Value: id},
+		},
+	}
+}
+
+func testJobStore(t *testing.T, store JobStore) {
+	created := time.Now().Truncate(time.Second)
+	first := &Job{ID: "job-b", Command: "CUSTOMER.LIST", Status: JobPending, CreatedAt: created}
+	second := &Job{ID: "job-a", Command: "INVOICE.LIST", Status: JobPending, CreatedAt: created.Add(time.Second)}
+	for _, job := range []*Job{first, second} {
+		if err := store.Save(job); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	first.Status = JobCompleted
+	first.Data = map[string]interface{}{"count": "3"}
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Get("job-b")
+	if err != nil || got.Status != JobCompleted || got.Data.(map[string]interface{})["count"] != "3" {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+
+	jobs, err := store.List()
+	if err != nil || len(jobs) != 2 || jobs[0].ID != "job-b" || jobs[1].ID != "job-a" {
+		t.Errorf("List() = %v, %v", jobs, err)
+	}
+
+	if err := store.Delete("job-b"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("job-b"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get() after Delete() error = %v", err)
+	}
+	if err := store.Delete("job-b"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestMemoryJobStore(t *testing.T) {
+	testJobStore(t, NewMemoryJobStore())
+}
+
+func TestFileJobStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileJobStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileJobStore() error = %v", err)
+	}
+	testJobStore(t, store)
+
+	// Jobs survive a new store on the same directory
+	store.Save(&Job{ID: "job-c", Status: JobRunning})
+	reopened, _ := NewFileJobStore(dir)
+	if job, err := reopened.Get("job-c"); err != nil || job.Status != JobRunning {
+		t.Errorf("Get() from reopened store = %v, %v", job, err)
+	}
+
+	for _, id := range []string{"", "../job", "a/b", ".hidden"} {
+		if _, err := store.Get(id); err == nil || errors.Is(err, ErrJobNotFound) {
+			t.Errorf("Get(%q) error = %v, want invalid ID", id, err)
+		}
+	}
+	if _, err := NewFileJobStore(" "); err == nil {
+		t.Error("NewFileJobStore() without directory did not fail")
+	}
+}
+
+func TestEngine_ExecuteAsync(t *testing.T) {
+	client := NewMockServiceClient()
+	engine, _ := New(Options{ServiceClient: client})
+	engine.SetRegistry(createTestRegistry())
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{
+		Success: true,
+		Data:    []interface{}{"C-1", "C-2"},
+	})
+
+	execCtx := createTestContext()
+	cmd := &mdwast.Command{Object: "CUSTOMER", Method: "LIST", Parameters: map[string]mdwast.Value{}}
+	id, err := engine.ExecuteAsync(context.Background(), cmd, execCtx)
+	if err != nil {
+		t.Fatalf("ExecuteAsync() error = %v", err)
+	}
+	if !strings.HasPrefix(id, "job-") {
+		t.Errorf("job ID = %q", id)
+	}
+
+	job, err := engine.WaitJob(context.Background(), id)
+	if err != nil || job.Status != JobCompleted || job.UserID != execCtx.UserID {
+		t.Fatalf("WaitJob() = %+v, %v", job, err)
+	}
+
+	status, err := engine.Execute(context.Background(), jobCommand("STATUS", id), execCtx)
+	if err != nil || status.Data.(map[string]interface{})["status"] != "COMPLETED" {
+		t.Errorf("JOB.STATUS = %v, %v", status, err)
+	}
+	result, err := engine.Execute(context.Background(), jobCommand("RESULT", id), execCtx)
+	if err != nil || len(result.Data.([]interface{})) != 2 {
+		t.Errorf("JOB.RESULT = %v, %v", result, err)
+	}
+	if _, err := engine.Execute(context.Background(), jobCommand("CANCEL", id), execCtx); err == nil {
+		t.Error("JOB.CANCEL of a completed job did not fail")
+	}
+
+	// Jobs of other users are not visible
+	other := createTestContext()
+	other.UserID = "someone-else"
+	if _, err := engine.Execute(context.Background(), jobCommand("STATUS", id), other); err == nil ||
+		!strings.Contains(err.Error(), "job not found") {
+		t.Errorf("JOB.STATUS for another user error = %v", err)
+	}
+	if _, err := engine.Execute(context.Background(), jobCommand("STATUS", "job-missing"), execCtx); err == nil {
+		t.Error("JOB.STATUS of an unknown job did not fail")
+	}
+	if _, err := engine.Execute(context.Background(), &mdwast.Command{Object: "JOB", Method: "STATUS"}, execCtx); err == nil {
+		t.Error("JOB.STATUS without id did not fail")
+	}
+}
+
+func TestEngine_ExecuteAsync_Failure(t *testing.T) {
+	client := NewMockServiceClient()
+	engine, _ := New(Options{ServiceClient: client})
+	engine.SetRegistry(createTestRegistry())
+	client.SetError("customer-service", "CUSTOMER", "LIST", errors.New("service down"))
+
+	cmd := &mdwast.Command{Object: "CUSTOMER", Method: "LIST", Parameters: map[string]mdwast.Value{}}
+	id, _ := engine.ExecuteAsync(context.Background(), cmd, createTestContext())
+	job, err := engine.WaitJob(context.Background(), id)
+	if err != nil || job.Status != JobFailed || !strings.Contains(job.Error, "service down") {
+		t.Errorf("WaitJob() = %+v, %v", job, err)
+	}
+	if _, err := engine.Execute(context.Background(), jobCommand("RESULT", id), createTestContext()); err == nil ||
+		!strings.Contains(err.Error(), "is FAILED") {
+		t.Errorf("JOB.RESULT of a failed job error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := engine.ExecuteAsync(ctx, cmd, createTestContext()); err == nil {
+		t.Error("ExecuteAsync() with a cancelled context did not fail")
+	}
+	if _, err := engine.ExecuteAsync(context.Background(), nil, nil); err == nil {
+		t.Error("ExecuteAsync() without command did not fail")
+	}
+}
+
+func TestEngine_CancelJob(t *testing.T) {
+	client := &blockingClient{started: make(chan struct{})}
+	engine, _ := New(Options{ServiceClient: client})
+	engine.SetRegistry(createTestRegistry())
+
+	execCtx := createTestContext()
+	cmd := &mdwast.Command{Object: "CUSTOMER", Method: "LIST", Parameters: map[string]mdwast.Value{}}
+	id, err := engine.ExecuteAsync(context.Background(), cmd, execCtx)
+	if err != nil {
+		t.Fatalf("ExecuteAsync() error = %v", err)
+	}
+	<-client.started
+
+	if job, _ := engine.GetJob(id); job.Status != JobRunning {
+		t.Errorf("status while running = %s", job.Status)
+	}
+	if _, err := engine.Execute(context.Background(), jobCommand("CANCEL", id), execCtx); err != nil {
+		t.Fatalf("JOB.CANCEL error = %v", err)
+	}
+	if job, _ := engine.GetJob(id); job.Status != JobCancelled {
+		t.Errorf("status after cancel = %s", job.Status)
+	}
+
+	// Close cancels jobs that are still running
+	second := &blockingClient{started: make(chan struct{})}
+	engine, _ = New(Options{ServiceClient: second})
+	engine.SetRegistry(createTestRegistry())
+	id, _ = engine.ExecuteAsync(context.Background(), cmd, execCtx)
+	<-second.started
+	engine.Close()
+	if job, _ := engine.GetJob(id); job.Status != JobCancelled {
+		t.Errorf("status after Close() = %s", job.Status)
+	}
+
+	// Jobs left unfinished by an earlier process can still be cancelled
+	store := NewMemoryJobStore()
+	store.Save(&Job{ID: "job-stale", UserID: execCtx.UserID, Status: JobRunning})
+	engine, _ = New(Options{ServiceClient: NewMockServiceClient(), JobStore: store})
+	if err := engine.CancelJob("job-stale"); err != nil {
+		t.Errorf("CancelJob() error = %v", err)
+	}
+	if job, _ := store.Get("job-stale"); job.Status != JobCancelled {
+		t.Errorf("stale job status = %s", job.Status)
+	}
+}
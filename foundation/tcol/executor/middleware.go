@@ -0,0 +1,214 @@
+// File: middleware.go
+// Title: TCOL Executor Middleware Pipeline
+// Description: Implements the middleware pipeline of the executor. Middleware
+//              wraps the execution of each command in registration order and
+//              may be scoped to objects and methods. Provides built-in
+//              middleware for authentication, rate limiting, result caching,
+//              and audit logging.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the middleware pipeline
+// - 2026-10-16 v0.1.1: Moved result caching to ResultCache
+// - 2026-10-16 v0.1.2: The pipeline is instrumented with a command span and metrics
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// ExecutorFunc executes a single command; it is the next step of a middleware
+type ExecutorFunc func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error)
+
+// Middleware wraps command execution. It calls next to continue the
+// pipeline, or returns without calling it to short-circuit execution. The
+// context passed to next is seen by all later middleware and the service call.
+type Middleware func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error)
+
+// Errors returned by the built-in middleware
+var (
+	ErrUnauthenticated = errors.New("user not authenticated")
+	ErrRateLimited     = errors.New("rate limit exceeded")
+)
+
+// scopedMiddleware is a registered middleware with its object/method scope
+type scopedMiddleware struct {
+	object     string
+	method     string
+	middleware Middleware
+}
+
+// matches reports whether the middleware applies to a command
+func (sm scopedMiddleware) matches(cmd *mdwast.Command) bool {
+	return matchesScope(sm.object, cmd.Object) && matchesScope(sm.method, cmd.Method)
+}
+
+// matchesScope matches a scope pattern; "" and "*" match everything
+func matchesScope(pattern, value string) bool {
+	return pattern == "" || pattern == "*" || strings.EqualFold(pattern, value)
+}
+
+// Use adds middleware that applies to all commands. Middleware runs in
+// registration order: the first registered is the outermost.
+func (e *Engine) Use(middleware Middleware) {
+	e.UseFor("*", "*", middleware)
+}
+
+// UseFor adds middleware that applies only to commands on object and
+// method; "*" matches any object or method
+func (e *Engine) UseFor(object, method string, middleware Middleware) {
+	if middleware == nil {
+		e.logger.Warn("Ignoring nil TCOL middleware", mdwlog.Fields{
+			"object": object,
+			"method": method,
+		})
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.middleware = append(e.middleware, scopedMiddleware{object: object, method: method, middleware: middleware})
+}
+
+// pipeline returns final wrapped in the middleware that applies to cmd and
+// in the instrumentation of the engine
+func (e *Engine) pipeline(cmd *mdwast.Command, final ExecutorFunc) ExecutorFunc {
+	e.mutex.RLock()
+	registered := e.middleware
+	e.mutex.RUnlock()
+
+	handler := final
+	for i := len(registered) - 1; i >= 0; i-- {
+		if !registered[i].matches(cmd) {
+			continue
+		}
+		middleware, next := registered[i].middleware, handler
+		handler = func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+			return middleware(ctx, cmd, execCtx, next)
+		}
+	}
+	return e.instrument(handler)
+}
+
+// AuthMiddleware rejects commands without a user ID and, if checker is not
+// nil, commands the user is not permitted to execute
+func AuthMiddleware(checker PermissionChecker) Middleware {
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		if strings.TrimSpace(execCtx.UserID) == "" {
+			return nil, fmt.Errorf("%s.%s: %w", cmd.Object, cmd.Method, ErrUnauthenticated)
+		}
+		if checker != nil {
+			if err := checker.CheckPermission(ctx, execCtx.UserID, cmd.Object, cmd.Method, execCtx); err != nil {
+				return nil, err
+			}
+		}
+		return next(ctx, cmd, execCtx)
+	}
+}
+
+// RateLimitMiddleware allows each user at most limit commands per window.
+// Commands without a user ID are counted per session.
+func RateLimitMiddleware(limit int, window time.Duration) Middleware {
+	type counter struct {
+		start time.Time
+		count int
+	}
+	var mutex sync.Mutex
+	counters := make(map[string]*counter)
+
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		key := "user:" + execCtx.UserID
+		if execCtx.UserID == "" {
+			key = "session:" + execCtx.SessionID
+		}
+		now := time.Now()
+
+		mutex.Lock()
+		c, exists := counters[key]
+		if !exists || now.Sub(c.start) >= window {
+			// Drop expired windows so idle users do not accumulate
+			for k, old := range counters {
+				if now.Sub(old.start) >= window {
+					delete(counters, k)
+				}
+			}
+			c = &counter{start: now}
+			counters[key] = c
+		}
+		c.count++
+		exceeded := c.count > limit
+		retryAfter := c.start.Add(window).Sub(now)
+		mutex.Unlock()
+
+		if exceeded {
+			return nil, fmt.Errorf("%w: %d commands per %s, retry in %s",
+				ErrRateLimited, limit, window, retryAfter.Round(time.Millisecond))
+		}
+		return next(ctx, cmd, execCtx)
+	}
+}
+
+// CacheMiddleware caches successful results of read methods per user and
+// command. Any other successful method on an object invalidates the cached
+// results of that object. Use NewResultCache to invalidate results or read
+// the cache counters.
+func CacheMiddleware(opts CacheOptions) Middleware {
+	return NewResultCache(opts).Middleware()
+}
+
+// copyMetadata returns a shallow copy of result metadata
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(metadata)+1)
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
+
+// AuditMiddleware writes an audit log entry for every command with its
+// outcome and duration
+func AuditMiddleware(logger *mdwlog.Logger) Middleware {
+	if logger == nil {
+		logger = mdwlog.GetDefault()
+	}
+
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		start := time.Now()
+		result, err := next(ctx, cmd, execCtx)
+
+		fields := mdwlog.Fields{
+			"requestID": execCtx.RequestID,
+			"userID":    execCtx.UserID,
+			"sessionID": execCtx.SessionID,
+			"clientIP":  execCtx.ClientIP,
+			"object":    cmd.Object,
+			"method":    cmd.Method,
+			"objectID":  cmd.ObjectID,
+			"duration":  time.Since(start),
+		}
+		switch {
+		case err != nil:
+			fields["status"] = "FAILED"
+			fields["error"] = err.Error()
+		case !result.Success:
+			fields["status"] = "UNSUCCESSFUL"
+		default:
+			fields["status"] = "COMPLETED"
+		}
+		logger.Audit("TCOL command", fields)
+
+		return result, err
+	}
+}
@@ -0,0 +1,262 @@
+// File: middleware_test.go
+// Title: TCOL Executor Middleware Tests
+// Description: Tests middleware ordering, scoping, context propagation and
+//              short-circuiting, and the built-in authentication, rate
+//              limiting, caching, and audit middleware.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial middleware tests
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+type middlewareKey struct{}
+
+// contextClient records a context value seen by the service call
+type contextClient struct {
+	MockServiceClient
+	seen interface{}
+}
+
+func (c *contextClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *ExecutionContext) (*ServiceResponse, error) {
+	c.seen = ctx.Value(middlewareKey{})
+	return c.MockServiceClient.Execute(ctx, serviceName, objectName, methodName, params, execCtx)
+}
+
+func newMiddlewareEngine(t *testing.T) (*Engine, *MockServiceClient) {
+	client := NewMockServiceClient()
+	engine, err := New(Options{ServiceClient: client})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: []interface{}{"C-1"}})
+	client.SetResponse("customer-service", "CUSTOMER", "UPDATE", &ServiceResponse{Success: true})
+	return engine, client
+}
+
+func customerCommand(method string, params map[string]mdwast.Value) *mdwast.Command {
+	if params == nil {
+		params = map[string]mdwast.Value{}
+	}
+	return &mdwast.Command{Object: "CUSTOMER", Method: method, Parameters: params}
+}
+
+// tracing returns middleware appending name to trace before and after next
+func tracing(name string, trace *[]string) Middleware {
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		*trace = append(*trace, name+">")
+		result, err := next(ctx, cmd, execCtx)
+		*trace = append(*trace, "<"+name)
+		return result, err
+	}
+}
+
+func TestEngine_Use_Order(t *testing.T) {
+	engine, _ := newMiddlewareEngine(t)
+	var trace []string
+	engine.Use(tracing("a", &trace))
+	engine.Use(tracing("b", &trace))
+	engine.UseFor("CUSTOMER", "UPDATE", tracing("update", &trace))
+	engine.UseFor("invoice", "*", tracing("invoice", &trace))
+	engine.Use(nil)
+
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := strings.Join(trace, " "); got != "a> b> <b <a" {
+		t.Errorf("LIST trace = %q", got)
+	}
+
+	trace = nil
+	engine.Execute(context.Background(), customerCommand("update", nil), createTestContext())
+	if got := strings.Join(trace, " "); got != "a> b> update> <update <b <a" {
+		t.Errorf("UPDATE trace = %q", got)
+	}
+}
+
+func TestEngine_Use_ContextAndShortCircuit(t *testing.T) {
+	client := &contextClient{MockServiceClient: *NewMockServiceClient()}
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true})
+	engine, _ := New(Options{ServiceClient: client})
+	engine.SetRegistry(createTestRegistry())
+
+	engine.Use(func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		return next(context.WithValue(ctx, middlewareKey{}, "tenant-42"), cmd, execCtx)
+	})
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if client.seen != "tenant-42" {
+		t.Errorf("service saw context value %v", client.seen)
+	}
+
+	// Middleware returning an error stops the pipeline before the service call
+	denied := errors.New("maintenance window")
+	engine.UseFor("CUSTOMER", "DELETE", func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, next ExecutorFunc) (*ExecutionResult, error) {
+		return nil, denied
+	})
+	client.ClearHistory()
+	if _, err := engine.Execute(context.Background(), customerCommand("DELETE", nil), createTestContext()); !errors.Is(err, denied) {
+		t.Errorf("Execute() error = %v, want %v", err, denied)
+	}
+	if len(client.GetCallHistory()) != 0 {
+		t.Error("service called despite short-circuit")
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	engine, client := newMiddlewareEngine(t)
+	checker := NewMockPermissionChecker()
+	checker.SetPermission("test-user", "CUSTOMER", "UPDATE", false)
+	engine.Use(AuthMiddleware(checker))
+
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+	if _, err := engine.Execute(context.Background(), customerCommand("UPDATE", nil), createTestContext()); err == nil ||
+		!strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("UPDATE error = %v", err)
+	}
+
+	anonymous := createTestContext()
+	anonymous.UserID = ""
+	client.ClearHistory()
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), anonymous); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("anonymous error = %v, want %v", err, ErrUnauthenticated)
+	}
+	if len(client.GetCallHistory()) != 0 {
+		t.Error("service called for an anonymous user")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	engine, _ := newMiddlewareEngine(t)
+	engine.Use(RateLimitMiddleware(2, 50*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+			t.Fatalf("call %d error = %v", i+1, err)
+		}
+	}
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("third call error = %v, want %v", err, ErrRateLimited)
+	}
+
+	other := createTestContext()
+	other.UserID = "other-user"
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), other); err != nil {
+		t.Errorf("other user error = %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+		t.Errorf("call after window error = %v", err)
+	}
+}
+
+func TestCacheMiddleware(t *testing.T) {
+	engine, client := newMiddlewareEngine(t)
+	engine.Use(CacheMiddleware(CacheOptions{TTL: 50 * time.Millisecond}))
+	params := func(status, region string) map[string]mdwast.Value {
+		return map[string]mdwast.Value{
+			"status": {Type: mdwast.ValueTypeString, Value: status},
+			"region": {Type: mdwast.ValueTypeString, Value: region},
+		}
+	}
+
+	first, err := engine.Execute(context.Background(), customerCommand("LIST", params("active", "eu")), createTestContext())
+	if err != nil || first.Metadata["cached"] != nil {
+		t.Fatalf("first LIST = %+v, %v", first, err)
+	}
+	second, err := engine.Execute(context.Background(), customerCommand("LIST", params("active", "eu")), createTestContext())
+	if err != nil || second.Metadata["cached"] != true || len(second.Data.([]interface{})) != 1 {
+		t.Errorf("second LIST = %+v, %v", second, err)
+	}
+	if calls := len(client.GetCallHistory()); calls != 1 {
+		t.Errorf("service calls = %d, want 1", calls)
+	}
+
+	// Different parameters and other users are cached separately
+	engine.Execute(context.Background(), customerCommand("LIST", params("inactive", "eu")), createTestContext())
+	other := createTestContext()
+	other.UserID = "other-user"
+	engine.Execute(context.Background(), customerCommand("LIST", params("active", "eu")), other)
+	if calls := len(client.GetCallHistory()); calls != 3 {
+		t.Errorf("service calls = %d, want 3", calls)
+	}
+
+	// A write invalidates the object's cached results
+	engine.Execute(context.Background(), customerCommand("UPDATE", nil), createTestContext())
+	client.ClearHistory()
+	engine.Execute(context.Background(), customerCommand("LIST", params("active", "eu")), createTestContext())
+	if calls := len(client.GetCallHistory()); calls != 1 {
+		t.Errorf("service calls after UPDATE = %d, want 1", calls)
+	}
+
+	// Cached results expire after the TTL
+	time.Sleep(60 * time.Millisecond)
+	client.ClearHistory()
+	engine.Execute(context.Background(), customerCommand("LIST", params("active", "eu")), createTestContext())
+	if calls := len(client.GetCallHistory()); calls != 1 {
+		t.Errorf("service calls after TTL = %d, want 1", calls)
+	}
+}
+
+func TestCacheMiddleware_MaxEntries(t *testing.T) {
+	engine, client := newMiddlewareEngine(t)
+	engine.Use(CacheMiddleware(CacheOptions{MaxEntries: 1}))
+	list := func(status string) {
+		engine.Execute(context.Background(), customerCommand("LIST", map[string]mdwast.Value{
+			"status": {Type: mdwast.ValueTypeString, Value: status},
+		}), createTestContext())
+	}
+
+	list("active")
+	list("inactive")
+	list("active")
+	if calls := len(client.GetCallHistory()); calls != 3 {
+		t.Errorf("service calls = %d, want 3 after eviction", calls)
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := mdwlog.NewWithConfig(mdwlog.Config{Level: mdwlog.LevelInfo, Format: mdwlog.FormatJSON, Output: &buf})
+	engine, client := newMiddlewareEngine(t)
+	engine.Use(AuditMiddleware(logger))
+
+	if _, err := engine.Execute(context.Background(), customerCommand("LIST", nil), createTestContext()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	client.SetError("customer-service", "CUSTOMER", "UPDATE", errors.New("service down"))
+	if _, err := engine.Execute(context.Background(), customerCommand("UPDATE", nil), createTestContext()); err == nil {
+		t.Error("UPDATE did not fail")
+	}
+
+	output := buf.String()
+	if entries := strings.Count(output, `"message":"TCOL command"`); entries != 2 {
+		t.Fatalf("audit entries = %d, want 2:\n%s", entries, output)
+	}
+	for _, want := range []string{`"status":"COMPLETED"`, `"status":"FAILED"`, `"method":"LIST"`, "service down"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("audit output lacks %s:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// File: permissions.go
+// Title: TCOL Role-Based Permission Model
+// Description: Implements a PermissionChecker backed by roles. Roles grant
+//              permissions in OBJECT:METHOD:scope syntax, may deny them with
+//              a leading '!', and inherit the permissions of other roles.
+//              Roles and the role assignments of users are kept in a
+//              pluggable PermissionStore.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of roles, scopes, deny rules and wildcards
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// Errors of permission checks; denials wrap ErrPermissionDenied and carry
+// CodeTCOLPermission
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrRoleNotFound     = errors.New("role not found")
+)
+
+// PermissionScopeKey is the metadata key of the execution context under
+// which RolePermissionChecker stores the scope granted to a command.
+// Services read it to restrict the records a command may touch.
+const PermissionScopeKey = "permission_scope"
+
+// PermissionWildcard matches every object or every method
+const PermissionWildcard = "*"
+
+// PermissionScope limits the records a granted command may touch
+type PermissionScope int
+
+// Permission scopes, from narrowest to widest
+const (
+	ScopeSelf PermissionScope = iota + 1 // Records owned by the user
+	ScopeTeam                            // Records owned by the user's team
+	ScopeAll                             // All records
+)
+
+// String returns the name of the scope as used in permissions
+func (s PermissionScope) String() string {
+	switch s {
+	case ScopeSelf:
+		return "self"
+	case ScopeTeam:
+		return "team"
+	case ScopeAll:
+		return "all"
+	default:
+		return "unknown"
+	}
+}
+
+// Permission is a grant or deny rule for the methods of an object
+type Permission struct {
+	Object string          // Object name or PermissionWildcard
+	Method string          // Method name or PermissionWildcard
+	Scope  PermissionScope // Scope of a grant; ScopeAll for deny rules
+	Deny   bool
+}
+
+// ParsePermission parses a permission in the syntax [!]OBJECT:METHOD[:scope].
+// The scope is all, team or self and defaults to all; deny rules apply to
+// every scope and take none. Object and method may be '*'.
+func ParsePermission(text string) (Permission, error) {
+	perm := Permission{Scope: ScopeAll}
+	rest := strings.TrimSpace(text)
+	if strings.HasPrefix(rest, "!") {
+		perm.Deny = true
+		rest = strings.TrimSpace(rest[1:])
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Permission{}, fmt.Errorf("invalid permission %q: expected OBJECT:METHOD[:scope]", text)
+	}
+	perm.Object = strings.ToUpper(strings.TrimSpace(parts[0]))
+	perm.Method = strings.ToUpper(strings.TrimSpace(parts[1]))
+	if perm.Object == "" || perm.Method == "" {
+		return Permission{}, fmt.Errorf("invalid permission %q: object and method are required", text)
+	}
+
+	if len(parts) == 3 {
+		if perm.Deny {
+			return Permission{}, fmt.Errorf("invalid permission %q: deny rules take no scope", text)
+		}
+		switch strings.ToLower(strings.TrimSpace(parts[2])) {
+		case "all":
+			perm.Scope = ScopeAll
+		case "team":
+			perm.Scope = ScopeTeam
+		case "self":
+			perm.Scope = ScopeSelf
+		default:
+			return Permission{}, fmt.Errorf("invalid permission %q: scope must be all, team or self", text)
+		}
+	}
+	return perm, nil
+}
+
+// String returns the permission in the syntax ParsePermission accepts
+func (p Permission) String() string {
+	if p.Deny {
+		return "!" + p.Object + ":" + p.Method
+	}
+	return p.Object + ":" + p.Method + ":" + p.Scope.String()
+}
+
+// Matches reports whether the permission applies to a method of an object
+func (p Permission) Matches(objectName, methodName string) bool {
+	return (p.Object == PermissionWildcard || strings.EqualFold(p.Object, objectName)) &&
+		(p.Method == PermissionWildcard || strings.EqualFold(p.Method, methodName))
+}
+
+// Role is a named set of permissions. A role includes the permissions of
+// the roles it inherits; deny rules win over grants from any role.
+type Role struct {
+	Name        string
+	Inherits    []string // Roles whose permissions this role includes
+	Permissions []string // Grants and deny rules in ParsePermission syntax
+}
+
+// PermissionStore provides roles and the roles assigned to users
+type PermissionStore interface {
+	// GetRole returns a role or an error wrapping ErrRoleNotFound
+	GetRole(ctx context.Context, name string) (*Role, error)
+
+	// GetUserRoles returns the names of the roles assigned to a user
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+}
+
+// MemoryPermissionStore keeps roles and role assignments in memory
+type MemoryPermissionStore struct {
+	roles     map[string]*Role
+	userRoles map[string][]string
+	mutex     sync.RWMutex
+}
+
+// NewMemoryPermissionStore creates an empty in-memory permission store
+func NewMemoryPermissionStore() *MemoryPermissionStore {
+	return &MemoryPermissionStore{
+		roles:     make(map[string]*Role),
+		userRoles: make(map[string][]string),
+	}
+}
+
+// SaveRole adds or replaces a role after validating its permissions
+func (s *MemoryPermissionStore) SaveRole(role *Role) error {
+	if strings.TrimSpace(role.Name) == "" {
+		return fmt.Errorf("role name is required")
+	}
+	for _, text := range role.Permissions {
+		if _, err := ParsePermission(text); err != nil {
+			return fmt.Errorf("role %s: %w", role.Name, err)
+		}
+	}
+
+	stored := &Role{
+		Name:        role.Name,
+		Inherits:    append([]string(nil), role.Inherits...),
+		Permissions: append([]string(nil), role.Permissions...),
+	}
+	s.mutex.Lock()
+	s.roles[role.Name] = stored
+	s.mutex.Unlock()
+	return nil
+}
+
+// AssignRoles replaces the roles assigned to a user
+func (s *MemoryPermissionStore) AssignRoles(userID string, roles ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.userRoles[userID] = append([]string(nil), roles...)
+}
+
+// GetRole returns a copy of a role
+func (s *MemoryPermissionStore) GetRole(ctx context.Context, name string) (*Role, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	role, exists := s.roles[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrRoleNotFound, name)
+	}
+	return &Role{
+		Name:        role.Name,
+		Inherits:    append([]string(nil), role.Inherits...),
+		Permissions: append([]string(nil), role.Permissions...),
+	}, nil
+}
+
+// GetUserRoles returns the roles assigned to a user
+func (s *MemoryPermissionStore) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return append([]string(nil), s.userRoles[userID]...), nil
+}
+
+// RolePermissionChecker checks commands against the roles of users. A
+// command is allowed if a role grants its method and no role denies it; the
+// widest granted scope is stored in the execution context metadata under
+// PermissionScopeKey.
+type RolePermissionChecker struct {
+	store PermissionStore
+}
+
+// NewRolePermissionChecker creates a permission checker reading roles from
+// store
+func NewRolePermissionChecker(store PermissionStore) *RolePermissionChecker {
+	return &RolePermissionChecker{store: store}
+}
+
+// CheckPermission returns an error wrapping ErrPermissionDenied unless the
+// user may execute the method of the object
+func (c *RolePermissionChecker) CheckPermission(ctx context.Context, userID, objectName, methodName string, execCtx *ExecutionContext) error {
+	perms, err := c.permissions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve permissions of user %s: %w", userID, err)
+	}
+
+	var scope PermissionScope
+	for _, perm := range perms {
+		if !perm.Matches(objectName, methodName) {
+			continue
+		}
+		if perm.Deny {
+			return denied(userID, objectName, methodName, perm.String())
+		}
+		scope = max(scope, perm.Scope)
+	}
+	if scope == 0 {
+		return denied(userID, objectName, methodName, "")
+	}
+
+	if execCtx != nil {
+		if execCtx.Metadata == nil {
+			execCtx.Metadata = make(map[string]interface{})
+		}
+		execCtx.Metadata[PermissionScopeKey] = scope.String()
+	}
+	return nil
+}
+
+// GetUserPermissions returns the grants and deny rules of all roles of a
+// user, including inherited roles
+func (c *RolePermissionChecker) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	perms, err := c.permissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(perms))
+	for i, perm := range perms {
+		texts[i] = perm.String()
+	}
+	return texts, nil
+}
+
+// permissions resolves the roles of a user and the roles they inherit into
+// a list of permissions without duplicates
+func (c *RolePermissionChecker) permissions(ctx context.Context, userID string) ([]Permission, error) {
+	roles, err := c.store.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var perms []Permission
+	seenRoles := make(map[string]bool)
+	seenPerms := make(map[Permission]bool)
+	var resolve func(name string) error
+	resolve = func(name string) error {
+		if seenRoles[name] {
+			return nil // Inherited twice or a cycle
+		}
+		seenRoles[name] = true
+
+		role, err := c.store.GetRole(ctx, name)
+		if err != nil {
+			return err
+		}
+		for _, text := range role.Permissions {
+			perm, err := ParsePermission(text)
+			if err != nil {
+				return fmt.Errorf("role %s: %w", name, err)
+			}
+			if !seenPerms[perm] {
+				seenPerms[perm] = true
+				perms = append(perms, perm)
+			}
+		}
+		for _, parent := range role.Inherits {
+			if err := resolve(parent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, name := range roles {
+		if err := resolve(name); err != nil {
+			return nil, err
+		}
+	}
+	return perms, nil
+}
+
+// denied returns the error of a denied command; rule is the deny rule that
+// matched, or empty if no role grants the method
+func denied(userID, objectName, methodName, rule string) error {
+	message := fmt.Sprintf("user %s may not execute %s.%s", userID, objectName, methodName)
+	if rule != "" {
+		message += " (denied by " + rule + ")"
+	}
+	return mdwerror.Wrap(ErrPermissionDenied, message).
+		WithCode(mdwerror.CodeTCOLPermission).
+		WithDetail("userID", userID).
+		WithDetail("object", objectName).
+		WithDetail("method", methodName)
+}
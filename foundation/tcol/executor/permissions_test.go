@@ -0,0 +1,163 @@
+// File: permissions_test.go
+// Title: TCOL Role-Based Permission Model Tests
+// Description: Tests the permission syntax, role inheritance, deny rules,
+//              wildcard grants, scopes, and the enforcement of permissions
+//              by the executor.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial permission tests
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"CUSTOMER:LIST", "CUSTOMER:LIST:all", false},
+		{"customer:update:team", "CUSTOMER:UPDATE:team", false},
+		{"INVOICE:*:self", "INVOICE:*:self", false},
+		{"*:*", "*:*:all", false},
+		{"! CUSTOMER:DELETE", "!CUSTOMER:DELETE", false},
+		{"CUSTOMER", "", true},
+		{"CUSTOMER:", "", true},
+		{"CUSTOMER:LIST:world", "", true},
+		{"!CUSTOMER:DELETE:self", "", true},
+		{"A:B:all:extra", "", true},
+	}
+
+	for _, tt := range tests {
+		perm, err := ParsePermission(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePermission(%q) = %v, want error", tt.input, perm)
+			}
+			continue
+		}
+		if err != nil || perm.String() != tt.expected {
+			t.Errorf("ParsePermission(%q) = %v, %v, want %s", tt.input, perm, err, tt.expected)
+		}
+	}
+}
+
+// newRoleChecker creates a checker with a role hierarchy of viewer, clerk
+// (inherits viewer) and admin (inherits clerk)
+func newRoleChecker(t *testing.T) (*RolePermissionChecker, *MemoryPermissionStore) {
+	store := NewMemoryPermissionStore()
+	roles := []*Role{
+		{Name: "viewer", Permissions: []string{"*:LIST:self", "*:GET:self"}},
+		{Name: "clerk", Inherits: []string{"viewer"}, Permissions: []string{
+			"CUSTOMER:*:team", "!CUSTOMER:DELETE",
+		}},
+		{Name: "admin", Inherits: []string{"clerk", "viewer"}, Permissions: []string{"*:*:all"}},
+		{Name: "auditor", Inherits: []string{"auditor"}, Permissions: []string{"INVOICE:LIST"}},
+	}
+	for _, role := range roles {
+		if err := store.SaveRole(role); err != nil {
+			t.Fatalf("SaveRole(%s) error = %v", role.Name, err)
+		}
+	}
+	store.AssignRoles("vera", "viewer")
+	store.AssignRoles("carl", "clerk")
+	store.AssignRoles("ada", "admin")
+	store.AssignRoles("otto", "viewer", "auditor")
+	return NewRolePermissionChecker(store), store
+}
+
+func TestRolePermissionChecker(t *testing.T) {
+	checker, store := newRoleChecker(t)
+
+	tests := []struct {
+		userID  string
+		object  string
+		method  string
+		allowed bool
+		scope   string
+	}{
+		{"vera", "INVOICE", "LIST", true, "self"},
+		{"vera", "CUSTOMER", "CREATE", false, ""},
+		{"carl", "CUSTOMER", "list", true, "team"},
+		{"carl", "INVOICE", "LIST", true, "self"},
+		{"carl", "CUSTOMER", "DELETE", false, ""},
+		{"ada", "INVOICE", "SEND", true, "all"},
+		{"ada", "CUSTOMER", "DELETE", false, ""}, // Deny rules of inherited roles win
+		{"otto", "INVOICE", "LIST", true, "all"},
+		{"nobody", "INVOICE", "LIST", false, ""},
+	}
+
+	for _, tt := range tests {
+		execCtx := &ExecutionContext{UserID: tt.userID}
+		err := checker.CheckPermission(context.Background(), tt.userID, tt.object, tt.method, execCtx)
+		if tt.allowed {
+			if err != nil || execCtx.Metadata[PermissionScopeKey] != tt.scope {
+				t.Errorf("%s %s.%s = %v, scope %v, want allowed with scope %s",
+					tt.userID, tt.object, tt.method, err, execCtx.Metadata[PermissionScopeKey], tt.scope)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrPermissionDenied) || mdwerror.GetCode(err) != mdwerror.CodeTCOLPermission {
+			t.Errorf("%s %s.%s error = %v, want permission denied", tt.userID, tt.object, tt.method, err)
+		}
+	}
+
+	perms, err := checker.GetUserPermissions(context.Background(), "carl")
+	if err != nil || strings.Join(perms, " ") != "CUSTOMER:*:team !CUSTOMER:DELETE *:LIST:self *:GET:self" {
+		t.Errorf("GetUserPermissions(carl) = %v, %v", perms, err)
+	}
+
+	store.SaveRole(&Role{Name: "broken", Inherits: []string{"missing"}})
+	store.AssignRoles("bea", "broken")
+	err = checker.CheckPermission(context.Background(), "bea", "INVOICE", "LIST", nil)
+	if !errors.Is(err, ErrRoleNotFound) {
+		t.Errorf("CheckPermission() with a missing role error = %v, want ErrRoleNotFound", err)
+	}
+	if err := store.SaveRole(&Role{Name: "bad", Permissions: []string{"INVOICE"}}); err == nil {
+		t.Error("SaveRole() with an invalid permission did not fail")
+	}
+}
+
+func TestEngine_PermissionEnforcement(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	engine.permissions, _ = newRoleChecker(t)
+	execCtx := createTestContext()
+	execCtx.UserID = "carl"
+
+	if _, err := enter(t, engine, `CUSTOMER.LIST`, execCtx); err != nil {
+		t.Fatalf("CUSTOMER.LIST error = %v", err)
+	}
+	calls := client.GetCallHistory()
+	if len(calls) != 1 || calls[0].Context.Metadata[PermissionScopeKey] != "team" {
+		t.Errorf("service calls = %+v, want one call with scope team", calls)
+	}
+
+	// Each stage of a pipe is checked before it runs
+	_, err := enter(t, engine, `CUSTOMER.LIST | CUSTOMER.DELETE`, execCtx)
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("pipe error = %v, want permission denied", err)
+	}
+	if _, err := enter(t, engine, `INVOICE.CREATE`, execCtx); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("INVOICE.CREATE error = %v, want permission denied", err)
+	}
+	if calls := client.GetCallHistory(); len(calls) != 2 || calls[1].MethodName != "LIST" {
+		t.Errorf("service calls = %+v, denied commands reached the service", calls)
+	}
+
+	// Built-in objects are not checked
+	if _, err := enter(t, engine, `HISTORY.LIST`, execCtx); err != nil {
+		t.Errorf("HISTORY.LIST error = %v", err)
+	}
+}
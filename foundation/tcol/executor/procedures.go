@@ -0,0 +1,266 @@
+// File: procedures.go
+// Title: TCOL Stored Procedure Execution
+// Description: Implements the definition and execution of stored procedures
+//              and the built-in PROC object. Procedure bodies are scripts
+//              whose parameter placeholders are bound as variables from the
+//              arguments of PROC.RUN.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of stored procedures
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// DefineProcedure checks that the body of a procedure parses as a script
+// and stores the procedure in the registry, replacing one of the same name
+// if replace is set
+func (e *Engine) DefineProcedure(proc mdwregistry.Procedure, replace bool) error {
+	registry, err := e.procedureRegistry()
+	if err != nil {
+		return err
+	}
+
+	for _, param := range proc.Parameters {
+		if param == LastIDVariable || param == PrevVariable {
+			return fmt.Errorf("parameter %s of procedure %s is a reserved variable", param, proc.Name)
+		}
+	}
+	if _, err := e.parseProcedure(registry, &proc); err != nil {
+		return err
+	}
+
+	return registry.DefineProcedure(proc, replace)
+}
+
+// RunProcedure runs a stored procedure with the given arguments, which must
+// match its parameters. The body runs in a new variable scope holding only
+// the arguments, so it does not see or change the variables of the caller.
+// Its commands are checked against the permissions of execCtx.UserID like
+// any other command.
+func (e *Engine) RunProcedure(ctx context.Context, name string, args map[string]interface{}, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	registry, err := e.procedureRegistry()
+	if err != nil {
+		return nil, err
+	}
+	execCtx = withScope(execCtx)
+
+	proc, err := registry.GetProcedure(name)
+	if err != nil {
+		return nil, err
+	}
+	script, err := e.parseProcedure(registry, proc)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := NewScope(nil)
+	for _, param := range proc.Parameters {
+		value, exists := args[param]
+		if !exists {
+			return nil, fmt.Errorf("procedure %s requires parameter %s", proc.Name, param)
+		}
+		if err := scope.Define(param, value); err != nil {
+			return nil, fmt.Errorf("procedure %s: %w", proc.Name, err)
+		}
+	}
+	for _, arg := range sortedArgs(args) {
+		if _, bound := scope.Lookup(arg); !bound {
+			return nil, fmt.Errorf("procedure %s has no parameter %s", proc.Name, arg)
+		}
+	}
+
+	// Procedures may run procedures; the nesting is bounded like chains
+	runCtx := *execCtx
+	runCtx.ChainDepth++
+	runCtx.Variables = scope
+	runCtx.Input = ""
+	if runCtx.ChainDepth >= e.options.MaxChainDepth {
+		return nil, fmt.Errorf("procedure %s exceeds the maximum nesting depth of %d", proc.Name, e.options.MaxChainDepth)
+	}
+
+	e.logger.Info("Running TCOL procedure", mdwlog.Fields{
+		"requestID": execCtx.RequestID,
+		"userID":    execCtx.UserID,
+		"procedure": proc.Name,
+		"arguments": len(args),
+	})
+
+	results, err := e.ExecuteScript(ctx, script, &runCtx)
+	if err != nil {
+		return nil, fmt.Errorf("procedure %s: %w", proc.Name, err)
+	}
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        results,
+		CommandType: "PROCEDURE",
+		Metadata: map[string]interface{}{
+			"procedure":  proc.Name,
+			"statements": len(results),
+		},
+	}, nil
+}
+
+// parseProcedure parses the body of a procedure. Like HISTORY.RERUN, the
+// text is parsed when it runs, so aliases expand as they are defined then.
+func (e *Engine) parseProcedure(registry *mdwregistry.Registry, proc *mdwregistry.Procedure) (*mdwast.Script, error) {
+	parser, err := mdwparser.New(mdwparser.Options{Logger: e.logger, EnableChaining: true, Registry: registry})
+	if err != nil {
+		return nil, err
+	}
+	script, err := parser.ParseScript(proc.Body)
+	if err != nil {
+		return nil, fmt.Errorf("procedure %s: %w", proc.Name, err)
+	}
+	return script, nil
+}
+
+// procedureRegistry returns the registry that stores the procedures
+func (e *Engine) procedureRegistry() (*mdwregistry.Registry, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if e.registry == nil {
+		return nil, fmt.Errorf("registry not available for procedure operations")
+	}
+	return e.registry, nil
+}
+
+// executeProcCommand executes PROC commands
+func (e *Engine) executeProcCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	value, hasName := cmd.Parameters["name"]
+	name := fmt.Sprint(value.Value)
+	if !hasName && cmd.Method != "LIST" {
+		return nil, fmt.Errorf("PROC.%s requires 'name' parameter", cmd.Method)
+	}
+
+	switch cmd.Method {
+	case "DEFINE":
+		body, hasBody := cmd.Parameters["body"]
+		if !hasBody {
+			return nil, fmt.Errorf("PROC.DEFINE requires 'body' parameter")
+		}
+		proc := mdwregistry.Procedure{
+			Name:      name,
+			Body:      fmt.Sprint(body.Value),
+			CreatedBy: execCtx.UserID,
+		}
+		if value, exists := cmd.Parameters["description"]; exists {
+			proc.Description = fmt.Sprint(value.Value)
+		}
+		if value, exists := cmd.Parameters["params"]; exists {
+			for _, param := range strings.Split(fmt.Sprint(value.Value), ",") {
+				if param = strings.TrimSpace(param); param != "" {
+					proc.Parameters = append(proc.Parameters, param)
+				}
+			}
+		}
+		replace := false
+		if value, exists := cmd.Parameters["replace"]; exists {
+			replace, _ = value.Value.(bool)
+		}
+
+		if err := e.DefineProcedure(proc, replace); err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        fmt.Sprintf("Procedure '%s' defined successfully", name),
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "RUN":
+		args := make(map[string]interface{}, len(cmd.Parameters))
+		for param, value := range cmd.Parameters {
+			if param != "name" {
+				args[param] = value.Value
+			}
+		}
+		return e.RunProcedure(ctx, name, args, execCtx)
+
+	case "SHOW":
+		registry, err := e.procedureRegistry()
+		if err != nil {
+			return nil, err
+		}
+		proc, err := registry.GetProcedure(name)
+		if err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			Success: true,
+			Data: map[string]interface{}{
+				"name":        proc.Name,
+				"description": proc.Description,
+				"parameters":  strings.Join(proc.Parameters, ", "),
+				"body":        proc.Body,
+				"created_by":  proc.CreatedBy,
+				"created_at":  proc.CreatedAt,
+			},
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "LIST":
+		registry, err := e.procedureRegistry()
+		if err != nil {
+			return nil, err
+		}
+		procedures := registry.GetProcedures()
+		data := make([]interface{}, len(procedures))
+		for i, proc := range procedures {
+			data[i] = map[string]interface{}{
+				"name":        proc.Name,
+				"description": proc.Description,
+				"parameters":  strings.Join(proc.Parameters, ", "),
+			}
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        data,
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "DELETE":
+		registry, err := e.procedureRegistry()
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.DeleteProcedure(name); err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        fmt.Sprintf("Procedure '%s' deleted successfully", name),
+			CommandType: "BUILTIN",
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown PROC method: %s", cmd.Method)
+	}
+}
+
+// sortedArgs returns the names of procedure arguments in sorted order, so
+// errors about them are deterministic
+func sortedArgs(args map[string]interface{}) []string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,167 @@
+// File: procedures_test.go
+// Title: TCOL Stored Procedure Execution Tests
+// Description: Tests defining and running stored procedures, the binding of
+//              their arguments, nesting limits, and the built-in PROC object.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial stored procedure tests
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+func TestEngine_RunProcedure(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	execCtx := createTestContext()
+
+	err := engine.DefineProcedure(mdwregistry.Procedure{
+		Name:       "onboard",
+		Parameters: []string{"customer", "amount"},
+		Body: `LET c = CUSTOMER.CREATE name=$customer
+INVOICE.CREATE customer=$c.id amount=$amount`,
+	}, false)
+	if err != nil {
+		t.Fatalf("DefineProcedure() error = %v", err)
+	}
+
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{
+		Success: true,
+		Data:    map[string]interface{}{"id": "C-1"},
+	})
+	result, err := engine.RunProcedure(context.Background(), "ONBOARD",
+		map[string]interface{}{"customer": "Acme", "amount": int64(250)}, execCtx)
+	if err != nil {
+		t.Fatalf("RunProcedure() error = %v", err)
+	}
+	if result.CommandType != "PROCEDURE" || result.Metadata["statements"] != 2 {
+		t.Errorf("result = %+v", result)
+	}
+
+	calls := client.GetCallHistory()
+	if len(calls) != 2 || calls[0].Params["name"] != "Acme" ||
+		calls[1].Params["customer"] != "C-1" || calls[1].Params["amount"] != int64(250) {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	// The body runs in its own scope
+	if execCtx.Variables != nil {
+		if _, defined := execCtx.Variables.Lookup("c"); defined {
+			t.Error("procedure variable leaked into the caller scope")
+		}
+	}
+
+	errorTests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{"onboard", map[string]interface{}{"customer": "Acme"}, "requires parameter amount"},
+		{"onboard", map[string]interface{}{"customer": "Acme", "amount": 1, "extra": 2}, "has no parameter extra"},
+		{"missing", nil, "procedure not found"},
+	}
+	for _, tt := range errorTests {
+		if _, err := engine.RunProcedure(context.Background(), tt.name, tt.args, execCtx); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("RunProcedure(%s, %v) error = %v, want %q", tt.name, tt.args, err, tt.expected)
+		}
+	}
+	if _, err := engine.RunProcedure(context.Background(), "missing", nil, execCtx); !errors.Is(err, mdwregistry.ErrProcedureNotFound) {
+		t.Errorf("RunProcedure(missing) error = %v, want ErrProcedureNotFound", err)
+	}
+}
+
+func TestEngine_DefineProcedure(t *testing.T) {
+	engine, _ := newScriptEngine(t)
+
+	invalid := []struct {
+		proc     mdwregistry.Procedure
+		expected string
+	}{
+		{mdwregistry.Procedure{Name: "p", Body: "CUSTOMER.LIST ("}, "procedure p"},
+		{mdwregistry.Procedure{Name: "p", Body: "CUSTOMER.LIST", Parameters: []string{LastIDVariable}}, "reserved variable"},
+		{mdwregistry.Procedure{Name: "p q", Body: "CUSTOMER.LIST"}, "invalid procedure name"},
+	}
+	for _, tt := range invalid {
+		if err := engine.DefineProcedure(tt.proc, false); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("DefineProcedure(%+v) error = %v, want %q", tt.proc, err, tt.expected)
+		}
+	}
+
+	// Recursive procedures stop at the maximum nesting depth
+	if err := engine.DefineProcedure(mdwregistry.Procedure{Name: "loop", Body: `PROC.RUN name="loop"`}, false); err != nil {
+		t.Fatalf("DefineProcedure() error = %v", err)
+	}
+	_, err := engine.RunProcedure(context.Background(), "loop", nil, createTestContext())
+	if err == nil || !strings.Contains(err.Error(), "maximum nesting depth") {
+		t.Errorf("recursive procedure error = %v", err)
+	}
+}
+
+func TestEngine_ProcBuiltins(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	execCtx := createTestContext()
+
+	define := "PROC.DEFINE name=\"monthly-close\" params=\"month, owner\" description=\"Close a month\" body=<<'EOF'\n" +
+		"INVOICE.SEND month=$month owner=$owner\nEOF\n"
+	if _, err := enter(t, engine, define, execCtx); err != nil {
+		t.Fatalf("PROC.DEFINE error = %v", err)
+	}
+	if _, err := enter(t, engine, define, execCtx); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second PROC.DEFINE error = %v", err)
+	}
+
+	result, err := enter(t, engine, `PROC.RUN name="monthly-close" month="2026-09" owner=finance`, execCtx)
+	if err != nil {
+		t.Fatalf("PROC.RUN error = %v", err)
+	}
+	calls := client.GetCallHistory()
+	if last := calls[len(calls)-1]; last.MethodName != "SEND" || last.Params["month"] != "2026-09" || last.Params["owner"] != "finance" {
+		t.Errorf("procedure call = %+v", last)
+	}
+	if result.Metadata["procedure"] != "monthly-close" {
+		t.Errorf("PROC.RUN metadata = %v", result.Metadata)
+	}
+
+	result, err = enter(t, engine, `PROC.SHOW name="monthly-close"`, execCtx)
+	if err != nil {
+		t.Fatalf("PROC.SHOW error = %v", err)
+	}
+	shown := result.Data.(map[string]interface{})
+	if shown["parameters"] != "month, owner" || shown["created_by"] != "test-user" || !strings.Contains(shown["body"].(string), "$month") {
+		t.Errorf("PROC.SHOW data = %v", shown)
+	}
+
+	result, err = enter(t, engine, `PROC.LIST`, execCtx)
+	if rows, ok := result.Data.([]interface{}); err != nil || !ok || len(rows) != 1 {
+		t.Errorf("PROC.LIST = %v, %v", result.Data, err)
+	}
+
+	if _, err := enter(t, engine, `PROC.DELETE name="monthly-close"`, execCtx); err != nil {
+		t.Fatalf("PROC.DELETE error = %v", err)
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`PROC.RUN name="monthly-close"`, "procedure not found"},
+		{`PROC.DEFINE name="p"`, "requires 'body' parameter"},
+		{`PROC.SHOW`, "requires 'name' parameter"},
+		{`PROC.DELETE name="monthly-close"`, "procedure not found"},
+	}
+	for _, tt := range errorTests {
+		if _, err := enter(t, engine, tt.input, execCtx); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s error = %v, want %q", tt.input, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,714 @@
+// File: schedule.go
+// Title: TCOL Command Scheduling
+// Description: Implements scheduled execution of TCOL commands by cron
+//              expression and the built-in SCHEDULE object. Schedules and
+//              the history of their runs are kept in a ScheduleStore, in
+//              memory or as JSON files; a background scheduler runs the
+//              commands that are due.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of scheduled commands
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+	mdwtimex "github.com/msto63/mDW/foundation/utils/timex"
+)
+
+// Scheduler defaults
+const (
+	DefaultScheduleInterval = 30 * time.Second
+	DefaultScheduleRuns     = 20
+)
+
+// ErrScheduleNotFound is returned by schedule stores for unknown schedule IDs
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Schedule is a command that the scheduler executes whenever its cron
+// expression activates. Runs missed while no scheduler was running are
+// made up by a single run.
+type Schedule struct {
+	ID        string        `json:"id"`
+	Command   string        `json:"command"`
+	Cron      string        `json:"cron"`
+	Timezone  string        `json:"timezone,omitempty"` // Location of the cron expression; local time if empty
+	UserID    string        `json:"user_id,omitempty"`
+	Paused    bool          `json:"paused"`
+	CreatedAt time.Time     `json:"created_at"`
+	NextRun   time.Time     `json:"next_run"`
+	Runs      []ScheduleRun `json:"runs,omitempty"` // Latest runs, oldest first
+}
+
+// ScheduleRun records one execution of a scheduled command
+type ScheduleRun struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Status     JobStatus `json:"status"` // COMPLETED or FAILED
+	Error      string    `json:"error,omitempty"`
+}
+
+// ScheduleStore persists schedules. Implementations must be safe for
+// concurrent use.
+type ScheduleStore interface {
+	Save(schedule *Schedule) error
+	Get(id string) (*Schedule, error)
+	Delete(id string) error
+	List() ([]*Schedule, error)
+}
+
+// MemoryScheduleStore keeps schedules in memory
+type MemoryScheduleStore struct {
+	schedules map[string]*Schedule
+	mutex     sync.RWMutex
+}
+
+// NewMemoryScheduleStore creates an empty in-memory schedule store
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{schedules: make(map[string]*Schedule)}
+}
+
+// Save stores a copy of the schedule
+func (s *MemoryScheduleStore) Save(schedule *Schedule) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.schedules[schedule.ID] = copySchedule(schedule)
+	return nil
+}
+
+// Get returns a copy of the schedule with the given ID
+func (s *MemoryScheduleStore) Get(id string) (*Schedule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	schedule, exists := s.schedules[id]
+	if !exists {
+		return nil, ErrScheduleNotFound
+	}
+	return copySchedule(schedule), nil
+}
+
+// Delete removes the schedule with the given ID
+func (s *MemoryScheduleStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.schedules[id]; !exists {
+		return ErrScheduleNotFound
+	}
+	delete(s.schedules, id)
+	return nil
+}
+
+// List returns copies of all schedules ordered by creation time
+func (s *MemoryScheduleStore) List() ([]*Schedule, error) {
+	s.mutex.RLock()
+	schedules := make([]*Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, copySchedule(schedule))
+	}
+	s.mutex.RUnlock()
+
+	sortSchedules(schedules)
+	return schedules, nil
+}
+
+// copySchedule copies a schedule including its runs
+func copySchedule(schedule *Schedule) *Schedule {
+	copied := *schedule
+	copied.Runs = append([]ScheduleRun(nil), schedule.Runs...)
+	return &copied
+}
+
+// FileScheduleStore keeps each schedule as a JSON file in a directory, so
+// schedules survive restarts of the process
+type FileScheduleStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileScheduleStore creates a schedule store in dir, creating the
+// directory if needed
+func NewFileScheduleStore(dir string) (*FileScheduleStore, error) {
+	if mdwstringx.IsBlank(dir) {
+		return nil, fmt.Errorf("schedule store directory is required")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create schedule store directory: %w", err)
+	}
+	return &FileScheduleStore{dir: dir}, nil
+}
+
+// Save writes the schedule atomically to its file
+func (s *FileScheduleStore) Save(schedule *Schedule) error {
+	path, err := s.path(schedule.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(schedule, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schedule %s: %w", schedule.ID, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return mdwfilex.WriteFileAtomic(path, data, 0600)
+}
+
+// Get reads the schedule with the given ID
+func (s *FileScheduleStore) Get(id string) (*Schedule, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	data, err := os.ReadFile(path)
+	s.mutex.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule %s: %w", id, err)
+	}
+
+	var schedule Schedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule %s: %w", id, err)
+	}
+	return &schedule, nil
+}
+
+// Delete removes the file of the schedule with the given ID
+func (s *FileScheduleStore) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return ErrScheduleNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to delete schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+// List reads all schedules in the directory ordered by creation time
+func (s *FileScheduleStore) List() ([]*Schedule, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*Schedule, 0, len(matches))
+	for _, match := range matches {
+		schedule, err := s.Get(strings.TrimSuffix(filepath.Base(match), ".json"))
+		if errors.Is(err, ErrScheduleNotFound) {
+			continue // Deleted concurrently
+		}
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	sortSchedules(schedules)
+	return schedules, nil
+}
+
+// path returns the file of a schedule, rejecting IDs that would leave the
+// directory
+func (s *FileScheduleStore) path(id string) (string, error) {
+	if mdwstringx.IsBlank(id) || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid schedule ID %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// sortSchedules orders schedules by creation time, then ID
+func sortSchedules(schedules []*Schedule) {
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].CreatedAt.Equal(schedules[j].CreatedAt) {
+			return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+}
+
+// scheduler tracks the background scheduler of an engine
+type scheduler struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// CreateSchedule schedules a command for execution on behalf of the user of
+// execCtx whenever cron activates, in timezone or local time if it is
+// empty. The command is parsed again for every run, so aliases expand as
+// they are defined then.
+func (e *Engine) CreateSchedule(command, cron, timezone string, execCtx *ExecutionContext) (*Schedule, error) {
+	execCtx = withScope(execCtx)
+	if mdwstringx.IsBlank(command) {
+		return nil, fmt.Errorf("scheduled command cannot be empty")
+	}
+	if _, err := e.parseScheduled(command); err != nil {
+		return nil, err
+	}
+	expr, loc, err := scheduleTiming(cron, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := mdwstringx.RandomHex(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schedule ID: %w", err)
+	}
+	now := time.Now()
+	schedule := &Schedule{
+		ID:        "sched-" + suffix,
+		Command:   command,
+		Cron:      expr.String(),
+		Timezone:  timezone,
+		UserID:    execCtx.UserID,
+		CreatedAt: now,
+		NextRun:   expr.Next(now.In(loc)),
+	}
+	if schedule.NextRun.IsZero() {
+		return nil, fmt.Errorf("cron expression %q never activates", cron)
+	}
+	if err := e.schedules.Save(schedule); err != nil {
+		return nil, fmt.Errorf("failed to store schedule: %w", err)
+	}
+
+	e.logger.Info("TCOL command scheduled", mdwlog.Fields{
+		"scheduleID": schedule.ID,
+		"userID":     schedule.UserID,
+		"cron":       schedule.Cron,
+		"nextRun":    schedule.NextRun,
+	})
+
+	return schedule, nil
+}
+
+// GetSchedule returns the schedule with the given ID
+func (e *Engine) GetSchedule(id string) (*Schedule, error) {
+	return e.schedules.Get(id)
+}
+
+// ListSchedules returns the schedules of a user ordered by creation time
+func (e *Engine) ListSchedules(userID string) ([]*Schedule, error) {
+	schedules, err := e.schedules.List()
+	if err != nil {
+		return nil, err
+	}
+
+	owned := schedules[:0]
+	for _, schedule := range schedules {
+		if schedule.UserID == userID {
+			owned = append(owned, schedule)
+		}
+	}
+	return owned, nil
+}
+
+// PauseSchedule stops running a schedule until it is resumed
+func (e *Engine) PauseSchedule(id string) error {
+	return e.updateSchedule(id, func(schedule *Schedule) error {
+		schedule.Paused = true
+		return nil
+	})
+}
+
+// ResumeSchedule runs a paused schedule again from its next activation;
+// activations while it was paused are not made up
+func (e *Engine) ResumeSchedule(id string) error {
+	return e.updateSchedule(id, func(schedule *Schedule) error {
+		expr, loc, err := scheduleTiming(schedule.Cron, schedule.Timezone)
+		if err != nil {
+			return err
+		}
+		schedule.Paused = false
+		schedule.NextRun = expr.Next(time.Now().In(loc))
+		return nil
+	})
+}
+
+// DeleteSchedule removes a schedule. A run in progress completes but is
+// not recorded.
+func (e *Engine) DeleteSchedule(id string) error {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+	return e.schedules.Delete(id)
+}
+
+// updateSchedule applies change to a stored schedule and saves it
+func (e *Engine) updateSchedule(id string, change func(schedule *Schedule) error) error {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	schedule, err := e.schedules.Get(id)
+	if err != nil {
+		return err
+	}
+	if err := change(schedule); err != nil {
+		return err
+	}
+	return e.schedules.Save(schedule)
+}
+
+// StartScheduler starts running due schedules in the background every
+// Options.ScheduleInterval until StopScheduler or Close is called
+func (e *Engine) StartScheduler() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.scheduler != nil {
+		return fmt.Errorf("scheduler is already running")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.scheduler = &scheduler{cancel: cancel, done: make(chan struct{})}
+	go e.runScheduler(ctx, e.scheduler)
+
+	e.logger.Info("TCOL scheduler started", mdwlog.Fields{
+		"interval": e.options.ScheduleInterval,
+	})
+	return nil
+}
+
+// StopScheduler stops the background scheduler and waits for the commands
+// it is running. Running commands are cancelled.
+func (e *Engine) StopScheduler() {
+	e.mutex.Lock()
+	running := e.scheduler
+	e.scheduler = nil
+	e.mutex.Unlock()
+
+	if running != nil {
+		running.cancel()
+		<-running.done
+	}
+}
+
+// runScheduler runs due schedules on every tick
+func (e *Engine) runScheduler(ctx context.Context, running *scheduler) {
+	defer close(running.done)
+
+	ticker := time.NewTicker(e.options.ScheduleInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			// A slow command does not hold up the next tick; schedules that
+			// are still running are skipped by RunDueSchedules
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				e.RunDueSchedules(ctx, now)
+			}()
+		}
+	}
+}
+
+// RunDueSchedules runs every active schedule whose next run is at or before
+// now, concurrently, and waits for them. Schedules still running from an
+// earlier call are skipped. It returns the number of schedules run.
+func (e *Engine) RunDueSchedules(ctx context.Context, now time.Time) int {
+	schedules, err := e.schedules.List()
+	if err != nil {
+		e.logger.Error("Failed to list TCOL schedules", mdwlog.Fields{
+			"error": err.Error(),
+		})
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	started := 0
+	for _, schedule := range schedules {
+		if schedule.Paused || schedule.NextRun.After(now) || !e.claimSchedule(schedule.ID) {
+			continue
+		}
+		started++
+		wg.Add(1)
+		go func(schedule *Schedule) {
+			defer wg.Done()
+			defer e.releaseSchedule(schedule.ID)
+			e.runSchedule(ctx, schedule.ID, now)
+		}(schedule)
+	}
+	wg.Wait()
+	return started
+}
+
+// claimSchedule marks a schedule as running unless it already is
+func (e *Engine) claimSchedule(id string) bool {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	if e.scheduling[id] {
+		return false
+	}
+	e.scheduling[id] = true
+	return true
+}
+
+// releaseSchedule marks a schedule as no longer running
+func (e *Engine) releaseSchedule(id string) {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+	delete(e.scheduling, id)
+}
+
+// runSchedule executes a due schedule and records the run. The next run is
+// stored before the command executes, so a crash does not repeat it.
+func (e *Engine) runSchedule(ctx context.Context, id string, now time.Time) {
+	var schedule *Schedule
+	err := e.updateSchedule(id, func(stored *Schedule) error {
+		if stored.Paused || stored.NextRun.After(now) {
+			return errScheduleNotDue
+		}
+		expr, loc, err := scheduleTiming(stored.Cron, stored.Timezone)
+		if err != nil {
+			return err
+		}
+		stored.NextRun = expr.Next(now.In(loc))
+		schedule = copySchedule(stored)
+		return nil
+	})
+	if errors.Is(err, errScheduleNotDue) || errors.Is(err, ErrScheduleNotFound) {
+		return // Paused, resumed, or deleted concurrently
+	}
+
+	run := ScheduleRun{StartedAt: time.Now(), Status: JobCompleted}
+	if err == nil {
+		err = e.executeScheduled(ctx, schedule, now)
+	}
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Status = JobFailed
+		run.Error = err.Error()
+	}
+
+	err = e.updateSchedule(id, func(stored *Schedule) error {
+		stored.Runs = append(stored.Runs, run)
+		if excess := len(stored.Runs) - e.options.MaxScheduleRuns; excess > 0 {
+			stored.Runs = stored.Runs[excess:]
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrScheduleNotFound) {
+		e.logger.Error("Failed to record TCOL schedule run", mdwlog.Fields{
+			"scheduleID": id,
+			"error":      err.Error(),
+		})
+	}
+
+	e.logger.Info("TCOL scheduled command finished", mdwlog.Fields{
+		"scheduleID": id,
+		"status":     string(run.Status),
+		"duration":   run.FinishedAt.Sub(run.StartedAt),
+	})
+}
+
+// errScheduleNotDue stops a run of a schedule that changed since it was listed
+var errScheduleNotDue = errors.New("schedule is not due")
+
+// executeScheduled executes the command of a schedule on behalf of its user
+func (e *Engine) executeScheduled(ctx context.Context, schedule *Schedule, now time.Time) error {
+	cmd, err := e.parseScheduled(schedule.Command)
+	if err != nil {
+		return err
+	}
+
+	execCtx := &ExecutionContext{
+		RequestID: fmt.Sprintf("%s-%d", schedule.ID, now.Unix()),
+		UserID:    schedule.UserID,
+		Timestamp: now,
+		Metadata:  map[string]interface{}{"schedule_id": schedule.ID},
+		Input:     schedule.Command,
+	}
+	result, err := e.Execute(ctx, cmd, execCtx)
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return result.Error
+		}
+		return fmt.Errorf("command was not successful")
+	}
+	return nil
+}
+
+// parseScheduled parses the command of a schedule
+func (e *Engine) parseScheduled(command string) (*mdwast.Command, error) {
+	e.mutex.RLock()
+	registry := e.registry
+	e.mutex.RUnlock()
+
+	parser, err := mdwparser.New(mdwparser.Options{Logger: e.logger, EnableChaining: true, Registry: registry})
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := parser.Parse(command)
+	if err != nil {
+		return nil, fmt.Errorf("scheduled command: %w", err)
+	}
+	return cmd, nil
+}
+
+// scheduleTiming parses the cron expression and timezone of a schedule
+func scheduleTiming(cron, timezone string) (*mdwtimex.CronSchedule, *time.Location, error) {
+	expr, err := mdwtimex.ParseCron(cron)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc := time.Local
+	if timezone != "" {
+		if loc, err = mdwtimex.LoadLocation(timezone); err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+	return expr, loc, nil
+}
+
+// executeScheduleCommand executes SCHEDULE commands. Users only see their
+// own schedules.
+func (e *Engine) executeScheduleCommand(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	switch cmd.Method {
+	case "CREATE":
+		command, hasCommand := cmd.Parameters["command"]
+		cron, hasCron := cmd.Parameters["cron"]
+		if !hasCommand || !hasCron {
+			return nil, fmt.Errorf("SCHEDULE.CREATE requires 'command' and 'cron' parameters")
+		}
+		timezone := ""
+		if value, exists := cmd.Parameters["timezone"]; exists {
+			timezone = fmt.Sprint(value.Value)
+		}
+
+		schedule, err := e.CreateSchedule(fmt.Sprint(command.Value), fmt.Sprint(cron.Value), timezone, execCtx)
+		if err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        scheduleData(schedule),
+			CommandType: "BUILTIN",
+		}, nil
+
+	case "LIST":
+		schedules, err := e.ListSchedules(execCtx.UserID)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]interface{}, len(schedules))
+		for i, schedule := range schedules {
+			data[i] = scheduleData(schedule)
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        data,
+			CommandType: "BUILTIN",
+		}, nil
+	}
+
+	idParam, hasID := cmd.Parameters["id"]
+	if !hasID {
+		return nil, fmt.Errorf("SCHEDULE.%s requires 'id' parameter", cmd.Method)
+	}
+	id := fmt.Sprint(idParam.Value)
+
+	schedule, err := e.schedules.Get(id)
+	if err == nil && schedule.UserID != execCtx.UserID {
+		err = ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", id, err)
+	}
+
+	var message string
+	switch cmd.Method {
+	case "PAUSE":
+		message = "paused"
+		err = e.PauseSchedule(id)
+	case "RESUME":
+		message = "resumed"
+		err = e.ResumeSchedule(id)
+	case "DELETE":
+		message = "deleted"
+		err = e.DeleteSchedule(id)
+	case "HISTORY":
+		data := make([]interface{}, len(schedule.Runs))
+		for i, run := range schedule.Runs {
+			data[i] = map[string]interface{}{
+				"started_at":  run.StartedAt,
+				"finished_at": run.FinishedAt,
+				"status":      string(run.Status),
+				"error":       run.Error,
+			}
+		}
+		return &ExecutionResult{
+			Success:     true,
+			Data:        data,
+			CommandType: "BUILTIN",
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown SCHEDULE method: %s", cmd.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", id, err)
+	}
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        fmt.Sprintf("Schedule '%s' %s", id, message),
+		CommandType: "BUILTIN",
+	}, nil
+}
+
+// scheduleData returns the fields of a schedule shown by SCHEDULE.LIST
+func scheduleData(schedule *Schedule) map[string]interface{} {
+	status, lastRun := "ACTIVE", ""
+	if schedule.Paused {
+		status = "PAUSED"
+	}
+	if len(schedule.Runs) > 0 {
+		lastRun = string(schedule.Runs[len(schedule.Runs)-1].Status)
+	}
+	return map[string]interface{}{
+		"id":       schedule.ID,
+		"command":  schedule.Command,
+		"cron":     schedule.Cron,
+		"timezone": schedule.Timezone,
+		"status":   status,
+		"next_run": schedule.NextRun,
+		"last_run": lastRun,
+	}
+}
@@ -0,0 +1,293 @@
+// File: schedule_test.go
+// Title: TCOL Command Scheduling Tests
+// Description: Tests the memory and file schedule stores, running due
+//              schedules, the run history, the background scheduler, and
+//              the built-in SCHEDULE object.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial scheduling tests
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// scheduleCommand builds a SCHEDULE command with string parameters
+func scheduleCommand(method string, params map[string]string) *mdwast.Command {
+	cmd := &mdwast.Command{Object: "SCHEDULE", Method: method, Parameters: map[string]mdwast.Value{}}
+	for name, value := range params {
+		cmd.Parameters[name] = mdwast.Value{Type: mdwast.ValueTypeString, Raw: value, Value: value}
+	}
+	return cmd
+}
+
+// newScheduleEngine creates an engine with the test registry
+func newScheduleEngine(t *testing.T, opts Options) (*Engine, *MockServiceClient) {
+	client := NewMockServiceClient()
+	opts.ServiceClient = client
+	engine, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+	return engine, client
+}
+
+// makeDue moves the next run of a schedule into the past
+func makeDue(t *testing.T, engine *Engine, id string) time.Time {
+	due := time.Now().Add(-time.Minute)
+	if err := engine.updateSchedule(id, func(schedule *Schedule) error {
+		schedule.NextRun = due
+		return nil
+	}); err != nil {
+		t.Fatalf("updateSchedule() error = %v", err)
+	}
+	return due
+}
+
+func testScheduleStore(t *testing.T, store ScheduleStore) {
+	created := time.Now().Truncate(time.Second)
+	first := &Schedule{ID: "sched-b", Command: "CUSTOMER.LIST", Cron: "@daily", CreatedAt: created}
+	second := &Schedule{ID: "sched-a", Command: "INVOICE.LIST", Cron: "@hourly", CreatedAt: created.Add(time.Second)}
+	for _, schedule := range []*Schedule{first, second} {
+		if err := store.Save(schedule); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	first.Paused = true
+	first.Runs = []ScheduleRun{{Status: JobFailed, Error: "service down"}}
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Get("sched-b")
+	if err != nil || !got.Paused || len(got.Runs) != 1 || got.Runs[0].Error != "service down" {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+
+	schedules, err := store.List()
+	if err != nil || len(schedules) != 2 || schedules[0].ID != "sched-b" || schedules[1].ID != "sched-a" {
+		t.Errorf("List() = %v, %v", schedules, err)
+	}
+
+	if err := store.Delete("sched-b"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("sched-b"); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("Get() after Delete() error = %v", err)
+	}
+	if err := store.Delete("sched-b"); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestMemoryScheduleStore(t *testing.T) {
+	testScheduleStore(t, NewMemoryScheduleStore())
+}
+
+func TestFileScheduleStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileScheduleStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileScheduleStore() error = %v", err)
+	}
+	testScheduleStore(t, store)
+
+	// Schedules survive a new store on the same directory
+	store.Save(&Schedule{ID: "sched-c", Cron: "0 6 * * 1"})
+	reopened, _ := NewFileScheduleStore(dir)
+	if schedule, err := reopened.Get("sched-c"); err != nil || schedule.Cron != "0 6 * * 1" {
+		t.Errorf("Get() from reopened store = %v, %v", schedule, err)
+	}
+
+	for _, id := range []string{"", "../sched", "a/b", ".hidden"} {
+		if _, err := store.Get(id); err == nil || errors.Is(err, ErrScheduleNotFound) {
+			t.Errorf("Get(%q) error = %v, want invalid ID", id, err)
+		}
+	}
+	if _, err := NewFileScheduleStore(" "); err == nil {
+		t.Error("NewFileScheduleStore() without directory did not fail")
+	}
+}
+
+func TestEngine_CreateSchedule(t *testing.T) {
+	engine, _ := newScheduleEngine(t, Options{})
+	execCtx := createTestContext()
+
+	before := time.Now()
+	schedule, err := engine.CreateSchedule("CUSTOMER.LIST", "0 6 * * 1", "UTC", execCtx)
+	if err != nil {
+		t.Fatalf("CreateSchedule() error = %v", err)
+	}
+	if !strings.HasPrefix(schedule.ID, "sched-") || schedule.UserID != execCtx.UserID {
+		t.Errorf("schedule = %+v", schedule)
+	}
+	next := schedule.NextRun.In(time.UTC)
+	if !next.After(before) || next.Weekday() != time.Monday || next.Hour() != 6 || next.Minute() != 0 {
+		t.Errorf("NextRun = %v", schedule.NextRun)
+	}
+
+	for _, tc := range []struct{ command, cron, timezone string }{
+		{"", "@daily", ""},
+		{"CUSTOMER.", "@daily", ""},
+		{"CUSTOMER.LIST", "0 6 * *", ""},
+		{"CUSTOMER.LIST", "0 0 30 2 *", ""},
+		{"CUSTOMER.LIST", "@daily", "Mars/Olympus"},
+	} {
+		if _, err := engine.CreateSchedule(tc.command, tc.cron, tc.timezone, execCtx); err == nil {
+			t.Errorf("CreateSchedule(%q, %q, %q) did not fail", tc.command, tc.cron, tc.timezone)
+		}
+	}
+}
+
+func TestEngine_RunDueSchedules(t *testing.T) {
+	engine, client := newScheduleEngine(t, Options{MaxScheduleRuns: 2})
+	execCtx := createTestContext()
+
+	schedule, _ := engine.CreateSchedule("CUSTOMER.LIST", "@hourly", "", execCtx)
+	paused, _ := engine.CreateSchedule("CUSTOMER.DELETE", "@hourly", "", execCtx)
+	engine.PauseSchedule(paused.ID)
+	makeDue(t, engine, paused.ID)
+
+	// Nothing is due before the next run
+	if n := engine.RunDueSchedules(context.Background(), time.Now()); n != 0 {
+		t.Errorf("RunDueSchedules() before due = %d", n)
+	}
+
+	makeDue(t, engine, schedule.ID)
+	now := time.Now()
+	if n := engine.RunDueSchedules(context.Background(), now); n != 1 {
+		t.Fatalf("RunDueSchedules() = %d, want 1", n)
+	}
+	calls := client.GetCallHistory()
+	if len(calls) != 1 || calls[0].MethodName != "LIST" || calls[0].Context.UserID != execCtx.UserID ||
+		calls[0].Context.Metadata["schedule_id"] != schedule.ID {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	stored, _ := engine.GetSchedule(schedule.ID)
+	if !stored.NextRun.After(now) || len(stored.Runs) != 1 || stored.Runs[0].Status != JobCompleted {
+		t.Errorf("schedule after run = %+v", stored)
+	}
+
+	// Failed runs are recorded and the history is bounded
+	client.SetError("customer-service", "CUSTOMER", "LIST", errors.New("service down"))
+	for i := 0; i < 2; i++ {
+		makeDue(t, engine, schedule.ID)
+		engine.RunDueSchedules(context.Background(), time.Now())
+	}
+	stored, _ = engine.GetSchedule(schedule.ID)
+	if len(stored.Runs) != 2 || stored.Runs[1].Status != JobFailed ||
+		!strings.Contains(stored.Runs[1].Error, "service down") {
+		t.Errorf("runs = %+v", stored.Runs)
+	}
+
+	// Resuming skips the activations missed while paused
+	if err := engine.ResumeSchedule(paused.ID); err != nil {
+		t.Fatalf("ResumeSchedule() error = %v", err)
+	}
+	if n := engine.RunDueSchedules(context.Background(), time.Now()); n != 0 {
+		t.Errorf("RunDueSchedules() after resume = %d", n)
+	}
+}
+
+func TestEngine_StartScheduler(t *testing.T) {
+	engine, client := newScheduleEngine(t, Options{ScheduleInterval: 5 * time.Millisecond})
+	schedule, _ := engine.CreateSchedule("CUSTOMER.LIST", "@daily", "", createTestContext())
+	makeDue(t, engine, schedule.ID)
+
+	if err := engine.StartScheduler(); err != nil {
+		t.Fatalf("StartScheduler() error = %v", err)
+	}
+	if err := engine.StartScheduler(); err == nil {
+		t.Error("second StartScheduler() did not fail")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stored, _ := engine.GetSchedule(schedule.ID)
+		if len(stored.Runs) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("scheduler did not run the due schedule")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	engine.Close()
+	if calls := client.GetCallHistory(); len(calls) != 1 {
+		t.Errorf("calls = %d, want 1", len(calls))
+	}
+}
+
+func TestEngine_ScheduleCommands(t *testing.T) {
+	engine, _ := newScheduleEngine(t, Options{})
+	execCtx := createTestContext()
+	ctx := context.Background()
+
+	created, err := engine.Execute(ctx, scheduleCommand("CREATE", map[string]string{
+		"command": `CUSTOMER.LIST`,
+		"cron":    "0 6 * * 1",
+	}), execCtx)
+	if err != nil {
+		t.Fatalf("SCHEDULE.CREATE error = %v", err)
+	}
+	id := created.Data.(map[string]interface{})["id"].(string)
+
+	list, err := engine.Execute(ctx, scheduleCommand("LIST", nil), execCtx)
+	if err != nil || len(list.Data.([]interface{})) != 1 {
+		t.Fatalf("SCHEDULE.LIST = %v, %v", list, err)
+	}
+
+	if _, err := engine.Execute(ctx, scheduleCommand("PAUSE", map[string]string{"id": id}), execCtx); err != nil {
+		t.Fatalf("SCHEDULE.PAUSE error = %v", err)
+	}
+	list, _ = engine.Execute(ctx, scheduleCommand("LIST", nil), execCtx)
+	if status := list.Data.([]interface{})[0].(map[string]interface{})["status"]; status != "PAUSED" {
+		t.Errorf("status after pause = %v", status)
+	}
+	if _, err := engine.Execute(ctx, scheduleCommand("RESUME", map[string]string{"id": id}), execCtx); err != nil {
+		t.Errorf("SCHEDULE.RESUME error = %v", err)
+	}
+	history, err := engine.Execute(ctx, scheduleCommand("HISTORY", map[string]string{"id": id}), execCtx)
+	if err != nil || len(history.Data.([]interface{})) != 0 {
+		t.Errorf("SCHEDULE.HISTORY = %v, %v", history, err)
+	}
+
+	// Schedules of other users are not visible
+	other := createTestContext()
+	other.UserID = "someone-else"
+	if _, err := engine.Execute(ctx, scheduleCommand("DELETE", map[string]string{"id": id}), other); err == nil ||
+		!strings.Contains(err.Error(), "schedule not found") {
+		t.Errorf("SCHEDULE.DELETE for another user error = %v", err)
+	}
+	if list, _ := engine.Execute(ctx, scheduleCommand("LIST", nil), other); len(list.Data.([]interface{})) != 0 {
+		t.Errorf("SCHEDULE.LIST for another user = %v", list.Data)
+	}
+
+	if _, err := engine.Execute(ctx, scheduleCommand("DELETE", map[string]string{"id": id}), execCtx); err != nil {
+		t.Errorf("SCHEDULE.DELETE error = %v", err)
+	}
+	if _, err := engine.GetSchedule(id); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("GetSchedule() after delete error = %v", err)
+	}
+
+	if _, err := engine.Execute(ctx, scheduleCommand("CREATE", map[string]string{"cron": "@daily"}), execCtx); err == nil {
+		t.Error("SCHEDULE.CREATE without command did not fail")
+	}
+	if _, err := engine.Execute(ctx, scheduleCommand("PAUSE", nil), execCtx); err == nil {
+		t.Error("SCHEDULE.PAUSE without id did not fail")
+	}
+}
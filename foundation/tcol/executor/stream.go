@@ -0,0 +1,278 @@
+// File: stream.go
+// Title: TCOL Result Pagination and Streaming
+// Description: Implements cursor-based pagination of command results with
+//              the page_size and next_token parameters, and streaming of
+//              result items to a callback so large results are never held
+//              in memory at once.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.4
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of pagination and streaming
+// - 2026-10-16 v0.1.1: Streams run through the middleware pipeline
+// - 2026-10-16 v0.1.2: Shared built-in object check
+// - 2026-10-16 v0.1.3: Warnings for deprecated commands
+// - 2026-10-16 v0.1.4: Service objects in place of EXPORT and IMPORT can be streamed
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// Paging parameters of commands and the reserved keys they are sent as
+const (
+	PageSizeParam    = "page_size"
+	NextTokenParam   = "next_token"
+	PageSizeKey      = "_page_size"
+	NextTokenKey     = "_next_token"
+	streamedItemsKey = "items"
+)
+
+// ErrStopStream may be returned by a StreamHandler to end a stream early
+// without an error
+var ErrStopStream = errors.New("stop stream")
+
+// StreamHandler receives the items of a streamed result one at a time
+type StreamHandler func(item interface{}) error
+
+// StreamingServiceClient is implemented by service clients that deliver
+// results incrementally, e.g. with gRPC server streaming. The returned
+// response carries status and metadata; its Data is not used.
+type StreamingServiceClient interface {
+	ServiceClient
+	ExecuteStream(ctx context.Context, serviceName, objectName, methodName string,
+		params map[string]interface{}, execCtx *ExecutionContext, handler StreamHandler) (*ServiceResponse, error)
+}
+
+// pageParams validates page_size and next_token and moves them to their
+// reserved keys
+func (e *Engine) pageParams(params map[string]interface{}) error {
+	if value, exists := params[PageSizeParam]; exists {
+		size, ok := toPageSize(value)
+		if !ok || size < 1 || size > e.options.MaxPageSize {
+			return fmt.Errorf("%s must be an integer between 1 and %d, got %v",
+				PageSizeParam, e.options.MaxPageSize, value)
+		}
+		delete(params, PageSizeParam)
+		params[PageSizeKey] = size
+	}
+
+	if value, exists := params[NextTokenParam]; exists {
+		token, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string, got %v", NextTokenParam, value)
+		}
+		delete(params, NextTokenParam)
+		if token != "" {
+			params[NextTokenKey] = token
+		}
+	}
+	return nil
+}
+
+// toPageSize converts a numeric parameter value to an int
+func toPageSize(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	default:
+		return 0, false
+	}
+}
+
+// ExecuteStream executes a method call and passes the items of its result
+// to handler as they arrive. Clients implementing StreamingServiceClient
+// stream natively; for other clients the result is fetched page by page
+// following next_token. The returned result holds the number of items in
+// Metadata["items"] and no data.
+func (e *Engine) ExecuteStream(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext, handler StreamHandler) (*ExecutionResult, error) {
+	if cmd == nil {
+		return nil, fmt.Errorf("command cannot be nil")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("stream handler cannot be nil")
+	}
+	if cmd.Method == "" || cmd.ObjectID != "" || cmd.Chain != nil {
+		return nil, fmt.Errorf("streaming requires a single OBJECT.METHOD command")
+	}
+	if e.isBuiltin(cmd.Object) {
+		return nil, fmt.Errorf("built-in command %s.%s cannot be streamed", cmd.Object, cmd.Method)
+	}
+	execCtx = withScope(execCtx)
+	startTime := time.Now()
+
+	if e.options.EnableAuditLog {
+		e.auditCommand(cmd, execCtx, "STARTED")
+	}
+
+	resolved, err := resolveCommand(cmd, execCtx.Variables)
+	var result *ExecutionResult
+	if err == nil {
+		final := func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+			return e.stream(ctx, cmd, execCtx, handler)
+		}
+		result, err = e.pipeline(resolved, final)(ctx, resolved, execCtx)
+	}
+	if err != nil {
+		if e.options.EnableAuditLog {
+			e.auditCommand(cmd, execCtx, "FAILED")
+		}
+		return nil, err
+	}
+	result.ExecutionTime = time.Since(startTime)
+
+	if e.options.EnableAuditLog {
+		e.auditCommand(cmd, execCtx, "COMPLETED")
+	}
+
+	e.logger.Debug("TCOL command streaming completed", mdwlog.Fields{
+		"requestID":     execCtx.RequestID,
+		"items":         result.Metadata[streamedItemsKey],
+		"executionTime": result.ExecutionTime,
+	})
+
+	return result, nil
+}
+
+// stream checks a resolved command and streams its result
+func (e *Engine) stream(ctx context.Context, resolved *mdwast.Command, execCtx *ExecutionContext, handler StreamHandler) (*ExecutionResult, error) {
+	serviceName, params, err := e.prepareMethodCall(ctx, resolved, execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count items and tell handler errors apart from service errors
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	items, stopped := 0, false
+	var handlerErr error
+	counted := func(item interface{}) error {
+		if err := handler(item); err != nil {
+			if errors.Is(err, ErrStopStream) {
+				stopped = true
+			} else {
+				handlerErr = err
+			}
+			cancel()
+			return err
+		}
+		items++
+		return nil
+	}
+
+	var response *ServiceResponse
+	if streaming, ok := e.client.(StreamingServiceClient); ok {
+		response, err = streaming.ExecuteStream(streamCtx, serviceName, resolved.Object, resolved.Method, params, execCtx, counted)
+	} else {
+		response, err = e.streamPages(streamCtx, serviceName, resolved, params, execCtx, counted)
+	}
+	switch {
+	case stopped:
+	case handlerErr != nil:
+		return nil, fmt.Errorf("stream of %s.%s aborted: %w", resolved.Object, resolved.Method, handlerErr)
+	case err != nil:
+		return nil, e.wrapServiceError(err, serviceName, resolved.Object, resolved.Method)
+	}
+
+	result := &ExecutionResult{
+		Success:     true,
+		ServiceName: serviceName,
+		CommandType: "STREAM",
+		Metadata:    map[string]interface{}{streamedItemsKey: items},
+		Warnings:    e.deprecationWarnings(resolved, execCtx),
+	}
+	if response != nil {
+		result.Success = response.Success
+		for key, value := range response.Metadata {
+			if key != streamedItemsKey {
+				result.Metadata[key] = value
+			}
+		}
+	}
+	if stopped {
+		result.Metadata["stopped"] = true
+	}
+	return result, nil
+}
+
+// streamPages fetches a result page by page and passes its items to handler
+func (e *Engine) streamPages(ctx context.Context, serviceName string, cmd *mdwast.Command, params map[string]interface{}, execCtx *ExecutionContext, handler StreamHandler) (*ServiceResponse, error) {
+	if _, exists := params[PageSizeKey]; !exists {
+		params[PageSizeKey] = e.options.StreamPageSize
+	}
+
+	seen := make(map[string]bool)
+	token, _ := params[NextTokenKey].(string)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Each page gets its own parameters, as clients may keep them
+		page := make(map[string]interface{}, len(params)+1)
+		for key, value := range params {
+			page[key] = value
+		}
+		if token != "" {
+			page[NextTokenKey] = token
+		}
+
+		response, err := e.client.Execute(ctx, serviceName, cmd.Object, cmd.Method, page, execCtx)
+		if err != nil {
+			return nil, err
+		}
+		if !response.Success {
+			return response, nil
+		}
+		if err := emitItems(response.Data, handler); err != nil {
+			return nil, err
+		}
+
+		if response.NextToken == "" {
+			return response, nil
+		}
+		if seen[response.NextToken] {
+			return nil, fmt.Errorf("service repeated next_token %q", response.NextToken)
+		}
+		seen[response.NextToken] = true
+		token = response.NextToken
+	}
+}
+
+// emitItems passes the elements of a list, or a single non-list value, to
+// handler
+func emitItems(data interface{}, handler StreamHandler) error {
+	switch list := data.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		for _, item := range list {
+			if err := handler(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []map[string]interface{}:
+		for _, item := range list {
+			if err := handler(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return handler(data)
+	}
+}
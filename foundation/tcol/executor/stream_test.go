@@ -0,0 +1,203 @@
+// File: stream_test.go
+// Title: TCOL Result Pagination and Streaming Tests
+// Description: Tests page_size and next_token handling, streaming over
+//              paged services, native streaming clients, and early stops.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial pagination and streaming tests
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// pagingClient serves a list of rows in pages using numeric next tokens
+type pagingClient struct {
+	MockServiceClient
+	rows   []interface{}
+	tokens []string
+	repeat bool
+}
+
+func (p *pagingClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *ExecutionContext) (*ServiceResponse, error) {
+	token, _ := params[NextTokenKey].(string)
+	p.tokens = append(p.tokens, token)
+
+	start, _ := strconv.Atoi(token)
+	end := start + params[PageSizeKey].(int)
+	if end > len(p.rows) {
+		end = len(p.rows)
+	}
+	response := &ServiceResponse{Success: true, Data: p.rows[start:end]}
+	if end < len(p.rows) {
+		response.NextToken = strconv.Itoa(end)
+	}
+	if p.repeat {
+		response.NextToken = "0"
+	}
+	return response, nil
+}
+
+// streamingClient delivers its rows through ExecuteStream
+type streamingClient struct {
+	MockServiceClient
+	rows []interface{}
+}
+
+func (s *streamingClient) ExecuteStream(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *ExecutionContext, handler StreamHandler) (*ServiceResponse, error) {
+	for _, row := range s.rows {
+		if err := handler(row); err != nil {
+			return nil, err
+		}
+	}
+	return &ServiceResponse{Success: true, Metadata: map[string]interface{}{"source": "stream"}}, nil
+}
+
+func newStreamEngine(t *testing.T, client ServiceClient) *Engine {
+	engine, err := New(Options{ServiceClient: client, StreamPageSize: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+	return engine
+}
+
+func listCommand(params map[string]mdwast.Value) *mdwast.Command {
+	if params == nil {
+		params = map[string]mdwast.Value{}
+	}
+	return &mdwast.Command{Object: "CUSTOMER", Method: "LIST", Parameters: params}
+}
+
+func TestEngine_Pagination(t *testing.T) {
+	client := NewMockServiceClient()
+	engine := newStreamEngine(t, client)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{
+		Success:   true,
+		Data:      []interface{}{"C-1", "C-2"},
+		NextToken: "page-2",
+	})
+
+	result, err := engine.Execute(context.Background(), listCommand(map[string]mdwast.Value{
+		"page_size":  {Type: mdwast.ValueTypeNumber, Value: int64(2)},
+		"next_token": {Type: mdwast.ValueTypeString, Value: "page-1"},
+		"status":     {Type: mdwast.ValueTypeString, Value: "active"},
+	}), createTestContext())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.NextToken != "page-2" {
+		t.Errorf("NextToken = %q", result.NextToken)
+	}
+	params := client.GetCallHistory()[0].Params
+	if params[PageSizeKey] != 2 || params[NextTokenKey] != "page-1" || params["status"] != "active" {
+		t.Errorf("params = %v", params)
+	}
+	if _, exists := params["page_size"]; exists {
+		t.Error("page_size sent as a business parameter")
+	}
+
+	for _, size := range []interface{}{int64(0), int64(5000), 1.5, "ten"} {
+		_, err := engine.Execute(context.Background(), listCommand(map[string]mdwast.Value{
+			"page_size": {Value: size},
+		}), createTestContext())
+		if err == nil || !strings.Contains(err.Error(), "page_size") {
+			t.Errorf("page_size=%v error = %v", size, err)
+		}
+	}
+	if _, err := engine.Execute(context.Background(), listCommand(map[string]mdwast.Value{
+		"next_token": {Type: mdwast.ValueTypeNumber, Value: int64(3)},
+	}), createTestContext()); err == nil {
+		t.Error("numeric next_token did not fail")
+	}
+}
+
+func TestEngine_ExecuteStream_Pages(t *testing.T) {
+	client := &pagingClient{MockServiceClient: *NewMockServiceClient(), rows: []interface{}{"a", "b", "c", "d", "e"}}
+	engine := newStreamEngine(t, client)
+
+	var items []interface{}
+	result, err := engine.ExecuteStream(context.Background(), listCommand(nil), createTestContext(), func(item interface{}) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStream() error = %v", err)
+	}
+	if len(items) != 5 || result.Metadata["items"] != 5 || result.Data != nil || result.CommandType != "STREAM" {
+		t.Errorf("items = %v, result = %+v", items, result)
+	}
+	if strings.Join(client.tokens, ",") != ",2,4" {
+		t.Errorf("tokens = %v", client.tokens)
+	}
+
+	// ErrStopStream ends the stream without fetching further pages
+	client.tokens = nil
+	count := 0
+	result, err = engine.ExecuteStream(context.Background(), listCommand(nil), createTestContext(), func(item interface{}) error {
+		if count++; count == 3 {
+			return ErrStopStream
+		}
+		return nil
+	})
+	if err != nil || result.Metadata["stopped"] != true || result.Metadata["items"] != 2 || len(client.tokens) != 2 {
+		t.Errorf("stopped stream = %+v, %v, tokens %v", result, err, client.tokens)
+	}
+
+	// Other handler errors abort the stream
+	failure := errors.New("disk full")
+	if _, err := engine.ExecuteStream(context.Background(), listCommand(nil), createTestContext(), func(item interface{}) error {
+		return failure
+	}); !errors.Is(err, failure) {
+		t.Errorf("ExecuteStream() error = %v, want %v", err, failure)
+	}
+
+	// A service repeating a token would loop forever
+	client.repeat = true
+	if _, err := engine.ExecuteStream(context.Background(), listCommand(nil), createTestContext(), func(item interface{}) error {
+		return nil
+	}); err == nil || !strings.Contains(err.Error(), "repeated next_token") {
+		t.Errorf("ExecuteStream() error = %v", err)
+	}
+}
+
+func TestEngine_ExecuteStream_StreamingClient(t *testing.T) {
+	client := &streamingClient{MockServiceClient: *NewMockServiceClient(), rows: []interface{}{1, 2, 3}}
+	engine := newStreamEngine(t, client)
+
+	sum := 0
+	result, err := engine.ExecuteStream(context.Background(), listCommand(nil), createTestContext(), func(item interface{}) error {
+		sum += item.(int)
+		return nil
+	})
+	if err != nil || sum != 6 || result.Metadata["source"] != "stream" || result.Metadata["items"] != 3 {
+		t.Errorf("ExecuteStream() = %+v, %v, sum %d", result, err, sum)
+	}
+
+	invalid := []*mdwast.Command{
+		{Object: "CUSTOMER", ObjectID: "1"},
+		{Object: "HELP", Method: "LIST"},
+		{Object: "CUSTOMER", Method: "LIST", Chain: listCommand(nil)},
+	}
+	for _, cmd := range invalid {
+		if _, err := engine.ExecuteStream(context.Background(), cmd, nil, func(interface{}) error { return nil }); err == nil {
+			t.Errorf("ExecuteStream(%s) did not fail", cmd)
+		}
+	}
+	if _, err := engine.ExecuteStream(context.Background(), listCommand(nil), nil, nil); err == nil {
+		t.Error("ExecuteStream() without handler did not fail")
+	}
+}
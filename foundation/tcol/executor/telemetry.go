@@ -0,0 +1,68 @@
+// File: telemetry.go
+// Title: TCOL Executor Instrumentation
+// Description: Starts tracing spans for the stages of pipes and for each
+//              command that runs through the middleware pipeline, and
+//              records the commands in the engine metrics.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial instrumentation of the executor
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
+)
+
+// Metrics returns the metrics of the engine, e.g. to serve them on an
+// admin endpoint
+func (e *Engine) Metrics() *mdwtelemetry.Metrics {
+	return e.options.Metrics
+}
+
+// instrument wraps the middleware pipeline of a command in a command span
+// and records the command in the metrics. Commands served by middleware,
+// such as cached results, are counted as well; transform stages are not.
+func (e *Engine) instrument(handler ExecutorFunc) ExecutorFunc {
+	return func(ctx context.Context, cmd *mdwast.Command, execCtx *ExecutionContext) (*ExecutionResult, error) {
+		ctx, span := e.options.Tracer.Start(ctx, mdwtelemetry.SpanCommand,
+			mdwtelemetry.String(mdwtelemetry.AttrObject, cmd.Object),
+			mdwtelemetry.String(mdwtelemetry.AttrMethod, cmd.Method))
+		startTime := time.Now()
+
+		result, err := handler(ctx, cmd, execCtx)
+		e.options.Metrics.ObserveCommand(cmd.Object, cmd.Method, time.Since(startTime), err)
+		endSpan(span, err)
+		return result, err
+	}
+}
+
+// stageAttributes returns the span attributes of a stage
+func stageAttributes(cmd *mdwast.Command, execCtx *ExecutionContext) []mdwtelemetry.Attribute {
+	attrs := []mdwtelemetry.Attribute{
+		mdwtelemetry.Int(mdwtelemetry.AttrStage, execCtx.ChainDepth),
+		mdwtelemetry.String(mdwtelemetry.AttrRequestID, execCtx.RequestID),
+	}
+	if cmd.Transform != nil {
+		return append(attrs, mdwtelemetry.String(mdwtelemetry.AttrTransform, string(cmd.Transform.Kind)))
+	}
+	return append(attrs,
+		mdwtelemetry.String(mdwtelemetry.AttrObject, cmd.Object),
+		mdwtelemetry.String(mdwtelemetry.AttrMethod, cmd.Method))
+}
+
+// endSpan records a failure with its error code and ends the span
+func endSpan(span mdwtelemetry.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(mdwtelemetry.String(mdwtelemetry.AttrErrorCode, mdwtelemetry.ErrorCode(err)))
+	}
+	span.End()
+}
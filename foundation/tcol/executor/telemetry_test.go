@@ -0,0 +1,73 @@
+// File: telemetry_test.go
+// Title: TCOL Executor Instrumentation Tests
+// Description: Tests the spans of stages and commands and the command
+//              metrics recorded by the executor.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial instrumentation tests
+
+package executor
+
+import (
+	"testing"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
+)
+
+func TestEngine_Telemetry(t *testing.T) {
+	tracer := mdwtelemetry.NewRecordingTracer()
+	client := NewMockServiceClient()
+	engine, err := New(Options{ServiceClient: client, Tracer: tracer})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.SetRegistry(createTestRegistry())
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: testCustomers()})
+	client.SetError("customer-service", "CUSTOMER", "DELETE",
+		mdwerror.New("customer not found").WithCode(mdwerror.CodeTCOLObjectNotFound))
+
+	if _, err := executePipe(t, engine, `CUSTOMER.LIST | SORT BY name`); err != nil {
+		t.Fatalf("pipe error = %v", err)
+	}
+	if _, err := executePipe(t, engine, `CUSTOMER.DELETE id=7`); err == nil {
+		t.Fatal("CUSTOMER.DELETE succeeded")
+	}
+
+	// Spans end innermost first: the command, the transform stage, and the
+	// first stage, which contains the stages after it
+	spans := tracer.Spans()
+	if len(spans) != 5 {
+		t.Fatalf("spans = %+v, want 5", spans)
+	}
+	command, transform, stage := spans[0], spans[1], spans[2]
+	if command.Name != mdwtelemetry.SpanCommand || command.ParentID != stage.ID ||
+		command.Attributes[mdwtelemetry.AttrMethod] != "LIST" {
+		t.Errorf("command span = %+v", command)
+	}
+	if transform.Name != mdwtelemetry.SpanExecute || transform.ParentID != stage.ID ||
+		transform.Attributes[mdwtelemetry.AttrTransform] != "SORT" || transform.Attributes[mdwtelemetry.AttrStage] != 1 {
+		t.Errorf("transform span = %+v", transform)
+	}
+	if stage.Name != mdwtelemetry.SpanExecute || stage.ParentID != 0 || stage.Attributes[mdwtelemetry.AttrObject] != "CUSTOMER" {
+		t.Errorf("stage span = %+v", stage)
+	}
+	if failed := spans[4]; failed.Err == nil || failed.Attributes[mdwtelemetry.AttrErrorCode] != "TCOL_OBJECT_NOT_FOUND" {
+		t.Errorf("failed stage span = %+v", failed)
+	}
+
+	snapshot := engine.Metrics().Snapshot()
+	if snapshot.CommandsTotal != 2 || snapshot.ErrorsTotal != 1 || len(snapshot.Commands) != 2 {
+		t.Fatalf("metrics = %+v", snapshot)
+	}
+	if deleted := snapshot.Commands[0]; deleted.Method != "DELETE" || deleted.Errors != 1 {
+		t.Errorf("CUSTOMER.DELETE metrics = %+v", deleted)
+	}
+	if snapshot.ErrorCodes["TCOL_OBJECT_NOT_FOUND"] != 1 {
+		t.Errorf("error codes = %v", snapshot.ErrorCodes)
+	}
+}
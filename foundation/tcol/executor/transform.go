@@ -0,0 +1,273 @@
+// File: transform.go
+// Title: TCOL Pipe Transform Stages
+// Description: Implements the SELECT, SORT BY, LIMIT, and GROUP BY stages
+//              of pipes, which reshape the result of the previous stage
+//              locally between service calls.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of transform stages
+
+package executor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// Fields added to each group by GROUP BY
+const (
+	GroupCountField = "count"
+	GroupItemsField = "items"
+)
+
+// executeTransform applies a transform stage to the data of the previous
+// stage of the pipe
+func (e *Engine) executeTransform(transform *mdwast.Transform, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	if err := transform.Validate(); err != nil {
+		return nil, err
+	}
+	data, exists := execCtx.Variables.Lookup(PrevVariable)
+	if !exists {
+		return nil, fmt.Errorf("%s must follow a command in a pipe", transform.Kind)
+	}
+
+	// A single object is transformed like a list of one row
+	rows, isList := data.([]interface{})
+	if !isList && data != nil {
+		rows = []interface{}{data}
+	}
+
+	var result interface{}
+	switch transform.Kind {
+	case mdwast.TransformSelect:
+		selected, err := selectFields(rows, transform.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if !isList && len(selected) == 1 {
+			result = selected[0]
+		} else {
+			result = selected
+		}
+	case mdwast.TransformSort:
+		sorted, err := sortRows(rows, transform.Fields, transform.Descending)
+		if err != nil {
+			return nil, err
+		}
+		result = sorted
+	case mdwast.TransformLimit:
+		result = rows[:min(transform.Limit, len(rows))]
+	case mdwast.TransformGroup:
+		groups, err := groupRows(rows, transform.Fields)
+		if err != nil {
+			return nil, err
+		}
+		result = groups
+	}
+
+	e.logger.Debug("TCOL transform executed", mdwlog.Fields{
+		"requestID": execCtx.RequestID,
+		"transform": transform.String(),
+		"rows":      len(rows),
+	})
+
+	return &ExecutionResult{
+		Success:     true,
+		Data:        result,
+		CommandType: "TRANSFORM",
+		Metadata: map[string]interface{}{
+			"transform": transform.String(),
+			"timestamp": time.Now(),
+		},
+	}, nil
+}
+
+// fieldValue returns the value of a field path (name or name.sub) of a
+// row; missing fields are nil
+func fieldValue(row interface{}, path string) (interface{}, error) {
+	value := row
+	for _, field := range strings.Split(path, ".") {
+		if value == nil {
+			return nil, nil
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot read field %s of %T", path, row)
+		}
+		value = object[field]
+	}
+	return value, nil
+}
+
+// selectFields reduces rows to the given fields, keyed by their paths
+func selectFields(rows []interface{}, fields []string) ([]interface{}, error) {
+	selected := make([]interface{}, len(rows))
+	for i, row := range rows {
+		projection := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			value, err := fieldValue(row, field)
+			if err != nil {
+				return nil, err
+			}
+			projection[field] = value
+		}
+		selected[i] = projection
+	}
+	return selected, nil
+}
+
+// sortRows returns the rows stably sorted by the given fields. Missing
+// values sort first in ascending order.
+func sortRows(rows []interface{}, fields []string, descending []bool) ([]interface{}, error) {
+	keys := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		keys[i] = make([]interface{}, len(fields))
+		for j, field := range fields {
+			value, err := fieldValue(row, field)
+			if err != nil {
+				return nil, err
+			}
+			keys[i][j] = value
+		}
+	}
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		for j := range fields {
+			c := compareValues(keys[order[a]][j], keys[order[b]][j])
+			if c == 0 {
+				continue
+			}
+			if j < len(descending) && descending[j] {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]interface{}, len(rows))
+	for i, index := range order {
+		sorted[i] = rows[index]
+	}
+	return sorted, nil
+}
+
+// compareValues orders nil before booleans, numbers, and strings; values of
+// other or different types are compared by their text
+func compareValues(a, b interface{}) int {
+	rank := func(v interface{}) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case bool:
+			return 1
+		case int, int32, int64, float32, float64:
+			return 2
+		case string:
+			return 3
+		default:
+			return 4
+		}
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+
+	switch va := a.(type) {
+	case nil:
+		return 0
+	case bool:
+		vb := b.(bool)
+		switch {
+		case va == vb:
+			return 0
+		case !va:
+			return -1
+		default:
+			return 1
+		}
+	case string:
+		return strings.Compare(va, b.(string))
+	}
+	if fa, ok := toNumber(a); ok {
+		fb, _ := toNumber(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// toNumber converts numeric values to float64
+func toNumber(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case int:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case float32:
+		return float64(val), true
+	case float64:
+		return val, true
+	default:
+		return 0, false
+	}
+}
+
+// groupRows groups rows by the values of the given fields, in the order
+// the groups first appear. Each group holds the field values, the number of
+// rows, and the rows themselves.
+func groupRows(rows []interface{}, fields []string) ([]interface{}, error) {
+	var groups []interface{}
+	index := make(map[string]map[string]interface{})
+	for _, row := range rows {
+		values := make([]interface{}, len(fields))
+		for j, field := range fields {
+			value, err := fieldValue(row, field)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = value
+		}
+		key := fmt.Sprintf("%#v", values)
+
+		group, exists := index[key]
+		if !exists {
+			group = make(map[string]interface{}, len(fields)+2)
+			for j, field := range fields {
+				group[field] = values[j]
+			}
+			group[GroupCountField] = 0
+			group[GroupItemsField] = []interface{}{}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group[GroupCountField] = group[GroupCountField].(int) + 1
+		group[GroupItemsField] = append(group[GroupItemsField].([]interface{}), row)
+	}
+
+	if groups == nil {
+		groups = []interface{}{}
+	}
+	return groups, nil
+}
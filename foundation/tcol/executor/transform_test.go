@@ -0,0 +1,174 @@
+// File: transform_test.go
+// Title: TCOL Pipe Transform Stage Tests
+// Description: Tests $PREV references between pipe stages, list paths, and
+//              the SELECT, SORT BY, LIMIT, and GROUP BY transform stages.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial transform stage tests
+
+package executor
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+func testCustomers() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"name": "Carol", "region": "eu", "total": 250, "address": map[string]interface{}{"city": "Bern"}},
+		map[string]interface{}{"name": "Alice", "region": "us", "total": 100.5},
+		map[string]interface{}{"name": "Bob", "region": "eu", "total": 900},
+		map[string]interface{}{"name": "Dave", "region": "eu"},
+	}
+}
+
+// executePipe parses and executes a single pipe
+func executePipe(t *testing.T, engine *Engine, input string) (*ExecutionResult, error) {
+	script := parseScript(t, input)
+	cmd, ok := script.Statements[0].(*mdwast.Command)
+	if !ok {
+		t.Fatalf("%q is not a command", input)
+	}
+	return engine.Execute(context.Background(), cmd, createTestContext())
+}
+
+// names returns the name field of each row
+func names(t *testing.T, data interface{}) []string {
+	rows, ok := data.([]interface{})
+	if !ok {
+		t.Fatalf("data is %T, not a list", data)
+	}
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i], _ = row.(map[string]interface{})["name"].(string)
+	}
+	return result
+}
+
+func TestEngine_Execute_Transforms(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: true, Data: testCustomers()})
+
+	tests := []struct {
+		pipe     string
+		expected []string
+	}{
+		{"CUSTOMER.LIST | SORT BY name", []string{"Alice", "Bob", "Carol", "Dave"}},
+		{"CUSTOMER.LIST | SORT BY total DESC", []string{"Bob", "Carol", "Alice", "Dave"}},
+		{"CUSTOMER.LIST | SORT BY region, total DESC", []string{"Bob", "Carol", "Dave", "Alice"}},
+		{"CUSTOMER.LIST | sort by total | limit 2", []string{"Dave", "Alice"}},
+		{"CUSTOMER.LIST | LIMIT 10", []string{"Carol", "Alice", "Bob", "Dave"}},
+	}
+
+	for _, tt := range tests {
+		result, err := executePipe(t, engine, tt.pipe)
+		if err != nil {
+			t.Errorf("%s: error = %v", tt.pipe, err)
+			continue
+		}
+		if got := names(t, result.Data); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s = %v, want %v", tt.pipe, got, tt.expected)
+		}
+	}
+
+	result, err := executePipe(t, engine, "CUSTOMER.LIST | SELECT name, address.city | LIMIT 1")
+	if err != nil {
+		t.Fatalf("SELECT error = %v", err)
+	}
+	expected := []interface{}{map[string]interface{}{"name": "Carol", "address.city": "Bern"}}
+	if !reflect.DeepEqual(result.Data, expected) || result.CommandType != "TRANSFORM" {
+		t.Errorf("SELECT result = %#v", result.Data)
+	}
+
+	result, err = executePipe(t, engine, "CUSTOMER.LIST | GROUP BY region")
+	if err != nil {
+		t.Fatalf("GROUP BY error = %v", err)
+	}
+	groups := result.Data.([]interface{})
+	if len(groups) != 2 {
+		t.Fatalf("GROUP BY returned %d groups", len(groups))
+	}
+	eu := groups[0].(map[string]interface{})
+	if eu["region"] != "eu" || eu[GroupCountField] != 3 || len(eu[GroupItemsField].([]interface{})) != 3 {
+		t.Errorf("first group = %v", eu)
+	}
+
+	// Transforms run locally: only the list was sent to a service
+	if calls := client.GetCallHistory(); len(calls) != len(tests)+2 {
+		t.Errorf("service calls = %d, want %d", len(calls), len(tests)+2)
+	}
+}
+
+func TestEngine_Execute_PrevReferences(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"id":    "C-1",
+			"items": []interface{}{map[string]interface{}{"sku": "A1"}, map[string]interface{}{"sku": "B2"}},
+		},
+	})
+
+	result, err := executePipe(t, engine, `CUSTOMER.CREATE name="Acme" | INVOICE.CREATE customer=$PREV.id skus=$PREV.items[*].sku first=$PREV.items[0].sku`)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	calls := client.GetCallHistory()
+	params := calls[len(calls)-1].Params
+	if params["customer"] != "C-1" || !reflect.DeepEqual(params["skus"], []interface{}{"A1", "B2"}) || params["first"] != "A1" {
+		t.Errorf("INVOICE.CREATE params = %v", params)
+	}
+	if result.Data != "Mock response for invoice-service.INVOICE.CREATE" {
+		t.Errorf("pipe result = %v, want the result of the last stage", result.Data)
+	}
+
+	// A failing stage fails the pipe
+	_, err = executePipe(t, engine, `CUSTOMER.CREATE name="Acme" | INVOICE.CREATE customer=$PREV.missing`)
+	if err == nil || !strings.Contains(err.Error(), "$PREV has no field missing") {
+		t.Errorf("reference to a missing field error = %v", err)
+	}
+	_, err = executePipe(t, engine, `CUSTOMER.CREATE name="Acme" | INVOICE.CREATE sku=$PREV.items[5].sku`)
+	if err == nil || !strings.Contains(err.Error(), "$PREV.items has no element 5") {
+		t.Errorf("index out of range error = %v", err)
+	}
+}
+
+func TestScope_ResolveListPaths(t *testing.T) {
+	scope := NewScope(nil)
+	scope.Define("orders", []interface{}{
+		map[string]interface{}{"lines": []interface{}{map[string]interface{}{"sku": "A"}, map[string]interface{}{"sku": "B"}}},
+		map[string]interface{}{"lines": []interface{}{map[string]interface{}{"sku": "C"}}},
+	})
+
+	tests := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"orders[1].lines[0].sku", "C"},
+		{"orders[*].lines[*].sku", []interface{}{"A", "B", "C"}},
+		{"orders[0].lines[*].sku", []interface{}{"A", "B"}},
+	}
+	for _, tt := range tests {
+		if got, err := scope.resolve(tt.path); err != nil || !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("resolve(%s) = %v, %v", tt.path, got, err)
+		}
+	}
+
+	if _, err := scope.resolve("orders.lines"); err == nil || err.Error() != "$orders is not an object" {
+		t.Errorf("field of a list error = %v", err)
+	}
+	if _, err := scope.resolve("orders[0].lines[0][0]"); err == nil || err.Error() != "$orders[0].lines[0] is not a list" {
+		t.Errorf("index of an object error = %v", err)
+	}
+	if err := scope.Define(PrevVariable, 1); err == nil {
+		t.Error("definition of PREV did not fail")
+	}
+}
@@ -0,0 +1,596 @@
+// File: variables.go
+// Title: TCOL Script Variables
+// Description: Implements the scoped variable table of TCOL scripts and the
+//              resolution of $variable references and string interpolation
+//              in commands before they are sent to services. Includes the
+//              execution of scripts and LET statements.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.3
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of script variables
+// - 2026-10-16 v0.1.1: Scripts run under a timeout; statements run through
+//                       executeStatements
+// - 2026-10-16 v0.1.2: $PREV in pipes and list indexes in variable paths
+// - 2026-10-16 v0.1.3: Script statements are recorded in the history as
+//                       their own commands
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// Implicit variables
+const (
+	// LastIDVariable is set to the "id" field of the data of each successful command
+	LastIDVariable = "LAST_ID"
+
+	// PrevVariable holds the data of the previous stage in a pipe
+	PrevVariable = "PREV"
+)
+
+// Scope is a variable table. Lookups fall back to the parent scope; LET
+// binds in the scope it runs in and cannot rebind a name there.
+type Scope struct {
+	parent *Scope
+	vars   map[string]interface{}
+	mutex  sync.RWMutex
+}
+
+// NewScope creates a scope; parent is nil for the outermost scope
+func NewScope(parent *Scope) *Scope {
+	return &Scope{
+		parent: parent,
+		vars:   make(map[string]interface{}),
+	}
+}
+
+// Define binds a new variable in this scope. It fails if the name is
+// already bound in this scope or is reserved.
+func (s *Scope) Define(name string, value interface{}) error {
+	if name == LastIDVariable || name == PrevVariable {
+		return fmt.Errorf("variable %s is reserved", name)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.vars[name]; exists {
+		return fmt.Errorf("variable %s is already defined", name)
+	}
+	s.vars[name] = value
+	return nil
+}
+
+// Lookup returns the value of a variable from this scope or its parents
+func (s *Scope) Lookup(name string) (interface{}, bool) {
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.mutex.RLock()
+		value, exists := scope.vars[name]
+		scope.mutex.RUnlock()
+		if exists {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the sorted names of all variables visible in this scope
+func (s *Scope) Names() []string {
+	seen := make(map[string]bool)
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.mutex.RLock()
+		for name := range scope.vars {
+			seen[name] = true
+		}
+		scope.mutex.RUnlock()
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setImplicit sets an implicit variable in the outermost scope
+func (s *Scope) setImplicit(name string, value interface{}) {
+	root := s
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	root.vars[name] = value
+}
+
+// setLocal binds an implicit variable in this scope
+func (s *Scope) setLocal(name string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.vars[name] = value
+}
+
+// pathStep is a step of a variable path: a field, a list index, or [*]
+type pathStep struct {
+	field string
+	index int
+	all   bool // [*]: the rest of the path applies to every element
+}
+
+// resolve returns the value of a variable path (name, name.field.sub,
+// name.list[0], or name.list[*].field). With [*], the result is the list of
+// values for all elements, flattened if the rest of the path has [*] too.
+func (s *Scope) resolve(path string) (interface{}, error) {
+	name, steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	value, exists := s.Lookup(name)
+	if !exists {
+		return nil, fmt.Errorf("undefined variable $%s", name)
+	}
+	return walkPath(value, steps, "$"+name)
+}
+
+// parsePath splits a variable path into its name and steps
+func parsePath(path string) (string, []pathStep, error) {
+	var steps []pathStep
+	for _, part := range strings.Split(path, ".") {
+		field := part
+		var indexes []string
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			field = part[:open]
+			indexes = strings.Split(strings.TrimSuffix(part[open+1:], "]"), "][")
+		}
+		steps = append(steps, pathStep{field: field})
+		for _, index := range indexes {
+			if index == "*" {
+				steps = append(steps, pathStep{all: true})
+				continue
+			}
+			n, err := strconv.Atoi(index)
+			if err != nil || n < 0 {
+				return "", nil, fmt.Errorf("invalid list index [%s] in $%s", index, path)
+			}
+			steps = append(steps, pathStep{index: n})
+		}
+	}
+	return steps[0].field, steps[1:], nil
+}
+
+// walkPath applies path steps to value; shown is the path so far for
+// error messages
+func walkPath(value interface{}, steps []pathStep, shown string) (interface{}, error) {
+	for i, step := range steps {
+		if step.field != "" {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", shown)
+			}
+			if value, ok = object[step.field]; !ok {
+				return nil, fmt.Errorf("%s has no field %s", shown, step.field)
+			}
+			shown += "." + step.field
+			continue
+		}
+
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not a list", shown)
+		}
+		if !step.all {
+			if step.index >= len(list) {
+				return nil, fmt.Errorf("%s has no element %d", shown, step.index)
+			}
+			value = list[step.index]
+			shown += fmt.Sprintf("[%d]", step.index)
+			continue
+		}
+
+		rest := steps[i+1:]
+		spread := false
+		for _, r := range rest {
+			spread = spread || r.all
+		}
+		values := make([]interface{}, 0, len(list))
+		for j, element := range list {
+			v, err := walkPath(element, rest, fmt.Sprintf("%s[%d]", shown, j))
+			if err != nil {
+				return nil, err
+			}
+			if nested, ok := v.([]interface{}); ok && spread {
+				values = append(values, nested...)
+			} else {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	}
+	return value, nil
+}
+
+// ExecuteScript executes the statements of a script in order and stops at
+// the first error or when the script timeout expires. Variables are bound in
+// execCtx.Variables, or in a new scope if it is nil.
+func (e *Engine) ExecuteScript(ctx context.Context, script *mdwast.Script, execCtx *ExecutionContext) ([]*ExecutionResult, error) {
+	if script == nil {
+		return nil, fmt.Errorf("script cannot be nil")
+	}
+	execCtx = withScope(execCtx)
+
+	ctx, cancel := context.WithTimeout(ctx, e.options.ScriptTimeout)
+	defer cancel()
+
+	return e.executeStatements(ctx, script.Statements, execCtx)
+}
+
+// executeStatements executes a block of statements in order and stops at
+// the first error
+func (e *Engine) executeStatements(ctx context.Context, stmts []mdwast.Statement, execCtx *ExecutionContext) ([]*ExecutionResult, error) {
+	results := make([]*ExecutionResult, 0, len(stmts))
+	for i, stmt := range stmts {
+		if err := ctx.Err(); err != nil {
+			return results, fmt.Errorf("statement %d: script aborted: %w", i+1, err)
+		}
+
+		stmtCtx := *execCtx
+		stmtCtx.RequestID = fmt.Sprintf("%s-%d", execCtx.RequestID, i)
+		stmtCtx.Input = "" // Statements are recorded as their own commands
+
+		var result *ExecutionResult
+		var err error
+		switch s := stmt.(type) {
+		case *mdwast.Command:
+			result, err = e.Execute(ctx, s, &stmtCtx)
+		case *mdwast.LetStmt:
+			result, err = e.executeLet(ctx, s, &stmtCtx)
+		case *mdwast.IfStmt:
+			result, err = e.executeIf(ctx, s, &stmtCtx)
+		case *mdwast.ForEachStmt:
+			result, err = e.executeForEach(ctx, s, &stmtCtx)
+		default:
+			err = fmt.Errorf("unsupported statement type %T", stmt)
+		}
+		if err != nil {
+			return results, fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// executeLet executes a LET statement and binds its variable
+func (e *Engine) executeLet(ctx context.Context, stmt *mdwast.LetStmt, execCtx *ExecutionContext) (*ExecutionResult, error) {
+	if err := stmt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if stmt.Command != nil {
+		result, err := e.Execute(ctx, stmt.Command, execCtx)
+		if err != nil {
+			return nil, fmt.Errorf("LET %s: %w", stmt.Name, err)
+		}
+		if !result.Success {
+			return nil, fmt.Errorf("LET %s: command %s.%s failed", stmt.Name, stmt.Command.Object, stmt.Command.Method)
+		}
+		if err := execCtx.Variables.Define(stmt.Name, result.Data); err != nil {
+			return nil, fmt.Errorf("LET %s: %w", stmt.Name, err)
+		}
+		return result, nil
+	}
+
+	value, err := resolveValue(*stmt.Value, execCtx.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("LET %s: %w", stmt.Name, err)
+	}
+	if err := execCtx.Variables.Define(stmt.Name, value.Value); err != nil {
+		return nil, fmt.Errorf("LET %s: %w", stmt.Name, err)
+	}
+	return &ExecutionResult{
+		Success:     true,
+		Data:        value.Value,
+		CommandType: "LET",
+	}, nil
+}
+
+// withScope returns execCtx, or a copy of it with a new variable scope if it
+// has none
+func withScope(execCtx *ExecutionContext) *ExecutionContext {
+	if execCtx == nil {
+		execCtx = &ExecutionContext{
+			RequestID: fmt.Sprintf("req-%d", time.Now().UnixNano()),
+			Timestamp: time.Now(),
+			Metadata:  make(map[string]interface{}),
+		}
+	}
+	if execCtx.Variables == nil {
+		scoped := *execCtx
+		scoped.Variables = NewScope(nil)
+		execCtx = &scoped
+	}
+	return execCtx
+}
+
+// recordLastID sets LAST_ID from the "id" field of result data
+func recordLastID(scope *Scope, result *ExecutionResult) {
+	if data, ok := result.Data.(map[string]interface{}); ok && result.Success {
+		if id, exists := data["id"]; exists {
+			scope.setImplicit(LastIDVariable, id)
+		}
+	}
+}
+
+// resolveCommand returns cmd with variable references and interpolated
+// strings replaced by their values. The chained command is resolved when it
+// runs. Commands without variables are returned unchanged.
+func resolveCommand(cmd *mdwast.Command, scope *Scope) (*mdwast.Command, error) {
+	if !usesVariables(cmd) {
+		return cmd, nil
+	}
+
+	resolved := *cmd
+	if cmd.Parameters != nil {
+		resolved.Parameters = make(map[string]mdwast.Value, len(cmd.Parameters))
+		for name, value := range cmd.Parameters {
+			v, err := resolveValue(value, scope)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", name, err)
+			}
+			resolved.Parameters[name] = v
+		}
+	}
+
+	if cmd.FieldOp != nil {
+		fieldOp := *cmd.FieldOp
+		v, err := resolveValue(fieldOp.Value, scope)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", fieldOp.Field, err)
+		}
+		fieldOp.Value = v
+		resolved.FieldOp = &fieldOp
+	}
+
+	if cmd.Filter != nil {
+		condition, err := resolveExpr(cmd.Filter.Condition, scope)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+		resolved.Filter = &mdwast.FilterExpr{Condition: condition, Pos: cmd.Filter.Pos}
+	}
+
+	return &resolved, nil
+}
+
+// usesVariables reports whether a command, not counting its chain, contains
+// variable references or interpolated strings
+func usesVariables(cmd *mdwast.Command) bool {
+	for _, value := range cmd.Parameters {
+		if needsResolution(value) {
+			return true
+		}
+	}
+	if cmd.FieldOp != nil && needsResolution(cmd.FieldOp.Value) {
+		return true
+	}
+	if cmd.Filter != nil {
+		for _, literal := range mdwast.CollectNodes(cmd.Filter.Condition).Literals {
+			if needsResolution(literal.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// needsResolution reports whether a value is a variable reference or an
+// interpolated string
+func needsResolution(value mdwast.Value) bool {
+	return value.Type == mdwast.ValueTypeVariable || (value.Type == mdwast.ValueTypeString && value.Interpolate)
+}
+
+// resolveValue resolves a variable reference or interpolated string
+func resolveValue(value mdwast.Value, scope *Scope) (mdwast.Value, error) {
+	switch {
+	case value.Type == mdwast.ValueTypeVariable:
+		path, _ := value.Value.(string)
+		resolved, err := scope.resolve(path)
+		if err != nil {
+			return mdwast.Value{}, err
+		}
+		return toValue(resolved, value.Pos), nil
+	case value.Type == mdwast.ValueTypeString && value.Interpolate:
+		text, err := interpolate(value.Raw, scope)
+		if err != nil {
+			return mdwast.Value{}, err
+		}
+		return mdwast.Value{Type: mdwast.ValueTypeString, Raw: text, Value: text, Pos: value.Pos}, nil
+	default:
+		return value, nil
+	}
+}
+
+// resolveExpr returns a copy of a filter expression with variable literals
+// replaced by their values
+func resolveExpr(expr mdwast.Expr, scope *Scope) (mdwast.Expr, error) {
+	resolveAll := func(exprs []mdwast.Expr) ([]mdwast.Expr, error) {
+		result := make([]mdwast.Expr, len(exprs))
+		for i, e := range exprs {
+			r, err := resolveExpr(e, scope)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = r
+		}
+		return result, nil
+	}
+
+	switch e := expr.(type) {
+	case *mdwast.LiteralExpr:
+		if !needsResolution(e.Value) {
+			return e, nil
+		}
+		value, err := resolveValue(e.Value, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.LiteralExpr{Value: value, Pos: e.Pos}, nil
+	case *mdwast.BinaryExpr:
+		operands, err := resolveAll([]mdwast.Expr{e.Left, e.Right})
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.BinaryExpr{Left: operands[0], Op: e.Op, Right: operands[1], Pos: e.Pos}, nil
+	case *mdwast.UnaryExpr:
+		operand, err := resolveExpr(e.Expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.UnaryExpr{Op: e.Op, Expr: operand, Pos: e.Pos}, nil
+	case *mdwast.FunctionCallExpr:
+		args, err := resolveAll(e.Args)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.FunctionCallExpr{Name: e.Name, Args: args, Pos: e.Pos}, nil
+	case *mdwast.ArrayExpr:
+		elements, err := resolveAll(e.Elements)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.ArrayExpr{Elements: elements, Pos: e.Pos}, nil
+	case *mdwast.ObjectExpr:
+		fields := make(map[string]mdwast.Expr, len(e.Fields))
+		for key, field := range e.Fields {
+			r, err := resolveExpr(field, scope)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = r
+		}
+		return &mdwast.ObjectExpr{Fields: fields, Pos: e.Pos}, nil
+	case *mdwast.BetweenExpr:
+		operands, err := resolveAll([]mdwast.Expr{e.Expr, e.Low, e.High})
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.BetweenExpr{Expr: operands[0], Low: operands[1], High: operands[2], Pos: e.Pos}, nil
+	case *mdwast.IsNullExpr:
+		operand, err := resolveExpr(e.Expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.IsNullExpr{Expr: operand, Pos: e.Pos}, nil
+	default:
+		return expr, nil
+	}
+}
+
+// interpolate replaces $name, $name.field, and ${name.field} in text by the
+// variable values; \$ stands for a literal dollar sign, as does a '$' not
+// followed by a name
+func interpolate(text string, scope *Scope) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch == '\\' && i+1 < len(text) && text[i+1] == '$' {
+			result.WriteByte('$')
+			i++
+			continue
+		}
+		if ch != '$' || i+1 >= len(text) {
+			result.WriteByte(ch)
+			continue
+		}
+
+		var path string
+		if text[i+1] == '{' {
+			end := strings.IndexByte(text[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated ${ in string")
+			}
+			path = strings.TrimSpace(text[i+2 : i+2+end])
+			i += end + 2
+		} else {
+			end := i + 1
+			for end < len(text) && isNameChar(text[end], end == i+1) {
+				end++
+				// A dot continues the path only if a name follows
+				if end+1 < len(text) && text[end] == '.' && isNameChar(text[end+1], true) {
+					end++
+				}
+			}
+			if end == i+1 {
+				result.WriteByte(ch)
+				continue
+			}
+			path = text[i+1 : end]
+			i = end - 1
+		}
+
+		value, err := scope.resolve(path)
+		if err != nil {
+			return "", err
+		}
+		if value != nil {
+			fmt.Fprint(&result, value)
+		}
+	}
+	return result.String(), nil
+}
+
+// isNameChar reports whether ch may appear in a variable name; first
+// characters must not be digits
+func isNameChar(ch byte, first bool) bool {
+	if ch == '_' || ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') {
+		return true
+	}
+	return !first && '0' <= ch && ch <= '9'
+}
+
+// toValue converts a variable value into an AST value
+func toValue(value interface{}, pos mdwast.Position) mdwast.Value {
+	v := mdwast.Value{Value: value, Pos: pos, Raw: fmt.Sprint(value)}
+
+	switch val := value.(type) {
+	case nil:
+		v.Type = mdwast.ValueTypeNull
+		v.Raw = "null"
+	case string:
+		v.Type = mdwast.ValueTypeString
+	case bool:
+		v.Type = mdwast.ValueTypeBoolean
+	case int, int64, float64:
+		v.Type = mdwast.ValueTypeNumber
+	case int32:
+		v.Type = mdwast.ValueTypeNumber
+		v.Value = int64(val)
+	case float32:
+		v.Type = mdwast.ValueTypeNumber
+		v.Value = float64(val)
+	case time.Time:
+		v.Type = mdwast.ValueTypeTime
+		v.Raw = val.Format(time.RFC3339)
+	case []interface{}:
+		v.Type = mdwast.ValueTypeArray
+	case map[string]interface{}:
+		v.Type = mdwast.ValueTypeObject
+	default:
+		v.Type = mdwast.ValueTypeString
+	}
+	return v
+}
@@ -0,0 +1,239 @@
+// File: variables_test.go
+// Title: TCOL Script Variable Tests
+// Description: Tests variable scopes, LET bindings, resolution of variable
+//              references in parameters and filters, string interpolation,
+//              and the implicit LAST_ID variable.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial script variable tests
+
+package executor
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// newScriptEngine creates an engine with CUSTOMER and INVOICE objects
+func newScriptEngine(t *testing.T) (*Engine, *MockServiceClient) {
+	client := NewMockServiceClient()
+	engine, err := New(Options{ServiceClient: client})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	registry := createTestRegistry()
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "INVOICE",
+		Service: "invoice-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"CREATE": {Name: "CREATE"},
+			"LIST":   {Name: "LIST"},
+			"SEND":   {Name: "SEND"},
+		},
+	})
+	engine.SetRegistry(registry)
+	return engine, client
+}
+
+// parseScript parses a script or fails the test
+func parseScript(t *testing.T, input string) *mdwast.Script {
+	parser, _ := mdwparser.New(mdwparser.Options{Logger: mdwlog.GetDefault(), EnableChaining: true})
+	script, err := parser.ParseScript(input)
+	if err != nil {
+		t.Fatalf("ParseScript() error = %v", err)
+	}
+	return script
+}
+
+func TestScope(t *testing.T) {
+	root := NewScope(nil)
+	if err := root.Define("a", 1); err != nil {
+		t.Fatalf("Define() error = %v", err)
+	}
+	if err := root.Define("a", 2); err == nil {
+		t.Error("redefinition in the same scope did not fail")
+	}
+	if err := root.Define(LastIDVariable, 3); err == nil {
+		t.Error("definition of LAST_ID did not fail")
+	}
+
+	child := NewScope(root)
+	if err := child.Define("a", "shadow"); err != nil {
+		t.Errorf("shadowing in a child scope failed: %v", err)
+	}
+	child.Define("b", map[string]interface{}{"c": map[string]interface{}{"d": 4}})
+	child.setImplicit(LastIDVariable, "x")
+
+	if value, _ := child.Lookup("a"); value != "shadow" {
+		t.Errorf("Lookup(a) = %v", value)
+	}
+	if value, _ := root.Lookup(LastIDVariable); value != "x" {
+		t.Errorf("LAST_ID not set in the outermost scope: %v", value)
+	}
+	if _, exists := root.Lookup("b"); exists {
+		t.Error("child variable visible in parent")
+	}
+	if names := child.Names(); !reflect.DeepEqual(names, []string{"LAST_ID", "a", "b"}) {
+		t.Errorf("Names() = %v", names)
+	}
+
+	if value, err := child.resolve("b.c.d"); err != nil || value != 4 {
+		t.Errorf("resolve(b.c.d) = %v, %v", value, err)
+	}
+	for _, path := range []string{"missing", "b.x", "a.x"} {
+		if _, err := child.resolve(path); err == nil {
+			t.Errorf("resolve(%s) did not fail", path)
+		}
+	}
+}
+
+func TestEngine_ExecuteScript(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{
+		Success: true,
+		Data:    map[string]interface{}{"id": "C-1", "name": "ACME"},
+	})
+	client.SetResponse("invoice-service", "INVOICE", "CREATE", &ServiceResponse{
+		Success: true,
+		Data:    map[string]interface{}{"id": "I-7"},
+	})
+
+	script := parseScript(t, `
+LET cust = CUSTOMER.CREATE name="ACME"
+LET limit = 500
+INVOICE.CREATE customer_id=$cust.id amount=$limit note="""For ${cust.name}: \$$limit"""
+INVOICE[customer_id = $cust.id AND amount > $limit].LIST
+INVOICE.SEND id=$LAST_ID
+`)
+	results, err := engine.ExecuteScript(context.Background(), script, createTestContext())
+	if err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if len(results) != 5 || results[1].CommandType != "LET" || results[1].Data != int64(500) {
+		t.Fatalf("results = %v", results)
+	}
+
+	calls := client.GetCallHistory()
+	if len(calls) != 4 {
+		t.Fatalf("Expected 4 service calls, got %d", len(calls))
+	}
+	create := calls[1].Params
+	if create["customer_id"] != "C-1" || create["amount"] != int64(500) || create["note"] != "For ACME: $500" {
+		t.Errorf("INVOICE.CREATE params = %v", create)
+	}
+	filter := calls[2].Params["_filter"].(map[string]interface{})["condition"].(map[string]interface{})
+	left := filter["left"].(map[string]interface{})["right"].(map[string]interface{})
+	if left["value"] != "C-1" {
+		t.Errorf("filter operand = %v", left)
+	}
+	if calls[3].Params["id"] != "I-7" {
+		t.Errorf("LAST_ID = %v", calls[3].Params["id"])
+	}
+}
+
+func TestEngine_ExecuteScript_SessionScope(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "CREATE", &ServiceResponse{
+		Success: true,
+		Data:    map[string]interface{}{"id": "C-1"},
+	})
+
+	// Variables outlive a script if the caller provides the scope
+	execCtx := createTestContext()
+	execCtx.Variables = NewScope(nil)
+	if _, err := engine.ExecuteScript(context.Background(), parseScript(t, `LET cust = CUSTOMER.CREATE name="ACME"`), execCtx); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if _, err := engine.ExecuteScript(context.Background(), parseScript(t, `INVOICE.CREATE customer_id=$cust.id`), execCtx); err != nil {
+		t.Fatalf("ExecuteScript() error = %v", err)
+	}
+	if calls := client.GetCallHistory(); calls[1].Params["customer_id"] != "C-1" {
+		t.Errorf("customer_id = %v", calls[1].Params["customer_id"])
+	}
+
+	// A chained command sees LAST_ID of the command before it
+	client.ClearHistory()
+	cmd := parseScript(t, `CUSTOMER.CREATE name="B" | INVOICE.CREATE customer_id=$LAST_ID`).Statements[0].(*mdwast.Command)
+	if _, err := engine.Execute(context.Background(), cmd, createTestContext()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if calls := client.GetCallHistory(); len(calls) != 2 || calls[1].Params["customer_id"] != "C-1" {
+		t.Errorf("chained calls = %v", calls)
+	}
+}
+
+func TestEngine_ExecuteScript_Errors(t *testing.T) {
+	engine, client := newScriptEngine(t)
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &ServiceResponse{Success: false})
+
+	tests := []struct {
+		name   string
+		script string
+		calls  int
+		errMsg string
+	}{
+		{"Undefined variable", "INVOICE.CREATE customer_id=$nobody", 0, "undefined variable $nobody"},
+		{"Undefined in string", `INVOICE.CREATE note="""Hi $nobody"""`, 0, "undefined variable $nobody"},
+		{"Redefinition", "LET a = 1\nLET a = 2\nCUSTOMER.CREATE name=x", 0, "already defined"},
+		{"Failed LET command", "LET list = CUSTOMER.LIST\nCUSTOMER.CREATE name=x", 1, "CUSTOMER.LIST failed"},
+		{"Stops at first error", "CUSTOMER.CREATE name=x\nINVOICE[id = $x].LIST\nCUSTOMER.CREATE name=y", 1, "statement 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.ClearHistory()
+			_, err := engine.ExecuteScript(context.Background(), parseScript(t, tt.script), createTestContext())
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ExecuteScript() error = %v, want %q", err, tt.errMsg)
+			}
+			if calls := len(client.GetCallHistory()); calls != tt.calls {
+				t.Errorf("service calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+
+	if _, err := engine.ExecuteScript(context.Background(), nil, nil); err == nil {
+		t.Error("nil script did not fail")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	scope := NewScope(nil)
+	scope.Define("name", "Ada")
+	scope.Define("order", map[string]interface{}{"id": 42, "note": nil})
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Hello $name!", "Hello Ada!"},
+		{"Order $order.id.", "Order 42."},
+		{"${order.id}th", "42th"},
+		{`Costs \$5 or $5 or $`, "Costs $5 or $5 or $"},
+		{"Note: [$order.note]", "Note: []"},
+		{"$name$name", "AdaAda"},
+	}
+	for _, tt := range tests {
+		if got, err := interpolate(tt.text, scope); err != nil || got != tt.expected {
+			t.Errorf("interpolate(%q) = %q, %v, want %q", tt.text, got, err, tt.expected)
+		}
+	}
+
+	for _, text := range []string{"${name", "$order.missing"} {
+		if _, err := interpolate(text, scope); err == nil {
+			t.Errorf("interpolate(%q) did not fail", text)
+		}
+	}
+}
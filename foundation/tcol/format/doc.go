@@ -0,0 +1,51 @@
+// File: doc.go
+// Title: TCOL Output Formatting Package Documentation
+// Description: Renders TCOL result data as aligned terminal tables, JSON,
+//              CSV, YAML, or XLSX so that consumers share one renderer.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial table, JSON, CSV, and YAML formatters
+// - 2026-10-16 v0.1.1: Documented XLSX workbooks
+
+/*
+Package format renders the data of TCOL results for display and export.
+
+Result items are normalized into rows first: maps are used as they are,
+structs by their JSON field names, and scalar items become a single "value"
+column. The rows are then written in one of these formats:
+
+	table  aligned columns for terminals (default)
+	json   indented JSON array
+	csv    header line and one record per row
+	yaml   YAML sequence
+	xlsx   Excel workbook with a header row, for files only
+
+Usage:
+
+	err := format.Render(os.Stdout, result.Data, format.Options{
+		Format:   format.Table,
+		Columns:  []string{"name", "email"},
+		MaxWidth: 30,
+	})
+
+Columns selects and orders the fields written by every format; without it
+all fields are written in alphabetical order. MaxWidth truncates table cells
+with an ellipsis. Table padding is based on the display width of the text,
+so combining marks take no space and East Asian wide characters take two
+columns. Numbers are right-aligned; nested maps and lists are shown as
+compact JSON in table and CSV cells.
+
+TCOL commands choose the format with the format= and columns= parameters:
+
+	CUSTOMER.LIST format=csv columns="name,email"
+
+Parse accepts every format but xlsx, which is binary; the EXPORT stage of
+the executor writes results to files in it:
+
+	CUSTOMER.LIST | EXPORT.XLSX file="customers.xlsx"
+*/
+package format
@@ -0,0 +1,291 @@
+// File: format.go
+// Title: TCOL Output Formats
+// Description: Normalizes result data into rows and renders them as JSON,
+//              CSV, YAML, or XLSX, dispatching tables to the table writer.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of output formats
+// - 2026-10-16 v0.1.1: Added XLSX workbooks for file exports
+
+package format
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+	"gopkg.in/yaml.v3"
+)
+
+// Format names an output format
+type Format string
+
+// Supported output formats
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	YAML  Format = "yaml"
+
+	// XLSX is a binary Excel workbook for files; Parse does not accept it
+	// because it cannot be shown on a terminal
+	XLSX Format = "xlsx"
+)
+
+// valueColumn is the column of scalar result items
+const valueColumn = "value"
+
+// Options configures rendering
+type Options struct {
+	Format   Format   // Output format; Table if empty
+	Columns  []string // Fields to write, in order; all fields if empty
+	MaxWidth int      // Maximum table cell width in columns; unlimited if 0
+}
+
+// Parse returns the format with the given case-insensitive name
+func Parse(name string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(name))); format {
+	case Table, JSON, CSV, YAML:
+		return format, nil
+	case "yml":
+		return YAML, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (use table, json, csv, or yaml)", name)
+	}
+}
+
+// ParseColumns splits a comma-separated column list, dropping empty names
+func ParseColumns(list string) []string {
+	var columns []string
+	for _, column := range strings.Split(list, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Render writes data to w in the format selected by opts
+func Render(w io.Writer, data []interface{}, opts Options) error {
+	format := opts.Format
+	if format == "" {
+		format = Table
+	}
+
+	rows, err := normalize(data)
+	if err != nil {
+		return err
+	}
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = allColumns(rows)
+	}
+
+	switch format {
+	case Table:
+		return writeTable(w, rows, columns, opts.MaxWidth)
+	case JSON:
+		return writeJSON(w, project(rows, opts.Columns))
+	case CSV:
+		return writeCSV(w, rows, columns)
+	case YAML:
+		return writeYAML(w, project(rows, opts.Columns))
+	case XLSX:
+		return writeXLSX(w, rows, columns)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// String renders data and returns the output as a string
+func String(data []interface{}, opts Options) (string, error) {
+	var buf bytes.Buffer
+	if err := Render(&buf, data, opts); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// normalize converts result items into rows. Structs are converted by their
+// JSON encoding so that field names match the JSON output.
+func normalize(data []interface{}) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, 0, len(data))
+	for _, item := range data {
+		value, err := plain(item)
+		if err != nil {
+			return nil, fmt.Errorf("cannot format result item: %w", err)
+		}
+		if row, ok := value.(map[string]interface{}); ok {
+			rows = append(rows, row)
+		} else {
+			rows = append(rows, map[string]interface{}{valueColumn: value})
+		}
+	}
+	return rows, nil
+}
+
+// plain converts a value to maps, slices, strings, numbers, booleans, and nil
+func plain(item interface{}) (interface{}, error) {
+	switch item.(type) {
+	case nil, string, bool, int, int64, float64:
+		return item, nil
+	}
+
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return numbers(value), nil
+}
+
+// numbers replaces JSON numbers by int64 or float64 values
+func numbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, element := range v {
+			v[key] = numbers(element)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = numbers(element)
+		}
+	}
+	return value
+}
+
+// allColumns returns the fields of all rows in alphabetical order
+func allColumns(rows []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// project reduces rows to the selected columns; scalar rows are unwrapped
+// again if no columns are selected
+func project(rows []map[string]interface{}, columns []string) []interface{} {
+	items := make([]interface{}, len(rows))
+	for i, row := range rows {
+		if len(columns) == 0 {
+			if value, ok := row[valueColumn]; ok && len(row) == 1 {
+				items[i] = value
+			} else {
+				items[i] = row
+			}
+			continue
+		}
+		selected := make(map[string]interface{}, len(columns))
+		for _, column := range columns {
+			selected[column] = row[column]
+		}
+		items[i] = selected
+	}
+	return items
+}
+
+// cellText returns the text of a table or CSV cell
+func cellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
+
+// writeJSON writes items as an indented JSON array
+func writeJSON(w io.Writer, items []interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(items)
+}
+
+// writeCSV writes a header line and one record per row
+func writeCSV(w io.Writer, rows []map[string]interface{}, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = cellText(row[column])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeXLSX writes a workbook with a header row and one row per row;
+// numbers become numeric cells
+func writeXLSX(w io.Writer, rows []map[string]interface{}, columns []string) error {
+	records := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		records[i] = make(map[string]string, len(columns))
+		for _, column := range columns {
+			records[i][column] = cellText(row[column])
+		}
+	}
+	return mdwfilex.EncodeXLSX(w, columns, records)
+}
+
+// writeYAML writes items as a YAML sequence
+func writeYAML(w io.Writer, items []interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(items); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
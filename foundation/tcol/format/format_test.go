@@ -0,0 +1,151 @@
+// File: format_test.go
+// Title: TCOL Output Format Tests
+// Description: Tests format parsing, row normalization, column selection,
+//              and JSON, CSV, YAML, and XLSX output.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial output format tests
+// - 2026-10-16 v0.1.1: Added XLSX output test
+
+package format
+
+import (
+	"archive/zip"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type customer struct {
+	Name    string  `json:"name"`
+	Balance float64 `json:"balance"`
+	Active  bool    `json:"active"`
+}
+
+func testData() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"name": "Alice", "email": "alice@example.com", "orders": 12},
+		customer{Name: "Bob, Jr.", Balance: 10.5, Active: true},
+	}
+}
+
+func TestParse(t *testing.T) {
+	for name, expected := range map[string]Format{"table": Table, "JSON": JSON, " csv ": CSV, "yml": YAML, "yaml": YAML} {
+		if got, err := Parse(name); err != nil || got != expected {
+			t.Errorf("Parse(%q) = %q, %v", name, got, err)
+		}
+	}
+	for _, name := range []string{"xml", "xlsx"} {
+		if _, err := Parse(name); err == nil {
+			t.Errorf("Parse(%s) did not fail", name)
+		}
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	if got := ParseColumns(" name, ,email,"); !reflect.DeepEqual(got, []string{"name", "email"}) {
+		t.Errorf("ParseColumns() = %v", got)
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	output, err := String(testData(), Options{Format: JSON})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	for _, expected := range []string{`"email": "alice@example.com"`, `"orders": 12`, `"balance": 10.5`, `"active": true`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("JSON output misses %s:\n%s", expected, output)
+		}
+	}
+
+	output, _ = String(testData(), Options{Format: JSON, Columns: []string{"name"}})
+	if strings.Contains(output, "email") || !strings.Contains(output, `"name": "Bob, Jr."`) {
+		t.Errorf("JSON output with columns:\n%s", output)
+	}
+
+	output, _ = String([]interface{}{"done", 3}, Options{Format: JSON})
+	if output != "[\n  \"done\",\n  3\n]\n" {
+		t.Errorf("JSON output of scalars = %q", output)
+	}
+}
+
+func TestRender_CSV(t *testing.T) {
+	output, err := String(testData(), Options{Format: CSV, Columns: []string{"name", "orders"}})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	expected := "name,orders\nAlice,12\n\"Bob, Jr.\",\n"
+	if output != expected {
+		t.Errorf("CSV output = %q, want %q", output, expected)
+	}
+
+	output, _ = String(testData(), Options{Format: CSV})
+	if !strings.HasPrefix(output, "active,balance,email,name,orders\n") {
+		t.Errorf("CSV header = %q", output)
+	}
+	if output, _ := String(nil, Options{Format: CSV}); output != "" {
+		t.Errorf("CSV output of no data = %q", output)
+	}
+}
+
+func TestRender_XLSX(t *testing.T) {
+	output, err := String(testData(), Options{Format: XLSX, Columns: []string{"name", "orders"}})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	archive, err := zip.NewReader(strings.NewReader(output), int64(len(output)))
+	if err != nil {
+		t.Fatalf("XLSX output is no zip archive: %v", err)
+	}
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		reader, _ := file.Open()
+		sheet, _ := io.ReadAll(reader)
+		reader.Close()
+		for _, expected := range []string{">name</t>", ">Bob, Jr.</t>", `<c r="B2"><v>12</v></c>`} {
+			if !strings.Contains(string(sheet), expected) {
+				t.Errorf("worksheet misses %s:\n%s", expected, sheet)
+			}
+		}
+		return
+	}
+	t.Error("XLSX output has no worksheet")
+}
+
+func TestRender_YAML(t *testing.T) {
+	output, err := String(testData(), Options{Format: YAML})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	for _, expected := range []string{"- email: alice@example.com\n", "  orders: 12\n", "- active: true\n", "  balance: 10.5\n"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("YAML output misses %q:\n%s", expected, output)
+		}
+	}
+}
+
+func TestRender_NestedValues(t *testing.T) {
+	data := []interface{}{map[string]interface{}{
+		"name": "Alice",
+		"tags": []string{"vip", "eu"},
+	}}
+	output, err := String(data, Options{Format: CSV})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	if output != "name,tags\nAlice,\"[\"\"vip\"\",\"\"eu\"\"]\"\n" {
+		t.Errorf("CSV output = %q", output)
+	}
+
+	if _, err := String([]interface{}{func() {}}, Options{}); err == nil {
+		t.Error("String() of an unencodable item did not fail")
+	}
+}
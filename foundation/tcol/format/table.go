@@ -0,0 +1,179 @@
+// File: table.go
+// Title: TCOL Terminal Table Writer
+// Description: Writes rows as aligned terminal tables with width limits and
+//              padding based on the display width of Unicode text.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the table writer
+
+package format
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Table layout
+const (
+	columnGap = "  "
+	ellipsis  = "…"
+)
+
+// writeTable writes a header, a rule, and one line per row. Results of
+// scalar items only are written one per line without a header.
+func writeTable(w io.Writer, rows []map[string]interface{}, columns []string, maxWidth int) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	cells := make([][]string, len(rows))
+	numeric := make([]bool, len(columns))
+	for i := range numeric {
+		numeric[i] = true
+	}
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			value := row[column]
+			cells[i][j] = truncate(cellText(value), maxWidth)
+			if value != nil && !isNumber(value) {
+				numeric[j] = false
+			}
+		}
+	}
+
+	var b strings.Builder
+	if len(columns) == 1 && columns[0] == valueColumn {
+		for _, line := range cells {
+			b.WriteString(line[0])
+			b.WriteByte('\n')
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	header := make([]string, len(columns))
+	widths := make([]int, len(columns))
+	for j, column := range columns {
+		header[j] = truncate(strings.ToUpper(column), maxWidth)
+		widths[j] = displayWidth(header[j])
+		for _, line := range cells {
+			widths[j] = max(widths[j], displayWidth(line[j]))
+		}
+	}
+
+	rule := make([]string, len(columns))
+	for j, width := range widths {
+		rule[j] = strings.Repeat("-", width)
+	}
+
+	writeLine(&b, header, widths, nil)
+	writeLine(&b, rule, widths, nil)
+	for _, line := range cells {
+		writeLine(&b, line, widths, numeric)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeLine writes one table line, right-aligning numeric columns and
+// leaving no trailing spaces
+func writeLine(b *strings.Builder, cells []string, widths []int, numeric []bool) {
+	var line strings.Builder
+	for j, cell := range cells {
+		if j > 0 {
+			line.WriteString(columnGap)
+		}
+		padding := strings.Repeat(" ", widths[j]-displayWidth(cell))
+		if numeric != nil && numeric[j] {
+			line.WriteString(padding + cell)
+		} else {
+			line.WriteString(cell + padding)
+		}
+	}
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteByte('\n')
+}
+
+// isNumber reports whether value is a number
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// truncate shortens s to at most maxWidth columns, ending in an ellipsis.
+// Line breaks and tabs are replaced by spaces to keep rows on one line.
+func truncate(s string, maxWidth int) string {
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ").Replace(s)
+	if maxWidth <= 0 || displayWidth(s) <= maxWidth {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if width+w > maxWidth-1 {
+			break
+		}
+		b.WriteRune(r)
+		width += w
+	}
+	b.WriteString(ellipsis)
+	return b.String()
+}
+
+// displayWidth returns the number of terminal columns s occupies
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the number of terminal columns r occupies: none for
+// combining marks and control characters, two for East Asian wide
+// characters and emoji, one otherwise
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r), unicode.IsControl(r), r == '\u200b':
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// isWide reports whether r is displayed with double width
+func isWide(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115f, // Hangul Jamo
+		r >= 0x2e80 && r <= 0x303e,   // CJK radicals and punctuation
+		r >= 0x3041 && r <= 0x33ff,   // Kana and CJK compatibility
+		r >= 0x3400 && r <= 0x4dbf,   // CJK extension A
+		r >= 0x4e00 && r <= 0x9fff,   // CJK unified ideographs
+		r >= 0xa000 && r <= 0xa4cf,   // Yi
+		r >= 0xac00 && r <= 0xd7a3,   // Hangul syllables
+		r >= 0xf900 && r <= 0xfaff,   // CJK compatibility ideographs
+		r >= 0xfe30 && r <= 0xfe4f,   // CJK compatibility forms
+		r >= 0xff00 && r <= 0xff60,   // Fullwidth forms
+		r >= 0xffe0 && r <= 0xffe6,   // Fullwidth signs
+		r >= 0x1f300 && r <= 0x1f64f, // Pictographs and emoticons
+		r >= 0x1f900 && r <= 0x1f9ff, // Supplemental pictographs
+		r >= 0x20000 && r <= 0x3fffd: // CJK extensions B and later
+		return true
+	default:
+		return false
+	}
+}
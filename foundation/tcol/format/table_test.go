@@ -0,0 +1,105 @@
+// File: table_test.go
+// Title: TCOL Terminal Table Writer Tests
+// Description: Tests table alignment, width limits, and display width of
+//              Unicode text.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial table writer tests
+
+package format
+
+import (
+	"testing"
+)
+
+func TestRender_Table(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{"name": "Alice", "orders": 12},
+		map[string]interface{}{"name": "Bob", "orders": 3, "email": "bob@example.com"},
+	}
+
+	output, err := String(data, Options{})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	expected := "" +
+		"EMAIL            NAME   ORDERS\n" +
+		"---------------  -----  ------\n" +
+		"                 Alice      12\n" +
+		"bob@example.com  Bob         3\n"
+	if output != expected {
+		t.Errorf("table =\n%s\nwant\n%s", output, expected)
+	}
+
+	output, _ = String(data, Options{Format: Table, Columns: []string{"orders", "name"}, MaxWidth: 4})
+	expected = "" +
+		"ORD…  NAME\n" +
+		"----  ----\n" +
+		"  12  Ali…\n" +
+		"   3  Bob\n"
+	if output != expected {
+		t.Errorf("table with columns =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRender_TableUnicode(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{"name": "東京", "city": "Zürich"},
+		map[string]interface{}{"name": "Tokyo", "city": "Bern"},
+	}
+
+	output, err := String(data, Options{})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	expected := "" +
+		"CITY    NAME\n" +
+		"------  -----\n" +
+		"Zürich  東京\n" +
+		"Bern    Tokyo\n"
+	if output != expected {
+		t.Errorf("table =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRender_TableScalars(t *testing.T) {
+	output, err := String([]interface{}{"first line", "second\tline"}, Options{})
+	if err != nil {
+		t.Fatalf("String() error = %v", err)
+	}
+	if output != "first line\nsecond line\n" {
+		t.Errorf("table of scalars = %q", output)
+	}
+	if output, _ := String(nil, Options{}); output != "" {
+		t.Errorf("table of no data = %q", output)
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"Grüße", 5},
+		{"é", 1},
+		{"日本語", 6},
+		{"ｆｕｌｌ", 8},
+		{"ok👍", 4},
+	}
+
+	for _, tt := range tests {
+		if got := displayWidth(tt.s); got != tt.expected {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.s, got, tt.expected)
+		}
+	}
+
+	if got := truncate("日本語テキスト", 5); got != "日本…" || displayWidth(got) > 5 {
+		t.Errorf("truncate() = %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+// File: doc.go
+// Title: TCOL Script Linter Package Documentation
+// Description: Static checks of TCOL scripts against the registry, with
+//              structured diagnostics for CI pipelines and the interactive
+//              shell.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial linter
+// - 2026-10-16 v0.1.1: Documented the deprecated rule
+
+/*
+Package lint checks TCOL scripts without executing them.
+
+A Linter parses a script and checks every command, including the stages of
+chains and the bodies of IF and FOREACH, against a registry:
+
+	linter, err := lint.New(engine.Registry(), lint.Options{})
+	if err != nil {
+		return err
+	}
+	diagnostics := linter.Lint(source)
+
+# Rules
+
+Each diagnostic names the rule that reported it:
+
+	syntax             error    the script does not parse
+	unknown-object     error    the object is not registered
+	unknown-method     error    the object has no such method or method version
+	abbreviation       warning  an abbreviation such as CUST.CR is used
+	missing-parameter  error    a required parameter is not given
+	deprecated         warning  the object or method version is deprecated
+	unfiltered-bulk    warning  UPDATE or DELETE without a filter, object ID or id
+
+Unknown objects and methods carry the registered commands that resemble
+them in Suggestions, abbreviations their full form. Options.Disable turns
+rules off, and Options.BulkMethods replaces the methods checked by
+unfiltered-bulk.
+
+# Output
+
+Diagnostics have a 1-based line and column and marshal to JSON for tools.
+Write writes them in the "file:line:column: severity: message [rule]" form
+that editors and CI systems recognize, and HasErrors tells whether a
+pipeline should fail:
+
+	lint.Write(os.Stderr, "nightly.tcol", diagnostics)
+	if lint.HasErrors(diagnostics) {
+		os.Exit(1)
+	}
+
+The interactive shell of package repl writes the warnings of each command
+before executing it.
+*/
+package lint
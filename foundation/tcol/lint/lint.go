@@ -0,0 +1,323 @@
+// File: lint.go
+// Title: TCOL Script Linter
+// Description: Checks TCOL scripts against the registry without executing
+//              them: syntax errors, unknown objects and methods, command
+//              abbreviations, missing required parameters, and updates or
+//              deletes without a filter. Reports structured diagnostics
+//              with source positions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.3
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial linter
+// - 2026-10-16 v0.1.1: Checks method versions and deprecations
+// - 2026-10-16 v0.1.2: Parameters of commands following IMPORT come from the file
+// - 2026-10-16 v0.1.3: Only the built-in IMPORT supplies parameters
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// Severity classifies a diagnostic
+type Severity string
+
+// Severities of diagnostics
+const (
+	SeverityError   Severity = "error"   // The script fails when it runs
+	SeverityWarning Severity = "warning" // The script runs but is likely wrong or dangerous
+)
+
+// Rules reported by the linter
+const (
+	RuleSyntax           = "syntax"
+	RuleUnknownObject    = "unknown-object"
+	RuleUnknownMethod    = "unknown-method"
+	RuleAbbreviation     = "abbreviation"
+	RuleMissingParameter = "missing-parameter"
+	RuleUnfilteredBulk   = "unfiltered-bulk"
+	RuleDeprecated       = "deprecated"
+)
+
+// maxSuggestions limits the commands suggested for unknown ones
+const maxSuggestions = 3
+
+// parseErrorPattern extracts the position from the text of parse errors
+var parseErrorPattern = regexp.MustCompile(`parse error at line (\d+), column (\d+): (.*)`)
+
+// Diagnostic is a problem found in a script
+type Diagnostic struct {
+	Rule        string   `json:"rule"`
+	Severity    Severity `json:"severity"`
+	Message     string   `json:"message"`
+	Line        int      `json:"line"`                  // 1-based; 0 if unknown
+	Column      int      `json:"column"`                // 1-based; 0 if unknown
+	Suggestions []string `json:"suggestions,omitempty"` // Replacements, best first
+}
+
+// String returns the diagnostic as "line:column: severity: message [rule]"
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s [%s]", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Options configures a Linter
+type Options struct {
+	// Disable lists rules that are not reported
+	Disable []string
+
+	// BulkMethods are the methods that change every object matched when
+	// they have neither a filter, an object ID, nor an id parameter
+	// (default: UPDATE and DELETE)
+	BulkMethods []string
+
+	// Logger for linting (optional, defaults to default logger)
+	Logger *mdwlog.Logger
+}
+
+// Linter checks scripts against the objects and methods of a registry
+type Linter struct {
+	registry *mdwregistry.Registry
+	parser   *mdwparser.Parser
+	disabled map[string]bool
+	bulk     map[string]bool
+	options  Options
+}
+
+// New creates a linter for the commands registered in registry
+func New(registry *mdwregistry.Registry, opts Options) (*Linter, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("registry is required")
+	}
+	if opts.Logger == nil {
+		opts.Logger = mdwlog.GetDefault()
+	}
+	if len(opts.BulkMethods) == 0 {
+		opts.BulkMethods = []string{"UPDATE", "DELETE"}
+	}
+
+	parser, err := mdwparser.New(mdwparser.Options{
+		Logger:         opts.Logger.WithField("component", "tcol-lint"),
+		EnableChaining: true,
+		Registry:       registry,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	linter := &Linter{
+		registry: registry,
+		parser:   parser,
+		disabled: make(map[string]bool, len(opts.Disable)),
+		bulk:     make(map[string]bool, len(opts.BulkMethods)),
+		options:  opts,
+	}
+	for _, rule := range opts.Disable {
+		linter.disabled[rule] = true
+	}
+	for _, method := range opts.BulkMethods {
+		linter.bulk[strings.ToUpper(method)] = true
+	}
+	return linter, nil
+}
+
+// Lint parses a script and checks it. A script that does not parse yields
+// a single syntax diagnostic. Diagnostics are ordered by position.
+func (l *Linter) Lint(source string) []Diagnostic {
+	script, err := l.parser.ParseScript(source)
+	if err != nil {
+		return l.filter([]Diagnostic{syntaxDiagnostic(err)})
+	}
+	return l.LintScript(script)
+}
+
+// LintScript checks a parsed script. Diagnostics are ordered by position.
+func (l *Linter) LintScript(script *mdwast.Script) []Diagnostic {
+	var diagnostics []Diagnostic
+	imported := make(map[*mdwast.Command]bool) // Stages that run once per imported row
+	mdwast.Walk(script, func(node mdwast.Node) bool {
+		if cmd, ok := node.(*mdwast.Command); ok {
+			if strings.EqualFold(cmd.Object, "IMPORT") && cmd.Chain != nil && l.builtinImport() {
+				imported[cmd.Chain] = true
+			}
+			diagnostics = append(diagnostics, l.checkCommand(cmd, imported[cmd])...)
+		}
+		return true
+	})
+
+	diagnostics = l.filter(diagnostics)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
+
+// builtinImport reports whether IMPORT is the built-in object rather than
+// a service object registered in its place
+func (l *Linter) builtinImport() bool {
+	obj, err := l.registry.GetObject("IMPORT")
+	return err == nil && obj.Service == mdwregistry.InternalService
+}
+
+// checkCommand checks a single stage; its chain is visited by the walk.
+// Required parameters of a stage following IMPORT may come from the file.
+func (l *Linter) checkCommand(cmd *mdwast.Command, imported bool) []Diagnostic {
+	if cmd.Transform != nil || cmd.Object == "" {
+		return nil // Local pipe stages need no registry
+	}
+	at := func(rule string, severity Severity, message string, suggestions ...string) Diagnostic {
+		return Diagnostic{
+			Rule:        rule,
+			Severity:    severity,
+			Message:     message,
+			Line:        cmd.Pos.Line,
+			Column:      cmd.Pos.Column,
+			Suggestions: suggestions,
+		}
+	}
+
+	object, method := strings.ToUpper(cmd.Object), strings.ToUpper(cmd.Method)
+	if method == "" {
+		// OBJECT:ID and field operations only need a known object
+		if !l.registry.HasObject(object) {
+			return []Diagnostic{at(RuleUnknownObject, SeverityError,
+				fmt.Sprintf("unknown object %s", object), l.suggest(object, "")...)}
+		}
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+	if !l.registry.HasMethod(object, method) {
+		command := object + "." + method
+		full := strings.ToUpper(l.registry.ExpandAbbreviation(command))
+		expandedObject, expandedMethod, _ := strings.Cut(full, ".")
+		switch {
+		case full != command && l.registry.HasMethod(expandedObject, expandedMethod):
+			diagnostics = append(diagnostics, at(RuleAbbreviation, SeverityWarning,
+				fmt.Sprintf("abbreviation %s is deprecated in scripts, use %s", command, full), full))
+			object, method = expandedObject, expandedMethod
+		case !l.registry.HasObject(object):
+			return []Diagnostic{at(RuleUnknownObject, SeverityError,
+				fmt.Sprintf("unknown object %s", object), l.suggest(object, method)...)}
+		default:
+			return []Diagnostic{at(RuleUnknownMethod, SeverityError,
+				fmt.Sprintf("unknown method %s for object %s", method, object), l.suggest(object, method)...)}
+		}
+	}
+
+	definition, err := l.registry.GetMethodVersion(object, method, cmd.Version)
+	if err != nil {
+		return append(diagnostics, at(RuleUnknownMethod, SeverityError, err.Error()))
+	}
+	for _, warning := range l.registry.Deprecations(object, method, cmd.Version) {
+		diagnostics = append(diagnostics, at(RuleDeprecated, SeverityWarning, warning))
+	}
+	for _, name := range sortedParameters(definition.Parameters) {
+		param := definition.Parameters[name]
+		if _, given := cmd.Parameters[name]; param.Required && !given && !imported {
+			diagnostics = append(diagnostics, at(RuleMissingParameter, SeverityError,
+				fmt.Sprintf("%s.%s requires parameter %s", object, method, name)))
+		}
+	}
+
+	if _, hasID := cmd.Parameters["id"]; l.bulk[method] && cmd.Filter == nil && cmd.ObjectID == "" && !hasID {
+		diagnostics = append(diagnostics, at(RuleUnfilteredBulk, SeverityWarning,
+			fmt.Sprintf("%s.%s without filter or id applies to every %s", object, method, object),
+			fmt.Sprintf("%s[...].%s", object, method)))
+	}
+	return diagnostics
+}
+
+// suggest returns the registered commands resembling an unknown one
+func (l *Linter) suggest(object, method string) []string {
+	suggestions := l.registry.Suggest(object, method, maxSuggestions)
+	commands := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		commands[i] = suggestion.Command
+	}
+	return commands
+}
+
+// filter removes the diagnostics of disabled rules
+func (l *Linter) filter(diagnostics []Diagnostic) []Diagnostic {
+	if len(l.disabled) == 0 {
+		return diagnostics
+	}
+	kept := diagnostics[:0]
+	for _, diagnostic := range diagnostics {
+		if !l.disabled[diagnostic.Rule] {
+			kept = append(kept, diagnostic)
+		}
+	}
+	return kept
+}
+
+// syntaxDiagnostic converts a parse error into a diagnostic
+func syntaxDiagnostic(err error) Diagnostic {
+	diagnostic := Diagnostic{Rule: RuleSyntax, Severity: SeverityError, Message: err.Error()}
+	var parseErr *mdwparser.ParseError
+	if errors.As(err, &parseErr) {
+		diagnostic.Message = parseErr.Message
+		diagnostic.Line, diagnostic.Column = parseErr.Line, parseErr.Column
+	} else if match := parseErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		diagnostic.Line, _ = strconv.Atoi(match[1])
+		diagnostic.Column, _ = strconv.Atoi(match[2])
+		diagnostic.Message = match[3]
+	}
+	return diagnostic
+}
+
+// sortedParameters returns the names of parameters in sorted order
+func sortedParameters(params map[string]*mdwregistry.ParameterDefinition) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasErrors reports whether any diagnostic is an error
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes one diagnostic per line, prefixed with name (e.g. the file
+// name) if it is not empty, in the "name:line:column: severity: message"
+// form that editors and CI systems recognize
+func Write(w io.Writer, name string, diagnostics []Diagnostic) error {
+	for _, diagnostic := range diagnostics {
+		line := diagnostic.String()
+		if name != "" {
+			line = name + ":" + line
+		}
+		if len(diagnostic.Suggestions) > 0 {
+			line += " (did you mean " + strings.Join(diagnostic.Suggestions, " or ") + "?)"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
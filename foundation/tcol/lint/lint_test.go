@@ -0,0 +1,176 @@
+// File: lint_test.go
+// Title: TCOL Script Linter Tests
+// Description: Tests the rules of the linter, the positions and
+//              suggestions of its diagnostics, and their text output.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial linter tests
+// - 2026-10-16 v0.1.1: Added method version tests
+// - 2026-10-16 v0.1.2: Added IMPORT batch tests
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// newLinter creates a linter for a registry with a CUSTOMER object
+func newLinter(t *testing.T, opts Options) *Linter {
+	registry, err := mdwregistry.NewSimple(mdwregistry.Options{EnableAbbreviations: true})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"CREATE": {Name: "CREATE", Parameters: map[string]*mdwregistry.ParameterDefinition{
+				"name":  {Name: "name", Type: "string", Required: true},
+				"email": {Name: "email", Type: "string"},
+			}},
+			"LIST": {Name: "LIST", Version: 2, Versions: []*mdwregistry.MethodDefinition{{
+				Version:     1,
+				Deprecation: &mdwregistry.Deprecation{Replacement: "CUSTOMER.LIST@v2"},
+			}}},
+			"UPDATE": {Name: "UPDATE"},
+			"DELETE": {Name: "DELETE"},
+		},
+	})
+
+	linter, err := New(registry, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return linter
+}
+
+// rules returns the rules of diagnostics
+func rules(diagnostics []Diagnostic) []string {
+	result := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		result[i] = diagnostic.Rule
+	}
+	return result
+}
+
+func TestLinter_Rules(t *testing.T) {
+	linter := newLinter(t, Options{})
+	testCases := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{"valid", `CUSTOMER.CREATE name="Acme"`, nil},
+		{"object id", `CUSTOMER:42`, nil},
+		{"syntax", `CUSTOMER.CREATE name=`, []string{RuleSyntax}},
+		{"unknown object", `ORDER.LIST`, []string{RuleUnknownObject}},
+		{"unknown method", `CUSTOMER.LSIT`, []string{RuleUnknownMethod}},
+		{"abbreviation", `CUST.CR name="Acme"`, []string{RuleAbbreviation}},
+		{"missing parameter", `CUSTOMER.CREATE email="a@acme.com"`, []string{RuleMissingParameter}},
+		{"abbreviation and missing parameter", `CUST.CR`, []string{RuleAbbreviation, RuleMissingParameter}},
+		{"unfiltered update", `CUSTOMER.UPDATE status="inactive"`, []string{RuleUnfilteredBulk}},
+		{"unfiltered delete", `CUSTOMER.DELETE`, []string{RuleUnfilteredBulk}},
+		{"filtered update", `CUSTOMER[status="new"].UPDATE status="inactive"`, nil},
+		{"update by id", `CUSTOMER.UPDATE id=42 status="inactive"`, nil},
+		{"chain", `CUSTOMER.LIST | ORDER.LIST`, []string{RuleUnknownObject}},
+		{"current version", `CUSTOMER.LIST@v2`, nil},
+		{"deprecated version", `CUSTOMER.LIST@v1`, []string{RuleDeprecated}},
+		{"unknown version", `CUSTOMER.LIST@v3`, []string{RuleUnknownMethod}},
+		{"import batch", `IMPORT.CSV file="new.csv" | CUSTOMER.CREATE`, nil},
+		{"export", `CUSTOMER.LIST | EXPORT.CSV`, []string{RuleMissingParameter}},
+	}
+
+	for _, tc := range testCases {
+		got := rules(linter.Lint(tc.source))
+		if strings.Join(got, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("%s: Lint(%q) rules = %v, want %v", tc.name, tc.source, got, tc.expected)
+		}
+	}
+}
+
+func TestLinter_Script(t *testing.T) {
+	linter := newLinter(t, Options{})
+	source := strings.Join([]string{
+		`LET names = CUSTOMER.LIST`,
+		`FOREACH n IN $names DO`,
+		`  CUSTOMER.UPDTE status="active"`,
+		`END`,
+		`CUSTOMER.DELETE`,
+	}, "\n")
+
+	diagnostics := linter.Lint(source)
+	if len(diagnostics) != 2 {
+		t.Fatalf("Lint() = %v, want 2 diagnostics", diagnostics)
+	}
+	first, second := diagnostics[0], diagnostics[1]
+	if first.Rule != RuleUnknownMethod || first.Line != 3 || first.Severity != SeverityError {
+		t.Errorf("first diagnostic = %+v", first)
+	}
+	if len(first.Suggestions) == 0 || first.Suggestions[0] != "CUSTOMER.UPDATE" {
+		t.Errorf("suggestions = %v, want CUSTOMER.UPDATE first", first.Suggestions)
+	}
+	if second.Rule != RuleUnfilteredBulk || second.Line != 5 || second.Severity != SeverityWarning {
+		t.Errorf("second diagnostic = %+v", second)
+	}
+	if !HasErrors(diagnostics) || HasErrors(diagnostics[1:]) {
+		t.Error("HasErrors() does not distinguish errors from warnings")
+	}
+}
+
+func TestLinter_SyntaxPosition(t *testing.T) {
+	linter := newLinter(t, Options{})
+	diagnostics := linter.Lint("CUSTOMER.LIST\nCUSTOMER.CREATE name=")
+	if len(diagnostics) != 1 || diagnostics[0].Line != 2 || diagnostics[0].Column == 0 {
+		t.Fatalf("Lint() = %v, want a syntax error in line 2", diagnostics)
+	}
+	if strings.Contains(diagnostics[0].Message, "parse error at") {
+		t.Errorf("message repeats the position: %q", diagnostics[0].Message)
+	}
+}
+
+func TestLinter_Options(t *testing.T) {
+	linter := newLinter(t, Options{
+		Disable:     []string{RuleAbbreviation},
+		BulkMethods: []string{"delete"},
+	})
+	got := rules(linter.Lint("CUST.CR name=\"Acme\"\nCUSTOMER.UPDATE\nCUSTOMER.DELETE"))
+	if len(got) != 1 || got[0] != RuleUnfilteredBulk {
+		t.Errorf("rules = %v, want only the unfiltered DELETE", got)
+	}
+
+	if _, err := New(nil, Options{}); err == nil {
+		t.Error("New() without registry succeeded")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	linter := newLinter(t, Options{})
+	diagnostics := linter.Lint(`CUST.CR name="Acme"`)
+
+	var out bytes.Buffer
+	if err := Write(&out, "import.tcol", diagnostics); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	expected := "import.tcol:1:1: warning: abbreviation CUST.CR is deprecated in scripts, use CUSTOMER.CREATE" +
+		" [abbreviation] (did you mean CUSTOMER.CREATE?)\n"
+	if out.String() != expected {
+		t.Errorf("Write() = %q, want %q", out.String(), expected)
+	}
+
+	data, err := json.Marshal(diagnostics[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"severity":"warning"`) || !strings.Contains(string(data), `"line":1`) {
+		t.Errorf("JSON = %s", data)
+	}
+}
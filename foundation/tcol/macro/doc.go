@@ -0,0 +1,48 @@
+// File: doc.go
+// Title: TCOL Filter Macro Package Documentation
+// Description: Implements user-defined filter functions (macros) for TCOL.
+//              Macros are written in the restricted TCOL filter expression
+//              language, expanded inside [filters] before execution, and can
+//              be evaluated in-process by a bounded expression VM.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial filter macro library and expression VM
+
+/*
+Package macro provides named filter macros for TCOL commands.
+
+Administrators register reusable business predicates as plain data instead of
+shipping Go code:
+
+	lib, _ := macro.New(macro.Options{})
+	lib.Register(macro.Definition{
+		Name:   "is_overdue",
+		Body:   `due_date < today() AND status != "paid"`,
+	})
+	lib.Register(macro.Definition{
+		Name:   "is_large",
+		Params: []string{"limit"},
+		Body:   "total > limit",
+	})
+
+Such macros can then be used inside any filter:
+
+	INVOICE[is_overdue() AND is_large(1000)].SEND-REMINDER
+
+Safety guarantees:
+  - The body language is the TCOL filter expression grammar only; there is no
+    I/O, assignment, or looping construct.
+  - A macro may only call built-in pure functions and previously registered
+    macros, so recursion is impossible by construction.
+  - Expansion is bounded by a maximum node count and evaluation by a maximum
+    number of VM steps.
+
+Pure built-in calls whose arguments are constant (for example today()) are
+folded into literals during expansion, so downstream services only receive
+plain field comparisons.
+*/
+package macro
@@ -0,0 +1,588 @@
+// File: macro.go
+// Title: TCOL Filter Macro Library
+// Description: Implements registration, validation, and expansion of named
+//              filter macros. Macro bodies are parsed with the TCOL filter
+//              grammar, restricted to pure built-ins and earlier macros, and
+//              substituted into filter expressions with bounded size.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-15
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial filter macro library
+// - 2026-10-16 v0.1.1: Accept BETWEEN and IS NULL expressions
+
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// Default limits for macro definitions and evaluation
+const (
+	defaultMaxBodyLength     = 1024
+	defaultMaxExpansionNodes = 512
+	defaultMaxSteps          = 10000
+)
+
+// Definition describes a filter macro as supplied by an administrator
+type Definition struct {
+	Name        string   `json:"name" yaml:"name" toml:"name"`                                          // Macro name (e.g., "is_overdue")
+	Params      []string `json:"params,omitempty" yaml:"params,omitempty" toml:"params"`                // Parameter names
+	Body        string   `json:"body" yaml:"body" toml:"body"`                                          // Filter expression body
+	Description string   `json:"description,omitempty" yaml:"description,omitempty" toml:"description"` // Human-readable description
+}
+
+// Macro is a validated, registered filter macro
+type Macro struct {
+	Definition
+	body     mdwast.Expr // Body with nested macros expanded and parameters as placeholders
+	requires []string    // Macros referenced by the body
+}
+
+// Options configures the macro library
+type Options struct {
+	Logger            *mdwlog.Logger
+	MaxBodyLength     int              // Maximum length of a macro body (default: 1024)
+	MaxExpansionNodes int              // Maximum AST nodes after expansion (default: 512)
+	MaxSteps          int              // Maximum VM steps per evaluation (default: 10000)
+	Now               func() time.Time // Clock used by today()/now() (default: time.Now)
+}
+
+// Library stores filter macros and expands them inside filter expressions
+type Library struct {
+	macros  map[string]*Macro
+	parser  *mdwparser.Parser
+	logger  *mdwlog.Logger
+	options Options
+	mutex   sync.RWMutex
+}
+
+// New creates a new, empty filter macro library
+func New(opts Options) (*Library, error) {
+	// Set defaults
+	if opts.Logger == nil {
+		opts.Logger = mdwlog.GetDefault()
+	}
+	if opts.MaxBodyLength == 0 {
+		opts.MaxBodyLength = defaultMaxBodyLength
+	}
+	if opts.MaxExpansionNodes == 0 {
+		opts.MaxExpansionNodes = defaultMaxExpansionNodes
+	}
+	if opts.MaxSteps == 0 {
+		opts.MaxSteps = defaultMaxSteps
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	logger := opts.Logger.WithField("component", "tcol-macro")
+
+	p, err := mdwparser.New(mdwparser.Options{
+		Logger:         logger,
+		MaxInputLength: opts.MaxBodyLength,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize macro parser: %w", err)
+	}
+
+	return &Library{
+		macros:  make(map[string]*Macro),
+		parser:  p,
+		logger:  logger,
+		options: opts,
+	}, nil
+}
+
+// Register validates and registers a filter macro. The body may reference its
+// parameters, record fields, built-in functions, and previously registered
+// macros only.
+func (l *Library) Register(def Definition) error {
+	name := strings.ToLower(strings.TrimSpace(def.Name))
+	if mdwstringx.IsBlank(name) {
+		return errors.New("macro name cannot be empty")
+	}
+	if !mdwparser.IsValidIdentifier(name) || mdwparser.IsKeyword(name) {
+		return fmt.Errorf("invalid macro name: %s", def.Name)
+	}
+	if IsBuiltin(name) {
+		return fmt.Errorf("macro name %s shadows a built-in function", name)
+	}
+	if mdwstringx.IsBlank(def.Body) {
+		return fmt.Errorf("macro %s: body cannot be empty", name)
+	}
+	if len(def.Body) > l.options.MaxBodyLength {
+		return fmt.Errorf("macro %s: body exceeds maximum length: %d > %d",
+			name, len(def.Body), l.options.MaxBodyLength)
+	}
+
+	params := make(map[string]bool, len(def.Params))
+	for _, param := range def.Params {
+		if !mdwparser.IsValidIdentifier(param) || mdwparser.IsKeyword(param) {
+			return fmt.Errorf("macro %s: invalid parameter name: %s", name, param)
+		}
+		if params[param] {
+			return fmt.Errorf("macro %s: duplicate parameter: %s", name, param)
+		}
+		params[param] = true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, exists := l.macros[name]; exists {
+		return fmt.Errorf("macro %s already registered", name)
+	}
+
+	// The library parser is shared and not reentrant
+	body, err := l.parser.ParseExpression(def.Body)
+	if err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+
+	requires := make(map[string]bool)
+	if err := l.checkBody(body, requires); err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+
+	// Parameters are renamed to placeholders that cannot collide with field
+	// names used by nested macro bodies
+	placeholders := make(map[string]mdwast.Expr, len(def.Params))
+	for i, param := range def.Params {
+		placeholders[param] = &mdwast.IdentifierExpr{Name: placeholder(i)}
+	}
+	budget := l.options.MaxExpansionNodes
+	expanded, err := l.substitute(body, placeholders, &budget)
+	if err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+
+	// Compile once to reject constructs the VM cannot evaluate
+	if _, err := Compile(expanded); err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+
+	def.Name = name
+	def.Params = append([]string(nil), def.Params...)
+	m := &Macro{
+		Definition: def,
+		body:       expanded,
+		requires:   sortedKeys(requires),
+	}
+	l.macros[name] = m
+
+	l.logger.Info("TCOL filter macro registered", mdwlog.Fields{
+		"macro":    name,
+		"params":   len(def.Params),
+		"requires": m.requires,
+	})
+
+	return nil
+}
+
+// Unregister removes a macro. Macros that other macros depend on cannot be
+// removed.
+func (l *Library) Unregister(name string) error {
+	name = strings.ToLower(name)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, exists := l.macros[name]; !exists {
+		return fmt.Errorf("macro %s not found", name)
+	}
+
+	for other, m := range l.macros {
+		for _, dep := range m.requires {
+			if dep == name {
+				return fmt.Errorf("macro %s is used by macro %s", name, other)
+			}
+		}
+	}
+
+	delete(l.macros, name)
+	return nil
+}
+
+// Get returns a copy of the definition of a registered macro
+func (l *Library) Get(name string) (Definition, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	m, exists := l.macros[strings.ToLower(name)]
+	if !exists {
+		return Definition{}, false
+	}
+	return m.Definition, true
+}
+
+// Has reports whether a macro is registered
+func (l *Library) Has(name string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	_, exists := l.macros[strings.ToLower(name)]
+	return exists
+}
+
+// Names returns the sorted names of all registered macros
+func (l *Library) Names() []string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	names := make([]string, 0, len(l.macros))
+	for name := range l.macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Definitions returns all registered macro definitions sorted by name
+func (l *Library) Definitions() []Definition {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	defs := make([]Definition, 0, len(l.macros))
+	for _, m := range l.macros {
+		defs = append(defs, m.Definition)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// Expand replaces macro calls in an expression with their bodies and folds
+// constant built-in calls. The input expression is not modified.
+func (l *Library) Expand(expr mdwast.Expr) (mdwast.Expr, error) {
+	if expr == nil {
+		return nil, errors.New("expression cannot be nil")
+	}
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	expanded, err := l.expand(expr)
+	if err != nil {
+		return nil, err
+	}
+	return l.fold(expanded), nil
+}
+
+// ExpandFilter expands the macros of a filter, returning a new filter
+func (l *Library) ExpandFilter(filter *mdwast.FilterExpr) (*mdwast.FilterExpr, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	condition, err := l.Expand(filter.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("filter macro expansion: %w", err)
+	}
+
+	return &mdwast.FilterExpr{
+		Condition: condition,
+		Pos:       filter.Pos,
+	}, nil
+}
+
+// Evaluate expands and evaluates an expression against a record in-process
+// and reports whether the record matches
+func (l *Library) Evaluate(expr mdwast.Expr, record map[string]interface{}) (bool, error) {
+	expanded, err := l.Expand(expr)
+	if err != nil {
+		return false, err
+	}
+
+	prog, err := Compile(expanded)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := prog.run(record, l.options.MaxSteps, l.options.Now)
+	if err != nil {
+		return false, err
+	}
+	return truthy(value), nil
+}
+
+// checkBody verifies that a macro body only uses the restricted grammar and
+// records the macros it references. Must be called with the lock held.
+func (l *Library) checkBody(expr mdwast.Expr, requires map[string]bool) error {
+	switch e := expr.(type) {
+	case *mdwast.LiteralExpr, *mdwast.IdentifierExpr:
+		return nil
+
+	case *mdwast.UnaryExpr:
+		return l.checkBody(e.Expr, requires)
+
+	case *mdwast.BinaryExpr:
+		if err := l.checkBody(e.Left, requires); err != nil {
+			return err
+		}
+		return l.checkBody(e.Right, requires)
+
+	case *mdwast.ArrayExpr:
+		for _, elem := range e.Elements {
+			if err := l.checkBody(elem, requires); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *mdwast.BetweenExpr:
+		for _, operand := range []mdwast.Expr{e.Expr, e.Low, e.High} {
+			if err := l.checkBody(operand, requires); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *mdwast.IsNullExpr:
+		return l.checkBody(e.Expr, requires)
+
+	case *mdwast.FunctionCallExpr:
+		name := strings.ToLower(e.Name)
+		if fn, exists := builtins[name]; exists {
+			if err := fn.checkArity(name, len(e.Args)); err != nil {
+				return err
+			}
+		} else if m, exists := l.macros[name]; exists {
+			if len(e.Args) != len(m.Params) {
+				return fmt.Errorf("macro %s expects %d argument(s), got %d", name, len(m.Params), len(e.Args))
+			}
+			requires[name] = true
+		} else {
+			return fmt.Errorf("unknown function: %s", e.Name)
+		}
+		for _, arg := range e.Args {
+			if err := l.checkBody(arg, requires); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported expression in macro body: %T", expr)
+	}
+}
+
+// expand substitutes registered macros and enforces the node limit. Must be
+// called with the lock held.
+func (l *Library) expand(expr mdwast.Expr) (mdwast.Expr, error) {
+	budget := l.options.MaxExpansionNodes
+	return l.substitute(expr, nil, &budget)
+}
+
+func (l *Library) substitute(expr mdwast.Expr, bindings map[string]mdwast.Expr, budget *int) (mdwast.Expr, error) {
+	*budget--
+	if *budget < 0 {
+		return nil, fmt.Errorf("expanded filter exceeds maximum size of %d nodes", l.options.MaxExpansionNodes)
+	}
+
+	switch e := expr.(type) {
+	case *mdwast.IdentifierExpr:
+		if bound, ok := bindings[e.Name]; ok {
+			return l.substitute(bound, nil, budget)
+		}
+		return &mdwast.IdentifierExpr{Name: e.Name, Pos: e.Pos}, nil
+
+	case *mdwast.LiteralExpr:
+		return &mdwast.LiteralExpr{Value: e.Value, Pos: e.Pos}, nil
+
+	case *mdwast.UnaryExpr:
+		inner, err := l.substitute(e.Expr, bindings, budget)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.UnaryExpr{Op: e.Op, Expr: inner, Pos: e.Pos}, nil
+
+	case *mdwast.BinaryExpr:
+		left, err := l.substitute(e.Left, bindings, budget)
+		if err != nil {
+			return nil, err
+		}
+		right, err := l.substitute(e.Right, bindings, budget)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.BinaryExpr{Left: left, Op: e.Op, Right: right, Pos: e.Pos}, nil
+
+	case *mdwast.ArrayExpr:
+		elems := make([]mdwast.Expr, len(e.Elements))
+		for i, elem := range e.Elements {
+			expanded, err := l.substitute(elem, bindings, budget)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = expanded
+		}
+		return &mdwast.ArrayExpr{Elements: elems, Pos: e.Pos}, nil
+
+	case *mdwast.BetweenExpr:
+		operands := make([]mdwast.Expr, 3)
+		for i, operand := range []mdwast.Expr{e.Expr, e.Low, e.High} {
+			expanded, err := l.substitute(operand, bindings, budget)
+			if err != nil {
+				return nil, err
+			}
+			operands[i] = expanded
+		}
+		return &mdwast.BetweenExpr{Expr: operands[0], Low: operands[1], High: operands[2], Pos: e.Pos}, nil
+
+	case *mdwast.IsNullExpr:
+		inner, err := l.substitute(e.Expr, bindings, budget)
+		if err != nil {
+			return nil, err
+		}
+		return &mdwast.IsNullExpr{Expr: inner, Pos: e.Pos}, nil
+
+	case *mdwast.ObjectExpr:
+		fields := make(map[string]mdwast.Expr, len(e.Fields))
+		for key, value := range e.Fields {
+			expanded, err := l.substitute(value, bindings, budget)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = expanded
+		}
+		return &mdwast.ObjectExpr{Fields: fields, Pos: e.Pos}, nil
+
+	case *mdwast.FunctionCallExpr:
+		args := make([]mdwast.Expr, len(e.Args))
+		for i, arg := range e.Args {
+			expanded, err := l.substitute(arg, bindings, budget)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = expanded
+		}
+
+		m, isMacro := l.macros[strings.ToLower(e.Name)]
+		if !isMacro {
+			// Built-ins and service-side functions pass through unchanged
+			return &mdwast.FunctionCallExpr{Name: e.Name, Args: args, Pos: e.Pos}, nil
+		}
+		if len(args) != len(m.Params) {
+			return nil, fmt.Errorf("macro %s expects %d argument(s), got %d", m.Name, len(m.Params), len(args))
+		}
+
+		// Arguments are already expanded, so the body is substituted with
+		// plain bindings; nested macros were expanded at registration time
+		callBindings := make(map[string]mdwast.Expr, len(args))
+		for i := range m.Params {
+			callBindings[placeholder(i)] = args[i]
+		}
+		return l.substitute(m.body, callBindings, budget)
+
+	default:
+		return nil, fmt.Errorf("unsupported expression in filter: %T", expr)
+	}
+}
+
+// fold replaces built-in calls with constant arguments by their value so that
+// services receive plain literals (e.g. today() becomes a date literal)
+func (l *Library) fold(expr mdwast.Expr) mdwast.Expr {
+	switch e := expr.(type) {
+	case *mdwast.UnaryExpr:
+		e.Expr = l.fold(e.Expr)
+	case *mdwast.BinaryExpr:
+		e.Left = l.fold(e.Left)
+		e.Right = l.fold(e.Right)
+	case *mdwast.ArrayExpr:
+		for i, elem := range e.Elements {
+			e.Elements[i] = l.fold(elem)
+		}
+	case *mdwast.BetweenExpr:
+		e.Expr = l.fold(e.Expr)
+		e.Low = l.fold(e.Low)
+		e.High = l.fold(e.High)
+	case *mdwast.IsNullExpr:
+		e.Expr = l.fold(e.Expr)
+	case *mdwast.FunctionCallExpr:
+		constant := true
+		for i, arg := range e.Args {
+			e.Args[i] = l.fold(arg)
+			if _, ok := e.Args[i].(*mdwast.LiteralExpr); !ok {
+				constant = false
+			}
+		}
+		if !constant || !IsBuiltin(e.Name) {
+			return e
+		}
+
+		prog, err := Compile(e)
+		if err != nil {
+			return e
+		}
+		value, err := prog.run(nil, l.options.MaxSteps, l.options.Now)
+		if err != nil {
+			return e
+		}
+		if literal, ok := toLiteral(value, e.Pos); ok {
+			return literal
+		}
+	}
+	return expr
+}
+
+// toLiteral converts a VM value back into an AST literal
+func toLiteral(value interface{}, pos mdwast.Position) (*mdwast.LiteralExpr, bool) {
+	v := mdwast.Value{Pos: pos, Value: value}
+
+	switch val := value.(type) {
+	case nil:
+		v.Type = mdwast.ValueTypeNull
+		v.Raw = "null"
+	case string:
+		v.Type = mdwast.ValueTypeString
+		v.Raw = val
+	case bool:
+		v.Type = mdwast.ValueTypeBoolean
+		v.Raw = fmt.Sprintf("%t", val)
+	case int64:
+		v.Type = mdwast.ValueTypeNumber
+		v.Raw = fmt.Sprintf("%d", val)
+	case float64:
+		v.Type = mdwast.ValueTypeNumber
+		v.Raw = fmt.Sprintf("%g", val)
+	case time.Time:
+		if val.Hour() == 0 && val.Minute() == 0 && val.Second() == 0 && val.Nanosecond() == 0 {
+			v.Type = mdwast.ValueTypeDate
+			v.Raw = val.Format("2006-01-02")
+		} else {
+			v.Type = mdwast.ValueTypeTime
+			v.Raw = val.Format(time.RFC3339)
+		}
+	default:
+		return nil, false
+	}
+
+	return &mdwast.LiteralExpr{Value: v, Pos: pos}, true
+}
+
+// placeholder returns the internal identifier for the i-th macro parameter
+func placeholder(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
+
+// sortedKeys returns the sorted keys of a set
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,300 @@
+// File: macro_test.go
+// Title: TCOL Filter Macro Library Tests
+// Description: Unit tests for macro registration, validation, expansion,
+//              constant folding, and in-process evaluation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial macro library tests
+
+package macro
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+)
+
+var fixedNow = time.Date(2025, 3, 15, 10, 30, 0, 0, time.UTC)
+
+func newTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	lib, err := New(Options{Now: func() time.Time { return fixedNow }})
+	if err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+	return lib
+}
+
+func parseFilter(t *testing.T, input string) mdwast.Expr {
+	t.Helper()
+	p, err := mdwparser.New(mdwparser.Options{})
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	expr, err := p.ParseExpression(input)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", input, err)
+	}
+	return expr
+}
+
+func TestLibrary_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     Definition
+		wantErr string
+	}{
+		{
+			name: "Simple macro",
+			def:  Definition{Name: "is_active", Body: `status = "active"`},
+		},
+		{
+			name: "Macro with parameters",
+			def:  Definition{Name: "above", Params: []string{"limit"}, Body: "total > limit"},
+		},
+		{
+			name:    "Empty name",
+			def:     Definition{Body: "a = 1"},
+			wantErr: "name cannot be empty",
+		},
+		{
+			name:    "Invalid name",
+			def:     Definition{Name: "9lives", Body: "a = 1"},
+			wantErr: "invalid macro name",
+		},
+		{
+			name:    "Shadows built-in",
+			def:     Definition{Name: "today", Body: "a = 1"},
+			wantErr: "shadows a built-in",
+		},
+		{
+			name:    "Empty body",
+			def:     Definition{Name: "empty", Body: "  "},
+			wantErr: "body cannot be empty",
+		},
+		{
+			name:    "Duplicate parameter",
+			def:     Definition{Name: "dup", Params: []string{"a", "a"}, Body: "x = a"},
+			wantErr: "duplicate parameter",
+		},
+		{
+			name:    "Syntax error",
+			def:     Definition{Name: "broken", Body: "a = "},
+			wantErr: "parse error",
+		},
+		{
+			name:    "Unknown function",
+			def:     Definition{Name: "calls_unknown", Body: "read_file(path)"},
+			wantErr: "unknown function",
+		},
+		{
+			name:    "Self recursion",
+			def:     Definition{Name: "loop", Body: "loop()"},
+			wantErr: "unknown function",
+		},
+		{
+			name:    "Object literal",
+			def:     Definition{Name: "obj", Body: "{a: 1}"},
+			wantErr: "unsupported expression",
+		},
+		{
+			name:    "Built-in arity",
+			def:     Definition{Name: "bad_arity", Body: "lower(a, b) = 1"},
+			wantErr: "expects 1 argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lib := newTestLibrary(t)
+			err := lib.Register(tt.def)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !lib.Has(tt.def.Name) {
+					t.Error("Expected macro to be registered")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLibrary_RegisterDuplicate(t *testing.T) {
+	lib := newTestLibrary(t)
+	def := Definition{Name: "Is_Open", Body: `status = "open"`}
+
+	if err := lib.Register(def); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := lib.Register(def); err == nil {
+		t.Error("Expected error for duplicate macro")
+	}
+	if got, ok := lib.Get("IS_OPEN"); !ok || got.Name != "is_open" {
+		t.Errorf("Expected case-insensitive lookup, got %+v (found=%v)", got, ok)
+	}
+}
+
+func TestLibrary_NestedMacrosAndUnregister(t *testing.T) {
+	lib := newTestLibrary(t)
+	if err := lib.Register(Definition{Name: "above", Params: []string{"limit"}, Body: "total > limit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.Register(Definition{Name: "big_open", Params: []string{"total"}, Body: `above(total) AND status = "open"`}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lib.Unregister("above"); err == nil {
+		t.Error("Expected error when removing a macro that is still in use")
+	}
+	if names := lib.Names(); len(names) != 2 || names[0] != "above" || names[1] != "big_open" {
+		t.Errorf("Unexpected macro names: %v", names)
+	}
+	if err := lib.Unregister("big_open"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := lib.Unregister("above"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := lib.Unregister("above"); err == nil {
+		t.Error("Expected error for unknown macro")
+	}
+}
+
+func TestLibrary_ParameterHygiene(t *testing.T) {
+	lib := newTestLibrary(t)
+	// "total" inside above() is a record field, while big(total) binds its own
+	// parameter named total; the two must not be confused
+	if err := lib.Register(Definition{Name: "above", Params: []string{"limit"}, Body: "total > limit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.Register(Definition{Name: "big", Params: []string{"total"}, Body: "above(total)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expanded, err := lib.Expand(parseFilter(t, "big(100)"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := expanded.String(); got != "(total > 100)" {
+		t.Errorf("Expected (total > 100), got %s", got)
+	}
+}
+
+func TestLibrary_Expand(t *testing.T) {
+	lib := newTestLibrary(t)
+	if err := lib.Register(Definition{Name: "is_overdue", Body: `due_date < today() AND status != "paid"`}); err != nil {
+		t.Fatal(err)
+	}
+
+	original := parseFilter(t, "is_overdue() OR priority = 1")
+	expanded, err := lib.Expand(original)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := `(((due_date < 2025-03-15) AND (status != paid)) OR (priority = 1))`
+	if got := expanded.String(); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if got := original.String(); !strings.Contains(got, "is_overdue()") {
+		t.Errorf("Original expression was modified: %s", got)
+	}
+
+	// Unknown functions pass through for service-side evaluation
+	passthrough, err := lib.Expand(parseFilter(t, "geo_within(location, 5)"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := passthrough.(*mdwast.FunctionCallExpr); !ok {
+		t.Errorf("Expected unknown function to pass through, got %T", passthrough)
+	}
+}
+
+func TestLibrary_ExpansionLimit(t *testing.T) {
+	lib, err := New(Options{MaxExpansionNodes: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.Register(Definition{Name: "m0", Params: []string{"x"}, Body: "x = 1 OR x = 2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.Register(Definition{Name: "m1", Params: []string{"x"}, Body: "m0(x) OR m0(x)"}); err != nil {
+		t.Fatal(err)
+	}
+	err = lib.Register(Definition{Name: "m2", Params: []string{"x"}, Body: "m1(x) OR m1(x) OR m1(x)"})
+	if err == nil || !strings.Contains(err.Error(), "maximum size") {
+		t.Errorf("Expected expansion size error, got %v", err)
+	}
+}
+
+func TestLibrary_Evaluate(t *testing.T) {
+	lib := newTestLibrary(t)
+	if err := lib.Register(Definition{Name: "is_overdue", Body: `due_date < today() AND status != "paid"`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.Register(Definition{Name: "in_region", Params: []string{"regions"}, Body: "region IN regions"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		record map[string]interface{}
+		want   bool
+	}{
+		{"Overdue unpaid", "is_overdue()", map[string]interface{}{"due_date": "2025-03-01", "status": "open"}, true},
+		{"Overdue paid", "is_overdue()", map[string]interface{}{"due_date": "2025-03-01", "status": "paid"}, false},
+		{"Not yet due", "is_overdue()", map[string]interface{}{"due_date": "2025-04-01", "status": "open"}, false},
+		{"Missing field", "is_overdue()", map[string]interface{}{"status": "open"}, false},
+		{"Array parameter", `in_region(["north", "east"])`, map[string]interface{}{"region": "east"}, true},
+		{"Combined with NOT", `NOT in_region(["north"]) AND amount >= 10`, map[string]interface{}{"region": "south", "amount": 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lib.Evaluate(parseFilter(t, tt.filter), tt.record)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate(%s) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibrary_ExpandFilter(t *testing.T) {
+	lib := newTestLibrary(t)
+	if err := lib.Register(Definition{Name: "is_active", Body: "active = true"}); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := &mdwast.FilterExpr{Condition: parseFilter(t, "is_active()")}
+	expanded, err := lib.ExpandFilter(filter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := expanded.String(); got != "[(active = true)]" {
+		t.Errorf("Expected [(active = true)], got %s", got)
+	}
+
+	if _, err := lib.ExpandFilter(&mdwast.FilterExpr{Condition: parseFilter(t, "is_active(1)")}); err == nil {
+		t.Error("Expected arity error")
+	}
+
+	if got, err := lib.ExpandFilter(nil); got != nil || err != nil {
+		t.Errorf("Expected nil result for nil filter, got %v, %v", got, err)
+	}
+}
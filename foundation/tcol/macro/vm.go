@@ -0,0 +1,692 @@
+// File: vm.go
+// Title: TCOL Filter Expression VM
+// Description: Compiles TCOL filter expressions into a compact instruction
+//              sequence and evaluates them against in-memory records with a
+//              bounded step budget. Provides the pure built-in functions that
+//              are available to filter macros.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-15
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial stack-based expression VM
+// - 2026-10-16 v0.1.1: Added arithmetic, BETWEEN, and IS NULL
+
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+// ErrStepLimitExceeded is returned when an evaluation exceeds its step budget
+var ErrStepLimitExceeded = errors.New("expression evaluation exceeded step limit")
+
+// maxStackDepth bounds the operand stack of a running program
+const maxStackDepth = 256
+
+// opcode identifies a VM instruction
+type opcode uint8
+
+const (
+	opConst       opcode = iota // push consts[arg]
+	opField                     // push record[name]
+	opNot                       // pop a; push !truthy(a)
+	opBool                      // pop a; push truthy(a)
+	opBinary                    // pop b, a; push a <name> b
+	opCall                      // pop arg values; push builtin <name>(args)
+	opArray                     // pop arg values; push []interface{}
+	opJumpIfFalse               // if !truthy(top) jump to arg (keeps top)
+	opJumpIfTrue                // if truthy(top) jump to arg (keeps top)
+	opPop                       // discard top
+	opNeg                       // pop a; push -a
+	opBetween                   // pop high, low, a; push low <= a <= high
+	opIsNull                    // pop a; push a == nil
+)
+
+// instruction is a single VM instruction
+type instruction struct {
+	op   opcode
+	arg  int
+	name string
+}
+
+// Program is a compiled filter expression
+type Program struct {
+	code   []instruction
+	consts []interface{}
+	source string
+}
+
+// String returns the source expression the program was compiled from
+func (p *Program) String() string {
+	return p.source
+}
+
+// Len returns the number of instructions in the program
+func (p *Program) Len() int {
+	return len(p.code)
+}
+
+// Compile compiles a filter expression into a VM program. Only the node types
+// of the filter grammar are accepted; function calls must reference built-ins
+// (expand macros with a Library before compiling).
+func Compile(expr mdwast.Expr) (*Program, error) {
+	if expr == nil {
+		return nil, errors.New("expression cannot be nil")
+	}
+
+	prog := &Program{source: expr.String()}
+	if err := prog.compile(expr); err != nil {
+		return nil, err
+	}
+	return prog, nil
+}
+
+func (p *Program) emit(op opcode, arg int, name string) int {
+	p.code = append(p.code, instruction{op: op, arg: arg, name: name})
+	return len(p.code) - 1
+}
+
+func (p *Program) compile(expr mdwast.Expr) error {
+	switch e := expr.(type) {
+	case *mdwast.LiteralExpr:
+		p.consts = append(p.consts, literalValue(e.Value))
+		p.emit(opConst, len(p.consts)-1, "")
+
+	case *mdwast.IdentifierExpr:
+		p.emit(opField, 0, e.Name)
+
+	case *mdwast.UnaryExpr:
+		op := opNot
+		switch strings.ToUpper(e.Op) {
+		case "NOT":
+		case "-":
+			op = opNeg
+		default:
+			return fmt.Errorf("unsupported unary operator: %s", e.Op)
+		}
+		if err := p.compile(e.Expr); err != nil {
+			return err
+		}
+		p.emit(op, 0, "")
+
+	case *mdwast.BinaryExpr:
+		op := strings.ToUpper(e.Op)
+		switch op {
+		case "AND", "OR":
+			if err := p.compile(e.Left); err != nil {
+				return err
+			}
+			p.emit(opBool, 0, "")
+			jump := opJumpIfFalse
+			if op == "OR" {
+				jump = opJumpIfTrue
+			}
+			at := p.emit(jump, 0, "")
+			p.emit(opPop, 0, "")
+			if err := p.compile(e.Right); err != nil {
+				return err
+			}
+			p.emit(opBool, 0, "")
+			p.code[at].arg = len(p.code)
+
+		case "=", "==", "!=", "<", "<=", ">", ">=", "LIKE", "IN", "+", "-", "*", "/", "%":
+			if err := p.compile(e.Left); err != nil {
+				return err
+			}
+			if err := p.compile(e.Right); err != nil {
+				return err
+			}
+			p.emit(opBinary, 0, op)
+
+		default:
+			return fmt.Errorf("unsupported binary operator: %s", e.Op)
+		}
+
+	case *mdwast.BetweenExpr:
+		for _, operand := range []mdwast.Expr{e.Expr, e.Low, e.High} {
+			if err := p.compile(operand); err != nil {
+				return err
+			}
+		}
+		p.emit(opBetween, 0, "")
+
+	case *mdwast.IsNullExpr:
+		if err := p.compile(e.Expr); err != nil {
+			return err
+		}
+		p.emit(opIsNull, 0, "")
+
+	case *mdwast.FunctionCallExpr:
+		name := strings.ToLower(e.Name)
+		fn, exists := builtins[name]
+		if !exists {
+			return fmt.Errorf("unknown function: %s", e.Name)
+		}
+		if err := fn.checkArity(name, len(e.Args)); err != nil {
+			return err
+		}
+		for _, arg := range e.Args {
+			if err := p.compile(arg); err != nil {
+				return err
+			}
+		}
+		p.emit(opCall, len(e.Args), name)
+
+	case *mdwast.ArrayExpr:
+		for _, elem := range e.Elements {
+			if err := p.compile(elem); err != nil {
+				return err
+			}
+		}
+		p.emit(opArray, len(e.Elements), "")
+
+	default:
+		return fmt.Errorf("unsupported expression in filter: %T", expr)
+	}
+
+	return nil
+}
+
+// Run evaluates the program against a record. Identifiers resolve to record
+// fields; missing fields evaluate to nil. maxSteps bounds the number of
+// executed instructions (a value <= 0 selects the default budget).
+func (p *Program) Run(record map[string]interface{}, maxSteps int) (interface{}, error) {
+	return p.run(record, maxSteps, time.Now)
+}
+
+// Matches evaluates the program and reports whether the result is truthy
+func (p *Program) Matches(record map[string]interface{}, maxSteps int) (bool, error) {
+	value, err := p.Run(record, maxSteps)
+	if err != nil {
+		return false, err
+	}
+	return truthy(value), nil
+}
+
+func (p *Program) run(record map[string]interface{}, maxSteps int, now func() time.Time) (interface{}, error) {
+	if maxSteps <= 0 {
+		maxSteps = defaultMaxSteps
+	}
+
+	stack := make([]interface{}, 0, 16)
+	steps := 0
+
+	for pc := 0; pc < len(p.code); pc++ {
+		steps++
+		if steps > maxSteps {
+			return nil, ErrStepLimitExceeded
+		}
+		if len(stack) >= maxStackDepth {
+			return nil, fmt.Errorf("expression stack exceeds maximum depth of %d", maxStackDepth)
+		}
+
+		ins := p.code[pc]
+		switch ins.op {
+		case opConst:
+			stack = append(stack, p.consts[ins.arg])
+
+		case opField:
+			stack = append(stack, lookupField(record, ins.name))
+
+		case opNot:
+			stack[len(stack)-1] = !truthy(stack[len(stack)-1])
+
+		case opBool:
+			stack[len(stack)-1] = truthy(stack[len(stack)-1])
+
+		case opBinary:
+			b := stack[len(stack)-1]
+			a := stack[len(stack)-2]
+			stack = stack[:len(stack)-2]
+			result, err := applyBinary(ins.name, a, b)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, result)
+
+		case opCall:
+			args := make([]interface{}, ins.arg)
+			copy(args, stack[len(stack)-ins.arg:])
+			stack = stack[:len(stack)-ins.arg]
+			steps += ins.arg
+			result, err := builtins[ins.name].fn(args, now)
+			if err != nil {
+				return nil, fmt.Errorf("%s(): %w", ins.name, err)
+			}
+			stack = append(stack, result)
+
+		case opArray:
+			elems := make([]interface{}, ins.arg)
+			copy(elems, stack[len(stack)-ins.arg:])
+			stack = stack[:len(stack)-ins.arg]
+			stack = append(stack, elems)
+
+		case opJumpIfFalse:
+			if !truthy(stack[len(stack)-1]) {
+				pc = ins.arg - 1
+			}
+
+		case opJumpIfTrue:
+			if truthy(stack[len(stack)-1]) {
+				pc = ins.arg - 1
+			}
+
+		case opPop:
+			stack = stack[:len(stack)-1]
+
+		case opNeg:
+			result, err := applyBinary("*", stack[len(stack)-1], int64(-1))
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = result
+
+		case opBetween:
+			high := stack[len(stack)-1]
+			low := stack[len(stack)-2]
+			a := stack[len(stack)-3]
+			stack = stack[:len(stack)-3]
+			lowCmp, okLow := compareValues(a, low)
+			highCmp, okHigh := compareValues(a, high)
+			stack = append(stack, okLow && okHigh && lowCmp >= 0 && highCmp <= 0)
+
+		case opIsNull:
+			stack[len(stack)-1] = stack[len(stack)-1] == nil
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("invalid program: stack holds %d values after evaluation", len(stack))
+	}
+	return stack[0], nil
+}
+
+// lookupField resolves an identifier against a record, falling back to a
+// case-insensitive match
+func lookupField(record map[string]interface{}, name string) interface{} {
+	if value, ok := record[name]; ok {
+		return value
+	}
+	for key, value := range record {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return nil
+}
+
+// literalValue converts an AST value into its VM representation
+func literalValue(v mdwast.Value) interface{} {
+	switch val := v.Value.(type) {
+	case int:
+		return int64(val)
+	default:
+		return val
+	}
+}
+
+// truthy reports the boolean interpretation of a VM value
+func truthy(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != ""
+	case []interface{}:
+		return len(val) > 0
+	default:
+		if n, ok := toNumber(val); ok {
+			return n != 0
+		}
+		return true
+	}
+}
+
+// toNumber converts numeric VM values to float64
+func toNumber(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case int:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case float32:
+		return float64(val), true
+	case float64:
+		return val, true
+	default:
+		return 0, false
+	}
+}
+
+// toTime converts time values and date strings to time.Time
+func toTime(v interface{}) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, val); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// compareValues orders two VM values; ok is false when they are not comparable
+func compareValues(a, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0, true
+		}
+		return 0, false
+	}
+
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	_, aIsTime := a.(time.Time)
+	_, bIsTime := b.(time.Time)
+	if aIsTime || bIsTime {
+		x, okA := toTime(a)
+		y, okB := toTime(b)
+		if okA && okB {
+			return x.Compare(y), true
+		}
+		return 0, false
+	}
+
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0, true
+			}
+			if !x {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+
+	return 0, false
+}
+
+// applyBinary evaluates a comparison or arithmetic operator
+func applyBinary(op string, a, b interface{}) (interface{}, error) {
+	switch op {
+	case "+", "-", "*", "/", "%":
+		return applyArithmetic(op, a, b)
+	case "=", "==":
+		cmp, ok := compareValues(a, b)
+		return ok && cmp == 0, nil
+	case "!=":
+		cmp, ok := compareValues(a, b)
+		return !ok || cmp != 0, nil
+	case "<", "<=", ">", ">=":
+		cmp, ok := compareValues(a, b)
+		if !ok {
+			return false, nil
+		}
+		switch op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	case "LIKE":
+		s, okS := a.(string)
+		pattern, okP := b.(string)
+		if !okS || !okP {
+			return false, nil
+		}
+		return matchLike(s, pattern), nil
+	case "IN":
+		list, ok := b.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("right operand of IN must be an array, got %T", b)
+		}
+		for _, elem := range list {
+			if cmp, ok := compareValues(a, elem); ok && cmp == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator: %s", op)
+	}
+}
+
+// applyArithmetic evaluates an arithmetic operator. Null operands yield
+// null; integer operands keep integer results except for division. +
+// concatenates strings.
+func applyArithmetic(op string, a, b interface{}) (interface{}, error) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	if x, ok := a.(string); ok && op == "+" {
+		if y, ok := b.(string); ok {
+			return x + y, nil
+		}
+	}
+
+	x, okA := toNumber(a)
+	y, okB := toNumber(b)
+	if !okA || !okB {
+		return nil, fmt.Errorf("operator %s requires numeric operands, got %T and %T", op, a, b)
+	}
+	if (op == "/" || op == "%") && y == 0 {
+		return nil, errors.New("division by zero")
+	}
+
+	_, intA := a.(int64)
+	_, intB := b.(int64)
+	integers := intA && intB
+	switch op {
+	case "+":
+		if integers {
+			return a.(int64) + b.(int64), nil
+		}
+		return x + y, nil
+	case "-":
+		if integers {
+			return a.(int64) - b.(int64), nil
+		}
+		return x - y, nil
+	case "*":
+		if integers {
+			return a.(int64) * b.(int64), nil
+		}
+		return x * y, nil
+	case "/":
+		return x / y, nil
+	default:
+		if integers {
+			return a.(int64) % b.(int64), nil
+		}
+		return math.Mod(x, y), nil
+	}
+}
+
+// matchLike implements SQL LIKE matching with % (any run) and _ (any rune),
+// case-insensitively and without backtracking explosions
+func matchLike(s, pattern string) bool {
+	str := []rune(strings.ToLower(s))
+	pat := []rune(strings.ToLower(pattern))
+
+	si, pi := 0, 0
+	starPi, starSi := -1, 0
+	for si < len(str) {
+		switch {
+		case pi < len(pat) && (pat[pi] == '_' || pat[pi] == str[si]):
+			si++
+			pi++
+		case pi < len(pat) && pat[pi] == '%':
+			starPi = pi
+			starSi = si
+			pi++
+		case starPi >= 0:
+			pi = starPi + 1
+			starSi++
+			si = starSi
+		default:
+			return false
+		}
+	}
+	for pi < len(pat) && pat[pi] == '%' {
+		pi++
+	}
+	return pi == len(pat)
+}
+
+// Built-in functions
+
+// builtin describes a pure function available to filter expressions
+type builtin struct {
+	minArgs int
+	maxArgs int
+	fn      func(args []interface{}, now func() time.Time) (interface{}, error)
+}
+
+func (b builtin) checkArity(name string, n int) error {
+	if n < b.minArgs || n > b.maxArgs {
+		if b.minArgs == b.maxArgs {
+			return fmt.Errorf("function %s expects %d argument(s), got %d", name, b.minArgs, n)
+		}
+		return fmt.Errorf("function %s expects %d to %d arguments, got %d", name, b.minArgs, b.maxArgs, n)
+	}
+	return nil
+}
+
+// builtins lists the pure functions available inside filters and macros
+var builtins = map[string]builtin{
+	"today": {0, 0, func(_ []interface{}, now func() time.Time) (interface{}, error) {
+		t := now()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+	}},
+	"now": {0, 0, func(_ []interface{}, now func() time.Time) (interface{}, error) {
+		return now(), nil
+	}},
+	"add_days": {2, 2, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		t, ok := toTime(args[0])
+		if !ok {
+			return nil, fmt.Errorf("expected a date, got %T", args[0])
+		}
+		n, ok := toNumber(args[1])
+		if !ok {
+			return nil, fmt.Errorf("expected a number of days, got %T", args[1])
+		}
+		return t.AddDate(0, 0, int(n)), nil
+	}},
+	"days_between": {2, 2, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		from, okFrom := toTime(args[0])
+		to, okTo := toTime(args[1])
+		if !okFrom || !okTo {
+			return nil, nil
+		}
+		return int64(math.Floor(to.Sub(from).Hours() / 24)), nil
+	}},
+	"lower": {1, 1, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		return strings.ToLower(fmt.Sprint(args[0])), nil
+	}},
+	"upper": {1, 1, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		return strings.ToUpper(fmt.Sprint(args[0])), nil
+	}},
+	"trim": {1, 1, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		return strings.TrimSpace(fmt.Sprint(args[0])), nil
+	}},
+	"contains": {2, 2, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		s, okS := args[0].(string)
+		sub, okSub := args[1].(string)
+		return okS && okSub && strings.Contains(strings.ToLower(s), strings.ToLower(sub)), nil
+	}},
+	"starts_with": {2, 2, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		s, okS := args[0].(string)
+		prefix, okP := args[1].(string)
+		return okS && okP && strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix)), nil
+	}},
+	"len": {1, 1, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		switch val := args[0].(type) {
+		case nil:
+			return int64(0), nil
+		case string:
+			return int64(utf8.RuneCountInString(val)), nil
+		case []interface{}:
+			return int64(len(val)), nil
+		default:
+			return nil, fmt.Errorf("cannot take length of %T", val)
+		}
+	}},
+	"abs": {1, 1, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		switch val := args[0].(type) {
+		case int64:
+			if val < 0 {
+				return -val, nil
+			}
+			return val, nil
+		default:
+			n, ok := toNumber(val)
+			if !ok {
+				return nil, fmt.Errorf("expected a number, got %T", val)
+			}
+			return math.Abs(n), nil
+		}
+	}},
+	"coalesce": {1, 8, func(args []interface{}, _ func() time.Time) (interface{}, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}},
+}
+
+// IsBuiltin reports whether name refers to a built-in filter function
+func IsBuiltin(name string) bool {
+	_, exists := builtins[strings.ToLower(name)]
+	return exists
+}
+
+// BuiltinNames returns the names of all built-in filter functions
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,192 @@
+// File: vm_test.go
+// Title: TCOL Filter Expression VM Tests
+// Description: Unit tests for expression compilation, evaluation semantics,
+//              built-in functions, and step budget enforcement.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-15
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial VM tests
+// - 2026-10-16 v0.1.1: Tests for arithmetic, BETWEEN, and IS NULL
+
+package macro
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+)
+
+func TestCompile_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		expr mdwast.Expr
+	}{
+		{"Nil expression", nil},
+		{"Object literal", &mdwast.ObjectExpr{Fields: map[string]mdwast.Expr{}}},
+		{"Unknown function", &mdwast.FunctionCallExpr{Name: "exec"}},
+		{"Unknown operator", &mdwast.BinaryExpr{
+			Op:    "XOR",
+			Left:  &mdwast.IdentifierExpr{Name: "a"},
+			Right: &mdwast.IdentifierExpr{Name: "b"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile(tt.expr); err == nil {
+				t.Error("Expected compile error")
+			}
+		})
+	}
+}
+
+func TestProgram_Run(t *testing.T) {
+	record := map[string]interface{}{
+		"name":    "Müller GmbH",
+		"total":   1500.5,
+		"count":   3,
+		"active":  true,
+		"created": time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		"tags":    []interface{}{"a", "b"},
+		"delta":   -5,
+	}
+
+	tests := []struct {
+		filter string
+		want   interface{}
+	}{
+		{"total > 1000", true},
+		{"count = 3", true},
+		{"count != 3", false},
+		{"COUNT >= 4", false},
+		{`name LIKE "m%gmbh"`, true},
+		{`name LIKE "M_ller%"`, true},
+		{`name LIKE "%AG"`, false},
+		{"count IN [1, 2, 3]", true},
+		{"active AND total < 2000", true},
+		{"missing OR active", true},
+		{"NOT active", false},
+		{"missing = null", true},
+		{`created < "2025-02-01"`, true},
+		{`days_between(created, "2025-01-20") = 10`, true},
+		{`add_days(created, 5) = "2025-01-15"`, true},
+		{`upper(name) = "MÜLLER GMBH"`, true},
+		{"len(tags) = 2", true},
+		{`coalesce(missing, "x") = "x"`, true},
+		{`contains(name, "müll") AND starts_with(name, "mü")`, true},
+		{"abs(delta) = 5", true},
+		{`(name = "x" OR name LIKE "m%") AND total > 100`, true},
+		{`count NOT IN (1, 2)`, true},
+		{"count BETWEEN 1 AND 3", true},
+		{"total NOT BETWEEN 1000 AND 2000", false},
+		{`created BETWEEN "2025-01-01" AND "2025-01-31"`, true},
+		{"missing IS NULL AND name IS NOT NULL", true},
+		{`name NOT LIKE "%AG"`, true},
+		{"total * 2 > count * 1000", true},
+		{"count + delta = -2", true},
+		{"-delta = 5", true},
+		{"count % 2 = 1 AND count / 2 = 1.5", true},
+		{"missing + 1 IS NULL", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			prog, err := Compile(parseFilter(t, tt.filter))
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+			got, err := prog.Run(record, 0)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Run(%s) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgram_ArithmeticErrors(t *testing.T) {
+	for _, filter := range []string{"count / 0 = 1", "count % 0 = 1", `name * 2 = 1`} {
+		prog, err := Compile(parseFilter(t, filter))
+		if err != nil {
+			t.Fatalf("Compile(%s) failed: %v", filter, err)
+		}
+		if _, err := prog.Run(map[string]interface{}{"count": 3, "name": "x"}, 0); err == nil {
+			t.Errorf("Expected error for %s", filter)
+		}
+	}
+}
+
+func TestProgram_ShortCircuit(t *testing.T) {
+	// The right-hand side would fail (IN requires an array) if evaluated
+	prog, err := Compile(parseFilter(t, "active OR x IN y"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := prog.Matches(map[string]interface{}{"active": true}, 0)
+	if err != nil || !matched {
+		t.Errorf("Expected short-circuit match, got %v, %v", matched, err)
+	}
+
+	if _, err := prog.Run(map[string]interface{}{"active": false}, 0); err == nil {
+		t.Error("Expected error when IN operand is not an array")
+	}
+}
+
+func TestProgram_StepLimit(t *testing.T) {
+	prog, err := Compile(parseFilter(t, "a = 1 AND b = 2 AND c = 3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := map[string]interface{}{"a": 1, "b": 2, "c": 3}
+
+	if _, err := prog.Run(record, 3); !errors.Is(err, ErrStepLimitExceeded) {
+		t.Errorf("Expected ErrStepLimitExceeded, got %v", err)
+	}
+	if matched, err := prog.Matches(record, prog.Len()); err != nil || !matched {
+		t.Errorf("Expected match within budget, got %v, %v", matched, err)
+	}
+}
+
+func TestMatchLike(t *testing.T) {
+	tests := []struct {
+		s, pattern string
+		want       bool
+	}{
+		{"hello", "hello", true},
+		{"hello", "h%", true},
+		{"hello", "%llo", true},
+		{"hello", "h_llo", true},
+		{"hello", "%l%o", true},
+		{"hello", "h_lo", false},
+		{"", "%", true},
+		{"aaaaaaaaaaaaaaaaaaaaaaaab", "%a%a%a%a%a%a%c", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchLike(tt.s, tt.pattern); got != tt.want {
+			t.Errorf("matchLike(%q, %q) = %v, want %v", tt.s, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestBuiltinNames(t *testing.T) {
+	names := BuiltinNames()
+	if len(names) == 0 {
+		t.Fatal("Expected built-in functions")
+	}
+	for _, name := range []string{"today", "lower", "coalesce"} {
+		if !IsBuiltin(name) {
+			t.Errorf("Expected %s to be a built-in", name)
+		}
+	}
+	if IsBuiltin("system") {
+		t.Error("Did not expect system to be a built-in")
+	}
+}
@@ -4,12 +4,15 @@
 //              Converts TCOL command strings into structured AST representations
 //              with comprehensive error reporting and syntax validation.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.3
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial parser implementation
+// - 2026-10-16 v0.1.1: Documented filter expression precedence
+// - 2026-10-16 v0.1.2: Documented multi-line string tokens
+// - 2026-10-16 v0.1.3: Documented parse spans and metrics
 
 /*
 Package parser provides lexical analysis and parsing capabilities for TCOL commands.
@@ -24,5 +27,29 @@ strings into Abstract Syntax Tree (AST) representations. It includes:
 
 The parser follows TCOL grammar rules and produces well-formed AST nodes
 that can be analyzed, optimized, and executed by other components.
+
+Filter expressions bind from loosest to tightest as follows:
+
+	OR
+	AND
+	=  !=
+	<  <=  >  >=  [NOT] LIKE  [NOT] IN  [NOT] BETWEEN ... AND ...  IS [NOT] NULL
+	+  -
+	*  /  %
+	NOT  - (unary)
+
+IN accepts a parenthesized list, an array literal, or a field. BETWEEN and
+IS NULL produce ast.BetweenExpr and ast.IsNullExpr; the negated forms wrap
+the positive form in a NOT ast.UnaryExpr.
+
+Triple-quoted strings and heredocs (<<EOF, <<-EOF, <<'EOF') yield STRING
+tokens with newlines preserved. Tokens of interpolating forms carry
+Interpolate; their values keep \$ escapes, which the parser resolves into
+ast.Value.Value while ast.Value.Raw keeps them for variable substitution.
+
+ParseContext and ParseScriptContext tokenize the input up front in a
+tcol.lex span inside a tcol.parse span on Options.Tracer, both children of
+the span in the context; Parse and ParseScript start new traces.
+Options.Metrics records the parse latency and counts rejected input.
 */
 package parser
\ No newline at end of file
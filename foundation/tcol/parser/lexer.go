@@ -5,12 +5,18 @@
 //              the parser. Handles all TCOL syntax elements and provides
 //              detailed position information for error reporting.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial lexer implementation
+// - 2026-10-16 v0.1.1: Added arithmetic operators and the BETWEEN and IS keywords
+// - 2026-10-16 v0.1.2: Added triple-quoted strings and heredocs
+// - 2026-10-16 v0.1.3: Added the LET keyword and $variable references
+// - 2026-10-16 v0.1.4: Added IF and FOREACH keywords
+// - 2026-10-16 v0.1.5: Added list indexes to variable paths
+// - 2026-10-16 v0.1.6: Added @ for method versions
 
 package parser
 
@@ -34,7 +40,7 @@ const (
 
 	// Identifiers and literals
 	TokenIdentifier // CUSTOMER, CREATE, field_name
-	TokenString     // "string literal"
+	TokenString     // "string literal", """multi-line""", <<EOF heredoc
 	TokenNumber     // 123, 123.45
 	TokenBoolean    // true, false
 
@@ -66,6 +72,30 @@ const (
 
 	// Keywords
 	TokenNull // null
+
+	// Filter grammar
+	TokenPlus    // +
+	TokenMinus   // -
+	TokenStar    // *
+	TokenSlash   // /
+	TokenPercent // %
+	TokenBetween // BETWEEN
+	TokenIs      // IS
+
+	// Scripts
+	TokenLet      // LET
+	TokenVariable // $name, $name.field, $name.list[0], $name.list[*].field (Value holds the path without '$')
+
+	// Control flow
+	TokenIf      // IF
+	TokenThen    // THEN
+	TokenElse    // ELSE
+	TokenEnd     // END
+	TokenForEach // FOREACH
+	TokenDo      // DO
+
+	// Method versions
+	TokenAt // @ (METHOD@v2)
 )
 
 // Token represents a lexical token with position information
@@ -75,6 +105,10 @@ type Token struct {
 	Position int       // Byte position in input
 	Line     int       // Line number (1-based)
 	Column   int       // Column number (1-based)
+
+	// Interpolate marks triple-quoted strings and unquoted heredocs, whose
+	// Value may contain $variable references and \$ escapes
+	Interpolate bool
 }
 
 // String returns a string representation of the token
@@ -150,6 +184,38 @@ func (tt TokenType) String() string {
 		return "SEMICOLON"
 	case TokenNull:
 		return "NULL"
+	case TokenPlus:
+		return "PLUS"
+	case TokenMinus:
+		return "MINUS"
+	case TokenStar:
+		return "STAR"
+	case TokenSlash:
+		return "SLASH"
+	case TokenPercent:
+		return "PERCENT"
+	case TokenBetween:
+		return "BETWEEN"
+	case TokenIs:
+		return "IS"
+	case TokenLet:
+		return "LET"
+	case TokenVariable:
+		return "VARIABLE"
+	case TokenIf:
+		return "IF"
+	case TokenThen:
+		return "THEN"
+	case TokenElse:
+		return "ELSE"
+	case TokenEnd:
+		return "END"
+	case TokenForEach:
+		return "FOREACH"
+	case TokenDo:
+		return "DO"
+	case TokenAt:
+		return "AT"
 	default:
 		return "UNKNOWN"
 	}
@@ -205,7 +271,12 @@ func (l *Lexer) NextToken() Token {
 			tok = newToken(TokenIllegal, l.ch, pos, line, column)
 		}
 	case '<':
-		if l.peekChar() == '=' {
+		if l.peekChar() == '<' {
+			tok = l.readHeredoc()
+			tok.Position = pos
+			tok.Line = line
+			tok.Column = column
+		} else if l.peekChar() == '=' {
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: TokenLessEq, Value: string(ch) + string(l.ch), Position: pos, Line: line, Column: column}
@@ -242,9 +313,32 @@ func (l *Lexer) NextToken() Token {
 		tok = newToken(TokenPipe, l.ch, pos, line, column)
 	case ';':
 		tok = newToken(TokenSemicolon, l.ch, pos, line, column)
+	case '@':
+		tok = newToken(TokenAt, l.ch, pos, line, column)
+	case '+':
+		tok = newToken(TokenPlus, l.ch, pos, line, column)
+	case '-':
+		tok = newToken(TokenMinus, l.ch, pos, line, column)
+	case '*':
+		tok = newToken(TokenStar, l.ch, pos, line, column)
+	case '/':
+		tok = newToken(TokenSlash, l.ch, pos, line, column)
+	case '%':
+		tok = newToken(TokenPercent, l.ch, pos, line, column)
+	case '$':
+		if isLetter(l.peekChar()) {
+			l.readChar() // Skip '$'
+			tok = Token{Type: TokenVariable, Value: l.readVariablePath(), Position: pos, Line: line, Column: column}
+			return tok // Early return to avoid readChar()
+		}
+		tok = newToken(TokenIllegal, l.ch, pos, line, column)
 	case '"':
-		tok.Type = TokenString
-		tok.Value = l.readString()
+		if l.peekChar() == '"' && l.peekCharAt(2) == '"' {
+			tok = l.readTripleQuotedString()
+		} else {
+			tok.Type = TokenString
+			tok.Value = l.readString()
+		}
 		tok.Position = pos
 		tok.Line = line
 		tok.Column = column
@@ -293,6 +387,10 @@ func (l *Lexer) Tokenize() ([]Token, error) {
 		}
 
 		if tok.Type == TokenIllegal {
+			if strings.HasPrefix(tok.Value, "<<") || strings.HasPrefix(tok.Value, `"""`) {
+				return tokens, fmt.Errorf("unterminated or invalid string %s at line %d, column %d (position %d)",
+					tok.Value, tok.Line, tok.Column, tok.Position)
+			}
 			return tokens, fmt.Errorf("illegal character '%s' at line %d, column %d (position %d)", 
 				tok.Value, tok.Line, tok.Column, tok.Position)
 		}
@@ -329,7 +427,16 @@ func (l *Lexer) peekChar() byte {
 	return l.input[l.readPos]
 }
 
-// readIdentifier reads an identifier (letters, digits, underscores, hyphens)
+// peekCharAt returns the character offset positions ahead of the current one
+func (l *Lexer) peekCharAt(offset int) byte {
+	if l.position+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.position+offset]
+}
+
+// readIdentifier reads an identifier (letters, digits, underscores, hyphens).
+// A hyphen belongs to the identifier, so subtraction needs spaces: a - b.
 func (l *Lexer) readIdentifier() string {
 	start := l.position
 	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' || l.ch == '-' {
@@ -338,6 +445,43 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[start:l.position]
 }
 
+// readVariablePath reads a variable name with optional field path and list
+// indexes (name.field.sub, name.list[0], name.list[*].field). Unlike
+// identifiers, names do not contain hyphens.
+func (l *Lexer) readVariablePath() string {
+	start := l.position
+	for {
+		for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' {
+			l.readChar()
+		}
+		for l.ch == '[' && l.atListIndex() {
+			for l.ch != ']' {
+				l.readChar()
+			}
+			l.readChar() // consume ']'
+		}
+		if l.ch != '.' || !isLetter(l.peekChar()) {
+			break
+		}
+		l.readChar() // consume '.'
+	}
+	return l.input[start:l.position]
+}
+
+// atListIndex reports whether the '[' at the current position opens a list
+// index of a variable path: [*] or [digits]
+func (l *Lexer) atListIndex() bool {
+	rest := l.input[l.position+1:]
+	if strings.HasPrefix(rest, "*]") {
+		return true
+	}
+	digits := 0
+	for digits < len(rest) && isDigit(rest[digits]) {
+		digits++
+	}
+	return digits > 0 && digits < len(rest) && rest[digits] == ']'
+}
+
 // readNumber reads a numeric literal (integer or float)
 func (l *Lexer) readNumber() string {
 	start := l.position
@@ -394,6 +538,135 @@ func (l *Lexer) readSingleQuotedString() string {
 	return l.input[start:l.position]
 }
 
+// readTripleQuotedString reads a """-quoted string. Newlines are kept,
+// except one directly after the opening quotes. The current character is
+// the last closing quote afterwards.
+func (l *Lexer) readTripleQuotedString() Token {
+	l.readChar()
+	l.readChar()
+	l.readChar() // Skip opening quotes
+	if l.ch == '\r' && l.peekChar() == '\n' {
+		l.readChar()
+	}
+	if l.ch == '\n' {
+		l.readChar()
+	}
+
+	start := l.position
+	for {
+		if l.ch == 0 {
+			return Token{Type: TokenIllegal, Value: `"""`}
+		}
+		if l.ch == '"' && l.peekChar() == '"' && l.peekCharAt(2) == '"' {
+			break
+		}
+		// Handle escape sequences
+		if l.ch == '\\' {
+			l.readChar()
+		}
+		l.readChar()
+	}
+	value := l.input[start:l.position]
+	l.readChar()
+	l.readChar()
+
+	return Token{Type: TokenString, Value: value, Interpolate: true}
+}
+
+// readHeredoc reads a heredoc. The opener <<DELIM must end its line; the
+// body is every following line up to a line holding only DELIM:
+//
+//	<<EOF     body as written
+//	<<-EOF    common indentation removed; DELIM may be indented
+//	<<'EOF'   body taken literally, without interpolation
+//
+// The body does not include the newline before the closing DELIM. The
+// current character is the newline after the closing DELIM afterwards.
+func (l *Lexer) readHeredoc() Token {
+	l.readChar()
+	l.readChar() // Skip '<<'
+
+	dedent := l.ch == '-'
+	if dedent {
+		l.readChar()
+	}
+	quoted := l.ch == '\''
+	if quoted {
+		l.readChar()
+	}
+
+	start := l.position
+	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+	delimiter := l.input[start:l.position]
+	opener := "<<" + delimiter
+	if delimiter == "" || (quoted && l.ch != '\'') {
+		return Token{Type: TokenIllegal, Value: "<<"}
+	}
+	if quoted {
+		l.readChar()
+	}
+	for l.ch == ' ' || l.ch == '\t' || l.ch == '\r' {
+		l.readChar()
+	}
+	if l.ch != '\n' {
+		return Token{Type: TokenIllegal, Value: opener}
+	}
+
+	var lines []string
+	for {
+		l.readChar() // Skip newline
+		lineStart := l.position
+		for l.ch != '\n' && l.ch != 0 {
+			l.readChar()
+		}
+		text := strings.TrimSuffix(l.input[lineStart:l.position], "\r")
+
+		candidate := text
+		if dedent {
+			candidate = strings.TrimLeft(text, " \t")
+		}
+		if candidate == delimiter {
+			break
+		}
+		if l.ch == 0 {
+			return Token{Type: TokenIllegal, Value: opener}
+		}
+		lines = append(lines, text)
+	}
+
+	if dedent {
+		lines = removeIndentation(lines)
+	}
+	return Token{Type: TokenString, Value: strings.Join(lines, "\n"), Interpolate: !quoted}
+}
+
+// removeIndentation removes the leading whitespace common to all non-blank
+// lines
+func removeIndentation(lines []string) []string {
+	indent := -1
+	for _, text := range lines {
+		trimmed := strings.TrimLeft(text, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if width := len(text) - len(trimmed); indent < 0 || width < indent {
+			indent = width
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, text := range lines {
+		if len(text) >= indent && indent > 0 {
+			result[i] = text[indent:]
+		} else {
+			result[i] = strings.TrimLeft(text, " \t")
+		}
+	}
+	return result
+}
+
 // skipWhitespace skips whitespace characters
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
@@ -431,6 +704,15 @@ var keywords = map[string]TokenType{
 	"NOT":   TokenNot,
 	"LIKE":  TokenLike,
 	"IN":    TokenIn,
+	"BETWEEN": TokenBetween,
+	"IS":    TokenIs,
+	"LET":   TokenLet,
+	"IF":      TokenIf,
+	"THEN":    TokenThen,
+	"ELSE":    TokenElse,
+	"END":     TokenEnd,
+	"FOREACH": TokenForEach,
+	"DO":      TokenDo,
 	"true":  TokenBoolean,
 	"false": TokenBoolean,
 	"null":  TokenNull,
@@ -4,12 +4,18 @@
 //              Tests cover tokenization of all TCOL syntax elements,
 //              error handling, position tracking, and edge cases.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial comprehensive test suite
+// - 2026-10-16 v0.1.1: Added filter grammar tokens
+// - 2026-10-16 v0.1.2: Added multi-line string tests
+// - 2026-10-16 v0.1.3: Added LET and variable tokens
+// - 2026-10-16 v0.1.4: Added control flow tokens
+// - 2026-10-16 v0.1.5: Added list indexes in variable paths
+// - 2026-10-16 v0.1.6: @ is the method version token
 
 package parser
 
@@ -377,7 +383,7 @@ func TestLexer_Tokenize(t *testing.T) {
 		},
 		{
 			name:     "Illegal character",
-			input:    "CUSTOMER@CREATE",
+			input:    "CUSTOMER~CREATE",
 			wantErr:  true,
 			errMsg:   "illegal character",
 		},
@@ -418,6 +424,111 @@ func TestLexer_Tokenize(t *testing.T) {
 	}
 }
 
+func TestLexer_MultiLineStrings(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		value       string
+		interpolate bool
+		next        TokenType
+	}{
+		{"Triple-quoted", "\"\"\"\nDear $name,\n  \"thanks\"\n\"\"\" x", "Dear $name,\n  \"thanks\"\n", true, TokenIdentifier},
+		{"Triple-quoted escape", `"""a\"""b"""`, `a\"""b`, true, TokenEOF},
+		{"Heredoc", "<<EOF\nHello $name,\n\n  regards\nEOF\n| NEXT", "Hello $name,\n\n  regards", true, TokenPipe},
+		{"Heredoc CRLF", "<<EOF\r\nline\r\nEOF", "line", true, TokenEOF},
+		{"Heredoc delimiter in text", "<<END\nEND of text\nEND", "END of text", true, TokenEOF},
+		{"Indented heredoc", "<<-EOF\n    Hello,\n      world\n\n    bye\n  EOF\n", "Hello,\n  world\n\nbye", true, TokenEOF},
+		{"Quoted heredoc", "<<'EOF'\nCost: $5 \\$x\nEOF", "Cost: $5 \\$x", false, TokenEOF},
+		{"Empty heredoc", "<<EOF\nEOF", "", true, TokenEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			tok := lexer.NextToken()
+			if tok.Type != TokenString || tok.Value != tt.value || tok.Interpolate != tt.interpolate {
+				t.Fatalf("NextToken() = %v (interpolate %v), want STRING(%q) (interpolate %v)",
+					tok, tok.Interpolate, tt.value, tt.interpolate)
+			}
+			if next := lexer.NextToken(); next.Type != tt.next {
+				t.Errorf("token after string = %v, want %v", next, tt.next)
+			}
+		})
+	}
+
+	// Positions of tokens after a heredoc account for its lines
+	lexer := NewLexer("A.B body=<<EOF\none\ntwo\nEOF\n| C.D")
+	for tok := lexer.NextToken(); tok.Type != TokenPipe; tok = lexer.NextToken() {
+		if tok.Type == TokenEOF {
+			t.Fatal("pipe after heredoc not found")
+		}
+	}
+	if tok := lexer.NextToken(); tok.Line != 5 || tok.Column != 3 {
+		t.Errorf("token after heredoc at line %d, column %d", tok.Line, tok.Column)
+	}
+
+	invalid := []string{
+		`"""unterminated`,
+		"<<EOF\nno end",
+		"<<EOF trailing\nEOF",
+		"<<\nEOF",
+		"<<'EOF\nEOF",
+	}
+	for _, input := range invalid {
+		if _, err := NewLexer(input).Tokenize(); err == nil {
+			t.Errorf("Tokenize(%q) did not fail", input)
+		}
+	}
+}
+
+func TestLexer_Variables(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Token
+	}{
+		{"LET id = $cust.id", []Token{
+			{Type: TokenLet, Value: "LET"},
+			{Type: TokenIdentifier, Value: "id"},
+			{Type: TokenEquals, Value: "="},
+			{Type: TokenVariable, Value: "cust.id"},
+		}},
+		{"[id=$LAST_ID.]", []Token{
+			{Type: TokenLeftBracket, Value: "["},
+			{Type: TokenIdentifier, Value: "id"},
+			{Type: TokenEquals, Value: "="},
+			{Type: TokenVariable, Value: "LAST_ID"},
+			{Type: TokenDot, Value: "."},
+			{Type: TokenRightBracket, Value: "]"},
+		}},
+		{"$total-1", []Token{
+			{Type: TokenVariable, Value: "total"},
+			{Type: TokenMinus, Value: "-"},
+			{Type: TokenNumber, Value: "1"},
+		}},
+		{"$5", []Token{
+			{Type: TokenIllegal, Value: "$"},
+		}},
+		{"$PREV.items[*].sku $list[12] $a[x]", []Token{
+			{Type: TokenVariable, Value: "PREV.items[*].sku"},
+			{Type: TokenVariable, Value: "list[12]"},
+			{Type: TokenVariable, Value: "a"},
+			{Type: TokenLeftBracket, Value: "["},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			for i, expected := range tt.expected {
+				tok := lexer.NextToken()
+				if tok.Type != expected.Type || tok.Value != expected.Value {
+					t.Fatalf("token %d = %v, want %v", i, tok, expected)
+				}
+			}
+		})
+	}
+}
+
 func TestTokenType_String(t *testing.T) {
 	tests := []struct {
 		tokenType TokenType
@@ -452,6 +563,21 @@ func TestTokenType_String(t *testing.T) {
 		{TokenPipe, "PIPE"},
 		{TokenSemicolon, "SEMICOLON"},
 		{TokenNull, "NULL"},
+		{TokenPlus, "PLUS"},
+		{TokenMinus, "MINUS"},
+		{TokenStar, "STAR"},
+		{TokenSlash, "SLASH"},
+		{TokenPercent, "PERCENT"},
+		{TokenBetween, "BETWEEN"},
+		{TokenIs, "IS"},
+		{TokenLet, "LET"},
+		{TokenVariable, "VARIABLE"},
+		{TokenIf, "IF"},
+		{TokenThen, "THEN"},
+		{TokenElse, "ELSE"},
+		{TokenEnd, "END"},
+		{TokenForEach, "FOREACH"},
+		{TokenDo, "DO"},
 		{TokenType(999), "UNKNOWN"},
 	}
 
@@ -605,7 +731,7 @@ func TestTokenizeInput(t *testing.T) {
 		},
 		{
 			name:    "Invalid character",
-			input:   "CUSTOMER@LIST",
+			input:   "CUSTOMER~LIST",
 			wantErr: true,
 		},
 		{
@@ -5,28 +5,40 @@
 //              recursive descent parsing. Handles all TCOL grammar rules
 //              with comprehensive error reporting and recovery.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.7
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial parser implementation
+// - 2026-10-16 v0.1.1: Full filter grammar: IN lists, BETWEEN, IS NULL, NOT forms,
+//                       and arithmetic
+// - 2026-10-16 v0.1.2: Multi-line string values with \$ escapes
+// - 2026-10-16 v0.1.3: Scripts with LET statements and $variable references
+// - 2026-10-16 v0.1.4: IF and FOREACH blocks in scripts
+// - 2026-10-16 v0.1.5: SELECT, SORT BY, LIMIT, and GROUP BY stages in pipes
+// - 2026-10-16 v0.1.6: Method versions (METHOD@v2)
+// - 2026-10-16 v0.1.7: Lex and parse spans and parse metrics; input is tokenized up front
 
 package parser
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
 )
 
 // Parser implements recursive descent parsing for TCOL
 type Parser struct {
-	lexer    *Lexer
+	tokens   []Token // Tokens of the input, ending with EOF or an illegal token
+	next     int     // Index of the token after the current one
 	current  Token  // Current token
 	previous Token  // Previous token
 	logger   *mdwlog.Logger
@@ -40,6 +52,8 @@ type Options struct {
 	MaxInputLength int
 	EnableChaining bool
 	Registry       *mdwregistry.Registry
+	Tracer         mdwtelemetry.Tracer   // Receives lex and parse spans (optional)
+	Metrics        *mdwtelemetry.Metrics // Records parse latency and errors (optional)
 }
 
 // ParseError represents a parsing error with position information
@@ -65,6 +79,9 @@ func New(opts Options) (*Parser, error) {
 	if opts.MaxInputLength == 0 {
 		opts.MaxInputLength = 4096
 	}
+	if opts.Tracer == nil {
+		opts.Tracer = mdwtelemetry.NoopTracer()
+	}
 
 	return &Parser{
 		logger:  opts.Logger.WithField("component", "tcol-parser"),
@@ -75,15 +92,23 @@ func New(opts Options) (*Parser, error) {
 
 // Parse parses a TCOL command string and returns an AST
 func (p *Parser) Parse(input string) (*mdwast.Command, error) {
+	return p.ParseContext(context.Background(), input)
+}
+
+// ParseContext parses a TCOL command string like Parse; the lex and parse
+// spans are children of the span in ctx
+func (p *Parser) ParseContext(ctx context.Context, input string) (cmd *mdwast.Command, err error) {
+	ctx, finish := p.observe(ctx, input)
+	defer func() { finish(err) }()
+
 	// Validate input length
 	if len(input) > p.options.MaxInputLength {
 		return nil, fmt.Errorf("input exceeds maximum length: %d > %d", 
 			len(input), p.options.MaxInputLength)
 	}
 
-	// Initialize lexer
-	p.lexer = NewLexer(input)
-	p.advance() // Load first token
+	// Tokenize the input
+	p.start(ctx, input)
 
 	p.logger.Debug("Starting TCOL parsing", mdwlog.Fields{
 		"input":  input,
@@ -91,7 +116,7 @@ func (p *Parser) Parse(input string) (*mdwast.Command, error) {
 	})
 
 	// Parse the command
-	cmd, err := p.parseCommand()
+	cmd, err = p.parseCommand()
 	if err != nil {
 		p.logger.Warn("TCOL parsing failed", mdwlog.Fields{
 			"input": input,
@@ -114,6 +139,281 @@ func (p *Parser) Parse(input string) (*mdwast.Command, error) {
 	return cmd, nil
 }
 
+// ParseExpression parses a standalone filter expression (the content between
+// the brackets of OBJECT[...]) and returns its AST
+func (p *Parser) ParseExpression(input string) (mdwast.Expr, error) {
+	if len(input) > p.options.MaxInputLength {
+		return nil, fmt.Errorf("input exceeds maximum length: %d > %d",
+			len(input), p.options.MaxInputLength)
+	}
+
+	p.start(context.Background(), input)
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current.Type != TokenEOF {
+		return nil, p.parseError(fmt.Sprintf("unexpected token after expression: %s", p.current.Value))
+	}
+
+	return expr, nil
+}
+
+// ParseScript parses a script of statements separated by newlines or ';'.
+// A statement is a command, optionally chained, a LET binding, or an IF or
+// FOREACH block. A new line ends a command only where the next statement
+// starts, so commands may still span lines.
+func (p *Parser) ParseScript(input string) (*mdwast.Script, error) {
+	return p.ParseScriptContext(context.Background(), input)
+}
+
+// ParseScriptContext parses a script like ParseScript; the lex and parse
+// spans are children of the span in ctx
+func (p *Parser) ParseScriptContext(ctx context.Context, input string) (script *mdwast.Script, err error) {
+	ctx, finish := p.observe(ctx, input)
+	defer func() { finish(err) }()
+
+	if len(input) > p.options.MaxInputLength {
+		return nil, fmt.Errorf("input exceeds maximum length: %d > %d",
+			len(input), p.options.MaxInputLength)
+	}
+
+	p.start(ctx, input)
+
+	script = &mdwast.Script{Pos: p.currentPosition()}
+	stmts, err := p.parseStatements()
+	if err != nil {
+		p.logger.Warn("TCOL script parsing failed", mdwlog.Fields{
+			"statement": len(stmts) + 1,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+	script.Statements = stmts
+
+	if len(script.Statements) == 0 {
+		return nil, p.parseError("script contains no statements")
+	}
+
+	return script, nil
+}
+
+// parseStatements parses statements until EOF or one of the terminator
+// tokens, which is left as the current token
+func (p *Parser) parseStatements(terminators ...TokenType) ([]mdwast.Statement, error) {
+	var stmts []mdwast.Statement
+	for {
+		for p.current.Type == TokenSemicolon {
+			p.advance()
+		}
+		if p.current.Type == TokenEOF || p.isTerminator(terminators) {
+			return stmts, nil
+		}
+
+		stmt, err := p.parseStatement()
+		if err == nil && p.current.Type != TokenEOF && p.current.Type != TokenSemicolon &&
+			!p.isTerminator(terminators) && p.current.Line == p.previous.Line {
+			err = p.parseError(fmt.Sprintf("unexpected token after statement: %s", p.current.Value))
+		}
+		if err != nil {
+			return stmts, err
+		}
+		stmts = append(stmts, stmt)
+	}
+}
+
+// isTerminator reports whether the current token is one of the terminators
+func (p *Parser) isTerminator(terminators []TokenType) bool {
+	for _, terminator := range terminators {
+		if p.current.Type == terminator {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatement parses a single script statement
+func (p *Parser) parseStatement() (mdwast.Statement, error) {
+	switch p.current.Type {
+	case TokenLet:
+		return p.parseLet()
+	case TokenIf:
+		return p.parseIf()
+	case TokenForEach:
+		return p.parseForEach()
+	case TokenThen, TokenElse, TokenEnd, TokenDo:
+		return nil, p.parseError(fmt.Sprintf("unexpected %s outside of IF or FOREACH", p.current.Type.String()))
+	}
+	return p.parseCommand()
+}
+
+// parseIf parses IF condition THEN statements [ELSE statements] END
+func (p *Parser) parseIf() (*mdwast.IfStmt, error) {
+	pos := p.currentPosition()
+	p.advance() // consume 'IF'
+
+	condition, err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("IF condition: %w", err)
+	}
+	if p.current.Type != TokenThen {
+		return nil, p.parseError("expected THEN after IF condition")
+	}
+	p.advance() // consume 'THEN'
+
+	stmt := &mdwast.IfStmt{Condition: condition, Pos: pos}
+	stmt.Then, err = p.parseStatements(TokenElse, TokenEnd)
+	if err != nil {
+		return nil, err
+	}
+	if p.current.Type == TokenElse {
+		p.advance() // consume 'ELSE'
+		stmt.Else, err = p.parseStatements(TokenEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.current.Type != TokenEnd {
+		return nil, p.parseError(fmt.Sprintf("expected END to close IF at line %d", pos.Line))
+	}
+	p.advance() // consume 'END'
+
+	return stmt, nil
+}
+
+// parseForEach parses FOREACH name IN ($variable | command) DO statements END
+func (p *Parser) parseForEach() (*mdwast.ForEachStmt, error) {
+	pos := p.currentPosition()
+	p.advance() // consume 'FOREACH'
+
+	if p.current.Type != TokenIdentifier || strings.Contains(p.current.Value, "-") {
+		return nil, p.parseError("expected loop variable name after FOREACH")
+	}
+	stmt := &mdwast.ForEachStmt{Name: p.current.Value, Pos: pos}
+	p.advance()
+
+	if p.current.Type != TokenIn {
+		return nil, p.parseError("expected IN after loop variable name")
+	}
+	p.advance() // consume 'IN'
+
+	switch {
+	case p.current.Type == TokenVariable:
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Value = &value
+	case p.current.Type == TokenIdentifier && p.startsCommand():
+		cmd, err := p.parseCommand()
+		if err != nil {
+			return nil, fmt.Errorf("FOREACH %s: %w", stmt.Name, err)
+		}
+		stmt.Command = cmd
+	default:
+		return nil, p.parseError("expected $variable or command after IN")
+	}
+
+	if p.current.Type != TokenDo {
+		return nil, p.parseError("expected DO after FOREACH source")
+	}
+	p.advance() // consume 'DO'
+
+	body, err := p.parseStatements(TokenEnd)
+	if err != nil {
+		return nil, err
+	}
+	if p.current.Type != TokenEnd {
+		return nil, p.parseError(fmt.Sprintf("expected END to close FOREACH at line %d", pos.Line))
+	}
+	p.advance() // consume 'END'
+	stmt.Body = body
+
+	return stmt, nil
+}
+
+// parseLet parses a LET statement (LET name = command or LET name = value)
+func (p *Parser) parseLet() (*mdwast.LetStmt, error) {
+	pos := p.currentPosition()
+	p.advance() // consume 'LET'
+
+	if p.current.Type != TokenIdentifier || strings.Contains(p.current.Value, "-") {
+		return nil, p.parseError("expected variable name after LET")
+	}
+	name := p.current.Value
+	p.advance()
+
+	if p.current.Type != TokenEquals {
+		return nil, p.parseError("expected '=' after variable name")
+	}
+	p.advance() // consume '='
+
+	if p.current.Type == TokenIdentifier && p.startsCommand() {
+		cmd, err := p.parseCommand()
+		if err != nil {
+			return nil, fmt.Errorf("LET %s: %w", name, err)
+		}
+		return &mdwast.LetStmt{Name: name, Command: cmd, Pos: pos}, nil
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, fmt.Errorf("LET %s: %w", name, err)
+	}
+	return &mdwast.LetStmt{Name: name, Value: &value, Pos: pos}, nil
+}
+
+// startsCommand reports whether the current identifier begins a command
+// (OBJECT.METHOD, OBJECT[filter], or OBJECT:ID)
+func (p *Parser) startsCommand() bool {
+	switch p.peek().Type {
+	case TokenDot, TokenLeftBracket, TokenColon:
+		return true
+	default:
+		return false
+	}
+}
+
+// atStatementStart reports whether the current token begins a new script
+// statement on a later line than the previous token
+func (p *Parser) atStatementStart() bool {
+	if p.current.Line <= p.previous.Line {
+		return false
+	}
+	switch p.current.Type {
+	case TokenLet, TokenIf, TokenForEach:
+		return true
+	case TokenIdentifier:
+		return p.startsCommand()
+	default:
+		return false
+	}
+}
+
+// atBlockKeyword reports whether the current token closes a command inside
+// a block (ELSE, END, or DO). Followed by '=', the word is a parameter name.
+func (p *Parser) atBlockKeyword() bool {
+	switch p.current.Type {
+	case TokenElse, TokenEnd, TokenDo:
+		return p.peek().Type != TokenEquals
+	default:
+		return false
+	}
+}
+
+// isControlKeyword reports whether the current token is a control flow
+// keyword, which may still be used as a parameter name
+func (p *Parser) isControlKeyword() bool {
+	switch p.current.Type {
+	case TokenIf, TokenThen, TokenElse, TokenEnd, TokenForEach, TokenDo:
+		return true
+	default:
+		return false
+	}
+}
+
 // parseCommand parses a complete TCOL command
 func (p *Parser) parseCommand() (*mdwast.Command, error) {
 	pos := p.currentPosition()
@@ -126,17 +426,139 @@ func (p *Parser) parseCommand() (*mdwast.Command, error) {
 	cmd.Pos = pos
 
 	// Parse optional command chain
-	if p.options.EnableChaining && p.current.Type == TokenPipe {
-		p.advance() // consume '|'
-		
+	if err := p.parseChain(cmd); err != nil {
+		return nil, err
+	}
+
+	return cmd, nil
+}
+
+// parseChain parses the stage following '|' after cmd, which is a command
+// or a transform stage, and the rest of the pipe
+func (p *Parser) parseChain(cmd *mdwast.Command) error {
+	if !p.options.EnableChaining || p.current.Type != TokenPipe {
+		return nil
+	}
+	p.advance() // consume '|'
+
+	if !p.atTransform() {
 		chainCmd, err := p.parseCommand()
 		if err != nil {
-			return nil, fmt.Errorf("chain command: %w", err)
+			return fmt.Errorf("chain command: %w", err)
 		}
 		cmd.Chain = chainCmd
+		return nil
 	}
 
-	return cmd, nil
+	pos := p.currentPosition()
+	transform, err := p.parseTransform()
+	if err != nil {
+		return fmt.Errorf("transform: %w", err)
+	}
+	transform.Pos = pos
+	cmd.Chain = &mdwast.Command{Transform: transform, Pos: pos}
+	return p.parseChain(cmd.Chain)
+}
+
+// atTransform reports whether the current identifier begins a transform
+// stage. Followed by '.', '[', or ':', the word is an object name.
+func (p *Parser) atTransform() bool {
+	if p.current.Type != TokenIdentifier || p.startsCommand() {
+		return false
+	}
+	switch mdwast.TransformKind(strings.ToUpper(p.current.Value)) {
+	case mdwast.TransformSelect, mdwast.TransformSort, mdwast.TransformLimit, mdwast.TransformGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseTransform parses SELECT field, ..., SORT BY field [ASC|DESC], ...,
+// LIMIT count, or GROUP BY field, ...
+func (p *Parser) parseTransform() (*mdwast.Transform, error) {
+	transform := &mdwast.Transform{Kind: mdwast.TransformKind(strings.ToUpper(p.current.Value))}
+	p.advance()
+
+	if transform.Kind == mdwast.TransformLimit {
+		if p.current.Type != TokenNumber {
+			return nil, p.parseError("expected row count after LIMIT")
+		}
+		limit, err := strconv.Atoi(p.current.Value)
+		if err != nil || limit < 1 {
+			return nil, p.parseError("LIMIT requires a positive integer")
+		}
+		transform.Limit = limit
+		p.advance()
+		return transform, p.expectStageEnd()
+	}
+
+	if transform.Kind != mdwast.TransformSelect {
+		if p.current.Type != TokenIdentifier || !strings.EqualFold(p.current.Value, "BY") {
+			return nil, p.parseError(fmt.Sprintf("expected BY after %s", transform.Kind))
+		}
+		p.advance()
+	}
+
+	for {
+		field, err := p.parseFieldPath()
+		if err != nil {
+			return nil, err
+		}
+		transform.Fields = append(transform.Fields, field)
+
+		if transform.Kind == mdwast.TransformSort {
+			descending := false
+			if p.current.Type == TokenIdentifier {
+				switch strings.ToUpper(p.current.Value) {
+				case "ASC":
+					p.advance()
+				case "DESC":
+					descending = true
+					p.advance()
+				}
+			}
+			transform.Descending = append(transform.Descending, descending)
+		}
+
+		if p.current.Type != TokenComma {
+			break
+		}
+		p.advance() // consume ','
+	}
+
+	return transform, p.expectStageEnd()
+}
+
+// parseFieldPath parses a field name with optional sub-fields (name.sub)
+func (p *Parser) parseFieldPath() (string, error) {
+	if p.current.Type != TokenIdentifier {
+		return "", p.parseError("expected field name")
+	}
+	path := p.current.Value
+	p.advance()
+
+	for p.current.Type == TokenDot {
+		p.advance() // consume '.'
+		if p.current.Type != TokenIdentifier {
+			return "", p.parseError("expected field name after '.'")
+		}
+		path += "." + p.current.Value
+		p.advance()
+	}
+	return path, nil
+}
+
+// expectStageEnd checks that a transform stage ends at a pipe or the end of
+// its statement
+func (p *Parser) expectStageEnd() error {
+	switch {
+	case p.current.Type == TokenEOF, p.current.Type == TokenPipe, p.current.Type == TokenSemicolon,
+		p.atStatementStart(), p.atBlockKeyword():
+		return nil
+	default:
+		return p.parseError("unexpected token after transform")
+	}
 }
 
 // parseMainCommand parses the main command (without chaining)
@@ -177,9 +599,16 @@ func (p *Parser) parseMainCommand() (*mdwast.Command, error) {
 	method := p.current.Value
 	p.advance()
 
+	// Parse optional method version (METHOD@v2)
+	version, err := p.parseMethodVersion()
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse optional parameters
 	parameters := make(map[string]mdwast.Value)
-	for p.current.Type != TokenEOF && p.current.Type != TokenPipe && p.current.Type != TokenSemicolon {
+	for p.current.Type != TokenEOF && p.current.Type != TokenPipe && p.current.Type != TokenSemicolon &&
+		!p.atStatementStart() && !p.atBlockKeyword() {
 		param, err := p.parseParameter()
 		if err != nil {
 			return nil, fmt.Errorf("parameter: %w", err)
@@ -190,11 +619,29 @@ func (p *Parser) parseMainCommand() (*mdwast.Command, error) {
 	return &mdwast.Command{
 		Object:     object,
 		Method:     method,
+		Version:    version,
 		Parameters: parameters,
 		Filter:     filter,
 	}, nil
 }
 
+// parseMethodVersion parses the version after a method name (@v2); it
+// returns 0 if there is none
+func (p *Parser) parseMethodVersion() (int, error) {
+	if p.current.Type != TokenAt {
+		return 0, nil
+	}
+	p.advance() // consume '@'
+
+	name := strings.ToLower(p.current.Value)
+	version, err := strconv.Atoi(strings.TrimPrefix(name, "v"))
+	if p.current.Type != TokenIdentifier || !strings.HasPrefix(name, "v") || err != nil || version <= 0 {
+		return 0, p.parseError("expected method version such as v2 after '@'")
+	}
+	p.advance()
+	return version, nil
+}
+
 // parseObjectAccess parses object access patterns (OBJECT:ID or OBJECT:ID:field=value)
 func (p *Parser) parseObjectAccess(object string) (*mdwast.Command, error) {
 	p.advance() // consume ':'
@@ -362,17 +809,164 @@ func (p *Parser) parseEqualityExpression() (mdwast.Expr, error) {
 	return left, nil
 }
 
-// parseComparisonExpression parses comparison expressions (<, >, <=, >=, LIKE, IN)
+// parseComparisonExpression parses comparison expressions (<, >, <=, >=,
+// [NOT] LIKE, [NOT] IN, [NOT] BETWEEN, IS [NOT] NULL). Negated forms become
+// a NOT UnaryExpr around the positive form.
 func (p *Parser) parseComparisonExpression() (mdwast.Expr, error) {
+	left, err := p.parseAdditiveExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		pos := p.currentPosition()
+
+		negated := false
+		if p.current.Type == TokenNot {
+			next := p.peek().Type
+			if next != TokenLike && next != TokenIn && next != TokenBetween {
+				return left, nil
+			}
+			negated = true
+			p.advance() // consume NOT
+		}
+
+		var expr mdwast.Expr
+		switch p.current.Type {
+		case TokenLess, TokenLessEq, TokenGreater, TokenGreaterEq, TokenLike:
+			op := p.current.Value
+			p.advance()
+
+			right, err := p.parseAdditiveExpression()
+			if err != nil {
+				return nil, err
+			}
+			expr = &mdwast.BinaryExpr{Left: left, Op: op, Right: right, Pos: pos}
+
+		case TokenIn:
+			op := p.current.Value
+			p.advance()
+
+			right, err := p.parseInOperand()
+			if err != nil {
+				return nil, err
+			}
+			expr = &mdwast.BinaryExpr{Left: left, Op: op, Right: right, Pos: pos}
+
+		case TokenBetween:
+			p.advance()
+
+			// Bounds are additive expressions, so the AND is not consumed
+			low, err := p.parseAdditiveExpression()
+			if err != nil {
+				return nil, err
+			}
+			if p.current.Type != TokenAnd {
+				return nil, p.parseError("expected AND in BETWEEN expression")
+			}
+			p.advance() // consume AND
+			high, err := p.parseAdditiveExpression()
+			if err != nil {
+				return nil, err
+			}
+			expr = &mdwast.BetweenExpr{Expr: left, Low: low, High: high, Pos: pos}
+
+		case TokenIs:
+			p.advance()
+
+			if p.current.Type == TokenNot {
+				negated = true
+				p.advance()
+			}
+			if p.current.Type != TokenNull {
+				return nil, p.parseError("expected NULL after IS")
+			}
+			p.advance()
+			expr = &mdwast.IsNullExpr{Expr: left, Pos: pos}
+
+		default:
+			return left, nil
+		}
+
+		if negated {
+			expr = &mdwast.UnaryExpr{Op: "NOT", Expr: expr, Pos: pos}
+		}
+		left = expr
+	}
+}
+
+// parseInOperand parses the right operand of IN: a parenthesized list
+// (a, b, c), which becomes an ArrayExpr, or any additive expression such as
+// an array literal or a field holding a list
+func (p *Parser) parseInOperand() (mdwast.Expr, error) {
+	if p.current.Type != TokenLeftParen {
+		return p.parseAdditiveExpression()
+	}
+
+	pos := p.currentPosition()
+	p.advance() // consume '('
+
+	var elements []mdwast.Expr
+	for {
+		elem, err := p.parseExpression()
+		if err != nil {
+			return nil, fmt.Errorf("IN list element: %w", err)
+		}
+		elements = append(elements, elem)
+
+		if p.current.Type != TokenComma {
+			break
+		}
+		p.advance() // consume ','
+	}
+
+	if p.current.Type != TokenRightParen {
+		return nil, p.parseError("expected ')' after IN list")
+	}
+	p.advance() // consume ')'
+
+	return &mdwast.ArrayExpr{
+		Elements: elements,
+		Pos:      pos,
+	}, nil
+}
+
+// parseAdditiveExpression parses additive expressions (+, -)
+func (p *Parser) parseAdditiveExpression() (mdwast.Expr, error) {
+	left, err := p.parseMultiplicativeExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.current.Type == TokenPlus || p.current.Type == TokenMinus {
+		op := p.current.Value
+		pos := p.currentPosition()
+		p.advance()
+
+		right, err := p.parseMultiplicativeExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &mdwast.BinaryExpr{
+			Left:  left,
+			Op:    op,
+			Right: right,
+			Pos:   pos,
+		}
+	}
+
+	return left, nil
+}
+
+// parseMultiplicativeExpression parses multiplicative expressions (*, /, %)
+func (p *Parser) parseMultiplicativeExpression() (mdwast.Expr, error) {
 	left, err := p.parseUnaryExpression()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.current.Type == TokenLess || p.current.Type == TokenLessEq ||
-		p.current.Type == TokenGreater || p.current.Type == TokenGreaterEq ||
-		p.current.Type == TokenLike || p.current.Type == TokenIn {
-		
+	for p.current.Type == TokenStar || p.current.Type == TokenSlash || p.current.Type == TokenPercent {
 		op := p.current.Value
 		pos := p.currentPosition()
 		p.advance()
@@ -395,7 +989,7 @@ func (p *Parser) parseComparisonExpression() (mdwast.Expr, error) {
 
 // parseUnaryExpression parses unary expressions (NOT, -)
 func (p *Parser) parseUnaryExpression() (mdwast.Expr, error) {
-	if p.current.Type == TokenNot {
+	if p.current.Type == TokenNot || p.current.Type == TokenMinus {
 		op := p.current.Value
 		pos := p.currentPosition()
 		p.advance()
@@ -405,6 +999,20 @@ func (p *Parser) parseUnaryExpression() (mdwast.Expr, error) {
 			return nil, err
 		}
 
+		// Negative number literals stay literals
+		if literal, ok := expr.(*mdwast.LiteralExpr); ok && op == "-" && literal.Value.Type == mdwast.ValueTypeNumber {
+			switch v := literal.Value.Value.(type) {
+			case int64:
+				literal.Value.Value = -v
+			case float64:
+				literal.Value.Value = -v
+			}
+			literal.Value.Raw = "-" + literal.Value.Raw
+			literal.Pos = pos
+			literal.Value.Pos = pos
+			return literal, nil
+		}
+
 		return &mdwast.UnaryExpr{
 			Op:   op,
 			Expr: expr,
@@ -435,7 +1043,7 @@ func (p *Parser) parsePrimaryExpression() (mdwast.Expr, error) {
 			Pos:  pos,
 		}, nil
 
-	case TokenString, TokenNumber, TokenBoolean, TokenNull:
+	case TokenString, TokenNumber, TokenBoolean, TokenNull, TokenVariable:
 		value, err := p.parseValue()
 		if err != nil {
 			return nil, err
@@ -618,7 +1226,7 @@ type Parameter struct {
 
 // parseParameter parses a parameter (name=value)
 func (p *Parser) parseParameter() (*Parameter, error) {
-	if p.current.Type != TokenIdentifier {
+	if p.current.Type != TokenIdentifier && !p.isControlKeyword() {
 		return nil, p.parseError("expected parameter name")
 	}
 
@@ -648,10 +1256,14 @@ func (p *Parser) parseValue() (mdwast.Value, error) {
 	switch p.current.Type {
 	case TokenString:
 		value := mdwast.Value{
-			Type:  mdwast.ValueTypeString,
-			Raw:   p.current.Value,
-			Value: p.current.Value,
-			Pos:   pos,
+			Type:        mdwast.ValueTypeString,
+			Raw:         p.current.Value,
+			Value:       p.current.Value,
+			Pos:         pos,
+			Interpolate: p.current.Interpolate,
+		}
+		if value.Interpolate {
+			value.Value = strings.ReplaceAll(p.current.Value, `\$`, "$")
 		}
 		p.advance()
 		return value, nil
@@ -723,6 +1335,22 @@ func (p *Parser) parseValue() (mdwast.Value, error) {
 		p.advance()
 		return value, nil
 
+	case TokenVariable:
+		value := mdwast.Value{
+			Type:  mdwast.ValueTypeVariable,
+			Raw:   "$" + p.current.Value,
+			Value: p.current.Value,
+			Pos:   pos,
+		}
+		p.advance()
+		return value, nil
+
+	case TokenIllegal:
+		if strings.HasPrefix(p.current.Value, "<<") || strings.HasPrefix(p.current.Value, `"""`) {
+			return mdwast.Value{}, p.parseError("unterminated or invalid multi-line string")
+		}
+		return mdwast.Value{}, p.parseError(fmt.Sprintf("expected value, got %s", p.current.Type.String()))
+
 	default:
 		return mdwast.Value{}, p.parseError(fmt.Sprintf("expected value, got %s", p.current.Type.String()))
 	}
@@ -730,10 +1358,59 @@ func (p *Parser) parseValue() (mdwast.Value, error) {
 
 // Utility methods
 
+// observe starts the parse span of an input and returns a function that
+// ends it and records the outcome in the metrics
+func (p *Parser) observe(ctx context.Context, input string) (context.Context, func(error)) {
+	startTime := time.Now()
+	ctx, span := p.options.Tracer.Start(ctx, mdwtelemetry.SpanParse, mdwtelemetry.Int(mdwtelemetry.AttrLength, len(input)))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		if p.options.Metrics != nil {
+			p.options.Metrics.ObserveParse(time.Since(startTime), err)
+		}
+	}
+}
+
+// start tokenizes the input in a lex span and loads the first token.
+// Tokenizing stops at the first illegal token, which the parser rejects.
+func (p *Parser) start(ctx context.Context, input string) {
+	_, span := p.options.Tracer.Start(ctx, mdwtelemetry.SpanLex)
+	lexer := NewLexer(input)
+	p.tokens = p.tokens[:0]
+	for {
+		tok := lexer.NextToken()
+		p.tokens = append(p.tokens, tok)
+		if tok.Type == TokenIllegal {
+			span.RecordError(fmt.Errorf("illegal token '%s' at line %d, column %d", tok.Value, tok.Line, tok.Column))
+		}
+		if tok.Type == TokenEOF || tok.Type == TokenIllegal {
+			break
+		}
+	}
+	span.SetAttributes(mdwtelemetry.Int(mdwtelemetry.AttrTokens, len(p.tokens)))
+	span.End()
+
+	p.next = 0
+	p.advance()
+}
+
 // advance moves to the next token
 func (p *Parser) advance() {
 	p.previous = p.current
-	p.current = p.lexer.NextToken()
+	p.current = p.peek()
+	p.next++
+}
+
+// peek returns the token after the current one without consuming it; the
+// last token repeats at the end of the input
+func (p *Parser) peek() Token {
+	if p.next >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.next]
 }
 
 // currentPosition returns the current AST position
@@ -4,22 +4,32 @@
 //              Tests cover all command structures, expression parsing, error
 //              handling, and edge cases in TCOL syntax parsing.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.7
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial comprehensive parser test suite
+// - 2026-10-16 v0.1.1: Added filter grammar tests
+// - 2026-10-16 v0.1.2: Added multi-line string parameter tests
+// - 2026-10-16 v0.1.3: Added script and LET tests
+// - 2026-10-16 v0.1.4: Added IF and FOREACH tests
+// - 2026-10-16 v0.1.5: Added transform stage tests
+// - 2026-10-16 v0.1.6: Added method version tests
+// - 2026-10-16 v0.1.7: Added tracing and metrics tests
 
 package parser
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
 )
 
 func TestParser_Parse(t *testing.T) {
@@ -593,6 +603,390 @@ func TestParser_DisableChaining(t *testing.T) {
 	}
 }
 
+func TestParser_ParseExpression(t *testing.T) {
+	parser, _ := New(Options{
+		Logger: mdwlog.GetDefault(),
+	})
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Comparison", "age > 30", "(age > 30)", false},
+		{"Function call", "is_overdue() AND total >= 100", "(is_overdue() AND (total >= 100))", false},
+		{"Array membership", `status IN ["open", "late"]`, "(status IN [open, late])", false},
+		{"Trailing tokens", "age > 30 ]", "", true},
+		{"Incomplete", "age >", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExpression(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for input %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Errorf("ParseExpression(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_FilterGrammar(t *testing.T) {
+	parser, _ := New(Options{
+		Logger: mdwlog.GetDefault(),
+	})
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Grouping", `(status="open" OR status="hold") AND amount>100`, "(((status = open) OR (status = hold)) AND (amount > 100))", false},
+		{"IN list", `status IN ("open", "hold")`, "(status IN [open, hold])", false},
+		{"NOT IN", "id NOT IN (1, 2, 3)", "(NOT (id IN [1, 2, 3]))", false},
+		{"BETWEEN", "amount BETWEEN 10 AND 20 AND active", "((amount BETWEEN 10 AND 20) AND active)", false},
+		{"NOT BETWEEN", "amount NOT BETWEEN -5 AND 5", "(NOT (amount BETWEEN -5 AND 5))", false},
+		{"NOT LIKE", `name NOT LIKE "A%"`, "(NOT (name LIKE A%))", false},
+		{"IS NULL", "deleted_at IS NULL", "(deleted_at IS NULL)", false},
+		{"IS NOT NULL", "deleted_at IS NOT NULL", "(NOT (deleted_at IS NULL))", false},
+		{"Arithmetic on both sides", "amount * 1.19 > limit - 10", "((amount * 1.19) > (limit - 10))", false},
+		{"Arithmetic precedence", "a + b * c = -5", "((a + (b * c)) = -5)", false},
+		{"Unary minus", "-(a + b) < 0", "((- (a + b)) < 0)", false},
+		{"Modulo", "total % 2 = 0", "((total % 2) = 0)", false},
+		{"NOT before comparison", "NOT active AND x = 1", "((NOT active) AND (x = 1))", false},
+		{"BETWEEN without AND", "amount BETWEEN 10", "", true},
+		{"IS without NULL", "amount IS 5", "", true},
+		{"Unclosed IN list", "id IN (1, 2", "", true},
+		{"Dangling operator", "a + ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExpression(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for input %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Errorf("ParseExpression(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	// Filters in commands use the same grammar and yield typed nodes
+	cmd, err := parser.Parse(`ORDER[amount BETWEEN 100 AND 500 AND closed_at IS NOT NULL].LIST`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	and, ok := cmd.Filter.Condition.(*mdwast.BinaryExpr)
+	if !ok {
+		t.Fatalf("Expected BinaryExpr, got %T", cmd.Filter.Condition)
+	}
+	if _, ok := and.Left.(*mdwast.BetweenExpr); !ok {
+		t.Errorf("Expected BetweenExpr, got %T", and.Left)
+	}
+	if not, ok := and.Right.(*mdwast.UnaryExpr); !ok || not.Op != "NOT" {
+		t.Errorf("Expected NOT UnaryExpr, got %T", and.Right)
+	} else if _, ok := not.Expr.(*mdwast.IsNullExpr); !ok {
+		t.Errorf("Expected IsNullExpr, got %T", not.Expr)
+	}
+}
+
+func TestParser_MultiLineStrings(t *testing.T) {
+	parser, _ := New(Options{
+		Logger: mdwlog.GetDefault(),
+	})
+
+	input := "EMAIL.SEND to=\"ops@example.com\" body=<<EOF\nTotal: \\$100\nSent by $user\nEOF\n"
+	cmd, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	body := cmd.Parameters["body"]
+	if body.Value != "Total: $100\nSent by $user" || !body.Interpolate {
+		t.Errorf("body = %q (interpolate %v)", body.Value, body.Interpolate)
+	}
+	if body.Raw != "Total: \\$100\nSent by $user" {
+		t.Errorf("body raw = %q", body.Raw)
+	}
+	if got := body.GetStringValue(); got != "Total: $100\nSent by $user" {
+		t.Errorf("GetStringValue() = %q", got)
+	}
+
+	// The canonical form parses to the same value
+	again, err := parser.Parse("EMAIL.SEND body=" + body.String())
+	if err != nil {
+		t.Fatalf("Parse of %s failed: %v", body.String(), err)
+	}
+	if again.Parameters["body"].Value != body.Value {
+		t.Errorf("round trip = %q", again.Parameters["body"].Value)
+	}
+
+	if _, err := parser.Parse("EMAIL.SEND body=<<EOF\nno end"); err == nil || !strings.Contains(err.Error(), "multi-line string") {
+		t.Errorf("unterminated heredoc error = %v", err)
+	}
+}
+
+func TestParser_ParseScript(t *testing.T) {
+	parser, _ := New(Options{
+		Logger:         mdwlog.GetDefault(),
+		EnableChaining: true,
+	})
+
+	script, err := parser.ParseScript(`
+LET cust = CUSTOMER.CREATE name="ACME"
+  email="info@acme.example"
+LET limit = 500
+INVOICE.CREATE customer_id=$cust.id amount=$limit; INVOICE[amount > $limit].LIST
+CUSTOMER.CREATE name="Other" |
+  INVOICE.CREATE customer_id=$LAST_ID
+`)
+	if err != nil {
+		t.Fatalf("ParseScript failed: %v", err)
+	}
+	if len(script.Statements) != 5 {
+		t.Fatalf("Expected 5 statements, got %d: %s", len(script.Statements), script)
+	}
+
+	let, ok := script.Statements[0].(*mdwast.LetStmt)
+	if !ok || let.Name != "cust" || let.Command == nil || len(let.Command.Parameters) != 2 {
+		t.Errorf("Statement 1 = %#v", script.Statements[0])
+	}
+	if let, ok := script.Statements[1].(*mdwast.LetStmt); !ok || let.Value == nil || let.Value.Value != int64(500) {
+		t.Errorf("Statement 2 = %#v", script.Statements[1])
+	}
+
+	invoice, ok := script.Statements[2].(*mdwast.Command)
+	if !ok {
+		t.Fatalf("Statement 3 = %T", script.Statements[2])
+	}
+	customerID := invoice.Parameters["customer_id"]
+	if customerID.Type != mdwast.ValueTypeVariable || customerID.Value != "cust.id" || customerID.Raw != "$cust.id" {
+		t.Errorf("customer_id = %#v", customerID)
+	}
+	filter := script.Statements[3].(*mdwast.Command).Filter.Condition.(*mdwast.BinaryExpr)
+	if literal, ok := filter.Right.(*mdwast.LiteralExpr); !ok || literal.Value.Type != mdwast.ValueTypeVariable {
+		t.Errorf("Filter operand = %#v", filter.Right)
+	}
+	if chained := script.Statements[4].(*mdwast.Command); chained.Chain == nil {
+		t.Error("Chain across lines not parsed")
+	}
+	if errs := mdwast.ValidateAST(script); len(errs) > 0 {
+		t.Errorf("ValidateAST() = %v", errs)
+	}
+
+	invalid := []string{
+		"",
+		"LET = CUSTOMER.LIST",
+		"LET x CUSTOMER.LIST",
+		"LET x-y = 1",
+		"CUSTOMER.LIST INVOICE.LIST",
+		"LET x = 1 2",
+	}
+	for _, input := range invalid {
+		if _, err := parser.ParseScript(input); err == nil {
+			t.Errorf("ParseScript(%q) did not fail", input)
+		}
+	}
+}
+
+func TestParser_ControlFlow(t *testing.T) {
+	parser, _ := New(Options{
+		Logger:         mdwlog.GetDefault(),
+		EnableChaining: true,
+	})
+
+	script, err := parser.ParseScript(`
+LET limit = 500
+FOREACH c IN CUSTOMER[status = "active"].LIST DO
+  IF $c.balance > $limit THEN
+    INVOICE.CREATE customer_id=$c.id end="2026-12-31"
+  ELSE
+    CUSTOMER.NOTIFY id=$c.id
+  END
+END
+FOREACH i IN $c DO INVOICE.SEND id=$i.id; END
+IF $limit = 0 THEN CUSTOMER.LIST END
+`)
+	if err != nil {
+		t.Fatalf("ParseScript failed: %v", err)
+	}
+	if len(script.Statements) != 4 {
+		t.Fatalf("Expected 4 statements, got %d: %s", len(script.Statements), script)
+	}
+
+	loop, ok := script.Statements[1].(*mdwast.ForEachStmt)
+	if !ok || loop.Name != "c" || loop.Command == nil || loop.Command.Filter == nil || len(loop.Body) != 1 {
+		t.Fatalf("Statement 2 = %#v", script.Statements[1])
+	}
+	branch, ok := loop.Body[0].(*mdwast.IfStmt)
+	if !ok || len(branch.Then) != 1 || len(branch.Else) != 1 {
+		t.Fatalf("FOREACH body = %#v", loop.Body[0])
+	}
+	if cond, ok := branch.Condition.(*mdwast.BinaryExpr); !ok || cond.Op != ">" {
+		t.Errorf("IF condition = %#v", branch.Condition)
+	}
+	create := branch.Then[0].(*mdwast.Command)
+	if create.Parameters["end"].Value != "2026-12-31" || len(create.Parameters) != 2 {
+		t.Errorf("THEN parameters = %v", create.Parameters)
+	}
+
+	overVar := script.Statements[2].(*mdwast.ForEachStmt)
+	if overVar.Value == nil || overVar.Value.Type != mdwast.ValueTypeVariable || len(overVar.Body) != 1 {
+		t.Errorf("Statement 3 = %#v", overVar)
+	}
+	if single := script.Statements[3].(*mdwast.IfStmt); len(single.Then) != 1 || single.Else != nil {
+		t.Errorf("Statement 4 = %#v", single)
+	}
+	if errs := mdwast.ValidateAST(script); len(errs) > 0 {
+		t.Errorf("ValidateAST() = %v", errs)
+	}
+
+	invalid := map[string]string{
+		"IF $a > 1 CUSTOMER.LIST END":             "expected THEN",
+		"IF $a > 1 THEN\nCUSTOMER.LIST":           "expected END to close IF",
+		"FOREACH c IN CUSTOMER.LIST DO":           "expected END to close FOREACH",
+		"FOREACH c CUSTOMER.LIST DO END":          "expected IN",
+		"FOREACH c IN 42 DO END":                  "expected $variable or command",
+		"FOREACH c IN $list\nCUSTOMER.LIST\nEND": "expected DO",
+		"CUSTOMER.LIST\nEND":                      "unexpected END",
+		"IF $a THEN ELSE ELSE END":                "unexpected ELSE",
+		"IF $a THEN CUSTOMER.LIST END INVOICE.LIST": "unexpected token after statement",
+	}
+	for input, errMsg := range invalid {
+		if _, err := parser.ParseScript(input); err == nil || !strings.Contains(err.Error(), errMsg) {
+			t.Errorf("ParseScript(%q) error = %v, want %q", input, err, errMsg)
+		}
+	}
+}
+
+func TestParser_Transforms(t *testing.T) {
+	parser, _ := New(Options{
+		Logger:         mdwlog.GetDefault(),
+		EnableChaining: true,
+	})
+
+	cmd, err := parser.Parse(`CUSTOMER.LIST | SELECT name, address.city | sort by total desc, name | LIMIT 10 | GROUP BY region | EXPORT.CSV file=$PREV`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var stages []string
+	for stage := cmd.Chain; stage != nil; stage = stage.Chain {
+		if stage.IsTransform() {
+			stages = append(stages, stage.Transform.String())
+		} else {
+			stages = append(stages, stage.Object+"."+stage.Method)
+		}
+	}
+	expected := "SELECT name, address.city|SORT BY total DESC, name|LIMIT 10|GROUP BY region|EXPORT.CSV"
+	if got := strings.Join(stages, "|"); got != expected {
+		t.Errorf("stages = %s, want %s", got, expected)
+	}
+	if errs := mdwast.ValidateAST(cmd); len(errs) > 0 {
+		t.Errorf("ValidateAST() = %v", errs)
+	}
+
+	// Followed by '.', a transform word is an object name
+	if cmd, err := parser.Parse("CUSTOMER.LIST | LIMIT.SET value=1"); err != nil || cmd.Chain.IsTransform() || cmd.Chain.Object != "LIMIT" {
+		t.Errorf("Parse(LIMIT.SET) = %v, %v", cmd, err)
+	}
+
+	invalid := map[string]string{
+		"CUSTOMER.LIST | LIMIT 0":         "LIMIT requires a positive integer",
+		"CUSTOMER.LIST | LIMIT name":      "expected row count after LIMIT",
+		"CUSTOMER.LIST | SORT name":       "expected BY after SORT",
+		"CUSTOMER.LIST | GROUP BY":        "expected field name",
+		"CUSTOMER.LIST | SELECT name age": "unexpected token after transform",
+		"SELECT name":                     "expected '.' after object name",
+	}
+	for input, errMsg := range invalid {
+		if _, err := parser.Parse(input); err == nil || !strings.Contains(err.Error(), errMsg) {
+			t.Errorf("Parse(%q) error = %v, want %q", input, err, errMsg)
+		}
+	}
+}
+
+func TestParser_MethodVersions(t *testing.T) {
+	parser, _ := New(Options{
+		Logger:         mdwlog.GetDefault(),
+		EnableChaining: true,
+	})
+
+	cmd, err := parser.Parse(`CUSTOMER[status="new"].UPDATE@v2 status="active" | INVOICE.LIST@V3`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if cmd.Method != "UPDATE" || cmd.Version != 2 || cmd.Chain.Version != 3 {
+		t.Errorf("versions = %s@%d, %s@%d", cmd.Method, cmd.Version, cmd.Chain.Method, cmd.Chain.Version)
+	}
+	if cmd, _ := parser.Parse("CUSTOMER.LIST"); cmd.Version != 0 {
+		t.Errorf("Version without @ = %d, want 0", cmd.Version)
+	}
+
+	// The canonical text keeps the version
+	expected := `CUSTOMER[(status = "new")].UPDATE@v2 status="active" | INVOICE.LIST@v3`
+	if got := cmd.String(); got != expected {
+		t.Errorf("String() = %s, want %s", got, expected)
+	}
+
+	for _, input := range []string{"CUSTOMER.UPDATE@", "CUSTOMER.UPDATE@2", "CUSTOMER.UPDATE@v0", "CUSTOMER.UPDATE@beta"} {
+		if _, err := parser.Parse(input); err == nil || !strings.Contains(err.Error(), "expected method version") {
+			t.Errorf("Parse(%q) error = %v, want invalid version", input, err)
+		}
+	}
+}
+
+func TestParser_Telemetry(t *testing.T) {
+	tracer := mdwtelemetry.NewRecordingTracer()
+	metrics := mdwtelemetry.NewMetrics()
+	parser, _ := New(Options{EnableChaining: true, Tracer: tracer, Metrics: metrics})
+
+	ctx, root := tracer.Start(context.Background(), "request")
+	if _, err := parser.ParseContext(ctx, `CUSTOMER.LIST | SORT BY name`); err != nil {
+		t.Fatalf("ParseContext() error = %v", err)
+	}
+	root.End()
+	if _, err := parser.ParseScript("LET x = CUSTOMER.LIST\nCUSTOMER.CREATE name=#"); err == nil {
+		t.Fatal("ParseScript() with an illegal character succeeded")
+	}
+
+	spans := tracer.Spans()
+	if len(spans) != 5 {
+		t.Fatalf("spans = %+v, want 5", spans)
+	}
+	lex, parse, request := spans[0], spans[1], spans[2]
+	if lex.Name != mdwtelemetry.SpanLex || lex.ParentID != parse.ID || lex.Attributes[mdwtelemetry.AttrTokens] != 8 {
+		t.Errorf("lex span = %+v", lex)
+	}
+	if parse.Name != mdwtelemetry.SpanParse || parse.ParentID != request.ID || parse.Err != nil {
+		t.Errorf("parse span = %+v", parse)
+	}
+	if failedLex, failedParse := spans[3], spans[4]; failedLex.Err == nil || failedParse.Err == nil || failedParse.ParentID != 0 {
+		t.Errorf("spans of the failed script = %+v, %+v", failedLex, failedParse)
+	}
+
+	snapshot := metrics.Snapshot()
+	if snapshot.ParsesTotal != 2 || snapshot.ParseErrors != 1 || snapshot.ErrorCodes["TCOL_SYNTAX"] != 1 {
+		t.Errorf("metrics = %+v", snapshot)
+	}
+}
+
 func TestParseError_Error(t *testing.T) {
 	err := &ParseError{
 		Message:  "test error",
@@ -0,0 +1,490 @@
+// File: aliases.go
+// Title: TCOL Persistent Alias Namespaces
+// Description: Implements global and per-user command aliases backed by a
+//              pluggable AliasStore. Provides in-memory and file-based
+//              stores, conflict detection against registered commands, and
+//              hot reload of aliases changed outside the process.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of alias stores and namespaces
+
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/log"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// ErrAliasNotFound is returned for aliases that do not exist
+var ErrAliasNotFound = errors.New("alias not found")
+
+// Alias is a command alias in the global namespace or a user's namespace
+type Alias struct {
+	Name      string    `json:"name"`
+	Command   string    `json:"command"`
+	UserID    string    `json:"user_id,omitempty"` // Owner; empty for global aliases
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AliasStore persists aliases. UserID "" denotes the global namespace.
+type AliasStore interface {
+	Load() ([]Alias, error)
+	Save(alias Alias) error
+	Delete(userID, name string) error
+}
+
+// AliasWatcher is implemented by alias stores that can report changes made
+// outside the process
+type AliasWatcher interface {
+	Watch(onChange func()) (io.Closer, error)
+}
+
+// aliasKey identifies an alias within all namespaces
+type aliasKey struct {
+	userID string
+	name   string
+}
+
+// MemoryAliasStore keeps aliases in memory
+type MemoryAliasStore struct {
+	aliases map[aliasKey]Alias
+	mutex   sync.RWMutex
+}
+
+// NewMemoryAliasStore creates an empty in-memory alias store
+func NewMemoryAliasStore() *MemoryAliasStore {
+	return &MemoryAliasStore{aliases: make(map[aliasKey]Alias)}
+}
+
+// Load returns all aliases ordered by namespace and name
+func (s *MemoryAliasStore) Load() ([]Alias, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	aliases := make([]Alias, 0, len(s.aliases))
+	for _, alias := range s.aliases {
+		aliases = append(aliases, alias)
+	}
+	sortAliases(aliases)
+	return aliases, nil
+}
+
+// Save stores or replaces an alias
+func (s *MemoryAliasStore) Save(alias Alias) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.aliases[aliasKey{alias.UserID, alias.Name}] = alias
+	return nil
+}
+
+// Delete removes an alias
+func (s *MemoryAliasStore) Delete(userID, name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := aliasKey{userID, name}
+	if _, exists := s.aliases[key]; !exists {
+		return ErrAliasNotFound
+	}
+	delete(s.aliases, key)
+	return nil
+}
+
+// FileAliasStore keeps each namespace in a JSON file: global.json for
+// global aliases and users/<user ID>.json for the aliases of a user
+type FileAliasStore struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// NewFileAliasStore creates an alias store in dir, creating the directory
+// if needed
+func NewFileAliasStore(dir string) (*FileAliasStore, error) {
+	if mdwstringx.IsBlank(dir) {
+		return nil, fmt.Errorf("alias store directory is required")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "users"), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create alias store directory: %w", err)
+	}
+	return &FileAliasStore{dir: dir}, nil
+}
+
+// Load reads the aliases of all namespaces
+func (s *FileAliasStore) Load() ([]Alias, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	aliases, err := readAliasFile(filepath.Join(s.dir, "global.json"))
+	if err != nil {
+		return nil, err
+	}
+	for i := range aliases {
+		aliases[i].UserID = ""
+	}
+
+	// The namespace of user aliases is given by their file, not their content
+	files, err := filepath.Glob(filepath.Join(s.dir, "users", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		namespace, err := readAliasFile(file)
+		if err != nil {
+			return nil, err
+		}
+		userID := strings.TrimSuffix(filepath.Base(file), ".json")
+		for i := range namespace {
+			namespace[i].UserID = userID
+		}
+		aliases = append(aliases, namespace...)
+	}
+	sortAliases(aliases)
+	return aliases, nil
+}
+
+// Save stores or replaces an alias in the file of its namespace
+func (s *FileAliasStore) Save(alias Alias) error {
+	path, err := s.path(alias.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	aliases, err := readAliasFile(path)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range aliases {
+		if aliases[i].Name == alias.Name {
+			aliases[i], replaced = alias, true
+		}
+	}
+	if !replaced {
+		aliases = append(aliases, alias)
+	}
+	return writeAliasFile(path, aliases)
+}
+
+// Delete removes an alias from the file of its namespace
+func (s *FileAliasStore) Delete(userID, name string) error {
+	path, err := s.path(userID)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	aliases, err := readAliasFile(path)
+	if err != nil {
+		return err
+	}
+	for i := range aliases {
+		if aliases[i].Name == name {
+			return writeAliasFile(path, append(aliases[:i], aliases[i+1:]...))
+		}
+	}
+	return ErrAliasNotFound
+}
+
+// Watch calls onChange when alias files are created, modified, or deleted
+func (s *FileAliasStore) Watch(onChange func()) (io.Closer, error) {
+	watcher, err := mdwfilex.Watch(s.dir, mdwfilex.WatchOptions{
+		Recursive:    true,
+		Patterns:     []string{"*.json"},
+		PollInterval: time.Second,
+		Debounce:     200 * time.Millisecond,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for range watcher.Events() {
+			onChange()
+		}
+	}()
+	return watcher, nil
+}
+
+// path returns the file of a namespace, rejecting user IDs that would
+// leave the directory
+func (s *FileAliasStore) path(userID string) (string, error) {
+	if userID == "" {
+		return filepath.Join(s.dir, "global.json"), nil
+	}
+	if mdwstringx.IsBlank(userID) || strings.ContainsAny(userID, `/\`) || strings.HasPrefix(userID, ".") {
+		return "", fmt.Errorf("invalid alias user ID %q", userID)
+	}
+	return filepath.Join(s.dir, "users", userID+".json"), nil
+}
+
+// readAliasFile reads a namespace file; a missing file holds no aliases
+func readAliasFile(path string) ([]Alias, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aliases: %w", err)
+	}
+
+	var aliases []Alias
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to decode aliases in %s: %w", filepath.Base(path), err)
+	}
+	return aliases, nil
+}
+
+// writeAliasFile writes a namespace file atomically
+func writeAliasFile(path string, aliases []Alias) error {
+	sortAliases(aliases)
+	data, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode aliases: %w", err)
+	}
+	return mdwfilex.WriteFileAtomic(path, data, 0600)
+}
+
+// sortAliases orders aliases by namespace, global first, then name
+func sortAliases(aliases []Alias) {
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].UserID != aliases[j].UserID {
+			return aliases[i].UserID < aliases[j].UserID
+		}
+		return aliases[i].Name < aliases[j].Name
+	})
+}
+
+// RegisterUserAlias registers an alias in a user's namespace, or in the
+// global namespace if userID is empty. User aliases take precedence over
+// global aliases of the same name. Aliases may not shadow registered
+// objects, commands, or abbreviations.
+func (r *SimpleRegistry) RegisterUserAlias(userID, alias, command string) error {
+	if !r.options.EnableAliases {
+		return errors.New("aliases are disabled in this registry")
+	}
+
+	if mdwstringx.IsBlank(alias) {
+		return errors.New("alias name cannot be empty")
+	}
+
+	if mdwstringx.IsBlank(command) {
+		return errors.New("command cannot be empty")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Normalize alias
+	alias = strings.ToUpper(alias)
+	if err := r.aliasConflict(alias); err != nil {
+		return err
+	}
+
+	if r.options.AliasStore != nil {
+		err := r.options.AliasStore.Save(Alias{Name: alias, Command: command, UserID: userID, CreatedAt: time.Now()})
+		if err != nil {
+			return fmt.Errorf("failed to store alias %s: %w", alias, err)
+		}
+	}
+	r.namespace(userID, true)[alias] = command
+
+	r.logger.Debug("TCOL alias registered", log.Fields{
+		"alias":   alias,
+		"command": command,
+		"userID":  userID,
+	})
+
+	return nil
+}
+
+// DeleteAlias removes an alias from a user's namespace, or from the global
+// namespace if userID is empty
+func (r *SimpleRegistry) DeleteAlias(userID, alias string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	alias = strings.ToUpper(alias)
+	aliases := r.namespace(userID, false)
+	if _, exists := aliases[alias]; !exists {
+		return fmt.Errorf("alias %s: %w", alias, ErrAliasNotFound)
+	}
+
+	if r.options.AliasStore != nil {
+		err := r.options.AliasStore.Delete(userID, alias)
+		if err != nil && !errors.Is(err, ErrAliasNotFound) {
+			return fmt.Errorf("failed to delete alias %s: %w", alias, err)
+		}
+	}
+	delete(aliases, alias)
+	return nil
+}
+
+// ResolveUserAlias resolves an alias in a user's namespace, then in the
+// global namespace
+func (r *SimpleRegistry) ResolveUserAlias(userID, alias string) string {
+	if !r.options.EnableAliases {
+		return alias
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	name := strings.ToUpper(alias)
+	if command, exists := r.userAliases[userID][name]; exists {
+		return command
+	}
+	if command, exists := r.aliases[name]; exists {
+		return command
+	}
+
+	return alias
+}
+
+// GetUserAliases returns the aliases visible to a user: the global aliases
+// overridden by the user's own
+func (r *SimpleRegistry) GetUserAliases(userID string) map[string]string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	aliases := make(map[string]string, len(r.aliases))
+	for k, v := range r.aliases {
+		aliases[k] = v
+	}
+	if userID != "" {
+		for k, v := range r.userAliases[userID] {
+			aliases[k] = v
+		}
+	}
+
+	return aliases
+}
+
+// ReloadAliases replaces all aliases with those in the alias store
+func (r *SimpleRegistry) ReloadAliases() error {
+	if r.options.AliasStore == nil {
+		return errors.New("no alias store configured")
+	}
+
+	stored, err := r.options.AliasStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load aliases: %w", err)
+	}
+
+	global := make(map[string]string)
+	users := make(map[string]map[string]string)
+	for _, alias := range stored {
+		name := strings.ToUpper(alias.Name)
+		if alias.UserID == "" {
+			global[name] = alias.Command
+			continue
+		}
+		if users[alias.UserID] == nil {
+			users[alias.UserID] = make(map[string]string)
+		}
+		users[alias.UserID][name] = alias.Command
+	}
+
+	r.mutex.Lock()
+	r.aliases = global
+	r.userAliases = users
+	r.mutex.Unlock()
+
+	r.logger.Debug("TCOL aliases reloaded", log.Fields{
+		"aliasCount": len(stored),
+	})
+
+	return nil
+}
+
+// WatchAliases reloads the aliases whenever the alias store reports a
+// change; the store must implement AliasWatcher. Close stops watching.
+func (r *SimpleRegistry) WatchAliases() error {
+	watcher, ok := r.options.AliasStore.(AliasWatcher)
+	if !ok {
+		return errors.New("alias store does not support watching")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.aliasWatcher != nil {
+		return nil
+	}
+
+	closer, err := watcher.Watch(func() {
+		if err := r.ReloadAliases(); err != nil {
+			r.logger.Warn("Failed to reload TCOL aliases", log.Fields{"error": err.Error()})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch aliases: %w", err)
+	}
+	r.aliasWatcher = closer
+	return nil
+}
+
+// Close stops watching the alias store
+func (r *SimpleRegistry) Close() error {
+	r.mutex.Lock()
+	watcher := r.aliasWatcher
+	r.aliasWatcher = nil
+	r.mutex.Unlock()
+
+	if watcher == nil {
+		return nil
+	}
+	return watcher.Close()
+}
+
+// namespace returns the alias map of a user, or the global map for an
+// empty user ID. The caller must hold the mutex.
+func (r *SimpleRegistry) namespace(userID string, create bool) map[string]string {
+	if userID == "" {
+		return r.aliases
+	}
+	if r.userAliases[userID] == nil && create {
+		r.userAliases[userID] = make(map[string]string)
+	}
+	return r.userAliases[userID]
+}
+
+// aliasConflict rejects alias names that would shadow a registered object,
+// command, or abbreviation. The caller must hold the mutex.
+func (r *SimpleRegistry) aliasConflict(alias string) error {
+	if strings.ContainsAny(alias, " \t\r\n") {
+		return fmt.Errorf("alias name %q must not contain whitespace", alias)
+	}
+	if _, exists := r.objects[alias]; exists {
+		return fmt.Errorf("alias %s conflicts with object %s", alias, alias)
+	}
+	if object, method, found := strings.Cut(alias, "."); found {
+		if obj, exists := r.objects[object]; exists && obj.Methods[method] != nil {
+			return fmt.Errorf("alias %s conflicts with command %s", alias, alias)
+		}
+	}
+	if expanded, exists := r.abbreviations[alias]; exists {
+		return fmt.Errorf("alias %s conflicts with abbreviation for %s", alias, expanded)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// File: aliases_test.go
+// Title: TCOL Persistent Alias Namespace Tests
+// Description: Tests the memory and file alias stores, global and user
+//              alias namespaces, conflict detection, and hot reload.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial alias store and namespace tests
+
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newAliasRegistry(t *testing.T, store AliasStore) *SimpleRegistry {
+	registry, err := NewSimple(Options{EnableAliases: true, EnableAbbreviations: true, AliasStore: store})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	registry.RegisterObject(&ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*MethodDefinition{"LIST": {}},
+	})
+	return registry
+}
+
+func testAliasStore(t *testing.T, store AliasStore) {
+	aliases := []Alias{
+		{Name: "UC", Command: "CUSTOMER.LIST status=unpaid"},
+		{Name: "UC", Command: "CUSTOMER.LIST status=open", UserID: "alice"},
+		{Name: "AC", Command: "CUSTOMER.LIST status=active", UserID: "alice"},
+	}
+	for _, alias := range aliases {
+		if err := store.Save(alias); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	store.Save(Alias{Name: "UC", Command: "CUSTOMER.LIST status=unpaid region=eu"})
+
+	loaded, err := store.Load()
+	if err != nil || len(loaded) != 3 {
+		t.Fatalf("Load() = %v, %v", loaded, err)
+	}
+	if loaded[0].UserID != "" || loaded[0].Command != "CUSTOMER.LIST status=unpaid region=eu" ||
+		loaded[1].Name != "AC" || loaded[2].UserID != "alice" {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	if err := store.Delete("alice", "UC"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := store.Delete("alice", "UC"); !errors.Is(err, ErrAliasNotFound) {
+		t.Errorf("second Delete() error = %v", err)
+	}
+	if loaded, _ := store.Load(); len(loaded) != 2 {
+		t.Errorf("Load() after Delete() = %+v", loaded)
+	}
+}
+
+func TestMemoryAliasStore(t *testing.T) {
+	testAliasStore(t, NewMemoryAliasStore())
+}
+
+func TestFileAliasStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileAliasStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileAliasStore() error = %v", err)
+	}
+	testAliasStore(t, store)
+
+	if _, err := os.Stat(filepath.Join(dir, "users", "alice.json")); err != nil {
+		t.Errorf("user alias file: %v", err)
+	}
+	for _, userID := range []string{"../alice", "a/b", ".hidden", " "} {
+		if err := store.Save(Alias{Name: "X", Command: "CUSTOMER.LIST", UserID: userID}); err == nil {
+			t.Errorf("Save() for user %q did not fail", userID)
+		}
+	}
+
+	// A user file decides the namespace, whatever its content claims
+	os.WriteFile(filepath.Join(dir, "users", "bob.json"), []byte(`[{"name":"BC","command":"CUSTOMER.LIST"}]`), 0600)
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if last := loaded[len(loaded)-1]; last.Name != "BC" || last.UserID != "bob" {
+		t.Errorf("alias from bob.json = %+v", last)
+	}
+
+	os.WriteFile(filepath.Join(dir, "global.json"), []byte("not json"), 0600)
+	if _, err := store.Load(); err == nil {
+		t.Error("Load() of a corrupt file did not fail")
+	}
+	if _, err := NewFileAliasStore(""); err == nil {
+		t.Error("NewFileAliasStore() without directory did not fail")
+	}
+}
+
+func TestSimpleRegistry_UserAliases(t *testing.T) {
+	store := NewMemoryAliasStore()
+	registry := newAliasRegistry(t, store)
+
+	if err := registry.RegisterAlias("uc", "CUSTOMER.LIST status=unpaid"); err != nil {
+		t.Fatalf("RegisterAlias() error = %v", err)
+	}
+	if err := registry.RegisterUserAlias("alice", "uc", "CUSTOMER.LIST status=open"); err != nil {
+		t.Fatalf("RegisterUserAlias() error = %v", err)
+	}
+
+	if got := registry.ResolveUserAlias("alice", "UC"); got != "CUSTOMER.LIST status=open" {
+		t.Errorf("alice resolves UC to %q", got)
+	}
+	if got := registry.ResolveUserAlias("bob", "uc"); got != "CUSTOMER.LIST status=unpaid" {
+		t.Errorf("bob resolves UC to %q", got)
+	}
+	if got := registry.ResolveAlias("uc"); got != "CUSTOMER.LIST status=unpaid" {
+		t.Errorf("ResolveAlias() = %q", got)
+	}
+	if got := registry.ResolveUserAlias("bob", "missing"); got != "missing" {
+		t.Errorf("unknown alias resolves to %q", got)
+	}
+	if aliases := registry.GetUserAliases("alice"); len(aliases) != 1 || aliases["UC"] != "CUSTOMER.LIST status=open" {
+		t.Errorf("GetUserAliases() = %v", aliases)
+	}
+	if aliases := registry.GetAliases(); aliases["UC"] != "CUSTOMER.LIST status=unpaid" {
+		t.Errorf("GetAliases() = %v", aliases)
+	}
+
+	// Aliases are persisted and survive a new registry on the same store
+	reopened := newAliasRegistry(t, store)
+	if got := reopened.ResolveUserAlias("alice", "uc"); got != "CUSTOMER.LIST status=open" {
+		t.Errorf("reopened registry resolves UC to %q", got)
+	}
+
+	if err := registry.DeleteAlias("alice", "uc"); err != nil {
+		t.Errorf("DeleteAlias() error = %v", err)
+	}
+	if got := registry.ResolveUserAlias("alice", "uc"); got != "CUSTOMER.LIST status=unpaid" {
+		t.Errorf("after DeleteAlias() alice resolves UC to %q", got)
+	}
+	if err := registry.DeleteAlias("alice", "uc"); !errors.Is(err, ErrAliasNotFound) {
+		t.Errorf("second DeleteAlias() error = %v", err)
+	}
+	if stored, _ := store.Load(); len(stored) != 1 {
+		t.Errorf("stored aliases = %+v", stored)
+	}
+}
+
+func TestSimpleRegistry_AliasConflicts(t *testing.T) {
+	registry := newAliasRegistry(t, nil)
+
+	for _, name := range []string{"customer", "CUSTOMER.LIST", "CUST.LS", "my alias"} {
+		if err := registry.RegisterUserAlias("alice", name, "CUSTOMER.LIST"); err == nil {
+			t.Errorf("RegisterUserAlias(%q) did not fail", name)
+		}
+	}
+	if err := registry.RegisterAlias("CUSTOMER.PURGE", "CUSTOMER.LIST"); err != nil {
+		t.Errorf("alias for an unknown method error = %v", err)
+	}
+
+	disabled, _ := NewSimple(Options{})
+	if err := disabled.RegisterUserAlias("alice", "uc", "CUSTOMER.LIST"); err == nil {
+		t.Error("RegisterUserAlias() with aliases disabled did not fail")
+	}
+	if err := registry.ReloadAliases(); err == nil {
+		t.Error("ReloadAliases() without store did not fail")
+	}
+	if err := registry.WatchAliases(); err == nil {
+		t.Error("WatchAliases() without store did not fail")
+	}
+}
+
+func TestSimpleRegistry_WatchAliases(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := NewFileAliasStore(dir)
+	registry := newAliasRegistry(t, store)
+	if err := registry.WatchAliases(); err != nil {
+		t.Fatalf("WatchAliases() error = %v", err)
+	}
+	defer registry.Close()
+
+	// Another process adds an alias
+	other, _ := NewFileAliasStore(dir)
+	other.Save(Alias{Name: "UC", Command: "CUSTOMER.LIST status=unpaid", UserID: "alice"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for registry.ResolveUserAlias("alice", "uc") == "uc" {
+		if time.Now().After(deadline) {
+			t.Fatal("alias was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if aliases := registry.GetUserAliases("alice"); !strings.HasPrefix(aliases["UC"], "CUSTOMER.LIST") {
+		t.Errorf("GetUserAliases() = %v", aliases)
+	}
+
+	if err := registry.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+// File: describe.go
+// Title: TCOL Registry Schema Introspection
+// Description: Describes registered objects and methods with their
+//              parameters, defaults, required permissions, and examples,
+//              and renders these descriptions as help text for users.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Describe and help rendering
+// - 2026-10-16 v0.1.1: Describes method versions and deprecations
+
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ObjectDescription describes a registered object for introspection
+type ObjectDescription struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Service     string              `json:"service,omitempty"`
+	Deprecated  string              `json:"deprecated,omitempty"` // Deprecation warning
+	Methods     []MethodDescription `json:"methods"`
+	Fields      []FieldDescription  `json:"fields,omitempty"`
+}
+
+// MethodDescription describes a method of an object
+type MethodDescription struct {
+	Object      string                 `json:"object"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  []ParameterDescription `json:"parameters,omitempty"`
+	Returns     string                 `json:"returns,omitempty"`
+	Permissions []string               `json:"permissions"`
+	Examples    []string               `json:"examples,omitempty"`
+	Version     int                    `json:"version"`
+	Versions    []int                  `json:"versions,omitempty"`   // All versions if there are several
+	Deprecated  string                 `json:"deprecated,omitempty"` // Deprecation warning
+}
+
+// ParameterDescription describes a method parameter
+type ParameterDescription struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// FieldDescription describes an object field
+type FieldDescription struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Readable    bool   `json:"readable"`
+	Writable    bool   `json:"writable"`
+}
+
+// Describe returns the description of an object with its methods and
+// fields in name order
+func (r *SimpleRegistry) Describe(objectName string) (*ObjectDescription, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	obj, exists := r.objects[strings.ToUpper(objectName)]
+	if !exists {
+		return nil, fmt.Errorf("object %s not found in registry", objectName)
+	}
+
+	desc := &ObjectDescription{
+		Name:        obj.Name,
+		Description: obj.Description,
+		Service:     obj.Service,
+		Methods:     make([]MethodDescription, 0, len(obj.Methods)),
+	}
+	if obj.Deprecation != nil {
+		desc.Deprecated = obj.Deprecation.Warning(obj.Name)
+	}
+	for _, method := range obj.Methods {
+		desc.Methods = append(desc.Methods, describeMethod(obj.Name, method, method))
+	}
+	sort.Slice(desc.Methods, func(i, j int) bool {
+		return desc.Methods[i].Name < desc.Methods[j].Name
+	})
+
+	for _, field := range obj.Fields {
+		desc.Fields = append(desc.Fields, FieldDescription{
+			Name:        field.Name,
+			Type:        field.Type,
+			Description: field.Description,
+			Readable:    field.Readable,
+			Writable:    field.Writable,
+		})
+	}
+	sort.Slice(desc.Fields, func(i, j int) bool {
+		return desc.Fields[i].Name < desc.Fields[j].Name
+	})
+
+	return desc, nil
+}
+
+// DescribeMethod returns the description of a single method; a name such
+// as UPDATE@v2 describes that version of the method
+func (r *SimpleRegistry) DescribeMethod(objectName, methodName string) (*MethodDescription, error) {
+	methodName, version, err := SplitVersion(methodName)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.GetMethod(objectName, methodName)
+	if err != nil {
+		return nil, err
+	}
+	method, err := r.GetMethodVersion(objectName, methodName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := describeMethod(strings.ToUpper(objectName), method, current)
+	return &desc, nil
+}
+
+// describeMethod builds the description of a version of a method, the
+// other versions taken from the current one; required parameters come
+// first. Methods without declared permissions require OBJECT.METHOD, the
+// permission the executor checks.
+func describeMethod(objectName string, method, current *MethodDefinition) MethodDescription {
+	desc := MethodDescription{
+		Object:      objectName,
+		Name:        method.Name,
+		Description: method.Description,
+		Returns:     method.Returns,
+		Permissions: append([]string(nil), method.Permissions...),
+		Examples:    append([]string(nil), method.Examples...),
+		Version:     method.VersionNumber(),
+	}
+	if len(desc.Permissions) == 0 {
+		desc.Permissions = []string{objectName + "." + method.Name}
+	}
+	if len(current.Versions) > 0 {
+		desc.Versions = methodVersions(current)
+	}
+	if method.Deprecation != nil {
+		desc.Deprecated = method.Deprecation.Warning(desc.command())
+	}
+
+	for name, param := range method.Parameters {
+		if param.Name != "" {
+			name = param.Name
+		}
+		desc.Parameters = append(desc.Parameters, ParameterDescription{
+			Name:        name,
+			Type:        param.Type,
+			Required:    param.Required,
+			Default:     param.Default,
+			Values:      append([]string(nil), param.Values...),
+			Description: param.Description,
+		})
+	}
+	sort.Slice(desc.Parameters, func(i, j int) bool {
+		a, b := desc.Parameters[i], desc.Parameters[j]
+		if a.Required != b.Required {
+			return a.Required
+		}
+		return a.Name < b.Name
+	})
+
+	return desc
+}
+
+// Text renders the object description as help text
+func (d *ObjectDescription) Text() string {
+	var text strings.Builder
+	text.WriteString(withDescription(d.Name, d.Description) + "\n")
+	if d.Deprecated != "" {
+		fmt.Fprintf(&text, "Deprecated: %s\n", d.Deprecated)
+	}
+	if d.Service != "" {
+		fmt.Fprintf(&text, "Service: %s\n", d.Service)
+	}
+
+	if len(d.Methods) > 0 {
+		text.WriteString("\nMethods:\n")
+		for i := range d.Methods {
+			d.Methods[i].writeText(&text, "  ")
+		}
+	}
+
+	if len(d.Fields) > 0 {
+		text.WriteString("\nFields:\n")
+		for _, field := range d.Fields {
+			fmt.Fprintf(&text, "  %s\n", withDescription(
+				fmt.Sprintf("%s (%s)", field.Name, fieldDetails(field)), field.Description))
+		}
+	}
+
+	return text.String()
+}
+
+// Text renders the method description as help text
+func (d *MethodDescription) Text() string {
+	var text strings.Builder
+	d.writeText(&text, "")
+	return text.String()
+}
+
+// command returns OBJECT.METHOD, with the version if there are several
+func (d *MethodDescription) command() string {
+	if len(d.Versions) == 0 {
+		return d.Object + "." + d.Name
+	}
+	return fmt.Sprintf("%s.%s@v%d", d.Object, d.Name, d.Version)
+}
+
+// writeText writes the method description indented by indent
+func (d *MethodDescription) writeText(text *strings.Builder, indent string) {
+	fmt.Fprintf(text, "%s%s\n", indent, withDescription(d.command(), d.Description))
+	if d.Deprecated != "" {
+		fmt.Fprintf(text, "%s  Deprecated: %s\n", indent, d.Deprecated)
+	}
+	if len(d.Versions) > 0 {
+		fmt.Fprintf(text, "%s  Versions: %s\n", indent, formatVersions(d.Versions))
+	}
+
+	if len(d.Parameters) > 0 {
+		fmt.Fprintf(text, "%s  Parameters:\n", indent)
+		for _, param := range d.Parameters {
+			fmt.Fprintf(text, "%s    %s\n", indent, withDescription(
+				fmt.Sprintf("%s (%s)", param.Name, parameterDetails(param)), param.Description))
+		}
+	}
+	if d.Returns != "" {
+		fmt.Fprintf(text, "%s  Returns: %s\n", indent, d.Returns)
+	}
+	fmt.Fprintf(text, "%s  Permissions: %s\n", indent, strings.Join(d.Permissions, ", "))
+	if len(d.Examples) > 0 {
+		fmt.Fprintf(text, "%s  Examples:\n", indent)
+		for _, example := range d.Examples {
+			fmt.Fprintf(text, "%s    %s\n", indent, example)
+		}
+	}
+}
+
+// parameterDetails summarizes type, requirement, default, and allowed values
+func parameterDetails(param ParameterDescription) string {
+	details := []string{orDefault(param.Type, "any")}
+	if param.Required {
+		details = append(details, "required")
+	}
+	if param.Default != "" {
+		details = append(details, "default: "+param.Default)
+	}
+	if len(param.Values) > 0 {
+		details = append(details, "one of: "+strings.Join(param.Values, "|"))
+	}
+	return strings.Join(details, ", ")
+}
+
+// fieldDetails summarizes type and access of a field
+func fieldDetails(field FieldDescription) string {
+	access := "no access"
+	switch {
+	case field.Readable && field.Writable:
+		access = "read/write"
+	case field.Readable:
+		access = "read-only"
+	case field.Writable:
+		access = "write-only"
+	}
+	return orDefault(field.Type, "any") + ", " + access
+}
+
+// withDescription appends " - description" to name if there is one
+func withDescription(name, description string) string {
+	if description == "" {
+		return name
+	}
+	return name + " - " + description
+}
+
+// orDefault returns value, or fallback if value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
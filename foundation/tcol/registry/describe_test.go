@@ -0,0 +1,147 @@
+// File: describe_test.go
+// Title: TCOL Registry Schema Introspection Tests
+// Description: Tests object and method descriptions, their ordering and
+//              permission defaults, and the rendered help text.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial schema introspection tests
+
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func newDescribeRegistry(t *testing.T) *SimpleRegistry {
+	registry, err := NewSimple(Options{})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	err = registry.RegisterObject(&ObjectDefinition{
+		Name:        "customer",
+		Description: "Customer management",
+		Service:     "customer-service",
+		Methods: map[string]*MethodDefinition{
+			"create": {
+				Description: "Create a customer",
+				Parameters: map[string]*ParameterDefinition{
+					"status": {Name: "status", Type: "string", Default: "active", Values: []string{"active", "inactive"}},
+					"name":   {Name: "name", Type: "string", Required: true, Description: "Customer name"},
+					"email":  {Name: "email", Type: "string", Required: true},
+				},
+				Returns:     "Customer ID",
+				Permissions: []string{"customer:write"},
+				Examples:    []string{`CUSTOMER.CREATE name="ACME" email="info@acme.example"`},
+			},
+			"list": {Description: "List customers"},
+		},
+		Fields: map[string]*FieldDefinition{
+			"name": {Name: "name", Type: "string", Readable: true, Writable: true},
+			"id":   {Name: "id", Type: "string", Readable: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterObject() error = %v", err)
+	}
+	return registry
+}
+
+func TestSimpleRegistry_Describe(t *testing.T) {
+	registry := newDescribeRegistry(t)
+
+	desc, err := registry.Describe("Customer")
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if desc.Name != "CUSTOMER" || desc.Service != "customer-service" || len(desc.Methods) != 2 {
+		t.Fatalf("Describe() = %+v", desc)
+	}
+	if desc.Methods[0].Name != "CREATE" || desc.Methods[1].Name != "LIST" {
+		t.Errorf("methods = %s, %s", desc.Methods[0].Name, desc.Methods[1].Name)
+	}
+	if desc.Fields[0].Name != "id" || desc.Fields[1].Name != "name" {
+		t.Errorf("fields = %+v", desc.Fields)
+	}
+
+	// Required parameters come first, each group in name order
+	var names []string
+	for _, param := range desc.Methods[0].Parameters {
+		names = append(names, param.Name)
+	}
+	if got := strings.Join(names, ","); got != "email,name,status" {
+		t.Errorf("parameters = %s", got)
+	}
+	if status := desc.Methods[0].Parameters[2]; status.Default != "active" || len(status.Values) != 2 {
+		t.Errorf("status parameter = %+v", status)
+	}
+
+	if perms := desc.Methods[0].Permissions; len(perms) != 1 || perms[0] != "customer:write" {
+		t.Errorf("CREATE permissions = %v", perms)
+	}
+	if perms := desc.Methods[1].Permissions; len(perms) != 1 || perms[0] != "CUSTOMER.LIST" {
+		t.Errorf("LIST permissions = %v, want the default", perms)
+	}
+
+	if _, err := registry.Describe("ORDER"); err == nil {
+		t.Error("Describe() of an unknown object did not fail")
+	}
+}
+
+func TestSimpleRegistry_DescribeMethod(t *testing.T) {
+	registry := newDescribeRegistry(t)
+
+	desc, err := registry.DescribeMethod("customer", "create")
+	if err != nil {
+		t.Fatalf("DescribeMethod() error = %v", err)
+	}
+	if desc.Object != "CUSTOMER" || desc.Name != "CREATE" || desc.Returns != "Customer ID" || len(desc.Examples) != 1 {
+		t.Errorf("DescribeMethod() = %+v", desc)
+	}
+
+	// Descriptions are copies of the definitions
+	desc.Examples[0] = "changed"
+	if method, _ := registry.GetMethod("CUSTOMER", "CREATE"); method.Examples[0] == "changed" {
+		t.Error("description shares examples with the definition")
+	}
+
+	if _, err := registry.DescribeMethod("CUSTOMER", "PURGE"); err == nil {
+		t.Error("DescribeMethod() of an unknown method did not fail")
+	}
+	if _, err := registry.DescribeMethod("ORDER", "LIST"); err == nil {
+		t.Error("DescribeMethod() of an unknown object did not fail")
+	}
+}
+
+func TestObjectDescription_Text(t *testing.T) {
+	registry := newDescribeRegistry(t)
+	desc, _ := registry.Describe("CUSTOMER")
+
+	text := desc.Text()
+	for _, want := range []string{
+		"CUSTOMER - Customer management\n",
+		"Service: customer-service\n",
+		"  CUSTOMER.CREATE - Create a customer\n",
+		"      name (string, required) - Customer name\n",
+		"      status (string, default: active, one of: active|inactive)\n",
+		"    Returns: Customer ID\n",
+		"    Permissions: customer:write\n",
+		`      CUSTOMER.CREATE name="ACME" email="info@acme.example"`,
+		"  CUSTOMER.LIST - List customers\n",
+		"  id (string, read-only)\n",
+		"  name (string, read/write)\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() lacks %q:\n%s", want, text)
+		}
+	}
+
+	method, _ := registry.DescribeMethod("CUSTOMER", "LIST")
+	if got := method.Text(); got != "CUSTOMER.LIST - List customers\n  Permissions: CUSTOMER.LIST\n" {
+		t.Errorf("MethodDescription.Text() = %q", got)
+	}
+}
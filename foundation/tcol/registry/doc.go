@@ -5,12 +5,18 @@
 //              registration, lookup, and validation services for the TCOL
 //              execution engine.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial registry implementation
+// - 2026-10-16 v0.1.1: Documented schema introspection
+// - 2026-10-16 v0.1.2: Documented alias stores
+// - 2026-10-16 v0.1.3: Documented command suggestions
+// - 2026-10-16 v0.1.4: Documented stored procedures
+// - 2026-10-16 v0.1.5: Documented the SCHEDULE object
+// - 2026-10-16 v0.1.6: Documented method versions and deprecation
 
 /*
 Package registry provides command registration and lookup services for TCOL.
@@ -20,9 +26,31 @@ abbreviations, and aliases. It provides:
 
   • Object and method registration
   • Command abbreviation expansion
-  • Alias resolution and management
+  • Alias resolution and management in global and per-user namespaces
+  • Alias persistence with memory and file AliasStores and hot reload
+  • Stored procedures persisted in memory and file ProcedureStores
+  • Built-in objects such as JOB, HISTORY, PROC and SCHEDULE
   • Service routing information
-  • Validation of command availability
+  • Validation of command availability with "did you mean" suggestions
+  • Schema introspection with Describe and rendered help text
+  • Method versions addressed as METHOD@v2 and deprecation metadata
+  • Startup checks that declared i18n message keys have translations
+
+A MethodDefinition is the current version of a method (Version, default
+1); its Versions hold the others, each with its own parameters and
+optionally its own service. Objects and method versions carry a
+Deprecation, from which Deprecations builds the warnings for a command:
+
+	registry.RegisterObject(&registry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*registry.MethodDefinition{
+			"UPDATE": {Version: 2, Versions: []*registry.MethodDefinition{{
+				Version:     1,
+				Deprecation: &registry.Deprecation{Since: "2026.10", Replacement: "CUSTOMER.UPDATE@v2"},
+			}}},
+		},
+	})
 
 The registry serves as the central authority for what commands are available
 in the TCOL system and how they should be resolved and routed.
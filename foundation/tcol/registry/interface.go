@@ -3,9 +3,16 @@
 // Description: Defines the common interface for TCOL registry implementations
 //              to enable abstraction and testing with different registry types.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.4
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2025-01-25 v0.1.0: Initial registry interface
+// - 2026-10-16 v0.1.1: Added method permissions and schema introspection
+// - 2026-10-16 v0.1.2: Added alias store and user alias namespaces
+// - 2026-10-16 v0.1.3: Added stored procedures and the procedure store
+// - 2026-10-16 v0.1.4: Added method versions and deprecation metadata
 
 package registry
 
@@ -19,15 +26,19 @@ type Options struct {
 	Services            []string
 	EnableAbbreviations bool
 	EnableAliases       bool
+	AliasStore          AliasStore // Persists aliases (default: none, aliases live in memory)
+	ProcedureStore      ProcedureStore // Persists procedures (default: none, procedures live in memory)
 }
 
 // ObjectDefinition defines a TCOL object with its methods
 type ObjectDefinition struct {
 	Name        string                    // Object name (e.g., "CUSTOMER")
 	Description string                    // Object description
+	DescriptionKey string                 // i18n key for the description (optional)
 	Service     string                    // Service that handles this object
 	Methods     map[string]*MethodDefinition // Available methods
 	Fields      map[string]*FieldDefinition  // Object fields
+	Deprecation *Deprecation              // Set if the object is deprecated
 }
 
 // MethodDefinition defines a TCOL method
@@ -37,6 +48,18 @@ type MethodDefinition struct {
 	Parameters  map[string]*ParameterDefinition // Method parameters
 	Returns     string                     // Return type description
 	Examples    []string                   // Usage examples
+	Permissions []string                   // Required permissions (default: OBJECT.METHOD)
+
+	// i18n message keys (optional); resolved via the i18n manager at runtime
+	DescriptionKey  string            // Key for the method description
+	ConfirmationKey string            // Key for the confirmation prompt
+	ErrorKeys       map[string]string // Error code -> error template key
+
+	// Versions (optional); a command without @vN uses the current version
+	Version     int                 // Version of this definition, the current one (default 1)
+	Service     string              // Service that handles this version (default: the object's service)
+	Deprecation *Deprecation        // Set if this version is deprecated
+	Versions    []*MethodDefinition // Other versions, addressed as METHOD@vN
 }
 
 // ParameterDefinition defines a method parameter
@@ -45,6 +68,7 @@ type ParameterDefinition struct {
 	Type        string   // Parameter type (string, number, boolean, etc.)
 	Required    bool     // Whether parameter is required
 	Description string   // Parameter description
+	DescriptionKey string // i18n key for the description (optional)
 	Default     string   // Default value (if any)
 	Values      []string // Allowed values (for enums)
 }
@@ -54,6 +78,7 @@ type FieldDefinition struct {
 	Name        string // Field name
 	Type        string // Field type
 	Description string // Field description
+	DescriptionKey string // i18n key for the description (optional)
 	Readable    bool   // Can be read
 	Writable    bool   // Can be written
 }
@@ -71,14 +96,30 @@ type RegistryInterface interface {
 	HasMethod(objectName, methodName string) bool
 	GetMethod(objectName, methodName string) (*MethodDefinition, error)
 	GetMethodNames(objectName string) []string
+	GetMethodVersion(objectName, methodName string, version int) (*MethodDefinition, error)
+	Deprecations(objectName, methodName string, version int) []string
+
+	// Schema introspection
+	Describe(objectName string) (*ObjectDescription, error)
+	DescribeMethod(objectName, methodName string) (*MethodDescription, error)
 
 	// Command validation
 	ValidateCommand(objectName, methodName string) error
 
 	// Alias management
 	RegisterAlias(alias, command string) error
+	RegisterUserAlias(userID, alias, command string) error
+	DeleteAlias(userID, alias string) error
 	ResolveAlias(alias string) string
+	ResolveUserAlias(userID, alias string) string
 	GetAliases() map[string]string
+	GetUserAliases(userID string) map[string]string
+
+	// Stored procedures
+	DefineProcedure(proc Procedure, replace bool) error
+	GetProcedure(name string) (*Procedure, error)
+	GetProcedures() []Procedure
+	DeleteProcedure(name string) error
 
 	// Abbreviation management
 	ExpandAbbreviation(abbrev string) string
@@ -0,0 +1,170 @@
+// File: message_keys.go
+// Title: TCOL Registry Message Key Integrity Checks
+// Description: Collects the i18n message keys declared by registered objects
+//              (descriptions, confirmation prompts, error templates) and
+//              verifies they exist in the configured locales, so missing
+//              translations are reported at startup instead of raw keys
+//              reaching end users.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of message key validation
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// Message key kinds reported by CollectMessageKeys
+const (
+	MessageKindDescription  = "description"
+	MessageKindConfirmation = "confirmation"
+	MessageKindError        = "error"
+)
+
+// TranslationChecker looks up message keys in a specific locale.
+// It is implemented by *i18n.Manager.
+type TranslationChecker interface {
+	HasTranslationInLocale(key, locale string) bool
+	GetDefaultLocale() string
+}
+
+// MessageKeyRef identifies where a message key is declared in the registry
+type MessageKeyRef struct {
+	Key    string // i18n message key
+	Kind   string // description, confirmation, or error
+	Object string // Declaring object
+	Method string // Declaring method (empty for object-level keys)
+	Target string // Parameter/field name or error code (optional)
+}
+
+// Location returns a readable description of where the key is declared
+func (r MessageKeyRef) Location() string {
+	location := r.Object
+	if r.Method != "" {
+		location += "." + r.Method
+	}
+	if r.Target != "" {
+		location += " " + r.Target
+	}
+	return location + " " + r.Kind
+}
+
+// MissingMessageKey is a declared key that has no translation in a locale
+type MissingMessageKey struct {
+	MessageKeyRef
+	Locale string
+}
+
+// MissingMessageKeysError reports all missing message keys at once
+type MissingMessageKeysError struct {
+	Missing []MissingMessageKey
+}
+
+// Error returns a consolidated report of the missing keys
+func (e *MissingMessageKeysError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d TCOL message key(s) missing translations:", len(e.Missing))
+	for _, missing := range e.Missing {
+		fmt.Fprintf(&builder, "\n  - [%s] %s (%s)", missing.Locale, missing.Key, missing.Location())
+	}
+	return builder.String()
+}
+
+// CollectMessageKeys returns all message keys declared by the registered
+// objects, sorted by object, method, and key
+func CollectMessageKeys(reg RegistryInterface) []MessageKeyRef {
+	var refs []MessageKeyRef
+
+	add := func(key, kind, object, method, target string) {
+		if !mdwstringx.IsBlank(key) {
+			refs = append(refs, MessageKeyRef{Key: key, Kind: kind, Object: object, Method: method, Target: target})
+		}
+	}
+
+	for objName, obj := range reg.GetObjects() {
+		add(obj.DescriptionKey, MessageKindDescription, objName, "", "")
+
+		for fieldName, field := range obj.Fields {
+			if field != nil {
+				add(field.DescriptionKey, MessageKindDescription, objName, "", "field "+fieldName)
+			}
+		}
+
+		for methodName, method := range obj.Methods {
+			if method == nil {
+				continue
+			}
+			add(method.DescriptionKey, MessageKindDescription, objName, methodName, "")
+			add(method.ConfirmationKey, MessageKindConfirmation, objName, methodName, "")
+
+			for paramName, param := range method.Parameters {
+				if param != nil {
+					add(param.DescriptionKey, MessageKindDescription, objName, methodName, "parameter "+paramName)
+				}
+			}
+			for code, key := range method.ErrorKeys {
+				add(key, MessageKindError, objName, methodName, code)
+			}
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Object != refs[j].Object {
+			return refs[i].Object < refs[j].Object
+		}
+		if refs[i].Method != refs[j].Method {
+			return refs[i].Method < refs[j].Method
+		}
+		if refs[i].Key != refs[j].Key {
+			return refs[i].Key < refs[j].Key
+		}
+		return refs[i].Target < refs[j].Target
+	})
+
+	return refs
+}
+
+// ValidateMessageKeys verifies that every message key declared in the registry
+// exists in the checker's default locale and in any additional locales given.
+// It returns a *MissingMessageKeysError listing every missing key.
+func ValidateMessageKeys(reg RegistryInterface, checker TranslationChecker, locales ...string) error {
+	if reg == nil {
+		return errors.New("registry cannot be nil")
+	}
+	if checker == nil {
+		return errors.New("translation checker cannot be nil")
+	}
+
+	required := []string{checker.GetDefaultLocale()}
+	for _, locale := range locales {
+		if !mdwstringx.IsBlank(locale) && locale != required[0] {
+			required = append(required, locale)
+		}
+	}
+
+	refs := CollectMessageKeys(reg)
+
+	var missing []MissingMessageKey
+	for _, locale := range required {
+		for _, ref := range refs {
+			if !checker.HasTranslationInLocale(ref.Key, locale) {
+				missing = append(missing, MissingMessageKey{MessageKeyRef: ref, Locale: locale})
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingMessageKeysError{Missing: missing}
+	}
+	return nil
+}
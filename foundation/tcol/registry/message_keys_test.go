@@ -0,0 +1,219 @@
+// File: message_keys_test.go
+// Title: TCOL Registry Message Key Integrity Tests
+// Description: Tests for collecting registry-declared i18n message keys and
+//              validating them against the available locales, including the
+//              consolidated missing key report.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial message key validation tests
+
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mdwi18n "github.com/msto63/mDW/foundation/core/i18n"
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+)
+
+// Compile-time check that the i18n manager satisfies TranslationChecker
+var _ TranslationChecker = (*mdwi18n.Manager)(nil)
+
+// newMessageKeyRegistry creates a registry with one object declaring message keys
+func newMessageKeyRegistry(t *testing.T) *SimpleRegistry {
+	t.Helper()
+
+	reg, err := NewSimple(Options{Logger: mdwlog.GetDefault()})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+
+	err = reg.RegisterObject(&ObjectDefinition{
+		Name:           "invoice",
+		Description:    "Invoices",
+		DescriptionKey: "tcol.invoice.description",
+		Service:        "billing",
+		Methods: map[string]*MethodDefinition{
+			"delete": {
+				DescriptionKey:  "tcol.invoice.delete.description",
+				ConfirmationKey: "tcol.invoice.delete.confirm",
+				ErrorKeys: map[string]string{
+					"NOT_FOUND": "tcol.invoice.errors.not_found",
+				},
+				Parameters: map[string]*ParameterDefinition{
+					"id": {Name: "id", Type: "string", DescriptionKey: "tcol.invoice.params.id"},
+				},
+			},
+		},
+		Fields: map[string]*FieldDefinition{
+			"total": {Name: "total", Type: "number", DescriptionKey: "tcol.invoice.fields.total"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterObject() error = %v", err)
+	}
+
+	return reg
+}
+
+// newMessageKeyManager creates an i18n manager from TOML locale contents
+func newMessageKeyManager(t *testing.T, locales map[string]string) *mdwi18n.Manager {
+	t.Helper()
+
+	dir := t.TempDir()
+	for locale, content := range locales {
+		if err := os.WriteFile(filepath.Join(dir, locale+".toml"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s.toml: %v", locale, err)
+		}
+	}
+
+	manager, err := mdwi18n.New(mdwi18n.Options{
+		DefaultLocale: "en",
+		LocalesDir:    dir,
+		Format:        mdwi18n.FormatTOML,
+	})
+	if err != nil {
+		t.Fatalf("i18n.New() error = %v", err)
+	}
+	return manager
+}
+
+const completeEnglish = `
+[tcol.invoice]
+description = "Invoices"
+
+[tcol.invoice.delete]
+description = "Delete an invoice"
+confirm = "Delete invoice {{.ID}}?"
+
+[tcol.invoice.errors]
+not_found = "Invoice {{.ID}} not found"
+
+[tcol.invoice.params]
+id = "Invoice number"
+
+[tcol.invoice.fields]
+total = "Invoice total"
+`
+
+func TestCollectMessageKeys(t *testing.T) {
+	reg := newMessageKeyRegistry(t)
+
+	refs := CollectMessageKeys(reg)
+	if len(refs) != 6 {
+		t.Fatalf("CollectMessageKeys() returned %d keys, want 6: %v", len(refs), refs)
+	}
+
+	kinds := make(map[string]string)
+	for _, ref := range refs {
+		if ref.Object != "INVOICE" {
+			t.Errorf("ref %s has object %q, want INVOICE", ref.Key, ref.Object)
+		}
+		kinds[ref.Key] = ref.Kind
+	}
+
+	tests := []struct {
+		key  string
+		kind string
+	}{
+		{"tcol.invoice.description", MessageKindDescription},
+		{"tcol.invoice.delete.confirm", MessageKindConfirmation},
+		{"tcol.invoice.errors.not_found", MessageKindError},
+		{"tcol.invoice.params.id", MessageKindDescription},
+		{"tcol.invoice.fields.total", MessageKindDescription},
+	}
+	for _, tt := range tests {
+		if kinds[tt.key] != tt.kind {
+			t.Errorf("key %s kind = %q, want %q", tt.key, kinds[tt.key], tt.kind)
+		}
+	}
+}
+
+func TestValidateMessageKeys(t *testing.T) {
+	reg := newMessageKeyRegistry(t)
+
+	tests := []struct {
+		name        string
+		locales     map[string]string
+		extra       []string
+		wantMissing []string
+	}{
+		{
+			name:    "all keys present",
+			locales: map[string]string{"en": completeEnglish},
+		},
+		{
+			name: "missing keys in default locale",
+			locales: map[string]string{"en": `
+[tcol.invoice]
+description = "Invoices"
+`},
+			wantMissing: []string{
+				"[en] tcol.invoice.delete.confirm",
+				"[en] tcol.invoice.delete.description",
+				"[en] tcol.invoice.errors.not_found",
+				"[en] tcol.invoice.fields.total",
+				"[en] tcol.invoice.params.id",
+			},
+		},
+		{
+			name: "additional locale does not fall back",
+			locales: map[string]string{
+				"en": completeEnglish,
+				"de": `
+[tcol.invoice]
+description = "Rechnungen"
+`,
+			},
+			extra: []string{"de"},
+			wantMissing: []string{
+				"[de] tcol.invoice.delete.confirm",
+				"[de] tcol.invoice.params.id",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newMessageKeyManager(t, tt.locales)
+
+			err := ValidateMessageKeys(reg, manager, tt.extra...)
+			if len(tt.wantMissing) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateMessageKeys() error = %v", err)
+				}
+				return
+			}
+
+			var missingErr *MissingMessageKeysError
+			if !errors.As(err, &missingErr) {
+				t.Fatalf("ValidateMessageKeys() error = %v, want *MissingMessageKeysError", err)
+			}
+			report := err.Error()
+			for _, want := range tt.wantMissing {
+				if !strings.Contains(report, want) {
+					t.Errorf("report missing %q:\n%s", want, report)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMessageKeys_InvalidArguments(t *testing.T) {
+	reg := newMessageKeyRegistry(t)
+
+	if err := ValidateMessageKeys(nil, newMessageKeyManager(t, map[string]string{"en": ""})); err == nil {
+		t.Error("ValidateMessageKeys(nil registry) should fail")
+	}
+	if err := ValidateMessageKeys(reg, nil); err == nil {
+		t.Error("ValidateMessageKeys(nil checker) should fail")
+	}
+}
@@ -0,0 +1,349 @@
+// File: procedures.go
+// Title: TCOL Stored Procedures
+// Description: Implements stored procedures: named TCOL scripts with
+//              parameter placeholders, kept in the registry and persisted
+//              in a pluggable ProcedureStore. Provides in-memory and
+//              file-based stores.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of stored procedures
+
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/log"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// ErrProcedureNotFound is returned for procedures that do not exist
+var ErrProcedureNotFound = errors.New("procedure not found")
+
+// Procedure is a stored TCOL script. Its parameters are bound as variables
+// when it runs, so the body refers to them as $name.
+type Procedure struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Parameters  []string  `json:"parameters,omitempty"`
+	Body        string    `json:"body"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProcedureStore persists procedures
+type ProcedureStore interface {
+	Load() ([]Procedure, error)
+	Save(proc Procedure) error
+	Delete(name string) error
+}
+
+// MemoryProcedureStore keeps procedures in memory
+type MemoryProcedureStore struct {
+	procedures map[string]Procedure
+	mutex      sync.RWMutex
+}
+
+// NewMemoryProcedureStore creates an empty in-memory procedure store
+func NewMemoryProcedureStore() *MemoryProcedureStore {
+	return &MemoryProcedureStore{procedures: make(map[string]Procedure)}
+}
+
+// Load returns all procedures ordered by name
+func (s *MemoryProcedureStore) Load() ([]Procedure, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	procedures := make([]Procedure, 0, len(s.procedures))
+	for _, proc := range s.procedures {
+		procedures = append(procedures, proc)
+	}
+	sortProcedures(procedures)
+	return procedures, nil
+}
+
+// Save stores or replaces a procedure
+func (s *MemoryProcedureStore) Save(proc Procedure) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.procedures[procedureKey(proc.Name)] = proc
+	return nil
+}
+
+// Delete removes a procedure
+func (s *MemoryProcedureStore) Delete(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := procedureKey(name)
+	if _, exists := s.procedures[key]; !exists {
+		return ErrProcedureNotFound
+	}
+	delete(s.procedures, key)
+	return nil
+}
+
+// FileProcedureStore keeps all procedures in a JSON file
+type FileProcedureStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileProcedureStore creates a procedure store in the file at path,
+// creating its directory if needed
+func NewFileProcedureStore(path string) (*FileProcedureStore, error) {
+	if mdwstringx.IsBlank(path) {
+		return nil, fmt.Errorf("procedure store path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create procedure store directory: %w", err)
+	}
+	return &FileProcedureStore{path: path}, nil
+}
+
+// Load reads all procedures; a missing file holds no procedures
+func (s *FileProcedureStore) Load() ([]Procedure, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.read()
+}
+
+// Save stores or replaces a procedure
+func (s *FileProcedureStore) Save(proc Procedure) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	procedures, err := s.read()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range procedures {
+		if procedureKey(procedures[i].Name) == procedureKey(proc.Name) {
+			procedures[i], replaced = proc, true
+		}
+	}
+	if !replaced {
+		procedures = append(procedures, proc)
+	}
+	return s.write(procedures)
+}
+
+// Delete removes a procedure
+func (s *FileProcedureStore) Delete(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	procedures, err := s.read()
+	if err != nil {
+		return err
+	}
+	for i := range procedures {
+		if procedureKey(procedures[i].Name) == procedureKey(name) {
+			return s.write(append(procedures[:i], procedures[i+1:]...))
+		}
+	}
+	return ErrProcedureNotFound
+}
+
+func (s *FileProcedureStore) read() ([]Procedure, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read procedures: %w", err)
+	}
+
+	var procedures []Procedure
+	if err := json.Unmarshal(data, &procedures); err != nil {
+		return nil, fmt.Errorf("failed to decode procedures in %s: %w", filepath.Base(s.path), err)
+	}
+	return procedures, nil
+}
+
+// write writes the file atomically
+func (s *FileProcedureStore) write(procedures []Procedure) error {
+	sortProcedures(procedures)
+	data, err := json.MarshalIndent(procedures, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode procedures: %w", err)
+	}
+	return mdwfilex.WriteFileAtomic(s.path, data, 0600)
+}
+
+// procedureKey returns the key of a procedure name; names ignore case
+func procedureKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// sortProcedures orders procedures by name
+func sortProcedures(procedures []Procedure) {
+	sort.Slice(procedures, func(i, j int) bool {
+		return procedureKey(procedures[i].Name) < procedureKey(procedures[j].Name)
+	})
+}
+
+// DefineProcedure stores a procedure, replacing one of the same name if
+// replace is set. Names ignore case and consist of letters, digits, '_'
+// and '-'. The body is not parsed here; the executor validates it.
+func (r *SimpleRegistry) DefineProcedure(proc Procedure, replace bool) error {
+	if err := validateProcedure(proc); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := procedureKey(proc.Name)
+	if _, exists := r.procedures[key]; exists && !replace {
+		return fmt.Errorf("procedure %s already exists", proc.Name)
+	}
+	if proc.CreatedAt.IsZero() {
+		proc.CreatedAt = time.Now()
+	}
+
+	if r.options.ProcedureStore != nil {
+		if err := r.options.ProcedureStore.Save(proc); err != nil {
+			return fmt.Errorf("failed to store procedure %s: %w", proc.Name, err)
+		}
+	}
+	r.procedures[key] = proc
+
+	r.logger.Debug("TCOL procedure defined", log.Fields{
+		"procedure":  proc.Name,
+		"parameters": proc.Parameters,
+		"createdBy":  proc.CreatedBy,
+	})
+
+	return nil
+}
+
+// GetProcedure returns a procedure by name
+func (r *SimpleRegistry) GetProcedure(name string) (*Procedure, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	proc, exists := r.procedures[procedureKey(name)]
+	if !exists {
+		return nil, fmt.Errorf("procedure %s: %w", name, ErrProcedureNotFound)
+	}
+	return &proc, nil
+}
+
+// GetProcedures returns all procedures ordered by name
+func (r *SimpleRegistry) GetProcedures() []Procedure {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	procedures := make([]Procedure, 0, len(r.procedures))
+	for _, proc := range r.procedures {
+		procedures = append(procedures, proc)
+	}
+	sortProcedures(procedures)
+	return procedures
+}
+
+// DeleteProcedure removes a procedure
+func (r *SimpleRegistry) DeleteProcedure(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := procedureKey(name)
+	if _, exists := r.procedures[key]; !exists {
+		return fmt.Errorf("procedure %s: %w", name, ErrProcedureNotFound)
+	}
+
+	if r.options.ProcedureStore != nil {
+		err := r.options.ProcedureStore.Delete(name)
+		if err != nil && !errors.Is(err, ErrProcedureNotFound) {
+			return fmt.Errorf("failed to delete procedure %s: %w", name, err)
+		}
+	}
+	delete(r.procedures, key)
+	return nil
+}
+
+// ReloadProcedures replaces all procedures with those in the procedure
+// store
+func (r *SimpleRegistry) ReloadProcedures() error {
+	if r.options.ProcedureStore == nil {
+		return errors.New("no procedure store configured")
+	}
+
+	stored, err := r.options.ProcedureStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load procedures: %w", err)
+	}
+
+	procedures := make(map[string]Procedure, len(stored))
+	for _, proc := range stored {
+		procedures[procedureKey(proc.Name)] = proc
+	}
+
+	r.mutex.Lock()
+	r.procedures = procedures
+	r.mutex.Unlock()
+
+	r.logger.Debug("TCOL procedures reloaded", log.Fields{
+		"procedureCount": len(stored),
+	})
+
+	return nil
+}
+
+// validateProcedure checks the name and parameters of a procedure
+func validateProcedure(proc Procedure) error {
+	if !isProcedureName(proc.Name, true) {
+		return fmt.Errorf("invalid procedure name %q: use letters, digits, '_' and '-'", proc.Name)
+	}
+	if mdwstringx.IsBlank(proc.Body) {
+		return fmt.Errorf("procedure %s has an empty body", proc.Name)
+	}
+
+	seen := make(map[string]bool, len(proc.Parameters))
+	for _, param := range proc.Parameters {
+		switch {
+		case !isProcedureName(param, false):
+			return fmt.Errorf("invalid parameter name %q of procedure %s", param, proc.Name)
+		case param == "name":
+			return fmt.Errorf("parameter name of procedure %s is reserved for the procedure name", proc.Name)
+		case seen[param]:
+			return fmt.Errorf("duplicate parameter %s of procedure %s", param, proc.Name)
+		}
+		seen[param] = true
+	}
+	return nil
+}
+
+// isProcedureName reports whether name starts with a letter and consists of
+// letters, digits and '_', and '-' if hyphens are allowed. Parameter names
+// are variables, which cannot contain hyphens.
+func isProcedureName(name string, hyphens bool) bool {
+	if name == "" {
+		return false
+	}
+	for i, ch := range name {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z':
+		case i > 0 && (ch >= '0' && ch <= '9' || ch == '_' || ch == '-' && hyphens):
+		default:
+			return false
+		}
+	}
+	return true
+}
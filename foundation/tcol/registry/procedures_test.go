@@ -0,0 +1,154 @@
+// File: procedures_test.go
+// Title: TCOL Stored Procedures Tests
+// Description: Tests the memory and file procedure stores and the definition,
+//              validation, persistence and reload of stored procedures.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial stored procedure tests
+
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testProcedureStore(t *testing.T, store ProcedureStore) {
+	procedures := []Procedure{
+		{Name: "close-month", Parameters: []string{"month"}, Body: "INVOICE.CLOSE month=$month"},
+		{Name: "Archive", Body: "CUSTOMER.ARCHIVE"},
+	}
+	for _, proc := range procedures {
+		if err := store.Save(proc); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	store.Save(Procedure{Name: "CLOSE-MONTH", Parameters: []string{"month"}, Body: "INVOICE.CLOSE month=$month final=true"})
+
+	loaded, err := store.Load()
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("Load() = %v, %v", loaded, err)
+	}
+	if loaded[0].Name != "Archive" || loaded[1].Body != "INVOICE.CLOSE month=$month final=true" {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	if err := store.Delete("archive"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := store.Delete("archive"); !errors.Is(err, ErrProcedureNotFound) {
+		t.Errorf("second Delete() error = %v", err)
+	}
+	if loaded, _ := store.Load(); len(loaded) != 1 {
+		t.Errorf("Load() after Delete() = %+v", loaded)
+	}
+}
+
+func TestMemoryProcedureStore(t *testing.T) {
+	testProcedureStore(t, NewMemoryProcedureStore())
+}
+
+func TestFileProcedureStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcol", "procedures.json")
+	store, err := NewFileProcedureStore(path)
+	if err != nil {
+		t.Fatalf("NewFileProcedureStore() error = %v", err)
+	}
+	if loaded, err := store.Load(); err != nil || len(loaded) != 0 {
+		t.Errorf("Load() of a missing file = %v, %v", loaded, err)
+	}
+	testProcedureStore(t, store)
+
+	os.WriteFile(path, []byte("not json"), 0600)
+	if _, err := store.Load(); err == nil {
+		t.Error("Load() of a corrupt file did not fail")
+	}
+	if _, err := NewFileProcedureStore(" "); err == nil {
+		t.Error("NewFileProcedureStore() without path did not fail")
+	}
+}
+
+func TestSimpleRegistry_Procedures(t *testing.T) {
+	store := NewMemoryProcedureStore()
+	registry, err := NewSimple(Options{ProcedureStore: store})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+
+	proc := Procedure{Name: "close-month", Parameters: []string{"month"}, Body: "INVOICE.CLOSE month=$month", CreatedBy: "alice"}
+	if err := registry.DefineProcedure(proc, false); err != nil {
+		t.Fatalf("DefineProcedure() error = %v", err)
+	}
+	if err := registry.DefineProcedure(proc, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second DefineProcedure() error = %v", err)
+	}
+	proc.Description = "Close a month"
+	if err := registry.DefineProcedure(proc, true); err != nil {
+		t.Errorf("DefineProcedure() with replace error = %v", err)
+	}
+
+	got, err := registry.GetProcedure("CLOSE-MONTH")
+	if err != nil || got.Description != "Close a month" || got.CreatedAt.IsZero() {
+		t.Errorf("GetProcedure() = %+v, %v", got, err)
+	}
+	if procedures := registry.GetProcedures(); len(procedures) != 1 {
+		t.Errorf("GetProcedures() = %+v", procedures)
+	}
+
+	// Procedures are persisted and survive a new registry on the same store
+	reopened, _ := NewSimple(Options{ProcedureStore: store})
+	if _, err := reopened.GetProcedure("close-month"); err != nil {
+		t.Errorf("reopened registry: %v", err)
+	}
+
+	store.Save(Procedure{Name: "archive", Body: "CUSTOMER.ARCHIVE"})
+	if err := registry.ReloadProcedures(); err != nil || len(registry.GetProcedures()) != 2 {
+		t.Errorf("ReloadProcedures() = %v, procedures %+v", err, registry.GetProcedures())
+	}
+
+	if err := registry.DeleteProcedure("close-month"); err != nil {
+		t.Errorf("DeleteProcedure() error = %v", err)
+	}
+	if _, err := registry.GetProcedure("close-month"); !errors.Is(err, ErrProcedureNotFound) {
+		t.Errorf("GetProcedure() after DeleteProcedure() error = %v", err)
+	}
+	if err := registry.DeleteProcedure("close-month"); !errors.Is(err, ErrProcedureNotFound) {
+		t.Errorf("second DeleteProcedure() error = %v", err)
+	}
+	if stored, _ := store.Load(); len(stored) != 1 {
+		t.Errorf("stored procedures = %+v", stored)
+	}
+}
+
+func TestSimpleRegistry_ProcedureValidation(t *testing.T) {
+	registry, _ := NewSimple(Options{})
+
+	invalid := []struct {
+		proc     Procedure
+		expected string
+	}{
+		{Procedure{Name: "", Body: "A.B"}, "invalid procedure name"},
+		{Procedure{Name: "1st", Body: "A.B"}, "invalid procedure name"},
+		{Procedure{Name: "p q", Body: "A.B"}, "invalid procedure name"},
+		{Procedure{Name: "p", Body: "  \n"}, "empty body"},
+		{Procedure{Name: "p", Body: "A.B", Parameters: []string{"a-b"}}, "invalid parameter name"},
+		{Procedure{Name: "p", Body: "A.B", Parameters: []string{"name"}}, "reserved"},
+		{Procedure{Name: "p", Body: "A.B", Parameters: []string{"x", "x"}}, "duplicate parameter"},
+	}
+	for _, tt := range invalid {
+		if err := registry.DefineProcedure(tt.proc, false); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("DefineProcedure(%+v) error = %v, want %q", tt.proc, err, tt.expected)
+		}
+	}
+
+	if err := registry.ReloadProcedures(); err == nil {
+		t.Error("ReloadProcedures() without store did not fail")
+	}
+}
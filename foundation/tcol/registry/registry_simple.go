@@ -4,15 +4,29 @@
 //              errors for faster development and testing. Will be enhanced
 //              with foundation error handling later.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.10
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2025-01-25 v0.1.0: Initial simplified registry
+// - 2026-10-16 v0.1.1: Added built-in JOB object
+// - 2026-10-16 v0.1.2: Added built-in DESCRIBE object
+// - 2026-10-16 v0.1.3: Aliases in global and user namespaces with an alias store
+// - 2026-10-16 v0.1.4: Suggestions for unknown commands
+// - 2026-10-16 v0.1.5: Added built-in HISTORY object
+// - 2026-10-16 v0.1.6: Added stored procedures and the built-in PROC object
+// - 2026-10-16 v0.1.7: Added built-in SCHEDULE object
+// - 2026-10-16 v0.1.8: Checks method versions on registration
+// - 2026-10-16 v0.1.9: Added built-in EXPORT and IMPORT objects
+// - 2026-10-16 v0.1.10: Service objects replace the built-in EXPORT and IMPORT
 
 package registry
 
 import (
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
@@ -21,11 +35,25 @@ import (
 	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
 )
 
+// InternalService is the service of the built-in objects, which the
+// executor handles itself
+const InternalService = "tcol-internal"
+
+// replaceableBuiltins are the built-in objects a service may register an
+// object of the same name for; the service object then replaces them
+var replaceableBuiltins = map[string]bool{
+	"EXPORT": true,
+	"IMPORT": true,
+}
+
 // SimpleRegistry is a simplified version of the TCOL registry
 type SimpleRegistry struct {
 	objects       map[string]*ObjectDefinition
 	abbreviations map[string]string
-	aliases       map[string]string
+	aliases       map[string]string            // Global aliases
+	userAliases   map[string]map[string]string // User ID -> aliases of the user
+	aliasWatcher  io.Closer
+	procedures    map[string]Procedure // Lower-case name -> procedure
 	services      map[string]string
 	logger        *log.Logger
 	mutex         sync.RWMutex
@@ -43,6 +71,8 @@ func NewSimple(opts Options) (*SimpleRegistry, error) {
 		objects:       make(map[string]*ObjectDefinition),
 		abbreviations: make(map[string]string),
 		aliases:       make(map[string]string),
+		userAliases:   make(map[string]map[string]string),
+		procedures:    make(map[string]Procedure),
 		services:      make(map[string]string),
 		logger:        opts.Logger.WithField("component", "tcol-registry"),
 		options:       opts,
@@ -58,6 +88,20 @@ func NewSimple(opts Options) (*SimpleRegistry, error) {
 		registry.initializeAbbreviations()
 	}
 
+	// Load persisted aliases
+	if opts.AliasStore != nil {
+		if err := registry.ReloadAliases(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load persisted procedures
+	if opts.ProcedureStore != nil {
+		if err := registry.ReloadProcedures(); err != nil {
+			return nil, err
+		}
+	}
+
 	registry.logger.Info("TCOL registry initialized", log.Fields{
 		"objectCount":          len(registry.objects),
 		"serviceCount":         len(opts.Services),
@@ -85,9 +129,17 @@ func (r *SimpleRegistry) RegisterObject(obj *ObjectDefinition) error {
 	objName := strings.ToUpper(obj.Name)
 	obj.Name = objName
 
-	// Check if object already exists
-	if _, exists := r.objects[objName]; exists {
-		return fmt.Errorf("object %s already registered", objName)
+	// Check if object already exists; a service object replaces a
+	// replaceable built-in
+	if existing, exists := r.objects[objName]; exists {
+		if !replaceableBuiltins[objName] || existing.Service != InternalService ||
+			obj.Service == InternalService || mdwstringx.IsBlank(obj.Service) {
+			return fmt.Errorf("object %s already registered", objName)
+		}
+		r.logger.Info("TCOL service object replaces built-in object", log.Fields{
+			"objectName": objName,
+			"service":    obj.Service,
+		})
 	}
 
 	// Validate methods
@@ -105,6 +157,10 @@ func (r *SimpleRegistry) RegisterObject(obj *ObjectDefinition) error {
 		delete(obj.Methods, methodName)
 		obj.Methods[normalizedMethodName] = method
 		method.Name = normalizedMethodName
+
+		if err := normalizeVersions(objName, method); err != nil {
+			return err
+		}
 	}
 
 	// Register object
@@ -130,34 +186,9 @@ func (r *SimpleRegistry) RegisterObject(obj *ObjectDefinition) error {
 	return nil
 }
 
-// RegisterAlias registers a command alias
+// RegisterAlias registers a command alias in the global namespace
 func (r *SimpleRegistry) RegisterAlias(alias, command string) error {
-	if !r.options.EnableAliases {
-		return errors.New("aliases are disabled in this registry")
-	}
-
-	if mdwstringx.IsBlank(alias) {
-		return errors.New("alias name cannot be empty")
-	}
-
-	if mdwstringx.IsBlank(command) {
-		return errors.New("command cannot be empty")
-	}
-
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	// Normalize alias
-	alias = strings.ToUpper(alias)
-
-	r.aliases[alias] = command
-
-	r.logger.Debug("TCOL alias registered", log.Fields{
-		"alias":   alias,
-		"command": command,
-	})
-
-	return nil
+	return r.RegisterUserAlias("", alias, command)
 }
 
 // HasObject checks if an object is registered
@@ -270,7 +301,7 @@ func (r *SimpleRegistry) GetAbbreviations() map[string]string {
 	return abbrevs
 }
 
-// GetAliases returns all registered aliases
+// GetAliases returns all global aliases
 func (r *SimpleRegistry) GetAliases() map[string]string {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
@@ -314,12 +345,21 @@ func (r *SimpleRegistry) ValidateCommand(objectName, methodName string) error {
 
 	// Check if object exists
 	if !r.HasObject(objectName) {
-		return fmt.Errorf("unknown object: %s", objectName)
+		return &UnknownCommandError{
+			Object:        objectName,
+			Method:        methodName,
+			UnknownObject: true,
+			Suggestions:   r.Suggest(objectName, methodName, maxSuggestions),
+		}
 	}
 
 	// Check if method exists
 	if !r.HasMethod(objectName, methodName) {
-		return fmt.Errorf("unknown method %s for object %s", methodName, objectName)
+		return &UnknownCommandError{
+			Object:      objectName,
+			Method:      methodName,
+			Suggestions: r.Suggest(objectName, methodName, maxSuggestions),
+		}
 	}
 
 	return nil
@@ -362,6 +402,12 @@ func (r *SimpleRegistry) GetMethodNames(objectName string) []string {
 
 func (r *SimpleRegistry) registerBuiltinCommands() error {
 	// Register ALIAS object for managing aliases
+	aliasScope := &ParameterDefinition{
+		Name:        "scope",
+		Type:        "string",
+		Description: "Namespace of the alias (default: user, or global without a user)",
+		Values:      []string{"user", "global"},
+	}
 	aliasObj := &ObjectDefinition{
 		Name:        "ALIAS",
 		Description: "Manage command aliases",
@@ -383,9 +429,11 @@ func (r *SimpleRegistry) registerBuiltinCommands() error {
 						Required:    true,
 						Description: "Command to alias",
 					},
+					"scope": aliasScope,
 				},
 				Examples: []string{
 					`ALIAS.CREATE name="uc" command="CUSTOMER.LIST status=unpaid"`,
+					`ALIAS.CREATE name="uc" command="CUSTOMER.LIST status=unpaid" scope="global"`,
 				},
 			},
 			"DELETE": {
@@ -398,11 +446,12 @@ func (r *SimpleRegistry) registerBuiltinCommands() error {
 						Required:    true,
 						Description: "Alias name to delete",
 					},
+					"scope": aliasScope,
 				},
 			},
 			"LIST": {
 				Name:        "LIST",
-				Description: "List all aliases",
+				Description: "List the global aliases and your own",
 			},
 		},
 	}
@@ -412,6 +461,28 @@ func (r *SimpleRegistry) registerBuiltinCommands() error {
 	}
 
 	// Register HELP object for documentation
+	objectParams := map[string]*ParameterDefinition{
+		"name": {
+			Name:        "name",
+			Type:        "string",
+			Required:    true,
+			Description: "Object name",
+		},
+	}
+	methodParams := map[string]*ParameterDefinition{
+		"object": {
+			Name:        "object",
+			Type:        "string",
+			Required:    true,
+			Description: "Object name",
+		},
+		"method": {
+			Name:        "method",
+			Type:        "string",
+			Required:    true,
+			Description: "Method name",
+		},
+	}
 	helpObj := &ObjectDefinition{
 		Name:        "HELP",
 		Description: "Get help information",
@@ -420,42 +491,383 @@ func (r *SimpleRegistry) registerBuiltinCommands() error {
 			"OBJECT": {
 				Name:        "OBJECT",
 				Description: "Get help for an object",
+				Parameters:  objectParams,
+				Examples: []string{
+					`HELP.OBJECT name="CUSTOMER"`,
+				},
+			},
+			"METHOD": {
+				Name:        "METHOD",
+				Description: "Get help for a method",
+				Parameters:  methodParams,
+				Examples: []string{
+					`HELP.METHOD object="CUSTOMER" method="CREATE"`,
+				},
+			},
+			"LIST": {
+				Name:        "LIST",
+				Description: "List all available objects",
+			},
+		},
+	}
+
+	if err := r.RegisterObject(helpObj); err != nil {
+		return fmt.Errorf("failed to register HELP object: %w", err)
+	}
+
+	// Register DESCRIBE object for structured schema introspection
+	describeObj := &ObjectDefinition{
+		Name:        "DESCRIBE",
+		Description: "Get the schema of objects and methods",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"OBJECT": {
+				Name:        "OBJECT",
+				Description: "Describe an object with its methods and fields",
+				Parameters:  objectParams,
+				Returns:     "Object description",
+				Examples: []string{
+					`DESCRIBE.OBJECT name="CUSTOMER"`,
+				},
+			},
+			"METHOD": {
+				Name:        "METHOD",
+				Description: "Describe a method with its parameters",
+				Parameters:  methodParams,
+				Returns:     "Method description",
+			},
+		},
+	}
+
+	if err := r.RegisterObject(describeObj); err != nil {
+		return fmt.Errorf("failed to register DESCRIBE object: %w", err)
+	}
+
+	// Register JOB object for asynchronously executed commands
+	jobID := map[string]*ParameterDefinition{
+		"id": {
+			Name:        "id",
+			Type:        "string",
+			Required:    true,
+			Description: "Job ID returned by the asynchronous execution",
+		},
+	}
+	jobObj := &ObjectDefinition{
+		Name:        "JOB",
+		Description: "Track asynchronously executed commands",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"STATUS": {
+				Name:        "STATUS",
+				Description: "Get the status of a job",
+				Parameters:  jobID,
+				Examples: []string{
+					`JOB.STATUS id="job-1a2b3c4d"`,
+				},
+			},
+			"RESULT": {
+				Name:        "RESULT",
+				Description: "Get the result of a completed job",
+				Parameters:  jobID,
+			},
+			"CANCEL": {
+				Name:        "CANCEL",
+				Description: "Cancel a pending or running job",
+				Parameters:  jobID,
+			},
+		},
+	}
+
+	if err := r.RegisterObject(jobObj); err != nil {
+		return fmt.Errorf("failed to register JOB object: %w", err)
+	}
+
+	// Register HISTORY object for the command history of the user
+	historyObj := &ObjectDefinition{
+		Name:        "HISTORY",
+		Description: "Search and re-execute previously executed commands",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"LIST": {
+				Name:        "LIST",
+				Description: "List the latest commands of the user",
 				Parameters: map[string]*ParameterDefinition{
-					"name": {
-						Name:        "name",
+					"contains": {
+						Name:        "contains",
 						Type:        "string",
+						Description: "Only commands containing this text, ignoring case",
+					},
+					"limit": {
+						Name:        "limit",
+						Type:        "number",
+						Description: "Maximum number of entries",
+						Default:     "20",
+					},
+					"session": {
+						Name:        "session",
+						Type:        "string",
+						Description: "Entries of the current session or of all sessions",
+						Default:     "all",
+						Values:      []string{"current", "all"},
+					},
+				},
+				Returns: "History entries, oldest first",
+				Examples: []string{
+					`HISTORY.LIST contains="customer" limit=10`,
+				},
+			},
+			"RERUN": {
+				Name:        "RERUN",
+				Description: "Execute a command from the history again",
+				Parameters: map[string]*ParameterDefinition{
+					"index": {
+						Name:        "index",
+						Type:        "number",
 						Required:    true,
-						Description: "Object name",
+						Description: "History index of the command; negative counts back from the latest",
 					},
 				},
+				Examples: []string{
+					"HISTORY.RERUN index=42",
+					`HISTORY.RERUN index="-1"`,
+				},
 			},
-			"METHOD": {
-				Name:        "METHOD",
-				Description: "Get help for a method",
+		},
+	}
+
+	if err := r.RegisterObject(historyObj); err != nil {
+		return fmt.Errorf("failed to register HISTORY object: %w", err)
+	}
+
+	// Register PROC object for stored procedures
+	procName := &ParameterDefinition{
+		Name:        "name",
+		Type:        "string",
+		Required:    true,
+		Description: "Procedure name",
+	}
+	procObj := &ObjectDefinition{
+		Name:        "PROC",
+		Description: "Define and run stored procedures",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"DEFINE": {
+				Name:        "DEFINE",
+				Description: "Define a procedure from a script with parameter placeholders",
 				Parameters: map[string]*ParameterDefinition{
-					"object": {
-						Name:        "object",
+					"name": procName,
+					"body": {
+						Name:        "body",
 						Type:        "string",
 						Required:    true,
-						Description: "Object name",
+						Description: "Script run by the procedure; parameters are referenced as $name",
 					},
-					"method": {
-						Name:        "method",
+					"params": {
+						Name:        "params",
+						Type:        "string",
+						Description: "Comma-separated parameter names",
+					},
+					"description": {
+						Name:        "description",
+						Type:        "string",
+						Description: "What the procedure does",
+					},
+					"replace": {
+						Name:        "replace",
+						Type:        "boolean",
+						Description: "Replace an existing procedure of the same name",
+						Default:     "false",
+					},
+				},
+				Examples: []string{
+					"PROC.DEFINE name=\"monthly-close\" params=\"month\" body=<<'EOF'\nINVOICE.CLOSE month=$month\nREPORT.CREATE type=\"monthly\" month=$month\nEOF",
+				},
+			},
+			"RUN": {
+				Name:        "RUN",
+				Description: "Run a procedure; the other parameters are its arguments",
+				Parameters:  map[string]*ParameterDefinition{"name": procName},
+				Returns:     "Results of the statements of the procedure",
+				Examples: []string{
+					`PROC.RUN name="monthly-close" month="2026-09"`,
+				},
+			},
+			"SHOW": {
+				Name:        "SHOW",
+				Description: "Show the definition of a procedure",
+				Parameters:  map[string]*ParameterDefinition{"name": procName},
+			},
+			"LIST": {
+				Name:        "LIST",
+				Description: "List the stored procedures",
+			},
+			"DELETE": {
+				Name:        "DELETE",
+				Description: "Delete a procedure",
+				Parameters:  map[string]*ParameterDefinition{"name": procName},
+			},
+		},
+	}
+
+	if err := r.RegisterObject(procObj); err != nil {
+		return fmt.Errorf("failed to register PROC object: %w", err)
+	}
+
+	// Register SCHEDULE object for commands executed by cron expression
+	scheduleID := map[string]*ParameterDefinition{
+		"id": {
+			Name:        "id",
+			Type:        "string",
+			Required:    true,
+			Description: "Schedule ID returned by SCHEDULE.CREATE",
+		},
+	}
+	scheduleObj := &ObjectDefinition{
+		Name:        "SCHEDULE",
+		Description: "Execute commands periodically by cron expression",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"CREATE": {
+				Name:        "CREATE",
+				Description: "Schedule a command",
+				Parameters: map[string]*ParameterDefinition{
+					"command": {
+						Name:        "command",
+						Type:        "string",
+						Required:    true,
+						Description: "Command to execute",
+					},
+					"cron": {
+						Name:        "cron",
 						Type:        "string",
 						Required:    true,
-						Description: "Method name",
+						Description: "Cron expression: minute, hour, day of month, month, day of week",
+					},
+					"timezone": {
+						Name:        "timezone",
+						Type:        "string",
+						Description: "Timezone of the cron expression, e.g. Europe/Berlin",
 					},
 				},
+				Returns: "The created schedule with its next run",
+				Examples: []string{
+					`SCHEDULE.CREATE command="REPORT.GENERATE type='weekly'" cron="0 6 * * 1"`,
+				},
 			},
 			"LIST": {
 				Name:        "LIST",
-				Description: "List all available objects",
+				Description: "List the schedules of the user",
+			},
+			"PAUSE": {
+				Name:        "PAUSE",
+				Description: "Pause a schedule",
+				Parameters:  scheduleID,
+			},
+			"RESUME": {
+				Name:        "RESUME",
+				Description: "Resume a paused schedule",
+				Parameters:  scheduleID,
+			},
+			"DELETE": {
+				Name:        "DELETE",
+				Description: "Delete a schedule",
+				Parameters:  scheduleID,
+			},
+			"HISTORY": {
+				Name:        "HISTORY",
+				Description: "List the latest runs of a schedule",
+				Parameters:  scheduleID,
+				Returns:     "Runs with their status, oldest first",
 			},
 		},
 	}
 
-	if err := r.RegisterObject(helpObj); err != nil {
-		return fmt.Errorf("failed to register HELP object: %w", err)
+	if err := r.RegisterObject(scheduleObj); err != nil {
+		return fmt.Errorf("failed to register SCHEDULE object: %w", err)
+	}
+
+	// Register EXPORT and IMPORT objects for files in the file root of
+	// the executor
+	exportParams := map[string]*ParameterDefinition{
+		"file": {
+			Name:        "file",
+			Type:        "string",
+			Required:    true,
+			Description: "File to write, relative to the file root",
+		},
+		"fields": {
+			Name:        "fields",
+			Type:        "string",
+			Description: "Comma-separated fields to write, in order (default: all)",
+		},
+		"overwrite": {
+			Name:        "overwrite",
+			Type:        "boolean",
+			Description: "Replace an existing file (default: false)",
+		},
+	}
+	exportMethod := func(name, description string) *MethodDefinition {
+		return &MethodDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  exportParams,
+			Returns:     "The file, its format, and the number of rows written",
+			Examples: []string{
+				fmt.Sprintf(`CUSTOMER[city="Berlin"].LIST | EXPORT.%s file="berlin-customers.%s"`, name, strings.ToLower(name)),
+			},
+		}
+	}
+	exportObj := &ObjectDefinition{
+		Name:        "EXPORT",
+		Description: "Write the result of the previous pipe stage to a file",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"CSV":  exportMethod("CSV", "Write a CSV file with a header line"),
+			"JSON": exportMethod("JSON", "Write a JSON array"),
+			"XLSX": exportMethod("XLSX", "Write an Excel workbook"),
+		},
+	}
+
+	if err := r.RegisterObject(exportObj); err != nil {
+		return fmt.Errorf("failed to register EXPORT object: %w", err)
+	}
+
+	importFile := &ParameterDefinition{
+		Name:        "file",
+		Type:        "string",
+		Required:    true,
+		Description: "File to read, relative to the file root",
+	}
+	importMethod := func(name, description string) *MethodDefinition {
+		return &MethodDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  map[string]*ParameterDefinition{"file": importFile},
+			Returns:     "One parameter set per row; a following command runs once per set",
+			Examples: []string{
+				fmt.Sprintf(`IMPORT.%s file="new-customers.%s" | CUSTOMER.CREATE`, name, strings.ToLower(name)),
+			},
+		}
+	}
+	importCSV := importMethod("CSV", "Read a CSV file with a header line")
+	importCSV.Parameters["delimiter"] = &ParameterDefinition{
+		Name:        "delimiter",
+		Type:        "string",
+		Description: "Field delimiter (default: detected)",
+	}
+	importObj := &ObjectDefinition{
+		Name:        "IMPORT",
+		Description: "Read parameter sets from a file for batch commands",
+		Service:     "tcol-internal",
+		Methods: map[string]*MethodDefinition{
+			"CSV":   importCSV,
+			"JSON":  importMethod("JSON", "Read a JSON array of objects"),
+			"JSONL": importMethod("JSONL", "Read JSON Lines, one object per line"),
+		},
+	}
+
+	if err := r.RegisterObject(importObj); err != nil {
+		return fmt.Errorf("failed to register IMPORT object: %w", err)
 	}
 
 	return nil
@@ -5,12 +5,18 @@
 //              service mappings, and validation. Tests cover both positive and
 //              negative scenarios with comprehensive error handling.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.6
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial comprehensive registry test suite
+// - 2026-10-16 v0.1.1: Added built-in JOB object
+// - 2026-10-16 v0.1.2: Added built-in DESCRIBE object
+// - 2026-10-16 v0.1.3: ALIAS.CREATE scope parameter
+// - 2026-10-16 v0.1.4: Added built-in HISTORY object
+// - 2026-10-16 v0.1.5: Added built-in EXPORT and IMPORT objects
+// - 2026-10-16 v0.1.6: Service objects replace the built-in EXPORT
 
 package registry
 
@@ -256,6 +262,28 @@ func TestSimpleRegistry_RegisterObject(t *testing.T) {
 			t.Errorf("Expected 'already registered' error, got: %v", err)
 		}
 	})
+
+	// A service object replaces the built-in EXPORT but no other built-in
+	t.Run("Service object replaces built-in", func(t *testing.T) {
+		err := registry.RegisterObject(&ObjectDefinition{Name: "EXPORT", Service: "export-service"})
+		if err != nil {
+			t.Fatalf("Registering a service EXPORT object failed: %v", err)
+		}
+		obj, _ := registry.GetObject("EXPORT")
+		if obj.Service != "export-service" {
+			t.Errorf("Expected EXPORT of export-service, got service %q", obj.Service)
+		}
+
+		for _, obj := range []*ObjectDefinition{
+			{Name: "EXPORT", Service: "other-service"},
+			{Name: "IMPORT"},
+			{Name: "HELP", Service: "help-service"},
+		} {
+			if err := registry.RegisterObject(obj); err == nil {
+				t.Errorf("Expected error registering %s of %q", obj.Name, obj.Service)
+			}
+		}
+	})
 }
 
 func TestSimpleRegistry_RegisterAlias(t *testing.T) {
@@ -400,6 +428,21 @@ func TestSimpleRegistry_HasObject(t *testing.T) {
 			objectName: "HELP",
 			expected:   true,
 		},
+		{
+			name:       "Built-in JOB object",
+			objectName: "JOB",
+			expected:   true,
+		},
+		{
+			name:       "Built-in DESCRIBE object",
+			objectName: "DESCRIBE",
+			expected:   true,
+		},
+		{
+			name:       "Built-in HISTORY object",
+			objectName: "HISTORY",
+			expected:   true,
+		},
 		{
 			name:       "Empty object name",
 			objectName: "",
@@ -645,7 +688,7 @@ func TestSimpleRegistry_GetMethod(t *testing.T) {
 			methodName: "CREATE",
 			expectErr:  false,
 			checkFunc: func(method *MethodDefinition) bool {
-				return method.Name == "CREATE" && len(method.Parameters) == 2
+				return method.Name == "CREATE" && len(method.Parameters) == 3
 			},
 		},
 	}
@@ -1063,7 +1106,7 @@ func TestSimpleRegistry_GetObjectNames(t *testing.T) {
 	names := registry.GetObjectNames()
 
 	// Check that all registered objects are included
-	expectedNames := append(testObjects, "ALIAS", "HELP") // Built-in objects
+	expectedNames := append(testObjects, "ALIAS", "DESCRIBE", "EXPORT", "HELP", "HISTORY", "IMPORT", "JOB", "PROC", "SCHEDULE") // Built-in objects
 	if len(names) != len(expectedNames) {
 		t.Errorf("Expected %d object names, got %d", len(expectedNames), len(names))
 	}
@@ -0,0 +1,159 @@
+// File: suggest.go
+// Title: TCOL Fuzzy Command Suggestions
+// Description: Ranks registered commands by similarity to an unknown object
+//              or method, taking prefixes and abbreviations into account,
+//              and reports them with the error for unknown commands.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of command suggestions
+
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// Suggestion thresholds
+const (
+	minSuggestionScore = 0.5 // Lowest similarity worth suggesting
+	maxSuggestions     = 3   // Suggestions reported with an unknown command
+)
+
+// Suggestion is a registered command that may be meant by an unknown one
+type Suggestion struct {
+	Command string  // OBJECT or OBJECT.METHOD
+	Score   float64 // Similarity between 0 and 1
+}
+
+// UnknownCommandError reports an unknown object or method together with
+// the registered commands the user may have meant
+type UnknownCommandError struct {
+	Object        string
+	Method        string
+	UnknownObject bool // The object is unknown, not only the method
+	Suggestions   []Suggestion
+}
+
+// Error implements the error interface
+func (e *UnknownCommandError) Error() string {
+	message := fmt.Sprintf("unknown method %s for object %s", e.Method, e.Object)
+	if e.UnknownObject {
+		message = fmt.Sprintf("unknown object: %s", e.Object)
+	}
+	if len(e.Suggestions) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (did you mean %s?)", message, strings.Join(e.Commands(), " or "))
+}
+
+// Commands returns the suggested commands, best first
+func (e *UnknownCommandError) Commands() []string {
+	commands := make([]string, len(e.Suggestions))
+	for i, suggestion := range e.Suggestions {
+		commands[i] = suggestion.Command
+	}
+	return commands
+}
+
+// Suggest returns up to limit registered commands resembling objectName
+// and methodName, best first. Without a method, objects are suggested.
+// Prefixes ("CUST") and abbreviations ("CRT") of names count as close
+// matches, as do misspellings of registered abbreviations.
+func (r *SimpleRegistry) Suggest(objectName, methodName string, limit int) []Suggestion {
+	object, method := strings.ToUpper(objectName), strings.ToUpper(methodName)
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	scores := make(map[string]float64)
+	add := func(command string, score float64) {
+		if score >= minSuggestionScore && score > scores[command] {
+			scores[command] = score
+		}
+	}
+
+	if obj, exists := r.objects[object]; exists {
+		for name := range obj.Methods {
+			add(object+"."+name, r.nameScore(method, name))
+		}
+	} else {
+		for name, obj := range r.objects {
+			objectScore := r.nameScore(object, name)
+			if method == "" {
+				add(name, objectScore)
+				continue
+			}
+			for methodName := range obj.Methods {
+				methodScore := 1.0
+				if methodName != method {
+					methodScore = r.nameScore(method, methodName)
+				}
+				if methodScore >= minSuggestionScore {
+					add(name+"."+methodName, (objectScore+methodScore)/2)
+				}
+			}
+		}
+	}
+
+	// Misspelled abbreviations suggest their expansion
+	if method != "" {
+		typed := object + "." + method
+		for abbrev, expanded := range r.abbreviations {
+			add(expanded, mdwstringx.Similarity(typed, abbrev))
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(scores))
+	for command, score := range scores {
+		suggestions = append(suggestions, Suggestion{Command: command, Score: score})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Command < suggestions[j].Command
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// nameScore rates how likely typed is meant to be name: by similarity, or
+// highly if typed is a prefix or an abbreviation of name
+func (r *SimpleRegistry) nameScore(typed, name string) float64 {
+	score := mdwstringx.Similarity(typed, name)
+	if len(typed) < 2 || typed == name {
+		return score
+	}
+
+	switch {
+	case typed == r.generateAbbreviation(name):
+		score = max(score, 0.95)
+	case strings.HasPrefix(name, typed):
+		score = max(score, 0.9)
+	case typed[0] == name[0] && isSubsequence(typed, name):
+		score = max(score, 0.8)
+	}
+	return score
+}
+
+// isSubsequence reports whether the characters of short appear in long in
+// order, e.g. "CRT" in "CREATE"
+func isSubsequence(short, long string) bool {
+	i := 0
+	for j := 0; i < len(short) && j < len(long); j++ {
+		if short[i] == long[j] {
+			i++
+		}
+	}
+	return i == len(short)
+}
@@ -0,0 +1,104 @@
+// File: suggest_test.go
+// Title: TCOL Fuzzy Command Suggestion Tests
+// Description: Tests ranking of suggestions for misspelled, prefixed, and
+//              abbreviated commands and the unknown command error.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial suggestion tests
+
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newSuggestRegistry(t *testing.T) *SimpleRegistry {
+	registry, err := NewSimple(Options{EnableAbbreviations: true})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	for _, name := range []string{"CUSTOMER", "CONTRACT", "INVOICE"} {
+		registry.RegisterObject(&ObjectDefinition{
+			Name:    name,
+			Service: strings.ToLower(name) + "-service",
+			Methods: map[string]*MethodDefinition{"CREATE": {}, "LIST": {}, "DELETE": {}, "UPDATE": {}},
+		})
+	}
+	return registry
+}
+
+func firstSuggestion(suggestions []Suggestion) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return suggestions[0].Command
+}
+
+func TestSimpleRegistry_Suggest(t *testing.T) {
+	registry := newSuggestRegistry(t)
+
+	tests := []struct {
+		name           string
+		object, method string
+		expected       string
+	}{
+		{"misspelled method", "CUSTOMER", "CRAETE", "CUSTOMER.CREATE"},
+		{"method prefix", "customer", "upd", "CUSTOMER.UPDATE"},
+		{"method abbreviation", "CUSTOMER", "DLT", "CUSTOMER.DELETE"},
+		{"misspelled object", "CUSTOMR", "CREATE", "CUSTOMER.CREATE"},
+		{"both misspelled", "INVOCE", "LSIT", "INVOICE.LIST"},
+		{"object prefix", "CONTR", "", "CONTRACT"},
+		{"misspelled object only", "INVIOCE", "", "INVOICE"},
+		{"misspelled abbreviation", "CUST", "LST", "CUSTOMER.LIST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := registry.Suggest(tt.object, tt.method, 3)
+			if got := firstSuggestion(suggestions); got != tt.expected {
+				t.Errorf("Suggest(%q, %q) = %v, want %s first", tt.object, tt.method, suggestions, tt.expected)
+			}
+			for i := 1; i < len(suggestions); i++ {
+				if suggestions[i].Score > suggestions[i-1].Score {
+					t.Errorf("suggestions not ranked: %v", suggestions)
+				}
+			}
+		})
+	}
+
+	if suggestions := registry.Suggest("WAREHOUSE", "SHIP", 3); len(suggestions) != 0 {
+		t.Errorf("Suggest() for an unrelated command = %v", suggestions)
+	}
+	if suggestions := registry.Suggest("CUSTOMER", "ATE", 1); len(suggestions) > 1 {
+		t.Errorf("Suggest() ignored the limit: %v", suggestions)
+	}
+}
+
+func TestSimpleRegistry_ValidateCommand_Suggestions(t *testing.T) {
+	registry := newSuggestRegistry(t)
+
+	err := registry.ValidateCommand("CUSTOMER", "CRAETE")
+	var unknown *UnknownCommandError
+	if !errors.As(err, &unknown) || unknown.UnknownObject {
+		t.Fatalf("ValidateCommand() error = %#v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "unknown method CRAETE for object CUSTOMER (did you mean CUSTOMER.CREATE") {
+		t.Errorf("error message = %q", err.Error())
+	}
+
+	err = registry.ValidateCommand("CUSTMER", "LIST")
+	if !errors.As(err, &unknown) || !unknown.UnknownObject || unknown.Commands()[0] != "CUSTOMER.LIST" {
+		t.Errorf("ValidateCommand() error = %#v", err)
+	}
+
+	err = registry.ValidateCommand("WAREHOUSE", "SHIP")
+	if err == nil || err.Error() != "unknown object: WAREHOUSE" {
+		t.Errorf("error without suggestions = %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// File: versions.go
+// Title: TCOL Method Versions and Deprecation
+// Description: Versions of methods addressed as METHOD@vN, deprecation
+//              metadata of objects and methods, and the warnings issued
+//              when deprecated commands are used, so services can evolve
+//              their TCOL surface without breaking existing scripts.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial method versions and deprecation metadata
+
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Deprecation marks an object or a method version as deprecated
+type Deprecation struct {
+	Since       string // Release or date since which it is deprecated (optional)
+	Replacement string // Command to use instead, e.g. "CUSTOMER.UPDATE@v2" (optional)
+	Message     string // Further explanation (optional)
+}
+
+// Warning returns the warning issued when subject, e.g. "CUSTOMER.UPDATE@v1",
+// is used
+func (d *Deprecation) Warning(subject string) string {
+	var warning strings.Builder
+	warning.WriteString(subject + " is deprecated")
+	if d.Since != "" {
+		warning.WriteString(" since " + d.Since)
+	}
+	if d.Replacement != "" {
+		warning.WriteString(", use " + d.Replacement + " instead")
+	}
+	if d.Message != "" {
+		warning.WriteString(": " + d.Message)
+	}
+	return warning.String()
+}
+
+// VersionNumber returns the version of the method; methods registered
+// without a version are version 1
+func (m *MethodDefinition) VersionNumber() int {
+	if m.Version <= 0 {
+		return 1
+	}
+	return m.Version
+}
+
+// SplitVersion splits a method name such as "UPDATE@v2" into the method
+// and its version. The version is 0 if the name has none, which selects
+// the current version of the method.
+func SplitVersion(methodName string) (string, int, error) {
+	method, version, versioned := strings.Cut(methodName, "@")
+	if !versioned {
+		return methodName, 0, nil
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(version), "v"))
+	if err != nil || number <= 0 || !strings.HasPrefix(strings.ToLower(version), "v") {
+		return "", 0, fmt.Errorf("invalid method version %q: expected v1, v2, ...", version)
+	}
+	return method, number, nil
+}
+
+// normalizeVersions checks and normalizes the other versions of a method
+// registered with an object
+func normalizeVersions(objectName string, method *MethodDefinition) error {
+	seen := map[int]bool{method.VersionNumber(): true}
+	for _, version := range method.Versions {
+		if version == nil || version.Version <= 0 {
+			return fmt.Errorf("versions of method %s.%s need a version number", objectName, method.Name)
+		}
+		if seen[version.Version] {
+			return fmt.Errorf("method %s.%s has version %d more than once", objectName, method.Name, version.Version)
+		}
+		seen[version.Version] = true
+
+		version.Name = method.Name
+		version.Versions = nil
+		if version.Parameters == nil {
+			version.Parameters = make(map[string]*ParameterDefinition)
+		}
+	}
+	return nil
+}
+
+// GetMethodVersion returns a version of a method; version 0 is the
+// current version, the one used when a command names no version
+func (r *SimpleRegistry) GetMethodVersion(objectName, methodName string, version int) (*MethodDefinition, error) {
+	method, err := r.GetMethod(objectName, methodName)
+	if err != nil || version == 0 || version == method.VersionNumber() {
+		return method, err
+	}
+
+	for _, other := range method.Versions {
+		if other.Version == version {
+			return other, nil
+		}
+	}
+	return nil, fmt.Errorf("method %s.%s has no version %d (available: %s)",
+		strings.ToUpper(objectName), method.Name, version, formatVersions(methodVersions(method)))
+}
+
+// Deprecations returns the warnings for a command using a version of a
+// method (0 for the current version): one if the object is deprecated and
+// one if the method version is
+func (r *SimpleRegistry) Deprecations(objectName, methodName string, version int) []string {
+	obj, err := r.GetObject(objectName)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	if obj.Deprecation != nil {
+		warnings = append(warnings, obj.Deprecation.Warning(obj.Name))
+	}
+	method, err := r.GetMethodVersion(objectName, methodName, version)
+	if err == nil && method.Deprecation != nil {
+		subject := obj.Name + "." + method.Name
+		if version != 0 || len(method.Versions) > 0 {
+			subject += fmt.Sprintf("@v%d", method.VersionNumber())
+		}
+		warnings = append(warnings, method.Deprecation.Warning(subject))
+	}
+	return warnings
+}
+
+// methodVersions returns all version numbers of a method in ascending order
+func methodVersions(method *MethodDefinition) []int {
+	versions := []int{method.VersionNumber()}
+	for _, other := range method.Versions {
+		versions = append(versions, other.Version)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// formatVersions returns versions as "v1, v2"
+func formatVersions(versions []int) string {
+	names := make([]string, len(versions))
+	for i, version := range versions {
+		names[i] = fmt.Sprintf("v%d", version)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,168 @@
+// File: versions_test.go
+// Title: TCOL Method Versions and Deprecation Tests
+// Description: Tests the registration and lookup of method versions, the
+//              deprecation warnings, and the description of versions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial method version tests
+
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+// newVersionRegistry creates a registry with CUSTOMER.UPDATE in versions 1
+// (deprecated) and 2 (current), and the deprecated object LEGACY
+func newVersionRegistry(t *testing.T) *SimpleRegistry {
+	registry, err := NewSimple(Options{})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	err = registry.RegisterObject(&ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*MethodDefinition{
+			"update": {
+				Description: "Update customers",
+				Version:     2,
+				Parameters: map[string]*ParameterDefinition{
+					"id": {Name: "id", Type: "string", Required: true},
+				},
+				Versions: []*MethodDefinition{{
+					Version:     1,
+					Description: "Update customers by name",
+					Service:     "customer-legacy",
+					Deprecation: &Deprecation{Since: "2026.10", Replacement: "CUSTOMER.UPDATE@v2"},
+				}},
+			},
+			"LIST": {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterObject() error = %v", err)
+	}
+	registry.RegisterObject(&ObjectDefinition{
+		Name:        "LEGACY",
+		Service:     "legacy-service",
+		Methods:     map[string]*MethodDefinition{"LIST": {}},
+		Deprecation: &Deprecation{Message: "migrated to CUSTOMER"},
+	})
+	return registry
+}
+
+func TestSplitVersion(t *testing.T) {
+	testCases := []struct {
+		name    string
+		method  string
+		version int
+		valid   bool
+	}{
+		{"UPDATE", "UPDATE", 0, true},
+		{"UPDATE@v2", "UPDATE", 2, true},
+		{"UPDATE@V10", "UPDATE", 10, true},
+		{"UPDATE@2", "", 0, false},
+		{"UPDATE@v0", "", 0, false},
+		{"UPDATE@vx", "", 0, false},
+	}
+	for _, tc := range testCases {
+		method, version, err := SplitVersion(tc.name)
+		if (err == nil) != tc.valid || method != tc.method || version != tc.version {
+			t.Errorf("SplitVersion(%q) = %q, %d, %v", tc.name, method, version, err)
+		}
+	}
+}
+
+func TestGetMethodVersion(t *testing.T) {
+	registry := newVersionRegistry(t)
+
+	for _, version := range []int{0, 2} {
+		method, err := registry.GetMethodVersion("customer", "update", version)
+		if err != nil || method.VersionNumber() != 2 || method.Description != "Update customers" {
+			t.Errorf("GetMethodVersion(%d) = %+v, %v; want the current version 2", version, method, err)
+		}
+	}
+
+	legacy, err := registry.GetMethodVersion("CUSTOMER", "UPDATE", 1)
+	if err != nil || legacy.Name != "UPDATE" || legacy.Service != "customer-legacy" || legacy.Parameters == nil {
+		t.Errorf("GetMethodVersion(1) = %+v, %v", legacy, err)
+	}
+
+	if _, err := registry.GetMethodVersion("CUSTOMER", "UPDATE", 3); err == nil ||
+		!strings.Contains(err.Error(), "available: v1, v2") {
+		t.Errorf("GetMethodVersion(3) error = %v", err)
+	}
+	if method, err := registry.GetMethodVersion("CUSTOMER", "LIST", 1); err != nil || method.VersionNumber() != 1 {
+		t.Errorf("unversioned method is not version 1: %+v, %v", method, err)
+	}
+}
+
+func TestRegisterObject_InvalidVersions(t *testing.T) {
+	registry, _ := NewSimple(Options{})
+	for name, versions := range map[string][]*MethodDefinition{
+		"DUPLICATE":  {{Version: 2}, {Version: 2}},
+		"CURRENT":    {{Version: 1}},
+		"UNNUMBERED": {{Description: "no version"}},
+	} {
+		err := registry.RegisterObject(&ObjectDefinition{
+			Name:    name,
+			Methods: map[string]*MethodDefinition{"LIST": {Versions: versions}},
+		})
+		if err == nil {
+			t.Errorf("RegisterObject(%s) succeeded", name)
+		}
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	registry := newVersionRegistry(t)
+
+	warnings := registry.Deprecations("CUSTOMER", "UPDATE", 1)
+	expected := "CUSTOMER.UPDATE@v1 is deprecated since 2026.10, use CUSTOMER.UPDATE@v2 instead"
+	if len(warnings) != 1 || warnings[0] != expected {
+		t.Errorf("Deprecations(v1) = %q, want %q", warnings, expected)
+	}
+	if warnings := registry.Deprecations("CUSTOMER", "UPDATE", 0); len(warnings) != 0 {
+		t.Errorf("Deprecations(current) = %q", warnings)
+	}
+	warnings = registry.Deprecations("LEGACY", "LIST", 0)
+	if len(warnings) != 1 || warnings[0] != "LEGACY is deprecated: migrated to CUSTOMER" {
+		t.Errorf("Deprecations(LEGACY) = %q", warnings)
+	}
+}
+
+func TestDescribeMethod_Versions(t *testing.T) {
+	registry := newVersionRegistry(t)
+
+	desc, err := registry.DescribeMethod("CUSTOMER", "UPDATE@v1")
+	if err != nil {
+		t.Fatalf("DescribeMethod() error = %v", err)
+	}
+	text := desc.Text()
+	for _, expected := range []string{
+		"CUSTOMER.UPDATE@v1 - Update customers by name",
+		"  Deprecated: CUSTOMER.UPDATE@v1 is deprecated since 2026.10",
+		"  Versions: v1, v2",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Text() does not contain %q:\n%s", expected, text)
+		}
+	}
+
+	if desc, _ := registry.DescribeMethod("CUSTOMER", "UPDATE"); desc.Version != 2 || desc.Deprecated != "" {
+		t.Errorf("current version = %d, deprecated %q", desc.Version, desc.Deprecated)
+	}
+	if _, err := registry.DescribeMethod("CUSTOMER", "UPDATE@v5"); err == nil {
+		t.Error("DescribeMethod(UPDATE@v5) succeeded")
+	}
+
+	object, _ := registry.Describe("LEGACY")
+	if !strings.Contains(object.Text(), "Deprecated: LEGACY is deprecated") {
+		t.Errorf("object text:\n%s", object.Text())
+	}
+}
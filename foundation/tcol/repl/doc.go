@@ -0,0 +1,105 @@
+// File: doc.go
+// Title: TCOL Interactive Shell Package Documentation
+// Description: Interactive shell for TCOL commands with line editing, tab
+//              completion, syntax highlighting, multi-line input, persistent
+//              history, and inline help; the terminal mode of the mdw CLI.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial interactive shell
+// - 2026-10-16 v0.1.1: Documented ask
+// - 2026-10-16 v0.1.2: Documented lint warnings
+
+/*
+Package repl provides an interactive shell for TCOL commands.
+
+A Shell reads commands, executes them with a tcol.Engine, and renders the
+results in the output format of the engine:
+
+	shell, err := repl.New(engine, repl.Options{
+		HistoryFile: filepath.Join(home, ".mdw_history"),
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+	return shell.Run(ctx)
+
+# Line Editing
+
+If the input is a terminal, lines are edited in raw mode with the keys
+known from readline: the arrow keys, Home, End and Delete, Ctrl-A and
+Ctrl-E to move to the start and end, Ctrl-U, Ctrl-K and Ctrl-W to delete,
+Up and Down (or Ctrl-P and Ctrl-N) to recall the history, Ctrl-C to discard
+the input, and Ctrl-D on an empty line to leave the shell. The terminal is
+in raw mode only while a line is edited. Raw mode requires Linux; on other
+platforms, and if the input is not a terminal, whole lines are read without
+prompts, so scripts can be piped into the shell.
+
+Tab completes the word before the cursor from the registry:
+
+	CU<Tab>                 CUSTOMER.
+	CUSTOMER.CR<Tab>        CUSTOMER.CREATE
+	CUSTOMER.CREATE na<Tab> CUSTOMER.CREATE name=
+	CUSTOMER.LIST | SE<Tab> CUSTOMER.LIST | SELECT
+
+If several words match, their common prefix is inserted; pressing Tab again
+lists them. The line is highlighted while it is edited: objects, methods,
+keywords, strings, literals and variables have their own colors, and
+invalid input is red. Options.NoColor turns highlighting off.
+
+# Multi-line Input
+
+A command continues on the next line if it ends with a backslash or a pipe,
+or if a string, a triple-quoted string, a heredoc or a bracket is still
+open. The backslash is removed and the lines are joined with newlines.
+
+# History
+
+Every command entered is kept in the history, without repeating the
+previous entry. With Options.HistoryFile the history is appended to the
+file, one quoted entry per line, and loaded by New; the file is compacted
+to the latest Options.HistorySize entries. This editing history is separate
+from the command history of the executor (HISTORY.LIST).
+
+# Inline Help
+
+A command ending in ? shows the description of an object or a method
+instead of executing it; help or ? alone lists the keys and shell
+commands, and exit or quit leaves the shell:
+
+	tcol> CUSTOMER ?
+	tcol> CUSTOMER.CREATE ?
+
+# Requests in Plain Language
+
+With Options.Translator, ask proposes a command for a request in plain
+language. The proposal is shown with an explanation and executed only if
+the user answers y or yes; it is added to the history either way, so it can
+be recalled and edited:
+
+	tcol> ask open invoices of Acme
+	Proposed: INVOICE[(customer = "Acme")].LIST status="open"
+	  Lists the open invoices of Acme.
+	Execute? [y/N] y
+
+# Lint Warnings
+
+Before a command is executed it is checked with package lint. Warnings,
+such as an abbreviation or an update without a filter, are written first;
+an UPDATE or DELETE without a filter or id is executed only if the user
+confirms it:
+
+	tcol> CUSTOMER.UPDATE status="inactive"
+	Warning: CUSTOMER.UPDATE without filter or id applies to every CUSTOMER
+	Execute? [y/N]
+
+Errors are left to the engine. Options.NoLint turns the checks off.
+
+Errors are written with the commands the user may have meant. Complete,
+Highlight and Incomplete are exported for other front ends.
+*/
+package repl
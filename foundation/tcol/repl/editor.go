@@ -0,0 +1,265 @@
+// File: editor.go
+// Title: TCOL Shell Line Editor
+// Description: Minimal readline-style line editor for terminals in raw mode:
+//              cursor movement, history navigation, tab completion, and
+//              highlighting of the line while it is edited.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial line editor
+
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// errInterrupted is returned by readLine when the user presses Ctrl-C
+var errInterrupted = errors.New("interrupted")
+
+// Control keys
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// newlineMarker shows the line breaks of recalled multi-line entries
+const newlineMarker = "↵"
+
+// lineEditor reads lines from a terminal in raw mode
+type lineEditor struct {
+	in        *bufio.Reader
+	out       io.Writer
+	history   []string // Oldest first
+	complete  func(line string, pos int) ([]string, int)
+	highlight func(line string) string
+
+	buf     []rune
+	pos     int
+	prompt  string
+	recall  int    // Index into history while navigating; len(history) is the new line
+	pending string // Line being edited before history navigation started
+}
+
+// readLine reads a line with prompt. It returns io.EOF on Ctrl-D on an
+// empty line and errInterrupted on Ctrl-C.
+func (ed *lineEditor) readLine(prompt string) (string, error) {
+	ed.buf = ed.buf[:0]
+	ed.pos = 0
+	ed.prompt = prompt
+	ed.recall = len(ed.history)
+	ed.pending = ""
+	ed.refresh()
+
+	for {
+		r, _, err := ed.in.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(ed.buf) > 0 {
+				fmt.Fprint(ed.out, "\r\n")
+				return string(ed.buf), nil
+			}
+			return "", err
+		}
+
+		switch r {
+		case keyEnter, keyLineFeed:
+			ed.pos = len(ed.buf)
+			ed.refresh()
+			fmt.Fprint(ed.out, "\r\n")
+			return string(ed.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(ed.out, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(ed.buf) == 0 {
+				fmt.Fprint(ed.out, "\r\n")
+				return "", io.EOF
+			}
+			ed.deleteAt(ed.pos)
+		case keyBackspace, keyCtrlH:
+			if ed.pos > 0 {
+				ed.pos--
+				ed.deleteAt(ed.pos)
+			}
+		case keyTab:
+			ed.completeWord()
+		case keyCtrlA:
+			ed.pos = 0
+		case keyCtrlE:
+			ed.pos = len(ed.buf)
+		case keyCtrlB:
+			ed.pos = max(ed.pos-1, 0)
+		case keyCtrlF:
+			ed.pos = min(ed.pos+1, len(ed.buf))
+		case keyCtrlK:
+			ed.buf = ed.buf[:ed.pos]
+		case keyCtrlU:
+			ed.buf = append(ed.buf[:0], ed.buf[ed.pos:]...)
+			ed.pos = 0
+		case keyCtrlW:
+			start := ed.pos
+			for start > 0 && ed.buf[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && ed.buf[start-1] != ' ' {
+				start--
+			}
+			ed.buf = append(ed.buf[:start], ed.buf[ed.pos:]...)
+			ed.pos = start
+		case keyCtrlL:
+			fmt.Fprint(ed.out, "\x1b[H\x1b[2J")
+		case keyCtrlP:
+			ed.recallEntry(-1)
+		case keyCtrlN:
+			ed.recallEntry(1)
+		case keyEscape:
+			ed.escapeSequence()
+		default:
+			if r >= ' ' {
+				ed.insert([]rune{r})
+			}
+		}
+		ed.refresh()
+	}
+}
+
+// escapeSequence handles the arrow, Home, End and Delete keys
+func (ed *lineEditor) escapeSequence() {
+	first, _, err := ed.in.ReadRune()
+	if err != nil || (first != '[' && first != 'O') {
+		return
+	}
+	key, _, err := ed.in.ReadRune()
+	if err != nil {
+		return
+	}
+
+	switch key {
+	case 'A':
+		ed.recallEntry(-1)
+	case 'B':
+		ed.recallEntry(1)
+	case 'C':
+		ed.pos = min(ed.pos+1, len(ed.buf))
+	case 'D':
+		ed.pos = max(ed.pos-1, 0)
+	case 'H':
+		ed.pos = 0
+	case 'F':
+		ed.pos = len(ed.buf)
+	case '1', '3', '4', '7', '8':
+		// VT sequences end with '~': 1 and 7 are Home, 4 and 8 End, 3 Delete
+		if next, _, err := ed.in.ReadRune(); err != nil || next != '~' {
+			return
+		}
+		switch key {
+		case '1', '7':
+			ed.pos = 0
+		case '4', '8':
+			ed.pos = len(ed.buf)
+		case '3':
+			ed.deleteAt(ed.pos)
+		}
+	}
+}
+
+// insert inserts text at the cursor
+func (ed *lineEditor) insert(text []rune) {
+	tail := append([]rune(nil), ed.buf[ed.pos:]...)
+	ed.buf = append(append(ed.buf[:ed.pos], text...), tail...)
+	ed.pos += len(text)
+}
+
+// deleteAt removes the character at index i, if any
+func (ed *lineEditor) deleteAt(i int) {
+	if i < len(ed.buf) {
+		ed.buf = append(ed.buf[:i], ed.buf[i+1:]...)
+	}
+}
+
+// recallEntry replaces the line with the previous (-1) or next (1) history
+// entry; moving past the newest entry restores the line being edited
+func (ed *lineEditor) recallEntry(step int) {
+	target := ed.recall + step
+	if target < 0 || target > len(ed.history) {
+		return
+	}
+	if ed.recall == len(ed.history) {
+		ed.pending = string(ed.buf)
+	}
+	ed.recall = target
+
+	text := ed.pending
+	if target < len(ed.history) {
+		text = ed.history[target]
+	}
+	ed.buf = []rune(text)
+	ed.pos = len(ed.buf)
+}
+
+// completeWord completes the word before the cursor. A single completion
+// is inserted with a trailing space unless it ends in '=' or '.'; several
+// completions are completed to their common prefix, and listed if that
+// adds nothing.
+func (ed *lineEditor) completeWord() {
+	if ed.complete == nil {
+		return
+	}
+	candidates, start := ed.complete(string(ed.buf), ed.pos)
+	if len(candidates) == 0 {
+		return
+	}
+
+	word := ed.buf[start:ed.pos]
+	completion := []rune(commonPrefix(candidates))
+	if len(candidates) == 1 && !strings.HasSuffix(candidates[0], "=") && !strings.HasSuffix(candidates[0], ".") {
+		completion = append(completion, ' ')
+	}
+	if len(completion) > len(word) {
+		ed.buf = append(ed.buf[:start], ed.buf[ed.pos:]...)
+		ed.pos = start
+		ed.insert(completion)
+		return
+	}
+
+	if len(candidates) > 1 {
+		fmt.Fprintf(ed.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+	}
+}
+
+// refresh redraws the prompt and the line and places the cursor
+func (ed *lineEditor) refresh() {
+	line := string(ed.buf)
+	if ed.highlight != nil {
+		line = ed.highlight(line)
+	}
+	line = strings.ReplaceAll(line, "\n", newlineMarker)
+
+	fmt.Fprintf(ed.out, "\r%s%s\x1b[K", ed.prompt, line)
+	if back := len(ed.buf) - ed.pos; back > 0 {
+		fmt.Fprintf(ed.out, "\x1b[%dD", back)
+	}
+}
@@ -0,0 +1,156 @@
+// File: editor_test.go
+// Title: TCOL Shell Line Editor Tests
+// Description: Tests editing keys, history navigation, tab completion, and
+//              interrupts of the line editor with scripted key presses.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial line editor tests
+
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Escape sequences of the arrow keys
+const (
+	keyUp    = "\x1b[A"
+	keyDown  = "\x1b[B"
+	keyLeft  = "\x1b[D"
+	keyRight = "\x1b[C"
+	keyHome  = "\x1b[1~"
+	keyDel   = "\x1b[3~"
+)
+
+// newTestEditor creates an editor reading keys and completing with the
+// test registry
+func newTestEditor(t *testing.T, keys string, history ...string) (*lineEditor, *bytes.Buffer) {
+	registry := newTestRegistry(t)
+	var out bytes.Buffer
+	return &lineEditor{
+		in:      bufio.NewReader(strings.NewReader(keys)),
+		out:     &out,
+		history: history,
+		complete: func(line string, pos int) ([]string, int) {
+			return Complete(registry, line, pos)
+		},
+	}, &out
+}
+
+func TestLineEditor_Editing(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"typing", "CUSTOMER.LIST\r", "CUSTOMER.LIST"},
+		{"backspace", "CUSTOMER.LISX\x7fT\r", "CUSTOMER.LIST"},
+		{"insert after cursor movement", "CUSTOMER.LST" + keyLeft + keyLeft + "I\r", "CUSTOMER.LIST"},
+		{"home and delete", "XCUSTOMER.LIST" + keyHome + keyDel + "\r", "CUSTOMER.LIST"},
+		{"ctrl-a and ctrl-e", "USTOMER\x01C\x05.LIST\r", "CUSTOMER.LIST"},
+		{"ctrl-k", "CUSTOMER.LIST | LIMIT 5" + strings.Repeat(keyLeft, 10) + "\x0b\r", "CUSTOMER.LIST"},
+		{"ctrl-u", "junk CUSTOMER" + strings.Repeat(keyLeft, 8) + "\x15\x05.LIST\r", "CUSTOMER.LIST"},
+		{"ctrl-w", "CUSTOMER.LIST junk  \x17\r", "CUSTOMER.LIST "},
+		{"right stops at the end", "CUSTOMER" + keyRight + ".LIST\n", "CUSTOMER.LIST"},
+		{"unicode", "CUSTOMER.CREATE name=\"Mü\x7fue\"" + keyLeft + keyLeft + "ß\r", "CUSTOMER.CREATE name=\"Muße\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, _ := newTestEditor(t, tt.keys)
+			line, err := editor.readLine(DefaultPrompt)
+			if err != nil || line != tt.expected {
+				t.Errorf("readLine() = %q, %v, want %q", line, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLineEditor_History(t *testing.T) {
+	history := []string{"CUSTOMER.LIST", "CONTRACT.LIST", "CUSTOMER.CREATE\nname=\"A\""}
+
+	editor, out := newTestEditor(t, "draft"+keyUp+keyUp+"\r", history...)
+	if line, _ := editor.readLine(DefaultPrompt); line != "CONTRACT.LIST" {
+		t.Errorf("two entries back = %q, want CONTRACT.LIST", line)
+	}
+
+	editor, _ = newTestEditor(t, "draft"+keyUp+keyUp+keyDown+keyDown+"\r", history...)
+	if line, _ := editor.readLine(DefaultPrompt); line != "draft" {
+		t.Errorf("back to the edited line = %q, want draft", line)
+	}
+
+	editor, _ = newTestEditor(t, strings.Repeat(keyUp, 5)+"\r", history...)
+	if line, _ := editor.readLine(DefaultPrompt); line != "CUSTOMER.LIST" {
+		t.Errorf("past the oldest entry = %q, want CUSTOMER.LIST", line)
+	}
+
+	editor, out = newTestEditor(t, keyUp+"\r", history...)
+	if line, _ := editor.readLine(DefaultPrompt); line != history[2] {
+		t.Errorf("multi-line entry = %q", line)
+	}
+	if !strings.Contains(out.String(), "CUSTOMER.CREATE"+newlineMarker+"name") {
+		t.Errorf("multi-line entry shown as %q", out.String())
+	}
+}
+
+func TestLineEditor_Completion(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"object", "CUST\t\r", "CUSTOMER"},
+		{"object then method", "CON\tL\t\r", "CONTRACT.LIST "},
+		{"method", "CUSTOMER.CR\t\r", "CUSTOMER.CREATE "},
+		{"parameter", "CUSTOMER.CREATE na\t\"Acme\"\r", "CUSTOMER.CREATE name=\"Acme\""},
+		{"no match", "ORDER\t\r", "ORDER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor, _ := newTestEditor(t, tt.keys)
+			if line, _ := editor.readLine(DefaultPrompt); line != tt.expected {
+				t.Errorf("readLine() = %q, want %q", line, tt.expected)
+			}
+		})
+	}
+
+	// Ambiguous input lists the candidates once nothing can be added
+	editor, out := newTestEditor(t, "CUSTOMER.\t\r")
+	editor.readLine(DefaultPrompt)
+	if !strings.Contains(out.String(), "CUSTOMER.CREATE  CUSTOMER.LIST") {
+		t.Errorf("candidates not listed: %q", out.String())
+	}
+}
+
+func TestLineEditor_Interrupts(t *testing.T) {
+	editor, _ := newTestEditor(t, "CUSTOMER\x03")
+	if _, err := editor.readLine(DefaultPrompt); !errors.Is(err, errInterrupted) {
+		t.Errorf("ctrl-c error = %v, want errInterrupted", err)
+	}
+
+	editor, _ = newTestEditor(t, "\x04")
+	if _, err := editor.readLine(DefaultPrompt); err != io.EOF {
+		t.Errorf("ctrl-d on an empty line error = %v, want EOF", err)
+	}
+
+	// Ctrl-D deletes the character under the cursor on a non-empty line
+	editor, _ = newTestEditor(t, "CUSTOMERX.LIST"+strings.Repeat(keyLeft, 6)+"\x04\r")
+	if line, _ := editor.readLine(DefaultPrompt); line != "CUSTOMER.LIST" {
+		t.Errorf("ctrl-d on a line = %q", line)
+	}
+
+	editor, _ = newTestEditor(t, "CUSTOMER.LIST")
+	if line, err := editor.readLine(DefaultPrompt); err != nil || line != "CUSTOMER.LIST" {
+		t.Errorf("line at the end of input = %q, %v", line, err)
+	}
+}
@@ -0,0 +1,481 @@
+// File: repl.go
+// Title: TCOL Interactive Shell
+// Description: Interactive shell around a TCOL engine: reads commands with
+//              the line editor or line by line, continues incomplete input
+//              on further lines, persists the input history, shows inline
+//              help, renders results and errors, and proposes commands for
+//              requests in plain language.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.3
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial interactive shell
+// - 2026-10-16 v0.1.1: Added ask for commands proposed from plain language
+// - 2026-10-16 v0.1.2: Lint warnings and confirmation of unfiltered updates
+// - 2026-10-16 v0.1.3: Writes the warnings of results
+
+package repl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	"github.com/msto63/mDW/foundation/tcol"
+	mdwlint "github.com/msto63/mDW/foundation/tcol/lint"
+	mdwtranslate "github.com/msto63/mDW/foundation/tcol/translate"
+)
+
+// Defaults of the shell options
+const (
+	DefaultPrompt             = "tcol> "
+	DefaultContinuationPrompt = "  ... "
+	DefaultHistorySize        = 1000
+)
+
+// helpText is shown for help and ?
+const helpText = `Enter TCOL commands such as CUSTOMER.LIST or CUSTOMER.CREATE name="Acme".
+Input ending in \ or |, or with an open string or bracket, continues on the next line.
+
+  OBJECT ?            describe an object and its methods
+  OBJECT.METHOD ?     describe a method and its parameters
+  ask REQUEST         propose a command for a request in plain language
+  help, ?             show this help
+  exit, quit          leave the shell
+
+Keys: Tab completes objects, methods and parameters; Up and Down recall the
+history; Ctrl-A and Ctrl-E move to the start and end of the line; Ctrl-U and
+Ctrl-K delete before and after the cursor; Ctrl-W deletes a word; Ctrl-C
+discards the input; Ctrl-D on an empty line leaves the shell.`
+
+// Options configures the shell
+type Options struct {
+	// In and Out are the terminal of the shell (default: os.Stdin, os.Stdout).
+	// Line editing is used if In is a terminal; otherwise whole lines are
+	// read and no prompts are written.
+	In  io.Reader
+	Out io.Writer
+
+	// Prompt and ContinuationPrompt are written before the first and the
+	// following lines of a command (defaults: DefaultPrompt,
+	// DefaultContinuationPrompt)
+	Prompt             string
+	ContinuationPrompt string
+
+	// HistoryFile persists the input history between sessions (optional)
+	HistoryFile string
+
+	// HistorySize is the number of entries kept (default: DefaultHistorySize)
+	HistorySize int
+
+	// NoColor disables syntax highlighting
+	NoColor bool
+
+	// UserID is the user commands are executed for; it is passed to the
+	// engine as the "userId" context value (optional)
+	UserID string
+
+	// NoLint disables the lint warnings written before a command is executed
+	// and the confirmation of updates and deletes without a filter
+	NoLint bool
+
+	// Translator proposes commands for requests entered with ask (optional;
+	// without it ask is not available)
+	Translator *mdwtranslate.Translator
+
+	// Logger for shell operations (optional, defaults to default logger)
+	Logger *mdwlog.Logger
+}
+
+// Shell is an interactive TCOL shell
+type Shell struct {
+	engine  *tcol.Engine
+	options Options
+	history []string
+	read    func(prompt string) (string, error)
+	linter  *mdwlint.Linter
+	logger  *mdwlog.Logger
+}
+
+// New creates a shell that executes commands with engine and loads the
+// history file, if any
+func New(engine *tcol.Engine, opts Options) (*Shell, error) {
+	if engine == nil {
+		return nil, fmt.Errorf("TCOL engine is required")
+	}
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.Prompt == "" {
+		opts.Prompt = DefaultPrompt
+	}
+	if opts.ContinuationPrompt == "" {
+		opts.ContinuationPrompt = DefaultContinuationPrompt
+	}
+	if opts.HistorySize <= 0 {
+		opts.HistorySize = DefaultHistorySize
+	}
+	if opts.Logger == nil {
+		opts.Logger = mdwlog.GetDefault()
+	}
+
+	shell := &Shell{
+		engine:  engine,
+		options: opts,
+		logger:  opts.Logger.WithField("component", "tcol-repl"),
+	}
+	if !opts.NoLint {
+		linter, err := mdwlint.New(engine.Registry(), mdwlint.Options{Logger: opts.Logger})
+		if err != nil {
+			return nil, err
+		}
+		shell.linter = linter
+	}
+	if err := shell.loadHistory(); err != nil {
+		return nil, err
+	}
+	return shell, nil
+}
+
+// History returns the input history, oldest first
+func (s *Shell) History() []string {
+	return append([]string(nil), s.history...)
+}
+
+// Run reads and executes commands until the input ends, the user exits, or
+// ctx is cancelled
+func (s *Shell) Run(ctx context.Context) error {
+	s.read = s.input()
+	for ctx.Err() == nil {
+		command, err := s.readCommand(s.read)
+		if errors.Is(err, errInterrupted) {
+			continue
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !s.handle(ctx, command) {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// input returns the function reading a line with a prompt. A terminal is
+// in raw mode for the line editor only while a line is edited, so commands
+// run with the usual terminal settings.
+func (s *Shell) input() func(prompt string) (string, error) {
+	reader := bufio.NewReader(s.options.In)
+	if file, ok := s.options.In.(*os.File); ok {
+		if restore, err := makeRaw(file.Fd()); err == nil {
+			restore()
+			editor := &lineEditor{
+				in:  reader,
+				out: s.options.Out,
+				complete: func(line string, pos int) ([]string, int) {
+					return Complete(s.engine.Registry(), line, pos)
+				},
+			}
+			if !s.options.NoColor {
+				editor.highlight = Highlight
+			}
+			return func(prompt string) (string, error) {
+				restore, err := makeRaw(file.Fd())
+				if err != nil {
+					return "", err
+				}
+				defer restore()
+				editor.history = s.history
+				return editor.readLine(prompt)
+			}
+		}
+	}
+
+	return func(prompt string) (string, error) {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		return strings.TrimRight(line, "\r\n"), err
+	}
+}
+
+// readCommand reads lines until the command is complete. A trailing
+// backslash is removed; the lines are joined with newlines.
+func (s *Shell) readCommand(read func(prompt string) (string, error)) (string, error) {
+	line, err := read(s.options.Prompt)
+	if err != nil {
+		return "", err
+	}
+
+	command := line
+	for Incomplete(command) {
+		line, err := read(s.options.ContinuationPrompt)
+		if err == io.EOF {
+			break // Let the parser report the incomplete command
+		}
+		if err != nil {
+			return "", err
+		}
+		trimmed := strings.TrimRight(command, " \t")
+		if strings.HasSuffix(trimmed, "\\") {
+			command = strings.TrimSuffix(trimmed, "\\")
+		}
+		command += "\n" + line
+	}
+	return command, nil
+}
+
+// handle executes a command or a shell command; it returns false if the
+// shell should exit
+func (s *Shell) handle(ctx context.Context, command string) bool {
+	trimmed := strings.TrimSpace(command)
+	switch {
+	case trimmed == "":
+		return true
+	case strings.EqualFold(trimmed, "exit") || strings.EqualFold(trimmed, "quit"):
+		return false
+	case trimmed == "?" || strings.EqualFold(trimmed, "help"):
+		s.println(helpText)
+		return true
+	}
+
+	s.addHistory(trimmed)
+	if request, isAsk := cutAsk(trimmed); isAsk {
+		s.ask(ctx, request)
+		return true
+	}
+	if strings.HasSuffix(trimmed, "?") {
+		s.showHelp(strings.TrimSpace(strings.TrimSuffix(trimmed, "?")))
+		return true
+	}
+
+	s.execute(ctx, trimmed)
+	return true
+}
+
+// cutAsk returns the request of an ask command
+func cutAsk(command string) (string, bool) {
+	if len(command) < 4 || !strings.EqualFold(command[:3], "ask") || (command[3] != ' ' && command[3] != '\t') {
+		return "", false
+	}
+	return strings.TrimSpace(command[4:]), true
+}
+
+// ask shows the command proposed for a request in plain language and
+// executes it only if the user confirms it. The proposal is added to the
+// history, so it can be recalled and edited instead.
+func (s *Shell) ask(ctx context.Context, request string) {
+	if s.options.Translator == nil {
+		s.println("Error: ask is not configured for this shell")
+		return
+	}
+
+	proposal, err := s.options.Translator.Translate(ctx, request)
+	if err != nil {
+		s.printError(err)
+		return
+	}
+	s.println("Proposed: " + proposal.Command)
+	if proposal.Explanation != "" {
+		s.println("  " + proposal.Explanation)
+	}
+	s.addHistory(proposal.Command)
+
+	answer, err := s.read("Execute? [y/N] ")
+	if err != nil && err != io.EOF {
+		return
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		s.execute(ctx, proposal.Command)
+	default:
+		s.println("Not executed.")
+	}
+}
+
+// execute executes a command and renders its result
+func (s *Shell) execute(ctx context.Context, command string) {
+	if !s.check(command) {
+		s.println("Not executed.")
+		return
+	}
+	if s.options.UserID != "" {
+		ctx = context.WithValue(ctx, "userId", s.options.UserID)
+	}
+	result, err := s.engine.Execute(ctx, command)
+	if err != nil {
+		s.printError(err)
+		return
+	}
+	for _, warning := range result.Warnings {
+		s.println("Warning: " + warning)
+	}
+	if err := result.Render(s.options.Out); err != nil {
+		s.printError(err)
+	}
+}
+
+// check writes the lint warnings of a command and asks for confirmation
+// if it updates or deletes without a filter; it returns false if the
+// command should not be executed. Errors are left to the engine, which
+// also knows aliases and reports them with suggestions.
+func (s *Shell) check(command string) bool {
+	if s.linter == nil {
+		return true
+	}
+	diagnostics := s.linter.Lint(command)
+	if mdwlint.HasErrors(diagnostics) {
+		return true
+	}
+
+	confirm := false
+	for _, diagnostic := range diagnostics {
+		s.println("Warning: " + diagnostic.Message)
+		confirm = confirm || diagnostic.Rule == mdwlint.RuleUnfilteredBulk
+	}
+	if !confirm {
+		return true
+	}
+
+	answer, err := s.read("Execute? [y/N] ")
+	if err != nil && err != io.EOF {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// showHelp writes the description of an object or a method
+func (s *Shell) showHelp(topic string) {
+	registry := s.engine.Registry()
+	object, method, hasMethod := strings.Cut(topic, ".")
+	object = registry.ExpandAbbreviation(object)
+
+	if hasMethod && method != "" {
+		desc, err := registry.DescribeMethod(object, method)
+		if err != nil {
+			s.printError(err)
+			return
+		}
+		s.println(strings.TrimRight(desc.Text(), "\n"))
+		return
+	}
+
+	desc, err := registry.Describe(object)
+	if err != nil {
+		s.printError(err)
+		return
+	}
+	s.println(strings.TrimRight(desc.Text(), "\n"))
+}
+
+// printError writes an error with the commands the user may have meant
+func (s *Shell) printError(err error) {
+	s.println("Error: " + err.Error())
+
+	var tcolErr *tcol.Error
+	if errors.As(err, &tcolErr) {
+		if suggestions := tcolErr.Suggestions(); len(suggestions) > 0 {
+			s.println("Did you mean: " + strings.Join(suggestions, ", "))
+		}
+	}
+}
+
+// println writes text and a line break
+func (s *Shell) println(text string) {
+	fmt.Fprintln(s.options.Out, text)
+}
+
+// addHistory appends an entry to the history and the history file, unless
+// it repeats the previous entry
+func (s *Shell) addHistory(entry string) {
+	if len(s.history) > 0 && s.history[len(s.history)-1] == entry {
+		return
+	}
+	s.history = append(s.history, entry)
+	if excess := len(s.history) - s.options.HistorySize; excess > 0 {
+		s.history = append([]string(nil), s.history[excess:]...)
+	}
+
+	if s.options.HistoryFile == "" {
+		return
+	}
+	if err := s.appendHistoryFile(entry); err != nil {
+		s.logger.Warn("Failed to persist shell history", mdwlog.Fields{
+			"file":  s.options.HistoryFile,
+			"error": err.Error(),
+		})
+	}
+}
+
+// appendHistoryFile writes an entry to the history file. Entries are quoted
+// so that multi-line commands take one line of the file.
+func (s *Shell) appendHistoryFile(entry string) error {
+	file, err := os.OpenFile(s.options.HistoryFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(file, strconv.Quote(entry)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// loadHistory reads the latest HistorySize entries of the history file.
+// A missing file is an empty history; the file is compacted if it holds
+// more entries than are kept.
+func (s *Shell) loadHistory() error {
+	if s.options.HistoryFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.options.HistoryFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read shell history %s: %w", s.options.HistoryFile, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for _, line := range lines {
+		entry, err := strconv.Unquote(line)
+		if err != nil {
+			continue // Skip damaged lines
+		}
+		s.history = append(s.history, entry)
+	}
+	if len(s.history) <= s.options.HistorySize {
+		return nil
+	}
+
+	s.history = s.history[len(s.history)-s.options.HistorySize:]
+	var b strings.Builder
+	for _, entry := range s.history {
+		b.WriteString(strconv.Quote(entry) + "\n")
+	}
+	if err := os.WriteFile(s.options.HistoryFile, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to compact shell history %s: %w", s.options.HistoryFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// File: repl_test.go
+// Title: TCOL Interactive Shell Tests
+// Description: Tests command execution, multi-line continuation, inline
+//              help, error output, history persistence, and the
+//              confirmation of proposed commands and of unfiltered
+//              updates of the shell.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial shell tests
+// - 2026-10-16 v0.1.1: Added ask tests
+// - 2026-10-16 v0.1.2: Added lint tests
+
+package repl
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/msto63/mDW/foundation/tcol"
+	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtranslate "github.com/msto63/mDW/foundation/tcol/translate"
+)
+
+// echoClient answers every call with the parameters it received and
+// records the user of each call
+type echoClient struct {
+	users []string
+	mutex sync.Mutex
+}
+
+func (c *echoClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+	c.mutex.Lock()
+	c.users = append(c.users, execCtx.UserID)
+	c.mutex.Unlock()
+
+	data := map[string]interface{}{"method": methodName}
+	for name, value := range params {
+		data[name] = value
+	}
+	return &mdwexecutor.ServiceResponse{Success: true, Data: data}, nil
+}
+
+func (c *echoClient) Health(ctx context.Context, serviceName string) error { return nil }
+func (c *echoClient) Close() error                                         { return nil }
+
+// newEngine creates an engine with a CUSTOMER object served by an
+// echoClient
+func newEngine(t *testing.T) (*tcol.Engine, *echoClient) {
+	client := &echoClient{}
+	engine, err := tcol.NewEngine(tcol.Options{ServiceClient: client})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:        "CUSTOMER",
+		Description: "Customer master data",
+		Service:     "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"CREATE": {Name: "CREATE", Description: "Create a customer", Parameters: map[string]*mdwregistry.ParameterDefinition{
+				"name": {Name: "name", Type: "string", Required: true},
+			}},
+			"LIST":   {Name: "LIST"},
+			"UPDATE": {Name: "UPDATE"},
+		},
+	})
+	return engine, client
+}
+
+// runShell runs a shell on input and returns its output
+func runShell(t *testing.T, input string, opts Options) (string, *Shell, *echoClient) {
+	engine, client := newEngine(t)
+
+	var out bytes.Buffer
+	opts.In = strings.NewReader(input)
+	opts.Out = &out
+	shell, err := New(engine, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := shell.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return out.String(), shell, client
+}
+
+func TestShell_Run(t *testing.T) {
+	input := strings.Join([]string{
+		`CUSTOMER.CREATE name="Acme"`,
+		``,
+		`CUSTOMER.CREATE \`,
+		`  name="Beta"`,
+		`CUSTOMER.CREATE name="""Gamma`,
+		`Delta"""`,
+		`exit`,
+		`CUSTOMER.LIST`,
+	}, "\n")
+
+	out, shell, client := runShell(t, input, Options{UserID: "carl"})
+	for _, expected := range []string{"Acme", "Beta", "Gamma Delta"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("output does not contain %q:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "LIST") || strings.Contains(out, DefaultPrompt) {
+		t.Errorf("output after exit or with prompts:\n%s", out)
+	}
+	if len(client.users) != 3 || client.users[0] != "carl" {
+		t.Errorf("commands executed for %v, want carl three times", client.users)
+	}
+
+	history := shell.History()
+	if len(history) != 3 || history[1] != "CUSTOMER.CREATE \n  name=\"Beta\"" {
+		t.Errorf("History() = %q", history)
+	}
+}
+
+func TestShell_HelpAndErrors(t *testing.T) {
+	input := strings.Join([]string{
+		`help`,
+		`CUSTOMER ?`,
+		`CUSTOMER.CREATE ?`,
+		`ORDER ?`,
+		`CUSTOMER.LSIT`,
+	}, "\n")
+
+	out, _, client := runShell(t, input, Options{})
+	for _, expected := range []string{
+		"OBJECT.METHOD ?",
+		"CUSTOMER - Customer master data",
+		"Service: customer-service",
+		"CUSTOMER.CREATE - Create a customer\n  Parameters:\n    name (string, required)",
+		"Error: object ORDER not found",
+		"Did you mean: CUSTOMER.LIST",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("output does not contain %q:\n%s", expected, out)
+		}
+	}
+	if len(client.users) != 0 {
+		t.Errorf("help and failed commands reached the service %d times", len(client.users))
+	}
+}
+
+func TestShell_HistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	runShell(t, "CUSTOMER.LIST\nCUSTOMER.LIST\nCUSTOMER.CREATE \\\nname=\"A\"\n", Options{HistoryFile: path})
+
+	_, shell, _ := runShell(t, "CUSTOMER.CREATE name=\"B\"\n", Options{HistoryFile: path})
+	history := shell.History()
+	if len(history) != 3 || history[1] != "CUSTOMER.CREATE \nname=\"A\"" || history[2] != `CUSTOMER.CREATE name="B"` {
+		t.Errorf("History() = %q", history)
+	}
+
+	// Loading keeps the latest entries and compacts the file
+	_, shell, _ = runShell(t, "", Options{HistoryFile: path, HistorySize: 2})
+	if history := shell.History(); len(history) != 2 || history[0] != "CUSTOMER.CREATE \nname=\"A\"" {
+		t.Errorf("History() with HistorySize 2 = %q", history)
+	}
+	data, _ := os.ReadFile(path)
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+		t.Errorf("history file has %d lines, want 2:\n%s", len(lines), data)
+	}
+}
+
+// fakeTuring proposes the same reply for every request
+type fakeTuring struct{ reply string }
+
+func (f fakeTuring) Chat(ctx context.Context, systemPrompt, prompt string) (string, error) {
+	return f.reply, nil
+}
+
+func TestShell_Ask(t *testing.T) {
+	engine, _ := newEngine(t)
+	translator, err := mdwtranslate.New(engine, mdwtranslate.Options{Client: fakeTuring{
+		reply: `{"command": "CUSTOMER.CREATE name=Acme", "explanation": "Creates the customer Acme."}`,
+	}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	input := strings.Join([]string{
+		`ask add a customer called Acme`,
+		`n`,
+		`ASK add a customer called Acme`,
+		`yes`,
+	}, "\n")
+	out, shell, client := runShell(t, input, Options{Translator: translator})
+
+	for _, expected := range []string{
+		`Proposed: CUSTOMER.CREATE name="Acme"`,
+		"  Creates the customer Acme.",
+		"Not executed.",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("output does not contain %q:\n%s", expected, out)
+		}
+	}
+	if len(client.users) != 1 {
+		t.Errorf("confirmed proposals executed %d times, want 1", len(client.users))
+	}
+	if history := shell.History(); len(history) != 4 || history[1] != `CUSTOMER.CREATE name="Acme"` {
+		t.Errorf("History() = %q", history)
+	}
+
+	out, _, _ = runShell(t, "ask anything\n", Options{})
+	if !strings.Contains(out, "ask is not configured") {
+		t.Errorf("ask without translator:\n%s", out)
+	}
+}
+
+func TestShell_Lint(t *testing.T) {
+	input := strings.Join([]string{
+		`CUSTOMER.UPDATE status="inactive"`,
+		`n`,
+		`CUSTOMER.UPDATE status="inactive"`,
+		`y`,
+		`CUSTOMER[status="new"].UPDATE status="inactive"`,
+	}, "\n")
+	out, _, client := runShell(t, input, Options{})
+
+	for _, expected := range []string{
+		"Warning: CUSTOMER.UPDATE without filter or id applies to every CUSTOMER",
+		"Not executed.",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("output does not contain %q:\n%s", expected, out)
+		}
+	}
+	if strings.Count(out, "Warning:") != 2 {
+		t.Errorf("filtered update was warned about:\n%s", out)
+	}
+	if len(client.users) != 2 {
+		t.Errorf("updates executed %d times, want 2", len(client.users))
+	}
+
+	out, _, client = runShell(t, `CUSTOMER.UPDATE status="inactive"`+"\n", Options{NoLint: true})
+	if strings.Contains(out, "Warning:") || len(client.users) != 1 {
+		t.Errorf("NoLint executed %d times:\n%s", len(client.users), out)
+	}
+}
@@ -0,0 +1,324 @@
+// File: syntax.go
+// Title: TCOL Shell Completion, Highlighting and Continuation
+// Description: Completes object, method, parameter and pipe stage names from
+//              the registry, highlights commands with ANSI colors based on
+//              the TCOL lexer, and detects input that continues on the next
+//              line.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial completion, highlighting and continuation
+
+package repl
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// ANSI colors of highlighted tokens
+const (
+	colorReset    = "\x1b[0m"
+	colorObject   = "\x1b[1;36m" // Bold cyan
+	colorMethod   = "\x1b[32m"   // Green
+	colorKeyword  = "\x1b[35m"   // Magenta
+	colorString   = "\x1b[33m"   // Yellow
+	colorLiteral  = "\x1b[34m"   // Blue: numbers, booleans, null
+	colorVariable = "\x1b[36m"   // Cyan
+	colorIllegal  = "\x1b[31m"   // Red
+)
+
+// transformStages are the names of the transform stages of pipes
+var transformStages = []string{"GROUP BY", "LIMIT", "SELECT", "SORT BY"}
+
+// Complete returns the completions of the word before position pos of
+// line and the position the word starts at. The first word of a command
+// completes to objects, with a trailing dot, and aliases, OBJECT. to the
+// methods of the object,
+// later words to the parameters of the method, and the first word after a
+// pipe also to transform stages. Matching ignores case.
+func Complete(registry *mdwregistry.Registry, line string, pos int) ([]string, int) {
+	runes := []rune(line)
+	pos = min(max(pos, 0), len(runes))
+
+	start := pos
+	for start > 0 && isWordRune(runes[start-1]) {
+		start--
+	}
+	word := string(runes[start:pos])
+
+	// The command the word belongs to starts after the last pipe or semicolon
+	before := string(runes[:start])
+	afterPipe := false
+	if i := strings.LastIndexAny(before, "|;"); i >= 0 {
+		afterPipe = before[i] == '|'
+		before = before[i+1:]
+	}
+	fields := strings.Fields(before)
+
+	var candidates []string
+	switch {
+	case len(fields) == 0 && strings.Contains(word, "."):
+		dot := strings.LastIndex(word, ".")
+		object := registry.ExpandAbbreviation(word[:dot])
+		for _, method := range registry.GetMethodNames(object) {
+			candidates = append(candidates, word[:dot+1]+method)
+		}
+	case len(fields) == 0:
+		for _, object := range registry.GetObjectNames() {
+			candidates = append(candidates, object+".")
+		}
+		for alias := range registry.GetAliases() {
+			candidates = append(candidates, alias)
+		}
+		if afterPipe {
+			candidates = append(candidates, transformStages...)
+		}
+	case !strings.Contains(word, "="):
+		object, method, found := strings.Cut(fields[0], ".")
+		if !found {
+			break
+		}
+		definition, err := registry.GetMethod(registry.ExpandAbbreviation(object), method)
+		if err != nil {
+			break
+		}
+		for name := range definition.Parameters {
+			candidates = append(candidates, name+"=")
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if len(candidate) >= len(word) && strings.EqualFold(candidate[:len(word)], word) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches, start
+}
+
+// isWordRune reports whether r belongs to a completable word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '=' || r == '-'
+}
+
+// commonPrefix returns the longest prefix shared by all words, ignoring case
+// and keeping the case of the first word
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		runes := []rune(word)
+		n := 0
+		for n < len(prefix) && n < len(runes) && unicode.ToUpper(prefix[n]) == unicode.ToUpper(runes[n]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
+
+// Highlight returns line with ANSI colors around its tokens. Objects are
+// the identifiers before a dot and methods the identifiers after one. The
+// visible text is unchanged.
+func Highlight(line string) string {
+	lexer := mdwparser.NewLexer(line)
+	var tokens []mdwparser.Token
+	for {
+		tok := lexer.NextToken()
+		if tok.Type == mdwparser.TokenEOF {
+			break
+		}
+		tokens = append(tokens, tok)
+		if tok.Type == mdwparser.TokenIllegal {
+			break // The rest of the line is not lexed reliably
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, tok := range tokens {
+		if tok.Position < last || tok.Position > len(line) {
+			continue
+		}
+		end := len(line)
+		if i+1 < len(tokens) && tokens[i+1].Position >= tok.Position {
+			end = tokens[i+1].Position
+		}
+		if tok.Type == mdwparser.TokenIllegal {
+			end = len(line)
+		}
+		text := line[tok.Position:end]
+		trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+
+		b.WriteString(line[last:tok.Position])
+		if color := tokenColor(tokens, i); color != "" && trimmed != "" {
+			b.WriteString(color + trimmed + colorReset)
+		} else {
+			b.WriteString(trimmed)
+		}
+		b.WriteString(text[len(trimmed):])
+		last = end
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// tokenColor returns the color of the token at index i, or "" for none
+func tokenColor(tokens []mdwparser.Token, i int) string {
+	switch tokens[i].Type {
+	case mdwparser.TokenIdentifier:
+		if i+1 < len(tokens) && tokens[i+1].Type == mdwparser.TokenDot {
+			return colorObject
+		}
+		if i > 0 && tokens[i-1].Type == mdwparser.TokenDot {
+			return colorMethod
+		}
+		return ""
+	case mdwparser.TokenString:
+		return colorString
+	case mdwparser.TokenNumber, mdwparser.TokenBoolean, mdwparser.TokenNull:
+		return colorLiteral
+	case mdwparser.TokenVariable:
+		return colorVariable
+	case mdwparser.TokenIllegal:
+		return colorIllegal
+	case mdwparser.TokenAnd, mdwparser.TokenOr, mdwparser.TokenNot, mdwparser.TokenLike,
+		mdwparser.TokenIn, mdwparser.TokenBetween, mdwparser.TokenIs, mdwparser.TokenLet,
+		mdwparser.TokenIf, mdwparser.TokenThen, mdwparser.TokenElse, mdwparser.TokenEnd,
+		mdwparser.TokenForEach, mdwparser.TokenDo:
+		return colorKeyword
+	default:
+		return ""
+	}
+}
+
+// Incomplete reports whether input continues on the next line: it ends with
+// a backslash or a pipe, or a string, heredoc or bracket is still open
+func Incomplete(input string) bool {
+	trimmed := strings.TrimRightFunc(input, unicode.IsSpace)
+	if strings.HasSuffix(trimmed, "\\") || strings.HasSuffix(trimmed, "|") {
+		return true
+	}
+
+	depth := 0
+	var quote byte
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case strings.HasPrefix(input[i:], `"""`):
+			end := closingQuotes(input, i+3)
+			if end < 0 {
+				return true
+			}
+			i = end + 2
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(' || c == '{':
+			depth++
+		case c == ']' || c == ')' || c == '}':
+			depth--
+		case strings.HasPrefix(input[i:], "<<"):
+			end, open := heredocEnd(input, i+2)
+			if open {
+				return true
+			}
+			i = end
+		}
+	}
+	return quote != 0 || depth > 0
+}
+
+// closingQuotes returns the index of the quotes closing a triple-quoted
+// string whose text starts at from, or -1 if the string is open
+func closingQuotes(input string, from int) int {
+	for i := from; i < len(input); i++ {
+		if input[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(input[i:], `"""`) {
+			return i
+		}
+	}
+	return -1
+}
+
+// heredocEnd scans the heredoc whose opener follows "<<" at from. It returns
+// the index of the last character of the heredoc and whether the heredoc
+// still lacks its terminating line. Malformed openers are left to the
+// parser and end at from.
+func heredocEnd(input string, from int) (int, bool) {
+	i := from
+	dedent := i < len(input) && input[i] == '-'
+	if dedent {
+		i++
+	}
+	quoted := i < len(input) && input[i] == '\''
+	if quoted {
+		i++
+	}
+	start := i
+	for i < len(input) && (isLetterOrDigit(input[i]) || input[i] == '_') {
+		i++
+	}
+	delimiter := input[start:i]
+	if delimiter == "" {
+		return from, false
+	}
+	if quoted {
+		if i >= len(input) || input[i] != '\'' {
+			return from, false
+		}
+		i++
+	}
+	for i < len(input) && (input[i] == ' ' || input[i] == '\t' || input[i] == '\r') {
+		i++
+	}
+	if i >= len(input) {
+		return i, true // The body starts on the next line
+	}
+	if input[i] != '\n' {
+		return from, false
+	}
+
+	for i < len(input) {
+		lineStart := i + 1
+		lineEnd := strings.IndexByte(input[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(input)
+		} else {
+			lineEnd += lineStart
+		}
+		text := strings.TrimSuffix(input[lineStart:lineEnd], "\r")
+		if dedent {
+			text = strings.TrimLeft(text, " \t")
+		}
+		if text == delimiter {
+			return lineEnd - 1, false
+		}
+		i = lineEnd
+	}
+	return len(input), true
+}
+
+// isLetterOrDigit reports whether c is an ASCII letter or digit
+func isLetterOrDigit(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
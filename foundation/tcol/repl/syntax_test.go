@@ -0,0 +1,151 @@
+// File: syntax_test.go
+// Title: TCOL Shell Completion, Highlighting and Continuation Tests
+// Description: Tests completion from the registry, syntax highlighting, and
+//              the detection of input continuing on the next line.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial syntax tests
+
+package repl
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// newTestRegistry creates a registry with the CUSTOMER and CONTRACT objects
+func newTestRegistry(t *testing.T) *mdwregistry.Registry {
+	registry, err := mdwregistry.NewSimple(mdwregistry.Options{Logger: mdwlog.GetDefault(), EnableAliases: true})
+	if err != nil {
+		t.Fatalf("NewSimple() error = %v", err)
+	}
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"CREATE": {Name: "CREATE", Parameters: map[string]*mdwregistry.ParameterDefinition{
+				"name":  {Name: "name", Type: "string", Required: true},
+				"email": {Name: "email", Type: "string"},
+			}},
+			"LIST": {Name: "LIST"},
+		},
+	})
+	registry.RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CONTRACT",
+		Service: "contract-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"LIST": {Name: "LIST"}},
+	})
+	registry.RegisterAlias("CUSTOMERS", "CUSTOMER.LIST")
+	return registry
+}
+
+func TestComplete(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := []struct {
+		line     string
+		pos      int
+		expected string
+		start    int
+	}{
+		{"CU", 2, "CUSTOMER. CUSTOMERS", 0},
+		{"co", 2, "CONTRACT.", 0},
+		{"customer.c", 10, "customer.CREATE", 0},
+		{"CUSTOMER.", 9, "CUSTOMER.CREATE CUSTOMER.LIST", 0},
+		{"CUSTOMER.CREATE ", 16, "email= name=", 16},
+		{"CUSTOMER.CREATE name=\"Acme\" e", 29, "email=", 28},
+		{"CUSTOMER.CREATE name=", 21, "", 16},
+		{"CUSTOMER.LIST | SE", 18, "SELECT", 16},
+		{"CUSTOMER.LIST | LIMIT ", 22, "", 22},
+		{"CONTRACT.LIST; CU", 17, "CUSTOMER. CUSTOMERS", 15},
+		{"CUSTOMER.LIST", 2, "CUSTOMER. CUSTOMERS", 0},
+		{"UNKNOWN.", 8, "", 0},
+	}
+
+	for _, tt := range tests {
+		matches, start := Complete(registry, tt.line, tt.pos)
+		if strings.Join(matches, " ") != tt.expected || start != tt.start {
+			t.Errorf("Complete(%q, %d) = %q, %d, want %q, %d", tt.line, tt.pos, matches, start, tt.expected, tt.start)
+		}
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	if prefix := commonPrefix([]string{"CUSTOMER.", "customers"}); prefix != "CUSTOMER" {
+		t.Errorf("commonPrefix() = %q, want CUSTOMER", prefix)
+	}
+	if prefix := commonPrefix(nil); prefix != "" {
+		t.Errorf("commonPrefix(nil) = %q", prefix)
+	}
+}
+
+var ansi = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestHighlight(t *testing.T) {
+	inputs := []string{
+		`CUSTOMER.CREATE name="Acme Corp"  active=true`,
+		`CUSTOMER.LIST [revenue > 1000 AND name LIKE "A%"] | LIMIT 5`,
+		`LET c = CUSTOMER.GET id=$id`,
+		`CUSTOMER.CREATE name="unterminated`,
+		`   `,
+	}
+	for _, input := range inputs {
+		if plain := ansi.ReplaceAllString(Highlight(input), ""); plain != input {
+			t.Errorf("Highlight(%q) changed the text to %q", input, plain)
+		}
+	}
+
+	highlighted := Highlight(`CUSTOMER.CREATE name="Acme" count=3 AND $x`)
+	for _, colored := range []string{
+		colorObject + "CUSTOMER" + colorReset,
+		colorMethod + "CREATE" + colorReset,
+		colorString + `"Acme"` + colorReset,
+		colorLiteral + "3" + colorReset,
+		colorKeyword + "AND" + colorReset,
+		colorVariable + "$x" + colorReset,
+	} {
+		if !strings.Contains(highlighted, colored) {
+			t.Errorf("Highlight() = %q, want it to contain %q", highlighted, colored)
+		}
+	}
+	if strings.Contains(highlighted, colorObject+"name") {
+		t.Errorf("parameter names are highlighted as objects: %q", highlighted)
+	}
+}
+
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		input      string
+		incomplete bool
+	}{
+		{`CUSTOMER.LIST`, false},
+		{`CUSTOMER.LIST |`, true},
+		{"CUSTOMER.LIST \\", true},
+		{`CUSTOMER.LIST [name = "A"`, true},
+		{"CUSTOMER.LIST [name = \"A\"\n]", false},
+		{`CUSTOMER.CREATE name="Acme`, true},
+		{`CUSTOMER.CREATE name="say \"hi\""`, false},
+		{`CUSTOMER.CREATE name='it''`, true},
+		{`CUSTOMER.CREATE note="""first`, true},
+		{"CUSTOMER.CREATE note=\"\"\"first\nsecond\"\"\"", false},
+		{`CUSTOMER.CREATE note=<<EOF`, true},
+		{"CUSTOMER.CREATE note=<<EOF\ntext", true},
+		{"CUSTOMER.CREATE note=<<-EOF\ntext\n  EOF", false},
+		{"CUSTOMER.CREATE note=<<EOF junk", false},
+		{`CUSTOMER.LIST [revenue << 3]`, false},
+	}
+
+	for _, tt := range tests {
+		if got := Incomplete(tt.input); got != tt.incomplete {
+			t.Errorf("Incomplete(%q) = %v, want %v", tt.input, got, tt.incomplete)
+		}
+	}
+}
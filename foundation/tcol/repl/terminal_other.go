@@ -0,0 +1,22 @@
+// File: terminal_other.go
+// Title: TCOL Shell Terminal Mode (Portable)
+// Description: Raw mode is not supported on platforms without termios in the
+//              standard library; the shell reads whole lines there.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial portable implementation
+
+//go:build !linux
+
+package repl
+
+import "errors"
+
+// makeRaw always fails, so the shell falls back to reading whole lines
+func makeRaw(fd uintptr) (func() error, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
@@ -4,12 +4,25 @@
 //              for parsing and executing TCOL commands. Integrates parser,
 //              AST, executor, and registry components.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.13
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial TCOL engine implementation
+// - 2026-10-16 v0.1.1: Added script parsing
+// - 2026-10-16 v0.1.2: Added the next page cursor to results
+// - 2026-10-16 v0.1.3: Added the alias store option
+// - 2026-10-16 v0.1.4: Command suggestions on errors
+// - 2026-10-16 v0.1.5: Output formatting of results
+// - 2026-10-16 v0.1.6: Added the history store option
+// - 2026-10-16 v0.1.7: Parsing is serialized so commands can execute concurrently
+// - 2026-10-16 v0.1.8: Added the role-based permissions option
+// - 2026-10-16 v0.1.9: Added the procedure store option
+// - 2026-10-16 v0.1.10: Added the schedule store option and the scheduler
+// - 2026-10-16 v0.1.11: Results carry warnings for deprecated commands
+// - 2026-10-16 v0.1.12: Added the file root option for EXPORT and IMPORT
+// - 2026-10-16 v0.1.13: Added the tracer and metrics options
 
 package tcol
 
@@ -17,14 +30,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
 	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+	mdwformat "github.com/msto63/mDW/foundation/tcol/format"
+	mdwmacro "github.com/msto63/mDW/foundation/tcol/macro"
 	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
+	mdwfilex "github.com/msto63/mDW/foundation/utils/filex"
 	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
 )
 
@@ -35,6 +54,9 @@ type Engine struct {
 	registry *mdwregistry.Registry
 	logger   *mdwlog.Logger
 	options  Options
+
+	// The parser keeps state while parsing; parseMutex serializes its use
+	parseMutex sync.Mutex
 }
 
 // Options configures the TCOL engine behavior
@@ -57,6 +79,13 @@ type Options struct {
 	// EnableAliases allows user-defined command aliases (default: true)
 	EnableAliases bool
 
+	// AliasStore persists global and per-user aliases (optional, default: in memory)
+	AliasStore mdwregistry.AliasStore
+
+	// ProcedureStore persists stored procedures defined with PROC.DEFINE
+	// (optional, default: in memory)
+	ProcedureStore mdwregistry.ProcedureStore
+
 	// EnableChaining allows command chaining with pipes (default: true)
 	EnableChaining bool
 
@@ -66,13 +95,56 @@ type Options struct {
 	// PermissionChecker validates user permissions for commands
 	PermissionChecker PermissionChecker
 
+	// Permissions is checked by the executor for every command, including
+	// the stages of pipes and the commands of scripts, e.g. a
+	// mdwexecutor.RolePermissionChecker (optional)
+	Permissions mdwexecutor.PermissionChecker
+
 	// AuditLogger logs all command executions for compliance
 	AuditLogger AuditLogger
 
 	// ServiceClient for communicating with microservices (optional for testing)
 	ServiceClient mdwexecutor.ServiceClient
+
+	// FilterMacros provides user-defined functions that expand inside [filters] (optional)
+	FilterMacros *mdwmacro.Library
+
+	// OutputFormat is the format results are rendered in unless a command
+	// sets format= (default: table)
+	OutputFormat mdwformat.Format
+
+	// MaxColumnWidth truncates table cells to this many columns (default: unlimited)
+	MaxColumnWidth int
+
+	// HistoryStore records executed commands for HISTORY.LIST and
+	// HISTORY.RERUN (optional, default: in memory)
+	HistoryStore mdwexecutor.HistoryStore
+
+	// ScheduleStore persists commands scheduled with SCHEDULE.CREATE
+	// (optional, default: in memory)
+	ScheduleStore mdwexecutor.ScheduleStore
+
+	// FileRoot is the directory EXPORT writes to and IMPORT reads from;
+	// file names cannot leave it (optional, EXPORT and IMPORT are disabled
+	// without it)
+	FileRoot *mdwfilex.Root
+
+	// Tracer receives spans of lexing, parsing, and execution, e.g. an
+	// adapter for an OpenTelemetry tracer (optional)
+	Tracer mdwtelemetry.Tracer
+
+	// Metrics counts commands, latencies, and errors (optional, default:
+	// new metrics, see Engine.Metrics)
+	Metrics *mdwtelemetry.Metrics
 }
 
+// Output parameters of commands; they select how the result is rendered and
+// are not sent to services
+const (
+	FormatParam  = "format"
+	ColumnsParam = "columns"
+)
+
 // Result represents the result of a TCOL command execution
 type Result struct {
 	// Success indicates if the command executed successfully
@@ -95,6 +167,15 @@ type Result struct {
 
 	// Metadata contains additional result information
 	Metadata map[string]interface{}
+
+	// NextToken is passed as next_token to fetch the next page (empty on the last page)
+	NextToken string
+
+	// Warnings for the user, e.g. about deprecated objects or methods
+	Warnings []string
+
+	// Output selects how Render writes the data
+	Output mdwformat.Options
 }
 
 // Error represents a TCOL-specific error with additional context
@@ -114,6 +195,21 @@ func (e *Error) Error() string {
 	return "TCOL error"
 }
 
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Suggestions returns the registered commands the user may have meant if
+// the command was unknown, best first
+func (e *Error) Suggestions() []string {
+	var unknown *mdwregistry.UnknownCommandError
+	if errors.As(e.Err, &unknown) {
+		return unknown.Commands()
+	}
+	return nil
+}
+
 // PermissionChecker interface for validating user permissions
 type PermissionChecker interface {
 	// HasPermission checks if user has permission for object.method
@@ -186,17 +282,41 @@ func NewEngine(opts ...Options) (*Engine, error) {
 		options.PermissionChecker = provided.PermissionChecker
 		options.AuditLogger = provided.AuditLogger
 		options.ServiceClient = provided.ServiceClient
+		options.FilterMacros = provided.FilterMacros
+		options.AliasStore = provided.AliasStore
+		options.ProcedureStore = provided.ProcedureStore
+		options.HistoryStore = provided.HistoryStore
+		options.ScheduleStore = provided.ScheduleStore
+		options.FileRoot = provided.FileRoot
+		options.Tracer = provided.Tracer
+		options.Metrics = provided.Metrics
+		options.Permissions = provided.Permissions
+		options.MaxColumnWidth = provided.MaxColumnWidth
+		if provided.OutputFormat != "" {
+			format, err := mdwformat.Parse(string(provided.OutputFormat))
+			if err != nil {
+				return nil, fmt.Errorf("invalid TCOL options: %w", err)
+			}
+			options.OutputFormat = format
+		}
 	}
 
 	// Create logger with TCOL context
 	logger := options.Logger.WithField("component", "tcol-engine")
 
+	// Parser and executor record into the same metrics
+	if options.Metrics == nil {
+		options.Metrics = mdwtelemetry.NewMetrics()
+	}
+
 	// Create registry
 	reg, err := mdwregistry.NewSimple(mdwregistry.Options{
 		Logger:              logger,
 		Services:            options.Services,
 		EnableAbbreviations: options.EnableAbbreviations,
 		EnableAliases:       options.EnableAliases,
+		AliasStore:          options.AliasStore,
+		ProcedureStore:      options.ProcedureStore,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize TCOL registry: %w", err)
@@ -208,6 +328,8 @@ func NewEngine(opts ...Options) (*Engine, error) {
 		MaxInputLength:   options.MaxCommandLength,
 		EnableChaining:   options.EnableChaining,
 		Registry:         reg,
+		Tracer:           options.Tracer,
+		Metrics:          options.Metrics,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize TCOL parser: %w", err)
@@ -215,8 +337,15 @@ func NewEngine(opts ...Options) (*Engine, error) {
 
 	// Create executor with service client if provided
 	exec, err := mdwexecutor.New(mdwexecutor.Options{
-		Logger:        logger,
-		ServiceClient: options.ServiceClient,
+		Logger:            logger,
+		ServiceClient:     options.ServiceClient,
+		FilterMacros:      options.FilterMacros,
+		HistoryStore:      options.HistoryStore,
+		ScheduleStore:     options.ScheduleStore,
+		PermissionChecker: options.Permissions,
+		FileRoot:          options.FileRoot,
+		Tracer:            options.Tracer,
+		Metrics:           options.Metrics,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize TCOL executor: %w", err)
@@ -265,7 +394,9 @@ func (e *Engine) Execute(ctx context.Context, command string) (*Result, error) {
 	timer.Checkpoint("input_validated")
 
 	// Parse command
-	parsedCmd, err := e.parser.Parse(command)
+	e.parseMutex.Lock()
+	parsedCmd, err := e.parser.ParseContext(ctx, command)
+	e.parseMutex.Unlock()
 	if err != nil {
 		timer.StopWithError(err)
 		e.logger.Warn("TCOL parsing failed", mdwlog.Fields{
@@ -275,6 +406,12 @@ func (e *Engine) Execute(ctx context.Context, command string) (*Result, error) {
 		return nil, e.wrapParseError(err, command)
 	}
 
+	output, err := e.outputOptions(parsedCmd)
+	if err != nil {
+		timer.StopWithError(err)
+		return nil, e.wrapParseError(err, command)
+	}
+
 	timer.Checkpoint("command_parsed")
 
 	// Check permissions if checker is configured
@@ -301,6 +438,10 @@ func (e *Engine) Execute(ctx context.Context, command string) (*Result, error) {
 		RequestID: fmt.Sprintf("req-%d", time.Now().UnixNano()),
 		Timestamp: time.Now(),
 		Metadata:  make(map[string]interface{}),
+		Input:     command,
+	}
+	if userID, ok := ctx.Value("userId").(string); ok {
+		execCtx.UserID = userID
 	}
 	result, err := e.executor.Execute(ctx, parsedCmd, execCtx)
 	if err != nil {
@@ -340,6 +481,9 @@ func (e *Engine) Execute(ctx context.Context, command string) (*Result, error) {
 		Command:       command,
 		ParsedCommand: parsedCmd,
 		Metadata:      result.Metadata,
+		NextToken:     result.NextToken,
+		Warnings:      result.Warnings,
+		Output:        output,
 	}
 
 	// Log successful execution
@@ -367,14 +511,62 @@ func (e *Engine) Parse(command string) (*mdwast.Command, error) {
 	}
 
 	// Parse command
+	e.parseMutex.Lock()
+	defer e.parseMutex.Unlock()
 	return e.parser.Parse(command)
 }
 
+// ParseScript parses a TCOL script of commands and LET statements without
+// executing it
+func (e *Engine) ParseScript(script string) (*mdwast.Script, error) {
+	if err := e.validateInput(script); err != nil {
+		return nil, err
+	}
+
+	e.parseMutex.Lock()
+	defer e.parseMutex.Unlock()
+	return e.parser.ParseScript(script)
+}
+
+// Metrics returns the metrics of the engine; they implement
+// mdwtelemetry.MetricsSource for admin metrics endpoints
+func (e *Engine) Metrics() *mdwtelemetry.Metrics {
+	return e.options.Metrics
+}
+
+// StartScheduler starts executing the commands scheduled with
+// SCHEDULE.CREATE when they are due
+func (e *Engine) StartScheduler() error {
+	return e.executor.StartScheduler()
+}
+
+// StopScheduler stops executing scheduled commands and waits for those
+// that are running
+func (e *Engine) StopScheduler() {
+	e.executor.StopScheduler()
+}
+
 // Registry returns the command registry for registration of custom objects and methods
 func (e *Engine) Registry() *mdwregistry.Registry {
 	return e.registry
 }
 
+// ValidateMessageKeys checks that every i18n message key declared by registered
+// objects exists in the default locale (and any additional locales). Call it at
+// startup after all objects are registered to fail fast on missing translations.
+func (e *Engine) ValidateMessageKeys(checker mdwregistry.TranslationChecker, locales ...string) error {
+	err := mdwregistry.ValidateMessageKeys(e.registry, checker, locales...)
+
+	var missingErr *mdwregistry.MissingMessageKeysError
+	if errors.As(err, &missingErr) {
+		e.logger.Error("TCOL message keys missing translations", mdwlog.Fields{
+			"missingCount": len(missingErr.Missing),
+		})
+	}
+
+	return err
+}
+
 // ValidateCommand checks if a command is syntactically valid
 func (e *Engine) ValidateCommand(command string) error {
 	_, err := e.Parse(command)
@@ -391,6 +583,41 @@ func (e *Engine) GetAliases() map[string]string {
 	return e.registry.GetAliases()
 }
 
+// outputOptions removes the output parameters from the commands of a chain
+// and returns the rendering options they select; later commands win
+func (e *Engine) outputOptions(cmd *mdwast.Command) (mdwformat.Options, error) {
+	output := mdwformat.Options{
+		Format:   e.options.OutputFormat,
+		MaxWidth: e.options.MaxColumnWidth,
+	}
+
+	for ; cmd != nil; cmd = cmd.Chain {
+		if value, exists := cmd.Parameters[FormatParam]; exists {
+			name, ok := value.Value.(string)
+			if !ok {
+				return output, fmt.Errorf("%s must be a string, got %v", FormatParam, value.Value)
+			}
+			format, err := mdwformat.Parse(name)
+			if err != nil {
+				return output, err
+			}
+			output.Format = format
+			delete(cmd.Parameters, FormatParam)
+		}
+
+		if value, exists := cmd.Parameters[ColumnsParam]; exists {
+			list, ok := value.Value.(string)
+			if !ok {
+				return output, fmt.Errorf("%s must be a comma-separated string, got %v", ColumnsParam, value.Value)
+			}
+			output.Columns = mdwformat.ParseColumns(list)
+			delete(cmd.Parameters, ColumnsParam)
+		}
+	}
+
+	return output, nil
+}
+
 // validateInput validates the input command string
 func (e *Engine) validateInput(command string) error {
 	// Check for empty input
@@ -449,6 +676,17 @@ func (r *Result) String() string {
 		r.Message, len(r.Data), r.ExecutionTime)
 }
 
+// Render writes the result data to w in the format selected by the command
+// or the engine options
+func (r *Result) Render(w io.Writer) error {
+	return mdwformat.Render(w, r.Data, r.Output)
+}
+
+// Formatted returns the result data rendered like Render does
+func (r *Result) Formatted() (string, error) {
+	return mdwformat.String(r.Data, r.Output)
+}
+
 // IsEmpty returns true if the result contains no data
 func (r *Result) IsEmpty() bool {
 	return len(r.Data) == 0
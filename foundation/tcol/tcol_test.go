@@ -5,25 +5,32 @@
 //              components. Tests cover basic commands, error handling, and
 //              integration scenarios.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.3
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial TCOL tests
+// - 2026-10-16 v0.1.1: Added command suggestion tests
+// - 2026-10-16 v0.1.2: Added output format tests
+// - 2026-10-16 v0.1.3: Added tracing and metrics tests
 
 package tcol
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	mdwlog "github.com/msto63/mDW/foundation/core/log"
 	mdwclient "github.com/msto63/mDW/foundation/tcol/client"
 	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+	mdwformat "github.com/msto63/mDW/foundation/tcol/format"
 	mdwparser "github.com/msto63/mDW/foundation/tcol/parser"
 	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwtelemetry "github.com/msto63/mDW/foundation/tcol/telemetry"
 )
 
 func TestTCOLEngine_Execute(t *testing.T) {
@@ -438,4 +445,114 @@ func BenchmarkTCOLEngine_Execute(b *testing.B) {
 			b.Fatalf("Execution failed: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestEngine_Execute_Suggestions(t *testing.T) {
+	engine, err := NewEngine(Options{ServiceClient: NewMockServiceClient()})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"CREATE": {}, "LIST": {}},
+	})
+
+	_, err = engine.Execute(context.Background(), "CUSTOMER.CRAETE")
+	var tcolErr *Error
+	if !errors.As(err, &tcolErr) {
+		t.Fatalf("Execute() error = %v, want *Error", err)
+	}
+	if suggestions := tcolErr.Suggestions(); len(suggestions) == 0 || suggestions[0] != "CUSTOMER.CREATE" {
+		t.Errorf("Suggestions() = %v", suggestions)
+	}
+	var unknown *mdwregistry.UnknownCommandError
+	if !errors.As(err, &unknown) || unknown.Method != "CRAETE" {
+		t.Errorf("error does not wrap the unknown command: %v", err)
+	}
+
+	if _, err := engine.Execute(context.Background(), "CUSTOMER.LIST"); err != nil {
+		if errors.As(err, &tcolErr) && len(tcolErr.Suggestions()) > 0 {
+			t.Errorf("Suggestions() for a known command = %v", tcolErr.Suggestions())
+		}
+	}
+}
+
+func TestEngine_Execute_OutputFormat(t *testing.T) {
+	client := NewMockServiceClient()
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &mdwexecutor.ServiceResponse{
+		Success: true,
+		Data: []interface{}{
+			map[string]interface{}{"name": "Alice", "email": "alice@example.com"},
+			map[string]interface{}{"name": "Bob", "email": "bob@example.com"},
+		},
+	})
+	engine, err := NewEngine(Options{ServiceClient: client, OutputFormat: "json"})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"LIST": {}},
+	})
+
+	result, err := engine.Execute(context.Background(), `CUSTOMER.LIST format=csv columns="name"`)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output, err := result.Formatted(); err != nil || output != "name\nAlice\nBob\n" {
+		t.Errorf("Formatted() = %q, %v", output, err)
+	}
+	calls := client.GetCallHistory()
+	if params := calls[len(calls)-1].Params; params[FormatParam] != nil || params[ColumnsParam] != nil {
+		t.Errorf("output parameters were sent to the service: %v", params)
+	}
+
+	// Without format= the engine option applies
+	result, _ = engine.Execute(context.Background(), "CUSTOMER.LIST")
+	if result.Output.Format != mdwformat.JSON {
+		t.Errorf("Output.Format = %q, want json", result.Output.Format)
+	}
+
+	if _, err := engine.Execute(context.Background(), "CUSTOMER.LIST format=xml"); err == nil {
+		t.Error("Execute() with an unknown format did not fail")
+	}
+	if _, err := NewEngine(Options{OutputFormat: "html"}); err == nil {
+		t.Error("NewEngine() with an unknown output format did not fail")
+	}
+}
+
+func TestEngine_Execute_Telemetry(t *testing.T) {
+	client := NewMockServiceClient()
+	client.SetResponse("customer-service", "CUSTOMER", "LIST", &mdwexecutor.ServiceResponse{Success: true})
+	tracer := mdwtelemetry.NewRecordingTracer()
+	engine, err := NewEngine(Options{ServiceClient: client, Tracer: tracer})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:    "CUSTOMER",
+		Service: "customer-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{"LIST": {}},
+	})
+
+	if _, err := engine.Execute(context.Background(), "CUSTOMER.LIST"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := engine.Execute(context.Background(), "CUSTOMER.LIST name="); err == nil {
+		t.Fatal("Execute() with a syntax error succeeded")
+	}
+
+	snapshot := engine.Metrics().Snapshot()
+	if snapshot.ParsesTotal != 2 || snapshot.ParseErrors != 1 || snapshot.CommandsTotal != 1 || snapshot.ErrorCodes["TCOL_SYNTAX"] != 1 {
+		t.Errorf("metrics = %+v", snapshot)
+	}
+	var names []string
+	for _, span := range tracer.Spans() {
+		names = append(names, span.Name)
+	}
+	if strings.Join(names, ",") != "tcol.lex,tcol.parse,tcol.command,tcol.execute,tcol.lex,tcol.parse" {
+		t.Errorf("spans = %v", names)
+	}
+}
@@ -0,0 +1,64 @@
+// File: doc.go
+// Title: TCOL Telemetry Package Documentation
+// Description: Documents the tracing interfaces and the metrics of the TCOL
+//              engine and how admin endpoints scrape them.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial documentation
+
+/*
+Package telemetry instruments the TCOL engine with tracing spans and
+metrics.
+
+# Tracing
+
+The parser and executor start spans through the Tracer interface:
+
+	tcol.parse    parsing of a command or script
+	tcol.lex      tokenizing of the input, a child of tcol.parse
+	tcol.execute  a stage of a pipe, including the stages after it
+	tcol.command  the execution of a single object and method
+
+Spans carry the object and method (tcol.object, tcol.method), and failed
+spans the error and its code (tcol.error_code). The interfaces mirror the
+OpenTelemetry trace API without depending on it; an adapter wraps an
+OpenTelemetry tracer in a few lines:
+
+	type otelTracer struct{ tracer trace.Tracer }
+
+	func (t otelTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+		ctx, span := t.tracer.Start(ctx, name)
+		s := otelSpan{span}
+		s.SetAttributes(attrs...)
+		return ctx, s
+	}
+
+Without a tracer nothing is recorded. RecordingTracer keeps finished spans in
+memory for tests and debugging.
+
+# Metrics
+
+Metrics counts the executed commands in total and by object and method,
+failures by error code, and keeps latency histograms of commands and of
+parsing:
+
+	metrics := telemetry.NewMetrics()
+	engine, err := tcol.NewEngine(tcol.Options{Metrics: metrics, Tracer: tracer})
+
+	snapshot := metrics.Snapshot()
+	fmt.Println(snapshot.CommandsTotal, snapshot.Latency.Mean())
+
+Error codes are taken from the mDW errors in the error chain, e.g.
+TCOL_PERMISSION for denied commands; exceeded deadlines count as TIMEOUT,
+other failures as TCOL_EXECUTION, and parse errors as TCOL_SYNTAX.
+
+Admin endpoints depend on the MetricsSource interface. Handler serves its
+snapshots as JSON, or in the Prometheus text format for ?format=prometheus:
+
+	mux.Handle("/admin/tcol/metrics", telemetry.Handler(metrics))
+*/
+package telemetry
@@ -0,0 +1,364 @@
+// File: metrics.go
+// Title: TCOL Engine Metrics
+// Description: Counts executed commands in total and by object and method,
+//              records latency histograms of commands and parsing, and
+//              counts failures by error code. Snapshots are served as JSON
+//              or in the Prometheus text format, e.g. by admin metrics
+//              endpoints.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the engine metrics
+
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histograms
+// unless NewMetrics is given others
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond,
+	25 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond,
+	250 * time.Millisecond, 500 * time.Millisecond, time.Second,
+	2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// MetricsSource provides metrics snapshots; *Metrics implements it. Admin
+// endpoints depend on this interface rather than on the engine.
+type MetricsSource interface {
+	Snapshot() MetricsSnapshot
+}
+
+// MetricsSnapshot is a copy of the counters of Metrics. Durations are
+// written to JSON in nanoseconds.
+type MetricsSnapshot struct {
+	CommandsTotal uint64            `json:"commands_total"` // Executed commands
+	ErrorsTotal   uint64            `json:"errors_total"`   // Failed commands
+	Commands      []CommandMetrics  `json:"commands"`       // By object and method, sorted
+	ErrorCodes    map[string]uint64 `json:"error_codes"`    // Failed commands and parses by error code
+	Latency       Histogram         `json:"latency"`        // Latency of all commands
+	ParsesTotal   uint64            `json:"parses_total"`   // Parsed commands and scripts
+	ParseErrors   uint64            `json:"parse_errors"`   // Inputs rejected by the parser
+	ParseLatency  Histogram         `json:"parse_latency"`  // Time spent lexing and parsing
+}
+
+// CommandMetrics holds the counters of one object and method
+type CommandMetrics struct {
+	Object  string    `json:"object"`
+	Method  string    `json:"method"`
+	Count   uint64    `json:"count"`
+	Errors  uint64    `json:"errors"`
+	Latency Histogram `json:"latency"`
+}
+
+// Histogram is a latency distribution. Bucket counts are cumulative like
+// in Prometheus: each counts the observations up to its upper bound.
+type Histogram struct {
+	Buckets []Bucket      `json:"buckets"`
+	Count   uint64        `json:"count"`
+	Sum     time.Duration `json:"sum"`
+}
+
+// Bucket is a histogram bucket
+type Bucket struct {
+	UpperBound time.Duration `json:"le"`
+	Count      uint64        `json:"count"`
+}
+
+// Mean returns the average of the observations, or 0 if there are none
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Metrics collects the metrics of a TCOL engine. It is safe for concurrent
+// use; the zero value is not usable, use NewMetrics.
+type Metrics struct {
+	mutex        sync.Mutex
+	buckets      []time.Duration
+	total        uint64
+	errors       uint64
+	commands     map[commandKey]*commandCounters
+	errorCodes   map[string]uint64
+	latency      *histogram
+	parses       uint64
+	parseErrors  uint64
+	parseLatency *histogram
+}
+
+// commandKey identifies the counters of an object and method
+type commandKey struct {
+	object string
+	method string
+}
+
+// commandCounters are the counters of an object and method
+type commandCounters struct {
+	count   uint64
+	errors  uint64
+	latency *histogram
+}
+
+// histogram counts observations per bucket; the last count is for
+// observations above all bounds
+type histogram struct {
+	counts []uint64
+	sum    time.Duration
+}
+
+// NewMetrics creates metrics with the given latency bucket bounds in
+// ascending order, or DefaultLatencyBuckets if none are given
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	bounds := append([]time.Duration(nil), buckets...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	m := &Metrics{buckets: bounds}
+	m.Reset()
+	return m
+}
+
+// ObserveCommand records an executed command; err is nil on success
+func (m *Metrics) ObserveCommand(object, method string, duration time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := commandKey{object: object, method: method}
+	counters, exists := m.commands[key]
+	if !exists {
+		counters = &commandCounters{latency: m.newHistogram()}
+		m.commands[key] = counters
+	}
+
+	m.total++
+	counters.count++
+	m.observe(m.latency, duration)
+	m.observe(counters.latency, duration)
+	if err != nil {
+		m.errors++
+		counters.errors++
+		m.errorCodes[ErrorCode(err)]++
+	}
+}
+
+// ObserveParse records the parsing of a command or script; err is nil on
+// success. Failures are counted as TCOL_SYNTAX unless err carries a code.
+func (m *Metrics) ObserveParse(duration time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.parses++
+	m.observe(m.parseLatency, duration)
+	if err != nil {
+		m.parseErrors++
+		code := string(mdwerror.CodeTCOLSyntax)
+		var mdwErr *mdwerror.Error
+		if errors.As(err, &mdwErr) {
+			code = string(mdwErr.Code())
+		}
+		m.errorCodes[code]++
+	}
+}
+
+// Snapshot returns a copy of the current counters
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	snapshot := MetricsSnapshot{
+		CommandsTotal: m.total,
+		ErrorsTotal:   m.errors,
+		Commands:      make([]CommandMetrics, 0, len(m.commands)),
+		ErrorCodes:    make(map[string]uint64, len(m.errorCodes)),
+		Latency:       m.snapshotHistogram(m.latency),
+		ParsesTotal:   m.parses,
+		ParseErrors:   m.parseErrors,
+		ParseLatency:  m.snapshotHistogram(m.parseLatency),
+	}
+	for key, counters := range m.commands {
+		snapshot.Commands = append(snapshot.Commands, CommandMetrics{
+			Object:  key.object,
+			Method:  key.method,
+			Count:   counters.count,
+			Errors:  counters.errors,
+			Latency: m.snapshotHistogram(counters.latency),
+		})
+	}
+	sort.Slice(snapshot.Commands, func(i, j int) bool {
+		a, b := snapshot.Commands[i], snapshot.Commands[j]
+		if a.Object != b.Object {
+			return a.Object < b.Object
+		}
+		return a.Method < b.Method
+	})
+	for code, count := range m.errorCodes {
+		snapshot.ErrorCodes[code] = count
+	}
+	return snapshot
+}
+
+// Reset sets all counters to zero
+func (m *Metrics) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.total, m.errors, m.parses, m.parseErrors = 0, 0, 0, 0
+	m.commands = make(map[commandKey]*commandCounters)
+	m.errorCodes = make(map[string]uint64)
+	m.latency = m.newHistogram()
+	m.parseLatency = m.newHistogram()
+}
+
+// newHistogram creates an empty histogram for the configured buckets
+func (m *Metrics) newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(m.buckets)+1)}
+}
+
+// observe adds a duration to the bucket it falls into
+func (m *Metrics) observe(h *histogram, duration time.Duration) {
+	index := sort.Search(len(m.buckets), func(i int) bool { return duration <= m.buckets[i] })
+	h.counts[index]++
+	h.sum += duration
+}
+
+// snapshotHistogram converts a histogram to cumulative buckets
+func (m *Metrics) snapshotHistogram(h *histogram) Histogram {
+	result := Histogram{Buckets: make([]Bucket, len(m.buckets)), Sum: h.sum}
+	for i, bound := range m.buckets {
+		result.Count += h.counts[i]
+		result.Buckets[i] = Bucket{UpperBound: bound, Count: result.Count}
+	}
+	result.Count += h.counts[len(m.buckets)]
+	return result
+}
+
+// ErrorCode returns the code metrics count a failure under: the code of
+// the first mDW error in the chain of err, TIMEOUT for exceeded deadlines,
+// and TCOL_EXECUTION for other errors
+func ErrorCode(err error) string {
+	var mdwErr *mdwerror.Error
+	switch {
+	case errors.As(err, &mdwErr):
+		return string(mdwErr.Code())
+	case errors.Is(err, context.DeadlineExceeded):
+		return string(mdwerror.CodeTimeout)
+	default:
+		return string(mdwerror.CodeTCOLExecution)
+	}
+}
+
+// ===============================
+// Exposition
+// ===============================
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition
+// format. Latencies are written in seconds.
+func (s MetricsSnapshot) WritePrometheus(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	writeHeader(out, "tcol_commands_total", "counter", "TCOL commands executed by object and method.")
+	for _, command := range s.Commands {
+		fmt.Fprintf(out, "tcol_commands_total{%s} %d\n", commandLabels(command), command.Count)
+	}
+	writeHeader(out, "tcol_command_errors_total", "counter", "Failed TCOL commands by object and method.")
+	for _, command := range s.Commands {
+		fmt.Fprintf(out, "tcol_command_errors_total{%s} %d\n", commandLabels(command), command.Errors)
+	}
+	writeHeader(out, "tcol_errors_total", "counter", "Failed TCOL commands and parses by error code.")
+	codes := make([]string, 0, len(s.ErrorCodes))
+	for code := range s.ErrorCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(out, "tcol_errors_total{code=%s} %d\n", quoteLabel(code), s.ErrorCodes[code])
+	}
+
+	writeHeader(out, "tcol_command_duration_seconds", "histogram", "Latency of TCOL commands by object and method.")
+	for _, command := range s.Commands {
+		writeHistogram(out, "tcol_command_duration_seconds", commandLabels(command), command.Latency)
+	}
+	writeHeader(out, "tcol_parse_duration_seconds", "histogram", "Time spent lexing and parsing TCOL input.")
+	writeHistogram(out, "tcol_parse_duration_seconds", "", s.ParseLatency)
+	writeHeader(out, "tcol_parse_errors_total", "counter", "TCOL inputs rejected by the parser.")
+	fmt.Fprintf(out, "tcol_parse_errors_total %d\n", s.ParseErrors)
+
+	return out.Flush()
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric
+func writeHeader(out *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeHistogram writes the bucket, sum, and count samples of a histogram
+func writeHistogram(out *bufio.Writer, name, labels string, h Histogram) {
+	separator := ""
+	if labels != "" {
+		separator = ","
+	}
+	for _, bucket := range h.Buckets {
+		le := strconv.FormatFloat(bucket.UpperBound.Seconds(), 'g', -1, 64)
+		fmt.Fprintf(out, "%s_bucket{%s%sle=%q} %d\n", name, labels, separator, le, bucket.Count)
+	}
+	fmt.Fprintf(out, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, separator, h.Count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(out, "%s_sum%s %s\n", name, labels, strconv.FormatFloat(h.Sum.Seconds(), 'g', -1, 64))
+	fmt.Fprintf(out, "%s_count%s %d\n", name, labels, h.Count)
+}
+
+// commandLabels returns the object and method labels of a command
+func commandLabels(command CommandMetrics) string {
+	return "object=" + quoteLabel(command.Object) + ",method=" + quoteLabel(command.Method)
+}
+
+// quoteLabel quotes a label value with the escapes of the text format
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// Handler returns an HTTP handler serving snapshots of source as JSON, or
+// in the Prometheus text format if the request has format=prometheus or
+// accepts text/plain
+func Handler(source MetricsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		snapshot := source.Snapshot()
+		if r.URL.Query().Get("format") == "prometheus" || strings.Contains(r.Header.Get("Accept"), "text/plain") {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			snapshot.WritePrometheus(w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshot)
+	})
+}
@@ -0,0 +1,174 @@
+// File: telemetry_test.go
+// Title: TCOL Telemetry Tests
+// Description: Tests the recording tracer, the counters and histograms of
+//              the metrics, error codes, and the Prometheus and HTTP
+//              exposition.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial telemetry tests
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mdwerror "github.com/msto63/mDW/foundation/core/error"
+)
+
+func TestRecordingTracer(t *testing.T) {
+	tracer := NewRecordingTracer()
+	ctx, parent := tracer.Start(context.Background(), SpanParse, Int(AttrLength, 12))
+	_, child := tracer.Start(ctx, SpanLex)
+	child.RecordError(errors.New("illegal character"))
+	child.End()
+	parent.SetAttributes(String(AttrObject, "CUSTOMER"))
+	parent.End()
+	parent.End()
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Spans() = %d, want 2", len(spans))
+	}
+	lex, parse := spans[0], spans[1]
+	if lex.Name != SpanLex || lex.ParentID != parse.ID || lex.Err == nil {
+		t.Errorf("lex span = %+v", lex)
+	}
+	if parse.ParentID != 0 || parse.Attributes[AttrLength] != 12 || parse.Attributes[AttrObject] != "CUSTOMER" {
+		t.Errorf("parse span = %+v", parse)
+	}
+
+	tracer.Reset()
+	if len(tracer.Spans()) != 0 {
+		t.Error("Reset() kept spans")
+	}
+	if ctx, span := NoopTracer().Start(ctx, SpanExecute); ctx == nil || span == nil {
+		t.Error("NoopTracer().Start() returned nil")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics(10*time.Millisecond, time.Millisecond)
+	denied := mdwerror.New("denied").WithCode(mdwerror.CodeTCOLPermission)
+
+	metrics.ObserveCommand("CUSTOMER", "LIST", 500*time.Microsecond, nil)
+	metrics.ObserveCommand("CUSTOMER", "LIST", 5*time.Millisecond, nil)
+	metrics.ObserveCommand("CUSTOMER", "DELETE", 20*time.Millisecond, fmt.Errorf("stage 2: %w", denied))
+	metrics.ObserveCommand("ALIAS", "LIST", time.Millisecond, errors.New("failed"))
+	metrics.ObserveParse(time.Millisecond, nil)
+	metrics.ObserveParse(time.Millisecond, errors.New("unexpected token"))
+
+	snapshot := metrics.Snapshot()
+	if snapshot.CommandsTotal != 4 || snapshot.ErrorsTotal != 2 || snapshot.ParsesTotal != 2 || snapshot.ParseErrors != 1 {
+		t.Errorf("totals = %+v", snapshot)
+	}
+	var order []string
+	for _, command := range snapshot.Commands {
+		order = append(order, command.Object+"."+command.Method)
+	}
+	if strings.Join(order, ",") != "ALIAS.LIST,CUSTOMER.DELETE,CUSTOMER.LIST" {
+		t.Errorf("commands = %v", order)
+	}
+	list := snapshot.Commands[2]
+	if list.Count != 2 || list.Errors != 0 || list.Latency.Mean() != 2750*time.Microsecond {
+		t.Errorf("CUSTOMER.LIST = %+v", list)
+	}
+
+	expected := map[string]uint64{"TCOL_PERMISSION": 1, "TCOL_EXECUTION": 1, "TCOL_SYNTAX": 1}
+	for code, count := range expected {
+		if snapshot.ErrorCodes[code] != count {
+			t.Errorf("ErrorCodes = %v, want %v", snapshot.ErrorCodes, expected)
+		}
+	}
+
+	latency := snapshot.Latency
+	if latency.Count != 4 || len(latency.Buckets) != 2 ||
+		latency.Buckets[0] != (Bucket{time.Millisecond, 2}) || latency.Buckets[1] != (Bucket{10 * time.Millisecond, 3}) {
+		t.Errorf("latency = %+v", latency)
+	}
+
+	metrics.Reset()
+	if snapshot := metrics.Snapshot(); snapshot.CommandsTotal != 0 || len(snapshot.Commands) != 0 || snapshot.Latency.Count != 0 {
+		t.Errorf("snapshot after Reset() = %+v", snapshot)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	for err, want := range map[error]string{
+		mdwerror.New("x").WithCode(mdwerror.CodeTCOLObjectNotFound): "TCOL_OBJECT_NOT_FOUND",
+		fmt.Errorf("call: %w", ctx.Err()):                           "TIMEOUT",
+		errors.New("x"):                                             "TCOL_EXECUTION",
+	} {
+		if got := ErrorCode(err); got != want {
+			t.Errorf("ErrorCode(%v) = %s, want %s", err, got, want)
+		}
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	metrics := NewMetrics(time.Millisecond)
+	metrics.ObserveCommand("CUSTOMER", `LI"ST`, 2*time.Millisecond, errors.New("failed"))
+	metrics.ObserveParse(500*time.Microsecond, nil)
+
+	var out bytes.Buffer
+	if err := metrics.Snapshot().WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	for _, expected := range []string{
+		"# TYPE tcol_commands_total counter\n",
+		`tcol_commands_total{object="CUSTOMER",method="LI\"ST"} 1`,
+		`tcol_command_errors_total{object="CUSTOMER",method="LI\"ST"} 1`,
+		`tcol_errors_total{code="TCOL_EXECUTION"} 1`,
+		`tcol_command_duration_seconds_bucket{object="CUSTOMER",method="LI\"ST",le="0.001"} 0`,
+		`tcol_command_duration_seconds_bucket{object="CUSTOMER",method="LI\"ST",le="+Inf"} 1`,
+		`tcol_command_duration_seconds_sum{object="CUSTOMER",method="LI\"ST"} 0.002`,
+		`tcol_parse_duration_seconds_bucket{le="0.001"} 1`,
+		"tcol_parse_duration_seconds_count 1\n",
+		"tcol_parse_errors_total 0\n",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("output does not contain %s:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ObserveCommand("CUSTOMER", "LIST", time.Millisecond, nil)
+	handler := Handler(metrics)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	var snapshot MetricsSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil || snapshot.CommandsTotal != 1 {
+		t.Errorf("JSON response = %s (error %v)", recorder.Body, err)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics?format=prometheus", nil))
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain") ||
+		!strings.Contains(recorder.Body.String(), "tcol_commands_total") {
+		t.Errorf("Prometheus response = %s", recorder.Body)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/metrics", nil))
+	if recorder.Code != 405 {
+		t.Errorf("POST status = %d, want 405", recorder.Code)
+	}
+}
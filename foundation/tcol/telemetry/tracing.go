@@ -0,0 +1,204 @@
+// File: tracing.go
+// Title: TCOL Tracing Interfaces
+// Description: Defines the tracer and span interfaces the lexer, parser, and
+//              executor report their work through. They mirror the
+//              OpenTelemetry trace API, so an adapter for an OpenTelemetry
+//              tracer is a thin wrapper. Provides a no-op tracer and a
+//              recording tracer for tests and debugging.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial tracing interfaces
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Span names of the TCOL engine
+const (
+	SpanLex     = "tcol.lex"
+	SpanParse   = "tcol.parse"
+	SpanExecute = "tcol.execute"
+	SpanCommand = "tcol.command"
+)
+
+// Span attribute keys of the TCOL engine
+const (
+	AttrObject    = "tcol.object"
+	AttrMethod    = "tcol.method"
+	AttrStage     = "tcol.stage"     // Position of the stage in its pipe, from 0
+	AttrTransform = "tcol.transform" // Kind of a transform stage, e.g. SORT
+	AttrTokens    = "tcol.tokens"
+	AttrLength    = "tcol.input_length"
+	AttrErrorCode = "tcol.error_code"
+	AttrRequestID = "tcol.request_id"
+)
+
+// Attribute is a key-value pair describing a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a unit of work started by a Tracer; End must be called once
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans. The returned context carries the span, so spans
+// started with it become its children.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// ===============================
+// No-op Tracer
+// ===============================
+
+// NoopTracer returns a tracer that records nothing; it is used if no
+// tracer is configured
+func NoopTracer() Tracer {
+	return noopTracer{}
+}
+
+type noopTracer struct{}
+
+// Start implements Tracer and returns ctx unchanged
+func (noopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
+
+// ===============================
+// Recording Tracer
+// ===============================
+
+// SpanRecord is a finished span of a RecordingTracer
+type SpanRecord struct {
+	ID         int                    // Sequence number of the span, starting at 1
+	ParentID   int                    // ID of the parent span; 0 for root spans
+	Name       string                 // Span name, e.g. SpanExecute
+	Attributes map[string]interface{} // Attributes by key; later values win
+	Err        error                  // Last recorded error
+	Start      time.Time              // Start time
+	Duration   time.Duration          // Time between Start and End
+}
+
+// RecordingTracer keeps finished spans in memory, in the order they ended
+type RecordingTracer struct {
+	mutex  sync.Mutex
+	nextID int
+	spans  []SpanRecord
+}
+
+// spanContextKey is the context key of the current recording span
+type spanContextKey struct{}
+
+// NewRecordingTracer creates a tracer that records spans in memory
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+// Start implements Tracer
+func (t *RecordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	t.mutex.Lock()
+	t.nextID++
+	span := &recordingSpan{
+		tracer: t,
+		record: SpanRecord{
+			ID:         t.nextID,
+			Name:       name,
+			Attributes: make(map[string]interface{}, len(attrs)),
+			Start:      time.Now(),
+		},
+	}
+	t.mutex.Unlock()
+
+	if parent, exists := ctx.Value(spanContextKey{}).(*recordingSpan); exists && parent.tracer == t {
+		span.record.ParentID = parent.record.ID
+	}
+	span.SetAttributes(attrs...)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// Spans returns the finished spans
+func (t *RecordingTracer) Spans() []SpanRecord {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]SpanRecord(nil), t.spans...)
+}
+
+// Reset discards the finished spans
+func (t *RecordingTracer) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = nil
+}
+
+// recordingSpan is an open span of a RecordingTracer
+type recordingSpan struct {
+	tracer *RecordingTracer
+	mutex  sync.Mutex
+	record SpanRecord
+	ended  bool
+}
+
+// SetAttributes implements Span
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, attr := range attrs {
+		s.record.Attributes[attr.Key] = attr.Value
+	}
+}
+
+// RecordError implements Span
+func (s *recordingSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.record.Err = err
+}
+
+// End implements Span; calls after the first are ignored
+func (s *recordingSpan) End() {
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.record.Duration = time.Since(s.record.Start)
+	record := s.record
+	s.mutex.Unlock()
+
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.tracer.spans = append(s.tracer.spans, record)
+}
@@ -0,0 +1,48 @@
+// File: doc.go
+// Title: Natural Language to TCOL Translation Package Documentation
+// Description: Optional bridge that proposes TCOL commands for requests in
+//              plain language with the Turing LLM service, for the user to
+//              confirm before they are executed.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial translation bridge
+
+/*
+Package translate proposes TCOL commands for requests in plain language.
+
+A Translator sends the request to the Turing LLM service together with the
+schema of the registered objects, as returned by the registry's Describe,
+and returns the command the model proposes:
+
+	translator, err := translate.New(engine, translate.Options{
+		Client: turingClient, // Implemented by the service with the Turing gRPC client
+	})
+	if err != nil {
+		return err
+	}
+	proposal, err := translator.Translate(ctx, "open invoices of Acme over 1000")
+	if err != nil {
+		return err
+	}
+	fmt.Println(proposal.Command)     // INVOICE[((customer = "Acme") AND (total > 1000))].LIST status="open"
+	fmt.Println(proposal.Explanation) // Lists the open invoices of Acme over 1000.
+
+# Confirmation
+
+A translator never executes commands. The proposed command is parsed with
+the aliases and abbreviations of the engine and checked against the
+registry: every stage must use a registered object and method, and the
+built-in objects such as ALIAS, JOB and PROC are neither described to the
+model nor accepted. Proposal.Command holds the canonical text of the
+command, which the caller shows to the user and executes with
+Engine.Execute only if the user confirms it; the usual permission checks
+apply then. The ask command of the interactive shell does this.
+
+If the model finds no command for the request, Translate returns an error
+wrapping ErrNoTranslation with the reason given by the model.
+*/
+package translate
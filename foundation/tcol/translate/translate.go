@@ -0,0 +1,233 @@
+// File: translate.go
+// Title: Natural Language to TCOL Translation
+// Description: Translates free-text requests into proposed TCOL commands
+//              with the Turing LLM service, using the registry schema as
+//              context. Proposals are parsed and validated against the
+//              registry but never executed; the gRPC transport is supplied
+//              by the caller through TuringClient.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the translation bridge
+
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	mdwlog "github.com/msto63/mDW/foundation/core/log"
+	"github.com/msto63/mDW/foundation/tcol"
+	mdwast "github.com/msto63/mDW/foundation/tcol/ast"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+	mdwstringx "github.com/msto63/mDW/foundation/utils/stringx"
+)
+
+// internalService is the service of the built-in objects such as ALIAS and
+// PROC, which are neither described to the model nor accepted in proposals
+const internalService = "tcol-internal"
+
+// DefaultMaxInputLength is the default limit of the text to translate
+const DefaultMaxInputLength = 2000
+
+// ErrNoTranslation is returned if the model finds no command for the
+// request; the error message carries its reason
+var ErrNoTranslation = errors.New("no TCOL command for this request")
+
+// TuringClient sends a chat prompt to the Turing LLM service and returns the
+// reply. Services implement it with the generated gRPC client.
+type TuringClient interface {
+	Chat(ctx context.Context, systemPrompt, prompt string) (string, error)
+}
+
+// Options configures a Translator
+type Options struct {
+	// Client sends the prompts to the Turing LLM service (required)
+	Client TuringClient
+
+	// MaxInputLength limits the text to translate (default: DefaultMaxInputLength)
+	MaxInputLength int
+
+	// Logger for translations (optional, defaults to default logger)
+	Logger *mdwlog.Logger
+}
+
+// Proposal is a TCOL command proposed for a free-text request. It has been
+// parsed and validated against the registry, but the user must confirm it
+// before it is executed.
+type Proposal struct {
+	Input       string          `json:"input"`                 // Text entered by the user
+	Command     string          `json:"command"`               // Canonical text of the proposed command
+	Explanation string          `json:"explanation,omitempty"` // What the command does, as told by the model
+	Parsed      *mdwast.Command `json:"parsed"`                // Parsed proposed command
+}
+
+// Translator proposes TCOL commands for free-text requests
+type Translator struct {
+	engine  *tcol.Engine
+	options Options
+	logger  *mdwlog.Logger
+}
+
+// New creates a translator for the commands registered in engine
+func New(engine *tcol.Engine, opts Options) (*Translator, error) {
+	if engine == nil {
+		return nil, fmt.Errorf("TCOL engine is required")
+	}
+	if opts.Client == nil {
+		return nil, fmt.Errorf("Turing client is required")
+	}
+	if opts.MaxInputLength <= 0 {
+		opts.MaxInputLength = DefaultMaxInputLength
+	}
+	if opts.Logger == nil {
+		opts.Logger = mdwlog.GetDefault()
+	}
+
+	return &Translator{
+		engine:  engine,
+		options: opts,
+		logger:  opts.Logger.WithField("component", "tcol-translate"),
+	}, nil
+}
+
+// systemPrompt instructs the model to answer with a single command
+const systemPrompt = `You translate requests of business users into commands of TCOL, the Terminal Command Object Language. A command has the form OBJECT.METHOD name=value ..., with string values in double quotes. A filter goes between object and method, as in CUSTOMER[status = "open" AND revenue > 1000].LIST. OBJECT:ID reads an object, OBJECT:ID:field reads a field, and OBJECT:ID:field="value" sets it. Commands are piped with |, and the stages SELECT field, ..., SORT BY field [ASC|DESC], LIMIT count and GROUP BY field reshape the result of the previous stage.
+
+The user sends the schema of the available objects as JSON, followed by the request. Use only these objects, methods, parameters and fields. Reply with a JSON object {"command": "...", "explanation": "..."} and nothing else, where explanation says in one sentence what the command does. If no command fits the request, reply with an empty command and explain why.`
+
+// Translate asks the model for a command for input and returns it as a
+// proposal. The command is parsed with the aliases and abbreviations of the
+// engine and must only use registered objects and methods; it is never
+// executed.
+func (t *Translator) Translate(ctx context.Context, input string) (*Proposal, error) {
+	input = strings.TrimSpace(input)
+	if mdwstringx.IsBlank(input) {
+		return nil, fmt.Errorf("request is empty")
+	}
+	if len(input) > t.options.MaxInputLength {
+		return nil, fmt.Errorf("request exceeds maximum length of %d characters", t.options.MaxInputLength)
+	}
+
+	schema, err := t.Schema()
+	if err != nil {
+		return nil, err
+	}
+	prompt := fmt.Sprintf("Schema:\n%s\n\nRequest:\n%s", schema, input)
+
+	reply, err := t.options.Client.Chat(ctx, systemPrompt, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("Turing chat request failed: %w", err)
+	}
+	answer, err := parseReply(reply)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Turing reply: %w", err)
+	}
+	if mdwstringx.IsBlank(answer.Command) {
+		if mdwstringx.IsBlank(answer.Explanation) {
+			return nil, ErrNoTranslation
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNoTranslation, answer.Explanation)
+	}
+
+	cmd, err := t.engine.Parse(answer.Command)
+	if err != nil {
+		return nil, fmt.Errorf("proposed command %q is invalid: %w", answer.Command, err)
+	}
+	if err := t.validate(cmd); err != nil {
+		return nil, fmt.Errorf("proposed command %q is invalid: %w", answer.Command, err)
+	}
+
+	proposal := &Proposal{
+		Input:       input,
+		Command:     cmd.String(),
+		Explanation: strings.TrimSpace(answer.Explanation),
+		Parsed:      cmd,
+	}
+
+	t.logger.Info("Proposed TCOL command for request", mdwlog.Fields{
+		"inputLength": len(input),
+		"command":     proposal.Command,
+	})
+
+	return proposal, nil
+}
+
+// Schema returns the JSON description of the objects sent to the model:
+// all registered objects except the built-in ones
+func (t *Translator) Schema() (string, error) {
+	registry := t.engine.Registry()
+
+	var objects []*mdwregistry.ObjectDescription
+	for _, name := range registry.GetObjectNames() {
+		desc, err := registry.Describe(name)
+		if err != nil {
+			return "", err
+		}
+		if desc.Service != internalService {
+			objects = append(objects, desc)
+		}
+	}
+	if len(objects) == 0 {
+		return "", fmt.Errorf("no objects registered to translate requests into")
+	}
+
+	data, err := json.Marshal(objects)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return string(data), nil
+}
+
+// validate checks that every stage of a proposed command uses a registered
+// object and method that is not built in
+func (t *Translator) validate(cmd *mdwast.Command) error {
+	registry := t.engine.Registry()
+
+	for stage := cmd; stage != nil; stage = stage.Chain {
+		if stage.Transform != nil {
+			continue
+		}
+
+		if stage.Method != "" {
+			if err := registry.ValidateCommand(stage.Object, stage.Method); err != nil {
+				return err
+			}
+		}
+		obj, err := registry.GetObject(stage.Object)
+		if err != nil {
+			return err
+		}
+		if obj.Service == internalService {
+			return fmt.Errorf("built-in object %s cannot be proposed", obj.Name)
+		}
+	}
+	return nil
+}
+
+// reply is the answer expected from the model
+type reply struct {
+	Command     string `json:"command"`
+	Explanation string `json:"explanation"`
+}
+
+// parseReply extracts the JSON object of a model reply, which may be
+// wrapped in prose or a Markdown code fence
+func parseReply(text string) (*reply, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in reply")
+	}
+	var answer reply
+	if err := json.Unmarshal([]byte(text[start:end+1]), &answer); err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
@@ -0,0 +1,156 @@
+// File: translate_test.go
+// Title: Natural Language to TCOL Translation Tests
+// Description: Tests the schema sent to the model, the parsing of replies,
+//              and the validation of proposed commands.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial translation tests
+
+package translate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/msto63/mDW/foundation/tcol"
+	mdwexecutor "github.com/msto63/mDW/foundation/tcol/executor"
+	mdwregistry "github.com/msto63/mDW/foundation/tcol/registry"
+)
+
+// fakeTuring answers every prompt with a fixed reply and records the prompts
+type fakeTuring struct {
+	reply   string
+	err     error
+	prompts []string
+}
+
+func (f *fakeTuring) Chat(ctx context.Context, systemPrompt, prompt string) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	return f.reply, f.err
+}
+
+// failingClient fails the test if a command is executed
+type failingClient struct{ t *testing.T }
+
+func (c failingClient) Execute(ctx context.Context, serviceName, objectName, methodName string,
+	params map[string]interface{}, execCtx *mdwexecutor.ExecutionContext) (*mdwexecutor.ServiceResponse, error) {
+	c.t.Errorf("command %s.%s was executed", objectName, methodName)
+	return &mdwexecutor.ServiceResponse{Success: true}, nil
+}
+
+func (c failingClient) Health(ctx context.Context, serviceName string) error { return nil }
+func (c failingClient) Close() error                                         { return nil }
+
+func newTranslator(t *testing.T, turing *fakeTuring) *Translator {
+	engine, err := tcol.NewEngine(tcol.Options{ServiceClient: failingClient{t}, EnableChaining: true})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	engine.Registry().RegisterObject(&mdwregistry.ObjectDefinition{
+		Name:        "INVOICE",
+		Description: "Customer invoices",
+		Service:     "invoice-service",
+		Methods: map[string]*mdwregistry.MethodDefinition{
+			"LIST": {Name: "LIST", Parameters: map[string]*mdwregistry.ParameterDefinition{
+				"status": {Name: "status", Type: "string", Values: []string{"open", "paid"}},
+			}},
+		},
+	})
+
+	translator, err := New(engine, Options{Client: turing})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return translator
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	turing := &fakeTuring{reply: "Here you go:\n```json\n" +
+		`{"command": "INVOICE[total > 1000].LIST status=open | SORT BY total DESC", "explanation": "Lists open invoices over 1000."}` +
+		"\n```"}
+	translator := newTranslator(t, turing)
+
+	proposal, err := translator.Translate(context.Background(), "  open invoices over 1000, largest first ")
+	if err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if proposal.Command != `INVOICE[(total > 1000)].LIST status="open" | SORT BY total DESC` {
+		t.Errorf("Command = %s", proposal.Command)
+	}
+	if proposal.Input != "open invoices over 1000, largest first" || proposal.Explanation != "Lists open invoices over 1000." ||
+		proposal.Parsed == nil || proposal.Parsed.Object != "INVOICE" {
+		t.Errorf("proposal = %+v", proposal)
+	}
+
+	// The schema of business objects and the request go to the model
+	prompt := turing.prompts[0]
+	if !strings.Contains(prompt, `"name":"INVOICE"`) || !strings.Contains(prompt, `"values":["open","paid"]`) ||
+		!strings.HasSuffix(prompt, "open invoices over 1000, largest first") {
+		t.Errorf("prompt = %s", prompt)
+	}
+	if strings.Contains(prompt, `"name":"ALIAS"`) {
+		t.Errorf("prompt describes built-in objects: %s", prompt)
+	}
+}
+
+func TestTranslator_TranslateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		turing   *fakeTuring
+		expected string
+	}{
+		{"empty request", "  ", &fakeTuring{}, "request is empty"},
+		{"chat failure", "invoices", &fakeTuring{err: errors.New("unavailable")}, "Turing chat request failed"},
+		{"prose reply", "invoices", &fakeTuring{reply: "INVOICE.LIST"}, "no JSON object"},
+		{"no command", "order pizza", &fakeTuring{reply: `{"command": "", "explanation": "There is no object for food orders."}`}, "no object for food orders"},
+		{"syntax error", "invoices", &fakeTuring{reply: `{"command": "INVOICE.LIST status="}`}, "is invalid"},
+		{"unknown object", "orders", &fakeTuring{reply: `{"command": "ORDER.LIST"}`}, "ORDER"},
+		{"unknown method", "delete invoices", &fakeTuring{reply: `{"command": "INVOICE.DELETE"}`}, "DELETE"},
+		{"built-in object", "drop alias", &fakeTuring{reply: `{"command": "ALIAS.DELETE name=\"x\""}`}, "built-in object ALIAS"},
+		{"built-in stage", "invoices", &fakeTuring{reply: `{"command": "INVOICE.LIST | ALIAS.LIST"}`}, "built-in object ALIAS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTranslator(t, tt.turing).Translate(context.Background(), tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Translate() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+
+	_, err := newTranslator(t, &fakeTuring{reply: `{"command": ""}`}).Translate(context.Background(), "hello")
+	if !errors.Is(err, ErrNoTranslation) {
+		t.Errorf("Translate() error = %v, want ErrNoTranslation", err)
+	}
+	long := strings.Repeat("x", DefaultMaxInputLength+1)
+	if _, err := newTranslator(t, &fakeTuring{}).Translate(context.Background(), long); err == nil {
+		t.Error("Translate() of an overlong request did not fail")
+	}
+}
+
+func TestNew(t *testing.T) {
+	engine, err := tcol.NewEngine(tcol.Options{ServiceClient: failingClient{t}})
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	if _, err := New(nil, Options{Client: &fakeTuring{}}); err == nil {
+		t.Error("New() without engine did not fail")
+	}
+	if _, err := New(engine, Options{}); err == nil {
+		t.Error("New() without client did not fail")
+	}
+
+	// Only built-in objects leave nothing to translate into
+	translator, _ := New(engine, Options{Client: &fakeTuring{}})
+	if _, err := translator.Schema(); err == nil {
+		t.Error("Schema() without business objects did not fail")
+	}
+}
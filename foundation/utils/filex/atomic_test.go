@@ -0,0 +1,183 @@
+// File: atomic_test.go
+// Title: Atomic File Write Tests
+// Description: Tests for WriteFileAtomic, WriteAtomicFunc, and CopyAtomic
+//              covering replacement, permissions, failure cleanup, and
+//              concurrent writers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial atomic write tests
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+
+	if err := WriteFileAtomic(path, []byte("version = 1\n"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	if err := WriteStringAtomic(path, "version = 2\n", 0600); err != nil {
+		t.Fatalf("WriteStringAtomic() error = %v", err)
+	}
+
+	content, err := ReadString(path)
+	if err != nil {
+		t.Fatalf("ReadString() error = %v", err)
+	}
+	if content != "version = 2\n" {
+		t.Errorf("content = %q, want %q", content, "version = 2\n")
+	}
+
+	if runtime.GOOS != "windows" {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+		}
+	}
+
+	assertNoTempFiles(t, dir)
+}
+
+func TestWriteAtomicFunc_FailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.txt")
+	if err := WriteString(path, "original", 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writeErr := errors.New("disk on fire")
+	err := WriteAtomicFunc(path, 0644, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return writeErr
+	})
+	if !errors.Is(err, writeErr) {
+		t.Fatalf("WriteAtomicFunc() error = %v, want %v", err, writeErr)
+	}
+
+	content, _ := ReadString(path)
+	if content != "original" {
+		t.Errorf("content = %q, want original content", content)
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestWriteFileAtomic_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "file.txt")
+	if err := WriteFileAtomic(path, []byte("x"), 0644); err == nil {
+		t.Error("WriteFileAtomic() expected error for missing directory")
+	}
+}
+
+func TestWriteFileAtomic_ConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shared.txt")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			content := strings.Repeat(fmt.Sprintf("%d", i), 4096)
+			if err := WriteStringAtomic(path, content, 0644); err != nil {
+				t.Errorf("writer %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// The result must be exactly one writer's complete content
+	content, err := ReadString(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content) != 4096 || strings.Count(content, content[:1]) != 4096 {
+		t.Errorf("torn write detected: length %d", len(content))
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestCopyAtomic(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "nested", "dst.txt")
+
+	if err := WriteString(src, "payload", 0640); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(src, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyAtomic(src, dst); err != nil {
+		t.Fatalf("CopyAtomic() error = %v", err)
+	}
+
+	content, _ := ReadString(dst)
+	if content != "payload" {
+		t.Errorf("content = %q, want payload", content)
+	}
+	info, _ := os.Stat(dst)
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("mod time = %v, want %v", info.ModTime(), modTime)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+
+	// Overwrite is disabled by default
+	if err := CopyAtomic(src, dst); err == nil {
+		t.Error("CopyAtomic() expected error when destination exists")
+	}
+
+	opts := DefaultCopyOptions()
+	opts.OverwriteTarget = true
+	if err := WriteString(src, "updated", 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyAtomic(src, dst, opts); err != nil {
+		t.Fatalf("CopyAtomic() with overwrite error = %v", err)
+	}
+	content, _ = ReadString(dst)
+	if content != "updated" {
+		t.Errorf("content = %q, want updated", content)
+	}
+
+	if err := CopyAtomic(filepath.Join(dir, "missing"), dst, opts); err == nil {
+		t.Error("CopyAtomic() expected error for missing source")
+	}
+	if err := CopyAtomic(dir, dst, opts); err == nil {
+		t.Error("CopyAtomic() expected error for directory source")
+	}
+}
+
+// assertNoTempFiles fails if atomic write temporaries were left behind
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file left behind: %s", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// File: cleanup.go
+// Title: Directory Cleanup Policies
+// Description: Implements retention-based housekeeping for directories such as
+//              log and data stores. A CleanupPolicy combines maximum file age,
+//              maximum total size, and keep-N-newest rules and is applied with
+//              CleanDir, optionally as a dry run.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of CleanupPolicy and CleanDir
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ===============================
+// Cleanup Policies
+// ===============================
+
+// CleanupPolicy describes which files in a directory may be removed.
+// Files are considered newest first: the KeepNewest newest files are always
+// kept, older files are removed once they exceed MaxAge, and remaining files
+// are removed oldest first until the total size is within MaxTotalSize.
+// Zero values disable the corresponding rule.
+type CleanupPolicy struct {
+	MaxAge          time.Duration    // Remove files older than this
+	MaxTotalSize    int64            // Remove oldest files until total size fits
+	KeepNewest      int              // Always keep this many newest files
+	Pattern         string           // Glob pattern on file names (empty = all files)
+	Recursive       bool             // Include files in subdirectories
+	RemoveEmptyDirs bool             // Remove subdirectories left empty (recursive only)
+	DryRun          bool             // Report what would be removed without removing
+	Now             func() time.Time // Clock used for age calculation (default: time.Now)
+}
+
+// CleanupResult reports the outcome of applying a cleanup policy
+type CleanupResult struct {
+	Removed     []FileInfo // Files removed (or that would be removed in dry-run mode)
+	RemovedDirs []string   // Empty directories removed
+	FreedBytes  int64      // Total size of removed files
+	KeptFiles   int        // Number of files kept
+	KeptBytes   int64      // Total size of kept files
+	Errors      []error    // Removal failures; cleanup continues past them
+}
+
+// CleanDir applies the policy to dir. Individual removal failures are collected
+// in the result instead of aborting the cleanup.
+func CleanDir(dir string, policy CleanupPolicy) (*CleanupResult, error) {
+	if policy.MaxAge < 0 || policy.MaxTotalSize < 0 || policy.KeepNewest < 0 {
+		return nil, errors.New("cleanup policy values cannot be negative")
+	}
+	if policy.Pattern != "" {
+		if _, err := filepath.Match(policy.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid cleanup pattern %q: %w", policy.Pattern, err)
+		}
+	}
+	if !IsDir(dir) {
+		return nil, fmt.Errorf("cleanup path %s is not a directory", dir)
+	}
+
+	now := time.Now
+	if policy.Now != nil {
+		now = policy.Now
+	}
+
+	files, dirs, err := collectCleanupCandidates(dir, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first; ties broken by path for deterministic results
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].ModTime.Equal(files[j].ModTime) {
+			return files[i].ModTime.After(files[j].ModTime)
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	remove := make([]bool, len(files))
+	cutoff := now().Add(-policy.MaxAge)
+
+	var keptSize int64
+	for i, file := range files {
+		if i >= policy.KeepNewest && policy.MaxAge > 0 && file.ModTime.Before(cutoff) {
+			remove[i] = true
+			continue
+		}
+		keptSize += file.Size
+	}
+
+	// Enforce the size budget by dropping the oldest unprotected files
+	if policy.MaxTotalSize > 0 {
+		for i := len(files) - 1; i >= policy.KeepNewest && keptSize > policy.MaxTotalSize; i-- {
+			if !remove[i] {
+				remove[i] = true
+				keptSize -= files[i].Size
+			}
+		}
+	}
+
+	result := &CleanupResult{}
+	for i, file := range files {
+		if !remove[i] {
+			result.KeptFiles++
+			result.KeptBytes += file.Size
+			continue
+		}
+
+		if !policy.DryRun {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				result.Errors = append(result.Errors, fmt.Errorf("failed to remove %s: %w", file.Path, err))
+				result.KeptFiles++
+				result.KeptBytes += file.Size
+				continue
+			}
+		}
+		result.Removed = append(result.Removed, file)
+		result.FreedBytes += file.Size
+	}
+
+	if policy.RemoveEmptyDirs && policy.Recursive && !policy.DryRun {
+		// Deepest directories first so parents can become empty
+		sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+		for _, d := range dirs {
+			if entries, err := os.ReadDir(d); err == nil && len(entries) == 0 {
+				if err := os.Remove(d); err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("failed to remove directory %s: %w", d, err))
+					continue
+				}
+				result.RemovedDirs = append(result.RemovedDirs, d)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// collectCleanupCandidates lists the regular files matching the policy and,
+// for recursive policies, the subdirectories below dir
+func collectCleanupCandidates(dir string, policy CleanupPolicy) ([]FileInfo, []string, error) {
+	var files []FileInfo
+	var dirs []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if d.IsDir() {
+			if !policy.Recursive {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil // Leave symlinks and special files alone
+		}
+		if policy.Pattern != "" {
+			if ok, _ := filepath.Match(policy.Pattern, d.Name()); !ok {
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		files = append(files, newFileInfo(path, info))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan %s for cleanup: %w", dir, err)
+	}
+
+	return files, dirs, nil
+}
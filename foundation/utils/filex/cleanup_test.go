@@ -0,0 +1,204 @@
+// File: cleanup_test.go
+// Title: Directory Cleanup Policy Tests
+// Description: Tests for CleanDir covering age, size, and keep-newest rules,
+//              pattern filtering, recursion, empty directory removal, and
+//              dry-run mode.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial cleanup policy tests
+
+package filex
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cleanupNow is the fixed clock used by cleanup tests
+var cleanupNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+// createAgedFile writes a file of the given size with a modification time
+// the given number of days before cleanupNow
+func createAgedFile(t *testing.T, path string, size int, ageDays int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := cleanupNow.Add(-time.Duration(ageDays) * 24 * time.Hour)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// removedNames returns the sorted base names of removed files
+func removedNames(result *CleanupResult) []string {
+	var names []string
+	for _, file := range result.Removed {
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCleanDir(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      CleanupPolicy
+		wantRemoved []string
+	}{
+		{
+			name:        "max age",
+			policy:      CleanupPolicy{MaxAge: 5 * 24 * time.Hour},
+			wantRemoved: []string{"day10.log", "day30.log"},
+		},
+		{
+			name:        "max age with keep newest",
+			policy:      CleanupPolicy{MaxAge: 24 * time.Hour, KeepNewest: 3},
+			wantRemoved: []string{"day10.log", "day30.log", "day5.log"},
+		},
+		{
+			name:        "max total size",
+			policy:      CleanupPolicy{MaxTotalSize: 250},
+			wantRemoved: []string{"day10.log", "day30.log", "day5.log"},
+		},
+		{
+			name:        "size budget respects keep newest",
+			policy:      CleanupPolicy{MaxTotalSize: 50, KeepNewest: 2},
+			wantRemoved: []string{"day10.log", "day30.log", "day5.log", "scratch.tmp"},
+		},
+		{
+			name:        "pattern",
+			policy:      CleanupPolicy{MaxAge: time.Hour, Pattern: "*.tmp"},
+			wantRemoved: []string{"scratch.tmp"},
+		},
+		{
+			name:        "no rules keeps everything",
+			policy:      CleanupPolicy{},
+			wantRemoved: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			createAgedFile(t, filepath.Join(dir, "day0.log"), 100, 0)
+			createAgedFile(t, filepath.Join(dir, "day1.log"), 100, 1)
+			createAgedFile(t, filepath.Join(dir, "day5.log"), 100, 5)
+			createAgedFile(t, filepath.Join(dir, "day10.log"), 100, 10)
+			createAgedFile(t, filepath.Join(dir, "day30.log"), 100, 30)
+			createAgedFile(t, filepath.Join(dir, "scratch.tmp"), 10, 2)
+
+			policy := tt.policy
+			policy.Now = func() time.Time { return cleanupNow }
+
+			result, err := CleanDir(dir, policy)
+			if err != nil {
+				t.Fatalf("CleanDir() error = %v", err)
+			}
+
+			got := removedNames(result)
+			if strings.Join(got, ",") != strings.Join(tt.wantRemoved, ",") {
+				t.Errorf("CleanDir() removed %v, want %v", got, tt.wantRemoved)
+			}
+			for _, file := range result.Removed {
+				if Exists(file.Path) {
+					t.Errorf("%s still exists after cleanup", file.Name)
+				}
+			}
+			if policy.Pattern != "" {
+				return // Only matching files are accounted for
+			}
+			if result.FreedBytes+result.KeptBytes != 510 {
+				t.Errorf("FreedBytes + KeptBytes = %d, want 510", result.FreedBytes+result.KeptBytes)
+			}
+			if len(result.Removed)+result.KeptFiles != 6 {
+				t.Errorf("removed + kept = %d, want 6", len(result.Removed)+result.KeptFiles)
+			}
+		})
+	}
+}
+
+func TestCleanDir_RecursiveAndDryRun(t *testing.T) {
+	dir := t.TempDir()
+	createAgedFile(t, filepath.Join(dir, "current.log"), 10, 0)
+	createAgedFile(t, filepath.Join(dir, "2026", "09", "old.log"), 10, 40)
+	createAgedFile(t, filepath.Join(dir, "2026", "10", "recent.log"), 10, 2)
+
+	policy := CleanupPolicy{
+		MaxAge:          30 * 24 * time.Hour,
+		Recursive:       true,
+		RemoveEmptyDirs: true,
+		DryRun:          true,
+		Now:             func() time.Time { return cleanupNow },
+	}
+
+	result, err := CleanDir(dir, policy)
+	if err != nil {
+		t.Fatalf("CleanDir() dry run error = %v", err)
+	}
+	if names := removedNames(result); len(names) != 1 || names[0] != "old.log" {
+		t.Errorf("dry run would remove %v, want [old.log]", names)
+	}
+	if !Exists(filepath.Join(dir, "2026", "09", "old.log")) {
+		t.Fatal("dry run removed a file")
+	}
+
+	policy.DryRun = false
+	result, err = CleanDir(dir, policy)
+	if err != nil {
+		t.Fatalf("CleanDir() error = %v", err)
+	}
+	if Exists(filepath.Join(dir, "2026", "09")) {
+		t.Error("empty directory 2026/09 was not removed")
+	}
+	if !Exists(filepath.Join(dir, "2026", "10", "recent.log")) {
+		t.Error("recent.log was removed")
+	}
+	if len(result.RemovedDirs) != 1 {
+		t.Errorf("RemovedDirs = %v, want one entry", result.RemovedDirs)
+	}
+
+	// Non-recursive cleanup ignores subdirectories
+	createAgedFile(t, filepath.Join(dir, "sub", "ancient.log"), 10, 400)
+	result, err = CleanDir(dir, CleanupPolicy{MaxAge: time.Hour, Now: func() time.Time { return cleanupNow }})
+	if err != nil {
+		t.Fatalf("CleanDir() non-recursive error = %v", err)
+	}
+	if !Exists(filepath.Join(dir, "sub", "ancient.log")) {
+		t.Error("non-recursive cleanup removed a file in a subdirectory")
+	}
+}
+
+func TestCleanDir_InvalidArguments(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		dir    string
+		policy CleanupPolicy
+	}{
+		{"negative age", dir, CleanupPolicy{MaxAge: -time.Hour}},
+		{"negative keep", dir, CleanupPolicy{KeepNewest: -1}},
+		{"bad pattern", dir, CleanupPolicy{Pattern: "[abc"}},
+		{"missing directory", filepath.Join(dir, "missing"), CleanupPolicy{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CleanDir(tt.dir, tt.policy); err == nil {
+				t.Error("CleanDir() should fail")
+			}
+		})
+	}
+}
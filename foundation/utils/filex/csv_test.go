@@ -0,0 +1,240 @@
+// File: csv_test.go
+// Title: Structured CSV Reading and Writing Tests
+// Description: Tests for CSV reading into maps and structs, delimiter
+//              detection, header mapping, and writing round trips.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-15
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial CSV tests
+// - 2026-10-16 v0.1.1: Added EncodeCSV test
+
+package filex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample string
+		want   rune
+	}{
+		{"comma", "a,b,c\n1,2,3\n", ','},
+		{"semicolon", "name;city\n\"Doe, John\";Berlin\n", ';'},
+		{"tab", "a\tb\n1\t2\n", '\t'},
+		{"pipe", "a|b|c\n1|2|3\n", '|'},
+		{"quoted delimiters ignored", "\"a;b\",c\n\"1;2\",3\n", ','},
+		{"single column", "value\n1\n", ','},
+		{"empty", "", ','},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectCSVDelimiter([]byte(tt.sample)); got != tt.want {
+				t.Errorf("DetectCSVDelimiter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    []CSVOptions
+		want    []map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "header with detected semicolon and BOM",
+			content: "\uFEFFname; email\nAlice ; alice@example.com\nBob;bob@example.com\n",
+			want: []map[string]string{
+				{"name": "Alice", "email": "alice@example.com"},
+				{"name": "Bob", "email": "bob@example.com"},
+			},
+		},
+		{
+			name:    "short rows are padded",
+			content: "a,b,c\n1,2\n",
+			want:    []map[string]string{{"a": "1", "b": "2", "c": ""}},
+		},
+		{
+			name:    "long rows are rejected",
+			content: "a,b\n1,2,3\n",
+			wantErr: true,
+		},
+		{
+			name:    "header mapping",
+			content: "E-Mail,Vorname\nx@example.com,Max\n",
+			opts: []CSVOptions{{
+				HasHeader: true,
+				HeaderMap: map[string]string{"E-Mail": "email", "Vorname": "first_name"},
+			}},
+			want: []map[string]string{{"email": "x@example.com", "first_name": "Max"}},
+		},
+		{
+			name:    "explicit headers without header row",
+			content: "1|x\n# comment\n2|y\n",
+			opts:    []CSVOptions{{Delimiter: '|', Comment: '#', Headers: []string{"id", "code"}}},
+			want: []map[string]string{
+				{"id": "1", "code": "x"},
+				{"id": "2", "code": "y"},
+			},
+		},
+		{
+			name:    "positional columns",
+			content: "1,2\n",
+			opts:    []CSVOptions{{}},
+			want:    []map[string]string{{"column1": "1", "column2": "2"}},
+		},
+		{
+			name:    "header only",
+			content: "a,b\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.csv")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadCSV(path, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessCSV_Stop(t *testing.T) {
+	var rows []int
+	err := ProcessCSVReader(strings.NewReader("n\n1\n2\n3\n"), func(rowNum int, _ map[string]string) error {
+		rows = append(rows, rowNum)
+		if rowNum == 2 {
+			return ErrStopProcessing
+		}
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(rows, []int{1, 2}) {
+		t.Errorf("ProcessCSVReader() rows = %v, error = %v", rows, err)
+	}
+
+	boom := errors.New("boom")
+	err = ProcessCSVReader(strings.NewReader("n\n1\n"), func(int, map[string]string) error { return boom })
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ProcessCSVReader() error = %v, want wrapped boom with line", err)
+	}
+}
+
+type csvCustomer struct {
+	ID       int           `csv:"id"`
+	Name     string        `csv:"name"`
+	Active   bool          `csv:"active"`
+	Balance  float64       `csv:"balance"`
+	Since    time.Time     `csv:"since"`
+	Timeout  time.Duration `csv:"timeout"`
+	Referrer *string       `csv:"referrer"`
+	Internal string        `csv:"-"`
+}
+
+func TestCSVStructs_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "customers.csv")
+	ref := "web"
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	customers := []csvCustomer{
+		{ID: 1, Name: "Doe, Jane", Active: true, Balance: 12.5, Since: since, Timeout: 30 * time.Second, Referrer: &ref, Internal: "x"},
+		{ID: 2, Name: "Roe", Since: since},
+	}
+
+	if err := WriteCSVFrom(path, customers); err != nil {
+		t.Fatalf("WriteCSVFrom() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(content), "id,name,active,balance,since,timeout,referrer\n") {
+		t.Errorf("header = %q", strings.SplitN(string(content), "\n", 2)[0])
+	}
+
+	var got []csvCustomer
+	if err := ReadCSVInto(path, &got); err != nil {
+		t.Fatalf("ReadCSVInto() error = %v", err)
+	}
+	customers[0].Internal = ""
+	if len(got) != 2 || *got[0].Referrer != "web" || got[1].Referrer != nil {
+		t.Fatalf("ReadCSVInto() = %+v", got)
+	}
+	got[0].Referrer, customers[0].Referrer = nil, nil
+	if !reflect.DeepEqual(got, customers) {
+		t.Errorf("ReadCSVInto() = %+v, want %+v", got, customers)
+	}
+
+	var ptrs []*csvCustomer
+	if err := ReadCSVInto(path, &ptrs); err != nil || len(ptrs) != 2 || ptrs[1].Name != "Roe" {
+		t.Errorf("ReadCSVInto() pointers = %v, %v", ptrs, err)
+	}
+
+	var bad []csvCustomer
+	os.WriteFile(path, []byte("id\nnot-a-number\n"), 0644)
+	if err := ReadCSVInto(path, &bad); err == nil || !strings.Contains(err.Error(), "column id") {
+		t.Errorf("ReadCSVInto() invalid value error = %v", err)
+	}
+	if err := ReadCSVInto(path, bad); err == nil {
+		t.Error("ReadCSVInto() with non-pointer should fail")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	records := []map[string]string{
+		{"a": "1", "b": "x;y"},
+		{"a": "2"},
+	}
+	opts := DefaultCSVOptions()
+	opts.Delimiter = ';'
+
+	if err := WriteCSV(path, []string{"a", "b"}, records, opts); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if want := "a;b\n1;\"x;y\"\n2;\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	got, err := ReadCSV(path)
+	if err != nil || got[0]["b"] != "x;y" || got[1]["b"] != "" {
+		t.Errorf("ReadCSV() round trip = %v, %v", got, err)
+	}
+
+	if err := WriteCSV(path, nil, records); err == nil {
+		t.Error("WriteCSV() without headers should fail")
+	}
+}
+
+func TestEncodeCSV(t *testing.T) {
+	var out strings.Builder
+	opts := DefaultCSVOptions()
+	opts.HasHeader = false
+	opts.UseCRLF = true
+
+	if err := EncodeCSV(&out, []string{"name", "city"}, []map[string]string{{"name": "Acme", "city": "Berlin"}}, opts); err != nil {
+		t.Fatalf("EncodeCSV() error = %v", err)
+	}
+	if want := "Acme,Berlin\r\n"; out.String() != want {
+		t.Errorf("EncodeCSV() = %q, want %q", out.String(), want)
+	}
+}
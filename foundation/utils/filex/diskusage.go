@@ -0,0 +1,198 @@
+// File: diskusage.go
+// Title: Disk Usage Analysis
+// Description: Implements file system capacity queries and directory tree size
+//              reports with top-N largest entries, used for housekeeping of
+//              log directories and data stores.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of DiskUsage and DirTree
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ErrDiskUsageUnsupported is returned on platforms without capacity queries
+var ErrDiskUsageUnsupported = errors.New("disk usage is not supported on this platform")
+
+// ===============================
+// Disk Usage Analysis
+// ===============================
+
+// DiskUsageInfo describes the capacity of the file system containing a path
+type DiskUsageInfo struct {
+	Path  string // Queried path
+	Total uint64 // Total capacity in bytes
+	Free  uint64 // Bytes available to unprivileged users
+	Used  uint64 // Bytes in use (Total minus all free blocks)
+}
+
+// UsedPercent returns the used capacity as a percentage of the total
+func (d DiskUsageInfo) UsedPercent() float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	return float64(d.Used) / float64(d.Total) * 100
+}
+
+// DiskUsage returns capacity information for the file system containing path
+func DiskUsage(path string) (DiskUsageInfo, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return DiskUsageInfo{}, fmt.Errorf("failed to resolve path %s: %w", path, err)
+	}
+
+	usage, err := diskUsage(absPath)
+	if err != nil {
+		return DiskUsageInfo{}, fmt.Errorf("failed to get disk usage for %s: %w", path, err)
+	}
+	usage.Path = absPath
+
+	return usage, nil
+}
+
+// DirTreeEntry is an immediate child of a reported directory
+type DirTreeEntry struct {
+	FileInfo
+	TotalSize int64 // Recursive size for directories, file size otherwise
+	FileCount int   // Number of files contained (1 for files)
+}
+
+// DirTreeReport summarizes the size of a directory tree
+type DirTreeReport struct {
+	Path         string         // Absolute path of the root
+	TotalSize    int64          // Sum of all file sizes
+	FileCount    int            // Number of regular files
+	DirCount     int            // Number of subdirectories
+	Entries      []DirTreeEntry // Largest immediate children, by TotalSize descending
+	LargestFiles []FileInfo     // Largest files anywhere in the tree, descending
+}
+
+// DirTree walks path and reports its total size along with the topN largest
+// immediate children and the topN largest files. A topN of 0 or less reports
+// all entries. Symbolic links are not followed.
+func DirTree(path string, topN int) (*DirTreeReport, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %w", path, err)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("path %s is not a directory", path)
+	}
+
+	report := &DirTreeReport{Path: root}
+	children := make(map[string]*DirTreeEntry)
+	var files []FileInfo
+
+	err = filepath.WalkDir(root, func(current string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if current == root {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // Removed while walking
+			}
+			return err
+		}
+
+		// Attribute the entry to its top-level child
+		rel, _ := filepath.Rel(root, current)
+		top := rel
+		for dir := filepath.Dir(top); dir != "."; dir = filepath.Dir(top) {
+			top = dir
+		}
+		child, exists := children[top]
+		if !exists {
+			child = &DirTreeEntry{}
+			children[top] = child
+		}
+
+		if d.IsDir() {
+			report.DirCount++
+			if rel == top {
+				child.FileInfo = newFileInfo(current, info)
+			}
+			return nil
+		}
+
+		fileInfo := newFileInfo(current, info)
+		if rel == top {
+			child.FileInfo = fileInfo
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		report.TotalSize += info.Size()
+		report.FileCount++
+		child.TotalSize += info.Size()
+		child.FileCount++
+		files = append(files, fileInfo)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze directory %s: %w", path, err)
+	}
+
+	for _, child := range children {
+		report.Entries = append(report.Entries, *child)
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		if report.Entries[i].TotalSize != report.Entries[j].TotalSize {
+			return report.Entries[i].TotalSize > report.Entries[j].TotalSize
+		}
+		return report.Entries[i].Name < report.Entries[j].Name
+	})
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Size != files[j].Size {
+			return files[i].Size > files[j].Size
+		}
+		return files[i].Path < files[j].Path
+	})
+	report.LargestFiles = files
+
+	if topN > 0 {
+		if len(report.Entries) > topN {
+			report.Entries = report.Entries[:topN]
+		}
+		if len(report.LargestFiles) > topN {
+			report.LargestFiles = report.LargestFiles[:topN]
+		}
+	}
+
+	return report, nil
+}
+
+// newFileInfo builds a FileInfo from os.FileInfo without MIME detection
+func newFileInfo(path string, info os.FileInfo) FileInfo {
+	return FileInfo{
+		Name:    info.Name(),
+		Path:    path,
+		Size:    info.Size(),
+		Mode:    info.Mode(),
+		ModTime: info.ModTime(),
+		IsDir:   info.IsDir(),
+		Ext:     filepath.Ext(path),
+	}
+}
@@ -0,0 +1,20 @@
+// File: diskusage_other.go
+// Title: Disk Usage (Unsupported Platforms)
+// Description: Fallback disk usage query for platforms without statfs or
+//              GetDiskFreeSpaceExW support.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial fallback implementation
+
+//go:build !(darwin || dragonfly || freebsd || linux || windows)
+
+package filex
+
+// diskUsage reports that capacity queries are unavailable
+func diskUsage(path string) (DiskUsageInfo, error) {
+	return DiskUsageInfo{}, ErrDiskUsageUnsupported
+}
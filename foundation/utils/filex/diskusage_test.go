@@ -0,0 +1,131 @@
+// File: diskusage_test.go
+// Title: Disk Usage Analysis Tests
+// Description: Tests for DiskUsage capacity queries and DirTree size reports
+//              including top-N truncation and per-child aggregation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial disk usage tests
+
+package filex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiskUsage(t *testing.T) {
+	usage, err := DiskUsage(t.TempDir())
+	if errors.Is(err, ErrDiskUsageUnsupported) {
+		t.Skip("disk usage not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("DiskUsage() error = %v", err)
+	}
+
+	if usage.Total == 0 {
+		t.Error("DiskUsage() Total = 0, want > 0")
+	}
+	if usage.Free > usage.Total || usage.Used > usage.Total {
+		t.Errorf("DiskUsage() inconsistent values: %+v", usage)
+	}
+	if pct := usage.UsedPercent(); pct < 0 || pct > 100 {
+		t.Errorf("UsedPercent() = %f, want 0..100", pct)
+	}
+	if !filepath.IsAbs(usage.Path) {
+		t.Errorf("DiskUsage() Path = %q, want absolute", usage.Path)
+	}
+
+	if _, err := DiskUsage(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("DiskUsage() on missing path should fail")
+	}
+}
+
+func TestDirTree(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{
+		"small.txt":                 10,
+		"logs/app.log":              300,
+		"logs/archive/app.1.log":    500,
+		"vectors/index.bin":         1000,
+		"vectors/shards/shard0.bin": 50,
+		"empty/.keep":               0,
+	}
+	for name, size := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := DirTree(dir, 0)
+	if err != nil {
+		t.Fatalf("DirTree() error = %v", err)
+	}
+
+	if report.TotalSize != 1860 || report.FileCount != 6 || report.DirCount != 5 {
+		t.Errorf("DirTree() totals = size %d, files %d, dirs %d; want 1860, 6, 5",
+			report.TotalSize, report.FileCount, report.DirCount)
+	}
+
+	wantEntries := []struct {
+		name  string
+		size  int64
+		files int
+	}{
+		{"vectors", 1050, 2},
+		{"logs", 800, 2},
+		{"small.txt", 10, 1},
+		{"empty", 0, 1},
+	}
+	if len(report.Entries) != len(wantEntries) {
+		t.Fatalf("DirTree() returned %d entries, want %d", len(report.Entries), len(wantEntries))
+	}
+	for i, want := range wantEntries {
+		got := report.Entries[i]
+		if got.Name != want.name || got.TotalSize != want.size || got.FileCount != want.files {
+			t.Errorf("entry %d = {%s %d %d}, want {%s %d %d}", i, got.Name, got.TotalSize, got.FileCount, want.name, want.size, want.files)
+		}
+	}
+	if !report.Entries[0].IsDir || report.Entries[2].IsDir {
+		t.Error("DirTree() entries have wrong IsDir flags")
+	}
+
+	top, err := DirTree(dir, 2)
+	if err != nil {
+		t.Fatalf("DirTree(topN=2) error = %v", err)
+	}
+	if len(top.Entries) != 2 || len(top.LargestFiles) != 2 {
+		t.Fatalf("DirTree(topN=2) returned %d entries and %d files, want 2 and 2", len(top.Entries), len(top.LargestFiles))
+	}
+	if top.LargestFiles[0].Name != "index.bin" || top.LargestFiles[1].Name != "app.1.log" {
+		t.Errorf("LargestFiles = %s, %s; want index.bin, app.1.log", top.LargestFiles[0].Name, top.LargestFiles[1].Name)
+	}
+	if top.TotalSize != report.TotalSize {
+		t.Errorf("topN changed TotalSize: %d vs %d", top.TotalSize, report.TotalSize)
+	}
+}
+
+func TestDirTree_Errors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DirTree(file, 0); err == nil {
+		t.Error("DirTree() on a file should fail")
+	}
+	if _, err := DirTree(filepath.Join(dir, "missing"), 0); err == nil {
+		t.Error("DirTree() on a missing path should fail")
+	}
+}
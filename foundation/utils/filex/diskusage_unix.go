@@ -0,0 +1,34 @@
+// File: diskusage_unix.go
+// Title: Disk Usage (Unix)
+// Description: statfs(2) based implementation of the disk usage query.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial statfs implementation
+
+//go:build darwin || dragonfly || freebsd || linux
+
+package filex
+
+import "syscall"
+
+// diskUsage queries the file system containing path with statfs
+func diskUsage(path string) (DiskUsageInfo, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return DiskUsageInfo{}, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	total := uint64(stat.Blocks) * blockSize
+	free := uint64(stat.Bfree) * blockSize
+
+	return DiskUsageInfo{
+		Total: total,
+		Free:  uint64(stat.Bavail) * blockSize,
+		Used:  total - free,
+	}, nil
+}
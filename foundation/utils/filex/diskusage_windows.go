@@ -0,0 +1,46 @@
+// File: diskusage_windows.go
+// Title: Disk Usage (Windows)
+// Description: GetDiskFreeSpaceExW based implementation of the disk usage query.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial GetDiskFreeSpaceExW implementation
+
+//go:build windows
+
+package filex
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var procGetDiskFreeSpaceExW = kernel32.NewProc("GetDiskFreeSpaceExW")
+
+// diskUsage queries the volume containing path with GetDiskFreeSpaceExW
+func diskUsage(path string) (DiskUsageInfo, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return DiskUsageInfo{}, err
+	}
+
+	var freeAvailable, total, totalFree uint64
+	r1, _, err := procGetDiskFreeSpaceExW.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeAvailable)),
+		uintptr(unsafe.Pointer(&total)),
+		uintptr(unsafe.Pointer(&totalFree)),
+	)
+	if r1 == 0 {
+		return DiskUsageInfo{}, err
+	}
+
+	return DiskUsageInfo{
+		Total: total,
+		Free:  freeAvailable,
+		Used:  total - totalFree,
+	}, nil
+}
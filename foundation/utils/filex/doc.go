@@ -39,6 +39,28 @@
 //   - Support for various text encodings
 //   - Error handling for large files
 //
+// # Streaming Line Processing
+//
+// Bounded-memory processing of arbitrarily large text files:
+//   - ProcessLines/ProcessLinesReader: Stream lines through a callback
+//   - TransformFile: Stream-map lines from src to an atomically replaced dst
+//   - Configurable buffer size and maximum line length
+//   - Progress callbacks and context cancellation
+//   - ErrStopProcessing for early termination; optional BOM stripping
+//
+// # Structured Data (CSV, JSONL, and XLSX)
+//
+// Record-oriented import and export for data exchange files:
+//   - ReadCSV/ProcessCSV: Rows as map[string]string keyed by header
+//   - ReadCSVInto/WriteCSVFrom: Struct mapping via `csv:"column"` tags
+//   - WriteCSV: Atomic export with a fixed column order
+//   - EncodeCSV: The same export to any io.Writer
+//   - DetectCSVDelimiter: Comma, semicolon, tab, or pipe detection
+//   - CSVOptions: Header renaming, explicit headers, comments, trimming
+//   - ReadJSONL/ProcessJSONL: Streaming JSON Lines reading per record
+//   - AppendJSONL/WriteJSONL: Record appends and atomic rewrites
+//   - WriteXLSX/EncodeXLSX: Excel workbooks with a bold header row and numeric cells
+//
 // # File Writing Operations
 //
 // Safe and flexible file writing functions:
@@ -48,6 +70,70 @@
 //   - Atomic write operations for data integrity
 //   - Permission and ownership preservation
 //
+// # Atomic Writes and File Locking
+//
+// Crash-safe writes and cross-process coordination:
+//   - WriteFileAtomic/WriteStringAtomic: Temp file + fsync + rename replacement
+//   - WriteAtomicFunc: Atomic replacement with streamed content
+//   - CopyAtomic: Copy that never exposes a partially written destination
+//   - Lock/RLock: Blocking exclusive and shared advisory locks
+//   - TryLock/TryRLock: Non-blocking acquisition returning ErrLocked
+//   - LockContext/WithLock: Context-aware acquisition and scoped locking
+//   - flock on Unix, LockFileEx on Windows
+//
+// # Directory Watching
+//
+// Shared hot-reload support for configuration, locale, and pipeline files:
+//   - Watch: Polling watcher delivering WatchEvent values on a channel
+//   - Create/Modify/Delete/Rename events; renames detected via file identity
+//   - Recursive watching with include patterns and pruning ignore patterns
+//   - Per-path debounce windows coalescing bursts of writes into one event
+//
+// # Sandboxed Roots
+//
+// Chroot-like confinement for untrusted paths such as TCOL FILE.* arguments:
+//   - OpenRoot: Creates a Root bound to a base directory
+//   - Open/Create/OpenFile/ReadFile/WriteFile: File access inside the root
+//   - Stat/Lstat/List/MkdirAll/Remove: Metadata and directory operations
+//   - Symlink-aware resolution rejecting escapes with ErrPathEscapesRoot
+//   - openat with O_NOFOLLOW on Linux to detect links swapped in after resolution
+//
+// # Virtual File Systems
+//
+// io/fs integration for testable and embeddable file logic:
+//   - VFS: Writable file system interface extending fs.StatFS/ReadFileFS/ReadDirFS
+//   - OSFS: Host directory backed VFS, confined through a Root
+//   - MemFS: Thread-safe in-memory VFS for unit tests and ephemeral data
+//   - ExistsFS/ReadStringFS/ReadLinesFS/ProcessLinesFS/ListDirFS: Read helpers for any fs.FS
+//   - FindFilesFS/DirSizeFS/SHA256HashFS: Search, size, and hash helpers for any fs.FS
+//   - WriteStringFS/WriteLinesFS/AppendFileFS/CopyFS/CopyTreeFS: Write helpers for a VFS
+//
+// # Temporary Workspaces
+//
+// Isolated scratch directories for tool execution and document conversion:
+//   - NewWorkspace: Creates a contained temp directory with an optional size quota
+//   - WriteFile/Create/Path/Scan: Tracked writes and pickup of external tool output
+//   - ErrQuotaExceeded: Returned when a write would exceed the quota
+//   - Close/CleanupWorkspaces/CleanupOnSignal: Guaranteed removal on exit
+//   - SweepWorkspaces: Removal of leftovers from killed processes
+//
+// # Disk Usage and Cleanup
+//
+// Housekeeping for log directories and data stores:
+//   - DiskUsage: Total, free, and used capacity of the containing file system
+//   - DirTree: Recursive size report with top-N largest children and files
+//   - CleanupPolicy: Max age, max total size, and keep-N-newest retention rules
+//   - CleanDir: Applies a policy, with dry-run and empty directory removal
+//   - Rotator: io.WriteCloser rotating by size and interval, with gzip and retention
+//
+// # Secure Deletion and Permissions
+//
+// Hardening helpers for sensitive data and compliance scans:
+//   - Shred: Best-effort secure deletion by overwriting before removal
+//   - EnsurePermissions: Enforces mode and ownership, optionally recursively
+//   - PermissionReport: Changed, skipped, and failed entries; supports dry runs
+//   - IsWorldWritable/FindWorldWritable: Findings as PermissionFinding results
+//
 // # File Copy and Move Operations
 //
 // Advanced file copying and moving with options:
@@ -56,6 +142,17 @@
 //   - FileCopyOptions: Configurable copy behavior
 //   - Permission and timestamp preservation
 //   - Cross-filesystem support
+//   - CopyTree/MoveTree: Whole-tree transfers with atomic per-file writes
+//   - TreeOptions: Retries, resume by size and hash, exclude patterns, dry run
+//   - TreeResult: Per-file report listing failures instead of aborting
+//
+// # Directory Synchronization
+//
+// Reconciling directory trees with a structured change report:
+//   - Sync: Mirror, update-only, and two-way modes (SyncMode)
+//   - Delete propagation, including two-way deletions tracked in a state file
+//   - Exclude patterns, dry runs, and timestamp or content comparison
+//   - SyncReport/FormatSyncReport: Added, updated, deleted, and conflicting entries
 //
 // # Directory Operations
 //
@@ -427,6 +524,19 @@
 //   - No automatic execution of files
 //   - Validation of file operations before execution
 //
+// The package-level functions operate on host paths and perform no
+// containment. Paths from untrusted sources, in particular the arguments of
+// TCOL FILE.* commands, must be served through a Root:
+//   - Absolute names and ".." segments leaving the base are rejected
+//   - Symbolic links are resolved component by component and must stay inside
+//   - Link loops are bounded and reported as errors
+//   - On Linux every component is opened with openat and O_NOFOLLOW, so a
+//     link substituted between resolution and open fails with ErrPathEscapesRoot
+//   - On other platforms MkdirAll and the final open follow resolution without
+//     that guarantee; do not grant untrusted writers access to the base
+//     directory itself
+//   - List reports root-relative paths so host paths are not disclosed
+//
 // # Common Use Cases
 //
 // 1. Configuration File Management
@@ -448,19 +558,17 @@
 //
 // 2. Log File Rotation
 //
-//	// Check log file size and rotate if needed
-//	logPath := "app.log"
-//	info, _ := filex.GetFileInfo(logPath)
+//	// Rotate at 10MB and daily, keep a week of compressed files
+//	rotator, _ := filex.NewRotator("logs/app.log", filex.RotateOptions{
+//		MaxSize:    10 * 1024 * 1024,
+//		Interval:   24 * time.Hour,
+//		MaxBackups: 7,
+//		Compress:   true,
+//	})
+//	defer rotator.Close()
 //	
-//	if info.Size > 10*1024*1024 { // 10MB
-//		// Archive current log
-//		timestamp := time.Now().Format("20060102_150405")
-//		archivePath := fmt.Sprintf("logs/app_%s.log", timestamp)
-//		filex.Move(logPath, archivePath)
-//		
-//		// Create new log file
-//		filex.Touch(logPath)
-//	}
+//	// Rotated files are named logs/app-20261016T000000.000.log.gz
+//	fmt.Fprintln(rotator, "service started")
 //
 // 3. Batch File Processing
 //
@@ -468,11 +576,11 @@
 //	csvFiles, _ := filex.FindFiles("data", "*.csv")
 //	
 //	for _, file := range csvFiles {
-//		lines, _ := filex.ReadLines(file)
-//		processed := processCSVLines(lines)
+//		rows, _ := filex.ReadCSV(file)
+//		processed := processCSVRows(rows)
 //		
 //		outputPath := strings.Replace(file, ".csv", "_processed.csv", 1)
-//		filex.WriteLines(outputPath, processed, 0644)
+//		filex.WriteCSV(outputPath, []string{"id", "name", "status"}, processed)
 //	}
 //
 // 4. Safe File Updates
@@ -495,23 +603,19 @@
 //
 // 5. Directory Synchronization
 //
-//	// Sync source to destination
-//	sourceFiles, _ := filex.Find("source", "*")
+//	// Mirror source to destination, removing files deleted in source
+//	opts := filex.DefaultSyncOptions()
+//	opts.Mode = filex.SyncMirror
+//	opts.Delete = true
+//	opts.Exclude = []string{"*.tmp", ".git"}
 //	
-//	for _, srcFile := range sourceFiles {
-//		relPath, _ := filex.RelPath("source", srcFile)
-//		dstFile := filex.Join("destination", relPath)
-//		
-//		// Skip if destination is newer
-//		srcInfo, _ := filex.GetFileInfo(srcFile)
-//		dstInfo, _ := filex.GetFileInfo(dstFile)
-//		
-//		if dstInfo == nil || srcInfo.ModTime.After(dstInfo.ModTime) {
-//			filex.Copy(srcFile, dstFile, filex.FileCopyOptions{
-//				CreateDirs: true,
-//				PreserveTime: true,
-//			})
-//		}
+//	report, err := filex.Sync("source", "destination", opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(filex.FormatSyncReport(report))
+//	if err := report.Err(); err != nil {
+//		log.Printf("some changes failed: %v", err)
 //	}
 //
 // # Best Practices
@@ -639,7 +743,8 @@
 // File operations include security considerations:
 //   - Path validation to prevent directory traversal
 //   - Safe temporary file creation
-//   - Proper file permission handling
+//   - Proper file permission handling (EnsurePermissions, FindWorldWritable)
+//   - Best-effort secure deletion with Shred; prefer encrypted storage for guarantees
 //   - No automatic execution of files
 //   - Validation of file operations before execution
 //
@@ -4,12 +4,13 @@
 //              safe file operations, path manipulation, directory management,
 //              file type detection, and content processing for the mDW platform.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-15
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with comprehensive file utilities
+// - 2026-10-15 v0.1.1: Extracted line and hash readers shared with the FS variants
 
 package filex
 
@@ -289,18 +290,24 @@ func ReadLines(path string) ([]string, error) {
 	}
 	defer file.Close()
 	
+	lines, err := readAllLines(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading lines from %s: %w", path, err)
+	}
+	
+	return lines, nil
+}
+
+// readAllLines reads all lines from r
+func readAllLines(r io.Reader) ([]string, error) {
 	var lines []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
 	
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading lines from %s: %w", path, err)
-	}
-	
-	return lines, nil
+	return lines, scanner.Err()
 }
 
 // ReadFirstLines reads the first n lines of a file
@@ -788,8 +795,13 @@ func SHA256Hash(path string) (string, error) {
 	}
 	defer file.Close()
 	
+	return sha256Reader(file)
+}
+
+// sha256Reader calculates the hex-encoded SHA256 hash of r's content
+func sha256Reader(r io.Reader) (string, error) {
 	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
+	if _, err := io.Copy(hash, r); err != nil {
 		return "", fmt.Errorf("error calculating SHA256 hash: %w", err)
 	}
 	
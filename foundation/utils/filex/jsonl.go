@@ -0,0 +1,154 @@
+// File: jsonl.go
+// Title: JSON Lines Reading and Writing
+// Description: Implements streaming reading of JSON Lines files record by
+//              record with bounded memory, appending of records, and atomic
+//              writing of complete record sets. Built on the streaming line
+//              processing of ProcessLines.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of JSONL readers and writers
+
+package filex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ===============================
+// JSON Lines
+// ===============================
+
+// JSONLFunc is called for each raw JSONL record with its line number
+type JSONLFunc func(lineNum int, raw json.RawMessage) error
+
+// ProcessJSONL streams the JSONL file at path, calling fn with each
+// non-blank line. Lines are checked to be valid JSON before fn is called.
+// Options are interpreted as for ProcessLines; raise MaxLineLength for
+// records larger than 1MB.
+func ProcessJSONL(path string, fn JSONLFunc, options ...LineOptions) error {
+	return ProcessLines(path, jsonlLineFunc(fn), options...)
+}
+
+// ProcessJSONLReader streams JSONL records from r
+func ProcessJSONLReader(r io.Reader, fn JSONLFunc, options ...LineOptions) error {
+	return ProcessLinesReader(r, jsonlLineFunc(fn), options...)
+}
+
+// ProcessJSONLAs streams the JSONL file at path, decoding each record into
+// a T before calling fn
+func ProcessJSONLAs[T any](path string, fn func(lineNum int, record T) error, options ...LineOptions) error {
+	return ProcessJSONL(path, func(lineNum int, raw json.RawMessage) error {
+		var record T
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return fmt.Errorf("invalid record: %w", err)
+		}
+		return fn(lineNum, record)
+	}, options...)
+}
+
+// ReadJSONL reads all records of the JSONL file at path into a slice
+func ReadJSONL[T any](path string, options ...LineOptions) ([]T, error) {
+	var records []T
+	err := ProcessJSONLAs(path, func(_ int, record T) error {
+		records = append(records, record)
+		return nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// AppendJSONL appends records to the JSONL file at path, creating it if
+// necessary. All records are written with a single append, so concurrent
+// appenders never interleave partial lines on local file systems.
+func AppendJSONL(path string, records ...any) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		if err := encodeJSONLRecord(&buf, record); err != nil {
+			return err
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s for appending: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to append to file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteJSONL writes records to path, one per line. The file is replaced
+// atomically.
+func WriteJSONL[T any](path string, records []T) error {
+	err := WriteAtomicFunc(path, 0644, func(w io.Writer) error {
+		writer := bufio.NewWriter(w)
+		var buf bytes.Buffer
+		for _, record := range records {
+			buf.Reset()
+			if err := encodeJSONLRecord(&buf, record); err != nil {
+				return err
+			}
+			if _, err := writer.Write(buf.Bytes()); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write JSONL file %s: %w", path, err)
+	}
+	return nil
+}
+
+// jsonlLineFunc adapts a JSONLFunc to a LineFunc, skipping blank lines
+func jsonlLineFunc(fn JSONLFunc) LineFunc {
+	return func(lineNum int, line string) error {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return nil
+		}
+		raw := json.RawMessage(line)
+		if !json.Valid(raw) {
+			return fmt.Errorf("invalid JSON record")
+		}
+		return fn(lineNum, raw)
+	}
+}
+
+// encodeJSONLRecord appends one compact JSON record and a newline to buf
+func encodeJSONLRecord(buf *bytes.Buffer, record any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSONL record: %w", err)
+	}
+	// json.Marshal output never contains raw newlines, but RawMessage
+	// values are passed through and must be compacted
+	if bytes.ContainsAny(data, "\r\n") {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, data); err != nil {
+			return fmt.Errorf("failed to encode JSONL record: %w", err)
+		}
+		data = compact.Bytes()
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
@@ -0,0 +1,117 @@
+// File: jsonl_test.go
+// Title: JSON Lines Reading and Writing Tests
+// Description: Tests for streaming JSONL processing, typed reading,
+//              appending, and atomic writing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial JSONL tests
+
+package filex
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type jsonlEvent struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+}
+
+func TestJSONL_AppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	if err := AppendJSONL(path, jsonlEvent{1, "login"}); err != nil {
+		t.Fatalf("AppendJSONL() error = %v", err)
+	}
+	if err := AppendJSONL(path, jsonlEvent{2, "query"}, json.RawMessage("{\n\"id\": 3,\n\"type\": \"logout\"\n}")); err != nil {
+		t.Fatalf("AppendJSONL() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if lines := strings.Count(string(content), "\n"); lines != 3 {
+		t.Errorf("file has %d lines, want 3: %q", lines, content)
+	}
+
+	events, err := ReadJSONL[jsonlEvent](path)
+	if err != nil {
+		t.Fatalf("ReadJSONL() error = %v", err)
+	}
+	want := []jsonlEvent{{1, "login"}, {2, "query"}, {3, "logout"}}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("ReadJSONL() = %v, want %v", events, want)
+	}
+}
+
+func TestProcessJSONL(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantLines []int
+		wantErr   string
+	}{
+		{"blank lines skipped", "{\"id\":1}\n\n  \n{\"id\":2}\n", []int{1, 4}, ""},
+		{"invalid record", "{\"id\":1}\n{broken\n", []int{1}, "line 2"},
+		{"empty file", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []int
+			err := ProcessJSONLReader(strings.NewReader(tt.content), func(lineNum int, raw json.RawMessage) error {
+				lines = append(lines, lineNum)
+				return nil
+			})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ProcessJSONLReader() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ProcessJSONLReader() error = %v, want %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("processed lines = %v, want %v", lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestProcessJSONLAs_TypeMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.jsonl")
+	os.WriteFile(path, []byte("{\"id\":\"one\"}\n"), 0644)
+
+	err := ProcessJSONLAs(path, func(int, jsonlEvent) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "invalid record") {
+		t.Errorf("ProcessJSONLAs() error = %v, want invalid record", err)
+	}
+	if _, err := ReadJSONL[jsonlEvent](filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("ReadJSONL() on missing file should fail")
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	records := []map[string]any{{"a": 1}, {"b": "x\ny"}}
+
+	if err := WriteJSONL(path, records); err != nil {
+		t.Fatalf("WriteJSONL() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if want := "{\"a\":1}\n{\"b\":\"x\\ny\"}\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	if err := WriteJSONL(path, []any{func() {}}); err == nil {
+		t.Error("WriteJSONL() with unencodable record should fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(content) {
+		t.Error("failed WriteJSONL() modified the existing file")
+	}
+}
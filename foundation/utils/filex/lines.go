@@ -0,0 +1,256 @@
+// File: lines.go
+// Title: Streaming Line Processing
+// Description: Implements streaming line-by-line processing and file
+//              transformation with configurable buffer sizes, progress
+//              callbacks, and context cancellation. Unlike ReadLines, files
+//              are never loaded into memory as a whole, which makes these
+//              functions suitable for log ingestion and large CSV imports.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of ProcessLines and TransformFile
+
+package filex
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrStopProcessing can be returned by a line callback to stop processing
+// early without reporting an error
+var ErrStopProcessing = errors.New("stop processing")
+
+// utf8BOM is the UTF-8 byte order mark stripped from the first line
+const utf8BOM = "\uFEFF"
+
+// ===============================
+// Streaming Line Processing
+// ===============================
+
+// LineFunc is called for each line of input. lineNum is 1-based and the line
+// does not include its line ending.
+type LineFunc func(lineNum int, line string) error
+
+// LineTransformFunc maps an input line to an output line. Returning
+// keep == false drops the line from the output.
+type LineTransformFunc func(lineNum int, line string) (output string, keep bool, err error)
+
+// LineProgress describes the progress of a streaming operation
+type LineProgress struct {
+	Lines      int   // Lines processed so far
+	BytesRead  int64 // Bytes consumed from the input
+	TotalBytes int64 // Total input size (0 if unknown)
+}
+
+// Percent returns the completion percentage, or 0 if the total is unknown
+func (p LineProgress) Percent() float64 {
+	if p.TotalBytes <= 0 {
+		return 0
+	}
+	return float64(p.BytesRead) / float64(p.TotalBytes) * 100
+}
+
+// ProgressFunc receives progress updates during streaming operations
+type ProgressFunc func(progress LineProgress)
+
+// LineOptions represents options for streaming line operations
+type LineOptions struct {
+	Context          context.Context // Cancellation context (nil = background)
+	BufferSize       int             // Read buffer size (default: 64KB)
+	MaxLineLength    int             // Maximum accepted line length (default: 1MB)
+	StripBOM         bool            // Strip a UTF-8 BOM from the first line
+	LineEnding       string          // Line ending written by TransformFile (default: "\n")
+	Progress         ProgressFunc    // Progress callback (optional)
+	ProgressInterval int             // Lines between progress callbacks (default: 10000)
+}
+
+// DefaultLineOptions returns default options for streaming line operations
+func DefaultLineOptions() LineOptions {
+	return LineOptions{
+		Context:          context.Background(),
+		BufferSize:       64 * 1024,   // 64KB default buffer
+		MaxLineLength:    1024 * 1024, // 1MB maximum line length
+		StripBOM:         true,
+		LineEnding:       "\n",
+		ProgressInterval: 10000,
+	}
+}
+
+// normalizeLineOptions fills unset options with defaults
+func normalizeLineOptions(options []LineOptions) LineOptions {
+	opts := DefaultLineOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	defaults := DefaultLineOptions()
+	if opts.Context == nil {
+		opts.Context = defaults.Context
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaults.BufferSize
+	}
+	if opts.MaxLineLength <= 0 {
+		opts.MaxLineLength = defaults.MaxLineLength
+	}
+	if opts.MaxLineLength < opts.BufferSize {
+		opts.MaxLineLength = opts.BufferSize
+	}
+	if opts.LineEnding == "" {
+		opts.LineEnding = defaults.LineEnding
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = defaults.ProgressInterval
+	}
+	return opts
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+// Read implements io.Reader
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// ProcessLines streams the file at path line by line, calling fn for each
+// line. Memory use is bounded by the buffer and maximum line length
+// regardless of file size.
+func ProcessLines(path string, fn LineFunc, options ...LineOptions) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var total int64
+	if info, err := file.Stat(); err == nil {
+		total = info.Size()
+	}
+
+	if err := scanLines(file, total, fn, normalizeLineOptions(options)); err != nil {
+		return fmt.Errorf("error processing lines from %s: %w", path, err)
+	}
+	return nil
+}
+
+// ProcessLinesReader streams lines from r, calling fn for each line
+func ProcessLinesReader(r io.Reader, fn LineFunc, options ...LineOptions) error {
+	return scanLines(r, 0, fn, normalizeLineOptions(options))
+}
+
+// TransformFile streams src line by line through fn and writes the kept
+// lines to dst. The destination is replaced atomically, so dst may be the
+// same file as src and is left untouched if processing fails or is
+// cancelled. dst receives the permissions of src.
+func TransformFile(src, dst string, fn LineTransformFunc, options ...LineOptions) error {
+	if fn == nil {
+		return errors.New("transform function cannot be nil")
+	}
+	opts := normalizeLineOptions(options)
+
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file %s: %w", src, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat source file %s: %w", src, err)
+	}
+
+	err = WriteAtomicFunc(dst, info.Mode().Perm(), func(w io.Writer) error {
+		writer := bufio.NewWriterSize(w, opts.BufferSize)
+
+		err := scanLines(file, info.Size(), func(lineNum int, line string) error {
+			output, keep, err := fn(lineNum, line)
+			if err != nil || !keep {
+				return err
+			}
+			if _, err := writer.WriteString(output); err != nil {
+				return err
+			}
+			_, err = writer.WriteString(opts.LineEnding)
+			return err
+		}, opts)
+		if err != nil {
+			return err
+		}
+
+		return writer.Flush()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to transform %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
+
+// scanLines is the shared streaming loop
+func scanLines(r io.Reader, total int64, fn LineFunc, opts LineOptions) error {
+	if fn == nil {
+		return errors.New("line function cannot be nil")
+	}
+
+	counter := &countingReader{reader: r}
+	scanner := bufio.NewScanner(counter)
+	scanner.Buffer(make([]byte, 0, opts.BufferSize), opts.MaxLineLength)
+
+	done := opts.Context.Done()
+	report := func(lines int) {
+		if opts.Progress != nil {
+			opts.Progress(LineProgress{Lines: lines, BytesRead: counter.count, TotalBytes: total})
+		}
+	}
+
+	lineNum := 0
+	for scanner.Scan() {
+		select {
+		case <-done:
+			return opts.Context.Err()
+		default:
+		}
+
+		lineNum++
+		line := scanner.Text()
+		if lineNum == 1 && opts.StripBOM {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+
+		if err := fn(lineNum, line); err != nil {
+			if errors.Is(err, ErrStopProcessing) {
+				report(lineNum)
+				return nil
+			}
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		if lineNum%opts.ProgressInterval == 0 {
+			report(lineNum)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("line %d exceeds maximum length of %d bytes: %w", lineNum+1, opts.MaxLineLength, err)
+		}
+		return err
+	}
+
+	report(lineNum)
+	return nil
+}
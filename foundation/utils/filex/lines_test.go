@@ -0,0 +1,264 @@
+// File: lines_test.go
+// Title: Streaming Line Processing Tests
+// Description: Tests for ProcessLines, ProcessLinesReader, and TransformFile
+//              covering line handling, early termination, progress reporting,
+//              cancellation, line length limits, and atomic replacement.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial streaming line tests
+
+package filex
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProcessLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"unix endings", "a\nb\nc\n", []string{"a", "b", "c"}},
+		{"windows endings", "a\r\nb\r\n", []string{"a", "b"}},
+		{"no trailing newline", "a\nb", []string{"a", "b"}},
+		{"blank lines", "a\n\nb\n", []string{"a", "", "b"}},
+		{"bom stripped", "\uFEFFheader\nrow\n", []string{"header", "row"}},
+		{"empty file", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "input.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			err := ProcessLines(path, func(lineNum int, line string) error {
+				if lineNum != len(got)+1 {
+					t.Errorf("lineNum = %d, want %d", lineNum, len(got)+1)
+				}
+				got = append(got, line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ProcessLines() error = %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("ProcessLines() lines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessLines_Errors(t *testing.T) {
+	if err := ProcessLines(filepath.Join(t.TempDir(), "missing.txt"), func(int, string) error { return nil }); err == nil {
+		t.Error("ProcessLines() on missing file should fail")
+	}
+
+	input := "one\ntwo\nthree\n"
+
+	// Callback errors are wrapped with the line number
+	errBad := errors.New("bad record")
+	err := ProcessLinesReader(strings.NewReader(input), func(lineNum int, line string) error {
+		if line == "two" {
+			return errBad
+		}
+		return nil
+	})
+	if !errors.Is(err, errBad) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ProcessLinesReader() error = %v, want wrapped errBad at line 2", err)
+	}
+
+	// ErrStopProcessing ends early without an error
+	count := 0
+	err = ProcessLinesReader(strings.NewReader(input), func(lineNum int, line string) error {
+		count++
+		if lineNum == 2 {
+			return ErrStopProcessing
+		}
+		return nil
+	})
+	if err != nil || count != 2 {
+		t.Errorf("ProcessLinesReader() with stop: err = %v, count = %d, want nil, 2", err, count)
+	}
+
+	// Lines longer than MaxLineLength are rejected
+	opts := DefaultLineOptions()
+	opts.BufferSize = 16
+	opts.MaxLineLength = 32
+	err = ProcessLinesReader(strings.NewReader("short\n"+strings.Repeat("x", 100)+"\n"), func(int, string) error { return nil }, opts)
+	if !errors.Is(err, bufio.ErrTooLong) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ProcessLinesReader() long line error = %v, want ErrTooLong at line 2", err)
+	}
+}
+
+func TestProcessLines_ProgressAndCancellation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "large.log")
+	var builder strings.Builder
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(&builder, "entry %04d\n", i)
+	}
+	if err := os.WriteFile(path, []byte(builder.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []LineProgress
+	opts := DefaultLineOptions()
+	opts.BufferSize = 256
+	opts.ProgressInterval = 100
+	opts.Progress = func(p LineProgress) {
+		updates = append(updates, p)
+	}
+
+	if err := ProcessLines(path, func(int, string) error { return nil }, opts); err != nil {
+		t.Fatalf("ProcessLines() error = %v", err)
+	}
+
+	// One update every 100 lines plus the final update
+	if len(updates) != 11 {
+		t.Fatalf("got %d progress updates, want 11", len(updates))
+	}
+	last := updates[len(updates)-1]
+	if last.Lines != 1000 || last.BytesRead != last.TotalBytes || last.Percent() != 100 {
+		t.Errorf("final progress = %+v (%.1f%%), want 1000 lines and complete", last, last.Percent())
+	}
+	for i := 1; i < len(updates); i++ {
+		if updates[i].BytesRead < updates[i-1].BytesRead {
+			t.Errorf("progress went backwards: %+v -> %+v", updates[i-1], updates[i])
+		}
+	}
+
+	// Cancellation stops processing with the context error
+	ctx, cancel := context.WithCancel(context.Background())
+	opts = DefaultLineOptions()
+	opts.Context = ctx
+	processed := 0
+	err := ProcessLines(path, func(lineNum int, line string) error {
+		processed++
+		if lineNum == 10 {
+			cancel()
+		}
+		return nil
+	}, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ProcessLines() error = %v, want context.Canceled", err)
+	}
+	if processed != 10 {
+		t.Errorf("processed %d lines after cancel, want 10", processed)
+	}
+}
+
+func TestTransformFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "import.csv")
+	if err := os.WriteFile(src, []byte("\uFEFFid;name\r\n1;alice\r\n# comment\r\n2;bob\r\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	upper := func(lineNum int, line string) (string, bool, error) {
+		if strings.HasPrefix(line, "#") {
+			return "", false, nil
+		}
+		return strings.ToUpper(strings.ReplaceAll(line, ";", ",")), true, nil
+	}
+
+	dst := filepath.Join(dir, "export.csv")
+	if err := TransformFile(src, dst, upper); err != nil {
+		t.Fatalf("TransformFile() error = %v", err)
+	}
+
+	content, err := ReadString(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ID,NAME\n1,ALICE\n2,BOB\n"; content != want {
+		t.Errorf("TransformFile() output = %q, want %q", content, want)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("dst mode = %v, want 0640", info.Mode().Perm())
+	}
+
+	// In-place transformation with CRLF output
+	opts := DefaultLineOptions()
+	opts.LineEnding = "\r\n"
+	if err := TransformFile(dst, dst, func(_ int, line string) (string, bool, error) {
+		return strings.ToLower(line), true, nil
+	}, opts); err != nil {
+		t.Fatalf("TransformFile() in place error = %v", err)
+	}
+	content, _ = ReadString(dst)
+	if want := "id,name\r\n1,alice\r\n2,bob\r\n"; content != want {
+		t.Errorf("in-place output = %q, want %q", content, want)
+	}
+}
+
+func TestTransformFile_FailureLeavesDestination(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	if err := os.WriteFile(src, []byte("a\nb\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errFail := errors.New("transform failed")
+	err := TransformFile(src, dst, func(lineNum int, line string) (string, bool, error) {
+		if lineNum == 2 {
+			return "", false, errFail
+		}
+		return line, true, nil
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("TransformFile() error = %v, want errFail", err)
+	}
+
+	content, _ := ReadString(dst)
+	if content != "original" {
+		t.Errorf("dst content = %q, want it untouched", content)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want 2 (no temp files left)", len(entries))
+	}
+
+	if err := TransformFile(filepath.Join(dir, "missing.txt"), dst, nil); err == nil {
+		t.Error("TransformFile() with missing source should fail")
+	}
+}
+
+func BenchmarkProcessLines(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "bench.log")
+	var builder strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&builder, "2026-10-15T09:30:15Z INFO request %d handled in 12ms\n", i)
+	}
+	if err := os.WriteFile(path, []byte(builder.String()), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ProcessLines(path, func(int, string) error { return nil })
+	}
+}
@@ -0,0 +1,151 @@
+// File: lock.go
+// Title: Advisory File Locking
+// Description: Implements cross-process advisory file locks with blocking,
+//              non-blocking, shared, and context-aware acquisition. Uses
+//              flock on Unix systems and LockFileEx on Windows.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of Lock/TryLock API
+
+package filex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned by TryLock and TryRLock when the lock is held elsewhere
+var ErrLocked = errors.New("file is locked")
+
+// ErrLockingUnsupported is returned on platforms without file lock support
+var ErrLockingUnsupported = errors.New("file locking is not supported on this platform")
+
+// ===============================
+// File Locking
+// ===============================
+
+// FileLock represents an acquired advisory lock on a file. Locks are advisory:
+// they only coordinate processes that also use this API (or flock/LockFileEx).
+type FileLock struct {
+	path      string
+	file      *os.File
+	exclusive bool
+	mutex     sync.Mutex
+}
+
+// Lock acquires an exclusive lock on path, blocking until it is available.
+// The file is created if it does not exist.
+func Lock(path string) (*FileLock, error) {
+	return acquireLock(path, true, true)
+}
+
+// RLock acquires a shared lock on path, blocking until it is available
+func RLock(path string) (*FileLock, error) {
+	return acquireLock(path, false, true)
+}
+
+// TryLock attempts to acquire an exclusive lock without blocking. It returns
+// ErrLocked if the lock is held by someone else.
+func TryLock(path string) (*FileLock, error) {
+	return acquireLock(path, true, false)
+}
+
+// TryRLock attempts to acquire a shared lock without blocking
+func TryRLock(path string) (*FileLock, error) {
+	return acquireLock(path, false, false)
+}
+
+// LockContext acquires an exclusive lock, polling until it becomes available
+// or the context is done
+func LockContext(ctx context.Context, path string) (*FileLock, error) {
+	delay := 5 * time.Millisecond
+	for {
+		lock, err := TryLock(path)
+		if err == nil {
+			return lock, nil
+		}
+		if !errors.Is(err, ErrLocked) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to lock %s: %w", path, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if delay < 250*time.Millisecond {
+			delay *= 2
+		}
+	}
+}
+
+// WithLock runs fn while holding an exclusive lock on path
+func WithLock(path string, fn func() error) error {
+	lock, err := Lock(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	return fn()
+}
+
+// Path returns the path of the locked file
+func (l *FileLock) Path() string {
+	return l.path
+}
+
+// Exclusive reports whether the lock is exclusive (as opposed to shared)
+func (l *FileLock) Exclusive() bool {
+	return l.exclusive
+}
+
+// Unlock releases the lock. Calling Unlock more than once is a no-op.
+func (l *FileLock) Unlock() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	unlockErr := unlockFile(l.file)
+	closeErr := l.file.Close()
+	l.file = nil
+
+	if unlockErr != nil {
+		return fmt.Errorf("failed to unlock file %s: %w", l.path, unlockErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close lock file %s: %w", l.path, closeErr)
+	}
+	return nil
+}
+
+// acquireLock opens path and applies the platform lock
+func acquireLock(path string, exclusive, blocking bool) (*FileLock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+
+	if err := lockFile(file, exclusive, blocking); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return &FileLock{
+		path:      path,
+		file:      file,
+		exclusive: exclusive,
+	}, nil
+}
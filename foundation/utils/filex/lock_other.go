@@ -0,0 +1,27 @@
+// File: lock_other.go
+// Title: Advisory File Locking (Unsupported Platforms)
+// Description: Fallback lock primitives for platforms without flock or
+//              LockFileEx support.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial fallback implementation
+
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package filex
+
+import "os"
+
+// lockFile reports that locking is unavailable
+func lockFile(file *os.File, exclusive, blocking bool) error {
+	return ErrLockingUnsupported
+}
+
+// unlockFile is a no-op on unsupported platforms
+func unlockFile(file *os.File) error {
+	return nil
+}
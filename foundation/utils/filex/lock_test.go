@@ -0,0 +1,164 @@
+// File: lock_test.go
+// Title: Advisory File Locking Tests
+// Description: Tests for exclusive/shared locking, non-blocking acquisition,
+//              context cancellation, and lock release semantics.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial file locking tests
+
+package filex
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resource.lock")
+
+	lock, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if lock.Path() != path || !lock.Exclusive() {
+		t.Errorf("unexpected lock state: path=%s exclusive=%v", lock.Path(), lock.Exclusive())
+	}
+
+	if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("second TryLock() error = %v, want ErrLocked", err)
+	}
+	if _, err := TryRLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryRLock() on exclusive lock error = %v, want ErrLocked", err)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Errorf("second Unlock() error = %v, want nil", err)
+	}
+
+	relock, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() after Unlock error = %v", err)
+	}
+	relock.Unlock()
+}
+
+func TestSharedLocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.lock")
+
+	r1, err := RLock(path)
+	if err != nil {
+		t.Fatalf("RLock() error = %v", err)
+	}
+	defer r1.Unlock()
+
+	r2, err := TryRLock(path)
+	if err != nil {
+		t.Fatalf("second shared lock error = %v", err)
+	}
+	defer r2.Unlock()
+
+	if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryLock() with readers error = %v, want ErrLocked", err)
+	}
+}
+
+func TestLock_BlocksUntilReleased(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocking.lock")
+
+	first, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	var acquired atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		second, err := Lock(path)
+		if err != nil {
+			t.Errorf("blocked Lock() error = %v", err)
+			return
+		}
+		acquired.Store(true)
+		second.Unlock()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if acquired.Load() {
+		t.Fatal("second Lock() acquired while first lock was held")
+	}
+
+	first.Unlock()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("second Lock() did not acquire after release")
+	}
+}
+
+func TestLockContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctx.lock")
+
+	held, err := Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := LockContext(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LockContext() error = %v, want deadline exceeded", err)
+	}
+
+	held.Unlock()
+	lock, err := LockContext(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LockContext() after release error = %v", err)
+	}
+	lock.Unlock()
+}
+
+func TestWithLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "with.lock")
+
+	called := false
+	err := WithLock(path, func() error {
+		called = true
+		if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+			t.Errorf("TryLock() inside WithLock error = %v, want ErrLocked", err)
+		}
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("WithLock() error = %v, called = %v", err, called)
+	}
+
+	fnErr := errors.New("failed")
+	if err := WithLock(path, func() error { return fnErr }); !errors.Is(err, fnErr) {
+		t.Errorf("WithLock() error = %v, want %v", err, fnErr)
+	}
+
+	// The lock must be released after WithLock returns
+	lock, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() after WithLock error = %v", err)
+	}
+	lock.Unlock()
+}
+
+func TestLock_InvalidPath(t *testing.T) {
+	if _, err := TryLock(filepath.Join(t.TempDir(), "missing", "x.lock")); err == nil {
+		t.Error("TryLock() expected error for missing directory")
+	}
+}
@@ -0,0 +1,50 @@
+// File: lock_unix.go
+// Title: Advisory File Locking (Unix)
+// Description: flock(2) based implementation of the platform lock primitives.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial flock implementation
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package filex
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile applies a flock to the file
+func lockFile(file *os.File, exclusive, blocking bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	if !blocking {
+		how |= syscall.LOCK_NB
+	}
+
+	for {
+		err := syscall.Flock(int(file.Fd()), how)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, syscall.EINTR):
+			continue
+		case errors.Is(err, syscall.EWOULDBLOCK):
+			return ErrLocked
+		default:
+			return err
+		}
+	}
+}
+
+// unlockFile releases a flock held on the file
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
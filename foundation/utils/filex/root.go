@@ -0,0 +1,323 @@
+// File: root.go
+// Title: Sandboxed Path Resolution
+// Description: Implements Root, a chroot-like view of a base directory. All
+//              names are resolved relative to the base and symbolic links
+//              are followed component by component, rejecting any path that
+//              would escape the base. On Linux files are opened with openat
+//              and O_NOFOLLOW so links swapped in after resolution are
+//              detected as well.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of Root
+// - 2026-10-15 v0.1.1: Added RemoveAll and Rename for the OS-backed VFS
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathEscapesRoot is returned when a name resolves outside of a Root
+var ErrPathEscapesRoot = errors.New("path escapes root directory")
+
+// maxSymlinkHops limits symlink expansion during resolution (matches Linux)
+const maxSymlinkHops = 40
+
+// ===============================
+// Sandboxed Roots
+// ===============================
+
+// Root confines file operations to a base directory. Names passed to its
+// methods are always interpreted relative to the base, may use forward
+// slashes on every platform, and must not be absolute. Symbolic links are
+// allowed as long as their targets stay inside the base.
+//
+// Root is intended for serving untrusted paths, e.g. the arguments of TCOL
+// FILE.* commands. It is safe for concurrent use.
+type Root struct {
+	base string // Absolute, symlink-free base directory
+}
+
+// OpenRoot creates a Root for dir. The directory must exist.
+func OpenRoot(dir string) (*Root, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root %s: %w", dir, err)
+	}
+
+	base, err := filepath.EvalSymlinks(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root %s: %w", dir, err)
+	}
+
+	info, err := os.Stat(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat root %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root %s is not a directory", dir)
+	}
+
+	return &Root{base: base}, nil
+}
+
+// Name returns the absolute host path of the base directory
+func (r *Root) Name() string {
+	return r.base
+}
+
+// Resolve returns the host path name refers to after following symbolic
+// links. The path is guaranteed to lie inside the root at the time of the
+// call; prefer the Root methods over operating on the result directly.
+func (r *Root) Resolve(name string) (string, error) {
+	rel, err := r.resolve("resolve", name, true)
+	if err != nil {
+		return "", err
+	}
+	return r.hostPath(rel), nil
+}
+
+// Open opens the named file for reading
+func (r *Root) Open(name string) (*os.File, error) {
+	return r.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Create creates or truncates the named file
+func (r *Root) Create(name string) (*os.File, error) {
+	return r.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// OpenFile is the generalized open call, analogous to os.OpenFile
+func (r *Root) OpenFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	rel, err := r.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return openInRoot(r.base, rel, flag, perm)
+}
+
+// ReadFile reads the named file
+func (r *Root) ReadFile(name string) ([]byte, error) {
+	file, err := r.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// WriteFile writes data to the named file, creating it if necessary
+func (r *Root) WriteFile(name string, data []byte, perm os.FileMode) error {
+	file, err := r.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Stat returns file information for name, following symbolic links
+func (r *Root) Stat(name string) (os.FileInfo, error) {
+	rel, err := r.resolve("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(r.hostPath(rel))
+}
+
+// Lstat returns file information for name without following a final
+// symbolic link
+func (r *Root) Lstat(name string) (os.FileInfo, error) {
+	rel, err := r.resolve("lstat", name, false)
+	if err != nil {
+		return nil, err
+	}
+	return os.Lstat(r.hostPath(rel))
+}
+
+// List returns the entries of the named directory. The Path of each entry is
+// relative to the root, so host paths are never exposed to callers.
+func (r *Root) List(name string) ([]FileInfo, error) {
+	rel, err := r.resolve("list", name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := openInRoot(r.base, rel, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s: %w", name, err)
+	}
+
+	var files []FileInfo
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed while listing
+		}
+		files = append(files, newFileInfo(filepath.Join(rel, entry.Name()), info))
+	}
+
+	return files, nil
+}
+
+// MkdirAll creates the named directory along with any missing parents
+func (r *Root) MkdirAll(name string, perm os.FileMode) error {
+	rel, err := r.resolve("mkdir", name, true)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(r.hostPath(rel), perm)
+}
+
+// Remove removes the named file or empty directory. A final symbolic link
+// is removed itself, never its target. The root itself cannot be removed.
+func (r *Root) Remove(name string) error {
+	rel, err := r.resolve("remove", name, false)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrPermission}
+	}
+	return os.Remove(r.hostPath(rel))
+}
+
+// RemoveAll removes the named entry and everything it contains. A final
+// symbolic link is removed itself. The root itself cannot be removed.
+func (r *Root) RemoveAll(name string) error {
+	rel, err := r.resolve("removeall", name, false)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return &os.PathError{Op: "removeall", Path: name, Err: os.ErrPermission}
+	}
+	return os.RemoveAll(r.hostPath(rel))
+}
+
+// Rename renames oldName to newName, both inside the root. Final symbolic
+// links are renamed themselves, never their targets.
+func (r *Root) Rename(oldName, newName string) error {
+	oldRel, err := r.resolve("rename", oldName, false)
+	if err != nil {
+		return err
+	}
+	newRel, err := r.resolve("rename", newName, false)
+	if err != nil {
+		return err
+	}
+	if oldRel == "." || newRel == "." {
+		return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: os.ErrPermission}
+	}
+	return os.Rename(r.hostPath(oldRel), r.hostPath(newRel))
+}
+
+// hostPath converts a resolved root-relative path to a host path
+func (r *Root) hostPath(rel string) string {
+	return filepath.Join(r.base, rel)
+}
+
+// resolve maps name to a cleaned path relative to the base, expanding
+// symbolic links one component at a time. Components that do not exist yet
+// are kept lexically so that files and directories can be created.
+func (r *Root) resolve(op, name string, followFinal bool) (string, error) {
+	if name == "" || strings.IndexByte(name, 0) >= 0 {
+		return "", &os.PathError{Op: op, Path: name, Err: os.ErrInvalid}
+	}
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" || strings.HasPrefix(name, "/") {
+		return "", &os.PathError{Op: op, Path: name, Err: ErrPathEscapesRoot}
+	}
+
+	pending := splitRootPath(name)
+	var resolved []string
+	hops := 0
+
+	for len(pending) > 0 {
+		part := pending[0]
+		pending = pending[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) == 0 {
+				return "", &os.PathError{Op: op, Path: name, Err: ErrPathEscapesRoot}
+			}
+			resolved = resolved[:len(resolved)-1]
+			continue
+		}
+
+		current := filepath.Join(append(resolved[:len(resolved):len(resolved)], part)...)
+		if len(pending) == 0 && !followFinal {
+			resolved = append(resolved, part)
+			break
+		}
+
+		info, err := os.Lstat(r.hostPath(current))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				resolved = append(resolved, part)
+				continue
+			}
+			return "", &os.PathError{Op: op, Path: name, Err: err}
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = append(resolved, part)
+			continue
+		}
+
+		hops++
+		if hops > maxSymlinkHops {
+			return "", &os.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+		}
+
+		target, err := os.Readlink(r.hostPath(current))
+		if err != nil {
+			return "", &os.PathError{Op: op, Path: name, Err: err}
+		}
+
+		// Absolute targets are accepted only when they point into the base
+		if filepath.IsAbs(target) {
+			relTarget, err := filepath.Rel(r.base, target)
+			if err != nil || relTarget == ".." || strings.HasPrefix(relTarget, ".."+string(filepath.Separator)) {
+				return "", &os.PathError{Op: op, Path: name, Err: ErrPathEscapesRoot}
+			}
+			resolved = resolved[:0]
+			target = relTarget
+		}
+
+		pending = append(splitRootPath(target), pending...)
+	}
+
+	if len(resolved) == 0 {
+		return ".", nil
+	}
+	return filepath.Join(resolved...), nil
+}
+
+// splitRootPath splits a name on both separators accepted by Root
+func splitRootPath(name string) []string {
+	return strings.FieldsFunc(name, func(c rune) bool {
+		return c == '/' || c == filepath.Separator
+	})
+}
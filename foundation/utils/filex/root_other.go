@@ -0,0 +1,26 @@
+// File: root_other.go
+// Title: Sandboxed Path Resolution (Portable)
+// Description: Portable file opening for Root on platforms without openat
+//              support in the standard library. Containment relies on the
+//              symlink-aware resolution performed by Root.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial portable implementation
+
+//go:build !linux
+
+package filex
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// openInRoot opens the resolved path below base
+func openInRoot(base, rel string, flag int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(filepath.Join(base, rel), flag, perm)
+}
@@ -0,0 +1,192 @@
+// File: root_test.go
+// Title: Sandboxed Path Resolution Tests
+// Description: Tests for Root covering containment of relative, absolute, and
+//              symlinked paths as well as the file operations it exposes.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial Root tests
+
+package filex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRoot creates a root with a sibling "outside" directory holding a
+// secret file that must never be reachable
+func newTestRoot(t *testing.T) (*Root, string) {
+	t.Helper()
+	parent := t.TempDir()
+	base := filepath.Join(parent, "base")
+	outside := filepath.Join(parent, "outside")
+	for _, dir := range []string{filepath.Join(base, "data", "nested"), outside} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "data", "report.txt"), []byte("report"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := OpenRoot(base)
+	if err != nil {
+		t.Fatalf("OpenRoot() error = %v", err)
+	}
+	return root, outside
+}
+
+// symlinkOrSkip creates a symbolic link or skips the test if unsupported
+func symlinkOrSkip(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+}
+
+func TestRoot_Containment(t *testing.T) {
+	root, outside := newTestRoot(t)
+	symlinkOrSkip(t, outside, filepath.Join(root.Name(), "escape_abs"))
+	symlinkOrSkip(t, filepath.Join("..", "..", "outside"), filepath.Join(root.Name(), "data", "escape_rel"))
+	symlinkOrSkip(t, "nested", filepath.Join(root.Name(), "data", "inner_rel"))
+	symlinkOrSkip(t, filepath.Join(root.Name(), "data"), filepath.Join(root.Name(), "inner_abs"))
+	symlinkOrSkip(t, "loop_b", filepath.Join(root.Name(), "loop_a"))
+	symlinkOrSkip(t, "loop_a", filepath.Join(root.Name(), "loop_b"))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantEscape bool
+		wantErr    bool
+	}{
+		{"plain file", "data/report.txt", false, false},
+		{"dot segments inside", "data/nested/../report.txt", false, false},
+		{"root itself", ".", false, false},
+		{"parent escape", "../outside/secret.txt", true, true},
+		{"deep parent escape", "data/../../outside/secret.txt", true, true},
+		{"absolute path", filepath.Join(outside, "secret.txt"), true, true},
+		{"absolute symlink outside", "escape_abs/secret.txt", true, true},
+		{"relative symlink outside", "data/escape_rel/secret.txt", true, true},
+		{"relative symlink inside", "data/inner_rel", false, false},
+		{"absolute symlink inside", "inner_abs/report.txt", false, false},
+		{"symlink loop", "loop_a", false, true},
+		{"empty name", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := root.Resolve(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if errors.Is(err, ErrPathEscapesRoot) != tt.wantEscape {
+				t.Errorf("Resolve(%q) error = %v, wantEscape %v", tt.path, err, tt.wantEscape)
+			}
+
+			if tt.wantEscape {
+				if _, err := root.ReadFile(tt.path); !errors.Is(err, ErrPathEscapesRoot) {
+					t.Errorf("ReadFile(%q) error = %v, want ErrPathEscapesRoot", tt.path, err)
+				}
+				if err := root.WriteFile(tt.path, []byte("x"), 0644); !errors.Is(err, ErrPathEscapesRoot) {
+					t.Errorf("WriteFile(%q) error = %v, want ErrPathEscapesRoot", tt.path, err)
+				}
+			}
+		})
+	}
+
+	if data, err := os.ReadFile(filepath.Join(outside, "secret.txt")); err != nil || string(data) != "secret" {
+		t.Errorf("file outside root was modified: %q, %v", data, err)
+	}
+}
+
+func TestRoot_Operations(t *testing.T) {
+	root, _ := newTestRoot(t)
+
+	data, err := root.ReadFile("data/report.txt")
+	if err != nil || string(data) != "report" {
+		t.Fatalf("ReadFile() = %q, %v", data, err)
+	}
+
+	if err := root.MkdirAll("exports/2026", 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := root.WriteFile("exports/2026/out.csv", []byte("a,b\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if !IsFile(filepath.Join(root.Name(), "exports", "2026", "out.csv")) {
+		t.Error("WriteFile() did not create the file inside the root")
+	}
+
+	info, err := root.Stat("exports/2026/out.csv")
+	if err != nil || info.Size() != 4 {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+
+	files, err := root.List("data")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	names := map[string]string{}
+	for _, file := range files {
+		names[file.Name] = file.Path
+	}
+	if names["report.txt"] != filepath.Join("data", "report.txt") || names["nested"] == "" {
+		t.Errorf("List() = %v, want root-relative report.txt and nested", names)
+	}
+
+	file, err := root.Create("data/new.txt")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	file.Close()
+
+	if err := root.Remove("data/new.txt"); err != nil {
+		t.Errorf("Remove() error = %v", err)
+	}
+	if err := root.Remove("."); err == nil {
+		t.Error("Remove(\".\") should refuse to remove the root")
+	}
+	if _, err := root.Open("data/missing.txt"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Open() missing file error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestRoot_RemoveSymlink(t *testing.T) {
+	root, outside := newTestRoot(t)
+	symlinkOrSkip(t, filepath.Join(outside, "secret.txt"), filepath.Join(root.Name(), "link"))
+
+	info, err := root.Lstat("link")
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("Lstat() = %v, %v; want symlink", info, err)
+	}
+	if err := root.Remove("link"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if !Exists(filepath.Join(outside, "secret.txt")) {
+		t.Error("Remove() deleted the symlink target")
+	}
+}
+
+func TestOpenRoot_Errors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenRoot(file); err == nil {
+		t.Error("OpenRoot() on a file should fail")
+	}
+	if _, err := OpenRoot(filepath.Join(dir, "missing")); err == nil {
+		t.Error("OpenRoot() on a missing directory should fail")
+	}
+}
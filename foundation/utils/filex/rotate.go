@@ -0,0 +1,322 @@
+// File: rotate.go
+// Title: Rotating File Writer
+// Description: Implements Rotator, an io.WriteCloser that rotates its file by
+//              size and time interval, gzips rotated files in the background,
+//              and enforces retention limits on rotated files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Rotator
+
+package filex
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===============================
+// Rotation
+// ===============================
+
+// rotateTimeLayout is the UTC timestamp in names of rotated files,
+// e.g. app-20261016T150405.000.log
+const rotateTimeLayout = "20060102T150405.000"
+
+// RotateOptions configures a Rotator. Zero values disable the corresponding
+// limit.
+type RotateOptions struct {
+	MaxSize    int64            // Rotate before the file exceeds this many bytes
+	Interval   time.Duration    // Rotate when the clock crosses a multiple of this interval
+	MaxBackups int              // Keep at most this many rotated files
+	MaxAge     time.Duration    // Remove rotated files older than this
+	Compress   bool             // Gzip rotated files
+	Perm       os.FileMode      // Permissions of new files (default: 0644)
+	Now        func() time.Time // Clock used for rotation and retention (default: time.Now)
+}
+
+// Rotator writes to a file and rotates it according to RotateOptions.
+// Rotated files are renamed to <name>-<timestamp><ext> next to the file.
+// It is safe for concurrent use.
+type Rotator struct {
+	path    string
+	options RotateOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	millMu sync.Mutex     // Serializes compression and retention
+	millWG sync.WaitGroup // Tracks background compression and retention
+}
+
+// NewRotator opens or creates the file at path for appending
+func NewRotator(path string, options RotateOptions) (*Rotator, error) {
+	if options.MaxSize < 0 || options.Interval < 0 || options.MaxBackups < 0 || options.MaxAge < 0 {
+		return nil, errors.New("rotation options cannot be negative")
+	}
+	if options.Perm == 0 {
+		options.Perm = 0644
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	r := &Rotator{path: path, options: options}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write writes p to the file, rotating it first if p would exceed MaxSize
+// or the rotation interval has passed
+func (r *Rotator) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.dueForRotation(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file immediately
+func (r *Rotator) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	return r.rotate()
+}
+
+// Sync commits the file contents to stable storage
+func (r *Rotator) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	return r.file.Sync()
+}
+
+// Close closes the file and waits for background compression and retention
+func (r *Rotator) Close() error {
+	r.mu.Lock()
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.mu.Unlock()
+
+	r.millWG.Wait()
+	return err
+}
+
+// Backups returns the rotated files of the rotator, oldest first
+func (r *Rotator) Backups() ([]string, error) {
+	backups, err := r.listBackups()
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(backups))
+	for i, backup := range backups {
+		paths[i] = backup.path
+	}
+	return paths, nil
+}
+
+// open opens the file for appending and records its size and age
+func (r *Rotator) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, r.options.Perm)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", r.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat %s: %w", r.path, err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	r.openedAt = r.options.Now()
+	if info.Size() > 0 {
+		// An existing file belongs to the period it was last written in
+		r.openedAt = info.ModTime()
+	}
+	return nil
+}
+
+// dueForRotation reports whether writing n bytes requires a rotation first
+func (r *Rotator) dueForRotation(n int64) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.options.MaxSize > 0 && r.size+n > r.options.MaxSize {
+		return true
+	}
+	if r.options.Interval > 0 {
+		return !r.options.Now().Truncate(r.options.Interval).Equal(r.openedAt.Truncate(r.options.Interval))
+	}
+	return false
+}
+
+// rotate renames the current file, opens a new one, and starts compression
+// and retention in the background
+func (r *Rotator) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s for rotation: %w", r.path, err)
+	}
+	r.file = nil
+
+	backup := r.backupPath(r.options.Now())
+	if err := os.Rename(r.path, backup); err != nil && !os.IsNotExist(err) {
+		// Keep writing to the current file rather than losing output
+		if openErr := r.open(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate %s: %w", r.path, err)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.millWG.Add(1)
+	go func() {
+		defer r.millWG.Done()
+		r.millMu.Lock()
+		defer r.millMu.Unlock()
+
+		// Failures leave the rotated file uncompressed
+		if r.options.Compress {
+			compressFile(backup)
+		}
+		r.removeExpiredBackups()
+	}()
+	return nil
+}
+
+// backupPath returns an unused path for a file rotated at t
+func (r *Rotator) backupPath(t time.Time) string {
+	ext := filepath.Ext(r.path)
+	stem := strings.TrimSuffix(r.path, ext)
+	for {
+		path := stem + "-" + t.UTC().Format(rotateTimeLayout) + ext
+		if !Exists(path) && !Exists(path+".gz") {
+			return path
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// rotatedFile is a rotated file with the time it was rotated
+type rotatedFile struct {
+	path      string
+	rotatedAt time.Time
+}
+
+// listBackups returns the rotated files, oldest first
+func (r *Rotator) listBackups() ([]rotatedFile, error) {
+	dir := filepath.Dir(r.path)
+	ext := filepath.Ext(r.path)
+	prefix := strings.TrimSuffix(filepath.Base(r.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []rotatedFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(strings.TrimSuffix(name, ".gz"), prefix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		rotatedAt, err := time.Parse(rotateTimeLayout, strings.TrimSuffix(stamp, ext))
+		if err != nil {
+			continue
+		}
+		backups = append(backups, rotatedFile{path: filepath.Join(dir, name), rotatedAt: rotatedAt})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].rotatedAt.Before(backups[j].rotatedAt) })
+	return backups, nil
+}
+
+// removeExpiredBackups applies MaxBackups and MaxAge
+func (r *Rotator) removeExpiredBackups() {
+	if r.options.MaxBackups == 0 && r.options.MaxAge == 0 {
+		return
+	}
+	backups, err := r.listBackups()
+	if err != nil {
+		return
+	}
+
+	cutoff := r.options.Now().Add(-r.options.MaxAge)
+	for i, backup := range backups {
+		tooMany := r.options.MaxBackups > 0 && len(backups)-i > r.options.MaxBackups
+		tooOld := r.options.MaxAge > 0 && backup.rotatedAt.Before(cutoff)
+		if tooMany || tooOld {
+			os.Remove(backup.path)
+		}
+	}
+}
+
+// compressFile gzips path to path.gz and removes path on success
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	err = WriteAtomicFunc(path+".gz", info.Mode().Perm(), func(w io.Writer) error {
+		gz := gzip.NewWriter(w)
+		gz.Name = filepath.Base(path)
+		gz.ModTime = info.ModTime()
+		if _, err := io.Copy(gz, src); err != nil {
+			return err
+		}
+		return gz.Close()
+	})
+	if err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
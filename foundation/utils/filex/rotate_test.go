@@ -0,0 +1,156 @@
+// File: rotate_test.go
+// Title: Rotating File Writer Tests
+// Description: Tests size and interval rotation, gzip compression of rotated
+//              files, and retention by count and age.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial rotator tests
+
+package filex
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rotateClock is an adjustable clock for rotation tests
+type rotateClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *rotateClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *rotateClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRotator_Size(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	clock := &rotateClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	r, err := NewRotator(path, RotateOptions{MaxSize: 10, Now: clock.Now})
+	if err != nil {
+		t.Fatalf("NewRotator() error = %v", err)
+	}
+
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		clock.advance(time.Second)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	backups, err := r.Backups()
+	if err != nil || len(backups) != 2 {
+		t.Fatalf("Backups() = %v, %v; want 2 files", backups, err)
+	}
+	if filepath.Base(backups[0]) != "app-20261016T120001.000.log" {
+		t.Errorf("backup name = %s", filepath.Base(backups[0]))
+	}
+	for i, want := range []string{"first\n", "second\n"} {
+		if content, _ := os.ReadFile(backups[i]); string(content) != want {
+			t.Errorf("backup %d = %q, want %q", i, content, want)
+		}
+	}
+	if content, _ := os.ReadFile(path); string(content) != "third\n" {
+		t.Errorf("current file = %q", content)
+	}
+
+	// Writes after Close fail
+	if _, err := r.Write([]byte("late")); err == nil {
+		t.Error("Write() after Close() succeeded")
+	}
+}
+
+func TestRotator_IntervalAndCompression(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	clock := &rotateClock{now: time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)}
+	r, err := NewRotator(path, RotateOptions{Interval: time.Hour, Compress: true, Now: clock.Now})
+	if err != nil {
+		t.Fatalf("NewRotator() error = %v", err)
+	}
+
+	r.Write([]byte("12:30\n"))
+	clock.advance(20 * time.Minute)
+	r.Write([]byte("12:50\n"))
+	clock.advance(20 * time.Minute) // Crosses 13:00
+	r.Write([]byte("13:10\n"))
+	r.Close()
+
+	backups, _ := r.Backups()
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".log.gz") {
+		t.Fatalf("Backups() = %v, want one compressed file", backups)
+	}
+	file, err := os.Open(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	if content, _ := io.ReadAll(gz); string(content) != "12:30\n12:50\n" {
+		t.Errorf("decompressed backup = %q", content)
+	}
+	if Exists(strings.TrimSuffix(backups[0], ".gz")) {
+		t.Error("uncompressed backup was not removed")
+	}
+}
+
+func TestRotator_Retention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	clock := &rotateClock{now: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}
+	r, err := NewRotator(path, RotateOptions{MaxBackups: 3, MaxAge: 36 * time.Hour, Now: clock.Now})
+	if err != nil {
+		t.Fatalf("NewRotator() error = %v", err)
+	}
+	// Unrelated files are never removed
+	os.WriteFile(filepath.Join(dir, "app-notes.log"), []byte("keep"), 0644)
+
+	for i := 0; i < 5; i++ {
+		r.Write([]byte("entry\n"))
+		if err := r.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		clock.advance(time.Hour)
+	}
+	r.Close()
+	if backups, _ := r.Backups(); len(backups) != 3 {
+		t.Errorf("Backups() after count limit = %v", backups)
+	}
+
+	// Rotated files older than MaxAge are removed at the next rotation
+	r, _ = NewRotator(path, RotateOptions{MaxBackups: 3, MaxAge: 36 * time.Hour, Now: clock.Now})
+	clock.advance(36 * time.Hour)
+	r.Write([]byte("entry\n"))
+	r.Rotate()
+	r.Close()
+	if backups, _ := r.Backups(); len(backups) != 1 {
+		t.Errorf("Backups() after age limit = %v", backups)
+	}
+	if !Exists(filepath.Join(dir, "app-notes.log")) {
+		t.Error("unrelated file was removed")
+	}
+}
@@ -0,0 +1,25 @@
+// File: security_other.go
+// Title: File Ownership (Unsupported Platforms)
+// Description: Fallback for platforms without Unix file ownership, such as
+//              Windows, where ownership is reported as unknown.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial fallback implementation
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package filex
+
+import "os"
+
+// ownershipSupported reports whether fileOwner and chown are available
+const ownershipSupported = false
+
+// fileOwner returns unknown ownership on this platform
+func fileOwner(info os.FileInfo) Ownership {
+	return KeepOwnership
+}
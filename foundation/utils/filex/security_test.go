@@ -0,0 +1,171 @@
+// File: security_test.go
+// Title: Secure Deletion and Permission Hardening Tests
+// Description: Tests for Shred, EnsurePermissions, IsWorldWritable, and
+//              FindWorldWritable.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial security helper tests
+
+package filex
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// skipWithoutUnixPermissions skips tests that depend on Unix mode bits
+func skipWithoutUnixPermissions(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("Unix permission bits are not supported on " + runtime.GOOS)
+	}
+}
+
+func TestShred(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret.key")
+	if err := os.WriteFile(path, []byte("top secret key material"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Shred(path, 2); err != nil {
+		t.Fatalf("Shred() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("Shred() left entries behind: %v", entries)
+	}
+
+	if err := Shred(dir, 0); err == nil {
+		t.Error("Shred() on a directory should fail")
+	}
+	if err := Shred(path, 1); err == nil {
+		t.Error("Shred() on a missing file should fail")
+	}
+
+	empty := filepath.Join(dir, "empty")
+	os.WriteFile(empty, nil, 0600)
+	if err := Shred(empty, 0); err != nil || Exists(empty) {
+		t.Errorf("Shred() on empty file error = %v", err)
+	}
+}
+
+func TestEnsurePermissions(t *testing.T) {
+	skipWithoutUnixPermissions(t)
+	root := t.TempDir()
+	sub := filepath.Join(root, "conf")
+	os.MkdirAll(sub, 0777)
+	os.Chmod(sub, 0777)
+	file := filepath.Join(sub, "app.yaml")
+	os.WriteFile(file, []byte("x"), 0644)
+	os.Chmod(file, 0666)
+	symlinkOrSkip(t, "/etc/passwd", filepath.Join(sub, "link"))
+
+	// Dry run reports without changing anything
+	report, err := EnsurePermissions(root, 0640, KeepOwnership, PermissionOptions{Recursive: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("EnsurePermissions() error = %v", err)
+	}
+	if report.Checked != 4 || len(report.Skipped) != 1 || len(report.Changes) != 3 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	if info, _ := os.Stat(file); info.Mode().Perm() != 0666 {
+		t.Errorf("dry run changed mode to %v", info.Mode().Perm())
+	}
+
+	report, err = EnsurePermissions(root, 0640, KeepOwnership, PermissionOptions{Recursive: true})
+	if err != nil || report.Err() != nil {
+		t.Fatalf("EnsurePermissions() error = %v, %v", err, report.Err())
+	}
+	if info, _ := os.Stat(file); info.Mode().Perm() != 0640 {
+		t.Errorf("file mode = %v, want 0640", info.Mode().Perm())
+	}
+	if info, _ := os.Stat(sub); info.Mode().Perm() != 0750 {
+		t.Errorf("directory mode = %v, want derived 0750", info.Mode().Perm())
+	}
+
+	// A second run finds nothing to change
+	report, _ = EnsurePermissions(root, 0640, KeepOwnership, PermissionOptions{Recursive: true})
+	if len(report.Changes) != 0 {
+		t.Errorf("second run changes = %+v", report.Changes)
+	}
+
+	// Non-recursive with ownership set to the current owner
+	current := Ownership{UID: os.Getuid(), GID: os.Getgid()}
+	report, err = EnsurePermissions(file, 0600, current)
+	if err != nil || report.Checked != 1 || len(report.Changes) != 1 || report.Changes[0].OwnerChanged {
+		t.Errorf("EnsurePermissions() single file = %+v, %v", report, err)
+	}
+
+	if _, err := EnsurePermissions(file, os.ModeDir|0755, KeepOwnership); err == nil {
+		t.Error("EnsurePermissions() with type bits should fail")
+	}
+	if _, err := EnsurePermissions(filepath.Join(root, "missing"), 0600, KeepOwnership); err == nil {
+		t.Error("EnsurePermissions() on a missing path should fail")
+	}
+}
+
+func TestWorldWritable(t *testing.T) {
+	skipWithoutUnixPermissions(t)
+	root := t.TempDir()
+	open := filepath.Join(root, "open.txt")
+	safe := filepath.Join(root, "safe.txt")
+	shared := filepath.Join(root, "shared")
+	sticky := filepath.Join(root, "sticky")
+	os.WriteFile(open, nil, 0644)
+	os.WriteFile(safe, nil, 0644)
+	os.Mkdir(shared, 0755)
+	os.Mkdir(sticky, 0755)
+	os.Chmod(open, 0666)
+	os.Chmod(shared, 0777)
+	os.Chmod(sticky, os.ModeSticky|0777)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{open, true},
+		{safe, false},
+		{shared, true},
+	}
+	for _, tt := range tests {
+		got, err := IsWorldWritable(tt.path)
+		if err != nil || got != tt.want {
+			t.Errorf("IsWorldWritable(%s) = %v, %v; want %v", filepath.Base(tt.path), got, err, tt.want)
+		}
+	}
+	if _, err := IsWorldWritable(filepath.Join(root, "missing")); err == nil {
+		t.Error("IsWorldWritable() on a missing path should fail")
+	}
+
+	findings, err := FindWorldWritable(root)
+	if err != nil {
+		t.Fatalf("FindWorldWritable() error = %v", err)
+	}
+	issues := map[string]string{}
+	for _, finding := range findings {
+		issues[filepath.Base(finding.Path)] = finding.Issue
+	}
+	want := map[string]string{
+		"open.txt": "world-writable file",
+		"shared":   "world-writable directory without sticky bit",
+		"sticky":   "world-writable directory (sticky)",
+	}
+	if len(issues) != len(want) {
+		t.Errorf("FindWorldWritable() = %v, want %v", issues, want)
+	}
+	for name, issue := range want {
+		if issues[name] != issue {
+			t.Errorf("finding for %s = %q, want %q", name, issues[name], issue)
+		}
+	}
+	if findings[0].Owner.UID != os.Getuid() {
+		t.Errorf("finding owner = %+v, want uid %d", findings[0].Owner, os.Getuid())
+	}
+}
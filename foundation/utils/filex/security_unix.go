@@ -0,0 +1,30 @@
+// File: security_unix.go
+// Title: File Ownership (Unix)
+// Description: Reads file ownership from the stat data of Unix systems.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial Stat_t based implementation
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package filex
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownershipSupported reports whether fileOwner and chown are available
+const ownershipSupported = true
+
+// fileOwner returns the owner of a file from its stat data
+func fileOwner(info os.FileInfo) Ownership {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return Ownership{UID: int(stat.Uid), GID: int(stat.Gid)}
+	}
+	return KeepOwnership
+}
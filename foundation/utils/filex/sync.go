@@ -0,0 +1,597 @@
+// File: sync.go
+// Title: Directory Synchronization
+// Description: Implements Sync, a directory synchronization engine supporting
+//              mirror, update-only, and two-way modes with delete propagation,
+//              exclude patterns, dry runs, and a structured change report.
+//              Two-way deletions are detected with a small state file that
+//              records the paths present after the previous run.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of Sync
+
+package filex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ===============================
+// Directory Synchronization
+// ===============================
+
+// SyncMode selects how Sync reconciles two directory trees
+type SyncMode int
+
+const (
+	SyncMirror     SyncMode = iota // Make dst an exact copy of src
+	SyncUpdateOnly                 // Copy files missing in dst or newer in src
+	SyncTwoWay                     // Propagate changes in both directions, newer wins
+)
+
+// String returns the string representation of the mode
+func (m SyncMode) String() string {
+	switch m {
+	case SyncMirror:
+		return "mirror"
+	case SyncUpdateOnly:
+		return "update-only"
+	case SyncTwoWay:
+		return "two-way"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncChangeKind classifies an entry of a sync report
+type SyncChangeKind int
+
+const (
+	SyncAdded    SyncChangeKind = iota // Created on the target side
+	SyncUpdated                        // Replaced on the target side
+	SyncDeleted                        // Removed from the target side
+	SyncConflict                       // Both sides changed; left untouched
+)
+
+// String returns the string representation of the change kind
+func (k SyncChangeKind) String() string {
+	switch k {
+	case SyncAdded:
+		return "added"
+	case SyncUpdated:
+		return "updated"
+	case SyncDeleted:
+		return "deleted"
+	case SyncConflict:
+		return "conflict"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncDirection tells which side a change was applied to
+type SyncDirection int
+
+const (
+	SyncToDestination SyncDirection = iota // Change applied in dst
+	SyncToSource                           // Change applied in src (two-way only)
+)
+
+// String returns the string representation of the direction
+func (d SyncDirection) String() string {
+	if d == SyncToSource {
+		return "to-source"
+	}
+	return "to-destination"
+}
+
+// SyncChange describes one planned or applied change
+type SyncChange struct {
+	Path      string         // Path relative to both roots
+	Kind      SyncChangeKind // What happened
+	Direction SyncDirection  // Side the change was applied to
+	IsDir     bool           // Whether the entry is a directory
+	Size      int64          // Bytes transferred for additions and updates
+	Err       error          // Failure applying the change (nil on success)
+}
+
+// SyncReport is the structured result of a Sync run
+type SyncReport struct {
+	Mode             SyncMode     // Mode used
+	Changes          []SyncChange // All changes, sorted by path
+	Added            int          // Entries created
+	Updated          int          // Files replaced
+	Deleted          int          // Entries removed
+	Conflicts        int          // Files changed on both sides
+	Failed           int          // Changes that could not be applied
+	Unchanged        int          // Files already in sync
+	BytesTransferred int64        // Bytes copied (or that would be)
+	DryRun           bool         // Whether the run was a dry run
+}
+
+// HasChanges reports whether the sync changed (or would change) anything
+func (r *SyncReport) HasChanges() bool {
+	return r.Added+r.Updated+r.Deleted > 0
+}
+
+// Err joins all failed changes into one error, or returns nil
+func (r *SyncReport) Err() error {
+	var errs []error
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", change.Kind, change.Path, change.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SyncOptions represents options for directory synchronization
+type SyncOptions struct {
+	Context        context.Context // Cancellation context (nil = background)
+	Mode           SyncMode        // Synchronization mode
+	Delete         bool            // Propagate deletions (see Sync)
+	Exclude        []string        // Glob patterns matched against names and relative paths
+	DryRun         bool            // Report changes without applying them
+	CompareContent bool            // Compare equal-sized files by hash instead of time
+	ModTimeWindow  time.Duration   // Tolerated timestamp difference (e.g. 2s for FAT)
+	PreserveMode   bool            // Preserve file permissions on copies
+	StateFile      string          // Two-way state file (default: dst/.filex-sync.json)
+}
+
+// DefaultSyncOptions returns default options for synchronization
+func DefaultSyncOptions() SyncOptions {
+	return SyncOptions{
+		Context:      context.Background(),
+		Mode:         SyncMirror,
+		PreserveMode: true,
+	}
+}
+
+// defaultSyncStateFile is the state file name used for two-way syncs
+const defaultSyncStateFile = ".filex-sync.json"
+
+// syncEntry is a scanned file or directory
+type syncEntry struct {
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+// syncState is the persisted two-way state
+type syncState struct {
+	Version int      `json:"version"`
+	Paths   []string `json:"paths"`
+}
+
+// Sync reconciles dst with src according to the options.
+//
+// In mirror mode dst is updated to match src; with Delete, files and empty
+// directories that exist only in dst are removed. Update-only mode copies
+// files that are missing in dst or newer in src and never deletes. Two-way
+// mode copies in both directions with the newer file winning; files with
+// equal timestamps but different content are reported as conflicts. With
+// Delete, a file that is missing on one side but was present after the
+// previous two-way run is deleted from the other side; this requires the
+// state file written by that run.
+//
+// Copies are atomic and preserve modification times so that subsequent runs
+// detect unchanged files. Per-entry failures are collected in the report;
+// the returned error is reserved for invalid arguments, scan failures, and
+// cancellation.
+func Sync(src, dst string, options ...SyncOptions) (*SyncReport, error) {
+	opts := DefaultSyncOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+
+	if !IsDir(src) {
+		return nil, fmt.Errorf("source is not a directory: %s", src)
+	}
+	if Exists(dst) && !IsDir(dst) {
+		return nil, fmt.Errorf("destination is not a directory: %s", dst)
+	}
+	for _, pattern := range opts.Exclude {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	stateFile := opts.StateFile
+	if stateFile == "" {
+		stateFile = filepath.Join(dst, defaultSyncStateFile)
+	}
+
+	srcEntries, err := scanSyncTree(src, opts.Exclude, stateFile)
+	if err != nil {
+		return nil, err
+	}
+	dstEntries, err := scanSyncTree(dst, opts.Exclude, stateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous map[string]bool
+	if opts.Mode == SyncTwoWay && opts.Delete {
+		if previous, err = loadSyncState(stateFile); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &SyncReport{Mode: opts.Mode, DryRun: opts.DryRun}
+	s := &syncer{src: src, dst: dst, opts: opts, report: report, previous: previous}
+
+	if !opts.DryRun {
+		if err := os.MkdirAll(dst, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create destination %s: %w", dst, err)
+		}
+	}
+
+	paths := unionSyncPaths(srcEntries, dstEntries)
+	for _, path := range paths {
+		if err := opts.Context.Err(); err != nil {
+			return report, err
+		}
+		s.reconcile(path, srcEntries[path], dstEntries[path])
+	}
+	s.removeDirs()
+
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		return report.Changes[i].Path < report.Changes[j].Path
+	})
+
+	if opts.Mode == SyncTwoWay && !opts.DryRun && report.Failed == 0 {
+		if err := saveSyncState(stateFile, src, dst, opts.Exclude); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// syncer carries the state of one Sync run
+type syncer struct {
+	src, dst    string
+	opts        SyncOptions
+	report      *SyncReport
+	previous    map[string]bool // Paths present after the last two-way run
+	pendingDirs []string        // Directories to remove once files are gone
+}
+
+// reconcile decides and applies the change for one relative path
+func (s *syncer) reconcile(path string, srcEntry, dstEntry *syncEntry) {
+	twoWay := s.opts.Mode == SyncTwoWay
+
+	switch {
+	case srcEntry != nil && dstEntry != nil:
+		if srcEntry.isDir || dstEntry.isDir {
+			if srcEntry.isDir != dstEntry.isDir {
+				s.record(SyncChange{Path: path, Kind: SyncConflict})
+			}
+			return
+		}
+		s.reconcileFiles(path, srcEntry, dstEntry)
+
+	case srcEntry != nil:
+		if twoWay && s.opts.Delete && s.previous[path] {
+			s.remove(path, srcEntry.isDir, SyncToSource)
+			return
+		}
+		s.copy(path, srcEntry, SyncAdded, SyncToDestination)
+
+	case dstEntry != nil:
+		switch {
+		case twoWay && s.opts.Delete && s.previous[path]:
+			s.remove(path, dstEntry.isDir, SyncToDestination)
+		case twoWay:
+			s.copy(path, dstEntry, SyncAdded, SyncToSource)
+		case s.opts.Mode == SyncMirror && s.opts.Delete:
+			s.remove(path, dstEntry.isDir, SyncToDestination)
+		}
+	}
+}
+
+// reconcileFiles handles a regular file present on both sides
+func (s *syncer) reconcileFiles(path string, srcEntry, dstEntry *syncEntry) {
+	differs, err := s.differs(path, srcEntry, dstEntry)
+	if err != nil {
+		s.record(SyncChange{Path: path, Kind: SyncUpdated, Err: err})
+		return
+	}
+	if !differs {
+		s.report.Unchanged++
+		return
+	}
+
+	delta := srcEntry.modTime.Sub(dstEntry.modTime)
+	srcNewer := delta > s.opts.ModTimeWindow
+	dstNewer := -delta > s.opts.ModTimeWindow
+
+	switch s.opts.Mode {
+	case SyncMirror:
+		s.copy(path, srcEntry, SyncUpdated, SyncToDestination)
+	case SyncUpdateOnly:
+		if srcNewer {
+			s.copy(path, srcEntry, SyncUpdated, SyncToDestination)
+		} else {
+			s.report.Unchanged++
+		}
+	case SyncTwoWay:
+		switch {
+		case srcNewer:
+			s.copy(path, srcEntry, SyncUpdated, SyncToDestination)
+		case dstNewer:
+			s.copy(path, dstEntry, SyncUpdated, SyncToSource)
+		default:
+			s.record(SyncChange{Path: path, Kind: SyncConflict, Size: srcEntry.size})
+		}
+	}
+}
+
+// differs compares two files by size and either timestamp or content
+func (s *syncer) differs(path string, srcEntry, dstEntry *syncEntry) (bool, error) {
+	if srcEntry.size != dstEntry.size {
+		return true, nil
+	}
+	if s.opts.CompareContent {
+		same, err := sameSizeAndHash(filepath.Join(s.src, path), filepath.Join(s.dst, path))
+		return !same, err
+	}
+	delta := srcEntry.modTime.Sub(dstEntry.modTime)
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta > s.opts.ModTimeWindow, nil
+}
+
+// copy transfers a file or creates a directory on the target side
+func (s *syncer) copy(path string, entry *syncEntry, kind SyncChangeKind, direction SyncDirection) {
+	from, to := s.src, s.dst
+	if direction == SyncToSource {
+		from, to = s.dst, s.src
+	}
+
+	change := SyncChange{Path: path, Kind: kind, Direction: direction, IsDir: entry.isDir}
+	if !entry.isDir {
+		change.Size = entry.size
+	}
+
+	if !s.opts.DryRun {
+		if entry.isDir {
+			change.Err = os.MkdirAll(filepath.Join(to, path), 0755)
+		} else {
+			change.Err = CopyAtomic(filepath.Join(from, path), filepath.Join(to, path), FileCopyOptions{
+				PreserveMode:    s.opts.PreserveMode,
+				PreserveTime:    true,
+				CreateDirs:      true,
+				OverwriteTarget: true,
+			})
+		}
+	}
+	s.record(change)
+}
+
+// remove deletes a file on the target side; directories are deferred until
+// all files have been processed
+func (s *syncer) remove(path string, isDir bool, direction SyncDirection) {
+	if isDir {
+		root := s.dst
+		if direction == SyncToSource {
+			root = s.src
+		}
+		s.pendingDirs = append(s.pendingDirs, filepath.Join(root, path))
+		return
+	}
+
+	change := SyncChange{Path: path, Kind: SyncDeleted, Direction: direction}
+	if !s.opts.DryRun {
+		root := s.dst
+		if direction == SyncToSource {
+			root = s.src
+		}
+		if err := os.Remove(filepath.Join(root, path)); err != nil && !os.IsNotExist(err) {
+			change.Err = err
+		}
+	}
+	s.record(change)
+}
+
+// removeDirs removes deferred directories deepest first if they are empty
+func (s *syncer) removeDirs() {
+	sort.Slice(s.pendingDirs, func(i, j int) bool { return len(s.pendingDirs[i]) > len(s.pendingDirs[j]) })
+	for _, dir := range s.pendingDirs {
+		rel, direction := s.relative(dir)
+		if !s.opts.DryRun {
+			if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+				continue // Still holds excluded or failed files
+			}
+			if err := os.Remove(dir); err != nil {
+				s.record(SyncChange{Path: rel, Kind: SyncDeleted, Direction: direction, IsDir: true, Err: err})
+				continue
+			}
+		}
+		s.record(SyncChange{Path: rel, Kind: SyncDeleted, Direction: direction, IsDir: true})
+	}
+}
+
+// relative maps an absolute pending directory back to its relative path
+func (s *syncer) relative(dir string) (string, SyncDirection) {
+	if rel, err := filepath.Rel(s.dst, dir); err == nil && !startsWithParent(rel) {
+		return rel, SyncToDestination
+	}
+	rel, _ := filepath.Rel(s.src, dir)
+	return rel, SyncToSource
+}
+
+// record appends a change and updates the counters
+func (s *syncer) record(change SyncChange) {
+	s.report.Changes = append(s.report.Changes, change)
+	if change.Err != nil {
+		s.report.Failed++
+		return
+	}
+	switch change.Kind {
+	case SyncAdded:
+		s.report.Added++
+		s.report.BytesTransferred += change.Size
+	case SyncUpdated:
+		s.report.Updated++
+		s.report.BytesTransferred += change.Size
+	case SyncDeleted:
+		s.report.Deleted++
+	case SyncConflict:
+		s.report.Conflicts++
+	}
+}
+
+// scanSyncTree lists regular files and directories below root. A missing
+// root yields an empty tree.
+func scanSyncTree(root string, exclude []string, stateFile string) (map[string]*syncEntry, error) {
+	entries := make(map[string]*syncEntry)
+	if !Exists(root) {
+		return entries, nil
+	}
+
+	absState, _ := filepath.Abs(stateFile)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if isExcluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if abs, _ := filepath.Abs(path); abs == absState {
+			return nil
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil // Symlinks and special files are not synchronized
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		entries[rel] = &syncEntry{size: info.Size(), modTime: info.ModTime(), isDir: d.IsDir()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	return entries, nil
+}
+
+// unionSyncPaths returns all relative paths of both trees, parents first
+func unionSyncPaths(a, b map[string]*syncEntry) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var paths []string
+	for _, entries := range []map[string]*syncEntry{a, b} {
+		for path := range entries {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// loadSyncState reads the paths recorded by the previous two-way run. A
+// missing state file means there is no previous run.
+func loadSyncState(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to read sync state %s: %w", path, err)
+	}
+
+	var state syncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", path, err)
+	}
+
+	previous := make(map[string]bool, len(state.Paths))
+	for _, p := range state.Paths {
+		previous[filepath.FromSlash(p)] = true
+	}
+	return previous, nil
+}
+
+// saveSyncState records the paths present in both trees after a run
+func saveSyncState(path, src, dst string, exclude []string) error {
+	srcEntries, err := scanSyncTree(src, exclude, path)
+	if err != nil {
+		return err
+	}
+	dstEntries, err := scanSyncTree(dst, exclude, path)
+	if err != nil {
+		return err
+	}
+
+	state := syncState{Version: 1}
+	for rel := range srcEntries {
+		if _, ok := dstEntries[rel]; ok {
+			state.Paths = append(state.Paths, filepath.ToSlash(rel))
+		}
+	}
+	sort.Strings(state.Paths)
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync state %s: %w", path, err)
+	}
+	return nil
+}
+
+// FormatSyncReport renders a report as one line per change, suitable for
+// logs and CLI output
+func FormatSyncReport(report *SyncReport) string {
+	var b strings.Builder
+	for _, change := range report.Changes {
+		fmt.Fprintf(&b, "%-8s %-14s %s", change.Kind, change.Direction, filepath.ToSlash(change.Path))
+		if change.IsDir {
+			b.WriteString("/")
+		}
+		if change.Err != nil {
+			fmt.Fprintf(&b, " (error: %v)", change.Err)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d added, %d updated, %d deleted, %d conflicts, %d failed, %d unchanged",
+		report.Added, report.Updated, report.Deleted, report.Conflicts, report.Failed, report.Unchanged)
+	return b.String()
+}
@@ -0,0 +1,218 @@
+// File: sync_test.go
+// Title: Directory Synchronization Tests
+// Description: Tests for Sync covering mirror, update-only, and two-way modes,
+//              delete propagation, conflicts, excludes, and dry runs.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial synchronization tests
+
+package filex
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setModTime sets the modification time of a file relative to a fixed base
+func setModTime(t *testing.T, path string, offset time.Duration) {
+	t.Helper()
+	modTime := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC).Add(offset)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSync_Mirror(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	createTree(t, src, map[string]string{"a.txt": "new a", "sub/b.txt": "b", "skip.tmp": "tmp"})
+	createTree(t, dst, map[string]string{"a.txt": "old", "sub/b.txt": "b", "extra/c.txt": "c"})
+	setModTime(t, filepath.Join(src, "sub", "b.txt"), 0)
+	setModTime(t, filepath.Join(dst, "sub", "b.txt"), 0)
+
+	opts := DefaultSyncOptions()
+	opts.Exclude = []string{"*.tmp"}
+	opts.DryRun = true
+
+	report, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() dry run error = %v", err)
+	}
+	if report.Updated != 1 || report.Deleted != 0 || report.Unchanged != 1 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	assertTree(t, dst, map[string]string{"a.txt": "old"})
+
+	opts.DryRun = false
+	opts.Delete = true
+	report, err = Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	assertTree(t, dst, map[string]string{"a.txt": "new a", "sub/b.txt": "b"})
+	if Exists(filepath.Join(dst, "extra")) || Exists(filepath.Join(dst, "skip.tmp")) {
+		t.Error("mirror left extraneous or excluded entries in the destination")
+	}
+	if report.Updated != 1 || report.Deleted != 2 || report.Failed != 0 {
+		t.Errorf("mirror report = %+v\n%s", report, FormatSyncReport(report))
+	}
+
+	// A second run finds nothing to do
+	report, err = Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() second run error = %v", err)
+	}
+	if report.HasChanges() {
+		t.Errorf("second mirror run reported changes:\n%s", FormatSyncReport(report))
+	}
+}
+
+func TestSync_UpdateOnly(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	createTree(t, src, map[string]string{"newer.txt": "src newer", "older.txt": "src older", "added.txt": "added"})
+	createTree(t, dst, map[string]string{"newer.txt": "dst", "older.txt": "dst newer", "only.txt": "only"})
+	setModTime(t, filepath.Join(src, "newer.txt"), time.Hour)
+	setModTime(t, filepath.Join(dst, "newer.txt"), 0)
+	setModTime(t, filepath.Join(src, "older.txt"), 0)
+	setModTime(t, filepath.Join(dst, "older.txt"), time.Hour)
+
+	opts := DefaultSyncOptions()
+	opts.Mode = SyncUpdateOnly
+	opts.Delete = true // Ignored in update-only mode
+
+	report, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	assertTree(t, dst, map[string]string{
+		"newer.txt": "src newer",
+		"older.txt": "dst newer",
+		"added.txt": "added",
+		"only.txt":  "only",
+	})
+	if report.Added != 1 || report.Updated != 1 || report.Deleted != 0 {
+		t.Errorf("update-only report = %+v", report)
+	}
+}
+
+func TestSync_TwoWay(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	createTree(t, src, map[string]string{"shared.txt": "v1", "from_src.txt": "s", "gone.txt": "g"})
+	createTree(t, dst, map[string]string{"from_dst.txt": "d"})
+
+	opts := DefaultSyncOptions()
+	opts.Mode = SyncTwoWay
+	opts.Delete = true
+
+	if _, err := Sync(src, dst, opts); err != nil {
+		t.Fatalf("Sync() initial error = %v", err)
+	}
+	both := map[string]string{"shared.txt": "v1", "from_src.txt": "s", "from_dst.txt": "d", "gone.txt": "g"}
+	assertTree(t, src, both)
+	assertTree(t, dst, both)
+	if !Exists(filepath.Join(dst, defaultSyncStateFile)) {
+		t.Fatal("two-way sync did not write its state file")
+	}
+
+	// Delete on one side, modify on the other
+	if err := os.Remove(filepath.Join(dst, "gone.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, "shared.txt"), []byte("v2 from dst"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setModTime(t, filepath.Join(src, "shared.txt"), 0)
+	setModTime(t, filepath.Join(dst, "shared.txt"), time.Hour)
+
+	report, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if Exists(filepath.Join(src, "gone.txt")) {
+		t.Error("deletion was not propagated to the source")
+	}
+	assertTree(t, src, map[string]string{"shared.txt": "v2 from dst"})
+	if report.Deleted != 1 || report.Updated != 1 {
+		t.Errorf("two-way report = %+v\n%s", report, FormatSyncReport(report))
+	}
+	for _, change := range report.Changes {
+		if change.Direction != SyncToSource {
+			t.Errorf("change %s %s applied %s, want to-source", change.Kind, change.Path, change.Direction)
+		}
+	}
+}
+
+func TestSync_TwoWayConflict(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	createTree(t, src, map[string]string{"doc.txt": "src"})
+	createTree(t, dst, map[string]string{"doc.txt": "dst edit"})
+	setModTime(t, filepath.Join(src, "doc.txt"), 0)
+	setModTime(t, filepath.Join(dst, "doc.txt"), time.Second)
+
+	opts := DefaultSyncOptions()
+	opts.Mode = SyncTwoWay
+	opts.ModTimeWindow = 2 * time.Second
+
+	report, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if report.Conflicts != 1 || report.HasChanges() {
+		t.Errorf("conflict report = %+v", report)
+	}
+	assertTree(t, src, map[string]string{"doc.txt": "src"})
+	assertTree(t, dst, map[string]string{"doc.txt": "dst edit"})
+}
+
+func TestSync_CompareContent(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	createTree(t, src, map[string]string{"same.txt": "abc", "diff.txt": "abc"})
+	createTree(t, dst, map[string]string{"same.txt": "abc", "diff.txt": "xyz"})
+	for _, name := range []string{"same.txt", "diff.txt"} {
+		setModTime(t, filepath.Join(src, name), time.Hour)
+		setModTime(t, filepath.Join(dst, name), 0)
+	}
+
+	opts := DefaultSyncOptions()
+	opts.CompareContent = true
+
+	report, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if report.Updated != 1 || report.Unchanged != 1 {
+		t.Errorf("content comparison report = %+v", report)
+	}
+	if summary := FormatSyncReport(report); !strings.Contains(summary, "updated") || !strings.Contains(summary, "diff.txt") {
+		t.Errorf("FormatSyncReport() = %q", summary)
+	}
+}
+
+func TestSync_Errors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Sync(file, t.TempDir()); err == nil {
+		t.Error("Sync() from a file should fail")
+	}
+	if _, err := Sync(dir, file); err == nil {
+		t.Error("Sync() into a file should fail")
+	}
+	if _, err := Sync(dir, t.TempDir(), SyncOptions{Exclude: []string{"[x"}}); err == nil {
+		t.Error("Sync() with invalid pattern should fail")
+	}
+}
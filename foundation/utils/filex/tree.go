@@ -0,0 +1,469 @@
+// File: tree.go
+// Title: Directory Tree Copy and Move
+// Description: Implements CopyTree and MoveTree for whole directory trees with
+//              per-file retries, resume of interrupted transfers by skipping
+//              destination files with matching size and hash, exclude
+//              patterns, a dry-run mode, progress callbacks, and a result
+//              report that lists failures instead of aborting on the first.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of CopyTree and MoveTree
+
+package filex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ===============================
+// Tree Copy and Move
+// ===============================
+
+// TreeAction describes what happened to a single entry of a tree transfer
+type TreeAction int
+
+const (
+	TreeCopied  TreeAction = iota // Transferred to the destination
+	TreeSkipped                   // Destination already identical
+	TreeIgnored                   // Not a regular file (symlink, device, ...)
+	TreeFailed                    // Transfer failed after all retries
+)
+
+// String returns the string representation of the action
+func (a TreeAction) String() string {
+	switch a {
+	case TreeCopied:
+		return "copied"
+	case TreeSkipped:
+		return "skipped"
+	case TreeIgnored:
+		return "ignored"
+	case TreeFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TreeEntry reports the outcome for one file of a tree transfer
+type TreeEntry struct {
+	Path     string     // Path relative to the source root
+	Size     int64      // Source file size
+	Action   TreeAction // Outcome
+	Attempts int        // Number of transfer attempts made
+	Err      error      // Failure reason for TreeFailed entries
+}
+
+// TreeResult is the report of a CopyTree or MoveTree run
+type TreeResult struct {
+	Entries     []TreeEntry // One entry per source file, in walk order
+	Copied      int         // Files transferred (or that would be in dry-run mode)
+	Skipped     int         // Files already present with identical content
+	Ignored     int         // Non-regular files left untouched
+	Failed      int         // Files that could not be transferred
+	BytesCopied int64       // Bytes transferred (or that would be)
+	DirsCreated int         // Destination directories created
+	DryRun      bool        // Whether the run was a dry run
+}
+
+// Failures returns the failed entries
+func (r *TreeResult) Failures() []TreeEntry {
+	var failures []TreeEntry
+	for _, entry := range r.Entries {
+		if entry.Action == TreeFailed {
+			failures = append(failures, entry)
+		}
+	}
+	return failures
+}
+
+// Err joins all per-file failures into one error, or returns nil
+func (r *TreeResult) Err() error {
+	var errs []error
+	for _, entry := range r.Failures() {
+		errs = append(errs, fmt.Errorf("%s: %w", entry.Path, entry.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// TreeProgress describes the state of a tree transfer after a file
+type TreeProgress struct {
+	Path       string     // File just processed, relative to the source root
+	Action     TreeAction // Outcome for that file
+	FilesDone  int        // Files processed so far
+	FilesTotal int        // Files in the transfer
+	BytesDone  int64      // Source bytes processed so far
+	BytesTotal int64      // Total source bytes
+}
+
+// TreeProgressFunc receives a progress update after every file
+type TreeProgressFunc func(progress TreeProgress)
+
+// TreeOptions represents options for tree copy and move operations
+type TreeOptions struct {
+	Context      context.Context  // Cancellation context (nil = background)
+	PreserveMode bool             // Preserve file permissions
+	PreserveTime bool             // Preserve modification times
+	Overwrite    bool             // Replace destination files that differ
+	Resume       bool             // Skip files with matching size and hash; recopy the rest
+	DryRun       bool             // Report what would happen without writing
+	Exclude      []string         // Glob patterns matched against names and relative paths
+	Retries      int              // Additional attempts per file after a failure
+	RetryDelay   time.Duration    // Delay between attempts
+	BufferSize   int              // Copy buffer size (0 = default)
+	Progress     TreeProgressFunc // Per-file progress callback (optional)
+}
+
+// DefaultTreeOptions returns default options for tree operations
+func DefaultTreeOptions() TreeOptions {
+	return TreeOptions{
+		Context:      context.Background(),
+		PreserveMode: true,
+		PreserveTime: true,
+		Resume:       true,
+		Retries:      2,
+		RetryDelay:   100 * time.Millisecond,
+		BufferSize:   32 * 1024, // 32KB default buffer
+	}
+}
+
+// CopyTree copies the directory tree src into dst. Files are written
+// atomically, so an interrupted run never leaves partial destination files
+// and can be resumed by running it again. Per-file failures are reported in
+// the result; the returned error is reserved for an invalid source or a
+// cancelled context, in which case the partial result is still returned.
+func CopyTree(src, dst string, options ...TreeOptions) (*TreeResult, error) {
+	return transferTree(src, dst, false, options)
+}
+
+// MoveTree moves the directory tree src to dst. When dst does not exist and
+// nothing is excluded, the tree is renamed in one step; otherwise files are
+// copied as with CopyTree and each source file is removed once its
+// destination is verified. Failed files remain in the source tree.
+func MoveTree(src, dst string, options ...TreeOptions) (*TreeResult, error) {
+	return transferTree(src, dst, true, options)
+}
+
+// treePlan lists the contents of a source tree
+type treePlan struct {
+	dirs       []string    // Relative directory paths, parents first
+	files      []TreeEntry // Regular and ignored files
+	totalBytes int64
+}
+
+// transferTree implements CopyTree and MoveTree
+func transferTree(src, dst string, move bool, options []TreeOptions) (*TreeResult, error) {
+	opts := DefaultTreeOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+
+	if !IsDir(src) {
+		return nil, fmt.Errorf("source is not a directory: %s", src)
+	}
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve source %s: %w", src, err)
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve destination %s: %w", dst, err)
+	}
+	if rel, err := filepath.Rel(absSrc, absDst); err == nil && !startsWithParent(rel) {
+		return nil, fmt.Errorf("destination %s is inside source %s", dst, src)
+	}
+
+	plan, err := planTree(src, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TreeResult{DryRun: opts.DryRun}
+
+	if move && !opts.DryRun && len(opts.Exclude) == 0 && !Exists(dst) {
+		if err := MkdirAll(filepath.Dir(dst), 0755); err == nil && os.Rename(src, dst) == nil {
+			result.DirsCreated = len(plan.dirs) + 1
+			for _, entry := range plan.files {
+				entry.Action = TreeCopied // The rename moved every entry
+				entry.Attempts = 1
+				recordTreeEntry(result, entry)
+			}
+			return result, nil
+		}
+	}
+
+	for _, dir := range append([]string{"."}, plan.dirs...) {
+		target := filepath.Join(dst, dir)
+		if IsDir(target) {
+			continue
+		}
+		if !opts.DryRun {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return result, fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+		}
+		result.DirsCreated++
+	}
+
+	var filesDone int
+	var bytesDone int64
+	for _, entry := range plan.files {
+		if err := opts.Context.Err(); err != nil {
+			return result, err
+		}
+
+		if entry.Action != TreeIgnored {
+			entry = transferTreeFile(src, dst, entry, move, opts)
+		}
+		recordTreeEntry(result, entry)
+
+		filesDone++
+		bytesDone += entry.Size
+		if opts.Progress != nil {
+			opts.Progress(TreeProgress{
+				Path:       entry.Path,
+				Action:     entry.Action,
+				FilesDone:  filesDone,
+				FilesTotal: len(plan.files),
+				BytesDone:  bytesDone,
+				BytesTotal: plan.totalBytes,
+			})
+		}
+	}
+
+	if move && !opts.DryRun {
+		removeEmptySourceDirs(src, plan.dirs)
+	}
+
+	return result, nil
+}
+
+// planTree walks src and collects directories and files not excluded
+func planTree(src string, exclude []string) (*treePlan, error) {
+	for _, pattern := range exclude {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	plan := &treePlan{}
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == src {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if isExcluded(rel, exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			plan.dirs = append(plan.dirs, rel)
+			return nil
+		}
+
+		entry := TreeEntry{Path: rel, Action: TreeCopied}
+		if !d.Type().IsRegular() {
+			entry.Action = TreeIgnored
+		} else {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			entry.Size = info.Size()
+			plan.totalBytes += entry.Size
+		}
+		plan.files = append(plan.files, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan source tree %s: %w", src, err)
+	}
+
+	return plan, nil
+}
+
+// transferTreeFile copies (and for moves removes) one file with retries
+func transferTreeFile(src, dst string, entry TreeEntry, move bool, opts TreeOptions) TreeEntry {
+	srcPath := filepath.Join(src, entry.Path)
+	dstPath := filepath.Join(dst, entry.Path)
+
+	for {
+		entry.Attempts++
+		action, err := transferTreeFileOnce(srcPath, dstPath, move, opts)
+		if err == nil {
+			entry.Action = action
+			entry.Err = nil
+			return entry
+		}
+
+		entry.Action = TreeFailed
+		entry.Err = err
+		if entry.Attempts > opts.Retries || !sleepContext(opts.Context, opts.RetryDelay) {
+			return entry
+		}
+	}
+}
+
+// transferTreeFileOnce performs a single transfer attempt
+func transferTreeFileOnce(srcPath, dstPath string, move bool, opts TreeOptions) (TreeAction, error) {
+	action := TreeCopied
+
+	if dstInfo, err := os.Stat(dstPath); err == nil {
+		if dstInfo.IsDir() {
+			return TreeFailed, fmt.Errorf("destination is a directory: %s", dstPath)
+		}
+
+		identical := false
+		if opts.Resume {
+			if identical, err = sameSizeAndHash(srcPath, dstPath); err != nil {
+				return TreeFailed, err
+			}
+		}
+		switch {
+		case identical:
+			action = TreeSkipped
+		case !opts.Overwrite && !opts.Resume:
+			return TreeFailed, fmt.Errorf("destination file exists and overwrite is disabled: %s", dstPath)
+		}
+	}
+
+	if opts.DryRun {
+		return action, nil
+	}
+
+	if action == TreeCopied {
+		err := CopyAtomic(srcPath, dstPath, FileCopyOptions{
+			PreserveMode:    opts.PreserveMode,
+			PreserveTime:    opts.PreserveTime,
+			CreateDirs:      true,
+			OverwriteTarget: true,
+			BufferSize:      opts.BufferSize,
+		})
+		if err != nil {
+			return TreeFailed, err
+		}
+	}
+
+	if move {
+		if err := os.Remove(srcPath); err != nil && !os.IsNotExist(err) {
+			return TreeFailed, fmt.Errorf("failed to remove source after copy: %w", err)
+		}
+	}
+
+	return action, nil
+}
+
+// sameSizeAndHash reports whether two files have equal size and SHA256 hash
+func sameSizeAndHash(path1, path2 string) (bool, error) {
+	info1, err := os.Stat(path1)
+	if err != nil {
+		return false, err
+	}
+	info2, err := os.Stat(path2)
+	if err != nil {
+		return false, err
+	}
+	if info1.Size() != info2.Size() {
+		return false, nil
+	}
+
+	hash1, err := SHA256Hash(path1)
+	if err != nil {
+		return false, err
+	}
+	hash2, err := SHA256Hash(path2)
+	if err != nil {
+		return false, err
+	}
+	return hash1 == hash2, nil
+}
+
+// recordTreeEntry appends an entry and updates the counters
+func recordTreeEntry(result *TreeResult, entry TreeEntry) {
+	result.Entries = append(result.Entries, entry)
+	switch entry.Action {
+	case TreeCopied:
+		result.Copied++
+		result.BytesCopied += entry.Size
+	case TreeSkipped:
+		result.Skipped++
+	case TreeIgnored:
+		result.Ignored++
+	case TreeFailed:
+		result.Failed++
+	}
+}
+
+// removeEmptySourceDirs removes source directories emptied by a move,
+// deepest first, and finally the source root itself
+func removeEmptySourceDirs(src string, dirs []string) {
+	sorted := append([]string(nil), dirs...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, dir := range append(sorted, ".") {
+		path := filepath.Join(src, dir)
+		if entries, err := os.ReadDir(path); err == nil && len(entries) == 0 {
+			os.Remove(path)
+		}
+	}
+}
+
+// isExcluded matches a relative path against exclude patterns, both by base
+// name and by slash-separated relative path
+func isExcluded(rel string, patterns []string) bool {
+	name := filepath.Base(rel)
+	slashRel := filepath.ToSlash(rel)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, slashRel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// startsWithParent reports whether a relative path leaves its base
+func startsWithParent(rel string) bool {
+	return rel == ".." || len(rel) > 2 && rel[:3] == ".."+string(filepath.Separator)
+}
+
+// sleepContext waits for d or until ctx is done; it reports whether the
+// full delay elapsed
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,212 @@
+// File: tree_test.go
+// Title: Directory Tree Copy and Move Tests
+// Description: Tests for CopyTree and MoveTree covering resume, dry-run,
+//              exclude patterns, progress reporting, and failure reports.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial tree transfer tests
+
+package filex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// createTree writes the given relative files below dir
+func createTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// assertTree verifies that dir contains the given files with content
+func assertTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, want := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("missing %s: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+}
+
+var testTreeFiles = map[string]string{
+	"a.txt":           "alpha",
+	"docs/b.txt":      "bravo",
+	"docs/deep/c.txt": "charlie",
+	"cache/tmp.bin":   "scratch",
+}
+
+func TestCopyTree(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+	createTree(t, src, testTreeFiles)
+
+	var progress []TreeProgress
+	opts := DefaultTreeOptions()
+	opts.Progress = func(p TreeProgress) { progress = append(progress, p) }
+
+	result, err := CopyTree(src, dst, opts)
+	if err != nil {
+		t.Fatalf("CopyTree() error = %v", err)
+	}
+	assertTree(t, dst, testTreeFiles)
+
+	if result.Copied != 4 || result.Failed != 0 || result.BytesCopied != 24 {
+		t.Errorf("CopyTree() result = %+v", result)
+	}
+	if result.DirsCreated != 4 {
+		t.Errorf("DirsCreated = %d, want 4", result.DirsCreated)
+	}
+	if len(progress) != 4 || progress[3].FilesDone != 4 || progress[3].BytesDone != progress[3].BytesTotal {
+		t.Errorf("progress = %+v", progress)
+	}
+	assertTree(t, src, testTreeFiles)
+}
+
+func TestCopyTree_Resume(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+	createTree(t, src, testTreeFiles)
+
+	// Simulate an interrupted run: one file complete, one truncated
+	createTree(t, dst, map[string]string{"a.txt": "alpha", "docs/b.txt": "bra"})
+
+	result, err := CopyTree(src, dst)
+	if err != nil {
+		t.Fatalf("CopyTree() error = %v", err)
+	}
+	assertTree(t, dst, testTreeFiles)
+	if result.Skipped != 1 || result.Copied != 3 {
+		t.Errorf("CopyTree() resume copied %d, skipped %d; want 3, 1", result.Copied, result.Skipped)
+	}
+
+	// Without resume or overwrite existing files are reported as failures
+	opts := DefaultTreeOptions()
+	opts.Resume = false
+	opts.Retries = 0
+	result, err = CopyTree(src, dst, opts)
+	if err != nil {
+		t.Fatalf("CopyTree() error = %v", err)
+	}
+	if result.Failed != 4 || len(result.Failures()) != 4 || result.Err() == nil {
+		t.Errorf("CopyTree() without overwrite = %+v", result)
+	}
+	for _, failure := range result.Failures() {
+		if failure.Attempts != 1 || failure.Err == nil {
+			t.Errorf("failure %+v, want one attempt with error", failure)
+		}
+	}
+}
+
+func TestCopyTree_DryRunAndExclude(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+	createTree(t, src, testTreeFiles)
+
+	opts := DefaultTreeOptions()
+	opts.DryRun = true
+	opts.Exclude = []string{"cache", "docs/deep/*"}
+
+	result, err := CopyTree(src, dst, opts)
+	if err != nil {
+		t.Fatalf("CopyTree() dry run error = %v", err)
+	}
+	if Exists(dst) {
+		t.Error("dry run created the destination")
+	}
+	if !result.DryRun || result.Copied != 2 || result.BytesCopied != 10 {
+		t.Errorf("dry run result = %+v, want 2 files and 10 bytes", result)
+	}
+
+	opts.DryRun = false
+	if _, err := CopyTree(src, dst, opts); err != nil {
+		t.Fatalf("CopyTree() error = %v", err)
+	}
+	if Exists(filepath.Join(dst, "cache")) || Exists(filepath.Join(dst, "docs", "deep", "c.txt")) {
+		t.Error("excluded entries were copied")
+	}
+}
+
+func TestCopyTree_Errors(t *testing.T) {
+	src := t.TempDir()
+	createTree(t, src, testTreeFiles)
+
+	if _, err := CopyTree(filepath.Join(src, "a.txt"), t.TempDir()); err == nil {
+		t.Error("CopyTree() from a file should fail")
+	}
+	if _, err := CopyTree(src, filepath.Join(src, "docs", "copy")); err == nil {
+		t.Error("CopyTree() into its own source should fail")
+	}
+	if _, err := CopyTree(src, t.TempDir(), TreeOptions{Exclude: []string{"[x"}}); err == nil {
+		t.Error("CopyTree() with invalid pattern should fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts := DefaultTreeOptions()
+	opts.Context = ctx
+	result, err := CopyTree(src, filepath.Join(t.TempDir(), "dst"), opts)
+	if err != context.Canceled || result == nil || result.Copied != 0 {
+		t.Errorf("CopyTree() cancelled = %+v, %v", result, err)
+	}
+}
+
+func TestMoveTree(t *testing.T) {
+	t.Run("rename", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "src")
+		dst := filepath.Join(t.TempDir(), "nested", "dst")
+		createTree(t, src, testTreeFiles)
+
+		result, err := MoveTree(src, dst)
+		if err != nil {
+			t.Fatalf("MoveTree() error = %v", err)
+		}
+		assertTree(t, dst, testTreeFiles)
+		if Exists(src) || result.Copied != 4 {
+			t.Errorf("MoveTree() rename result = %+v, source exists = %v", result, Exists(src))
+		}
+	})
+
+	t.Run("merge into existing", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "src")
+		dst := filepath.Join(t.TempDir(), "dst")
+		createTree(t, src, testTreeFiles)
+		createTree(t, dst, map[string]string{"a.txt": "alpha", "keep.txt": "keep"})
+
+		opts := DefaultTreeOptions()
+		opts.Exclude = []string{"*.bin"}
+		result, err := MoveTree(src, dst, opts)
+		if err != nil {
+			t.Fatalf("MoveTree() error = %v", err)
+		}
+		assertTree(t, dst, map[string]string{"a.txt": "alpha", "keep.txt": "keep", "docs/deep/c.txt": "charlie"})
+		if result.Skipped != 1 || result.Copied != 2 {
+			t.Errorf("MoveTree() merge result = %+v", result)
+		}
+		if Exists(filepath.Join(src, "docs")) || Exists(filepath.Join(src, "a.txt")) {
+			t.Error("moved files or emptied directories remain in the source")
+		}
+		if !Exists(filepath.Join(src, "cache", "tmp.bin")) {
+			t.Error("excluded file was removed from the source")
+		}
+	})
+}
@@ -0,0 +1,329 @@
+// File: vfs.go
+// Title: Virtual File System Abstraction
+// Description: Defines the writable VFS interface on top of io/fs together
+//              with FS-based variants of the filex helpers. Read-only helpers
+//              accept any fs.FS (embed.FS, fstest.MapFS, os.DirFS), writing
+//              helpers accept a VFS such as OSFS or the in-memory MemFS, so
+//              services can unit-test file logic without touching the disk.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial VFS interface and FS-based helpers
+
+package filex
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// ===============================
+// Virtual File System
+// ===============================
+
+// File is an open file of a VFS. Files opened for reading only return an
+// error from Write.
+type File interface {
+	fs.File
+	io.Writer
+}
+
+// VFS is a writable file system. Names follow the io/fs conventions: they
+// are slash-separated, unrooted, and must satisfy fs.ValidPath.
+type VFS interface {
+	fs.StatFS
+	fs.ReadFileFS
+	fs.ReadDirFS
+
+	// OpenFile opens a file with os.OpenFile flags (O_RDONLY, O_CREATE, ...)
+	OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+
+	// WriteFile writes data to the named file, creating or truncating it
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+
+	// MkdirAll creates a directory along with any missing parents
+	MkdirAll(name string, perm fs.FileMode) error
+
+	// Remove removes a file or empty directory
+	Remove(name string) error
+
+	// RemoveAll removes a path and everything it contains
+	RemoveAll(name string) error
+
+	// Rename moves oldName to newName
+	Rename(oldName, newName string) error
+}
+
+// validVFSPath checks a name against the io/fs path rules
+func validVFSPath(op, name string) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return nil
+}
+
+// ===============================
+// Read-Only FS Helpers
+// ===============================
+
+// ExistsFS checks if a file or directory exists in fsys
+func ExistsFS(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+// IsFileFS checks if name is a regular file in fsys
+func IsFileFS(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IsDirFS checks if name is a directory in fsys
+func IsDirFS(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && info.IsDir()
+}
+
+// ReadStringFS reads the entire file from fsys as a string
+func ReadStringFS(fsys fs.FS, name string) (string, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", name, err)
+	}
+	return string(content), nil
+}
+
+// ReadLinesFS reads a file from fsys and returns its lines
+func ReadLinesFS(fsys fs.FS, name string) ([]string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", name, err)
+	}
+	defer file.Close()
+
+	lines, err := readAllLines(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading lines from %s: %w", name, err)
+	}
+	return lines, nil
+}
+
+// ProcessLinesFS streams the lines of a file from fsys through fn, with the
+// same semantics as ProcessLines
+func ProcessLinesFS(fsys fs.FS, name string, fn LineFunc, options ...LineOptions) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", name, err)
+	}
+	defer file.Close()
+
+	var total int64
+	if info, err := file.Stat(); err == nil {
+		total = info.Size()
+	}
+
+	if err := scanLines(file, total, fn, normalizeLineOptions(options)); err != nil {
+		return fmt.Errorf("error processing lines from %s: %w", name, err)
+	}
+	return nil
+}
+
+// ListDirFS returns the entries of a directory in fsys. Paths are
+// slash-separated and relative to the root of fsys.
+func ListDirFS(fsys fs.FS, name string) ([]FileInfo, error) {
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", name, err)
+	}
+
+	var fileInfos []FileInfo
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue // Skip entries that can't be read
+		}
+		entryPath := path.Join(name, entry.Name())
+		fileInfos = append(fileInfos, FileInfo{
+			Name:     info.Name(),
+			Path:     entryPath,
+			Size:     info.Size(),
+			Mode:     info.Mode(),
+			ModTime:  info.ModTime(),
+			IsDir:    info.IsDir(),
+			Ext:      path.Ext(entryPath),
+			MimeType: DetectMimeType(entryPath),
+		})
+	}
+
+	return fileInfos, nil
+}
+
+// FindFilesFS searches fsys below root for files whose name matches pattern
+func FindFilesFS(fsys fs.FS, root, pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var matches []string
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if matched, _ := path.Match(pattern, d.Name()); matched {
+			matches = append(matches, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during file search: %w", err)
+	}
+
+	return matches, nil
+}
+
+// DirSizeFS calculates the total size of the files below root in fsys
+func DirSizeFS(fsys fs.FS, root string) (int64, error) {
+	var totalSize int64
+
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		totalSize += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate directory size for %s: %w", root, err)
+	}
+
+	return totalSize, nil
+}
+
+// SHA256HashFS calculates the SHA256 hash of a file in fsys
+func SHA256HashFS(fsys fs.FS, name string) (string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return "", fmt.Errorf("cannot open file %s: %w", name, err)
+	}
+	defer file.Close()
+
+	return sha256Reader(file)
+}
+
+// ===============================
+// Writable VFS Helpers
+// ===============================
+
+// WriteStringFS writes content to the named file in fsys
+func WriteStringFS(fsys VFS, name, content string, perm os.FileMode) error {
+	if err := fsys.WriteFile(name, []byte(content), perm); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", name, err)
+	}
+	return nil
+}
+
+// WriteLinesFS writes lines, each terminated by a newline, to fsys
+func WriteLinesFS(fsys VFS, name string, lines []string, perm os.FileMode) error {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := fsys.WriteFile(name, buf.Bytes(), perm); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", name, err)
+	}
+	return nil
+}
+
+// AppendFileFS appends data to the named file in fsys, creating it if needed
+func AppendFileFS(fsys VFS, name string, data []byte, perm os.FileMode) error {
+	file, err := fsys.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s for appending: %w", name, err)
+	}
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to append to file %s: %w", name, err)
+	}
+	return nil
+}
+
+// CopyFS copies srcName from src into dstName in dst, creating parent
+// directories. Any fs.FS can be the source, so embedded assets can be
+// materialized into a writable VFS.
+func CopyFS(src fs.FS, srcName string, dst VFS, dstName string) error {
+	srcFile, err := src.Open(srcName)
+	if err != nil {
+		return fmt.Errorf("failed to open source file %s: %w", srcName, err)
+	}
+	defer srcFile.Close()
+
+	info, err := srcFile.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat source file %s: %w", srcName, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("source is a directory: %s", srcName)
+	}
+
+	if dir := path.Dir(dstName); dir != "." {
+		if err := dst.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create parent directories: %w", err)
+		}
+	}
+
+	dstFile, err := dst.OpenFile(dstName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create destination file %s: %w", dstName, err)
+	}
+
+	_, err = io.Copy(dstFile, srcFile)
+	if closeErr := dstFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
+	}
+	return nil
+}
+
+// CopyTreeFS copies every file below srcRoot in src into dstRoot in dst
+func CopyTreeFS(src fs.FS, srcRoot string, dst VFS, dstRoot string) error {
+	return fs.WalkDir(src, srcRoot, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := name[len(srcRoot):]
+		if srcRoot == "." {
+			rel = name
+		}
+		target := path.Join(dstRoot, rel)
+
+		if d.IsDir() {
+			return dst.MkdirAll(target, 0755)
+		}
+		return CopyFS(src, name, dst, target)
+	})
+}
@@ -0,0 +1,478 @@
+// File: vfs_mem.go
+// Title: In-Memory Virtual File System
+// Description: Implements MemFS, a thread-safe in-memory VFS for unit tests
+//              and ephemeral data. It supports directories, permissions,
+//              modification times, appends, seeks, and directory listings.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of MemFS
+
+package filex
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errDirNotEmpty is returned when removing a non-empty directory
+var errDirNotEmpty = errors.New("directory not empty")
+
+// MemFS is an in-memory VFS. The zero value is not usable; create instances
+// with NewMemFS. MemFS is safe for concurrent use.
+type MemFS struct {
+	mu    sync.RWMutex
+	nodes map[string]*memNode // Keyed by cleaned name; "." is the root
+}
+
+// memNode is a file or directory stored in a MemFS
+type memNode struct {
+	name    string
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// Compile-time interface check
+var _ VFS = (*MemFS)(nil)
+
+// NewMemFS creates an empty in-memory file system
+func NewMemFS() *MemFS {
+	return &MemFS{
+		nodes: map[string]*memNode{
+			".": {name: ".", mode: fs.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+// Open implements fs.FS
+func (m *MemFS) Open(name string) (fs.File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile opens a file with os.OpenFile flags
+func (m *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	if err := validVFSPath("open", name); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, exists := m.nodes[name]
+	switch {
+	case exists && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	case !exists && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !exists:
+		if err := m.checkParent("open", name); err != nil {
+			return nil, err
+		}
+		node = &memNode{name: path.Base(name), mode: perm.Perm(), modTime: time.Now()}
+		m.nodes[name] = node
+	}
+
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	if node.mode.IsDir() && writable {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+	if writable && flag&os.O_TRUNC != 0 {
+		node.data = nil
+		node.modTime = time.Now()
+	}
+
+	return &memFile{
+		fs:       m,
+		path:     name,
+		node:     node,
+		readable: flag&os.O_WRONLY == 0,
+		writable: writable,
+		append:   flag&os.O_APPEND != 0,
+	}, nil
+}
+
+// Stat implements fs.StatFS
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	if err := validVFSPath("stat", name); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return node.info(), nil
+}
+
+// ReadFile implements fs.ReadFileFS
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	if err := validVFSPath("readfile", name); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+	if node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+// ReadDir implements fs.ReadDirFS, returning entries sorted by name
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := validVFSPath("readdir", name); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if !node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return m.children(name), nil
+}
+
+// WriteFile writes data to the named file, creating or truncating it
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	file, err := m.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// MkdirAll creates a directory along with any missing parents
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	if err := validVFSPath("mkdir", name); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var dirs []string
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		dir := dirs[i]
+		if node, ok := m.nodes[dir]; ok {
+			if !node.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: errors.New("not a directory")}
+			}
+			continue
+		}
+		m.nodes[dir] = &memNode{name: path.Base(dir), mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+// Remove removes a file or empty directory
+func (m *MemFS) Remove(name string) error {
+	if err := validVFSPath("remove", name); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, ok := m.nodes[name]
+	switch {
+	case name == ".":
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrPermission}
+	case !ok:
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	case node.mode.IsDir() && len(m.children(name)) > 0:
+		return &fs.PathError{Op: "remove", Path: name, Err: errDirNotEmpty}
+	}
+	delete(m.nodes, name)
+	return nil
+}
+
+// RemoveAll removes a path and everything it contains. Removing a missing
+// path is not an error.
+func (m *MemFS) RemoveAll(name string) error {
+	if err := validVFSPath("removeall", name); err != nil {
+		return err
+	}
+	if name == "." {
+		return &fs.PathError{Op: "removeall", Path: name, Err: fs.ErrPermission}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix := name + "/"
+	for key := range m.nodes {
+		if key == name || strings.HasPrefix(key, prefix) {
+			delete(m.nodes, key)
+		}
+	}
+	return nil
+}
+
+// Rename moves oldName, including any children, to newName. An existing
+// file at newName is replaced; an existing directory must be empty.
+func (m *MemFS) Rename(oldName, newName string) error {
+	if err := validVFSPath("rename", oldName); err != nil {
+		return err
+	}
+	if err := validVFSPath("rename", newName); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	linkErr := func(err error) error {
+		return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: err}
+	}
+
+	node, ok := m.nodes[oldName]
+	switch {
+	case oldName == "." || newName == ".":
+		return linkErr(fs.ErrPermission)
+	case !ok:
+		return linkErr(fs.ErrNotExist)
+	case oldName == newName:
+		return nil
+	case strings.HasPrefix(newName, oldName+"/"):
+		return linkErr(fs.ErrInvalid)
+	}
+	if err := m.checkParent("rename", newName); err != nil {
+		return linkErr(err)
+	}
+	if target, exists := m.nodes[newName]; exists {
+		if target.mode.IsDir() != node.mode.IsDir() || (target.mode.IsDir() && len(m.children(newName)) > 0) {
+			return linkErr(fs.ErrExist)
+		}
+	}
+
+	prefix := oldName + "/"
+	moved := make(map[string]*memNode)
+	for key, n := range m.nodes {
+		if key == oldName {
+			moved[newName] = n
+		} else if strings.HasPrefix(key, prefix) {
+			moved[newName+"/"+key[len(prefix):]] = n
+		} else {
+			continue
+		}
+		delete(m.nodes, key)
+	}
+	for key, n := range moved {
+		m.nodes[key] = n
+	}
+	node.name = path.Base(newName)
+	return nil
+}
+
+// checkParent verifies that the parent of name is an existing directory;
+// the caller must hold the lock
+func (m *MemFS) checkParent(op, name string) error {
+	parent, ok := m.nodes[path.Dir(name)]
+	if !ok {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if !parent.mode.IsDir() {
+		return &fs.PathError{Op: op, Path: name, Err: errors.New("not a directory")}
+	}
+	return nil
+}
+
+// children lists the direct children of dir sorted by name; the caller must
+// hold the lock
+func (m *MemFS) children(dir string) []fs.DirEntry {
+	var entries []fs.DirEntry
+	for key, node := range m.nodes {
+		if key != "." && path.Dir(key) == dir {
+			entries = append(entries, fs.FileInfoToDirEntry(node.info()))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries
+}
+
+// info returns a snapshot of the node's metadata
+func (n *memNode) info() fs.FileInfo {
+	return &memFileInfo{name: n.name, size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+// memFileInfo implements fs.FileInfo for MemFS nodes
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return i.size }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *memFileInfo) Sys() any           { return nil }
+
+// memFile is an open MemFS file or directory
+type memFile struct {
+	fs       *MemFS
+	path     string
+	node     *memNode
+	offset   int64
+	readable bool
+	writable bool
+	append   bool
+	closed   bool
+	dirPos   int
+}
+
+// Stat implements fs.File
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	if f.closed {
+		return nil, &fs.PathError{Op: "stat", Path: f.path, Err: fs.ErrClosed}
+	}
+	f.fs.mu.RLock()
+	defer f.fs.mu.RUnlock()
+	return f.node.info(), nil
+}
+
+// Read implements fs.File
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: fs.ErrClosed}
+	}
+	if !f.readable || f.node.mode.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: fs.ErrInvalid}
+	}
+
+	f.fs.mu.RLock()
+	defer f.fs.mu.RUnlock()
+
+	if f.offset >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.node.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+// Write implements io.Writer
+func (f *memFile) Write(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "write", Path: f.path, Err: fs.ErrClosed}
+	}
+	if !f.writable {
+		return 0, &fs.PathError{Op: "write", Path: f.path, Err: fs.ErrPermission}
+	}
+
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if f.append {
+		f.offset = int64(len(f.node.data))
+	}
+	end := f.offset + int64(len(p))
+	if end > int64(len(f.node.data)) {
+		grown := make([]byte, end)
+		copy(grown, f.node.data)
+		f.node.data = grown
+	}
+	copy(f.node.data[f.offset:], p)
+	f.offset = end
+	f.node.modTime = time.Now()
+	return len(p), nil
+}
+
+// Seek implements io.Seeker
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrClosed}
+	}
+
+	f.fs.mu.RLock()
+	size := int64(len(f.node.data))
+	f.fs.mu.RUnlock()
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += size
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrInvalid}
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// ReadDir implements fs.ReadDirFile
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if f.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: f.path, Err: fs.ErrClosed}
+	}
+	if !f.node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.path, Err: errors.New("not a directory")}
+	}
+
+	f.fs.mu.RLock()
+	entries := f.fs.children(f.path)
+	f.fs.mu.RUnlock()
+
+	if f.dirPos > len(entries) {
+		f.dirPos = len(entries)
+	}
+	remaining := entries[f.dirPos:]
+	if n <= 0 {
+		f.dirPos = len(entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	f.dirPos += n
+	return remaining[:n], nil
+}
+
+// Close implements fs.File
+func (f *memFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.path, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,133 @@
+// File: vfs_os.go
+// Title: OS-Backed Virtual File System
+// Description: Implements OSFS, a VFS rooted at a host directory. All access
+//              goes through a Root, so io/fs names can never escape the base
+//              directory, including via symbolic links.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of OSFS
+
+package filex
+
+import (
+	"io/fs"
+	"sort"
+)
+
+// OSFS is a VFS backed by a directory of the host file system
+type OSFS struct {
+	root *Root
+}
+
+// Compile-time interface check
+var _ VFS = (*OSFS)(nil)
+
+// NewOSFS creates an OSFS rooted at dir. The directory must exist.
+func NewOSFS(dir string) (*OSFS, error) {
+	root, err := OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &OSFS{root: root}, nil
+}
+
+// Root returns the Root confining the file system
+func (o *OSFS) Root() *Root {
+	return o.root
+}
+
+// Open implements fs.FS
+func (o *OSFS) Open(name string) (fs.File, error) {
+	if err := validVFSPath("open", name); err != nil {
+		return nil, err
+	}
+	return o.root.Open(name)
+}
+
+// OpenFile opens a file with os.OpenFile flags
+func (o *OSFS) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	if err := validVFSPath("open", name); err != nil {
+		return nil, err
+	}
+	return o.root.OpenFile(name, flag, perm)
+}
+
+// Stat implements fs.StatFS
+func (o *OSFS) Stat(name string) (fs.FileInfo, error) {
+	if err := validVFSPath("stat", name); err != nil {
+		return nil, err
+	}
+	return o.root.Stat(name)
+}
+
+// ReadFile implements fs.ReadFileFS
+func (o *OSFS) ReadFile(name string) ([]byte, error) {
+	if err := validVFSPath("readfile", name); err != nil {
+		return nil, err
+	}
+	return o.root.ReadFile(name)
+}
+
+// ReadDir implements fs.ReadDirFS, returning entries sorted by name
+func (o *OSFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := validVFSPath("readdir", name); err != nil {
+		return nil, err
+	}
+
+	dir, err := o.root.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	entries, err := dir.ReadDir(-1)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, err
+}
+
+// WriteFile writes data to the named file, creating or truncating it
+func (o *OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := validVFSPath("writefile", name); err != nil {
+		return err
+	}
+	return o.root.WriteFile(name, data, perm)
+}
+
+// MkdirAll creates a directory along with any missing parents
+func (o *OSFS) MkdirAll(name string, perm fs.FileMode) error {
+	if err := validVFSPath("mkdir", name); err != nil {
+		return err
+	}
+	return o.root.MkdirAll(name, perm)
+}
+
+// Remove removes a file or empty directory
+func (o *OSFS) Remove(name string) error {
+	if err := validVFSPath("remove", name); err != nil {
+		return err
+	}
+	return o.root.Remove(name)
+}
+
+// RemoveAll removes a path and everything it contains
+func (o *OSFS) RemoveAll(name string) error {
+	if err := validVFSPath("removeall", name); err != nil {
+		return err
+	}
+	return o.root.RemoveAll(name)
+}
+
+// Rename moves oldName to newName
+func (o *OSFS) Rename(oldName, newName string) error {
+	if err := validVFSPath("rename", oldName); err != nil {
+		return err
+	}
+	if err := validVFSPath("rename", newName); err != nil {
+		return err
+	}
+	return o.root.Rename(oldName, newName)
+}
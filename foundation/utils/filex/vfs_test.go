@@ -0,0 +1,221 @@
+// File: vfs_test.go
+// Title: Virtual File System Tests
+// Description: Conformance tests for OSFS and MemFS using testing/fstest and
+//              a shared behavior suite, plus tests for the FS-based helpers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial VFS tests
+
+package filex
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// vfsImplementations returns fresh instances of every VFS implementation
+func vfsImplementations(t *testing.T) map[string]VFS {
+	t.Helper()
+	osfs, err := NewOSFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOSFS() error = %v", err)
+	}
+	return map[string]VFS{
+		"OSFS":  osfs,
+		"MemFS": NewMemFS(),
+	}
+}
+
+func TestVFS_Conformance(t *testing.T) {
+	for name, fsys := range vfsImplementations(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := fsys.MkdirAll("config/locales", 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.WriteFile("config/app.yaml", []byte("name: mdw\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.WriteFile("config/locales/de.toml", []byte("hello = \"Hallo\"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.WriteFile("readme.md", nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := fstest.TestFS(fsys, "config/app.yaml", "config/locales/de.toml", "readme.md"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestVFS_Operations(t *testing.T) {
+	for name, fsys := range vfsImplementations(t) {
+		t.Run(name, func(t *testing.T) {
+			// Writing requires an existing parent
+			if err := fsys.WriteFile("missing/file.txt", []byte("x"), 0644); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("WriteFile() without parent error = %v, want ErrNotExist", err)
+			}
+
+			if err := fsys.MkdirAll("data/sub", 0755); err != nil {
+				t.Fatalf("MkdirAll() error = %v", err)
+			}
+			if err := AppendFileFS(fsys, "data/log.txt", []byte("one\n"), 0644); err != nil {
+				t.Fatalf("AppendFileFS() error = %v", err)
+			}
+			if err := AppendFileFS(fsys, "data/log.txt", []byte("two\n"), 0644); err != nil {
+				t.Fatalf("AppendFileFS() error = %v", err)
+			}
+			lines, err := ReadLinesFS(fsys, "data/log.txt")
+			if err != nil || !reflect.DeepEqual(lines, []string{"one", "two"}) {
+				t.Errorf("ReadLinesFS() = %v, %v", lines, err)
+			}
+
+			// Read-write files support seeking and overwriting in place
+			file, err := fsys.OpenFile("data/log.txt", os.O_RDWR, 0)
+			if err != nil {
+				t.Fatalf("OpenFile() error = %v", err)
+			}
+			if _, err := file.Write([]byte("ONE")); err != nil {
+				t.Errorf("Write() error = %v", err)
+			}
+			if seeker, ok := file.(io.Seeker); ok {
+				seeker.Seek(0, io.SeekStart)
+				data, _ := io.ReadAll(file)
+				if string(data) != "ONE\ntwo\n" {
+					t.Errorf("content after overwrite = %q", data)
+				}
+			}
+			file.Close()
+
+			// Read-only files reject writes
+			ro, err := fsys.OpenFile("data/log.txt", os.O_RDONLY, 0)
+			if err != nil {
+				t.Fatalf("OpenFile() error = %v", err)
+			}
+			if _, err := ro.Write([]byte("x")); err == nil {
+				t.Error("Write() on read-only file should fail")
+			}
+			ro.Close()
+
+			if _, err := fsys.OpenFile("data/log.txt", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644); !errors.Is(err, fs.ErrExist) {
+				t.Errorf("OpenFile(O_EXCL) error = %v, want ErrExist", err)
+			}
+
+			if err := fsys.Rename("data", "archive"); err != nil {
+				t.Fatalf("Rename() error = %v", err)
+			}
+			if !IsFileFS(fsys, "archive/log.txt") || !IsDirFS(fsys, "archive/sub") || ExistsFS(fsys, "data") {
+				t.Error("Rename() did not move the directory tree")
+			}
+
+			if err := fsys.Remove("archive"); err == nil {
+				t.Error("Remove() on non-empty directory should fail")
+			}
+			if err := fsys.RemoveAll("archive"); err != nil {
+				t.Fatalf("RemoveAll() error = %v", err)
+			}
+			if ExistsFS(fsys, "archive/log.txt") {
+				t.Error("RemoveAll() left entries behind")
+			}
+			if err := fsys.RemoveAll("archive"); err != nil {
+				t.Errorf("RemoveAll() on missing path error = %v", err)
+			}
+
+			for _, invalid := range []string{"../escape", "/abs", "a/./b", ""} {
+				if _, err := fsys.Open(invalid); err == nil {
+					t.Errorf("Open(%q) should fail", invalid)
+				}
+			}
+		})
+	}
+}
+
+func TestFSHelpers(t *testing.T) {
+	fsys := fstest.MapFS{
+		"assets/logo.png":      {Data: []byte("png")},
+		"assets/css/site.css":  {Data: []byte("body{}")},
+		"templates/mail.tmpl":  {Data: []byte("Hello\r\n{{.Name}}\n")},
+		"templates/other.tmpl": {Data: []byte("x")},
+	}
+
+	files, err := FindFilesFS(fsys, ".", "*.tmpl")
+	if err != nil || !reflect.DeepEqual(files, []string{"templates/mail.tmpl", "templates/other.tmpl"}) {
+		t.Errorf("FindFilesFS() = %v, %v", files, err)
+	}
+
+	size, err := DirSizeFS(fsys, "assets")
+	if err != nil || size != 9 {
+		t.Errorf("DirSizeFS() = %d, %v; want 9", size, err)
+	}
+
+	entries, err := ListDirFS(fsys, "assets")
+	if err != nil || len(entries) != 2 || entries[1].Path != "assets/logo.png" || entries[1].MimeType != "image/png" {
+		t.Errorf("ListDirFS() = %+v, %v", entries, err)
+	}
+
+	var lines []string
+	err = ProcessLinesFS(fsys, "templates/mail.tmpl", func(_ int, line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(lines, []string{"Hello", "{{.Name}}"}) {
+		t.Errorf("ProcessLinesFS() = %q, %v", lines, err)
+	}
+
+	hash, err := SHA256HashFS(fsys, "templates/other.tmpl")
+	if err != nil || hash != "2d711642b726b04401627ca9fbac32f5c8530fb1903cc4db02258717921a4881" {
+		t.Errorf("SHA256HashFS() = %s, %v", hash, err)
+	}
+
+	content, err := ReadStringFS(fsys, "assets/css/site.css")
+	if err != nil || content != "body{}" {
+		t.Errorf("ReadStringFS() = %q, %v", content, err)
+	}
+
+	// Materialize embedded-style assets into a writable VFS
+	mem := NewMemFS()
+	if err := CopyTreeFS(fsys, "assets", mem, "public"); err != nil {
+		t.Fatalf("CopyTreeFS() error = %v", err)
+	}
+	if data, err := mem.ReadFile("public/css/site.css"); err != nil || string(data) != "body{}" {
+		t.Errorf("copied file = %q, %v", data, err)
+	}
+	if err := WriteLinesFS(mem, "public/list.txt", []string{"a", "b"}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteStringFS(mem, "public/note.txt", "note", 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ReadStringFS(mem, "public/list.txt"); got != "a\nb\n" {
+		t.Errorf("WriteLinesFS() content = %q", got)
+	}
+	if err := CopyFS(fsys, "assets", mem, "dir"); err == nil {
+		t.Error("CopyFS() from a directory should fail")
+	}
+}
+
+func TestOSFS_Containment(t *testing.T) {
+	dir := t.TempDir()
+	osfs, err := NewOSFS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symlinkOrSkip(t, os.TempDir(), dir+"/tmp")
+
+	if _, err := osfs.Open("tmp"); !errors.Is(err, ErrPathEscapesRoot) {
+		t.Errorf("Open() through escaping symlink error = %v, want ErrPathEscapesRoot", err)
+	}
+	if osfs.Root().Name() == "" {
+		t.Error("Root() returned an empty base")
+	}
+}
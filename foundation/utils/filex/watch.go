@@ -0,0 +1,465 @@
+// File: watch.go
+// Title: Directory Watcher with Debounced Events
+// Description: Implements a polling-based file system watcher that emits
+//              structured create/modify/delete/rename events with recursive
+//              watching, glob filtering, and per-path debounce windows. Shared
+//              by components that need hot-reload of configuration files.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of Watch
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===============================
+// Directory Watching
+// ===============================
+
+// WatchOp describes the kind of change reported by a watcher
+type WatchOp int
+
+const (
+	WatchCreate WatchOp = iota // File or directory was created
+	WatchModify                // File content or metadata changed
+	WatchDelete                // File or directory was removed
+	WatchRename                // File was moved within the watched tree
+)
+
+// String returns the string representation of the operation
+func (op WatchOp) String() string {
+	switch op {
+	case WatchCreate:
+		return "CREATE"
+	case WatchModify:
+		return "MODIFY"
+	case WatchDelete:
+		return "DELETE"
+	case WatchRename:
+		return "RENAME"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// WatchEvent describes a single file system change
+type WatchEvent struct {
+	Op      WatchOp   // Kind of change
+	Path    string    // Affected path (new path for renames)
+	OldPath string    // Previous path (renames only)
+	IsDir   bool      // Whether the path is a directory
+	Time    time.Time // Time the change was detected
+}
+
+// String returns a human-readable representation of the event
+func (e WatchEvent) String() string {
+	if e.Op == WatchRename {
+		return fmt.Sprintf("%s %s -> %s", e.Op, e.OldPath, e.Path)
+	}
+	return fmt.Sprintf("%s %s", e.Op, e.Path)
+}
+
+// WatchOptions configures a watcher
+type WatchOptions struct {
+	Recursive    bool          // Watch subdirectories
+	Patterns     []string      // Glob patterns to include (empty = all files)
+	Ignore       []string      // Glob patterns to exclude (also prunes directories)
+	IncludeDirs  bool          // Report events for directories
+	PollInterval time.Duration // Scan interval (default: 500ms)
+	Debounce     time.Duration // Quiet period before an event is emitted (0 = none)
+	BufferSize   int           // Event channel buffer size (default: 64)
+}
+
+// DefaultWatchOptions returns default options for watching
+func DefaultWatchOptions() WatchOptions {
+	return WatchOptions{
+		Recursive:    true,
+		PollInterval: 500 * time.Millisecond,
+		Debounce:     100 * time.Millisecond,
+		BufferSize:   64,
+	}
+}
+
+// Watcher delivers file system events for a file or directory tree
+type Watcher struct {
+	root     string
+	options  WatchOptions
+	events   chan WatchEvent
+	errors   chan error
+	done     chan struct{}
+	stopped  chan struct{}
+	snapshot map[string]os.FileInfo
+	pending  map[string]*pendingEvent
+	closeMu  sync.Mutex
+	closed   bool
+}
+
+// pendingEvent is an event waiting for its debounce window to elapse
+type pendingEvent struct {
+	event    WatchEvent
+	deadline time.Time
+}
+
+// Watch starts watching path (a file or directory) and returns a Watcher whose
+// Events channel receives changes. Patterns are matched against the base name,
+// or against the slash-separated path relative to the root if they contain '/'.
+func Watch(path string, options ...WatchOptions) (*Watcher, error) {
+	opts := DefaultWatchOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 500 * time.Millisecond
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 64
+	}
+
+	for _, pattern := range append(append([]string{}, opts.Patterns...), opts.Ignore...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid watch pattern %q: %w", pattern, err)
+		}
+	}
+
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve watch path %s: %w", path, err)
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	w := &Watcher{
+		root:    root,
+		options: opts,
+		events:  make(chan WatchEvent, opts.BufferSize),
+		errors:  make(chan error, 8),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		pending: make(map[string]*pendingEvent),
+	}
+
+	snapshot, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	w.snapshot = snapshot
+
+	go w.run()
+
+	return w, nil
+}
+
+// Events returns the channel on which file system events are delivered. The
+// channel is closed when the watcher is closed.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Errors returns the channel on which scan errors are delivered
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Root returns the absolute path being watched
+func (w *Watcher) Root() string {
+	return w.root
+}
+
+// Close stops the watcher and closes its channels. Pending debounced events
+// are discarded.
+func (w *Watcher) Close() error {
+	w.closeMu.Lock()
+	if w.closed {
+		w.closeMu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.closeMu.Unlock()
+
+	<-w.stopped
+	return nil
+}
+
+// run is the polling loop
+func (w *Watcher) run() {
+	defer close(w.stopped)
+	defer close(w.errors)
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.tickInterval())
+	defer ticker.Stop()
+
+	lastScan := time.Now()
+	for {
+		select {
+		case <-w.done:
+			return
+		case now := <-ticker.C:
+			if now.Sub(lastScan) >= w.options.PollInterval {
+				lastScan = now
+				w.poll(now)
+			}
+			if !w.flush(now) {
+				return
+			}
+		}
+	}
+}
+
+// tickInterval returns the loop interval, fine-grained enough for debouncing
+func (w *Watcher) tickInterval() time.Duration {
+	interval := w.options.PollInterval
+	if w.options.Debounce > 0 && w.options.Debounce/2 < interval {
+		interval = w.options.Debounce / 2
+	}
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	return interval
+}
+
+// poll scans the tree and queues changes against the previous snapshot
+func (w *Watcher) poll(now time.Time) {
+	current, err := w.scan()
+	if err != nil {
+		select {
+		case w.errors <- err:
+		default:
+		}
+		return
+	}
+
+	for _, event := range diffSnapshots(w.snapshot, current, now) {
+		w.queue(event, now)
+	}
+	w.snapshot = current
+}
+
+// queue merges an event into the pending set, coalescing events per path
+func (w *Watcher) queue(event WatchEvent, now time.Time) {
+	deadline := now.Add(w.options.Debounce)
+
+	if event.Op == WatchRename {
+		// A rename supersedes any pending event for the old path
+		if prev, ok := w.pending[event.OldPath]; ok {
+			delete(w.pending, event.OldPath)
+			if prev.event.Op == WatchCreate {
+				event = WatchEvent{Op: WatchCreate, Path: event.Path, IsDir: event.IsDir, Time: event.Time}
+			}
+		}
+		w.pending[event.Path] = &pendingEvent{event: event, deadline: deadline}
+		return
+	}
+
+	prev, ok := w.pending[event.Path]
+	if !ok {
+		w.pending[event.Path] = &pendingEvent{event: event, deadline: deadline}
+		return
+	}
+
+	switch {
+	case prev.event.Op == WatchCreate && event.Op == WatchDelete:
+		// Created and removed within the window: nothing happened
+		delete(w.pending, event.Path)
+		return
+	case prev.event.Op == WatchCreate || prev.event.Op == WatchRename:
+		// Keep the original create/rename; later modifications are implied
+		if event.Op == WatchDelete {
+			prev.event = event
+		}
+	case prev.event.Op == WatchDelete && event.Op == WatchCreate:
+		prev.event = WatchEvent{Op: WatchModify, Path: event.Path, IsDir: event.IsDir, Time: event.Time}
+	default:
+		prev.event = event
+	}
+	prev.deadline = deadline
+}
+
+// flush emits events whose debounce window has elapsed. It returns false if
+// the watcher was closed while delivering.
+func (w *Watcher) flush(now time.Time) bool {
+	var ready []WatchEvent
+	for path, p := range w.pending {
+		if !now.Before(p.deadline) {
+			ready = append(ready, p.event)
+			delete(w.pending, path)
+		}
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].Time.Equal(ready[j].Time) {
+			return ready[i].Time.Before(ready[j].Time)
+		}
+		return ready[i].Path < ready[j].Path
+	})
+
+	for _, event := range ready {
+		select {
+		case w.events <- event:
+		case <-w.done:
+			return false
+		}
+	}
+	return true
+}
+
+// scan builds a snapshot of all matching entries below the root
+func (w *Watcher) scan() (map[string]os.FileInfo, error) {
+	snapshot := make(map[string]os.FileInfo)
+
+	info, err := os.Stat(w.root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return snapshot, nil // Root removed: everything was deleted
+		}
+		return nil, fmt.Errorf("failed to scan %s: %w", w.root, err)
+	}
+
+	if !info.IsDir() {
+		snapshot[w.root] = info
+		return snapshot, nil
+	}
+
+	err = filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // Removed while walking
+			}
+			return err
+		}
+		if path == w.root {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(w.root, path)
+		if w.ignored(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if w.options.IncludeDirs && w.matches(rel) {
+				if info, err := d.Info(); err == nil {
+					snapshot[path] = info
+				}
+			}
+			if !w.options.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if w.matches(rel) {
+			if info, err := d.Info(); err == nil {
+				snapshot[path] = info
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", w.root, err)
+	}
+
+	return snapshot, nil
+}
+
+// matches reports whether a relative path passes the include patterns
+func (w *Watcher) matches(rel string) bool {
+	if len(w.options.Patterns) == 0 {
+		return true
+	}
+	return matchAnyPattern(w.options.Patterns, rel)
+}
+
+// ignored reports whether a relative path matches an ignore pattern
+func (w *Watcher) ignored(rel string) bool {
+	return len(w.options.Ignore) > 0 && matchAnyPattern(w.options.Ignore, rel)
+}
+
+// matchAnyPattern matches a relative path against glob patterns
+func matchAnyPattern(patterns []string, rel string) bool {
+	slashRel := filepath.ToSlash(rel)
+	base := filepath.Base(rel)
+	for _, pattern := range patterns {
+		target := base
+		if strings.Contains(pattern, "/") {
+			target = slashRel
+			pattern = filepath.ToSlash(pattern)
+		}
+		if ok, _ := filepath.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// diffSnapshots computes the events between two snapshots. A file that
+// disappeared and a new file that is the same underlying file are reported
+// as a rename.
+func diffSnapshots(old, current map[string]os.FileInfo, now time.Time) []WatchEvent {
+	var events []WatchEvent
+	var created, deleted []string
+
+	for path, info := range current {
+		prev, existed := old[path]
+		switch {
+		case !existed:
+			created = append(created, path)
+		case !info.IsDir() && (!prev.ModTime().Equal(info.ModTime()) || prev.Size() != info.Size() || prev.Mode() != info.Mode()):
+			events = append(events, WatchEvent{Op: WatchModify, Path: path, Time: now})
+		}
+	}
+	for path := range old {
+		if _, exists := current[path]; !exists {
+			deleted = append(deleted, path)
+		}
+	}
+
+	sort.Strings(created)
+	sort.Strings(deleted)
+
+	renamed := make(map[string]bool)
+	for _, newPath := range created {
+		newInfo := current[newPath]
+		oldPath := ""
+		for _, candidate := range deleted {
+			if !renamed[candidate] && os.SameFile(old[candidate], newInfo) {
+				oldPath = candidate
+				break
+			}
+		}
+
+		if oldPath != "" {
+			renamed[oldPath] = true
+			events = append(events, WatchEvent{Op: WatchRename, Path: newPath, OldPath: oldPath, IsDir: newInfo.IsDir(), Time: now})
+			continue
+		}
+		events = append(events, WatchEvent{Op: WatchCreate, Path: newPath, IsDir: newInfo.IsDir(), Time: now})
+	}
+
+	for _, path := range deleted {
+		if !renamed[path] {
+			events = append(events, WatchEvent{Op: WatchDelete, Path: path, IsDir: old[path].IsDir(), Time: now})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,298 @@
+// File: watch_test.go
+// Title: Directory Watcher Tests
+// Description: Tests for Watch covering create/modify/delete/rename events,
+//              recursive watching, glob filtering, and debounce coalescing.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial watcher tests
+
+package filex
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testWatchOptions returns fast options suitable for tests
+func testWatchOptions() WatchOptions {
+	return WatchOptions{
+		Recursive:    true,
+		PollInterval: 10 * time.Millisecond,
+		Debounce:     40 * time.Millisecond,
+	}
+}
+
+// waitForEvent reads events until one matches op and path or the timeout expires
+func waitForEvent(t *testing.T, w *Watcher, op WatchOp, path string) WatchEvent {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event, ok := <-w.Events():
+			if !ok {
+				t.Fatalf("events channel closed while waiting for %s %s", op, path)
+			}
+			if event.Op == op && event.Path == path {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s %s", op, path)
+		}
+	}
+}
+
+// collectEvents gathers all events delivered within the given duration
+func collectEvents(w *Watcher, d time.Duration) []WatchEvent {
+	var events []WatchEvent
+	timeout := time.After(d)
+	for {
+		select {
+		case event, ok := <-w.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		case <-timeout:
+			return events
+		}
+	}
+}
+
+func TestWatch_Events(t *testing.T) {
+	dir := t.TempDir()
+	w, err := Watch(dir, testWatchOptions())
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	path := filepath.Join(dir, "app.toml")
+	if err := os.WriteFile(path, []byte("a = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, w, WatchCreate, path)
+
+	if err := os.WriteFile(path, []byte("a = 1\nb = 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, w, WatchModify, path)
+
+	renamed := filepath.Join(dir, "renamed.toml")
+	if err := os.Rename(path, renamed); err != nil {
+		t.Fatal(err)
+	}
+	event := waitForEvent(t, w, WatchRename, renamed)
+	if event.OldPath != path {
+		t.Errorf("rename OldPath = %q, want %q", event.OldPath, path)
+	}
+
+	if err := os.Remove(renamed); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, w, WatchDelete, renamed)
+}
+
+func TestWatch_Recursive(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "nested", "deep")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		wantEvent bool
+	}{
+		{"recursive", true, true},
+		{"flat", false, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testWatchOptions()
+			opts.Recursive = tt.recursive
+			w, err := Watch(dir, opts)
+			if err != nil {
+				t.Fatalf("Watch() error = %v", err)
+			}
+			defer w.Close()
+
+			path := filepath.Join(sub, "file"+string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			found := false
+			for _, event := range collectEvents(w, 300*time.Millisecond) {
+				if event.Path == path {
+					found = true
+				}
+			}
+			if found != tt.wantEvent {
+				t.Errorf("event for nested file = %v, want %v", found, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestWatch_Patterns(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := testWatchOptions()
+	opts.Patterns = []string{"*.toml"}
+	opts.Ignore = []string{"cache", "*.tmp.toml"}
+	w, err := Watch(dir, opts)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	files := map[string]bool{
+		filepath.Join(dir, "app.toml"):        true,
+		filepath.Join(dir, "app.yaml"):        false,
+		filepath.Join(dir, "app.tmp.toml"):    false,
+		filepath.Join(dir, "cache", "x.toml"): false,
+	}
+	for path := range files {
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, event := range collectEvents(w, 300*time.Millisecond) {
+		seen[event.Path] = true
+	}
+	for path, want := range files {
+		if seen[path] != want {
+			t.Errorf("event for %s = %v, want %v", filepath.Base(path), seen[path], want)
+		}
+	}
+}
+
+func TestWatch_Debounce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "burst.txt")
+
+	opts := testWatchOptions()
+	opts.Debounce = 150 * time.Millisecond
+	w, err := Watch(dir, opts)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	// A burst of writes within the debounce window coalesces into one create
+	content := ""
+	for i := 0; i < 5; i++ {
+		content += "line\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+
+	events := collectEvents(w, 500*time.Millisecond)
+	if len(events) != 1 {
+		t.Fatalf("got %d events %v, want 1", len(events), events)
+	}
+	if events[0].Op != WatchCreate {
+		t.Errorf("event op = %s, want CREATE", events[0].Op)
+	}
+
+	// A file created and removed within the window produces no event
+	transient := filepath.Join(dir, "transient.txt")
+	if err := os.WriteFile(transient, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := os.Remove(transient); err != nil {
+		t.Fatal(err)
+	}
+	if events := collectEvents(w, 400*time.Millisecond); len(events) != 0 {
+		t.Errorf("got events %v for transient file, want none", events)
+	}
+}
+
+func TestWatch_SingleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "single.json")
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := Watch(path, testWatchOptions())
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	if err := os.WriteFile(filepath.Join(dir, "other.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"a": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	event := waitForEvent(t, w, WatchModify, path)
+	if event.IsDir {
+		t.Error("event IsDir = true, want false")
+	}
+}
+
+func TestWatch_Errors(t *testing.T) {
+	if _, err := Watch(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Watch() on missing path should fail")
+	}
+
+	opts := testWatchOptions()
+	opts.Patterns = []string{"[invalid"}
+	if _, err := Watch(t.TempDir(), opts); err == nil {
+		t.Error("Watch() with invalid pattern should fail")
+	}
+}
+
+func TestWatch_Close(t *testing.T) {
+	w, err := Watch(t.TempDir(), testWatchOptions())
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Error("events channel should be closed")
+	}
+}
+
+func TestWatchOp_String(t *testing.T) {
+	tests := []struct {
+		op   WatchOp
+		want string
+	}{
+		{WatchCreate, "CREATE"},
+		{WatchModify, "MODIFY"},
+		{WatchDelete, "DELETE"},
+		{WatchRename, "RENAME"},
+		{WatchOp(99), "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		if got := tt.op.String(); got != tt.want {
+			t.Errorf("WatchOp(%d).String() = %q, want %q", tt.op, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,508 @@
+// File: workspace.go
+// Title: Temporary Workspace Manager
+// Description: Implements Workspace, an isolated temporary directory for tool
+//              execution and document conversion steps. A workspace tracks
+//              the files created inside it, enforces a size quota, confines
+//              all names through a Root, and is removed on Close. Open
+//              workspaces are registered so that CleanupWorkspaces and
+//              CleanupOnSignal can remove them at process exit, and
+//              SweepWorkspaces removes leftovers of crashed processes.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of Workspace
+
+package filex
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when a write would exceed a workspace quota
+var ErrQuotaExceeded = errors.New("workspace quota exceeded")
+
+// ErrWorkspaceClosed is returned when using a closed workspace
+var ErrWorkspaceClosed = errors.New("workspace is closed")
+
+// ===============================
+// Temporary Workspaces
+// ===============================
+
+// WorkspaceOptions represents options for creating a workspace
+type WorkspaceOptions struct {
+	BaseDir     string // Parent directory (default: os.TempDir())
+	Prefix      string // Directory name prefix (default: "mdw-workspace-")
+	MaxSize     int64  // Size quota in bytes (0 = unlimited)
+	KeepOnClose bool   // Leave the directory in place on Close (debugging)
+}
+
+// DefaultWorkspaceOptions returns default options for workspaces
+func DefaultWorkspaceOptions() WorkspaceOptions {
+	return WorkspaceOptions{
+		BaseDir: os.TempDir(),
+		Prefix:  "mdw-workspace-",
+	}
+}
+
+// Workspace is an isolated temporary directory with file tracking and a
+// size quota. Names passed to its methods are relative to the workspace and
+// cannot escape it. Workspace is safe for concurrent use.
+type Workspace struct {
+	mu     sync.Mutex
+	root   *Root
+	opts   WorkspaceOptions
+	files  map[string]int64 // Tracked files and their sizes
+	used   int64            // Sum of tracked sizes
+	closed bool
+}
+
+// activeWorkspaces holds all open workspaces for process-exit cleanup
+var activeWorkspaces = struct {
+	sync.Mutex
+	set map[*Workspace]struct{}
+}{set: make(map[*Workspace]struct{})}
+
+// NewWorkspace creates a new workspace directory
+func NewWorkspace(options ...WorkspaceOptions) (*Workspace, error) {
+	opts := DefaultWorkspaceOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	defaults := DefaultWorkspaceOptions()
+	if opts.BaseDir == "" {
+		opts.BaseDir = defaults.BaseDir
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = defaults.Prefix
+	}
+	if opts.MaxSize < 0 {
+		return nil, errors.New("workspace quota cannot be negative")
+	}
+
+	if err := os.MkdirAll(opts.BaseDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create workspace base directory %s: %w", opts.BaseDir, err)
+	}
+	dir, err := os.MkdirTemp(opts.BaseDir, opts.Prefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	root, err := OpenRoot(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	w := &Workspace{root: root, opts: opts, files: make(map[string]int64)}
+
+	activeWorkspaces.Lock()
+	activeWorkspaces.set[w] = struct{}{}
+	activeWorkspaces.Unlock()
+
+	return w, nil
+}
+
+// Dir returns the absolute path of the workspace directory
+func (w *Workspace) Dir() string {
+	return w.root.Name()
+}
+
+// Path returns the host path for name, e.g. as an output argument for an
+// external tool. Files created there are picked up by Scan.
+func (w *Workspace) Path(name string) (string, error) {
+	if err := w.checkOpen(); err != nil {
+		return "", err
+	}
+	return w.root.Resolve(name)
+}
+
+// WriteFile writes data to name, creating parent directories. The write is
+// rejected without modifying the workspace if it would exceed the quota.
+func (w *Workspace) WriteFile(name string, data []byte) error {
+	rel, err := w.relative(name)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	over := w.opts.MaxSize > 0 && w.used-w.files[rel]+int64(len(data)) > w.opts.MaxSize
+	w.mu.Unlock()
+	if over {
+		return fmt.Errorf("%w: writing %s to %s", ErrQuotaExceeded, FormatSize(int64(len(data))), rel)
+	}
+
+	file, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := file.reserve(int64(len(data))); err != nil {
+		file.Close()
+		w.Remove(name)
+		return err
+	}
+
+	_, err = file.file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Create creates or truncates name, creating parent directories, and
+// returns a writer that enforces the quota
+func (w *Workspace) Create(name string) (*WorkspaceFile, error) {
+	if err := w.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	rel, err := w.relative(name)
+	if err != nil {
+		return nil, err
+	}
+	if dir := filepath.Dir(rel); dir != "." {
+		if err := w.root.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	file, err := w.root.Create(rel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace file %s: %w", name, err)
+	}
+
+	w.mu.Lock()
+	w.used -= w.files[rel]
+	w.files[rel] = 0
+	w.mu.Unlock()
+
+	return &WorkspaceFile{workspace: w, name: rel, file: file}, nil
+}
+
+// ReadFile reads name from the workspace
+func (w *Workspace) ReadFile(name string) ([]byte, error) {
+	if err := w.checkOpen(); err != nil {
+		return nil, err
+	}
+	return w.root.ReadFile(name)
+}
+
+// MkdirAll creates a directory inside the workspace
+func (w *Workspace) MkdirAll(name string) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+	return w.root.MkdirAll(name, 0755)
+}
+
+// Remove removes a file or directory tree and releases its quota
+func (w *Workspace) Remove(name string) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+	rel, err := w.relative(name)
+	if err != nil {
+		return err
+	}
+	if err := w.root.RemoveAll(rel); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prefix := rel + string(filepath.Separator)
+	for file, size := range w.files {
+		if file == rel || strings.HasPrefix(file, prefix) {
+			w.used -= size
+			delete(w.files, file)
+		}
+	}
+	return nil
+}
+
+// Scan walks the workspace and tracks every regular file, including files
+// written by external tools through Path. It returns ErrQuotaExceeded when
+// the measured usage is above the quota.
+func (w *Workspace) Scan() error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+
+	files := make(map[string]int64)
+	var used int64
+	dir := w.Dir()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[rel] = info.Size()
+		used += info.Size()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan workspace %s: %w", dir, err)
+	}
+
+	w.mu.Lock()
+	w.files = files
+	w.used = used
+	w.mu.Unlock()
+
+	if w.opts.MaxSize > 0 && used > w.opts.MaxSize {
+		return fmt.Errorf("%w: %s used of %s", ErrQuotaExceeded, FormatSize(used), FormatSize(w.opts.MaxSize))
+	}
+	return nil
+}
+
+// Files returns the tracked files relative to the workspace, sorted
+func (w *Workspace) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	files := make([]string, 0, len(w.files))
+	for file := range w.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Used returns the number of bytes used by tracked files
+func (w *Workspace) Used() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.used
+}
+
+// Remaining returns the bytes left in the quota, or -1 if unlimited
+func (w *Workspace) Remaining() int64 {
+	if w.opts.MaxSize == 0 {
+		return -1
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.used >= w.opts.MaxSize {
+		return 0
+	}
+	return w.opts.MaxSize - w.used
+}
+
+// Close removes the workspace directory unless KeepOnClose is set. Close is
+// idempotent.
+func (w *Workspace) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	activeWorkspaces.Lock()
+	delete(activeWorkspaces.set, w)
+	activeWorkspaces.Unlock()
+
+	if w.opts.KeepOnClose {
+		return nil
+	}
+	if err := os.RemoveAll(w.Dir()); err != nil {
+		return fmt.Errorf("failed to remove workspace %s: %w", w.Dir(), err)
+	}
+	return nil
+}
+
+// checkOpen returns ErrWorkspaceClosed after Close
+func (w *Workspace) checkOpen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWorkspaceClosed
+	}
+	return nil
+}
+
+// relative validates name and returns it as a cleaned relative path. The
+// check is lexical; symbolic links are handled by the Root.
+func (w *Workspace) relative(name string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if name == "" || rel == "." || filepath.IsAbs(rel) || startsWithParent(rel) {
+		return "", &os.PathError{Op: "workspace", Path: name, Err: ErrPathEscapesRoot}
+	}
+	return rel, nil
+}
+
+// WorkspaceFile is a file being written inside a workspace. Writes that
+// would exceed the quota fail with ErrQuotaExceeded.
+type WorkspaceFile struct {
+	workspace *Workspace
+	name      string
+	file      *os.File
+}
+
+// Name returns the file name relative to the workspace
+func (f *WorkspaceFile) Name() string {
+	return f.name
+}
+
+// Write implements io.Writer
+func (f *WorkspaceFile) Write(p []byte) (int, error) {
+	if err := f.reserve(int64(len(p))); err != nil {
+		return 0, err
+	}
+	n, err := f.file.Write(p)
+	if n < len(p) {
+		f.release(int64(len(p) - n))
+	}
+	return n, err
+}
+
+// Close closes the underlying file
+func (f *WorkspaceFile) Close() error {
+	return f.file.Close()
+}
+
+// reserve accounts n bytes against the quota before they are written
+func (f *WorkspaceFile) reserve(n int64) error {
+	w := f.workspace
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrWorkspaceClosed
+	}
+	if w.opts.MaxSize > 0 && w.used+n > w.opts.MaxSize {
+		return fmt.Errorf("%w: writing %s to %s", ErrQuotaExceeded, FormatSize(n), f.name)
+	}
+	w.used += n
+	w.files[f.name] += n
+	return nil
+}
+
+// release returns unused reserved bytes
+func (f *WorkspaceFile) release(n int64) {
+	w := f.workspace
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.used -= n
+	w.files[f.name] -= n
+}
+
+// ===============================
+// Process-Exit Cleanup
+// ===============================
+
+// CleanupWorkspaces closes all open workspaces. Call it with defer in main
+// so workspaces are removed on a normal exit.
+func CleanupWorkspaces() error {
+	activeWorkspaces.Lock()
+	workspaces := make([]*Workspace, 0, len(activeWorkspaces.set))
+	for w := range activeWorkspaces.set {
+		workspaces = append(workspaces, w)
+	}
+	activeWorkspaces.Unlock()
+
+	var errs []error
+	for _, w := range workspaces {
+		if err := w.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CleanupOnSignal removes all open workspaces when one of the signals
+// (default: os.Interrupt) is received, then re-delivers the signal with its
+// default behavior so the process terminates as it otherwise would. The
+// returned function stops the handler.
+func CleanupOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt}
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, signals...)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			CleanupWorkspaces()
+			signal.Reset(signals...)
+			if process, err := os.FindProcess(os.Getpid()); err == nil && process.Signal(sig) == nil {
+				return
+			}
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// SweepWorkspaces removes workspace directories below baseDir whose name
+// starts with prefix and that were last modified before olderThan ago. It
+// cleans up after processes that were killed before they could run their
+// cleanup. Workspaces open in this process are never removed.
+func SweepWorkspaces(baseDir, prefix string, olderThan time.Duration) ([]string, error) {
+	if prefix == "" {
+		prefix = DefaultWorkspaceOptions().Prefix
+	}
+
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace base directory %s: %w", baseDir, err)
+	}
+
+	active := make(map[string]bool)
+	activeWorkspaces.Lock()
+	for w := range activeWorkspaces.set {
+		active[filepath.Base(w.Dir())] = true
+	}
+	activeWorkspaces.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	var removed []string
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) || active[entry.Name()] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		path := filepath.Join(baseDir, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, path)
+	}
+
+	return removed, errors.Join(errs...)
+}
@@ -0,0 +1,199 @@
+// File: workspace_test.go
+// Title: Temporary Workspace Manager Tests
+// Description: Tests for Workspace covering file tracking, quota
+//              enforcement, containment, cleanup, and stale sweeping.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial workspace tests
+
+package filex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkspace_Lifecycle(t *testing.T) {
+	base := t.TempDir()
+	ws, err := NewWorkspace(WorkspaceOptions{BaseDir: base})
+	if err != nil {
+		t.Fatalf("NewWorkspace() error = %v", err)
+	}
+	if !strings.HasPrefix(filepath.Base(ws.Dir()), "mdw-workspace-") {
+		t.Errorf("Dir() = %s, want default prefix", ws.Dir())
+	}
+
+	if err := ws.WriteFile("input/doc.md", []byte("# Title")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	file, err := ws.Create("output/doc.html")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	file.Write([]byte("<h1>Title</h1>"))
+	file.Close()
+
+	want := []string{filepath.Join("input", "doc.md"), filepath.Join("output", "doc.html")}
+	if got := ws.Files(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %v, want %v", got, want)
+	}
+	if ws.Used() != 21 || ws.Remaining() != -1 {
+		t.Errorf("Used() = %d, Remaining() = %d; want 21, -1", ws.Used(), ws.Remaining())
+	}
+
+	data, err := ws.ReadFile("input/doc.md")
+	if err != nil || string(data) != "# Title" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+
+	if err := ws.Remove("output"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if ws.Used() != 7 || len(ws.Files()) != 1 {
+		t.Errorf("after Remove() used %d with files %v", ws.Used(), ws.Files())
+	}
+
+	dir := ws.Dir()
+	if err := ws.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if Exists(dir) {
+		t.Error("Close() did not remove the workspace directory")
+	}
+	if err := ws.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	if err := ws.WriteFile("late.txt", nil); !errors.Is(err, ErrWorkspaceClosed) {
+		t.Errorf("WriteFile() after Close error = %v, want ErrWorkspaceClosed", err)
+	}
+}
+
+func TestWorkspace_Quota(t *testing.T) {
+	ws, err := NewWorkspace(WorkspaceOptions{BaseDir: t.TempDir(), MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	if err := ws.WriteFile("a.txt", []byte("123456")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := ws.WriteFile("b.txt", []byte("123456")); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("WriteFile() over quota error = %v, want ErrQuotaExceeded", err)
+	}
+	if Exists(filepath.Join(ws.Dir(), "b.txt")) {
+		t.Error("rejected write left a file behind")
+	}
+
+	// Overwriting releases the previous size first
+	if err := ws.WriteFile("a.txt", []byte("1234567890")); err != nil {
+		t.Errorf("WriteFile() overwrite within quota error = %v", err)
+	}
+	if ws.Remaining() != 0 {
+		t.Errorf("Remaining() = %d, want 0", ws.Remaining())
+	}
+
+	file, err := ws.Create("stream.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("x")); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("streamed write over quota error = %v, want ErrQuotaExceeded", err)
+	}
+	file.Close()
+
+	// Files written by external tools are measured by Scan
+	path, err := ws.Path("tool/output.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, []byte("external"), 0644)
+	if err := ws.Scan(); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Scan() error = %v, want ErrQuotaExceeded", err)
+	}
+	if ws.Used() != 18 || len(ws.Files()) != 3 {
+		t.Errorf("after Scan() used %d with files %v", ws.Used(), ws.Files())
+	}
+}
+
+func TestWorkspace_Containment(t *testing.T) {
+	ws, err := NewWorkspace(WorkspaceOptions{BaseDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	for _, name := range []string{"../escape.txt", "/etc/passwd", "", "."} {
+		if err := ws.WriteFile(name, []byte("x")); err == nil {
+			t.Errorf("WriteFile(%q) should fail", name)
+		}
+	}
+	if _, err := ws.Path("../outside"); !errors.Is(err, ErrPathEscapesRoot) {
+		t.Errorf("Path() error = %v, want ErrPathEscapesRoot", err)
+	}
+	if _, err := NewWorkspace(WorkspaceOptions{MaxSize: -1}); err == nil {
+		t.Error("NewWorkspace() with negative quota should fail")
+	}
+}
+
+func TestCleanupWorkspaces(t *testing.T) {
+	base := t.TempDir()
+	first, _ := NewWorkspace(WorkspaceOptions{BaseDir: base})
+	second, _ := NewWorkspace(WorkspaceOptions{BaseDir: base, KeepOnClose: true})
+
+	if err := CleanupWorkspaces(); err != nil {
+		t.Fatalf("CleanupWorkspaces() error = %v", err)
+	}
+	if Exists(first.Dir()) {
+		t.Error("CleanupWorkspaces() left an open workspace behind")
+	}
+	if !Exists(second.Dir()) {
+		t.Error("CleanupWorkspaces() removed a KeepOnClose workspace")
+	}
+
+	stop := CleanupOnSignal()
+	stop()
+	stop()
+}
+
+func TestSweepWorkspaces(t *testing.T) {
+	base := t.TempDir()
+	stale := filepath.Join(base, "mdw-workspace-stale")
+	other := filepath.Join(base, "unrelated")
+	for _, dir := range []string{stale, other} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-48 * time.Hour)
+		os.Chtimes(dir, old, old)
+	}
+
+	open, err := NewWorkspace(WorkspaceOptions{BaseDir: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer open.Close()
+	old := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(open.Dir(), old, old)
+
+	removed, err := SweepWorkspaces(base, "", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("SweepWorkspaces() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != stale {
+		t.Errorf("SweepWorkspaces() removed %v, want [%s]", removed, stale)
+	}
+	if !Exists(other) || !Exists(open.Dir()) {
+		t.Error("SweepWorkspaces() removed an unrelated or open directory")
+	}
+}
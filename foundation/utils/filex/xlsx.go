@@ -0,0 +1,216 @@
+// File: xlsx.go
+// Title: Excel Workbook Writing
+// Description: Implements export of records as an Office Open XML workbook
+//              (.xlsx) with a single worksheet, a bold header row, and
+//              numeric cells for values that are numbers. Needs no
+//              dependency beyond archive/zip and encoding/xml.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the XLSX writer
+
+package filex
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxSheetNameLength is the longest worksheet name Excel accepts
+const maxSheetNameLength = 31
+
+// xlsxStaticParts are the package parts that do not depend on the data;
+// the worksheet and workbook are written separately
+var xlsxStaticParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// ===============================
+// XLSX Options
+// ===============================
+
+// XLSXOptions represents options for XLSX writing
+type XLSXOptions struct {
+	SheetName string // Worksheet name (default "Sheet1"); at most 31 characters
+	TextOnly  bool   // Write all values as text instead of detecting numbers
+}
+
+// ===============================
+// XLSX Writing
+// ===============================
+
+// WriteXLSX writes records to path as a workbook with one worksheet. The
+// first row holds the headers; missing keys produce empty cells. Without
+// headers the worksheet is empty. The file is replaced atomically.
+func WriteXLSX(path string, headers []string, records []map[string]string, options ...XLSXOptions) error {
+	err := WriteAtomicFunc(path, 0644, func(w io.Writer) error {
+		return EncodeXLSX(w, headers, records, options...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write XLSX file %s: %w", path, err)
+	}
+	return nil
+}
+
+// EncodeXLSX writes records to w like WriteXLSX
+func EncodeXLSX(w io.Writer, headers []string, records []map[string]string, options ...XLSXOptions) error {
+	var opts XLSXOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	sheetName, err := xlsxSheetName(opts.SheetName)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		if err := writeZipPart(archive, part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	var workbook strings.Builder
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"` +
+		` xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(&workbook, []byte(sheetName))
+	workbook.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	if err := writeZipPart(archive, "xl/workbook.xml", workbook.String()); err != nil {
+		return err
+	}
+
+	part, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := writeXLSXSheet(part, headers, records, opts); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// writeXLSXSheet writes the worksheet XML with the header row in bold
+func writeXLSXSheet(w io.Writer, headers []string, records []map[string]string, opts XLSXOptions) error {
+	buffered := bufio.NewWriter(w)
+	buffered.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	columns := make([]string, len(headers))
+	for i := range headers {
+		columns[i] = xlsxColumn(i)
+	}
+
+	writeRow := func(rowNum int, values []string, header bool) {
+		fmt.Fprintf(buffered, `<row r="%d">`, rowNum)
+		for i, value := range values {
+			if value == "" {
+				continue
+			}
+			ref := columns[i] + strconv.Itoa(rowNum)
+			switch {
+			case header:
+				fmt.Fprintf(buffered, `<c r="%s" s="1" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			case !opts.TextOnly && xlsxNumber(value):
+				fmt.Fprintf(buffered, `<c r="%s"><v>%s</v></c>`, ref, value)
+				continue
+			default:
+				fmt.Fprintf(buffered, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			}
+			xml.EscapeText(buffered, []byte(value))
+			buffered.WriteString(`</t></is></c>`)
+		}
+		buffered.WriteString(`</row>`)
+	}
+
+	if len(headers) > 0 {
+		writeRow(1, headers, true)
+		values := make([]string, len(headers))
+		for i, record := range records {
+			for j, header := range headers {
+				values[j] = record[header]
+			}
+			writeRow(i+2, values, false)
+		}
+	}
+
+	buffered.WriteString(`</sheetData></worksheet>`)
+	return buffered.Flush()
+}
+
+// writeZipPart adds a part with the given content to the archive
+func writeZipPart(archive *zip.Writer, name, content string) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+// xlsxSheetName checks a worksheet name against the rules of Excel
+func xlsxSheetName(name string) (string, error) {
+	if name == "" {
+		return "Sheet1", nil
+	}
+	if len([]rune(name)) > maxSheetNameLength {
+		return "", fmt.Errorf("sheet name %q exceeds %d characters", name, maxSheetNameLength)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) || strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return "", fmt.Errorf("sheet name %q contains characters not allowed by Excel", name)
+	}
+	return name, nil
+}
+
+// xlsxColumn returns the column letters for a 0-based column index:
+// A..Z, AA..AZ, ...
+func xlsxColumn(index int) string {
+	var letters []byte
+	for index++; index > 0; index = (index - 1) / 26 {
+		letters = append([]byte{byte('A' + (index-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// xlsxNumber reports whether a value is written as a numeric cell. Values
+// with leading zeros or a plus sign, such as postal codes and phone
+// numbers, stay text so they are not changed by Excel.
+func xlsxNumber(value string) bool {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return false
+	}
+	digits := strings.TrimPrefix(value, "-")
+	if digits == "" || digits[0] < '0' || digits[0] > '9' || strings.ContainsAny(digits, "xX_") {
+		return false // Signs, hex, underscores, and ".5" are left to text
+	}
+	return len(digits) == 1 || digits[0] != '0' || digits[1] == '.'
+}
@@ -0,0 +1,129 @@
+// File: xlsx_test.go
+// Title: Excel Workbook Writing Tests
+// Description: Tests the package structure, cell types, and escaping of
+//              written workbooks and the checks of sheet names.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial XLSX tests
+
+package filex
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readZipParts returns the parts of a zip archive by name
+func readZipParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+func TestEncodeXLSX(t *testing.T) {
+	var out bytes.Buffer
+	records := []map[string]string{
+		{"name": "Müller & Söhne", "zip": "01067", "balance": "1500.50"},
+		{"name": "<Acme>", "balance": "-3"},
+	}
+	if err := EncodeXLSX(&out, []string{"name", "zip", "balance"}, records, XLSXOptions{SheetName: "Customers"}); err != nil {
+		t.Fatalf("EncodeXLSX() error = %v", err)
+	}
+
+	parts := readZipParts(t, out.Bytes())
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		content, exists := parts[name]
+		if !exists {
+			t.Fatalf("part %s is missing", name)
+		}
+		if err := xml.Unmarshal([]byte(content), new(struct{})); err != nil {
+			t.Errorf("part %s is not well-formed: %v", name, err)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Customers"`) {
+		t.Errorf("workbook = %s", parts["xl/workbook.xml"])
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, expected := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">Müller &amp; Söhne</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">01067</t></is></c>`,
+		`<c r="C2"><v>1500.50</v></c>`,
+		`<t xml:space="preserve">&lt;Acme&gt;</t>`,
+		`<c r="C3"><v>-3</v></c>`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Errorf("sheet does not contain %s:\n%s", expected, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Error("missing value produced a cell")
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.xlsx")
+	if err := WriteXLSX(path, []string{"a"}, []map[string]string{{"a": "1"}}, XLSXOptions{TextOnly: true}); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+	data, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if sheet := readZipParts(t, data)["xl/worksheets/sheet1.xml"]; !strings.Contains(sheet, `<c r="A2" t="inlineStr">`) {
+		t.Errorf("TextOnly wrote a numeric cell:\n%s", sheet)
+	}
+
+	var empty bytes.Buffer
+	if err := EncodeXLSX(&empty, nil, []map[string]string{{"a": "1"}}); err != nil {
+		t.Fatalf("EncodeXLSX() without headers error = %v", err)
+	}
+	if sheet := readZipParts(t, empty.Bytes())["xl/worksheets/sheet1.xml"]; strings.Contains(sheet, "<c ") {
+		t.Errorf("worksheet without headers has cells:\n%s", sheet)
+	}
+	for _, name := range []string{"a/b", "[x]", strings.Repeat("s", 32)} {
+		if err := EncodeXLSX(io.Discard, []string{"a"}, nil, XLSXOptions{SheetName: name}); err == nil {
+			t.Errorf("EncodeXLSX() with sheet name %q should fail", name)
+		}
+	}
+}
+
+func TestXLSXHelpers(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(index); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", index, got, want)
+		}
+	}
+	for value, want := range map[string]bool{
+		"0": true, "42": true, "-1.5": true, "0.25": true, "1e3": true,
+		"007": false, "+49": false, ".5": false, "inf": false, "NaN": false, "0x1F": false, "1_000": false, "": false,
+	} {
+		if got := xlsxNumber(value); got != want {
+			t.Errorf("xlsxNumber(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -4,13 +4,14 @@
 //              offering Unicode-safe string manipulation, performance optimizations,
 //              and commonly needed utilities that extend Go's standard library.
 // Author: msto63 with Claude Opus 4.0
-// Version: v0.2.0
+// Version: v0.2.1
 // Created: 2025-01-24
-// Modified: 2025-01-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-24 v0.1.0: Initial implementation with core string utilities
 // - 2025-01-26 v0.2.0: Enhanced documentation with comprehensive structure and examples
+// - 2026-10-16 v0.2.1: Documented string similarity
 
 // Package stringx provides extended string operations for the mDW platform.
 //
@@ -49,6 +50,8 @@
 //   - Core Operations: Basic string utilities (stringx.go)
 //   - Case Conversion: Transform between naming conventions (case.go)
 //   - Random Generation: Secure and fast random string creation (random.go)
+//   - Line Endings: BOM stripping and newline normalization (newline.go)
+//   - Similarity: Edit distance and fuzzy matching (similarity.go)
 //   - Validation: String content validation and checking
 //   - Performance: Optimized implementations with benchmarks
 //
@@ -135,6 +138,15 @@
 //	stringx.HasSuffix("world", "ld")     // true
 //	stringx.ContainsAny("test", "aeiou") // true
 //
+// String similarity:
+//
+//	stringx.Levenshtein("CUSTOMR", "CUSTOMER") // 1
+//	stringx.Similarity("CUSTOMR", "CUSTOMER")  // 0.875
+//	
+//	// Rank candidates for "did you mean" suggestions
+//	matches := stringx.ClosestMatches("CRATE", []string{"CREATE", "DELETE", "LIST"}, 0.6, 3)
+//	// Result: []Match{{Value: "CREATE", Score: 0.83}}
+//
 // Performance Considerations
 //
 // The package includes several performance optimizations:
@@ -235,7 +247,6 @@
 // Planned additions to the package include:
 //   - Natural language processing utilities
 //   - Advanced pattern matching with glob support
-//   - String similarity and distance algorithms
 //   - Template processing with variable substitution
 //   - Localization-aware string operations
 //
@@ -0,0 +1,158 @@
+// File: newline.go
+// Title: Byte Order Mark and Newline Utilities
+// Description: Implements detection and normalization of line ending styles
+//              and UTF-8 byte order mark handling for text originating from
+//              different platforms (Windows clients, legacy Mac exports).
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial implementation of BOM and newline utilities
+
+package stringx
+
+import "strings"
+
+// BOM is the UTF-8 encoded byte order mark
+const BOM = "\uFEFF"
+
+// NewlineStyle identifies a line ending convention
+type NewlineStyle int
+
+const (
+	NewlineNone  NewlineStyle = iota // No line endings present
+	NewlineLF                        // Unix: \n
+	NewlineCRLF                      // Windows: \r\n
+	NewlineCR                        // Classic Mac: \r
+	NewlineMixed                     // More than one style present
+)
+
+// String returns the name of the newline style
+func (s NewlineStyle) String() string {
+	switch s {
+	case NewlineNone:
+		return "none"
+	case NewlineLF:
+		return "LF"
+	case NewlineCRLF:
+		return "CRLF"
+	case NewlineCR:
+		return "CR"
+	case NewlineMixed:
+		return "mixed"
+	default:
+		return "unknown"
+	}
+}
+
+// Sequence returns the line ending characters for the style.
+// NewlineNone and NewlineMixed fall back to "\n".
+func (s NewlineStyle) Sequence() string {
+	switch s {
+	case NewlineCRLF:
+		return "\r\n"
+	case NewlineCR:
+		return "\r"
+	default:
+		return "\n"
+	}
+}
+
+// DetectNewlineStyle reports the line ending convention used in s.
+// Example: "a\r\nb\r\n" -> NewlineCRLF, "a\nb\r\n" -> NewlineMixed
+func DetectNewlineStyle(s string) NewlineStyle {
+	style := NewlineNone
+
+	for i := 0; i < len(s); i++ {
+		var found NewlineStyle
+		switch s[i] {
+		case '\n':
+			found = NewlineLF
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				found = NewlineCRLF
+				i++
+			} else {
+				found = NewlineCR
+			}
+		default:
+			continue
+		}
+
+		if style == NewlineNone {
+			style = found
+		} else if style != found {
+			return NewlineMixed
+		}
+	}
+
+	return style
+}
+
+// NormalizeNewlines converts all line endings in s to the given style.
+// NewlineNone and NewlineMixed normalize to LF.
+// Example: NormalizeNewlines("a\r\nb\rc", NewlineLF) -> "a\nb\nc"
+func NormalizeNewlines(s string, style NewlineStyle) string {
+	if strings.IndexByte(s, '\r') < 0 && (style == NewlineLF || strings.IndexByte(s, '\n') < 0) {
+		return s // Fast path: nothing to convert
+	}
+
+	target := style.Sequence()
+
+	var builder strings.Builder
+	builder.Grow(len(s) + len(s)/16)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+			builder.WriteString(target)
+		case '\n':
+			builder.WriteString(target)
+		default:
+			builder.WriteByte(s[i])
+		}
+	}
+
+	return builder.String()
+}
+
+// HasBOM reports whether s starts with a UTF-8 byte order mark
+func HasBOM(s string) bool {
+	return strings.HasPrefix(s, BOM)
+}
+
+// StripBOM removes a leading UTF-8 byte order mark from s, if present
+func StripBOM(s string) string {
+	return strings.TrimPrefix(s, BOM)
+}
+
+// StripBOMBytes removes a leading UTF-8 byte order mark from b, if present.
+// The returned slice shares the underlying array with b.
+func StripBOMBytes(b []byte) []byte {
+	if len(b) >= len(BOM) && string(b[:len(BOM)]) == BOM {
+		return b[len(BOM):]
+	}
+	return b
+}
+
+// EnsureTrailingNewline appends a line ending to s if it does not already end
+// with one. The line ending matches the style detected in s (LF if none or
+// mixed). Empty strings are returned unchanged.
+// Example: EnsureTrailingNewline("a\r\nb") -> "a\r\nb\r\n"
+func EnsureTrailingNewline(s string) string {
+	if s == "" {
+		return s
+	}
+
+	last := s[len(s)-1]
+	if last == '\n' || last == '\r' {
+		return s
+	}
+
+	return s + DetectNewlineStyle(s).Sequence()
+}
@@ -0,0 +1,151 @@
+// File: newline_test.go
+// Title: Unit Tests for BOM and Newline Utilities
+// Description: Unit tests for newline style detection, normalization,
+//              trailing newline handling, and UTF-8 byte order mark removal.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-15
+// Modified: 2026-10-15
+//
+// Change History:
+// - 2026-10-15 v0.1.0: Initial test implementation
+
+package stringx
+
+import (
+	"testing"
+)
+
+func TestDetectNewlineStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected NewlineStyle
+	}{
+		{"empty string", "", NewlineNone},
+		{"single line", "hello", NewlineNone},
+		{"unix", "a\nb\n", NewlineLF},
+		{"windows", "a\r\nb\r\n", NewlineCRLF},
+		{"classic mac", "a\rb\r", NewlineCR},
+		{"mixed lf and crlf", "a\nb\r\n", NewlineMixed},
+		{"mixed cr and lf", "a\rb\n", NewlineMixed},
+		{"trailing cr only", "a\r", NewlineCR},
+		{"blank crlf lines", "\r\n\r\n", NewlineCRLF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectNewlineStyle(tt.input); got != tt.expected {
+				t.Errorf("DetectNewlineStyle(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		style    NewlineStyle
+		expected string
+	}{
+		{"empty string", "", NewlineCRLF, ""},
+		{"no newlines", "hello", NewlineCRLF, "hello"},
+		{"crlf to lf", "a\r\nb\r\n", NewlineLF, "a\nb\n"},
+		{"cr to lf", "a\rb", NewlineLF, "a\nb"},
+		{"mixed to lf", "a\r\nb\rc\n", NewlineLF, "a\nb\nc\n"},
+		{"lf to crlf", "a\nb\n", NewlineCRLF, "a\r\nb\r\n"},
+		{"crlf stays crlf", "a\r\nb", NewlineCRLF, "a\r\nb"},
+		{"lf to cr", "a\nb", NewlineCR, "a\rb"},
+		{"mixed target means lf", "a\r\nb", NewlineMixed, "a\nb"},
+		{"blank lines preserved", "\r\n\r\n", NewlineLF, "\n\n"},
+		{"unicode content", "größe\r\nmaß", NewlineLF, "größe\nmaß"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeNewlines(tt.input, tt.style); got != tt.expected {
+				t.Errorf("NormalizeNewlines(%q, %s) = %q, want %q", tt.input, tt.style, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripBOM(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		hasBOM   bool
+	}{
+		{"empty string", "", "", false},
+		{"no bom", "hello", "hello", false},
+		{"with bom", "\uFEFFhello", "hello", true},
+		{"only bom", "\uFEFF", "", true},
+		{"bom not at start", "a\uFEFFb", "a\uFEFFb", false},
+		{"double bom strips one", "\uFEFF\uFEFFx", "\uFEFFx", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripBOM(tt.input); got != tt.expected {
+				t.Errorf("StripBOM(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if got := string(StripBOMBytes([]byte(tt.input))); got != tt.expected {
+				t.Errorf("StripBOMBytes(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if got := HasBOM(tt.input); got != tt.hasBOM {
+				t.Errorf("HasBOM(%q) = %v, want %v", tt.input, got, tt.hasBOM)
+			}
+		})
+	}
+}
+
+func TestEnsureTrailingNewline(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty string", "", ""},
+		{"single line", "hello", "hello\n"},
+		{"already lf", "a\n", "a\n"},
+		{"already crlf", "a\r\n", "a\r\n"},
+		{"already cr", "a\r", "a\r"},
+		{"crlf content", "a\r\nb", "a\r\nb\r\n"},
+		{"cr content", "a\rb", "a\rb\r"},
+		{"mixed content", "a\nb\r\nc", "a\nb\r\nc\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EnsureTrailingNewline(tt.input); got != tt.expected {
+				t.Errorf("EnsureTrailingNewline(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewlineStyle_String(t *testing.T) {
+	tests := []struct {
+		style    NewlineStyle
+		expected string
+		sequence string
+	}{
+		{NewlineNone, "none", "\n"},
+		{NewlineLF, "LF", "\n"},
+		{NewlineCRLF, "CRLF", "\r\n"},
+		{NewlineCR, "CR", "\r"},
+		{NewlineMixed, "mixed", "\n"},
+		{NewlineStyle(42), "unknown", "\n"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.String(); got != tt.expected {
+			t.Errorf("NewlineStyle(%d).String() = %q, want %q", tt.style, got, tt.expected)
+		}
+		if got := tt.style.Sequence(); got != tt.sequence {
+			t.Errorf("NewlineStyle(%d).Sequence() = %q, want %q", tt.style, got, tt.sequence)
+		}
+	}
+}
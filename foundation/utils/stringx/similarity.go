@@ -0,0 +1,95 @@
+// File: similarity.go
+// Title: String Similarity and Distance
+// Description: Implements Unicode-aware edit distance and normalized
+//              similarity scores for fuzzy matching, e.g. suggesting the
+//              intended name for a misspelled command.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of Levenshtein and Similarity
+
+package stringx
+
+import (
+	"sort"
+)
+
+// Levenshtein returns the number of single-rune insertions, deletions, and
+// substitutions needed to turn a into b
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	// Two rows of the distance matrix, indexed by the shorter string
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// Similarity returns a score between 0 (nothing in common) and 1 (equal)
+// based on the Levenshtein distance relative to the longer string
+func Similarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// Match is a candidate string with its similarity to a target
+type Match struct {
+	Value string
+	Score float64
+}
+
+// ClosestMatches returns the candidates with a similarity to target of at
+// least minScore, best first and at most limit (0 = no limit). Ties are
+// ordered by value.
+func ClosestMatches(target string, candidates []string, minScore float64, limit int) []Match {
+	var matches []Match
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		if score := Similarity(target, candidate); score >= minScore {
+			matches = append(matches, Match{Value: candidate, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Value < matches[j].Value
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
@@ -0,0 +1,82 @@
+// File: similarity_test.go
+// Title: Unit Tests for String Similarity and Distance
+// Description: Tests edit distance, similarity scores, and ranking of the
+//              closest matches, including Unicode input.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial similarity tests
+
+package stringx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"CREATE", "CREATE", 0},
+		{"CRAETE", "CREATE", 2},
+		{"CUSTOMR", "CUSTOMER", 1},
+		{"kitten", "sitting", 3},
+		{"Grüße", "Grüsse", 2},
+		{"日本語", "日本", 1},
+	}
+
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+		if got := Levenshtein(tt.b, tt.a); got != tt.expected {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.expected)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "", 1},
+		{"LIST", "LIST", 1},
+		{"LIST", "", 0},
+		{"CUSTOMR", "CUSTOMER", 0.875},
+		{"abcd", "wxyz", 0},
+	}
+
+	for _, tt := range tests {
+		if got := Similarity(tt.a, tt.b); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"CREATE", "DELETE", "LIST", "UPDATE", "CREATE"}
+
+	matches := ClosestMatches("CRATE", candidates, 0.6, 0)
+	if len(matches) != 1 || matches[0].Value != "CREATE" {
+		t.Errorf("ClosestMatches(CRATE) = %v", matches)
+	}
+
+	// The limit keeps the best matches
+	matches = ClosestMatches("XPDATE", candidates, 0.3, 2)
+	if len(matches) != 2 || matches[0].Value != "UPDATE" || matches[1].Value != "CREATE" {
+		t.Errorf("ClosestMatches(XPDATE) = %v", matches)
+	}
+
+	if matches := ClosestMatches("ZZZ", candidates, 0.5, 0); len(matches) != 0 {
+		t.Errorf("ClosestMatches(ZZZ) = %v, want none", matches)
+	}
+}
@@ -0,0 +1,121 @@
+// File: cron_test.go
+// Title: Cron Expression Engine Tests
+// Description: Tests parsing of cron expressions and the calculation of
+//              their next activation times.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial cron tests
+
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * FOO *",
+		"@often",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2026-10-16 is a Friday
+	from := time.Date(2026, 10, 16, 10, 30, 45, 0, time.UTC)
+	testCases := []struct {
+		expr     string
+		from     time.Time
+		expected string
+	}{
+		{"* * * * *", from, "2026-10-16T10:31:00Z"},
+		{"0 6 * * 1", from, "2026-10-19T06:00:00Z"},
+		{"0 6 * * MON", from, "2026-10-19T06:00:00Z"},
+		{"*/15 * * * *", from, "2026-10-16T10:45:00Z"},
+		{"5/20 * * * *", from, "2026-10-16T10:45:00Z"},
+		{"0 9-17/4 * * *", from, "2026-10-16T13:00:00Z"},
+		{"30 10 * * *", from, "2026-10-17T10:30:00Z"},
+		{"0 0 1 * *", from, "2026-11-01T00:00:00Z"},
+		{"0 0 1 JAN *", from, "2027-01-01T00:00:00Z"},
+		{"0 0 * * 7", from, "2026-10-18T00:00:00Z"},
+		{"0 0 * * 0", from, "2026-10-18T00:00:00Z"},
+		{"0 0 29 2 *", from, "2028-02-29T00:00:00Z"},
+		{"0 12 1,15 * *", from, "2026-11-01T12:00:00Z"},
+		// Day of month or day of week if neither is '*'
+		{"0 0 20 * 0", from, "2026-10-18T00:00:00Z"},
+		{"@daily", from, "2026-10-17T00:00:00Z"},
+		{"@hourly", from, "2026-10-16T11:00:00Z"},
+		{"@weekly", from, "2026-10-18T00:00:00Z"},
+		// The activation at the start time itself is skipped
+		{"0 6 * * 1", time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC), "2026-10-26T06:00:00Z"},
+	}
+
+	for _, tc := range testCases {
+		schedule, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tc.expr, err)
+			continue
+		}
+		if got := schedule.Next(tc.from).Format(time.RFC3339); got != tc.expected {
+			t.Errorf("ParseCron(%q).Next() = %s, want %s", tc.expr, got, tc.expected)
+		}
+	}
+}
+
+func TestCronSchedule_NextNever(t *testing.T) {
+	schedule, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Next() = %v, want zero time", next)
+	}
+}
+
+func TestCronSchedule_NextInLocation(t *testing.T) {
+	berlin, err := LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	schedule, _ := ParseCron("0 6 * * *")
+
+	// Activations stay at 06:00 local time across the end of summer time
+	next := schedule.Next(time.Date(2026, 10, 24, 12, 0, 0, 0, berlin))
+	for _, expected := range []string{"2026-10-25T06:00:00+01:00", "2026-10-26T06:00:00+01:00"} {
+		if got := next.Format(time.RFC3339); got != expected {
+			t.Errorf("Next() = %s, want %s", got, expected)
+		}
+		next = schedule.Next(next)
+	}
+}
+
+func TestCronSchedule_Matches(t *testing.T) {
+	schedule, _ := ParseCron("0 6 * * 1-5")
+	if !schedule.Matches(time.Date(2026, 10, 16, 6, 0, 30, 0, time.UTC)) {
+		t.Error("Matches() = false for Friday 06:00")
+	}
+	if schedule.Matches(time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)) {
+		t.Error("Matches() = true for Saturday 06:00")
+	}
+	if schedule.String() != "0 6 * * 1-5" {
+		t.Errorf("String() = %q", schedule.String())
+	}
+}
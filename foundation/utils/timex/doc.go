@@ -8,13 +8,14 @@
 //              time series generation. All functions are designed for real-world
 //              business applications with focus on performance and ease of use.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with comprehensive time operations
 // - 2025-01-26 v0.1.1: Enhanced documentation with comprehensive examples and mDW integration
+// - 2026-10-16 v0.1.2: Documented cron expressions
 //
 // Package Overview:
 //
@@ -109,6 +110,16 @@
 //   - Unix/UnixMilli: Convert Unix timestamps to time.Time
 //   - ToUnix/ToUnixMilli: Convert time.Time to Unix timestamps
 //
+// # Cron Expressions
+//
+// ParseCron parses standard five-field cron expressions (minute, hour, day
+// of month, month, day of week) with lists, ranges, steps, month and
+// weekday names, and macros such as @daily. CronSchedule.Next returns the
+// next activation in the location of the given time:
+//
+//	schedule, err := timex.ParseCron("0 6 * * 1") // Mondays at 06:00
+//	next := schedule.Next(time.Now().In(location))
+//
 // # Common Time Constants
 //
 // The package provides predefined format constants for common use cases:
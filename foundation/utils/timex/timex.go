@@ -4,15 +4,16 @@
 //              formatting, business day calculations, duration operations, and
 //              timezone handling for the mDW platform.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.1
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-07-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with comprehensive time utilities
 // - 2025-07-26 v0.1.1: Added FormatDurationCompact function, fixed business day logic,
 //                       enhanced European date parsing support (DD.MM.YYYY format),
 //                       improved negative duration validation
+// - 2026-10-16 v0.1.2: Added LoadLocation exposing the timezone cache
 
 package timex
 
@@ -161,6 +162,15 @@ func getCachedLocation(tz string) (*time.Location, error) {
 	return loc, nil
 }
 
+// LoadLocation returns the location for an IANA timezone name such as
+// "Europe/Berlin", using the package timezone cache
+func LoadLocation(tz string) (*time.Location, error) {
+	if tz == "" {
+		return nil, fmt.Errorf("timezone cannot be empty")
+	}
+	return getCachedLocation(tz)
+}
+
 // ===============================
 // Parsing Functions
 // ===============================
@@ -3,12 +3,13 @@
 // Description: Comprehensive test suite for all timex utility functions including
 //              unit tests, edge cases, and integration scenarios.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.1
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial test implementation with comprehensive coverage
+// - 2026-10-16 v0.1.1: Added LoadLocation tests
 
 package timex
 
@@ -581,6 +582,26 @@ func TestConvertTimezone(t *testing.T) {
 	}
 }
 
+func TestLoadLocation(t *testing.T) {
+	loc, err := LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation() unexpected error: %v", err)
+	}
+	if loc.String() != "Europe/Berlin" {
+		t.Errorf("LoadLocation() = %s, want Europe/Berlin", loc)
+	}
+	
+	if cached, _ := LoadLocation("Europe/Berlin"); cached != loc {
+		t.Error("LoadLocation() should return the cached location")
+	}
+	
+	for _, tz := range []string{"", "Invalid/Timezone"} {
+		if _, err := LoadLocation(tz); err == nil {
+			t.Errorf("LoadLocation(%q) should return error", tz)
+		}
+	}
+}
+
 func TestToUTC(t *testing.T) {
 	localTime := time.Date(2023, 12, 25, 15, 0, 0, 0, time.Local)
 	utcTime := ToUTC(localTime)
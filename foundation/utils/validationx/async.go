@@ -0,0 +1,259 @@
+// File: async.go
+// Title: Asynchronous and External-Lookup Validators
+// Description: Implements validators that call databases or services, such
+//              as unique-username or customer-exists checks. Async validators
+//              receive the caller's context, run with a per-validator timeout,
+//              and plug into validator chains. ValidateContext validates the
+//              fields of a record in parallel and aggregates the results.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of async validators and parallel field validation
+// - 2026-10-16 v0.1.1: Assign field names to warnings
+
+package validationx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// Error codes for failed external lookups
+const (
+	CodeLookupFailed = "VALIDATION_LOOKUP_FAILED" // External lookup returned an error
+	CodeTimeout      = "VALIDATION_TIMEOUT"       // External lookup exceeded its timeout
+)
+
+// DefaultAsyncTimeout is the per-validator timeout used when none is set
+const DefaultAsyncTimeout = 5 * time.Second
+
+// ===============================
+// Async Validator Interface
+// ===============================
+
+// AsyncValidator validates a value using external resources. The returned
+// error reports a failed lookup (database down, service unreachable) and is
+// distinct from the value being invalid, which is reported in the result.
+type AsyncValidator interface {
+	ValidateAsync(ctx context.Context, value interface{}) (ValidationResult, error)
+}
+
+// AsyncValidatorFunc is a function type that implements AsyncValidator
+type AsyncValidatorFunc func(ctx context.Context, value interface{}) (ValidationResult, error)
+
+// ValidateAsync implements the AsyncValidator interface
+func (f AsyncValidatorFunc) ValidateAsync(ctx context.Context, value interface{}) (ValidationResult, error) {
+	return f(ctx, value)
+}
+
+// LookupFunc checks whether a value exists in an external system
+type LookupFunc func(ctx context.Context, value interface{}) (bool, error)
+
+// AsyncOptions configures how an async validator is executed
+type AsyncOptions struct {
+	Name     string        // Name used in error context (optional)
+	Timeout  time.Duration // Per-validator timeout (0 = DefaultAsyncTimeout, <0 = none)
+	FailOpen bool          // Treat lookup failures and timeouts as passing
+}
+
+// DefaultAsyncOptions returns default options for async validators
+func DefaultAsyncOptions() AsyncOptions {
+	return AsyncOptions{
+		Timeout: DefaultAsyncTimeout,
+	}
+}
+
+// ===============================
+// Async Adapter
+// ===============================
+
+// asyncAdapter adapts an AsyncValidator to the validation.Validator interface
+type asyncAdapter struct {
+	validator AsyncValidator
+	options   AsyncOptions
+}
+
+// Async wraps an AsyncValidator so it can be added to a ValidatorChain.
+// The chain's context is propagated to the validator with the configured
+// timeout applied. Lookup failures become CodeLookupFailed errors and
+// timeouts CodeTimeout errors unless FailOpen is set.
+func Async(validator AsyncValidator, options ...AsyncOptions) validation.Validator {
+	opts := DefaultAsyncOptions()
+	if len(options) > 0 {
+		opts = options[0]
+		if opts.Timeout == 0 {
+			opts.Timeout = DefaultAsyncTimeout
+		}
+	}
+	return &asyncAdapter{validator: validator, options: opts}
+}
+
+// Validate runs the async validator with a background context
+func (a *asyncAdapter) Validate(value interface{}) ValidationResult {
+	return a.ValidateWithContext(context.Background(), value)
+}
+
+// ValidateWithContext runs the async validator with timeout and error mapping
+func (a *asyncAdapter) ValidateWithContext(ctx context.Context, value interface{}) ValidationResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if a.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.options.Timeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		result ValidationResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		result, err := a.validator.ValidateAsync(ctx, value)
+		done <- outcome{result, err}
+	}()
+
+	// Do not wait beyond the deadline for validators ignoring the context
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out = outcome{err: ctx.Err()}
+	}
+
+	if out.err == nil {
+		return out.result
+	}
+	if a.options.FailOpen {
+		result := validation.NewValidationResult()
+		result.WithContext("lookupError", out.err.Error())
+		return result
+	}
+
+	var result ValidationResult
+	if errors.Is(out.err, context.DeadlineExceeded) {
+		result = validation.NewValidationErrorWithKey(CodeTimeout, "", "validation lookup timed out",
+			map[string]interface{}{"Timeout": a.options.Timeout})
+	} else {
+		result = validation.NewValidationErrorWithKey(CodeLookupFailed, "", fmt.Sprintf("validation lookup failed: %v", out.err), nil)
+	}
+	result.Errors[0].Context = map[string]interface{}{
+		"asyncValidator": a.options.Name,
+		"duration":       time.Since(start),
+	}
+	return result
+}
+
+// ===============================
+// Lookup Validators
+// ===============================
+
+// Unique creates a validator that fails if lookup reports the value as
+// already existing, e.g. a username or e-mail address that is taken
+func Unique(lookup LookupFunc, options ...AsyncOptions) validation.Validator {
+	return Async(AsyncValidatorFunc(func(ctx context.Context, value interface{}) (ValidationResult, error) {
+		exists, err := lookup(ctx, value)
+		if err != nil {
+			return ValidationResult{}, err
+		}
+		if exists {
+			return validation.NewValidationErrorWithKey(validation.CodeCustom, KeyUnique, "value is already taken", nil), nil
+		}
+		return validation.NewValidationResult(), nil
+	}), options...)
+}
+
+// Exists creates a validator that fails if lookup reports the value as not
+// existing, e.g. a referenced customer or product number
+func Exists(lookup LookupFunc, options ...AsyncOptions) validation.Validator {
+	return Async(AsyncValidatorFunc(func(ctx context.Context, value interface{}) (ValidationResult, error) {
+		exists, err := lookup(ctx, value)
+		if err != nil {
+			return ValidationResult{}, err
+		}
+		if !exists {
+			return validation.NewValidationErrorWithKey(validation.CodeCustom, KeyExists, "value does not exist", nil), nil
+		}
+		return validation.NewValidationResult(), nil
+	}), options...)
+}
+
+// ===============================
+// Parallel Field Validation
+// ===============================
+
+// ValidateOptions configures ValidateContext
+type ValidateOptions struct {
+	MaxConcurrency int // Maximum fields validated at once (0 = all fields)
+}
+
+// DefaultValidateOptions returns default options for ValidateContext
+func DefaultValidateOptions() ValidateOptions {
+	return ValidateOptions{}
+}
+
+// ValidateContext validates a map of field values like Validate, but runs
+// the field chains in parallel and propagates ctx to every validator. Errors
+// are aggregated in field name order. Fields not yet started when ctx is
+// cancelled are reported with CodeTimeout.
+func ValidateContext(ctx context.Context, data map[string]interface{}, rules map[string]*ValidatorChain, options ...ValidateOptions) ValidationResult {
+	opts := DefaultValidateOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 || concurrency > len(fields) {
+		concurrency = len(fields)
+	}
+
+	results := make([]ValidationResult, len(fields))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, field := range fields {
+		if ctx.Err() != nil {
+			results[i] = validation.NewValidationErrorWithField(CodeTimeout, field, "validation cancelled", nil)
+			continue
+		}
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = validation.NewValidationErrorWithField(CodeTimeout, field, "validation cancelled", nil)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, field string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fieldResult := rules[field].ValidateWithContext(ctx, data[field])
+			assignField(&fieldResult, field)
+			results[i] = fieldResult
+		}(i, field)
+	}
+
+	wg.Wait()
+	return validation.Combine(results...)
+}
@@ -0,0 +1,174 @@
+// File: async_test.go
+// Title: Asynchronous Validator Tests
+// Description: Tests for async validators covering lookups, timeouts,
+//              fail-open behavior, chain integration, context propagation,
+//              and parallel field validation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial async validator tests
+
+package validationx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// usernameTaken simulates a database lookup of existing usernames
+func usernameTaken(ctx context.Context, value interface{}) (bool, error) {
+	switch value {
+	case "admin", "root":
+		return true, nil
+	case "db-down":
+		return false, errors.New("connection refused")
+	}
+	return false, nil
+}
+
+// slowLookup blocks until the context is done
+func slowLookup(ctx context.Context, value interface{}) (bool, error) {
+	<-ctx.Done()
+	return false, ctx.Err()
+}
+
+func TestUniqueAndExists(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validation.Validator
+		value     interface{}
+		wantCode  string
+	}{
+		{"unique free", Unique(usernameTaken), "alice", ""},
+		{"unique taken", Unique(usernameTaken), "admin", validation.CodeCustom},
+		{"exists found", Exists(usernameTaken), "root", ""},
+		{"exists missing", Exists(usernameTaken), "nobody", validation.CodeCustom},
+		{"lookup failure", Unique(usernameTaken), "db-down", CodeLookupFailed},
+		{"fail open", Unique(usernameTaken, AsyncOptions{FailOpen: true}), "db-down", ""},
+		{"timeout", Unique(slowLookup, AsyncOptions{Timeout: 10 * time.Millisecond}), "x", CodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.validator.Validate(tt.value)
+			if tt.wantCode == "" {
+				if !result.Valid {
+					t.Errorf("Validate(%v) = %v, want valid", tt.value, result.ErrorMessages())
+				}
+				return
+			}
+			if result.Valid || !result.HasError(tt.wantCode) {
+				t.Errorf("Validate(%v) codes = %v, want %s", tt.value, result.ErrorCodes(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAsync_ContextPropagation(t *testing.T) {
+	type key struct{}
+	var seen interface{}
+	validator := Async(AsyncValidatorFunc(func(ctx context.Context, value interface{}) (ValidationResult, error) {
+		seen = ctx.Value(key{})
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("async validator context has no deadline")
+		}
+		return validation.NewValidationResult(), nil
+	}))
+
+	chain := NewValidatorChain("username").Add(Required).Add(validator)
+	ctx := context.WithValue(context.Background(), key{}, "request-42")
+	if result := chain.ValidateWithContext(ctx, "alice"); !result.Valid {
+		t.Fatalf("chain result = %v", result.ErrorMessages())
+	}
+	if seen != "request-42" {
+		t.Errorf("context value = %v, want request-42", seen)
+	}
+
+	// A cancelled caller context stops the lookup immediately
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := Unique(slowLookup, AsyncOptions{Timeout: time.Minute}).ValidateWithContext(cancelled, "x")
+	if !result.HasError(CodeLookupFailed) {
+		t.Errorf("cancelled lookup codes = %v, want %s", result.ErrorCodes(), CodeLookupFailed)
+	}
+}
+
+func TestAsync_IgnoresUncooperativeValidator(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	validator := Async(AsyncValidatorFunc(func(ctx context.Context, value interface{}) (ValidationResult, error) {
+		<-release // Ignores ctx
+		return validation.NewValidationResult(), nil
+	}), AsyncOptions{Name: "legacy", Timeout: 20 * time.Millisecond})
+
+	start := time.Now()
+	result := validator.Validate("x")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Validate() took %v despite timeout", elapsed)
+	}
+	if !result.HasError(CodeTimeout) || result.Errors[0].Context["asyncValidator"] != "legacy" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	var active, maxActive int32
+	tracked := func(ctx context.Context, value interface{}) (bool, error) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			current := atomic.LoadInt32(&maxActive)
+			if n <= current || atomic.CompareAndSwapInt32(&maxActive, current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return value == "taken", nil
+	}
+
+	rules := map[string]*ValidatorChain{
+		"username": NewValidatorChain("username").Add(Required).Add(Unique(tracked)),
+		"email":    NewValidatorChain("email").Add(Email).Add(Unique(tracked)),
+		"nickname": NewValidatorChain("nickname").Add(Unique(tracked)),
+		"customer": NewValidatorChain("customer").Add(Exists(tracked)),
+	}
+	data := map[string]interface{}{
+		"username": "taken",
+		"email":    "a@example.com",
+		"nickname": "taken",
+		"customer": "taken",
+	}
+
+	result := ValidateContext(context.Background(), data, rules)
+
+	if result.Valid || len(result.Errors) != 2 {
+		t.Fatalf("ValidateContext() errors = %v", result.Errors)
+	}
+	if result.Errors[0].Field != "nickname" || result.Errors[1].Field != "username" {
+		t.Errorf("errors not in field order: %s, %s", result.Errors[0].Field, result.Errors[1].Field)
+	}
+	if got := atomic.LoadInt32(&maxActive); got < 2 {
+		t.Errorf("fields were not validated in parallel (max active %d)", got)
+	}
+
+	atomic.StoreInt32(&maxActive, 0)
+	ValidateContext(context.Background(), data, rules, ValidateOptions{MaxConcurrency: 1})
+	if got := atomic.LoadInt32(&maxActive); got != 1 {
+		t.Errorf("MaxConcurrency 1 ran %d lookups at once", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = ValidateContext(ctx, data, rules, ValidateOptions{MaxConcurrency: 1})
+	if result.Valid || !result.HasError(CodeTimeout) {
+		t.Errorf("cancelled ValidateContext() codes = %v", result.ErrorCodes())
+	}
+}
@@ -0,0 +1,183 @@
+// File: batch.go
+// Title: Batch Validation
+// Description: Validates large datasets such as CSV imports concurrently.
+//              Progress is reported through a callback, an error budget
+//              stops validation after a number of failed items, and the
+//              returned report lists findings by item index.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of batch validation
+
+package validationx
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// BatchProgress is a snapshot of a running batch validation
+type BatchProgress struct {
+	Total     int // Number of items in the batch
+	Processed int // Items validated so far
+	Valid     int // Items without errors
+	Invalid   int // Items with errors
+	Warnings  int // Items with warnings
+}
+
+// BatchOptions configures ValidateBatch
+type BatchOptions struct {
+	Concurrency      int                 // Number of workers (0 = runtime.NumCPU)
+	MaxFailures      int                 // Stop dispatching after this many invalid items (0 = no limit)
+	Progress         func(BatchProgress) // Progress callback (optional, called sequentially)
+	ProgressInterval int                 // Items between progress callbacks (0 = 100)
+}
+
+// DefaultBatchOptions returns default options for batch validation
+func DefaultBatchOptions() BatchOptions {
+	return BatchOptions{
+		Concurrency:      runtime.NumCPU(),
+		ProgressInterval: 100,
+	}
+}
+
+// BatchItemResult is the validation result of a single item
+type BatchItemResult struct {
+	Index  int              // Position of the item in the batch
+	Result ValidationResult // Errors and warnings of the item
+}
+
+// BatchReport summarizes a batch validation. Items lists only items with
+// errors or warnings, ordered by index, to keep reports of large valid
+// datasets small.
+type BatchReport struct {
+	BatchProgress
+	Items   []BatchItemResult // Items with findings, by index
+	Stopped bool              // Validation stopped early (error budget or cancellation)
+}
+
+// Failed returns the items with errors
+func (r *BatchReport) Failed() []BatchItemResult {
+	var failed []BatchItemResult
+	for _, item := range r.Items {
+		if !item.Result.Valid {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Err returns an error summarizing invalid items, or nil if all
+// processed items are valid
+func (r *BatchReport) Err() error {
+	if r.Invalid == 0 {
+		return nil
+	}
+	if r.Stopped {
+		return fmt.Errorf("validation stopped after %d of %d items: %d invalid", r.Processed, r.Total, r.Invalid)
+	}
+	return fmt.Errorf("%d of %d items invalid", r.Invalid, r.Total)
+}
+
+// ValidateBatch validates items concurrently against the same rules
+func ValidateBatch(items []map[string]interface{}, rules map[string]*ValidatorChain, options ...BatchOptions) *BatchReport {
+	return ValidateBatchContext(context.Background(), items, rules, options...)
+}
+
+// ValidateBatchContext validates items concurrently, propagating ctx to every
+// validator. Cancelling ctx stops validation like an exhausted error budget.
+func ValidateBatchContext(ctx context.Context, items []map[string]interface{}, rules map[string]*ValidatorChain, options ...BatchOptions) *BatchReport {
+	opts := DefaultBatchOptions()
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = runtime.NumCPU()
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = 100
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	report := &BatchReport{BatchProgress: BatchProgress{Total: len(items)}}
+
+	// Items already being validated finish when the error budget is
+	// exhausted; only dispatching of further items stops
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var mu sync.Mutex
+	record := func(index int, result ValidationResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		report.Processed++
+		if result.Valid {
+			report.Valid++
+		} else {
+			report.Invalid++
+		}
+		if result.HasWarnings() {
+			report.Warnings++
+		}
+		if !result.Valid || result.HasWarnings() {
+			report.Items = append(report.Items, BatchItemResult{Index: index, Result: result})
+		}
+
+		if opts.MaxFailures > 0 && report.Invalid >= opts.MaxFailures {
+			stopOnce.Do(func() { close(stop) })
+		}
+		if opts.Progress != nil && report.Processed%opts.ProgressInterval == 0 {
+			opts.Progress(report.BatchProgress)
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				result := ValidateContext(ctx, items[index], rules, ValidateOptions{MaxConcurrency: 1})
+				record(index, result)
+			}
+		}()
+	}
+
+dispatch:
+	for index := range items {
+		select {
+		case <-stop:
+			break dispatch
+		case <-ctx.Done():
+			break dispatch
+		default:
+		}
+		select {
+		case jobs <- index:
+		case <-stop:
+			break dispatch
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.Stopped = report.Processed < report.Total
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].Index < report.Items[j].Index
+	})
+	if opts.Progress != nil && report.Processed%opts.ProgressInterval != 0 {
+		opts.Progress(report.BatchProgress)
+	}
+	return report
+}
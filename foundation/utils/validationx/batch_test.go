@@ -0,0 +1,125 @@
+// File: batch_test.go
+// Title: Batch Validation Tests
+// Description: Tests batch validation of datasets covering indexed reports,
+//              progress callbacks, the error budget, warnings, and
+//              cancellation.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial batch validation tests
+
+package validationx
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// importRules returns rules for a simple customer import
+func importRules() map[string]*ValidatorChain {
+	return map[string]*ValidatorChain{
+		"name":  NewValidatorChain("name").Add(Required),
+		"email": NewValidatorChain("email").Add(Required).Add(Email),
+		"phone": NewValidatorChain("phone").Add(AsWarning(Optional(Phone))),
+	}
+}
+
+// importRows returns n rows; every row whose index is a multiple of
+// invalidEvery has an invalid e-mail address
+func importRows(n, invalidEvery int) []map[string]interface{} {
+	rows := make([]map[string]interface{}, n)
+	for i := range rows {
+		rows[i] = map[string]interface{}{
+			"name":  fmt.Sprintf("Customer %d", i),
+			"email": fmt.Sprintf("customer%d@example.com", i),
+		}
+		if invalidEvery > 0 && i%invalidEvery == 0 {
+			rows[i]["email"] = "invalid"
+		}
+	}
+	return rows
+}
+
+func TestValidateBatch(t *testing.T) {
+	rows := importRows(1000, 100)
+	rows[5]["phone"] = "12" // Warning only
+
+	var calls []BatchProgress
+	report := ValidateBatch(rows, importRules(), BatchOptions{
+		Concurrency:      4,
+		ProgressInterval: 250,
+		Progress:         func(p BatchProgress) { calls = append(calls, p) },
+	})
+
+	if report.Total != 1000 || report.Processed != 1000 || report.Invalid != 10 || report.Valid != 990 || report.Stopped {
+		t.Fatalf("report = %+v", report.BatchProgress)
+	}
+	if report.Warnings != 1 || len(report.Items) != 11 {
+		t.Errorf("warnings = %d, items = %d", report.Warnings, len(report.Items))
+	}
+
+	failed := report.Failed()
+	if len(failed) != 10 {
+		t.Fatalf("Failed() = %d items, want 10", len(failed))
+	}
+	for i, item := range failed {
+		if item.Index != i*100 || item.Result.Errors[0].Field != "email" {
+			t.Errorf("failed[%d] = index %d, field %s", i, item.Index, item.Result.Errors[0].Field)
+		}
+	}
+	if report.Items[1].Index != 5 || !report.Items[1].Result.Valid {
+		t.Errorf("warning item = %+v", report.Items[1])
+	}
+
+	if len(calls) != 4 || calls[3].Processed != 1000 {
+		t.Errorf("progress calls = %+v", calls)
+	}
+	if report.Err() == nil {
+		t.Error("Err() = nil for report with invalid items")
+	}
+}
+
+func TestValidateBatch_ErrorBudget(t *testing.T) {
+	rows := importRows(10000, 1) // Every row is invalid
+
+	var last BatchProgress
+	report := ValidateBatch(rows, importRules(), BatchOptions{
+		Concurrency: 2,
+		MaxFailures: 5,
+		Progress:    func(p BatchProgress) { last = p },
+	})
+
+	if !report.Stopped || report.Invalid < 5 || report.Processed >= report.Total {
+		t.Errorf("report = %+v, want stopped after about 5 failures", report.BatchProgress)
+	}
+	if report.Invalid > 5+2 {
+		t.Errorf("Invalid = %d, more items than budget plus workers were validated", report.Invalid)
+	}
+	if last.Processed != report.Processed {
+		t.Errorf("final progress = %+v, want %d processed", last, report.Processed)
+	}
+	if err := report.Err(); err == nil {
+		t.Error("Err() = nil for stopped report")
+	}
+}
+
+func TestValidateBatch_Edges(t *testing.T) {
+	report := ValidateBatch(importRows(50, 0), importRules())
+	if report.Err() != nil || report.Processed != 50 || len(report.Items) != 0 {
+		t.Errorf("valid batch report = %+v, err = %v", report.BatchProgress, report.Err())
+	}
+
+	if report := ValidateBatch(nil, importRules()); report.Total != 0 || report.Stopped {
+		t.Errorf("empty batch report = %+v", report)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := ValidateBatchContext(ctx, importRows(100, 0), importRules()); !report.Stopped || report.Processed != 0 {
+		t.Errorf("cancelled batch report = %+v", report.BatchProgress)
+	}
+}
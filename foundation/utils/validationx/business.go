@@ -0,0 +1,360 @@
+// File: business.go
+// Title: Financial and Trade Identifier Validators
+// Description: Implements validators for banking and trade identifiers used
+//              in enterprise billing: IBAN (country length and mod-97 check),
+//              BIC/SWIFT, EU VAT identification numbers (per-country format),
+//              EAN/GTIN check digits, and ISIN. Input may contain spaces as
+//              commonly used in printed form.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of IBAN, BIC, VAT-ID, EAN/GTIN, and ISIN validators
+// - 2026-10-16 v0.1.1: Check the country code of BICs
+
+package validationx
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// ibanLengths maps IBAN country codes to the total IBAN length
+// (SWIFT IBAN registry)
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// vatPatterns maps EU VAT country prefixes to the format of the number part.
+// Greece uses EL; XI covers Northern Ireland.
+var vatPatterns = map[string]string{
+	"AT": `^U[0-9]{8}$`,
+	"BE": `^[01][0-9]{9}$`,
+	"BG": `^[0-9]{9,10}$`,
+	"CY": `^[0-9]{8}[A-Z]$`,
+	"CZ": `^[0-9]{8,10}$`,
+	"DE": `^[0-9]{9}$`,
+	"DK": `^[0-9]{8}$`,
+	"EE": `^[0-9]{9}$`,
+	"EL": `^[0-9]{9}$`,
+	"ES": `^[0-9A-Z][0-9]{7}[0-9A-Z]$`,
+	"FI": `^[0-9]{8}$`,
+	"FR": `^[0-9A-Z]{2}[0-9]{9}$`,
+	"HR": `^[0-9]{11}$`,
+	"HU": `^[0-9]{8}$`,
+	"IE": `^[0-9][0-9A-Z+*][0-9]{5}[A-Z]{1,2}$`,
+	"IT": `^[0-9]{11}$`,
+	"LT": `^([0-9]{9}|[0-9]{12})$`,
+	"LU": `^[0-9]{8}$`,
+	"LV": `^[0-9]{11}$`,
+	"MT": `^[0-9]{8}$`,
+	"NL": `^[0-9]{9}B[0-9]{2}$`,
+	"PL": `^[0-9]{10}$`,
+	"PT": `^[0-9]{9}$`,
+	"RO": `^[0-9]{2,10}$`,
+	"SE": `^[0-9]{10}01$`,
+	"SI": `^[0-9]{8}$`,
+	"SK": `^[0-9]{10}$`,
+	"XI": `^([0-9]{9}|[0-9]{12}|GD[0-9]{3}|HA[0-9]{3})$`,
+}
+
+// countryCodes are the ISO 3166-1 alpha-2 country codes and XK (Kosovo),
+// which banks use in BICs and IBANs
+var countryCodes = func(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}(
+	"AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE " +
+		"BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD " +
+		"CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM " +
+		"DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF " +
+		"GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU " +
+		"ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN " +
+		"KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME " +
+		"MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ NA " +
+		"NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM " +
+		"PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI " +
+		"SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK " +
+		"TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI " +
+		"VN VU WF WS XK YE YT ZA ZM ZW",
+)
+
+// BIC and ISIN structure patterns
+const (
+	bicPattern  = `^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`
+	isinPattern = `^[A-Z]{2}[A-Z0-9]{9}[0-9]$`
+)
+
+// normalizeIdentifier removes spaces (and optionally dots and dashes) and
+// converts to upper case
+func normalizeIdentifier(value string, separators string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if r == ' ' || strings.ContainsRune(separators, r) {
+			return -1
+		}
+		return r
+	}, value))
+}
+
+// ===============================
+// Banking Identifiers
+// ===============================
+
+// IBAN validates an International Bank Account Number: known country code,
+// country-specific length, and the ISO 7064 mod-97 check digits.
+// Spaces are ignored ("DE89 3704 0044 0532 0130 00").
+var IBAN validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	iban := normalizeIdentifier(str, "")
+	if len(iban) < 4 {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIBAN, "must be a valid IBAN", nil)
+	}
+
+	country := iban[:2]
+	length, known := ibanLengths[country]
+	if !known {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIBANCountry, "IBAN country code is not supported",
+			map[string]interface{}{"Country": country})
+	}
+	if len(iban) != length {
+		return validation.NewValidationErrorWithKey(validation.CodeLength, KeyIBANLength, "IBAN has an invalid length for its country",
+			map[string]interface{}{"Country": country, "Length": length})
+	}
+
+	if !ibanChecksum(iban) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIBAN, "must be a valid IBAN", nil)
+	}
+
+	return validation.NewValidationResult()
+}
+
+// ibanChecksum verifies the mod-97 check digits of a normalized IBAN
+func ibanChecksum(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			// Letters expand to two digits: A=10 ... Z=35
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// BIC validates a Business Identifier Code (SWIFT code) of 8 or 11
+// characters: bank code, ISO 3166 country code, location code, optional
+// branch
+var BIC validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	regex, err := getCompiledRegex(bicPattern)
+	if err != nil {
+		return validation.NewValidationError(validation.CodePattern, "invalid regex pattern")
+	}
+
+	bic := normalizeIdentifier(str, "")
+	if !regex.MatchString(bic) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyBIC, "must be a valid BIC", nil)
+	}
+	if country := bic[4:6]; !countryCodes[country] {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyBICCountry, "BIC country code is not valid",
+			map[string]interface{}{"Country": country})
+	}
+
+	return validation.NewValidationResult()
+}
+
+// ===============================
+// Tax Identifiers
+// ===============================
+
+// VATID validates the format of an EU VAT identification number including
+// its country prefix, e.g. "DE123456789" or "NL123456789B01". Spaces, dots,
+// and dashes are ignored. Only the per-country format is checked; use an
+// external lookup (VIES) to verify that a number is registered.
+var VATID validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	vat := normalizeIdentifier(str, ".-")
+	if len(vat) < 3 {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyVATID, "must be a valid VAT identification number", nil)
+	}
+
+	country := vat[:2]
+	pattern, known := vatPatterns[country]
+	if !known {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyVATIDCountry, "VAT country prefix is not supported",
+			map[string]interface{}{"Country": country})
+	}
+
+	regex, err := getCompiledRegex(pattern)
+	if err != nil {
+		return validation.NewValidationError(validation.CodePattern, "invalid regex pattern")
+	}
+	if !regex.MatchString(vat[2:]) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyVATID, "must be a valid VAT identification number",
+			map[string]interface{}{"Country": country})
+	}
+
+	return validation.NewValidationResult()
+}
+
+// ===============================
+// Trade and Securities Identifiers
+// ===============================
+
+// EAN validates a European Article Number (EAN-8 or EAN-13) including its
+// check digit
+var EAN validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	return validateGTIN(value, KeyEAN, "must be a valid EAN", 8, 13)
+}
+
+// GTIN validates a Global Trade Item Number (GTIN-8, GTIN-12/UPC-A,
+// GTIN-13/EAN-13, or GTIN-14) including its check digit
+var GTIN validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	return validateGTIN(value, KeyGTIN, "must be a valid GTIN", 8, 12, 13, 14)
+}
+
+// validateGTIN checks the length and GS1 check digit of a GTIN family code
+func validateGTIN(value interface{}, key, message string, lengths ...int) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	code := normalizeIdentifier(str, "-")
+	validLength := false
+	for _, length := range lengths {
+		if len(code) == length {
+			validLength = true
+			break
+		}
+	}
+	if !validLength || !gtinCheckDigit(code) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, key, message, nil)
+	}
+
+	return validation.NewValidationResult()
+}
+
+// gtinCheckDigit verifies the GS1 mod-10 check digit: digits are weighted
+// 3 and 1 alternately from the right, excluding the check digit itself
+func gtinCheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := code[i]
+		if digit < '0' || digit > '9' {
+			return false
+		}
+		weight := 1
+		if (len(code)-2-i)%2 == 0 {
+			weight = 3
+		}
+		sum += int(digit-'0') * weight
+	}
+
+	check := code[len(code)-1]
+	if check < '0' || check > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(check-'0')
+}
+
+// ISIN validates an International Securities Identification Number:
+// country prefix, nine-character national code, and Luhn check digit
+var ISIN validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	isin := normalizeIdentifier(str, "")
+	regex, err := getCompiledRegex(isinPattern)
+	if err != nil {
+		return validation.NewValidationError(validation.CodePattern, "invalid regex pattern")
+	}
+	if !regex.MatchString(isin) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyISIN, "must be a valid ISIN", nil)
+	}
+
+	// Letters expand to two digits (A=10 ... Z=35) before the Luhn check
+	var digits strings.Builder
+	for _, r := range isin {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	if !luhnCheck(digits.String()) {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyISIN, "must be a valid ISIN", nil)
+	}
+
+	return validation.NewValidationResult()
+}
+
+// ===============================
+// Convenience Functions
+// ===============================
+
+// IsValidIBAN checks if a string is a valid IBAN
+func IsValidIBAN(iban string) bool {
+	return IBAN(iban).Valid
+}
+
+// IsValidBIC checks if a string is a valid BIC/SWIFT code
+func IsValidBIC(bic string) bool {
+	return BIC(bic).Valid
+}
+
+// IsValidVATID checks if a string is a validly formatted EU VAT number
+func IsValidVATID(vatID string) bool {
+	return VATID(vatID).Valid
+}
+
+// IsValidEAN checks if a string is a valid EAN-8 or EAN-13
+func IsValidEAN(ean string) bool {
+	return EAN(ean).Valid
+}
+
+// IsValidGTIN checks if a string is a valid GTIN-8, -12, -13, or -14
+func IsValidGTIN(gtin string) bool {
+	return GTIN(gtin).Valid
+}
+
+// IsValidISIN checks if a string is a valid ISIN
+func IsValidISIN(isin string) bool {
+	return ISIN(isin).Valid
+}
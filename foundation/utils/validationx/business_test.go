@@ -0,0 +1,148 @@
+// File: business_test.go
+// Title: Financial and Trade Identifier Validator Tests
+// Description: Table-driven tests for the IBAN, BIC, VAT-ID, EAN/GTIN, and
+//              ISIN validators using published sample identifiers.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.1
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial business identifier tests
+// - 2026-10-16 v0.1.1: Added BIC country code tests
+
+package validationx
+
+import (
+	"testing"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// identifierCase is a single validator input with the expected outcome
+type identifierCase struct {
+	name     string
+	value    interface{}
+	valid    bool
+	wantCode string
+}
+
+// runIdentifierCases runs validator against all cases
+func runIdentifierCases(t *testing.T, validator validation.ValidatorFunc, cases []identifierCase) {
+	t.Helper()
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.Validate(tt.value)
+			if result.Valid != tt.valid {
+				t.Fatalf("Validate(%v) valid = %v, want %v (%v)", tt.value, result.Valid, tt.valid, result.ErrorMessages())
+			}
+			if tt.wantCode != "" && !result.HasError(tt.wantCode) {
+				t.Errorf("Validate(%v) codes = %v, want %s", tt.value, result.ErrorCodes(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	runIdentifierCases(t, IBAN, []identifierCase{
+		{"German", "DE89370400440532013000", true, ""},
+		{"German printed form", "DE89 3704 0044 0532 0130 00", true, ""},
+		{"British lower case", "gb82west12345698765432", true, ""},
+		{"Dutch", "NL91ABNA0417164300", true, ""},
+		{"Norwegian shortest", "NO9386011117947", true, ""},
+		{"wrong check digits", "DE88370400440532013000", false, validation.CodeFormat},
+		{"wrong length", "DE8937040044053201300", false, validation.CodeLength},
+		{"unknown country", "XX89370400440532013000", false, validation.CodeFormat},
+		{"invalid characters", "DE89-37040044053201300", false, validation.CodeFormat},
+		{"too short", "DE", false, validation.CodeFormat},
+		{"not a string", 12345, false, validation.CodeType},
+	})
+
+	if !IsValidIBAN("FR1420041010050500013M02606") || IsValidIBAN("FR1420041010050500013M02607") {
+		t.Error("IsValidIBAN() gave wrong result for French IBAN")
+	}
+}
+
+func TestBIC(t *testing.T) {
+	runIdentifierCases(t, BIC, []identifierCase{
+		{"8 characters", "DEUTDEFF", true, ""},
+		{"11 characters", "DEUTDEFF500", true, ""},
+		{"lower case", "cobadeffxxx", true, ""},
+		{"9 characters", "DEUTDEFF5", false, validation.CodeFormat},
+		{"digit in bank code", "DE1TDEFF", false, validation.CodeFormat},
+		{"Kosovo", "RBKOXKPR", true, ""},
+		{"unknown country", "DEUTXXFF", false, validation.CodeFormat},
+		{"not a string", nil, false, validation.CodeType},
+	})
+
+	if !IsValidBIC("NWBKGB2L") {
+		t.Error("IsValidBIC() rejected a valid BIC")
+	}
+}
+
+func TestVATID(t *testing.T) {
+	runIdentifierCases(t, VATID, []identifierCase{
+		{"Germany", "DE123456789", true, ""},
+		{"Austria", "ATU12345678", true, ""},
+		{"Netherlands", "NL123456789B01", true, ""},
+		{"Greece", "EL123456789", true, ""},
+		{"France with letters", "FRXX123456789", true, ""},
+		{"Spain", "ESX1234567Z", true, ""},
+		{"Ireland", "IE1234567WA", true, ""},
+		{"Sweden", "SE123456789001", true, ""},
+		{"Northern Ireland", "XI123456789", true, ""},
+		{"separators ignored", "de 123.456-789", true, ""},
+		{"Germany too short", "DE12345678", false, validation.CodeFormat},
+		{"Austria without U", "AT12345678", false, validation.CodeFormat},
+		{"Greece with ISO code", "GR123456789", false, validation.CodeFormat},
+		{"Sweden without suffix", "SE123456789012", false, validation.CodeFormat},
+		{"non-EU", "US123456789", false, validation.CodeFormat},
+		{"empty", "", false, validation.CodeFormat},
+	})
+
+	if IsValidVATID("BE2123456789") {
+		t.Error("IsValidVATID() accepted Belgian number starting with 2")
+	}
+}
+
+func TestEANAndGTIN(t *testing.T) {
+	runIdentifierCases(t, EAN, []identifierCase{
+		{"EAN-13", "4006381333931", true, ""},
+		{"EAN-13 with dashes", "400-6381-33393-1", true, ""},
+		{"EAN-8", "96385074", true, ""},
+		{"EAN-13 wrong check digit", "4006381333932", false, validation.CodeFormat},
+		{"UPC-A is not an EAN", "036000291452", false, validation.CodeFormat},
+		{"letters", "400638133393A", false, validation.CodeFormat},
+		{"not a string", 4006381333931, false, validation.CodeType},
+	})
+
+	runIdentifierCases(t, GTIN, []identifierCase{
+		{"GTIN-12 (UPC-A)", "036000291452", true, ""},
+		{"GTIN-13", "4006381333931", true, ""},
+		{"GTIN-14", "10614141000415", true, ""},
+		{"GTIN-8", "96385074", true, ""},
+		{"GTIN-14 wrong check digit", "10614141000416", false, validation.CodeFormat},
+		{"11 digits", "03600029145", false, validation.CodeFormat},
+	})
+
+	if !IsValidEAN("5901234123457") || !IsValidGTIN("00614141000418") {
+		t.Error("IsValidEAN()/IsValidGTIN() rejected valid codes")
+	}
+}
+
+func TestISIN(t *testing.T) {
+	runIdentifierCases(t, ISIN, []identifierCase{
+		{"US (Apple)", "US0378331005", true, ""},
+		{"German", "DE0007164600", true, ""},
+		{"British with letters", "GB0002634946", true, ""},
+		{"Australian with letters", "AU0000XVGZA3", true, ""},
+		{"wrong check digit", "US0378331006", false, validation.CodeFormat},
+		{"too short", "US037833100", false, validation.CodeFormat},
+		{"lowercase accepted", "us0378331005", true, ""},
+		{"not a string", 42, false, validation.CodeType},
+	})
+
+	if IsValidISIN("XX") {
+		t.Error("IsValidISIN() accepted an invalid ISIN")
+	}
+}
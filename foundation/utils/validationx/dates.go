@@ -0,0 +1,183 @@
+// File: dates.go
+// Title: Calendar and Time Zone Validators
+// Description: Implements date and time validators backed by timex: business
+//              days, business hours, minimum and maximum age for birth
+//              dates, and IANA time zone names. Values may be time.Time or
+//              strings in any format understood by timex.Parse.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of timex-backed date validators
+
+package validationx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+	"github.com/msto63/mDW/foundation/utils/timex"
+)
+
+// toTime converts a validated value to a time. The returned result is
+// non-nil if the value is not a date.
+func toTime(value interface{}) (time.Time, *ValidationResult) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		t, err := timex.Parse(v)
+		if err != nil {
+			result := validation.NewValidationError(validation.CodeDate, "must be a valid date")
+			return time.Time{}, &result
+		}
+		return t, nil
+	}
+	result := validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeDate, "must be a date", nil)
+	return time.Time{}, &result
+}
+
+// ===============================
+// Business Calendar Validators
+// ===============================
+
+// BusinessDay validates that a date is a business day according to config
+// (timex.DefaultBusinessDayConfig if omitted)
+func BusinessDay(config ...*timex.BusinessDayConfig) validation.ValidatorFunc {
+	return func(value interface{}) validation.ValidationResult {
+		t, invalid := toTime(value)
+		if invalid != nil {
+			return *invalid
+		}
+
+		if !timex.IsBusinessDay(t, config...) {
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyBusinessDay, "must be a business day",
+				map[string]interface{}{"Date": t.Format(timex.BusinessDate)})
+		}
+
+		return validation.NewValidationResult()
+	}
+}
+
+// BusinessHours describes a daily opening schedule on business days
+type BusinessHours struct {
+	Start    string                   // Opening time "15:04", inclusive
+	End      string                   // Closing time "15:04", exclusive
+	Location *time.Location           // Time zone of the schedule (nil = value's own)
+	Days     *timex.BusinessDayConfig // Business days (nil = timex default)
+}
+
+// DefaultBusinessHours returns a schedule of 09:00 to 17:00 on business days
+func DefaultBusinessHours() BusinessHours {
+	return BusinessHours{
+		Start: "09:00",
+		End:   "17:00",
+	}
+}
+
+// WithinBusinessHours validates that a point in time falls on a business day
+// between the schedule's opening and closing time
+func WithinBusinessHours(schedule BusinessHours) validation.ValidatorFunc {
+	start, startErr := time.Parse(timex.ShortTime, schedule.Start)
+	end, endErr := time.Parse(timex.ShortTime, schedule.End)
+
+	return func(value interface{}) validation.ValidationResult {
+		if startErr != nil || endErr != nil || !start.Before(end) {
+			return validation.NewValidationError(validation.CodeTime,
+				fmt.Sprintf("invalid business hours %s-%s", schedule.Start, schedule.End))
+		}
+
+		t, invalid := toTime(value)
+		if invalid != nil {
+			return *invalid
+		}
+		if schedule.Location != nil {
+			t = t.In(schedule.Location)
+		}
+
+		params := map[string]interface{}{"Start": schedule.Start, "End": schedule.End}
+		if !timex.IsBusinessDay(t, schedule.Days) {
+			return validation.NewValidationErrorWithKey(validation.CodeTime, KeyBusinessHours,
+				fmt.Sprintf("must be within business hours (%s-%s)", schedule.Start, schedule.End), params)
+		}
+
+		clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+		opens := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+		closes := time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute
+		if clock < opens || clock >= closes {
+			return validation.NewValidationErrorWithKey(validation.CodeTime, KeyBusinessHours,
+				fmt.Sprintf("must be within business hours (%s-%s)", schedule.Start, schedule.End), params)
+		}
+
+		return validation.NewValidationResult()
+	}
+}
+
+// ===============================
+// Age Validators
+// ===============================
+
+// MinAge validates that a birth date corresponds to an age of at least years
+func MinAge(years int) validation.ValidatorFunc {
+	return func(value interface{}) validation.ValidationResult {
+		birthDate, invalid := toTime(value)
+		if invalid != nil {
+			return *invalid
+		}
+
+		if timex.AgeToday(birthDate) < years {
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyMinAge,
+				fmt.Sprintf("must be at least %d years old", years), map[string]interface{}{"Age": years})
+		}
+
+		return validation.NewValidationResult()
+	}
+}
+
+// MaxAge validates that a birth date corresponds to an age of at most years
+func MaxAge(years int) validation.ValidatorFunc {
+	return func(value interface{}) validation.ValidationResult {
+		birthDate, invalid := toTime(value)
+		if invalid != nil {
+			return *invalid
+		}
+
+		if timex.AgeToday(birthDate) > years {
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyMaxAge,
+				fmt.Sprintf("must be at most %d years old", years), map[string]interface{}{"Age": years})
+		}
+
+		return validation.NewValidationResult()
+	}
+}
+
+// ===============================
+// Time Zone Validators
+// ===============================
+
+// Timezone validates that a string is an IANA time zone name such as
+// "Europe/Berlin"
+var Timezone validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
+	str, ok := value.(string)
+	if !ok {
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
+	}
+
+	if _, err := timex.LoadLocation(str); err != nil {
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyTimezone, "must be a valid time zone", nil)
+	}
+
+	return validation.NewValidationResult()
+}
+
+// IsValidTimezone checks if a string is a valid IANA time zone name
+func IsValidTimezone(tz string) bool {
+	return Timezone.Validate(tz).Valid
+}
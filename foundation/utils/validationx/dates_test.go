@@ -0,0 +1,132 @@
+// File: dates_test.go
+// Title: Calendar and Time Zone Validator Tests
+// Description: Tests for the business day, business hours, age, and time
+//              zone validators, including their rule definitions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial date validator tests
+
+package validationx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+	"github.com/msto63/mDW/foundation/utils/timex"
+)
+
+func TestBusinessDay(t *testing.T) {
+	newYear := &timex.BusinessDayConfig{
+		WeekendDays: []timex.Weekday{timex.Saturday, timex.Sunday},
+		Holidays:    []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	runIdentifierCases(t, BusinessDay(), []identifierCase{
+		{"Monday", time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), true, ""},
+		{"Monday as string", "2024-01-15", true, ""},
+		{"Saturday", "2024-01-13", false, validation.CodeDate},
+		{"unparsable", "someday", false, validation.CodeDate},
+		{"not a date", 42, false, validation.CodeType},
+	})
+	runIdentifierCases(t, BusinessDay(newYear), []identifierCase{
+		{"holiday", "2024-01-01", false, validation.CodeDate},
+		{"day after holiday", "2024-01-02", true, ""},
+	})
+}
+
+func TestWithinBusinessHours(t *testing.T) {
+	berlin, err := timex.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	runIdentifierCases(t, WithinBusinessHours(DefaultBusinessHours()), []identifierCase{
+		{"opening time", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), true, ""},
+		{"afternoon", "2024-01-15 16:59:59", true, ""},
+		{"closing time", "2024-01-15 17:00:00", false, validation.CodeTime},
+		{"early morning", "2024-01-15 08:30:00", false, validation.CodeTime},
+		{"weekend", "2024-01-13 10:00:00", false, validation.CodeTime},
+		{"not a date", true, false, validation.CodeType},
+	})
+
+	schedule := BusinessHours{Start: "08:00", End: "12:00", Location: berlin}
+	runIdentifierCases(t, WithinBusinessHours(schedule), []identifierCase{
+		{"UTC converted to Berlin", time.Date(2024, 1, 15, 7, 30, 0, 0, time.UTC), true, ""},
+		{"after closing in Berlin", time.Date(2024, 1, 15, 11, 30, 0, 0, time.UTC), false, validation.CodeTime},
+	})
+
+	invalid := WithinBusinessHours(BusinessHours{Start: "18:00", End: "09:00"})
+	if result := invalid.Validate("2024-01-15 10:00:00"); result.Valid {
+		t.Error("WithinBusinessHours() with inverted schedule accepted a value")
+	}
+}
+
+func TestMinAgeMaxAge(t *testing.T) {
+	today := timex.Today()
+	adult := today.AddDate(-18, 0, 0)
+	almostAdult := today.AddDate(-18, 0, 1)
+
+	runIdentifierCases(t, MinAge(18), []identifierCase{
+		{"18th birthday today", adult, true, ""},
+		{"18th birthday tomorrow", almostAdult, false, validation.CodeDate},
+		{"birth date string", today.AddDate(-40, 0, 0).Format(timex.BusinessDate), true, ""},
+		{"not a date", 18, false, validation.CodeType},
+	})
+	runIdentifierCases(t, MaxAge(120), []identifierCase{
+		{"adult", adult, true, ""},
+		{"implausible", today.AddDate(-130, 0, 0), false, validation.CodeDate},
+	})
+
+	result := MinAge(18).Validate(almostAdult)
+	if keys := result.Errors[0].MessageKeys(); keys[0] != KeyMinAge || result.Errors[0].Params["Age"] != 18 {
+		t.Errorf("MinAge() keys = %v, params = %v", keys, result.Errors[0].Params)
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	if !IsValidTimezone("UTC") {
+		t.Fatal("IsValidTimezone(UTC) = false")
+	}
+	if _, err := timex.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	runIdentifierCases(t, Timezone, []identifierCase{
+		{"Berlin", "Europe/Berlin", true, ""},
+		{"New York", "America/New_York", true, ""},
+		{"unknown", "Mars/Olympus_Mons", false, validation.CodeFormat},
+		{"empty", "", false, validation.CodeFormat},
+		{"not a string", 1, false, validation.CodeType},
+	})
+}
+
+func TestDateRules(t *testing.T) {
+	rules, err := LoadRules([]byte(`
+birthdate = ["required", { rule = "min_age", years = 18 }, { rule = "max_age", years = 120 }]
+delivery  = ["business_day"]
+callback  = [{ rule = "business_hours", start = "08:00", end = "18:00", timezone = "UTC" }]
+zone      = ["timezone"]
+`), RuleFormatTOML)
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	result := Validate(map[string]interface{}{
+		"birthdate": timex.Today().AddDate(-10, 0, 0),
+		"delivery":  "2024-01-14",
+		"callback":  "2024-01-15 07:59:00",
+		"zone":      "Nowhere",
+	}, rules)
+	if len(result.Errors) != 4 {
+		t.Errorf("Validate() errors = %v, want 4", result.ErrorMessages())
+	}
+
+	if _, err := LoadRules([]byte(`t = [{ rule = "business_hours", timezone = "Nowhere" }]`), RuleFormatTOML); err == nil {
+		t.Error("LoadRules() accepted an invalid business hours timezone")
+	}
+}
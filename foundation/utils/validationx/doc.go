@@ -8,14 +8,15 @@
 //              it provides concrete validators with consistent error handling and 
 //              integration patterns for enterprise applications.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.2.0
+// Version: v0.2.1
 // Created: 2025-01-25
-// Modified: 2025-01-26
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with comprehensive validation utilities
 // - 2025-01-26 v0.1.1: Enhanced documentation with comprehensive examples and mDW integration
 // - 2025-01-26 v0.2.0: Refactored to use core validation framework with standardized error codes
+// - 2026-10-16 v0.2.1: Documented localized error messages
 //
 // Package Overview:
 //
@@ -73,6 +74,10 @@
 // Temporal data validation:
 //   - IsDate: Date format validation
 //   - DateAfter/DateBefore: Date comparison validation
+//   - BusinessDay: Business day check using timex business day rules
+//   - WithinBusinessHours: Opening hours check with time zone support
+//   - MinAge/MaxAge: Age limits for birth dates
+//   - Timezone: IANA time zone name validation
 //   - Multiple date format support
 //
 // # Collection Validation Functions
@@ -86,6 +91,11 @@
 // Common business data validation:
 //   - CreditCard: Credit card number validation with Luhn algorithm
 //   - Phone: Phone number format validation
+//   - IBAN: Country-specific length and mod-97 check digits
+//   - BIC: BIC/SWIFT code structure (8 or 11 characters)
+//   - VATID: EU VAT identification number format per country
+//   - EAN/GTIN: Article numbers with GS1 check digit
+//   - ISIN: Securities identification numbers with check digit
 //   - Extensible for custom business rules
 //
 // # Validator Chains
@@ -93,7 +103,93 @@
 // Powerful composition system for combining validators:
 //   - ValidatorChain: Compose multiple validators
 //   - Field-specific error reporting
-//   - Short-circuit evaluation on first failure
+//   - Collect-all errors by default; StopOnFirstError and MaxErrors limit evaluation
+//
+// # Typed Validators
+//
+// Generic validators checked at compile time, built on validation.Chain[T]:
+//   - NewChain[T]: Typed validator chain
+//   - NotBlank, LengthBetween, Matches: String validators
+//   - AtLeast, AtMost, Between: Validators for any numeric type
+//   - NotZeroTime, TimeAfter, TimeBefore: time.Time validators
+//   - OneOf: Set membership for any comparable type
+//
+//	age := validationx.NewChain[int]("age").Add(validationx.Between(18, 120))
+//	name := validationx.NewChain[string]("name").
+//		Add(validationx.NotBlank).
+//		Add(validation.Typed[string](validationx.AlphaOnly)) // Untyped validators still work
+//
+// # Declarative Rules
+//
+// Validator chains can be defined in TOML or YAML and loaded at runtime:
+//   - LoadRules/LoadRulesFile: Parse rule documents into validator chains
+//   - RulesFromMap: Build chains from a decoded configuration section
+//   - RegisterRule: Make custom validators available to rule documents
+//
+//	# rules.toml
+//	username = ["required", { rule = "min_length", min = 3 }]
+//	email    = ["required", "email"]
+//	age      = [{ rule = "range", min = 18, max = 120, optional = true }]
+//
+//	rules, err := validationx.LoadRulesFile("rules.toml")
+//	result := validationx.Validate(formData, rules)
+//
+// Loaded rules carry metadata (rule name, params, error codes); DescribeRules
+// returns it per field for API documentation and client-side validation.
+// Rule names match the snake_case validator names (min_length, vat_id,
+// date_after, ...); RegisteredRules lists them. Invalid documents are
+// rejected as a whole, so a faulty reload keeps the previous rules active.
+//
+// # Warnings
+//
+// Soft findings can be reported without rejecting a value:
+//   - AsWarning: Reports a validator's errors in result.Warnings; Valid stays true
+//   - ValidatorChain.WarningsAsErrors: Chain policy promoting warnings to errors
+//   - warning = true: Rule definition key for the same effect in rule documents
+//
+//	chain := validationx.NewValidatorChain("phone").
+//		Add(validationx.Required).
+//		Add(validationx.AsWarning(validationx.Phone))
+//
+//	result := chain.Validate("12")
+//	// result.Valid == true, result.WarningMessages() lists the phone finding
+//
+// # Async and External-Lookup Validation
+//
+// Validators that call databases or services:
+//   - AsyncValidator/AsyncValidatorFunc: Context-aware validators returning lookup errors
+//   - Async: Adapts an AsyncValidator for use in validator chains
+//   - Unique/Exists: Lookup-based checks (username taken, customer exists)
+//   - AsyncOptions: Per-validator timeout and fail-open behavior
+//   - ValidateContext: Parallel field validation with aggregated results
+//
+//	rules := map[string]*validationx.ValidatorChain{
+//		"username": validationx.NewValidatorChain("username").
+//			Add(validationx.Required).
+//			Add(validationx.Unique(userRepo.UsernameExists,
+//				validationx.AsyncOptions{Name: "username-unique", Timeout: 200 * time.Millisecond})),
+//	}
+//	result := validationx.ValidateContext(r.Context(), formData, rules)
+//
+// Failed lookups are reported as CodeLookupFailed and timeouts as CodeTimeout,
+// so callers can tell an unavailable database from invalid input.
+//
+// # Batch Validation
+//
+// Large datasets such as CSV imports are validated concurrently:
+//   - ValidateBatch/ValidateBatchContext: Validate rows against shared rules
+//   - BatchOptions: Worker count, error budget (MaxFailures), progress callback
+//   - BatchReport: Counts plus findings indexed by row for import UIs
+//
+//	report := validationx.ValidateBatch(rows, rules, validationx.BatchOptions{
+//		MaxFailures: 100,
+//		Progress: func(p validationx.BatchProgress) {
+//			ui.SetProgress(p.Processed, p.Total)
+//		},
+//	})
+//	for _, item := range report.Failed() {
+//		fmt.Printf("row %d: %v\n", item.Index+1, item.Result.ErrorMessages())
+//	}
 //
 // # Custom Validation
 //
@@ -110,6 +206,27 @@
 //   - Field-specific error messages
 //   - Multiple error aggregation
 //
+// # Localized Error Messages
+//
+// Error messages are translated with core/i18n instead of mapping codes in
+// every caller. Each error carries an i18n message key derived from its code
+// ("validation.<code>", e.g. "validation.required"), optionally refined as
+// "<code key>_<variant>" (e.g. "validation.length_min") with template params:
+//
+//	// locales/de.toml
+//	[validation]
+//	required = "Feld ist erforderlich"
+//	length_min = "{{.FieldLabel}} muss mindestens {{.Min}} Zeichen lang sein"
+//
+//	[validation.fields]
+//	name = "Name"
+//
+//	result := validationx.Validate(formData, rules)
+//	localized := result.Localize(i18nManager, "de")
+//
+// Lookup tries the refined key, then the code key, and keeps the English
+// message when neither exists. MessageKeys lists all keys for locale files.
+//
 // # Usage Examples
 //
 // Basic field validation:
@@ -236,6 +353,13 @@
 //		Add(validationx.Required).
 //		Add(validationx.UUID)
 //
+//	// Billing data validation
+//	billingRules := map[string]*validationx.ValidatorChain{
+//		"iban":   validationx.NewValidatorChain("iban").Add(validationx.Required).Add(validationx.IBAN),
+//		"bic":    validationx.NewValidatorChain("bic").Add(validationx.Optional(validationx.BIC)),
+//		"vat_id": validationx.NewValidatorChain("vat_id").Add(validationx.Optional(validationx.VATID)),
+//	}
+//
 // Convenience functions for quick validation:
 //
 //	// Quick validation without detailed error information
@@ -244,6 +368,8 @@
 //	isValid = validationx.IsValidIP("192.168.1.1")
 //	isValid = validationx.IsValidUUID("550e8400-e29b-41d4-a716-446655440000")
 //	isValid = validationx.IsValidCreditCard("4532015112830366")
+//	isValid = validationx.IsValidIBAN("DE89 3704 0044 0532 0130 00")
+//	isValid = validationx.IsValidVATID("NL123456789B01")
 //	isValid = validationx.IsValidPhone("555-123-4567")
 //
 // Error handling and reporting:
@@ -300,7 +426,7 @@
 //
 // Validator Composition:
 //   - Chain multiple validators together
-//   - Short-circuit evaluation
+//   - Collect-all or short-circuit evaluation
 //   - Field-specific error reporting
 //   - Optional validation (skip when empty)
 //
@@ -356,6 +482,8 @@
 //		Value    interface{}           // Value that failed validation
 //		Context  map[string]interface{} // Additional error context
 //		Expected interface{}           // Expected value or format
+//		MessageKey string              // i18n key for the message
+//		Params   map[string]interface{} // Template data for the localized message
 //	}
 //
 //	type ValidationResult struct {
@@ -443,7 +571,7 @@
 //		"dateOfBirth": validationx.NewValidatorChain("dateOfBirth").
 //			Add(validationx.Required).
 //			Add(validationx.IsDate).
-//			Add(validationx.DateBefore(time.Now().AddDate(-18, 0, 0))), // 18 years old
+//			Add(validationx.MinAge(18)),
 //	}
 //	
 //	step2Rules := map[string]*validationx.ValidatorChain{
@@ -505,13 +633,18 @@
 //			Add(validationx.In(validCategories)),
 //	}
 //	
-//	// Process each row
-//	for i, row := range csvRows {
+//	// Stream each row from the import file (see filex.ProcessCSV)
+//	err := filex.ProcessCSV("products.csv", func(i int, record map[string]string) error {
+//		row := make(map[string]interface{}, len(record))
+//		for key, value := range record {
+//			row[key] = value
+//		}
 //		result := validationx.Validate(row, csvRowRules)
 //		if !result.Valid {
-//			importErrors = append(importErrors, fmt.Sprintf("Row %d: %s", i+1, result.FirstError().Message))
+//			importErrors = append(importErrors, fmt.Sprintf("Row %d: %s", i, result.FirstError().Message))
 //		}
-//	}
+//		return nil
+//	})
 //
 // # Best Practices
 //
@@ -660,6 +793,7 @@
 //   - core/log: Logging validation events
 //   - utils/stringx: String manipulation for validation  
 //   - utils/mathx: Mathematical utilities for numeric validation
+//   - utils/timex: Business day and time zone rules for date validators
 //
 // # Extensibility
 //
@@ -0,0 +1,132 @@
+// File: messages.go
+// Title: Localizable Validation Message Keys
+// Description: Defines the i18n message keys emitted by the validators of
+//              this package. Keys follow the core convention
+//              "validation.<code>" and refine it where one error code covers
+//              several messages. Results are translated with
+//              ValidationResult.Localize and an i18n manager.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial message key definitions
+// - 2026-10-16 v0.1.1: Added keys for async, business calendar, and time zone validators
+// - 2026-10-16 v0.1.2: Added the BIC country key
+
+package validationx
+
+import (
+	"sort"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// Translator is an alias to the core validation translator interface,
+// implemented by *i18n.Manager
+type Translator = validation.Translator
+
+// Message keys refining the code-derived keys as "<code key>_<variant>", so
+// that both can live side by side in one [validation] table of a locale
+// file. Template params available to each message are listed in brackets;
+// every message additionally receives Field, FieldLabel, Value, Expected,
+// and Message.
+const (
+	KeyTypeString  = "validation.type_string"  // Value is not a string
+	KeyTypeNumber  = "validation.type_number"  // Value is not numeric
+	KeyTypeInteger = "validation.type_integer" // Value is not an integer type
+	KeyTypeDate    = "validation.type_date"    // Value is not a date
+
+	KeyLengthMin   = "validation.length_min"   // [Min]
+	KeyLengthMax   = "validation.length_max"   // [Max]
+	KeyLengthExact = "validation.length_exact" // [Length]
+
+	KeyContains     = "validation.pattern_contains"     // [Substring]
+	KeyStartsWith   = "validation.pattern_starts_with"  // [Prefix]
+	KeyEndsWith     = "validation.pattern_ends_with"    // [Suffix]
+	KeyAlphaOnly    = "validation.pattern_alpha"        // Letters only
+	KeyAlphaNumeric = "validation.pattern_alphanumeric" // Letters and digits only
+	KeyNumericOnly  = "validation.pattern_numeric"      // Digits only
+	KeyPattern      = "validation.pattern_mismatch"     // [Pattern]
+
+	KeyIP   = "validation.format_ip"   // IP address
+	KeyIPv4 = "validation.format_ipv4" // IPv4 address
+	KeyIPv6 = "validation.format_ipv6" // IPv6 address
+	KeyUUID = "validation.format_uuid" // UUID
+
+	KeyInteger      = "validation.numeric_integer" // String is not an integer
+	KeyRangeMin     = "validation.range_min"       // [Min]
+	KeyRangeMax     = "validation.range_max"       // [Max]
+	KeyRangeBetween = "validation.range_between"   // [Min, Max]
+
+	KeyDateAfter  = "validation.date_after"  // [Date]
+	KeyDateBefore = "validation.date_before" // [Date]
+
+	KeyBusinessDay   = "validation.date_business_day"   // [Date]
+	KeyBusinessHours = "validation.time_business_hours" // [Start, End]
+	KeyMinAge        = "validation.date_min_age"        // [Age]
+	KeyMaxAge        = "validation.date_max_age"        // [Age]
+	KeyTimezone      = "validation.format_timezone"     // IANA time zone name
+
+	KeyIn    = "validation.custom_in"     // [Allowed]
+	KeyNotIn = "validation.custom_not_in" // [Forbidden]
+
+	KeyCreditCard       = "validation.format_credit_card"        // Luhn check failed
+	KeyCreditCardDigits = "validation.format_credit_card_digits" // Non-digit characters
+	KeyCreditCardLength = "validation.length_credit_card"        // [Min, Max]
+	KeyPhoneCharacters  = "validation.phone_characters"          // Invalid characters
+	KeyPhoneLength      = "validation.phone_length"              // [Min, Max]
+
+	KeyIBAN         = "validation.format_iban"         // Structure or check digits invalid
+	KeyIBANCountry  = "validation.format_iban_country" // [Country]
+	KeyIBANLength   = "validation.length_iban"         // [Country, Length]
+	KeyBIC          = "validation.format_bic"          // BIC/SWIFT code
+	KeyBICCountry   = "validation.format_bic_country"  // [Country]
+	KeyVATID        = "validation.format_vat_id"       // [Country] when known
+	KeyVATIDCountry = "validation.format_vat_country"  // [Country]
+	KeyEAN          = "validation.format_ean"          // EAN-8/EAN-13
+	KeyGTIN         = "validation.format_gtin"         // GTIN-8/12/13/14
+	KeyISIN         = "validation.format_isin"         // ISIN
+
+	KeyUnique = "validation.custom_unique" // Value already taken
+	KeyExists = "validation.custom_exists" // Referenced value not found
+)
+
+// messageKeys lists the refined keys emitted by this package
+var messageKeys = []string{
+	KeyTypeString, KeyTypeNumber, KeyTypeInteger, KeyTypeDate,
+	KeyLengthMin, KeyLengthMax, KeyLengthExact,
+	KeyContains, KeyStartsWith, KeyEndsWith, KeyAlphaOnly, KeyAlphaNumeric, KeyNumericOnly, KeyPattern,
+	KeyIP, KeyIPv4, KeyIPv6, KeyUUID,
+	KeyInteger, KeyRangeMin, KeyRangeMax, KeyRangeBetween,
+	KeyDateAfter, KeyDateBefore,
+	KeyBusinessDay, KeyBusinessHours, KeyMinAge, KeyMaxAge, KeyTimezone,
+	KeyIn, KeyNotIn,
+	KeyCreditCard, KeyCreditCardDigits, KeyCreditCardLength, KeyPhoneCharacters, KeyPhoneLength,
+	KeyIBAN, KeyIBANCountry, KeyIBANLength, KeyBIC, KeyBICCountry, KeyVATID, KeyVATIDCountry, KeyEAN, KeyGTIN, KeyISIN,
+	KeyUnique, KeyExists,
+}
+
+// codeKeys lists the error codes whose derived keys are emitted directly
+var codeKeys = []string{
+	validation.CodeRequired, validation.CodeFormat, validation.CodeLength, validation.CodeRange,
+	validation.CodeType, validation.CodePattern, validation.CodeCustom, validation.CodeEmail,
+	validation.CodeURL, validation.CodePhoneNumber, validation.CodeNumeric, validation.CodeDate,
+	validation.CodeTime,
+	CodeLookupFailed, CodeTimeout,
+}
+
+// MessageKeys returns all i18n keys this package may look up, sorted.
+// Locale files providing these keys translate every built-in validator;
+// missing refined keys fall back to the code-derived key and then to the
+// English message.
+func MessageKeys() []string {
+	keys := make([]string, 0, len(messageKeys)+len(codeKeys))
+	keys = append(keys, messageKeys...)
+	for _, code := range codeKeys {
+		keys = append(keys, validation.MessageKeyForCode(code))
+	}
+	sort.Strings(keys)
+	return keys
+}
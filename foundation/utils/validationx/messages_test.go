@@ -0,0 +1,110 @@
+// File: messages_test.go
+// Title: Localizable Validation Message Tests
+// Description: Tests localization of validator results through the core
+//              i18n manager and the completeness of the message key list.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial localization tests
+
+package validationx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/msto63/mDW/foundation/core/i18n"
+)
+
+func TestValidate_Localize(t *testing.T) {
+	dir := t.TempDir()
+	de := `
+[validation]
+required = "Feld ist erforderlich"
+length = "Ungültige Länge"
+length_min = "{{.FieldLabel}} muss mindestens {{.Min}} Zeichen lang sein"
+range_between = "{{.FieldLabel}} muss zwischen {{.Min}} und {{.Max}} liegen"
+
+[validation.fields]
+name = "Name"
+age = "Alter"
+`
+	en := `
+[validation]
+required = "This field is required"
+`
+	os.WriteFile(filepath.Join(dir, "de.toml"), []byte(de), 0644)
+	os.WriteFile(filepath.Join(dir, "en.toml"), []byte(en), 0644)
+
+	manager, err := i18n.New(i18n.Options{DefaultLocale: "en", LocalesDir: dir, Format: i18n.FormatTOML})
+	if err != nil {
+		t.Fatalf("i18n.New() error = %v", err)
+	}
+
+	rules := map[string]*ValidatorChain{
+		"email": NewValidatorChain("email").Add(Required),
+		"name":  NewValidatorChain("name").Add(MinLength(3)),
+		"code":  NewValidatorChain("code").Add(MaxLength(2)),
+		"age":   NewValidatorChain("age").Add(Range(18, 99)),
+	}
+	data := map[string]interface{}{"email": "", "name": "Al", "code": "ABC", "age": 12}
+
+	result := Validate(data, rules)
+	messages := map[string]string{}
+	for _, err := range result.Localize(manager, "de").Errors {
+		messages[err.Field] = err.Message
+	}
+	want := map[string]string{
+		"email": "Feld ist erforderlich",
+		"name":  "Name muss mindestens 3 Zeichen lang sein",
+		"code":  "Ungültige Länge", // No refined key: falls back to the code key
+		"age":   "Alter muss zwischen 18 und 99 liegen",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("Localize(de) = %v, want %v", messages, want)
+	}
+
+	// Keys missing in the default locale keep the English message
+	for _, err := range result.Localize(manager, "en").Errors {
+		if err.Field == "name" && err.Message != "must be at least 3 characters long" {
+			t.Errorf("Localize(en) name = %q", err.Message)
+		}
+	}
+	if manager.GetCurrentLocale() != "en" {
+		t.Error("Localize() changed the manager's current locale")
+	}
+}
+
+func TestMessageKeys(t *testing.T) {
+	keys := MessageKeys()
+	if !sort.StringsAreSorted(keys) {
+		t.Error("MessageKeys() is not sorted")
+	}
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if seen[key] {
+			t.Errorf("duplicate key %s", key)
+		}
+		seen[key] = true
+	}
+	for _, key := range []string{"validation.required", KeyLengthMin, KeyRangeBetween, "validation.email"} {
+		if !seen[key] {
+			t.Errorf("MessageKeys() is missing %s", key)
+		}
+	}
+
+	result := MinLength(5).Validate("abc")
+	if got := result.Errors[0].MessageKeys(); !reflect.DeepEqual(got, []string{KeyLengthMin, "validation.length"}) {
+		t.Errorf("MinLength() message keys = %v", got)
+	}
+	if result.Errors[0].Params["Min"] != 5 {
+		t.Errorf("MinLength() params = %v", result.Errors[0].Params)
+	}
+}
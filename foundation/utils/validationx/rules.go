@@ -0,0 +1,603 @@
+// File: rules.go
+// Title: Declarative Validation Rule Loader
+// Description: Parses declarative rule definitions from TOML or YAML into
+//              validator chains, so validation can be changed without
+//              recompiling. Each field maps to a list of rules, either a
+//              bare validator name or a table with the validator name and
+//              its params. Custom validators are added through RegisterRule.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.3
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of the rule DSL loader
+// - 2026-10-16 v0.1.1: Added warning key to downgrade rules to warnings
+// - 2026-10-16 v0.1.2: Registered business calendar, age, and time zone rules
+// - 2026-10-16 v0.1.3: Attached rule metadata (name, params, codes) to loaded rules
+
+package validationx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+	"github.com/msto63/mDW/foundation/utils/timex"
+)
+
+// RuleFormat represents the format of a rule definition document
+type RuleFormat int
+
+const (
+	// RuleFormatTOML represents TOML format (default)
+	RuleFormatTOML RuleFormat = iota
+
+	// RuleFormatYAML represents YAML format
+	RuleFormatYAML
+
+	// RuleFormatAuto auto-detects format from file extension
+	RuleFormatAuto
+)
+
+// String returns the string representation of the format
+func (f RuleFormat) String() string {
+	switch f {
+	case RuleFormatTOML:
+		return "toml"
+	case RuleFormatYAML:
+		return "yaml"
+	case RuleFormatAuto:
+		return "auto"
+	default:
+		return "unknown"
+	}
+}
+
+// RuleKey is the table key naming the validator of a rule definition
+const RuleKey = "rule"
+
+// OptionalKey is the table key that wraps a rule with Optional
+const OptionalKey = "optional"
+
+// WarningKey is the table key that reports a rule's errors as warnings
+const WarningKey = "warning"
+
+// ===============================
+// Rule Registry
+// ===============================
+
+// RuleParams holds the params of a single rule definition
+type RuleParams map[string]interface{}
+
+// RuleFactory creates a validator from the params of a rule definition
+type RuleFactory func(params RuleParams) (validation.Validator, error)
+
+// registeredRule is a rule factory with the error codes its validators report
+type registeredRule struct {
+	factory RuleFactory
+	codes   []string
+}
+
+var (
+	ruleRegistry   = map[string]registeredRule{}
+	ruleRegistryMu sync.RWMutex
+)
+
+// RegisterRule makes a validator available to rule definitions under name.
+// The error codes the validator may report are included in its rule
+// metadata. Registering an existing name replaces the previous factory.
+func RegisterRule(name string, factory RuleFactory, codes ...string) {
+	ruleRegistryMu.Lock()
+	defer ruleRegistryMu.Unlock()
+	ruleRegistry[name] = registeredRule{factory: factory, codes: codes}
+}
+
+// RegisteredRules returns the sorted names of all registered rules
+func RegisteredRules() []string {
+	ruleRegistryMu.RLock()
+	defer ruleRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(ruleRegistry))
+	for name := range ruleRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupRule returns the rule registered under name
+func lookupRule(name string) (registeredRule, bool) {
+	ruleRegistryMu.RLock()
+	defer ruleRegistryMu.RUnlock()
+	rule, ok := ruleRegistry[name]
+	return rule, ok
+}
+
+// builtinRuleCodes lists the error codes reported by the built-in rules
+var builtinRuleCodes = map[string][]string{
+	"required":       {validation.CodeRequired},
+	"alpha":          {validation.CodeType, validation.CodePattern},
+	"alphanumeric":   {validation.CodeType, validation.CodePattern},
+	"numeric":        {validation.CodeType, validation.CodePattern},
+	"email":          {validation.CodeType, validation.CodeEmail},
+	"url":            {validation.CodeType, validation.CodeURL},
+	"ip":             {validation.CodeType, validation.CodeFormat},
+	"ipv4":           {validation.CodeType, validation.CodeFormat},
+	"ipv6":           {validation.CodeType, validation.CodeFormat},
+	"uuid":           {validation.CodeType, validation.CodeFormat, validation.CodePattern},
+	"number":         {validation.CodeType, validation.CodeNumeric},
+	"integer":        {validation.CodeType, validation.CodeNumeric},
+	"date":           {validation.CodeType, validation.CodeDate},
+	"credit_card":    {validation.CodeType, validation.CodeFormat, validation.CodeLength},
+	"phone":          {validation.CodeType, validation.CodePhoneNumber},
+	"iban":           {validation.CodeType, validation.CodeFormat, validation.CodeLength},
+	"bic":            {validation.CodeType, validation.CodeFormat, validation.CodePattern},
+	"vat_id":         {validation.CodeType, validation.CodeFormat, validation.CodePattern},
+	"ean":            {validation.CodeType, validation.CodeFormat},
+	"gtin":           {validation.CodeType, validation.CodeFormat},
+	"isin":           {validation.CodeType, validation.CodeFormat, validation.CodePattern},
+	"business_day":   {validation.CodeType, validation.CodeDate},
+	"timezone":       {validation.CodeType, validation.CodeFormat},
+	"min_length":     {validation.CodeType, validation.CodeLength},
+	"max_length":     {validation.CodeType, validation.CodeLength},
+	"length":         {validation.CodeType, validation.CodeLength},
+	"contains":       {validation.CodeType, validation.CodePattern},
+	"starts_with":    {validation.CodeType, validation.CodePattern},
+	"ends_with":      {validation.CodeType, validation.CodePattern},
+	"pattern":        {validation.CodeType, validation.CodePattern},
+	"min":            {validation.CodeType, validation.CodeRange},
+	"max":            {validation.CodeType, validation.CodeRange},
+	"range":          {validation.CodeType, validation.CodeRange},
+	"date_after":     {validation.CodeType, validation.CodeDate},
+	"date_before":    {validation.CodeType, validation.CodeDate},
+	"min_age":        {validation.CodeType, validation.CodeDate},
+	"max_age":        {validation.CodeType, validation.CodeDate},
+	"business_hours": {validation.CodeType, validation.CodeDate, validation.CodeTime},
+	"in":             {validation.CodeCustom},
+	"not_in":         {validation.CodeCustom},
+}
+
+// registerBuiltin registers a built-in rule with its error codes
+func registerBuiltin(name string, factory RuleFactory) {
+	RegisterRule(name, factory, builtinRuleCodes[name]...)
+}
+
+// fixed returns a factory for a validator without params
+func fixed(validator validation.Validator) RuleFactory {
+	return func(RuleParams) (validation.Validator, error) {
+		return validator, nil
+	}
+}
+
+func init() {
+	for name, validator := range map[string]validation.Validator{
+		"required":     Required,
+		"alpha":        AlphaOnly,
+		"alphanumeric": AlphaNumeric,
+		"numeric":      NumericOnly,
+		"email":        Email,
+		"url":          URL,
+		"ip":           IP,
+		"ipv4":         IPv4,
+		"ipv6":         IPv6,
+		"uuid":         UUID,
+		"number":       IsNumber,
+		"integer":      IsInteger,
+		"date":         IsDate,
+		"credit_card":  CreditCard,
+		"phone":        Phone,
+		"iban":         IBAN,
+		"bic":          BIC,
+		"vat_id":       VATID,
+		"ean":          EAN,
+		"gtin":         GTIN,
+		"isin":         ISIN,
+		"business_day": BusinessDay(),
+		"timezone":     Timezone,
+	} {
+		registerBuiltin(name, fixed(validator))
+	}
+
+	registerBuiltin("min_length", func(p RuleParams) (validation.Validator, error) {
+		min, err := p.Int("min")
+		return MinLength(min), err
+	})
+	registerBuiltin("max_length", func(p RuleParams) (validation.Validator, error) {
+		max, err := p.Int("max")
+		return MaxLength(max), err
+	})
+	registerBuiltin("length", func(p RuleParams) (validation.Validator, error) {
+		length, err := p.Int("length")
+		return Length(length), err
+	})
+	registerBuiltin("contains", func(p RuleParams) (validation.Validator, error) {
+		substring, err := p.String("value")
+		return Contains(substring), err
+	})
+	registerBuiltin("starts_with", func(p RuleParams) (validation.Validator, error) {
+		prefix, err := p.String("prefix")
+		return StartsWith(prefix), err
+	})
+	registerBuiltin("ends_with", func(p RuleParams) (validation.Validator, error) {
+		suffix, err := p.String("suffix")
+		return EndsWith(suffix), err
+	})
+	registerBuiltin("pattern", func(p RuleParams) (validation.Validator, error) {
+		pattern, err := p.String("pattern")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := getCompiledRegex(pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return Pattern(pattern), nil
+	})
+	registerBuiltin("min", func(p RuleParams) (validation.Validator, error) {
+		min, err := p.Float("min")
+		return Min(min), err
+	})
+	registerBuiltin("max", func(p RuleParams) (validation.Validator, error) {
+		max, err := p.Float("max")
+		return Max(max), err
+	})
+	registerBuiltin("range", func(p RuleParams) (validation.Validator, error) {
+		min, err := p.Float("min")
+		if err != nil {
+			return nil, err
+		}
+		max, err := p.Float("max")
+		return Range(min, max), err
+	})
+	registerBuiltin("date_after", func(p RuleParams) (validation.Validator, error) {
+		after, err := p.Time("date")
+		return DateAfter(after), err
+	})
+	registerBuiltin("date_before", func(p RuleParams) (validation.Validator, error) {
+		before, err := p.Time("date")
+		return DateBefore(before), err
+	})
+	registerBuiltin("min_age", func(p RuleParams) (validation.Validator, error) {
+		years, err := p.Int("years")
+		return MinAge(years), err
+	})
+	registerBuiltin("max_age", func(p RuleParams) (validation.Validator, error) {
+		years, err := p.Int("years")
+		return MaxAge(years), err
+	})
+	registerBuiltin("business_hours", func(p RuleParams) (validation.Validator, error) {
+		schedule := DefaultBusinessHours()
+		if _, ok := p["start"]; ok {
+			start, err := p.String("start")
+			if err != nil {
+				return nil, err
+			}
+			schedule.Start = start
+		}
+		if _, ok := p["end"]; ok {
+			end, err := p.String("end")
+			if err != nil {
+				return nil, err
+			}
+			schedule.End = end
+		}
+		if _, ok := p["timezone"]; ok {
+			tz, err := p.String("timezone")
+			if err != nil {
+				return nil, err
+			}
+			if schedule.Location, err = timex.LoadLocation(tz); err != nil {
+				return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+			}
+		}
+		return WithinBusinessHours(schedule), nil
+	})
+	registerBuiltin("in", func(p RuleParams) (validation.Validator, error) {
+		values, err := p.List("values")
+		return In(values...), err
+	})
+	registerBuiltin("not_in", func(p RuleParams) (validation.Validator, error) {
+		values, err := p.List("values")
+		return NotIn(values...), err
+	})
+}
+
+// ===============================
+// Rule Params
+// ===============================
+
+// get returns the param name or an error if it is missing
+func (p RuleParams) get(name string) (interface{}, error) {
+	value, ok := p[name]
+	if !ok {
+		return nil, fmt.Errorf("missing param %q", name)
+	}
+	return value, nil
+}
+
+// flag removes the boolean param name and returns its value (default false)
+func (p RuleParams) flag(name string) (bool, error) {
+	value, ok := p[name]
+	if !ok {
+		return false, nil
+	}
+	delete(p, name)
+	enabled, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("param %q must be a boolean, got %v", name, value)
+	}
+	return enabled, nil
+}
+
+// String returns the param name as a string
+func (p RuleParams) String(name string) (string, error) {
+	value, err := p.get(name)
+	if err != nil {
+		return "", err
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("param %q must be a string, got %T", name, value)
+	}
+	return str, nil
+}
+
+// Int returns the param name as an int
+func (p RuleParams) Int(name string) (int, error) {
+	value, err := p.get(name)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("param %q must be an integer, got %v", name, value)
+}
+
+// Float returns the param name as a float64
+func (p RuleParams) Float(name string) (float64, error) {
+	value, err := p.get(name)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("param %q must be a number, got %v", name, value)
+}
+
+// Time returns the param name as a time. Native TOML/YAML timestamps as well
+// as strings in RFC 3339 or "2006-01-02" format are accepted.
+func (p RuleParams) Time(name string) (time.Time, error) {
+	value, err := p.get(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("param %q must be a date, got %v", name, value)
+}
+
+// List returns the param name as a list. Integers are normalized to int so
+// that TOML and YAML definitions compare equal to Go int values.
+func (p RuleParams) List(name string) ([]interface{}, error) {
+	value, err := p.get(name)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("param %q must be a list, got %T", name, value)
+	}
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		if v, ok := item.(int64); ok {
+			item = int(v)
+		}
+		list[i] = item
+	}
+	return list, nil
+}
+
+// ===============================
+// Rule Loading
+// ===============================
+
+// LoadRules parses a rule definition document into validator chains keyed
+// by field name. The document maps each field to a list of rules:
+//
+//	username = ["required", { rule = "min_length", min = 3 }]
+//	email    = ["required", "email"]
+//	age      = [{ rule = "range", min = 18, max = 120, optional = true }]
+//	phone    = [{ rule = "phone", warning = true }]
+//
+// RuleFormatAuto is treated as TOML.
+func LoadRules(data []byte, format RuleFormat) (map[string]*ValidatorChain, error) {
+	var doc map[string]interface{}
+
+	switch format {
+	case RuleFormatTOML, RuleFormatAuto:
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML rules: %w", err)
+		}
+	case RuleFormatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML rules: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rule format: %s", format)
+	}
+
+	return RulesFromMap(doc)
+}
+
+// LoadRulesFile reads and parses a rule definition file. The format is
+// detected from the file extension (.yaml/.yml, otherwise TOML).
+func LoadRulesFile(path string) (map[string]*ValidatorChain, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file %s: %w", path, err)
+	}
+
+	format := RuleFormatTOML
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = RuleFormatYAML
+	}
+
+	rules, err := LoadRules(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// RulesFromMap builds validator chains from already decoded rule
+// definitions, e.g. a section of a loaded configuration. All definitions
+// are checked; the returned error lists every invalid rule.
+func RulesFromMap(definitions map[string]interface{}) (map[string]*ValidatorChain, error) {
+	fields := make([]string, 0, len(definitions))
+	for field := range definitions {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	rules := make(map[string]*ValidatorChain, len(definitions))
+	var problems []string
+
+	for _, field := range fields {
+		entries, err := ruleEntries(definitions[field])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("field %q: %v", field, err))
+			continue
+		}
+
+		chain := NewValidatorChain(field)
+		for i, entry := range entries {
+			validator, err := buildRule(entry)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("field %q rule %d: %v", field, i+1, err))
+				continue
+			}
+			chain.Add(validator)
+		}
+		rules[field] = chain
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid validation rules: %s", strings.Join(problems, "; "))
+	}
+	return rules, nil
+}
+
+// ruleEntries normalizes the rule list of a field
+func ruleEntries(definition interface{}) ([]interface{}, error) {
+	switch v := definition.(type) {
+	case []interface{}:
+		return v, nil
+	case []map[string]interface{}: // TOML arrays of tables
+		entries := make([]interface{}, len(v))
+		for i, entry := range v {
+			entries[i] = entry
+		}
+		return entries, nil
+	case string, map[string]interface{}:
+		return []interface{}{v}, nil
+	}
+	return nil, fmt.Errorf("rules must be a list, got %T", definition)
+}
+
+// buildRule creates the validator for a single rule entry
+func buildRule(entry interface{}) (validation.Validator, error) {
+	var name string
+	params := RuleParams{}
+
+	switch v := entry.(type) {
+	case string:
+		name = v
+	case map[string]interface{}:
+		for key, value := range v {
+			params[key] = value
+		}
+		var err error
+		if name, err = params.String(RuleKey); err != nil {
+			return nil, err
+		}
+		delete(params, RuleKey)
+	default:
+		return nil, fmt.Errorf("rule must be a name or a table, got %T", entry)
+	}
+
+	optional, err := params.flag(OptionalKey)
+	if err != nil {
+		return nil, err
+	}
+	warning, err := params.flag(WarningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, ok := lookupRule(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown rule %q", name)
+	}
+	validator, err := rule.factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if optional {
+		validator = Optional(validator)
+	}
+	description := validation.RuleDescription{
+		Name:     name,
+		Codes:    rule.codes,
+		Optional: optional,
+	}
+	if len(params) > 0 {
+		description.Params = params
+	}
+	validator = validation.WithDescription(validator, description)
+	if warning {
+		validator = AsWarning(validator)
+	}
+	return validator, nil
+}
+
+// DescribeRules returns the metadata of validator chains keyed by field, e.g.
+// to generate API documentation or client-side validation from loaded rules
+func DescribeRules(rules map[string]*ValidatorChain) map[string]validation.RuleDescription {
+	descriptions := make(map[string]validation.RuleDescription, len(rules))
+	for field, chain := range rules {
+		descriptions[field] = chain.Describe()
+	}
+	return descriptions
+}
@@ -0,0 +1,241 @@
+// File: rules_test.go
+// Title: Declarative Validation Rule Loader Tests
+// Description: Tests loading rule definitions from TOML and YAML, rule
+//              params, custom rule registration, and error reporting for
+//              invalid definitions.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.2
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial rule loader tests
+// - 2026-10-16 v0.1.1: Added warning rule tests
+// - 2026-10-16 v0.1.2: Added rule metadata tests
+
+package validationx
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+const tomlRules = `
+username = ["required", { rule = "min_length", min = 3 }, { rule = "pattern", pattern = "^[a-z0-9_]+$" }]
+email    = ["required", "email"]
+age      = [{ rule = "range", min = 18, max = 120, optional = true }]
+role     = [{ rule = "in", values = ["admin", "user"] }]
+level    = [{ rule = "in", values = [1, 2, 3] }]
+
+[[iban]]
+rule = "iban"
+optional = true
+`
+
+const yamlRules = `
+username:
+  - required
+  - rule: min_length
+    min: 3
+  - rule: pattern
+    pattern: "^[a-z0-9_]+$"
+email: [required, email]
+age:
+  - {rule: range, min: 18, max: 120, optional: true}
+role:
+  - {rule: in, values: [admin, user]}
+level:
+  - {rule: in, values: [1, 2, 3]}
+iban:
+  - {rule: iban, optional: true}
+`
+
+func TestLoadRules(t *testing.T) {
+	valid := map[string]interface{}{
+		"username": "alice_1",
+		"email":    "alice@example.com",
+		"role":     "admin",
+		"level":    2,
+		"iban":     "",
+	}
+	invalid := map[string]interface{}{
+		"username": "Al",
+		"email":    "not-an-email",
+		"age":      12,
+		"role":     "root",
+		"level":    5,
+		"iban":     "DE00",
+	}
+
+	for _, tt := range []struct {
+		name   string
+		data   string
+		format RuleFormat
+	}{
+		{"TOML", tomlRules, RuleFormatTOML},
+		{"YAML", yamlRules, RuleFormatYAML},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := LoadRules([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("LoadRules() error = %v", err)
+			}
+			if len(rules) != 6 {
+				t.Fatalf("LoadRules() fields = %d, want 6", len(rules))
+			}
+
+			if result := Validate(valid, rules); !result.Valid {
+				t.Errorf("valid data rejected: %v", result.ErrorMessages())
+			}
+
+			result := Validate(invalid, rules)
+			failed := map[string]bool{}
+			for _, err := range result.Errors {
+				failed[err.Field] = true
+			}
+			for field := range invalid {
+				if !failed[field] {
+					t.Errorf("field %s passed validation, want error", field)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRules_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr []string
+	}{
+		{"unknown rule", `name = ["required", "nonsense"]`, []string{`field "name" rule 2`, `unknown rule "nonsense"`}},
+		{"missing param", `name = [{ rule = "min_length" }]`, []string{`min_length: missing param "min"`}},
+		{"wrong param type", `name = [{ rule = "max_length", max = "ten" }]`, []string{`param "max" must be an integer`}},
+		{"invalid pattern", `code = [{ rule = "pattern", pattern = "[" }]`, []string{"invalid pattern"}},
+		{"missing rule name", `code = [{ min = 3 }]`, []string{`missing param "rule"`}},
+		{"not a list", `code = 42`, []string{"rules must be a list"}},
+		{"all problems reported", "a = [\"x\"]\nb = [\"y\"]", []string{`unknown rule "x"`, `unknown rule "y"`}},
+		{"syntax error", `name = [`, []string{"failed to parse TOML rules"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRules([]byte(tt.data), RuleFormatTOML)
+			if err == nil {
+				t.Fatal("LoadRules() error = nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadRules() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRulesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yml")
+	os.WriteFile(path, []byte(yamlRules), 0644)
+
+	rules, err := LoadRulesFile(path)
+	if err != nil {
+		t.Fatalf("LoadRulesFile() error = %v", err)
+	}
+	if result := rules["email"].Validate("x"); result.Valid {
+		t.Error("email rule from YAML file accepted invalid value")
+	}
+
+	if _, err := LoadRulesFile(filepath.Join(dir, "missing.toml")); err == nil {
+		t.Error("LoadRulesFile() on missing file error = nil")
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("test_even", func(p RuleParams) (validation.Validator, error) {
+		return Custom(func(value interface{}) (bool, string) {
+			n, ok := value.(int)
+			return ok && n%2 == 0, "must be even"
+		}), nil
+	})
+
+	found := false
+	for _, name := range RegisteredRules() {
+		found = found || name == "test_even"
+	}
+	if !found {
+		t.Fatal("RegisteredRules() is missing test_even")
+	}
+
+	rules, err := RulesFromMap(map[string]interface{}{
+		"count": []interface{}{"required", "test_even"},
+	})
+	if err != nil {
+		t.Fatalf("RulesFromMap() error = %v", err)
+	}
+	if !rules["count"].Validate(4).Valid || rules["count"].Validate(3).Valid {
+		t.Error("custom rule gave wrong result")
+	}
+}
+
+func TestLoadRules_Warnings(t *testing.T) {
+	_, err := LoadRules([]byte(`
+sku   = ["required", { rule = "gtin", warning = true }]
+email = [{ rule = "email", warning = "yes" }]
+`), RuleFormatTOML)
+	if err == nil || !strings.Contains(err.Error(), `param "warning" must be a boolean`) {
+		t.Fatalf("LoadRules() error = %v, want boolean param error", err)
+	}
+
+	rules, err := LoadRules([]byte(`sku = ["required", { rule = "gtin", warning = true }]`), RuleFormatTOML)
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	result := Validate(map[string]interface{}{"sku": "12345"}, rules)
+	if !result.Valid || len(result.Warnings) != 1 || result.Warnings[0].Field != "sku" {
+		t.Errorf("Validate() = %+v, want valid with sku warning", result)
+	}
+
+	rules["sku"].WarningsAsErrors(true)
+	if Validate(map[string]interface{}{"sku": "12345"}, rules).Valid {
+		t.Error("WarningsAsErrors() chain accepted value with warning")
+	}
+}
+
+func TestDescribeRules(t *testing.T) {
+	rules, err := LoadRules([]byte(`phone = [{ rule = "phone", warning = true }]`+tomlRules), RuleFormatTOML)
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	descriptions := DescribeRules(rules)
+	username := descriptions["username"]
+	if username.Name != validation.RuleChain || len(username.Rules) != 3 {
+		t.Fatalf("username description = %+v", username)
+	}
+	minLength := username.Rules[1]
+	if minLength.Name != "min_length" || minLength.Params["min"] != int64(3) || !slices.Contains(minLength.Codes, validation.CodeLength) {
+		t.Errorf("min_length description = %+v", minLength)
+	}
+	if required := username.Rules[0]; required.Name != "required" || required.Params != nil {
+		t.Errorf("required description = %+v", required)
+	}
+	if age := descriptions["age"].Rules[0]; !age.Optional || age.Params["max"] != int64(120) {
+		t.Errorf("age description = %+v", age)
+	}
+	if phone := descriptions["phone"].Rules[0]; phone.Name != "phone" || !phone.Warning {
+		t.Errorf("phone description = %+v", phone)
+	}
+
+	for _, name := range RegisteredRules() {
+		if _, ok := builtinRuleCodes[name]; !ok && !strings.HasPrefix(name, "test_") {
+			t.Errorf("built-in rule %s has no error codes", name)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// File: typed.go
+// Title: Typed Generic Validators
+// Description: Implements type-safe validators for strings, numbers, times,
+//              and comparable values on top of the generic core validation
+//              API. They need no runtime type assertions and report the same
+//              error codes and message keys as their interface{} based
+//              counterparts. Untyped validators remain usable through
+//              validation.Typed.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial implementation of typed validators
+
+package validationx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// Number is the constraint for typed numeric validators
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// NewChain creates a typed validator chain, e.g. NewChain[string]("username")
+func NewChain[T any](name string) *validation.Chain[T] {
+	return validation.NewChain[T](name)
+}
+
+// ===============================
+// Typed String Validators
+// ===============================
+
+// NotBlank validates that a string contains non-whitespace characters
+var NotBlank validation.TypedFunc[string] = func(value string) validation.ValidationResult {
+	if strings.TrimSpace(value) == "" {
+		return validation.NewValidationError(validation.CodeRequired, "value is required")
+	}
+	return validation.NewValidationResult()
+}
+
+// LengthBetween validates that a string has between min and max characters
+func LengthBetween(min, max int) validation.TypedFunc[string] {
+	return func(value string) validation.ValidationResult {
+		length := utf8.RuneCountInString(value)
+		if length < min {
+			return validation.NewValidationErrorWithKey(validation.CodeLength, KeyLengthMin, fmt.Sprintf("must be at least %d characters long", min), map[string]interface{}{"Min": min})
+		}
+		if length > max {
+			return validation.NewValidationErrorWithKey(validation.CodeLength, KeyLengthMax, fmt.Sprintf("must be at most %d characters long", max), map[string]interface{}{"Max": max})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// Matches validates that a string matches a regular expression
+func Matches(pattern string) validation.TypedFunc[string] {
+	return func(value string) validation.ValidationResult {
+		regex, err := getCompiledRegex(pattern)
+		if err != nil {
+			return validation.NewValidationError(validation.CodePattern, fmt.Sprintf("invalid pattern: %v", err))
+		}
+		if !regex.MatchString(value) {
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyPattern, "does not match required pattern", map[string]interface{}{"Pattern": pattern})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// ===============================
+// Typed Numeric Validators
+// ===============================
+
+// AtLeast validates that a number is at least min
+func AtLeast[N Number](min N) validation.TypedFunc[N] {
+	return func(value N) validation.ValidationResult {
+		if value < min {
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeMin, fmt.Sprintf("must be at least %v", min), map[string]interface{}{"Min": min})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// AtMost validates that a number is at most max
+func AtMost[N Number](max N) validation.TypedFunc[N] {
+	return func(value N) validation.ValidationResult {
+		if value > max {
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeMax, fmt.Sprintf("must be at most %v", max), map[string]interface{}{"Max": max})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// Between validates that a number is between min and max (inclusive)
+func Between[N Number](min, max N) validation.TypedFunc[N] {
+	return func(value N) validation.ValidationResult {
+		if value < min || value > max {
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeBetween, fmt.Sprintf("must be between %v and %v", min, max), map[string]interface{}{"Min": min, "Max": max})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// ===============================
+// Typed Time and Set Validators
+// ===============================
+
+// NotZeroTime validates that a time is set
+var NotZeroTime validation.TypedFunc[time.Time] = func(value time.Time) validation.ValidationResult {
+	if value.IsZero() {
+		return validation.NewValidationError(validation.CodeRequired, "value is required")
+	}
+	return validation.NewValidationResult()
+}
+
+// TimeAfter validates that a time is after the given time
+func TimeAfter(after time.Time) validation.TypedFunc[time.Time] {
+	return func(value time.Time) validation.ValidationResult {
+		if !value.After(after) {
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyDateAfter, fmt.Sprintf("must be after %s", after.Format("2006-01-02")), map[string]interface{}{"Date": after.Format("2006-01-02")})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// TimeBefore validates that a time is before the given time
+func TimeBefore(before time.Time) validation.TypedFunc[time.Time] {
+	return func(value time.Time) validation.ValidationResult {
+		if !value.Before(before) {
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyDateBefore, fmt.Sprintf("must be before %s", before.Format("2006-01-02")), map[string]interface{}{"Date": before.Format("2006-01-02")})
+		}
+		return validation.NewValidationResult()
+	}
+}
+
+// OneOf validates that a value is one of the allowed values
+func OneOf[T comparable](allowed ...T) validation.TypedFunc[T] {
+	return func(value T) validation.ValidationResult {
+		for _, item := range allowed {
+			if value == item {
+				return validation.NewValidationResult()
+			}
+		}
+		return validation.NewValidationErrorWithKey(validation.CodeCustom, KeyIn, fmt.Sprintf("must be one of: %v", allowed), map[string]interface{}{"Allowed": allowed})
+	}
+}
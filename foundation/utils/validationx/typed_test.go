@@ -0,0 +1,97 @@
+// File: typed_test.go
+// Title: Typed Generic Validator Tests
+// Description: Tests the typed string, numeric, time, and set validators
+//              and their use in typed and untyped chains.
+// Author: msto63 with Claude Sonnet 4.0
+// Version: v0.1.0
+// Created: 2026-10-16
+// Modified: 2026-10-16
+//
+// Change History:
+// - 2026-10-16 v0.1.0: Initial typed validator tests
+
+package validationx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/foundation/core/validation"
+)
+
+// Status is a named string type used to test OneOf
+type Status string
+
+func TestTypedStringValidators(t *testing.T) {
+	username := NewChain[string]("username").
+		Add(NotBlank).
+		Add(LengthBetween(3, 20)).
+		Add(Matches(`^[a-z0-9_]+$`)).
+		Add(validation.Typed[string](AlphaNumeric)). // Legacy validator
+		StopOnFirstError(true)
+
+	tests := []struct {
+		value    string
+		wantCode string
+	}{
+		{"alice42", ""},
+		{"   ", validation.CodeRequired},
+		{"al", validation.CodeLength},
+		{"Alice", validation.CodePattern},
+		{"alice_42", validation.CodePattern}, // Fails the legacy AlphaNumeric
+	}
+	for _, tt := range tests {
+		result := username.Validate(tt.value)
+		if tt.wantCode == "" && !result.Valid || tt.wantCode != "" && !result.HasError(tt.wantCode) {
+			t.Errorf("Validate(%q) codes = %v, want %q", tt.value, result.ErrorCodes(), tt.wantCode)
+		}
+	}
+}
+
+func TestTypedNumericValidators(t *testing.T) {
+	if !Between(1, 10).Validate(10).Valid || Between(1, 10).Validate(11).Valid {
+		t.Error("Between[int] gave wrong result")
+	}
+	if !AtLeast(0.5).Validate(0.5).Valid || AtLeast(0.5).Validate(0.4).Valid {
+		t.Error("AtLeast[float64] gave wrong result")
+	}
+	if !AtMost[uint8](200).Validate(200).Valid || AtMost[uint8](200).Validate(201).Valid {
+		t.Error("AtMost[uint8] gave wrong result")
+	}
+
+	result := Between(18, 99).Validate(12)
+	if result.Errors[0].MessageKeys()[0] != KeyRangeBetween || result.Errors[0].Params["Max"] != 99 {
+		t.Errorf("Between() error = %+v", result.Errors[0])
+	}
+
+	// Typed chains plug into untyped rule maps
+	rules := map[string]*ValidatorChain{
+		"age": NewChain[int]("age").Add(Between(18, 120)).Untyped(),
+	}
+	if result := Validate(map[string]interface{}{"age": "18"}, rules); !result.HasError(validation.CodeType) {
+		t.Errorf("string age codes = %v, want type error", result.ErrorCodes())
+	}
+	if result := Validate(map[string]interface{}{"age": 30}, rules); !result.Valid {
+		t.Errorf("age 30 rejected: %v", result.ErrorMessages())
+	}
+}
+
+func TestTypedTimeAndSetValidators(t *testing.T) {
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	chain := NewChain[time.Time]("shipDate").Add(NotZeroTime).Add(TimeAfter(cutoff)).Add(TimeBefore(cutoff.AddDate(1, 0, 0)))
+
+	if !chain.Validate(cutoff.AddDate(0, 6, 0)).Valid {
+		t.Error("date within range rejected")
+	}
+	if result := chain.Validate(time.Time{}); !result.HasError(validation.CodeRequired) {
+		t.Errorf("zero time codes = %v", result.ErrorCodes())
+	}
+	if result := chain.Validate(cutoff.AddDate(2, 0, 0)); !result.HasError(validation.CodeDate) {
+		t.Errorf("late date codes = %v", result.ErrorCodes())
+	}
+
+	status := OneOf[Status]("open", "closed")
+	if !status.Validate("open").Valid || status.Validate("pending").Valid {
+		t.Error("OneOf[Status] gave wrong result")
+	}
+}
@@ -4,12 +4,14 @@
 //              string validation, format validation, business rule validation,
 //              and custom validator chains for the mDW platform.
 // Author: msto63 with Claude Sonnet 4.0
-// Version: v0.1.0
+// Version: v0.1.2
 // Created: 2025-01-25
-// Modified: 2025-01-25
+// Modified: 2026-10-16
 //
 // Change History:
 // - 2025-01-25 v0.1.0: Initial implementation with comprehensive validation utilities
+// - 2026-10-16 v0.1.1: Added i18n message keys and params to validator errors
+// - 2026-10-16 v0.1.2: Added AsWarning and field assignment for warnings
 
 package validationx
 
@@ -117,6 +119,12 @@ func Optional(validator validation.Validator) validation.ValidatorFunc {
 	}
 }
 
+// AsWarning reports the errors of a validator as warnings, so values with
+// soft issues pass validation while the findings are still reported
+func AsWarning(validator validation.Validator) validation.Validator {
+	return validation.AsWarning(validator)
+}
+
 // ===============================
 // String Validation Functions
 // ===============================
@@ -126,11 +134,11 @@ func MinLength(min int) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if utf8.RuneCountInString(str) < min {
-			return validation.NewValidationError(validation.CodeLength, fmt.Sprintf("must be at least %d characters long", min))
+			return validation.NewValidationErrorWithKey(validation.CodeLength, KeyLengthMin, fmt.Sprintf("must be at least %d characters long", min), map[string]interface{}{"Min": min})
 		}
 		
 		return validation.NewValidationResult()
@@ -142,11 +150,11 @@ func MaxLength(max int) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if utf8.RuneCountInString(str) > max {
-			return validation.NewValidationError(validation.CodeLength, fmt.Sprintf("must be at most %d characters long", max))
+			return validation.NewValidationErrorWithKey(validation.CodeLength, KeyLengthMax, fmt.Sprintf("must be at most %d characters long", max), map[string]interface{}{"Max": max})
 		}
 		
 		return validation.NewValidationResult()
@@ -158,11 +166,11 @@ func Length(length int) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if utf8.RuneCountInString(str) != length {
-			return validation.NewValidationError(validation.CodeLength, fmt.Sprintf("must be exactly %d characters long", length))
+			return validation.NewValidationErrorWithKey(validation.CodeLength, KeyLengthExact, fmt.Sprintf("must be exactly %d characters long", length), map[string]interface{}{"Length": length})
 		}
 		
 		return validation.NewValidationResult()
@@ -174,11 +182,11 @@ func Contains(substring string) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if !strings.Contains(str, substring) {
-			return validation.NewValidationError(validation.CodePattern, fmt.Sprintf("must contain '%s'", substring))
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyContains, fmt.Sprintf("must contain '%s'", substring), map[string]interface{}{"Substring": substring})
 		}
 		
 		return validation.NewValidationResult()
@@ -190,11 +198,11 @@ func StartsWith(prefix string) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if !strings.HasPrefix(str, prefix) {
-			return validation.NewValidationError(validation.CodePattern, fmt.Sprintf("must start with '%s'", prefix))
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyStartsWith, fmt.Sprintf("must start with '%s'", prefix), map[string]interface{}{"Prefix": prefix})
 		}
 		
 		return validation.NewValidationResult()
@@ -206,11 +214,11 @@ func EndsWith(suffix string) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		if !strings.HasSuffix(str, suffix) {
-			return validation.NewValidationError(validation.CodePattern, fmt.Sprintf("must end with '%s'", suffix))
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyEndsWith, fmt.Sprintf("must end with '%s'", suffix), map[string]interface{}{"Suffix": suffix})
 		}
 		
 		return validation.NewValidationResult()
@@ -221,12 +229,12 @@ func EndsWith(suffix string) validation.ValidatorFunc {
 var AlphaOnly validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	for _, r := range str {
 		if !unicode.IsLetter(r) {
-			return validation.NewValidationError(validation.CodePattern, "must contain only alphabetic characters")
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyAlphaOnly, "must contain only alphabetic characters", nil)
 		}
 	}
 	
@@ -237,12 +245,12 @@ var AlphaOnly validation.ValidatorFunc = func(value interface{}) validation.Vali
 var AlphaNumeric validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	for _, r := range str {
 		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
-			return validation.NewValidationError(validation.CodePattern, "must contain only alphanumeric characters")
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyAlphaNumeric, "must contain only alphanumeric characters", nil)
 		}
 	}
 	
@@ -253,12 +261,12 @@ var AlphaNumeric validation.ValidatorFunc = func(value interface{}) validation.V
 var NumericOnly validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	for _, r := range str {
 		if !unicode.IsNumber(r) {
-			return validation.NewValidationError(validation.CodePattern, "must contain only numeric characters")
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyNumericOnly, "must contain only numeric characters", nil)
 		}
 	}
 	
@@ -274,7 +282,7 @@ func Pattern(pattern string) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		str, ok := value.(string)
 		if !ok {
-			return validation.NewValidationError(validation.CodeType, "value must be a string")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 		}
 		
 		regex, err := getCompiledRegex(pattern)
@@ -283,7 +291,7 @@ func Pattern(pattern string) validation.ValidatorFunc {
 		}
 		
 		if !regex.MatchString(str) {
-			return validation.NewValidationError(validation.CodePattern, "does not match required pattern")
+			return validation.NewValidationErrorWithKey(validation.CodePattern, KeyPattern, "does not match required pattern", map[string]interface{}{"Pattern": pattern})
 		}
 		
 		return validation.NewValidationResult()
@@ -294,7 +302,7 @@ func Pattern(pattern string) validation.ValidatorFunc {
 var Email validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	_, err := mail.ParseAddress(str)
@@ -309,7 +317,7 @@ var Email validation.ValidatorFunc = func(value interface{}) validation.Validati
 var URL validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	if strings.TrimSpace(str) == "" {
@@ -328,11 +336,11 @@ var URL validation.ValidatorFunc = func(value interface{}) validation.Validation
 var IP validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	if net.ParseIP(str) == nil {
-		return validation.NewValidationError(validation.CodeFormat, "must be a valid IP address")
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIP, "must be a valid IP address", nil)
 	}
 	
 	return validation.NewValidationResult()
@@ -342,12 +350,12 @@ var IP validation.ValidatorFunc = func(value interface{}) validation.ValidationR
 var IPv4 validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	ip := net.ParseIP(str)
 	if ip == nil || ip.To4() == nil {
-		return validation.NewValidationError(validation.CodeFormat, "must be a valid IPv4 address")
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIPv4, "must be a valid IPv4 address", nil)
 	}
 	
 	return validation.NewValidationResult()
@@ -357,12 +365,12 @@ var IPv4 validation.ValidatorFunc = func(value interface{}) validation.Validatio
 var IPv6 validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	ip := net.ParseIP(str)
 	if ip == nil || ip.To4() != nil {
-		return validation.NewValidationError(validation.CodeFormat, "must be a valid IPv6 address")
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyIPv6, "must be a valid IPv6 address", nil)
 	}
 	
 	return validation.NewValidationResult()
@@ -372,7 +380,7 @@ var IPv6 validation.ValidatorFunc = func(value interface{}) validation.Validatio
 var UUID validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	// UUID regex pattern
@@ -383,7 +391,7 @@ var UUID validation.ValidatorFunc = func(value interface{}) validation.Validatio
 	}
 	
 	if !regex.MatchString(str) {
-		return validation.NewValidationError(validation.CodeFormat, "must be a valid UUID")
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyUUID, "must be a valid UUID", nil)
 	}
 	
 	return validation.NewValidationResult()
@@ -409,7 +417,7 @@ var IsNumber validation.ValidatorFunc = func(value interface{}) validation.Valid
 		}
 		return validation.NewValidationResult()
 	default:
-		return validation.NewValidationError(validation.CodeType, "must be a number")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeNumber, "must be a number", nil)
 	}
 }
 
@@ -423,11 +431,11 @@ var IsInteger validation.ValidatorFunc = func(value interface{}) validation.Vali
 	case string:
 		str := value.(string)
 		if _, err := strconv.ParseInt(str, 10, 64); err != nil {
-			return validation.NewValidationError(validation.CodeNumeric, "must be a valid integer")
+			return validation.NewValidationErrorWithKey(validation.CodeNumeric, KeyInteger, "must be a valid integer", nil)
 		}
 		return validation.NewValidationResult()
 	default:
-		return validation.NewValidationError(validation.CodeType, "must be an integer")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeInteger, "must be an integer", nil)
 	}
 }
 
@@ -436,11 +444,11 @@ func Min(min float64) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		num, err := validation.ConvertToFloat64(value)
 		if err != nil {
-			return validation.NewValidationError(validation.CodeType, "must be a valid number")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeNumber, "must be a valid number", nil)
 		}
 		
 		if num < min {
-			return validation.NewValidationError(validation.CodeRange, fmt.Sprintf("must be at least %g", min))
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeMin, fmt.Sprintf("must be at least %g", min), map[string]interface{}{"Min": min})
 		}
 		
 		return validation.NewValidationResult()
@@ -452,11 +460,11 @@ func Max(max float64) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		num, err := validation.ConvertToFloat64(value)
 		if err != nil {
-			return validation.NewValidationError(validation.CodeType, "must be a valid number")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeNumber, "must be a valid number", nil)
 		}
 		
 		if num > max {
-			return validation.NewValidationError(validation.CodeRange, fmt.Sprintf("must be at most %g", max))
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeMax, fmt.Sprintf("must be at most %g", max), map[string]interface{}{"Max": max})
 		}
 		
 		return validation.NewValidationResult()
@@ -468,11 +476,11 @@ func Range(min, max float64) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		num, err := validation.ConvertToFloat64(value)
 		if err != nil {
-			return validation.NewValidationError(validation.CodeType, "must be a valid number")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeNumber, "must be a valid number", nil)
 		}
 		
 		if num < min || num > max {
-			return validation.NewValidationError(validation.CodeRange, fmt.Sprintf("must be between %g and %g", min, max))
+			return validation.NewValidationErrorWithKey(validation.CodeRange, KeyRangeBetween, fmt.Sprintf("must be between %g and %g", min, max), map[string]interface{}{"Min": min, "Max": max})
 		}
 		
 		return validation.NewValidationResult()
@@ -487,7 +495,7 @@ func Range(min, max float64) validation.ValidatorFunc {
 var IsDate validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	formats := []string{
@@ -535,11 +543,11 @@ func DateAfter(after time.Time) validation.ValidatorFunc {
 				return validation.NewValidationError(validation.CodeDate, "must be a valid date")
 			}
 		default:
-			return validation.NewValidationError(validation.CodeType, "must be a date")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeDate, "must be a date", nil)
 		}
 		
 		if !t.After(after) {
-			return validation.NewValidationError(validation.CodeDate, fmt.Sprintf("must be after %s", after.Format("2006-01-02")))
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyDateAfter, fmt.Sprintf("must be after %s", after.Format("2006-01-02")), map[string]interface{}{"Date": after.Format("2006-01-02")})
 		}
 		
 		return validation.NewValidationResult()
@@ -574,11 +582,11 @@ func DateBefore(before time.Time) validation.ValidatorFunc {
 				return validation.NewValidationError(validation.CodeDate, "must be a valid date")
 			}
 		default:
-			return validation.NewValidationError(validation.CodeType, "must be a date")
+			return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeDate, "must be a date", nil)
 		}
 		
 		if !t.Before(before) {
-			return validation.NewValidationError(validation.CodeDate, fmt.Sprintf("must be before %s", before.Format("2006-01-02")))
+			return validation.NewValidationErrorWithKey(validation.CodeDate, KeyDateBefore, fmt.Sprintf("must be before %s", before.Format("2006-01-02")), map[string]interface{}{"Date": before.Format("2006-01-02")})
 		}
 		
 		return validation.NewValidationResult()
@@ -598,7 +606,7 @@ func In(allowed ...interface{}) validation.ValidatorFunc {
 			}
 		}
 		
-		return validation.NewValidationError(validation.CodeCustom, fmt.Sprintf("must be one of: %v", allowed))
+		return validation.NewValidationErrorWithKey(validation.CodeCustom, KeyIn, fmt.Sprintf("must be one of: %v", allowed), map[string]interface{}{"Allowed": allowed})
 	}
 }
 
@@ -607,7 +615,7 @@ func NotIn(forbidden ...interface{}) validation.ValidatorFunc {
 	return func(value interface{}) validation.ValidationResult {
 		for _, item := range forbidden {
 			if value == item {
-				return validation.NewValidationError(validation.CodeCustom, fmt.Sprintf("must not be one of: %v", forbidden))
+				return validation.NewValidationErrorWithKey(validation.CodeCustom, KeyNotIn, fmt.Sprintf("must not be one of: %v", forbidden), map[string]interface{}{"Forbidden": forbidden})
 			}
 		}
 		
@@ -623,7 +631,7 @@ func NotIn(forbidden ...interface{}) validation.ValidatorFunc {
 var CreditCard validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	// Remove spaces and dashes
@@ -632,18 +640,18 @@ var CreditCard validation.ValidatorFunc = func(value interface{}) validation.Val
 	// Check if all characters are digits
 	for _, r := range cleaned {
 		if !unicode.IsDigit(r) {
-			return validation.NewValidationError(validation.CodeFormat, "must contain only digits")
+			return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyCreditCardDigits, "must contain only digits", nil)
 		}
 	}
 	
 	// Check length (most credit cards are 13-19 digits)
 	if len(cleaned) < 13 || len(cleaned) > 19 {
-		return validation.NewValidationError(validation.CodeLength, "must be between 13 and 19 digits")
+		return validation.NewValidationErrorWithKey(validation.CodeLength, KeyCreditCardLength, "must be between 13 and 19 digits", map[string]interface{}{"Min": 13, "Max": 19})
 	}
 	
 	// Luhn algorithm validation
 	if !luhnCheck(cleaned) {
-		return validation.NewValidationError(validation.CodeFormat, "must be a valid credit card number")
+		return validation.NewValidationErrorWithKey(validation.CodeFormat, KeyCreditCard, "must be a valid credit card number", nil)
 	}
 	
 	return validation.NewValidationResult()
@@ -676,7 +684,7 @@ func luhnCheck(number string) bool {
 var Phone validation.ValidatorFunc = func(value interface{}) validation.ValidationResult {
 	str, ok := value.(string)
 	if !ok {
-		return validation.NewValidationError(validation.CodeType, "value must be a string")
+		return validation.NewValidationErrorWithKey(validation.CodeType, KeyTypeString, "value must be a string", nil)
 	}
 	
 	// Remove common formatting characters
@@ -690,13 +698,13 @@ var Phone validation.ValidatorFunc = func(value interface{}) validation.Validati
 	// Check if remaining characters are digits
 	for _, r := range cleaned {
 		if !unicode.IsDigit(r) {
-			return validation.NewValidationError(validation.CodePhoneNumber, "must contain only digits and formatting characters")
+			return validation.NewValidationErrorWithKey(validation.CodePhoneNumber, KeyPhoneCharacters, "must contain only digits and formatting characters", nil)
 		}
 	}
 	
 	// Check length (most phone numbers are 7-15 digits)
 	if len(cleaned) < 7 || len(cleaned) > 15 {
-		return validation.NewValidationError(validation.CodePhoneNumber, "must be between 7 and 15 digits")
+		return validation.NewValidationErrorWithKey(validation.CodePhoneNumber, KeyPhoneLength, "must be between 7 and 15 digits", map[string]interface{}{"Min": 7, "Max": 15})
 	}
 	
 	return validation.NewValidationResult()
@@ -735,18 +743,28 @@ func Validate(data map[string]interface{}, rules map[string]*ValidatorChain) val
 		}
 		
 		fieldResult := chain.Validate(value)
-		// Add field context to errors if not already present
-		for i := range fieldResult.Errors {
-			if fieldResult.Errors[i].Field == "" {
-				fieldResult.Errors[i].Field = field
-			}
-		}
+		// Add field context to errors and warnings if not already present
+		assignField(&fieldResult, field)
 		results = append(results, fieldResult)
 	}
 	
 	return validation.Combine(results...)
 }
 
+// assignField sets field on all errors and warnings that have no field yet
+func assignField(result *validation.ValidationResult, field string) {
+	for i := range result.Errors {
+		if result.Errors[i].Field == "" {
+			result.Errors[i].Field = field
+		}
+	}
+	for i := range result.Warnings {
+		if result.Warnings[i].Field == "" {
+			result.Warnings[i].Field = field
+		}
+	}
+}
+
 // ValidateStruct validates struct fields using tags (basic implementation)
 func ValidateStruct(s interface{}) validation.ValidationResult {
 	result := &validation.ValidationResult{Valid: true}
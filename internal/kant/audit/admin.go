@@ -0,0 +1,148 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Admin endpoint that serves the audit trail
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/httpx"
+)
+
+// AdminPath is the route of the audit trail endpoint
+const AdminPath = "/api/v1/admin/audit"
+
+// TrailResponse is a page of the audit trail
+type TrailResponse struct {
+	Records []Record `json:"records"`
+	Total   int      `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+	Stats   Stats    `json:"stats"`
+}
+
+// AdminHandler serves the audit trail:
+//
+//	GET /api/v1/admin/audit - list recorded calls, newest first
+//
+// The query parameters request_id, tenant, caller, method, path (prefix),
+// status (e.g. 404, 5xx, or 400-499), from and to (RFC 3339), limit, and
+// offset filter the records. The middleware requires the admin scope for
+// the route; the handler checks it again. Admins bound to a tenant only see
+// the calls of their own tenant.
+func (r *Recorder) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.config.Enabled {
+			httpx.WriteError(w, http.StatusNotFound, "not_found", "Audit trail is disabled", "")
+			return
+		}
+		principal, authenticated := auth.FromContext(req.Context())
+		if authenticated && !principal.HasScope(auth.ScopeAdmin) {
+			httpx.WriteError(w, http.StatusForbidden, "forbidden", "Access denied", `scope "admin" required`)
+			return
+		}
+		if req.Method != http.MethodGet {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
+			return
+		}
+
+		q, err := parseQuery(req.URL.Query())
+		if err != nil {
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_query", "Invalid query", err.Error())
+			return
+		}
+		if principal != nil && principal.Tenant != "" {
+			if q.Tenant != "" && q.Tenant != principal.Tenant {
+				httpx.WriteError(w, http.StatusForbidden, "forbidden", "Access denied",
+					fmt.Sprintf("credentials are bound to tenant %q", principal.Tenant))
+				return
+			}
+			q.Tenant = principal.Tenant
+		}
+
+		records, total, err := r.store.Query(req.Context(), q)
+		if err != nil {
+			r.logger.Warn("Failed to query audit records", "error", err)
+			httpx.WriteError(w, http.StatusServiceUnavailable, "audit_unavailable", "Audit trail not available", err.Error())
+			return
+		}
+		httpx.WriteJSON(w, http.StatusOK, TrailResponse{
+			Records: records,
+			Total:   total,
+			Limit:   q.limit(),
+			Offset:  q.Offset,
+			Stats:   r.Stats(),
+		})
+	})
+}
+
+// parseQuery reads the filters of an audit trail request
+func parseQuery(values url.Values) (Query, error) {
+	q := Query{
+		RequestID:  values.Get("request_id"),
+		Tenant:     values.Get("tenant"),
+		Caller:     values.Get("caller"),
+		Method:     strings.ToUpper(values.Get("method")),
+		PathPrefix: values.Get("path"),
+	}
+
+	var err error
+	if status := values.Get("status"); status != "" {
+		if q.MinStatus, q.MaxStatus, err = parseStatus(status); err != nil {
+			return q, err
+		}
+	}
+	for name, t := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		if value := values.Get(name); value != "" {
+			if *t, err = time.Parse(time.RFC3339, value); err != nil {
+				return q, fmt.Errorf("%s must be an RFC 3339 time", name)
+			}
+		}
+	}
+	for name, n := range map[string]*int{"limit": &q.Limit, "offset": &q.Offset} {
+		if value := values.Get(name); value != "" {
+			if *n, err = strconv.Atoi(value); err != nil || *n < 0 {
+				return q, fmt.Errorf("%s must be a non-negative number", name)
+			}
+		}
+	}
+	return q, nil
+}
+
+// parseStatus reads a status filter: a code, a class like "5xx", or a
+// range like "400-499"
+func parseStatus(s string) (int, int, error) {
+	invalid := fmt.Errorf("invalid status %q, use a code, a class like 5xx, or a range like 400-499", s)
+
+	if class, found := strings.CutSuffix(strings.ToLower(s), "xx"); found {
+		n, err := strconv.Atoi(class)
+		if err != nil || n < 1 || n > 5 {
+			return 0, 0, invalid
+		}
+		return n * 100, n*100 + 99, nil
+	}
+	low, high, isRange := strings.Cut(s, "-")
+	if !isRange {
+		high = low
+	}
+	from, err1 := strconv.Atoi(low)
+	to, err2 := strconv.Atoi(high)
+	if err1 != nil || err2 != nil || from > to {
+		return 0, 0, invalid
+	}
+	return from, to, nil
+}
@@ -0,0 +1,182 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Audit trail of the API calls served by the gateway
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/tenant"
+)
+
+// RequestIDHeader carries the ID of a request; clients may send their own,
+// otherwise the gateway assigns one and returns it in the response
+const RequestIDHeader = "X-Request-ID"
+
+// Defaults of the configuration
+const (
+	DefaultMaxBodyBytes  = 4096
+	DefaultBufferSize    = 1024
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = 2 * time.Second
+)
+
+// DefaultSkipPaths are not audited
+var DefaultSkipPaths = []string{"/api/v1/health", "/mdw.kant.KantService/HealthCheck"}
+
+// Config configures the audit trail of the gateway
+type Config struct {
+	// Enabled turns the audit trail on
+	Enabled bool
+
+	// CaptureRequestBody and CaptureResponseBody record the bodies of the
+	// calls after redaction, up to MaxBodyBytes each
+	CaptureRequestBody  bool
+	CaptureResponseBody bool
+	MaxBodyBytes        int
+
+	// Redaction removes personal data from captured bodies and queries
+	Redaction RedactionConfig
+
+	// SkipPaths are not audited (DefaultSkipPaths if empty)
+	SkipPaths []string
+
+	// BufferSize is the number of records queued for the store; records
+	// are dropped while the queue is full
+	BufferSize int
+
+	// BatchSize and FlushInterval control how often queued records are
+	// written to the store
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// withDefaults fills unset values with the defaults
+func (c Config) withDefaults() Config {
+	if c.MaxBodyBytes <= 0 {
+		c.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if len(c.SkipPaths) == 0 {
+		c.SkipPaths = DefaultSkipPaths
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = DefaultBufferSize
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultBatchSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = DefaultFlushInterval
+	}
+	return c
+}
+
+// Record is the audit entry of an API call
+type Record struct {
+	Time             time.Time `json:"time"`
+	RequestID        string    `json:"request_id"`
+	Method           string    `json:"method"`
+	Path             string    `json:"path"`
+	Query            string    `json:"query,omitempty"`
+	Status           int       `json:"status"`
+	LatencyMS        int64     `json:"latency_ms"`
+	Caller           string    `json:"caller,omitempty"`      // Subject of the principal
+	KeyID            string    `json:"key_id,omitempty"`      // API key of the principal
+	AuthMethod       string    `json:"auth_method,omitempty"` // auth.MethodAPIKey or auth.MethodJWT
+	Tenant           string    `json:"tenant,omitempty"`
+	Remote           string    `json:"remote,omitempty"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	TotalTokens      int       `json:"total_tokens,omitempty"`
+	RequestBody      string    `json:"request_body,omitempty"`
+	ResponseBody     string    `json:"response_body,omitempty"`
+	Truncated        bool      `json:"truncated,omitempty"` // A captured body exceeded MaxBodyBytes
+}
+
+// call collects what the handlers learn about a call while it is served.
+// The middleware keeps it in the request context; handlers and the inner
+// middleware update it through the context.
+type call struct {
+	mu        sync.Mutex
+	principal *auth.Principal
+	tenant    string
+	tokens    Tokens
+}
+
+type callKey struct{}
+
+// withCall stores the state of a call in the context
+func withCall(ctx context.Context, c *call) context.Context {
+	return context.WithValue(ctx, callKey{}, c)
+}
+
+// callFromContext returns the state of the audited call of a context
+func callFromContext(ctx context.Context) (*call, bool) {
+	c, ok := ctx.Value(callKey{}).(*call)
+	return c, ok
+}
+
+// Tokens is the token usage of a model call
+type Tokens struct {
+	Prompt     int
+	Completion int
+	Total      int // Prompt and Completion if 0
+}
+
+// AddTokens adds the token usage of a model call to the audited call of
+// the context. Streaming sessions may add usage several times; calls that
+// are not audited ignore it.
+func AddTokens(ctx context.Context, tokens Tokens) {
+	c, ok := callFromContext(ctx)
+	if !ok {
+		return
+	}
+	if tokens.Total == 0 {
+		tokens.Total = tokens.Prompt + tokens.Completion
+	}
+	c.mu.Lock()
+	c.tokens.Prompt += tokens.Prompt
+	c.tokens.Completion += tokens.Completion
+	c.tokens.Total += tokens.Total
+	c.mu.Unlock()
+}
+
+// identify records the principal and tenant of the context in its call
+func identify(ctx context.Context) {
+	c, ok := callFromContext(ctx)
+	if !ok {
+		return
+	}
+	principal, _ := auth.FromContext(ctx)
+	id, _ := tenant.FromContext(ctx)
+	c.mu.Lock()
+	c.principal = principal
+	c.tenant = id
+	c.mu.Unlock()
+}
+
+// fill copies the collected state into a record
+func (c *call) fill(rec *Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.principal != nil {
+		rec.Caller = c.principal.Subject
+		rec.KeyID = c.principal.KeyID
+		rec.AuthMethod = c.principal.Method
+	}
+	rec.Tenant = c.tenant
+	rec.PromptTokens = c.tokens.Prompt
+	rec.CompletionTokens = c.tokens.Completion
+	rec.TotalTokens = c.tokens.Total
+}
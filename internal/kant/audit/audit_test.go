@@ -0,0 +1,425 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Unit tests for the audit middleware and gRPC interceptors,
+//              redaction, storage, and admin endpoint
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newRecorder(t *testing.T, cfg Config, store Store) *Recorder {
+	t.Helper()
+	cfg.Enabled = true
+	r, err := New(cfg, store)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return r
+}
+
+func newRedactor(t *testing.T, cfg RedactionConfig) *Redactor {
+	t.Helper()
+	r, err := NewRedactor(cfg)
+	if err != nil {
+		t.Fatalf("NewRedactor() error = %v", err)
+	}
+	return r
+}
+
+// ============================================================================
+// Unit Tests - Redaction
+// ============================================================================
+
+func TestRedactor_Text(t *testing.T) {
+	r := newRedactor(t, RedactionConfig{Custom: []string{`KD-[0-9]{6}`}})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Mail an max.mustermann@example.de bitte", "Mail an [REDACTED:email] bitte"},
+		{"IBAN DE89 3704 0044 0532 0130 00 überweisen", "IBAN [REDACTED:iban] überweisen"},
+		{"Karte 4111 1111 1111 1111", "Karte [REDACTED:credit_card]"},
+		{"Bestellung 1234567890123", "Bestellung 1234567890123"}, // Fails the checksum
+		{"Ruf an: +49 30 1234567", "Ruf an: [REDACTED:phone]"},
+		{"Tel. 030/1234567", "Tel. [REDACTED:phone]"},
+		{"Client 192.168.1.20", "Client [REDACTED:ipv4]"},
+		{"Kunde KD-123456", "Kunde [REDACTED]"},
+		{"Version 1.2.3 mit 42 Treffern", "Version 1.2.3 mit 42 Treffern"},
+	}
+	for _, tt := range tests {
+		if got := r.Text(tt.in); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	none := newRedactor(t, RedactionConfig{Patterns: []string{}, Fields: []string{}})
+	if got := none.Text("max@example.de"); got != "max@example.de" {
+		t.Errorf("Text() without patterns = %q", got)
+	}
+
+	// API keys of Kant are removed even without patterns
+	key := auth.KeyPrefix + "0123456789abcdef_" + strings.Repeat("ab", 24)
+	if got := none.Text("Schlüssel " + key); got != "Schlüssel [REDACTED:api_key]" {
+		t.Errorf("Text(api key) = %q", got)
+	}
+}
+
+func TestRedactor_Body(t *testing.T) {
+	r := newRedactor(t, RedactionConfig{})
+
+	body := `{"user":"max@example.de","Password":"geheim","messages":[{"content":"Ruf +49 30 1234567 an"}],"n":3}`
+	got := r.Body([]byte(body), false)
+	want := `{"Password":"[REDACTED]","messages":[{"content":"Ruf [REDACTED:phone] an"}],"n":3,"user":"[REDACTED:email]"}`
+	if got != want {
+		t.Errorf("Body() = %s, want %s", got, want)
+	}
+
+	// Truncated JSON cannot be parsed; masked fields are still removed
+	got = r.Body([]byte(`{"api_key":"mdw_abc\"def","text":"max@exa`), true)
+	if strings.Contains(got, "mdw_abc") || !strings.Contains(got, `"api_key":"[REDACTED]"`) {
+		t.Errorf("Body(truncated) = %s", got)
+	}
+
+	if got := r.Query("q=max%40example.de&token=abc&limit=5"); got != "limit=5&q=%5BREDACTED%3Aemail%5D&token=%5BREDACTED%5D" {
+		t.Errorf("Query() = %s", got)
+	}
+}
+
+func TestNewRedactor_Invalid(t *testing.T) {
+	if _, err := NewRedactor(RedactionConfig{Patterns: []string{"passport"}}); err == nil {
+		t.Error("NewRedactor() with unknown pattern succeeded")
+	}
+	if _, err := NewRedactor(RedactionConfig{Custom: []string{"("}}); err == nil {
+		t.Error("NewRedactor() with invalid pattern succeeded")
+	}
+}
+
+// ============================================================================
+// Unit Tests - Middleware
+// ============================================================================
+
+func TestRecorder_Middleware(t *testing.T) {
+	store := NewMemoryStore(0)
+	r := newRecorder(t, Config{CaptureRequestBody: true, CaptureResponseBody: true, MaxBodyBytes: 64}, store)
+
+	api := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		io.ReadAll(req.Body)
+		AddTokens(req.Context(), Tokens{Prompt: 10, Completion: 5})
+		AddTokens(req.Context(), Tokens{Total: 7})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"reply":"Schreib an anna@example.de"}`)
+	})
+	// Stands in for the authentication and tenant middleware
+	identified := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := auth.WithPrincipal(req.Context(), &auth.Principal{Subject: "ci", KeyID: "k1", Method: auth.MethodAPIKey})
+		ctx = tenant.WithTenant(ctx, "acme")
+		Identify(api).ServeHTTP(w, req.WithContext(ctx))
+	})
+	handler := r.Middleware(identified)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat?email=max@example.de", strings.NewReader(`{"password":"geheim","text":"hallo"}`))
+	req.Header.Set(RequestIDHeader, "req-1")
+	req.RemoteAddr = "10.0.0.1:51234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get(RequestIDHeader) != "req-1" {
+		t.Errorf("X-Request-ID = %q, want req-1", rec.Header().Get(RequestIDHeader))
+	}
+
+	// Health checks are skipped, requests without an ID get one
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/models", nil))
+	if len(rec.Header().Get(RequestIDHeader)) != 32 {
+		t.Errorf("generated X-Request-ID = %q", rec.Header().Get(RequestIDHeader))
+	}
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	records, total, _ := store.Query(context.Background(), Query{})
+	if total != 2 {
+		t.Fatalf("recorded %d calls, want 2: %+v", total, records)
+	}
+
+	got, _, _ := store.Query(context.Background(), Query{RequestID: "req-1"})
+	if len(got) != 1 {
+		t.Fatalf("no record of req-1")
+	}
+	call := got[0]
+	if call.Method != http.MethodPost || call.Path != "/api/v1/chat" || call.Status != http.StatusCreated ||
+		call.Caller != "ci" || call.KeyID != "k1" || call.AuthMethod != auth.MethodAPIKey ||
+		call.Tenant != "acme" || call.Remote != "10.0.0.1" {
+		t.Errorf("record = %+v", call)
+	}
+	if call.PromptTokens != 10 || call.CompletionTokens != 5 || call.TotalTokens != 22 {
+		t.Errorf("tokens = %d/%d/%d, want 10/5/22", call.PromptTokens, call.CompletionTokens, call.TotalTokens)
+	}
+	if call.Query != "email=%5BREDACTED%3Aemail%5D" {
+		t.Errorf("query = %q", call.Query)
+	}
+	if call.RequestBody != `{"password":"[REDACTED]","text":"hallo"}` {
+		t.Errorf("request body = %s", call.RequestBody)
+	}
+	if strings.Contains(call.ResponseBody, "anna@") || call.Truncated {
+		t.Errorf("response body = %s, truncated %v", call.ResponseBody, call.Truncated)
+	}
+	if stats := r.Stats(); stats.Recorded != 2 || stats.Dropped != 0 || stats.Failed != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestRecorder_IssuedKeys(t *testing.T) {
+	store := NewMemoryStore(0)
+	r := newRecorder(t, Config{CaptureResponseBody: true}, store)
+
+	// The response that creates a key carries it in plaintext once
+	key := auth.KeyPrefix + "0123456789abcdef_" + strings.Repeat("ab", 24)
+	r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"key":     key,
+			"api_key": map[string]string{"id": "0123456789abcdef", "name": "ci"},
+			"note":    "Schlüssel " + key,
+		})
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, auth.KeysPath, strings.NewReader(`{"name":"ci"}`)))
+	r.Close(context.Background())
+
+	records, _, _ := store.Query(context.Background(), Query{})
+	if len(records) != 1 {
+		t.Fatalf("records = %+v", records)
+	}
+	if body := records[0].ResponseBody; strings.Contains(body, key[len(auth.KeyPrefix):]) || !strings.Contains(body, `"key":"[REDACTED]"`) {
+		t.Errorf("response body = %s", body)
+	}
+}
+
+func TestRecorder_TruncatesBodies(t *testing.T) {
+	store := NewMemoryStore(0)
+	r := newRecorder(t, Config{CaptureResponseBody: true, MaxBodyBytes: 16}, store)
+
+	r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, `{"token":"0123456789abcdef"}`)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/models", nil))
+	r.Close(context.Background())
+
+	records, _, _ := store.Query(context.Background(), Query{})
+	if len(records) != 1 || !records[0].Truncated || records[0].ResponseBody != `{"token":"[REDACTED]"` {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestRecorder_Disabled(t *testing.T) {
+	store := NewMemoryStore(0)
+	r, err := New(Config{}, store)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+	r.Middleware(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/models", nil))
+	r.Record(Record{Path: "/api/v1/models"})
+	r.Close(context.Background())
+
+	if _, total, _ := store.Query(context.Background(), Query{}); total != 0 {
+		t.Errorf("disabled recorder stored %d records", total)
+	}
+}
+
+// ============================================================================
+// Unit Tests - gRPC Interceptors
+// ============================================================================
+
+// fakeServerStream is a server stream that only carries a context and
+// collects the response header
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRecorder_ServerInterceptors(t *testing.T) {
+	store := NewMemoryStore(0)
+	r := newRecorder(t, Config{CaptureRequestBody: true, CaptureResponseBody: true}, store)
+	unary := r.UnaryServerInterceptor()
+
+	// Stands in for the authentication and tenant interceptors
+	chat := &grpc.UnaryServerInfo{FullMethod: "/mdw.kant.KantService/Chat"}
+	identified := func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: "ci", Method: auth.MethodAPIKey})
+		ctx = tenant.WithTenant(ctx, "acme")
+		return UnaryIdentifyInterceptor()(ctx, req, chat, func(ctx context.Context, req interface{}) (interface{}, error) {
+			AddTokens(ctx, Tokens{Prompt: 3, Completion: 4})
+			return structpb.NewStringValue("ok"), nil
+		})
+	}
+
+	req, _ := structpb.NewStruct(map[string]interface{}{"password": "geheim", "text": "hallo"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+	if _, err := unary(ctx, req, chat, identified); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	denied := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	unary(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/mdw.kant.KantService/Search"}, denied)
+	unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/mdw.kant.KantService/HealthCheck"}, denied)
+
+	ss := &fakeServerStream{ctx: context.Background()}
+	r.StreamServerInterceptor()(nil, ss, &grpc.StreamServerInfo{FullMethod: "/mdw.kant.KantService/StreamChat"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			AddTokens(stream.Context(), Tokens{Prompt: 1, Completion: 1})
+			return status.Error(codes.Unavailable, "turing service not connected")
+		})
+	r.Close(context.Background())
+
+	records, total, _ := store.Query(context.Background(), Query{Method: GRPCMethod})
+	if total != 3 {
+		t.Fatalf("recorded %d calls, want 3 without the health check: %+v", total, records)
+	}
+	byPath := make(map[string]Record)
+	for _, rec := range records {
+		byPath[rec.Path] = rec
+	}
+
+	call := byPath["/mdw.kant.KantService/Chat"]
+	if call.RequestID != "req-1" || call.Status != http.StatusOK || call.Caller != "ci" || call.Tenant != "acme" || call.TotalTokens != 7 {
+		t.Errorf("unary record = %+v", call)
+	}
+	if strings.Contains(call.RequestBody, "geheim") || !strings.Contains(call.RequestBody, Redacted) || call.ResponseBody != `"ok"` {
+		t.Errorf("bodies = %s / %s", call.RequestBody, call.ResponseBody)
+	}
+	if got := byPath["/mdw.kant.KantService/Search"].Status; got != http.StatusForbidden {
+		t.Errorf("denied status = %d, want 403", got)
+	}
+
+	stream := byPath["/mdw.kant.KantService/StreamChat"]
+	if stream.Status != http.StatusServiceUnavailable || stream.TotalTokens != 2 || stream.RequestBody != "" {
+		t.Errorf("stream record = %+v", stream)
+	}
+	if id := ss.header.Get("x-request-id"); len(id) != 1 || id[0] != stream.RequestID || len(stream.RequestID) != 32 {
+		t.Errorf("stream request ID header = %v, record %q", id, stream.RequestID)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Storage and Admin Endpoint
+// ============================================================================
+
+func TestBayesEntries(t *testing.T) {
+	rec := Record{
+		Time:        time.Date(2026, 10, 16, 12, 0, 0, 123000000, time.UTC),
+		RequestID:   "req-1",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/chat",
+		Status:      200,
+		LatencyMS:   42,
+		Caller:      "ci",
+		Tenant:      "acme",
+		TotalTokens: 9,
+		RequestBody: `{"text":"hallo"}`,
+		Truncated:   true,
+	}
+	entry := recordToEntry(rec)
+	if entry.Service != Service || entry.Message != "POST /api/v1/chat 200" || entry.RequestId != "req-1" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if got := entryToRecord(entry); got != rec {
+		t.Errorf("round trip = %+v, want %+v", got, rec)
+	}
+}
+
+func TestAdminHandler(t *testing.T) {
+	store := NewMemoryStore(0)
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	store.Write(context.Background(), []Record{
+		{Time: base, RequestID: "1", Method: "GET", Path: "/api/v1/models", Status: 200, Tenant: "acme"},
+		{Time: base.Add(time.Second), RequestID: "2", Method: "POST", Path: "/api/v1/chat", Status: 502, Tenant: "acme"},
+		{Time: base.Add(2 * time.Second), RequestID: "3", Method: "POST", Path: "/api/v1/chat", Status: 500, Tenant: "globex"},
+	})
+	r := newRecorder(t, Config{}, store)
+	defer r.Close(context.Background())
+
+	serve := func(query string, principal *auth.Principal) (*httptest.ResponseRecorder, TrailResponse) {
+		req := httptest.NewRequest(http.MethodGet, AdminPath+query, nil)
+		if principal != nil {
+			req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+		}
+		rec := httptest.NewRecorder()
+		r.AdminHandler().ServeHTTP(rec, req)
+		var resp TrailResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec, resp
+	}
+
+	_, resp := serve("", nil)
+	if resp.Total != 3 || resp.Records[0].RequestID != "3" || resp.Limit != DefaultQueryLimit {
+		t.Errorf("all records = %+v", resp)
+	}
+	_, resp = serve("?status=5xx&path=/api/v1/chat&limit=1", nil)
+	if resp.Total != 2 || len(resp.Records) != 1 || resp.Records[0].RequestID != "3" {
+		t.Errorf("server errors = %+v", resp)
+	}
+	_, resp = serve("?status=500-502&offset=1", nil)
+	if resp.Total != 2 || len(resp.Records) != 1 || resp.Records[0].RequestID != "2" {
+		t.Errorf("second page = %+v", resp)
+	}
+
+	bound := &auth.Principal{Subject: "ops", Scopes: []string{auth.ScopeAdmin}, Tenant: "acme"}
+	_, resp = serve("", bound)
+	if resp.Total != 2 {
+		t.Errorf("tenant admin sees %d records, want 2", resp.Total)
+	}
+	if rec, _ := serve("?tenant=globex", bound); rec.Code != http.StatusForbidden {
+		t.Errorf("other tenant status = %d, want 403", rec.Code)
+	}
+	if rec, _ := serve("", &auth.Principal{Subject: "ci", Scopes: []string{"chat"}}); rec.Code != http.StatusForbidden {
+		t.Errorf("without admin scope status = %d, want 403", rec.Code)
+	}
+	for _, query := range []string{"?status=9xx", "?status=500-400", "?from=yesterday", "?limit=-1"} {
+		if rec, _ := serve(query, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want 400", query, rec.Code)
+		}
+	}
+
+	disabled, _ := New(Config{}, store)
+	rec := httptest.NewRecorder()
+	disabled.AdminHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AdminPath, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("disabled status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,181 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Audit records stored as log entries of the Bayes service
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	bayespb "github.com/msto63/mDW/api/gen/bayes"
+)
+
+// Service is the service name audit records are logged under in Bayes
+const Service = "kant-audit"
+
+// DefaultQueryWindow is the time range searched in Bayes for queries
+// without a start
+const DefaultQueryWindow = 24 * time.Hour
+
+// ErrBayesUnavailable is returned while no Bayes client is connected
+var ErrBayesUnavailable = errors.New("bayes service not available")
+
+// BayesStore stores audit records as log entries of the Bayes service.
+// Bayes filters by service, time, and request ID; the other filters of a
+// query are applied to the entries it returns.
+type BayesStore struct {
+	client func() bayespb.BayesServiceClient
+}
+
+// NewBayesStore creates a store that uses the Bayes client returned by
+// client, which may be nil while Bayes is not connected
+func NewBayesStore(client func() bayespb.BayesServiceClient) *BayesStore {
+	return &BayesStore{client: client}
+}
+
+// Write implements Store
+func (s *BayesStore) Write(ctx context.Context, records []Record) error {
+	client := s.client()
+	if client == nil {
+		return ErrBayesUnavailable
+	}
+
+	entries := make([]*bayespb.LogEntry, len(records))
+	for i, rec := range records {
+		entries[i] = recordToEntry(rec)
+	}
+	resp, err := client.LogBatch(ctx, &bayespb.LogBatchRequest{Entries: entries})
+	if err != nil {
+		return err
+	}
+	if resp.Rejected > 0 {
+		return fmt.Errorf("bayes rejected %d of %d audit records", resp.Rejected, len(records))
+	}
+	return nil
+}
+
+// Query implements Store
+func (s *BayesStore) Query(ctx context.Context, q Query) ([]Record, int, error) {
+	client := s.client()
+	if client == nil {
+		return nil, 0, ErrBayesUnavailable
+	}
+
+	from := q.From
+	if from.IsZero() {
+		from = time.Now().Add(-DefaultQueryWindow)
+	}
+	req := &bayespb.QueryLogsRequest{
+		Service: Service,
+		// Bayes matches the level exactly; all records are logged at info
+		MinLevel:      bayespb.LogLevel_LOG_LEVEL_INFO,
+		FromTimestamp: from.Unix(),
+		RequestId:     q.RequestID,
+	}
+	if !q.To.IsZero() {
+		// Bayes times entries when they arrive, after the records were
+		// queued; the record times are filtered exactly below
+		req.ToTimestamp = q.To.Add(time.Minute).Unix()
+	}
+
+	resp, err := client.QueryLogs(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	records := make([]Record, 0, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		records = append(records, entryToRecord(entry))
+	}
+	result, total := page(records, q)
+	return result, total, nil
+}
+
+// recordToEntry converts a record into a Bayes log entry
+func recordToEntry(rec Record) *bayespb.LogEntry {
+	fields := map[string]string{
+		"time":       rec.Time.UTC().Format(time.RFC3339Nano),
+		"method":     rec.Method,
+		"path":       rec.Path,
+		"status":     strconv.Itoa(rec.Status),
+		"latency_ms": strconv.FormatInt(rec.LatencyMS, 10),
+	}
+	optional := map[string]string{
+		"query":         rec.Query,
+		"caller":        rec.Caller,
+		"key_id":        rec.KeyID,
+		"auth_method":   rec.AuthMethod,
+		"tenant":        rec.Tenant,
+		"remote":        rec.Remote,
+		"request_body":  rec.RequestBody,
+		"response_body": rec.ResponseBody,
+	}
+	for key, value := range optional {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	if rec.TotalTokens > 0 {
+		fields["prompt_tokens"] = strconv.Itoa(rec.PromptTokens)
+		fields["completion_tokens"] = strconv.Itoa(rec.CompletionTokens)
+		fields["total_tokens"] = strconv.Itoa(rec.TotalTokens)
+	}
+	if rec.Truncated {
+		fields["truncated"] = "true"
+	}
+
+	return &bayespb.LogEntry{
+		Service:   Service,
+		Level:     bayespb.LogLevel_LOG_LEVEL_INFO,
+		Message:   fmt.Sprintf("%s %s %d", rec.Method, rec.Path, rec.Status),
+		Timestamp: rec.Time.Unix(),
+		Fields:    fields,
+		RequestId: rec.RequestID,
+		Caller:    rec.Caller,
+	}
+}
+
+// entryToRecord converts a Bayes log entry back into a record
+func entryToRecord(entry *bayespb.LogEntry) Record {
+	f := entry.Fields
+	number := func(key string) int {
+		n, _ := strconv.Atoi(f[key])
+		return n
+	}
+
+	rec := Record{
+		RequestID:        entry.RequestId,
+		Method:           f["method"],
+		Path:             f["path"],
+		Query:            f["query"],
+		Status:           number("status"),
+		Caller:           f["caller"],
+		KeyID:            f["key_id"],
+		AuthMethod:       f["auth_method"],
+		Tenant:           f["tenant"],
+		Remote:           f["remote"],
+		PromptTokens:     number("prompt_tokens"),
+		CompletionTokens: number("completion_tokens"),
+		TotalTokens:      number("total_tokens"),
+		RequestBody:      f["request_body"],
+		ResponseBody:     f["response_body"],
+		Truncated:        f["truncated"] == "true",
+	}
+	rec.LatencyMS, _ = strconv.ParseInt(f["latency_ms"], 10, 64)
+	if t, err := time.Parse(time.RFC3339Nano, f["time"]); err == nil {
+		rec.Time = t
+	} else {
+		rec.Time = time.Unix(entry.Timestamp, 0).UTC()
+	}
+	return rec
+}
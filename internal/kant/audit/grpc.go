@@ -0,0 +1,187 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: gRPC server interceptors that record the calls of the native
+//              gRPC gateway in the audit trail
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/httpx"
+	coreGrpc "github.com/msto63/mDW/pkg/core/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// GRPCMethod is the method of the records of gRPC calls; their path is the
+// full name of the called method, e.g. /mdw.kant.KantService/Chat
+const GRPCMethod = "GRPC"
+
+// UnaryServerInterceptor records unary gRPC calls like the HTTP middleware.
+// The status is the HTTP equivalent of the gRPC status code; bodies are
+// captured as protobuf JSON. It must run before the authentication
+// interceptor; UnaryIdentifyInterceptor supplies the caller and tenant.
+// Calls get an ID in the x-request-id header unless they carry one.
+func (r *Recorder) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !r.config.Enabled || r.skip[httpx.NormalizePath(info.FullMethod)] {
+			return handler(ctx, req)
+		}
+
+		start := r.now()
+		id := callRequestID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(coreGrpc.RequestIDHeader, id))
+
+		c := &call{}
+		resp, err := handler(withCall(coreGrpc.WithRequestID(ctx, id), c), req)
+
+		rec := r.grpcRecord(ctx, info.FullMethod, id, start, err, c)
+		if r.config.CaptureRequestBody {
+			rec.RequestBody, rec.Truncated = r.messageBody(req)
+		}
+		if r.config.CaptureResponseBody && err == nil {
+			body, truncated := r.messageBody(resp)
+			rec.ResponseBody = body
+			rec.Truncated = rec.Truncated || truncated
+		}
+		r.Record(rec)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming gRPC calls like
+// UnaryServerInterceptor when the stream ends; bodies are not captured
+func (r *Recorder) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !r.config.Enabled || r.skip[httpx.NormalizePath(info.FullMethod)] {
+			return handler(srv, ss)
+		}
+
+		start := r.now()
+		ctx := ss.Context()
+		id := callRequestID(ctx)
+		ss.SetHeader(metadata.Pairs(coreGrpc.RequestIDHeader, id))
+
+		c := &call{}
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: withCall(coreGrpc.WithRequestID(ctx, id), c)})
+
+		r.Record(r.grpcRecord(ctx, info.FullMethod, id, start, err, c))
+		return err
+	}
+}
+
+// UnaryIdentifyInterceptor records the principal and tenant of unary gRPC
+// calls in their audit records. It must run after the authentication and
+// tenant interceptors.
+func UnaryIdentifyInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		identify(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// StreamIdentifyInterceptor records the principal and tenant of streaming
+// gRPC calls in their audit records like UnaryIdentifyInterceptor
+func StreamIdentifyInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		identify(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+// grpcRecord creates the record of a gRPC call that ended with err
+func (r *Recorder) grpcRecord(ctx context.Context, method, id string, start time.Time, err error, c *call) Record {
+	rec := Record{
+		Time:      start.UTC(),
+		RequestID: id,
+		Method:    GRPCMethod,
+		Path:      method,
+		Status:    httpStatus(status.Code(err)),
+		LatencyMS: r.now().Sub(start).Milliseconds(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rec.Remote = remoteHost(p.Addr.String())
+	}
+	c.fill(&rec)
+	return rec
+}
+
+// messageBody returns the redacted protobuf JSON of a message, truncated
+// to MaxBodyBytes
+func (r *Recorder) messageBody(msg interface{}) (string, bool) {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return "", false
+	}
+	body := &capture{limit: r.config.MaxBodyBytes}
+	body.write(data)
+	return r.redactor.Body(body.buf.Bytes(), body.truncated), body.truncated
+}
+
+// callRequestID returns the ID a client sent with a gRPC call or a new one
+func callRequestID(ctx context.Context) string {
+	if id := coreGrpc.GetRequestID(ctx); id != "" && len(id) <= 128 && printable(id) {
+		return id
+	}
+	return newRequestID()
+}
+
+// httpStatus returns the HTTP status equivalent to a gRPC status code, so
+// the records of both transports can be filtered alike
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client closed request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// serverStream replaces the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
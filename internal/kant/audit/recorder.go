@@ -0,0 +1,378 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: HTTP middleware that records API calls and writes them to
+//              the audit store in the background
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/httpx"
+	"github.com/msto63/mDW/pkg/core/logging"
+)
+
+// writeTimeout bounds a write of queued records to the store
+const writeTimeout = 5 * time.Second
+
+// Stats counts the records of the audit trail since the gateway started
+type Stats struct {
+	Recorded int64 `json:"recorded"` // Queued for the store
+	Dropped  int64 `json:"dropped"`  // Lost because the queue was full
+	Failed   int64 `json:"failed"`   // Lost because the store failed
+}
+
+// Recorder records the API calls of the gateway and writes them to a store
+type Recorder struct {
+	config   Config
+	store    Store
+	redactor *Redactor
+	skip     map[string]bool
+	logger   *logging.Logger
+	now      func() time.Time
+
+	mu     sync.RWMutex // Guards sending to the queue against Close
+	closed bool
+	queue  chan Record
+	done   chan struct{}
+
+	recorded, dropped, failed atomic.Int64
+}
+
+// New creates a recorder that writes to store. If the audit trail is
+// enabled, records are written in the background until Close.
+func New(cfg Config, store Store) (*Recorder, error) {
+	cfg = cfg.withDefaults()
+	redactor, err := NewRedactor(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		config:   cfg,
+		store:    store,
+		redactor: redactor,
+		skip:     make(map[string]bool),
+		logger:   logging.New("kant-audit"),
+		now:      time.Now,
+		queue:    make(chan Record, cfg.BufferSize),
+		done:     make(chan struct{}),
+	}
+	for _, path := range cfg.SkipPaths {
+		r.skip[httpx.NormalizePath(path)] = true
+	}
+
+	if cfg.Enabled {
+		go r.run()
+	} else {
+		close(r.done)
+	}
+	return r, nil
+}
+
+// Enabled reports whether API calls are recorded
+func (r *Recorder) Enabled() bool {
+	return r.config.Enabled
+}
+
+// Record queues a record for the store. Records are dropped while the
+// queue is full, so a slow store does not hold up requests.
+func (r *Recorder) Record(rec Record) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed || !r.config.Enabled {
+		return
+	}
+	select {
+	case r.queue <- rec:
+		r.recorded.Add(1)
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Stats returns the counters of the audit trail
+func (r *Recorder) Stats() Stats {
+	return Stats{
+		Recorded: r.recorded.Load(),
+		Dropped:  r.dropped.Load(),
+		Failed:   r.failed.Load(),
+	}
+}
+
+// Close writes the queued records and stops the recorder; records of calls
+// that end later are discarded
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes queued records in batches until the queue is closed
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, r.config.BatchSize)
+	var reportedDrops int64
+	for {
+		select {
+		case rec, ok := <-r.queue:
+			if !ok {
+				r.write(batch)
+				return
+			}
+			batch = append(batch, rec)
+			if len(batch) < r.config.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if dropped := r.dropped.Load(); dropped > reportedDrops {
+				r.logger.Warn("Audit queue full, records dropped", "dropped", dropped-reportedDrops)
+				reportedDrops = dropped
+			}
+		}
+		r.write(batch)
+		batch = batch[:0]
+	}
+}
+
+// write stores a batch of records
+func (r *Recorder) write(batch []Record) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+	if err := r.store.Write(ctx, batch); err != nil {
+		r.failed.Add(int64(len(batch)))
+		r.logger.Warn("Failed to write audit records", "records", len(batch), "error", err)
+	}
+}
+
+// Middleware records every API call that is not skipped: the request ID,
+// method, path, redacted query, status, latency, caller, tenant, token
+// usage, and, if configured, the redacted bodies. It must run before the
+// authentication middleware, so rejected calls are recorded as well;
+// Identify supplies the caller and tenant from behind it. Requests get an
+// ID in the X-Request-ID header unless they carry one.
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	if !r.config.Enabled {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodOptions || r.skip[httpx.NormalizePath(req.URL.Path)] {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		start := r.now()
+		id := requestID(req)
+		req.Header.Set(RequestIDHeader, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		var requestBody *capture
+		if r.config.CaptureRequestBody && req.Body != nil && req.Body != http.NoBody {
+			requestBody = &capture{limit: r.config.MaxBodyBytes}
+			req.Body = &captureReader{ReadCloser: req.Body, capture: requestBody}
+		}
+		rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		if r.config.CaptureResponseBody {
+			rw.body = &capture{limit: r.config.MaxBodyBytes}
+		}
+
+		c := &call{}
+		next.ServeHTTP(rw, req.WithContext(withCall(req.Context(), c)))
+
+		rec := Record{
+			Time:      start.UTC(),
+			RequestID: id,
+			Method:    req.Method,
+			Path:      req.URL.Path,
+			Query:     r.redactor.Query(req.URL.RawQuery),
+			Status:    rw.status,
+			LatencyMS: r.now().Sub(start).Milliseconds(),
+			Remote:    remoteHost(req.RemoteAddr),
+		}
+		c.fill(&rec)
+		if requestBody != nil && textual(req.Header.Get("Content-Type")) {
+			rec.RequestBody = r.redactor.Body(requestBody.buf.Bytes(), requestBody.truncated)
+			rec.Truncated = requestBody.truncated
+		}
+		if rw.body != nil && textual(rw.Header().Get("Content-Type")) {
+			rec.ResponseBody = r.redactor.Body(rw.body.buf.Bytes(), rw.body.truncated)
+			rec.Truncated = rec.Truncated || rw.body.truncated
+		}
+		r.Record(rec)
+	})
+}
+
+// Identify records the principal and tenant of requests in their audit
+// records. It must run after the authentication and tenant middleware.
+func Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identify(r.Context())
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestID returns the ID a client sent with a request or a new one
+func requestID(r *http.Request) string {
+	if id := r.Header.Get(RequestIDHeader); id != "" && len(id) <= 128 && printable(id) {
+		return id
+	}
+	return newRequestID()
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// printable reports whether s consists of printable ASCII characters
+func printable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// textual reports whether bodies of a content type are text worth
+// recording; bodies without a content type are assumed to be JSON
+func textual(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+// remoteHost strips the port from a remote address
+func remoteHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// capture keeps the first bytes of a body
+type capture struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (c *capture) write(p []byte) {
+	if room := c.limit - c.buf.Len(); len(p) > room {
+		p = p[:max(room, 0)]
+		c.truncated = true
+	}
+	c.buf.Write(p)
+}
+
+// captureReader captures a request body as far as the handler reads it
+type captureReader struct {
+	io.ReadCloser
+	capture *capture
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.capture.write(p[:n])
+	return n, err
+}
+
+// responseRecorder captures the status and, if body is set, the body of a
+// response
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        *capture
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseRecorder) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		w.body.write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher for SSE streaming support
+func (w *responseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker for WebSocket upgrades; the call is
+// recorded with status 101 when the connection closes
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.status = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
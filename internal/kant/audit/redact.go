@@ -0,0 +1,249 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Redaction of personal data in captured bodies and queries
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+)
+
+// Redacted replaces masked values and matches of custom patterns; matches
+// of built-in patterns name the pattern, e.g. "[REDACTED:email]"
+const Redacted = "[REDACTED]"
+
+// Built-in patterns are applied in this order, so a credit card number is
+// not taken for a phone number
+var builtinOrder = []string{"email", "iban", "credit_card", "phone", "ipv4"}
+
+var builtinPatterns = map[string]*regexp.Regexp{
+	"email":       regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	"iban":        regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{4}){3,7}(?: ?[A-Z0-9]{1,3})?\b`),
+	"credit_card": regexp.MustCompile(`\b[0-9](?:[ -]?[0-9]){12,18}\b`),
+	"phone":       regexp.MustCompile(`(?:\+|\b00)[1-9][0-9]{0,2}[ /.-]?(?:\(0\))?[ /.-]?[0-9]{2,5}(?:[ /.-]?[0-9]{2,}){1,3}|\b0[1-9][0-9]{1,4}[ /-]?[0-9]{3,}(?:[ -]?[0-9]+)?\b`),
+	"ipv4":        regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\b`),
+}
+
+// DefaultPatterns are the built-in patterns applied if none are configured
+var DefaultPatterns = builtinOrder
+
+// apiKeyRule removes API keys issued by Kant, e.g. from the response that
+// creates a key. It applies regardless of the configured patterns.
+var apiKeyRule = rule{
+	pattern:     regexp.MustCompile(regexp.QuoteMeta(auth.KeyPrefix) + `[0-9a-f]+_[0-9a-f]+`),
+	replacement: "[REDACTED:api_key]",
+}
+
+// DefaultFields are the JSON fields and query parameters masked if none
+// are configured
+var DefaultFields = []string{
+	"password", "secret", "token", "key", "api_key", "apikey",
+	"access_token", "refresh_token", "client_secret", "authorization",
+}
+
+// RedactionConfig configures what is removed from captured data
+type RedactionConfig struct {
+	// Patterns are the built-in patterns to apply: email, iban,
+	// credit_card, phone, and ipv4 (DefaultPatterns if nil)
+	Patterns []string
+
+	// Custom are additional regular expressions whose matches are removed
+	Custom []string
+
+	// Fields are JSON fields and query parameters whose values are masked
+	// entirely, matched case-insensitively (DefaultFields if nil)
+	Fields []string
+}
+
+// rule replaces the matches of a pattern
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+	valid       func(string) bool // Confirms a match, e.g. by checksum
+}
+
+// Redactor removes personal data from text and JSON
+type Redactor struct {
+	rules  []rule
+	fields map[string]bool
+	inline *regexp.Regexp // Masked fields in JSON that cannot be parsed
+}
+
+// NewRedactor compiles the redaction rules of a configuration
+func NewRedactor(cfg RedactionConfig) (*Redactor, error) {
+	patterns := cfg.Patterns
+	if patterns == nil {
+		patterns = DefaultPatterns
+	}
+	enabled := make(map[string]bool, len(patterns))
+	for _, name := range patterns {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := builtinPatterns[name]; !ok {
+			return nil, fmt.Errorf("unknown redaction pattern %q", name)
+		}
+		enabled[name] = true
+	}
+
+	r := &Redactor{rules: []rule{apiKeyRule}, fields: make(map[string]bool)}
+	for _, name := range builtinOrder {
+		if !enabled[name] {
+			continue
+		}
+		ru := rule{pattern: builtinPatterns[name], replacement: "[REDACTED:" + name + "]"}
+		if name == "credit_card" {
+			ru.valid = luhn
+		}
+		r.rules = append(r.rules, ru)
+	}
+	for _, expr := range cfg.Custom {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		r.rules = append(r.rules, rule{pattern: pattern, replacement: Redacted})
+	}
+
+	fields := cfg.Fields
+	if fields == nil {
+		fields = DefaultFields
+	}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || r.fields[field] {
+			continue
+		}
+		r.fields[field] = true
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	if len(quoted) > 0 {
+		sort.Strings(quoted)
+		r.inline = regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+	}
+	return r, nil
+}
+
+// Text removes the matches of the patterns from text. Masked fields are
+// also removed from JSON in the text, so truncated bodies are redacted.
+func (r *Redactor) Text(s string) string {
+	if r.inline != nil {
+		s = r.inline.ReplaceAllString(s, `$1"`+Redacted+`"`)
+	}
+	for _, ru := range r.rules {
+		if ru.valid == nil {
+			s = ru.pattern.ReplaceAllLiteralString(s, ru.replacement)
+			continue
+		}
+		s = ru.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if ru.valid(match) {
+				return ru.replacement
+			}
+			return match
+		})
+	}
+	return s
+}
+
+// Body redacts a captured body. Complete JSON bodies have masked fields
+// replaced and their strings redacted; other bodies are redacted as text.
+func (r *Redactor) Body(body []byte, truncated bool) string {
+	if !truncated && json.Valid(body) {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err == nil {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(r.value(v)); err == nil {
+				return strings.TrimSuffix(buf.String(), "\n")
+			}
+		}
+	}
+	return r.Text(strings.ToValidUTF8(string(body), "�"))
+}
+
+// value redacts a decoded JSON value
+func (r *Redactor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] {
+				v[key] = Redacted
+			} else {
+				v[key] = r.value(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.value(item)
+		}
+		return v
+	case string:
+		return r.Text(v)
+	default:
+		return v
+	}
+}
+
+// Query redacts a raw query string: masked parameters are replaced, the
+// values of the others redacted as text
+func (r *Redactor) Query(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return r.Text(raw)
+	}
+	for key, list := range values {
+		for i, value := range list {
+			if r.fields[strings.ToLower(key)] {
+				list[i] = Redacted
+			} else {
+				list[i] = r.Text(value)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+// luhn reports whether the digits of a number pass the Luhn checksum of
+// payment card numbers
+func luhn(number string) bool {
+	sum, digits := 0, 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,137 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     audit
+// Description: Storage and queries of audit records
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package audit
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits of queries
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+// Store persists audit records and queries them
+type Store interface {
+	// Write stores a batch of records
+	Write(ctx context.Context, records []Record) error
+
+	// Query returns the records matching a query, newest first, and the
+	// number of all matching records
+	Query(ctx context.Context, q Query) ([]Record, int, error)
+}
+
+// Query filters audit records; empty fields match every record
+type Query struct {
+	RequestID  string
+	Tenant     string
+	Caller     string
+	Method     string
+	PathPrefix string
+	MinStatus  int // Inclusive, e.g. 500 for server errors
+	MaxStatus  int // Inclusive
+	From       time.Time
+	To         time.Time
+	Limit      int // DefaultQueryLimit if 0, at most MaxQueryLimit
+	Offset     int
+}
+
+// limit returns the effective limit of the query
+func (q Query) limit() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultQueryLimit
+	case q.Limit > MaxQueryLimit:
+		return MaxQueryLimit
+	default:
+		return q.Limit
+	}
+}
+
+// Matches reports whether a record matches the query
+func (q Query) Matches(rec Record) bool {
+	switch {
+	case q.RequestID != "" && rec.RequestID != q.RequestID,
+		q.Tenant != "" && rec.Tenant != q.Tenant,
+		q.Caller != "" && rec.Caller != q.Caller,
+		q.Method != "" && !strings.EqualFold(rec.Method, q.Method),
+		q.PathPrefix != "" && !strings.HasPrefix(rec.Path, q.PathPrefix),
+		q.MinStatus > 0 && rec.Status < q.MinStatus,
+		q.MaxStatus > 0 && rec.Status > q.MaxStatus,
+		!q.From.IsZero() && rec.Time.Before(q.From),
+		!q.To.IsZero() && rec.Time.After(q.To):
+		return false
+	}
+	return true
+}
+
+// page filters records, sorts them newest first, and returns the page of
+// the query with the number of matching records
+func page(records []Record, q Query) ([]Record, int) {
+	matched := make([]Record, 0)
+	for _, rec := range records {
+		if q.Matches(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time.After(matched[j].Time) })
+
+	total := len(matched)
+	if q.Offset >= total {
+		return []Record{}, total
+	}
+	matched = matched[max(q.Offset, 0):]
+	if limit := q.limit(); len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total
+}
+
+// MemoryStore keeps the latest records in memory; it serves tests and
+// gateways without a Bayes service
+type MemoryStore struct {
+	mu       sync.RWMutex
+	records  []Record
+	capacity int
+}
+
+// NewMemoryStore creates a store that keeps up to capacity records
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = MaxQueryLimit
+	}
+	return &MemoryStore{capacity: capacity}
+}
+
+// Write implements Store
+func (s *MemoryStore) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, records...)
+	if over := len(s.records) - s.capacity; over > 0 {
+		s.records = append([]Record(nil), s.records[over:]...)
+	}
+	return nil
+}
+
+// Query implements Store
+func (s *MemoryStore) Query(ctx context.Context, q Query) ([]Record, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records, total := page(s.records, q)
+	return records, total, nil
+}
@@ -0,0 +1,172 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: Admin endpoints to issue, list, and revoke API keys
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/httpx"
+)
+
+// KeysPath is the route of the key management endpoints
+const KeysPath = "/api/v1/admin/keys"
+
+// IssueKeyRequest is the request to issue an API key
+type IssueKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Tenant    string   `json:"tenant,omitempty"`     // Binds the key to a tenant; callers bound to a tenant issue keys for it
+	ExpiresIn string   `json:"expires_in,omitempty"` // Duration, e.g. "720h"; never expires if empty
+}
+
+// IssueKeyResponse contains the issued key, which is only returned once
+type IssueKeyResponse struct {
+	Key    string  `json:"key"`
+	APIKey *APIKey `json:"api_key"`
+}
+
+// KeysResponse lists API keys
+type KeysResponse struct {
+	Keys  []*APIKey `json:"keys"`
+	Total int       `json:"total"`
+}
+
+// AdminHandler serves the key management endpoints:
+//
+//	GET    /api/v1/admin/keys       - list keys
+//	POST   /api/v1/admin/keys       - issue a key
+//	GET    /api/v1/admin/keys/{id}  - get a key
+//	DELETE /api/v1/admin/keys/{id}  - revoke a key
+//
+// The middleware requires the admin scope for these routes; the handler
+// checks it again so it is safe to mount without the middleware. Admins
+// bound to a tenant only see and manage the keys of their tenant.
+func (a *Authenticator) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.config.Enabled {
+			writeError(w, http.StatusNotFound, "not_found", "Authentication is disabled", "")
+			return
+		}
+		principal, _ := FromContext(r.Context())
+		if !principal.HasScope(ScopeAdmin) {
+			writeError(w, http.StatusForbidden, "forbidden", "Access denied", `scope "admin" required`)
+			return
+		}
+
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, KeysPath), "/")
+		switch {
+		case id == "" && r.Method == http.MethodGet:
+			a.handleListKeys(w, principal)
+		case id == "" && r.Method == http.MethodPost:
+			a.handleIssueKey(w, r, principal)
+		case id != "" && r.Method == http.MethodGet:
+			a.handleGetKey(w, principal, id)
+		case id != "" && r.Method == http.MethodDelete:
+			a.handleRevokeKey(w, principal, id)
+		case id == "":
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET or POST", "")
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET or DELETE", "")
+		}
+	})
+}
+
+func (a *Authenticator) handleListKeys(w http.ResponseWriter, principal *Principal) {
+	all, err := a.keys.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list API keys", err.Error())
+		return
+	}
+	keys := make([]*APIKey, 0, len(all))
+	for _, key := range all {
+		if principal.Tenant == "" || key.Tenant == principal.Tenant {
+			keys = append(keys, key)
+		}
+	}
+	httpx.WriteJSON(w, http.StatusOK, KeysResponse{Keys: keys, Total: len(keys)})
+}
+
+func (a *Authenticator) handleIssueKey(w http.ResponseWriter, r *http.Request, principal *Principal) {
+	var req IssueKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
+		return
+	}
+	var ttl time.Duration
+	if req.ExpiresIn != "" {
+		parsed, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Invalid expires_in", "use a positive duration such as 720h")
+			return
+		}
+		ttl = parsed
+	}
+
+	tenant := req.Tenant
+	if principal.Tenant != "" {
+		if tenant != "" && normalizeTenant(tenant) != principal.Tenant {
+			writeError(w, http.StatusForbidden, "forbidden", "Access denied", "keys can only be issued for your own tenant")
+			return
+		}
+		tenant = principal.Tenant
+	}
+
+	token, key, err := a.keys.IssueForTenant(tenant, req.Name, req.Scopes, ttl)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Failed to issue API key", err.Error())
+		return
+	}
+
+	a.logger.Info("API key issued", "id", key.ID, "name", key.Name, "scopes", key.Scopes, "tenant", key.Tenant, "by", principal.Subject)
+	httpx.WriteJSON(w, http.StatusCreated, IssueKeyResponse{Key: token, APIKey: key})
+}
+
+func (a *Authenticator) handleGetKey(w http.ResponseWriter, principal *Principal, id string) {
+	key, err := a.keys.Get(id)
+	if err == nil && !principal.CanAccessTenant(key.Tenant) {
+		err = ErrKeyNotFound
+	}
+	if errors.Is(err, ErrKeyNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "API key not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get API key", err.Error())
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, key)
+}
+
+func (a *Authenticator) handleRevokeKey(w http.ResponseWriter, principal *Principal, id string) {
+	key, err := a.keys.Get(id)
+	if err == nil && !principal.CanAccessTenant(key.Tenant) {
+		err = ErrKeyNotFound
+	}
+	if err == nil {
+		key, err = a.keys.Revoke(id)
+	}
+	if errors.Is(err, ErrKeyNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "API key not found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to revoke API key", err.Error())
+		return
+	}
+
+	a.logger.Info("API key revoked", "id", key.ID, "name", key.Name, "by", principal.Subject)
+	httpx.WriteJSON(w, http.StatusOK, key)
+}
@@ -0,0 +1,354 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: API keys with scopes and expiry, key stores, and the key
+//              manager that issues, revokes, and validates keys
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/msto63/mDW/foundation/utils/filex"
+)
+
+// KeyPrefix starts every API key issued by Kant; it tells keys apart from
+// JWT bearer tokens
+const KeyPrefix = "mdw_"
+
+// APIKey is an issued API key. Only the SHA-256 hash of the secret is
+// kept; the key itself is shown once when it is issued.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"hash,omitempty"`
+	Scopes    []string   `json:"scopes"`
+	Tenant    string     `json:"tenant,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the key is neither revoked nor expired at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// redacted returns a copy of the key without its hash
+func (k *APIKey) redacted() *APIKey {
+	copied := *k
+	copied.Hash = ""
+	return &copied
+}
+
+// KeyStore persists API keys
+type KeyStore interface {
+	// Save creates or replaces a key
+	Save(key *APIKey) error
+
+	// Get returns a key by ID, or ErrKeyNotFound
+	Get(id string) (*APIKey, error)
+
+	// List returns all keys
+	List() ([]*APIKey, error)
+}
+
+// ============================================================================
+// Memory Key Store
+// ============================================================================
+
+// MemoryKeyStore keeps keys in memory; they are lost on restart
+type MemoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]*APIKey
+}
+
+// NewMemoryKeyStore creates an empty in-memory key store
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: make(map[string]*APIKey)}
+}
+
+// Save implements KeyStore
+func (s *MemoryKeyStore) Save(key *APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *key
+	s.keys[key.ID] = &copied
+	return nil
+}
+
+// Get implements KeyStore
+func (s *MemoryKeyStore) Get(id string) (*APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	copied := *key
+	return &copied, nil
+}
+
+// List implements KeyStore
+func (s *MemoryKeyStore) List() ([]*APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]*APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		copied := *key
+		keys = append(keys, &copied)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// ============================================================================
+// File Key Store
+// ============================================================================
+
+// FileKeyStore keeps keys in memory and writes them to a JSON file on every
+// change
+type FileKeyStore struct {
+	*MemoryKeyStore
+	path    string
+	writeMu sync.Mutex // Serializes writes of the file
+}
+
+// NewFileKeyStore loads the keys of a JSON file; a missing file is created
+// with the first saved key
+func NewFileKeyStore(path string) (*FileKeyStore, error) {
+	store := &FileKeyStore{MemoryKeyStore: NewMemoryKeyStore(), path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var keys []*APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+	for _, key := range keys {
+		store.keys[key.ID] = key
+	}
+	return store, nil
+}
+
+// Save implements KeyStore and writes the file
+func (s *FileKeyStore) Save(key *APIKey) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.MemoryKeyStore.Save(key); err != nil {
+		return err
+	}
+	keys, _ := s.MemoryKeyStore.List()
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := filex.WriteFileAtomic(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Key Manager
+// ============================================================================
+
+// KeyManager issues, revokes, and validates API keys
+type KeyManager struct {
+	store KeyStore
+	now   func() time.Time
+}
+
+// NewKeyManager creates a key manager for a store
+func NewKeyManager(store KeyStore) *KeyManager {
+	return &KeyManager{store: store, now: time.Now}
+}
+
+// Issue creates a key with the given scopes that expires after ttl, or
+// never if ttl is 0. It returns the key, which cannot be retrieved again,
+// and its stored record.
+func (m *KeyManager) Issue(name string, scopes []string, ttl time.Duration) (string, *APIKey, error) {
+	return m.IssueForTenant("", name, scopes, ttl)
+}
+
+// IssueForTenant creates a key like Issue that is bound to a tenant; its
+// requests are served for that tenant only
+func (m *KeyManager) IssueForTenant(tenant, name string, scopes []string, ttl time.Duration) (string, *APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("key name is required")
+	}
+	scopes = normalizeScopes(scopes)
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("at least one scope is required")
+	}
+	if ttl < 0 {
+		return "", nil, fmt.Errorf("key lifetime must not be negative")
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return "", nil, err
+	}
+	token := KeyPrefix + id + "_" + secret
+
+	now := m.now().UTC()
+	key := &APIKey{
+		ID:        id,
+		Name:      name,
+		Hash:      hashKey(token),
+		Scopes:    scopes,
+		Tenant:    normalizeTenant(tenant),
+		CreatedAt: now,
+	}
+	if ttl > 0 {
+		expires := now.Add(ttl)
+		key.ExpiresAt = &expires
+	}
+	if err := m.store.Save(key); err != nil {
+		return "", nil, fmt.Errorf("failed to store api key: %w", err)
+	}
+	return token, key.redacted(), nil
+}
+
+// Revoke revokes a key; revoking a revoked key succeeds
+func (m *KeyManager) Revoke(id string) (*APIKey, error) {
+	key, err := m.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt == nil {
+		now := m.now().UTC()
+		key.RevokedAt = &now
+		if err := m.store.Save(key); err != nil {
+			return nil, fmt.Errorf("failed to store api key: %w", err)
+		}
+	}
+	return key.redacted(), nil
+}
+
+// Get returns a key without its hash
+func (m *KeyManager) Get(id string) (*APIKey, error) {
+	key, err := m.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return key.redacted(), nil
+}
+
+// List returns all keys without their hashes
+func (m *KeyManager) List() ([]*APIKey, error) {
+	keys, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = key.redacted()
+	}
+	return keys, nil
+}
+
+// Authenticate implements TokenAuthenticator for keys issued by Issue
+func (m *KeyManager) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	id, ok := keyID(token)
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	key, err := m.store.Get(id)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashKey(token)), []byte(key.Hash)) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrKeyRevoked
+	}
+	if !key.Active(m.now()) {
+		return nil, ErrKeyExpired
+	}
+	return &Principal{
+		Subject: key.Name,
+		KeyID:   key.ID,
+		Method:  MethodAPIKey,
+		Scopes:  append([]string(nil), key.Scopes...),
+		Tenant:  key.Tenant,
+	}, nil
+}
+
+// StaticKey authenticates a single configured key, e.g. the bootstrap
+// admin key used to issue the first keys
+type StaticKey struct {
+	Name   string
+	Key    string
+	Scopes []string
+}
+
+// Authenticate implements TokenAuthenticator
+func (k StaticKey) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if k.Key == "" || subtle.ConstantTimeCompare([]byte(hashKey(token)), []byte(hashKey(k.Key))) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	return &Principal{Subject: k.Name, Method: MethodAPIKey, Scopes: append([]string(nil), k.Scopes...)}, nil
+}
+
+// keyID returns the ID part of an issued key
+func keyID(token string) (string, bool) {
+	if !strings.HasPrefix(token, KeyPrefix) {
+		return "", false
+	}
+	id, secret, found := strings.Cut(strings.TrimPrefix(token, KeyPrefix), "_")
+	return id, found && id != "" && secret != ""
+}
+
+// hashKey returns the hex SHA-256 hash of a key
+func hashKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
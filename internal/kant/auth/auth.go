@@ -0,0 +1,126 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: Authentication of Kant API requests with API keys and JWT
+//              bearer tokens, principals, and scopes
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+// Package auth authenticates requests to the Kant API gateway. Clients send
+// an API key issued by Kant or a JWT bearer token of an identity provider,
+// validated against its JWKS or discovered through OIDC. Every request gets
+// a Principal whose scopes decide which routes it may call.
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Authentication methods of principals
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Well-known scopes
+const (
+	// ScopeAll grants access to every route
+	ScopeAll = "*"
+
+	// ScopeAdmin grants access to the admin routes, including key management
+	ScopeAdmin = "admin"
+
+	// ScopeAllTenants lets a principal without a tenant choose the tenant
+	// of its requests
+	ScopeAllTenants = "tenant:*"
+)
+
+// Errors returned by authenticators
+var (
+	ErrNoCredentials      = errors.New("no credentials provided")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrKeyRevoked         = errors.New("api key has been revoked")
+	ErrKeyExpired         = errors.New("api key has expired")
+	ErrTokenExpired       = errors.New("token has expired")
+	ErrKeyNotFound        = errors.New("api key not found")
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string   `json:"subject"`          // Key name or token subject
+	KeyID   string   `json:"key_id,omitempty"` // ID of the API key, if authenticated by key
+	Method  string   `json:"method"`           // MethodAPIKey or MethodJWT
+	Scopes  []string `json:"scopes"`
+	Tenant  string   `json:"tenant,omitempty"` // Tenant the caller is bound to; any tenant if empty
+}
+
+// HasScope reports whether the principal was granted scope, directly or
+// through ScopeAll
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for _, granted := range p.Scopes {
+		if granted == ScopeAll || strings.EqualFold(granted, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessTenant reports whether the principal may act for a tenant:
+// principals without a tenant may act for every tenant
+func (p *Principal) CanAccessTenant(tenant string) bool {
+	return p == nil || p.Tenant == "" || p.Tenant == tenant
+}
+
+// CanSelectTenant reports whether the principal may choose the tenant of
+// its requests: only principals without a tenant that were granted
+// ScopeAdmin or ScopeAllTenants
+func (p *Principal) CanSelectTenant() bool {
+	return p != nil && p.Tenant == "" && (p.HasScope(ScopeAdmin) || p.HasScope(ScopeAllTenants))
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// TokenAuthenticator validates a credential taken from a request
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// normalizeScopes trims, lowercases, and deduplicates scopes
+func normalizeScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	return result
+}
+
+// normalizeTenant trims and lowercases a tenant ID
+func normalizeTenant(tenant string) string {
+	return strings.ToLower(strings.TrimSpace(tenant))
+}
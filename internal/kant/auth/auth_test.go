@@ -0,0 +1,612 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: Unit tests for API keys, JWT validation, the middleware, and
+//              the gRPC interceptors
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signToken creates an RS256 or ES256 token for the claims
+func signToken(t *testing.T, key crypto.Signer, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	alg := "RS256"
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		alg = "ES256"
+	}
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = sig
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    "https://idp.example",
+		"sub":    "alice",
+		"aud":    []string{"mdw"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"scope":  "chat search",
+		"tenant": "Acme",
+	}
+}
+
+// jwksServer serves the public keys as JWKS
+func jwksServer(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	doc := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(doc)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// Unit Tests - API Keys
+// ============================================================================
+
+func TestKeyManager_IssueAndAuthenticate(t *testing.T) {
+	manager := NewKeyManager(NewMemoryKeyStore())
+	token, key, err := manager.Issue("ci", []string{"Chat", " search ", "chat"}, 0)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if !strings.HasPrefix(token, KeyPrefix) || key.Hash != "" {
+		t.Fatalf("Issue() = %q, %+v", token, key)
+	}
+	if strings.Join(key.Scopes, ",") != "chat,search" {
+		t.Errorf("scopes = %v, want [chat search]", key.Scopes)
+	}
+
+	principal, err := manager.Authenticate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if principal.KeyID != key.ID || principal.Method != MethodAPIKey || !principal.HasScope("search") || principal.HasScope("admin") {
+		t.Errorf("principal = %+v", principal)
+	}
+
+	// A wrong secret with a valid ID is rejected
+	forged := token[:len(token)-4] + "0000"
+	if _, err := manager.Authenticate(context.Background(), forged); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("forged key error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if _, err := manager.Revoke(key.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err := manager.Authenticate(context.Background(), token); !errors.Is(err, ErrKeyRevoked) {
+		t.Errorf("revoked key error = %v, want ErrKeyRevoked", err)
+	}
+}
+
+func TestKeyManager_Expiry(t *testing.T) {
+	manager := NewKeyManager(NewMemoryKeyStore())
+	token, _, err := manager.Issue("temp", []string{"chat"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	manager.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := manager.Authenticate(context.Background(), token); !errors.Is(err, ErrKeyExpired) {
+		t.Errorf("expired key error = %v, want ErrKeyExpired", err)
+	}
+
+	if _, _, err := manager.Issue("", []string{"chat"}, 0); err == nil {
+		t.Error("Issue() without name succeeded")
+	}
+	if _, _, err := manager.Issue("none", nil, 0); err == nil {
+		t.Error("Issue() without scopes succeeded")
+	}
+}
+
+func TestFileKeyStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "api_keys.json")
+	store, err := NewFileKeyStore(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyStore() error = %v", err)
+	}
+	token, key, err := NewKeyManager(store).Issue("ci", []string{"chat"}, 0)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	reloaded, err := NewFileKeyStore(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyStore() reload error = %v", err)
+	}
+	principal, err := NewKeyManager(reloaded).Authenticate(context.Background(), token)
+	if err != nil || principal.KeyID != key.ID {
+		t.Errorf("Authenticate() after reload = %+v, %v", principal, err)
+	}
+}
+
+// ============================================================================
+// Unit Tests - JWT
+// ============================================================================
+
+func TestJWTValidator_RemoteKeySet(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	server := jwksServer(t, rsaKey, ecKey)
+
+	validator := &JWTValidator{
+		Keys:     NewRemoteKeySet(server.URL, server.Client(), 0),
+		Issuer:   "https://idp.example",
+		Audience: "mdw",
+	}
+
+	for _, tc := range []struct {
+		name string
+		key  crypto.Signer
+		kid  string
+	}{
+		{"RS256", rsaKey, "rsa-1"},
+		{"ES256", ecKey, "ec-1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			principal, err := validator.Authenticate(context.Background(), signToken(t, tc.key, tc.kid, validClaims()))
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.Subject != "alice" || principal.Method != MethodJWT || !principal.HasScope("chat") || principal.HasScope("admin") || principal.Tenant != "acme" {
+				t.Errorf("principal = %+v", principal)
+			}
+		})
+	}
+}
+
+func TestRemoteKeySet_Unreachable(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	keys := NewRemoteKeySet(server.URL, server.Client(), 0)
+
+	// Lookups stop waiting with their request; the fetch continues
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := keys.Key(ctx, "rsa-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Key() with expired context error = %v", err)
+	}
+
+	// Concurrent lookups share the running fetch
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.Key(context.Background(), "rsa-1")
+			errs <- err
+		}()
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err == nil {
+			t.Error("Key() succeeded while the provider is down")
+		}
+	}
+
+	// Failed fetches are throttled like successful ones
+	start := time.Now()
+	if _, err := keys.Key(context.Background(), "rsa-1"); err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Errorf("Key() after failed fetch error = %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Key() after failed fetch took %v", time.Since(start))
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestJWTValidator_Rejects(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	validator := &JWTValidator{
+		Keys:     StaticKeySet{"rsa-1": &rsaKey.PublicKey},
+		Issuer:   "https://idp.example",
+		Audience: "mdw",
+	}
+
+	with := func(key, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key.(string))
+		} else {
+			claims[key.(string)] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"expired", signToken(t, rsaKey, "rsa-1", with("exp", time.Now().Add(-time.Hour).Unix())), ErrTokenExpired},
+		{"no expiry", signToken(t, rsaKey, "rsa-1", with("exp", nil)), ErrInvalidCredentials},
+		{"wrong issuer", signToken(t, rsaKey, "rsa-1", with("iss", "https://evil.example")), ErrInvalidCredentials},
+		{"wrong audience", signToken(t, rsaKey, "rsa-1", with("aud", "other")), ErrInvalidCredentials},
+		{"wrong key", signToken(t, otherKey, "rsa-1", validClaims()), ErrInvalidCredentials},
+		{"unknown kid", signToken(t, rsaKey, "rsa-2", validClaims()), ErrInvalidCredentials},
+		{"malformed", "not.a-token", ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validator.Authenticate(context.Background(), tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Unsigned tokens must never be accepted
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+	payload, _ := json.Marshal(validClaims())
+	if _, err := validator.Authenticate(context.Background(), b64(header)+"."+b64(payload)+"."); err == nil {
+		t.Error("Authenticate() accepted alg none")
+	}
+}
+
+func TestOIDCKeySet_Discovery(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	jwks := jwksServer(t, rsaKey, ecKey)
+
+	var issuer string
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != discoveryPath {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": jwks.URL})
+	}))
+	defer idp.Close()
+	issuer = idp.URL
+
+	authenticator, err := New(Config{Enabled: true, OIDCIssuer: issuer, Audience: "mdw"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	claims := validClaims()
+	claims["iss"] = issuer
+	if _, err := authenticator.Authenticate(context.Background(), signToken(t, rsaKey, "rsa-1", claims)); err != nil {
+		t.Errorf("Authenticate() error = %v", err)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Middleware and Admin Endpoints
+// ============================================================================
+
+func TestMiddleware(t *testing.T) {
+	authenticator, err := New(Config{Enabled: true, AdminKey: "bootstrap-secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	chatKey, _, err := authenticator.Keys().Issue("chat-only", []string{"chat"}, 0)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	var seen *Principal
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		want    int
+	}{
+		{"public health", http.MethodGet, "/api/v1/health/", nil, http.StatusOK},
+		{"preflight", http.MethodOptions, "/api/v1/chat", nil, http.StatusOK},
+		{"no credentials", http.MethodPost, "/api/v1/chat", nil, http.StatusUnauthorized},
+		{"invalid key", http.MethodPost, "/api/v1/chat", map[string]string{"Authorization": "Bearer mdw_00_11"}, http.StatusUnauthorized},
+		{"scoped key", http.MethodPost, "/api/v1/chat/stream", map[string]string{"Authorization": "Bearer " + chatKey}, http.StatusOK},
+		{"key header", http.MethodPost, "/api/v1/chat", map[string]string{APIKeyHeader: chatKey}, http.StatusOK},
+		{"missing scope", http.MethodPost, "/api/v1/search", map[string]string{APIKeyHeader: chatKey}, http.StatusForbidden},
+		{"admin key", http.MethodGet, "/api/v1/admin/overview", map[string]string{"Authorization": "Bearer bootstrap-secret"}, http.StatusOK},
+		{"websocket query token", http.MethodGet, "/api/v1/chat/ws?access_token=" + chatKey, map[string]string{"Upgrade": "websocket"}, http.StatusOK},
+		{"query token without upgrade", http.MethodGet, "/api/v1/chat/ws?access_token=" + chatKey, nil, http.StatusUnauthorized},
+		{"multiplexed stream", http.MethodGet, "/api/v1/ws?access_token=" + chatKey, map[string]string{"Upgrade": "websocket"}, http.StatusOK},
+		{"multiplexed stream without credentials", http.MethodGet, "/api/v1/ws", map[string]string{"Upgrade": "websocket"}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	// The principal is passed to the handler
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/chat", nil)
+	req.Header.Set(APIKeyHeader, chatKey)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if seen == nil || seen.Subject != "chat-only" {
+		t.Errorf("principal in handler = %+v", seen)
+	}
+}
+
+func TestMiddleware_Disabled(t *testing.T) {
+	authenticator, err := New(Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/chat", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
+
+func TestAdminHandler_KeyLifecycle(t *testing.T) {
+	authenticator, err := New(Config{Enabled: true, AdminKey: "bootstrap-secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(KeysPath, authenticator.AdminHandler())
+	mux.Handle(KeysPath+"/", authenticator.AdminHandler())
+	mux.Handle("/api/v1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler := authenticator.Middleware(mux)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, KeysPath, "bootstrap-secret", `{"name":"ui","scopes":["chat","search"],"expires_in":"720h"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("issue status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var issued IssueKeyResponse
+	json.NewDecoder(rec.Body).Decode(&issued)
+	if issued.Key == "" || issued.APIKey.ExpiresAt == nil {
+		t.Fatalf("issued = %+v", issued)
+	}
+
+	// The issued key may chat but not manage keys
+	if rec := do(http.MethodGet, "/api/v1/chat", issued.Key, ""); rec.Code != http.StatusOK {
+		t.Errorf("chat with issued key status = %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, KeysPath, issued.Key, ""); rec.Code != http.StatusForbidden {
+		t.Errorf("list with issued key status = %d, want 403", rec.Code)
+	}
+
+	rec = do(http.MethodGet, KeysPath, "bootstrap-secret", "")
+	var list KeysResponse
+	json.NewDecoder(rec.Body).Decode(&list)
+	if list.Total != 1 || list.Keys[0].Hash != "" {
+		t.Errorf("list = %+v", list)
+	}
+
+	if rec := do(http.MethodDelete, KeysPath+"/"+issued.APIKey.ID, "bootstrap-secret", ""); rec.Code != http.StatusOK {
+		t.Fatalf("revoke status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodGet, "/api/v1/chat", issued.Key, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("chat with revoked key status = %d, want 401", rec.Code)
+	}
+	if rec := do(http.MethodDelete, KeysPath+"/unknown", "bootstrap-secret", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("revoke unknown key status = %d, want 404", rec.Code)
+	}
+	if rec := do(http.MethodPost, KeysPath, "bootstrap-secret", `{"name":"bad","scopes":["chat"],"expires_in":"soon"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid expires_in status = %d, want 400", rec.Code)
+	}
+}
+
+func TestAdminHandler_TenantKeys(t *testing.T) {
+	authenticator, err := New(Config{Enabled: true, AdminKey: "bootstrap-secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	handler := authenticator.Middleware(authenticator.AdminHandler())
+	acmeAdmin, _, err := authenticator.Keys().IssueForTenant("ACME", "acme-admin", []string{"admin"}, 0)
+	if err != nil {
+		t.Fatalf("IssueForTenant() error = %v", err)
+	}
+	_, other, err := authenticator.Keys().IssueForTenant("globex", "globex-ui", []string{"chat"}, 0)
+	if err != nil {
+		t.Fatalf("IssueForTenant() error = %v", err)
+	}
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(APIKeyHeader, acmeAdmin)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Keys issued by a tenant admin are bound to its tenant
+	rec := do(http.MethodPost, KeysPath, `{"name":"ui","scopes":["chat"]}`)
+	var issued IssueKeyResponse
+	json.NewDecoder(rec.Body).Decode(&issued)
+	if rec.Code != http.StatusCreated || issued.APIKey.Tenant != "acme" {
+		t.Fatalf("issue = %d %+v", rec.Code, issued.APIKey)
+	}
+	principal, err := authenticator.Authenticate(context.Background(), issued.Key)
+	if err != nil || principal.Tenant != "acme" {
+		t.Errorf("principal = %+v, %v", principal, err)
+	}
+	if rec := do(http.MethodPost, KeysPath, `{"name":"ui","scopes":["chat"],"tenant":"globex"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("issue for other tenant status = %d, want 403", rec.Code)
+	}
+
+	// Keys of other tenants are invisible
+	var list KeysResponse
+	json.NewDecoder(do(http.MethodGet, KeysPath, "").Body).Decode(&list)
+	if list.Total != 2 {
+		t.Errorf("list total = %d, want 2", list.Total)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		if rec := do(method, KeysPath+"/"+other.ID, ""); rec.Code != http.StatusNotFound {
+			t.Errorf("%s other tenant's key status = %d, want 404", method, rec.Code)
+		}
+	}
+}
+
+// ============================================================================
+// Unit Tests - gRPC Interceptors
+// ============================================================================
+
+// fakeServerStream is a server stream that only carries a context
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestServerInterceptors(t *testing.T) {
+	authenticator, err := New(Config{Enabled: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	chatKey, _, err := authenticator.Keys().Issue("chat-only", []string{"chat"}, 0)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	scopeOf := func(method string) (string, bool) {
+		switch method {
+		case "/mdw.kant.KantService/HealthCheck":
+			return "", true
+		case "/mdw.kant.KantService/Search":
+			return "search", false
+		default:
+			return "chat", false
+		}
+	}
+
+	var seen *Principal
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, _ = FromContext(ctx)
+		return nil, nil
+	}
+	unary := authenticator.UnaryServerInterceptor(scopeOf)
+
+	tests := []struct {
+		name   string
+		method string
+		md     metadata.MD
+		want   codes.Code
+	}{
+		{"public", "/mdw.kant.KantService/HealthCheck", nil, codes.OK},
+		{"no credentials", "/mdw.kant.KantService/Chat", nil, codes.Unauthenticated},
+		{"invalid key", "/mdw.kant.KantService/Chat", metadata.Pairs("authorization", "Bearer mdw_00_11"), codes.Unauthenticated},
+		{"bearer", "/mdw.kant.KantService/Chat", metadata.Pairs("authorization", "Bearer "+chatKey), codes.OK},
+		{"key metadata", "/mdw.kant.KantService/Chat", metadata.Pairs(APIKeyMetadata, chatKey), codes.OK},
+		{"missing scope", "/mdw.kant.KantService/Search", metadata.Pairs(APIKeyMetadata, chatKey), codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+
+	// The principal is passed to the handler of streams
+	seen = nil
+	ss := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyMetadata, chatKey))}
+	err = authenticator.StreamServerInterceptor(scopeOf)(nil, ss, &grpc.StreamServerInfo{FullMethod: "/mdw.kant.KantService/StreamChat"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			seen, _ = FromContext(stream.Context())
+			return nil
+		})
+	if err != nil || seen == nil || seen.Subject != "chat-only" {
+		t.Errorf("stream = %v, principal %+v", err, seen)
+	}
+
+	// Without authentication calls are passed through
+	disabled, _ := New(Config{})
+	_, err = disabled.UnaryServerInterceptor(scopeOf)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/mdw.kant.KantService/Chat"}, handler)
+	if err != nil {
+		t.Errorf("disabled error = %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: gRPC server interceptors that authenticate gateway calls and
+//              check the scope of the called method
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyMetadata is the metadata key clients may send an API key in
+// instead of the authorization metadata
+const APIKeyMetadata = "x-api-key"
+
+// MethodScope returns the scope a gRPC method requires and whether the
+// method is served without credentials
+type MethodScope func(fullMethod string) (scope string, public bool)
+
+// UnaryServerInterceptor authenticates unary gRPC calls like the HTTP
+// middleware: methods that are not public need credentials with the scope
+// returned by scopeOf. If authentication is disabled, calls are passed
+// through unchanged.
+func (a *Authenticator) UnaryServerInterceptor(scopeOf MethodScope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !a.config.Enabled {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authorizeCall(ctx, info.FullMethod, scopeOf)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming gRPC calls like
+// UnaryServerInterceptor
+func (a *Authenticator) StreamServerInterceptor(scopeOf MethodScope) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !a.config.Enabled {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authorizeCall(ss.Context(), info.FullMethod, scopeOf)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorizeCall authenticates a gRPC call and returns its context with the
+// principal. Without scopeOf every method needs credentials but no scope.
+func (a *Authenticator) authorizeCall(ctx context.Context, method string, scopeOf MethodScope) (context.Context, error) {
+	var scope string
+	if scopeOf != nil {
+		var public bool
+		if scope, public = scopeOf(method); public {
+			return ctx, nil
+		}
+	}
+
+	principal, err := a.authorize(ctx, metadataCredentials(ctx), scope, method, peerAddr(ctx))
+	var scopeErr *ScopeError
+	switch {
+	case errors.Is(err, ErrNoCredentials):
+		return nil, status.Error(codes.Unauthenticated, "authentication required: "+err.Error())
+	case errors.As(err, &scopeErr):
+		return nil, status.Error(codes.PermissionDenied, "access denied: "+err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, "authentication failed: "+failureReason(err))
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// metadataCredentials returns the token of a gRPC call: a bearer token in
+// the authorization metadata or the API key metadata
+func metadataCredentials(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, found := strings.Cut(values[0], " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	if values := md.Get(APIKeyMetadata); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// peerAddr returns the remote address of a gRPC call
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// serverStream replaces the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,415 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: Validation of JWT bearer tokens signed with RSA or ECDSA keys
+//              published as a JSON Web Key Set (JWKS)
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for crypto.SHA256
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of the JWKS cache
+const (
+	DefaultJWKSRefresh     = time.Hour
+	minJWKSRefetchInterval = 30 * time.Second
+	jwksFetchTimeout       = 10 * time.Second
+)
+
+// signingMethods maps the supported JWS algorithms to their hash. Symmetric
+// algorithms and "none" are rejected.
+var signingMethods = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+// Claims are the registered, scope, and tenant claims of a token
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+	Scope     string   `json:"scope"` // Space-separated scopes (OAuth 2.0)
+	Scp       audience `json:"scp"`   // Scope list used by some providers
+	Tenant    string   `json:"tenant"`
+}
+
+// audience accepts a single string or a list of strings
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = strings.Fields(single)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// scopes returns the scopes granted by the scope and scp claims
+func (c *Claims) scopes() []string {
+	return normalizeScopes(append(strings.Fields(c.Scope), c.Scp...))
+}
+
+// KeySet resolves the public key a token was signed with
+type KeySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// JWTValidator validates bearer tokens and maps their claims to principals
+type JWTValidator struct {
+	Keys     KeySet
+	Issuer   string        // Required iss claim (optional)
+	Audience string        // Required member of the aud claim (optional)
+	Leeway   time.Duration // Tolerated clock skew for exp and nbf
+
+	now func() time.Time
+}
+
+// Authenticate implements TokenAuthenticator
+func (v *JWTValidator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	claims, err := v.Validate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{
+		Subject: claims.Subject,
+		Method:  MethodJWT,
+		Scopes:  claims.scopes(),
+		Tenant:  normalizeTenant(claims.Tenant),
+	}, nil
+}
+
+// Validate verifies the signature and the time, issuer, and audience
+// claims of a token and returns its claims
+func (v *JWTValidator) Validate(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed token header", ErrInvalidCredentials)
+	}
+	hash, supported := signingMethods[header.Alg]
+	if !supported {
+		return nil, fmt.Errorf("%w: unsupported signing algorithm %q", ErrInvalidCredentials, header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token signature", ErrInvalidCredentials)
+	}
+
+	key, err := v.Keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if err := verifySignature(header.Alg, hash, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed token claims", ErrInvalidCredentials)
+	}
+	return &claims, v.checkClaims(&claims)
+}
+
+// checkClaims checks the time, issuer, and audience claims
+func (v *JWTValidator) checkClaims(claims *Claims) error {
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: token has no expiry", ErrInvalidCredentials)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(v.Leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(v.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidCredentials)
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidCredentials, claims.Issuer)
+	}
+	if v.Audience != "" {
+		for _, aud := range claims.Audience {
+			if aud == v.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: token is not issued for %q", ErrInvalidCredentials, v.Audience)
+	}
+	return nil
+}
+
+// verifySignature verifies an RSA PKCS #1 v1.5 or ECDSA signature
+func verifySignature(alg string, hash crypto.Hash, key crypto.PublicKey, signed string, signature []byte) error {
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("key type does not match algorithm %s", alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("key type does not match algorithm %s", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ============================================================================
+// JSON Web Key Sets
+// ============================================================================
+
+// StaticKeySet is a fixed set of keys by key ID
+type StaticKeySet map[string]crypto.PublicKey
+
+// Key implements KeySet; a set with one key serves tokens without kid
+func (s StaticKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(s) == 1 {
+		for _, key := range s {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// RemoteKeySet fetches a JWKS document over HTTP and caches its keys. An
+// unknown key ID triggers a refetch, so rotated keys are picked up, but
+// not more often than every 30 seconds, whether the fetch succeeds or not.
+// Concurrent lookups share one fetch, which outlives the requests waiting
+// for it.
+type RemoteKeySet struct {
+	url     string
+	client  *http.Client
+	refresh time.Duration
+
+	mu          sync.Mutex
+	keys        StaticKeySet
+	fetchedAt   time.Time     // Of the last successful fetch
+	attemptedAt time.Time     // Of the last fetch, successful or not
+	lastErr     error         // Of the last fetch
+	fetching    chan struct{} // Closed when the running fetch ends
+}
+
+// NewRemoteKeySet creates a key set for a JWKS URL; its keys are refetched
+// after refresh (DefaultJWKSRefresh if 0)
+func NewRemoteKeySet(url string, client *http.Client, refresh time.Duration) *RemoteKeySet {
+	if client == nil {
+		client = &http.Client{Timeout: jwksFetchTimeout}
+	}
+	if refresh <= 0 {
+		refresh = DefaultJWKSRefresh
+	}
+	return &RemoteKeySet{url: url, client: client, refresh: refresh}
+}
+
+// Key implements KeySet
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	for fetched := false; ; fetched = true {
+		s.mu.Lock()
+		key, err := s.cached(ctx, kid)
+		if err == nil && time.Since(s.fetchedAt) <= s.refresh {
+			s.mu.Unlock()
+			return key, nil
+		}
+		// Keep serving the cached keys while the provider is unreachable
+		if fetched || (s.fetching == nil && time.Since(s.attemptedAt) < minJWKSRefetchInterval) {
+			if err != nil && s.keys == nil && s.lastErr != nil {
+				err = s.lastErr
+			}
+			s.mu.Unlock()
+			return key, err
+		}
+		if s.fetching == nil {
+			s.fetching = make(chan struct{})
+			s.attemptedAt = time.Now()
+			go s.fetch(s.fetching)
+		}
+		done := s.fetching
+		s.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// cached returns a key of the cached set; the caller holds the mutex
+func (s *RemoteKeySet) cached(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if s.keys == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return s.keys.Key(ctx, kid)
+}
+
+// fetch fetches the keys and signals the waiting lookups by closing done.
+// It is not bound to the request that started it, so the result serves
+// all lookups.
+func (s *RemoteKeySet) fetch(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	keys, err := fetchJWKS(ctx, s.client, s.url)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		s.keys, s.fetchedAt = keys, time.Now()
+	}
+	s.lastErr = err
+	s.fetching = nil
+	close(done)
+}
+
+// jwk is a JSON Web Key with the members of RSA and EC public keys
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchJWKS downloads and parses a JWKS document. Keys that are not for
+// signatures or of unsupported types are skipped.
+func fetchJWKS(ctx context.Context, client *http.Client, url string) (StaticKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(StaticKeySet, len(doc.Keys))
+	for _, key := range doc.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if pub, err := key.publicKey(); err == nil {
+			keys[key.Kid] = pub
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+// publicKey converts the JWK to an RSA or ECDSA public key
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, errors.New("invalid EC key")
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("EC key is not on its curve")
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
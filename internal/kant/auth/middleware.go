@@ -0,0 +1,268 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: HTTP middleware that authenticates API requests and checks
+//              the scope of the requested route
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/httpx"
+	"github.com/msto63/mDW/pkg/core/logging"
+)
+
+// APIKeyHeader is the header clients may send an API key in instead of the
+// Authorization header
+const APIKeyHeader = "X-API-Key"
+
+// DefaultPublicPaths are served without credentials
+var DefaultPublicPaths = []string{"/", "/health", "/api/v1", "/api/v1/health"}
+
+// Config configures authentication of the gateway
+type Config struct {
+	// Enabled turns authentication on; without it every request is served
+	Enabled bool
+
+	// PublicPaths are served without credentials (DefaultPublicPaths if empty)
+	PublicPaths []string
+
+	// AdminKey is a static key with all scopes to issue the first API keys
+	AdminKey string
+
+	// KeyFile stores the issued API keys; keys are kept in memory if empty
+	KeyFile string
+
+	// JWKSURL is the key set JWT bearer tokens are validated against
+	JWKSURL string
+
+	// OIDCIssuer discovers the key set of an OpenID Connect provider and
+	// requires its tokens to be issued by it; it replaces JWKSURL
+	OIDCIssuer string
+
+	// Issuer and Audience are required in JWT claims if set
+	Issuer   string
+	Audience string
+
+	// JWKSRefresh is how long fetched keys are cached (DefaultJWKSRefresh if 0)
+	JWKSRefresh time.Duration
+}
+
+// Authenticator authenticates requests with API keys and, if configured,
+// JWT bearer tokens
+type Authenticator struct {
+	config Config
+	keys   *KeyManager
+	admin  TokenAuthenticator
+	jwt    TokenAuthenticator
+	public map[string]bool
+	logger *logging.Logger
+}
+
+// New creates an authenticator for the configuration
+func New(cfg Config) (*Authenticator, error) {
+	var store KeyStore = NewMemoryKeyStore()
+	if cfg.KeyFile != "" {
+		fileStore, err := NewFileKeyStore(cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+
+	a := &Authenticator{
+		config: cfg,
+		keys:   NewKeyManager(store),
+		public: make(map[string]bool),
+		logger: logging.New("kant-auth"),
+	}
+	if cfg.AdminKey != "" {
+		a.admin = StaticKey{Name: "admin", Key: cfg.AdminKey, Scopes: []string{ScopeAll}}
+	}
+
+	switch {
+	case cfg.OIDCIssuer != "":
+		issuer := cfg.Issuer
+		if issuer == "" {
+			issuer = cfg.OIDCIssuer
+		}
+		a.jwt = &JWTValidator{
+			Keys:     NewOIDCKeySet(cfg.OIDCIssuer, nil, cfg.JWKSRefresh),
+			Issuer:   issuer,
+			Audience: cfg.Audience,
+			Leeway:   time.Minute,
+		}
+	case cfg.JWKSURL != "":
+		a.jwt = &JWTValidator{
+			Keys:     NewRemoteKeySet(cfg.JWKSURL, nil, cfg.JWKSRefresh),
+			Issuer:   cfg.Issuer,
+			Audience: cfg.Audience,
+			Leeway:   time.Minute,
+		}
+	}
+
+	publicPaths := cfg.PublicPaths
+	if len(publicPaths) == 0 {
+		publicPaths = DefaultPublicPaths
+	}
+	for _, path := range publicPaths {
+		a.public[httpx.NormalizePath(path)] = true
+	}
+	return a, nil
+}
+
+// Keys returns the key manager of the issued API keys
+func (a *Authenticator) Keys() *KeyManager {
+	return a.keys
+}
+
+// Authenticate authenticates a token: keys with KeyPrefix against the
+// issued keys and the admin key, any other token as JWT
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if strings.HasPrefix(token, KeyPrefix) {
+		principal, err := a.keys.Authenticate(ctx, token)
+		if errors.Is(err, ErrInvalidCredentials) && a.admin != nil {
+			return a.admin.Authenticate(ctx, token)
+		}
+		return principal, err
+	}
+	if a.admin != nil {
+		if principal, err := a.admin.Authenticate(ctx, token); err == nil {
+			return principal, nil
+		}
+	}
+	if a.jwt != nil {
+		return a.jwt.Authenticate(ctx, token)
+	}
+	return nil, ErrInvalidCredentials
+}
+
+// Middleware authenticates every request that is not public and rejects
+// it unless the principal has the scope of the route. If authentication
+// is disabled, requests are passed through unchanged.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	if !a.config.Enabled {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS preflight requests carry no credentials
+		if r.Method == http.MethodOptions || a.public[httpx.NormalizePath(r.URL.Path)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := a.authorize(r.Context(), credentials(r), RequiredScope(r.URL.Path), r.URL.Path, r.RemoteAddr)
+		var scopeErr *ScopeError
+		switch {
+		case errors.Is(err, ErrNoCredentials):
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Authentication required", err.Error())
+			return
+		case errors.As(err, &scopeErr):
+			writeError(w, http.StatusForbidden, "forbidden", "Access denied", err.Error())
+			return
+		case err != nil:
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Authentication failed", failureReason(err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// ScopeError rejects a principal that lacks the scope of a call
+type ScopeError struct {
+	Scope string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("scope %q required", e.Scope)
+}
+
+// authorize authenticates the token of a call to target and checks that
+// the principal has the scope, if any. Failures are logged; calls without
+// a token fail with ErrNoCredentials and principals without the scope with
+// a ScopeError.
+func (a *Authenticator) authorize(ctx context.Context, token, scope, target, remote string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	principal, err := a.Authenticate(ctx, token)
+	if err != nil {
+		a.logger.Warn("Authentication failed", "path", target, "remote", remote, "error", err)
+		return nil, err
+	}
+	if scope != "" && !principal.HasScope(scope) {
+		a.logger.Warn("Access denied", "path", target, "subject", principal.Subject, "scope", scope)
+		return nil, &ScopeError{Scope: scope}
+	}
+	return principal, nil
+}
+
+// multiplexedRoutes carry the requests of several routes, like the
+// streaming WebSocket; they check the scope of every request themselves
+var multiplexedRoutes = map[string]bool{"ws": true}
+
+// RequiredScope returns the scope of an API route, which is its first path
+// segment below /api/v1, e.g. "chat" for /api/v1/chat/stream. Multiplexed
+// routes need no scope to connect.
+func RequiredScope(path string) string {
+	path = strings.TrimPrefix(path, "/api/v1")
+	path = strings.TrimPrefix(path, "/")
+	scope, _, _ := strings.Cut(path, "/")
+	scope = strings.ToLower(scope)
+	if multiplexedRoutes[scope] {
+		return ""
+	}
+	return scope
+}
+
+// credentials returns the token of a request: a bearer token, the API key
+// header, or, for WebSocket handshakes that cannot set headers in
+// browsers, the access_token query parameter
+func credentials(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return strings.TrimSpace(key)
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// failureReason returns the reason of a failed authentication that may be
+// shown to clients; details of invalid tokens are only logged
+func failureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrKeyRevoked), errors.Is(err, ErrKeyExpired), errors.Is(err, ErrTokenExpired):
+		return err.Error()
+	default:
+		return ErrInvalidCredentials.Error()
+	}
+}
+
+// writeError writes an error response that challenges unauthenticated
+// clients for a bearer token
+func writeError(w http.ResponseWriter, status int, code, message, details string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mdw"`)
+	}
+	httpx.WriteError(w, status, code, message, details)
+}
@@ -0,0 +1,99 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     auth
+// Description: OpenID Connect discovery of the issuer and JWKS of an
+//              identity provider
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// discoveryPath is the path of the OIDC discovery document below the issuer
+const discoveryPath = "/.well-known/openid-configuration"
+
+// OIDCKeySet resolves the signing keys of an OpenID Connect provider. The
+// discovery document is fetched on first use, so the gateway starts even
+// if the provider is unreachable; a failed discovery is retried with the
+// next token.
+type OIDCKeySet struct {
+	issuer  string
+	client  *http.Client
+	refresh time.Duration
+
+	mu   sync.Mutex
+	keys *RemoteKeySet
+}
+
+// NewOIDCKeySet creates a key set for the provider at issuer
+func NewOIDCKeySet(issuer string, client *http.Client, refresh time.Duration) *OIDCKeySet {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCKeySet{issuer: strings.TrimSuffix(issuer, "/"), client: client, refresh: refresh}
+}
+
+// Key implements KeySet
+func (s *OIDCKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	keys, err := s.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return keys.Key(ctx, kid)
+}
+
+// discover fetches the discovery document once and returns the key set of
+// its jwks_uri
+func (s *OIDCKeySet) discover(ctx context.Context) (*RemoteKeySet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys != nil {
+		return s.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery failed: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC discovery document: %w", err)
+	}
+	// The provider must identify itself with the configured issuer, so a
+	// spoofed document cannot redirect key lookups
+	if strings.TrimSuffix(doc.Issuer, "/") != s.issuer {
+		return nil, fmt.Errorf("OIDC discovery returned issuer %q, expected %q", doc.Issuer, s.issuer)
+	}
+	if doc.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document has no jwks_uri")
+	}
+
+	s.keys = NewRemoteKeySet(doc.JWKSURI, s.client, s.refresh)
+	return s.keys, nil
+}
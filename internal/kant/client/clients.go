@@ -8,17 +8,24 @@ import (
 
 	aristotelepb "github.com/msto63/mDW/api/gen/aristoteles"
 	babbagepb "github.com/msto63/mDW/api/gen/babbage"
+	bayespb "github.com/msto63/mDW/api/gen/bayes"
+	"github.com/msto63/mDW/api/gen/common"
 	hypatiapb "github.com/msto63/mDW/api/gen/hypatia"
 	leibnizpb "github.com/msto63/mDW/api/gen/leibniz"
 	platonpb "github.com/msto63/mDW/api/gen/platon"
 	russellpb "github.com/msto63/mDW/api/gen/russell"
 	turingpb "github.com/msto63/mDW/api/gen/turing"
+	"github.com/msto63/mDW/internal/kant/tenant"
 	"github.com/msto63/mDW/pkg/core/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// ServiceClients manages gRPC client connections to all services
+// ServiceNames are the names of the backend services
+var ServiceNames = []string{"russell", "turing", "hypatia", "leibniz", "babbage", "platon", "aristoteles", "bayes"}
+
+// ServiceClients manages gRPC client connections to all services. Calls
+// carry the tenant of their context to the services as metadata.
 type ServiceClients struct {
 	mu     sync.RWMutex
 	logger *logging.Logger
@@ -31,6 +38,7 @@ type ServiceClients struct {
 	babbageAddr     string
 	platonAddr      string
 	aristotelesAddr string
+	bayesAddr       string
 
 	// gRPC connections
 	russellConn     *grpc.ClientConn
@@ -40,6 +48,7 @@ type ServiceClients struct {
 	babbageConn     *grpc.ClientConn
 	platonConn      *grpc.ClientConn
 	aristotelesConn *grpc.ClientConn
+	bayesConn       *grpc.ClientConn
 
 	// Service clients
 	Russell     russellpb.RussellServiceClient
@@ -49,6 +58,7 @@ type ServiceClients struct {
 	Babbage     babbagepb.BabbageServiceClient
 	Platon      platonpb.PlatonServiceClient
 	Aristoteles aristotelepb.AristotelesServiceClient
+	Bayes       bayespb.BayesServiceClient
 }
 
 // Config holds client configuration
@@ -60,6 +70,7 @@ type Config struct {
 	BabbageAddr     string
 	PlatonAddr      string
 	AristotelesAddr string
+	BayesAddr       string
 }
 
 // DefaultConfig returns default client configuration
@@ -72,6 +83,7 @@ func DefaultConfig() Config {
 		BabbageAddr:     "localhost:9150",
 		PlatonAddr:      "localhost:9130",
 		AristotelesAddr: "localhost:9160",
+		BayesAddr:       "localhost:9120",
 	}
 }
 
@@ -86,6 +98,7 @@ func NewServiceClients(cfg Config) *ServiceClients {
 		babbageAddr:     cfg.BabbageAddr,
 		platonAddr:      cfg.PlatonAddr,
 		aristotelesAddr: cfg.AristotelesAddr,
+		bayesAddr:       cfg.BayesAddr,
 	}
 }
 
@@ -97,6 +110,8 @@ func (c *ServiceClients) Connect(ctx context.Context) error {
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
+		grpc.WithChainUnaryInterceptor(tenant.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tenant.StreamClientInterceptor()),
 	}
 
 	var err error
@@ -179,6 +194,17 @@ func (c *ServiceClients) Connect(ctx context.Context) error {
 		c.Aristoteles = aristotelepb.NewAristotelesServiceClient(c.aristotelesConn)
 	}
 
+	// Connect to Bayes (Logging)
+	c.logger.Info("Connecting to Bayes", "addr", c.bayesAddr)
+	connectCtx, cancel = context.WithTimeout(ctx, timeout)
+	c.bayesConn, err = grpc.DialContext(connectCtx, c.bayesAddr, opts...)
+	cancel()
+	if err != nil {
+		c.logger.Warn("Failed to connect to Bayes", "error", err)
+	} else {
+		c.Bayes = bayespb.NewBayesServiceClient(c.bayesConn)
+	}
+
 	c.logger.Info("Service client connections initialized")
 	return nil
 }
@@ -190,6 +216,8 @@ func (c *ServiceClients) ConnectLazy() error {
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tenant.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tenant.StreamClientInterceptor()),
 	}
 
 	var err error
@@ -243,6 +271,13 @@ func (c *ServiceClients) ConnectLazy() error {
 	}
 	c.Aristoteles = aristotelepb.NewAristotelesServiceClient(c.aristotelesConn)
 
+	// Connect to Bayes
+	c.bayesConn, err = grpc.Dial(c.bayesAddr, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to dial bayes: %w", err)
+	}
+	c.Bayes = bayespb.NewBayesServiceClient(c.bayesConn)
+
 	c.logger.Info("Service client connections initialized (lazy)")
 	return nil
 }
@@ -289,6 +324,11 @@ func (c *ServiceClients) Close() error {
 			errs = append(errs, err)
 		}
 	}
+	if c.bayesConn != nil {
+		if err := c.bayesConn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing connections: %v", errs)
@@ -316,6 +356,8 @@ func (c *ServiceClients) IsConnected(service string) bool {
 		return c.Platon != nil
 	case "aristoteles":
 		return c.Aristoteles != nil
+	case "bayes":
+		return c.Bayes != nil
 	default:
 		return false
 	}
@@ -370,5 +412,57 @@ func (c *ServiceClients) GetServiceStatus() map[string]string {
 		status["aristoteles"] = "disconnected"
 	}
 
+	if c.Bayes != nil {
+		status["bayes"] = "connected"
+	} else {
+		status["bayes"] = "disconnected"
+	}
+
 	return status
 }
+
+// healthChecker is the health check every backend service implements
+type healthChecker interface {
+	HealthCheck(ctx context.Context, in *common.HealthCheckRequest, opts ...grpc.CallOption) (*common.HealthCheckResponse, error)
+}
+
+// HealthCheck calls the health check of a service. It fails if the service
+// is not connected, does not answer, or reports itself unhealthy.
+func (c *ServiceClients) HealthCheck(ctx context.Context, service string) error {
+	c.mu.RLock()
+	var checker healthChecker
+	switch service {
+	case "russell":
+		checker = c.Russell
+	case "turing":
+		checker = c.Turing
+	case "hypatia":
+		checker = c.Hypatia
+	case "leibniz":
+		checker = c.Leibniz
+	case "babbage":
+		checker = c.Babbage
+	case "platon":
+		checker = c.Platon
+	case "aristoteles":
+		checker = c.Aristoteles
+	case "bayes":
+		checker = c.Bayes
+	default:
+		c.mu.RUnlock()
+		return fmt.Errorf("unknown service: %s", service)
+	}
+	c.mu.RUnlock()
+
+	if checker == nil {
+		return fmt.Errorf("%s not connected", service)
+	}
+	resp, err := checker.HealthCheck(ctx, &common.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Status == "unhealthy" {
+		return fmt.Errorf("%s reports itself unhealthy", service)
+	}
+	return nil
+}
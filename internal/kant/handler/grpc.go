@@ -0,0 +1,290 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Native gRPC gateway service for chat, search, and agents
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/msto63/mDW/api/gen/common"
+	hypatiapb "github.com/msto63/mDW/api/gen/hypatia"
+	kantpb "github.com/msto63/mDW/api/gen/kant"
+	leibnizpb "github.com/msto63/mDW/api/gen/leibniz"
+	turingpb "github.com/msto63/mDW/api/gen/turing"
+	"github.com/msto63/mDW/internal/kant/audit"
+	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/probe"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure GatewayService implements KantServiceServer
+var _ kantpb.KantServiceServer = (*GatewayService)(nil)
+
+// GatewayService serves the gateway methods over gRPC. It takes the
+// messages of the backend services and calls them with the same routes,
+// tenant namespacing, and token accounting as the REST API; the server's
+// interceptors authenticate, resolve the tenant, and audit the calls.
+type GatewayService struct {
+	kantpb.UnimplementedKantServiceServer
+	clients   *client.ServiceClients
+	proxy     *proxy.Proxy
+	prober    *probe.Prober
+	startTime time.Time
+	version   string
+}
+
+// NewGatewayService creates the gRPC gateway service that calls the
+// backend services with the timeouts and retries of the proxy and reports
+// their health from the prober
+func NewGatewayService(version string, clients *client.ServiceClients, px *proxy.Proxy, prober *probe.Prober) *GatewayService {
+	return &GatewayService{
+		clients:   clients,
+		proxy:     px,
+		prober:    prober,
+		startTime: time.Now(),
+		version:   version,
+	}
+}
+
+// GatewayMethodScope returns the scope of a gateway method like
+// auth.RequiredScope does for REST routes; the health check is public
+func GatewayMethodScope(fullMethod string) (string, bool) {
+	switch fullMethod {
+	case kantpb.KantService_Chat_FullMethodName, kantpb.KantService_StreamChat_FullMethodName:
+		return "chat", false
+	case kantpb.KantService_Search_FullMethodName, kantpb.KantService_HybridSearch_FullMethodName:
+		return "search", false
+	case kantpb.KantService_ExecuteAgent_FullMethodName, kantpb.KantService_StreamAgent_FullMethodName:
+		return "agent", false
+	case kantpb.KantService_HealthCheck_FullMethodName:
+		return "", true
+	default:
+		// Other services of the listener, like reflection, need credentials
+		return "", false
+	}
+}
+
+// GatewayMethodPublic reports whether a gateway method is served without
+// credentials and tenant
+func GatewayMethodPublic(fullMethod string) bool {
+	_, public := GatewayMethodScope(fullMethod)
+	return public
+}
+
+// Chat handles chat requests
+func (s *GatewayService) Chat(ctx context.Context, req *turingpb.ChatRequest) (*turingpb.ChatResponse, error) {
+	if s.clients.Turing == nil {
+		return nil, unavailable("turing")
+	}
+	if len(req.Messages) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "messages are required")
+	}
+	conversationID, err := tenant.Scope(ctx, req.ConversationId)
+	if err != nil {
+		return nil, scopeError(err)
+	}
+	req.ConversationId = conversationID
+
+	resp, err := proxy.Do(ctx, s.proxy, proxy.RouteChat, proxy.Unary(s.clients.Turing.Chat, req))
+	if err != nil {
+		return nil, backendError(err)
+	}
+	audit.AddTokens(ctx, audit.Tokens{
+		Prompt:     int(resp.PromptTokens),
+		Completion: int(resp.CompletionTokens),
+		Total:      int(resp.TotalTokens),
+	})
+	resp.ConversationId = localName(ctx, resp.ConversationId)
+	return resp, nil
+}
+
+// StreamChat handles streaming chat requests
+func (s *GatewayService) StreamChat(req *turingpb.ChatRequest, stream kantpb.KantService_StreamChatServer) error {
+	if s.clients.Turing == nil {
+		return unavailable("turing")
+	}
+	if len(req.Messages) == 0 {
+		return status.Error(codes.InvalidArgument, "messages are required")
+	}
+	ctx, cancel := s.proxy.Context(stream.Context(), proxy.RouteChatStream)
+	defer cancel()
+	conversationID, err := tenant.Scope(ctx, req.ConversationId)
+	if err != nil {
+		return scopeError(err)
+	}
+	req.ConversationId = conversationID
+
+	backend, err := s.clients.Turing.StreamChat(ctx, req)
+	if err != nil {
+		return backendError(err)
+	}
+	for {
+		chunk, err := backend.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return backendError(streamError(ctx, err))
+		}
+		audit.AddTokens(ctx, audit.Tokens{Prompt: int(chunk.PromptTokens), Completion: int(chunk.CompletionTokens)})
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// Search handles semantic search requests in the collections of the
+// caller's tenant
+func (s *GatewayService) Search(ctx context.Context, req *hypatiapb.SearchRequest) (*hypatiapb.SearchResponse, error) {
+	if s.clients.Hypatia == nil {
+		return nil, unavailable("hypatia")
+	}
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	collection, err := collectionName(ctx, req.Collection)
+	if err != nil {
+		return nil, scopeError(err)
+	}
+	req.Collection = collection
+
+	resp, err := proxy.Do(ctx, s.proxy, proxy.RouteSearch, proxy.Unary(s.clients.Hypatia.Search, req))
+	if err != nil {
+		return nil, backendError(err)
+	}
+	return resp, nil
+}
+
+// HybridSearch handles hybrid search requests in the collections of the
+// caller's tenant
+func (s *GatewayService) HybridSearch(ctx context.Context, req *hypatiapb.HybridSearchRequest) (*hypatiapb.SearchResponse, error) {
+	if s.clients.Hypatia == nil {
+		return nil, unavailable("hypatia")
+	}
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	collection, err := collectionName(ctx, req.Collection)
+	if err != nil {
+		return nil, scopeError(err)
+	}
+	req.Collection = collection
+
+	resp, err := proxy.Do(ctx, s.proxy, proxy.RouteSearch, proxy.Unary(s.clients.Hypatia.HybridSearch, req))
+	if err != nil {
+		return nil, backendError(err)
+	}
+	return resp, nil
+}
+
+// ExecuteAgent handles agent execution requests
+func (s *GatewayService) ExecuteAgent(ctx context.Context, req *leibnizpb.ExecuteRequest) (*leibnizpb.ExecuteResponse, error) {
+	if s.clients.Leibniz == nil {
+		return nil, unavailable("leibniz")
+	}
+	if req.Message == "" {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+	conversationID, err := tenant.Scope(ctx, req.ConversationId)
+	if err != nil {
+		return nil, scopeError(err)
+	}
+	req.ConversationId = conversationID
+
+	resp, err := proxy.Do(ctx, s.proxy, proxy.RouteAgent, proxy.Unary(s.clients.Leibniz.Execute, req))
+	if err != nil {
+		return nil, backendError(err)
+	}
+	audit.AddTokens(ctx, audit.Tokens{Total: int(resp.TotalTokens)})
+	resp.ConversationId = localName(ctx, resp.ConversationId)
+	return resp, nil
+}
+
+// StreamAgent handles streaming agent execution requests
+func (s *GatewayService) StreamAgent(req *leibnizpb.ExecuteRequest, stream kantpb.KantService_StreamAgentServer) error {
+	if s.clients.Leibniz == nil {
+		return unavailable("leibniz")
+	}
+	if req.Message == "" {
+		return status.Error(codes.InvalidArgument, "message is required")
+	}
+	ctx, cancel := s.proxy.Context(stream.Context(), proxy.RouteAgentStream)
+	defer cancel()
+	conversationID, err := tenant.Scope(ctx, req.ConversationId)
+	if err != nil {
+		return scopeError(err)
+	}
+	req.ConversationId = conversationID
+
+	backend, err := s.clients.Leibniz.StreamExecute(ctx, req)
+	if err != nil {
+		return backendError(err)
+	}
+	for {
+		chunk, err := backend.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return backendError(streamError(ctx, err))
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// HealthCheck reports the readiness of the gateway and the status of the
+// checked backend services
+func (s *GatewayService) HealthCheck(ctx context.Context, req *common.HealthCheckRequest) (*common.HealthCheckResponse, error) {
+	report := s.prober.Ready(ctx)
+	details := make(map[string]string, len(report.Dependencies))
+	for _, dep := range report.Dependencies {
+		details[dep.Name] = dep.Status
+	}
+	return &common.HealthCheckResponse{
+		Status:        report.Status,
+		Service:       "kant",
+		Version:       s.version,
+		UptimeSeconds: int64(time.Since(s.startTime).Seconds()),
+		Details:       details,
+	}, nil
+}
+
+// unavailable is the error of calls to a backend service that is not
+// connected
+func unavailable(service string) error {
+	return status.Errorf(codes.Unavailable, "%s service not connected", service)
+}
+
+// scopeError is the error of calls that name a resource of another tenant
+func scopeError(err error) error {
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// backendError returns the error of a failed backend call as a status
+// error: status errors of the backends are passed on, context errors keep
+// their meaning, and other failures make the backend unavailable
+func backendError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
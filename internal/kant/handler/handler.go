@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,7 +14,12 @@ import (
 	hypatiapb "github.com/msto63/mDW/api/gen/hypatia"
 	leibnizpb "github.com/msto63/mDW/api/gen/leibniz"
 	turingpb "github.com/msto63/mDW/api/gen/turing"
+	"github.com/msto63/mDW/internal/kant/audit"
 	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/probe"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/router"
+	"github.com/msto63/mDW/internal/kant/tenant"
 	"github.com/msto63/mDW/pkg/core/logging"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -248,6 +252,9 @@ type SystemMetricsResponse struct {
 	FailedRequests      int64   `json:"failed_requests"`
 	AverageResponseTime string  `json:"average_response_time"`
 	RequestsPerSecond   float64 `json:"requests_per_second"`
+
+	// Backend calls per route, including retries and hedged attempts
+	Backends []proxy.RouteStats `json:"backends,omitempty"`
 }
 
 // ErrorEntryResponse represents an error entry
@@ -407,10 +414,11 @@ type ErrorResponse struct {
 
 // HealthResponse represents health check response
 type HealthResponse struct {
-	Status   string            `json:"status"`
-	Version  string            `json:"version"`
-	Uptime   string            `json:"uptime"`
-	Services map[string]string `json:"services,omitempty"`
+	Status       string                   `json:"status"`
+	Version      string                   `json:"version"`
+	Uptime       string                   `json:"uptime"`
+	Services     map[string]string        `json:"services,omitempty"`
+	Dependencies []probe.DependencyStatus `json:"dependencies,omitempty"`
 }
 
 // ServiceInfo represents information about a registered service
@@ -432,19 +440,28 @@ type ServicesResponse struct {
 // Handler handles HTTP requests for the API Gateway
 type Handler struct {
 	clients   *client.ServiceClients
+	proxy     *proxy.Proxy
+	prober    *probe.Prober
+	router    *router.Router
 	logger    *logging.Logger
 	startTime time.Time
 	version   string
 }
 
-// NewHandler creates a new API handler
-func NewHandler(version string, clients *client.ServiceClients) *Handler {
-	return &Handler{
+// NewHandler creates a new API handler that calls the backend services
+// with the timeouts and retries of the proxy and reports their health from
+// the prober
+func NewHandler(version string, clients *client.ServiceClients, px *proxy.Proxy, prober *probe.Prober) *Handler {
+	h := &Handler{
 		clients:   clients,
+		proxy:     px,
+		prober:    prober,
 		logger:    logging.New("kant-handler"),
 		startTime: time.Now(),
 		version:   version,
 	}
+	h.router = h.routes()
+	return h
 }
 
 // ServeHTTP implements http.Handler
@@ -452,144 +469,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Add CORS headers
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-Tenant-ID, X-Request-ID")
 
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusOK)
 		return
 	}
 
-	// Route requests
-	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
-	path = strings.TrimPrefix(path, "/")
-
-	switch {
-	case path == "" || path == "/":
-		h.handleRoot(w, r)
-	case path == "health" || path == "health/":
-		h.handleHealth(w, r)
-	case path == "services" || path == "services/":
-		h.handleServices(w, r)
-	case path == "models" || path == "models/":
-		h.handleModels(w, r)
-	case strings.HasPrefix(path, "models/pull"):
-		h.handleModelPull(w, r)
-	case strings.HasPrefix(path, "models/"):
-		h.handleModelDelete(w, r, strings.TrimPrefix(path, "models/"))
-	case path == "chat" || path == "chat/":
-		h.handleChat(w, r)
-	case path == "chat/stream" || path == "chat/stream/":
-		h.handleChatStream(w, r)
-	case path == "embed" || path == "embed/":
-		h.handleEmbed(w, r)
-	case path == "conversations" || path == "conversations/":
-		h.handleConversations(w, r)
-	case strings.HasPrefix(path, "conversations/"):
-		h.handleConversation(w, r, strings.TrimPrefix(path, "conversations/"))
-	case path == "search" || path == "search/":
-		h.handleSearch(w, r)
-	case path == "search/hybrid" || path == "search/hybrid/":
-		h.handleHybridSearch(w, r)
-	case path == "ingest" || path == "ingest/":
-		h.handleIngest(w, r)
-	case path == "collections" || path == "collections/":
-		h.handleCollections(w, r)
-	case strings.HasPrefix(path, "collections/") && strings.HasSuffix(path, "/stats"):
-		name := strings.TrimSuffix(strings.TrimPrefix(path, "collections/"), "/stats")
-		h.handleCollectionStats(w, r, name)
-	case strings.HasPrefix(path, "collections/"):
-		h.handleCollection(w, r, strings.TrimPrefix(path, "collections/"))
-	case path == "documents" || path == "documents/":
-		h.handleDocuments(w, r)
-	case strings.HasPrefix(path, "documents/"):
-		h.handleDocument(w, r, strings.TrimPrefix(path, "documents/"))
-	case path == "rag/augment" || path == "rag/augment/":
-		h.handleRAGAugment(w, r)
-	case path == "analyze" || path == "analyze/":
-		h.handleAnalyze(w, r)
-	case path == "summarize" || path == "summarize/":
-		h.handleSummarize(w, r)
-	case path == "agent" || path == "agent/" || path == "agent/execute" || path == "agent/execute/":
-		h.handleAgent(w, r)
-	case path == "agent/stream" || path == "agent/stream/":
-		h.handleAgentStream(w, r)
-	case path == "agent/tools" || path == "agent/tools/":
-		h.handleAgentTools(w, r)
-	case path == "admin/overview" || path == "admin/overview/":
-		h.handleAdminOverview(w, r)
-	case path == "admin/metrics" || path == "admin/metrics/":
-		h.handleAdminMetrics(w, r)
-	case path == "admin/errors" || path == "admin/errors/":
-		h.handleAdminErrors(w, r)
-	case path == "pipelines" || path == "pipelines/":
-		h.handlePipelines(w, r)
-	case strings.HasPrefix(path, "pipelines/") && strings.HasSuffix(path, "/execute"):
-		id := strings.TrimSuffix(strings.TrimPrefix(path, "pipelines/"), "/execute")
-		h.handlePipelineExecute(w, r, id)
-	case strings.HasPrefix(path, "pipelines/"):
-		h.handlePipeline(w, r, strings.TrimPrefix(path, "pipelines/"))
-	// Pipeline Processing API (via Platon)
-	case path == "pipeline/process" || path == "pipeline/process/":
-		h.HandlePipelineProcess(w, r)
-	case path == "pipeline/process/pre" || path == "pipeline/process/pre/":
-		h.HandlePipelineProcessPre(w, r)
-	case path == "pipeline/process/post" || path == "pipeline/process/post/":
-		h.HandlePipelineProcessPost(w, r)
-	case path == "pipeline/handlers" || path == "pipeline/handlers/":
-		h.HandleHandlers(w, r)
-	case path == "pipeline/pipelines" || path == "pipeline/pipelines/":
-		h.HandlePipelineDefinitions(w, r)
-	case strings.HasPrefix(path, "pipeline/pipelines/"):
-		h.HandlePipelineDefinition(w, r, strings.TrimPrefix(path, "pipeline/pipelines/"))
-	case path == "pipeline/policies" || path == "pipeline/policies/":
-		h.HandlePolicyDefinitions(w, r)
-	case path == "pipeline/policies/test" || path == "pipeline/policies/test/":
-		h.HandlePolicyTest(w, r)
-	case strings.HasPrefix(path, "pipeline/policies/"):
-		h.HandlePolicyDefinition(w, r, strings.TrimPrefix(path, "pipeline/policies/"))
-	// Platon Pipeline Processing API
-	case path == "platon/process" || path == "platon/process/":
-		h.HandlePlatonProcess(w, r)
-	case path == "platon/process/pre" || path == "platon/process/pre/":
-		h.HandlePlatonProcessPre(w, r)
-	case path == "platon/process/post" || path == "platon/process/post/":
-		h.HandlePlatonProcessPost(w, r)
-	case path == "platon/handlers" || path == "platon/handlers/":
-		h.HandlePlatonListHandlers(w, r)
-	case strings.HasPrefix(path, "platon/handlers/"):
-		h.HandlePlatonGetHandler(w, r)
-	case path == "platon/pipelines" || path == "platon/pipelines/":
-		if r.Method == http.MethodPost {
-			h.HandlePlatonCreatePipeline(w, r)
-		} else {
-			h.HandlePlatonListPipelines(w, r)
-		}
-	case strings.HasPrefix(path, "platon/pipelines/"):
-		if r.Method == http.MethodDelete {
-			h.HandlePlatonDeletePipeline(w, r)
-		} else {
-			h.HandlePlatonGetPipeline(w, r)
-		}
-	case path == "platon/stats" || path == "platon/stats/":
-		h.HandlePlatonStats(w, r)
-	// Aristoteles Agentic Pipeline API
-	case path == "aristoteles/process" || path == "aristoteles/process/":
-		h.HandleAristotelesProcess(w, r)
-	case path == "aristoteles/stream" || path == "aristoteles/stream/":
-		h.HandleAristotelesStream(w, r)
-	case path == "aristoteles/intent" || path == "aristoteles/intent/":
-		h.HandleAristotelesIntent(w, r)
-	case path == "aristoteles/status" || path == "aristoteles/status/":
-		h.HandleAristotelesStatus(w, r)
-	case path == "aristoteles/cancel" || path == "aristoteles/cancel/":
-		h.HandleAristotelesCancel(w, r)
-	case path == "aristoteles/config" || path == "aristoteles/config/":
-		h.HandleAristotelesConfig(w, r)
-	case path == "aristoteles/strategies" || path == "aristoteles/strategies/":
-		h.HandleAristotelesStrategies(w, r)
-	default:
-		h.writeError(w, http.StatusNotFound, "not_found", "Endpoint not found", "")
-	}
+	h.router.ServeHTTP(w, r)
 }
 
 // handleRoot handles the root endpoint
@@ -637,6 +524,9 @@ func (h *Handler) handleRoot(w http.ResponseWriter, r *http.Request) {
 				"POST /api/v1/agent/stream",
 				"GET  /api/v1/agent/tools",
 			},
+			"streaming": {
+				"GET  /api/v1/ws",
+			},
 			"admin": {
 				"GET  /api/v1/admin/overview",
 				"GET  /api/v1/admin/metrics",
@@ -678,96 +568,29 @@ func (h *Handler) handleRoot(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, info)
 }
 
-// handleHealth handles health check requests
+// handleHealth handles health check requests. It answers like the
+// readiness probe: 503 while a critical dependency is unhealthy.
 func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
+	report := h.prober.Ready(r.Context())
 
-	services := map[string]string{"kant": "healthy"}
-
-	// Check Turing health
-	if h.clients.Turing != nil {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		resp, err := h.clients.Turing.HealthCheck(ctx, &common.HealthCheckRequest{})
-		cancel()
-		if err != nil {
-			services["turing"] = "unhealthy"
-		} else {
-			services["turing"] = resp.Status
-		}
-	} else {
-		services["turing"] = "disconnected"
-	}
-
-	// Check Hypatia health
-	if h.clients.Hypatia != nil {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		resp, err := h.clients.Hypatia.HealthCheck(ctx, &common.HealthCheckRequest{})
-		cancel()
-		if err != nil {
-			services["hypatia"] = "unhealthy"
-		} else {
-			services["hypatia"] = resp.Status
-		}
-	} else {
-		services["hypatia"] = "disconnected"
-	}
-
-	// Check Leibniz health
-	if h.clients.Leibniz != nil {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		resp, err := h.clients.Leibniz.HealthCheck(ctx, &common.HealthCheckRequest{})
-		cancel()
-		if err != nil {
-			services["leibniz"] = "unhealthy"
-		} else {
-			services["leibniz"] = resp.Status
-		}
-	} else {
-		services["leibniz"] = "disconnected"
-	}
-
-	// Check Babbage health
-	if h.clients.Babbage != nil {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		resp, err := h.clients.Babbage.HealthCheck(ctx, &common.HealthCheckRequest{})
-		cancel()
-		if err != nil {
-			services["babbage"] = "unhealthy"
-		} else {
-			services["babbage"] = resp.Status
-		}
-	} else {
-		services["babbage"] = "disconnected"
+	services := map[string]string{"kant": probe.StatusHealthy}
+	for _, dep := range report.Dependencies {
+		services[dep.Name] = dep.Status
 	}
 
 	resp := HealthResponse{
-		Status:   "healthy",
-		Version:  h.version,
-		Uptime:   time.Since(h.startTime).String(),
-		Services: services,
+		Status:       report.Status,
+		Version:      h.version,
+		Uptime:       time.Since(h.startTime).String(),
+		Services:     services,
+		Dependencies: report.Dependencies,
 	}
-	h.writeJSON(w, http.StatusOK, resp)
+	h.writeJSON(w, probe.StatusCode(report), resp)
 }
 
 // handleServices handles service discovery requests
 func (h *Handler) handleServices(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Russell == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Russell service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	grpcResp, err := h.clients.Russell.ListServices(ctx, &common.Empty{})
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteServices, proxy.Unary(h.clients.Russell.ListServices, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list services", err.Error())
 		return
@@ -794,20 +617,7 @@ func (h *Handler) handleServices(w http.ResponseWriter, r *http.Request) {
 
 // handleModels handles model listing
 func (h *Handler) handleModels(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Turing == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Turing service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
-	resp, err := h.clients.Turing.ListModels(ctx, &common.Empty{})
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteModels, proxy.Unary(h.clients.Turing.ListModels, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list models", err.Error())
 		return
@@ -828,11 +638,6 @@ func (h *Handler) handleModels(w http.ResponseWriter, r *http.Request) {
 
 // handleChat handles chat completion requests
 func (h *Handler) handleChat(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ChatRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -844,11 +649,6 @@ func (h *Handler) handleChat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Turing == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Turing service not available", "")
-		return
-	}
-
 	// Convert messages to protobuf format
 	pbMessages := make([]*turingpb.Message, len(req.Messages))
 	for i, m := range req.Messages {
@@ -858,9 +658,6 @@ func (h *Handler) handleChat(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
-	defer cancel()
-
 	grpcReq := &turingpb.ChatRequest{
 		Messages:    pbMessages,
 		Model:       req.Model,
@@ -868,11 +665,16 @@ func (h *Handler) handleChat(w http.ResponseWriter, r *http.Request) {
 		Temperature: float32(req.Temperature),
 	}
 
-	grpcResp, err := h.clients.Turing.Chat(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteChat, proxy.Unary(h.clients.Turing.Chat, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Chat failed", err.Error())
 		return
 	}
+	audit.AddTokens(r.Context(), audit.Tokens{
+		Prompt:     int(grpcResp.PromptTokens),
+		Completion: int(grpcResp.CompletionTokens),
+		Total:      int(grpcResp.TotalTokens),
+	})
 
 	resp := ChatResponse{
 		ID:      fmt.Sprintf("chat-%d", time.Now().UnixNano()),
@@ -893,11 +695,6 @@ func (h *Handler) handleChat(w http.ResponseWriter, r *http.Request) {
 
 // handleChatStream handles streaming chat requests via SSE
 func (h *Handler) handleChatStream(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ChatRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -909,11 +706,6 @@ func (h *Handler) handleChatStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Turing == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Turing service not available", "")
-		return
-	}
-
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -934,7 +726,7 @@ func (h *Handler) handleChatStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	ctx, cancel := h.proxy.Context(r.Context(), proxy.RouteChatStream)
 	defer cancel()
 
 	grpcReq := &turingpb.ChatRequest{
@@ -963,6 +755,7 @@ func (h *Handler) handleChatStream(w http.ResponseWriter, r *http.Request) {
 			flusher.Flush()
 			break
 		}
+		audit.AddTokens(r.Context(), audit.Tokens{Prompt: int(chunk.PromptTokens), Completion: int(chunk.CompletionTokens)})
 
 		data := map[string]interface{}{
 			"content": chunk.Delta,
@@ -976,11 +769,6 @@ func (h *Handler) handleChatStream(w http.ResponseWriter, r *http.Request) {
 
 // handleSearch handles RAG search requests
 func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req SearchRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -992,22 +780,20 @@ func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	collection, err := collectionName(r.Context(), req.Collection)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	grpcReq := &hypatiapb.SearchRequest{
 		Query:      req.Query,
-		Collection: req.Collection,
+		Collection: collection,
 		TopK:       int32(req.TopK),
 		MinScore:   float32(req.MinScore),
 	}
 
-	grpcResp, err := h.clients.Hypatia.Search(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteSearch, proxy.Unary(h.clients.Hypatia.Search, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Search failed", err.Error())
 		return
@@ -1032,11 +818,6 @@ func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
 
 // handleIngest handles document ingestion
 func (h *Handler) handleIngest(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req IngestRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1048,23 +829,21 @@ func (h *Handler) handleIngest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	collection, err := collectionName(r.Context(), req.Collection)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &hypatiapb.IngestDocumentRequest{
 		Content:    req.Content,
 		Title:      req.Title,
 		Source:     req.Source,
-		Collection: req.Collection,
+		Collection: collection,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Hypatia.IngestDocument(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteIngest, proxy.Unary(h.clients.Hypatia.IngestDocument, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Ingest failed", err.Error())
 		return
@@ -1079,11 +858,6 @@ func (h *Handler) handleIngest(w http.ResponseWriter, r *http.Request) {
 
 // handleAnalyze handles NLP analysis requests
 func (h *Handler) handleAnalyze(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req AnalyzeRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1095,19 +869,11 @@ func (h *Handler) handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Babbage == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Babbage service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	grpcReq := &babbagepb.AnalyzeRequest{
 		Text: req.Text,
 	}
 
-	grpcResp, err := h.clients.Babbage.Analyze(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAnalyze, proxy.Unary(h.clients.Babbage.Analyze, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Analysis failed", err.Error())
 		return
@@ -1153,11 +919,6 @@ func (h *Handler) handleAnalyze(w http.ResponseWriter, r *http.Request) {
 
 // handleSummarize handles summarization requests
 func (h *Handler) handleSummarize(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req SummarizeRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1169,14 +930,6 @@ func (h *Handler) handleSummarize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Babbage == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Babbage service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	// Convert style string to enum
 	style := babbagepb.SummarizationStyle_SUMMARIZATION_STYLE_BRIEF
 	switch req.Style {
@@ -1194,7 +947,7 @@ func (h *Handler) handleSummarize(w http.ResponseWriter, r *http.Request) {
 		Style:     style,
 	}
 
-	grpcResp, err := h.clients.Babbage.Summarize(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteSummarize, proxy.Unary(h.clients.Babbage.Summarize, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Summarization failed", err.Error())
 		return
@@ -1210,11 +963,6 @@ func (h *Handler) handleSummarize(w http.ResponseWriter, r *http.Request) {
 
 // handleAgent handles agent execution requests
 func (h *Handler) handleAgent(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req AgentRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1231,24 +979,17 @@ func (h *Handler) handleAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Leibniz == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Leibniz service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 300*time.Second)
-	defer cancel()
-
 	grpcReq := &leibnizpb.ExecuteRequest{
 		AgentId: req.AgentID,
 		Message: task,
 	}
 
-	grpcResp, err := h.clients.Leibniz.Execute(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAgent, proxy.Unary(h.clients.Leibniz.Execute, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Agent execution failed", err.Error())
 		return
 	}
+	audit.AddTokens(r.Context(), audit.Tokens{Total: int(grpcResp.TotalTokens)})
 
 	resp := AgentResponse{
 		ID:       grpcResp.ExecutionId,
@@ -1262,11 +1003,6 @@ func (h *Handler) handleAgent(w http.ResponseWriter, r *http.Request) {
 
 // handleAgentStream handles streaming agent execution via SSE
 func (h *Handler) handleAgentStream(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req AgentRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1278,11 +1014,6 @@ func (h *Handler) handleAgentStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Leibniz == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Leibniz service not available", "")
-		return
-	}
-
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -1294,7 +1025,7 @@ func (h *Handler) handleAgentStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 300*time.Second)
+	ctx, cancel := h.proxy.Context(r.Context(), proxy.RouteAgentStream)
 	defer cancel()
 
 	grpcReq := &leibnizpb.ExecuteRequest{
@@ -1334,20 +1065,7 @@ func (h *Handler) handleAgentStream(w http.ResponseWriter, r *http.Request) {
 
 // handleAgentTools handles listing available agent tools
 func (h *Handler) handleAgentTools(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Leibniz == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Leibniz service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	grpcResp, err := h.clients.Leibniz.ListTools(ctx, &common.Empty{})
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAgentTools, proxy.Unary(h.clients.Leibniz.ListTools, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list tools", err.Error())
 		return
@@ -1371,28 +1089,25 @@ func (h *Handler) handleAgentTools(w http.ResponseWriter, r *http.Request) {
 
 // handleCollections handles collection listing and creation
 func (h *Handler) handleCollections(w http.ResponseWriter, r *http.Request) {
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	switch r.Method {
 	case http.MethodGet:
-		grpcResp, err := h.clients.Hypatia.ListCollections(ctx, &common.Empty{})
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteCollections, proxy.Unary(h.clients.Hypatia.ListCollections, &common.Empty{}))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list collections", err.Error())
 			return
 		}
 
-		collections := make([]CollectionResponse, len(grpcResp.Collections))
-		for i, c := range grpcResp.Collections {
-			collections[i] = CollectionResponse{
-				Name:  c.Name,
-				Count: int64(c.DocumentCount),
+		// Only the collections of the request's tenant are listed
+		collections := make([]CollectionResponse, 0, len(grpcResp.Collections))
+		for _, c := range grpcResp.Collections {
+			name, owned := tenant.Unscope(r.Context(), c.Name)
+			if !owned {
+				continue
 			}
+			collections = append(collections, CollectionResponse{
+				Name:  name,
+				Count: int64(c.DocumentCount),
+			})
 		}
 
 		h.writeJSON(w, http.StatusOK, CollectionsResponse{
@@ -1412,18 +1127,24 @@ func (h *Handler) handleCollections(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		name, err := tenant.Scope(r.Context(), req.Name)
+		if err != nil {
+			h.writeScopeError(w, err)
+			return
+		}
+
 		grpcReq := &hypatiapb.CreateCollectionRequest{
-			Name: req.Name,
+			Name: name,
 		}
 
-		grpcResp, err := h.clients.Hypatia.CreateCollection(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteCollectionsWrite, proxy.Unary(h.clients.Hypatia.CreateCollection, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create collection", err.Error())
 			return
 		}
 
 		h.writeJSON(w, http.StatusCreated, CollectionResponse{
-			Name:  grpcResp.Name,
+			Name:  localName(r.Context(), grpcResp.Name),
 			Count: 0,
 		})
 
@@ -1434,33 +1155,31 @@ func (h *Handler) handleCollections(w http.ResponseWriter, r *http.Request) {
 
 // handleCollection handles single collection operations
 func (h *Handler) handleCollection(w http.ResponseWriter, r *http.Request, name string) {
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	name = strings.TrimSuffix(name, "/")
+	stored, err := tenant.Scope(r.Context(), name)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	name = strings.TrimSuffix(name, "/")
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	switch r.Method {
 	case http.MethodGet:
 		// Use GetCollectionStats to get collection info
-		grpcReq := &hypatiapb.GetCollectionStatsRequest{Name: name}
-		grpcResp, err := h.clients.Hypatia.GetCollectionStats(ctx, grpcReq)
+		grpcReq := &hypatiapb.GetCollectionStatsRequest{Name: stored}
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteCollections, proxy.Unary(h.clients.Hypatia.GetCollectionStats, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusNotFound, "not_found", "Collection not found", err.Error())
 			return
 		}
 
 		h.writeJSON(w, http.StatusOK, CollectionResponse{
-			Name:  grpcResp.Name,
+			Name:  localName(r.Context(), grpcResp.Name),
 			Count: int64(grpcResp.DocumentCount),
 		})
 
 	case http.MethodDelete:
-		grpcReq := &hypatiapb.DeleteCollectionRequest{Name: name}
-		_, err := h.clients.Hypatia.DeleteCollection(ctx, grpcReq)
+		grpcReq := &hypatiapb.DeleteCollectionRequest{Name: stored}
+		_, err := proxy.Do(r.Context(), h.proxy, proxy.RouteCollectionsWrite, proxy.Unary(h.clients.Hypatia.DeleteCollection, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete collection", err.Error())
 			return
@@ -1478,21 +1197,14 @@ func (h *Handler) handleCollection(w http.ResponseWriter, r *http.Request, name
 
 // handleCollectionStats handles collection statistics
 func (h *Handler) handleCollectionStats(w http.ResponseWriter, r *http.Request, name string) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	stored, err := tenant.Scope(r.Context(), name)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
-	grpcReq := &hypatiapb.GetCollectionStatsRequest{Name: name}
-	grpcResp, err := h.clients.Hypatia.GetCollectionStats(ctx, grpcReq)
+	grpcReq := &hypatiapb.GetCollectionStatsRequest{Name: stored}
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteCollections, proxy.Unary(h.clients.Hypatia.GetCollectionStats, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusNotFound, "not_found", "Collection not found", err.Error())
 		return
@@ -1509,45 +1221,41 @@ func (h *Handler) handleCollectionStats(w http.ResponseWriter, r *http.Request,
 
 // handleDocuments handles document listing
 func (h *Handler) handleDocuments(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	collection, err := tenant.Scope(r.Context(), r.URL.Query().Get("collection"))
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
-	collection := r.URL.Query().Get("collection")
 	grpcReq := &hypatiapb.ListDocumentsRequest{
 		Collection: collection,
 	}
 
-	grpcResp, err := h.clients.Hypatia.ListDocuments(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteDocuments, proxy.Unary(h.clients.Hypatia.ListDocuments, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list documents", err.Error())
 		return
 	}
 
-	documents := make([]DocumentResponse, len(grpcResp.Documents))
-	for i, d := range grpcResp.Documents {
+	// Only the documents in collections of the request's tenant are listed
+	documents := make([]DocumentResponse, 0, len(grpcResp.Documents))
+	for _, d := range grpcResp.Documents {
+		collection, owned := tenant.Unscope(r.Context(), d.Collection)
+		if !owned {
+			continue
+		}
 		// Convert DocumentMetadata to map[string]string
 		var metadata map[string]string
 		if d.Metadata != nil && d.Metadata.Custom != nil {
 			metadata = d.Metadata.Custom
 		}
-		documents[i] = DocumentResponse{
+		documents = append(documents, DocumentResponse{
 			ID:         d.Id,
 			Title:      d.Title,
 			Source:     d.Source,
-			Collection: d.Collection,
+			Collection: collection,
 			Metadata:   metadata,
 			ChunkCount: int(d.ChunkCount),
-		}
+		})
 	}
 
 	h.writeJSON(w, http.StatusOK, DocumentsResponse{
@@ -1558,23 +1266,20 @@ func (h *Handler) handleDocuments(w http.ResponseWriter, r *http.Request) {
 
 // handleDocument handles single document operations
 func (h *Handler) handleDocument(w http.ResponseWriter, r *http.Request, id string) {
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
-		return
-	}
-
 	id = strings.TrimSuffix(id, "/")
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
 
 	switch r.Method {
 	case http.MethodGet:
 		grpcReq := &hypatiapb.GetDocumentRequest{DocumentId: id}
-		grpcResp, err := h.clients.Hypatia.GetDocument(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteDocuments, proxy.Unary(h.clients.Hypatia.GetDocument, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusNotFound, "not_found", "Document not found", err.Error())
 			return
 		}
+		if !tenant.Owns(r.Context(), grpcResp.Collection) {
+			h.writeError(w, http.StatusNotFound, "not_found", "Document not found", "")
+			return
+		}
 
 		// Convert DocumentMetadata to map[string]string
 		var metadata map[string]string
@@ -1586,14 +1291,21 @@ func (h *Handler) handleDocument(w http.ResponseWriter, r *http.Request, id stri
 			ID:         grpcResp.Id,
 			Title:      grpcResp.Title,
 			Source:     grpcResp.Source,
-			Collection: grpcResp.Collection,
+			Collection: localName(r.Context(), grpcResp.Collection),
 			Metadata:   metadata,
 			ChunkCount: int(grpcResp.ChunkCount),
 		})
 
 	case http.MethodDelete:
+		// Documents of other tenants' collections cannot be deleted
+		doc, err := proxy.Do(r.Context(), h.proxy, proxy.RouteDocuments, proxy.Unary(h.clients.Hypatia.GetDocument, &hypatiapb.GetDocumentRequest{DocumentId: id}))
+		if err != nil || !tenant.Owns(r.Context(), doc.Collection) {
+			h.writeError(w, http.StatusNotFound, "not_found", "Document not found", "")
+			return
+		}
+
 		grpcReq := &hypatiapb.DeleteDocumentRequest{DocumentId: id}
-		_, err := h.clients.Hypatia.DeleteDocument(ctx, grpcReq)
+		_, err = proxy.Do(r.Context(), h.proxy, proxy.RouteDocumentsWrite, proxy.Unary(h.clients.Hypatia.DeleteDocument, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete document", err.Error())
 			return
@@ -1611,11 +1323,6 @@ func (h *Handler) handleDocument(w http.ResponseWriter, r *http.Request, id stri
 
 // handleHybridSearch handles hybrid search requests
 func (h *Handler) handleHybridSearch(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req HybridSearchRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1627,30 +1334,28 @@ func (h *Handler) handleHybridSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	// Default vector weight if not specified
 	vectorWeight := float32(0.7)
 	if req.AlphaVector > 0 {
 		vectorWeight = float32(req.AlphaVector)
 	}
 
+	collection, err := collectionName(r.Context(), req.Collection)
+	if err != nil {
+		h.writeScopeError(w, err)
+		return
+	}
+
 	grpcReq := &hypatiapb.HybridSearchRequest{
 		Query:         req.Query,
-		Collection:    req.Collection,
+		Collection:    collection,
 		TopK:          int32(req.TopK),
 		MinScore:      float32(req.MinScore),
 		VectorWeight:  vectorWeight,
 		KeywordWeight: 1.0 - vectorWeight,
 	}
 
-	grpcResp, err := h.clients.Hypatia.HybridSearch(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteSearch, proxy.Unary(h.clients.Hypatia.HybridSearch, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Hybrid search failed", err.Error())
 		return
@@ -1674,11 +1379,6 @@ func (h *Handler) handleHybridSearch(w http.ResponseWriter, r *http.Request) {
 
 // handleRAGAugment handles RAG augmentation requests
 func (h *Handler) handleRAGAugment(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req RAGAugmentRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1690,22 +1390,20 @@ func (h *Handler) handleRAGAugment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Hypatia == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Hypatia service not available", "")
+	// Use AugmentPrompt RPC
+	collection, err := collectionName(r.Context(), req.Collection)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
-	// Use AugmentPrompt RPC
 	grpcReq := &hypatiapb.AugmentPromptRequest{
 		Prompt:     req.Query,
-		Collection: req.Collection,
+		Collection: collection,
 		TopK:       int32(req.TopK),
 	}
 
-	grpcResp, err := h.clients.Hypatia.AugmentPrompt(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteRAG, proxy.Unary(h.clients.Hypatia.AugmentPrompt, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "RAG augmentation failed", err.Error())
 		return
@@ -1734,11 +1432,6 @@ func (h *Handler) handleRAGAugment(w http.ResponseWriter, r *http.Request) {
 
 // handleEmbed handles embedding requests
 func (h *Handler) handleEmbed(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req EmbedRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1756,14 +1449,6 @@ func (h *Handler) handleEmbed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Turing == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Turing service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	// Use BatchEmbed for multiple texts, Embed for single text
 	if len(texts) == 1 {
 		grpcReq := &turingpb.EmbedRequest{
@@ -1771,7 +1456,7 @@ func (h *Handler) handleEmbed(w http.ResponseWriter, r *http.Request) {
 			Model: req.Model,
 		}
 
-		grpcResp, err := h.clients.Turing.Embed(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteEmbed, proxy.Unary(h.clients.Turing.Embed, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Embedding failed", err.Error())
 			return
@@ -1794,7 +1479,7 @@ func (h *Handler) handleEmbed(w http.ResponseWriter, r *http.Request) {
 			Model:  req.Model,
 		}
 
-		grpcResp, err := h.clients.Turing.BatchEmbed(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteEmbed, proxy.Unary(h.clients.Turing.BatchEmbed, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Batch embedding failed", err.Error())
 			return
@@ -1818,11 +1503,6 @@ func (h *Handler) handleEmbed(w http.ResponseWriter, r *http.Request) {
 
 // handleModelPull handles model pull requests (streams progress via SSE)
 func (h *Handler) handleModelPull(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ModelPullRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -1834,11 +1514,6 @@ func (h *Handler) handleModelPull(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Turing == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Turing service not available", "")
-		return
-	}
-
 	// Set SSE headers for streaming progress
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -1850,7 +1525,7 @@ func (h *Handler) handleModelPull(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 600*time.Second) // Long timeout for model pull
+	ctx, cancel := h.proxy.Context(r.Context(), proxy.RouteModelPull)
 	defer cancel()
 
 	grpcReq := &turingpb.PullModelRequest{
@@ -1903,11 +1578,6 @@ func (h *Handler) handleModelPull(w http.ResponseWriter, r *http.Request) {
 // handleModelDelete handles model deletion
 // TODO: Add DeleteModel to Turing proto when Ollama deletion support is needed
 func (h *Handler) handleModelDelete(w http.ResponseWriter, r *http.Request, name string) {
-	if r.Method != http.MethodDelete {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use DELETE", "")
-		return
-	}
-
 	// Model deletion not yet implemented in gRPC service
 	h.writeError(w, http.StatusNotImplemented, "not_implemented", "Model deletion not yet implemented", "")
 }
@@ -1933,11 +1603,6 @@ func (h *Handler) handleConversation(w http.ResponseWriter, r *http.Request, id
 // handleAdminOverview handles system overview requests
 // TODO: Implement once Russell proto is regenerated with admin endpoints
 func (h *Handler) handleAdminOverview(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
 	// Admin overview requires proto regeneration - returning stub data for now
 	h.writeJSON(w, http.StatusOK, AdminOverviewResponse{
 		Timestamp:       time.Now().Format(time.RFC3339),
@@ -1953,11 +1618,6 @@ func (h *Handler) handleAdminOverview(w http.ResponseWriter, r *http.Request) {
 // handleAdminMetrics handles system metrics requests
 // TODO: Implement once Russell proto is regenerated with admin endpoints
 func (h *Handler) handleAdminMetrics(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
 	// Metrics requires proto regeneration - returning stub data for now
 	h.writeJSON(w, http.StatusOK, SystemMetricsResponse{
 		TotalRequests:       0,
@@ -1965,17 +1625,13 @@ func (h *Handler) handleAdminMetrics(w http.ResponseWriter, r *http.Request) {
 		FailedRequests:      0,
 		AverageResponseTime: "0ms",
 		RequestsPerSecond:   0,
+		Backends:            h.proxy.Stats(),
 	})
 }
 
 // handleAdminErrors handles error listing requests
 // TODO: Implement once Russell proto is regenerated with admin endpoints
 func (h *Handler) handleAdminErrors(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
 	// Errors requires proto regeneration - returning empty for now
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"errors": []ErrorEntryResponse{},
@@ -2012,6 +1668,12 @@ func (h *Handler) handlePipelineExecute(w http.ResponseWriter, r *http.Request,
 	h.writeError(w, http.StatusNotImplemented, "not_implemented", "Pipeline execution requires proto regeneration", "")
 }
 
+// writeScopeError answers a request that names a resource of another
+// tenant
+func (h *Handler) writeScopeError(w http.ResponseWriter, err error) {
+	h.writeError(w, http.StatusForbidden, "forbidden", "Access denied", err.Error())
+}
+
 // Helper methods
 
 func (h *Handler) readJSON(r *http.Request, v interface{}) error {
@@ -2043,16 +1705,6 @@ func (h *Handler) writeError(w http.ResponseWriter, status int, code, message, d
 
 // HandleAristotelesProcess handles the /api/v1/aristoteles/process endpoint
 func (h *Handler) HandleAristotelesProcess(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
 	var req struct {
 		RequestID      string            `json:"request_id,omitempty"`
 		Prompt         string            `json:"prompt"`
@@ -2079,10 +1731,16 @@ func (h *Handler) HandleAristotelesProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	conversationID, err := tenant.Scope(r.Context(), req.ConversationID)
+	if err != nil {
+		h.writeScopeError(w, err)
+		return
+	}
+
 	grpcReq := &aristotelepb.ProcessRequest{
 		RequestId:      req.RequestID,
 		Prompt:         req.Prompt,
-		ConversationId: req.ConversationID,
+		ConversationId: conversationID,
 		Metadata:       req.Metadata,
 	}
 
@@ -2094,7 +1752,7 @@ func (h *Handler) HandleAristotelesProcess(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	resp, err := h.clients.Aristoteles.Process(r.Context(), grpcReq)
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristoteles, proxy.Unary(h.clients.Aristoteles.Process, grpcReq))
 	if err != nil {
 		h.logger.Error("Aristoteles process failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "processing_failed", "Processing failed", err.Error())
@@ -2108,16 +1766,6 @@ func (h *Handler) HandleAristotelesProcess(w http.ResponseWriter, r *http.Reques
 
 // HandleAristotelesStream handles the /api/v1/aristoteles/stream endpoint (SSE)
 func (h *Handler) HandleAristotelesStream(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
 	var req struct {
 		RequestID      string            `json:"request_id,omitempty"`
 		Prompt         string            `json:"prompt"`
@@ -2130,10 +1778,16 @@ func (h *Handler) HandleAristotelesStream(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	conversationID, err := tenant.Scope(r.Context(), req.ConversationID)
+	if err != nil {
+		h.writeScopeError(w, err)
+		return
+	}
+
 	grpcReq := &aristotelepb.ProcessRequest{
 		RequestId:      req.RequestID,
 		Prompt:         req.Prompt,
-		ConversationId: req.ConversationID,
+		ConversationId: conversationID,
 		Metadata:       req.Metadata,
 	}
 
@@ -2147,7 +1801,10 @@ func (h *Handler) HandleAristotelesStream(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	stream, err := h.clients.Aristoteles.StreamProcess(r.Context(), grpcReq)
+	ctx, cancel := h.proxy.Context(r.Context(), proxy.RouteAristoteles)
+	defer cancel()
+
+	stream, err := h.clients.Aristoteles.StreamProcess(ctx, grpcReq)
 	if err != nil {
 		h.logger.Error("Failed to start stream", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "stream_error", "Failed to start stream", err.Error())
@@ -2177,16 +1834,6 @@ func (h *Handler) HandleAristotelesStream(w http.ResponseWriter, r *http.Request
 
 // HandleAristotelesIntent handles the /api/v1/aristoteles/intent endpoint
 func (h *Handler) HandleAristotelesIntent(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
 	var req struct {
 		Prompt         string `json:"prompt"`
 		ConversationID string `json:"conversation_id,omitempty"`
@@ -2202,10 +1849,16 @@ func (h *Handler) HandleAristotelesIntent(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	resp, err := h.clients.Aristoteles.AnalyzeIntent(r.Context(), &aristotelepb.IntentRequest{
+	conversationID, err := tenant.Scope(r.Context(), req.ConversationID)
+	if err != nil {
+		h.writeScopeError(w, err)
+		return
+	}
+
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristoteles, proxy.Unary(h.clients.Aristoteles.AnalyzeIntent, &aristotelepb.IntentRequest{
 		Prompt:         req.Prompt,
-		ConversationId: req.ConversationID,
-	})
+		ConversationId: conversationID,
+	}))
 	if err != nil {
 		h.logger.Error("Intent analysis failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "analysis_failed", "Intent analysis failed", err.Error())
@@ -2219,25 +1872,15 @@ func (h *Handler) HandleAristotelesIntent(w http.ResponseWriter, r *http.Request
 
 // HandleAristotelesStatus handles the /api/v1/aristoteles/status endpoint
 func (h *Handler) HandleAristotelesStatus(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
 	requestID := r.URL.Query().Get("request_id")
 	if requestID == "" {
 		h.writeError(w, http.StatusBadRequest, "missing_request_id", "request_id is required", "")
 		return
 	}
 
-	resp, err := h.clients.Aristoteles.GetPipelineStatus(r.Context(), &aristotelepb.PipelineStatusRequest{
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristotelesStatus, proxy.Unary(h.clients.Aristoteles.GetPipelineStatus, &aristotelepb.PipelineStatusRequest{
 		RequestId: requestID,
-	})
+	}))
 	if err != nil {
 		h.logger.Error("Get pipeline status failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "status_failed", "Failed to get status", err.Error())
@@ -2251,25 +1894,15 @@ func (h *Handler) HandleAristotelesStatus(w http.ResponseWriter, r *http.Request
 
 // HandleAristotelesCancel handles the /api/v1/aristoteles/cancel endpoint
 func (h *Handler) HandleAristotelesCancel(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
 	requestID := r.URL.Query().Get("request_id")
 	if requestID == "" {
 		h.writeError(w, http.StatusBadRequest, "missing_request_id", "request_id is required", "")
 		return
 	}
 
-	_, err := h.clients.Aristoteles.CancelPipeline(r.Context(), &aristotelepb.CancelPipelineRequest{
+	_, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristotelesWrite, proxy.Unary(h.clients.Aristoteles.CancelPipeline, &aristotelepb.CancelPipelineRequest{
 		RequestId: requestID,
-	})
+	}))
 	if err != nil {
 		h.logger.Error("Cancel pipeline failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "cancel_failed", "Failed to cancel", err.Error())
@@ -2281,17 +1914,7 @@ func (h *Handler) HandleAristotelesCancel(w http.ResponseWriter, r *http.Request
 
 // HandleAristotelesConfig handles the /api/v1/aristoteles/config endpoint
 func (h *Handler) HandleAristotelesConfig(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
-	resp, err := h.clients.Aristoteles.GetConfig(r.Context(), &common.Empty{})
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristotelesStatus, proxy.Unary(h.clients.Aristoteles.GetConfig, &common.Empty{}))
 	if err != nil {
 		h.logger.Error("Get config failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "config_failed", "Failed to get config", err.Error())
@@ -2305,17 +1928,7 @@ func (h *Handler) HandleAristotelesConfig(w http.ResponseWriter, r *http.Request
 
 // HandleAristotelesStrategies handles the /api/v1/aristoteles/strategies endpoint
 func (h *Handler) HandleAristotelesStrategies(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed", "")
-		return
-	}
-
-	if h.clients.Aristoteles == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Aristoteles service not available", "")
-		return
-	}
-
-	resp, err := h.clients.Aristoteles.ListStrategies(r.Context(), &common.Empty{})
+	resp, err := proxy.Do(r.Context(), h.proxy, proxy.RouteAristotelesStatus, proxy.Unary(h.clients.Aristoteles.ListStrategies, &common.Empty{}))
 	if err != nil {
 		h.logger.Error("List strategies failed", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "strategies_failed", "Failed to list strategies", err.Error())
@@ -21,6 +21,8 @@ import (
 
 	"github.com/msto63/mDW/api/gen/common"
 	platonpb "github.com/msto63/mDW/api/gen/platon"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
 )
 
 // ============================================================================
@@ -198,11 +200,6 @@ type HandlersListResponse struct {
 
 // HandlePipelineProcess handles POST /api/v1/pipeline/process
 func (h *Handler) HandlePipelineProcess(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ProcessPipelineRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -214,18 +211,16 @@ func (h *Handler) HandlePipelineProcess(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	// Build gRPC request
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
-	defer cancel()
-
-	// Build gRPC request
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  fmt.Sprintf("kant-%d", time.Now().UnixNano()),
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Response:   req.Response,
 		Metadata:   req.Metadata,
@@ -241,7 +236,7 @@ func (h *Handler) HandlePipelineProcess(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	grpcResp, err := h.clients.Platon.Process(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePipelineProcess, proxy.Unary(h.clients.Platon.Process, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Pipeline processing failed", err.Error())
 		return
@@ -275,11 +270,6 @@ func (h *Handler) HandlePipelineProcess(w http.ResponseWriter, r *http.Request)
 
 // HandlePipelineProcessPre handles POST /api/v1/pipeline/process/pre
 func (h *Handler) HandlePipelineProcessPre(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ProcessPipelineRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -291,22 +281,20 @@ func (h *Handler) HandlePipelineProcessPre(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  fmt.Sprintf("kant-%d", time.Now().UnixNano()),
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Platon.ProcessPre(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonProcess, proxy.Unary(h.clients.Platon.ProcessPre, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Pre-processing failed", err.Error())
 		return
@@ -338,11 +326,6 @@ func (h *Handler) HandlePipelineProcessPre(w http.ResponseWriter, r *http.Reques
 
 // HandlePipelineProcessPost handles POST /api/v1/pipeline/process/post
 func (h *Handler) HandlePipelineProcessPost(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req ProcessPipelineRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -354,23 +337,21 @@ func (h *Handler) HandlePipelineProcessPost(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  fmt.Sprintf("kant-%d", time.Now().UnixNano()),
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Response:   req.Response,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Platon.ProcessPost(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonProcess, proxy.Unary(h.clients.Platon.ProcessPost, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Post-processing failed", err.Error())
 		return
@@ -406,20 +387,7 @@ func (h *Handler) HandlePipelineProcessPost(w http.ResponseWriter, r *http.Reque
 
 // HandleHandlers handles GET /api/v1/pipeline/handlers
 func (h *Handler) HandleHandlers(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
-	grpcResp, err := h.clients.Platon.ListHandlers(ctx, &common.Empty{})
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListHandlers, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list handlers", err.Error())
 		return
@@ -449,30 +417,25 @@ func (h *Handler) HandleHandlers(w http.ResponseWriter, r *http.Request) {
 
 // HandlePipelineDefinitions handles GET/POST /api/v1/pipeline/pipelines
 func (h *Handler) HandlePipelineDefinitions(w http.ResponseWriter, r *http.Request) {
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	switch r.Method {
 	case http.MethodGet:
-		grpcResp, err := h.clients.Platon.ListPipelines(ctx, &common.Empty{})
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListPipelines, &common.Empty{}))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list pipelines", err.Error())
 			return
 		}
 
-		pipelines := make([]PipelineDefinitionResponse, len(grpcResp.Pipelines))
-		for i, p := range grpcResp.Pipelines {
-			pipelines[i] = pipelineInfoToResponse(p)
+		// Only the pipelines of the request's tenant are listed
+		pipelines := make([]PipelineDefinitionResponse, 0, len(grpcResp.Pipelines))
+		for _, p := range grpcResp.Pipelines {
+			if tenant.Owns(r.Context(), p.Id) {
+				pipelines = append(pipelines, pipelineInfoToResponse(r.Context(), p))
+			}
 		}
 
 		h.writeJSON(w, http.StatusOK, PipelineDefinitionsResponse{
 			Pipelines: pipelines,
-			Total:     int(grpcResp.Total),
+			Total:     len(pipelines),
 		})
 
 	case http.MethodPost:
@@ -482,8 +445,14 @@ func (h *Handler) HandlePipelineDefinitions(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		pipelineID, err := tenant.Scope(r.Context(), req.ID)
+		if err != nil {
+			h.writeScopeError(w, err)
+			return
+		}
+
 		grpcReq := &platonpb.CreatePipelineRequest{
-			Id:           req.ID,
+			Id:           pipelineID,
 			Name:         req.Name,
 			Description:  req.Description,
 			Enabled:      req.Enabled,
@@ -492,13 +461,13 @@ func (h *Handler) HandlePipelineDefinitions(w http.ResponseWriter, r *http.Reque
 			Config:       req.Config,
 		}
 
-		grpcResp, err := h.clients.Platon.CreatePipeline(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.CreatePipeline, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create pipeline", err.Error())
 			return
 		}
 
-		h.writeJSON(w, http.StatusCreated, pipelineInfoToResponse(grpcResp))
+		h.writeJSON(w, http.StatusCreated, pipelineInfoToResponse(r.Context(), grpcResp))
 
 	default:
 		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET or POST", "")
@@ -507,23 +476,21 @@ func (h *Handler) HandlePipelineDefinitions(w http.ResponseWriter, r *http.Reque
 
 // HandlePipelineDefinition handles GET/PUT/DELETE /api/v1/pipeline/pipelines/{id}
 func (h *Handler) HandlePipelineDefinition(w http.ResponseWriter, r *http.Request, id string) {
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	id = strings.TrimSuffix(id, "/")
+	storedID, err := tenant.Scope(r.Context(), id)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	id = strings.TrimSuffix(id, "/")
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	switch r.Method {
 	case http.MethodGet:
-		grpcResp, err := h.clients.Platon.GetPipeline(ctx, &platonpb.GetPipelineRequest{Id: id})
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.GetPipeline, &platonpb.GetPipelineRequest{Id: storedID}))
 		if err != nil {
 			h.writeError(w, http.StatusNotFound, "not_found", "Pipeline not found", err.Error())
 			return
 		}
-		h.writeJSON(w, http.StatusOK, pipelineInfoToResponse(grpcResp))
+		h.writeJSON(w, http.StatusOK, pipelineInfoToResponse(r.Context(), grpcResp))
 
 	case http.MethodPut:
 		var req PipelineDefinitionRequest
@@ -534,7 +501,7 @@ func (h *Handler) HandlePipelineDefinition(w http.ResponseWriter, r *http.Reques
 		req.ID = id
 
 		grpcReq := &platonpb.UpdatePipelineRequest{
-			Id:           id,
+			Id:           storedID,
 			Name:         req.Name,
 			Description:  req.Description,
 			Enabled:      req.Enabled,
@@ -543,15 +510,15 @@ func (h *Handler) HandlePipelineDefinition(w http.ResponseWriter, r *http.Reques
 			Config:       req.Config,
 		}
 
-		grpcResp, err := h.clients.Platon.UpdatePipeline(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.UpdatePipeline, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update pipeline", err.Error())
 			return
 		}
-		h.writeJSON(w, http.StatusOK, pipelineInfoToResponse(grpcResp))
+		h.writeJSON(w, http.StatusOK, pipelineInfoToResponse(r.Context(), grpcResp))
 
 	case http.MethodDelete:
-		_, err := h.clients.Platon.DeletePipeline(ctx, &platonpb.DeletePipelineRequest{Id: id})
+		_, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.DeletePipeline, &platonpb.DeletePipelineRequest{Id: storedID}))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete pipeline", err.Error())
 			return
@@ -572,17 +539,9 @@ func (h *Handler) HandlePipelineDefinition(w http.ResponseWriter, r *http.Reques
 
 // HandlePolicyDefinitions handles GET/POST /api/v1/pipeline/policies
 func (h *Handler) HandlePolicyDefinitions(w http.ResponseWriter, r *http.Request) {
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	switch r.Method {
 	case http.MethodGet:
-		grpcResp, err := h.clients.Platon.ListPolicies(ctx, &common.Empty{})
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListPolicies, &common.Empty{}))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list policies", err.Error())
 			return
@@ -606,7 +565,7 @@ func (h *Handler) HandlePolicyDefinitions(w http.ResponseWriter, r *http.Request
 		}
 
 		grpcReq := policyRequestToProto(&req)
-		grpcResp, err := h.clients.Platon.CreatePolicy(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.CreatePolicy, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create policy", err.Error())
 			return
@@ -621,18 +580,11 @@ func (h *Handler) HandlePolicyDefinitions(w http.ResponseWriter, r *http.Request
 
 // HandlePolicyDefinition handles GET/PUT/DELETE /api/v1/pipeline/policies/{id}
 func (h *Handler) HandlePolicyDefinition(w http.ResponseWriter, r *http.Request, id string) {
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
 	id = strings.TrimSuffix(id, "/")
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
 
 	switch r.Method {
 	case http.MethodGet:
-		grpcResp, err := h.clients.Platon.GetPolicy(ctx, &platonpb.GetPolicyRequest{Id: id})
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.GetPolicy, &platonpb.GetPolicyRequest{Id: id}))
 		if err != nil {
 			h.writeError(w, http.StatusNotFound, "not_found", "Policy not found", err.Error())
 			return
@@ -666,7 +618,7 @@ func (h *Handler) HandlePolicyDefinition(w http.ResponseWriter, r *http.Request,
 			}
 		}
 
-		grpcResp, err := h.clients.Platon.UpdatePolicy(ctx, grpcReq)
+		grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.UpdatePolicy, grpcReq))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update policy", err.Error())
 			return
@@ -674,7 +626,7 @@ func (h *Handler) HandlePolicyDefinition(w http.ResponseWriter, r *http.Request,
 		h.writeJSON(w, http.StatusOK, policyInfoToResponse(grpcResp))
 
 	case http.MethodDelete:
-		_, err := h.clients.Platon.DeletePolicy(ctx, &platonpb.DeletePolicyRequest{Id: id})
+		_, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.DeletePolicy, &platonpb.DeletePolicyRequest{Id: id}))
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete policy", err.Error())
 			return
@@ -691,11 +643,6 @@ func (h *Handler) HandlePolicyDefinition(w http.ResponseWriter, r *http.Request,
 
 // HandlePolicyTest handles POST /api/v1/pipeline/policies/test
 func (h *Handler) HandlePolicyTest(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req TestPolicyRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -707,14 +654,6 @@ func (h *Handler) HandlePolicyTest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.TestPolicyRequest{
 		TestText: req.TestText,
 	}
@@ -741,7 +680,7 @@ func (h *Handler) HandlePolicyTest(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	grpcResp, err := h.clients.Platon.TestPolicy(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.TestPolicy, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Policy test failed", err.Error())
 		return
@@ -781,9 +720,11 @@ func (h *Handler) writeSSEEvent(w http.ResponseWriter, event string, data interf
 	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, jsonData)
 }
 
-func pipelineInfoToResponse(p *platonpb.PipelineInfo) PipelineDefinitionResponse {
+// pipelineInfoToResponse converts a pipeline with the ID the request's
+// tenant knows it by
+func pipelineInfoToResponse(ctx context.Context, p *platonpb.PipelineInfo) PipelineDefinitionResponse {
 	resp := PipelineDefinitionResponse{
-		ID:           p.Id,
+		ID:           localName(ctx, p.Id),
 		Name:         p.Name,
 		Description:  p.Description,
 		Enabled:      p.Enabled,
@@ -12,13 +12,12 @@
 package handler
 
 import (
-	"context"
 	"net/http"
-	"strings"
-	"time"
 
 	"github.com/msto63/mDW/api/gen/common"
 	platonpb "github.com/msto63/mDW/api/gen/platon"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
 )
 
 // ============================================================================
@@ -87,11 +86,6 @@ type PlatonPipelineInfo struct {
 
 // HandlePlatonProcessPre handles POST /api/v1/platon/process/pre
 func (h *Handler) HandlePlatonProcessPre(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req PlatonProcessRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -103,23 +97,21 @@ func (h *Handler) HandlePlatonProcessPre(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  req.RequestID,
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Response:   req.Response,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Platon.ProcessPre(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonProcess, proxy.Unary(h.clients.Platon.ProcessPre, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Pre-processing failed", err.Error())
 		return
@@ -131,11 +123,6 @@ func (h *Handler) HandlePlatonProcessPre(w http.ResponseWriter, r *http.Request)
 
 // HandlePlatonProcessPost handles POST /api/v1/platon/process/post
 func (h *Handler) HandlePlatonProcessPost(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req PlatonProcessRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -147,23 +134,21 @@ func (h *Handler) HandlePlatonProcessPost(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  req.RequestID,
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Response:   req.Response,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Platon.ProcessPost(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonProcess, proxy.Unary(h.clients.Platon.ProcessPost, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Post-processing failed", err.Error())
 		return
@@ -175,11 +160,6 @@ func (h *Handler) HandlePlatonProcessPost(w http.ResponseWriter, r *http.Request
 
 // HandlePlatonProcess handles POST /api/v1/platon/process
 func (h *Handler) HandlePlatonProcess(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req PlatonProcessRequest
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -191,23 +171,21 @@ func (h *Handler) HandlePlatonProcess(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.PipelineID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.ProcessRequest{
 		RequestId:  req.RequestID,
-		PipelineId: req.PipelineID,
+		PipelineId: pipelineID,
 		Prompt:     req.Prompt,
 		Response:   req.Response,
 		Metadata:   req.Metadata,
 	}
 
-	grpcResp, err := h.clients.Platon.Process(ctx, grpcReq)
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonProcess, proxy.Unary(h.clients.Platon.Process, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Processing failed", err.Error())
 		return
@@ -223,20 +201,7 @@ func (h *Handler) HandlePlatonProcess(w http.ResponseWriter, r *http.Request) {
 
 // HandlePlatonListHandlers handles GET /api/v1/platon/handlers
 func (h *Handler) HandlePlatonListHandlers(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	grpcResp, err := h.clients.Platon.ListHandlers(ctx, &common.Empty{})
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListHandlers, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list handlers", err.Error())
 		return
@@ -261,28 +226,15 @@ func (h *Handler) HandlePlatonListHandlers(w http.ResponseWriter, r *http.Reques
 
 // HandlePlatonGetHandler handles GET /api/v1/platon/handlers/{name}
 func (h *Handler) HandlePlatonGetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
 	// Extract handler name from path
-	name := strings.TrimPrefix(r.URL.Path, "/api/v1/platon/handlers/")
+	name := r.PathValue("name")
 	if name == "" {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Handler name required", "")
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
 	// GetHandler returns HandlerInfo directly
-	hi, err := h.clients.Platon.GetHandler(ctx, &platonpb.GetHandlerRequest{Name: name})
+	hi, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.GetHandler, &platonpb.GetHandlerRequest{Name: name}))
 	if err != nil {
 		h.writeError(w, http.StatusNotFound, "not_found", "Handler not found", err.Error())
 		return
@@ -305,29 +257,21 @@ func (h *Handler) HandlePlatonGetHandler(w http.ResponseWriter, r *http.Request)
 
 // HandlePlatonListPipelines handles GET /api/v1/platon/pipelines
 func (h *Handler) HandlePlatonListPipelines(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	grpcResp, err := h.clients.Platon.ListPipelines(ctx, &common.Empty{})
+	grpcResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListPipelines, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list pipelines", err.Error())
 		return
 	}
 
-	pipelines := make([]PlatonPipelineInfo, len(grpcResp.Pipelines))
-	for i, p := range grpcResp.Pipelines {
-		pipelines[i] = PlatonPipelineInfo{
-			ID:           p.Id,
+	// Only the pipelines of the request's tenant are listed
+	pipelines := make([]PlatonPipelineInfo, 0, len(grpcResp.Pipelines))
+	for _, p := range grpcResp.Pipelines {
+		id, owned := tenant.Unscope(r.Context(), p.Id)
+		if !owned {
+			continue
+		}
+		pipelines = append(pipelines, PlatonPipelineInfo{
+			ID:           id,
 			Name:         p.Name,
 			Description:  p.Description,
 			Enabled:      p.Enabled,
@@ -336,7 +280,7 @@ func (h *Handler) HandlePlatonListPipelines(w http.ResponseWriter, r *http.Reque
 			Config:       p.Config,
 			CreatedAt:    p.CreatedAt,
 			UpdatedAt:    p.UpdatedAt,
-		}
+		})
 	}
 
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
@@ -347,35 +291,28 @@ func (h *Handler) HandlePlatonListPipelines(w http.ResponseWriter, r *http.Reque
 
 // HandlePlatonGetPipeline handles GET /api/v1/platon/pipelines/{id}
 func (h *Handler) HandlePlatonGetPipeline(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
 	// Extract pipeline ID from path
-	id := strings.TrimPrefix(r.URL.Path, "/api/v1/platon/pipelines/")
+	id := r.PathValue("id")
 	if id == "" {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Pipeline ID required", "")
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	// GetPipeline returns PipelineInfo directly
+	pipelineID, err := tenant.Scope(r.Context(), id)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	// GetPipeline returns PipelineInfo directly
-	p, err := h.clients.Platon.GetPipeline(ctx, &platonpb.GetPipelineRequest{Id: id})
+	p, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.GetPipeline, &platonpb.GetPipelineRequest{Id: pipelineID}))
 	if err != nil {
 		h.writeError(w, http.StatusNotFound, "not_found", "Pipeline not found", err.Error())
 		return
 	}
 
 	pipeline := PlatonPipelineInfo{
-		ID:           p.Id,
+		ID:           localName(r.Context(), p.Id),
 		Name:         p.Name,
 		Description:  p.Description,
 		Enabled:      p.Enabled,
@@ -391,11 +328,6 @@ func (h *Handler) HandlePlatonGetPipeline(w http.ResponseWriter, r *http.Request
 
 // HandlePlatonCreatePipeline handles POST /api/v1/platon/pipelines
 func (h *Handler) HandlePlatonCreatePipeline(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use POST", "")
-		return
-	}
-
 	var req PlatonPipelineInfo
 	if err := h.readJSON(r, &req); err != nil {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON", err.Error())
@@ -407,16 +339,14 @@ func (h *Handler) HandlePlatonCreatePipeline(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), req.ID)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
 	grpcReq := &platonpb.CreatePipelineRequest{
-		Id:           req.ID,
+		Id:           pipelineID,
 		Name:         req.Name,
 		Description:  req.Description,
 		Enabled:      req.Enabled,
@@ -426,14 +356,14 @@ func (h *Handler) HandlePlatonCreatePipeline(w http.ResponseWriter, r *http.Requ
 	}
 
 	// CreatePipeline returns PipelineInfo directly
-	p, err := h.clients.Platon.CreatePipeline(ctx, grpcReq)
+	p, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.CreatePipeline, grpcReq))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create pipeline", err.Error())
 		return
 	}
 
 	pipeline := PlatonPipelineInfo{
-		ID:           p.Id,
+		ID:           localName(r.Context(), p.Id),
 		Name:         p.Name,
 		Description:  p.Description,
 		Enabled:      p.Enabled,
@@ -449,27 +379,20 @@ func (h *Handler) HandlePlatonCreatePipeline(w http.ResponseWriter, r *http.Requ
 
 // HandlePlatonDeletePipeline handles DELETE /api/v1/platon/pipelines/{id}
 func (h *Handler) HandlePlatonDeletePipeline(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use DELETE", "")
-		return
-	}
-
 	// Extract pipeline ID from path
-	id := strings.TrimPrefix(r.URL.Path, "/api/v1/platon/pipelines/")
+	id := r.PathValue("id")
 	if id == "" {
 		h.writeError(w, http.StatusBadRequest, "invalid_request", "Pipeline ID required", "")
 		return
 	}
 
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
+	pipelineID, err := tenant.Scope(r.Context(), id)
+	if err != nil {
+		h.writeScopeError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	_, err := h.clients.Platon.DeletePipeline(ctx, &platonpb.DeletePipelineRequest{Id: id})
+	_, err = proxy.Do(r.Context(), h.proxy, proxy.RoutePlatonWrite, proxy.Unary(h.clients.Platon.DeletePipeline, &platonpb.DeletePipelineRequest{Id: pipelineID}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete pipeline", err.Error())
 		return
@@ -480,28 +403,15 @@ func (h *Handler) HandlePlatonDeletePipeline(w http.ResponseWriter, r *http.Requ
 
 // HandlePlatonStats handles GET /api/v1/platon/stats
 func (h *Handler) HandlePlatonStats(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
-		return
-	}
-
-	if h.clients.Platon == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", "Platon service not available", "")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
 	// Get handler count from ListHandlers
-	handlersResp, err := h.clients.Platon.ListHandlers(ctx, &common.Empty{})
+	handlersResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListHandlers, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get stats", err.Error())
 		return
 	}
 
 	// Get pipeline count from ListPipelines
-	pipelinesResp, err := h.clients.Platon.ListPipelines(ctx, &common.Empty{})
+	pipelinesResp, err := proxy.Do(r.Context(), h.proxy, proxy.RoutePlaton, proxy.Unary(h.clients.Platon.ListPipelines, &common.Empty{}))
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get stats", err.Error())
 		return
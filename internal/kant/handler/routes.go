@@ -0,0 +1,165 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Route table of the REST API
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/msto63/mDW/internal/kant/router"
+)
+
+// routes registers the endpoints of the API below /api/v1 and, as the
+// gateway has always served them, without the prefix (e.g. /health).
+func (h *Handler) routes() *router.Router {
+	rt := router.New()
+	rt.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.writeError(w, http.StatusNotFound, "not_found", "Endpoint not found", "")
+	})
+	rt.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use "+w.Header().Get("Allow"), "")
+	})
+
+	h.apiRoutes(rt.Route("/api/v1"))
+	h.apiRoutes(rt.Route(""))
+	return rt
+}
+
+// apiRoutes registers the endpoints of the API in a group. The routes of a
+// backend service share a group that answers 503 while the service is not
+// connected, so the handlers can use its client directly.
+func (h *Handler) apiRoutes(api *router.Group) {
+	api.Get("", h.handleRoot)
+	api.Get("health", h.handleHealth)
+
+	// Russell (Service Discovery)
+	russell := api.Route("", h.requireService("Russell", func() bool { return h.clients.Russell != nil }))
+	russell.Get("services", h.handleServices)
+
+	// Turing (LLM)
+	turing := api.Route("", h.requireService("Turing", func() bool { return h.clients.Turing != nil }))
+	turing.Get("models", h.handleModels)
+	turing.Post("models/pull", h.handleModelPull)
+	turing.Post("chat", h.handleChat)
+	turing.Post("chat/stream", h.handleChatStream)
+	turing.Post("embed", h.handleEmbed)
+	api.Delete("models/{name...}", h.withName(h.handleModelDelete))
+	api.Get("conversations", h.handleConversations)
+	api.Post("conversations", h.handleConversations)
+	api.Get("conversations/{id}", h.withID(h.handleConversation))
+	api.Delete("conversations/{id}", h.withID(h.handleConversation))
+
+	// Hypatia (RAG)
+	hypatia := api.Route("", h.requireService("Hypatia", func() bool { return h.clients.Hypatia != nil }))
+	hypatia.Post("search", h.handleSearch)
+	hypatia.Post("search/hybrid", h.handleHybridSearch)
+	hypatia.Post("ingest", h.handleIngest)
+	hypatia.Post("rag/augment", h.handleRAGAugment)
+	hypatia.Get("collections", h.handleCollections)
+	hypatia.Post("collections", h.handleCollections)
+	hypatia.Get("collections/{name}", h.withName(h.handleCollection))
+	hypatia.Delete("collections/{name}", h.withName(h.handleCollection))
+	hypatia.Get("collections/{name}/stats", h.withName(h.handleCollectionStats))
+	hypatia.Get("documents", h.handleDocuments)
+	hypatia.Get("documents/{id}", h.withID(h.handleDocument))
+	hypatia.Delete("documents/{id}", h.withID(h.handleDocument))
+
+	// Babbage (NLP)
+	babbage := api.Route("", h.requireService("Babbage", func() bool { return h.clients.Babbage != nil }))
+	babbage.Post("analyze", h.handleAnalyze)
+	babbage.Post("summarize", h.handleSummarize)
+
+	// Leibniz (Agents)
+	leibniz := api.Route("agent", h.requireService("Leibniz", func() bool { return h.clients.Leibniz != nil }))
+	leibniz.Post("", h.handleAgent)
+	leibniz.Post("execute", h.handleAgent)
+	leibniz.Post("stream", h.handleAgentStream)
+	leibniz.Get("tools", h.handleAgentTools)
+
+	// Administration
+	admin := api.Route("admin")
+	admin.Get("overview", h.handleAdminOverview)
+	admin.Get("metrics", h.handleAdminMetrics)
+	admin.Get("errors", h.handleAdminErrors)
+	api.Get("pipelines", h.handlePipelines)
+	api.Post("pipelines", h.handlePipelines)
+	api.Get("pipelines/{id}", h.withID(h.handlePipeline))
+	api.Delete("pipelines/{id}", h.withID(h.handlePipeline))
+	api.Post("pipelines/{id}/execute", h.withID(h.handlePipelineExecute))
+
+	// Pipeline Processing API (via Platon)
+	platonAvailable := h.requireService("Platon", func() bool { return h.clients.Platon != nil })
+	pipeline := api.Route("pipeline", platonAvailable)
+	pipeline.Post("process", h.HandlePipelineProcess)
+	pipeline.Post("process/pre", h.HandlePipelineProcessPre)
+	pipeline.Post("process/post", h.HandlePipelineProcessPost)
+	pipeline.Get("handlers", h.HandleHandlers)
+	pipeline.Get("pipelines", h.HandlePipelineDefinitions)
+	pipeline.Post("pipelines", h.HandlePipelineDefinitions)
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		pipeline.HandleFunc(method, "pipelines/{id}", h.withID(h.HandlePipelineDefinition))
+		pipeline.HandleFunc(method, "policies/{id}", h.withID(h.HandlePolicyDefinition))
+	}
+	pipeline.Get("policies", h.HandlePolicyDefinitions)
+	pipeline.Post("policies", h.HandlePolicyDefinitions)
+	pipeline.Post("policies/test", h.HandlePolicyTest)
+
+	// Platon Pipeline Processing API
+	platon := api.Route("platon", platonAvailable)
+	platon.Post("process", h.HandlePlatonProcess)
+	platon.Post("process/pre", h.HandlePlatonProcessPre)
+	platon.Post("process/post", h.HandlePlatonProcessPost)
+	platon.Get("handlers", h.HandlePlatonListHandlers)
+	platon.Get("handlers/{name}", h.HandlePlatonGetHandler)
+	platon.Get("pipelines", h.HandlePlatonListPipelines)
+	platon.Post("pipelines", h.HandlePlatonCreatePipeline)
+	platon.Get("pipelines/{id}", h.HandlePlatonGetPipeline)
+	platon.Delete("pipelines/{id}", h.HandlePlatonDeletePipeline)
+	platon.Get("stats", h.HandlePlatonStats)
+
+	// Aristoteles Agentic Pipeline API
+	aristoteles := api.Route("aristoteles", h.requireService("Aristoteles", func() bool { return h.clients.Aristoteles != nil }))
+	aristoteles.Post("process", h.HandleAristotelesProcess)
+	aristoteles.Post("stream", h.HandleAristotelesStream)
+	aristoteles.Post("intent", h.HandleAristotelesIntent)
+	aristoteles.Get("status", h.HandleAristotelesStatus)
+	aristoteles.Post("cancel", h.HandleAristotelesCancel)
+	aristoteles.Get("config", h.HandleAristotelesConfig)
+	aristoteles.Get("strategies", h.HandleAristotelesStrategies)
+}
+
+// requireService answers 503 while a backend service is not connected
+func (h *Handler) requireService(name string, connected func() bool) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !connected() {
+				h.writeError(w, http.StatusServiceUnavailable, "service_unavailable", fmt.Sprintf("%s service not available", name), "")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withID passes the {id} path parameter to a handler
+func (h *Handler) withID(handler func(http.ResponseWriter, *http.Request, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, r.PathValue("id"))
+	}
+}
+
+// withName passes the {name} path parameter to a handler
+func (h *Handler) withName(handler func(http.ResponseWriter, *http.Request, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, r.PathValue("name"))
+	}
+}
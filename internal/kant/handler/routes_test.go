@@ -0,0 +1,65 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Unit tests for the route table of the REST API
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/probe"
+	"github.com/msto63/mDW/internal/kant/proxy"
+)
+
+// ============================================================================
+// Unit Tests - Routes
+// ============================================================================
+
+func TestRoutes_Prefixes(t *testing.T) {
+	prober, err := probe.New(probe.Config{}, probe.Dependency{
+		Name:  "turing",
+		Check: func(ctx context.Context) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("probe.New() error = %v", err)
+	}
+	hypatia := &fakeHypatia{}
+	h := NewHandler("test", &client.ServiceClients{Hypatia: hypatia}, proxy.New(proxy.DefaultConfig()), prober)
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/", http.StatusOK},
+		{http.MethodGet, "/api/v1", http.StatusOK},
+		{http.MethodGet, "/health", http.StatusOK},
+		{http.MethodGet, "/api/v1/health/", http.StatusOK},
+		{http.MethodGet, "/collections/docs/stats", http.StatusOK},
+		{http.MethodGet, "/api/v1/collections/docs/stats", http.StatusOK},
+		// Routes of a disconnected service answer 503 under both prefixes
+		{http.MethodGet, "/models", http.StatusServiceUnavailable},
+		{http.MethodGet, "/api/v1/models", http.StatusServiceUnavailable},
+		{http.MethodPost, "/health", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v2/health", http.StatusNotFound},
+		{http.MethodGet, "/unknown", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.want, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,570 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Bidirectional WebSocket protocol for chat streams, agent step
+//              events, tool confirmations, and cancellation
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	leibnizpb "github.com/msto63/mDW/api/gen/leibniz"
+	turingpb "github.com/msto63/mDW/api/gen/turing"
+	"github.com/msto63/mDW/internal/kant/audit"
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
+	"github.com/msto63/mDW/pkg/core/logging"
+)
+
+// StreamPath is the path of the streaming WebSocket endpoint
+const StreamPath = "/api/v1/ws"
+
+// Message types of the streaming protocol. Every message carries the id
+// of the stream it belongs to, chosen by the client when it starts the
+// stream; a connection runs several streams at the same time.
+const (
+	// Client to server
+	WSTypeChat          = "chat"           // Start a chat stream (WSChatPayload)
+	WSTypeAgent         = "agent"          // Start an agent (WSAgentPayload)
+	WSTypeAgentContinue = "agent.continue" // Answer an agent.confirm prompt (WSContinuePayload)
+	WSTypeCancel        = "cancel"         // Abort a stream
+	WSTypePing          = "ping"
+
+	// Server to client
+	WSTypeChunk        = "chunk"         // Chat tokens (WSChunkPayload)
+	WSTypeAgentStep    = "agent.step"    // Agent progress (WSAgentStepPayload)
+	WSTypeAgentConfirm = "agent.confirm" // Agent waits for a tool approval (WSAgentConfirmPayload)
+	WSTypeDone         = "done"          // Stream completed
+	WSTypeCancelled    = "cancelled"     // Stream aborted by a cancel message
+	WSTypeError        = "error"         // Stream or protocol error (WSErrorPayload)
+	WSTypePong         = "pong"
+)
+
+const (
+	wsMaxMessageSize = 1 << 20
+	wsMaxStreams     = 8
+	wsPongWait       = 120 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsWriteTimeout   = 10 * time.Second
+	wsConfirmTimeout = 10 * time.Minute
+)
+
+// WSAgentPayload starts an agent. With ConfirmTools the agent asks for
+// approval with an agent.confirm message before it runs a tool; otherwise
+// tools run automatically and every step is streamed.
+type WSAgentPayload struct {
+	Task           string            `json:"task"`
+	AgentID        string            `json:"agent_id,omitempty"`
+	ConversationID string            `json:"conversation_id,omitempty"`
+	Variables      map[string]string `json:"variables,omitempty"`
+	ConfirmTools   bool              `json:"confirm_tools,omitempty"`
+}
+
+// WSContinuePayload answers an agent.confirm prompt
+type WSContinuePayload struct {
+	Approve bool   `json:"approve"`
+	Input   string `json:"input,omitempty"` // Replaces the tool input if set
+}
+
+// WSAgentStepPayload reports the progress of an agent
+type WSAgentStepPayload struct {
+	Step      string         `json:"step"` // thinking, tool_call, tool_result, response, final
+	Content   string         `json:"content,omitempty"`
+	Iteration int32          `json:"iteration"`
+	Action    *WSAgentAction `json:"action,omitempty"`
+}
+
+// WSAgentAction is a tool call of an agent
+type WSAgentAction struct {
+	Tool       string `json:"tool"`
+	Input      string `json:"input,omitempty"`
+	Output     string `json:"output,omitempty"`
+	Success    bool   `json:"success"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+}
+
+// WSAgentConfirmPayload asks the client to approve a tool call
+type WSAgentConfirmPayload struct {
+	ExecutionID string         `json:"execution_id"`
+	Action      *WSAgentAction `json:"action,omitempty"`
+}
+
+// StreamHandler serves the streaming WebSocket endpoint
+type StreamHandler struct {
+	clients *client.ServiceClients
+	proxy   *proxy.Proxy
+	logger  *logging.Logger
+}
+
+// NewStreamHandler creates a new streaming WebSocket handler
+func NewStreamHandler(clients *client.ServiceClients, px *proxy.Proxy) *StreamHandler {
+	return &StreamHandler{
+		clients: clients,
+		proxy:   px,
+		logger:  logging.New("kant-stream"),
+	}
+}
+
+// ServeHTTP handles the WebSocket upgrade and runs the session
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Error("WebSocket upgrade failed", "error", err)
+		return
+	}
+	principal, _ := auth.FromContext(r.Context())
+
+	// Streams outlive the upgrade request but keep its values, like the
+	// tenant that backend calls are made for
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	s := &wsSession{
+		handler:   h,
+		conn:      conn,
+		ctx:       ctx,
+		principal: principal,
+		streams:   make(map[string]*wsStream),
+	}
+	s.run()
+	cancel()
+	s.wg.Wait()
+	conn.Close()
+}
+
+// wsSession is a WebSocket connection and its running streams
+type wsSession struct {
+	handler   *StreamHandler
+	conn      *websocket.Conn
+	ctx       context.Context
+	principal *auth.Principal
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	streams map[string]*wsStream
+	wg      sync.WaitGroup
+}
+
+// wsStream is a running chat or agent stream
+type wsStream struct {
+	cancel    context.CancelFunc
+	cancelled bool
+
+	// Interactive agents wait for an answer while awaiting is set
+	awaiting bool
+	answers  chan WSContinuePayload
+}
+
+// run reads client messages until the connection closes
+func (s *wsSession) run() {
+	logger := s.handler.logger
+	logger.Info("WebSocket stream session established", "remote", s.conn.RemoteAddr().String())
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	go s.keepAlive()
+
+	for {
+		var msg WSMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				logger.Error("WebSocket read error", "error", err)
+			} else {
+				logger.Info("WebSocket stream session closed")
+			}
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		s.dispatch(msg)
+	}
+}
+
+// keepAlive pings the client until the session ends
+func (s *wsSession) keepAlive() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// dispatch handles a client message
+func (s *wsSession) dispatch(msg WSMessage) {
+	switch msg.Type {
+	case WSTypePing:
+		s.send(WSResponse{Type: WSTypePong, ID: msg.ID})
+
+	case WSTypeChat:
+		var payload WSChatPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			s.sendError(msg.ID, "invalid_payload", "Invalid chat payload")
+			return
+		}
+		switch {
+		case !s.allowed("chat"):
+			s.sendError(msg.ID, "forbidden", `Scope "chat" required`)
+		case s.handler.clients.Turing == nil:
+			s.sendError(msg.ID, "service_unavailable", "Turing service not available")
+		case len(payload.Messages) == 0:
+			s.sendError(msg.ID, "invalid_request", "Messages required")
+		default:
+			s.start(msg.ID, proxy.RouteChatStream, false, func(ctx context.Context, stream *wsStream) error {
+				return s.chat(ctx, msg.ID, payload)
+			})
+		}
+
+	case WSTypeAgent:
+		var payload WSAgentPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			s.sendError(msg.ID, "invalid_payload", "Invalid agent payload")
+			return
+		}
+		switch {
+		case !s.allowed("agent"):
+			s.sendError(msg.ID, "forbidden", `Scope "agent" required`)
+		case s.handler.clients.Leibniz == nil:
+			s.sendError(msg.ID, "service_unavailable", "Leibniz service not available")
+		case payload.Task == "":
+			s.sendError(msg.ID, "invalid_request", "Task required")
+		case payload.ConfirmTools:
+			// The session waits for the client between the calls, so the
+			// route timeout applies to each call instead of the stream
+			s.start(msg.ID, "", true, func(ctx context.Context, stream *wsStream) error {
+				return s.agentWithConfirmation(ctx, msg.ID, payload, stream)
+			})
+		default:
+			s.start(msg.ID, proxy.RouteAgentStream, false, func(ctx context.Context, stream *wsStream) error {
+				return s.agent(ctx, msg.ID, payload)
+			})
+		}
+
+	case WSTypeAgentContinue:
+		var payload WSContinuePayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			s.sendError(msg.ID, "invalid_payload", "Invalid continue payload")
+			return
+		}
+		s.mu.Lock()
+		stream, ok := s.streams[msg.ID]
+		waiting := ok && stream.awaiting
+		if waiting {
+			stream.awaiting = false
+			stream.answers <- payload
+		}
+		s.mu.Unlock()
+		if !waiting {
+			s.sendError(msg.ID, "invalid_request", "No agent waiting for confirmation")
+		}
+
+	case WSTypeCancel:
+		s.mu.Lock()
+		stream, ok := s.streams[msg.ID]
+		if ok {
+			stream.cancelled = true
+			stream.cancel()
+		}
+		s.mu.Unlock()
+		if !ok {
+			s.sendError(msg.ID, "not_found", "No running stream with this id")
+		}
+
+	default:
+		s.sendError(msg.ID, "unknown_type", "Unknown message type: "+msg.Type)
+	}
+}
+
+// allowed reports whether the caller may use a route. Without
+// authentication there is no principal and every route is allowed.
+func (s *wsSession) allowed(scope string) bool {
+	return s.principal == nil || s.principal.HasScope(scope)
+}
+
+// start runs a stream in the background. The stream gets the timeout of
+// the route, if any, and ends with done, cancelled, or error.
+func (s *wsSession) start(id, route string, interactive bool, run func(context.Context, *wsStream) error) {
+	if id == "" {
+		s.sendError(id, "invalid_request", "Stream id required")
+		return
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if route != "" {
+		ctx, cancel = s.handler.proxy.Context(s.ctx, route)
+	} else {
+		ctx, cancel = context.WithCancel(s.ctx)
+	}
+	stream := &wsStream{cancel: cancel}
+	if interactive {
+		stream.answers = make(chan WSContinuePayload, 1)
+	}
+
+	s.mu.Lock()
+	_, exists := s.streams[id]
+	full := len(s.streams) >= wsMaxStreams
+	if !exists && !full {
+		s.streams[id] = stream
+	}
+	s.mu.Unlock()
+	if exists || full {
+		cancel()
+		if exists {
+			s.sendError(id, "invalid_request", "Stream id already in use")
+		} else {
+			s.sendError(id, "too_many_streams", fmt.Sprintf("At most %d streams per connection", wsMaxStreams))
+		}
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		err := run(ctx, stream)
+
+		s.mu.Lock()
+		delete(s.streams, id)
+		cancelled := stream.cancelled
+		s.mu.Unlock()
+		cancel()
+
+		switch {
+		case cancelled:
+			s.send(WSResponse{Type: WSTypeCancelled, ID: id})
+		case errors.Is(err, context.DeadlineExceeded):
+			s.sendError(id, "timeout", "Stream timed out")
+		case err != nil:
+			s.sendError(id, "stream_error", err.Error())
+		}
+	}()
+}
+
+// chat streams a chat completion
+func (s *wsSession) chat(ctx context.Context, id string, payload WSChatPayload) error {
+	pbMessages := make([]*turingpb.Message, len(payload.Messages))
+	for i, m := range payload.Messages {
+		pbMessages[i] = &turingpb.Message{
+			Role:    m.Role,
+			Content: m.Content,
+		}
+	}
+
+	stream, err := s.handler.clients.Turing.StreamChat(ctx, &turingpb.ChatRequest{
+		Messages:    pbMessages,
+		Model:       payload.Model,
+		MaxTokens:   int32(payload.MaxTokens),
+		Temperature: float32(payload.Temperature),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start chat stream: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			s.send(WSResponse{Type: WSTypeDone, ID: id, Payload: WSChunkPayload{Done: true}})
+			return nil
+		}
+		if err != nil {
+			return streamError(ctx, err)
+		}
+		audit.AddTokens(ctx, audit.Tokens{Prompt: int(chunk.PromptTokens), Completion: int(chunk.CompletionTokens)})
+		s.send(WSResponse{Type: WSTypeChunk, ID: id, Payload: WSChunkPayload{
+			Content: chunk.Delta,
+			Done:    chunk.Done,
+		}})
+	}
+}
+
+// agent runs an agent with automatic tool approval and streams its steps
+func (s *wsSession) agent(ctx context.Context, id string, payload WSAgentPayload) error {
+	conversationID, err := tenant.Scope(ctx, payload.ConversationID)
+	if err != nil {
+		return err
+	}
+	stream, err := s.handler.clients.Leibniz.StreamExecute(ctx, &leibnizpb.ExecuteRequest{
+		AgentId:          payload.AgentID,
+		Message:          payload.Task,
+		ConversationId:   conversationID,
+		Variables:        payload.Variables,
+		AutoApproveTools: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start agent: %w", err)
+	}
+
+	var result string
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			s.send(WSResponse{Type: WSTypeDone, ID: id, Payload: AgentResponse{
+				Status:   "completed",
+				Result:   result,
+				Response: result,
+			}})
+			return nil
+		}
+		if err != nil {
+			return streamError(ctx, err)
+		}
+		if chunk.Type == leibnizpb.ChunkType_CHUNK_TYPE_FINAL {
+			result = chunk.Content
+		}
+		s.send(WSResponse{Type: WSTypeAgentStep, ID: id, Payload: WSAgentStepPayload{
+			Step:      strings.ToLower(strings.TrimPrefix(chunk.Type.String(), "CHUNK_TYPE_")),
+			Content:   chunk.Content,
+			Iteration: chunk.Iteration,
+			Action:    wsAction(chunk.Action),
+		}})
+	}
+}
+
+// agentWithConfirmation runs an agent that asks the client before every
+// tool call and reports the executed tool calls as steps
+func (s *wsSession) agentWithConfirmation(ctx context.Context, id string, payload WSAgentPayload, stream *wsStream) error {
+	conversationID, err := tenant.Scope(ctx, payload.ConversationID)
+	if err != nil {
+		return err
+	}
+	leibniz := s.handler.clients.Leibniz
+	resp, err := proxy.Do(ctx, s.handler.proxy, proxy.RouteAgent, proxy.Unary(leibniz.Execute, &leibnizpb.ExecuteRequest{
+		AgentId:        payload.AgentID,
+		Message:        payload.Task,
+		ConversationId: conversationID,
+		Variables:      payload.Variables,
+	}))
+	if err != nil {
+		return err
+	}
+
+	reported := 0
+	for {
+		for ; reported < len(resp.Actions); reported++ {
+			if resp.Status == leibnizpb.ExecutionStatus_EXECUTION_STATUS_AWAITING_CONFIRMATION && reported == len(resp.Actions)-1 {
+				// The last action is the pending one
+				break
+			}
+			s.send(WSResponse{Type: WSTypeAgentStep, ID: id, Payload: WSAgentStepPayload{
+				Step:      "tool_result",
+				Iteration: resp.Iterations,
+				Action:    wsAction(resp.Actions[reported]),
+			}})
+		}
+
+		if resp.Status != leibnizpb.ExecutionStatus_EXECUTION_STATUS_AWAITING_CONFIRMATION {
+			s.send(WSResponse{Type: WSTypeDone, ID: id, Payload: AgentResponse{
+				ID:       resp.ExecutionId,
+				Status:   strings.ToLower(strings.TrimPrefix(resp.Status.String(), "EXECUTION_STATUS_")),
+				Result:   resp.Response,
+				Response: resp.Response,
+			}})
+			return nil
+		}
+
+		confirm := WSAgentConfirmPayload{ExecutionID: resp.ExecutionId}
+		if n := len(resp.Actions); n > 0 {
+			confirm.Action = wsAction(resp.Actions[n-1])
+		}
+		s.mu.Lock()
+		stream.awaiting = true
+		s.mu.Unlock()
+		s.send(WSResponse{Type: WSTypeAgentConfirm, ID: id, Payload: confirm})
+
+		var answer WSContinuePayload
+		select {
+		case answer = <-stream.answers:
+		case <-time.After(wsConfirmTimeout):
+			s.cancelExecution(resp.ExecutionId)
+			return context.DeadlineExceeded
+		case <-ctx.Done():
+			s.cancelExecution(resp.ExecutionId)
+			return ctx.Err()
+		}
+
+		resp, err = proxy.Do(ctx, s.handler.proxy, proxy.RouteAgent, proxy.Unary(leibniz.ContinueExecution, &leibnizpb.ContinueRequest{
+			ExecutionId:       resp.ExecutionId,
+			ApproveTool:       answer.Approve,
+			ToolInputOverride: answer.Input,
+		}))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// cancelExecution stops an agent execution that waits for confirmation
+func (s *wsSession) cancelExecution(executionID string) {
+	_, err := proxy.Do(context.WithoutCancel(s.ctx), s.handler.proxy, proxy.RouteAgentCancel, proxy.Unary(s.handler.clients.Leibniz.CancelExecution, &leibnizpb.CancelRequest{
+		ExecutionId: executionID,
+	}))
+	if err != nil {
+		s.handler.logger.Warn("Failed to cancel agent execution", "execution_id", executionID, "error", err)
+	}
+}
+
+// send writes a message; writes of concurrent streams are serialized
+func (s *wsSession) send(resp WSResponse) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := s.conn.WriteJSON(resp); err != nil {
+		s.handler.logger.Error("WebSocket send error", "error", err)
+	}
+}
+
+// sendError writes an error message for a stream
+func (s *wsSession) sendError(id, code, message string) {
+	s.send(WSResponse{Type: WSTypeError, ID: id, Payload: WSErrorPayload{
+		Code:    code,
+		Message: message,
+	}})
+}
+
+// streamError returns the context error if the stream ended because its
+// context was canceled or timed out, since gRPC wraps it in a status
+func streamError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// wsAction converts an agent action
+func wsAction(action *leibnizpb.AgentAction) *WSAgentAction {
+	if action == nil {
+		return nil
+	}
+	return &WSAgentAction{
+		Tool:       action.Tool,
+		Input:      action.Input,
+		Output:     action.Output,
+		Success:    action.Success,
+		DurationMs: action.DurationMs,
+	}
+}
@@ -0,0 +1,38 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Names of tenant-owned resources in the backend services
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"context"
+
+	"github.com/msto63/mDW/internal/kant/tenant"
+)
+
+// defaultCollection is the collection Hypatia uses for requests without one
+const defaultCollection = "default"
+
+// collectionName returns the name a collection of the request's tenant is
+// stored under in Hypatia. Requests without a collection use the default
+// collection of their tenant.
+func collectionName(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		name = defaultCollection
+	}
+	return tenant.Scope(ctx, name)
+}
+
+// localName returns the name of a stored resource as the request's tenant
+// knows it
+func localName(ctx context.Context, stored string) string {
+	name, _ := tenant.Unscope(ctx, stored)
+	return name
+}
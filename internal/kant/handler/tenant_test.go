@@ -0,0 +1,84 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     handler
+// Description: Unit tests for the tenant isolation of collection routes
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/msto63/mDW/api/gen/common"
+	hypatiapb "github.com/msto63/mDW/api/gen/hypatia"
+	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
+	"google.golang.org/grpc"
+)
+
+// fakeHypatia records the collection names Hypatia is called with
+type fakeHypatia struct {
+	hypatiapb.HypatiaServiceClient
+	names []string
+}
+
+func (f *fakeHypatia) GetCollectionStats(ctx context.Context, in *hypatiapb.GetCollectionStatsRequest, opts ...grpc.CallOption) (*hypatiapb.CollectionStats, error) {
+	f.names = append(f.names, in.Name)
+	return &hypatiapb.CollectionStats{Name: in.Name}, nil
+}
+
+func (f *fakeHypatia) DeleteCollection(ctx context.Context, in *hypatiapb.DeleteCollectionRequest, opts ...grpc.CallOption) (*common.Empty, error) {
+	f.names = append(f.names, in.Name)
+	return &common.Empty{}, nil
+}
+
+// ============================================================================
+// Unit Tests - Tenant Isolation
+// ============================================================================
+
+func TestCollection_TenantIsolation(t *testing.T) {
+	hypatia := &fakeHypatia{}
+	h := NewHandler("test", &client.ServiceClients{Hypatia: hypatia}, proxy.New(proxy.DefaultConfig()), nil)
+
+	tests := []struct {
+		tenant string
+		method string
+		path   string
+		want   int
+		stored string
+	}{
+		{tenant.Default, http.MethodGet, "/api/v1/collections/docs", http.StatusOK, "docs"},
+		{"acme", http.MethodGet, "/api/v1/collections/docs", http.StatusOK, "acme__docs"},
+		// The default tenant cannot reach the collections of other tenants
+		{tenant.Default, http.MethodGet, "/api/v1/collections/acme__x", http.StatusForbidden, ""},
+		{tenant.Default, http.MethodDelete, "/api/v1/collections/acme__x", http.StatusForbidden, ""},
+		{tenant.Default, http.MethodGet, "/api/v1/collections/acme__x/stats", http.StatusForbidden, ""},
+		{tenant.Default, http.MethodGet, "/api/v1/documents?collection=acme__x", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		hypatia.names = nil
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		r = r.WithContext(tenant.WithTenant(r.Context(), tt.tenant))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s %s as %s = %d, want %d: %s", tt.method, tt.path, tt.tenant, rec.Code, tt.want, rec.Body)
+		}
+		switch {
+		case tt.stored == "" && len(hypatia.names) != 0:
+			t.Errorf("%s %s as %s called Hypatia with %v", tt.method, tt.path, tt.tenant, hypatia.names)
+		case tt.stored != "" && (len(hypatia.names) != 1 || hypatia.names[0] != tt.stored):
+			t.Errorf("%s %s as %s called Hypatia with %v, want %q", tt.method, tt.path, tt.tenant, hypatia.names, tt.stored)
+		}
+	}
+}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/gorilla/websocket"
 	turingpb "github.com/msto63/mDW/api/gen/turing"
+	"github.com/msto63/mDW/internal/kant/audit"
 	"github.com/msto63/mDW/internal/kant/client"
+	"github.com/msto63/mDW/internal/kant/proxy"
 	"github.com/msto63/mDW/pkg/core/logging"
 )
 
@@ -26,21 +28,24 @@ var upgrader = websocket.Upgrader{
 // WebSocketHandler handles WebSocket connections for real-time chat
 type WebSocketHandler struct {
 	clients *client.ServiceClients
+	proxy   *proxy.Proxy
 	logger  *logging.Logger
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(clients *client.ServiceClients) *WebSocketHandler {
+func NewWebSocketHandler(clients *client.ServiceClients, px *proxy.Proxy) *WebSocketHandler {
 	return &WebSocketHandler{
 		clients: clients,
+		proxy:   px,
 		logger:  logging.New("kant-websocket"),
 	}
 }
 
 // WSMessage represents a WebSocket message
 type WSMessage struct {
-	Type    string          `json:"type"`    // "chat", "ping", "config"
-	Payload json.RawMessage `json:"payload"` // Message-specific payload
+	Type    string          `json:"type"`         // "chat", "ping", "config"
+	ID      string          `json:"id,omitempty"` // Stream id on /api/v1/ws
+	Payload json.RawMessage `json:"payload"`      // Message-specific payload
 }
 
 // WSChatPayload represents the chat message payload
@@ -53,8 +58,9 @@ type WSChatPayload struct {
 
 // WSResponse represents a WebSocket response
 type WSResponse struct {
-	Type    string      `json:"type"`    // "chunk", "done", "error", "pong"
-	Payload interface{} `json:"payload"` // Response-specific payload
+	Type    string      `json:"type"`         // "chunk", "done", "error", "pong"
+	ID      string      `json:"id,omitempty"` // Stream id on /api/v1/ws
+	Payload interface{} `json:"payload"`      // Response-specific payload
 }
 
 // WSChunkPayload represents a streaming chunk payload
@@ -76,17 +82,18 @@ func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("WebSocket upgrade failed", "error", err)
 		return
 	}
-	h.handleConnection(conn)
+	h.handleConnection(r.Context(), conn)
 }
 
-// handleConnection handles a single WebSocket connection
-func (h *WebSocketHandler) handleConnection(conn *websocket.Conn) {
+// handleConnection handles a single WebSocket connection; its calls keep
+// the values of the upgrade request, like the tenant
+func (h *WebSocketHandler) handleConnection(parent context.Context, conn *websocket.Conn) {
 	defer conn.Close()
 
 	h.logger.Info("WebSocket connection established", "remote", conn.RemoteAddr().String())
 
 	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
 	defer cancel()
 
 	// Set read deadline for ping/pong
@@ -152,7 +159,7 @@ func (h *WebSocketHandler) handleChatMessage(ctx context.Context, conn *websocke
 		}
 	}
 
-	grpcCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	grpcCtx, cancel := h.proxy.Context(ctx, proxy.RouteChatStream)
 	defer cancel()
 
 	grpcReq := &turingpb.ChatRequest{
@@ -184,6 +191,7 @@ func (h *WebSocketHandler) handleChatMessage(ctx context.Context, conn *websocke
 			h.sendError(conn, "stream_error", "Stream error: "+err.Error())
 			return
 		}
+		audit.AddTokens(ctx, audit.Tokens{Prompt: int(chunk.PromptTokens), Completion: int(chunk.CompletionTokens)})
 
 		h.sendResponse(conn, WSResponse{
 			Type: "chunk",
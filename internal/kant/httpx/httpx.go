@@ -0,0 +1,50 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     httpx
+// Description: JSON responses and path matching shared by the middleware
+//              and admin endpoints of the gateway
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+// Package httpx holds the HTTP helpers of the Kant middleware packages, so
+// their responses match those of the API handler.
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ErrorResponse matches the error format of the API handler
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// WriteJSON writes v as a JSON response with the status
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes an error response with the status
+func WriteError(w http.ResponseWriter, status int, code, message, details string) {
+	// Browsers only expose the error to scripts with the CORS header
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	WriteJSON(w, status, ErrorResponse{Error: message, Code: code, Details: details})
+}
+
+// NormalizePath strips trailing slashes so "/api/v1/health/" matches
+func NormalizePath(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
@@ -0,0 +1,88 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     probe
+// Description: HTTP endpoints of the liveness and readiness probes
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package probe
+
+import (
+	"net/http"
+
+	"github.com/msto63/mDW/internal/kant/httpx"
+)
+
+// Routes of the probes. They are served outside the API middleware, so
+// probes need no credentials and tenant and are not audited.
+const (
+	LivePath  = "/livez"
+	ReadyPath = "/readyz"
+)
+
+// LiveResponse reports that the gateway is running
+type LiveResponse struct {
+	Status string `json:"status"`
+	Uptime string `json:"uptime"`
+}
+
+// LiveHandler serves the liveness probe:
+//
+//	GET /livez - 200 while the gateway serves requests
+//
+// Dependencies are not checked, so outages of backend services do not get
+// the gateway restarted.
+func (p *Prober) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowed(w, r) {
+			return
+		}
+		writeJSON(w, http.StatusOK, LiveResponse{Status: "alive", Uptime: p.Uptime().String()})
+	})
+}
+
+// ReadyHandler serves the readiness probe:
+//
+//	GET /readyz - 200 if ready, 503 otherwise
+//
+// The response reports the status, latency, and last error of every
+// checked dependency.
+func (p *Prober) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowed(w, r) {
+			return
+		}
+		report := p.Ready(r.Context())
+		writeJSON(w, StatusCode(report), report)
+	})
+}
+
+// StatusCode returns the HTTP status of a readiness report: 200 if the
+// gateway is ready, even if degraded, and 503 otherwise
+func StatusCode(report Report) int {
+	if report.Ready {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+// allowed answers requests other than GET and HEAD with 405
+func allowed(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	w.Header().Set("Cache-Control", "no-store")
+	httpx.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
+	return false
+}
+
+// writeJSON writes a probe response, which must not be served from caches
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Cache-Control", "no-store")
+	httpx.WriteJSON(w, status, v)
+}
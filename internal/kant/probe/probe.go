@@ -0,0 +1,298 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     probe
+// Description: Liveness and readiness of the gateway with the state of its
+//              critical and optional dependencies
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/msto63/mDW/pkg/core/logging"
+)
+
+// Defaults of the configuration
+const (
+	DefaultTimeout  = 2 * time.Second
+	DefaultCacheTTL = 5 * time.Second
+)
+
+// DefaultCritical are the dependencies the gateway is not ready without
+var DefaultCritical = []string{"turing"}
+
+// Status of a dependency or of the gateway
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded" // An optional dependency is unhealthy
+	StatusUnhealthy = "unhealthy"
+	StatusUnknown   = "unknown" // Not checked yet
+)
+
+// Config configures the dependency checks of the readiness probe
+type Config struct {
+	// Critical dependencies must be healthy for the gateway to be ready
+	// (DefaultCritical if nil; an empty list makes every dependency optional)
+	Critical []string
+
+	// Optional dependencies are reported and degrade the gateway but keep
+	// it ready. If nil, every dependency that is not critical is optional;
+	// otherwise dependencies named in neither list are not checked.
+	Optional []string
+
+	// Timeout bounds each dependency check (DefaultTimeout if 0)
+	Timeout time.Duration
+
+	// CacheTTL is how long the results of a check are reused, so frequent
+	// probes do not load the backends (DefaultCacheTTL if 0)
+	CacheTTL time.Duration
+}
+
+// withDefaults fills unset values with the defaults
+func (c Config) withDefaults() Config {
+	if c.Critical == nil {
+		c.Critical = DefaultCritical
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = DefaultCacheTTL
+	}
+	return c
+}
+
+// Check checks a dependency; an error makes it unhealthy
+type Check func(ctx context.Context) error
+
+// Dependency is a service the gateway depends on
+type Dependency struct {
+	Name  string
+	Check Check
+}
+
+// DependencyStatus is the state of a dependency after its latest check
+type DependencyStatus struct {
+	Name                string    `json:"name"`
+	Critical            bool      `json:"critical"`
+	Status              string    `json:"status"`
+	LatencyMS           int64     `json:"latency_ms"`
+	CheckedAt           time.Time `json:"checked_at,omitzero"`
+	LastSuccess         time.Time `json:"last_success,omitzero"`
+	LastError           string    `json:"last_error,omitempty"` // Kept after the dependency recovers
+	LastErrorAt         time.Time `json:"last_error_at,omitzero"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+}
+
+// Report is the readiness of the gateway
+type Report struct {
+	Status       string             `json:"status"`
+	Ready        bool               `json:"ready"`
+	Draining     bool               `json:"draining,omitempty"` // The gateway is shutting down
+	CheckedAt    time.Time          `json:"checked_at,omitzero"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+// dependency is a checked dependency and its state
+type dependency struct {
+	Dependency
+	status DependencyStatus
+}
+
+// Prober checks the dependencies of the gateway for the readiness probe
+type Prober struct {
+	config  Config
+	deps    []*dependency
+	logger  *logging.Logger
+	now     func() time.Time
+	started time.Time
+
+	draining atomic.Bool
+
+	round     sync.Mutex // Serializes check rounds
+	mu        sync.RWMutex
+	checkedAt time.Time
+}
+
+// New creates a prober for the dependencies. Dependencies named in the
+// configuration must exist.
+func New(cfg Config, deps ...Dependency) (*Prober, error) {
+	cfg = cfg.withDefaults()
+
+	known := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		known[dep.Name] = true
+	}
+	critical := make(map[string]bool, len(cfg.Critical))
+	for _, name := range cfg.Critical {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown critical dependency %q", name)
+		}
+		critical[name] = true
+	}
+	var optional map[string]bool
+	if cfg.Optional != nil {
+		optional = make(map[string]bool, len(cfg.Optional))
+		for _, name := range cfg.Optional {
+			if !known[name] {
+				return nil, fmt.Errorf("unknown optional dependency %q", name)
+			}
+			optional[name] = true
+		}
+	}
+
+	p := &Prober{
+		config:  cfg,
+		logger:  logging.New("kant-probe"),
+		now:     time.Now,
+		started: time.Now(),
+	}
+	for _, dep := range deps {
+		if !critical[dep.Name] && optional != nil && !optional[dep.Name] {
+			continue
+		}
+		p.deps = append(p.deps, &dependency{
+			Dependency: dep,
+			status: DependencyStatus{
+				Name:     dep.Name,
+				Critical: critical[dep.Name],
+				Status:   StatusUnknown,
+			},
+		})
+	}
+	return p, nil
+}
+
+// Uptime returns how long the gateway has been running
+func (p *Prober) Uptime() time.Duration {
+	return p.now().Sub(p.started)
+}
+
+// Drain marks the gateway as shutting down, so the readiness probe fails
+// and load balancers stop sending requests while running ones complete
+func (p *Prober) Drain() {
+	p.draining.Store(true)
+}
+
+// Ready checks the dependencies, unless the last check is more recent than
+// the cache TTL, and reports whether the gateway is ready: not draining and
+// with all critical dependencies healthy. While a check runs, other probes
+// get the previous results instead of waiting for slow dependencies.
+func (p *Prober) Ready(ctx context.Context) Report {
+	checkedAt := p.lastChecked()
+	if checkedAt.IsZero() {
+		p.round.Lock()
+	} else if p.now().Sub(checkedAt) < p.config.CacheTTL || !p.round.TryLock() {
+		return p.report()
+	}
+	// Another round may have finished while waiting for the first results
+	if p.lastChecked().Equal(checkedAt) {
+		p.check(ctx)
+	}
+	p.round.Unlock()
+	return p.report()
+}
+
+// lastChecked returns when the dependencies were last checked
+func (p *Prober) lastChecked() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.checkedAt
+}
+
+// check runs the checks of all dependencies concurrently and records
+// their results. The results are shared by all probes, so the checks do
+// not end with the request that started them.
+func (p *Prober) check(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	var wg sync.WaitGroup
+	for _, dep := range p.deps {
+		wg.Add(1)
+		go func(dep *dependency) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+			defer cancel()
+
+			start := p.now()
+			err := dep.Check(checkCtx)
+			p.record(dep, start, err)
+		}(dep)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	p.checkedAt = p.now()
+	p.mu.Unlock()
+}
+
+// record updates the state of a dependency with the result of a check and
+// logs changes of its status
+func (p *Prober) record(dep *dependency, start time.Time, err error) {
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &dep.status
+	previous := s.Status
+	s.LatencyMS = now.Sub(start).Milliseconds()
+	s.CheckedAt = now
+	if err != nil {
+		s.Status = StatusUnhealthy
+		s.LastError = err.Error()
+		s.LastErrorAt = now
+		s.ConsecutiveFailures++
+	} else {
+		s.Status = StatusHealthy
+		s.LastSuccess = now
+		s.ConsecutiveFailures = 0
+	}
+
+	switch {
+	case s.Status == previous:
+	case err != nil:
+		p.logger.Warn("Dependency unhealthy", "dependency", dep.Name, "critical", s.Critical, "error", err)
+	case previous == StatusUnhealthy:
+		p.logger.Info("Dependency recovered", "dependency", dep.Name, "critical", s.Critical)
+	}
+}
+
+// report returns the readiness from the recorded states
+func (p *Prober) report() Report {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	report := Report{
+		Status:       StatusHealthy,
+		Ready:        true,
+		Draining:     p.draining.Load(),
+		CheckedAt:    p.checkedAt,
+		Dependencies: make([]DependencyStatus, 0, len(p.deps)),
+	}
+	for _, dep := range p.deps {
+		report.Dependencies = append(report.Dependencies, dep.status)
+		if dep.status.Status == StatusHealthy {
+			continue
+		}
+		if dep.status.Critical {
+			report.Ready = false
+		} else if report.Status == StatusHealthy {
+			report.Status = StatusDegraded
+		}
+	}
+	if !report.Ready || report.Draining {
+		report.Ready = false
+		report.Status = StatusUnhealthy
+	}
+	return report
+}
@@ -0,0 +1,303 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     probe
+// Description: Unit tests for the liveness and readiness probes
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeCheck is a dependency check whose result the tests set
+type fakeCheck struct {
+	mu    sync.Mutex
+	err   error
+	calls atomic.Int32
+}
+
+func (f *fakeCheck) set(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+func (f *fakeCheck) check(ctx context.Context) error {
+	f.calls.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// fakeClock is a clock the tests advance
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func newProber(t *testing.T, cfg Config, checks map[string]*fakeCheck) (*Prober, *fakeClock) {
+	t.Helper()
+	var deps []Dependency
+	for _, name := range []string{"turing", "hypatia", "leibniz"} {
+		if f, ok := checks[name]; ok {
+			deps = append(deps, Dependency{Name: name, Check: f.check})
+		}
+	}
+	p, err := New(cfg, deps...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	p.now = clock.Now
+	p.started = clock.Now()
+	return p, clock
+}
+
+func dependencyStatus(t *testing.T, report Report, name string) DependencyStatus {
+	t.Helper()
+	for _, dep := range report.Dependencies {
+		if dep.Name == name {
+			return dep
+		}
+	}
+	t.Fatalf("dependency %q not reported", name)
+	return DependencyStatus{}
+}
+
+// ============================================================================
+// Unit Tests - Prober
+// ============================================================================
+
+func TestNew_UnknownDependency(t *testing.T) {
+	deps := []Dependency{{Name: "turing", Check: (&fakeCheck{}).check}}
+	if _, err := New(Config{Critical: []string{"plato"}}, deps...); err == nil {
+		t.Error("New() with unknown critical dependency succeeded")
+	}
+	if _, err := New(Config{Optional: []string{"hypatia"}}, deps...); err == nil {
+		t.Error("New() with unknown optional dependency succeeded")
+	}
+	if _, err := New(Config{}); err == nil {
+		t.Error("New() without the default critical dependency succeeded")
+	}
+}
+
+func TestReady_CriticalAndOptional(t *testing.T) {
+	turing, hypatia := &fakeCheck{}, &fakeCheck{}
+	p, clock := newProber(t, Config{}, map[string]*fakeCheck{"turing": turing, "hypatia": hypatia})
+
+	report := p.Ready(context.Background())
+	if !report.Ready || report.Status != StatusHealthy {
+		t.Fatalf("Ready() = %+v, want ready and healthy", report)
+	}
+	if !dependencyStatus(t, report, "turing").Critical || dependencyStatus(t, report, "hypatia").Critical {
+		t.Errorf("Critical flags = %+v, want only turing critical", report.Dependencies)
+	}
+
+	// An optional dependency degrades the gateway but keeps it ready
+	hypatia.set(errors.New("connection refused"))
+	clock.Advance(DefaultCacheTTL)
+	report = p.Ready(context.Background())
+	if !report.Ready || report.Status != StatusDegraded {
+		t.Errorf("Ready() with optional down = %+v, want ready and degraded", report)
+	}
+	if StatusCode(report) != http.StatusOK {
+		t.Errorf("StatusCode() = %d, want 200", StatusCode(report))
+	}
+
+	// A critical dependency makes it not ready
+	turing.set(errors.New("deadline exceeded"))
+	clock.Advance(DefaultCacheTTL)
+	report = p.Ready(context.Background())
+	if report.Ready || report.Status != StatusUnhealthy {
+		t.Errorf("Ready() with critical down = %+v, want not ready and unhealthy", report)
+	}
+	if StatusCode(report) != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode() = %d, want 503", StatusCode(report))
+	}
+}
+
+func TestReady_OptionalList(t *testing.T) {
+	turing, hypatia, leibniz := &fakeCheck{}, &fakeCheck{}, &fakeCheck{}
+	p, _ := newProber(t, Config{Optional: []string{"hypatia"}},
+		map[string]*fakeCheck{"turing": turing, "hypatia": hypatia, "leibniz": leibniz})
+
+	report := p.Ready(context.Background())
+	if len(report.Dependencies) != 2 {
+		t.Errorf("Dependencies = %+v, want turing and hypatia", report.Dependencies)
+	}
+	if leibniz.calls.Load() != 0 {
+		t.Errorf("unlisted dependency checked %d times", leibniz.calls.Load())
+	}
+}
+
+func TestReady_Cache(t *testing.T) {
+	turing := &fakeCheck{}
+	p, clock := newProber(t, Config{CacheTTL: 10 * time.Second}, map[string]*fakeCheck{"turing": turing})
+
+	p.Ready(context.Background())
+	clock.Advance(5 * time.Second)
+	p.Ready(context.Background())
+	if got := turing.calls.Load(); got != 1 {
+		t.Errorf("checks within TTL = %d, want 1", got)
+	}
+
+	clock.Advance(5 * time.Second)
+	p.Ready(context.Background())
+	if got := turing.calls.Load(); got != 2 {
+		t.Errorf("checks after TTL = %d, want 2", got)
+	}
+}
+
+func TestReady_Timeout(t *testing.T) {
+	p, err := New(Config{Timeout: 10 * time.Millisecond}, Dependency{
+		Name: "turing",
+		Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// The check outlives a canceled probe request
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := p.Ready(ctx)
+	dep := dependencyStatus(t, report, "turing")
+	if dep.Status != StatusUnhealthy || dep.LastError != context.DeadlineExceeded.Error() {
+		t.Errorf("status = %+v, want unhealthy after the timeout", dep)
+	}
+}
+
+func TestReady_LastError(t *testing.T) {
+	turing := &fakeCheck{}
+	p, clock := newProber(t, Config{}, map[string]*fakeCheck{"turing": turing})
+
+	turing.set(errors.New("connection refused"))
+	p.Ready(context.Background())
+	clock.Advance(DefaultCacheTTL)
+	report := p.Ready(context.Background())
+	failedAt := clock.Now()
+
+	dep := dependencyStatus(t, report, "turing")
+	if dep.ConsecutiveFailures != 2 || dep.LastError != "connection refused" {
+		t.Errorf("status = %+v, want 2 failures with the error", dep)
+	}
+	if !dep.LastSuccess.IsZero() {
+		t.Errorf("LastSuccess = %v, want zero", dep.LastSuccess)
+	}
+
+	// The last error is kept after the dependency recovers
+	turing.set(nil)
+	clock.Advance(DefaultCacheTTL)
+	report = p.Ready(context.Background())
+	dep = dependencyStatus(t, report, "turing")
+	if dep.Status != StatusHealthy || dep.ConsecutiveFailures != 0 {
+		t.Errorf("status = %+v, want healthy", dep)
+	}
+	if dep.LastError != "connection refused" || !dep.LastErrorAt.Equal(failedAt) {
+		t.Errorf("last error = %q at %v, want kept from %v", dep.LastError, dep.LastErrorAt, failedAt)
+	}
+	if !dep.LastSuccess.Equal(clock.Now()) {
+		t.Errorf("LastSuccess = %v, want %v", dep.LastSuccess, clock.Now())
+	}
+}
+
+func TestReady_Drain(t *testing.T) {
+	p, _ := newProber(t, Config{}, map[string]*fakeCheck{"turing": {}})
+
+	p.Drain()
+	report := p.Ready(context.Background())
+	if report.Ready || !report.Draining || report.Status != StatusUnhealthy {
+		t.Errorf("Ready() while draining = %+v, want not ready", report)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Handlers
+// ============================================================================
+
+func TestLiveHandler(t *testing.T) {
+	turing := &fakeCheck{}
+	turing.set(errors.New("connection refused"))
+	p, clock := newProber(t, Config{}, map[string]*fakeCheck{"turing": turing})
+	clock.Advance(time.Minute)
+
+	rec := httptest.NewRecorder()
+	p.LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LivePath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d, want 200", LivePath, rec.Code)
+	}
+	var resp LiveResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != "alive" || resp.Uptime != "1m0s" {
+		t.Errorf("response = %+v", resp)
+	}
+	if turing.calls.Load() != 0 {
+		t.Error("liveness probe checked dependencies")
+	}
+
+	rec = httptest.NewRecorder()
+	p.LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, LivePath, nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST %s = %d, Allow %q", LivePath, rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	turing := &fakeCheck{}
+	p, clock := newProber(t, Config{}, map[string]*fakeCheck{"turing": turing})
+
+	rec := httptest.NewRecorder()
+	p.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET %s = %d, want 200", ReadyPath, rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+
+	turing.set(errors.New("connection refused"))
+	clock.Advance(DefaultCacheTTL)
+	rec = httptest.NewRecorder()
+	p.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET %s with critical down = %d, want 503", ReadyPath, rec.Code)
+	}
+	var report Report
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if dep := dependencyStatus(t, report, "turing"); dep.LastError != "connection refused" {
+		t.Errorf("turing = %+v, want the last error", dep)
+	}
+}
@@ -0,0 +1,371 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     proxy
+// Description: Per-route timeouts, retries, and hedged requests for calls
+//              from the Kant gateway to the backend services
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+// Package proxy applies the call policy of a gateway route to the gRPC calls
+// Kant makes to the backend services. Every route has a timeout; idempotent
+// routes are retried on transient errors and may hedge slow calls by
+// starting another attempt. Retries and hedges are limited by a retry budget
+// shared by all routes, so a failing backend is not flooded with retries.
+package proxy
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBackoff caps the exponential backoff between retries
+const maxBackoff = 5 * time.Second
+
+// Policy is the call policy of a route
+type Policy struct {
+	// Timeout is the deadline of a call, including all its attempts
+	Timeout time.Duration
+
+	// Idempotent allows retries and hedging; calls that change state or
+	// run expensive generations should not be repeated
+	Idempotent bool
+
+	// MaxAttempts limits the attempts of an idempotent call, including
+	// hedged ones; 1 disables retries
+	MaxAttempts int
+
+	// Backoff is the wait before the first retry; it doubles per retry
+	Backoff time.Duration
+
+	// HedgeDelay starts another attempt of an idempotent call if no
+	// attempt has answered after the delay; 0 disables hedging
+	HedgeDelay time.Duration
+}
+
+// Config configures the proxy
+type Config struct {
+	// Default applies to routes without a policy and fills the unset
+	// Timeout, MaxAttempts, and Backoff of route policies
+	Default Policy
+
+	// Routes are the policies by route name
+	Routes map[string]Policy
+
+	// RetryRatio is the share of calls that may be retried or hedged,
+	// e.g. 0.2 for 20 retries per 100 calls
+	RetryRatio float64
+
+	// MinRetriesPerSecond allows retries when there is little traffic
+	MinRetriesPerSecond int
+}
+
+// Proxy applies route policies to backend calls
+type Proxy struct {
+	config Config
+	budget *retryBudget
+
+	mu    sync.Mutex
+	stats map[string]*routeStats
+}
+
+// New creates a proxy for the configuration
+func New(cfg Config) *Proxy {
+	if cfg.Default.Timeout <= 0 {
+		cfg.Default.Timeout = DefaultConfig().Default.Timeout
+	}
+	if cfg.Default.MaxAttempts <= 0 {
+		cfg.Default.MaxAttempts = 1
+	}
+	return &Proxy{
+		config: cfg,
+		budget: newRetryBudget(cfg.RetryRatio, cfg.MinRetriesPerSecond),
+		stats:  make(map[string]*routeStats),
+	}
+}
+
+// Policy returns the policy of a route
+func (p *Proxy) Policy(route string) Policy {
+	policy, ok := p.config.Routes[route]
+	if !ok {
+		return p.config.Default
+	}
+	if policy.Timeout <= 0 {
+		policy.Timeout = p.config.Default.Timeout
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = p.config.Default.MaxAttempts
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = p.config.Default.Backoff
+	}
+	return policy
+}
+
+// Context returns a context with the timeout of a route, for streaming
+// calls, which are never retried
+func (p *Proxy) Context(ctx context.Context, route string) (context.Context, context.CancelFunc) {
+	if p == nil {
+		return context.WithCancel(ctx)
+	}
+	p.route(route).calls.Add(1)
+	return context.WithTimeout(ctx, p.Policy(route).Timeout)
+}
+
+// Do runs a unary backend call with the policy of a route. Calls of
+// idempotent routes are retried on transient errors and hedged after the
+// hedge delay while the retry budget allows it; the first successful
+// attempt wins and the others are canceled. A nil proxy runs the call once.
+func Do[T any](ctx context.Context, p *Proxy, route string, call func(context.Context) (T, error)) (T, error) {
+	if p == nil {
+		return call(ctx)
+	}
+	policy := p.Policy(route)
+	stats := p.route(route)
+	stats.calls.Add(1)
+	p.budget.deposit()
+
+	ctx, cancel := context.WithTimeout(ctx, policy.Timeout)
+	defer cancel()
+
+	if !policy.Idempotent || policy.MaxAttempts <= 1 {
+		value, err := call(ctx)
+		if err != nil {
+			stats.failures.Add(1)
+		}
+		return value, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	results := make(chan result, policy.MaxAttempts)
+	launch := func() {
+		go func() {
+			value, err := call(ctx)
+			results <- result{value, err}
+		}()
+	}
+
+	launch()
+	started, pending := 1, 1
+	backoff := policy.Backoff
+
+	var hedge, retry <-chan time.Time
+	if policy.HedgeDelay > 0 {
+		hedge = time.After(policy.HedgeDelay)
+	}
+	done := ctx.Done()
+
+	var last result
+	for {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.value, nil
+			}
+			last = res
+			if !Retryable(res.err) || ctx.Err() != nil {
+				stats.failures.Add(1)
+				return last.value, last.err
+			}
+			if pending > 0 || retry != nil {
+				// A hedged attempt is still running or a retry is scheduled
+				continue
+			}
+			if started >= policy.MaxAttempts || !p.budget.withdraw() {
+				if started < policy.MaxAttempts {
+					stats.budgetExhausted.Add(1)
+				}
+				stats.failures.Add(1)
+				return last.value, last.err
+			}
+			retry, hedge = time.After(jitter(backoff)), nil
+			backoff = min(2*backoff, maxBackoff)
+
+		case <-retry:
+			retry = nil
+			stats.retries.Add(1)
+			launch()
+			started++
+			pending++
+			if policy.HedgeDelay > 0 && started < policy.MaxAttempts {
+				hedge = time.After(policy.HedgeDelay)
+			}
+
+		case <-hedge:
+			hedge = nil
+			if started >= policy.MaxAttempts {
+				continue
+			}
+			if !p.budget.withdraw() {
+				stats.budgetExhausted.Add(1)
+				continue
+			}
+			stats.hedges.Add(1)
+			launch()
+			started++
+			pending++
+			if started < policy.MaxAttempts {
+				hedge = time.After(policy.HedgeDelay)
+			}
+
+		case <-done:
+			done = nil
+			if pending > 0 {
+				// The attempts return with the context error
+				continue
+			}
+			stats.failures.Add(1)
+			return last.value, last.err
+		}
+	}
+}
+
+// Unary adapts a method of a gRPC client and its request to a call for
+// Do, e.g. Unary(clients.Turing.ListModels, &common.Empty{})
+func Unary[Req, Resp any](method func(context.Context, Req, ...grpc.CallOption) (Resp, error), req Req) func(context.Context) (Resp, error) {
+	return func(ctx context.Context) (Resp, error) {
+		return method(ctx, req)
+	}
+}
+
+// Retryable reports whether a failed call may succeed if repeated: the
+// backend was unavailable, overloaded, or aborted the call
+func Retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// jitter spreads a backoff randomly over [d/2, 3d/2)
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d)
+}
+
+// ============================================================================
+// Statistics
+// ============================================================================
+
+// RouteStats are the call counters of a route
+type RouteStats struct {
+	Route           string `json:"route"`
+	Calls           int64  `json:"calls"`
+	Retries         int64  `json:"retries"`
+	Hedges          int64  `json:"hedges"`
+	Failures        int64  `json:"failures"`
+	BudgetExhausted int64  `json:"budget_exhausted"` // Retries or hedges denied by the retry budget
+}
+
+type routeStats struct {
+	calls, retries, hedges, failures, budgetExhausted atomic.Int64
+}
+
+// route returns the counters of a route
+func (p *Proxy) route(route string) *routeStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats, ok := p.stats[route]
+	if !ok {
+		stats = &routeStats{}
+		p.stats[route] = stats
+	}
+	return stats
+}
+
+// Stats returns the counters of all called routes, sorted by route
+func (p *Proxy) Stats() []RouteStats {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := make([]RouteStats, 0, len(p.stats))
+	for route, stats := range p.stats {
+		result = append(result, RouteStats{
+			Route:           route,
+			Calls:           stats.calls.Load(),
+			Retries:         stats.retries.Load(),
+			Hedges:          stats.hedges.Load(),
+			Failures:        stats.failures.Load(),
+			BudgetExhausted: stats.budgetExhausted.Load(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Route < result[j].Route })
+	return result
+}
+
+// ============================================================================
+// Retry Budget
+// ============================================================================
+
+// retryBudget is a token bucket for retries and hedges. Every call adds
+// ratio tokens and every second adds minPerSecond tokens; a retry takes
+// one token.
+type retryBudget struct {
+	mu           sync.Mutex
+	ratio        float64
+	minPerSecond float64
+	capacity     float64
+	tokens       float64
+	last         time.Time
+	now          func() time.Time
+}
+
+func newRetryBudget(ratio float64, minPerSecond int) *retryBudget {
+	b := &retryBudget{
+		ratio:        ratio,
+		minPerSecond: float64(minPerSecond),
+		now:          time.Now,
+	}
+	// Up to 10 seconds of minimum retries or the retries of 100 calls
+	b.capacity = max(10*b.minPerSecond, 100*ratio, 1)
+	b.tokens = b.capacity
+	b.last = b.now()
+	return b
+}
+
+func (b *retryBudget) refill() {
+	now := b.now()
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.minPerSecond)
+	b.last = now
+}
+
+// deposit records a call
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens = min(b.capacity, b.tokens+b.ratio)
+}
+
+// withdraw takes a token for a retry and reports whether there was one
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
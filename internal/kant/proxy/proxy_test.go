@@ -0,0 +1,258 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     proxy
+// Description: Unit tests for route policies, retries, hedging, and the
+//              retry budget
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testProxy returns a proxy with a single route and fast backoff
+func testProxy(policy Policy) *Proxy {
+	return New(Config{
+		Default:             Policy{Timeout: time.Second, MaxAttempts: 1, Backoff: time.Millisecond},
+		Routes:              map[string]Policy{"test": policy},
+		RetryRatio:          0.2,
+		MinRetriesPerSecond: 10,
+	})
+}
+
+func statsOf(p *Proxy, route string) RouteStats {
+	for _, stats := range p.Stats() {
+		if stats.Route == route {
+			return stats
+		}
+	}
+	return RouteStats{}
+}
+
+// ============================================================================
+// Unit Tests - Policies
+// ============================================================================
+
+func TestProxy_Policy(t *testing.T) {
+	cfg := DefaultConfig()
+	idempotent := false
+	cfg.Override(RouteSearch, Override{HedgeDelay: 200 * time.Millisecond})
+	cfg.Override(RouteModels, Override{Idempotent: &idempotent})
+	cfg.Override("custom", Override{Timeout: 5 * time.Second})
+	p := New(cfg)
+
+	tests := []struct {
+		route string
+		want  Policy
+	}{
+		{RouteSearch, Policy{Timeout: 30 * time.Second, Idempotent: true, MaxAttempts: 3, Backoff: 100 * time.Millisecond, HedgeDelay: 200 * time.Millisecond}},
+		{RouteModels, Policy{Timeout: 30 * time.Second, MaxAttempts: 3, Backoff: 100 * time.Millisecond}},
+		{RouteChat, Policy{Timeout: 120 * time.Second, MaxAttempts: 3, Backoff: 100 * time.Millisecond}},
+		{"custom", Policy{Timeout: 5 * time.Second, MaxAttempts: 3, Backoff: 100 * time.Millisecond}},
+		{"unknown", cfg.Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			if got := p.Policy(tt.route); got != tt.want {
+				t.Errorf("Policy(%q) = %+v, want %+v", tt.route, got, tt.want)
+			}
+		})
+	}
+
+	// Overrides must not change the defaults
+	if DefaultConfig().Routes[RouteSearch].HedgeDelay != 0 {
+		t.Error("Override() changed DefaultConfig()")
+	}
+}
+
+func TestProxy_Context(t *testing.T) {
+	p := testProxy(Policy{Timeout: 50 * time.Millisecond})
+	ctx, cancel := p.Context(context.Background(), "test")
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 50*time.Millisecond {
+		t.Errorf("deadline = %v, %v", deadline, ok)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Retries and Hedging
+// ============================================================================
+
+func TestDo_RetriesTransientErrors(t *testing.T) {
+	p := testProxy(Policy{Idempotent: true, MaxAttempts: 3})
+	var attempts atomic.Int32
+	value, err := Do(context.Background(), p, "test", func(ctx context.Context) (string, error) {
+		if attempts.Add(1) < 3 {
+			return "", status.Error(codes.Unavailable, "connection refused")
+		}
+		return "ok", nil
+	})
+	if err != nil || value != "ok" {
+		t.Fatalf("Do() = %q, %v", value, err)
+	}
+	if attempts.Load() != 3 || statsOf(p, "test").Retries != 2 {
+		t.Errorf("attempts = %d, stats = %+v", attempts.Load(), statsOf(p, "test"))
+	}
+}
+
+func TestDo_NoRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		err    error
+	}{
+		{"not idempotent", Policy{MaxAttempts: 3}, status.Error(codes.Unavailable, "down")},
+		{"permanent error", Policy{Idempotent: true, MaxAttempts: 3}, status.Error(codes.InvalidArgument, "bad")},
+		{"plain error", Policy{Idempotent: true, MaxAttempts: 3}, errors.New("failed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProxy(tt.policy)
+			var attempts atomic.Int32
+			_, err := Do(context.Background(), p, "test", func(ctx context.Context) (int, error) {
+				attempts.Add(1)
+				return 0, tt.err
+			})
+			if err != tt.err || attempts.Load() != 1 {
+				t.Errorf("Do() error = %v after %d attempts", err, attempts.Load())
+			}
+			if statsOf(p, "test").Failures != 1 {
+				t.Errorf("stats = %+v", statsOf(p, "test"))
+			}
+		})
+	}
+}
+
+func TestDo_MaxAttempts(t *testing.T) {
+	p := testProxy(Policy{Idempotent: true, MaxAttempts: 2})
+	var attempts atomic.Int32
+	_, err := Do(context.Background(), p, "test", func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, status.Error(codes.Unavailable, "down")
+	})
+	if status.Code(err) != codes.Unavailable || attempts.Load() != 2 {
+		t.Errorf("Do() error = %v after %d attempts", err, attempts.Load())
+	}
+}
+
+func TestDo_Hedging(t *testing.T) {
+	p := testProxy(Policy{Idempotent: true, MaxAttempts: 2, HedgeDelay: 10 * time.Millisecond})
+	var attempts atomic.Int32
+	value, err := Do(context.Background(), p, "test", func(ctx context.Context) (int, error) {
+		attempt := attempts.Add(1)
+		if attempt == 1 {
+			// The first attempt hangs until the hedged attempt wins
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return int(attempt), nil
+	})
+	if err != nil || value != 2 {
+		t.Fatalf("Do() = %d, %v", value, err)
+	}
+	if stats := statsOf(p, "test"); stats.Hedges != 1 || stats.Failures != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestDo_Timeout(t *testing.T) {
+	p := testProxy(Policy{Timeout: 20 * time.Millisecond, Idempotent: true, MaxAttempts: 3})
+	start := time.Now()
+	_, err := Do(context.Background(), p, "test", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, status.FromContextError(ctx.Err()).Err()
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Do() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do() took %v", elapsed)
+	}
+}
+
+func TestDo_NilProxy(t *testing.T) {
+	value, err := Do(context.Background(), nil, "test", func(ctx context.Context) (string, error) {
+		return "direct", nil
+	})
+	if err != nil || value != "direct" {
+		t.Errorf("Do() = %q, %v", value, err)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Retry Budget
+// ============================================================================
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newRetryBudget(0.5, 1)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	// The budget starts full with the retries of 100 calls
+	for i := 0; i < 50; i++ {
+		if !b.withdraw() {
+			t.Fatalf("budget exhausted after %d retries", i)
+		}
+	}
+	if b.withdraw() {
+		t.Fatal("withdraw() succeeded on an empty budget")
+	}
+
+	// Two calls earn a retry at a ratio of 0.5
+	b.deposit()
+	b.deposit()
+	if !b.withdraw() || b.withdraw() {
+		t.Error("two calls did not earn exactly one retry")
+	}
+
+	// A second earns the minimum rate
+	now = now.Add(time.Second)
+	if !b.withdraw() {
+		t.Error("withdraw() failed after refill")
+	}
+}
+
+func TestDo_BudgetExhausted(t *testing.T) {
+	p := New(Config{
+		Default:    Policy{Timeout: time.Second, Backoff: time.Millisecond},
+		Routes:     map[string]Policy{"test": {Idempotent: true, MaxAttempts: 3}},
+		RetryRatio: 0,
+	})
+	// Drain the initial budget
+	for p.budget.withdraw() {
+	}
+	var attempts atomic.Int32
+	_, err := Do(context.Background(), p, "test", func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, status.Error(codes.Unavailable, "down")
+	})
+	if err == nil || attempts.Load() != 1 || statsOf(p, "test").BudgetExhausted != 1 {
+		t.Errorf("Do() error = %v after %d attempts, stats = %+v", err, attempts.Load(), statsOf(p, "test"))
+	}
+}
+
+func TestUnary(t *testing.T) {
+	method := func(ctx context.Context, req string, opts ...grpc.CallOption) (int, error) {
+		return len(req), nil
+	}
+	value, err := Do(context.Background(), testProxy(Policy{}), "test", Unary(method, "hello"))
+	if err != nil || value != 5 {
+		t.Errorf("Do(Unary()) = %d, %v", value, err)
+	}
+}
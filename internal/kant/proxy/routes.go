@@ -0,0 +1,134 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     proxy
+// Description: Route names of the gateway and their default call policies
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package proxy
+
+import "time"
+
+// Routes of the gateway. Reads and writes of the same resource are
+// separate routes, because only reads may be retried.
+const (
+	RouteHealth            = "health"
+	RouteServices          = "services"
+	RouteModels            = "models"
+	RouteModelPull         = "models.pull"
+	RouteChat              = "chat"
+	RouteChatStream        = "chat.stream"
+	RouteEmbed             = "embed"
+	RouteSearch            = "search"
+	RouteRAG               = "rag"
+	RouteIngest            = "ingest"
+	RouteAnalyze           = "analyze"
+	RouteSummarize         = "summarize"
+	RouteAgent             = "agent"
+	RouteAgentStream       = "agent.stream"
+	RouteAgentTools        = "agent.tools"
+	RouteAgentCancel       = "agent.cancel"
+	RouteCollections       = "collections"
+	RouteCollectionsWrite  = "collections.write"
+	RouteDocuments         = "documents"
+	RouteDocumentsWrite    = "documents.write"
+	RoutePipelineProcess   = "pipeline.process"
+	RoutePlatonProcess     = "platon.process"
+	RoutePlaton            = "platon"
+	RoutePlatonWrite       = "platon.write"
+	RouteAristoteles       = "aristoteles"
+	RouteAristotelesStatus = "aristoteles.status"
+	RouteAristotelesWrite  = "aristoteles.write"
+)
+
+// DefaultConfig returns the default policies: generous timeouts for LLM
+// generations, agents, and model downloads, and up to three attempts for
+// reads. Hedging is disabled by default.
+func DefaultConfig() Config {
+	read := func(timeout time.Duration) Policy {
+		return Policy{Timeout: timeout, Idempotent: true, MaxAttempts: 3}
+	}
+	write := func(timeout time.Duration) Policy {
+		return Policy{Timeout: timeout}
+	}
+
+	return Config{
+		Default: Policy{Timeout: 30 * time.Second, MaxAttempts: 3, Backoff: 100 * time.Millisecond},
+		Routes: map[string]Policy{
+			RouteHealth:            {Timeout: 2 * time.Second, Idempotent: true, MaxAttempts: 1},
+			RouteServices:          read(10 * time.Second),
+			RouteModels:            read(30 * time.Second),
+			RouteModelPull:         write(600 * time.Second),
+			RouteChat:              write(120 * time.Second),
+			RouteChatStream:        write(120 * time.Second),
+			RouteEmbed:             read(60 * time.Second),
+			RouteSearch:            read(30 * time.Second),
+			RouteRAG:               read(60 * time.Second),
+			RouteIngest:            write(60 * time.Second),
+			RouteAnalyze:           read(30 * time.Second),
+			RouteSummarize:         write(60 * time.Second),
+			RouteAgent:             write(300 * time.Second),
+			RouteAgentStream:       write(300 * time.Second),
+			RouteAgentTools:        read(10 * time.Second),
+			RouteAgentCancel:       write(10 * time.Second),
+			RouteCollections:       read(30 * time.Second),
+			RouteCollectionsWrite:  write(30 * time.Second),
+			RouteDocuments:         read(30 * time.Second),
+			RouteDocumentsWrite:    write(30 * time.Second),
+			RoutePipelineProcess:   write(120 * time.Second),
+			RoutePlatonProcess:     write(60 * time.Second),
+			RoutePlaton:            read(30 * time.Second),
+			RoutePlatonWrite:       write(30 * time.Second),
+			RouteAristoteles:       write(300 * time.Second),
+			RouteAristotelesStatus: read(10 * time.Second),
+			RouteAristotelesWrite:  write(30 * time.Second),
+		},
+		RetryRatio:          0.2,
+		MinRetriesPerSecond: 5,
+	}
+}
+
+// Override changes the fields of a route policy that are set, e.g. from
+// the configuration file
+type Override struct {
+	Timeout     time.Duration
+	Idempotent  *bool
+	MaxAttempts int
+	Backoff     time.Duration
+	HedgeDelay  time.Duration
+}
+
+// Override applies an override to the policy of a route; a route without
+// a policy starts from the default policy
+func (c *Config) Override(route string, o Override) {
+	policy, ok := c.Routes[route]
+	if !ok {
+		policy = c.Default
+	}
+	if o.Timeout > 0 {
+		policy.Timeout = o.Timeout
+	}
+	if o.Idempotent != nil {
+		policy.Idempotent = *o.Idempotent
+	}
+	if o.MaxAttempts > 0 {
+		policy.MaxAttempts = o.MaxAttempts
+	}
+	if o.Backoff > 0 {
+		policy.Backoff = o.Backoff
+	}
+	if o.HedgeDelay > 0 {
+		policy.HedgeDelay = o.HedgeDelay
+	}
+
+	routes := make(map[string]Policy, len(c.Routes)+1)
+	for name, existing := range c.Routes {
+		routes[name] = existing
+	}
+	routes[route] = policy
+	c.Routes = routes
+}
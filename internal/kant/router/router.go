@@ -0,0 +1,320 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     router
+// Description: Method-based HTTP router with path parameters and route
+//              groups for the Kant API gateway
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+// Package router routes the requests of the gateway by method and path.
+//
+// A pattern consists of literal segments and parameters: {id} matches one
+// segment and a final {name...} matches the rest of the path, slashes
+// included. Handlers read parameters with r.PathValue. When several patterns
+// match a path, literal segments take precedence over parameters from left
+// to right, so /collections/{name}/stats, /collections/{name}, and
+// /pipeline/policies/test never capture each other's requests.
+//
+// A path that matches a pattern registered for other methods only is
+// answered with 405 and an Allow header. Trailing slashes are ignored.
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Middleware wraps the handlers of a route group
+type Middleware func(http.Handler) http.Handler
+
+// Router dispatches requests to the handlers of the registered routes
+type Router struct {
+	*Group
+
+	// NotFound answers requests without a matching route
+	NotFound http.Handler
+
+	// MethodNotAllowed answers requests whose path matches a route that
+	// is not registered for the method; the Allow header is already set
+	MethodNotAllowed http.Handler
+
+	routes []*route
+}
+
+// New creates an empty router
+func New() *Router {
+	r := &Router{
+		NotFound: http.NotFoundHandler(),
+		MethodNotAllowed: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}),
+	}
+	r.Group = &Group{router: r}
+	return r
+}
+
+// Group registers routes below a common prefix that share middleware
+type Group struct {
+	router     *Router
+	prefix     string
+	middleware []Middleware
+}
+
+// Route creates a sub-group below the prefix; it inherits the middleware
+// of the group and adds its own
+func (g *Group) Route(prefix string, middleware ...Middleware) *Group {
+	return &Group{
+		router:     g.router,
+		prefix:     join(g.prefix, prefix),
+		middleware: append(append([]Middleware(nil), g.middleware...), middleware...),
+	}
+}
+
+// Use adds middleware to the group. It applies to routes registered
+// afterwards, so it must be called before the routes are registered.
+func (g *Group) Use(middleware ...Middleware) {
+	g.middleware = append(g.middleware, middleware...)
+}
+
+// Handle registers a handler for a method and a pattern relative to the
+// group prefix. It panics on an invalid pattern or a duplicate route.
+func (g *Group) Handle(method, pattern string, handler http.Handler) {
+	for i := len(g.middleware) - 1; i >= 0; i-- {
+		handler = g.middleware[i](handler)
+	}
+	g.router.add(method, join(g.prefix, pattern), handler)
+}
+
+// HandleFunc registers a handler function for a method and a pattern
+func (g *Group) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	g.Handle(method, pattern, handler)
+}
+
+// Get registers a GET handler
+func (g *Group) Get(pattern string, handler http.HandlerFunc) {
+	g.Handle(http.MethodGet, pattern, handler)
+}
+
+// Post registers a POST handler
+func (g *Group) Post(pattern string, handler http.HandlerFunc) {
+	g.Handle(http.MethodPost, pattern, handler)
+}
+
+// Put registers a PUT handler
+func (g *Group) Put(pattern string, handler http.HandlerFunc) {
+	g.Handle(http.MethodPut, pattern, handler)
+}
+
+// Delete registers a DELETE handler
+func (g *Group) Delete(pattern string, handler http.HandlerFunc) {
+	g.Handle(http.MethodDelete, pattern, handler)
+}
+
+// ServeHTTP implements http.Handler
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := splitPath(r.URL.Path)
+
+	var best *route
+	var bestValues []string
+	for _, candidate := range rt.routes {
+		values, ok := candidate.match(path)
+		if ok && (best == nil || candidate.precedes(best)) {
+			best, bestValues = candidate, values
+		}
+	}
+	if best == nil {
+		rt.NotFound.ServeHTTP(w, r)
+		return
+	}
+
+	handler, ok := best.handlers[r.Method]
+	if !ok {
+		w.Header().Set("Allow", strings.Join(best.methods(), ", "))
+		rt.MethodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+	for i, seg := range best.segments {
+		if seg.param != "" {
+			r.SetPathValue(seg.param, bestValues[i])
+		}
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// Routes returns the registered routes as "METHOD /pattern", sorted by
+// pattern and method
+func (rt *Router) Routes() []string {
+	var routes []string
+	for _, route := range rt.routes {
+		for _, method := range route.methods() {
+			routes = append(routes, method+" "+route.pattern)
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		mi, pi, _ := strings.Cut(routes[i], " ")
+		mj, pj, _ := strings.Cut(routes[j], " ")
+		if pi != pj {
+			return pi < pj
+		}
+		return mi < mj
+	})
+	return routes
+}
+
+func (rt *Router) add(method, pattern string, handler http.Handler) {
+	if pattern == "" {
+		pattern = "/"
+	}
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("router: pattern %q: %v", pattern, err))
+	}
+	for _, existing := range rt.routes {
+		if existing.pattern != pattern {
+			continue
+		}
+		if _, dup := existing.handlers[method]; dup {
+			panic(fmt.Sprintf("router: duplicate route %s %s", method, pattern))
+		}
+		existing.handlers[method] = handler
+		return
+	}
+	rt.routes = append(rt.routes, &route{
+		pattern:  pattern,
+		segments: segments,
+		handlers: map[string]http.Handler{method: handler},
+	})
+}
+
+// ============================================================================
+// Patterns
+// ============================================================================
+
+// segment is a literal or a parameter of a pattern
+type segment struct {
+	literal string
+	param   string
+	rest    bool // {name...} matches the remaining segments
+}
+
+// route holds the handlers of a pattern by method
+type route struct {
+	pattern  string
+	segments []segment
+	handlers map[string]http.Handler
+}
+
+func parsePattern(pattern string) ([]segment, error) {
+	parts := splitPath(pattern)
+	segments := make([]segment, 0, len(parts))
+	names := make(map[string]bool)
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("invalid segment %q", part)
+			}
+			segments = append(segments, segment{literal: part})
+			continue
+		}
+		if !strings.HasSuffix(part, "}") {
+			return nil, fmt.Errorf("invalid segment %q", part)
+		}
+		name := part[1 : len(part)-1]
+		seg := segment{param: name}
+		if strings.HasSuffix(name, "...") {
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("%q must be the last segment", part)
+			}
+			seg.param, seg.rest = strings.TrimSuffix(name, "..."), true
+		}
+		if seg.param == "" || strings.ContainsAny(seg.param, "{}/") {
+			return nil, fmt.Errorf("invalid parameter %q", part)
+		}
+		if names[seg.param] {
+			return nil, fmt.Errorf("duplicate parameter %q", seg.param)
+		}
+		names[seg.param] = true
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// join appends a pattern to a prefix, normalizing the slashes between them
+func join(prefix, pattern string) string {
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return prefix
+	}
+	return prefix + "/" + pattern
+}
+
+// splitPath splits a path into its segments, ignoring leading, trailing,
+// and repeated slashes
+func splitPath(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// match returns the segment values of a path matching the route
+func (r *route) match(path []string) ([]string, bool) {
+	values := make([]string, len(r.segments))
+	for i, seg := range r.segments {
+		if seg.rest {
+			if i >= len(path) {
+				return nil, false
+			}
+			values[i] = strings.Join(path[i:], "/")
+			return values, true
+		}
+		if i >= len(path) || (seg.param == "" && seg.literal != path[i]) {
+			return nil, false
+		}
+		values[i] = path[i]
+	}
+	return values, len(path) == len(r.segments)
+}
+
+// precedes reports whether the route is more specific than another route
+// matching the same path: the first segment that differs decides, with
+// literals before parameters before rest parameters
+func (r *route) precedes(other *route) bool {
+	for i := 0; i < len(r.segments) && i < len(other.segments); i++ {
+		a, b := r.segments[i].rank(), other.segments[i].rank()
+		if a != b {
+			return a < b
+		}
+	}
+	return len(r.segments) > len(other.segments)
+}
+
+func (s segment) rank() int {
+	switch {
+	case s.rest:
+		return 2
+	case s.param != "":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// methods returns the registered methods of the route, sorted
+func (r *route) methods() []string {
+	methods := make([]string, 0, len(r.handlers))
+	for method := range r.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,205 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     router
+// Description: Unit tests for route matching, precedence, path parameters,
+//              method handling, and group middleware
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// echo returns a handler that writes its name and the given parameters
+func echo(name string, params ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := name
+		for _, param := range params {
+			body += " " + param + "=" + r.PathValue(param)
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func testRouter() *Router {
+	r := New()
+	api := r.Route("/api/v1")
+	api.Get("collections", echo("list"))
+	api.Post("collections", echo("create"))
+	api.Get("collections/{name}", echo("get", "name"))
+	api.Delete("collections/{name}", echo("delete", "name"))
+	api.Get("collections/{name}/stats", echo("stats", "name"))
+	api.Post("pipeline/policies/test", echo("test"))
+	api.Get("pipeline/policies/{id}", echo("policy", "id"))
+	api.Delete("models/{name...}", echo("model", "name"))
+	api.Post("models/pull", echo("pull"))
+	return r
+}
+
+// ============================================================================
+// Unit Tests - Matching
+// ============================================================================
+
+func TestRouter_Match(t *testing.T) {
+	r := testRouter()
+
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/api/v1/collections", "list"},
+		{"GET", "/api/v1/collections/", "list"},
+		{"POST", "/api/v1/collections", "create"},
+		{"GET", "/api/v1/collections/docs", "get name=docs"},
+		{"DELETE", "/api/v1/collections/docs/", "delete name=docs"},
+		{"GET", "/api/v1/collections/docs/stats", "stats name=docs"},
+		{"GET", "/api/v1/collections/stats", "get name=stats"},
+		{"POST", "/api/v1/pipeline/policies/test", "test"},
+		{"GET", "/api/v1/pipeline/policies/pii", "policy id=pii"},
+		{"DELETE", "/api/v1/models/library/mistral:7b", "model name=library/mistral:7b"},
+		{"POST", "/api/v1/models/pull", "pull"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec := serve(r, tt.method, tt.path)
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("got %d %q, want %q", rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRouter_NotFound(t *testing.T) {
+	r := testRouter()
+	r.NotFound = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, path := range []string{
+		"/api/v1/collections/docs/extra",
+		"/api/v1/collections/docs/stats/extra",
+		"/api/v1/models",
+		"/other",
+	} {
+		if rec := serve(r, "GET", path); rec.Code != http.StatusTeapot {
+			t.Errorf("GET %s = %d, want not found", path, rec.Code)
+		}
+	}
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	r := testRouter()
+
+	rec := serve(r, "PUT", "/api/v1/collections/docs")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("PUT = %d, want 405", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "DELETE, GET" {
+		t.Errorf("Allow = %q, want DELETE, GET", allow)
+	}
+
+	// A literal route owns its path: GET does not fall back to {id}
+	rec = serve(r, "GET", "/api/v1/pipeline/policies/test")
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "POST" {
+		t.Errorf("GET policies/test = %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+// ============================================================================
+// Unit Tests - Groups and Registration
+// ============================================================================
+
+func TestGroup_Middleware(t *testing.T) {
+	tag := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(name + ">"))
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	r := New()
+	r.Use(tag("root"))
+	api := r.Route("/api", tag("api"))
+	api.Get("plain", echo("plain"))
+	admin := api.Route("admin", tag("admin"))
+	admin.Get("stats", echo("stats"))
+
+	tests := map[string]string{
+		"/api/plain":       "root>api>plain",
+		"/api/admin/stats": "root>api>admin>stats",
+	}
+	for path, want := range tests {
+		if got := serve(r, "GET", path).Body.String(); got != want {
+			t.Errorf("GET %s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRouter_Routes(t *testing.T) {
+	r := New()
+	r.Get("/", echo("root"))
+	api := r.Route("api/v1/")
+	api.Post("chat", echo("chat"))
+	api.Get("", echo("index"))
+	api.Delete("chat", echo("chat"))
+
+	want := []string{"GET /", "GET /api/v1", "DELETE /api/v1/chat", "POST /api/v1/chat"}
+	if got := r.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Routes() = %v, want %v", got, want)
+	}
+	if rec := serve(r, "GET", "/"); rec.Body.String() != "root" {
+		t.Errorf("GET / = %q", rec.Body.String())
+	}
+}
+
+func TestRouter_InvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{"a/{}", "a/{id", "a/{rest...}/b", "a/{id}/{id}", "a/b{c}"} {
+		t.Run(pattern, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Get(%q) did not panic", pattern)
+				}
+			}()
+			New().Get(pattern, echo("x"))
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate route did not panic")
+		}
+	}()
+	r := New()
+	r.Get("a/{id}", echo("x"))
+	r.Get("a/{id}/", echo("y"))
+}
+
+func TestGroup_EmptyPrefix(t *testing.T) {
+	r := New()
+	api := r.Route("/api/v1")
+	api.Route("", func(next http.Handler) http.Handler { return next }).Get("search", echo("search"))
+	api.Get("/", echo("index"))
+
+	want := []string{"GET /api/v1", "GET /api/v1/search"}
+	if got := r.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Routes() = %v, want %v", got, want)
+	}
+}
@@ -1,23 +1,41 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
+	bayespb "github.com/msto63/mDW/api/gen/bayes"
+	kantpb "github.com/msto63/mDW/api/gen/kant"
+	"github.com/msto63/mDW/internal/kant/audit"
+	"github.com/msto63/mDW/internal/kant/auth"
 	"github.com/msto63/mDW/internal/kant/client"
 	"github.com/msto63/mDW/internal/kant/handler"
+	"github.com/msto63/mDW/internal/kant/probe"
+	"github.com/msto63/mDW/internal/kant/proxy"
+	"github.com/msto63/mDW/internal/kant/tenant"
+	coreGrpc "github.com/msto63/mDW/pkg/core/grpc"
 	"github.com/msto63/mDW/pkg/core/health"
 	"github.com/msto63/mDW/pkg/core/logging"
+	"google.golang.org/grpc"
 )
 
 // Server is the Kant API Gateway server
 type Server struct {
 	httpServer *http.Server
+	grpc       *coreGrpc.Server
 	handler    *handler.Handler
 	clients    *client.ServiceClients
 	health     *health.Registry
+	auth       *auth.Authenticator
+	tenants    *tenant.Manager
+	audit      *audit.Recorder
+	proxy      *proxy.Proxy
+	prober     *probe.Prober
 	logger     *logging.Logger
 	config     Config
 }
@@ -30,6 +48,10 @@ type Config struct {
 	WriteTimeout time.Duration
 	Version      string
 
+	// Native gRPC listener for the gateway methods (disabled by default)
+	GRPCEnabled bool
+	GRPCPort    int
+
 	// Service addresses
 	RussellAddr     string
 	TuringAddr      string
@@ -38,6 +60,22 @@ type Config struct {
 	BabbageAddr     string
 	PlatonAddr      string
 	AristotelesAddr string
+	BayesAddr       string
+
+	// Authentication (disabled by default)
+	Auth auth.Config
+
+	// Timeouts, retries, and hedging of backend calls per route
+	Proxy proxy.Config
+
+	// Tenant resolution, namespacing, and quotas (disabled by default)
+	Tenancy tenant.Config
+
+	// Audit trail of API calls in Bayes (disabled by default)
+	Audit audit.Config
+
+	// Critical and optional dependencies of the readiness probe
+	Health probe.Config
 }
 
 // DefaultConfig returns default server configuration
@@ -48,6 +86,7 @@ func DefaultConfig() Config {
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 120 * time.Second,
 		Version:      "1.0.0",
+		GRPCPort:     9080,
 
 		// Default service addresses
 		RussellAddr:     "localhost:9100",
@@ -57,6 +96,9 @@ func DefaultConfig() Config {
 		BabbageAddr:     "localhost:9150",
 		PlatonAddr:      "localhost:9130",
 		AristotelesAddr: "localhost:9160",
+		BayesAddr:       "localhost:9120",
+
+		Proxy: proxy.DefaultConfig(),
 	}
 }
 
@@ -73,6 +115,7 @@ func New(cfg Config) (*Server, error) {
 		BabbageAddr:     cfg.BabbageAddr,
 		PlatonAddr:      cfg.PlatonAddr,
 		AristotelesAddr: cfg.AristotelesAddr,
+		BayesAddr:       cfg.BayesAddr,
 	}
 	clients := client.NewServiceClients(clientCfg)
 
@@ -81,30 +124,115 @@ func New(cfg Config) (*Server, error) {
 		logger.Warn("Failed to initialize service clients", "error", err)
 	}
 
+	// Create proxy for backend calls
+	px := proxy.New(cfg.Proxy)
+
+	// Create prober, which checks the backend services for the readiness
+	// probe and the health endpoints
+	deps := make([]probe.Dependency, 0, len(client.ServiceNames))
+	for _, service := range client.ServiceNames {
+		deps = append(deps, probe.Dependency{
+			Name:  service,
+			Check: func(ctx context.Context) error { return clients.HealthCheck(ctx, service) },
+		})
+	}
+	prober, err := probe.New(cfg.Health, deps...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize health probes: %w", err)
+	}
+
 	// Create handler with clients
-	h := handler.NewHandler(cfg.Version, clients)
+	h := handler.NewHandler(cfg.Version, clients, px, prober)
 
 	// Create WebSocket handler
-	wsHandler := handler.NewWebSocketHandler(clients)
+	wsHandler := handler.NewWebSocketHandler(clients, px)
+
+	// Create streaming WebSocket handler for chat and agents
+	streamHandler := handler.NewStreamHandler(clients, px)
+
+	// Create authenticator
+	authenticator, err := auth.New(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+	if !cfg.Auth.Enabled {
+		logger.Warn("Authentication is disabled, all endpoints are served without credentials")
+	}
+
+	// Create tenant manager
+	tenants, err := tenant.New(cfg.Tenancy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tenant isolation: %w", err)
+	}
+
+	// Create audit recorder, which stores the trail in Bayes
+	auditStore := audit.NewBayesStore(func() bayespb.BayesServiceClient { return clients.Bayes })
+	recorder, err := audit.New(cfg.Audit, auditStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize audit trail: %w", err)
+	}
 
 	// Create HTTP server
 	mux := http.NewServeMux()
 
-	// WebSocket route
+	// WebSocket routes
 	mux.Handle("/api/v1/chat/ws", wsHandler)
+	mux.Handle(handler.StreamPath, streamHandler)
+
+	// API key management routes
+	mux.Handle(auth.KeysPath, authenticator.AdminHandler())
+	mux.Handle(auth.KeysPath+"/", authenticator.AdminHandler())
+
+	// Tenant usage route
+	mux.Handle(tenant.AdminPath, tenants.AdminHandler())
+
+	// Audit trail route
+	mux.Handle(audit.AdminPath, recorder.AdminHandler())
 
 	// API routes
 	mux.Handle("/", h)
 	mux.Handle("/api/", h)
 	mux.Handle("/api/v1/", h)
 
+	// Probes are served outside the middleware chain, so they need no
+	// credentials and are neither logged nor audited
+	root := http.NewServeMux()
+	root.Handle(probe.LivePath, prober.LiveHandler())
+	root.Handle(probe.ReadyPath, prober.ReadyHandler())
+	root.Handle("/", loggingMiddleware(logger, recorder.Middleware(authenticator.Middleware(tenants.Middleware(audit.Identify(mux))))))
+
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.HTTPPort),
-		Handler:      loggingMiddleware(logger, mux),
+		Handler:      root,
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 	}
 
+	// Create gRPC listener, which shares authentication, tenant isolation,
+	// and the audit trail with the HTTP middleware chain
+	var grpcServer *coreGrpc.Server
+	if cfg.GRPCEnabled {
+		grpcCfg := coreGrpc.DefaultServerConfig()
+		grpcCfg.Host = cfg.Host
+		grpcCfg.Port = cfg.GRPCPort
+
+		grpcServer = coreGrpc.NewServer(grpcCfg,
+			grpc.ChainUnaryInterceptor(
+				recorder.UnaryServerInterceptor(),
+				authenticator.UnaryServerInterceptor(handler.GatewayMethodScope),
+				tenants.UnaryServerInterceptor(handler.GatewayMethodPublic),
+				audit.UnaryIdentifyInterceptor(),
+			),
+			grpc.ChainStreamInterceptor(
+				recorder.StreamServerInterceptor(),
+				authenticator.StreamServerInterceptor(handler.GatewayMethodScope),
+				tenants.StreamServerInterceptor(handler.GatewayMethodPublic),
+				audit.StreamIdentifyInterceptor(),
+			),
+		)
+		kantpb.RegisterKantServiceServer(grpcServer.GRPCServer(), handler.NewGatewayService(cfg.Version, clients, px, prober))
+	}
+
 	// Create health registry
 	healthRegistry := health.NewRegistry("kant", cfg.Version)
 	healthRegistry.RegisterFunc("http", func(ctx context.Context) health.CheckResult {
@@ -140,9 +268,15 @@ func New(cfg Config) (*Server, error) {
 
 	return &Server{
 		httpServer: httpServer,
+		grpc:       grpcServer,
 		handler:    h,
 		clients:    clients,
 		health:     healthRegistry,
+		auth:       authenticator,
+		tenants:    tenants,
+		audit:      recorder,
+		proxy:      px,
+		prober:     prober,
 		logger:     logger,
 		config:     cfg,
 	}, nil
@@ -185,12 +319,34 @@ func (w *responseWrapper) Flush() {
 	}
 }
 
+// Hijack implements http.Hijacker for WebSocket upgrades; the request is
+// logged with status 101
+func (w *responseWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.statusCode = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (w *responseWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // Start starts the server
 func (s *Server) Start() error {
 	s.logger.Info("Starting Kant API Gateway",
 		"host", s.config.Host,
 		"port", s.config.HTTPPort,
 	)
+	if err := s.startGRPC(); err != nil {
+		return err
+	}
 	return s.httpServer.ListenAndServe()
 }
 
@@ -200,6 +356,9 @@ func (s *Server) StartAsync() error {
 		"host", s.config.Host,
 		"port", s.config.HTTPPort,
 	)
+	if err := s.startGRPC(); err != nil {
+		return err
+	}
 
 	go func() {
 		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -210,10 +369,35 @@ func (s *Server) StartAsync() error {
 	return nil
 }
 
+// startGRPC starts the gRPC listener in the background, if enabled
+func (s *Server) startGRPC() error {
+	if s.grpc == nil {
+		return nil
+	}
+	if err := s.grpc.StartAsync(); err != nil {
+		return fmt.Errorf("failed to start gRPC listener: %w", err)
+	}
+	s.logger.Info("gRPC listener started", "address", s.grpc.Address())
+	return nil
+}
+
 // Stop gracefully stops the server
 func (s *Server) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping Kant API Gateway")
 
+	// Fail the readiness probe, so no new requests are routed here
+	s.prober.Drain()
+
+	err := s.httpServer.Shutdown(ctx)
+	if s.grpc != nil {
+		s.grpc.StopWithTimeout(ctx)
+	}
+
+	// Write the remaining audit records while Bayes is still connected
+	if err := s.audit.Close(ctx); err != nil {
+		s.logger.Warn("Error writing audit records", "error", err)
+	}
+
 	// Close service clients
 	if s.clients != nil {
 		if err := s.clients.Close(); err != nil {
@@ -221,7 +405,7 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
-	return s.httpServer.Shutdown(ctx)
+	return err
 }
 
 // Address returns the server address
@@ -234,6 +418,31 @@ func (s *Server) HealthRegistry() *health.Registry {
 	return s.health
 }
 
+// Auth returns the authenticator of the server
+func (s *Server) Auth() *auth.Authenticator {
+	return s.auth
+}
+
+// Tenants returns the tenant manager of the server
+func (s *Server) Tenants() *tenant.Manager {
+	return s.tenants
+}
+
+// Audit returns the recorder of the audit trail
+func (s *Server) Audit() *audit.Recorder {
+	return s.audit
+}
+
+// Proxy returns the proxy for backend calls
+func (s *Server) Proxy() *proxy.Proxy {
+	return s.proxy
+}
+
+// Prober returns the prober of the liveness and readiness probes
+func (s *Server) Prober() *probe.Prober {
+	return s.prober
+}
+
 // Clients returns the service clients
 func (s *Server) Clients() *client.ServiceClients {
 	return s.clients
@@ -0,0 +1,65 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     server
+// Description: Unit tests for the HTTP middleware chain of the gateway
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/msto63/mDW/internal/kant/handler"
+)
+
+// ============================================================================
+// Unit Tests - Middleware Chain
+// ============================================================================
+
+func TestServer_WebSocketUpgrade(t *testing.T) {
+	srv, err := New(DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer srv.Stop(context.Background())
+
+	ts := httptest.NewServer(srv.httpServer.Handler)
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + handler.StreamPath
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		t.Fatalf("Dial(%s) error = %v, status %d", handler.StreamPath, err, status)
+	}
+	defer conn.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("status = %d, want 101", resp.StatusCode)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.WriteJSON(handler.WSMessage{Type: handler.WSTypePing, ID: "s1"}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var pong handler.WSResponse
+	if err := conn.ReadJSON(&pong); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if pong.Type != handler.WSTypePong || pong.ID != "s1" {
+		t.Errorf("response = %+v, want pong for s1", pong)
+	}
+}
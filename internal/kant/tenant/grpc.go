@@ -0,0 +1,146 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     tenant
+// Description: gRPC interceptors that resolve the tenant of gateway calls
+//              and pass the tenant of a request to the backend services
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor resolves the tenant of unary gRPC calls like the
+// HTTP middleware, from the x-tenant-id metadata or the subdomain of the
+// :authority, enforces its quota, and passes it to the handler in the call
+// context. Methods for which public reports true are served without a
+// tenant. It must run after the authentication interceptor. If tenant
+// isolation is disabled, calls are passed through unchanged.
+func (m *Manager) UnaryServerInterceptor(public func(fullMethod string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !m.config.Enabled || (public != nil && public(info.FullMethod)) {
+			return handler(ctx, req)
+		}
+		id, err := m.admitCall(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id))
+		return handler(WithTenant(ctx, id), req)
+	}
+}
+
+// StreamServerInterceptor resolves the tenant of streaming gRPC calls like
+// UnaryServerInterceptor
+func (m *Manager) StreamServerInterceptor(public func(fullMethod string) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !m.config.Enabled || (public != nil && public(info.FullMethod)) {
+			return handler(srv, ss)
+		}
+		id, err := m.admitCall(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		ss.SetHeader(metadata.Pairs(MetadataKey, id))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: WithTenant(ss.Context(), id)})
+	}
+}
+
+// admitCall resolves the tenant of a gRPC call and counts the call against
+// its quota; failures are returned as status errors
+func (m *Manager) admitCall(ctx context.Context, method string) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	}
+
+	id, wait, err := m.admit(ctx, first(md.Get(MetadataKey)), first(md.Get(":authority")), method, remote)
+	switch {
+	case err == nil:
+		return id, nil
+	case errors.Is(err, ErrQuotaExceeded):
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+		return "", status.Errorf(codes.ResourceExhausted, "request quota of tenant %q is exhausted, retry after %ds", id, retryAfter)
+	case errors.Is(err, ErrTenantRequired):
+		return "", status.Errorf(codes.InvalidArgument, "tenant required: send the %s metadata", MetadataKey)
+	case errors.Is(err, ErrInvalidTenant):
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrTenantMismatch), errors.Is(err, ErrTenantDenied):
+		return "", status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return "", status.Error(codes.PermissionDenied, ErrUnknownTenant.Error())
+	}
+}
+
+// first returns the first of the values of a metadata key
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// serverStream replaces the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor adds the tenant of the call context to the
+// outgoing metadata of unary calls
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor adds the tenant of the call context to the
+// outgoing metadata of streaming calls
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// FromIncomingContext returns the tenant a backend call was made for
+func FromIncomingContext(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// outgoingContext sets the tenant metadata of a call, replacing a tenant
+// set by the caller
+func outgoingContext(ctx context.Context) context.Context {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(MetadataKey, id)
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,292 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     tenant
+// Description: HTTP middleware that resolves the tenant of API requests and
+//              enforces its quota
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/httpx"
+	"github.com/msto63/mDW/pkg/core/logging"
+)
+
+// AdminPath is the route of the tenant usage endpoint
+const AdminPath = "/api/v1/admin/tenants"
+
+// Errors returned by tenant resolution
+var (
+	ErrTenantRequired = errors.New("tenant required")
+	ErrInvalidTenant  = errors.New("invalid tenant")
+	ErrUnknownTenant  = errors.New("unknown tenant")
+	ErrTenantMismatch = errors.New("credentials are bound to another tenant")
+	ErrTenantDenied   = errors.New("credentials do not grant access to other tenants")
+	ErrQuotaExceeded  = errors.New("quota exceeded")
+)
+
+// Config configures tenant isolation of the gateway
+type Config struct {
+	// Enabled turns tenant isolation on; without it every request is served
+	// for the default tenant without quotas
+	Enabled bool
+
+	// RequireTenant rejects requests that name no tenant instead of serving
+	// them for the default tenant
+	RequireTenant bool
+
+	// BaseDomain resolves the tenant from the subdomain requests are sent
+	// to, e.g. "acme" for acme.mdw.example.com with base domain
+	// mdw.example.com; subdomains are ignored if empty
+	BaseDomain string
+
+	// Tenants are the known tenants and their quotas. If empty, every valid
+	// tenant ID is accepted.
+	Tenants map[string]Quota
+
+	// DefaultQuota applies to tenants without their own quota
+	DefaultQuota Quota
+
+	// PublicPaths are served without a tenant (auth.DefaultPublicPaths if empty)
+	PublicPaths []string
+}
+
+// Manager resolves the tenants of requests and enforces their quotas
+type Manager struct {
+	config  Config
+	limiter *limiter
+	public  map[string]bool
+	logger  *logging.Logger
+}
+
+// New creates a tenant manager for the configuration
+func New(cfg Config) (*Manager, error) {
+	tenants := make(map[string]Quota, len(cfg.Tenants))
+	for id, quota := range cfg.Tenants {
+		normalized := normalizeID(id)
+		if err := ValidateID(normalized); err != nil {
+			return nil, err
+		}
+		tenants[normalized] = quota
+	}
+	cfg.Tenants = tenants
+	cfg.BaseDomain = strings.Trim(strings.ToLower(cfg.BaseDomain), ".")
+
+	m := &Manager{
+		config:  cfg,
+		limiter: newLimiter(tenants, cfg.DefaultQuota),
+		public:  make(map[string]bool),
+		logger:  logging.New("kant-tenant"),
+	}
+
+	publicPaths := cfg.PublicPaths
+	if len(publicPaths) == 0 {
+		publicPaths = auth.DefaultPublicPaths
+	}
+	for _, path := range publicPaths {
+		m.public[httpx.NormalizePath(path)] = true
+	}
+	return m, nil
+}
+
+// Resolve returns the tenant of a request. A principal bound to a tenant
+// determines it; otherwise the X-Tenant-ID header, then the subdomain, and
+// finally the default tenant apply. Principals without a tenant need the
+// admin or tenant:* scope to request a tenant other than the default.
+func (m *Manager) Resolve(r *http.Request) (string, error) {
+	return m.resolve(r.Context(), r.Header.Get(Header), r.Host)
+}
+
+// resolve returns the tenant of a call that requested a tenant, if any,
+// and was sent to host
+func (m *Manager) resolve(ctx context.Context, requested, host string) (string, error) {
+	requested = normalizeID(requested)
+	if requested == "" {
+		requested = m.subdomain(host)
+	}
+
+	id := requested
+	if principal, ok := auth.FromContext(ctx); ok {
+		switch {
+		case principal.Tenant != "":
+			if requested != "" && requested != principal.Tenant {
+				return "", ErrTenantMismatch
+			}
+			id = principal.Tenant
+		case requested != "" && requested != Default && !principal.CanSelectTenant():
+			return "", ErrTenantDenied
+		}
+	}
+	if id == "" {
+		if m.config.RequireTenant {
+			return "", ErrTenantRequired
+		}
+		id = Default
+	}
+
+	if err := ValidateID(id); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTenant, err)
+	}
+	if !m.known(id) {
+		return "", ErrUnknownTenant
+	}
+	return id, nil
+}
+
+// known reports whether requests may be served for a tenant
+func (m *Manager) known(id string) bool {
+	if len(m.config.Tenants) == 0 {
+		return true
+	}
+	if _, ok := m.config.Tenants[id]; ok {
+		return true
+	}
+	return id == Default && !m.config.RequireTenant
+}
+
+// subdomain returns the tenant named by the subdomain of the base domain a
+// request was sent to, e.g. "acme" for acme.mdw.example.com:8080
+func (m *Manager) subdomain(host string) string {
+	if m.config.BaseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	sub, found := strings.CutSuffix(host, "."+m.config.BaseDomain)
+	if !found {
+		return ""
+	}
+	// Of nested subdomains the label next to the base domain names the tenant
+	if i := strings.LastIndex(sub, "."); i >= 0 {
+		sub = sub[i+1:]
+	}
+	return sub
+}
+
+// Middleware resolves the tenant of every request that is not public,
+// rejects requests over the quota of their tenant, and passes the tenant
+// to the handler in the request context. It must run after the
+// authentication middleware. If tenant isolation is disabled, requests are
+// passed through unchanged.
+func (m *Manager) Middleware(next http.Handler) http.Handler {
+	if !m.config.Enabled {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS preflight requests carry no tenant
+		if r.Method == http.MethodOptions || m.public[httpx.NormalizePath(r.URL.Path)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id, wait, err := m.admit(r.Context(), r.Header.Get(Header), r.Host, r.URL.Path, r.RemoteAddr)
+		switch {
+		case errors.Is(err, ErrQuotaExceeded):
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			httpx.WriteError(w, http.StatusTooManyRequests, "quota_exceeded", "Quota exceeded",
+				fmt.Sprintf("request quota of tenant %q is exhausted", id))
+			return
+		case err != nil:
+			writeResolveError(w, err)
+			return
+		}
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), id)))
+	})
+}
+
+// admit resolves the tenant of a call to target and counts the call
+// against the quota of the tenant. Calls over the quota fail with
+// ErrQuotaExceeded and the time until the quota renews. Failures are logged.
+func (m *Manager) admit(ctx context.Context, requested, host, target, remote string) (string, time.Duration, error) {
+	id, err := m.resolve(ctx, requested, host)
+	if err != nil {
+		m.logger.Warn("Tenant resolution failed", "path", target, "remote", remote, "error", err)
+		return "", 0, err
+	}
+	if wait, ok := m.limiter.allow(id); !ok {
+		m.logger.Warn("Tenant quota exceeded", "tenant", id, "path", target)
+		return id, wait, ErrQuotaExceeded
+	}
+	return id, 0, nil
+}
+
+// Usage returns the request usage of the tenants in the current windows
+func (m *Manager) Usage() []Usage {
+	return m.limiter.snapshot()
+}
+
+// TenantsResponse lists the usage of tenants
+type TenantsResponse struct {
+	Tenants []Usage `json:"tenants"`
+	Total   int     `json:"total"`
+}
+
+// AdminHandler serves the usage of the tenants:
+//
+//	GET /api/v1/admin/tenants - list tenants with their usage and quotas
+//
+// The middleware requires the admin scope for the route; the handler checks
+// it again. Admins bound to a tenant only see their own tenant.
+func (m *Manager) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.config.Enabled {
+			httpx.WriteError(w, http.StatusNotFound, "not_found", "Tenant isolation is disabled", "")
+			return
+		}
+		principal, authenticated := auth.FromContext(r.Context())
+		if authenticated && !principal.HasScope(auth.ScopeAdmin) {
+			httpx.WriteError(w, http.StatusForbidden, "forbidden", "Access denied", `scope "admin" required`)
+			return
+		}
+		if r.Method != http.MethodGet {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Use GET", "")
+			return
+		}
+
+		tenants := make([]Usage, 0)
+		for _, usage := range m.Usage() {
+			if principal.CanAccessTenant(usage.Tenant) {
+				tenants = append(tenants, usage)
+			}
+		}
+		httpx.WriteJSON(w, http.StatusOK, TenantsResponse{Tenants: tenants, Total: len(tenants)})
+	})
+}
+
+// writeResolveError answers a request whose tenant could not be resolved
+func writeResolveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrTenantRequired):
+		httpx.WriteError(w, http.StatusBadRequest, "tenant_required", "Tenant required",
+			fmt.Sprintf("send the %s header", Header))
+	case errors.Is(err, ErrInvalidTenant):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_tenant", "Invalid tenant", err.Error())
+	case errors.Is(err, ErrTenantMismatch):
+		httpx.WriteError(w, http.StatusForbidden, "tenant_mismatch", "Access denied", err.Error())
+	case errors.Is(err, ErrTenantDenied):
+		httpx.WriteError(w, http.StatusForbidden, "tenant_denied", "Access denied",
+			fmt.Sprintf("%v: scope %q or %q required", err, auth.ScopeAdmin, auth.ScopeAllTenants))
+	default:
+		httpx.WriteError(w, http.StatusForbidden, "unknown_tenant", "Unknown tenant", "")
+	}
+}
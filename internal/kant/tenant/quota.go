@@ -0,0 +1,174 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     tenant
+// Description: Per-tenant request quotas with fixed minute and day windows
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package tenant
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Quota limits the requests of a tenant; zero limits are unlimited. Minutes
+// and days are fixed windows, days end at midnight UTC.
+type Quota struct {
+	RequestsPerMinute int
+	RequestsPerDay    int
+}
+
+// Usage is the consumption of a tenant in the current windows
+type Usage struct {
+	Tenant             string `json:"tenant"`
+	RequestsThisMinute int    `json:"requests_this_minute"`
+	RequestsToday      int    `json:"requests_today"`
+	RequestsPerMinute  int    `json:"requests_per_minute,omitempty"` // Limit, 0 if unlimited
+	RequestsPerDay     int    `json:"requests_per_day,omitempty"`    // Limit, 0 if unlimited
+	Rejected           int64  `json:"rejected"`
+}
+
+// window counts requests in a fixed time window
+type window struct {
+	start time.Time
+	size  time.Duration
+	count int
+}
+
+// roll starts a new window once the current one has passed
+func (w *window) roll(now time.Time) {
+	if now.Sub(w.start) >= w.size {
+		w.start, w.count = now.Truncate(w.size), 0
+	}
+}
+
+// full reports whether the window holds limit requests; limits of 0 are
+// never reached
+func (w *window) full(limit int) bool {
+	return limit > 0 && w.count >= limit
+}
+
+// remaining returns the time until the window resets
+func (w *window) remaining(now time.Time) time.Duration {
+	return w.start.Add(w.size).Sub(now)
+}
+
+// usage tracks the windows of a tenant
+type usage struct {
+	minute   window
+	day      window
+	rejected int64
+}
+
+// sweepInterval is how often the usage of idle tenants is evicted
+const sweepInterval = time.Minute
+
+// limiter enforces the quotas of all tenants
+type limiter struct {
+	mu     sync.Mutex
+	usage  map[string]*usage
+	quotas map[string]Quota
+	quota  Quota // Quota of tenants without their own
+	now    func() time.Time
+	swept  time.Time
+}
+
+func newLimiter(quotas map[string]Quota, fallback Quota) *limiter {
+	return &limiter{
+		usage:  make(map[string]*usage),
+		quotas: quotas,
+		quota:  fallback,
+		now:    time.Now,
+	}
+}
+
+// quotaOf returns the quota of a tenant
+func (l *limiter) quotaOf(id string) Quota {
+	if quota, ok := l.quotas[id]; ok {
+		return quota
+	}
+	return l.quota
+}
+
+// allow counts a request of the tenant. If a quota is exhausted, the
+// request is rejected and the time until it resets is returned.
+func (l *limiter) allow(id string) (time.Duration, bool) {
+	quota := l.quotaOf(id)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	u := l.usageOf(id)
+	u.minute.roll(now)
+	u.day.roll(now)
+
+	switch {
+	case u.day.full(quota.RequestsPerDay):
+		u.rejected++
+		return u.day.remaining(now), false
+	case u.minute.full(quota.RequestsPerMinute):
+		u.rejected++
+		return u.minute.remaining(now), false
+	}
+	u.minute.count++
+	u.day.count++
+	return 0, true
+}
+
+// usageOf returns the usage of a tenant; the caller holds the lock
+func (l *limiter) usageOf(id string) *usage {
+	u, ok := l.usage[id]
+	if !ok {
+		u = &usage{minute: window{size: time.Minute}, day: window{size: 24 * time.Hour}}
+		l.usage[id] = u
+	}
+	return u
+}
+
+// sweep evicts the usage of tenants without their own quota whose day
+// window has passed, so tenant IDs that are accepted without being
+// configured do not accumulate; the caller holds the lock
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	l.swept = now
+	for id, u := range l.usage {
+		if _, configured := l.quotas[id]; !configured && now.Sub(u.day.start) >= u.day.size {
+			delete(l.usage, id)
+		}
+	}
+}
+
+// snapshot returns the usage of all tenants that made requests
+func (l *limiter) snapshot() []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	result := make([]Usage, 0, len(l.usage))
+	for id, u := range l.usage {
+		quota := l.quotaOf(id)
+		entry := Usage{
+			Tenant:            id,
+			RequestsPerMinute: quota.RequestsPerMinute,
+			RequestsPerDay:    quota.RequestsPerDay,
+			Rejected:          u.rejected,
+		}
+		u.minute.roll(now)
+		u.day.roll(now)
+		entry.RequestsThisMinute = u.minute.count
+		entry.RequestsToday = u.day.count
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tenant < result[j].Tenant })
+	return result
+}
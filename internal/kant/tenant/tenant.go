@@ -0,0 +1,146 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     tenant
+// Description: Tenants of the Kant API gateway, their context, and the
+//              namespacing of tenant-owned resources
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+// Package tenant isolates the tenants of the Kant API gateway. Every request
+// is resolved to a tenant, from the principal it was authenticated as, the
+// X-Tenant-ID header, or the subdomain it was sent to, and is served within
+// the quota of that tenant.
+//
+// The tenant travels with the request context to all backend calls as gRPC
+// metadata. Resources owned by tenants, like collections, conversations, and
+// pipelines, are stored in the shared backends under names prefixed with the
+// tenant ID. The default tenant keeps unprefixed names, so data of
+// single-tenant installations stays accessible.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Default is the tenant of requests that do not name one. Its resources
+// are not namespaced.
+const Default = "default"
+
+// Header is the request header that selects a tenant
+const Header = "X-Tenant-ID"
+
+// MetadataKey is the gRPC metadata key backend calls carry the tenant in
+const MetadataKey = "x-tenant-id"
+
+// separator joins the tenant ID and the name of a namespaced resource; it
+// cannot occur in tenant IDs
+const separator = "__"
+
+// maxIDLength limits tenant IDs to the length of a DNS label
+const maxIDLength = 63
+
+// ValidateID checks that id is a valid tenant ID: 1-63 lowercase letters,
+// digits, and hyphens, not starting or ending with a hyphen
+func ValidateID(id string) error {
+	if id == "" || len(id) > maxIDLength {
+		return fmt.Errorf("tenant id must have 1 to %d characters", maxIDLength)
+	}
+	for _, c := range id {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return fmt.Errorf("tenant id %q may only contain lowercase letters, digits, and hyphens", id)
+		}
+	}
+	if strings.HasPrefix(id, "-") || strings.HasSuffix(id, "-") {
+		return fmt.Errorf("tenant id %q must not start or end with a hyphen", id)
+	}
+	return nil
+}
+
+// normalizeID trims and lowercases a tenant ID
+func normalizeID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a context carrying the tenant ID
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant of a request
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// IDFromContext returns the tenant of a request, or Default for contexts
+// without a tenant
+func IDFromContext(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return Default
+}
+
+// ============================================================================
+// Namespacing
+// ============================================================================
+
+// ErrForeignName is returned for names of the default tenant that carry the
+// prefix of a tenant, since they would address that tenant's resources
+var ErrForeignName = errors.New("name is reserved for another tenant")
+
+// Scope returns the name a resource of the request's tenant is stored
+// under in the backends. Empty names and names of the default tenant are
+// returned unchanged, unless the default tenant names a resource with a
+// tenant prefix: with tenant isolation enabled, that fails with
+// ErrForeignName.
+func Scope(ctx context.Context, name string) (string, error) {
+	id, isolated := FromContext(ctx)
+	switch {
+	case name == "" || !isolated:
+		return name, nil
+	case id != Default:
+		return id + separator + name, nil
+	}
+	if owner, _ := split(name); owner != "" {
+		return "", fmt.Errorf("%w: %q", ErrForeignName, name)
+	}
+	return name, nil
+}
+
+// Unscope returns the name of a stored resource as the request's tenant
+// knows it, and whether the resource belongs to the tenant at all. The
+// default tenant owns the resources without a tenant prefix.
+func Unscope(ctx context.Context, stored string) (string, bool) {
+	id := IDFromContext(ctx)
+	owner, name := split(stored)
+	if owner == "" {
+		return stored, id == Default
+	}
+	return name, owner == id
+}
+
+// Owns reports whether a stored resource belongs to the request's tenant
+func Owns(ctx context.Context, stored string) bool {
+	_, ok := Unscope(ctx, stored)
+	return ok
+}
+
+// split separates the tenant prefix of a stored name; owner is empty for
+// names without a valid prefix
+func split(stored string) (owner, name string) {
+	prefix, rest, found := strings.Cut(stored, separator)
+	if !found || rest == "" || ValidateID(prefix) != nil {
+		return "", stored
+	}
+	return prefix, rest
+}
@@ -0,0 +1,439 @@
+// ============================================================================
+// meinDENKWERK (mDW) - Lokale KI-Plattform
+// ============================================================================
+//
+// Package:     tenant
+// Description: Unit tests for tenant resolution, namespacing, quotas, and
+//              metadata propagation
+// Author:      Mike Stoffels with Claude
+// Created:     2026-10-16
+// License:     MIT
+// ============================================================================
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/msto63/mDW/internal/kant/auth"
+	"github.com/msto63/mDW/internal/kant/httpx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newManager(t *testing.T, cfg Config) *Manager {
+	t.Helper()
+	cfg.Enabled = true
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return m
+}
+
+// ============================================================================
+// Unit Tests - Namespacing
+// ============================================================================
+
+func TestValidateID(t *testing.T) {
+	for _, id := range []string{"acme", "team-42", "a"} {
+		if err := ValidateID(id); err != nil {
+			t.Errorf("ValidateID(%q) error = %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "Acme", "a_b", "a.b", "-acme", "acme-", string(make([]byte, 64))} {
+		if err := ValidateID(id); err == nil {
+			t.Errorf("ValidateID(%q) succeeded", id)
+		}
+	}
+}
+
+func TestScope(t *testing.T) {
+	acme := WithTenant(context.Background(), "acme")
+	def := WithTenant(context.Background(), Default)
+
+	scopes := []struct {
+		ctx  context.Context
+		name string
+		want string
+	}{
+		{acme, "docs", "acme__docs"},
+		{def, "docs", "docs"},
+		{context.Background(), "docs", "docs"},
+		{acme, "", ""},
+		{acme, "beta__docs", "acme__beta__docs"},
+		// Without tenant isolation names are not namespaced
+		{context.Background(), "acme__docs", "acme__docs"},
+		// No valid tenant prefix
+		{def, "Acme__docs", "Acme__docs"},
+		{def, "__docs", "__docs"},
+	}
+	for _, tt := range scopes {
+		got, err := Scope(tt.ctx, tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("Scope(%q, %q) = %q, %v; want %q", IDFromContext(tt.ctx), tt.name, got, err, tt.want)
+		}
+	}
+
+	// The default tenant cannot address resources of other tenants
+	for _, name := range []string{"acme__docs", "default__docs"} {
+		if got, err := Scope(def, name); !errors.Is(err, ErrForeignName) {
+			t.Errorf("Scope(default, %q) = %q, %v; want ErrForeignName", name, got, err)
+		}
+	}
+
+	tests := []struct {
+		ctx    context.Context
+		stored string
+		want   string
+		owned  bool
+	}{
+		{acme, "acme__docs", "docs", true},
+		{acme, "globex__docs", "docs", false},
+		{acme, "docs", "docs", false},
+		{def, "docs", "docs", true},
+		{def, "acme__docs", "docs", false},
+		{def, "my__Docs", "my__Docs", false},
+		{def, "Big__docs", "Big__docs", true},
+	}
+	for _, tt := range tests {
+		got, owned := Unscope(tt.ctx, tt.stored)
+		if owned != tt.owned || (owned && got != tt.want) {
+			t.Errorf("Unscope(%s, %q) = %q, %v", IDFromContext(tt.ctx), tt.stored, got, owned)
+		}
+	}
+}
+
+// ============================================================================
+// Unit Tests - Resolution and Middleware
+// ============================================================================
+
+func TestManager_Resolve(t *testing.T) {
+	m := newManager(t, Config{
+		BaseDomain: "mdw.example.com",
+		Tenants:    map[string]Quota{"acme": {}, "Globex": {}},
+	})
+	bound := &auth.Principal{Subject: "ci", Tenant: "acme"}
+	unbound := &auth.Principal{Subject: "reports", Scopes: []string{"chat"}}
+	admin := &auth.Principal{Subject: "admin", Scopes: []string{auth.ScopeAll}}
+	operator := &auth.Principal{Subject: "ops", Scopes: []string{"chat", auth.ScopeAllTenants}}
+
+	tests := []struct {
+		name      string
+		host      string
+		header    string
+		principal *auth.Principal
+		want      string
+		err       error
+	}{
+		{"default", "localhost:8080", "", nil, Default, nil},
+		{"header", "localhost", "Globex", nil, "globex", nil},
+		{"subdomain", "acme.mdw.example.com:8080", "", nil, "acme", nil},
+		{"nested subdomain", "eu.globex.mdw.example.com", "", nil, "globex", nil},
+		{"header before subdomain", "acme.mdw.example.com", "globex", nil, "globex", nil},
+		{"other domain", "acme.example.org", "", nil, Default, nil},
+		{"principal", "localhost", "", bound, "acme", nil},
+		{"principal and header", "localhost", "acme", bound, "acme", nil},
+		{"principal mismatch", "localhost", "globex", bound, "", ErrTenantMismatch},
+		{"unbound principal", "localhost", "", unbound, Default, nil},
+		{"unbound principal and default", "localhost", Default, unbound, Default, nil},
+		{"unbound principal and header", "localhost", "globex", unbound, "", ErrTenantDenied},
+		{"unbound principal and subdomain", "acme.mdw.example.com", "", unbound, "", ErrTenantDenied},
+		{"admin and header", "localhost", "globex", admin, "globex", nil},
+		{"all tenants scope and header", "localhost", "globex", operator, "globex", nil},
+		{"unknown", "localhost", "initech", nil, "", ErrUnknownTenant},
+		{"invalid", "localhost", "a_b", nil, "", ErrInvalidTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/chat", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			got, err := m.Resolve(req)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Errorf("Resolve() = %q, %v, want %q, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+
+	required := newManager(t, Config{RequireTenant: true, Tenants: map[string]Quota{"acme": {}}})
+	for _, header := range []string{"", Default} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/chat", nil)
+		req.Header.Set(Header, header)
+		if _, err := required.Resolve(req); err == nil {
+			t.Errorf("Resolve(%q) with required tenant succeeded", header)
+		}
+	}
+}
+
+func TestManager_Middleware(t *testing.T) {
+	m := newManager(t, Config{RequireTenant: true})
+
+	var seen string
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+	}))
+
+	serve := func(path, tenant string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if tenant != "" {
+			req.Header.Set(Header, tenant)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("/api/v1/chat", "acme"); rec.Code != http.StatusOK || seen != "acme" || rec.Header().Get(Header) != "acme" {
+		t.Errorf("tenant request = %d, tenant %q", rec.Code, seen)
+	}
+	if rec := serve("/api/v1/health/", ""); rec.Code != http.StatusOK {
+		t.Errorf("public path status = %d, want 200", rec.Code)
+	}
+
+	rec := serve("/api/v1/chat", "")
+	var body httpx.ErrorResponse
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusBadRequest || body.Code != "tenant_required" {
+		t.Errorf("missing tenant = %d %+v", rec.Code, body)
+	}
+
+	// A key without a tenant cannot choose a foreign tenant
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/chat", nil)
+	req.Header.Set(Header, "acme")
+	req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: "reports", Scopes: []string{"chat"}}))
+	seen = ""
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	body = httpx.ErrorResponse{}
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusForbidden || body.Code != "tenant_denied" || seen != "" {
+		t.Errorf("unbound key with foreign tenant = %d %+v, tenant %q", rec.Code, body, seen)
+	}
+
+	disabled, _ := New(Config{})
+	seen = ""
+	rec = httptest.NewRecorder()
+	disabled.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/chat", nil))
+	if rec.Code != http.StatusOK || seen != "" {
+		t.Errorf("disabled = %d, tenant %q", rec.Code, seen)
+	}
+}
+
+// ============================================================================
+// Unit Tests - Quotas
+// ============================================================================
+
+func TestManager_Quota(t *testing.T) {
+	m := newManager(t, Config{
+		Tenants:      map[string]Quota{"acme": {RequestsPerMinute: 2}, "globex": {}},
+		DefaultQuota: Quota{RequestsPerDay: 1},
+	})
+	now := time.Date(2026, 10, 16, 12, 0, 30, 0, time.UTC)
+	m.limiter.now = func() time.Time { return now }
+
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(tenant string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/chat", nil)
+		req.Header.Set(Header, tenant)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := serve("acme"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d", i, rec.Code)
+		}
+	}
+	rec := serve("acme")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "30" {
+		t.Errorf("over quota = %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// Other tenants are not affected, and the next minute has room again
+	for i := 0; i < 5; i++ {
+		if rec := serve("globex"); rec.Code != http.StatusOK {
+			t.Fatalf("unlimited tenant status = %d", rec.Code)
+		}
+	}
+	now = now.Add(30 * time.Second)
+	if rec := serve("acme"); rec.Code != http.StatusOK {
+		t.Errorf("next minute status = %d", rec.Code)
+	}
+
+	// The default quota applies to the default tenant, per day
+	if rec := serve(Default); rec.Code != http.StatusOK {
+		t.Errorf("default tenant status = %d", rec.Code)
+	}
+	now = now.Add(time.Hour)
+	if rec := serve(Default); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("default tenant over daily quota status = %d", rec.Code)
+	}
+
+	usage := m.Usage()
+	if len(usage) != 3 || usage[0].Tenant != "acme" || usage[0].RequestsToday != 3 || usage[0].Rejected != 1 || usage[0].RequestsPerMinute != 2 {
+		t.Errorf("Usage() = %+v", usage)
+	}
+}
+
+func TestLimiter_EvictsIdleTenants(t *testing.T) {
+	l := newLimiter(map[string]Quota{"acme": {}}, Quota{})
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	for _, id := range []string{"acme", "t1", "t2"} {
+		l.allow(id)
+	}
+	now = now.Add(6 * time.Hour)
+	l.allow("t3")
+
+	// The next day, tenants without requests since yesterday are evicted
+	// unless configured
+	now = now.Add(18 * time.Hour)
+	l.allow("t4")
+	var tenants []string
+	for _, u := range l.snapshot() {
+		tenants = append(tenants, u.Tenant)
+	}
+	if strings.Join(tenants, ",") != "acme,t4" {
+		t.Errorf("tenants = %v, want [acme t4]", tenants)
+	}
+}
+
+// ============================================================================
+// Unit Tests - gRPC Metadata
+// ============================================================================
+
+func TestClientInterceptors(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(WithTenant(context.Background(), "acme"), MetadataKey, "spoofed", "x-trace", "1")
+
+	var md metadata.MD
+	err := UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+	if got := md.Get(MetadataKey); len(got) != 1 || got[0] != "acme" || md.Get("x-trace")[0] != "1" {
+		t.Errorf("metadata = %v", md)
+	}
+
+	_, err = StreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Stream",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if _, ok := metadata.FromOutgoingContext(ctx); ok {
+				t.Error("metadata set without tenant")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("stream interceptor error = %v", err)
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme"))
+	if id, ok := FromIncomingContext(incoming); !ok || id != "acme" {
+		t.Errorf("FromIncomingContext() = %q, %v", id, ok)
+	}
+}
+
+// fakeServerStream is a server stream that only carries a context
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestServerInterceptors(t *testing.T) {
+	m := newManager(t, Config{
+		RequireTenant: true,
+		BaseDomain:    "mdw.example.com",
+		Tenants:       map[string]Quota{"acme": {RequestsPerMinute: 1}, "globex": {}},
+	})
+	public := func(method string) bool { return method == "/mdw.kant.KantService/HealthCheck" }
+	unary := m.UnaryServerInterceptor(public)
+
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, _ = FromContext(ctx)
+		return nil, nil
+	}
+	call := func(method string, md metadata.MD, principal *auth.Principal) codes.Code {
+		seen = ""
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		if principal != nil {
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+
+	if code := call("/mdw.kant.KantService/Chat", metadata.Pairs(MetadataKey, "globex"), nil); code != codes.OK || seen != "globex" {
+		t.Errorf("metadata tenant = %v, tenant %q", code, seen)
+	}
+	if code := call("/mdw.kant.KantService/Chat", metadata.Pairs(":authority", "globex.mdw.example.com:9080"), nil); code != codes.OK || seen != "globex" {
+		t.Errorf("authority tenant = %v, tenant %q", code, seen)
+	}
+	if code := call("/mdw.kant.KantService/HealthCheck", nil, nil); code != codes.OK || seen != "" {
+		t.Errorf("public method = %v, tenant %q", code, seen)
+	}
+	if code := call("/mdw.kant.KantService/Chat", nil, nil); code != codes.InvalidArgument {
+		t.Errorf("missing tenant = %v, want InvalidArgument", code)
+	}
+	if code := call("/mdw.kant.KantService/Chat", metadata.Pairs(MetadataKey, "initech"), nil); code != codes.PermissionDenied {
+		t.Errorf("unknown tenant = %v, want PermissionDenied", code)
+	}
+	bound := &auth.Principal{Subject: "ci", Tenant: "acme"}
+	if code := call("/mdw.kant.KantService/Chat", metadata.Pairs(MetadataKey, "globex"), bound); code != codes.PermissionDenied {
+		t.Errorf("principal mismatch = %v, want PermissionDenied", code)
+	}
+	unbound := &auth.Principal{Subject: "reports", Scopes: []string{"chat"}}
+	if code := call("/mdw.kant.KantService/Chat", metadata.Pairs(MetadataKey, "globex"), unbound); code != codes.PermissionDenied {
+		t.Errorf("unbound principal with foreign tenant = %v, want PermissionDenied", code)
+	}
+
+	// acme allows one call per minute
+	if code := call("/mdw.kant.KantService/Chat", nil, bound); code != codes.OK || seen != "acme" {
+		t.Errorf("principal tenant = %v, tenant %q", code, seen)
+	}
+	if code := call("/mdw.kant.KantService/Chat", nil, bound); code != codes.ResourceExhausted {
+		t.Errorf("over quota = %v, want ResourceExhausted", code)
+	}
+
+	// Streams get the tenant in their context and response header
+	ss := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "globex"))}
+	err := m.StreamServerInterceptor(public)(nil, ss, &grpc.StreamServerInfo{FullMethod: "/mdw.kant.KantService/StreamChat"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			seen, _ = FromContext(stream.Context())
+			return nil
+		})
+	if header := ss.header.Get(MetadataKey); err != nil || seen != "globex" || len(header) != 1 || header[0] != "globex" {
+		t.Errorf("stream = %v, tenant %q, header %v", err, seen, ss.header)
+	}
+}
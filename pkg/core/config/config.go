@@ -31,12 +31,106 @@ type GeneralConfig struct {
 
 // KantConfig holds API Gateway configuration
 type KantConfig struct {
-	Port           int           `toml:"port"`
-	Host           string        `toml:"host"`
-	ReadTimeout    Duration      `toml:"read_timeout"`
-	WriteTimeout   Duration      `toml:"write_timeout"`
-	MaxRequestSize string        `toml:"max_request_size"`
-	CORS           CORSConfig    `toml:"cors"`
+	Port           int               `toml:"port"`
+	Host           string            `toml:"host"`
+	ReadTimeout    Duration          `toml:"read_timeout"`
+	WriteTimeout   Duration          `toml:"write_timeout"`
+	MaxRequestSize string            `toml:"max_request_size"`
+	CORS           CORSConfig        `toml:"cors"`
+	Auth           KantAuthConfig    `toml:"auth"`
+	Proxy          KantProxyConfig   `toml:"proxy"`
+	Tenancy        KantTenancyConfig `toml:"tenancy"`
+	Audit          KantAuditConfig   `toml:"audit"`
+	GRPC           KantGRPCConfig    `toml:"grpc"`
+	Health         KantHealthConfig  `toml:"health"`
+}
+
+// KantAuthConfig holds API Gateway authentication settings
+type KantAuthConfig struct {
+	Enabled     bool     `toml:"enabled"`
+	PublicPaths []string `toml:"public_paths"`
+	AdminKey    string   `toml:"admin_key"`
+	KeyFile     string   `toml:"key_file"`
+	JWKSURL     string   `toml:"jwks_url"`
+	OIDCIssuer  string   `toml:"oidc_issuer"`
+	Issuer      string   `toml:"issuer"`
+	Audience    string   `toml:"audience"`
+	JWKSRefresh Duration `toml:"jwks_refresh"`
+}
+
+// KantProxyConfig holds the policies for calls from the API Gateway to the
+// backend services; unset values keep the built-in defaults
+type KantProxyConfig struct {
+	RetryRatio          float64                    `toml:"retry_ratio"`
+	MinRetriesPerSecond int                        `toml:"min_retries_per_second"`
+	Default             KantRoutePolicy            `toml:"default"`
+	Routes              map[string]KantRoutePolicy `toml:"routes"`
+}
+
+// KantRoutePolicy holds the timeout, retry, and hedging settings of a route
+type KantRoutePolicy struct {
+	Timeout     Duration `toml:"timeout"`
+	Idempotent  *bool    `toml:"idempotent"`
+	MaxAttempts int      `toml:"max_attempts"`
+	Backoff     Duration `toml:"backoff"`
+	HedgeDelay  Duration `toml:"hedge_delay"`
+}
+
+// KantTenancyConfig holds the tenant isolation settings of the API Gateway.
+// The request limits are the quota of tenants without their own.
+type KantTenancyConfig struct {
+	Enabled           bool                       `toml:"enabled"`
+	RequireTenant     bool                       `toml:"require_tenant"`
+	BaseDomain        string                     `toml:"base_domain"`
+	RequestsPerMinute int                        `toml:"requests_per_minute"`
+	RequestsPerDay    int                        `toml:"requests_per_day"`
+	Tenants           map[string]KantTenantQuota `toml:"tenants"`
+}
+
+// KantTenantQuota holds the request limits of a tenant; 0 is unlimited
+type KantTenantQuota struct {
+	RequestsPerMinute int `toml:"requests_per_minute"`
+	RequestsPerDay    int `toml:"requests_per_day"`
+}
+
+// KantAuditConfig holds the audit trail settings of the API Gateway; unset
+// values keep the built-in defaults
+type KantAuditConfig struct {
+	Enabled             bool                `toml:"enabled"`
+	CaptureRequestBody  bool                `toml:"capture_request_body"`
+	CaptureResponseBody bool                `toml:"capture_response_body"`
+	MaxBodyBytes        int                 `toml:"max_body_bytes"`
+	SkipPaths           []string            `toml:"skip_paths"`
+	BufferSize          int                 `toml:"buffer_size"`
+	BatchSize           int                 `toml:"batch_size"`
+	FlushInterval       Duration            `toml:"flush_interval"`
+	Redaction           KantRedactionConfig `toml:"redaction"`
+}
+
+// KantGRPCConfig holds the settings of the native gRPC listener of the API
+// Gateway
+type KantGRPCConfig struct {
+	Enabled bool `toml:"enabled"`
+	Port    int  `toml:"port"`
+}
+
+// KantHealthConfig holds the dependencies of the readiness probe of the
+// API Gateway. Critical keeps the default list if not set; if Optional is
+// set, services named in neither list are not checked.
+type KantHealthConfig struct {
+	Critical []string `toml:"critical"`
+	Optional []string `toml:"optional"`
+	Timeout  Duration `toml:"timeout"`
+	CacheTTL Duration `toml:"cache_ttl"`
+}
+
+// KantRedactionConfig holds the rules that remove personal data from the
+// audit trail. Patterns and Fields keep the built-in lists if not set; an
+// empty list disables them.
+type KantRedactionConfig struct {
+	Patterns []string `toml:"patterns"`
+	Custom   []string `toml:"custom"`
+	Fields   []string `toml:"fields"`
 }
 
 // CORSConfig holds CORS settings
@@ -268,6 +362,12 @@ func (c *Config) applyDefaults() {
 	if c.Kant.WriteTimeout.Duration == 0 {
 		c.Kant.WriteTimeout.Duration = 120 * time.Second
 	}
+	if c.Kant.Auth.KeyFile == "" {
+		c.Kant.Auth.KeyFile = "./data/kant/api_keys.json"
+	}
+	if c.Kant.Auth.JWKSRefresh.Duration == 0 {
+		c.Kant.Auth.JWKSRefresh.Duration = time.Hour
+	}
 
 	// Russell
 	if c.Russell.Port == 0 {
@@ -379,6 +479,8 @@ func (c *Config) applyDefaults() {
 func (c *Config) expandEnvVars() {
 	c.Turing.Providers.OpenAI.APIKey = os.ExpandEnv(c.Turing.Providers.OpenAI.APIKey)
 	c.Turing.Providers.Anthropic.APIKey = os.ExpandEnv(c.Turing.Providers.Anthropic.APIKey)
+	c.Kant.Auth.AdminKey = os.ExpandEnv(c.Kant.Auth.AdminKey)
+	c.Kant.Auth.KeyFile = os.ExpandEnv(c.Kant.Auth.KeyFile)
 	c.General.DataDir = os.ExpandEnv(c.General.DataDir)
 	c.Bayes.StoragePath = os.ExpandEnv(c.Bayes.StoragePath)
 	c.Hypatia.VectorStore.Path = os.ExpandEnv(c.Hypatia.VectorStore.Path)
@@ -96,6 +96,12 @@ func TestConfig_applyDefaults(t *testing.T) {
 	if cfg.Kant.ReadTimeout.Duration != 30*time.Second {
 		t.Errorf("Kant.ReadTimeout = %v, want 30s", cfg.Kant.ReadTimeout.Duration)
 	}
+	if cfg.Kant.Auth.Enabled {
+		t.Error("Kant.Auth.Enabled = true, want false")
+	}
+	if cfg.Kant.Auth.KeyFile != "./data/kant/api_keys.json" {
+		t.Errorf("Kant.Auth.KeyFile = %v, want ./data/kant/api_keys.json", cfg.Kant.Auth.KeyFile)
+	}
 
 	// Russell defaults
 	if cfg.Russell.Port != 9100 {
@@ -276,3 +282,120 @@ func TestLoadFromEnv_NoConfigFound(t *testing.T) {
 		t.Error("LoadFromEnv() expected error when no config found")
 	}
 }
+
+func TestLoad_KantProxy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	configContent := `
+[kant.proxy]
+retry_ratio = 0.1
+
+[kant.proxy.routes.search]
+hedge_delay = "500ms"
+idempotent = false
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Kant.Proxy.RetryRatio != 0.1 {
+		t.Errorf("Kant.Proxy.RetryRatio = %v, want 0.1", cfg.Kant.Proxy.RetryRatio)
+	}
+	search, ok := cfg.Kant.Proxy.Routes["search"]
+	if !ok {
+		t.Fatal("Kant.Proxy.Routes[search] missing")
+	}
+	if search.HedgeDelay.Duration != 500*time.Millisecond {
+		t.Errorf("HedgeDelay = %v, want 500ms", search.HedgeDelay.Duration)
+	}
+	if search.Idempotent == nil || *search.Idempotent {
+		t.Errorf("Idempotent = %v, want false", search.Idempotent)
+	}
+	if search.MaxAttempts != 0 {
+		t.Errorf("MaxAttempts = %v, want 0 (unset)", search.MaxAttempts)
+	}
+}
+
+func TestLoad_KantTenancy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	configContent := `
+[kant.tenancy]
+enabled = true
+base_domain = "mdw.example.com"
+requests_per_minute = 60
+
+[kant.tenancy.tenants.acme]
+requests_per_day = 1000
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tenancy := cfg.Kant.Tenancy
+	if !tenancy.Enabled || tenancy.BaseDomain != "mdw.example.com" || tenancy.RequestsPerMinute != 60 {
+		t.Errorf("Kant.Tenancy = %+v", tenancy)
+	}
+	acme, ok := tenancy.Tenants["acme"]
+	if !ok {
+		t.Fatal("Kant.Tenancy.Tenants[acme] missing")
+	}
+	if acme.RequestsPerDay != 1000 || acme.RequestsPerMinute != 0 {
+		t.Errorf("Tenants[acme] = %+v, want 1000 per day", acme)
+	}
+}
+
+func TestLoad_KantAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	configContent := `
+[kant.audit]
+enabled = true
+capture_request_body = true
+max_body_bytes = 2048
+flush_interval = "5s"
+
+[kant.audit.redaction]
+patterns = []
+custom = ["KD-[0-9]{6}"]
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	a := cfg.Kant.Audit
+	if !a.Enabled || !a.CaptureRequestBody || a.CaptureResponseBody || a.MaxBodyBytes != 2048 || a.FlushInterval.Duration != 5*time.Second {
+		t.Errorf("Kant.Audit = %+v", a)
+	}
+	// An empty list disables the built-in patterns, a missing one keeps them
+	if a.Redaction.Patterns == nil || len(a.Redaction.Patterns) != 0 {
+		t.Errorf("Redaction.Patterns = %#v, want empty list", a.Redaction.Patterns)
+	}
+	if a.Redaction.Fields != nil {
+		t.Errorf("Redaction.Fields = %#v, want nil", a.Redaction.Fields)
+	}
+	if len(a.Redaction.Custom) != 1 || a.Redaction.Custom[0] != "KD-[0-9]{6}" {
+		t.Errorf("Redaction.Custom = %v", a.Redaction.Custom)
+	}
+}